@@ -435,6 +435,53 @@ func (_c *MockGitServer_CreateRepoFile_Call) RunAndReturn(run func(*types.Create
 	return _c
 }
 
+// CreateRepoTag provides a mock function with given fields: ctx, req
+func (_m *MockGitServer) CreateRepoTag(ctx context.Context, req gitserver.CreateTagReq) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRepoTag")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, gitserver.CreateTagReq) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockGitServer_CreateRepoTag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateRepoTag'
+type MockGitServer_CreateRepoTag_Call struct {
+	*mock.Call
+}
+
+// CreateRepoTag is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req gitserver.CreateTagReq
+func (_e *MockGitServer_Expecter) CreateRepoTag(ctx interface{}, req interface{}) *MockGitServer_CreateRepoTag_Call {
+	return &MockGitServer_CreateRepoTag_Call{Call: _e.mock.On("CreateRepoTag", ctx, req)}
+}
+
+func (_c *MockGitServer_CreateRepoTag_Call) Run(run func(ctx context.Context, req gitserver.CreateTagReq)) *MockGitServer_CreateRepoTag_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(gitserver.CreateTagReq))
+	})
+	return _c
+}
+
+func (_c *MockGitServer_CreateRepoTag_Call) Return(_a0 error) *MockGitServer_CreateRepoTag_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockGitServer_CreateRepoTag_Call) RunAndReturn(run func(context.Context, gitserver.CreateTagReq) error) *MockGitServer_CreateRepoTag_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DeleteRepo provides a mock function with given fields: ctx, relativePath
 func (_m *MockGitServer) DeleteRepo(ctx context.Context, relativePath string) error {
 	ret := _m.Called(ctx, relativePath)
@@ -575,6 +622,53 @@ func (_c *MockGitServer_DeleteRepoFile_Call) RunAndReturn(run func(*types.Delete
 	return _c
 }
 
+// DeleteRepoTag provides a mock function with given fields: ctx, req
+func (_m *MockGitServer) DeleteRepoTag(ctx context.Context, req gitserver.DeleteTagReq) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRepoTag")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, gitserver.DeleteTagReq) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockGitServer_DeleteRepoTag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteRepoTag'
+type MockGitServer_DeleteRepoTag_Call struct {
+	*mock.Call
+}
+
+// DeleteRepoTag is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req gitserver.DeleteTagReq
+func (_e *MockGitServer_Expecter) DeleteRepoTag(ctx interface{}, req interface{}) *MockGitServer_DeleteRepoTag_Call {
+	return &MockGitServer_DeleteRepoTag_Call{Call: _e.mock.On("DeleteRepoTag", ctx, req)}
+}
+
+func (_c *MockGitServer_DeleteRepoTag_Call) Run(run func(ctx context.Context, req gitserver.DeleteTagReq)) *MockGitServer_DeleteRepoTag_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(gitserver.DeleteTagReq))
+	})
+	return _c
+}
+
+func (_c *MockGitServer_DeleteRepoTag_Call) Return(_a0 error) *MockGitServer_DeleteRepoTag_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockGitServer_DeleteRepoTag_Call) RunAndReturn(run func(context.Context, gitserver.DeleteTagReq) error) *MockGitServer_DeleteRepoTag_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetArchive provides a mock function with given fields: ctx, req
 func (_m *MockGitServer) GetArchive(ctx context.Context, req gitserver.GetArchiveReq) ([]byte, error) {
 	ret := _m.Called(ctx, req)
@@ -634,6 +728,65 @@ func (_c *MockGitServer_GetArchive_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// GetArchiveReader provides a mock function with given fields: ctx, req
+func (_m *MockGitServer) GetArchiveReader(ctx context.Context, req gitserver.GetArchiveReq) (io.ReadCloser, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetArchiveReader")
+	}
+
+	var r0 io.ReadCloser
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, gitserver.GetArchiveReq) (io.ReadCloser, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, gitserver.GetArchiveReq) io.ReadCloser); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.ReadCloser)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, gitserver.GetArchiveReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockGitServer_GetArchiveReader_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetArchiveReader'
+type MockGitServer_GetArchiveReader_Call struct {
+	*mock.Call
+}
+
+// GetArchiveReader is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req gitserver.GetArchiveReq
+func (_e *MockGitServer_Expecter) GetArchiveReader(ctx interface{}, req interface{}) *MockGitServer_GetArchiveReader_Call {
+	return &MockGitServer_GetArchiveReader_Call{Call: _e.mock.On("GetArchiveReader", ctx, req)}
+}
+
+func (_c *MockGitServer_GetArchiveReader_Call) Run(run func(ctx context.Context, req gitserver.GetArchiveReq)) *MockGitServer_GetArchiveReader_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(gitserver.GetArchiveReq))
+	})
+	return _c
+}
+
+func (_c *MockGitServer_GetArchiveReader_Call) Return(_a0 io.ReadCloser, _a1 error) *MockGitServer_GetArchiveReader_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockGitServer_GetArchiveReader_Call) RunAndReturn(run func(context.Context, gitserver.GetArchiveReq) (io.ReadCloser, error)) *MockGitServer_GetArchiveReader_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetCommitDiff provides a mock function with given fields: ctx, req
 func (_m *MockGitServer) GetCommitDiff(ctx context.Context, req gitserver.GetRepoLastCommitReq) ([]byte, error) {
 	ret := _m.Called(ctx, req)
@@ -1822,6 +1975,65 @@ func (_c *MockGitServer_GetRepoSize_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// GetRepoTags provides a mock function with given fields: ctx, req
+func (_m *MockGitServer) GetRepoTags(ctx context.Context, req gitserver.GetTagsReq) ([]types.Tag, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRepoTags")
+	}
+
+	var r0 []types.Tag
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, gitserver.GetTagsReq) ([]types.Tag, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, gitserver.GetTagsReq) []types.Tag); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.Tag)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, gitserver.GetTagsReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockGitServer_GetRepoTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRepoTags'
+type MockGitServer_GetRepoTags_Call struct {
+	*mock.Call
+}
+
+// GetRepoTags is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req gitserver.GetTagsReq
+func (_e *MockGitServer_Expecter) GetRepoTags(ctx interface{}, req interface{}) *MockGitServer_GetRepoTags_Call {
+	return &MockGitServer_GetRepoTags_Call{Call: _e.mock.On("GetRepoTags", ctx, req)}
+}
+
+func (_c *MockGitServer_GetRepoTags_Call) Run(run func(ctx context.Context, req gitserver.GetTagsReq)) *MockGitServer_GetRepoTags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(gitserver.GetTagsReq))
+	})
+	return _c
+}
+
+func (_c *MockGitServer_GetRepoTags_Call) Return(_a0 []types.Tag, _a1 error) *MockGitServer_GetRepoTags_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockGitServer_GetRepoTags_Call) RunAndReturn(run func(context.Context, gitserver.GetTagsReq) ([]types.Tag, error)) *MockGitServer_GetRepoTags_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetSingleCommit provides a mock function with given fields: ctx, req
 func (_m *MockGitServer) GetSingleCommit(ctx context.Context, req gitserver.GetRepoLastCommitReq) (*types.CommitResponse, error) {
 	ret := _m.Called(ctx, req)
@@ -3,6 +3,8 @@
 package importer
 
 import (
+	context "context"
+
 	mock "github.com/stretchr/testify/mock"
 	importer "opencsg.com/csghub-server/builder/importer"
 )
@@ -20,9 +22,70 @@ func (_m *MockImporter) EXPECT() *MockImporter_Expecter {
 	return &MockImporter_Expecter{mock: &_m.Mock}
 }
 
-// GetRepositoryList provides a mock function with given fields: baseURL, accessToken, search, page, per
-func (_m *MockImporter) GetRepositoryList(baseURL string, accessToken string, search string, page int, per int) ([]importer.Repository, error) {
-	ret := _m.Called(baseURL, accessToken, search, page, per)
+// GetRepository provides a mock function with given fields: ctx, baseURL, accessToken, path
+func (_m *MockImporter) GetRepository(ctx context.Context, baseURL string, accessToken string, path string) (*importer.Repository, error) {
+	ret := _m.Called(ctx, baseURL, accessToken, path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRepository")
+	}
+
+	var r0 *importer.Repository
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*importer.Repository, error)); ok {
+		return rf(ctx, baseURL, accessToken, path)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *importer.Repository); ok {
+		r0 = rf(ctx, baseURL, accessToken, path)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*importer.Repository)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, baseURL, accessToken, path)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockImporter_GetRepository_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRepository'
+type MockImporter_GetRepository_Call struct {
+	*mock.Call
+}
+
+// GetRepository is a helper method to define mock.On call
+//   - ctx context.Context
+//   - baseURL string
+//   - accessToken string
+//   - path string
+func (_e *MockImporter_Expecter) GetRepository(ctx interface{}, baseURL interface{}, accessToken interface{}, path interface{}) *MockImporter_GetRepository_Call {
+	return &MockImporter_GetRepository_Call{Call: _e.mock.On("GetRepository", ctx, baseURL, accessToken, path)}
+}
+
+func (_c *MockImporter_GetRepository_Call) Run(run func(ctx context.Context, baseURL string, accessToken string, path string)) *MockImporter_GetRepository_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockImporter_GetRepository_Call) Return(_a0 *importer.Repository, _a1 error) *MockImporter_GetRepository_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockImporter_GetRepository_Call) RunAndReturn(run func(context.Context, string, string, string) (*importer.Repository, error)) *MockImporter_GetRepository_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRepositoryList provides a mock function with given fields: ctx, baseURL, accessToken, search, page, per
+func (_m *MockImporter) GetRepositoryList(ctx context.Context, baseURL string, accessToken string, search string, page int, per int) ([]importer.Repository, error) {
+	ret := _m.Called(ctx, baseURL, accessToken, search, page, per)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetRepositoryList")
@@ -30,19 +93,19 @@ func (_m *MockImporter) GetRepositoryList(baseURL string, accessToken string, se
 
 	var r0 []importer.Repository
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string, string, int, int) ([]importer.Repository, error)); ok {
-		return rf(baseURL, accessToken, search, page, per)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, int, int) ([]importer.Repository, error)); ok {
+		return rf(ctx, baseURL, accessToken, search, page, per)
 	}
-	if rf, ok := ret.Get(0).(func(string, string, string, int, int) []importer.Repository); ok {
-		r0 = rf(baseURL, accessToken, search, page, per)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, int, int) []importer.Repository); ok {
+		r0 = rf(ctx, baseURL, accessToken, search, page, per)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]importer.Repository)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string, string, int, int) error); ok {
-		r1 = rf(baseURL, accessToken, search, page, per)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, int, int) error); ok {
+		r1 = rf(ctx, baseURL, accessToken, search, page, per)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -56,18 +119,19 @@ type MockImporter_GetRepositoryList_Call struct {
 }
 
 // GetRepositoryList is a helper method to define mock.On call
+//   - ctx context.Context
 //   - baseURL string
 //   - accessToken string
 //   - search string
 //   - page int
 //   - per int
-func (_e *MockImporter_Expecter) GetRepositoryList(baseURL interface{}, accessToken interface{}, search interface{}, page interface{}, per interface{}) *MockImporter_GetRepositoryList_Call {
-	return &MockImporter_GetRepositoryList_Call{Call: _e.mock.On("GetRepositoryList", baseURL, accessToken, search, page, per)}
+func (_e *MockImporter_Expecter) GetRepositoryList(ctx interface{}, baseURL interface{}, accessToken interface{}, search interface{}, page interface{}, per interface{}) *MockImporter_GetRepositoryList_Call {
+	return &MockImporter_GetRepositoryList_Call{Call: _e.mock.On("GetRepositoryList", ctx, baseURL, accessToken, search, page, per)}
 }
 
-func (_c *MockImporter_GetRepositoryList_Call) Run(run func(baseURL string, accessToken string, search string, page int, per int)) *MockImporter_GetRepositoryList_Call {
+func (_c *MockImporter_GetRepositoryList_Call) Run(run func(ctx context.Context, baseURL string, accessToken string, search string, page int, per int)) *MockImporter_GetRepositoryList_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string), args[2].(string), args[3].(int), args[4].(int))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(int), args[5].(int))
 	})
 	return _c
 }
@@ -77,14 +141,14 @@ func (_c *MockImporter_GetRepositoryList_Call) Return(_a0 []importer.Repository,
 	return _c
 }
 
-func (_c *MockImporter_GetRepositoryList_Call) RunAndReturn(run func(string, string, string, int, int) ([]importer.Repository, error)) *MockImporter_GetRepositoryList_Call {
+func (_c *MockImporter_GetRepositoryList_Call) RunAndReturn(run func(context.Context, string, string, string, int, int) ([]importer.Repository, error)) *MockImporter_GetRepositoryList_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetUser provides a mock function with given fields: baseURL, accessToken
-func (_m *MockImporter) GetUser(baseURL string, accessToken string) (*importer.User, error) {
-	ret := _m.Called(baseURL, accessToken)
+// GetUser provides a mock function with given fields: ctx, baseURL, accessToken
+func (_m *MockImporter) GetUser(ctx context.Context, baseURL string, accessToken string) (*importer.User, error) {
+	ret := _m.Called(ctx, baseURL, accessToken)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetUser")
@@ -92,19 +156,19 @@ func (_m *MockImporter) GetUser(baseURL string, accessToken string) (*importer.U
 
 	var r0 *importer.User
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string) (*importer.User, error)); ok {
-		return rf(baseURL, accessToken)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*importer.User, error)); ok {
+		return rf(ctx, baseURL, accessToken)
 	}
-	if rf, ok := ret.Get(0).(func(string, string) *importer.User); ok {
-		r0 = rf(baseURL, accessToken)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *importer.User); ok {
+		r0 = rf(ctx, baseURL, accessToken)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*importer.User)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(baseURL, accessToken)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, baseURL, accessToken)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -118,15 +182,16 @@ type MockImporter_GetUser_Call struct {
 }
 
 // GetUser is a helper method to define mock.On call
+//   - ctx context.Context
 //   - baseURL string
 //   - accessToken string
-func (_e *MockImporter_Expecter) GetUser(baseURL interface{}, accessToken interface{}) *MockImporter_GetUser_Call {
-	return &MockImporter_GetUser_Call{Call: _e.mock.On("GetUser", baseURL, accessToken)}
+func (_e *MockImporter_Expecter) GetUser(ctx interface{}, baseURL interface{}, accessToken interface{}) *MockImporter_GetUser_Call {
+	return &MockImporter_GetUser_Call{Call: _e.mock.On("GetUser", ctx, baseURL, accessToken)}
 }
 
-func (_c *MockImporter_GetUser_Call) Run(run func(baseURL string, accessToken string)) *MockImporter_GetUser_Call {
+func (_c *MockImporter_GetUser_Call) Run(run func(ctx context.Context, baseURL string, accessToken string)) *MockImporter_GetUser_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
 	})
 	return _c
 }
@@ -136,7 +201,7 @@ func (_c *MockImporter_GetUser_Call) Return(_a0 *importer.User, _a1 error) *Mock
 	return _c
 }
 
-func (_c *MockImporter_GetUser_Call) RunAndReturn(run func(string, string) (*importer.User, error)) *MockImporter_GetUser_Call {
+func (_c *MockImporter_GetUser_Call) RunAndReturn(run func(context.Context, string, string) (*importer.User, error)) *MockImporter_GetUser_Call {
 	_c.Call.Return(run)
 	return _c
 }
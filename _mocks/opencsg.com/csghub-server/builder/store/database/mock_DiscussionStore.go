@@ -22,6 +22,53 @@ func (_m *MockDiscussionStore) EXPECT() *MockDiscussionStore_Expecter {
 	return &MockDiscussionStore_Expecter{mock: &_m.Mock}
 }
 
+// AddReaction provides a mock function with given fields: ctx, reaction
+func (_m *MockDiscussionStore) AddReaction(ctx context.Context, reaction database.CommentReaction) error {
+	ret := _m.Called(ctx, reaction)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddReaction")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.CommentReaction) error); ok {
+		r0 = rf(ctx, reaction)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDiscussionStore_AddReaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddReaction'
+type MockDiscussionStore_AddReaction_Call struct {
+	*mock.Call
+}
+
+// AddReaction is a helper method to define mock.On call
+//   - ctx context.Context
+//   - reaction database.CommentReaction
+func (_e *MockDiscussionStore_Expecter) AddReaction(ctx interface{}, reaction interface{}) *MockDiscussionStore_AddReaction_Call {
+	return &MockDiscussionStore_AddReaction_Call{Call: _e.mock.On("AddReaction", ctx, reaction)}
+}
+
+func (_c *MockDiscussionStore_AddReaction_Call) Run(run func(ctx context.Context, reaction database.CommentReaction)) *MockDiscussionStore_AddReaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.CommentReaction))
+	})
+	return _c
+}
+
+func (_c *MockDiscussionStore_AddReaction_Call) Return(_a0 error) *MockDiscussionStore_AddReaction_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDiscussionStore_AddReaction_Call) RunAndReturn(run func(context.Context, database.CommentReaction) error) *MockDiscussionStore_AddReaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Create provides a mock function with given fields: ctx, discussion
 func (_m *MockDiscussionStore) Create(ctx context.Context, discussion database.Discussion) (*database.Discussion, error) {
 	ret := _m.Called(ctx, discussion)
@@ -234,9 +281,58 @@ func (_c *MockDiscussionStore_DeleteComment_Call) RunAndReturn(run func(context.
 	return _c
 }
 
-// FindByDiscussionableID provides a mock function with given fields: ctx, discussionableType, discussionableID, per, page
-func (_m *MockDiscussionStore) FindByDiscussionableID(ctx context.Context, discussionableType string, discussionableID int64, per int, page int) ([]database.Discussion, int, error) {
-	ret := _m.Called(ctx, discussionableType, discussionableID, per, page)
+// DeleteReaction provides a mock function with given fields: ctx, commentID, userID, emoji
+func (_m *MockDiscussionStore) DeleteReaction(ctx context.Context, commentID int64, userID int64, emoji string) error {
+	ret := _m.Called(ctx, commentID, userID, emoji)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteReaction")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, string) error); ok {
+		r0 = rf(ctx, commentID, userID, emoji)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDiscussionStore_DeleteReaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteReaction'
+type MockDiscussionStore_DeleteReaction_Call struct {
+	*mock.Call
+}
+
+// DeleteReaction is a helper method to define mock.On call
+//   - ctx context.Context
+//   - commentID int64
+//   - userID int64
+//   - emoji string
+func (_e *MockDiscussionStore_Expecter) DeleteReaction(ctx interface{}, commentID interface{}, userID interface{}, emoji interface{}) *MockDiscussionStore_DeleteReaction_Call {
+	return &MockDiscussionStore_DeleteReaction_Call{Call: _e.mock.On("DeleteReaction", ctx, commentID, userID, emoji)}
+}
+
+func (_c *MockDiscussionStore_DeleteReaction_Call) Run(run func(ctx context.Context, commentID int64, userID int64, emoji string)) *MockDiscussionStore_DeleteReaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockDiscussionStore_DeleteReaction_Call) Return(_a0 error) *MockDiscussionStore_DeleteReaction_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDiscussionStore_DeleteReaction_Call) RunAndReturn(run func(context.Context, int64, int64, string) error) *MockDiscussionStore_DeleteReaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByDiscussionableID provides a mock function with given fields: ctx, discussionableType, discussionableID, filter, per, page
+func (_m *MockDiscussionStore) FindByDiscussionableID(ctx context.Context, discussionableType string, discussionableID int64, filter database.DiscussionFilter, per int, page int) ([]database.Discussion, int, error) {
+	ret := _m.Called(ctx, discussionableType, discussionableID, filter, per, page)
 
 	if len(ret) == 0 {
 		panic("no return value specified for FindByDiscussionableID")
@@ -245,25 +341,25 @@ func (_m *MockDiscussionStore) FindByDiscussionableID(ctx context.Context, discu
 	var r0 []database.Discussion
 	var r1 int
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, int64, int, int) ([]database.Discussion, int, error)); ok {
-		return rf(ctx, discussionableType, discussionableID, per, page)
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, database.DiscussionFilter, int, int) ([]database.Discussion, int, error)); ok {
+		return rf(ctx, discussionableType, discussionableID, filter, per, page)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, int64, int, int) []database.Discussion); ok {
-		r0 = rf(ctx, discussionableType, discussionableID, per, page)
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, database.DiscussionFilter, int, int) []database.Discussion); ok {
+		r0 = rf(ctx, discussionableType, discussionableID, filter, per, page)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]database.Discussion)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, int64, int, int) int); ok {
-		r1 = rf(ctx, discussionableType, discussionableID, per, page)
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64, database.DiscussionFilter, int, int) int); ok {
+		r1 = rf(ctx, discussionableType, discussionableID, filter, per, page)
 	} else {
 		r1 = ret.Get(1).(int)
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, string, int64, int, int) error); ok {
-		r2 = rf(ctx, discussionableType, discussionableID, per, page)
+	if rf, ok := ret.Get(2).(func(context.Context, string, int64, database.DiscussionFilter, int, int) error); ok {
+		r2 = rf(ctx, discussionableType, discussionableID, filter, per, page)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -280,15 +376,16 @@ type MockDiscussionStore_FindByDiscussionableID_Call struct {
 //   - ctx context.Context
 //   - discussionableType string
 //   - discussionableID int64
+//   - filter database.DiscussionFilter
 //   - per int
 //   - page int
-func (_e *MockDiscussionStore_Expecter) FindByDiscussionableID(ctx interface{}, discussionableType interface{}, discussionableID interface{}, per interface{}, page interface{}) *MockDiscussionStore_FindByDiscussionableID_Call {
-	return &MockDiscussionStore_FindByDiscussionableID_Call{Call: _e.mock.On("FindByDiscussionableID", ctx, discussionableType, discussionableID, per, page)}
+func (_e *MockDiscussionStore_Expecter) FindByDiscussionableID(ctx interface{}, discussionableType interface{}, discussionableID interface{}, filter interface{}, per interface{}, page interface{}) *MockDiscussionStore_FindByDiscussionableID_Call {
+	return &MockDiscussionStore_FindByDiscussionableID_Call{Call: _e.mock.On("FindByDiscussionableID", ctx, discussionableType, discussionableID, filter, per, page)}
 }
 
-func (_c *MockDiscussionStore_FindByDiscussionableID_Call) Run(run func(ctx context.Context, discussionableType string, discussionableID int64, per int, page int)) *MockDiscussionStore_FindByDiscussionableID_Call {
+func (_c *MockDiscussionStore_FindByDiscussionableID_Call) Run(run func(ctx context.Context, discussionableType string, discussionableID int64, filter database.DiscussionFilter, per int, page int)) *MockDiscussionStore_FindByDiscussionableID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(int64), args[3].(int), args[4].(int))
+		run(args[0].(context.Context), args[1].(string), args[2].(int64), args[3].(database.DiscussionFilter), args[4].(int), args[5].(int))
 	})
 	return _c
 }
@@ -298,7 +395,7 @@ func (_c *MockDiscussionStore_FindByDiscussionableID_Call) Return(_a0 []database
 	return _c
 }
 
-func (_c *MockDiscussionStore_FindByDiscussionableID_Call) RunAndReturn(run func(context.Context, string, int64, int, int) ([]database.Discussion, int, error)) *MockDiscussionStore_FindByDiscussionableID_Call {
+func (_c *MockDiscussionStore_FindByDiscussionableID_Call) RunAndReturn(run func(context.Context, string, int64, database.DiscussionFilter, int, int) ([]database.Discussion, int, error)) *MockDiscussionStore_FindByDiscussionableID_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -482,6 +579,210 @@ func (_c *MockDiscussionStore_FindDiscussionComments_Call) RunAndReturn(run func
 	return _c
 }
 
+// ListReactions provides a mock function with given fields: ctx, commentIDs, username
+func (_m *MockDiscussionStore) ListReactions(ctx context.Context, commentIDs []int64, username string) ([]database.CommentReactionSummary, error) {
+	ret := _m.Called(ctx, commentIDs, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListReactions")
+	}
+
+	var r0 []database.CommentReactionSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int64, string) ([]database.CommentReactionSummary, error)); ok {
+		return rf(ctx, commentIDs, username)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []int64, string) []database.CommentReactionSummary); ok {
+		r0 = rf(ctx, commentIDs, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.CommentReactionSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []int64, string) error); ok {
+		r1 = rf(ctx, commentIDs, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDiscussionStore_ListReactions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListReactions'
+type MockDiscussionStore_ListReactions_Call struct {
+	*mock.Call
+}
+
+// ListReactions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - commentIDs []int64
+//   - username string
+func (_e *MockDiscussionStore_Expecter) ListReactions(ctx interface{}, commentIDs interface{}, username interface{}) *MockDiscussionStore_ListReactions_Call {
+	return &MockDiscussionStore_ListReactions_Call{Call: _e.mock.On("ListReactions", ctx, commentIDs, username)}
+}
+
+func (_c *MockDiscussionStore_ListReactions_Call) Run(run func(ctx context.Context, commentIDs []int64, username string)) *MockDiscussionStore_ListReactions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]int64), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockDiscussionStore_ListReactions_Call) Return(_a0 []database.CommentReactionSummary, _a1 error) *MockDiscussionStore_ListReactions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDiscussionStore_ListReactions_Call) RunAndReturn(run func(context.Context, []int64, string) ([]database.CommentReactionSummary, error)) *MockDiscussionStore_ListReactions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetCommentHidden provides a mock function with given fields: ctx, id, hidden
+func (_m *MockDiscussionStore) SetCommentHidden(ctx context.Context, id int64, hidden bool) error {
+	ret := _m.Called(ctx, id, hidden)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetCommentHidden")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, bool) error); ok {
+		r0 = rf(ctx, id, hidden)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDiscussionStore_SetCommentHidden_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetCommentHidden'
+type MockDiscussionStore_SetCommentHidden_Call struct {
+	*mock.Call
+}
+
+// SetCommentHidden is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - hidden bool
+func (_e *MockDiscussionStore_Expecter) SetCommentHidden(ctx interface{}, id interface{}, hidden interface{}) *MockDiscussionStore_SetCommentHidden_Call {
+	return &MockDiscussionStore_SetCommentHidden_Call{Call: _e.mock.On("SetCommentHidden", ctx, id, hidden)}
+}
+
+func (_c *MockDiscussionStore_SetCommentHidden_Call) Run(run func(ctx context.Context, id int64, hidden bool)) *MockDiscussionStore_SetCommentHidden_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(bool))
+	})
+	return _c
+}
+
+func (_c *MockDiscussionStore_SetCommentHidden_Call) Return(_a0 error) *MockDiscussionStore_SetCommentHidden_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDiscussionStore_SetCommentHidden_Call) RunAndReturn(run func(context.Context, int64, bool) error) *MockDiscussionStore_SetCommentHidden_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetPinned provides a mock function with given fields: ctx, id, pinned
+func (_m *MockDiscussionStore) SetPinned(ctx context.Context, id int64, pinned bool) error {
+	ret := _m.Called(ctx, id, pinned)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetPinned")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, bool) error); ok {
+		r0 = rf(ctx, id, pinned)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDiscussionStore_SetPinned_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetPinned'
+type MockDiscussionStore_SetPinned_Call struct {
+	*mock.Call
+}
+
+// SetPinned is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - pinned bool
+func (_e *MockDiscussionStore_Expecter) SetPinned(ctx interface{}, id interface{}, pinned interface{}) *MockDiscussionStore_SetPinned_Call {
+	return &MockDiscussionStore_SetPinned_Call{Call: _e.mock.On("SetPinned", ctx, id, pinned)}
+}
+
+func (_c *MockDiscussionStore_SetPinned_Call) Run(run func(ctx context.Context, id int64, pinned bool)) *MockDiscussionStore_SetPinned_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(bool))
+	})
+	return _c
+}
+
+func (_c *MockDiscussionStore_SetPinned_Call) Return(_a0 error) *MockDiscussionStore_SetPinned_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDiscussionStore_SetPinned_Call) RunAndReturn(run func(context.Context, int64, bool) error) *MockDiscussionStore_SetPinned_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Transfer provides a mock function with given fields: ctx, id, repoID
+func (_m *MockDiscussionStore) Transfer(ctx context.Context, id int64, repoID int64) error {
+	ret := _m.Called(ctx, id, repoID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Transfer")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, id, repoID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDiscussionStore_Transfer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Transfer'
+type MockDiscussionStore_Transfer_Call struct {
+	*mock.Call
+}
+
+// Transfer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - repoID int64
+func (_e *MockDiscussionStore_Expecter) Transfer(ctx interface{}, id interface{}, repoID interface{}) *MockDiscussionStore_Transfer_Call {
+	return &MockDiscussionStore_Transfer_Call{Call: _e.mock.On("Transfer", ctx, id, repoID)}
+}
+
+func (_c *MockDiscussionStore_Transfer_Call) Run(run func(ctx context.Context, id int64, repoID int64)) *MockDiscussionStore_Transfer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockDiscussionStore_Transfer_Call) Return(_a0 error) *MockDiscussionStore_Transfer_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDiscussionStore_Transfer_Call) RunAndReturn(run func(context.Context, int64, int64) error) *MockDiscussionStore_Transfer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateByID provides a mock function with given fields: ctx, id, title
 func (_m *MockDiscussionStore) UpdateByID(ctx context.Context, id int64, title string) error {
 	ret := _m.Called(ctx, id, title)
@@ -578,6 +879,54 @@ func (_c *MockDiscussionStore_UpdateComment_Call) RunAndReturn(run func(context.
 	return _c
 }
 
+// UpdateStatus provides a mock function with given fields: ctx, id, status
+func (_m *MockDiscussionStore) UpdateStatus(ctx context.Context, id int64, status string) error {
+	ret := _m.Called(ctx, id, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) error); ok {
+		r0 = rf(ctx, id, status)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDiscussionStore_UpdateStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateStatus'
+type MockDiscussionStore_UpdateStatus_Call struct {
+	*mock.Call
+}
+
+// UpdateStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - status string
+func (_e *MockDiscussionStore_Expecter) UpdateStatus(ctx interface{}, id interface{}, status interface{}) *MockDiscussionStore_UpdateStatus_Call {
+	return &MockDiscussionStore_UpdateStatus_Call{Call: _e.mock.On("UpdateStatus", ctx, id, status)}
+}
+
+func (_c *MockDiscussionStore_UpdateStatus_Call) Run(run func(ctx context.Context, id int64, status string)) *MockDiscussionStore_UpdateStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockDiscussionStore_UpdateStatus_Call) Return(_a0 error) *MockDiscussionStore_UpdateStatus_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDiscussionStore_UpdateStatus_Call) RunAndReturn(run func(context.Context, int64, string) error) *MockDiscussionStore_UpdateStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockDiscussionStore creates a new instance of MockDiscussionStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockDiscussionStore(t interface {
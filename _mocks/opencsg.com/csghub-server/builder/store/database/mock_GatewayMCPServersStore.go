@@ -431,6 +431,65 @@ func (_c *MockGatewayMCPServersStore_List_Call) RunAndReturn(run func(context.Co
 	return _c
 }
 
+// ListForGateway provides a mock function with given fields: ctx, namespaces
+func (_m *MockGatewayMCPServersStore) ListForGateway(ctx context.Context, namespaces []string) ([]database.GatewayMCPServers, error) {
+	ret := _m.Called(ctx, namespaces)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListForGateway")
+	}
+
+	var r0 []database.GatewayMCPServers
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]database.GatewayMCPServers, error)); ok {
+		return rf(ctx, namespaces)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []database.GatewayMCPServers); ok {
+		r0 = rf(ctx, namespaces)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.GatewayMCPServers)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, namespaces)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockGatewayMCPServersStore_ListForGateway_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListForGateway'
+type MockGatewayMCPServersStore_ListForGateway_Call struct {
+	*mock.Call
+}
+
+// ListForGateway is a helper method to define mock.On call
+//   - ctx context.Context
+//   - namespaces []string
+func (_e *MockGatewayMCPServersStore_Expecter) ListForGateway(ctx interface{}, namespaces interface{}) *MockGatewayMCPServersStore_ListForGateway_Call {
+	return &MockGatewayMCPServersStore_ListForGateway_Call{Call: _e.mock.On("ListForGateway", ctx, namespaces)}
+}
+
+func (_c *MockGatewayMCPServersStore_ListForGateway_Call) Run(run func(ctx context.Context, namespaces []string)) *MockGatewayMCPServersStore_ListForGateway_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *MockGatewayMCPServersStore_ListForGateway_Call) Return(_a0 []database.GatewayMCPServers, _a1 error) *MockGatewayMCPServersStore_ListForGateway_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockGatewayMCPServersStore_ListForGateway_Call) RunAndReturn(run func(context.Context, []string) ([]database.GatewayMCPServers, error)) *MockGatewayMCPServersStore_ListForGateway_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Update provides a mock function with given fields: ctx, mcpServer
 func (_m *MockGatewayMCPServersStore) Update(ctx context.Context, mcpServer *database.GatewayMCPServers) error {
 	ret := _m.Called(ctx, mcpServer)
@@ -226,6 +226,54 @@ func (_c *MockMetadataStore_Upsert_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// UpsertCard provides a mock function with given fields: ctx, repoID, card
+func (_m *MockMetadataStore) UpsertCard(ctx context.Context, repoID int64, card *types.RepoCard) error {
+	ret := _m.Called(ctx, repoID, card)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertCard")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *types.RepoCard) error); ok {
+		r0 = rf(ctx, repoID, card)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockMetadataStore_UpsertCard_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertCard'
+type MockMetadataStore_UpsertCard_Call struct {
+	*mock.Call
+}
+
+// UpsertCard is a helper method to define mock.On call
+//   - ctx context.Context
+//   - repoID int64
+//   - card *types.RepoCard
+func (_e *MockMetadataStore_Expecter) UpsertCard(ctx interface{}, repoID interface{}, card interface{}) *MockMetadataStore_UpsertCard_Call {
+	return &MockMetadataStore_UpsertCard_Call{Call: _e.mock.On("UpsertCard", ctx, repoID, card)}
+}
+
+func (_c *MockMetadataStore_UpsertCard_Call) Run(run func(ctx context.Context, repoID int64, card *types.RepoCard)) *MockMetadataStore_UpsertCard_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(*types.RepoCard))
+	})
+	return _c
+}
+
+func (_c *MockMetadataStore_UpsertCard_Call) Return(_a0 error) *MockMetadataStore_UpsertCard_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockMetadataStore_UpsertCard_Call) RunAndReturn(run func(context.Context, int64, *types.RepoCard) error) *MockMetadataStore_UpsertCard_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockMetadataStore creates a new instance of MockMetadataStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockMetadataStore(t interface {
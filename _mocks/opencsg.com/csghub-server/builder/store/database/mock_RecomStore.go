@@ -7,6 +7,10 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 	database "opencsg.com/csghub-server/builder/store/database"
+
+	time "time"
+
+	types "opencsg.com/csghub-server/common/types"
 )
 
 // MockRecomStore is an autogenerated mock type for the RecomStore type
@@ -22,6 +26,173 @@ func (_m *MockRecomStore) EXPECT() *MockRecomStore_Expecter {
 	return &MockRecomStore_Expecter{mock: &_m.Mock}
 }
 
+// BatchGetCollectionBaskets provides a mock function with given fields: ctx, lastCollectionID, batch
+func (_m *MockRecomStore) BatchGetCollectionBaskets(ctx context.Context, lastCollectionID int64, batch int) ([]database.RecomBasket, error) {
+	ret := _m.Called(ctx, lastCollectionID, batch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BatchGetCollectionBaskets")
+	}
+
+	var r0 []database.RecomBasket
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) ([]database.RecomBasket, error)); ok {
+		return rf(ctx, lastCollectionID, batch)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) []database.RecomBasket); ok {
+		r0 = rf(ctx, lastCollectionID, batch)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.RecomBasket)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int) error); ok {
+		r1 = rf(ctx, lastCollectionID, batch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRecomStore_BatchGetCollectionBaskets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BatchGetCollectionBaskets'
+type MockRecomStore_BatchGetCollectionBaskets_Call struct {
+	*mock.Call
+}
+
+// BatchGetCollectionBaskets is a helper method to define mock.On call
+//   - ctx context.Context
+//   - lastCollectionID int64
+//   - batch int
+func (_e *MockRecomStore_Expecter) BatchGetCollectionBaskets(ctx interface{}, lastCollectionID interface{}, batch interface{}) *MockRecomStore_BatchGetCollectionBaskets_Call {
+	return &MockRecomStore_BatchGetCollectionBaskets_Call{Call: _e.mock.On("BatchGetCollectionBaskets", ctx, lastCollectionID, batch)}
+}
+
+func (_c *MockRecomStore_BatchGetCollectionBaskets_Call) Run(run func(ctx context.Context, lastCollectionID int64, batch int)) *MockRecomStore_BatchGetCollectionBaskets_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockRecomStore_BatchGetCollectionBaskets_Call) Return(_a0 []database.RecomBasket, _a1 error) *MockRecomStore_BatchGetCollectionBaskets_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRecomStore_BatchGetCollectionBaskets_Call) RunAndReturn(run func(context.Context, int64, int) ([]database.RecomBasket, error)) *MockRecomStore_BatchGetCollectionBaskets_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// BatchGetLikeBaskets provides a mock function with given fields: ctx, lastUserID, batch
+func (_m *MockRecomStore) BatchGetLikeBaskets(ctx context.Context, lastUserID int64, batch int) ([]database.RecomBasket, error) {
+	ret := _m.Called(ctx, lastUserID, batch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BatchGetLikeBaskets")
+	}
+
+	var r0 []database.RecomBasket
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) ([]database.RecomBasket, error)); ok {
+		return rf(ctx, lastUserID, batch)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) []database.RecomBasket); ok {
+		r0 = rf(ctx, lastUserID, batch)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.RecomBasket)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int) error); ok {
+		r1 = rf(ctx, lastUserID, batch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRecomStore_BatchGetLikeBaskets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BatchGetLikeBaskets'
+type MockRecomStore_BatchGetLikeBaskets_Call struct {
+	*mock.Call
+}
+
+// BatchGetLikeBaskets is a helper method to define mock.On call
+//   - ctx context.Context
+//   - lastUserID int64
+//   - batch int
+func (_e *MockRecomStore_Expecter) BatchGetLikeBaskets(ctx interface{}, lastUserID interface{}, batch interface{}) *MockRecomStore_BatchGetLikeBaskets_Call {
+	return &MockRecomStore_BatchGetLikeBaskets_Call{Call: _e.mock.On("BatchGetLikeBaskets", ctx, lastUserID, batch)}
+}
+
+func (_c *MockRecomStore_BatchGetLikeBaskets_Call) Run(run func(ctx context.Context, lastUserID int64, batch int)) *MockRecomStore_BatchGetLikeBaskets_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockRecomStore_BatchGetLikeBaskets_Call) Return(_a0 []database.RecomBasket, _a1 error) *MockRecomStore_BatchGetLikeBaskets_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRecomStore_BatchGetLikeBaskets_Call) RunAndReturn(run func(context.Context, int64, int) ([]database.RecomBasket, error)) *MockRecomStore_BatchGetLikeBaskets_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteRelatedReposBefore provides a mock function with given fields: ctx, t
+func (_m *MockRecomStore) DeleteRelatedReposBefore(ctx context.Context, t time.Time) error {
+	ret := _m.Called(ctx, t)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRelatedReposBefore")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) error); ok {
+		r0 = rf(ctx, t)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRecomStore_DeleteRelatedReposBefore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteRelatedReposBefore'
+type MockRecomStore_DeleteRelatedReposBefore_Call struct {
+	*mock.Call
+}
+
+// DeleteRelatedReposBefore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - t time.Time
+func (_e *MockRecomStore_Expecter) DeleteRelatedReposBefore(ctx interface{}, t interface{}) *MockRecomStore_DeleteRelatedReposBefore_Call {
+	return &MockRecomStore_DeleteRelatedReposBefore_Call{Call: _e.mock.On("DeleteRelatedReposBefore", ctx, t)}
+}
+
+func (_c *MockRecomStore_DeleteRelatedReposBefore_Call) Run(run func(ctx context.Context, t time.Time)) *MockRecomStore_DeleteRelatedReposBefore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockRecomStore_DeleteRelatedReposBefore_Call) Return(_a0 error) *MockRecomStore_DeleteRelatedReposBefore_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRecomStore_DeleteRelatedReposBefore_Call) RunAndReturn(run func(context.Context, time.Time) error) *MockRecomStore_DeleteRelatedReposBefore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // FindByRepoIDs provides a mock function with given fields: ctx, repoIDs
 func (_m *MockRecomStore) FindByRepoIDs(ctx context.Context, repoIDs []int64) ([]*database.RecomRepoScore, error) {
 	ret := _m.Called(ctx, repoIDs)
@@ -81,6 +252,66 @@ func (_c *MockRecomStore_FindByRepoIDs_Call) RunAndReturn(run func(context.Conte
 	return _c
 }
 
+// FindRelatedRepos provides a mock function with given fields: ctx, repoID, limit
+func (_m *MockRecomStore) FindRelatedRepos(ctx context.Context, repoID int64, limit int) ([]*database.RecomRelatedRepo, error) {
+	ret := _m.Called(ctx, repoID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindRelatedRepos")
+	}
+
+	var r0 []*database.RecomRelatedRepo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) ([]*database.RecomRelatedRepo, error)); ok {
+		return rf(ctx, repoID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) []*database.RecomRelatedRepo); ok {
+		r0 = rf(ctx, repoID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*database.RecomRelatedRepo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int) error); ok {
+		r1 = rf(ctx, repoID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRecomStore_FindRelatedRepos_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindRelatedRepos'
+type MockRecomStore_FindRelatedRepos_Call struct {
+	*mock.Call
+}
+
+// FindRelatedRepos is a helper method to define mock.On call
+//   - ctx context.Context
+//   - repoID int64
+//   - limit int
+func (_e *MockRecomStore_Expecter) FindRelatedRepos(ctx interface{}, repoID interface{}, limit interface{}) *MockRecomStore_FindRelatedRepos_Call {
+	return &MockRecomStore_FindRelatedRepos_Call{Call: _e.mock.On("FindRelatedRepos", ctx, repoID, limit)}
+}
+
+func (_c *MockRecomStore_FindRelatedRepos_Call) Run(run func(ctx context.Context, repoID int64, limit int)) *MockRecomStore_FindRelatedRepos_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockRecomStore_FindRelatedRepos_Call) Return(_a0 []*database.RecomRelatedRepo, _a1 error) *MockRecomStore_FindRelatedRepos_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRecomStore_FindRelatedRepos_Call) RunAndReturn(run func(context.Context, int64, int) ([]*database.RecomRelatedRepo, error)) *MockRecomStore_FindRelatedRepos_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // LoadRepoOpWeights provides a mock function with given fields: ctx, repoIDs
 func (_m *MockRecomStore) LoadRepoOpWeights(ctx context.Context, repoIDs []int64) (map[int64]int, error) {
 	ret := _m.Called(ctx, repoIDs)
@@ -198,6 +429,233 @@ func (_c *MockRecomStore_LoadWeights_Call) RunAndReturn(run func(context.Context
 	return _c
 }
 
+// RecommendForRepos provides a mock function with given fields: ctx, repoIDs, repoType, limit
+func (_m *MockRecomStore) RecommendForRepos(ctx context.Context, repoIDs []int64, repoType types.RepositoryType, limit int) ([]*database.RecomRelatedRepo, error) {
+	ret := _m.Called(ctx, repoIDs, repoType, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecommendForRepos")
+	}
+
+	var r0 []*database.RecomRelatedRepo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int64, types.RepositoryType, int) ([]*database.RecomRelatedRepo, error)); ok {
+		return rf(ctx, repoIDs, repoType, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []int64, types.RepositoryType, int) []*database.RecomRelatedRepo); ok {
+		r0 = rf(ctx, repoIDs, repoType, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*database.RecomRelatedRepo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []int64, types.RepositoryType, int) error); ok {
+		r1 = rf(ctx, repoIDs, repoType, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRecomStore_RecommendForRepos_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecommendForRepos'
+type MockRecomStore_RecommendForRepos_Call struct {
+	*mock.Call
+}
+
+// RecommendForRepos is a helper method to define mock.On call
+//   - ctx context.Context
+//   - repoIDs []int64
+//   - repoType types.RepositoryType
+//   - limit int
+func (_e *MockRecomStore_Expecter) RecommendForRepos(ctx interface{}, repoIDs interface{}, repoType interface{}, limit interface{}) *MockRecomStore_RecommendForRepos_Call {
+	return &MockRecomStore_RecommendForRepos_Call{Call: _e.mock.On("RecommendForRepos", ctx, repoIDs, repoType, limit)}
+}
+
+func (_c *MockRecomStore_RecommendForRepos_Call) Run(run func(ctx context.Context, repoIDs []int64, repoType types.RepositoryType, limit int)) *MockRecomStore_RecommendForRepos_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]int64), args[2].(types.RepositoryType), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *MockRecomStore_RecommendForRepos_Call) Return(_a0 []*database.RecomRelatedRepo, _a1 error) *MockRecomStore_RecommendForRepos_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRecomStore_RecommendForRepos_Call) RunAndReturn(run func(context.Context, []int64, types.RepositoryType, int) ([]*database.RecomRelatedRepo, error)) *MockRecomStore_RecommendForRepos_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RepoDownloadsSince provides a mock function with given fields: ctx, since
+func (_m *MockRecomStore) RepoDownloadsSince(ctx context.Context, since time.Time) (map[int64]int64, error) {
+	ret := _m.Called(ctx, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RepoDownloadsSince")
+	}
+
+	var r0 map[int64]int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (map[int64]int64, error)); ok {
+		return rf(ctx, since)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) map[int64]int64); ok {
+		r0 = rf(ctx, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int64]int64)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRecomStore_RepoDownloadsSince_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RepoDownloadsSince'
+type MockRecomStore_RepoDownloadsSince_Call struct {
+	*mock.Call
+}
+
+// RepoDownloadsSince is a helper method to define mock.On call
+//   - ctx context.Context
+//   - since time.Time
+func (_e *MockRecomStore_Expecter) RepoDownloadsSince(ctx interface{}, since interface{}) *MockRecomStore_RepoDownloadsSince_Call {
+	return &MockRecomStore_RepoDownloadsSince_Call{Call: _e.mock.On("RepoDownloadsSince", ctx, since)}
+}
+
+func (_c *MockRecomStore_RepoDownloadsSince_Call) Run(run func(ctx context.Context, since time.Time)) *MockRecomStore_RepoDownloadsSince_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockRecomStore_RepoDownloadsSince_Call) Return(_a0 map[int64]int64, _a1 error) *MockRecomStore_RepoDownloadsSince_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRecomStore_RepoDownloadsSince_Call) RunAndReturn(run func(context.Context, time.Time) (map[int64]int64, error)) *MockRecomStore_RepoDownloadsSince_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TopScoredRepos provides a mock function with given fields: ctx, repoType, limit
+func (_m *MockRecomStore) TopScoredRepos(ctx context.Context, repoType types.RepositoryType, limit int) ([]*database.RecomRepoScore, error) {
+	ret := _m.Called(ctx, repoType, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TopScoredRepos")
+	}
+
+	var r0 []*database.RecomRepoScore
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.RepositoryType, int) ([]*database.RecomRepoScore, error)); ok {
+		return rf(ctx, repoType, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, types.RepositoryType, int) []*database.RecomRepoScore); ok {
+		r0 = rf(ctx, repoType, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*database.RecomRepoScore)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, types.RepositoryType, int) error); ok {
+		r1 = rf(ctx, repoType, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRecomStore_TopScoredRepos_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TopScoredRepos'
+type MockRecomStore_TopScoredRepos_Call struct {
+	*mock.Call
+}
+
+// TopScoredRepos is a helper method to define mock.On call
+//   - ctx context.Context
+//   - repoType types.RepositoryType
+//   - limit int
+func (_e *MockRecomStore_Expecter) TopScoredRepos(ctx interface{}, repoType interface{}, limit interface{}) *MockRecomStore_TopScoredRepos_Call {
+	return &MockRecomStore_TopScoredRepos_Call{Call: _e.mock.On("TopScoredRepos", ctx, repoType, limit)}
+}
+
+func (_c *MockRecomStore_TopScoredRepos_Call) Run(run func(ctx context.Context, repoType types.RepositoryType, limit int)) *MockRecomStore_TopScoredRepos_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(types.RepositoryType), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockRecomStore_TopScoredRepos_Call) Return(_a0 []*database.RecomRepoScore, _a1 error) *MockRecomStore_TopScoredRepos_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRecomStore_TopScoredRepos_Call) RunAndReturn(run func(context.Context, types.RepositoryType, int) ([]*database.RecomRepoScore, error)) *MockRecomStore_TopScoredRepos_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpsertRelatedRepos provides a mock function with given fields: ctx, items
+func (_m *MockRecomStore) UpsertRelatedRepos(ctx context.Context, items []*database.RecomRelatedRepo) error {
+	ret := _m.Called(ctx, items)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertRelatedRepos")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*database.RecomRelatedRepo) error); ok {
+		r0 = rf(ctx, items)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRecomStore_UpsertRelatedRepos_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertRelatedRepos'
+type MockRecomStore_UpsertRelatedRepos_Call struct {
+	*mock.Call
+}
+
+// UpsertRelatedRepos is a helper method to define mock.On call
+//   - ctx context.Context
+//   - items []*database.RecomRelatedRepo
+func (_e *MockRecomStore_Expecter) UpsertRelatedRepos(ctx interface{}, items interface{}) *MockRecomStore_UpsertRelatedRepos_Call {
+	return &MockRecomStore_UpsertRelatedRepos_Call{Call: _e.mock.On("UpsertRelatedRepos", ctx, items)}
+}
+
+func (_c *MockRecomStore_UpsertRelatedRepos_Call) Run(run func(ctx context.Context, items []*database.RecomRelatedRepo)) *MockRecomStore_UpsertRelatedRepos_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]*database.RecomRelatedRepo))
+	})
+	return _c
+}
+
+func (_c *MockRecomStore_UpsertRelatedRepos_Call) Return(_a0 error) *MockRecomStore_UpsertRelatedRepos_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRecomStore_UpsertRelatedRepos_Call) RunAndReturn(run func(context.Context, []*database.RecomRelatedRepo) error) *MockRecomStore_UpsertRelatedRepos_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpsertScore provides a mock function with given fields: ctx, scores
 func (_m *MockRecomStore) UpsertScore(ctx context.Context, scores []*database.RecomRepoScore) error {
 	ret := _m.Called(ctx, scores)
@@ -193,6 +193,65 @@ func (_c *MockRepoFileStore_Create_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// DeleteStale provides a mock function with given fields: ctx, repoID, branch, current
+func (_m *MockRepoFileStore) DeleteStale(ctx context.Context, repoID int64, branch string, current []database.RepositoryFile) (int, error) {
+	ret := _m.Called(ctx, repoID, branch, current)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteStale")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, []database.RepositoryFile) (int, error)); ok {
+		return rf(ctx, repoID, branch, current)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, []database.RepositoryFile) int); ok {
+		r0 = rf(ctx, repoID, branch, current)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string, []database.RepositoryFile) error); ok {
+		r1 = rf(ctx, repoID, branch, current)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepoFileStore_DeleteStale_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteStale'
+type MockRepoFileStore_DeleteStale_Call struct {
+	*mock.Call
+}
+
+// DeleteStale is a helper method to define mock.On call
+//   - ctx context.Context
+//   - repoID int64
+//   - branch string
+//   - current []database.RepositoryFile
+func (_e *MockRepoFileStore_Expecter) DeleteStale(ctx interface{}, repoID interface{}, branch interface{}, current interface{}) *MockRepoFileStore_DeleteStale_Call {
+	return &MockRepoFileStore_DeleteStale_Call{Call: _e.mock.On("DeleteStale", ctx, repoID, branch, current)}
+}
+
+func (_c *MockRepoFileStore_DeleteStale_Call) Run(run func(ctx context.Context, repoID int64, branch string, current []database.RepositoryFile)) *MockRepoFileStore_DeleteStale_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string), args[3].([]database.RepositoryFile))
+	})
+	return _c
+}
+
+func (_c *MockRepoFileStore_DeleteStale_Call) Return(_a0 int, _a1 error) *MockRepoFileStore_DeleteStale_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepoFileStore_DeleteStale_Call) RunAndReturn(run func(context.Context, int64, string, []database.RepositoryFile) (int, error)) *MockRepoFileStore_DeleteStale_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Exists provides a mock function with given fields: ctx, file
 func (_m *MockRepoFileStore) Exists(ctx context.Context, file database.RepositoryFile) (bool, error) {
 	ret := _m.Called(ctx, file)
@@ -309,6 +368,134 @@ func (_c *MockRepoFileStore_ExistsSensitiveCheckRecord_Call) RunAndReturn(run fu
 	return _c
 }
 
+// PathsByLfsRelativePaths provides a mock function with given fields: ctx, repoID, relativePaths
+func (_m *MockRepoFileStore) PathsByLfsRelativePaths(ctx context.Context, repoID int64, relativePaths []string) (map[string]string, error) {
+	ret := _m.Called(ctx, repoID, relativePaths)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PathsByLfsRelativePaths")
+	}
+
+	var r0 map[string]string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []string) (map[string]string, error)); ok {
+		return rf(ctx, repoID, relativePaths)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []string) map[string]string); ok {
+		r0 = rf(ctx, repoID, relativePaths)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, []string) error); ok {
+		r1 = rf(ctx, repoID, relativePaths)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepoFileStore_PathsByLfsRelativePaths_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PathsByLfsRelativePaths'
+type MockRepoFileStore_PathsByLfsRelativePaths_Call struct {
+	*mock.Call
+}
+
+// PathsByLfsRelativePaths is a helper method to define mock.On call
+//   - ctx context.Context
+//   - repoID int64
+//   - relativePaths []string
+func (_e *MockRepoFileStore_Expecter) PathsByLfsRelativePaths(ctx interface{}, repoID interface{}, relativePaths interface{}) *MockRepoFileStore_PathsByLfsRelativePaths_Call {
+	return &MockRepoFileStore_PathsByLfsRelativePaths_Call{Call: _e.mock.On("PathsByLfsRelativePaths", ctx, repoID, relativePaths)}
+}
+
+func (_c *MockRepoFileStore_PathsByLfsRelativePaths_Call) Run(run func(ctx context.Context, repoID int64, relativePaths []string)) *MockRepoFileStore_PathsByLfsRelativePaths_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].([]string))
+	})
+	return _c
+}
+
+func (_c *MockRepoFileStore_PathsByLfsRelativePaths_Call) Return(_a0 map[string]string, _a1 error) *MockRepoFileStore_PathsByLfsRelativePaths_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepoFileStore_PathsByLfsRelativePaths_Call) RunAndReturn(run func(context.Context, int64, []string) (map[string]string, error)) *MockRepoFileStore_PathsByLfsRelativePaths_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Search provides a mock function with given fields: ctx, req, ownerNamespaces, isAdmin
+func (_m *MockRepoFileStore) Search(ctx context.Context, req *types.SearchRepoFileReq, ownerNamespaces []string, isAdmin bool) ([]database.RepositoryFile, int, error) {
+	ret := _m.Called(ctx, req, ownerNamespaces, isAdmin)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 []database.RepositoryFile
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *types.SearchRepoFileReq, []string, bool) ([]database.RepositoryFile, int, error)); ok {
+		return rf(ctx, req, ownerNamespaces, isAdmin)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *types.SearchRepoFileReq, []string, bool) []database.RepositoryFile); ok {
+		r0 = rf(ctx, req, ownerNamespaces, isAdmin)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.RepositoryFile)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *types.SearchRepoFileReq, []string, bool) int); ok {
+		r1 = rf(ctx, req, ownerNamespaces, isAdmin)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *types.SearchRepoFileReq, []string, bool) error); ok {
+		r2 = rf(ctx, req, ownerNamespaces, isAdmin)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockRepoFileStore_Search_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Search'
+type MockRepoFileStore_Search_Call struct {
+	*mock.Call
+}
+
+// Search is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *types.SearchRepoFileReq
+//   - ownerNamespaces []string
+//   - isAdmin bool
+func (_e *MockRepoFileStore_Expecter) Search(ctx interface{}, req interface{}, ownerNamespaces interface{}, isAdmin interface{}) *MockRepoFileStore_Search_Call {
+	return &MockRepoFileStore_Search_Call{Call: _e.mock.On("Search", ctx, req, ownerNamespaces, isAdmin)}
+}
+
+func (_c *MockRepoFileStore_Search_Call) Run(run func(ctx context.Context, req *types.SearchRepoFileReq, ownerNamespaces []string, isAdmin bool)) *MockRepoFileStore_Search_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*types.SearchRepoFileReq), args[2].([]string), args[3].(bool))
+	})
+	return _c
+}
+
+func (_c *MockRepoFileStore_Search_Call) Return(_a0 []database.RepositoryFile, _a1 int, _a2 error) *MockRepoFileStore_Search_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockRepoFileStore_Search_Call) RunAndReturn(run func(context.Context, *types.SearchRepoFileReq, []string, bool) ([]database.RepositoryFile, int, error)) *MockRepoFileStore_Search_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockRepoFileStore creates a new instance of MockRepoFileStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockRepoFileStore(t interface {
@@ -771,6 +771,66 @@ func (_c *MockRepoStore_Find_Call) RunAndReturn(run func(context.Context, string
 	return _c
 }
 
+// FindAfterID provides a mock function with given fields: ctx, batchSize, lastID
+func (_m *MockRepoStore) FindAfterID(ctx context.Context, batchSize int, lastID int64) ([]database.Repository, error) {
+	ret := _m.Called(ctx, batchSize, lastID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindAfterID")
+	}
+
+	var r0 []database.Repository
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int64) ([]database.Repository, error)); ok {
+		return rf(ctx, batchSize, lastID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int64) []database.Repository); ok {
+		r0 = rf(ctx, batchSize, lastID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.Repository)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int64) error); ok {
+		r1 = rf(ctx, batchSize, lastID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepoStore_FindAfterID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindAfterID'
+type MockRepoStore_FindAfterID_Call struct {
+	*mock.Call
+}
+
+// FindAfterID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - batchSize int
+//   - lastID int64
+func (_e *MockRepoStore_Expecter) FindAfterID(ctx interface{}, batchSize interface{}, lastID interface{}) *MockRepoStore_FindAfterID_Call {
+	return &MockRepoStore_FindAfterID_Call{Call: _e.mock.On("FindAfterID", ctx, batchSize, lastID)}
+}
+
+func (_c *MockRepoStore_FindAfterID_Call) Run(run func(ctx context.Context, batchSize int, lastID int64)) *MockRepoStore_FindAfterID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockRepoStore_FindAfterID_Call) Return(_a0 []database.Repository, _a1 error) *MockRepoStore_FindAfterID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepoStore_FindAfterID_Call) RunAndReturn(run func(context.Context, int, int64) ([]database.Repository, error)) *MockRepoStore_FindAfterID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // FindByGitPath provides a mock function with given fields: ctx, path
 func (_m *MockRepoStore) FindByGitPath(ctx context.Context, path string) (*database.Repository, error) {
 	ret := _m.Called(ctx, path)
@@ -690,6 +690,66 @@ func (_c *MockSpaceStore_ListByPath_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// ListWithPlainSecrets provides a mock function with given fields: ctx, afterID, limit
+func (_m *MockSpaceStore) ListWithPlainSecrets(ctx context.Context, afterID int64, limit int) ([]database.Space, error) {
+	ret := _m.Called(ctx, afterID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWithPlainSecrets")
+	}
+
+	var r0 []database.Space
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) ([]database.Space, error)); ok {
+		return rf(ctx, afterID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) []database.Space); ok {
+		r0 = rf(ctx, afterID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.Space)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int) error); ok {
+		r1 = rf(ctx, afterID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSpaceStore_ListWithPlainSecrets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListWithPlainSecrets'
+type MockSpaceStore_ListWithPlainSecrets_Call struct {
+	*mock.Call
+}
+
+// ListWithPlainSecrets is a helper method to define mock.On call
+//   - ctx context.Context
+//   - afterID int64
+//   - limit int
+func (_e *MockSpaceStore_Expecter) ListWithPlainSecrets(ctx interface{}, afterID interface{}, limit interface{}) *MockSpaceStore_ListWithPlainSecrets_Call {
+	return &MockSpaceStore_ListWithPlainSecrets_Call{Call: _e.mock.On("ListWithPlainSecrets", ctx, afterID, limit)}
+}
+
+func (_c *MockSpaceStore_ListWithPlainSecrets_Call) Run(run func(ctx context.Context, afterID int64, limit int)) *MockSpaceStore_ListWithPlainSecrets_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockSpaceStore_ListWithPlainSecrets_Call) Return(_a0 []database.Space, _a1 error) *MockSpaceStore_ListWithPlainSecrets_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSpaceStore_ListWithPlainSecrets_Call) RunAndReturn(run func(context.Context, int64, int) ([]database.Space, error)) *MockSpaceStore_ListWithPlainSecrets_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Update provides a mock function with given fields: ctx, input
 func (_m *MockSpaceStore) Update(ctx context.Context, input database.Space) error {
 	ret := _m.Called(ctx, input)
@@ -6,6 +6,7 @@ import (
 	context "context"
 
 	mock "github.com/stretchr/testify/mock"
+	database "opencsg.com/csghub-server/builder/store/database"
 )
 
 // MockUserLikesStore is an autogenerated mock type for the UserLikesStore type
@@ -281,6 +282,125 @@ func (_c *MockUserLikesStore_LikeCollection_Call) RunAndReturn(run func(context.
 	return _c
 }
 
+// LikedRepoIDs provides a mock function with given fields: ctx, userID, limit
+func (_m *MockUserLikesStore) LikedRepoIDs(ctx context.Context, userID int64, limit int) ([]int64, error) {
+	ret := _m.Called(ctx, userID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LikedRepoIDs")
+	}
+
+	var r0 []int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) ([]int64, error)); ok {
+		return rf(ctx, userID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) []int64); ok {
+		r0 = rf(ctx, userID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int64)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int) error); ok {
+		r1 = rf(ctx, userID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUserLikesStore_LikedRepoIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LikedRepoIDs'
+type MockUserLikesStore_LikedRepoIDs_Call struct {
+	*mock.Call
+}
+
+// LikedRepoIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - limit int
+func (_e *MockUserLikesStore_Expecter) LikedRepoIDs(ctx interface{}, userID interface{}, limit interface{}) *MockUserLikesStore_LikedRepoIDs_Call {
+	return &MockUserLikesStore_LikedRepoIDs_Call{Call: _e.mock.On("LikedRepoIDs", ctx, userID, limit)}
+}
+
+func (_c *MockUserLikesStore_LikedRepoIDs_Call) Run(run func(ctx context.Context, userID int64, limit int)) *MockUserLikesStore_LikedRepoIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockUserLikesStore_LikedRepoIDs_Call) Return(_a0 []int64, _a1 error) *MockUserLikesStore_LikedRepoIDs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUserLikesStore_LikedRepoIDs_Call) RunAndReturn(run func(context.Context, int64, int) ([]int64, error)) *MockUserLikesStore_LikedRepoIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Likers provides a mock function with given fields: ctx, repoID
+func (_m *MockUserLikesStore) Likers(ctx context.Context, repoID int64) ([]database.User, error) {
+	ret := _m.Called(ctx, repoID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Likers")
+	}
+
+	var r0 []database.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]database.User, error)); ok {
+		return rf(ctx, repoID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []database.User); ok {
+		r0 = rf(ctx, repoID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, repoID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUserLikesStore_Likers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Likers'
+type MockUserLikesStore_Likers_Call struct {
+	*mock.Call
+}
+
+// Likers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - repoID int64
+func (_e *MockUserLikesStore_Expecter) Likers(ctx interface{}, repoID interface{}) *MockUserLikesStore_Likers_Call {
+	return &MockUserLikesStore_Likers_Call{Call: _e.mock.On("Likers", ctx, repoID)}
+}
+
+func (_c *MockUserLikesStore_Likers_Call) Run(run func(ctx context.Context, repoID int64)) *MockUserLikesStore_Likers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockUserLikesStore_Likers_Call) Return(_a0 []database.User, _a1 error) *MockUserLikesStore_Likers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUserLikesStore_Likers_Call) RunAndReturn(run func(context.Context, int64) ([]database.User, error)) *MockUserLikesStore_Likers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UnLikeCollection provides a mock function with given fields: ctx, userId, collectionId
 func (_m *MockUserLikesStore) UnLikeCollection(ctx context.Context, userId int64, collectionId int64) error {
 	ret := _m.Called(ctx, userId, collectionId)
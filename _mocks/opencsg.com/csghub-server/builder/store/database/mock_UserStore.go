@@ -629,6 +629,65 @@ func (_c *MockUserStore_FindByUsernameWithDeleted_Call) RunAndReturn(run func(co
 	return _c
 }
 
+// FindByUsernames provides a mock function with given fields: ctx, usernames
+func (_m *MockUserStore) FindByUsernames(ctx context.Context, usernames []string) ([]database.User, error) {
+	ret := _m.Called(ctx, usernames)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByUsernames")
+	}
+
+	var r0 []database.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]database.User, error)); ok {
+		return rf(ctx, usernames)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []database.User); ok {
+		r0 = rf(ctx, usernames)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, usernames)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUserStore_FindByUsernames_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByUsernames'
+type MockUserStore_FindByUsernames_Call struct {
+	*mock.Call
+}
+
+// FindByUsernames is a helper method to define mock.On call
+//   - ctx context.Context
+//   - usernames []string
+func (_e *MockUserStore_Expecter) FindByUsernames(ctx interface{}, usernames interface{}) *MockUserStore_FindByUsernames_Call {
+	return &MockUserStore_FindByUsernames_Call{Call: _e.mock.On("FindByUsernames", ctx, usernames)}
+}
+
+func (_c *MockUserStore_FindByUsernames_Call) Run(run func(ctx context.Context, usernames []string)) *MockUserStore_FindByUsernames_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *MockUserStore_FindByUsernames_Call) Return(_a0 []database.User, _a1 error) *MockUserStore_FindByUsernames_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUserStore_FindByUsernames_Call) RunAndReturn(run func(context.Context, []string) ([]database.User, error)) *MockUserStore_FindByUsernames_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetAdminEmails provides a mock function with given fields: ctx
 func (_m *MockUserStore) GetAdminEmails(ctx context.Context) ([]string, error) {
 	ret := _m.Called(ctx)
@@ -6,6 +6,8 @@ import (
 	context "context"
 
 	mock "github.com/stretchr/testify/mock"
+	database "opencsg.com/csghub-server/builder/store/database"
+
 	types "opencsg.com/csghub-server/common/types"
 )
 
@@ -163,6 +165,194 @@ func (_c *MockGitCallbackComponent_MCPScan_Call) RunAndReturn(run func(context.C
 	return _c
 }
 
+// RebuildSearchDocument provides a mock function with given fields: ctx, repo
+func (_m *MockGitCallbackComponent) RebuildSearchDocument(ctx context.Context, repo *database.Repository) error {
+	ret := _m.Called(ctx, repo)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RebuildSearchDocument")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *database.Repository) error); ok {
+		r0 = rf(ctx, repo)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockGitCallbackComponent_RebuildSearchDocument_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RebuildSearchDocument'
+type MockGitCallbackComponent_RebuildSearchDocument_Call struct {
+	*mock.Call
+}
+
+// RebuildSearchDocument is a helper method to define mock.On call
+//   - ctx context.Context
+//   - repo *database.Repository
+func (_e *MockGitCallbackComponent_Expecter) RebuildSearchDocument(ctx interface{}, repo interface{}) *MockGitCallbackComponent_RebuildSearchDocument_Call {
+	return &MockGitCallbackComponent_RebuildSearchDocument_Call{Call: _e.mock.On("RebuildSearchDocument", ctx, repo)}
+}
+
+func (_c *MockGitCallbackComponent_RebuildSearchDocument_Call) Run(run func(ctx context.Context, repo *database.Repository)) *MockGitCallbackComponent_RebuildSearchDocument_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*database.Repository))
+	})
+	return _c
+}
+
+func (_c *MockGitCallbackComponent_RebuildSearchDocument_Call) Return(_a0 error) *MockGitCallbackComponent_RebuildSearchDocument_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockGitCallbackComponent_RebuildSearchDocument_Call) RunAndReturn(run func(context.Context, *database.Repository) error) *MockGitCallbackComponent_RebuildSearchDocument_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordFeedEvent provides a mock function with given fields: ctx, req
+func (_m *MockGitCallbackComponent) RecordFeedEvent(ctx context.Context, req *types.GiteaCallbackPushReq) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordFeedEvent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *types.GiteaCallbackPushReq) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockGitCallbackComponent_RecordFeedEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordFeedEvent'
+type MockGitCallbackComponent_RecordFeedEvent_Call struct {
+	*mock.Call
+}
+
+// RecordFeedEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *types.GiteaCallbackPushReq
+func (_e *MockGitCallbackComponent_Expecter) RecordFeedEvent(ctx interface{}, req interface{}) *MockGitCallbackComponent_RecordFeedEvent_Call {
+	return &MockGitCallbackComponent_RecordFeedEvent_Call{Call: _e.mock.On("RecordFeedEvent", ctx, req)}
+}
+
+func (_c *MockGitCallbackComponent_RecordFeedEvent_Call) Run(run func(ctx context.Context, req *types.GiteaCallbackPushReq)) *MockGitCallbackComponent_RecordFeedEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*types.GiteaCallbackPushReq))
+	})
+	return _c
+}
+
+func (_c *MockGitCallbackComponent_RecordFeedEvent_Call) Return(_a0 error) *MockGitCallbackComponent_RecordFeedEvent_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockGitCallbackComponent_RecordFeedEvent_Call) RunAndReturn(run func(context.Context, *types.GiteaCallbackPushReq) error) *MockGitCallbackComponent_RecordFeedEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RefreshRepoCard provides a mock function with given fields: ctx, req
+func (_m *MockGitCallbackComponent) RefreshRepoCard(ctx context.Context, req *types.GiteaCallbackPushReq) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RefreshRepoCard")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *types.GiteaCallbackPushReq) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockGitCallbackComponent_RefreshRepoCard_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RefreshRepoCard'
+type MockGitCallbackComponent_RefreshRepoCard_Call struct {
+	*mock.Call
+}
+
+// RefreshRepoCard is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *types.GiteaCallbackPushReq
+func (_e *MockGitCallbackComponent_Expecter) RefreshRepoCard(ctx interface{}, req interface{}) *MockGitCallbackComponent_RefreshRepoCard_Call {
+	return &MockGitCallbackComponent_RefreshRepoCard_Call{Call: _e.mock.On("RefreshRepoCard", ctx, req)}
+}
+
+func (_c *MockGitCallbackComponent_RefreshRepoCard_Call) Run(run func(ctx context.Context, req *types.GiteaCallbackPushReq)) *MockGitCallbackComponent_RefreshRepoCard_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*types.GiteaCallbackPushReq))
+	})
+	return _c
+}
+
+func (_c *MockGitCallbackComponent_RefreshRepoCard_Call) Return(_a0 error) *MockGitCallbackComponent_RefreshRepoCard_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockGitCallbackComponent_RefreshRepoCard_Call) RunAndReturn(run func(context.Context, *types.GiteaCallbackPushReq) error) *MockGitCallbackComponent_RefreshRepoCard_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RefreshSearchDocument provides a mock function with given fields: ctx, req
+func (_m *MockGitCallbackComponent) RefreshSearchDocument(ctx context.Context, req *types.GiteaCallbackPushReq) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RefreshSearchDocument")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *types.GiteaCallbackPushReq) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockGitCallbackComponent_RefreshSearchDocument_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RefreshSearchDocument'
+type MockGitCallbackComponent_RefreshSearchDocument_Call struct {
+	*mock.Call
+}
+
+// RefreshSearchDocument is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *types.GiteaCallbackPushReq
+func (_e *MockGitCallbackComponent_Expecter) RefreshSearchDocument(ctx interface{}, req interface{}) *MockGitCallbackComponent_RefreshSearchDocument_Call {
+	return &MockGitCallbackComponent_RefreshSearchDocument_Call{Call: _e.mock.On("RefreshSearchDocument", ctx, req)}
+}
+
+func (_c *MockGitCallbackComponent_RefreshSearchDocument_Call) Run(run func(ctx context.Context, req *types.GiteaCallbackPushReq)) *MockGitCallbackComponent_RefreshSearchDocument_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*types.GiteaCallbackPushReq))
+	})
+	return _c
+}
+
+func (_c *MockGitCallbackComponent_RefreshSearchDocument_Call) Return(_a0 error) *MockGitCallbackComponent_RefreshSearchDocument_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockGitCallbackComponent_RefreshSearchDocument_Call) RunAndReturn(run func(context.Context, *types.GiteaCallbackPushReq) error) *MockGitCallbackComponent_RefreshSearchDocument_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SensitiveCheck provides a mock function with given fields: ctx, req
 func (_m *MockGitCallbackComponent) SensitiveCheck(ctx context.Context, req *types.GiteaCallbackPushReq) error {
 	ret := _m.Called(ctx, req)
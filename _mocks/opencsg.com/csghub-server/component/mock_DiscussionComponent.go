@@ -22,6 +22,53 @@ func (_m *MockDiscussionComponent) EXPECT() *MockDiscussionComponent_Expecter {
 	return &MockDiscussionComponent_Expecter{mock: &_m.Mock}
 }
 
+// AddCommentReaction provides a mock function with given fields: ctx, req
+func (_m *MockDiscussionComponent) AddCommentReaction(ctx context.Context, req types.CommentReactionRequest) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddCommentReaction")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.CommentReactionRequest) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDiscussionComponent_AddCommentReaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddCommentReaction'
+type MockDiscussionComponent_AddCommentReaction_Call struct {
+	*mock.Call
+}
+
+// AddCommentReaction is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req types.CommentReactionRequest
+func (_e *MockDiscussionComponent_Expecter) AddCommentReaction(ctx interface{}, req interface{}) *MockDiscussionComponent_AddCommentReaction_Call {
+	return &MockDiscussionComponent_AddCommentReaction_Call{Call: _e.mock.On("AddCommentReaction", ctx, req)}
+}
+
+func (_c *MockDiscussionComponent_AddCommentReaction_Call) Run(run func(ctx context.Context, req types.CommentReactionRequest)) *MockDiscussionComponent_AddCommentReaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(types.CommentReactionRequest))
+	})
+	return _c
+}
+
+func (_c *MockDiscussionComponent_AddCommentReaction_Call) Return(_a0 error) *MockDiscussionComponent_AddCommentReaction_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDiscussionComponent_AddCommentReaction_Call) RunAndReturn(run func(context.Context, types.CommentReactionRequest) error) *MockDiscussionComponent_AddCommentReaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateDiscussionComment provides a mock function with given fields: ctx, req
 func (_m *MockDiscussionComponent) CreateDiscussionComment(ctx context.Context, req types.CreateCommentRequest) (*types.CreateCommentResponse, error) {
 	ret := _m.Called(ctx, req)
@@ -81,6 +128,65 @@ func (_c *MockDiscussionComponent_CreateDiscussionComment_Call) RunAndReturn(run
 	return _c
 }
 
+// CreateDiscussionLabel provides a mock function with given fields: ctx, req
+func (_m *MockDiscussionComponent) CreateDiscussionLabel(ctx context.Context, req types.DiscussionLabelRequest) (*types.DiscussionLabel, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateDiscussionLabel")
+	}
+
+	var r0 *types.DiscussionLabel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.DiscussionLabelRequest) (*types.DiscussionLabel, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, types.DiscussionLabelRequest) *types.DiscussionLabel); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.DiscussionLabel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, types.DiscussionLabelRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDiscussionComponent_CreateDiscussionLabel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateDiscussionLabel'
+type MockDiscussionComponent_CreateDiscussionLabel_Call struct {
+	*mock.Call
+}
+
+// CreateDiscussionLabel is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req types.DiscussionLabelRequest
+func (_e *MockDiscussionComponent_Expecter) CreateDiscussionLabel(ctx interface{}, req interface{}) *MockDiscussionComponent_CreateDiscussionLabel_Call {
+	return &MockDiscussionComponent_CreateDiscussionLabel_Call{Call: _e.mock.On("CreateDiscussionLabel", ctx, req)}
+}
+
+func (_c *MockDiscussionComponent_CreateDiscussionLabel_Call) Run(run func(ctx context.Context, req types.DiscussionLabelRequest)) *MockDiscussionComponent_CreateDiscussionLabel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(types.DiscussionLabelRequest))
+	})
+	return _c
+}
+
+func (_c *MockDiscussionComponent_CreateDiscussionLabel_Call) Return(_a0 *types.DiscussionLabel, _a1 error) *MockDiscussionComponent_CreateDiscussionLabel_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDiscussionComponent_CreateDiscussionLabel_Call) RunAndReturn(run func(context.Context, types.DiscussionLabelRequest) (*types.DiscussionLabel, error)) *MockDiscussionComponent_CreateDiscussionLabel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateRepoDiscussion provides a mock function with given fields: ctx, req
 func (_m *MockDiscussionComponent) CreateRepoDiscussion(ctx context.Context, req types.CreateRepoDiscussionRequest) (*types.CreateDiscussionResponse, error) {
 	ret := _m.Called(ctx, req)
@@ -236,6 +342,53 @@ func (_c *MockDiscussionComponent_DeleteDiscussion_Call) RunAndReturn(run func(c
 	return _c
 }
 
+// DeleteDiscussionLabel provides a mock function with given fields: ctx, req
+func (_m *MockDiscussionComponent) DeleteDiscussionLabel(ctx context.Context, req types.DiscussionLabelRequest) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteDiscussionLabel")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.DiscussionLabelRequest) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDiscussionComponent_DeleteDiscussionLabel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteDiscussionLabel'
+type MockDiscussionComponent_DeleteDiscussionLabel_Call struct {
+	*mock.Call
+}
+
+// DeleteDiscussionLabel is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req types.DiscussionLabelRequest
+func (_e *MockDiscussionComponent_Expecter) DeleteDiscussionLabel(ctx interface{}, req interface{}) *MockDiscussionComponent_DeleteDiscussionLabel_Call {
+	return &MockDiscussionComponent_DeleteDiscussionLabel_Call{Call: _e.mock.On("DeleteDiscussionLabel", ctx, req)}
+}
+
+func (_c *MockDiscussionComponent_DeleteDiscussionLabel_Call) Run(run func(ctx context.Context, req types.DiscussionLabelRequest)) *MockDiscussionComponent_DeleteDiscussionLabel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(types.DiscussionLabelRequest))
+	})
+	return _c
+}
+
+func (_c *MockDiscussionComponent_DeleteDiscussionLabel_Call) Return(_a0 error) *MockDiscussionComponent_DeleteDiscussionLabel_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDiscussionComponent_DeleteDiscussionLabel_Call) RunAndReturn(run func(context.Context, types.DiscussionLabelRequest) error) *MockDiscussionComponent_DeleteDiscussionLabel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetDiscussion provides a mock function with given fields: ctx, currentUser, id, cPer, cPage
 func (_m *MockDiscussionComponent) GetDiscussion(ctx context.Context, currentUser string, id int64, cPer int, cPage int) (*types.ShowDiscussionResponse, error) {
 	ret := _m.Called(ctx, currentUser, id, cPer, cPage)
@@ -298,6 +451,53 @@ func (_c *MockDiscussionComponent_GetDiscussion_Call) RunAndReturn(run func(cont
 	return _c
 }
 
+// HideComment provides a mock function with given fields: ctx, req
+func (_m *MockDiscussionComponent) HideComment(ctx context.Context, req types.HideCommentRequest) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HideComment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.HideCommentRequest) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDiscussionComponent_HideComment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HideComment'
+type MockDiscussionComponent_HideComment_Call struct {
+	*mock.Call
+}
+
+// HideComment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req types.HideCommentRequest
+func (_e *MockDiscussionComponent_Expecter) HideComment(ctx interface{}, req interface{}) *MockDiscussionComponent_HideComment_Call {
+	return &MockDiscussionComponent_HideComment_Call{Call: _e.mock.On("HideComment", ctx, req)}
+}
+
+func (_c *MockDiscussionComponent_HideComment_Call) Run(run func(ctx context.Context, req types.HideCommentRequest)) *MockDiscussionComponent_HideComment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(types.HideCommentRequest))
+	})
+	return _c
+}
+
+func (_c *MockDiscussionComponent_HideComment_Call) Return(_a0 error) *MockDiscussionComponent_HideComment_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDiscussionComponent_HideComment_Call) RunAndReturn(run func(context.Context, types.HideCommentRequest) error) *MockDiscussionComponent_HideComment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListDiscussionComments provides a mock function with given fields: ctx, currentUser, discussionID, per, page
 func (_m *MockDiscussionComponent) ListDiscussionComments(ctx context.Context, currentUser string, discussionID int64, per int, page int) ([]*types.DiscussionResponse_Comment, int, error) {
 	ret := _m.Called(ctx, currentUser, discussionID, per, page)
@@ -367,6 +567,65 @@ func (_c *MockDiscussionComponent_ListDiscussionComments_Call) RunAndReturn(run
 	return _c
 }
 
+// ListDiscussionLabels provides a mock function with given fields: ctx, req
+func (_m *MockDiscussionComponent) ListDiscussionLabels(ctx context.Context, req types.ListRepoDiscussionRequest) ([]types.DiscussionLabel, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDiscussionLabels")
+	}
+
+	var r0 []types.DiscussionLabel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.ListRepoDiscussionRequest) ([]types.DiscussionLabel, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, types.ListRepoDiscussionRequest) []types.DiscussionLabel); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.DiscussionLabel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, types.ListRepoDiscussionRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDiscussionComponent_ListDiscussionLabels_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDiscussionLabels'
+type MockDiscussionComponent_ListDiscussionLabels_Call struct {
+	*mock.Call
+}
+
+// ListDiscussionLabels is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req types.ListRepoDiscussionRequest
+func (_e *MockDiscussionComponent_Expecter) ListDiscussionLabels(ctx interface{}, req interface{}) *MockDiscussionComponent_ListDiscussionLabels_Call {
+	return &MockDiscussionComponent_ListDiscussionLabels_Call{Call: _e.mock.On("ListDiscussionLabels", ctx, req)}
+}
+
+func (_c *MockDiscussionComponent_ListDiscussionLabels_Call) Run(run func(ctx context.Context, req types.ListRepoDiscussionRequest)) *MockDiscussionComponent_ListDiscussionLabels_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(types.ListRepoDiscussionRequest))
+	})
+	return _c
+}
+
+func (_c *MockDiscussionComponent_ListDiscussionLabels_Call) Return(_a0 []types.DiscussionLabel, _a1 error) *MockDiscussionComponent_ListDiscussionLabels_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDiscussionComponent_ListDiscussionLabels_Call) RunAndReturn(run func(context.Context, types.ListRepoDiscussionRequest) ([]types.DiscussionLabel, error)) *MockDiscussionComponent_ListDiscussionLabels_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListRepoDiscussions provides a mock function with given fields: ctx, req, per, page
 func (_m *MockDiscussionComponent) ListRepoDiscussions(ctx context.Context, req types.ListRepoDiscussionRequest, per int, page int) (*types.ListRepoDiscussionResponse, int, error) {
 	ret := _m.Called(ctx, req, per, page)
@@ -435,6 +694,206 @@ func (_c *MockDiscussionComponent_ListRepoDiscussions_Call) RunAndReturn(run fun
 	return _c
 }
 
+// PinDiscussion provides a mock function with given fields: ctx, req
+func (_m *MockDiscussionComponent) PinDiscussion(ctx context.Context, req types.PinDiscussionRequest) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PinDiscussion")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.PinDiscussionRequest) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDiscussionComponent_PinDiscussion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PinDiscussion'
+type MockDiscussionComponent_PinDiscussion_Call struct {
+	*mock.Call
+}
+
+// PinDiscussion is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req types.PinDiscussionRequest
+func (_e *MockDiscussionComponent_Expecter) PinDiscussion(ctx interface{}, req interface{}) *MockDiscussionComponent_PinDiscussion_Call {
+	return &MockDiscussionComponent_PinDiscussion_Call{Call: _e.mock.On("PinDiscussion", ctx, req)}
+}
+
+func (_c *MockDiscussionComponent_PinDiscussion_Call) Run(run func(ctx context.Context, req types.PinDiscussionRequest)) *MockDiscussionComponent_PinDiscussion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(types.PinDiscussionRequest))
+	})
+	return _c
+}
+
+func (_c *MockDiscussionComponent_PinDiscussion_Call) Return(_a0 error) *MockDiscussionComponent_PinDiscussion_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDiscussionComponent_PinDiscussion_Call) RunAndReturn(run func(context.Context, types.PinDiscussionRequest) error) *MockDiscussionComponent_PinDiscussion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveCommentReaction provides a mock function with given fields: ctx, req
+func (_m *MockDiscussionComponent) RemoveCommentReaction(ctx context.Context, req types.CommentReactionRequest) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveCommentReaction")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.CommentReactionRequest) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDiscussionComponent_RemoveCommentReaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveCommentReaction'
+type MockDiscussionComponent_RemoveCommentReaction_Call struct {
+	*mock.Call
+}
+
+// RemoveCommentReaction is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req types.CommentReactionRequest
+func (_e *MockDiscussionComponent_Expecter) RemoveCommentReaction(ctx interface{}, req interface{}) *MockDiscussionComponent_RemoveCommentReaction_Call {
+	return &MockDiscussionComponent_RemoveCommentReaction_Call{Call: _e.mock.On("RemoveCommentReaction", ctx, req)}
+}
+
+func (_c *MockDiscussionComponent_RemoveCommentReaction_Call) Run(run func(ctx context.Context, req types.CommentReactionRequest)) *MockDiscussionComponent_RemoveCommentReaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(types.CommentReactionRequest))
+	})
+	return _c
+}
+
+func (_c *MockDiscussionComponent_RemoveCommentReaction_Call) Return(_a0 error) *MockDiscussionComponent_RemoveCommentReaction_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDiscussionComponent_RemoveCommentReaction_Call) RunAndReturn(run func(context.Context, types.CommentReactionRequest) error) *MockDiscussionComponent_RemoveCommentReaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetDiscussionLabels provides a mock function with given fields: ctx, req
+func (_m *MockDiscussionComponent) SetDiscussionLabels(ctx context.Context, req types.SetDiscussionLabelsRequest) ([]types.DiscussionLabel, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetDiscussionLabels")
+	}
+
+	var r0 []types.DiscussionLabel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.SetDiscussionLabelsRequest) ([]types.DiscussionLabel, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, types.SetDiscussionLabelsRequest) []types.DiscussionLabel); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.DiscussionLabel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, types.SetDiscussionLabelsRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDiscussionComponent_SetDiscussionLabels_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetDiscussionLabels'
+type MockDiscussionComponent_SetDiscussionLabels_Call struct {
+	*mock.Call
+}
+
+// SetDiscussionLabels is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req types.SetDiscussionLabelsRequest
+func (_e *MockDiscussionComponent_Expecter) SetDiscussionLabels(ctx interface{}, req interface{}) *MockDiscussionComponent_SetDiscussionLabels_Call {
+	return &MockDiscussionComponent_SetDiscussionLabels_Call{Call: _e.mock.On("SetDiscussionLabels", ctx, req)}
+}
+
+func (_c *MockDiscussionComponent_SetDiscussionLabels_Call) Run(run func(ctx context.Context, req types.SetDiscussionLabelsRequest)) *MockDiscussionComponent_SetDiscussionLabels_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(types.SetDiscussionLabelsRequest))
+	})
+	return _c
+}
+
+func (_c *MockDiscussionComponent_SetDiscussionLabels_Call) Return(_a0 []types.DiscussionLabel, _a1 error) *MockDiscussionComponent_SetDiscussionLabels_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDiscussionComponent_SetDiscussionLabels_Call) RunAndReturn(run func(context.Context, types.SetDiscussionLabelsRequest) ([]types.DiscussionLabel, error)) *MockDiscussionComponent_SetDiscussionLabels_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TransferDiscussion provides a mock function with given fields: ctx, req
+func (_m *MockDiscussionComponent) TransferDiscussion(ctx context.Context, req types.TransferDiscussionRequest) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TransferDiscussion")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.TransferDiscussionRequest) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDiscussionComponent_TransferDiscussion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TransferDiscussion'
+type MockDiscussionComponent_TransferDiscussion_Call struct {
+	*mock.Call
+}
+
+// TransferDiscussion is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req types.TransferDiscussionRequest
+func (_e *MockDiscussionComponent_Expecter) TransferDiscussion(ctx interface{}, req interface{}) *MockDiscussionComponent_TransferDiscussion_Call {
+	return &MockDiscussionComponent_TransferDiscussion_Call{Call: _e.mock.On("TransferDiscussion", ctx, req)}
+}
+
+func (_c *MockDiscussionComponent_TransferDiscussion_Call) Run(run func(ctx context.Context, req types.TransferDiscussionRequest)) *MockDiscussionComponent_TransferDiscussion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(types.TransferDiscussionRequest))
+	})
+	return _c
+}
+
+func (_c *MockDiscussionComponent_TransferDiscussion_Call) Return(_a0 error) *MockDiscussionComponent_TransferDiscussion_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDiscussionComponent_TransferDiscussion_Call) RunAndReturn(run func(context.Context, types.TransferDiscussionRequest) error) *MockDiscussionComponent_TransferDiscussion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateComment provides a mock function with given fields: ctx, currentUser, id, content
 func (_m *MockDiscussionComponent) UpdateComment(ctx context.Context, currentUser string, id int64, content string) error {
 	ret := _m.Called(ctx, currentUser, id, content)
@@ -531,6 +990,112 @@ func (_c *MockDiscussionComponent_UpdateDiscussion_Call) RunAndReturn(run func(c
 	return _c
 }
 
+// UpdateDiscussionLabel provides a mock function with given fields: ctx, req
+func (_m *MockDiscussionComponent) UpdateDiscussionLabel(ctx context.Context, req types.DiscussionLabelRequest) (*types.DiscussionLabel, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateDiscussionLabel")
+	}
+
+	var r0 *types.DiscussionLabel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.DiscussionLabelRequest) (*types.DiscussionLabel, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, types.DiscussionLabelRequest) *types.DiscussionLabel); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.DiscussionLabel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, types.DiscussionLabelRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDiscussionComponent_UpdateDiscussionLabel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateDiscussionLabel'
+type MockDiscussionComponent_UpdateDiscussionLabel_Call struct {
+	*mock.Call
+}
+
+// UpdateDiscussionLabel is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req types.DiscussionLabelRequest
+func (_e *MockDiscussionComponent_Expecter) UpdateDiscussionLabel(ctx interface{}, req interface{}) *MockDiscussionComponent_UpdateDiscussionLabel_Call {
+	return &MockDiscussionComponent_UpdateDiscussionLabel_Call{Call: _e.mock.On("UpdateDiscussionLabel", ctx, req)}
+}
+
+func (_c *MockDiscussionComponent_UpdateDiscussionLabel_Call) Run(run func(ctx context.Context, req types.DiscussionLabelRequest)) *MockDiscussionComponent_UpdateDiscussionLabel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(types.DiscussionLabelRequest))
+	})
+	return _c
+}
+
+func (_c *MockDiscussionComponent_UpdateDiscussionLabel_Call) Return(_a0 *types.DiscussionLabel, _a1 error) *MockDiscussionComponent_UpdateDiscussionLabel_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDiscussionComponent_UpdateDiscussionLabel_Call) RunAndReturn(run func(context.Context, types.DiscussionLabelRequest) (*types.DiscussionLabel, error)) *MockDiscussionComponent_UpdateDiscussionLabel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateDiscussionStatus provides a mock function with given fields: ctx, req
+func (_m *MockDiscussionComponent) UpdateDiscussionStatus(ctx context.Context, req types.UpdateDiscussionStatusRequest) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateDiscussionStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.UpdateDiscussionStatusRequest) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDiscussionComponent_UpdateDiscussionStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateDiscussionStatus'
+type MockDiscussionComponent_UpdateDiscussionStatus_Call struct {
+	*mock.Call
+}
+
+// UpdateDiscussionStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req types.UpdateDiscussionStatusRequest
+func (_e *MockDiscussionComponent_Expecter) UpdateDiscussionStatus(ctx interface{}, req interface{}) *MockDiscussionComponent_UpdateDiscussionStatus_Call {
+	return &MockDiscussionComponent_UpdateDiscussionStatus_Call{Call: _e.mock.On("UpdateDiscussionStatus", ctx, req)}
+}
+
+func (_c *MockDiscussionComponent_UpdateDiscussionStatus_Call) Run(run func(ctx context.Context, req types.UpdateDiscussionStatusRequest)) *MockDiscussionComponent_UpdateDiscussionStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(types.UpdateDiscussionStatusRequest))
+	})
+	return _c
+}
+
+func (_c *MockDiscussionComponent_UpdateDiscussionStatus_Call) Return(_a0 error) *MockDiscussionComponent_UpdateDiscussionStatus_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDiscussionComponent_UpdateDiscussionStatus_Call) RunAndReturn(run func(context.Context, types.UpdateDiscussionStatusRequest) error) *MockDiscussionComponent_UpdateDiscussionStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockDiscussionComponent creates a new instance of MockDiscussionComponent. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockDiscussionComponent(t interface {
@@ -257,6 +257,171 @@ func (_c *MockFinetuneComponent_GetFinetuneJob_Call) RunAndReturn(run func(conte
 	return _c
 }
 
+// GetFinetunePublication provides a mock function with given fields: ctx, req
+func (_m *MockFinetuneComponent) GetFinetunePublication(ctx context.Context, req types.FinetuneLogReq) (*types.FinetunePublishRes, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFinetunePublication")
+	}
+
+	var r0 *types.FinetunePublishRes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.FinetuneLogReq) (*types.FinetunePublishRes, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, types.FinetuneLogReq) *types.FinetunePublishRes); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.FinetunePublishRes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, types.FinetuneLogReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockFinetuneComponent_GetFinetunePublication_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFinetunePublication'
+type MockFinetuneComponent_GetFinetunePublication_Call struct {
+	*mock.Call
+}
+
+// GetFinetunePublication is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req types.FinetuneLogReq
+func (_e *MockFinetuneComponent_Expecter) GetFinetunePublication(ctx interface{}, req interface{}) *MockFinetuneComponent_GetFinetunePublication_Call {
+	return &MockFinetuneComponent_GetFinetunePublication_Call{Call: _e.mock.On("GetFinetunePublication", ctx, req)}
+}
+
+func (_c *MockFinetuneComponent_GetFinetunePublication_Call) Run(run func(ctx context.Context, req types.FinetuneLogReq)) *MockFinetuneComponent_GetFinetunePublication_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(types.FinetuneLogReq))
+	})
+	return _c
+}
+
+func (_c *MockFinetuneComponent_GetFinetunePublication_Call) Return(_a0 *types.FinetunePublishRes, _a1 error) *MockFinetuneComponent_GetFinetunePublication_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockFinetuneComponent_GetFinetunePublication_Call) RunAndReturn(run func(context.Context, types.FinetuneLogReq) (*types.FinetunePublishRes, error)) *MockFinetuneComponent_GetFinetunePublication_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HandleFinetuneFinished provides a mock function with given fields: ctx, wf
+func (_m *MockFinetuneComponent) HandleFinetuneFinished(ctx context.Context, wf *database.ArgoWorkflow) error {
+	ret := _m.Called(ctx, wf)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleFinetuneFinished")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *database.ArgoWorkflow) error); ok {
+		r0 = rf(ctx, wf)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockFinetuneComponent_HandleFinetuneFinished_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleFinetuneFinished'
+type MockFinetuneComponent_HandleFinetuneFinished_Call struct {
+	*mock.Call
+}
+
+// HandleFinetuneFinished is a helper method to define mock.On call
+//   - ctx context.Context
+//   - wf *database.ArgoWorkflow
+func (_e *MockFinetuneComponent_Expecter) HandleFinetuneFinished(ctx interface{}, wf interface{}) *MockFinetuneComponent_HandleFinetuneFinished_Call {
+	return &MockFinetuneComponent_HandleFinetuneFinished_Call{Call: _e.mock.On("HandleFinetuneFinished", ctx, wf)}
+}
+
+func (_c *MockFinetuneComponent_HandleFinetuneFinished_Call) Run(run func(ctx context.Context, wf *database.ArgoWorkflow)) *MockFinetuneComponent_HandleFinetuneFinished_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*database.ArgoWorkflow))
+	})
+	return _c
+}
+
+func (_c *MockFinetuneComponent_HandleFinetuneFinished_Call) Return(_a0 error) *MockFinetuneComponent_HandleFinetuneFinished_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockFinetuneComponent_HandleFinetuneFinished_Call) RunAndReturn(run func(context.Context, *database.ArgoWorkflow) error) *MockFinetuneComponent_HandleFinetuneFinished_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListFinetuneCheckpoints provides a mock function with given fields: ctx, req
+func (_m *MockFinetuneComponent) ListFinetuneCheckpoints(ctx context.Context, req types.FinetuneLogReq) ([]string, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListFinetuneCheckpoints")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.FinetuneLogReq) ([]string, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, types.FinetuneLogReq) []string); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, types.FinetuneLogReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockFinetuneComponent_ListFinetuneCheckpoints_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListFinetuneCheckpoints'
+type MockFinetuneComponent_ListFinetuneCheckpoints_Call struct {
+	*mock.Call
+}
+
+// ListFinetuneCheckpoints is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req types.FinetuneLogReq
+func (_e *MockFinetuneComponent_Expecter) ListFinetuneCheckpoints(ctx interface{}, req interface{}) *MockFinetuneComponent_ListFinetuneCheckpoints_Call {
+	return &MockFinetuneComponent_ListFinetuneCheckpoints_Call{Call: _e.mock.On("ListFinetuneCheckpoints", ctx, req)}
+}
+
+func (_c *MockFinetuneComponent_ListFinetuneCheckpoints_Call) Run(run func(ctx context.Context, req types.FinetuneLogReq)) *MockFinetuneComponent_ListFinetuneCheckpoints_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(types.FinetuneLogReq))
+	})
+	return _c
+}
+
+func (_c *MockFinetuneComponent_ListFinetuneCheckpoints_Call) Return(_a0 []string, _a1 error) *MockFinetuneComponent_ListFinetuneCheckpoints_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockFinetuneComponent_ListFinetuneCheckpoints_Call) RunAndReturn(run func(context.Context, types.FinetuneLogReq) ([]string, error)) *MockFinetuneComponent_ListFinetuneCheckpoints_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // OrgFinetuneInstances provides a mock function with given fields: ctx, req
 func (_m *MockFinetuneComponent) OrgFinetuneInstances(ctx context.Context, req *types.OrgFinetunesReq) ([]types.DeployRequest, int, error) {
 	ret := _m.Called(ctx, req)
@@ -389,6 +554,65 @@ func (_c *MockFinetuneComponent_OrgFinetuneJobs_Call) RunAndReturn(run func(cont
 	return _c
 }
 
+// PublishFinetuneResult provides a mock function with given fields: ctx, req
+func (_m *MockFinetuneComponent) PublishFinetuneResult(ctx context.Context, req types.FinetunePublishReq) (*types.FinetunePublishRes, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublishFinetuneResult")
+	}
+
+	var r0 *types.FinetunePublishRes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.FinetunePublishReq) (*types.FinetunePublishRes, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, types.FinetunePublishReq) *types.FinetunePublishRes); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.FinetunePublishRes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, types.FinetunePublishReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockFinetuneComponent_PublishFinetuneResult_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PublishFinetuneResult'
+type MockFinetuneComponent_PublishFinetuneResult_Call struct {
+	*mock.Call
+}
+
+// PublishFinetuneResult is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req types.FinetunePublishReq
+func (_e *MockFinetuneComponent_Expecter) PublishFinetuneResult(ctx interface{}, req interface{}) *MockFinetuneComponent_PublishFinetuneResult_Call {
+	return &MockFinetuneComponent_PublishFinetuneResult_Call{Call: _e.mock.On("PublishFinetuneResult", ctx, req)}
+}
+
+func (_c *MockFinetuneComponent_PublishFinetuneResult_Call) Run(run func(ctx context.Context, req types.FinetunePublishReq)) *MockFinetuneComponent_PublishFinetuneResult_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(types.FinetunePublishReq))
+	})
+	return _c
+}
+
+func (_c *MockFinetuneComponent_PublishFinetuneResult_Call) Return(_a0 *types.FinetunePublishRes, _a1 error) *MockFinetuneComponent_PublishFinetuneResult_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockFinetuneComponent_PublishFinetuneResult_Call) RunAndReturn(run func(context.Context, types.FinetunePublishReq) (*types.FinetunePublishRes, error)) *MockFinetuneComponent_PublishFinetuneResult_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ReadJobLogsInStream provides a mock function with given fields: ctx, req
 func (_m *MockFinetuneComponent) ReadJobLogsInStream(ctx context.Context, req types.FinetuneLogReq) (*deploy.MultiLogReader, error) {
 	ret := _m.Called(ctx, req)
@@ -81,6 +81,65 @@ func (_c *MockImportComponent_GetGitlabRepos_Call) RunAndReturn(run func(context
 	return _c
 }
 
+// GetRemoteRepos provides a mock function with given fields: ctx, req
+func (_m *MockImportComponent) GetRemoteRepos(ctx context.Context, req *types.GetRemoteReposReq) ([]types.RemoteRepository, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRemoteRepos")
+	}
+
+	var r0 []types.RemoteRepository
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *types.GetRemoteReposReq) ([]types.RemoteRepository, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *types.GetRemoteReposReq) []types.RemoteRepository); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.RemoteRepository)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *types.GetRemoteReposReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockImportComponent_GetRemoteRepos_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRemoteRepos'
+type MockImportComponent_GetRemoteRepos_Call struct {
+	*mock.Call
+}
+
+// GetRemoteRepos is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *types.GetRemoteReposReq
+func (_e *MockImportComponent_Expecter) GetRemoteRepos(ctx interface{}, req interface{}) *MockImportComponent_GetRemoteRepos_Call {
+	return &MockImportComponent_GetRemoteRepos_Call{Call: _e.mock.On("GetRemoteRepos", ctx, req)}
+}
+
+func (_c *MockImportComponent_GetRemoteRepos_Call) Run(run func(ctx context.Context, req *types.GetRemoteReposReq)) *MockImportComponent_GetRemoteRepos_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*types.GetRemoteReposReq))
+	})
+	return _c
+}
+
+func (_c *MockImportComponent_GetRemoteRepos_Call) Return(_a0 []types.RemoteRepository, _a1 error) *MockImportComponent_GetRemoteRepos_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockImportComponent_GetRemoteRepos_Call) RunAndReturn(run func(context.Context, *types.GetRemoteReposReq) ([]types.RemoteRepository, error)) *MockImportComponent_GetRemoteRepos_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Import provides a mock function with given fields: ctx, req
 func (_m *MockImportComponent) Import(ctx context.Context, req types.ImportReq) error {
 	ret := _m.Called(ctx, req)
@@ -129,7 +188,7 @@ func (_c *MockImportComponent_Import_Call) RunAndReturn(run func(context.Context
 }
 
 // ImportStatus provides a mock function with given fields: ctx, req
-func (_m *MockImportComponent) ImportStatus(ctx context.Context, req types.ImportStatusReq) ([]types.ImportedRepository, error) {
+func (_m *MockImportComponent) ImportStatus(ctx context.Context, req types.ImportStatusReq) ([]types.ImportedRepository, int, error) {
 	ret := _m.Called(ctx, req)
 
 	if len(ret) == 0 {
@@ -137,8 +196,9 @@ func (_m *MockImportComponent) ImportStatus(ctx context.Context, req types.Impor
 	}
 
 	var r0 []types.ImportedRepository
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, types.ImportStatusReq) ([]types.ImportedRepository, error)); ok {
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.ImportStatusReq) ([]types.ImportedRepository, int, error)); ok {
 		return rf(ctx, req)
 	}
 	if rf, ok := ret.Get(0).(func(context.Context, types.ImportStatusReq) []types.ImportedRepository); ok {
@@ -149,13 +209,19 @@ func (_m *MockImportComponent) ImportStatus(ctx context.Context, req types.Impor
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, types.ImportStatusReq) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, types.ImportStatusReq) int); ok {
 		r1 = rf(ctx, req)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(int)
 	}
 
-	return r0, r1
+	if rf, ok := ret.Get(2).(func(context.Context, types.ImportStatusReq) error); ok {
+		r2 = rf(ctx, req)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
 // MockImportComponent_ImportStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ImportStatus'
@@ -177,12 +243,12 @@ func (_c *MockImportComponent_ImportStatus_Call) Run(run func(ctx context.Contex
 	return _c
 }
 
-func (_c *MockImportComponent_ImportStatus_Call) Return(_a0 []types.ImportedRepository, _a1 error) *MockImportComponent_ImportStatus_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *MockImportComponent_ImportStatus_Call) Return(_a0 []types.ImportedRepository, _a1 int, _a2 error) *MockImportComponent_ImportStatus_Call {
+	_c.Call.Return(_a0, _a1, _a2)
 	return _c
 }
 
-func (_c *MockImportComponent_ImportStatus_Call) RunAndReturn(run func(context.Context, types.ImportStatusReq) ([]types.ImportedRepository, error)) *MockImportComponent_ImportStatus_Call {
+func (_c *MockImportComponent_ImportStatus_Call) RunAndReturn(run func(context.Context, types.ImportStatusReq) ([]types.ImportedRepository, int, error)) *MockImportComponent_ImportStatus_Call {
 	_c.Call.Return(run)
 	return _c
 }
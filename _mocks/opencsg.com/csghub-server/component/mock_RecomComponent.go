@@ -6,6 +6,7 @@ import (
 	context "context"
 
 	mock "github.com/stretchr/testify/mock"
+	types "opencsg.com/csghub-server/common/types"
 )
 
 // MockRecomComponent is an autogenerated mock type for the RecomComponent type
@@ -68,6 +69,170 @@ func (_c *MockRecomComponent_CalculateRecomScore_Call) RunAndReturn(run func(con
 	return _c
 }
 
+// CalculateRelatedRepos provides a mock function with given fields: ctx
+func (_m *MockRecomComponent) CalculateRelatedRepos(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CalculateRelatedRepos")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRecomComponent_CalculateRelatedRepos_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CalculateRelatedRepos'
+type MockRecomComponent_CalculateRelatedRepos_Call struct {
+	*mock.Call
+}
+
+// CalculateRelatedRepos is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockRecomComponent_Expecter) CalculateRelatedRepos(ctx interface{}) *MockRecomComponent_CalculateRelatedRepos_Call {
+	return &MockRecomComponent_CalculateRelatedRepos_Call{Call: _e.mock.On("CalculateRelatedRepos", ctx)}
+}
+
+func (_c *MockRecomComponent_CalculateRelatedRepos_Call) Run(run func(ctx context.Context)) *MockRecomComponent_CalculateRelatedRepos_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockRecomComponent_CalculateRelatedRepos_Call) Return(_a0 error) *MockRecomComponent_CalculateRelatedRepos_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRecomComponent_CalculateRelatedRepos_Call) RunAndReturn(run func(context.Context) error) *MockRecomComponent_CalculateRelatedRepos_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Feed provides a mock function with given fields: ctx, req
+func (_m *MockRecomComponent) Feed(ctx context.Context, req *types.RecomFeedReq) ([]types.RecomRepo, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Feed")
+	}
+
+	var r0 []types.RecomRepo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *types.RecomFeedReq) ([]types.RecomRepo, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *types.RecomFeedReq) []types.RecomRepo); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.RecomRepo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *types.RecomFeedReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRecomComponent_Feed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Feed'
+type MockRecomComponent_Feed_Call struct {
+	*mock.Call
+}
+
+// Feed is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *types.RecomFeedReq
+func (_e *MockRecomComponent_Expecter) Feed(ctx interface{}, req interface{}) *MockRecomComponent_Feed_Call {
+	return &MockRecomComponent_Feed_Call{Call: _e.mock.On("Feed", ctx, req)}
+}
+
+func (_c *MockRecomComponent_Feed_Call) Run(run func(ctx context.Context, req *types.RecomFeedReq)) *MockRecomComponent_Feed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*types.RecomFeedReq))
+	})
+	return _c
+}
+
+func (_c *MockRecomComponent_Feed_Call) Return(_a0 []types.RecomRepo, _a1 error) *MockRecomComponent_Feed_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRecomComponent_Feed_Call) RunAndReturn(run func(context.Context, *types.RecomFeedReq) ([]types.RecomRepo, error)) *MockRecomComponent_Feed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RelatedRepos provides a mock function with given fields: ctx, req
+func (_m *MockRecomComponent) RelatedRepos(ctx context.Context, req *types.RelatedReposReq) ([]types.RecomRepo, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RelatedRepos")
+	}
+
+	var r0 []types.RecomRepo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *types.RelatedReposReq) ([]types.RecomRepo, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *types.RelatedReposReq) []types.RecomRepo); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.RecomRepo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *types.RelatedReposReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRecomComponent_RelatedRepos_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RelatedRepos'
+type MockRecomComponent_RelatedRepos_Call struct {
+	*mock.Call
+}
+
+// RelatedRepos is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *types.RelatedReposReq
+func (_e *MockRecomComponent_Expecter) RelatedRepos(ctx interface{}, req interface{}) *MockRecomComponent_RelatedRepos_Call {
+	return &MockRecomComponent_RelatedRepos_Call{Call: _e.mock.On("RelatedRepos", ctx, req)}
+}
+
+func (_c *MockRecomComponent_RelatedRepos_Call) Run(run func(ctx context.Context, req *types.RelatedReposReq)) *MockRecomComponent_RelatedRepos_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*types.RelatedReposReq))
+	})
+	return _c
+}
+
+func (_c *MockRecomComponent_RelatedRepos_Call) Return(_a0 []types.RecomRepo, _a1 error) *MockRecomComponent_RelatedRepos_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRecomComponent_RelatedRepos_Call) RunAndReturn(run func(context.Context, *types.RelatedReposReq) ([]types.RecomRepo, error)) *MockRecomComponent_RelatedRepos_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SetOpWeight provides a mock function with given fields: ctx, repoID, weight
 func (_m *MockRecomComponent) SetOpWeight(ctx context.Context, repoID int64, weight int64) error {
 	ret := _m.Called(ctx, repoID, weight)
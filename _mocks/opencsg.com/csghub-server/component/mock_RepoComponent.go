@@ -981,22 +981,82 @@ func (_c *MockRepoComponent_CheckDeployPermissionForUser_Call) RunAndReturn(run
 	return _c
 }
 
+// CheckGatedAccess provides a mock function with given fields: ctx, repo, username
+func (_m *MockRepoComponent) CheckGatedAccess(ctx context.Context, repo *database.Repository, username string) error {
+	ret := _m.Called(ctx, repo, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckGatedAccess")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *database.Repository, string) error); ok {
+		r0 = rf(ctx, repo, username)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepoComponent_CheckGatedAccess_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckGatedAccess'
+type MockRepoComponent_CheckGatedAccess_Call struct {
+	*mock.Call
+}
+
+// CheckGatedAccess is a helper method to define mock.On call
+//   - ctx context.Context
+//   - repo *database.Repository
+//   - username string
+func (_e *MockRepoComponent_Expecter) CheckGatedAccess(ctx interface{}, repo interface{}, username interface{}) *MockRepoComponent_CheckGatedAccess_Call {
+	return &MockRepoComponent_CheckGatedAccess_Call{Call: _e.mock.On("CheckGatedAccess", ctx, repo, username)}
+}
+
+func (_c *MockRepoComponent_CheckGatedAccess_Call) Run(run func(ctx context.Context, repo *database.Repository, username string)) *MockRepoComponent_CheckGatedAccess_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*database.Repository), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepoComponent_CheckGatedAccess_Call) Return(_a0 error) *MockRepoComponent_CheckGatedAccess_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepoComponent_CheckGatedAccess_Call) RunAndReturn(run func(context.Context, *database.Repository, string) error) *MockRepoComponent_CheckGatedAccess_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CommitFiles provides a mock function with given fields: ctx, req
-func (_m *MockRepoComponent) CommitFiles(ctx context.Context, req types.CommitFilesReq) error {
+func (_m *MockRepoComponent) CommitFiles(ctx context.Context, req types.CommitFilesReq) (*types.CommitFilesResp, error) {
 	ret := _m.Called(ctx, req)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CommitFiles")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, types.CommitFilesReq) error); ok {
+	var r0 *types.CommitFilesResp
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.CommitFilesReq) (*types.CommitFilesResp, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, types.CommitFilesReq) *types.CommitFilesResp); ok {
 		r0 = rf(ctx, req)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.CommitFilesResp)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, types.CommitFilesReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // MockRepoComponent_CommitFiles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CommitFiles'
@@ -1018,12 +1078,12 @@ func (_c *MockRepoComponent_CommitFiles_Call) Run(run func(ctx context.Context,
 	return _c
 }
 
-func (_c *MockRepoComponent_CommitFiles_Call) Return(_a0 error) *MockRepoComponent_CommitFiles_Call {
-	_c.Call.Return(_a0)
+func (_c *MockRepoComponent_CommitFiles_Call) Return(_a0 *types.CommitFilesResp, _a1 error) *MockRepoComponent_CommitFiles_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockRepoComponent_CommitFiles_Call) RunAndReturn(run func(context.Context, types.CommitFilesReq) error) *MockRepoComponent_CommitFiles_Call {
+func (_c *MockRepoComponent_CommitFiles_Call) RunAndReturn(run func(context.Context, types.CommitFilesReq) (*types.CommitFilesResp, error)) *MockRepoComponent_CommitFiles_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -1096,6 +1156,112 @@ func (_c *MockRepoComponent_Commits_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// CreateAccessRequest provides a mock function with given fields: ctx, req
+func (_m *MockRepoComponent) CreateAccessRequest(ctx context.Context, req *types.CreateRepoAccessRequestReq) (*types.RepoAccessRequest, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateAccessRequest")
+	}
+
+	var r0 *types.RepoAccessRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *types.CreateRepoAccessRequestReq) (*types.RepoAccessRequest, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *types.CreateRepoAccessRequestReq) *types.RepoAccessRequest); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.RepoAccessRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *types.CreateRepoAccessRequestReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepoComponent_CreateAccessRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAccessRequest'
+type MockRepoComponent_CreateAccessRequest_Call struct {
+	*mock.Call
+}
+
+// CreateAccessRequest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *types.CreateRepoAccessRequestReq
+func (_e *MockRepoComponent_Expecter) CreateAccessRequest(ctx interface{}, req interface{}) *MockRepoComponent_CreateAccessRequest_Call {
+	return &MockRepoComponent_CreateAccessRequest_Call{Call: _e.mock.On("CreateAccessRequest", ctx, req)}
+}
+
+func (_c *MockRepoComponent_CreateAccessRequest_Call) Run(run func(ctx context.Context, req *types.CreateRepoAccessRequestReq)) *MockRepoComponent_CreateAccessRequest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*types.CreateRepoAccessRequestReq))
+	})
+	return _c
+}
+
+func (_c *MockRepoComponent_CreateAccessRequest_Call) Return(_a0 *types.RepoAccessRequest, _a1 error) *MockRepoComponent_CreateAccessRequest_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepoComponent_CreateAccessRequest_Call) RunAndReturn(run func(context.Context, *types.CreateRepoAccessRequestReq) (*types.RepoAccessRequest, error)) *MockRepoComponent_CreateAccessRequest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateBranch provides a mock function with given fields: ctx, req
+func (_m *MockRepoComponent) CreateBranch(ctx context.Context, req types.CreateBranchReq) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateBranch")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.CreateBranchReq) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepoComponent_CreateBranch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateBranch'
+type MockRepoComponent_CreateBranch_Call struct {
+	*mock.Call
+}
+
+// CreateBranch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req types.CreateBranchReq
+func (_e *MockRepoComponent_Expecter) CreateBranch(ctx interface{}, req interface{}) *MockRepoComponent_CreateBranch_Call {
+	return &MockRepoComponent_CreateBranch_Call{Call: _e.mock.On("CreateBranch", ctx, req)}
+}
+
+func (_c *MockRepoComponent_CreateBranch_Call) Run(run func(ctx context.Context, req types.CreateBranchReq)) *MockRepoComponent_CreateBranch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(types.CreateBranchReq))
+	})
+	return _c
+}
+
+func (_c *MockRepoComponent_CreateBranch_Call) Return(_a0 error) *MockRepoComponent_CreateBranch_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepoComponent_CreateBranch_Call) RunAndReturn(run func(context.Context, types.CreateBranchReq) error) *MockRepoComponent_CreateBranch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateFile provides a mock function with given fields: ctx, req
 func (_m *MockRepoComponent) CreateFile(ctx context.Context, req *types.CreateFileReq) (*types.CreateFileResp, error) {
 	ret := _m.Called(ctx, req)
@@ -1214,6 +1380,112 @@ func (_c *MockRepoComponent_CreateFork_Call) RunAndReturn(run func(context.Conte
 	return _c
 }
 
+// CreateGitTag provides a mock function with given fields: ctx, req
+func (_m *MockRepoComponent) CreateGitTag(ctx context.Context, req types.CreateGitTagReq) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateGitTag")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.CreateGitTagReq) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepoComponent_CreateGitTag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateGitTag'
+type MockRepoComponent_CreateGitTag_Call struct {
+	*mock.Call
+}
+
+// CreateGitTag is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req types.CreateGitTagReq
+func (_e *MockRepoComponent_Expecter) CreateGitTag(ctx interface{}, req interface{}) *MockRepoComponent_CreateGitTag_Call {
+	return &MockRepoComponent_CreateGitTag_Call{Call: _e.mock.On("CreateGitTag", ctx, req)}
+}
+
+func (_c *MockRepoComponent_CreateGitTag_Call) Run(run func(ctx context.Context, req types.CreateGitTagReq)) *MockRepoComponent_CreateGitTag_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(types.CreateGitTagReq))
+	})
+	return _c
+}
+
+func (_c *MockRepoComponent_CreateGitTag_Call) Return(_a0 error) *MockRepoComponent_CreateGitTag_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepoComponent_CreateGitTag_Call) RunAndReturn(run func(context.Context, types.CreateGitTagReq) error) *MockRepoComponent_CreateGitTag_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateRelease provides a mock function with given fields: ctx, req
+func (_m *MockRepoComponent) CreateRelease(ctx context.Context, req *types.CreateReleaseReq) (*types.Release, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRelease")
+	}
+
+	var r0 *types.Release
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *types.CreateReleaseReq) (*types.Release, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *types.CreateReleaseReq) *types.Release); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Release)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *types.CreateReleaseReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepoComponent_CreateRelease_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateRelease'
+type MockRepoComponent_CreateRelease_Call struct {
+	*mock.Call
+}
+
+// CreateRelease is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *types.CreateReleaseReq
+func (_e *MockRepoComponent_Expecter) CreateRelease(ctx interface{}, req interface{}) *MockRepoComponent_CreateRelease_Call {
+	return &MockRepoComponent_CreateRelease_Call{Call: _e.mock.On("CreateRelease", ctx, req)}
+}
+
+func (_c *MockRepoComponent_CreateRelease_Call) Run(run func(ctx context.Context, req *types.CreateReleaseReq)) *MockRepoComponent_CreateRelease_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*types.CreateReleaseReq))
+	})
+	return _c
+}
+
+func (_c *MockRepoComponent_CreateRelease_Call) Return(_a0 *types.Release, _a1 error) *MockRepoComponent_CreateRelease_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepoComponent_CreateRelease_Call) RunAndReturn(run func(context.Context, *types.CreateReleaseReq) (*types.Release, error)) *MockRepoComponent_CreateRelease_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateRepo provides a mock function with given fields: ctx, req
 func (_m *MockRepoComponent) CreateRepo(ctx context.Context, req types.CreateRepoReq) (*gitserver.CreateRepoResp, *database.Repository, *gitserver.CommitFilesReq, error) {
 	ret := _m.Called(ctx, req)
@@ -1350,6 +1622,53 @@ func (_c *MockRepoComponent_CreateRuntimeFramework_Call) RunAndReturn(run func(c
 	return _c
 }
 
+// DeleteBranch provides a mock function with given fields: ctx, req
+func (_m *MockRepoComponent) DeleteBranch(ctx context.Context, req types.DeleteBranchReq) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteBranch")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.DeleteBranchReq) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepoComponent_DeleteBranch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteBranch'
+type MockRepoComponent_DeleteBranch_Call struct {
+	*mock.Call
+}
+
+// DeleteBranch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req types.DeleteBranchReq
+func (_e *MockRepoComponent_Expecter) DeleteBranch(ctx interface{}, req interface{}) *MockRepoComponent_DeleteBranch_Call {
+	return &MockRepoComponent_DeleteBranch_Call{Call: _e.mock.On("DeleteBranch", ctx, req)}
+}
+
+func (_c *MockRepoComponent_DeleteBranch_Call) Run(run func(ctx context.Context, req types.DeleteBranchReq)) *MockRepoComponent_DeleteBranch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(types.DeleteBranchReq))
+	})
+	return _c
+}
+
+func (_c *MockRepoComponent_DeleteBranch_Call) Return(_a0 error) *MockRepoComponent_DeleteBranch_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepoComponent_DeleteBranch_Call) RunAndReturn(run func(context.Context, types.DeleteBranchReq) error) *MockRepoComponent_DeleteBranch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DeleteDeploy provides a mock function with given fields: ctx, delReq
 func (_m *MockRepoComponent) DeleteDeploy(ctx context.Context, delReq types.DeployActReq) error {
 	ret := _m.Called(ctx, delReq)
@@ -1456,6 +1775,53 @@ func (_c *MockRepoComponent_DeleteFile_Call) RunAndReturn(run func(context.Conte
 	return _c
 }
 
+// DeleteGitTag provides a mock function with given fields: ctx, req
+func (_m *MockRepoComponent) DeleteGitTag(ctx context.Context, req types.DeleteGitTagReq) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteGitTag")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.DeleteGitTagReq) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepoComponent_DeleteGitTag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteGitTag'
+type MockRepoComponent_DeleteGitTag_Call struct {
+	*mock.Call
+}
+
+// DeleteGitTag is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req types.DeleteGitTagReq
+func (_e *MockRepoComponent_Expecter) DeleteGitTag(ctx interface{}, req interface{}) *MockRepoComponent_DeleteGitTag_Call {
+	return &MockRepoComponent_DeleteGitTag_Call{Call: _e.mock.On("DeleteGitTag", ctx, req)}
+}
+
+func (_c *MockRepoComponent_DeleteGitTag_Call) Run(run func(ctx context.Context, req types.DeleteGitTagReq)) *MockRepoComponent_DeleteGitTag_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(types.DeleteGitTagReq))
+	})
+	return _c
+}
+
+func (_c *MockRepoComponent_DeleteGitTag_Call) Return(_a0 error) *MockRepoComponent_DeleteGitTag_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepoComponent_DeleteGitTag_Call) RunAndReturn(run func(context.Context, types.DeleteGitTagReq) error) *MockRepoComponent_DeleteGitTag_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DeletePendingDeletion provides a mock function with given fields: ctx
 func (_m *MockRepoComponent) DeletePendingDeletion(ctx context.Context) error {
 	ret := _m.Called(ctx)
@@ -1502,6 +1868,53 @@ func (_c *MockRepoComponent_DeletePendingDeletion_Call) RunAndReturn(run func(co
 	return _c
 }
 
+// DeleteRelease provides a mock function with given fields: ctx, req
+func (_m *MockRepoComponent) DeleteRelease(ctx context.Context, req *types.DeleteReleaseReq) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRelease")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *types.DeleteReleaseReq) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepoComponent_DeleteRelease_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteRelease'
+type MockRepoComponent_DeleteRelease_Call struct {
+	*mock.Call
+}
+
+// DeleteRelease is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *types.DeleteReleaseReq
+func (_e *MockRepoComponent_Expecter) DeleteRelease(ctx interface{}, req interface{}) *MockRepoComponent_DeleteRelease_Call {
+	return &MockRepoComponent_DeleteRelease_Call{Call: _e.mock.On("DeleteRelease", ctx, req)}
+}
+
+func (_c *MockRepoComponent_DeleteRelease_Call) Run(run func(ctx context.Context, req *types.DeleteReleaseReq)) *MockRepoComponent_DeleteRelease_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*types.DeleteReleaseReq))
+	})
+	return _c
+}
+
+func (_c *MockRepoComponent_DeleteRelease_Call) Return(_a0 error) *MockRepoComponent_DeleteRelease_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepoComponent_DeleteRelease_Call) RunAndReturn(run func(context.Context, *types.DeleteReleaseReq) error) *MockRepoComponent_DeleteRelease_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DeleteRepo provides a mock function with given fields: ctx, req
 func (_m *MockRepoComponent) DeleteRepo(ctx context.Context, req types.DeleteRepoReq) (*database.Repository, error) {
 	ret := _m.Called(ctx, req)
@@ -1963,53 +2376,112 @@ type MockRepoComponent_DeployUpdate_Call struct {
 	*mock.Call
 }
 
-// DeployUpdate is a helper method to define mock.On call
+// DeployUpdate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - updateReq types.DeployActReq
+//   - req *types.DeployUpdateReq
+func (_e *MockRepoComponent_Expecter) DeployUpdate(ctx interface{}, updateReq interface{}, req interface{}) *MockRepoComponent_DeployUpdate_Call {
+	return &MockRepoComponent_DeployUpdate_Call{Call: _e.mock.On("DeployUpdate", ctx, updateReq, req)}
+}
+
+func (_c *MockRepoComponent_DeployUpdate_Call) Run(run func(ctx context.Context, updateReq types.DeployActReq, req *types.DeployUpdateReq)) *MockRepoComponent_DeployUpdate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(types.DeployActReq), args[2].(*types.DeployUpdateReq))
+	})
+	return _c
+}
+
+func (_c *MockRepoComponent_DeployUpdate_Call) Return(_a0 error) *MockRepoComponent_DeployUpdate_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepoComponent_DeployUpdate_Call) RunAndReturn(run func(context.Context, types.DeployActReq, *types.DeployUpdateReq) error) *MockRepoComponent_DeployUpdate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DiffBetweenTwoCommits provides a mock function with given fields: ctx, req
+func (_m *MockRepoComponent) DiffBetweenTwoCommits(ctx context.Context, req types.GetDiffBetweenCommitsReq) ([]types.GiteaCallbackPushReq_Commit, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DiffBetweenTwoCommits")
+	}
+
+	var r0 []types.GiteaCallbackPushReq_Commit
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.GetDiffBetweenCommitsReq) ([]types.GiteaCallbackPushReq_Commit, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, types.GetDiffBetweenCommitsReq) []types.GiteaCallbackPushReq_Commit); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.GiteaCallbackPushReq_Commit)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, types.GetDiffBetweenCommitsReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepoComponent_DiffBetweenTwoCommits_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DiffBetweenTwoCommits'
+type MockRepoComponent_DiffBetweenTwoCommits_Call struct {
+	*mock.Call
+}
+
+// DiffBetweenTwoCommits is a helper method to define mock.On call
 //   - ctx context.Context
-//   - updateReq types.DeployActReq
-//   - req *types.DeployUpdateReq
-func (_e *MockRepoComponent_Expecter) DeployUpdate(ctx interface{}, updateReq interface{}, req interface{}) *MockRepoComponent_DeployUpdate_Call {
-	return &MockRepoComponent_DeployUpdate_Call{Call: _e.mock.On("DeployUpdate", ctx, updateReq, req)}
+//   - req types.GetDiffBetweenCommitsReq
+func (_e *MockRepoComponent_Expecter) DiffBetweenTwoCommits(ctx interface{}, req interface{}) *MockRepoComponent_DiffBetweenTwoCommits_Call {
+	return &MockRepoComponent_DiffBetweenTwoCommits_Call{Call: _e.mock.On("DiffBetweenTwoCommits", ctx, req)}
 }
 
-func (_c *MockRepoComponent_DeployUpdate_Call) Run(run func(ctx context.Context, updateReq types.DeployActReq, req *types.DeployUpdateReq)) *MockRepoComponent_DeployUpdate_Call {
+func (_c *MockRepoComponent_DiffBetweenTwoCommits_Call) Run(run func(ctx context.Context, req types.GetDiffBetweenCommitsReq)) *MockRepoComponent_DiffBetweenTwoCommits_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(types.DeployActReq), args[2].(*types.DeployUpdateReq))
+		run(args[0].(context.Context), args[1].(types.GetDiffBetweenCommitsReq))
 	})
 	return _c
 }
 
-func (_c *MockRepoComponent_DeployUpdate_Call) Return(_a0 error) *MockRepoComponent_DeployUpdate_Call {
-	_c.Call.Return(_a0)
+func (_c *MockRepoComponent_DiffBetweenTwoCommits_Call) Return(_a0 []types.GiteaCallbackPushReq_Commit, _a1 error) *MockRepoComponent_DiffBetweenTwoCommits_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockRepoComponent_DeployUpdate_Call) RunAndReturn(run func(context.Context, types.DeployActReq, *types.DeployUpdateReq) error) *MockRepoComponent_DeployUpdate_Call {
+func (_c *MockRepoComponent_DiffBetweenTwoCommits_Call) RunAndReturn(run func(context.Context, types.GetDiffBetweenCommitsReq) ([]types.GiteaCallbackPushReq_Commit, error)) *MockRepoComponent_DiffBetweenTwoCommits_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DiffBetweenTwoCommits provides a mock function with given fields: ctx, req
-func (_m *MockRepoComponent) DiffBetweenTwoCommits(ctx context.Context, req types.GetDiffBetweenCommitsReq) ([]types.GiteaCallbackPushReq_Commit, error) {
+// DownloadArchive provides a mock function with given fields: ctx, req
+func (_m *MockRepoComponent) DownloadArchive(ctx context.Context, req *types.DownloadArchiveReq) (io.ReadCloser, error) {
 	ret := _m.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DiffBetweenTwoCommits")
+		panic("no return value specified for DownloadArchive")
 	}
 
-	var r0 []types.GiteaCallbackPushReq_Commit
+	var r0 io.ReadCloser
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, types.GetDiffBetweenCommitsReq) ([]types.GiteaCallbackPushReq_Commit, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *types.DownloadArchiveReq) (io.ReadCloser, error)); ok {
 		return rf(ctx, req)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, types.GetDiffBetweenCommitsReq) []types.GiteaCallbackPushReq_Commit); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *types.DownloadArchiveReq) io.ReadCloser); ok {
 		r0 = rf(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]types.GiteaCallbackPushReq_Commit)
+			r0 = ret.Get(0).(io.ReadCloser)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, types.GetDiffBetweenCommitsReq) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *types.DownloadArchiveReq) error); ok {
 		r1 = rf(ctx, req)
 	} else {
 		r1 = ret.Error(1)
@@ -2018,31 +2490,31 @@ func (_m *MockRepoComponent) DiffBetweenTwoCommits(ctx context.Context, req type
 	return r0, r1
 }
 
-// MockRepoComponent_DiffBetweenTwoCommits_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DiffBetweenTwoCommits'
-type MockRepoComponent_DiffBetweenTwoCommits_Call struct {
+// MockRepoComponent_DownloadArchive_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DownloadArchive'
+type MockRepoComponent_DownloadArchive_Call struct {
 	*mock.Call
 }
 
-// DiffBetweenTwoCommits is a helper method to define mock.On call
+// DownloadArchive is a helper method to define mock.On call
 //   - ctx context.Context
-//   - req types.GetDiffBetweenCommitsReq
-func (_e *MockRepoComponent_Expecter) DiffBetweenTwoCommits(ctx interface{}, req interface{}) *MockRepoComponent_DiffBetweenTwoCommits_Call {
-	return &MockRepoComponent_DiffBetweenTwoCommits_Call{Call: _e.mock.On("DiffBetweenTwoCommits", ctx, req)}
+//   - req *types.DownloadArchiveReq
+func (_e *MockRepoComponent_Expecter) DownloadArchive(ctx interface{}, req interface{}) *MockRepoComponent_DownloadArchive_Call {
+	return &MockRepoComponent_DownloadArchive_Call{Call: _e.mock.On("DownloadArchive", ctx, req)}
 }
 
-func (_c *MockRepoComponent_DiffBetweenTwoCommits_Call) Run(run func(ctx context.Context, req types.GetDiffBetweenCommitsReq)) *MockRepoComponent_DiffBetweenTwoCommits_Call {
+func (_c *MockRepoComponent_DownloadArchive_Call) Run(run func(ctx context.Context, req *types.DownloadArchiveReq)) *MockRepoComponent_DownloadArchive_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(types.GetDiffBetweenCommitsReq))
+		run(args[0].(context.Context), args[1].(*types.DownloadArchiveReq))
 	})
 	return _c
 }
 
-func (_c *MockRepoComponent_DiffBetweenTwoCommits_Call) Return(_a0 []types.GiteaCallbackPushReq_Commit, _a1 error) *MockRepoComponent_DiffBetweenTwoCommits_Call {
+func (_c *MockRepoComponent_DownloadArchive_Call) Return(_a0 io.ReadCloser, _a1 error) *MockRepoComponent_DownloadArchive_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockRepoComponent_DiffBetweenTwoCommits_Call) RunAndReturn(run func(context.Context, types.GetDiffBetweenCommitsReq) ([]types.GiteaCallbackPushReq_Commit, error)) *MockRepoComponent_DiffBetweenTwoCommits_Call {
+func (_c *MockRepoComponent_DownloadArchive_Call) RunAndReturn(run func(context.Context, *types.DownloadArchiveReq) (io.ReadCloser, error)) *MockRepoComponent_DownloadArchive_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -2400,6 +2872,183 @@ func (_c *MockRepoComponent_GenerateEndpoint_Call) RunAndReturn(run func(context
 	return _c
 }
 
+// GetAccessPolicy provides a mock function with given fields: ctx, req
+func (_m *MockRepoComponent) GetAccessPolicy(ctx context.Context, req *types.GetRepoAccessPolicyReq) (*types.RepoAccessPolicy, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccessPolicy")
+	}
+
+	var r0 *types.RepoAccessPolicy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *types.GetRepoAccessPolicyReq) (*types.RepoAccessPolicy, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *types.GetRepoAccessPolicyReq) *types.RepoAccessPolicy); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.RepoAccessPolicy)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *types.GetRepoAccessPolicyReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepoComponent_GetAccessPolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAccessPolicy'
+type MockRepoComponent_GetAccessPolicy_Call struct {
+	*mock.Call
+}
+
+// GetAccessPolicy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *types.GetRepoAccessPolicyReq
+func (_e *MockRepoComponent_Expecter) GetAccessPolicy(ctx interface{}, req interface{}) *MockRepoComponent_GetAccessPolicy_Call {
+	return &MockRepoComponent_GetAccessPolicy_Call{Call: _e.mock.On("GetAccessPolicy", ctx, req)}
+}
+
+func (_c *MockRepoComponent_GetAccessPolicy_Call) Run(run func(ctx context.Context, req *types.GetRepoAccessPolicyReq)) *MockRepoComponent_GetAccessPolicy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*types.GetRepoAccessPolicyReq))
+	})
+	return _c
+}
+
+func (_c *MockRepoComponent_GetAccessPolicy_Call) Return(_a0 *types.RepoAccessPolicy, _a1 error) *MockRepoComponent_GetAccessPolicy_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepoComponent_GetAccessPolicy_Call) RunAndReturn(run func(context.Context, *types.GetRepoAccessPolicyReq) (*types.RepoAccessPolicy, error)) *MockRepoComponent_GetAccessPolicy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAccessRequest provides a mock function with given fields: ctx, req
+func (_m *MockRepoComponent) GetAccessRequest(ctx context.Context, req *types.GetRepoAccessRequestReq) (*types.RepoAccessRequest, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccessRequest")
+	}
+
+	var r0 *types.RepoAccessRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *types.GetRepoAccessRequestReq) (*types.RepoAccessRequest, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *types.GetRepoAccessRequestReq) *types.RepoAccessRequest); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.RepoAccessRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *types.GetRepoAccessRequestReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepoComponent_GetAccessRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAccessRequest'
+type MockRepoComponent_GetAccessRequest_Call struct {
+	*mock.Call
+}
+
+// GetAccessRequest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *types.GetRepoAccessRequestReq
+func (_e *MockRepoComponent_Expecter) GetAccessRequest(ctx interface{}, req interface{}) *MockRepoComponent_GetAccessRequest_Call {
+	return &MockRepoComponent_GetAccessRequest_Call{Call: _e.mock.On("GetAccessRequest", ctx, req)}
+}
+
+func (_c *MockRepoComponent_GetAccessRequest_Call) Run(run func(ctx context.Context, req *types.GetRepoAccessRequestReq)) *MockRepoComponent_GetAccessRequest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*types.GetRepoAccessRequestReq))
+	})
+	return _c
+}
+
+func (_c *MockRepoComponent_GetAccessRequest_Call) Return(_a0 *types.RepoAccessRequest, _a1 error) *MockRepoComponent_GetAccessRequest_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepoComponent_GetAccessRequest_Call) RunAndReturn(run func(context.Context, *types.GetRepoAccessRequestReq) (*types.RepoAccessRequest, error)) *MockRepoComponent_GetAccessRequest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCard provides a mock function with given fields: ctx, req
+func (_m *MockRepoComponent) GetCard(ctx context.Context, req *types.GetRepoCardReq) (*types.RepoCard, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCard")
+	}
+
+	var r0 *types.RepoCard
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *types.GetRepoCardReq) (*types.RepoCard, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *types.GetRepoCardReq) *types.RepoCard); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.RepoCard)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *types.GetRepoCardReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepoComponent_GetCard_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCard'
+type MockRepoComponent_GetCard_Call struct {
+	*mock.Call
+}
+
+// GetCard is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *types.GetRepoCardReq
+func (_e *MockRepoComponent_Expecter) GetCard(ctx interface{}, req interface{}) *MockRepoComponent_GetCard_Call {
+	return &MockRepoComponent_GetCard_Call{Call: _e.mock.On("GetCard", ctx, req)}
+}
+
+func (_c *MockRepoComponent_GetCard_Call) Run(run func(ctx context.Context, req *types.GetRepoCardReq)) *MockRepoComponent_GetCard_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*types.GetRepoCardReq))
+	})
+	return _c
+}
+
+func (_c *MockRepoComponent_GetCard_Call) Return(_a0 *types.RepoCard, _a1 error) *MockRepoComponent_GetCard_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepoComponent_GetCard_Call) RunAndReturn(run func(context.Context, *types.GetRepoCardReq) (*types.RepoCard, error)) *MockRepoComponent_GetCard_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetCommitWithDiff provides a mock function with given fields: ctx, req
 func (_m *MockRepoComponent) GetCommitWithDiff(ctx context.Context, req *types.GetCommitsReq) (*types.CommitResponse, error) {
 	ret := _m.Called(ctx, req)
@@ -2680,6 +3329,65 @@ func (_c *MockRepoComponent_GetNamespaceBillingUUID_Call) RunAndReturn(run func(
 	return _c
 }
 
+// GetRelease provides a mock function with given fields: ctx, req
+func (_m *MockRepoComponent) GetRelease(ctx context.Context, req *types.GetReleaseReq) (*types.Release, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRelease")
+	}
+
+	var r0 *types.Release
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *types.GetReleaseReq) (*types.Release, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *types.GetReleaseReq) *types.Release); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Release)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *types.GetReleaseReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepoComponent_GetRelease_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRelease'
+type MockRepoComponent_GetRelease_Call struct {
+	*mock.Call
+}
+
+// GetRelease is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *types.GetReleaseReq
+func (_e *MockRepoComponent_Expecter) GetRelease(ctx interface{}, req interface{}) *MockRepoComponent_GetRelease_Call {
+	return &MockRepoComponent_GetRelease_Call{Call: _e.mock.On("GetRelease", ctx, req)}
+}
+
+func (_c *MockRepoComponent_GetRelease_Call) Run(run func(ctx context.Context, req *types.GetReleaseReq)) *MockRepoComponent_GetRelease_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*types.GetReleaseReq))
+	})
+	return _c
+}
+
+func (_c *MockRepoComponent_GetRelease_Call) Return(_a0 *types.Release, _a1 error) *MockRepoComponent_GetRelease_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepoComponent_GetRelease_Call) RunAndReturn(run func(context.Context, *types.GetReleaseReq) (*types.Release, error)) *MockRepoComponent_GetRelease_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetRepoSizeByBranch provides a mock function with given fields: ctx, repoType, namespace, name, branch, currentUser
 func (_m *MockRepoComponent) GetRepoSizeByBranch(ctx context.Context, repoType types.RepositoryType, namespace string, name string, branch string, currentUser string) (types.RepoSizeResponse, error) {
 	ret := _m.Called(ctx, repoType, namespace, name, branch, currentUser)
@@ -2815,16 +3523,76 @@ func (_m *MockRepoComponent) GetUserRepoPermission(ctx context.Context, userName
 	if rf, ok := ret.Get(0).(func(context.Context, string, *database.Repository) (*types.UserRepoPermission, error)); ok {
 		return rf(ctx, userName, repo)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, *database.Repository) *types.UserRepoPermission); ok {
-		r0 = rf(ctx, userName, repo)
+	if rf, ok := ret.Get(0).(func(context.Context, string, *database.Repository) *types.UserRepoPermission); ok {
+		r0 = rf(ctx, userName, repo)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.UserRepoPermission)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *database.Repository) error); ok {
+		r1 = rf(ctx, userName, repo)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepoComponent_GetUserRepoPermission_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserRepoPermission'
+type MockRepoComponent_GetUserRepoPermission_Call struct {
+	*mock.Call
+}
+
+// GetUserRepoPermission is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userName string
+//   - repo *database.Repository
+func (_e *MockRepoComponent_Expecter) GetUserRepoPermission(ctx interface{}, userName interface{}, repo interface{}) *MockRepoComponent_GetUserRepoPermission_Call {
+	return &MockRepoComponent_GetUserRepoPermission_Call{Call: _e.mock.On("GetUserRepoPermission", ctx, userName, repo)}
+}
+
+func (_c *MockRepoComponent_GetUserRepoPermission_Call) Run(run func(ctx context.Context, userName string, repo *database.Repository)) *MockRepoComponent_GetUserRepoPermission_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*database.Repository))
+	})
+	return _c
+}
+
+func (_c *MockRepoComponent_GetUserRepoPermission_Call) Return(_a0 *types.UserRepoPermission, _a1 error) *MockRepoComponent_GetUserRepoPermission_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepoComponent_GetUserRepoPermission_Call) RunAndReturn(run func(context.Context, string, *database.Repository) (*types.UserRepoPermission, error)) *MockRepoComponent_GetUserRepoPermission_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GitTags provides a mock function with given fields: ctx, req
+func (_m *MockRepoComponent) GitTags(ctx context.Context, req *types.GetTagsReq) ([]types.Tag, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GitTags")
+	}
+
+	var r0 []types.Tag
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *types.GetTagsReq) ([]types.Tag, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *types.GetTagsReq) []types.Tag); ok {
+		r0 = rf(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*types.UserRepoPermission)
+			r0 = ret.Get(0).([]types.Tag)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, *database.Repository) error); ok {
-		r1 = rf(ctx, userName, repo)
+	if rf, ok := ret.Get(1).(func(context.Context, *types.GetTagsReq) error); ok {
+		r1 = rf(ctx, req)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -2832,32 +3600,31 @@ func (_m *MockRepoComponent) GetUserRepoPermission(ctx context.Context, userName
 	return r0, r1
 }
 
-// MockRepoComponent_GetUserRepoPermission_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserRepoPermission'
-type MockRepoComponent_GetUserRepoPermission_Call struct {
+// MockRepoComponent_GitTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GitTags'
+type MockRepoComponent_GitTags_Call struct {
 	*mock.Call
 }
 
-// GetUserRepoPermission is a helper method to define mock.On call
+// GitTags is a helper method to define mock.On call
 //   - ctx context.Context
-//   - userName string
-//   - repo *database.Repository
-func (_e *MockRepoComponent_Expecter) GetUserRepoPermission(ctx interface{}, userName interface{}, repo interface{}) *MockRepoComponent_GetUserRepoPermission_Call {
-	return &MockRepoComponent_GetUserRepoPermission_Call{Call: _e.mock.On("GetUserRepoPermission", ctx, userName, repo)}
+//   - req *types.GetTagsReq
+func (_e *MockRepoComponent_Expecter) GitTags(ctx interface{}, req interface{}) *MockRepoComponent_GitTags_Call {
+	return &MockRepoComponent_GitTags_Call{Call: _e.mock.On("GitTags", ctx, req)}
 }
 
-func (_c *MockRepoComponent_GetUserRepoPermission_Call) Run(run func(ctx context.Context, userName string, repo *database.Repository)) *MockRepoComponent_GetUserRepoPermission_Call {
+func (_c *MockRepoComponent_GitTags_Call) Run(run func(ctx context.Context, req *types.GetTagsReq)) *MockRepoComponent_GitTags_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(*database.Repository))
+		run(args[0].(context.Context), args[1].(*types.GetTagsReq))
 	})
 	return _c
 }
 
-func (_c *MockRepoComponent_GetUserRepoPermission_Call) Return(_a0 *types.UserRepoPermission, _a1 error) *MockRepoComponent_GetUserRepoPermission_Call {
+func (_c *MockRepoComponent_GitTags_Call) Return(_a0 []types.Tag, _a1 error) *MockRepoComponent_GitTags_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockRepoComponent_GetUserRepoPermission_Call) RunAndReturn(run func(context.Context, string, *database.Repository) (*types.UserRepoPermission, error)) *MockRepoComponent_GetUserRepoPermission_Call {
+func (_c *MockRepoComponent_GitTags_Call) RunAndReturn(run func(context.Context, *types.GetTagsReq) ([]types.Tag, error)) *MockRepoComponent_GitTags_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -3340,6 +4107,72 @@ func (_c *MockRepoComponent_LastCommit_Call) RunAndReturn(run func(context.Conte
 	return _c
 }
 
+// ListAccessRequests provides a mock function with given fields: ctx, req
+func (_m *MockRepoComponent) ListAccessRequests(ctx context.Context, req *types.ListRepoAccessRequestsReq) ([]types.RepoAccessRequest, int, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAccessRequests")
+	}
+
+	var r0 []types.RepoAccessRequest
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *types.ListRepoAccessRequestsReq) ([]types.RepoAccessRequest, int, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *types.ListRepoAccessRequestsReq) []types.RepoAccessRequest); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.RepoAccessRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *types.ListRepoAccessRequestsReq) int); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *types.ListRepoAccessRequestsReq) error); ok {
+		r2 = rf(ctx, req)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockRepoComponent_ListAccessRequests_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAccessRequests'
+type MockRepoComponent_ListAccessRequests_Call struct {
+	*mock.Call
+}
+
+// ListAccessRequests is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *types.ListRepoAccessRequestsReq
+func (_e *MockRepoComponent_Expecter) ListAccessRequests(ctx interface{}, req interface{}) *MockRepoComponent_ListAccessRequests_Call {
+	return &MockRepoComponent_ListAccessRequests_Call{Call: _e.mock.On("ListAccessRequests", ctx, req)}
+}
+
+func (_c *MockRepoComponent_ListAccessRequests_Call) Run(run func(ctx context.Context, req *types.ListRepoAccessRequestsReq)) *MockRepoComponent_ListAccessRequests_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*types.ListRepoAccessRequestsReq))
+	})
+	return _c
+}
+
+func (_c *MockRepoComponent_ListAccessRequests_Call) Return(_a0 []types.RepoAccessRequest, _a1 int, _a2 error) *MockRepoComponent_ListAccessRequests_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockRepoComponent_ListAccessRequests_Call) RunAndReturn(run func(context.Context, *types.ListRepoAccessRequestsReq) ([]types.RepoAccessRequest, int, error)) *MockRepoComponent_ListAccessRequests_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListDeploy provides a mock function with given fields: ctx, repoType, namespace, name, currentUser
 func (_m *MockRepoComponent) ListDeploy(ctx context.Context, repoType types.RepositoryType, namespace string, name string, currentUser string) ([]types.DeployRequest, error) {
 	ret := _m.Called(ctx, repoType, namespace, name, currentUser)
@@ -3402,6 +4235,72 @@ func (_c *MockRepoComponent_ListDeploy_Call) RunAndReturn(run func(context.Conte
 	return _c
 }
 
+// ListReleases provides a mock function with given fields: ctx, req
+func (_m *MockRepoComponent) ListReleases(ctx context.Context, req *types.ListReleasesReq) ([]types.Release, int, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListReleases")
+	}
+
+	var r0 []types.Release
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *types.ListReleasesReq) ([]types.Release, int, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *types.ListReleasesReq) []types.Release); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.Release)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *types.ListReleasesReq) int); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *types.ListReleasesReq) error); ok {
+		r2 = rf(ctx, req)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockRepoComponent_ListReleases_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListReleases'
+type MockRepoComponent_ListReleases_Call struct {
+	*mock.Call
+}
+
+// ListReleases is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *types.ListReleasesReq
+func (_e *MockRepoComponent_Expecter) ListReleases(ctx interface{}, req interface{}) *MockRepoComponent_ListReleases_Call {
+	return &MockRepoComponent_ListReleases_Call{Call: _e.mock.On("ListReleases", ctx, req)}
+}
+
+func (_c *MockRepoComponent_ListReleases_Call) Run(run func(ctx context.Context, req *types.ListReleasesReq)) *MockRepoComponent_ListReleases_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*types.ListReleasesReq))
+	})
+	return _c
+}
+
+func (_c *MockRepoComponent_ListReleases_Call) Return(_a0 []types.Release, _a1 int, _a2 error) *MockRepoComponent_ListReleases_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockRepoComponent_ListReleases_Call) RunAndReturn(run func(context.Context, *types.ListReleasesReq) ([]types.Release, int, error)) *MockRepoComponent_ListReleases_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListRuntimeFramework provides a mock function with given fields: ctx, repoType, namespace, name, deployType
 func (_m *MockRepoComponent) ListRuntimeFramework(ctx context.Context, repoType types.RepositoryType, namespace string, name string, deployType int) ([]types.RuntimeFramework, error) {
 	ret := _m.Called(ctx, repoType, namespace, name, deployType)
@@ -4079,6 +4978,65 @@ func (_c *MockRepoComponent_RemoteTree_Call) RunAndReturn(run func(context.Conte
 	return _c
 }
 
+// ReviewAccessRequest provides a mock function with given fields: ctx, req
+func (_m *MockRepoComponent) ReviewAccessRequest(ctx context.Context, req *types.ReviewRepoAccessRequestReq) (*types.RepoAccessRequest, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReviewAccessRequest")
+	}
+
+	var r0 *types.RepoAccessRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *types.ReviewRepoAccessRequestReq) (*types.RepoAccessRequest, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *types.ReviewRepoAccessRequestReq) *types.RepoAccessRequest); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.RepoAccessRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *types.ReviewRepoAccessRequestReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepoComponent_ReviewAccessRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReviewAccessRequest'
+type MockRepoComponent_ReviewAccessRequest_Call struct {
+	*mock.Call
+}
+
+// ReviewAccessRequest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *types.ReviewRepoAccessRequestReq
+func (_e *MockRepoComponent_Expecter) ReviewAccessRequest(ctx interface{}, req interface{}) *MockRepoComponent_ReviewAccessRequest_Call {
+	return &MockRepoComponent_ReviewAccessRequest_Call{Call: _e.mock.On("ReviewAccessRequest", ctx, req)}
+}
+
+func (_c *MockRepoComponent_ReviewAccessRequest_Call) Run(run func(ctx context.Context, req *types.ReviewRepoAccessRequestReq)) *MockRepoComponent_ReviewAccessRequest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*types.ReviewRepoAccessRequestReq))
+	})
+	return _c
+}
+
+func (_c *MockRepoComponent_ReviewAccessRequest_Call) Return(_a0 *types.RepoAccessRequest, _a1 error) *MockRepoComponent_ReviewAccessRequest_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepoComponent_ReviewAccessRequest_Call) RunAndReturn(run func(context.Context, *types.ReviewRepoAccessRequestReq) (*types.RepoAccessRequest, error)) *MockRepoComponent_ReviewAccessRequest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SDKDownloadFile provides a mock function with given fields: ctx, req, userName
 func (_m *MockRepoComponent) SDKDownloadFile(ctx context.Context, req *types.GetFileReq, userName string) (io.ReadCloser, int64, string, error) {
 	ret := _m.Called(ctx, req, userName)
@@ -4537,6 +5495,65 @@ func (_c *MockRepoComponent_TreeV2_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// UpdateAccessPolicy provides a mock function with given fields: ctx, req
+func (_m *MockRepoComponent) UpdateAccessPolicy(ctx context.Context, req *types.UpdateRepoAccessPolicyReq) (*types.RepoAccessPolicy, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateAccessPolicy")
+	}
+
+	var r0 *types.RepoAccessPolicy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *types.UpdateRepoAccessPolicyReq) (*types.RepoAccessPolicy, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *types.UpdateRepoAccessPolicyReq) *types.RepoAccessPolicy); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.RepoAccessPolicy)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *types.UpdateRepoAccessPolicyReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepoComponent_UpdateAccessPolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateAccessPolicy'
+type MockRepoComponent_UpdateAccessPolicy_Call struct {
+	*mock.Call
+}
+
+// UpdateAccessPolicy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *types.UpdateRepoAccessPolicyReq
+func (_e *MockRepoComponent_Expecter) UpdateAccessPolicy(ctx interface{}, req interface{}) *MockRepoComponent_UpdateAccessPolicy_Call {
+	return &MockRepoComponent_UpdateAccessPolicy_Call{Call: _e.mock.On("UpdateAccessPolicy", ctx, req)}
+}
+
+func (_c *MockRepoComponent_UpdateAccessPolicy_Call) Run(run func(ctx context.Context, req *types.UpdateRepoAccessPolicyReq)) *MockRepoComponent_UpdateAccessPolicy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*types.UpdateRepoAccessPolicyReq))
+	})
+	return _c
+}
+
+func (_c *MockRepoComponent_UpdateAccessPolicy_Call) Return(_a0 *types.RepoAccessPolicy, _a1 error) *MockRepoComponent_UpdateAccessPolicy_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepoComponent_UpdateAccessPolicy_Call) RunAndReturn(run func(context.Context, *types.UpdateRepoAccessPolicyReq) (*types.RepoAccessPolicy, error)) *MockRepoComponent_UpdateAccessPolicy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateDownloads provides a mock function with given fields: ctx, req
 func (_m *MockRepoComponent) UpdateDownloads(ctx context.Context, req *types.UpdateDownloadsReq) error {
 	ret := _m.Called(ctx, req)
@@ -4643,6 +5660,65 @@ func (_c *MockRepoComponent_UpdateFile_Call) RunAndReturn(run func(context.Conte
 	return _c
 }
 
+// UpdateRelease provides a mock function with given fields: ctx, req
+func (_m *MockRepoComponent) UpdateRelease(ctx context.Context, req *types.UpdateReleaseReq) (*types.Release, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateRelease")
+	}
+
+	var r0 *types.Release
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *types.UpdateReleaseReq) (*types.Release, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *types.UpdateReleaseReq) *types.Release); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Release)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *types.UpdateReleaseReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepoComponent_UpdateRelease_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateRelease'
+type MockRepoComponent_UpdateRelease_Call struct {
+	*mock.Call
+}
+
+// UpdateRelease is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *types.UpdateReleaseReq
+func (_e *MockRepoComponent_Expecter) UpdateRelease(ctx interface{}, req interface{}) *MockRepoComponent_UpdateRelease_Call {
+	return &MockRepoComponent_UpdateRelease_Call{Call: _e.mock.On("UpdateRelease", ctx, req)}
+}
+
+func (_c *MockRepoComponent_UpdateRelease_Call) Run(run func(ctx context.Context, req *types.UpdateReleaseReq)) *MockRepoComponent_UpdateRelease_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*types.UpdateReleaseReq))
+	})
+	return _c
+}
+
+func (_c *MockRepoComponent_UpdateRelease_Call) Return(_a0 *types.Release, _a1 error) *MockRepoComponent_UpdateRelease_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepoComponent_UpdateRelease_Call) RunAndReturn(run func(context.Context, *types.UpdateReleaseReq) (*types.Release, error)) *MockRepoComponent_UpdateRelease_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateRepo provides a mock function with given fields: ctx, req
 func (_m *MockRepoComponent) UpdateRepo(ctx context.Context, req types.UpdateRepoReq) (*database.Repository, error) {
 	ret := _m.Called(ctx, req)
@@ -4862,21 +5938,33 @@ func (_c *MockRepoComponent_UploadFile_Call) RunAndReturn(run func(context.Conte
 }
 
 // ValidateYaml provides a mock function with given fields: ctx, req
-func (_m *MockRepoComponent) ValidateYaml(ctx context.Context, req types.ValidateYamlReq) error {
+func (_m *MockRepoComponent) ValidateYaml(ctx context.Context, req types.ValidateYamlReq) ([]types.CardWarning, error) {
 	ret := _m.Called(ctx, req)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ValidateYaml")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, types.ValidateYamlReq) error); ok {
+	var r0 []types.CardWarning
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.ValidateYamlReq) ([]types.CardWarning, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, types.ValidateYamlReq) []types.CardWarning); ok {
 		r0 = rf(ctx, req)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.CardWarning)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, types.ValidateYamlReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // MockRepoComponent_ValidateYaml_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ValidateYaml'
@@ -4898,12 +5986,12 @@ func (_c *MockRepoComponent_ValidateYaml_Call) Run(run func(ctx context.Context,
 	return _c
 }
 
-func (_c *MockRepoComponent_ValidateYaml_Call) Return(_a0 error) *MockRepoComponent_ValidateYaml_Call {
-	_c.Call.Return(_a0)
+func (_c *MockRepoComponent_ValidateYaml_Call) Return(_a0 []types.CardWarning, _a1 error) *MockRepoComponent_ValidateYaml_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockRepoComponent_ValidateYaml_Call) RunAndReturn(run func(context.Context, types.ValidateYamlReq) error) *MockRepoComponent_ValidateYaml_Call {
+func (_c *MockRepoComponent_ValidateYaml_Call) RunAndReturn(run func(context.Context, types.ValidateYamlReq) ([]types.CardWarning, error)) *MockRepoComponent_ValidateYaml_Call {
 	_c.Call.Return(run)
 	return _c
 }
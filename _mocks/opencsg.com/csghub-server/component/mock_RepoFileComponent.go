@@ -120,6 +120,72 @@ func (_c *MockRepoFileComponent_GenRepoFileRecordsBatch_Call) RunAndReturn(run f
 	return _c
 }
 
+// SearchFiles provides a mock function with given fields: ctx, req
+func (_m *MockRepoFileComponent) SearchFiles(ctx context.Context, req *types.SearchRepoFileReq) ([]types.RepoFileSearchResult, int, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchFiles")
+	}
+
+	var r0 []types.RepoFileSearchResult
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *types.SearchRepoFileReq) ([]types.RepoFileSearchResult, int, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *types.SearchRepoFileReq) []types.RepoFileSearchResult); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.RepoFileSearchResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *types.SearchRepoFileReq) int); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *types.SearchRepoFileReq) error); ok {
+		r2 = rf(ctx, req)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockRepoFileComponent_SearchFiles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchFiles'
+type MockRepoFileComponent_SearchFiles_Call struct {
+	*mock.Call
+}
+
+// SearchFiles is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *types.SearchRepoFileReq
+func (_e *MockRepoFileComponent_Expecter) SearchFiles(ctx interface{}, req interface{}) *MockRepoFileComponent_SearchFiles_Call {
+	return &MockRepoFileComponent_SearchFiles_Call{Call: _e.mock.On("SearchFiles", ctx, req)}
+}
+
+func (_c *MockRepoFileComponent_SearchFiles_Call) Run(run func(ctx context.Context, req *types.SearchRepoFileReq)) *MockRepoFileComponent_SearchFiles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*types.SearchRepoFileReq))
+	})
+	return _c
+}
+
+func (_c *MockRepoFileComponent_SearchFiles_Call) Return(_a0 []types.RepoFileSearchResult, _a1 int, _a2 error) *MockRepoFileComponent_SearchFiles_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockRepoFileComponent_SearchFiles_Call) RunAndReturn(run func(context.Context, *types.SearchRepoFileReq) ([]types.RepoFileSearchResult, int, error)) *MockRepoFileComponent_SearchFiles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockRepoFileComponent creates a new instance of MockRepoFileComponent. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockRepoFileComponent(t interface {
@@ -292,6 +292,65 @@ func (_c *MockLogSender_QueryRange_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// SearchLogs provides a mock function with given fields: ctx, q
+func (_m *MockLogSender) SearchLogs(ctx context.Context, q *types.LogSearchQuery) (*types.LogSearchResult, error) {
+	ret := _m.Called(ctx, q)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchLogs")
+	}
+
+	var r0 *types.LogSearchResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *types.LogSearchQuery) (*types.LogSearchResult, error)); ok {
+		return rf(ctx, q)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *types.LogSearchQuery) *types.LogSearchResult); ok {
+		r0 = rf(ctx, q)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.LogSearchResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *types.LogSearchQuery) error); ok {
+		r1 = rf(ctx, q)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockLogSender_SearchLogs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchLogs'
+type MockLogSender_SearchLogs_Call struct {
+	*mock.Call
+}
+
+// SearchLogs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - q *types.LogSearchQuery
+func (_e *MockLogSender_Expecter) SearchLogs(ctx interface{}, q interface{}) *MockLogSender_SearchLogs_Call {
+	return &MockLogSender_SearchLogs_Call{Call: _e.mock.On("SearchLogs", ctx, q)}
+}
+
+func (_c *MockLogSender_SearchLogs_Call) Run(run func(ctx context.Context, q *types.LogSearchQuery)) *MockLogSender_SearchLogs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*types.LogSearchQuery))
+	})
+	return _c
+}
+
+func (_c *MockLogSender_SearchLogs_Call) Return(_a0 *types.LogSearchResult, _a1 error) *MockLogSender_SearchLogs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockLogSender_SearchLogs_Call) RunAndReturn(run func(context.Context, *types.LogSearchQuery) (*types.LogSearchResult, error)) *MockLogSender_SearchLogs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SendLogs provides a mock function with given fields: ctx, entries
 func (_m *MockLogSender) SendLogs(ctx context.Context, entries []types.LogEntry) error {
 	ret := _m.Called(ctx, entries)
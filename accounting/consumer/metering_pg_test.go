@@ -0,0 +1,72 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	mockacct "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/accounting/component"
+	bldmq "opencsg.com/csghub-server/builder/mq"
+	"opencsg.com/csghub-server/common/config"
+	"opencsg.com/csghub-server/common/tests"
+	"opencsg.com/csghub-server/common/types"
+)
+
+// TestMeteringImpl_Run_Postgres runs the metering consumer on the message queue embedded in the database
+func TestMeteringImpl_Run_Postgres(t *testing.T) {
+	db := tests.InitTransactionTestDB()
+	defer db.Close()
+	mq := bldmq.NewPostgresWithDB(db, 100*time.Millisecond, 0)
+	defer mq.Close()
+
+	mockMeterComp := mockacct.NewMockMeteringComponent(t)
+	mockAcctEvtComp := mockacct.NewMockAccountingEventComponent(t)
+	cfg := &config.Config{}
+	cfg.Accounting.ChargingEnable = true
+	cfg.Accounting.RetryLimit = 1
+	metering := NewTestConsumerMetering(mockMeterComp, mockAcctEvtComp, cfg, mq)
+
+	fees := make(chan []byte, 1)
+	err := mq.Subscribe(bldmq.SubscribeParams{
+		Group:   bldmq.AccountingEventGroup,
+		Topics:  []string{bldmq.FeeSendSubject, bldmq.TokenSendSubject, bldmq.QuotaSendSubject},
+		AutoACK: true,
+		Callback: func(raw []byte, meta bldmq.MessageMeta) error {
+			fees <- raw
+			return nil
+		},
+	})
+	require.NoError(t, err)
+
+	event := createTestMeteringEvent()
+	data, err := json.Marshal(event)
+	require.NoError(t, err)
+	saved := make(chan struct{}, 1)
+	mockMeterComp.EXPECT().GetMeteringByEventUUID(mock.Anything, event.Uuid).Return(nil, nil)
+	mockMeterComp.EXPECT().FindMeteringByCustomerIDAndRecordAtInMin(mock.Anything, event.CustomerID, mock.Anything).Return(nil, nil)
+	mockAcctEvtComp.EXPECT().AddNewAccountingEvent(mock.Anything, mock.Anything, false).Return(nil)
+	mockMeterComp.EXPECT().SaveMeteringEventRecord(mock.Anything, mock.Anything).RunAndReturn(
+		func(ctx context.Context, me *types.MeteringEvent) error {
+			saved <- struct{}{}
+			return nil
+		})
+
+	metering.Run()
+	err = mq.Publish(bldmq.MeterDurationSendSubject, data)
+	require.NoError(t, err)
+
+	select {
+	case <-saved:
+	case <-time.After(5 * time.Second):
+		t.Fatal("metering event is not consumed")
+	}
+	select {
+	case fee := <-fees:
+		require.JSONEq(t, string(data), string(fee))
+	case <-time.After(5 * time.Second):
+		t.Fatal("fee event is not published")
+	}
+}
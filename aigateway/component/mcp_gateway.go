@@ -0,0 +1,598 @@
+package component
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"opencsg.com/csghub-server/builder/event"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/config"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+)
+
+const (
+	mcpGatewayName          = "csghub-mcp-gateway"
+	mcpGatewayVersion       = "v1.0.0"
+	mcpUpstreamConnectLimit = 10 * time.Second
+)
+
+// MCPGatewayComponent aggregates the tools, resources and prompts of the registered
+// gateway MCP servers into a single MCP server per API key.
+type MCPGatewayComponent interface {
+	// NewServer builds the aggregated MCP server for a new session of the caller, it merges the enabled and
+	// healthy servers shared with everyone or owned by the caller or the organizations the caller belongs to.
+	// The tool policies are bound to the API key, so the sessions without an API key (login sessions) are not
+	// restricted by any allow or deny list.
+	NewServer(ctx context.Context, caller types.MCPGatewayCaller) (*mcp.Server, error)
+	CreateToolPolicy(ctx context.Context, req types.CreateMCPToolPolicyReq) (*types.MCPToolPolicy, error)
+	ListToolPolicies(ctx context.Context, currentUser string, apiKeyID int64) ([]types.MCPToolPolicy, error)
+	DeleteToolPolicy(ctx context.Context, currentUser string, apiKeyID, id int64) error
+	ListCallLogs(ctx context.Context, filter types.MCPGatewayCallLogFilter) ([]types.MCPGatewayCallLog, int, error)
+}
+
+// mcpUpstreamConnector opens a client session to a registered MCP server
+type mcpUpstreamConnector func(ctx context.Context, server *database.GatewayMCPServers) (*mcp.ClientSession, error)
+
+type mcpUpstream struct {
+	configHash string
+	session    *mcp.ClientSession
+}
+
+type mcpGatewayComponentImpl struct {
+	serverStore      database.GatewayMCPServersStore
+	policyStore      database.GatewayMCPToolPolicyStore
+	callLogStore     database.GatewayMCPCallLogStore
+	accessTokenStore database.AccessTokenStore
+	userStore        database.UserStore
+	orgStore         database.OrgStore
+	eventPub         *event.EventPublisher
+	connect          mcpUpstreamConnector
+
+	// upstream sessions are shared by all aggregated sessions, keyed by mcp server id
+	mu        sync.Mutex
+	upstreams map[int64]*mcpUpstream
+}
+
+func NewMCPGatewayComponent(config *config.Config) MCPGatewayComponent {
+	return &mcpGatewayComponentImpl{
+		serverStore:      database.NewGatewayMCPServersStore(),
+		policyStore:      database.NewGatewayMCPToolPolicyStore(),
+		callLogStore:     database.NewGatewayMCPCallLogStore(),
+		accessTokenStore: database.NewAccessTokenStore(),
+		userStore:        database.NewUserStore(),
+		orgStore:         database.NewOrgStore(),
+		eventPub:         &event.DefaultEventPublisher,
+		connect:          connectMCPUpstream,
+		upstreams:        make(map[int64]*mcpUpstream),
+	}
+}
+
+func (c *mcpGatewayComponentImpl) NewServer(ctx context.Context, caller types.MCPGatewayCaller) (*mcp.Server, error) {
+	namespaces, err := c.callerNamespaces(ctx, caller)
+	if err != nil {
+		return nil, err
+	}
+	servers, err := c.serverStore.ListForGateway(ctx, namespaces)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gateway mcp servers, error: %w", err)
+	}
+	var (
+		apiKeyID int64
+		policies []database.GatewayMCPToolPolicy
+	)
+	if caller.APIKey != "" {
+		// the tool policies are bound to the api key, a session must not run without them
+		token, err := c.accessTokenStore.FindByToken(ctx, caller.APIKey, string(types.AccessTokenAppAIGateway))
+		if err != nil {
+			if errors.Is(err, errorx.ErrDatabaseNoRows) {
+				return nil, fmt.Errorf("api key is not an aigateway key, %w", errorx.ErrUnauthorized)
+			}
+			return nil, fmt.Errorf("failed to find api key, error: %w", err)
+		}
+		apiKeyID = token.ID
+		policies, err = c.policyStore.ListByAccessTokenID(ctx, apiKeyID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get mcp tool policies, error: %w", err)
+		}
+	}
+
+	sess := &mcpGatewaySession{
+		comp:     c,
+		caller:   caller,
+		apiKeyID: apiKeyID,
+		policies: policies,
+		servers:  servers,
+		tools:    make(map[string]mcpGatewayTarget),
+	}
+	server := mcp.NewServer(&mcp.Implementation{Name: mcpGatewayName, Version: mcpGatewayVersion}, nil)
+	// denied tools are not listed, but calls naming them must still be audited
+	server.AddReceivingMiddleware(sess.denyMiddleware)
+
+	resourceURIs := make(map[string]bool)
+	for i := range servers {
+		upstream := &servers[i]
+		session, err := c.upstreamSession(ctx, upstream)
+		if err != nil {
+			// one broken server should not make the whole gateway unavailable
+			slog.WarnContext(ctx, "skip unavailable mcp server in gateway", slog.String("server", upstream.Name), slog.Any("error", err))
+			continue
+		}
+		caps := session.InitializeResult().Capabilities
+		if caps == nil {
+			continue
+		}
+		if caps.Tools != nil {
+			for tool, err := range session.Tools(ctx, nil) {
+				if err != nil {
+					slog.WarnContext(ctx, "failed to list tools of mcp server", slog.String("server", upstream.Name), slog.Any("error", err))
+					break
+				}
+				name := mcpGatewayToolName(upstream.Name, tool.Name)
+				if !mcpToolAllowed(policies, name) {
+					continue
+				}
+				target := mcpGatewayTarget{server: upstream, name: tool.Name}
+				sess.tools[name] = target
+				aggregated := *tool
+				aggregated.Name = name
+				server.AddTool(&aggregated, sess.callTool(target))
+			}
+		}
+		if caps.Resources != nil {
+			for res, err := range session.Resources(ctx, nil) {
+				if err != nil {
+					slog.WarnContext(ctx, "failed to list resources of mcp server", slog.String("server", upstream.Name), slog.Any("error", err))
+					break
+				}
+				// resource uris are globally unique by design, the first server wins on conflicts
+				if resourceURIs[res.URI] {
+					continue
+				}
+				resourceURIs[res.URI] = true
+				server.AddResource(res, sess.readResource(mcpGatewayTarget{server: upstream, name: res.URI}))
+			}
+		}
+		if caps.Prompts != nil {
+			for prompt, err := range session.Prompts(ctx, nil) {
+				if err != nil {
+					slog.WarnContext(ctx, "failed to list prompts of mcp server", slog.String("server", upstream.Name), slog.Any("error", err))
+					break
+				}
+				aggregated := *prompt
+				aggregated.Name = mcpGatewayToolName(upstream.Name, prompt.Name)
+				server.AddPrompt(&aggregated, sess.getPrompt(mcpGatewayTarget{server: upstream, name: prompt.Name}))
+			}
+		}
+	}
+	return server, nil
+}
+
+// callerNamespaces returns the namespaces whose gateway MCP servers the caller can use,
+// they are the caller's own namespace and the organizations the caller belongs to
+func (c *mcpGatewayComponentImpl) callerNamespaces(ctx context.Context, caller types.MCPGatewayCaller) ([]string, error) {
+	if caller.Username == "" {
+		return nil, nil
+	}
+	user, err := c.userStore.FindByUsername(ctx, caller.Username)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user %s, error: %w", caller.Username, err)
+	}
+	orgs, err := c.orgStore.GetUserBelongOrgs(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organizations of user %s, error: %w", caller.Username, err)
+	}
+	namespaces := []string{caller.Username}
+	for _, org := range orgs {
+		namespaces = append(namespaces, org.Name)
+	}
+	return namespaces, nil
+}
+
+// upstreamSession returns the shared client session of the mcp server, reconnecting when
+// the server config changed or the previous session was dropped. The lock is not held while
+// dialing so a slow server does not block the sessions of the other servers.
+func (c *mcpGatewayComponentImpl) upstreamSession(ctx context.Context, server *database.GatewayMCPServers) (*mcp.ClientSession, error) {
+	if session := c.cachedUpstream(server); session != nil {
+		return session, nil
+	}
+	connectCtx, cancel := context.WithTimeout(ctx, mcpUpstreamConnectLimit)
+	defer cancel()
+	session, err := c.connect(connectCtx, server)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect mcp server %s, error: %w", server.Name, err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// another session may have connected the same server while dialing, keep the first one
+	if up, ok := c.upstreams[server.ID]; ok && up.configHash == server.ConfigHash {
+		_ = session.Close()
+		return up.session, nil
+	}
+	c.upstreams[server.ID] = &mcpUpstream{configHash: server.ConfigHash, session: session}
+	return session, nil
+}
+
+// cachedUpstream returns the cached session of the mcp server, a session of an outdated config is closed
+func (c *mcpGatewayComponentImpl) cachedUpstream(server *database.GatewayMCPServers) *mcp.ClientSession {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	up, ok := c.upstreams[server.ID]
+	if !ok {
+		return nil
+	}
+	if up.configHash == server.ConfigHash {
+		return up.session
+	}
+	_ = up.session.Close()
+	delete(c.upstreams, server.ID)
+	return nil
+}
+
+func (c *mcpGatewayComponentImpl) dropUpstream(serverID int64, session *mcp.ClientSession) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if up, ok := c.upstreams[serverID]; ok && up.session == session {
+		_ = up.session.Close()
+		delete(c.upstreams, serverID)
+	}
+}
+
+func connectMCPUpstream(ctx context.Context, server *database.GatewayMCPServers) (*mcp.ClientSession, error) {
+	httpClient := &http.Client{Transport: &mcpHeaderTransport{headers: server.Headers, base: http.DefaultTransport}}
+	var transport mcp.Transport
+	switch server.Protocol {
+	case "sse":
+		transport = &mcp.SSEClientTransport{Endpoint: server.URL, HTTPClient: httpClient}
+	default:
+		transport = &mcp.StreamableClientTransport{Endpoint: server.URL, HTTPClient: httpClient}
+	}
+	client := mcp.NewClient(&mcp.Implementation{Name: mcpGatewayName, Version: mcpGatewayVersion}, nil)
+	return client.Connect(ctx, transport, nil)
+}
+
+// mcpHeaderTransport adds the configured headers of a gateway mcp server to every upstream request
+type mcpHeaderTransport struct {
+	headers map[string]any
+	base    http.RoundTripper
+}
+
+func (t *mcpHeaderTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if len(t.headers) > 0 {
+		req = req.Clone(req.Context())
+		for k, v := range t.headers {
+			req.Header.Set(k, fmt.Sprint(v))
+		}
+	}
+	return t.base.RoundTrip(req)
+}
+
+func (c *mcpGatewayComponentImpl) CreateToolPolicy(ctx context.Context, req types.CreateMCPToolPolicyReq) (*types.MCPToolPolicy, error) {
+	if err := c.checkAPIKeyOwner(ctx, req.CurrentUser, req.APIKeyID); err != nil {
+		return nil, err
+	}
+	policy, err := c.policyStore.Create(ctx, &database.GatewayMCPToolPolicy{
+		AccessTokenID: req.APIKeyID,
+		Tool:          strings.TrimSpace(req.Tool),
+		Action:        req.Action,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create mcp tool policy, error: %w", err)
+	}
+	return toMCPToolPolicy(policy), nil
+}
+
+func (c *mcpGatewayComponentImpl) ListToolPolicies(ctx context.Context, currentUser string, apiKeyID int64) ([]types.MCPToolPolicy, error) {
+	if err := c.checkAPIKeyOwner(ctx, currentUser, apiKeyID); err != nil {
+		return nil, err
+	}
+	policies, err := c.policyStore.ListByAccessTokenID(ctx, apiKeyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list mcp tool policies, error: %w", err)
+	}
+	res := make([]types.MCPToolPolicy, 0, len(policies))
+	for i := range policies {
+		res = append(res, *toMCPToolPolicy(&policies[i]))
+	}
+	return res, nil
+}
+
+func (c *mcpGatewayComponentImpl) DeleteToolPolicy(ctx context.Context, currentUser string, apiKeyID, id int64) error {
+	if err := c.checkAPIKeyOwner(ctx, currentUser, apiKeyID); err != nil {
+		return err
+	}
+	return c.policyStore.Delete(ctx, apiKeyID, id)
+}
+
+func (c *mcpGatewayComponentImpl) ListCallLogs(ctx context.Context, filter types.MCPGatewayCallLogFilter) ([]types.MCPGatewayCallLog, int, error) {
+	logs, total, err := c.callLogStore.List(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list mcp call logs, error: %w", err)
+	}
+	res := make([]types.MCPGatewayCallLog, 0, len(logs))
+	for _, l := range logs {
+		res = append(res, types.MCPGatewayCallLog{
+			ID:         l.ID,
+			SessionID:  l.SessionID,
+			ServerName: l.MCPServerName,
+			Method:     l.Method,
+			Target:     l.Target,
+			Status:     l.Status,
+			Error:      l.Error,
+			LatencyMs:  l.LatencyMs,
+			CreatedAt:  l.CreatedAt,
+		})
+	}
+	return res, total, nil
+}
+
+// checkAPIKeyOwner makes sure only the creator of an aigateway api key can manage its tool policies
+func (c *mcpGatewayComponentImpl) checkAPIKeyOwner(ctx context.Context, currentUser string, apiKeyID int64) error {
+	token, err := c.accessTokenStore.FindByID(ctx, apiKeyID)
+	if err != nil {
+		if errors.Is(err, errorx.ErrDatabaseNoRows) {
+			return errorx.ErrNotFound
+		}
+		return fmt.Errorf("failed to find api key, error: %w", err)
+	}
+	if token.Application != types.AccessTokenAppAIGateway {
+		return errorx.ErrNotFound
+	}
+	if token.User == nil || token.User.Username != currentUser {
+		return errorx.ErrForbiddenMsg("api key does not belong to current user")
+	}
+	return nil
+}
+
+func toMCPToolPolicy(p *database.GatewayMCPToolPolicy) *types.MCPToolPolicy {
+	return &types.MCPToolPolicy{
+		ID:        p.ID,
+		Tool:      p.Tool,
+		Action:    p.Action,
+		CreatedAt: p.CreatedAt,
+	}
+}
+
+// mcpGatewayTarget is an upstream tool, resource or prompt behind an aggregated name
+type mcpGatewayTarget struct {
+	server *database.GatewayMCPServers
+	name   string
+}
+
+// mcpGatewaySession holds the per-caller state of one aggregated MCP server
+type mcpGatewaySession struct {
+	comp     *mcpGatewayComponentImpl
+	caller   types.MCPGatewayCaller
+	apiKeyID int64
+	policies []database.GatewayMCPToolPolicy
+	servers  []database.GatewayMCPServers
+	tools    map[string]mcpGatewayTarget
+}
+
+func (s *mcpGatewaySession) denyMiddleware(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		if method != types.MCPMethodCallTool {
+			return next(ctx, method, req)
+		}
+		params, ok := req.GetParams().(*mcp.CallToolParamsRaw)
+		if !ok || mcpToolAllowed(s.policies, params.Name) {
+			return next(ctx, method, req)
+		}
+		err := fmt.Errorf("tool %s is not allowed for this api key", params.Name)
+		s.record(ctx, req.GetSession(), nil, types.MCPMethodCallTool, params.Name, types.MCPGatewayCallDenied, err, 0)
+		return nil, err
+	}
+}
+
+func (s *mcpGatewaySession) callTool(target mcpGatewayTarget) mcp.ToolHandler {
+	return func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		upstream, err := s.comp.upstreamSession(ctx, target.server)
+		if err != nil {
+			return nil, err
+		}
+		start := time.Now()
+		res, err := upstream.CallTool(ctx, &mcp.CallToolParams{
+			Meta:      req.Params.Meta,
+			Name:      target.name,
+			Arguments: req.Params.Arguments,
+		})
+		latency := time.Since(start)
+		status := types.MCPGatewayCallSuccess
+		callErr := err
+		if err != nil {
+			status = types.MCPGatewayCallError
+			if errors.Is(err, mcp.ErrConnectionClosed) {
+				s.comp.dropUpstream(target.server.ID, upstream)
+			}
+		} else if res.IsError {
+			status = types.MCPGatewayCallError
+			callErr = errors.New(mcpToolResultText(res))
+		}
+		s.record(ctx, req.Session, target.server, types.MCPMethodCallTool, req.Params.Name, status, callErr, latency)
+		if err == nil {
+			s.meter(ctx, target.server, req.Params.Name, latency)
+		}
+		return res, err
+	}
+}
+
+func (s *mcpGatewaySession) readResource(target mcpGatewayTarget) mcp.ResourceHandler {
+	return func(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		upstream, err := s.comp.upstreamSession(ctx, target.server)
+		if err != nil {
+			return nil, err
+		}
+		start := time.Now()
+		res, err := upstream.ReadResource(ctx, &mcp.ReadResourceParams{Meta: req.Params.Meta, URI: req.Params.URI})
+		status := types.MCPGatewayCallSuccess
+		if err != nil {
+			status = types.MCPGatewayCallError
+			if errors.Is(err, mcp.ErrConnectionClosed) {
+				s.comp.dropUpstream(target.server.ID, upstream)
+			}
+		}
+		s.record(ctx, req.Session, target.server, types.MCPMethodReadResource, req.Params.URI, status, err, time.Since(start))
+		return res, err
+	}
+}
+
+func (s *mcpGatewaySession) getPrompt(target mcpGatewayTarget) mcp.PromptHandler {
+	return func(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		upstream, err := s.comp.upstreamSession(ctx, target.server)
+		if err != nil {
+			return nil, err
+		}
+		start := time.Now()
+		res, err := upstream.GetPrompt(ctx, &mcp.GetPromptParams{
+			Meta:      req.Params.Meta,
+			Name:      target.name,
+			Arguments: req.Params.Arguments,
+		})
+		status := types.MCPGatewayCallSuccess
+		if err != nil {
+			status = types.MCPGatewayCallError
+			if errors.Is(err, mcp.ErrConnectionClosed) {
+				s.comp.dropUpstream(target.server.ID, upstream)
+			}
+		}
+		s.record(ctx, req.Session, target.server, types.MCPMethodGetPrompt, req.Params.Name, status, err, time.Since(start))
+		return res, err
+	}
+}
+
+// record writes the call log, failures are only logged so auditing never breaks a call
+func (s *mcpGatewaySession) record(ctx context.Context, session mcp.Session, server *database.GatewayMCPServers,
+	method, target string, status types.MCPGatewayCallStatus, callErr error, latency time.Duration) {
+	callLog := &database.GatewayMCPCallLog{
+		NamespaceUUID: s.caller.NamespaceUUID,
+		Username:      s.caller.Username,
+		APIKeyID:      s.apiKeyID,
+		Method:        method,
+		Target:        target,
+		Status:        status,
+		LatencyMs:     latency.Milliseconds(),
+	}
+	if session != nil {
+		callLog.SessionID = session.ID()
+	}
+	if server != nil {
+		callLog.MCPServerID = server.ID
+		callLog.MCPServerName = server.Name
+	} else if t, ok := s.tools[target]; ok {
+		callLog.MCPServerID = t.server.ID
+		callLog.MCPServerName = t.server.Name
+	} else if server := s.serverOf(target); server != nil {
+		callLog.MCPServerID = server.ID
+		callLog.MCPServerName = server.Name
+	}
+	if callErr != nil {
+		callLog.Error = callErr.Error()
+	}
+	if err := s.comp.callLogStore.Create(context.WithoutCancel(ctx), callLog); err != nil {
+		slog.ErrorContext(ctx, "failed to save mcp gateway call log", slog.String("target", target), slog.Any("error", err))
+	}
+}
+
+// serverOf finds the server of a prefixed tool name that is not exposed to the session, server
+// names may contain underscores so the longest matching server name wins
+func (s *mcpGatewaySession) serverOf(tool string) *database.GatewayMCPServers {
+	var found *database.GatewayMCPServers
+	for i := range s.servers {
+		server := &s.servers[i]
+		if strings.HasPrefix(tool, mcpGatewayToolName(server.Name, "")) && (found == nil || len(server.Name) > len(found.Name)) {
+			found = server
+		}
+	}
+	return found
+}
+
+// mcpToolCallExtra is serialized into MeteringEvent.Extra for mcp tool call billing
+type mcpToolCallExtra struct {
+	APIKeyID  int64  `json:"api_key_id,omitempty"`
+	Tool      string `json:"tool"`
+	LatencyMs int64  `json:"latency_ms"`
+}
+
+// meter publishes a metering event per successful tool call so it can be billed like llm calls
+func (s *mcpGatewaySession) meter(ctx context.Context, server *database.GatewayMCPServers, tool string, latency time.Duration) {
+	if s.comp.eventPub == nil || s.caller.NamespaceUUID == "" {
+		return
+	}
+	extra, err := json.Marshal(mcpToolCallExtra{APIKeyID: s.apiKeyID, Tool: tool, LatencyMs: latency.Milliseconds()})
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to marshal mcp tool call extra", slog.Any("error", err))
+		return
+	}
+	meteringEvent := types.MeteringEvent{
+		Uuid:         uuid.New(),
+		UserUUID:     s.caller.NamespaceUUID,
+		Value:        1,
+		ValueType:    types.CountNumberType,
+		Scene:        int(types.SceneMCPToolCall),
+		OpUID:        string(types.AccessTokenAppAIGateway),
+		ResourceID:   fmt.Sprintf("mcp:%s", server.Name),
+		ResourceName: tool,
+		CustomerID:   server.Name,
+		CreatedAt:    time.Now(),
+		Extra:        string(extra),
+	}
+	data, err := json.Marshal(meteringEvent)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to marshal mcp tool call metering event", slog.Any("error", err))
+		return
+	}
+	if err := s.comp.eventPub.PublishMeteringEvent(data); err != nil {
+		slog.ErrorContext(ctx, "failed to publish mcp tool call metering event", slog.String("tool", tool), slog.Any("error", err))
+	}
+}
+
+// mcpGatewayToolName prefixes a tool or prompt name with its server name, see types.ValidMCPServerName
+func mcpGatewayToolName(serverName, name string) string {
+	return serverName + "_" + name
+}
+
+// mcpToolAllowed applies the tool policies of an api key: deny rules always win, and once
+// any allow rule exists only the allowed tools are exposed
+func mcpToolAllowed(policies []database.GatewayMCPToolPolicy, tool string) bool {
+	hasAllow := false
+	allowed := false
+	for _, p := range policies {
+		matched := mcpToolPolicyMatch(p.Tool, tool)
+		switch p.Action {
+		case types.MCPToolPolicyDeny:
+			if matched {
+				return false
+			}
+		case types.MCPToolPolicyAllow:
+			hasAllow = true
+			allowed = allowed || matched
+		}
+	}
+	return !hasAllow || allowed
+}
+
+func mcpToolPolicyMatch(pattern, tool string) bool {
+	if prefix, ok := strings.CutSuffix(pattern, types.MCPToolPolicyWildcard); ok {
+		return strings.HasPrefix(tool, prefix)
+	}
+	return pattern == tool
+}
+
+func mcpToolResultText(res *mcp.CallToolResult) string {
+	for _, content := range res.Content {
+		if text, ok := content.(*mcp.TextContent); ok {
+			return text.Text
+		}
+	}
+	return "tool returned an error"
+}
@@ -0,0 +1,296 @@
+package component
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	mockbldmq "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/builder/mq"
+	mockdb "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/builder/event"
+	bldmq "opencsg.com/csghub-server/builder/mq"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+)
+
+type searchArgs struct {
+	Query string `json:"query"`
+}
+
+func newTestUpstreamMCPServer() *mcp.Server {
+	server := mcp.NewServer(&mcp.Implementation{Name: "github", Version: "v1"}, nil)
+	mcp.AddTool(server, &mcp.Tool{Name: "search", Description: "search repos"},
+		func(ctx context.Context, req *mcp.CallToolRequest, args searchArgs) (*mcp.CallToolResult, any, error) {
+			return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "found " + args.Query}}}, nil, nil
+		})
+	mcp.AddTool(server, &mcp.Tool{Name: "delete_repo", Description: "delete repo"},
+		func(ctx context.Context, req *mcp.CallToolRequest, args searchArgs) (*mcp.CallToolResult, any, error) {
+			return &mcp.CallToolResult{}, nil, nil
+		})
+	server.AddPrompt(&mcp.Prompt{Name: "review"}, func(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		return &mcp.GetPromptResult{Messages: []*mcp.PromptMessage{{Role: "user", Content: &mcp.TextContent{Text: "review it"}}}}, nil
+	})
+	return server
+}
+
+func TestMCPGatewayComponent_NewServer(t *testing.T) {
+	ctx := context.TODO()
+	serverStore := mockdb.NewMockGatewayMCPServersStore(t)
+	policyStore := mockdb.NewMockGatewayMCPToolPolicyStore(t)
+	callLogStore := mockdb.NewMockGatewayMCPCallLogStore(t)
+	accessTokenStore := mockdb.NewMockAccessTokenStore(t)
+	userStore := mockdb.NewMockUserStore(t)
+	orgStore := mockdb.NewMockOrgStore(t)
+	mq := mockbldmq.NewMockMessageQueue(t)
+
+	upstream := newTestUpstreamMCPServer()
+	comp := &mcpGatewayComponentImpl{
+		serverStore:      serverStore,
+		policyStore:      policyStore,
+		callLogStore:     callLogStore,
+		accessTokenStore: accessTokenStore,
+		userStore:        userStore,
+		orgStore:         orgStore,
+		eventPub:         &event.EventPublisher{MQ: mq},
+		upstreams:        make(map[int64]*mcpUpstream),
+		connect: func(ctx context.Context, server *database.GatewayMCPServers) (*mcp.ClientSession, error) {
+			serverTransport, clientTransport := mcp.NewInMemoryTransports()
+			if _, err := upstream.Connect(ctx, serverTransport, nil); err != nil {
+				return nil, err
+			}
+			client := mcp.NewClient(&mcp.Implementation{Name: "test"}, nil)
+			return client.Connect(ctx, clientTransport, nil)
+		},
+	}
+
+	caller := types.MCPGatewayCaller{Username: "user", NamespaceUUID: "ns-uuid", APIKey: "key"}
+	userStore.EXPECT().FindByUsername(ctx, "user").Return(database.User{ID: 3, Username: "user"}, nil)
+	orgStore.EXPECT().GetUserBelongOrgs(ctx, int64(3)).Return([]database.Organization{{Name: "opencsg"}}, nil)
+	// the servers are scoped to the caller and the organizations of the caller
+	serverStore.EXPECT().ListForGateway(ctx, []string{"user", "opencsg"}).Return([]database.GatewayMCPServers{
+		{ID: 1, Name: "github", Protocol: "streamable", ConfigHash: "h1"},
+		{ID: 2, Name: "github_enterprise", Protocol: "streamable", ConfigHash: "h2"},
+	}, nil)
+	policyStore.EXPECT().ListByAccessTokenID(ctx, int64(7)).Return([]database.GatewayMCPToolPolicy{
+		{Tool: "github_delete*", Action: types.MCPToolPolicyDeny},
+		{Tool: "github_enterprise_*", Action: types.MCPToolPolicyDeny},
+	}, nil)
+	accessTokenStore.EXPECT().FindByToken(ctx, "key", "aigateway").Return(&database.AccessToken{ID: 7}, nil)
+
+	server, err := comp.NewServer(ctx, caller)
+	require.Nil(t, err)
+
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	_, err = server.Connect(ctx, serverTransport, nil)
+	require.Nil(t, err)
+	client := mcp.NewClient(&mcp.Implementation{Name: "agent"}, nil)
+	session, err := client.Connect(ctx, clientTransport, nil)
+	require.Nil(t, err)
+	defer session.Close()
+
+	tools, err := session.ListTools(ctx, nil)
+	require.Nil(t, err)
+	require.Len(t, tools.Tools, 1)
+	require.Equal(t, "github_search", tools.Tools[0].Name)
+
+	prompts, err := session.ListPrompts(ctx, nil)
+	require.Nil(t, err)
+	require.Len(t, prompts.Prompts, 2)
+	require.Equal(t, "github_enterprise_review", prompts.Prompts[0].Name)
+	require.Equal(t, "github_review", prompts.Prompts[1].Name)
+
+	callLogStore.EXPECT().Create(mock.Anything, mock.MatchedBy(func(l *database.GatewayMCPCallLog) bool {
+		return l.Target == "github_search" && l.Status == types.MCPGatewayCallSuccess &&
+			l.MCPServerID == 1 && l.APIKeyID == 7 && l.NamespaceUUID == "ns-uuid"
+	})).Return(nil).Once()
+	mq.EXPECT().Publish(bldmq.MeterDurationSendSubject, mock.MatchedBy(func(data []byte) bool {
+		var e types.MeteringEvent
+		_ = json.Unmarshal(data, &e)
+		return e.UserUUID == "ns-uuid" && e.Scene == int(types.SceneMCPToolCall) && e.Value == 1 && e.ResourceName == "github_search"
+	})).Return(nil).Once()
+	res, err := session.CallTool(ctx, &mcp.CallToolParams{Name: "github_search", Arguments: map[string]any{"query": "csghub"}})
+	require.Nil(t, err)
+	require.Equal(t, "found csghub", res.Content[0].(*mcp.TextContent).Text)
+
+	callLogStore.EXPECT().Create(mock.Anything, mock.MatchedBy(func(l *database.GatewayMCPCallLog) bool {
+		return l.Target == "github_delete_repo" && l.Status == types.MCPGatewayCallDenied && l.MCPServerName == "github"
+	})).Return(nil).Once()
+	_, err = session.CallTool(ctx, &mcp.CallToolParams{Name: "github_delete_repo", Arguments: map[string]any{}})
+	require.NotNil(t, err)
+
+	// the server name contains the separator of the prefixed tool names
+	callLogStore.EXPECT().Create(mock.Anything, mock.MatchedBy(func(l *database.GatewayMCPCallLog) bool {
+		return l.Target == "github_enterprise_search" && l.Status == types.MCPGatewayCallDenied &&
+			l.MCPServerID == 2 && l.MCPServerName == "github_enterprise"
+	})).Return(nil).Once()
+	_, err = session.CallTool(ctx, &mcp.CallToolParams{Name: "github_enterprise_search", Arguments: map[string]any{}})
+	require.NotNil(t, err)
+}
+
+func TestMCPGatewayComponent_NewServer_APIKeyNotFound(t *testing.T) {
+	ctx := context.TODO()
+	serverStore := mockdb.NewMockGatewayMCPServersStore(t)
+	accessTokenStore := mockdb.NewMockAccessTokenStore(t)
+	userStore := mockdb.NewMockUserStore(t)
+	orgStore := mockdb.NewMockOrgStore(t)
+	comp := &mcpGatewayComponentImpl{
+		serverStore:      serverStore,
+		accessTokenStore: accessTokenStore,
+		userStore:        userStore,
+		orgStore:         orgStore,
+		upstreams:        make(map[int64]*mcpUpstream),
+	}
+	caller := types.MCPGatewayCaller{Username: "user", NamespaceUUID: "ns-uuid", APIKey: "key"}
+	userStore.EXPECT().FindByUsername(ctx, "user").Return(database.User{ID: 3, Username: "user"}, nil)
+	orgStore.EXPECT().GetUserBelongOrgs(ctx, int64(3)).Return(nil, nil)
+	serverStore.EXPECT().ListForGateway(ctx, []string{"user"}).Return([]database.GatewayMCPServers{{ID: 1, Name: "github"}}, nil)
+
+	// the key of another app type has no tool policies, the session must be rejected
+	accessTokenStore.EXPECT().FindByToken(ctx, "key", "aigateway").Return(nil, errorx.ErrDatabaseNoRows).Once()
+	server, err := comp.NewServer(ctx, caller)
+	require.ErrorIs(t, err, errorx.ErrUnauthorized)
+	require.Nil(t, server)
+
+	dbErr := errors.New("connection refused")
+	accessTokenStore.EXPECT().FindByToken(ctx, "key", "aigateway").Return(nil, dbErr).Once()
+	server, err = comp.NewServer(ctx, caller)
+	require.ErrorIs(t, err, dbErr)
+	require.Nil(t, server)
+}
+
+func TestMCPGatewayComponent_NewServer_WithoutAPIKey(t *testing.T) {
+	ctx := context.TODO()
+	serverStore := mockdb.NewMockGatewayMCPServersStore(t)
+	userStore := mockdb.NewMockUserStore(t)
+	orgStore := mockdb.NewMockOrgStore(t)
+
+	upstream := newTestUpstreamMCPServer()
+	comp := &mcpGatewayComponentImpl{
+		serverStore: serverStore,
+		userStore:   userStore,
+		orgStore:    orgStore,
+		upstreams:   make(map[int64]*mcpUpstream),
+		connect: func(ctx context.Context, server *database.GatewayMCPServers) (*mcp.ClientSession, error) {
+			serverTransport, clientTransport := mcp.NewInMemoryTransports()
+			if _, err := upstream.Connect(ctx, serverTransport, nil); err != nil {
+				return nil, err
+			}
+			client := mcp.NewClient(&mcp.Implementation{Name: "test"}, nil)
+			return client.Connect(ctx, clientTransport, nil)
+		},
+	}
+
+	// the login session has no api key, so no tool policy applies
+	caller := types.MCPGatewayCaller{Username: "user", NamespaceUUID: "ns-uuid"}
+	userStore.EXPECT().FindByUsername(ctx, "user").Return(database.User{ID: 3, Username: "user"}, nil)
+	orgStore.EXPECT().GetUserBelongOrgs(ctx, int64(3)).Return(nil, nil)
+	serverStore.EXPECT().ListForGateway(ctx, []string{"user"}).Return([]database.GatewayMCPServers{
+		{ID: 1, Name: "github", Protocol: "streamable", ConfigHash: "h1"},
+	}, nil)
+
+	server, err := comp.NewServer(ctx, caller)
+	require.Nil(t, err)
+
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	_, err = server.Connect(ctx, serverTransport, nil)
+	require.Nil(t, err)
+	client := mcp.NewClient(&mcp.Implementation{Name: "agent"}, nil)
+	session, err := client.Connect(ctx, clientTransport, nil)
+	require.Nil(t, err)
+	defer session.Close()
+
+	tools, err := session.ListTools(ctx, nil)
+	require.Nil(t, err)
+	require.Len(t, tools.Tools, 2)
+}
+
+func TestMCPGatewayComponent_CreateToolPolicy(t *testing.T) {
+	ctx := context.TODO()
+	policyStore := mockdb.NewMockGatewayMCPToolPolicyStore(t)
+	accessTokenStore := mockdb.NewMockAccessTokenStore(t)
+	comp := &mcpGatewayComponentImpl{
+		policyStore:      policyStore,
+		accessTokenStore: accessTokenStore,
+	}
+
+	accessTokenStore.EXPECT().FindByID(ctx, int64(7)).Return(&database.AccessToken{
+		ID: 7, Application: types.AccessTokenAppAIGateway, User: &database.User{Username: "user"},
+	}, nil).Twice()
+	policyStore.EXPECT().Create(ctx, &database.GatewayMCPToolPolicy{
+		AccessTokenID: 7, Tool: "github_search", Action: types.MCPToolPolicyAllow,
+	}).Return(&database.GatewayMCPToolPolicy{ID: 1, Tool: "github_search", Action: types.MCPToolPolicyAllow}, nil)
+
+	policy, err := comp.CreateToolPolicy(ctx, types.CreateMCPToolPolicyReq{
+		APIKeyID: 7, Tool: " github_search ", Action: types.MCPToolPolicyAllow, CurrentUser: "user",
+	})
+	require.Nil(t, err)
+	require.Equal(t, int64(1), policy.ID)
+
+	_, err = comp.CreateToolPolicy(ctx, types.CreateMCPToolPolicyReq{
+		APIKeyID: 7, Tool: "github_search", Action: types.MCPToolPolicyAllow, CurrentUser: "other",
+	})
+	require.ErrorIs(t, err, errorx.ErrForbidden)
+
+	// only aigateway api keys have tool policies
+	accessTokenStore.EXPECT().FindByID(ctx, int64(8)).Return(&database.AccessToken{
+		ID: 8, Application: types.AccessTokenAppGit, User: &database.User{Username: "user"},
+	}, nil).Once()
+	_, err = comp.CreateToolPolicy(ctx, types.CreateMCPToolPolicyReq{
+		APIKeyID: 8, Tool: "github_search", Action: types.MCPToolPolicyAllow, CurrentUser: "user",
+	})
+	require.ErrorIs(t, err, errorx.ErrNotFound)
+}
+
+func TestMCPGatewayComponent_mcpToolAllowed(t *testing.T) {
+	require.True(t, mcpToolAllowed(nil, "github_search"))
+
+	policies := []database.GatewayMCPToolPolicy{
+		{Tool: "github_*", Action: types.MCPToolPolicyAllow},
+		{Tool: "github_delete_repo", Action: types.MCPToolPolicyDeny},
+	}
+	require.True(t, mcpToolAllowed(policies, "github_search"))
+	require.False(t, mcpToolAllowed(policies, "github_delete_repo"))
+	require.False(t, mcpToolAllowed(policies, "slack_post"))
+
+	require.False(t, mcpToolAllowed([]database.GatewayMCPToolPolicy{
+		{Tool: types.MCPToolPolicyWildcard, Action: types.MCPToolPolicyDeny},
+	}, "github_search"))
+}
+
+func TestMCPGatewayComponent_upstreamSession_NotBlockedBySlowServer(t *testing.T) {
+	ctx := context.TODO()
+	upstream := newTestUpstreamMCPServer()
+	slow := make(chan struct{})
+	comp := &mcpGatewayComponentImpl{
+		upstreams: make(map[int64]*mcpUpstream),
+		connect: func(ctx context.Context, server *database.GatewayMCPServers) (*mcp.ClientSession, error) {
+			if server.ID == 1 {
+				<-slow
+			}
+			serverTransport, clientTransport := mcp.NewInMemoryTransports()
+			if _, err := upstream.Connect(ctx, serverTransport, nil); err != nil {
+				return nil, err
+			}
+			client := mcp.NewClient(&mcp.Implementation{Name: "test"}, nil)
+			return client.Connect(ctx, clientTransport, nil)
+		},
+	}
+
+	done := make(chan error)
+	go func() {
+		_, err := comp.upstreamSession(ctx, &database.GatewayMCPServers{ID: 1, Name: "slow", ConfigHash: "h1"})
+		done <- err
+	}()
+	session, err := comp.upstreamSession(ctx, &database.GatewayMCPServers{ID: 2, Name: "fast", ConfigHash: "h2"})
+	require.Nil(t, err)
+	require.NotNil(t, session)
+
+	close(slow)
+	require.Nil(t, <-done)
+	require.Len(t, comp.upstreams, 2)
+}
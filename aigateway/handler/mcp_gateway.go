@@ -0,0 +1,216 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	gwcomp "opencsg.com/csghub-server/aigateway/component"
+	"opencsg.com/csghub-server/api/httpbase"
+	"opencsg.com/csghub-server/common/config"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+	"opencsg.com/csghub-server/common/utils/common"
+)
+
+type MCPGatewayHandler interface {
+	Serve(c *gin.Context)
+	CreateToolPolicy(c *gin.Context)
+	ListToolPolicies(c *gin.Context)
+	DeleteToolPolicy(c *gin.Context)
+	ListCallLogs(c *gin.Context)
+}
+
+type MCPGatewayHandlerImpl struct {
+	gatewayComp gwcomp.MCPGatewayComponent
+	mcpHandler  http.Handler
+}
+
+type mcpGatewayCallerKey struct{}
+
+func NewMCPGatewayHandler(config *config.Config) (MCPGatewayHandler, error) {
+	h := &MCPGatewayHandlerImpl{
+		gatewayComp: gwcomp.NewMCPGatewayComponent(config),
+	}
+	sdkHandler := mcp.NewStreamableHTTPHandler(h.getServer, nil)
+	mcpHandler, err := wrapMCPSessionRouting(sdkHandler, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to init mcp session routing, %w", err)
+	}
+	h.mcpHandler = mcpHandler
+	return h, nil
+}
+
+// getServer builds the aggregated mcp server when a client initializes a new session
+func (h *MCPGatewayHandlerImpl) getServer(r *http.Request) *mcp.Server {
+	caller, ok := r.Context().Value(mcpGatewayCallerKey{}).(types.MCPGatewayCaller)
+	if !ok {
+		return nil
+	}
+	server, err := h.gatewayComp.NewServer(r.Context(), caller)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to build aggregated mcp server", slog.String("user", caller.Username), slog.Any("error", err))
+		return nil
+	}
+	return server
+}
+
+// Serve godoc
+// @Security     ApiKey
+// @Summary      Aggregated MCP endpoint
+// @Description  Streamable HTTP MCP endpoint that merges the tools, resources and prompts of all gateway MCP servers, tools are named {server}_{tool} and filtered by the tool policies of the API key
+// @Tags         AIGateway
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  string "OK"
+// @Failure      400  {object}  error "Bad request"
+// @Router       /v1/mcp/gateway [post]
+func (h *MCPGatewayHandlerImpl) Serve(c *gin.Context) {
+	caller := types.MCPGatewayCaller{
+		Username:      httpbase.GetCurrentUser(c),
+		NamespaceUUID: httpbase.GetCurrentNamespaceUUID(c),
+		APIKey:        httpbase.GetAccessToken(c),
+	}
+	req := c.Request.WithContext(context.WithValue(c.Request.Context(), mcpGatewayCallerKey{}, caller))
+	h.mcpHandler.ServeHTTP(c.Writer, req)
+}
+
+// CreateToolPolicy godoc
+// @Security     ApiKey
+// @Summary      Create MCP tool policy
+// @Description  Bind an allow or deny rule of an aggregated MCP tool to an API key of current user
+// @Tags         AIGateway
+// @Accept       json
+// @Produce      json
+// @Param        body body types.CreateMCPToolPolicyReq true "tool policy"
+// @Success      200  {object}  types.Response{data=types.MCPToolPolicy} "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /v1/mcp/gateway/policies [post]
+func (h *MCPGatewayHandlerImpl) CreateToolPolicy(c *gin.Context) {
+	var req types.CreateMCPToolPolicyReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		slog.ErrorContext(c.Request.Context(), "Bad request format", slog.Any("error", err))
+		httpbase.BadRequestWithExt(c, errorx.ReqBodyFormat(err, nil))
+		return
+	}
+	req.CurrentUser = httpbase.GetCurrentUser(c)
+	policy, err := h.gatewayComp.CreateToolPolicy(c.Request.Context(), req)
+	if err != nil {
+		handleMCPGatewayError(c, "failed to create mcp tool policy", err)
+		return
+	}
+	httpbase.OK(c, policy)
+}
+
+// ListToolPolicies godoc
+// @Security     ApiKey
+// @Summary      List MCP tool policies
+// @Description  List the aggregated MCP tool policies bound to an API key of current user
+// @Tags         AIGateway
+// @Accept       json
+// @Produce      json
+// @Param        api_key_id query int true "access token id of the api key"
+// @Success      200  {object}  types.Response{data=[]types.MCPToolPolicy} "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /v1/mcp/gateway/policies [get]
+func (h *MCPGatewayHandlerImpl) ListToolPolicies(c *gin.Context) {
+	apiKeyID, err := strconv.ParseInt(c.Query("api_key_id"), 10, 64)
+	if err != nil {
+		httpbase.BadRequest(c, "invalid api_key_id")
+		return
+	}
+	policies, err := h.gatewayComp.ListToolPolicies(c.Request.Context(), httpbase.GetCurrentUser(c), apiKeyID)
+	if err != nil {
+		handleMCPGatewayError(c, "failed to list mcp tool policies", err)
+		return
+	}
+	httpbase.OK(c, policies)
+}
+
+// DeleteToolPolicy godoc
+// @Security     ApiKey
+// @Summary      Delete MCP tool policy
+// @Description  Delete an aggregated MCP tool policy of an API key of current user
+// @Tags         AIGateway
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "policy id"
+// @Param        api_key_id query int true "access token id of the api key"
+// @Success      200  {object}  types.Response{} "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /v1/mcp/gateway/policies/{id} [delete]
+func (h *MCPGatewayHandlerImpl) DeleteToolPolicy(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		httpbase.BadRequest(c, "invalid policy id")
+		return
+	}
+	apiKeyID, err := strconv.ParseInt(c.Query("api_key_id"), 10, 64)
+	if err != nil {
+		httpbase.BadRequest(c, "invalid api_key_id")
+		return
+	}
+	err = h.gatewayComp.DeleteToolPolicy(c.Request.Context(), httpbase.GetCurrentUser(c), apiKeyID, id)
+	if err != nil {
+		handleMCPGatewayError(c, "failed to delete mcp tool policy", err)
+		return
+	}
+	httpbase.OK(c, nil)
+}
+
+// ListCallLogs godoc
+// @Security     ApiKey
+// @Summary      List MCP call logs
+// @Description  List the tool calls, resource reads and prompt gets proxied by the aggregated MCP endpoint for current namespace
+// @Tags         AIGateway
+// @Accept       json
+// @Produce      json
+// @Param        server_name query string false "mcp server name"
+// @Param        status query string false "call status" Enums(success, error, denied)
+// @Param        per query int false "per" default(20)
+// @Param        page query int false "page" default(1)
+// @Success      200  {object}  types.ResponseWithTotal{data=[]types.MCPGatewayCallLog,total=int} "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /v1/mcp/gateway/logs [get]
+func (h *MCPGatewayHandlerImpl) ListCallLogs(c *gin.Context) {
+	var filter types.MCPGatewayCallLogFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		httpbase.BadRequest(c, err.Error())
+		return
+	}
+	per, page, err := common.GetPerAndPageFromContext(c)
+	if err != nil {
+		httpbase.BadRequest(c, err.Error())
+		return
+	}
+	filter.Per = per
+	filter.Page = page
+	filter.NamespaceUUID = httpbase.GetCurrentNamespaceUUID(c)
+	logs, total, err := h.gatewayComp.ListCallLogs(c.Request.Context(), filter)
+	if err != nil {
+		handleMCPGatewayError(c, "failed to list mcp call logs", err)
+		return
+	}
+	httpbase.OKWithTotal(c, logs, total)
+}
+
+func handleMCPGatewayError(c *gin.Context, msg string, err error) {
+	slog.ErrorContext(c.Request.Context(), msg, slog.Any("error", err))
+	switch {
+	case errors.Is(err, errorx.ErrNotFound), errors.Is(err, errorx.ErrDatabaseNoRows):
+		httpbase.NotFoundError(c, err)
+	case errors.Is(err, errorx.ErrForbidden):
+		httpbase.ForbiddenError(c, err)
+	default:
+		httpbase.ServerError(c, err)
+	}
+}
@@ -0,0 +1,29 @@
+//go:build ee || saas
+
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"opencsg.com/csghub-server/builder/store/cache"
+	"opencsg.com/csghub-server/common/config"
+)
+
+// wrapMCPSessionRouting routes aggregated mcp sessions to the aigateway instance that owns them
+func wrapMCPSessionRouting(sdkHandler http.Handler, config *config.Config) (http.Handler, error) {
+	redis, err := cache.NewCache(context.Background(), cache.RedisConfig{
+		Addr:     config.Redis.Endpoint,
+		Username: config.Redis.User,
+		Password: config.Redis.Password,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create redis client, %w", err)
+	}
+	selfAddr := config.AIGateway.AdvertiseAddr
+	if selfAddr == "" {
+		selfAddr = fmt.Sprintf("127.0.0.1:%d", config.AIGateway.Port)
+	}
+	return NewMCPProxyAwareHandler(sdkHandler, NewMCPSessionRegistry(redis), selfAddr), nil
+}
@@ -0,0 +1,14 @@
+//go:build !ee && !saas
+
+package handler
+
+import (
+	"net/http"
+
+	"opencsg.com/csghub-server/common/config"
+)
+
+// wrapMCPSessionRouting keeps sessions in process, ce runs a single aigateway instance
+func wrapMCPSessionRouting(sdkHandler http.Handler, _ *config.Config) (http.Handler, error) {
+	return sdkHandler, nil
+}
@@ -106,7 +106,11 @@ func NewRouter(config *config.Config) (*gin.Engine, func(), error) {
 	if err != nil {
 		return nil, nil, fmt.Errorf("error creating mcp proxy handler :%w", err)
 	}
-	createMCPRoute(v1Group, mcpProxy)
+	mcpGateway, err := handler.NewMCPGatewayHandler(config)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating mcp gateway handler :%w", err)
+	}
+	createMCPRoute(v1Group, mcpProxy, mcpGateway, middlewareCollection)
 
 	cleanup, err := extendRoutes(v1Group, apiV1Group, adminGroup, middlewareCollection, config)
 	if err != nil {
@@ -130,9 +134,16 @@ func combineCleanup(cleanups ...func()) func() {
 	}
 }
 
-func createMCPRoute(v1Group *gin.RouterGroup, mcpProxy handler.MCPProxyHandler) {
+func createMCPRoute(v1Group *gin.RouterGroup, mcpProxy handler.MCPProxyHandler, mcpGateway handler.MCPGatewayHandler, middlewareCollection middleware.MiddlewareCollection) {
 	mcpGroup := v1Group.Group("mcp")
 	mcpGroup.GET("/resources", mcpProxy.Resources)
 
+	gatewayGroup := mcpGroup.Group("/gateway", middlewareCollection.Auth.MustUserOrgApiKey)
+	gatewayGroup.Any("", mcpGateway.Serve)
+	gatewayGroup.GET("/policies", mcpGateway.ListToolPolicies)
+	gatewayGroup.POST("/policies", mcpGateway.CreateToolPolicy)
+	gatewayGroup.DELETE("/policies/:id", mcpGateway.DeleteToolPolicy)
+	gatewayGroup.GET("/logs", mcpGateway.ListCallLogs)
+
 	mcpGroup.Any("/:servicename/*any", mcpProxy.ProxyToApi(""))
 }
@@ -0,0 +1,412 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"opencsg.com/csghub-server/api/httpbase"
+	"opencsg.com/csghub-server/common/config"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+	"opencsg.com/csghub-server/common/utils/common"
+	"opencsg.com/csghub-server/component"
+)
+
+func NewCredentialHandler(config *config.Config) (*CredentialHandler, error) {
+	c, err := component.NewCredentialComponent(config)
+	if err != nil {
+		return nil, err
+	}
+	return &CredentialHandler{c: c}, nil
+}
+
+type CredentialHandler struct {
+	c component.CredentialComponent
+}
+
+// ListProviders godoc
+// @Security     ApiKey
+// @Summary      List the supported credential providers
+// @Tags         Credential
+// @Produce      json
+// @Success      200  {object}  types.Response{data=[]types.CredentialProviderDefinition} "OK"
+// @Router       /agent/credentials/providers [get]
+func (h *CredentialHandler) ListProviders(ctx *gin.Context) {
+	httpbase.OK(ctx, h.c.ListProviders(ctx.Request.Context()))
+}
+
+// Verify godoc
+// @Security     ApiKey
+// @Summary      Verify a credential with its provider
+// @Description  the credential is not saved
+// @Tags         Credential
+// @Accept       json
+// @Produce      json
+// @Param        body body types.VerifyCredentialRequest true "credential"
+// @Success      200  {object}  types.Response{} "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /agent/credentials/verify [post]
+func (h *CredentialHandler) Verify(ctx *gin.Context) {
+	var req types.VerifyCredentialRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "Bad request format", "error", err)
+		httpbase.BadRequestWithExt(ctx, errorx.ReqParamInvalid(err, nil))
+		return
+	}
+	if err := h.c.Verify(ctx.Request.Context(), &req); err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "failed to verify credential", slog.String("provider", req.Provider), slog.Any("error", err))
+		handleCredentialError(ctx, err)
+		return
+	}
+	httpbase.OK(ctx, nil)
+}
+
+// Create godoc
+// @Security     ApiKey
+// @Summary      Create a credential of the current user or an organization
+// @Tags         Credential
+// @Accept       json
+// @Produce      json
+// @Param        namespace query string false "organization name, the credential belongs to the current user if empty"
+// @Param        body body types.CreateCredentialRequest true "credential"
+// @Success      200  {object}  types.Response{data=types.Credential} "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      403  {object}  types.APIForbidden "Forbidden"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /agent/credentials [post]
+func (h *CredentialHandler) Create(ctx *gin.Context) {
+	var req types.CreateCredentialRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "Bad request format", "error", err)
+		httpbase.BadRequestWithExt(ctx, errorx.ReqParamInvalid(err, nil))
+		return
+	}
+	owner := credentialOwner(ctx)
+	cred, err := h.c.Create(ctx.Request.Context(), owner, &req)
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "failed to create credential", slog.String("namespace", owner.Namespace),
+			slog.String("credential_name", req.CredentialName), slog.Any("error", err))
+		handleCredentialError(ctx, err)
+		return
+	}
+	httpbase.OK(ctx, cred)
+}
+
+// List godoc
+// @Security     ApiKey
+// @Summary      List the credentials of the current user or an organization
+// @Description  the credential values are never returned
+// @Tags         Credential
+// @Produce      json
+// @Param        namespace query string false "organization name, the credentials of the current user are listed if empty"
+// @Param        search query string false "search by credential name"
+// @Param        per query int false "per" default(20)
+// @Param        page query int false "page" default(1)
+// @Success      200  {object}  types.ResponseWithTotal{data=[]types.Credential} "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      403  {object}  types.APIForbidden "Forbidden"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /agent/credentials [get]
+func (h *CredentialHandler) List(ctx *gin.Context) {
+	per, page, err := common.GetPerAndPageFromContext(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "Bad request format", "error", err)
+		httpbase.BadRequestWithExt(ctx, errorx.ReqParamInvalid(err, nil))
+		return
+	}
+	owner := credentialOwner(ctx)
+	creds, total, err := h.c.List(ctx.Request.Context(), owner, types.CredentialFilter{Search: ctx.Query("search")}, per, page)
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "failed to list credentials", slog.String("namespace", owner.Namespace), slog.Any("error", err))
+		handleCredentialError(ctx, err)
+		return
+	}
+	httpbase.OKWithTotal(ctx, creds, total)
+}
+
+// Get godoc
+// @Security     ApiKey
+// @Summary      Get a credential of the current user or an organization
+// @Description  the credential values are never returned
+// @Tags         Credential
+// @Produce      json
+// @Param        credential_name path string true "credential name"
+// @Param        namespace query string false "organization name"
+// @Success      200  {object}  types.Response{data=types.Credential} "OK"
+// @Failure      403  {object}  types.APIForbidden "Forbidden"
+// @Failure      404  {object}  types.APINotFound "Not found"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /agent/credentials/{credential_name} [get]
+func (h *CredentialHandler) Get(ctx *gin.Context) {
+	owner := credentialOwner(ctx)
+	name := ctx.Param("credential_name")
+	cred, err := h.c.Get(ctx.Request.Context(), owner, name)
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "failed to get credential", slog.String("namespace", owner.Namespace),
+			slog.String("credential_name", name), slog.Any("error", err))
+		handleCredentialError(ctx, err)
+		return
+	}
+	httpbase.OK(ctx, cred)
+}
+
+// Update godoc
+// @Security     ApiKey
+// @Summary      Update the description or metadata of a credential
+// @Tags         Credential
+// @Accept       json
+// @Produce      json
+// @Param        credential_name path string true "credential name"
+// @Param        namespace query string false "organization name"
+// @Param        body body types.UpdateCredentialRequest true "credential"
+// @Success      200  {object}  types.Response{data=types.Credential} "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      403  {object}  types.APIForbidden "Forbidden"
+// @Failure      404  {object}  types.APINotFound "Not found"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /agent/credentials/{credential_name} [put]
+func (h *CredentialHandler) Update(ctx *gin.Context) {
+	var req types.UpdateCredentialRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "Bad request format", "error", err)
+		httpbase.BadRequestWithExt(ctx, errorx.ReqParamInvalid(err, nil))
+		return
+	}
+	owner := credentialOwner(ctx)
+	name := ctx.Param("credential_name")
+	cred, err := h.c.Update(ctx.Request.Context(), owner, name, &req)
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "failed to update credential", slog.String("namespace", owner.Namespace),
+			slog.String("credential_name", name), slog.Any("error", err))
+		handleCredentialError(ctx, err)
+		return
+	}
+	httpbase.OK(ctx, cred)
+}
+
+// Rotate godoc
+// @Security     ApiKey
+// @Summary      Replace the values of a credential
+// @Description  the tasks granted the credential get the new values when they refresh it
+// @Tags         Credential
+// @Accept       json
+// @Produce      json
+// @Param        credential_name path string true "credential name"
+// @Param        namespace query string false "organization name"
+// @Param        body body types.RotateCredentialRequest true "new credential values"
+// @Success      200  {object}  types.Response{data=types.Credential} "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      403  {object}  types.APIForbidden "Forbidden"
+// @Failure      404  {object}  types.APINotFound "Not found"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /agent/credentials/{credential_name}/rotate [post]
+func (h *CredentialHandler) Rotate(ctx *gin.Context) {
+	var req types.RotateCredentialRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "Bad request format", "error", err)
+		httpbase.BadRequestWithExt(ctx, errorx.ReqParamInvalid(err, nil))
+		return
+	}
+	owner := credentialOwner(ctx)
+	name := ctx.Param("credential_name")
+	cred, err := h.c.Rotate(ctx.Request.Context(), owner, name, &req)
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "failed to rotate credential", slog.String("namespace", owner.Namespace),
+			slog.String("credential_name", name), slog.Any("error", err))
+		handleCredentialError(ctx, err)
+		return
+	}
+	httpbase.OK(ctx, cred)
+}
+
+// Delete godoc
+// @Security     ApiKey
+// @Summary      Delete a credential
+// @Tags         Credential
+// @Produce      json
+// @Param        credential_name path string true "credential name"
+// @Param        namespace query string false "organization name"
+// @Success      200  {object}  types.Response{} "OK"
+// @Failure      403  {object}  types.APIForbidden "Forbidden"
+// @Failure      404  {object}  types.APINotFound "Not found"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /agent/credentials/{credential_name} [delete]
+func (h *CredentialHandler) Delete(ctx *gin.Context) {
+	owner := credentialOwner(ctx)
+	name := ctx.Param("credential_name")
+	if err := h.c.Delete(ctx.Request.Context(), owner, name); err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "failed to delete credential", slog.String("namespace", owner.Namespace),
+			slog.String("credential_name", name), slog.Any("error", err))
+		handleCredentialError(ctx, err)
+		return
+	}
+	httpbase.OK(ctx, nil)
+}
+
+// CreateGrant godoc
+// @Security     ApiKey
+// @Summary      Grant credentials to a task
+// @Description  returns a short-lived runtime token, the task reads the granted credentials with it from the runtime apis
+// @Tags         Credential
+// @Accept       json
+// @Produce      json
+// @Param        namespace query string false "organization name"
+// @Param        body body types.CreateTaskCredentialGrantRequest true "grant"
+// @Success      200  {object}  types.Response{data=types.CreateTaskCredentialGrantResponse} "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      403  {object}  types.APIForbidden "Forbidden"
+// @Failure      404  {object}  types.APINotFound "Not found"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /agent/credentials/grants [post]
+func (h *CredentialHandler) CreateGrant(ctx *gin.Context) {
+	var req types.CreateTaskCredentialGrantRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "Bad request format", "error", err)
+		httpbase.BadRequestWithExt(ctx, errorx.ReqParamInvalid(err, nil))
+		return
+	}
+	owner := credentialOwner(ctx)
+	grant, err := h.c.CreateGrant(ctx.Request.Context(), owner, &req)
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "failed to grant credentials", slog.String("namespace", owner.Namespace),
+			slog.String("agent_id", req.AgentID), slog.Any("error", err))
+		handleCredentialError(ctx, err)
+		return
+	}
+	httpbase.OK(ctx, grant)
+}
+
+// RuntimeGet godoc
+// @Summary      Read a credential granted to the runtime session
+// @Description  authorized by the runtime credential token instead of the user token, every read is audited
+// @Tags         Credential
+// @Produce      json
+// @Param        Authorization header string true "Bearer runtime credential token"
+// @Param        credential_name path string true "credential name"
+// @Success      200  {object}  types.Response{data=types.RuntimeCredentialResponse} "OK"
+// @Failure      401  {object}  types.APIUnauthorized "Unauthorized"
+// @Failure      403  {object}  types.APIForbidden "Forbidden"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /agent/credentials/runtime/{credential_name} [get]
+func (h *CredentialHandler) RuntimeGet(ctx *gin.Context) {
+	token, ok := runtimeToken(ctx)
+	if !ok {
+		return
+	}
+	name := ctx.Param("credential_name")
+	resp, err := h.c.RuntimeGet(ctx.Request.Context(), token, name)
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "failed to read runtime credential", slog.String("credential_name", name), slog.Any("error", err))
+		handleCredentialError(ctx, err)
+		return
+	}
+	httpbase.OK(ctx, resp)
+}
+
+// RuntimeRefresh godoc
+// @Summary      Read the latest values of the credentials granted to the runtime session
+// @Description  refreshes all the granted credentials if the credential name is empty
+// @Tags         Credential
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Bearer runtime credential token"
+// @Param        body body types.RuntimeRefreshRequest false "credential name"
+// @Success      200  {object}  types.Response{data=[]types.RuntimeCredentialResponse} "OK"
+// @Failure      401  {object}  types.APIUnauthorized "Unauthorized"
+// @Failure      403  {object}  types.APIForbidden "Forbidden"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /agent/credentials/runtime/refresh [post]
+func (h *CredentialHandler) RuntimeRefresh(ctx *gin.Context) {
+	token, ok := runtimeToken(ctx)
+	if !ok {
+		return
+	}
+	var req types.RuntimeRefreshRequest
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			slog.ErrorContext(ctx.Request.Context(), "Bad request format", "error", err)
+			httpbase.BadRequestWithExt(ctx, errorx.ReqParamInvalid(err, nil))
+			return
+		}
+	}
+	resp, err := h.c.RuntimeRefresh(ctx.Request.Context(), token, &req)
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "failed to refresh runtime credentials", slog.String("credential_name", req.CredentialName),
+			slog.Any("error", err))
+		handleCredentialError(ctx, err)
+		return
+	}
+	httpbase.OK(ctx, resp)
+}
+
+// RuntimeRevokeSession godoc
+// @Summary      Revoke the runtime session
+// @Description  the credentials granted to the session can not be read any more
+// @Tags         Credential
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Bearer runtime credential token"
+// @Param        body body types.RuntimeSessionRevokeRequest true "session"
+// @Success      200  {object}  types.Response{} "OK"
+// @Failure      401  {object}  types.APIUnauthorized "Unauthorized"
+// @Failure      403  {object}  types.APIForbidden "Forbidden"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /agent/credentials/runtime/session/revoke [post]
+func (h *CredentialHandler) RuntimeRevokeSession(ctx *gin.Context) {
+	token, ok := runtimeToken(ctx)
+	if !ok {
+		return
+	}
+	var req types.RuntimeSessionRevokeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "Bad request format", "error", err)
+		httpbase.BadRequestWithExt(ctx, errorx.ReqParamInvalid(err, nil))
+		return
+	}
+	if err := h.c.RuntimeRevokeSession(ctx.Request.Context(), token, &req); err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "failed to revoke runtime session", slog.String("session_id", req.SessionID),
+			slog.Any("error", err))
+		handleCredentialError(ctx, err)
+		return
+	}
+	httpbase.OK(ctx, nil)
+}
+
+func credentialOwner(ctx *gin.Context) types.CredentialOwnerReq {
+	return types.CredentialOwnerReq{
+		CurrentUser: httpbase.GetCurrentUser(ctx),
+		Namespace:   ctx.Query("namespace"),
+	}
+}
+
+// runtimeToken returns the runtime credential token in the Authorization header, the runtime routes skip
+// the user authentication and are authorized by the token only
+func runtimeToken(ctx *gin.Context) (string, bool) {
+	token, found := strings.CutPrefix(ctx.GetHeader("Authorization"), types.RuntimeCredentialTokenType+" ")
+	if !found || strings.TrimSpace(token) == "" {
+		httpbase.UnauthorizedError(ctx, errorx.RuntimeCredentialTokenInvalid(errors.New("missing runtime credential token"), nil))
+		return "", false
+	}
+	return strings.TrimSpace(token), true
+}
+
+func handleCredentialError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, errorx.ErrRuntimeCredentialTokenInvalid):
+		httpbase.UnauthorizedError(ctx, err)
+	case errors.Is(err, errorx.ErrForbidden), errors.Is(err, errorx.ErrRuntimeCredentialGrantUnavailable):
+		httpbase.ForbiddenError(ctx, err)
+	case errors.Is(err, errorx.ErrNotFound), errors.Is(err, errorx.ErrDatabaseNoRows):
+		httpbase.NotFoundError(ctx, err)
+	case errors.Is(err, errorx.ErrCredentialNameAlreadyExists):
+		httpbase.ConflictError(ctx, err)
+	case errors.Is(err, errorx.ErrReqParamInvalid), errors.Is(err, errorx.ErrCredentialVerifyURLInvalid),
+		errors.Is(err, errorx.ErrCredentialVerifyTokenInvalid), errors.Is(err, errorx.ErrCredentialVerifyFailed):
+		httpbase.BadRequestWithExt(ctx, err)
+	default:
+		httpbase.ServerError(ctx, err)
+	}
+}
@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	mockcomponent "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/component"
+	"opencsg.com/csghub-server/builder/testutil"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+)
+
+type CredentialTester struct {
+	*testutil.GinTester
+	handler *CredentialHandler
+	mocks   struct {
+		comp *mockcomponent.MockCredentialComponent
+	}
+}
+
+func NewCredentialTester(t *testing.T) *CredentialTester {
+	tester := &CredentialTester{GinTester: testutil.NewGinTester()}
+	tester.mocks.comp = mockcomponent.NewMockCredentialComponent(t)
+	tester.handler = &CredentialHandler{c: tester.mocks.comp}
+	return tester
+}
+
+func (t *CredentialTester) WithHandleFunc(fn func(h *CredentialHandler) gin.HandlerFunc) *CredentialTester {
+	t.Handler(fn(t.handler))
+	return t
+}
+
+func TestCredentialHandler_Create(t *testing.T) {
+	tester := NewCredentialTester(t).WithHandleFunc(func(h *CredentialHandler) gin.HandlerFunc {
+		return h.Create
+	})
+	tester.WithUser().WithQuery("namespace", "org")
+
+	req := &types.CreateCredentialRequest{
+		CredentialName: "hf", Provider: "huggingface", AuthType: "token", Credential: map[string]string{"token": "hf_abc"},
+	}
+	cred := &types.Credential{ID: 1, CredentialName: "hf", Provider: "huggingface", AuthType: "token", Status: "active"}
+	tester.mocks.comp.EXPECT().Create(tester.Ctx(), types.CredentialOwnerReq{CurrentUser: "u", Namespace: "org"}, req).Return(cred, nil)
+
+	tester.WithBody(t, req).Execute()
+	tester.ResponseEq(t, http.StatusOK, tester.OKText, cred)
+}
+
+func TestCredentialHandler_CreateDuplicated(t *testing.T) {
+	tester := NewCredentialTester(t).WithHandleFunc(func(h *CredentialHandler) gin.HandlerFunc {
+		return h.Create
+	})
+	tester.WithUser()
+
+	req := &types.CreateCredentialRequest{
+		CredentialName: "hf", Provider: "huggingface", AuthType: "token", Credential: map[string]string{"token": "hf_abc"},
+	}
+	tester.mocks.comp.EXPECT().Create(tester.Ctx(), types.CredentialOwnerReq{CurrentUser: "u"}, req).
+		Return(nil, errorx.CredentialNameAlreadyExists(nil, errorx.Ctx().Set("credential_name", "hf")))
+
+	tester.WithBody(t, req).Execute()
+	tester.ResponseEqCode(t, http.StatusConflict)
+}
+
+func TestCredentialHandler_List(t *testing.T) {
+	tester := NewCredentialTester(t).WithHandleFunc(func(h *CredentialHandler) gin.HandlerFunc {
+		return h.List
+	})
+	tester.WithUser().WithQuery("search", "hf").AddPagination(1, 10)
+
+	creds := []types.Credential{{ID: 1, CredentialName: "hf"}}
+	tester.mocks.comp.EXPECT().List(tester.Ctx(), types.CredentialOwnerReq{CurrentUser: "u"}, types.CredentialFilter{Search: "hf"}, 10, 1).
+		Return(creds, 1, nil)
+
+	tester.Execute()
+	tester.ResponseEqSimple(t, http.StatusOK, gin.H{"msg": "OK", "data": creds, "total": 1})
+}
+
+func TestCredentialHandler_RuntimeGet(t *testing.T) {
+	tester := NewCredentialTester(t).WithHandleFunc(func(h *CredentialHandler) gin.HandlerFunc {
+		return h.RuntimeGet
+	})
+	tester.WithParam("credential_name", "hf").WithHeader("Authorization", "Bearer runtime-token")
+
+	resp := &types.RuntimeCredentialResponse{CredentialName: "hf", Credential: map[string]string{"token": "hf_abc"}}
+	tester.mocks.comp.EXPECT().RuntimeGet(tester.Ctx(), "runtime-token", "hf").Return(resp, nil)
+
+	tester.Execute()
+	tester.ResponseEq(t, http.StatusOK, tester.OKText, resp)
+}
+
+func TestCredentialHandler_RuntimeGetWithoutToken(t *testing.T) {
+	tester := NewCredentialTester(t).WithHandleFunc(func(h *CredentialHandler) gin.HandlerFunc {
+		return h.RuntimeGet
+	})
+	tester.WithParam("credential_name", "hf")
+
+	tester.Execute()
+	tester.ResponseEqCode(t, http.StatusUnauthorized)
+}
+
+func TestCredentialHandler_RuntimeGetNotGranted(t *testing.T) {
+	tester := NewCredentialTester(t).WithHandleFunc(func(h *CredentialHandler) gin.HandlerFunc {
+		return h.RuntimeGet
+	})
+	tester.WithParam("credential_name", "hf").WithHeader("Authorization", "Bearer runtime-token")
+
+	tester.mocks.comp.EXPECT().RuntimeGet(tester.Ctx(), "runtime-token", "hf").
+		Return(nil, errorx.RuntimeCredentialGrantUnavailable(nil, nil))
+
+	tester.Execute()
+	tester.ResponseEqCode(t, http.StatusForbidden)
+}
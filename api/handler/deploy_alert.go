@@ -0,0 +1,233 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"opencsg.com/csghub-server/api/httpbase"
+	"opencsg.com/csghub-server/common/config"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+	"opencsg.com/csghub-server/common/utils/common"
+	"opencsg.com/csghub-server/component"
+)
+
+func NewDeployAlertHandler(config *config.Config) (*DeployAlertHandler, error) {
+	c, err := component.NewDeployAlertComponent(config)
+	if err != nil {
+		return nil, err
+	}
+	return &DeployAlertHandler{c: c}, nil
+}
+
+type DeployAlertHandler struct {
+	c component.DeployAlertComponent
+}
+
+// ListRules godoc
+// @Security     ApiKey
+// @Summary      List the alert rules of a deploy
+// @Tags         Deploy
+// @Produce      json
+// @Param        id path int true "deploy id"
+// @Success      200  {object}  types.Response{data=[]types.DeployAlertRule} "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      403  {object}  types.APIForbidden "Forbidden"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /deploys/{id}/alert_rules [get]
+func (h *DeployAlertHandler) ListRules(ctx *gin.Context) {
+	deployID, ok := parseDeployAlertID(ctx, "id")
+	if !ok {
+		return
+	}
+	req := types.DeployActReq{
+		CurrentUser: httpbase.GetCurrentUser(ctx),
+		DeployID:    deployID,
+	}
+	rules, err := h.c.ListRules(ctx.Request.Context(), req)
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "failed to list deploy alert rules", slog.Any("req", req), slog.Any("error", err))
+		handleDeployAlertError(ctx, err)
+		return
+	}
+	httpbase.OK(ctx, rules)
+}
+
+// CreateRule godoc
+// @Security     ApiKey
+// @Summary      Create an alert rule of a deploy
+// @Description  the alert fires when the metric breaches the threshold for the duration, and resolves once it no longer does, the owners are notified by internal messages and emails
+// @Tags         Deploy
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "deploy id"
+// @Param        body body types.DeployAlertRuleReq true "alert rule"
+// @Success      200  {object}  types.Response{data=types.DeployAlertRule} "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      403  {object}  types.APIForbidden "Forbidden"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /deploys/{id}/alert_rules [post]
+func (h *DeployAlertHandler) CreateRule(ctx *gin.Context) {
+	req, ok := h.bindRuleReq(ctx, false)
+	if !ok {
+		return
+	}
+	rule, err := h.c.CreateRule(ctx.Request.Context(), req)
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "failed to create deploy alert rule", slog.Any("req", req), slog.Any("error", err))
+		handleDeployAlertError(ctx, err)
+		return
+	}
+	httpbase.OK(ctx, rule)
+}
+
+// UpdateRule godoc
+// @Security     ApiKey
+// @Summary      Update an alert rule of a deploy
+// @Tags         Deploy
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "deploy id"
+// @Param        rule_id path int true "alert rule id"
+// @Param        body body types.DeployAlertRuleReq true "alert rule"
+// @Success      200  {object}  types.Response{data=types.DeployAlertRule} "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      403  {object}  types.APIForbidden "Forbidden"
+// @Failure      404  {object}  types.APINotFound "Not found"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /deploys/{id}/alert_rules/{rule_id} [put]
+func (h *DeployAlertHandler) UpdateRule(ctx *gin.Context) {
+	req, ok := h.bindRuleReq(ctx, true)
+	if !ok {
+		return
+	}
+	rule, err := h.c.UpdateRule(ctx.Request.Context(), req)
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "failed to update deploy alert rule", slog.Any("req", req), slog.Any("error", err))
+		handleDeployAlertError(ctx, err)
+		return
+	}
+	httpbase.OK(ctx, rule)
+}
+
+// DeleteRule godoc
+// @Security     ApiKey
+// @Summary      Delete an alert rule of a deploy
+// @Tags         Deploy
+// @Produce      json
+// @Param        id path int true "deploy id"
+// @Param        rule_id path int true "alert rule id"
+// @Success      200  {object}  types.Response{} "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      403  {object}  types.APIForbidden "Forbidden"
+// @Failure      404  {object}  types.APINotFound "Not found"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /deploys/{id}/alert_rules/{rule_id} [delete]
+func (h *DeployAlertHandler) DeleteRule(ctx *gin.Context) {
+	deployID, ok := parseDeployAlertID(ctx, "id")
+	if !ok {
+		return
+	}
+	ruleID, ok := parseDeployAlertID(ctx, "rule_id")
+	if !ok {
+		return
+	}
+	req := &types.DeployAlertRuleReq{
+		CurrentUser: httpbase.GetCurrentUser(ctx),
+		DeployID:    deployID,
+		RuleID:      ruleID,
+	}
+	err := h.c.DeleteRule(ctx.Request.Context(), req)
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "failed to delete deploy alert rule", slog.Any("req", req), slog.Any("error", err))
+		handleDeployAlertError(ctx, err)
+		return
+	}
+	httpbase.OK(ctx, nil)
+}
+
+// ListEvents godoc
+// @Security     ApiKey
+// @Summary      List the alerts fired and resolved of a deploy
+// @Tags         Deploy
+// @Produce      json
+// @Param        id path int true "deploy id"
+// @Param        per query int false "per" default(20)
+// @Param        page query int false "page index" default(1)
+// @Success      200  {object}  types.ResponseWithTotal{data=[]types.DeployAlertEvent} "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      403  {object}  types.APIForbidden "Forbidden"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /deploys/{id}/alert_events [get]
+func (h *DeployAlertHandler) ListEvents(ctx *gin.Context) {
+	deployID, ok := parseDeployAlertID(ctx, "id")
+	if !ok {
+		return
+	}
+	per, page, err := common.GetPerAndPageFromContext(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "Bad request format", "error", err)
+		httpbase.BadRequestWithExt(ctx, errorx.ReqParamInvalid(err, nil))
+		return
+	}
+	req := &types.DeployAlertEventsReq{
+		CurrentUser: httpbase.GetCurrentUser(ctx),
+		DeployID:    deployID,
+		Per:         per,
+		Page:        page,
+	}
+	events, total, err := h.c.ListEvents(ctx.Request.Context(), req)
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "failed to list deploy alert events", slog.Any("req", req), slog.Any("error", err))
+		handleDeployAlertError(ctx, err)
+		return
+	}
+	httpbase.OKWithTotal(ctx, events, total)
+}
+
+func (h *DeployAlertHandler) bindRuleReq(ctx *gin.Context, withRuleID bool) (*types.DeployAlertRuleReq, bool) {
+	deployID, ok := parseDeployAlertID(ctx, "id")
+	if !ok {
+		return nil, false
+	}
+	var req types.DeployAlertRuleReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "Bad request format", "error", err)
+		httpbase.BadRequestWithExt(ctx, errorx.ReqParamInvalid(err, nil))
+		return nil, false
+	}
+	if withRuleID {
+		req.RuleID, ok = parseDeployAlertID(ctx, "rule_id")
+		if !ok {
+			return nil, false
+		}
+	}
+	req.CurrentUser = httpbase.GetCurrentUser(ctx)
+	req.DeployID = deployID
+	return &req, true
+}
+
+func parseDeployAlertID(ctx *gin.Context, name string) (int64, bool) {
+	id, err := strconv.ParseInt(ctx.Param(name), 10, 64)
+	if err != nil || id <= 0 {
+		slog.ErrorContext(ctx.Request.Context(), "Bad request format", "error", err)
+		httpbase.BadRequestWithExt(ctx, errorx.ReqParamInvalid(err, errorx.Ctx().Set("param", name)))
+		return 0, false
+	}
+	return id, true
+}
+
+func handleDeployAlertError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, errorx.ErrForbidden):
+		httpbase.ForbiddenError(ctx, err)
+	case errors.Is(err, errorx.ErrDatabaseNoRows), errors.Is(err, errorx.ErrNotFound):
+		httpbase.NotFoundError(ctx, err)
+	case errors.Is(err, errorx.ErrReqParamInvalid):
+		httpbase.BadRequestWithExt(ctx, err)
+	default:
+		httpbase.ServerError(ctx, err)
+	}
+}
@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	mockcomponent "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/component"
+	"opencsg.com/csghub-server/builder/testutil"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+)
+
+type DeployAlertTester struct {
+	*testutil.GinTester
+	handler *DeployAlertHandler
+	mocks   struct {
+		comp *mockcomponent.MockDeployAlertComponent
+	}
+}
+
+func NewDeployAlertTester(t *testing.T) *DeployAlertTester {
+	tester := &DeployAlertTester{GinTester: testutil.NewGinTester()}
+	tester.mocks.comp = mockcomponent.NewMockDeployAlertComponent(t)
+	tester.handler = &DeployAlertHandler{c: tester.mocks.comp}
+	tester.WithParam("id", "1")
+	return tester
+}
+
+func (t *DeployAlertTester) WithHandleFunc(fn func(h *DeployAlertHandler) gin.HandlerFunc) *DeployAlertTester {
+	t.Handler(fn(t.handler))
+	return t
+}
+
+func TestDeployAlertHandler_CreateRule(t *testing.T) {
+	tester := NewDeployAlertTester(t).WithHandleFunc(func(h *DeployAlertHandler) gin.HandlerFunc {
+		return h.CreateRule
+	})
+	tester.WithUser()
+
+	rule := &types.DeployAlertRule{ID: 2, DeployID: 1, Metric: types.DeployAlertMetricErrorRate, Threshold: 0.1}
+	tester.mocks.comp.EXPECT().CreateRule(tester.Ctx(), mock.MatchedBy(func(req *types.DeployAlertRuleReq) bool {
+		return req.DeployID == 1 && req.CurrentUser == "u" && req.Metric == types.DeployAlertMetricErrorRate &&
+			req.Threshold == 0.1 && req.DurationSeconds == 300
+	})).Return(rule, nil)
+
+	tester.WithBody(t, &types.DeployAlertRuleReq{
+		Metric: types.DeployAlertMetricErrorRate, Threshold: 0.1, DurationSeconds: 300,
+	}).Execute()
+	tester.ResponseEq(t, http.StatusOK, tester.OKText, rule)
+}
+
+func TestDeployAlertHandler_UpdateRuleInvalid(t *testing.T) {
+	tester := NewDeployAlertTester(t).WithHandleFunc(func(h *DeployAlertHandler) gin.HandlerFunc {
+		return h.UpdateRule
+	})
+	tester.WithUser().WithParam("rule_id", "2")
+
+	tester.mocks.comp.EXPECT().UpdateRule(tester.Ctx(), mock.MatchedBy(func(req *types.DeployAlertRuleReq) bool {
+		return req.DeployID == 1 && req.RuleID == 2
+	})).Return(nil, errorx.ReqParamInvalid(errorx.ErrReqParamInvalid, nil))
+
+	tester.WithBody(t, &types.DeployAlertRuleReq{Metric: "cpu"}).Execute()
+	require.Equal(t, http.StatusBadRequest, tester.Response().Code)
+}
+
+func TestDeployAlertHandler_ListEvents(t *testing.T) {
+	tester := NewDeployAlertTester(t).WithHandleFunc(func(h *DeployAlertHandler) gin.HandlerFunc {
+		return h.ListEvents
+	})
+	tester.WithUser()
+
+	events := []types.DeployAlertEvent{{ID: 1, RuleID: 2, DeployID: 1, State: types.DeployAlertStateFiring}}
+	tester.mocks.comp.EXPECT().ListEvents(tester.Ctx(), &types.DeployAlertEventsReq{
+		CurrentUser: "u", DeployID: 1, Per: 10, Page: 1,
+	}).Return(events, 1, nil)
+
+	tester.AddPagination(1, 10).Execute()
+	tester.ResponseEqSimple(t, http.StatusOK, gin.H{
+		"msg":   "OK",
+		"data":  events,
+		"total": 1,
+	})
+}
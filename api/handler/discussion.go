@@ -234,6 +234,8 @@ func (h *DiscussionHandler) ShowDiscussion(ctx *gin.Context) {
 // @Param        repo_type path string true "repository type" Enums(models,datasets,codes,spaces)
 // @Param        namespace path string true "namespace"
 // @Param        name query string true "name"
+// @Param        status query string false "filter by status" Enums(open,closed,locked)
+// @Param        label query string false "filter by label name"
 // @Success      200  {object}  types.Response{data=types.ListRepoDiscussionResponse} "OK"
 // @Failure      400  {object}  types.APIBadRequest "Bad request"
 // @Failure      500  {object}  types.APIInternalServerError "Internal server error"
@@ -254,6 +256,10 @@ func (h *DiscussionHandler) ListRepoDiscussions(ctx *gin.Context) {
 	}
 
 	var req types.ListRepoDiscussionRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		httpbase.BadRequestWithExt(ctx, errorx.ReqParamInvalid(err, nil))
+		return
+	}
 	req.CurrentUser = currentUser
 	req.RepoType = types.RepositoryType(repoType)
 	req.Namespace = namespace
@@ -313,6 +319,10 @@ func (h *DiscussionHandler) CreateDiscussionComment(ctx *gin.Context) {
 
 	resp, err := h.discussion.CreateDiscussionComment(ctx.Request.Context(), req)
 	if err != nil {
+		if errors.Is(err, errorx.ErrForbidden) {
+			httpbase.ForbiddenError(ctx, err)
+			return
+		}
 		slog.ErrorContext(ctx.Request.Context(), "Failed to create discussion comment", "error", err, "request", req)
 		httpbase.ServerError(ctx, fmt.Errorf("failed to create discussion comment: %w", err))
 		return
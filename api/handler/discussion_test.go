@@ -275,3 +275,200 @@ func TestDiscussionHandler_ListDiscussionComments(t *testing.T) {
 		})
 	})
 }
+
+func TestDiscussionHandler_ListRepoDiscussionsWithFilters(t *testing.T) {
+	tester := NewDiscussionTester(t).WithHandleFunc(func(h *DiscussionHandler) gin.HandlerFunc {
+		return h.ListRepoDiscussions
+	})
+
+	tester.mocks.discussion.EXPECT().ListRepoDiscussions(
+		tester.Ctx(), types.ListRepoDiscussionRequest{
+			CurrentUser: "u",
+			RepoType:    types.ModelRepo,
+			Namespace:   "u",
+			Name:        "r",
+			Status:      types.DiscussionStatusClosed,
+			Label:       "bug",
+		},
+		10, 1,
+	).Return(&types.ListRepoDiscussionResponse{}, 0, nil)
+	tester.WithUser().WithParam("repo_type", "models").WithQuery("per", "10").WithQuery("page", "1").
+		WithQuery("status", "closed").WithQuery("label", "bug").Execute()
+	tester.ResponseEqCode(t, 200)
+
+	tester = NewDiscussionTester(t).WithHandleFunc(func(h *DiscussionHandler) gin.HandlerFunc {
+		return h.ListRepoDiscussions
+	})
+	tester.WithUser().WithParam("repo_type", "models").WithQuery("status", "deleted").Execute()
+	tester.ResponseEqCode(t, 400)
+}
+
+func TestDiscussionHandler_UpdateDiscussionStatus(t *testing.T) {
+	tester := NewDiscussionTester(t).WithHandleFunc(func(h *DiscussionHandler) gin.HandlerFunc {
+		return h.UpdateDiscussionStatus
+	})
+	tester.WithUser()
+
+	tester.mocks.discussion.EXPECT().UpdateDiscussionStatus(tester.Ctx(), types.UpdateDiscussionStatusRequest{
+		ID:          1,
+		CurrentUser: "u",
+		Status:      types.DiscussionStatusLocked,
+	}).Return(errorx.ErrForbidden)
+	tester.WithParam("id", "1").WithBody(t, &types.UpdateDiscussionStatusRequest{Status: types.DiscussionStatusLocked}).Execute()
+	tester.ResponseEqCode(t, 403)
+}
+
+func TestDiscussionHandler_PinDiscussion(t *testing.T) {
+	tester := NewDiscussionTester(t).WithHandleFunc(func(h *DiscussionHandler) gin.HandlerFunc {
+		return h.PinDiscussion
+	})
+	tester.WithUser()
+
+	tester.mocks.discussion.EXPECT().PinDiscussion(tester.Ctx(), types.PinDiscussionRequest{
+		ID:          1,
+		CurrentUser: "u",
+		Pinned:      true,
+	}).Return(nil)
+	tester.WithParam("id", "1").WithBody(t, &types.PinDiscussionRequest{Pinned: true}).Execute()
+	tester.ResponseEq(t, 200, tester.OKText, nil)
+}
+
+func TestDiscussionHandler_TransferDiscussion(t *testing.T) {
+	tester := NewDiscussionTester(t).WithHandleFunc(func(h *DiscussionHandler) gin.HandlerFunc {
+		return h.TransferDiscussion
+	})
+	tester.WithUser()
+
+	tester.mocks.discussion.EXPECT().TransferDiscussion(tester.Ctx(), types.TransferDiscussionRequest{
+		ID:          1,
+		CurrentUser: "u",
+		RepoType:    types.DatasetRepo,
+		Namespace:   "u",
+		Name:        "d",
+	}).Return(nil)
+	tester.WithParam("id", "1").WithBody(t, &types.TransferDiscussionRequest{
+		RepoType:  types.DatasetRepo,
+		Namespace: "u",
+		Name:      "d",
+	}).Execute()
+	tester.ResponseEq(t, 200, tester.OKText, nil)
+}
+
+func TestDiscussionHandler_SetDiscussionLabels(t *testing.T) {
+	tester := NewDiscussionTester(t).WithHandleFunc(func(h *DiscussionHandler) gin.HandlerFunc {
+		return h.SetDiscussionLabels
+	})
+	tester.WithUser()
+
+	labels := []types.DiscussionLabel{{ID: 2, Name: "bug"}}
+	tester.mocks.discussion.EXPECT().SetDiscussionLabels(tester.Ctx(), types.SetDiscussionLabelsRequest{
+		ID:          1,
+		CurrentUser: "u",
+		LabelIDs:    []int64{2},
+	}).Return(labels, nil)
+	tester.WithParam("id", "1").WithBody(t, &types.SetDiscussionLabelsRequest{LabelIDs: []int64{2}}).Execute()
+	tester.ResponseEq(t, 200, tester.OKText, labels)
+}
+
+func TestDiscussionHandler_DiscussionLabels(t *testing.T) {
+	tester := NewDiscussionTester(t).WithHandleFunc(func(h *DiscussionHandler) gin.HandlerFunc {
+		return h.ListDiscussionLabels
+	})
+	labels := []types.DiscussionLabel{{ID: 2, Name: "bug"}}
+	tester.mocks.discussion.EXPECT().ListDiscussionLabels(tester.Ctx(), types.ListRepoDiscussionRequest{
+		RepoType:  types.ModelRepo,
+		Namespace: "u",
+		Name:      "r",
+	}).Return(labels, nil)
+	tester.WithParam("repo_type", "models").Execute()
+	tester.ResponseEq(t, 200, tester.OKText, labels)
+
+	tester = NewDiscussionTester(t).WithHandleFunc(func(h *DiscussionHandler) gin.HandlerFunc {
+		return h.CreateDiscussionLabel
+	})
+	tester.WithUser()
+	tester.mocks.sensitive.EXPECT().CheckRequestV2(tester.Ctx(), &types.DiscussionLabelRequest{
+		Label: "bug",
+		Color: "#d73a4a",
+	}).Return(true, nil)
+	tester.mocks.discussion.EXPECT().CreateDiscussionLabel(tester.Ctx(), types.DiscussionLabelRequest{
+		RepoType:    types.ModelRepo,
+		Namespace:   "u",
+		Name:        "r",
+		CurrentUser: "u",
+		Label:       "bug",
+		Color:       "#d73a4a",
+	}).Return(nil, errorx.ErrDatabaseDuplicateKey)
+	tester.WithParam("repo_type", "models").WithBody(t, &types.DiscussionLabelRequest{
+		Label: "bug",
+		Color: "#d73a4a",
+	}).Execute()
+	tester.ResponseEqCode(t, 409)
+
+	tester = NewDiscussionTester(t).WithHandleFunc(func(h *DiscussionHandler) gin.HandlerFunc {
+		return h.CreateDiscussionLabel
+	})
+	tester.WithUser()
+	tester.WithParam("repo_type", "models").WithBody(t, &types.DiscussionLabelRequest{
+		Label: "bug",
+		Color: "red",
+	}).Execute()
+	tester.ResponseEqCode(t, 400)
+
+	tester = NewDiscussionTester(t).WithHandleFunc(func(h *DiscussionHandler) gin.HandlerFunc {
+		return h.DeleteDiscussionLabel
+	})
+	tester.WithUser()
+	tester.mocks.discussion.EXPECT().DeleteDiscussionLabel(tester.Ctx(), types.DiscussionLabelRequest{
+		RepoType:    types.ModelRepo,
+		Namespace:   "u",
+		Name:        "r",
+		CurrentUser: "u",
+		LabelID:     2,
+	}).Return(nil)
+	tester.WithParam("repo_type", "models").WithParam("label_id", "2").Execute()
+	tester.ResponseEq(t, 200, tester.OKText, nil)
+}
+
+func TestDiscussionHandler_HideComment(t *testing.T) {
+	tester := NewDiscussionTester(t).WithHandleFunc(func(h *DiscussionHandler) gin.HandlerFunc {
+		return h.HideComment
+	})
+	tester.WithUser()
+
+	tester.mocks.discussion.EXPECT().HideComment(tester.Ctx(), types.HideCommentRequest{
+		ID:          5,
+		CurrentUser: "u",
+		Hidden:      true,
+	}).Return(nil)
+	tester.WithParam("id", "1").WithParam("comment_id", "5").WithBody(t, &types.HideCommentRequest{Hidden: true}).Execute()
+	tester.ResponseEq(t, 200, tester.OKText, nil)
+}
+
+func TestDiscussionHandler_CommentReactions(t *testing.T) {
+	tester := NewDiscussionTester(t).WithHandleFunc(func(h *DiscussionHandler) gin.HandlerFunc {
+		return h.AddCommentReaction
+	})
+	tester.WithUser()
+
+	tester.mocks.discussion.EXPECT().AddCommentReaction(tester.Ctx(), types.CommentReactionRequest{
+		CommentID:   5,
+		CurrentUser: "u",
+		Emoji:       "heart",
+	}).Return(nil)
+	tester.WithParam("id", "1").WithParam("comment_id", "5").WithBody(t, &types.CommentReactionRequest{Emoji: "heart"}).Execute()
+	tester.ResponseEq(t, 200, tester.OKText, nil)
+
+	tester = NewDiscussionTester(t).WithHandleFunc(func(h *DiscussionHandler) gin.HandlerFunc {
+		return h.RemoveCommentReaction
+	})
+	tester.WithUser()
+
+	tester.mocks.discussion.EXPECT().RemoveCommentReaction(tester.Ctx(), types.CommentReactionRequest{
+		CommentID:   5,
+		CurrentUser: "u",
+		Emoji:       "+1",
+	}).Return(errorx.ReqParamInvalid(errorx.ErrReqParamInvalid, nil))
+	tester.WithParam("id", "1").WithParam("comment_id", "5").WithParam("emoji", "+1").Execute()
+	tester.ResponseEqCode(t, 400)
+}
@@ -0,0 +1,434 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"opencsg.com/csghub-server/api/httpbase"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+	"opencsg.com/csghub-server/common/utils/common"
+)
+
+// UpdateDiscussionStatus godoc
+// @Security     ApiKey
+// @Summary      Close, reopen, lock or unlock a discussion
+// @Description  the owner of the discussion and the repo writers can close and reopen it, only the repo admins can lock and unlock it
+// @Tags         Discussion
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "the discussion id"
+// @Param        body body types.UpdateDiscussionStatusRequest true "body"
+// @Success      200  {object}  types.Response "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      403  {object}  types.APIForbidden "Forbidden"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /discussions/{id}/status [put]
+func (h *DiscussionHandler) UpdateDiscussionStatus(ctx *gin.Context) {
+	id, ok := h.parseID(ctx, "id")
+	if !ok {
+		return
+	}
+	var req types.UpdateDiscussionStatusRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		httpbase.BadRequestWithExt(ctx, errorx.ReqParamInvalid(err, nil))
+		return
+	}
+	req.ID = id
+	req.CurrentUser = httpbase.GetCurrentUser(ctx)
+	if err := h.discussion.UpdateDiscussionStatus(ctx.Request.Context(), req); err != nil {
+		h.handleError(ctx, "Failed to update discussion status", err)
+		return
+	}
+	httpbase.OK(ctx, nil)
+}
+
+// PinDiscussion godoc
+// @Security     ApiKey
+// @Summary      Pin or unpin a discussion
+// @Description  pinned discussions are listed first, only the repo admins can pin them
+// @Tags         Discussion
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "the discussion id"
+// @Param        body body types.PinDiscussionRequest true "body"
+// @Success      200  {object}  types.Response "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      403  {object}  types.APIForbidden "Forbidden"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /discussions/{id}/pin [put]
+func (h *DiscussionHandler) PinDiscussion(ctx *gin.Context) {
+	id, ok := h.parseID(ctx, "id")
+	if !ok {
+		return
+	}
+	var req types.PinDiscussionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		httpbase.BadRequestWithExt(ctx, errorx.ReqParamInvalid(err, nil))
+		return
+	}
+	req.ID = id
+	req.CurrentUser = httpbase.GetCurrentUser(ctx)
+	if err := h.discussion.PinDiscussion(ctx.Request.Context(), req); err != nil {
+		h.handleError(ctx, "Failed to pin discussion", err)
+		return
+	}
+	httpbase.OK(ctx, nil)
+}
+
+// TransferDiscussion godoc
+// @Security     ApiKey
+// @Summary      Transfer a discussion to another repository
+// @Description  the user must be an admin of both repositories, the labels of the discussion are dropped
+// @Tags         Discussion
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "the discussion id"
+// @Param        body body types.TransferDiscussionRequest true "body"
+// @Success      200  {object}  types.Response "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      403  {object}  types.APIForbidden "Forbidden"
+// @Failure      404  {object}  types.APINotFound "Not found"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /discussions/{id}/transfer [post]
+func (h *DiscussionHandler) TransferDiscussion(ctx *gin.Context) {
+	id, ok := h.parseID(ctx, "id")
+	if !ok {
+		return
+	}
+	var req types.TransferDiscussionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		httpbase.BadRequestWithExt(ctx, errorx.ReqParamInvalid(err, nil))
+		return
+	}
+	req.ID = id
+	req.CurrentUser = httpbase.GetCurrentUser(ctx)
+	if err := h.discussion.TransferDiscussion(ctx.Request.Context(), req); err != nil {
+		h.handleError(ctx, "Failed to transfer discussion", err)
+		return
+	}
+	httpbase.OK(ctx, nil)
+}
+
+// SetDiscussionLabels godoc
+// @Security     ApiKey
+// @Summary      Replace the labels of a discussion
+// @Description  the labels must belong to the repository of the discussion, only the repo writers can set them
+// @Tags         Discussion
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "the discussion id"
+// @Param        body body types.SetDiscussionLabelsRequest true "body"
+// @Success      200  {object}  types.Response{data=[]types.DiscussionLabel} "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      403  {object}  types.APIForbidden "Forbidden"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /discussions/{id}/labels [put]
+func (h *DiscussionHandler) SetDiscussionLabels(ctx *gin.Context) {
+	id, ok := h.parseID(ctx, "id")
+	if !ok {
+		return
+	}
+	var req types.SetDiscussionLabelsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		httpbase.BadRequestWithExt(ctx, errorx.ReqParamInvalid(err, nil))
+		return
+	}
+	req.ID = id
+	req.CurrentUser = httpbase.GetCurrentUser(ctx)
+	labels, err := h.discussion.SetDiscussionLabels(ctx.Request.Context(), req)
+	if err != nil {
+		h.handleError(ctx, "Failed to set discussion labels", err)
+		return
+	}
+	httpbase.OK(ctx, labels)
+}
+
+// ListDiscussionLabels godoc
+// @Security     ApiKey
+// @Summary      List the discussion labels of a repository
+// @Tags         Discussion
+// @Produce      json
+// @Param        repo_type path string true "repository type" Enums(models,datasets,codes,spaces)
+// @Param        namespace path string true "namespace"
+// @Param        name path string true "name"
+// @Success      200  {object}  types.Response{data=[]types.DiscussionLabel} "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      403  {object}  types.APIForbidden "Forbidden"
+// @Failure      404  {object}  types.APINotFound "Not found"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /{repo_type}/{namespace}/{name}/discussion_labels [get]
+func (h *DiscussionHandler) ListDiscussionLabels(ctx *gin.Context) {
+	namespace, name, err := common.GetNamespaceAndNameFromContext(ctx)
+	if err != nil {
+		httpbase.BadRequestWithExt(ctx, errorx.ReqParamInvalid(err, nil))
+		return
+	}
+	labels, err := h.discussion.ListDiscussionLabels(ctx.Request.Context(), types.ListRepoDiscussionRequest{
+		RepoType:    h.getRepoType(ctx),
+		Namespace:   namespace,
+		Name:        name,
+		CurrentUser: httpbase.GetCurrentUser(ctx),
+	})
+	if err != nil {
+		h.handleError(ctx, "Failed to list discussion labels", err)
+		return
+	}
+	httpbase.OK(ctx, labels)
+}
+
+// CreateDiscussionLabel godoc
+// @Security     ApiKey
+// @Summary      Create a discussion label of a repository
+// @Description  only the repo writers can manage the labels
+// @Tags         Discussion
+// @Accept       json
+// @Produce      json
+// @Param        repo_type path string true "repository type" Enums(models,datasets,codes,spaces)
+// @Param        namespace path string true "namespace"
+// @Param        name path string true "name"
+// @Param        body body types.DiscussionLabelRequest true "body"
+// @Success      200  {object}  types.Response{data=types.DiscussionLabel} "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      403  {object}  types.APIForbidden "Forbidden"
+// @Failure      409  {object}  types.Response "Label exists"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /{repo_type}/{namespace}/{name}/discussion_labels [post]
+func (h *DiscussionHandler) CreateDiscussionLabel(ctx *gin.Context) {
+	req, ok := h.bindLabelRequest(ctx, false)
+	if !ok {
+		return
+	}
+	label, err := h.discussion.CreateDiscussionLabel(ctx.Request.Context(), *req)
+	if err != nil {
+		h.handleError(ctx, "Failed to create discussion label", err)
+		return
+	}
+	httpbase.OK(ctx, label)
+}
+
+// UpdateDiscussionLabel godoc
+// @Security     ApiKey
+// @Summary      Update a discussion label of a repository
+// @Tags         Discussion
+// @Accept       json
+// @Produce      json
+// @Param        repo_type path string true "repository type" Enums(models,datasets,codes,spaces)
+// @Param        namespace path string true "namespace"
+// @Param        name path string true "name"
+// @Param        label_id path int true "label id"
+// @Param        body body types.DiscussionLabelRequest true "body"
+// @Success      200  {object}  types.Response{data=types.DiscussionLabel} "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      403  {object}  types.APIForbidden "Forbidden"
+// @Failure      404  {object}  types.APINotFound "Not found"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /{repo_type}/{namespace}/{name}/discussion_labels/{label_id} [put]
+func (h *DiscussionHandler) UpdateDiscussionLabel(ctx *gin.Context) {
+	req, ok := h.bindLabelRequest(ctx, true)
+	if !ok {
+		return
+	}
+	label, err := h.discussion.UpdateDiscussionLabel(ctx.Request.Context(), *req)
+	if err != nil {
+		h.handleError(ctx, "Failed to update discussion label", err)
+		return
+	}
+	httpbase.OK(ctx, label)
+}
+
+// DeleteDiscussionLabel godoc
+// @Security     ApiKey
+// @Summary      Delete a discussion label of a repository
+// @Description  the label is removed from all the discussions
+// @Tags         Discussion
+// @Produce      json
+// @Param        repo_type path string true "repository type" Enums(models,datasets,codes,spaces)
+// @Param        namespace path string true "namespace"
+// @Param        name path string true "name"
+// @Param        label_id path int true "label id"
+// @Success      200  {object}  types.Response "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      403  {object}  types.APIForbidden "Forbidden"
+// @Failure      404  {object}  types.APINotFound "Not found"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /{repo_type}/{namespace}/{name}/discussion_labels/{label_id} [delete]
+func (h *DiscussionHandler) DeleteDiscussionLabel(ctx *gin.Context) {
+	namespace, name, err := common.GetNamespaceAndNameFromContext(ctx)
+	if err != nil {
+		httpbase.BadRequestWithExt(ctx, errorx.ReqParamInvalid(err, nil))
+		return
+	}
+	labelID, ok := h.parseID(ctx, "label_id")
+	if !ok {
+		return
+	}
+	err = h.discussion.DeleteDiscussionLabel(ctx.Request.Context(), types.DiscussionLabelRequest{
+		RepoType:    h.getRepoType(ctx),
+		Namespace:   namespace,
+		Name:        name,
+		CurrentUser: httpbase.GetCurrentUser(ctx),
+		LabelID:     labelID,
+	})
+	if err != nil {
+		h.handleError(ctx, "Failed to delete discussion label", err)
+		return
+	}
+	httpbase.OK(ctx, nil)
+}
+
+// HideComment godoc
+// @Security     ApiKey
+// @Summary      Hide or unhide a discussion comment
+// @Description  the content of the hidden comments is only visible to the repo admins and the author, only the repo admins can hide them
+// @Tags         Discussion
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "the discussion id"
+// @Param        comment_id path string true "the comment id"
+// @Param        body body types.HideCommentRequest true "body"
+// @Success      200  {object}  types.Response "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      403  {object}  types.APIForbidden "Forbidden"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /discussions/{id}/comments/{comment_id}/hidden [put]
+func (h *DiscussionHandler) HideComment(ctx *gin.Context) {
+	commentID, ok := h.parseID(ctx, "comment_id")
+	if !ok {
+		return
+	}
+	var req types.HideCommentRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		httpbase.BadRequestWithExt(ctx, errorx.ReqParamInvalid(err, nil))
+		return
+	}
+	req.ID = commentID
+	req.CurrentUser = httpbase.GetCurrentUser(ctx)
+	if err := h.discussion.HideComment(ctx.Request.Context(), req); err != nil {
+		h.handleError(ctx, "Failed to hide comment", err)
+		return
+	}
+	httpbase.OK(ctx, nil)
+}
+
+// AddCommentReaction godoc
+// @Security     ApiKey
+// @Summary      Add an emoji reaction to a discussion comment
+// @Tags         Discussion
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "the discussion id"
+// @Param        comment_id path string true "the comment id"
+// @Param        body body types.CommentReactionRequest true "emoji, one of +1, -1, laugh, hooray, confused, heart, rocket and eyes"
+// @Success      200  {object}  types.Response "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      403  {object}  types.APIForbidden "Forbidden"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /discussions/{id}/comments/{comment_id}/reactions [post]
+func (h *DiscussionHandler) AddCommentReaction(ctx *gin.Context) {
+	commentID, ok := h.parseID(ctx, "comment_id")
+	if !ok {
+		return
+	}
+	var req types.CommentReactionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		httpbase.BadRequestWithExt(ctx, errorx.ReqParamInvalid(err, nil))
+		return
+	}
+	req.CommentID = commentID
+	req.CurrentUser = httpbase.GetCurrentUser(ctx)
+	if err := h.discussion.AddCommentReaction(ctx.Request.Context(), req); err != nil {
+		h.handleError(ctx, "Failed to add comment reaction", err)
+		return
+	}
+	httpbase.OK(ctx, nil)
+}
+
+// RemoveCommentReaction godoc
+// @Security     ApiKey
+// @Summary      Remove an emoji reaction of the current user from a discussion comment
+// @Tags         Discussion
+// @Produce      json
+// @Param        id path string true "the discussion id"
+// @Param        comment_id path string true "the comment id"
+// @Param        emoji path string true "emoji" Enums(+1, -1, laugh, hooray, confused, heart, rocket, eyes)
+// @Success      200  {object}  types.Response "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      403  {object}  types.APIForbidden "Forbidden"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /discussions/{id}/comments/{comment_id}/reactions/{emoji} [delete]
+func (h *DiscussionHandler) RemoveCommentReaction(ctx *gin.Context) {
+	commentID, ok := h.parseID(ctx, "comment_id")
+	if !ok {
+		return
+	}
+	err := h.discussion.RemoveCommentReaction(ctx.Request.Context(), types.CommentReactionRequest{
+		CommentID:   commentID,
+		CurrentUser: httpbase.GetCurrentUser(ctx),
+		Emoji:       ctx.Param("emoji"),
+	})
+	if err != nil {
+		h.handleError(ctx, "Failed to remove comment reaction", err)
+		return
+	}
+	httpbase.OK(ctx, nil)
+}
+
+func (h *DiscussionHandler) bindLabelRequest(ctx *gin.Context, withID bool) (*types.DiscussionLabelRequest, bool) {
+	namespace, name, err := common.GetNamespaceAndNameFromContext(ctx)
+	if err != nil {
+		httpbase.BadRequestWithExt(ctx, errorx.ReqParamInvalid(err, nil))
+		return nil, false
+	}
+	var req types.DiscussionLabelRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		httpbase.BadRequestWithExt(ctx, errorx.ReqParamInvalid(err, nil))
+		return nil, false
+	}
+	if withID {
+		labelID, ok := h.parseID(ctx, "label_id")
+		if !ok {
+			return nil, false
+		}
+		req.LabelID = labelID
+	}
+	_, err = h.sensitive.CheckRequestV2(ctx.Request.Context(), &req)
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "failed to check sensitive request", slog.Any("error", err))
+		httpbase.BadRequestWithExt(ctx, errorx.ErrSensitiveInfoNotAllowed)
+		return nil, false
+	}
+	req.RepoType = h.getRepoType(ctx)
+	req.Namespace = namespace
+	req.Name = name
+	req.CurrentUser = httpbase.GetCurrentUser(ctx)
+	return &req, true
+}
+
+func (h *DiscussionHandler) parseID(ctx *gin.Context, param string) (int64, bool) {
+	id, err := strconv.ParseInt(ctx.Param(param), 10, 64)
+	if err != nil {
+		httpbase.BadRequestWithExt(ctx, errorx.ReqParamInvalid(err, errorx.Ctx().
+			Set(param, ctx.Param(param))))
+		return 0, false
+	}
+	return id, true
+}
+
+func (h *DiscussionHandler) handleError(ctx *gin.Context, msg string, err error) {
+	slog.ErrorContext(ctx.Request.Context(), msg, slog.String("current_user", httpbase.GetCurrentUser(ctx)), slog.Any("error", err))
+	switch {
+	case errors.Is(err, errorx.ErrReqParamInvalid):
+		httpbase.BadRequestWithExt(ctx, err)
+	case errors.Is(err, errorx.ErrForbidden) || errors.Is(err, errorx.ErrUserNotFound):
+		httpbase.ForbiddenError(ctx, err)
+	case errors.Is(err, errorx.ErrDatabaseNoRows):
+		httpbase.NotFoundError(ctx, err)
+	case errors.Is(err, errorx.ErrDatabaseDuplicateKey):
+		httpbase.ConflictError(ctx, err)
+	default:
+		httpbase.ServerError(ctx, err)
+	}
+}
@@ -233,3 +233,101 @@ func (h *FinetuneHandler) readLogInStream(ctx *gin.Context, req types.FinetuneLo
 		}
 	}
 }
+
+// PublishFinetuneResult godoc
+// @Security     ApiKey
+// @Summary      publish the result of a succeeded finetune job into a model repo
+// @Description  The files are published in the background, poll the publication to get the result
+// @Tags         Finetune
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "id"
+// @Param        body body types.FinetunePublishConfig true "publish settings"
+// @Success      200  {object}  types.Response{data=types.FinetunePublishRes} "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /finetunes/{id}/publish [post]
+func (h *FinetuneHandler) PublishFinetuneResult(ctx *gin.Context) {
+	currentUser := httpbase.GetCurrentUser(ctx)
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "Bad request format for publish finetune", "error", err)
+		httpbase.BadRequest(ctx, err.Error())
+		return
+	}
+	var req types.FinetunePublishReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "Bad publish finetune request format", "error", err)
+		httpbase.BadRequest(ctx, err.Error())
+		return
+	}
+	req.ID = id
+	req.CurrentUser = currentUser
+	res, err := h.ftComp.PublishFinetuneResult(ctx.Request.Context(), req)
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "Failed to publish finetune result", slog.Any("error", err), slog.Any("id", id))
+		httpbase.ServerError(ctx, err)
+		return
+	}
+	httpbase.OK(ctx, res)
+}
+
+// GetFinetunePublication godoc
+// @Security     ApiKey
+// @Summary      get the publish state of a finetune job
+// @Tags         Finetune
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "id"
+// @Success      200  {object}  types.Response{data=types.FinetunePublishRes} "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /finetunes/{id}/publish [get]
+func (h *FinetuneHandler) GetFinetunePublication(ctx *gin.Context) {
+	currentUser := httpbase.GetCurrentUser(ctx)
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "Bad request format for get finetune publication", "error", err)
+		httpbase.BadRequest(ctx, err.Error())
+		return
+	}
+	res, err := h.ftComp.GetFinetunePublication(ctx.Request.Context(), types.FinetuneLogReq{ID: id, CurrentUser: currentUser})
+	if err != nil {
+		if errors.Is(err, errorx.ErrDatabaseNoRows) {
+			httpbase.NotFoundError(ctx, err)
+			return
+		}
+		slog.ErrorContext(ctx.Request.Context(), "Failed to get finetune publication", slog.Any("error", err), slog.Any("id", id))
+		httpbase.ServerError(ctx, err)
+		return
+	}
+	httpbase.OK(ctx, res)
+}
+
+// ListFinetuneCheckpoints godoc
+// @Security     ApiKey
+// @Summary      list the intermediate checkpoints saved by a finetune job
+// @Tags         Finetune
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "id"
+// @Success      200  {object}  types.Response{data=[]string} "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /finetunes/{id}/checkpoints [get]
+func (h *FinetuneHandler) ListFinetuneCheckpoints(ctx *gin.Context) {
+	currentUser := httpbase.GetCurrentUser(ctx)
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "Bad request format for list finetune checkpoints", "error", err)
+		httpbase.BadRequest(ctx, err.Error())
+		return
+	}
+	checkpoints, err := h.ftComp.ListFinetuneCheckpoints(ctx.Request.Context(), types.FinetuneLogReq{ID: id, CurrentUser: currentUser})
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "Failed to list finetune checkpoints", slog.Any("error", err), slog.Any("id", id))
+		httpbase.ServerError(ctx, err)
+		return
+	}
+	httpbase.OK(ctx, checkpoints)
+}
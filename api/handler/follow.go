@@ -0,0 +1,260 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/gin-gonic/gin"
+	"opencsg.com/csghub-server/api/httpbase"
+	"opencsg.com/csghub-server/common/config"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+	"opencsg.com/csghub-server/common/utils/common"
+	"opencsg.com/csghub-server/component"
+)
+
+// FollowHandler handles the follows of the users, organizations and repositories and the activity feeds
+type FollowHandler struct {
+	c component.FollowComponent
+}
+
+func NewFollowHandler(cfg *config.Config) (*FollowHandler, error) {
+	c, err := component.NewFollowComponent(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create FollowComponent: %w", err)
+	}
+	return &FollowHandler{
+		c: c,
+	}, nil
+}
+
+// Follow godoc
+// @Security     ApiKey
+// @Summary      Follow a user, an organization or a repository
+// @Tags         Follow
+// @Accept       json
+// @Produce      json
+// @Param        body body types.FollowReq true "the entity to follow, namespace and repo_type are required for the repositories"
+// @Success      200  {object}  types.Response{} "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      403  {object}  types.APIForbidden "Forbidden"
+// @Failure      404  {object}  types.APINotFound "Not found"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /follows [post]
+func (h *FollowHandler) Follow(ctx *gin.Context) {
+	var req types.FollowReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		httpbase.BadRequestWithExt(ctx, errorx.ReqParamInvalid(err, nil))
+		return
+	}
+	req.CurrentUser = httpbase.GetCurrentUser(ctx)
+	if err := h.c.Follow(ctx.Request.Context(), &req); err != nil {
+		h.handleError(ctx, "failed to follow", err)
+		return
+	}
+	httpbase.OK(ctx, nil)
+}
+
+// Unfollow godoc
+// @Security     ApiKey
+// @Summary      Unfollow a user, an organization or a repository
+// @Tags         Follow
+// @Accept       json
+// @Produce      json
+// @Param        body body types.FollowReq true "the entity to unfollow"
+// @Success      200  {object}  types.Response{} "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      404  {object}  types.APINotFound "Not found"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /follows [delete]
+func (h *FollowHandler) Unfollow(ctx *gin.Context) {
+	var req types.FollowReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		httpbase.BadRequestWithExt(ctx, errorx.ReqParamInvalid(err, nil))
+		return
+	}
+	req.CurrentUser = httpbase.GetCurrentUser(ctx)
+	if err := h.c.Unfollow(ctx.Request.Context(), &req); err != nil {
+		h.handleError(ctx, "failed to unfollow", err)
+		return
+	}
+	httpbase.OK(ctx, nil)
+}
+
+// Stats godoc
+// @Security     ApiKey
+// @Summary      Get the follower count of a user, an organization or a repository
+// @Description  Get the follower count, the following count for the users, and whether the current user follows it
+// @Tags         Follow
+// @Produce      json
+// @Param        type query string true "followable type" Enums(user, organization, repository)
+// @Param        name query string true "username, organization path or repository name"
+// @Param        namespace query string false "namespace of the repository"
+// @Param        repo_type query string false "type of the repository" Enums(model, dataset, code, space, prompt, mcpserver)
+// @Success      200  {object}  types.Response{data=types.FollowStats} "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      403  {object}  types.APIForbidden "Forbidden"
+// @Failure      404  {object}  types.APINotFound "Not found"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /follows/stats [get]
+func (h *FollowHandler) Stats(ctx *gin.Context) {
+	var req types.FollowReq
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		httpbase.BadRequestWithExt(ctx, errorx.ReqParamInvalid(err, nil))
+		return
+	}
+	req.CurrentUser = httpbase.GetCurrentUser(ctx)
+	stats, err := h.c.Stats(ctx.Request.Context(), &req)
+	if err != nil {
+		h.handleError(ctx, "failed to get follow stats", err)
+		return
+	}
+	httpbase.OK(ctx, stats)
+}
+
+// Followers godoc
+// @Security     ApiKey
+// @Summary      List the followers of a user, an organization or a repository
+// @Tags         Follow
+// @Produce      json
+// @Param        type query string true "followable type" Enums(user, organization, repository)
+// @Param        name query string true "username, organization path or repository name"
+// @Param        namespace query string false "namespace of the repository"
+// @Param        repo_type query string false "type of the repository" Enums(model, dataset, code, space, prompt, mcpserver)
+// @Param        per query int false "per" default(20)
+// @Param        page query int false "page index" default(1)
+// @Success      200  {object}  types.ResponseWithTotal{data=[]types.Follower,total=int} "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      403  {object}  types.APIForbidden "Forbidden"
+// @Failure      404  {object}  types.APINotFound "Not found"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /follows/followers [get]
+func (h *FollowHandler) Followers(ctx *gin.Context) {
+	var req types.FollowReq
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		httpbase.BadRequestWithExt(ctx, errorx.ReqParamInvalid(err, nil))
+		return
+	}
+	per, page, err := common.GetPerAndPageFromContext(ctx)
+	if err != nil {
+		httpbase.BadRequestWithExt(ctx, errorx.ReqParamInvalid(err, nil))
+		return
+	}
+	req.CurrentUser = httpbase.GetCurrentUser(ctx)
+	followers, total, err := h.c.Followers(ctx.Request.Context(), &req, per, page)
+	if err != nil {
+		h.handleError(ctx, "failed to list followers", err)
+		return
+	}
+	httpbase.OKWithTotal(ctx, followers, total)
+}
+
+// Following godoc
+// @Security     ApiKey
+// @Summary      List the users, organizations and repositories followed by a user
+// @Tags         Follow
+// @Produce      json
+// @Param        username path string true "username"
+// @Param        per query int false "per" default(20)
+// @Param        page query int false "page index" default(1)
+// @Success      200  {object}  types.ResponseWithTotal{data=[]types.Following,total=int} "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      404  {object}  types.APINotFound "Not found"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /follows/following/{username} [get]
+func (h *FollowHandler) Following(ctx *gin.Context) {
+	per, page, err := common.GetPerAndPageFromContext(ctx)
+	if err != nil {
+		httpbase.BadRequestWithExt(ctx, errorx.ReqParamInvalid(err, nil))
+		return
+	}
+	followings, total, err := h.c.Following(ctx.Request.Context(), ctx.Param("username"), per, page)
+	if err != nil {
+		h.handleError(ctx, "failed to list following", err)
+		return
+	}
+	httpbase.OKWithTotal(ctx, followings, total)
+}
+
+// Feed godoc
+// @Security     ApiKey
+// @Summary      Get the activity feed of the current user
+// @Description  Get the new repositories, tags, space versions and discussions of the users, organizations and repositories followed by the current user, the latest first
+// @Tags         Follow
+// @Produce      json
+// @Param        per query int false "per" default(20)
+// @Param        page query int false "page index" default(1)
+// @Success      200  {object}  types.ResponseWithTotal{data=[]types.FeedEvent,total=int} "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /feed [get]
+func (h *FollowHandler) Feed(ctx *gin.Context) {
+	per, page, err := common.GetPerAndPageFromContext(ctx)
+	if err != nil {
+		httpbase.BadRequestWithExt(ctx, errorx.ReqParamInvalid(err, nil))
+		return
+	}
+	events, total, err := h.c.Feed(ctx.Request.Context(), httpbase.GetCurrentUser(ctx), per, page)
+	if err != nil {
+		h.handleError(ctx, "failed to get feed", err)
+		return
+	}
+	httpbase.OKWithTotal(ctx, events, total)
+}
+
+// GetDigestSetting godoc
+// @Security     ApiKey
+// @Summary      Get the email digest setting of the activity feed of the current user
+// @Tags         Follow
+// @Produce      json
+// @Success      200  {object}  types.Response{data=types.FeedDigestSetting} "OK"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /feed/digest [get]
+func (h *FollowHandler) GetDigestSetting(ctx *gin.Context) {
+	setting, err := h.c.GetDigestSetting(ctx.Request.Context(), httpbase.GetCurrentUser(ctx))
+	if err != nil {
+		h.handleError(ctx, "failed to get feed digest setting", err)
+		return
+	}
+	httpbase.OK(ctx, setting)
+}
+
+// UpdateDigestSetting godoc
+// @Security     ApiKey
+// @Summary      Enable or disable the email digest of the activity feed of the current user
+// @Tags         Follow
+// @Accept       json
+// @Produce      json
+// @Param        body body types.UpdateFeedDigestSettingReq true "digest setting"
+// @Success      200  {object}  types.Response{} "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /feed/digest [put]
+func (h *FollowHandler) UpdateDigestSetting(ctx *gin.Context) {
+	var req types.UpdateFeedDigestSettingReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		httpbase.BadRequestWithExt(ctx, errorx.ReqParamInvalid(err, nil))
+		return
+	}
+	req.CurrentUser = httpbase.GetCurrentUser(ctx)
+	if err := h.c.UpdateDigestSetting(ctx.Request.Context(), &req); err != nil {
+		h.handleError(ctx, "failed to update feed digest setting", err)
+		return
+	}
+	httpbase.OK(ctx, nil)
+}
+
+func (h *FollowHandler) handleError(ctx *gin.Context, msg string, err error) {
+	slog.ErrorContext(ctx.Request.Context(), msg, slog.String("current_user", httpbase.GetCurrentUser(ctx)), slog.Any("error", err))
+	switch {
+	case errors.Is(err, errorx.ErrReqParamInvalid):
+		httpbase.BadRequestWithExt(ctx, err)
+	case errors.Is(err, errorx.ErrForbidden) || errors.Is(err, errorx.ErrUserNotFound):
+		httpbase.ForbiddenError(ctx, err)
+	case errors.Is(err, errorx.ErrDatabaseNoRows):
+		httpbase.NotFoundError(ctx, err)
+	default:
+		httpbase.ServerError(ctx, err)
+	}
+}
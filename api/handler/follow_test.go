@@ -0,0 +1,192 @@
+package handler
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	mockcomponent "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/component"
+	"opencsg.com/csghub-server/builder/testutil"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+)
+
+type FollowTester struct {
+	*testutil.GinTester
+	handler *FollowHandler
+	mocks   struct {
+		follow *mockcomponent.MockFollowComponent
+	}
+}
+
+func NewFollowTester(t *testing.T) *FollowTester {
+	tester := &FollowTester{GinTester: testutil.NewGinTester()}
+	tester.mocks.follow = mockcomponent.NewMockFollowComponent(t)
+	tester.handler = &FollowHandler{c: tester.mocks.follow}
+	return tester
+}
+
+func (t *FollowTester) WithHandleFunc(fn func(h *FollowHandler) gin.HandlerFunc) *FollowTester {
+	t.Handler(fn(t.handler))
+	return t
+}
+
+func TestFollowHandler_Follow(t *testing.T) {
+	tester := NewFollowTester(t).WithHandleFunc(func(h *FollowHandler) gin.HandlerFunc {
+		return h.Follow
+	})
+	tester.WithUser()
+
+	tester.mocks.follow.EXPECT().Follow(tester.Ctx(), &types.FollowReq{
+		CurrentUser: "u",
+		Type:        types.FollowableRepository,
+		RepoType:    types.ModelRepo,
+		Namespace:   "ns",
+		Name:        "n",
+	}).Return(nil)
+
+	tester.WithBody(t, &types.FollowReq{
+		Type:      types.FollowableRepository,
+		RepoType:  types.ModelRepo,
+		Namespace: "ns",
+		Name:      "n",
+	}).Execute()
+	tester.ResponseEq(t, http.StatusOK, tester.OKText, nil)
+}
+
+func TestFollowHandler_FollowErrors(t *testing.T) {
+	cases := []struct {
+		err  error
+		code int
+	}{
+		{errorx.ReqParamInvalid(errorx.ErrReqParamInvalid, nil), http.StatusBadRequest},
+		{errorx.ErrForbidden, http.StatusForbidden},
+		{errorx.ErrDatabaseNoRows, http.StatusNotFound},
+	}
+	for _, c := range cases {
+		tester := NewFollowTester(t).WithHandleFunc(func(h *FollowHandler) gin.HandlerFunc {
+			return h.Follow
+		})
+		tester.WithUser()
+		tester.mocks.follow.EXPECT().Follow(tester.Ctx(), mock.Anything).Return(c.err)
+		tester.WithBody(t, &types.FollowReq{Type: types.FollowableUser, Name: "foo"}).Execute()
+		tester.ResponseEqCode(t, c.code)
+	}
+
+	tester := NewFollowTester(t).WithHandleFunc(func(h *FollowHandler) gin.HandlerFunc {
+		return h.Follow
+	})
+	tester.WithUser()
+	tester.WithBody(t, &types.FollowReq{Type: "team", Name: "foo"}).Execute()
+	tester.ResponseEqCode(t, http.StatusBadRequest)
+}
+
+func TestFollowHandler_Unfollow(t *testing.T) {
+	tester := NewFollowTester(t).WithHandleFunc(func(h *FollowHandler) gin.HandlerFunc {
+		return h.Unfollow
+	})
+	tester.WithUser()
+
+	tester.mocks.follow.EXPECT().Unfollow(tester.Ctx(), &types.FollowReq{
+		CurrentUser: "u",
+		Type:        types.FollowableUser,
+		Name:        "foo",
+	}).Return(nil)
+
+	tester.WithBody(t, &types.FollowReq{Type: types.FollowableUser, Name: "foo"}).Execute()
+	tester.ResponseEq(t, http.StatusOK, tester.OKText, nil)
+}
+
+func TestFollowHandler_Stats(t *testing.T) {
+	tester := NewFollowTester(t).WithHandleFunc(func(h *FollowHandler) gin.HandlerFunc {
+		return h.Stats
+	})
+	tester.WithUser()
+
+	stats := &types.FollowStats{Followers: 2, Following: 1, IsFollowing: true}
+	tester.mocks.follow.EXPECT().Stats(tester.Ctx(), &types.FollowReq{
+		CurrentUser: "u",
+		Type:        types.FollowableOrganization,
+		Name:        "org",
+	}).Return(stats, nil)
+
+	tester.WithQuery("type", "organization").WithQuery("name", "org").Execute()
+	tester.ResponseEq(t, http.StatusOK, tester.OKText, stats)
+}
+
+func TestFollowHandler_Followers(t *testing.T) {
+	tester := NewFollowTester(t).WithHandleFunc(func(h *FollowHandler) gin.HandlerFunc {
+		return h.Followers
+	})
+
+	followers := []types.Follower{{Username: "foo"}}
+	tester.mocks.follow.EXPECT().Followers(tester.Ctx(), &types.FollowReq{
+		Type: types.FollowableUser,
+		Name: "bar",
+	}, 10, 1).Return(followers, 1, nil)
+
+	tester.AddPagination(1, 10).WithQuery("type", "user").WithQuery("name", "bar").Execute()
+	tester.ResponseEqSimple(t, http.StatusOK, gin.H{
+		"msg":   "OK",
+		"data":  followers,
+		"total": 1,
+	})
+}
+
+func TestFollowHandler_Following(t *testing.T) {
+	tester := NewFollowTester(t).WithHandleFunc(func(h *FollowHandler) gin.HandlerFunc {
+		return h.Following
+	})
+
+	followings := []types.Following{{Type: types.FollowableRepository, Path: "ns/n", RepoType: types.ModelRepo}}
+	tester.mocks.follow.EXPECT().Following(tester.Ctx(), "foo", 10, 1).Return(followings, 1, nil)
+
+	tester.AddPagination(1, 10).WithParam("username", "foo").Execute()
+	tester.ResponseEqSimple(t, http.StatusOK, gin.H{
+		"msg":   "OK",
+		"data":  followings,
+		"total": 1,
+	})
+}
+
+func TestFollowHandler_Feed(t *testing.T) {
+	tester := NewFollowTester(t).WithHandleFunc(func(h *FollowHandler) gin.HandlerFunc {
+		return h.Feed
+	})
+	tester.WithUser()
+
+	events := []types.FeedEvent{{ID: 1, Type: types.FeedEventRepoCreated, RepoType: types.ModelRepo, RepoPath: "ns/n"}}
+	tester.mocks.follow.EXPECT().Feed(tester.Ctx(), "u", 10, 1).Return(events, 1, nil)
+
+	tester.AddPagination(1, 10).Execute()
+	tester.ResponseEqSimple(t, http.StatusOK, gin.H{
+		"msg":   "OK",
+		"data":  events,
+		"total": 1,
+	})
+}
+
+func TestFollowHandler_DigestSetting(t *testing.T) {
+	tester := NewFollowTester(t).WithHandleFunc(func(h *FollowHandler) gin.HandlerFunc {
+		return h.GetDigestSetting
+	})
+	tester.WithUser()
+
+	setting := &types.FeedDigestSetting{Enabled: true}
+	tester.mocks.follow.EXPECT().GetDigestSetting(tester.Ctx(), "u").Return(setting, nil)
+	tester.Execute()
+	tester.ResponseEq(t, http.StatusOK, tester.OKText, setting)
+
+	tester = NewFollowTester(t).WithHandleFunc(func(h *FollowHandler) gin.HandlerFunc {
+		return h.UpdateDigestSetting
+	})
+	tester.WithUser()
+
+	tester.mocks.follow.EXPECT().UpdateDigestSetting(tester.Ctx(), &types.UpdateFeedDigestSettingReq{
+		CurrentUser: "u",
+		Enabled:     true,
+	}).Return(nil)
+	tester.WithBody(t, &types.UpdateFeedDigestSettingReq{Enabled: true}).Execute()
+	tester.ResponseEq(t, http.StatusOK, tester.OKText, nil)
+}
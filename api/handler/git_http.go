@@ -176,6 +176,12 @@ func (h *GitHTTPHandler) GitReceivePack(ctx *gin.Context) {
 				"error": "File too large. Please track it using Git LFS.",
 			})
 		}
+		if errors.Is(err, errorx.ErrGitStorageQuotaExceeded) {
+			ctx.PureJSON(http.StatusInsufficientStorage, gin.H{
+				"error": "Storage quota exceeded. Please remove unused repositories or files, or contact the administrator.",
+			})
+			return
+		}
 		if errors.Is(err, errorx.ErrUnauthorized) {
 			ctx.Header("WWW-Authenticate", "Basic realm=opencsg-git")
 			ctx.PureJSON(http.StatusUnauthorized, nil)
@@ -228,6 +234,7 @@ func (h *GitHTTPHandler) LfsBatch(ctx *gin.Context) {
 		httpbase.ServerError(ctx, err)
 		return
 	}
+	setLfsDownloadOids(ctx, batchRequest, objectResponse)
 	ctx.Header("Content-Type", types.LfsMediaType)
 	ctx.PureJSON(http.StatusOK, objectResponse)
 }
@@ -272,10 +279,26 @@ func (h *GitHTTPHandler) LfsBatchHF(ctx *gin.Context) {
 		httpbase.ServerError(ctx, err)
 		return
 	}
+	setLfsDownloadOids(ctx, batchRequest, objectResponse)
 	ctx.Header("Content-Type", types.LfsMediaType)
 	ctx.PureJSON(http.StatusOK, objectResponse)
 }
 
+// setLfsDownloadOids saves the oids of the objects with the download links for the repo traffic events
+func setLfsDownloadOids(ctx *gin.Context, req types.BatchRequest, resp *types.BatchResponse) {
+	if req.Operation != types.LFSBatchDownload || resp == nil {
+		return
+	}
+	var oids []string
+	for _, object := range resp.Objects {
+		if object == nil || object.Error != nil || object.Actions["download"] == nil {
+			continue
+		}
+		oids = append(oids, object.Oid)
+	}
+	httpbase.SetLfsDownloadOids(ctx, oids)
+}
+
 func (h *GitHTTPHandler) LfsUpload(ctx *gin.Context) {
 	var err error
 	var uploadRequest types.UploadRequest
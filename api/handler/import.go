@@ -9,6 +9,7 @@ import (
 type ImportHandler interface {
 	Import(c *gin.Context)
 	GetGitlabRepos(ctx *gin.Context)
+	GetRemoteRepos(ctx *gin.Context)
 	ImportStatus(ctx *gin.Context)
 }
 
@@ -3,18 +3,150 @@
 package handler
 
 import (
+	"errors"
+	"log/slog"
+
 	"github.com/gin-gonic/gin"
 	"opencsg.com/csghub-server/api/httpbase"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+	"opencsg.com/csghub-server/common/utils/common"
 )
 
+// Import godoc
+// @Security     ApiKey
+// @Summary      Import repositories from GitLab, GitHub, Gitea or ModelScope
+// @Description  the local repositories are created at once, their git and lfs objects are transferred by the mirror tasks
+// @Tags         Import
+// @Accept       json
+// @Produce      json
+// @Param        body body types.ImportReq true "body"
+// @Success      200  {object}  types.Response{} "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      409  {object}  types.APIBadRequest "Repository already exists"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /import [post]
 func (h *importHandlerImpl) Import(ctx *gin.Context) {
+	var req types.ImportReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "Bad request format", "error", err)
+		httpbase.BadRequestWithExt(ctx, errorx.ReqBodyFormat(err, nil))
+		return
+	}
+	req.CurrentUser = httpbase.GetCurrentUser(ctx)
+	err := h.c.Import(ctx.Request.Context(), req)
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "failed to import repositories", slog.String("provider", string(req.Provider)), slog.Any("error", err))
+		h.handleError(ctx, err)
+		return
+	}
 	httpbase.OK(ctx, nil)
 }
 
+// GetGitlabRepos godoc
+// @Security     ApiKey
+// @Summary      List the GitLab repositories of the access token owner
+// @Tags         Import
+// @Accept       json
+// @Produce      json
+// @Param        body body types.GetRemoteReposReq true "body, the provider is ignored"
+// @Success      200  {object}  types.Response{data=[]types.RemoteRepository} "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /import/gitlab_repos [post]
 func (h *importHandlerImpl) GetGitlabRepos(ctx *gin.Context) {
-	httpbase.OK(ctx, nil)
+	var req types.GetRemoteReposReq
+	req.Provider = types.ImportProviderGitLab
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "Bad request format", "error", err)
+		httpbase.BadRequestWithExt(ctx, errorx.ReqBodyFormat(err, nil))
+		return
+	}
+	repos, err := h.c.GetGitlabRepos(ctx.Request.Context(), &types.GetGitlabReposReq{
+		CurrentUser: httpbase.GetCurrentUser(ctx),
+		BaseURL:     req.BaseURL,
+		AccessToken: req.AccessToken,
+		Search:      req.Search,
+		Per:         req.Per,
+		Page:        req.Page,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "failed to get gitlab repositories", slog.Any("error", err))
+		h.handleError(ctx, err)
+		return
+	}
+	httpbase.OK(ctx, repos)
+}
+
+// GetRemoteRepos godoc
+// @Security     ApiKey
+// @Summary      List the repositories of the access token owner on the remote platform
+// @Tags         Import
+// @Accept       json
+// @Produce      json
+// @Param        body body types.GetRemoteReposReq true "body"
+// @Success      200  {object}  types.Response{data=[]types.RemoteRepository} "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /import/remote_repos [post]
+func (h *importHandlerImpl) GetRemoteRepos(ctx *gin.Context) {
+	var req types.GetRemoteReposReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "Bad request format", "error", err)
+		httpbase.BadRequestWithExt(ctx, errorx.ReqBodyFormat(err, nil))
+		return
+	}
+	req.CurrentUser = httpbase.GetCurrentUser(ctx)
+	repos, err := h.c.GetRemoteRepos(ctx.Request.Context(), &req)
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "failed to get remote repositories", slog.String("provider", string(req.Provider)), slog.Any("error", err))
+		h.handleError(ctx, err)
+		return
+	}
+	httpbase.OK(ctx, repos)
 }
 
+// ImportStatus godoc
+// @Security     ApiKey
+// @Summary      Get the transfer status of the repositories imported by current user
+// @Tags         Import
+// @Produce      json
+// @Param        per query int false "per" default(20)
+// @Param        page query int false "page index" default(1)
+// @Success      200  {object}  types.ResponseWithTotal{data=[]types.ImportedRepository,total=int} "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /import/status [get]
 func (h *importHandlerImpl) ImportStatus(ctx *gin.Context) {
-	httpbase.OK(ctx, nil)
+	per, page, err := common.GetPerAndPageFromContext(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "Bad request format", "error", err)
+		httpbase.BadRequestWithExt(ctx, errorx.ReqParamInvalid(err, nil))
+		return
+	}
+	repos, total, err := h.c.ImportStatus(ctx.Request.Context(), types.ImportStatusReq{
+		CurrentUser: httpbase.GetCurrentUser(ctx),
+		Per:         per,
+		Page:        page,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "failed to get import status", slog.Any("error", err))
+		h.handleError(ctx, err)
+		return
+	}
+	httpbase.OKWithTotal(ctx, repos, total)
+}
+
+func (h *importHandlerImpl) handleError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, errorx.ErrReqParamInvalid), errors.Is(err, errorx.ErrBadRequest),
+		errors.Is(err, errorx.ErrMirrorSourceRepoAuthInvalid):
+		httpbase.BadRequestWithExt(ctx, err)
+	case errors.Is(err, errorx.ErrForbidden), errors.Is(err, errorx.ErrUserNotFound):
+		httpbase.ForbiddenError(ctx, err)
+	case errors.Is(err, errorx.ErrRepoAlreadyExist):
+		httpbase.ConflictError(ctx, err)
+	default:
+		httpbase.ServerError(ctx, err)
+	}
 }
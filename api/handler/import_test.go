@@ -0,0 +1,142 @@
+//go:build !ee && !saas
+
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	mockcomponent "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/component"
+	"opencsg.com/csghub-server/builder/testutil"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+)
+
+type ImportTester struct {
+	*testutil.GinTester
+	handler *importHandlerImpl
+	mocks   struct {
+		importer *mockcomponent.MockImportComponent
+	}
+}
+
+func NewImportTester(t *testing.T) *ImportTester {
+	tester := &ImportTester{GinTester: testutil.NewGinTester()}
+	tester.mocks.importer = mockcomponent.NewMockImportComponent(t)
+	tester.handler = &importHandlerImpl{c: tester.mocks.importer}
+	return tester
+}
+
+func (t *ImportTester) WithHandleFunc(fn func(h *importHandlerImpl) gin.HandlerFunc) *ImportTester {
+	t.Handler(fn(t.handler))
+	return t
+}
+
+func TestImportHandler_Import(t *testing.T) {
+	tester := NewImportTester(t).WithHandleFunc(func(h *importHandlerImpl) gin.HandlerFunc {
+		return h.Import
+	})
+	tester.WithUser()
+
+	req := types.ImportReq{
+		Provider:    types.ImportProviderGitHub,
+		AccessToken: "token",
+		ImportRepos: []types.ImportBaseReq{{Path: "ns/n", SourcePath: "octo/n"}},
+	}
+	expected := req
+	expected.CurrentUser = "u"
+	tester.mocks.importer.EXPECT().Import(tester.Ctx(), expected).Return(nil)
+
+	tester.WithBody(t, req).Execute()
+	tester.ResponseEq(t, http.StatusOK, tester.OKText, nil)
+}
+
+func TestImportHandler_ImportErrors(t *testing.T) {
+	cases := []struct {
+		err  error
+		code int
+	}{
+		{errorx.MirrorSourceRepoAuthInvalid(errors.New("bad token"), nil), http.StatusBadRequest},
+		{errors.Join(errorx.ErrRepoAlreadyExist), http.StatusConflict},
+		{errorx.ErrForbidden, http.StatusForbidden},
+		{errors.New("boom"), http.StatusInternalServerError},
+	}
+	for _, c := range cases {
+		tester := NewImportTester(t).WithHandleFunc(func(h *importHandlerImpl) gin.HandlerFunc {
+			return h.Import
+		})
+		tester.WithUser()
+		tester.mocks.importer.EXPECT().Import(tester.Ctx(), mock.Anything).Return(c.err)
+		tester.WithBody(t, types.ImportReq{AccessToken: "token"}).Execute()
+		tester.ResponseEqCode(t, c.code)
+	}
+}
+
+func TestImportHandler_GetRemoteRepos(t *testing.T) {
+	tester := NewImportTester(t).WithHandleFunc(func(h *importHandlerImpl) gin.HandlerFunc {
+		return h.GetRemoteRepos
+	})
+	tester.WithUser()
+
+	repos := []types.RemoteRepository{{Name: "n", Path: "octo/n"}}
+	tester.mocks.importer.EXPECT().GetRemoteRepos(tester.Ctx(), &types.GetRemoteReposReq{
+		CurrentUser: "u",
+		Provider:    types.ImportProviderGitea,
+		AccessToken: "token",
+		Page:        1,
+		Per:         10,
+	}).Return(repos, nil)
+
+	tester.WithBody(t, map[string]any{"provider": "gitea", "access_token": "token", "page": 1, "per": 10}).Execute()
+	tester.ResponseEq(t, http.StatusOK, tester.OKText, repos)
+
+	tester = NewImportTester(t).WithHandleFunc(func(h *importHandlerImpl) gin.HandlerFunc {
+		return h.GetRemoteRepos
+	})
+	tester.WithUser()
+	tester.WithBody(t, map[string]any{"provider": "svn", "access_token": "token"}).Execute()
+	tester.ResponseEqCode(t, http.StatusBadRequest)
+}
+
+func TestImportHandler_GetGitlabRepos(t *testing.T) {
+	tester := NewImportTester(t).WithHandleFunc(func(h *importHandlerImpl) gin.HandlerFunc {
+		return h.GetGitlabRepos
+	})
+	tester.WithUser()
+
+	tester.mocks.importer.EXPECT().GetGitlabRepos(tester.Ctx(), &types.GetGitlabReposReq{
+		CurrentUser: "u",
+		BaseURL:     "https://gitlab.example.com",
+		AccessToken: "token",
+		Search:      "foo",
+	}).Return([]types.RemoteRepository{}, nil)
+
+	tester.WithBody(t, map[string]any{"base_url": "https://gitlab.example.com", "access_token": "token", "search": "foo"}).Execute()
+	tester.ResponseEq(t, http.StatusOK, tester.OKText, []types.RemoteRepository{})
+}
+
+func TestImportHandler_ImportStatus(t *testing.T) {
+	tester := NewImportTester(t).WithHandleFunc(func(h *importHandlerImpl) gin.HandlerFunc {
+		return h.ImportStatus
+	})
+	tester.WithUser()
+
+	repos := []types.ImportedRepository{{
+		Provider:   types.ImportProviderGitHub,
+		SourcePath: "octo/n",
+		LocalPath:  "ns/n",
+		Status:     string(types.MirrorSyncOverallRunning),
+		Progress:   50,
+	}}
+	tester.mocks.importer.EXPECT().ImportStatus(tester.Ctx(), types.ImportStatusReq{
+		CurrentUser: "u",
+		Per:         10,
+		Page:        1,
+	}).Return(repos, 1, nil)
+
+	tester.AddPagination(1, 10).Execute()
+	tester.ResponseEqSimple(t, http.StatusOK, gin.H{"msg": "OK", "data": repos, "total": 1})
+}
@@ -0,0 +1,199 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.temporal.io/sdk/client"
+	"opencsg.com/csghub-server/api/httpbase"
+	"opencsg.com/csghub-server/api/workflow"
+	"opencsg.com/csghub-server/builder/temporal"
+	"opencsg.com/csghub-server/common/config"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+	"opencsg.com/csghub-server/component"
+)
+
+type InferenceRolloutHandler struct {
+	rollout  component.InferenceRolloutComponent
+	temporal temporal.Client
+}
+
+func NewInferenceRolloutHandler(config *config.Config) (*InferenceRolloutHandler, error) {
+	rc, err := component.NewInferenceRolloutComponent(config)
+	if err != nil {
+		return nil, fmt.Errorf("error creating inference rollout component:%w", err)
+	}
+	return &InferenceRolloutHandler{
+		rollout:  rc,
+		temporal: temporal.GetClient(),
+	}, nil
+}
+
+// CreateRollout      godoc
+// @Security     ApiKey
+// @Summary      start a progressive traffic rollout to an inference version
+// @Description  shift traffic to the canary version step by step, and roll back automatically when its error rate or latency breaches the limits
+// @Tags         Model
+// @Accept       json
+// @Produce      json
+// @Param        namespace path string true "namespace"
+// @Param        name path string true "name"
+// @Param        id path int true "deploy id"
+// @Param        req body types.CreateInferenceRolloutReq true "req"
+// @Success      200  {object}  types.Response{data=types.InferenceRollout} "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /models/{namespace}/{name}/run/versions/{id}/rollout [post]
+func (h *InferenceRolloutHandler) CreateRollout(ctx *gin.Context) {
+	currentUser := httpbase.GetCurrentUser(ctx)
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil || id == 0 {
+		slog.ErrorContext(ctx.Request.Context(), "Bad request format", "error", err)
+		err = errorx.ReqParamInvalid(err, errorx.Ctx().Set("param", "id"))
+		httpbase.BadRequestWithExt(ctx, err)
+		return
+	}
+
+	var req types.CreateInferenceRolloutReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "failed to bind json", "error", err)
+		httpbase.BadRequestWithExt(ctx, err)
+		return
+	}
+	req.CurrentUser = currentUser
+	req.DeployID = id
+
+	rollout, err := h.rollout.CreateRollout(ctx.Request.Context(), req)
+	if err != nil {
+		if errors.Is(err, errorx.ErrForbidden) {
+			slog.WarnContext(ctx.Request.Context(), "not allowed to create inference rollout",
+				slog.Any("error", err), slog.Any("req", req))
+			httpbase.ForbiddenError(ctx, err)
+			return
+		}
+		slog.ErrorContext(ctx.Request.Context(), "failed to create inference rollout", "error", err, "req", req)
+		httpbase.ServerError(ctx, err)
+		return
+	}
+
+	workflowOptions := client.StartWorkflowOptions{
+		TaskQueue: workflow.HandlePushQueueName,
+		ID:        workflow.InferenceRolloutWorkflowID(rollout.ID),
+	}
+	_, err = h.temporal.ExecuteWorkflow(ctx.Request.Context(), workflowOptions, workflow.InferenceRolloutWorkflow, rollout)
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "failed to start inference rollout workflow",
+			slog.Int64("rollout_id", rollout.ID), slog.Any("error", err))
+		if finishErr := h.rollout.FinishRollout(ctx.Request.Context(), rollout.ID, types.InferenceRolloutFailed,
+			"failed to start rollout workflow"); finishErr != nil {
+			slog.ErrorContext(ctx.Request.Context(), "failed to mark inference rollout failed",
+				slog.Int64("rollout_id", rollout.ID), slog.Any("error", finishErr))
+		}
+		httpbase.ServerError(ctx, err)
+		return
+	}
+
+	httpbase.OK(ctx, rollout)
+}
+
+// GetRollout      godoc
+// @Security     ApiKey
+// @Summary      get the latest traffic rollout of an inference deploy
+// @Tags         Model
+// @Accept       json
+// @Produce      json
+// @Param        namespace path string true "namespace"
+// @Param        name path string true "name"
+// @Param        id path int true "deploy id"
+// @Success      200  {object}  types.Response{data=types.InferenceRollout} "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /models/{namespace}/{name}/run/versions/{id}/rollout [get]
+func (h *InferenceRolloutHandler) GetRollout(ctx *gin.Context) {
+	currentUser := httpbase.GetCurrentUser(ctx)
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil || id == 0 {
+		slog.ErrorContext(ctx.Request.Context(), "Bad request format", "error", err)
+		err = errorx.ReqParamInvalid(err, errorx.Ctx().Set("param", "id"))
+		httpbase.BadRequestWithExt(ctx, err)
+		return
+	}
+
+	req := types.DeployActReq{
+		CurrentUser: currentUser,
+		DeployID:    id,
+	}
+	rollout, err := h.rollout.GetRollout(ctx.Request.Context(), req)
+	if err != nil {
+		if errors.Is(err, errorx.ErrForbidden) {
+			slog.WarnContext(ctx.Request.Context(), "not allowed to get inference rollout",
+				slog.Any("error", err), slog.Any("req", req))
+			httpbase.ForbiddenError(ctx, err)
+			return
+		}
+		slog.ErrorContext(ctx.Request.Context(), "failed to get inference rollout", "error", err)
+		httpbase.ServerError(ctx, err)
+		return
+	}
+
+	httpbase.OK(ctx, rollout)
+}
+
+// CancelRollout      godoc
+// @Security     ApiKey
+// @Summary      cancel the running traffic rollout of an inference deploy
+// @Description  all traffic goes back to the stable version
+// @Tags         Model
+// @Accept       json
+// @Produce      json
+// @Param        namespace path string true "namespace"
+// @Param        name path string true "name"
+// @Param        id path int true "deploy id"
+// @Success      200  {object}  types.Response{} "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /models/{namespace}/{name}/run/versions/{id}/rollout/cancel [post]
+func (h *InferenceRolloutHandler) CancelRollout(ctx *gin.Context) {
+	currentUser := httpbase.GetCurrentUser(ctx)
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil || id == 0 {
+		slog.ErrorContext(ctx.Request.Context(), "Bad request format", "error", err)
+		err = errorx.ReqParamInvalid(err, errorx.Ctx().Set("param", "id"))
+		httpbase.BadRequestWithExt(ctx, err)
+		return
+	}
+
+	req := types.DeployActReq{
+		CurrentUser: currentUser,
+		DeployID:    id,
+	}
+	rollout, err := h.rollout.CancelRollout(ctx.Request.Context(), req)
+	if err != nil {
+		if errors.Is(err, errorx.ErrForbidden) {
+			slog.WarnContext(ctx.Request.Context(), "not allowed to cancel inference rollout",
+				slog.Any("error", err), slog.Any("req", req))
+			httpbase.ForbiddenError(ctx, err)
+			return
+		}
+		slog.ErrorContext(ctx.Request.Context(), "failed to cancel inference rollout", "error", err)
+		httpbase.ServerError(ctx, err)
+		return
+	}
+
+	// the workflow rolls the traffic back when it is cancelled
+	if rollout != nil && rollout.Status == types.InferenceRolloutRunning {
+		err = h.temporal.CancelWorkflow(ctx.Request.Context(), workflow.InferenceRolloutWorkflowID(rollout.ID), "")
+		if err != nil {
+			slog.ErrorContext(ctx.Request.Context(), "failed to cancel inference rollout workflow",
+				slog.Int64("rollout_id", rollout.ID), slog.Any("error", err))
+			httpbase.ServerError(ctx, err)
+			return
+		}
+	}
+
+	httpbase.OK(ctx, nil)
+}
@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"go.temporal.io/sdk/client"
+	temporal_mock "go.temporal.io/sdk/mocks"
+	workflow_mock "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/builder/temporal"
+	mockcomponent "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/component"
+	"opencsg.com/csghub-server/api/workflow"
+	"opencsg.com/csghub-server/builder/testutil"
+	"opencsg.com/csghub-server/common/types"
+)
+
+type InferenceRolloutTester struct {
+	*testutil.GinTester
+	handler *InferenceRolloutHandler
+	mocks   struct {
+		rollout  *mockcomponent.MockInferenceRolloutComponent
+		workflow *workflow_mock.MockClient
+	}
+}
+
+func NewInferenceRolloutTester(t *testing.T) *InferenceRolloutTester {
+	tester := &InferenceRolloutTester{GinTester: testutil.NewGinTester()}
+	tester.mocks.rollout = mockcomponent.NewMockInferenceRolloutComponent(t)
+	tester.mocks.workflow = workflow_mock.NewMockClient(t)
+
+	tester.handler = &InferenceRolloutHandler{
+		rollout:  tester.mocks.rollout,
+		temporal: tester.mocks.workflow,
+	}
+	tester.WithParam("namespace", "u")
+	tester.WithParam("name", "r")
+	tester.WithParam("id", "1")
+	return tester
+}
+
+func (t *InferenceRolloutTester) WithHandleFunc(fn func(h *InferenceRolloutHandler) gin.HandlerFunc) *InferenceRolloutTester {
+	t.Handler(fn(t.handler))
+	return t
+}
+
+func TestInferenceRolloutHandler_CreateRollout(t *testing.T) {
+	tester := NewInferenceRolloutTester(t).WithHandleFunc(func(h *InferenceRolloutHandler) gin.HandlerFunc {
+		return h.CreateRollout
+	})
+	tester.WithUser()
+
+	rollout := &types.InferenceRollout{ID: 2, DeployID: 1, Steps: []int{10, 100}, Status: types.InferenceRolloutRunning}
+	tester.mocks.rollout.EXPECT().CreateRollout(tester.Ctx(), types.CreateInferenceRolloutReq{
+		CurrentUser: "u", DeployID: 1, CommitID: "bbbbbbb", Steps: []int{10, 100},
+	}).Return(rollout, nil)
+	runMock := &temporal_mock.WorkflowRun{}
+	tester.mocks.workflow.EXPECT().ExecuteWorkflow(tester.Ctx(), client.StartWorkflowOptions{
+		TaskQueue: workflow.HandlePushQueueName,
+		ID:        "inference-rollout-2",
+	}, mock.Anything, rollout).Return(runMock, nil)
+
+	tester.WithBody(t, &types.CreateInferenceRolloutReq{CommitID: "bbbbbbb", Steps: []int{10, 100}}).Execute()
+	tester.ResponseEq(t, 200, tester.OKText, rollout)
+}
+
+func TestInferenceRolloutHandler_GetRollout(t *testing.T) {
+	tester := NewInferenceRolloutTester(t).WithHandleFunc(func(h *InferenceRolloutHandler) gin.HandlerFunc {
+		return h.GetRollout
+	})
+	tester.WithUser()
+
+	rollout := &types.InferenceRollout{ID: 2, DeployID: 1, Status: types.InferenceRolloutSucceeded}
+	tester.mocks.rollout.EXPECT().GetRollout(tester.Ctx(), types.DeployActReq{
+		CurrentUser: "u", DeployID: 1,
+	}).Return(rollout, nil)
+
+	tester.Execute()
+	tester.ResponseEq(t, 200, tester.OKText, rollout)
+}
+
+func TestInferenceRolloutHandler_CancelRollout(t *testing.T) {
+	tester := NewInferenceRolloutTester(t).WithHandleFunc(func(h *InferenceRolloutHandler) gin.HandlerFunc {
+		return h.CancelRollout
+	})
+	tester.WithUser()
+
+	tester.mocks.rollout.EXPECT().CancelRollout(tester.Ctx(), types.DeployActReq{
+		CurrentUser: "u", DeployID: 1,
+	}).Return(&types.InferenceRollout{ID: 2, Status: types.InferenceRolloutRunning}, nil)
+	tester.mocks.workflow.EXPECT().CancelWorkflow(tester.Ctx(), "inference-rollout-2", "").Return(nil)
+
+	tester.Execute()
+	tester.ResponseEq(t, 200, tester.OKText, nil)
+}
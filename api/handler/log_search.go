@@ -0,0 +1,204 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"opencsg.com/csghub-server/api/httpbase"
+	"opencsg.com/csghub-server/common/config"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+	"opencsg.com/csghub-server/component"
+)
+
+func NewLogSearchHandler(config *config.Config) (*LogSearchHandler, error) {
+	c, err := component.NewLogSearchComponent(config)
+	if err != nil {
+		return nil, err
+	}
+	return &LogSearchHandler{c: c}, nil
+}
+
+type LogSearchHandler struct {
+	c component.LogSearchComponent
+}
+
+// SearchDeployLogs godoc
+// @Security     ApiKey
+// @Summary      Search the logs of a deploy
+// @Description  Search the run or image build logs of a deploy by time range, text, regex, level and pod, the logs are paged by the cursor
+// @Tags         Log
+// @Produce      json
+// @Param        id path int true "deploy id"
+// @Param        log_type query string false "build for the image build logs" Enums(build)
+// @Param        commit_id query string false "commit id of the deploy"
+// @Param        start query string false "start time in RFC3339"
+// @Param        end query string false "end time in RFC3339"
+// @Param        text query string false "text contained by the lines"
+// @Param        regex query string false "regex matched by the lines"
+// @Param        level query string false "log level" Enums(error, warn, info, debug)
+// @Param        pod query string false "pod name"
+// @Param        limit query int false "page size" default(100)
+// @Param        cursor query string false "next_cursor of the previous page"
+// @Param        direction query string false "direction" Enums(forward, backward) default(forward)
+// @Success      200  {object}  types.Response{data=types.LogSearchResult} "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      403  {object}  types.APIForbidden "Forbidden"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /deploys/{id}/logs/search [get]
+func (h *LogSearchHandler) SearchDeployLogs(ctx *gin.Context) {
+	req, ok := h.bindReq(ctx)
+	if !ok {
+		return
+	}
+	result, err := h.c.SearchDeployLogs(ctx.Request.Context(), req)
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "failed to search deploy logs", slog.Any("req", req), slog.Any("error", err))
+		handleLogSearchError(ctx, err)
+		return
+	}
+	httpbase.OK(ctx, result)
+}
+
+// DownloadDeployLogs godoc
+// @Security     ApiKey
+// @Summary      Download the logs of a deploy
+// @Description  Download all the run or image build logs of a deploy matched by the filters in gzip
+// @Tags         Log
+// @Produce      application/gzip
+// @Param        id path int true "deploy id"
+// @Param        log_type query string false "build for the image build logs" Enums(build)
+// @Param        commit_id query string false "commit id of the deploy"
+// @Param        start query string false "start time in RFC3339"
+// @Param        end query string false "end time in RFC3339"
+// @Param        text query string false "text contained by the lines"
+// @Param        regex query string false "regex matched by the lines"
+// @Param        level query string false "log level" Enums(error, warn, info, debug)
+// @Param        pod query string false "pod name"
+// @Success      200  {file}  file "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      403  {object}  types.APIForbidden "Forbidden"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /deploys/{id}/logs/download [get]
+func (h *LogSearchHandler) DownloadDeployLogs(ctx *gin.Context) {
+	req, ok := h.bindReq(ctx)
+	if !ok {
+		return
+	}
+	reader, err := h.c.DownloadDeployLogs(ctx.Request.Context(), req)
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "failed to download deploy logs", slog.Any("req", req), slog.Any("error", err))
+		handleLogSearchError(ctx, err)
+		return
+	}
+	writeLogDownload(ctx, reader, fmt.Sprintf("deploy-%d-logs.log.gz", req.ID))
+}
+
+// SearchWorkflowLogs godoc
+// @Security     ApiKey
+// @Summary      Search the logs of a workflow
+// @Description  Search the logs of a finetune or evaluation workflow by time range, text, regex, level and pod, the logs are paged by the cursor
+// @Tags         Log
+// @Produce      json
+// @Param        id path int true "workflow id"
+// @Param        start query string false "start time in RFC3339"
+// @Param        end query string false "end time in RFC3339"
+// @Param        text query string false "text contained by the lines"
+// @Param        regex query string false "regex matched by the lines"
+// @Param        level query string false "log level" Enums(error, warn, info, debug)
+// @Param        pod query string false "pod name"
+// @Param        limit query int false "page size" default(100)
+// @Param        cursor query string false "next_cursor of the previous page"
+// @Param        direction query string false "direction" Enums(forward, backward) default(forward)
+// @Success      200  {object}  types.Response{data=types.LogSearchResult} "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      403  {object}  types.APIForbidden "Forbidden"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /workflows/{id}/logs/search [get]
+func (h *LogSearchHandler) SearchWorkflowLogs(ctx *gin.Context) {
+	req, ok := h.bindReq(ctx)
+	if !ok {
+		return
+	}
+	result, err := h.c.SearchWorkflowLogs(ctx.Request.Context(), req)
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "failed to search workflow logs", slog.Any("req", req), slog.Any("error", err))
+		handleLogSearchError(ctx, err)
+		return
+	}
+	httpbase.OK(ctx, result)
+}
+
+// DownloadWorkflowLogs godoc
+// @Security     ApiKey
+// @Summary      Download the logs of a workflow
+// @Description  Download all the logs of a finetune or evaluation workflow matched by the filters in gzip
+// @Tags         Log
+// @Produce      application/gzip
+// @Param        id path int true "workflow id"
+// @Param        start query string false "start time in RFC3339"
+// @Param        end query string false "end time in RFC3339"
+// @Param        text query string false "text contained by the lines"
+// @Param        regex query string false "regex matched by the lines"
+// @Param        level query string false "log level" Enums(error, warn, info, debug)
+// @Param        pod query string false "pod name"
+// @Success      200  {file}  file "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      403  {object}  types.APIForbidden "Forbidden"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /workflows/{id}/logs/download [get]
+func (h *LogSearchHandler) DownloadWorkflowLogs(ctx *gin.Context) {
+	req, ok := h.bindReq(ctx)
+	if !ok {
+		return
+	}
+	reader, err := h.c.DownloadWorkflowLogs(ctx.Request.Context(), req)
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "failed to download workflow logs", slog.Any("req", req), slog.Any("error", err))
+		handleLogSearchError(ctx, err)
+		return
+	}
+	writeLogDownload(ctx, reader, fmt.Sprintf("workflow-%d-logs.log.gz", req.ID))
+}
+
+func (h *LogSearchHandler) bindReq(ctx *gin.Context) (*types.LogSearchReq, bool) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "Bad request format", "error", err)
+		httpbase.BadRequest(ctx, "invalid id")
+		return nil, false
+	}
+	var req types.LogSearchReq
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "Bad request format", "error", err)
+		httpbase.BadRequestWithExt(ctx, errorx.ReqParamInvalid(err, nil))
+		return nil, false
+	}
+	req.ID = id
+	req.CurrentUser = httpbase.GetCurrentUser(ctx)
+	return &req, true
+}
+
+func handleLogSearchError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, errorx.ErrForbidden):
+		httpbase.ForbiddenError(ctx, err)
+	case errors.Is(err, errorx.ErrDatabaseNoRows), errors.Is(err, errorx.ErrNotFound):
+		httpbase.NotFoundError(ctx, err)
+	case errors.Is(err, errorx.ErrBadRequest):
+		httpbase.BadRequestWithExt(ctx, err)
+	default:
+		httpbase.ServerError(ctx, err)
+	}
+}
+
+func writeLogDownload(ctx *gin.Context, reader io.ReadCloser, filename string) {
+	defer reader.Close()
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
+	ctx.DataFromReader(http.StatusOK, -1, "application/gzip", reader, nil)
+}
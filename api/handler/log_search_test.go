@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	mockcomponent "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/component"
+	"opencsg.com/csghub-server/builder/testutil"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+)
+
+type LogSearchTester struct {
+	*testutil.GinTester
+	handler *LogSearchHandler
+	mocks   struct {
+		comp *mockcomponent.MockLogSearchComponent
+	}
+}
+
+func NewLogSearchTester(t *testing.T) *LogSearchTester {
+	tester := &LogSearchTester{GinTester: testutil.NewGinTester()}
+	tester.mocks.comp = mockcomponent.NewMockLogSearchComponent(t)
+	tester.handler = &LogSearchHandler{c: tester.mocks.comp}
+	tester.WithParam("id", "1")
+	return tester
+}
+
+func (t *LogSearchTester) WithHandleFunc(fn func(h *LogSearchHandler) gin.HandlerFunc) *LogSearchTester {
+	t.Handler(fn(t.handler))
+	return t
+}
+
+func TestLogSearchHandler_SearchDeployLogs(t *testing.T) {
+	tester := NewLogSearchTester(t).WithHandleFunc(func(h *LogSearchHandler) gin.HandlerFunc {
+		return h.SearchDeployLogs
+	})
+	tester.WithUser()
+
+	result := &types.LogSearchResult{Entries: []types.LogSearchEntry{{Line: "ERROR failed"}}, NextCursor: "100"}
+	tester.mocks.comp.EXPECT().SearchDeployLogs(tester.Ctx(), mock.MatchedBy(func(req *types.LogSearchReq) bool {
+		return req.ID == 1 && req.CurrentUser == "u" && req.Level == types.LogLevelError &&
+			req.Limit == 10 && req.Start.Year() == 2026
+	})).Return(result, nil)
+
+	tester.WithQuery("level", "error").WithQuery("limit", "10").WithQuery("start", "2026-10-18T00:00:00Z").Execute()
+	tester.ResponseEq(t, http.StatusOK, tester.OKText, result)
+}
+
+func TestLogSearchHandler_SearchWorkflowLogsForbidden(t *testing.T) {
+	tester := NewLogSearchTester(t).WithHandleFunc(func(h *LogSearchHandler) gin.HandlerFunc {
+		return h.SearchWorkflowLogs
+	})
+	tester.WithUser()
+
+	tester.mocks.comp.EXPECT().SearchWorkflowLogs(tester.Ctx(), mock.Anything).Return(nil, errorx.Forbidden(errorx.ErrForbidden, nil))
+	tester.Execute()
+	require.Equal(t, http.StatusForbidden, tester.Response().Code)
+}
+
+func TestLogSearchHandler_InvalidQuery(t *testing.T) {
+	tester := NewLogSearchTester(t).WithHandleFunc(func(h *LogSearchHandler) gin.HandlerFunc {
+		return h.SearchDeployLogs
+	})
+	tester.WithUser()
+
+	tester.WithQuery("start", "yesterday").Execute()
+	require.Equal(t, http.StatusBadRequest, tester.Response().Code)
+}
+
+func TestLogSearchHandler_DownloadDeployLogs(t *testing.T) {
+	tester := NewLogSearchTester(t).WithHandleFunc(func(h *LogSearchHandler) gin.HandlerFunc {
+		return h.DownloadDeployLogs
+	})
+	tester.WithUser()
+
+	tester.mocks.comp.EXPECT().DownloadDeployLogs(tester.Ctx(), mock.MatchedBy(func(req *types.LogSearchReq) bool {
+		return req.ID == 1 && req.Text == "oom"
+	})).Return(io.NopCloser(strings.NewReader("gzip data")), nil)
+
+	tester.WithQuery("text", "oom").Execute()
+	require.Equal(t, http.StatusOK, tester.Response().Code)
+	require.Equal(t, "application/gzip", tester.Response().Header().Get("Content-Type"))
+	require.Equal(t, "attachment; filename=deploy-1-logs.log.gz", tester.Response().Header().Get("Content-Disposition"))
+	require.Equal(t, "gzip data", tester.Response().Body.String())
+}
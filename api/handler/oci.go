@@ -0,0 +1,169 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"opencsg.com/csghub-server/api/httpbase"
+	"opencsg.com/csghub-server/common/config"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+	"opencsg.com/csghub-server/component"
+)
+
+// OCIHandler serves model repositories as read-only artifacts of the OCI distribution spec,
+// the errors are returned in the format of the spec instead of the api response
+type OCIHandler struct {
+	oci    component.OCIComponent
+	config *config.Config
+}
+
+func NewOCIHandler(config *config.Config) (*OCIHandler, error) {
+	oc, err := component.NewOCIComponent(config)
+	if err != nil {
+		return nil, fmt.Errorf("error creating oci component:%w", err)
+	}
+	return &OCIHandler{
+		oci:    oc,
+		config: config,
+	}, nil
+}
+
+// ociError writes the error in the format of the distribution spec, a challenge is returned
+// to anonymous users so that the clients retry with the access token as the password
+func (h *OCIHandler) ociError(ctx *gin.Context, err error, unknownCode string) {
+	status := http.StatusInternalServerError
+	code := ""
+	switch {
+	case errors.Is(err, errorx.ErrDatabaseNoRows):
+		status, code = http.StatusNotFound, types.OCIErrNameUnknown
+	case errors.Is(err, errorx.ErrNotFound):
+		status, code = http.StatusNotFound, unknownCode
+	case errors.Is(err, errorx.ErrUnauthorized):
+		status, code = http.StatusUnauthorized, types.OCIErrUnauthorized
+	case errors.Is(err, errorx.ErrForbidden):
+		status, code = http.StatusForbidden, types.OCIErrDenied
+	case errors.Is(err, errorx.ErrBadRequest):
+		status, code = http.StatusBadRequest, types.OCIErrDigestInvalid
+	}
+	if status == http.StatusInternalServerError {
+		slog.ErrorContext(ctx.Request.Context(), "failed to serve oci request", slog.String("path", ctx.Request.URL.Path), slog.Any("error", err))
+		code = types.OCIErrUnsupported
+	}
+	if status == http.StatusUnauthorized {
+		ctx.Header("WWW-Authenticate", fmt.Sprintf(`Basic realm=%q`, h.config.APIServer.PublicDomain))
+	}
+	ctx.Header("Docker-Distribution-API-Version", types.OCIDistributionAPIVersion)
+	ctx.AbortWithStatusJSON(status, types.OCIErrorRes{
+		Errors: []types.OCIError{{Code: code, Message: err.Error()}},
+	})
+}
+
+// Ping         godoc
+// @Security     ApiKey
+// @Summary      Check the OCI distribution API version
+// @Tags         OCI
+// @Produce      json
+// @Success      200  {object}  object "OK"
+// @Failure      401  {object}  types.OCIErrorRes "Unauthorized"
+// @Router       /v2/ [get]
+func (h *OCIHandler) Ping(ctx *gin.Context) {
+	// docker only sends the credentials if the version check is challenged,
+	// anonymous pulls of public models still work as the credentials are optional
+	if httpbase.GetCurrentUser(ctx) == "" {
+		h.ociError(ctx, errorx.ErrUnauthorized, "")
+		return
+	}
+	ctx.Header("Docker-Distribution-API-Version", types.OCIDistributionAPIVersion)
+	ctx.JSON(http.StatusOK, gin.H{})
+}
+
+// GetManifest  godoc
+// @Security     ApiKey
+// @Summary      Get the OCI manifest of a model revision, one layer per file of the revision
+// @Tags         OCI
+// @Produce      json
+// @Param        namespace path string true "namespace"
+// @Param        name path string true "name"
+// @Param        reference path string true "tag, branch, commit or manifest digest, latest is the default branch"
+// @Success      200  {object}  types.OCIManifest "OK"
+// @Failure      401  {object}  types.OCIErrorRes "Unauthorized"
+// @Failure      404  {object}  types.OCIErrorRes "Not found"
+// @Router       /v2/{namespace}/{name}/manifests/{reference} [get]
+func (h *OCIHandler) GetManifest(ctx *gin.Context) {
+	req := &types.OCIManifestReq{
+		Namespace:   ctx.Param("namespace"),
+		Name:        ctx.Param("name"),
+		Reference:   ctx.Param("reference"),
+		CurrentUser: httpbase.GetCurrentUser(ctx),
+	}
+	manifest, err := h.oci.GetManifest(ctx.Request.Context(), req)
+	if err != nil {
+		h.ociError(ctx, err, types.OCIErrManifestUnknown)
+		return
+	}
+	ctx.Header("Docker-Distribution-API-Version", types.OCIDistributionAPIVersion)
+	ctx.Header("Docker-Content-Digest", manifest.Digest)
+	ctx.Header("Content-Length", strconv.Itoa(len(manifest.Content)))
+	if ctx.Request.Method == http.MethodHead {
+		ctx.Header("Content-Type", manifest.MediaType)
+		ctx.Status(http.StatusOK)
+		return
+	}
+	ctx.Data(http.StatusOK, manifest.MediaType, manifest.Content)
+}
+
+// GetBlob      godoc
+// @Security     ApiKey
+// @Summary      Get a blob of a model artifact, LFS files are redirected to the object storage
+// @Tags         OCI
+// @Produce      octet-stream
+// @Param        namespace path string true "namespace"
+// @Param        name path string true "name"
+// @Param        digest path string true "digest of the blob"
+// @Success      200  {file}  file "OK"
+// @Success      307  "Redirect to the object storage"
+// @Failure      401  {object}  types.OCIErrorRes "Unauthorized"
+// @Failure      404  {object}  types.OCIErrorRes "Not found"
+// @Router       /v2/{namespace}/{name}/blobs/{digest} [get]
+func (h *OCIHandler) GetBlob(ctx *gin.Context) {
+	req := &types.OCIBlobReq{
+		Namespace:   ctx.Param("namespace"),
+		Name:        ctx.Param("name"),
+		Digest:      ctx.Param("digest"),
+		CurrentUser: httpbase.GetCurrentUser(ctx),
+	}
+	ctx.Header("Docker-Distribution-API-Version", types.OCIDistributionAPIVersion)
+	if ctx.Request.Method == http.MethodHead {
+		desc, err := h.oci.StatBlob(ctx.Request.Context(), req)
+		if err != nil {
+			h.ociError(ctx, err, types.OCIErrBlobUnknown)
+			return
+		}
+		ctx.Header("Docker-Content-Digest", desc.Digest)
+		ctx.Header("Content-Length", strconv.FormatInt(desc.Size, 10))
+		ctx.Header("Content-Type", "application/octet-stream")
+		ctx.Status(http.StatusOK)
+		return
+	}
+
+	blob, err := h.oci.GetBlob(ctx.Request.Context(), req)
+	if err != nil {
+		h.ociError(ctx, err, types.OCIErrBlobUnknown)
+		return
+	}
+	ctx.Header("Docker-Content-Digest", blob.Descriptor.Digest)
+	switch {
+	case blob.DownloadURL != "":
+		ctx.Redirect(http.StatusTemporaryRedirect, blob.DownloadURL)
+	case blob.Reader != nil:
+		defer blob.Reader.Close()
+		ctx.DataFromReader(http.StatusOK, blob.Descriptor.Size, "application/octet-stream", blob.Reader, nil)
+	default:
+		ctx.Data(http.StatusOK, blob.Descriptor.MediaType, blob.Content)
+	}
+}
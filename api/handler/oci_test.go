@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	mockcomponent "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/component"
+	"opencsg.com/csghub-server/builder/testutil"
+	"opencsg.com/csghub-server/common/config"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+)
+
+type OCITester struct {
+	*testutil.GinTester
+	handler *OCIHandler
+	mocks   struct {
+		oci *mockcomponent.MockOCIComponent
+	}
+}
+
+func NewOCITester(t *testing.T) *OCITester {
+	tester := &OCITester{GinTester: testutil.NewGinTester()}
+	tester.mocks.oci = mockcomponent.NewMockOCIComponent(t)
+
+	cfg := &config.Config{}
+	cfg.APIServer.PublicDomain = "https://hub.example.com"
+	tester.handler = &OCIHandler{
+		oci:    tester.mocks.oci,
+		config: cfg,
+	}
+	tester.WithParam("namespace", "ns")
+	tester.WithParam("name", "n")
+	return tester
+}
+
+func (t *OCITester) WithHandleFunc(fn func(h *OCIHandler) gin.HandlerFunc) *OCITester {
+	t.Handler(fn(t.handler))
+	return t
+}
+
+func TestOCIHandler_Ping(t *testing.T) {
+	tester := NewOCITester(t).WithHandleFunc(func(h *OCIHandler) gin.HandlerFunc {
+		return h.Ping
+	})
+	tester.Execute()
+	tester.ResponseEqCode(t, http.StatusUnauthorized)
+	require.Equal(t, `Basic realm="https://hub.example.com"`, tester.Response().Header().Get("WWW-Authenticate"))
+
+	tester = NewOCITester(t).WithHandleFunc(func(h *OCIHandler) gin.HandlerFunc {
+		return h.Ping
+	})
+	tester.WithUser()
+	tester.Execute()
+	tester.ResponseEqSimple(t, http.StatusOK, gin.H{})
+	require.Equal(t, types.OCIDistributionAPIVersion, tester.Response().Header().Get("Docker-Distribution-API-Version"))
+}
+
+func TestOCIHandler_GetManifest(t *testing.T) {
+	tester := NewOCITester(t).WithHandleFunc(func(h *OCIHandler) gin.HandlerFunc {
+		return h.GetManifest
+	})
+	tester.WithUser().WithParam("reference", "latest")
+	tester.mocks.oci.EXPECT().GetManifest(tester.Ctx(), &types.OCIManifestReq{
+		Namespace: "ns", Name: "n", Reference: "latest", CurrentUser: "u",
+	}).Return(&types.OCIManifestResp{
+		Digest: "sha256:m", MediaType: types.OCIManifestMediaType, Content: []byte(`{"schemaVersion":2}`),
+	}, nil)
+
+	tester.Execute()
+	tester.ResponseEqSimple(t, http.StatusOK, gin.H{"schemaVersion": 2})
+	require.Equal(t, "sha256:m", tester.Response().Header().Get("Docker-Content-Digest"))
+	require.Equal(t, types.OCIManifestMediaType, tester.Response().Header().Get("Content-Type"))
+}
+
+func TestOCIHandler_GetManifestErrors(t *testing.T) {
+	cases := []struct {
+		err    error
+		status int
+		code   string
+	}{
+		{errorx.ErrDatabaseNoRows, http.StatusNotFound, types.OCIErrNameUnknown},
+		{errorx.ErrNotFound, http.StatusNotFound, types.OCIErrManifestUnknown},
+		{errorx.ErrUnauthorized, http.StatusUnauthorized, types.OCIErrUnauthorized},
+		{errorx.ErrForbidden, http.StatusForbidden, types.OCIErrDenied},
+	}
+	for _, c := range cases {
+		tester := NewOCITester(t).WithHandleFunc(func(h *OCIHandler) gin.HandlerFunc {
+			return h.GetManifest
+		})
+		tester.WithParam("reference", "main")
+		tester.mocks.oci.EXPECT().GetManifest(tester.Ctx(), &types.OCIManifestReq{
+			Namespace: "ns", Name: "n", Reference: "main",
+		}).Return(nil, c.err)
+
+		tester.Execute()
+		tester.ResponseEqSimple(t, c.status, types.OCIErrorRes{
+			Errors: []types.OCIError{{Code: c.code, Message: c.err.Error()}},
+		})
+	}
+}
+
+func TestOCIHandler_GetBlob(t *testing.T) {
+	req := &types.OCIBlobReq{Namespace: "ns", Name: "n", Digest: "sha256:b", CurrentUser: "u"}
+	desc := types.OCIDescriptor{MediaType: types.OCIModelWeightMediaType, Digest: "sha256:b", Size: 5}
+
+	tester := NewOCITester(t).WithHandleFunc(func(h *OCIHandler) gin.HandlerFunc {
+		return h.GetBlob
+	})
+	tester.WithUser().WithParam("digest", "sha256:b")
+	tester.Gctx().Request.Method = http.MethodGet
+	tester.mocks.oci.EXPECT().GetBlob(tester.Ctx(), req).Return(&types.OCIBlobResp{
+		Descriptor: desc, DownloadURL: "https://s3.example.com/lfs",
+	}, nil)
+	tester.Execute()
+	tester.ResponseEqCode(t, http.StatusTemporaryRedirect)
+	require.Equal(t, "https://s3.example.com/lfs", tester.Response().Header().Get("Location"))
+
+	tester = NewOCITester(t).WithHandleFunc(func(h *OCIHandler) gin.HandlerFunc {
+		return h.GetBlob
+	})
+	tester.WithUser().WithParam("digest", "sha256:b")
+	tester.mocks.oci.EXPECT().GetBlob(tester.Ctx(), req).Return(&types.OCIBlobResp{
+		Descriptor: desc, Reader: io.NopCloser(strings.NewReader("hello")),
+	}, nil)
+	tester.Execute()
+	tester.ResponseEqCode(t, http.StatusOK)
+	require.Equal(t, "hello", tester.Response().Body.String())
+	require.Equal(t, "sha256:b", tester.Response().Header().Get("Docker-Content-Digest"))
+
+	tester = NewOCITester(t).WithHandleFunc(func(h *OCIHandler) gin.HandlerFunc {
+		return h.GetBlob
+	})
+	tester.WithUser().WithParam("digest", "sha256:b")
+	tester.Gctx().Request.Method = http.MethodHead
+	tester.mocks.oci.EXPECT().StatBlob(tester.Ctx(), req).Return(&desc, nil)
+	tester.Execute()
+	tester.ResponseEqCode(t, http.StatusOK)
+	require.Equal(t, "5", tester.Response().Header().Get("Content-Length"))
+	require.Empty(t, tester.Response().Body.String())
+}
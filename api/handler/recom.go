@@ -1,12 +1,16 @@
 package handler
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
 
 	"github.com/gin-gonic/gin"
 	"opencsg.com/csghub-server/api/httpbase"
 	"opencsg.com/csghub-server/common/config"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+	"opencsg.com/csghub-server/common/utils/common"
 	"opencsg.com/csghub-server/component"
 )
 
@@ -58,3 +62,91 @@ func (h *RecomHandler) SetOpWeight(ctx *gin.Context) {
 	}
 	httpbase.OK(ctx, nil)
 }
+
+// RelatedRepos godoc
+// @Security     ApiKey
+// @Summary      Get the related repositories of a repository
+// @Description  Get the repositories used together with the repository, like "users who used this model also used", calculated offline from the likes of the users and the collections
+// @Tags         Recommendation
+// @Produce      json
+// @Param        repo_type path string true "repository type" Enums(models, datasets, codes, spaces, prompts, mcpservers)
+// @Param        namespace path string true "namespace"
+// @Param        name path string true "name"
+// @Param        limit query int false "max number of the repositories" default(10)
+// @Param        current_user query string false "current user"
+// @Success      200  {object}  types.Response{data=[]types.RecomRepo} "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      403  {object}  types.APIForbidden "Forbidden"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /recom/{repo_type}/{namespace}/{name}/related [get]
+func (h *RecomHandler) RelatedRepos(ctx *gin.Context) {
+	var req types.RelatedReposReq
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		httpbase.BadRequestWithExt(ctx, errorx.ReqParamInvalid(err, nil))
+		return
+	}
+	repoType, err := common.RepoTypeFromString(ctx.Param("repo_type"))
+	if err != nil {
+		httpbase.BadRequestWithExt(ctx, errorx.ReqParamInvalid(err, errorx.Ctx().Set("param", "repo_type")))
+		return
+	}
+	namespace, name, err := common.GetNamespaceAndNameFromContext(ctx)
+	if err != nil {
+		httpbase.BadRequestWithExt(ctx, errorx.ReqParamInvalid(err, nil))
+		return
+	}
+	req.RepoType = repoType
+	req.Namespace = namespace
+	req.Name = name
+	req.CurrentUser = httpbase.GetCurrentUser(ctx)
+
+	repos, err := h.c.RelatedRepos(ctx.Request.Context(), &req)
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "failed to get related repos", slog.Any("req", req), slog.Any("error", err))
+		if errors.Is(err, errorx.ErrForbidden) || errors.Is(err, errorx.ErrUserNotFound) {
+			httpbase.ForbiddenError(ctx, err)
+			return
+		}
+		if errors.Is(err, errorx.ErrDatabaseNoRows) {
+			httpbase.NotFoundError(ctx, err)
+			return
+		}
+		httpbase.ServerError(ctx, err)
+		return
+	}
+	httpbase.OK(ctx, repos)
+}
+
+// Feed godoc
+// @Security     ApiKey
+// @Summary      Get the recommended repositories of the current user
+// @Description  Get the repositories related to the ones liked by the current user, the trending repositories are returned if there are no related ones
+// @Tags         Recommendation
+// @Produce      json
+// @Param        repo_type query string false "repository type" Enums(model, dataset, code, space, prompt, mcpserver)
+// @Param        limit query int false "max number of the repositories" default(10)
+// @Success      200  {object}  types.Response{data=[]types.RecomRepo} "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      401  {object}  types.APIUnauthorized "Permission denied"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /recom/feed [get]
+func (h *RecomHandler) Feed(ctx *gin.Context) {
+	var req types.RecomFeedReq
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		httpbase.BadRequestWithExt(ctx, errorx.ReqParamInvalid(err, nil))
+		return
+	}
+	if req.RepoType != "" && !req.RepoType.IsValid() {
+		httpbase.BadRequestWithExt(ctx, errorx.ReqParamInvalid(fmt.Errorf("invalid repo type %s", req.RepoType), errorx.Ctx().Set("param", "repo_type")))
+		return
+	}
+	req.CurrentUser = httpbase.GetCurrentUser(ctx)
+
+	repos, err := h.c.Feed(ctx.Request.Context(), &req)
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "failed to get recommendation feed", slog.Any("req", req), slog.Any("error", err))
+		httpbase.ServerError(ctx, err)
+		return
+	}
+	httpbase.OK(ctx, repos)
+}
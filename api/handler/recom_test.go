@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	mockcomponent "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/component"
+	"opencsg.com/csghub-server/builder/testutil"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+)
+
+type RecomTester struct {
+	*testutil.GinTester
+	handler *RecomHandler
+	mocks   struct {
+		recom *mockcomponent.MockRecomComponent
+	}
+}
+
+func NewRecomTester(t *testing.T) *RecomTester {
+	tester := &RecomTester{GinTester: testutil.NewGinTester()}
+	tester.mocks.recom = mockcomponent.NewMockRecomComponent(t)
+	tester.handler = &RecomHandler{c: tester.mocks.recom}
+	return tester
+}
+
+func (t *RecomTester) WithHandleFunc(fn func(h *RecomHandler) gin.HandlerFunc) *RecomTester {
+	t.Handler(fn(t.handler))
+	return t
+}
+
+func TestRecomHandler_RelatedRepos(t *testing.T) {
+	tester := NewRecomTester(t).WithHandleFunc(func(h *RecomHandler) gin.HandlerFunc {
+		return h.RelatedRepos
+	})
+	tester.WithUser()
+
+	repos := []types.RecomRepo{{ID: 2, Path: "ns/b", Score: 0.5}}
+	tester.mocks.recom.EXPECT().RelatedRepos(tester.Ctx(), &types.RelatedReposReq{
+		RepoType:    types.ModelRepo,
+		Namespace:   "ns",
+		Name:        "n",
+		CurrentUser: "u",
+		Limit:       5,
+	}).Return(repos, nil)
+
+	tester.WithParam("repo_type", "models").WithParam("namespace", "ns").WithParam("name", "n").
+		WithQuery("limit", "5").Execute()
+	tester.ResponseEq(t, http.StatusOK, tester.OKText, repos)
+}
+
+func TestRecomHandler_RelatedReposForbidden(t *testing.T) {
+	tester := NewRecomTester(t).WithHandleFunc(func(h *RecomHandler) gin.HandlerFunc {
+		return h.RelatedRepos
+	})
+
+	tester.mocks.recom.EXPECT().RelatedRepos(tester.Ctx(), mock.Anything).Return(nil, errorx.ErrForbidden)
+
+	tester.WithParam("repo_type", "models").WithParam("namespace", "ns").WithParam("name", "n").Execute()
+	tester.ResponseEqCode(t, http.StatusForbidden)
+}
+
+func TestRecomHandler_Feed(t *testing.T) {
+	tester := NewRecomTester(t).WithHandleFunc(func(h *RecomHandler) gin.HandlerFunc {
+		return h.Feed
+	})
+	tester.WithUser()
+
+	repos := []types.RecomRepo{{ID: 3, Path: "ns/c", Score: 1.2}}
+	tester.mocks.recom.EXPECT().Feed(tester.Ctx(), &types.RecomFeedReq{
+		CurrentUser: "u",
+		RepoType:    types.DatasetRepo,
+	}).Return(repos, nil)
+
+	tester.WithQuery("repo_type", "dataset").Execute()
+	tester.ResponseEq(t, http.StatusOK, tester.OKText, repos)
+}
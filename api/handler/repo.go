@@ -62,6 +62,10 @@ func NewRepoHandler(config *config.Config) (*RepoHandler, error) {
 	if err != nil {
 		return nil, err
 	}
+	sc, err := component.NewSpaceComponent(config)
+	if err != nil {
+		return nil, err
+	}
 	mirror, err := component.NewMirrorComponent(config)
 	if err != nil {
 		return nil, err
@@ -70,6 +74,7 @@ func NewRepoHandler(config *config.Config) (*RepoHandler, error) {
 		c:                         uc,
 		m:                         m,
 		d:                         d,
+		s:                         sc,
 		mirror:                    mirror,
 		temporal:                  temporal.GetClient(),
 		deployStatusCheckInterval: time.Duration(config.Model.DeployStatusCheckInterval) * time.Second,
@@ -81,6 +86,7 @@ type RepoHandler struct {
 	c                         component.RepoComponent
 	m                         component.ModelComponent
 	d                         component.DatasetComponent
+	s                         component.SpaceComponent
 	mirror                    component.MirrorComponent
 	temporal                  temporal.Client
 	deployStatusCheckInterval time.Duration
@@ -233,18 +239,23 @@ func (h *RepoHandler) ValidateYaml(ctx *gin.Context) {
 		return
 	}
 
-	err := h.c.ValidateYaml(ctx.Request.Context(), req)
+	warnings, err := h.c.ValidateYaml(ctx.Request.Context(), req)
 	if err != nil {
 		slog.ErrorContext(ctx.Request.Context(), "Failed to validate yaml", slog.Any("error", err), slog.Any("req", req))
 		ctx.JSON(http.StatusBadRequest, gin.H{"errors": []string{err.Error()}})
 		return
 	}
+	if len(warnings) > 0 {
+		ctx.JSON(http.StatusOK, gin.H{"warnings": warnings})
+		return
+	}
 	ctx.JSON(http.StatusOK, gin.H{})
 }
 
 // CreateRepoFile godoc
 // @Security     ApiKey
 // @Summary      Create a new file in repository
+// @Description  The card_warnings of the README are returned for the commits made through this api, the warnings of the README pushed by git are returned by the card api
 // @Tags         Repository
 // @Accept       json
 // @Produce      json
@@ -295,6 +306,7 @@ func (h *RepoHandler) CreateFile(ctx *gin.Context) {
 // UpdateRepoFile godoc
 // @Security     ApiKey
 // @Summary      Update existing file in repository
+// @Description  The card_warnings of the README are returned for the commits made through this api, the warnings of the README pushed by git are returned by the card api
 // @Tags         Repository
 // @Accept       json
 // @Produce      json
@@ -504,6 +516,52 @@ func (h *RepoHandler) LastCommit(ctx *gin.Context) {
 	httpbase.OK(ctx, commit)
 }
 
+// GetRepoCard godoc
+// @Security     ApiKey
+// @Summary      Get the card of repository, it's the structured metadata of the README of the default branch
+// @Description  The warnings of the card are the README metadata not matching the card schema
+// @Tags         Repository
+// @Produce      json
+// @Param		 repo_type path string true "models or datasets" Enums(models,datasets)
+// @Param		 namespace path string true "repo owner name"
+// @Param		 name path string true "repo name"
+// @Param		 current_user query string false "current user name"
+// @Success      200  {object}  types.Response{data=types.RepoCard} "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      403  {object}  types.APIBadRequest "Forbidden"
+// @Failure      404  {object}  types.APIBadRequest "Not found"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /{repo_type}/{namespace}/{name}/card [get]
+func (h *RepoHandler) Card(ctx *gin.Context) {
+	namespace, name, err := common.GetNamespaceAndNameFromContext(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "Bad request format", "error", err)
+		httpbase.BadRequest(ctx, err.Error())
+		return
+	}
+	req := &types.GetRepoCardReq{
+		Namespace:   namespace,
+		Name:        name,
+		RepoType:    common.RepoTypeFromContext(ctx),
+		CurrentUser: httpbase.GetCurrentUser(ctx),
+	}
+	card, err := h.c.GetCard(ctx.Request.Context(), req)
+	if err != nil {
+		if errors.Is(err, errorx.ErrForbidden) {
+			httpbase.ForbiddenError(ctx, err)
+			return
+		}
+		if errors.Is(err, errorx.ErrNotFound) {
+			httpbase.NotFoundError(ctx, err)
+			return
+		}
+		slog.ErrorContext(ctx.Request.Context(), "Failed to get repo card", slog.String("repo_type", string(req.RepoType)), slog.Any("error", err), slog.Any("req", req))
+		httpbase.ServerError(ctx, err)
+		return
+	}
+	httpbase.OK(ctx, card)
+}
+
 // GetRepoFileContent godoc
 // @Security     ApiKey
 // @Summary      Get the last commit of repository
@@ -1160,7 +1218,7 @@ func (h *RepoHandler) SDKDownload(ctx *gin.Context) {
 // @Param		 namespace path string true "repo owner name"
 // @Param		 name path string true "repo name"
 // @Param		 file_path path string true "file path"
-// @Param		 ref query string true "branch or tag"
+// @Param		 ref query string true "branch, tag or release name, latest for the latest release"
 // @Param		 current_user query string false "current user name"
 // @Param        Range header string false "single byte range, for example bytes=0-1023"
 // @Param        If-Range header string false "strong ETag used to validate a range request"
@@ -1994,6 +2052,7 @@ func (h *RepoHandler) PreuploadHF(ctx *gin.Context) {
 // CommitFiles godoc
 // @Security     ApiKey
 // @Summary      Create commit with batch files
+// @Description  The card_warnings of the README are returned for the commits made through this api, the warnings of the README pushed by git are returned by the card api
 // @Tags         Repository
 // @Accept       json
 // @Produce      json
@@ -2003,7 +2062,7 @@ func (h *RepoHandler) PreuploadHF(ctx *gin.Context) {
 // @Param        revision path string true "revision"
 // @Param        current_user query string false "current user name"
 // @Param        body body types.CommitFilesReq true "body"
-// @Success      200  {object}  types.Response{} "OK"
+// @Success      200  {object}  types.Response{data=types.CommitFilesResp} "OK"
 // @Failure      400  {object}  types.APIBadRequest "Bad request"
 // @Failure      500  {object}  types.APIInternalServerError "Internal server error"
 // @Router       /{repo_type}/{namespace}/{name}/commit/{revision} [post]
@@ -2028,13 +2087,13 @@ func (h *RepoHandler) CommitFiles(ctx *gin.Context) {
 	req.RepoType = common.RepoTypeFromContext(ctx)
 	req.Revision = ctx.Param("revision")
 
-	err = h.c.CommitFiles(ctx.Request.Context(), req)
+	resp, err := h.c.CommitFiles(ctx.Request.Context(), req)
 	if err != nil {
 		slog.ErrorContext(ctx.Request.Context(), "failed to commit files", slog.Any("error", err))
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	httpbase.OK(ctx, nil)
+	httpbase.OK(ctx, resp)
 }
 
 func (h *RepoHandler) CommitFilesHF(ctx *gin.Context) {
@@ -2060,7 +2119,7 @@ func (h *RepoHandler) CommitFilesHF(ctx *gin.Context) {
 		req.Message = "initial commit"
 	}
 
-	err = h.c.CommitFiles(ctx.Request.Context(), *req)
+	_, err = h.c.CommitFiles(ctx.Request.Context(), *req)
 	if err != nil {
 		slog.ErrorContext(ctx.Request.Context(), "failed to commit files", slog.Any("error", err))
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -2322,6 +2381,75 @@ func (h *RepoHandler) BatchGetRepoExtra(ctx *gin.Context) {
 	httpbase.OK(ctx, extras)
 }
 
+// DownloadArchive godoc
+// @Security     ApiKey
+// @Summary      Download a repository revision as a zip or tar.gz archive
+// @Description  The archive is streamed, LFS files are archived as pointer files unless include_lfs is set
+// @Tags         Repository
+// @Produce      application/octet-stream
+// @Param        repo_type path string true "models,datasets,spaces or prompts" Enums(models,datasets,spaces,prompts)
+// @Param        namespace path string true "repo owner name"
+// @Param        name path string true "repo name"
+// @Param        ref path string true "branch, tag or commit, default branch if empty"
+// @Param        format query string false "zip or tar.gz" Enums(zip, tar.gz) default(zip)
+// @Param        include_lfs query bool false "archive the content of LFS files"
+// @Param        include query []string false "glob patterns of the paths to include"
+// @Param        exclude query []string false "glob patterns of the paths to exclude"
+// @Success      200  {file}  file "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      403  {object}  types.APIForbidden "Forbidden"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /{repo_type}/{namespace}/{name}/download_archive/refs/{ref} [get]
+func (h *RepoHandler) DownloadArchive(ctx *gin.Context) {
+	namespace, name, err := common.GetNamespaceAndNameFromContext(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "Bad request format", "error", err)
+		httpbase.BadRequest(ctx, err.Error())
+		return
+	}
+	var req types.DownloadArchiveReq
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "Bad request format", "error", err)
+		httpbase.BadRequestWithExt(ctx, errorx.ReqParamInvalid(err, nil))
+		return
+	}
+	req.RepoType = common.RepoTypeFromContext(ctx)
+	req.Namespace = namespace
+	req.Name = name
+	req.Revision = strings.TrimPrefix(ctx.Param("ref"), "/")
+	req.CurrentUser = httpbase.GetCurrentUser(ctx)
+
+	reader, err := h.c.DownloadArchive(ctx.Request.Context(), &req)
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "Failed to download repo archive", slog.Any("req", req), slog.Any("error", err))
+		switch {
+		case errors.Is(err, errorx.ErrForbidden):
+			httpbase.ForbiddenError(ctx, err)
+		case errors.Is(err, errorx.ErrUserNotFound):
+			httpbase.UnauthorizedError(ctx, err)
+		case errors.Is(err, errorx.ErrDatabaseNoRows), errors.Is(err, errorx.ErrNotFound):
+			httpbase.NotFoundError(ctx, err)
+		case errors.Is(err, errorx.ErrBadRequest):
+			httpbase.BadRequestWithExt(ctx, err)
+		default:
+			httpbase.ServerError(ctx, err)
+		}
+		return
+	}
+	defer reader.Close()
+
+	filename := strings.ReplaceAll(name, "/", "-")
+	if req.Revision != "" {
+		filename = fmt.Sprintf("%s-%s", filename, strings.ReplaceAll(req.Revision, "/", "-"))
+	}
+	contentType := "application/zip"
+	if req.Format == types.ArchiveFormatTarGz {
+		contentType = "application/gzip"
+	}
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s.%s", filename, req.Format))
+	ctx.DataFromReader(http.StatusOK, -1, contentType, reader, nil)
+}
+
 // DownloadCodeZip godoc
 // @Summary      Download code repository as zip archive
 // @Description  Download code repository as zip archive
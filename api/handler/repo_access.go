@@ -0,0 +1,372 @@
+package handler
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"opencsg.com/csghub-server/api/httpbase"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+	"opencsg.com/csghub-server/common/utils/common"
+)
+
+// GetAccessPolicy godoc
+// @Security     ApiKey
+// @Summary      Get the usage policy of a repository
+// @Description  The LFS files of a gated repository can only be downloaded after an access request is approved
+// @Tags         Repository
+// @Produce      json
+// @Param        repo_type path string true "repository type" Enums(models, datasets, codes, spaces, prompts, mcpservers)
+// @Param        namespace path string true "repo owner name"
+// @Param        name path string true "repo name"
+// @Success      200  {object}  types.Response{data=types.RepoAccessPolicy} "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      403  {object}  types.APIForbidden "Forbidden"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /{repo_type}/{namespace}/{name}/access_policy [get]
+func (h *RepoHandler) GetAccessPolicy(ctx *gin.Context) {
+	req, ok := bindRepoAccessReq(ctx)
+	if !ok {
+		return
+	}
+	policy, err := h.c.GetAccessPolicy(ctx.Request.Context(), req)
+	if err != nil {
+		h.handleRepoAccessError(ctx, "Failed to get access policy", err)
+		return
+	}
+	httpbase.OK(ctx, policy)
+}
+
+// UpdateAccessPolicy godoc
+// @Security     ApiKey
+// @Summary      Gate or ungate a repository and update its usage policy
+// @Description  Only the repository admins can update the policy, the repository writers bypass the gate
+// @Tags         Repository
+// @Accept       json
+// @Produce      json
+// @Param        repo_type path string true "repository type" Enums(models, datasets, codes, spaces, prompts, mcpservers)
+// @Param        namespace path string true "repo owner name"
+// @Param        name path string true "repo name"
+// @Param        body body types.UpdateRepoAccessPolicyReq true "policy"
+// @Success      200  {object}  types.Response{data=types.RepoAccessPolicy} "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      403  {object}  types.APIForbidden "Forbidden"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /{repo_type}/{namespace}/{name}/access_policy [put]
+func (h *RepoHandler) UpdateAccessPolicy(ctx *gin.Context) {
+	var req types.UpdateRepoAccessPolicyReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "Bad request format", "error", err)
+		httpbase.BadRequestWithExt(ctx, errorx.ReqParamInvalid(err, nil))
+		return
+	}
+	params, ok := bindRepoAccessReq(ctx)
+	if !ok {
+		return
+	}
+	req.Namespace = params.Namespace
+	req.Name = params.Name
+	req.RepoType = params.RepoType
+	req.CurrentUser = params.CurrentUser
+	policy, err := h.c.UpdateAccessPolicy(ctx.Request.Context(), &req)
+	if err != nil {
+		h.handleRepoAccessError(ctx, "Failed to update access policy", err)
+		return
+	}
+	httpbase.OK(ctx, policy)
+}
+
+// CreateAccessRequest godoc
+// @Security     ApiKey
+// @Summary      Request access to a gated repository
+// @Description  The terms must be accepted and the required form fields filled, the request is approved at once if the approval mode is auto, otherwise the repository owner is notified
+// @Tags         Repository
+// @Accept       json
+// @Produce      json
+// @Param        repo_type path string true "repository type" Enums(models, datasets, codes, spaces, prompts, mcpservers)
+// @Param        namespace path string true "repo owner name"
+// @Param        name path string true "repo name"
+// @Param        body body types.CreateRepoAccessRequestReq true "request"
+// @Success      200  {object}  types.Response{data=types.RepoAccessRequest} "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      403  {object}  types.APIForbidden "Forbidden"
+// @Failure      409  {object}  types.APIBadRequest "Pending or approved request exists"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /{repo_type}/{namespace}/{name}/access_request [post]
+func (h *RepoHandler) CreateAccessRequest(ctx *gin.Context) {
+	var req types.CreateRepoAccessRequestReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "Bad request format", "error", err)
+		httpbase.BadRequestWithExt(ctx, errorx.ReqParamInvalid(err, nil))
+		return
+	}
+	params, ok := bindRepoAccessReq(ctx)
+	if !ok {
+		return
+	}
+	req.Namespace = params.Namespace
+	req.Name = params.Name
+	req.RepoType = params.RepoType
+	req.CurrentUser = params.CurrentUser
+	request, err := h.c.CreateAccessRequest(ctx.Request.Context(), &req)
+	if err != nil {
+		h.handleRepoAccessError(ctx, "Failed to create access request", err)
+		return
+	}
+	httpbase.OK(ctx, request)
+}
+
+// GetAccessRequest godoc
+// @Security     ApiKey
+// @Summary      Get the access request of the current user to a gated repository
+// @Tags         Repository
+// @Produce      json
+// @Param        repo_type path string true "repository type" Enums(models, datasets, codes, spaces, prompts, mcpservers)
+// @Param        namespace path string true "repo owner name"
+// @Param        name path string true "repo name"
+// @Success      200  {object}  types.Response{data=types.RepoAccessRequest} "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      403  {object}  types.APIForbidden "Forbidden"
+// @Failure      404  {object}  types.APINotFound "Not found"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /{repo_type}/{namespace}/{name}/access_request [get]
+func (h *RepoHandler) GetAccessRequest(ctx *gin.Context) {
+	req, ok := bindRepoAccessReq(ctx)
+	if !ok {
+		return
+	}
+	request, err := h.c.GetAccessRequest(ctx.Request.Context(), req)
+	if err != nil {
+		h.handleRepoAccessError(ctx, "Failed to get access request", err)
+		return
+	}
+	httpbase.OK(ctx, request)
+}
+
+// ListAccessRequests godoc
+// @Security     ApiKey
+// @Summary      List the access requests of a gated repository, the newest first
+// @Description  Only the repository admins can list the access requests
+// @Tags         Repository
+// @Produce      json
+// @Param        repo_type path string true "repository type" Enums(models, datasets, codes, spaces, prompts, mcpservers)
+// @Param        namespace path string true "repo owner name"
+// @Param        name path string true "repo name"
+// @Param        status query string false "status of the requests" Enums(pending, approved, rejected, expired)
+// @Param        per query int false "per" default(20)
+// @Param        page query int false "page index" default(1)
+// @Success      200  {object}  types.ResponseWithTotal{data=[]types.RepoAccessRequest,total=int} "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      403  {object}  types.APIForbidden "Forbidden"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /{repo_type}/{namespace}/{name}/access_requests [get]
+func (h *RepoHandler) ListAccessRequests(ctx *gin.Context) {
+	params, ok := bindRepoAccessReq(ctx)
+	if !ok {
+		return
+	}
+	per, page, err := common.GetPerAndPageFromContext(ctx)
+	if err != nil {
+		httpbase.BadRequestWithExt(ctx, errorx.ReqParamInvalid(err, nil))
+		return
+	}
+	requests, total, err := h.c.ListAccessRequests(ctx.Request.Context(), &types.ListRepoAccessRequestsReq{
+		Namespace:   params.Namespace,
+		Name:        params.Name,
+		RepoType:    params.RepoType,
+		CurrentUser: params.CurrentUser,
+		Status:      types.RepoAccessRequestStatus(ctx.Query("status")),
+		Per:         per,
+		Page:        page,
+	})
+	if err != nil {
+		h.handleRepoAccessError(ctx, "Failed to list access requests", err)
+		return
+	}
+	httpbase.OKWithTotal(ctx, requests, total)
+}
+
+// ExportAccessRequests godoc
+// @Security     ApiKey
+// @Summary      Export the access requests of a gated repository as CSV
+// @Description  Only the repository admins can export the access requests, every form field is a column
+// @Tags         Repository
+// @Produce      text/csv
+// @Param        repo_type path string true "repository type" Enums(models, datasets, codes, spaces, prompts, mcpservers)
+// @Param        namespace path string true "repo owner name"
+// @Param        name path string true "repo name"
+// @Param        status query string false "status of the requests" Enums(pending, approved, rejected, expired)
+// @Success      200  {string}  string "CSV file"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      403  {object}  types.APIForbidden "Forbidden"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /{repo_type}/{namespace}/{name}/access_requests/export [get]
+func (h *RepoHandler) ExportAccessRequests(ctx *gin.Context) {
+	params, ok := bindRepoAccessReq(ctx)
+	if !ok {
+		return
+	}
+	requests, _, err := h.c.ListAccessRequests(ctx.Request.Context(), &types.ListRepoAccessRequestsReq{
+		Namespace:   params.Namespace,
+		Name:        params.Name,
+		RepoType:    params.RepoType,
+		CurrentUser: params.CurrentUser,
+		Status:      types.RepoAccessRequestStatus(ctx.Query("status")),
+	})
+	if err != nil {
+		h.handleRepoAccessError(ctx, "Failed to export access requests", err)
+		return
+	}
+
+	// the form fields of the policy may have changed, so the columns are the union of the answered fields
+	fieldSet := map[string]bool{}
+	for _, request := range requests {
+		for field := range request.Form {
+			fieldSet[field] = true
+		}
+	}
+	fields := make([]string, 0, len(fieldSet))
+	for field := range fieldSet {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	filename := fmt.Sprintf("%s_%s_access_requests.csv", params.Namespace, params.Name)
+	ctx.Header("Content-Type", "text/csv; charset=utf-8")
+	ctx.Header("Content-Disposition", "attachment; filename=\""+filename+"\"")
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Status(http.StatusOK)
+
+	writer := csv.NewWriter(ctx.Writer)
+	defer writer.Flush()
+	header := []string{"ID", "Username", "Email", "Status", "AcceptedTerms", "Reviewer", "ReviewedAt", "ExpiresAt", "CreatedAt"}
+	for _, field := range fields {
+		header = append(header, escapeCSVCell(field))
+	}
+	_ = writer.Write(header)
+	for _, request := range requests {
+		row := []string{
+			strconv.FormatInt(request.ID, 10),
+			escapeCSVCell(request.Username),
+			escapeCSVCell(request.Email),
+			string(request.Status),
+			strconv.FormatBool(request.AcceptedTerms),
+			escapeCSVCell(request.Reviewer),
+			formatOptionalTime(request.ReviewedAt),
+			formatOptionalTime(request.ExpiresAt),
+			request.CreatedAt.Format(time.RFC3339),
+		}
+		for _, field := range fields {
+			row = append(row, escapeCSVCell(request.Form[field]))
+		}
+		_ = writer.Write(row)
+	}
+}
+
+// ReviewAccessRequest godoc
+// @Security     ApiKey
+// @Summary      Approve or reject an access request of a gated repository
+// @Description  Rejecting an approved request revokes the access, approving again renews the approval, the requester is notified
+// @Tags         Repository
+// @Accept       json
+// @Produce      json
+// @Param        repo_type path string true "repository type" Enums(models, datasets, codes, spaces, prompts, mcpservers)
+// @Param        namespace path string true "repo owner name"
+// @Param        name path string true "repo name"
+// @Param        id path int true "access request id"
+// @Param        body body types.ReviewRepoAccessRequestReq true "review"
+// @Success      200  {object}  types.Response{data=types.RepoAccessRequest} "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      403  {object}  types.APIForbidden "Forbidden"
+// @Failure      404  {object}  types.APINotFound "Not found"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /{repo_type}/{namespace}/{name}/access_requests/{id} [put]
+func (h *RepoHandler) ReviewAccessRequest(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		httpbase.BadRequestWithExt(ctx, errorx.ReqParamInvalid(err, errorx.Ctx().Set("param", "id")))
+		return
+	}
+	var req types.ReviewRepoAccessRequestReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "Bad request format", "error", err)
+		httpbase.BadRequestWithExt(ctx, errorx.ReqParamInvalid(err, nil))
+		return
+	}
+	params, ok := bindRepoAccessReq(ctx)
+	if !ok {
+		return
+	}
+	req.Namespace = params.Namespace
+	req.Name = params.Name
+	req.RepoType = params.RepoType
+	req.CurrentUser = params.CurrentUser
+	req.ID = id
+	request, err := h.c.ReviewAccessRequest(ctx.Request.Context(), &req)
+	if err != nil {
+		h.handleRepoAccessError(ctx, "Failed to review access request", err)
+		return
+	}
+	httpbase.OK(ctx, request)
+}
+
+// bindRepoAccessReq reads the repository of the access routes, which serve all the repository types
+func bindRepoAccessReq(ctx *gin.Context) (*types.GetRepoAccessPolicyReq, bool) {
+	repoType, err := common.RepoTypeFromString(ctx.Param("repo_type"))
+	if err != nil {
+		httpbase.BadRequestWithExt(ctx, errorx.ReqParamInvalid(err, errorx.Ctx().Set("param", "repo_type")))
+		return nil, false
+	}
+	namespace, name, err := common.GetNamespaceAndNameFromContext(ctx)
+	if err != nil {
+		httpbase.BadRequestWithExt(ctx, errorx.ReqParamInvalid(err, nil))
+		return nil, false
+	}
+	return &types.GetRepoAccessPolicyReq{
+		Namespace:   namespace,
+		Name:        name,
+		RepoType:    repoType,
+		CurrentUser: httpbase.GetCurrentUser(ctx),
+	}, true
+}
+
+// escapeCSVCell prefixes the user input that spreadsheets would evaluate as a formula with a quote
+func escapeCSVCell(value string) string {
+	if value != "" && strings.ContainsRune("=+-@", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func (h *RepoHandler) handleRepoAccessError(ctx *gin.Context, msg string, err error) {
+	slog.ErrorContext(ctx.Request.Context(), msg, slog.String("repo_type", strings.TrimSuffix(ctx.Param("repo_type"), "s")), slog.Any("error", err))
+	switch {
+	case errors.Is(err, errorx.ErrBadRequest):
+		httpbase.BadRequestWithExt(ctx, err)
+	case errors.Is(err, errorx.ErrUserNotFound):
+		httpbase.UnauthorizedError(ctx, err)
+	case errors.Is(err, errorx.ErrForbidden):
+		httpbase.ForbiddenError(ctx, err)
+	case errors.Is(err, errorx.ErrNotFound), errors.Is(err, errorx.ErrDatabaseNoRows):
+		httpbase.NotFoundError(ctx, err)
+	case errors.Is(err, errorx.ErrAlreadyExists):
+		httpbase.ConflictError(ctx, err)
+	default:
+		httpbase.ServerError(ctx, err)
+	}
+}
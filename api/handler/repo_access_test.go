@@ -0,0 +1,205 @@
+package handler
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+)
+
+func TestRepoHandler_UpdateAccessPolicy(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		tester := NewRepoTester(t).WithHandleFunc(func(rp *RepoHandler) gin.HandlerFunc {
+			return rp.UpdateAccessPolicy
+		})
+
+		policy := &types.RepoAccessPolicy{Gated: true, ApprovalMode: types.RepoAccessApprovalAuto, FormFields: []types.RepoAccessFormField{}}
+		tester.mocks.repo.EXPECT().UpdateAccessPolicy(tester.Ctx(), &types.UpdateRepoAccessPolicyReq{
+			Namespace:         "u",
+			Name:              "r",
+			RepoType:          types.DatasetRepo,
+			CurrentUser:       "u",
+			Gated:             true,
+			ApprovalMode:      types.RepoAccessApprovalAuto,
+			Terms:             "cc-by-nc",
+			ApprovalValidDays: 30,
+		}).Return(policy, nil).Once()
+		tester.WithUser().WithParam("repo_type", "datasets").WithBody(t, map[string]any{
+			"gated":               true,
+			"approval_mode":       "auto",
+			"terms":               "cc-by-nc",
+			"approval_valid_days": 30,
+		}).Execute()
+		tester.ResponseEq(t, http.StatusOK, tester.OKText, policy)
+	})
+
+	t.Run("invalid approval mode", func(t *testing.T) {
+		tester := NewRepoTester(t).WithHandleFunc(func(rp *RepoHandler) gin.HandlerFunc {
+			return rp.UpdateAccessPolicy
+		})
+		tester.WithUser().WithParam("repo_type", "models").WithBody(t, map[string]any{
+			"gated":         true,
+			"approval_mode": "sometimes",
+		}).Execute()
+		tester.ResponseEqCode(t, http.StatusBadRequest)
+	})
+
+	t.Run("invalid repo type", func(t *testing.T) {
+		tester := NewRepoTester(t).WithHandleFunc(func(rp *RepoHandler) gin.HandlerFunc {
+			return rp.UpdateAccessPolicy
+		})
+		tester.WithUser().WithParam("repo_type", "foo").WithBody(t, map[string]any{"gated": true}).Execute()
+		tester.ResponseEqCode(t, http.StatusBadRequest)
+	})
+}
+
+func TestRepoHandler_CreateAccessRequest(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		tester := NewRepoTester(t).WithHandleFunc(func(rp *RepoHandler) gin.HandlerFunc {
+			return rp.CreateAccessRequest
+		})
+
+		request := &types.RepoAccessRequest{ID: 1, Username: "u", Status: types.RepoAccessRequestPending, Form: map[string]string{"company": "opencsg"}}
+		tester.mocks.repo.EXPECT().CreateAccessRequest(tester.Ctx(), &types.CreateRepoAccessRequestReq{
+			Namespace:   "u",
+			Name:        "r",
+			RepoType:    types.ModelRepo,
+			CurrentUser: "u",
+			AcceptTerms: true,
+			Form:        map[string]string{"company": "opencsg"},
+		}).Return(request, nil).Once()
+		tester.WithUser().WithParam("repo_type", "models").WithBody(t, &types.CreateRepoAccessRequestReq{
+			AcceptTerms: true,
+			Form:        map[string]string{"company": "opencsg"},
+		}).Execute()
+		tester.ResponseEq(t, http.StatusOK, tester.OKText, request)
+	})
+
+	t.Run("already exists", func(t *testing.T) {
+		tester := NewRepoTester(t).WithHandleFunc(func(rp *RepoHandler) gin.HandlerFunc {
+			return rp.CreateAccessRequest
+		})
+		tester.mocks.repo.EXPECT().CreateAccessRequest(mock.Anything, mock.Anything).Return(nil, errorx.ErrAlreadyExists).Once()
+		tester.WithUser().WithParam("repo_type", "models").WithBody(t, &types.CreateRepoAccessRequestReq{AcceptTerms: true}).Execute()
+		tester.ResponseEqCode(t, http.StatusConflict)
+	})
+}
+
+func TestRepoHandler_GetAccessRequest(t *testing.T) {
+	tester := NewRepoTester(t).WithHandleFunc(func(rp *RepoHandler) gin.HandlerFunc {
+		return rp.GetAccessRequest
+	})
+	tester.mocks.repo.EXPECT().GetAccessRequest(tester.Ctx(), &types.GetRepoAccessRequestReq{
+		Namespace:   "u",
+		Name:        "r",
+		RepoType:    types.ModelRepo,
+		CurrentUser: "u",
+	}).Return(nil, errorx.ErrNotFound).Once()
+	tester.WithUser().WithParam("repo_type", "models").Execute()
+	tester.ResponseEqCode(t, http.StatusNotFound)
+}
+
+func TestRepoHandler_ListAccessRequests(t *testing.T) {
+	tester := NewRepoTester(t).WithHandleFunc(func(rp *RepoHandler) gin.HandlerFunc {
+		return rp.ListAccessRequests
+	})
+
+	requests := []types.RepoAccessRequest{{ID: 1, Username: "a", Status: types.RepoAccessRequestPending, Form: map[string]string{}}}
+	tester.mocks.repo.EXPECT().ListAccessRequests(tester.Ctx(), &types.ListRepoAccessRequestsReq{
+		Namespace:   "u",
+		Name:        "r",
+		RepoType:    types.ModelRepo,
+		CurrentUser: "u",
+		Status:      types.RepoAccessRequestPending,
+		Per:         10,
+		Page:        1,
+	}).Return(requests, 1, nil).Once()
+	tester.WithUser().WithParam("repo_type", "models").WithQuery("status", "pending").AddPagination(1, 10).Execute()
+	tester.ResponseEqSimple(t, http.StatusOK, gin.H{
+		"msg":   "OK",
+		"data":  requests,
+		"total": 1,
+	})
+}
+
+func TestRepoHandler_ExportAccessRequests(t *testing.T) {
+	tester := NewRepoTester(t).WithHandleFunc(func(rp *RepoHandler) gin.HandlerFunc {
+		return rp.ExportAccessRequests
+	})
+
+	created := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	expires := time.Date(2026, 10, 31, 8, 0, 0, 0, time.UTC)
+	tester.mocks.repo.EXPECT().ListAccessRequests(tester.Ctx(), &types.ListRepoAccessRequestsReq{
+		Namespace:   "u",
+		Name:        "r",
+		RepoType:    types.ModelRepo,
+		CurrentUser: "u",
+	}).Return([]types.RepoAccessRequest{
+		{ID: 2, Username: "b", Email: "b@example.com", Status: types.RepoAccessRequestApproved, AcceptedTerms: true,
+			Reviewer: "u", ReviewedAt: &created, ExpiresAt: &expires, CreatedAt: created,
+			Form: map[string]string{"company": "opencsg", "purpose": "research, eval"}},
+		{ID: 1, Username: "a", Status: types.RepoAccessRequestPending, CreatedAt: created, Form: map[string]string{"company": "x"}},
+		{ID: 3, Username: "c", Status: types.RepoAccessRequestPending, CreatedAt: created,
+			Form: map[string]string{"company": "=HYPERLINK(\"http://evil\")", "purpose": "@SUM(A1)", "+phone": "-1"}},
+	}, 3, nil).Once()
+
+	tester.WithUser().WithParam("repo_type", "models").Execute()
+	resp := tester.Response()
+	require.Equal(t, http.StatusOK, resp.Code)
+	require.Contains(t, resp.Header().Get("Content-Type"), "text/csv")
+	require.Contains(t, resp.Header().Get("Content-Disposition"), "u_r_access_requests.csv")
+	require.Equal(t, "ID,Username,Email,Status,AcceptedTerms,Reviewer,ReviewedAt,ExpiresAt,CreatedAt,'+phone,company,purpose\n"+
+		"2,b,b@example.com,approved,true,u,2026-10-01T08:00:00Z,2026-10-31T08:00:00Z,2026-10-01T08:00:00Z,,opencsg,\"research, eval\"\n"+
+		"1,a,,pending,false,,,,2026-10-01T08:00:00Z,,x,\n"+
+		"3,c,,pending,false,,,,2026-10-01T08:00:00Z,'-1,\"'=HYPERLINK(\"\"http://evil\"\")\",'@SUM(A1)\n", resp.Body.String())
+}
+
+func TestRepoHandler_ReviewAccessRequest(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		tester := NewRepoTester(t).WithHandleFunc(func(rp *RepoHandler) gin.HandlerFunc {
+			return rp.ReviewAccessRequest
+		})
+
+		request := &types.RepoAccessRequest{ID: 3, Username: "a", Status: types.RepoAccessRequestRejected, Reviewer: "u", ReviewMsg: "no", Form: map[string]string{}}
+		tester.mocks.repo.EXPECT().ReviewAccessRequest(tester.Ctx(), &types.ReviewRepoAccessRequestReq{
+			Namespace:   "u",
+			Name:        "r",
+			RepoType:    types.ModelRepo,
+			CurrentUser: "u",
+			ID:          3,
+			Action:      types.RepoAccessReviewReject,
+			ReviewMsg:   "no",
+		}).Return(request, nil).Once()
+		tester.WithUser().WithParam("repo_type", "models").WithParam("id", "3").WithBody(t, map[string]any{
+			"action":     "reject",
+			"review_msg": "no",
+		}).Execute()
+		tester.ResponseEq(t, http.StatusOK, tester.OKText, request)
+	})
+
+	t.Run("invalid action", func(t *testing.T) {
+		tester := NewRepoTester(t).WithHandleFunc(func(rp *RepoHandler) gin.HandlerFunc {
+			return rp.ReviewAccessRequest
+		})
+		tester.WithUser().WithParam("repo_type", "models").WithParam("id", "3").WithBody(t, map[string]any{
+			"action": "ignore",
+		}).Execute()
+		tester.ResponseEqCode(t, http.StatusBadRequest)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		tester := NewRepoTester(t).WithHandleFunc(func(rp *RepoHandler) gin.HandlerFunc {
+			return rp.ReviewAccessRequest
+		})
+		tester.mocks.repo.EXPECT().ReviewAccessRequest(mock.Anything, mock.Anything).Return(nil, errorx.ErrForbidden).Once()
+		tester.WithUser().WithParam("repo_type", "models").WithParam("id", "3").WithBody(t, map[string]any{
+			"action": "approve",
+		}).Execute()
+		tester.ResponseEqCode(t, http.StatusForbidden)
+	})
+}
@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+
+	"github.com/gin-gonic/gin"
+	"opencsg.com/csghub-server/api/httpbase"
+	"opencsg.com/csghub-server/common/config"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+	"opencsg.com/csghub-server/common/utils/common"
+	"opencsg.com/csghub-server/component"
+)
+
+func NewRepoFileHandler(config *config.Config) (*RepoFileHandler, error) {
+	c, err := component.NewRepoFileComponent(config)
+	if err != nil {
+		return nil, err
+	}
+	return &RepoFileHandler{c: c}, nil
+}
+
+type RepoFileHandler struct {
+	c component.RepoFileComponent
+}
+
+// SearchFiles godoc
+// @Security     ApiKey
+// @Summary      Search files across repositories
+// @Description  Search the files of the default branches of all the repositories visible to the current user by glob, extension, size, LFS status and LFS object id. In the glob '*' matches any characters including '/' and '?' matches a single character
+// @Tags         Repository
+// @Produce      json
+// @Param        glob query string false "glob of the file path, e.g. *Q4_K_M.gguf or train/*.parquet"
+// @Param        extension query string false "file extension, e.g. parquet"
+// @Param        min_size query int false "min file size in bytes"
+// @Param        max_size query int false "max file size in bytes"
+// @Param        lfs query bool false "whether the file is stored in LFS"
+// @Param        lfs_oid query string false "sha256 oid of the LFS object"
+// @Param        repo_type query string false "repository type" Enums(model, dataset, code, space, prompt, mcpserver, template)
+// @Param        per query int false "per" default(20)
+// @Param        page query int false "page index" default(1)
+// @Success      200  {object}  types.ResponseWithTotal{data=[]types.RepoFileSearchResult,total=int} "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /repos/files/search [get]
+func (h *RepoFileHandler) SearchFiles(ctx *gin.Context) {
+	var req types.SearchRepoFileReq
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "Bad request format", slog.Any("error", err))
+		httpbase.BadRequestWithExt(ctx, errorx.ReqParamInvalid(err, nil))
+		return
+	}
+	per, page, err := common.GetPerAndPageFromContext(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "Bad request format", slog.Any("error", err))
+		httpbase.BadRequest(ctx, err.Error())
+		return
+	}
+	req.Per = per
+	req.Page = page
+	req.CurrentUser = httpbase.GetCurrentUser(ctx)
+
+	files, total, err := h.c.SearchFiles(ctx.Request.Context(), &req)
+	if err != nil {
+		if errors.Is(err, errorx.ErrReqParamInvalid) {
+			httpbase.BadRequestWithExt(ctx, err)
+			return
+		}
+		slog.ErrorContext(ctx.Request.Context(), "failed to search repo files", slog.Any("req", req), slog.Any("error", err))
+		httpbase.ServerError(ctx, err)
+		return
+	}
+	httpbase.OKWithTotal(ctx, files, total)
+}
@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	mockcomponent "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/component"
+	"opencsg.com/csghub-server/builder/testutil"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+)
+
+type RepoFileTester struct {
+	*testutil.GinTester
+	handler *RepoFileHandler
+	mocks   struct {
+		comp *mockcomponent.MockRepoFileComponent
+	}
+}
+
+func NewRepoFileTester(t *testing.T) *RepoFileTester {
+	tester := &RepoFileTester{GinTester: testutil.NewGinTester()}
+	tester.mocks.comp = mockcomponent.NewMockRepoFileComponent(t)
+	tester.handler = &RepoFileHandler{c: tester.mocks.comp}
+	tester.Handler(tester.handler.SearchFiles)
+	return tester
+}
+
+func TestRepoFileHandler_SearchFiles(t *testing.T) {
+	tester := NewRepoFileTester(t)
+	tester.WithUser()
+
+	files := []types.RepoFileSearchResult{{RepoType: types.ModelRepo, RepoPath: "ns/n", Path: "m-Q4_K_M.gguf", Size: 5 << 30, LFS: true}}
+	tester.mocks.comp.EXPECT().SearchFiles(tester.Ctx(), mock.MatchedBy(func(req *types.SearchRepoFileReq) bool {
+		return req.CurrentUser == "u" && req.Glob == "*Q4_K_M.gguf" && req.MinSize == 4<<30 &&
+			req.LFS != nil && *req.LFS && req.RepoType == types.ModelRepo && req.Per == 10 && req.Page == 2
+	})).Return(files, 11, nil)
+
+	tester.WithQuery("glob", "*Q4_K_M.gguf").WithQuery("min_size", "4294967296").WithQuery("lfs", "true").
+		WithQuery("repo_type", "model").WithQuery("per", "10").WithQuery("page", "2").Execute()
+	tester.ResponseEqSimple(t, http.StatusOK, gin.H{
+		"msg":   "OK",
+		"data":  files,
+		"total": 11,
+	})
+}
+
+func TestRepoFileHandler_SearchFilesInvalidParam(t *testing.T) {
+	tester := NewRepoFileTester(t)
+
+	tester.mocks.comp.EXPECT().SearchFiles(tester.Ctx(), mock.Anything).
+		Return(nil, 0, errorx.ReqParamInvalid(errorx.ErrReqParamInvalid, nil))
+
+	tester.WithQuery("lfs_oid", "abc").Execute()
+	tester.ResponseEqCode(t, http.StatusBadRequest)
+}
@@ -0,0 +1,493 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"opencsg.com/csghub-server/api/httpbase"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+	"opencsg.com/csghub-server/common/utils/common"
+)
+
+const (
+	hfListReposMaxLimit = 100
+	hfCommitsPageSize   = 50
+)
+
+// the sort keys of the hub api mapped to the sort options of csghub
+var hfRepoSorts = map[string]string{
+	"downloads":      "most_download",
+	"likes":          "most_favorite",
+	"lastModified":   "recently_update",
+	"createdAt":      "recently_create",
+	"trending_score": "trending",
+}
+
+// hfError responds the error in the format of the hub api, huggingface_hub raises
+// RepositoryNotFoundError and RevisionNotFoundError by the X-Error-Code header
+func hfError(ctx *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, errorx.ErrDatabaseNoRows):
+		status = http.StatusNotFound
+		ctx.Header("X-Error-Code", "RepoNotFound")
+	case errors.Is(err, errorx.ErrNotFound):
+		status = http.StatusNotFound
+		ctx.Header("X-Error-Code", "RevisionNotFound")
+	case errors.Is(err, errorx.ErrUnauthorized):
+		status = http.StatusUnauthorized
+	case errors.Is(err, errorx.ErrForbidden),
+		errors.Is(err, errorx.ErrNoSourceTransferPermission),
+		errors.Is(err, errorx.ErrNoTargetTransferPermission):
+		status = http.StatusForbidden
+	case errors.Is(err, errorx.ErrAlreadyExists),
+		errors.Is(err, errorx.ErrTransferTargetExists):
+		status = http.StatusConflict
+	case errors.Is(err, errorx.ErrBadRequest),
+		errors.Is(err, errorx.ErrTransferSameNamespace),
+		errors.Is(err, errorx.ErrTransferNotSupported),
+		errors.Is(err, errorx.ErrChangePathBlocked):
+		status = http.StatusBadRequest
+	}
+	ctx.Header("X-Error-Message", err.Error())
+	ctx.JSON(status, types.HFErrorRes{Error: err.Error()})
+}
+
+// hfRepoType converts the repo type of the hub api, an empty type means model
+func hfRepoType(t string) (types.RepositoryType, error) {
+	switch t {
+	case "", "model":
+		return types.ModelRepo, nil
+	case "dataset":
+		return types.DatasetRepo, nil
+	case "space":
+		return types.SpaceRepo, nil
+	default:
+		return "", fmt.Errorf("unsupported repo type: %s", t)
+	}
+}
+
+// hfRevision returns the revision in the wildcard path parameter, the hub client escapes
+// slashes in revisions but they are unescaped before routing
+func hfRevision(ctx *gin.Context, key string) string {
+	return strings.TrimPrefix(ctx.Param(key), "/")
+}
+
+// setHFNextPageLink sets the Link header which the hub client follows to get the next page
+func (h *RepoHandler) setHFNextPageLink(ctx *gin.Context, page int) {
+	query := ctx.Request.URL.Query()
+	query.Set("p", strconv.Itoa(page+1))
+	ctx.Header("Link", fmt.Sprintf("<%s%s?%s>; rel=\"next\"", h.config.APIServer.PublicDomain, ctx.Request.URL.Path, query.Encode()))
+}
+
+func toHFRepoInfo(repo *database.Repository) types.HFRepoInfo {
+	namespace, _ := repo.NamespaceAndName()
+	info := types.HFRepoInfo{
+		ID:           repo.Path,
+		Author:       namespace,
+		Private:      repo.Private,
+		Downloads:    repo.DownloadCount,
+		Likes:        repo.Likes,
+		Tags:         []string{},
+		CreatedAt:    repo.CreatedAt,
+		LastModified: repo.UpdatedAt,
+	}
+	for _, tag := range repo.Tags {
+		info.Tags = append(info.Tags, tag.Name)
+		switch tag.Category {
+		case "task":
+			info.PipelineTag = tag.Name
+		case "framework":
+			info.LibraryName = tag.Name
+		}
+	}
+	return info
+}
+
+// ListReposHF lists the visible repos, compatible with huggingface_hub list_models and list_datasets
+func (h *RepoHandler) ListReposHF(ctx *gin.Context) {
+	var req types.HFListReposReq
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "Bad request format", "error", err)
+		hfError(ctx, errorx.BadRequest(err, nil))
+		return
+	}
+	per := req.Limit
+	if per <= 0 || per > hfListReposMaxLimit {
+		per = hfListReposMaxLimit
+	}
+	page := max(req.Page, 1)
+
+	filter := &types.RepoFilter{
+		Search: req.Search,
+		Owner:  req.Author,
+		Sort:   hfRepoSorts[req.Sort],
+	}
+	if filter.Sort == "" {
+		filter.Sort = "recently_update"
+	}
+	for _, f := range req.Filter {
+		// prefixed tags of the hub like license:mit are matched by the tag category
+		if category, name, found := strings.Cut(f, ":"); found {
+			filter.Tags = append(filter.Tags, types.TagReq{Category: category, Name: name})
+		} else {
+			filter.Tags = append(filter.Tags, types.TagReq{Name: f})
+		}
+	}
+
+	repoType := common.RepoTypeFromContext(ctx)
+	repos, total, err := h.c.PublicToUser(ctx.Request.Context(), repoType, httpbase.GetCurrentUser(ctx), filter, per, page)
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "Failed to list repos", slog.String("repo_type", string(repoType)), slog.Any("error", err))
+		hfError(ctx, err)
+		return
+	}
+
+	infos := make([]types.HFRepoInfo, 0, len(repos))
+	for _, repo := range repos {
+		infos = append(infos, toHFRepoInfo(repo))
+	}
+	if page*per < total {
+		h.setHFNextPageLink(ctx, page)
+	}
+	ctx.JSON(http.StatusOK, infos)
+}
+
+// RefsHF lists the branches and tags of a repo, compatible with huggingface_hub list_repo_refs
+func (h *RepoHandler) RefsHF(ctx *gin.Context) {
+	namespace, name, err := common.GetNamespaceAndNameFromContext(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "Bad request format", "error", err)
+		hfError(ctx, err)
+		return
+	}
+	currentUser := httpbase.GetCurrentUser(ctx)
+	repoType := common.RepoTypeFromContext(ctx)
+
+	branches, err := h.c.Branches(ctx.Request.Context(), &types.GetBranchesReq{
+		Namespace:   namespace,
+		Name:        name,
+		RepoType:    repoType,
+		CurrentUser: currentUser,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "Failed to get repo branches", slog.String("repo_type", string(repoType)), slog.Any("error", err))
+		hfError(ctx, err)
+		return
+	}
+	tags, err := h.c.GitTags(ctx.Request.Context(), &types.GetTagsReq{
+		Namespace:   namespace,
+		Name:        name,
+		RepoType:    repoType,
+		CurrentUser: currentUser,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "Failed to get repo tags", slog.String("repo_type", string(repoType)), slog.Any("error", err))
+		hfError(ctx, err)
+		return
+	}
+
+	refs := types.HFGitRefs{
+		Branches: make([]types.HFGitRef, 0, len(branches)),
+		Converts: []types.HFGitRef{},
+		Tags:     make([]types.HFGitRef, 0, len(tags)),
+	}
+	for _, branch := range branches {
+		refs.Branches = append(refs.Branches, types.HFGitRef{
+			Name:         branch.Name,
+			Ref:          "refs/heads/" + branch.Name,
+			TargetCommit: branch.Commit.ID,
+		})
+	}
+	for _, tag := range tags {
+		refs.Tags = append(refs.Tags, types.HFGitRef{
+			Name:         tag.Name,
+			Ref:          "refs/tags/" + tag.Name,
+			TargetCommit: tag.Commit.ID,
+		})
+	}
+	ctx.JSON(http.StatusOK, refs)
+}
+
+// CommitsHF lists the commits of a revision, compatible with huggingface_hub list_repo_commits
+func (h *RepoHandler) CommitsHF(ctx *gin.Context) {
+	namespace, name, err := common.GetNamespaceAndNameFromContext(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "Bad request format", "error", err)
+		hfError(ctx, err)
+		return
+	}
+	page, err := strconv.Atoi(ctx.DefaultQuery("p", "1"))
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "Bad request format", "error", err)
+		hfError(ctx, errorx.BadRequest(err, errorx.Ctx().Set("query", "p")))
+		return
+	}
+	page = max(page, 1)
+	repoType := common.RepoTypeFromContext(ctx)
+
+	commits, pageOpts, err := h.c.Commits(ctx.Request.Context(), &types.GetCommitsReq{
+		Namespace:   namespace,
+		Name:        name,
+		Per:         hfCommitsPageSize,
+		Page:        page,
+		Ref:         hfRevision(ctx, "revision"),
+		RepoType:    repoType,
+		CurrentUser: httpbase.GetCurrentUser(ctx),
+	})
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "Failed to get repo commits", slog.String("repo_type", string(repoType)), slog.Any("error", err))
+		hfError(ctx, err)
+		return
+	}
+
+	resp := make([]types.HFGitCommit, 0, len(commits))
+	for _, commit := range commits {
+		// the title is the subject line of the commit message like git log --oneline
+		title, _, _ := strings.Cut(commit.Message, "\n")
+		resp = append(resp, types.HFGitCommit{
+			ID:      commit.ID,
+			Title:   strings.TrimSpace(title),
+			Message: commit.Message,
+			Authors: []types.HFCommitAuthor{{User: commit.AuthorName}},
+			Date:    commit.AuthoredDate,
+		})
+	}
+	if pageOpts != nil && page < pageOpts.PageCount {
+		h.setHFNextPageLink(ctx, page)
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// CreateBranchHF creates a branch, compatible with huggingface_hub create_branch
+func (h *RepoHandler) CreateBranchHF(ctx *gin.Context) {
+	var req types.HFCreateBranchReq
+	// the body is optional
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			slog.ErrorContext(ctx.Request.Context(), "Bad request format", "error", err)
+			hfError(ctx, errorx.BadRequest(err, nil))
+			return
+		}
+	}
+	namespace, name, err := common.GetNamespaceAndNameFromContext(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "Bad request format", "error", err)
+		hfError(ctx, err)
+		return
+	}
+
+	err = h.c.CreateBranch(ctx.Request.Context(), types.CreateBranchReq{
+		Namespace:   namespace,
+		Name:        name,
+		BranchName:  hfRevision(ctx, "branch_name"),
+		CommitID:    req.StartingPoint,
+		RepoType:    common.RepoTypeFromContext(ctx),
+		CurrentUser: httpbase.GetCurrentUser(ctx),
+	})
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "Failed to create branch", slog.Any("error", err))
+		hfError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{})
+}
+
+// DeleteBranchHF deletes a branch, compatible with huggingface_hub delete_branch
+func (h *RepoHandler) DeleteBranchHF(ctx *gin.Context) {
+	namespace, name, err := common.GetNamespaceAndNameFromContext(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "Bad request format", "error", err)
+		hfError(ctx, err)
+		return
+	}
+
+	err = h.c.DeleteBranch(ctx.Request.Context(), types.DeleteBranchReq{
+		Namespace:   namespace,
+		Name:        name,
+		BranchName:  hfRevision(ctx, "branch_name"),
+		RepoType:    common.RepoTypeFromContext(ctx),
+		CurrentUser: httpbase.GetCurrentUser(ctx),
+	})
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "Failed to delete branch", slog.Any("error", err))
+		hfError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{})
+}
+
+// CreateTagHF tags a revision, compatible with huggingface_hub create_tag
+func (h *RepoHandler) CreateTagHF(ctx *gin.Context) {
+	var req types.HFCreateTagReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "Bad request format", "error", err)
+		hfError(ctx, errorx.BadRequest(err, nil))
+		return
+	}
+	namespace, name, err := common.GetNamespaceAndNameFromContext(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "Bad request format", "error", err)
+		hfError(ctx, err)
+		return
+	}
+
+	err = h.c.CreateGitTag(ctx.Request.Context(), types.CreateGitTagReq{
+		Namespace:   namespace,
+		Name:        name,
+		TagName:     req.Tag,
+		Revision:    hfRevision(ctx, "revision"),
+		Message:     req.Message,
+		RepoType:    common.RepoTypeFromContext(ctx),
+		CurrentUser: httpbase.GetCurrentUser(ctx),
+	})
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "Failed to create tag", slog.Any("error", err))
+		hfError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{})
+}
+
+// DeleteTagHF deletes a tag, compatible with huggingface_hub delete_tag
+func (h *RepoHandler) DeleteTagHF(ctx *gin.Context) {
+	namespace, name, err := common.GetNamespaceAndNameFromContext(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "Bad request format", "error", err)
+		hfError(ctx, err)
+		return
+	}
+
+	err = h.c.DeleteGitTag(ctx.Request.Context(), types.DeleteGitTagReq{
+		Namespace:   namespace,
+		Name:        name,
+		TagName:     hfRevision(ctx, "tag"),
+		RepoType:    common.RepoTypeFromContext(ctx),
+		CurrentUser: httpbase.GetCurrentUser(ctx),
+	})
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "Failed to delete tag", slog.Any("error", err))
+		hfError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{})
+}
+
+// UpdateSettingsHF updates the visibility of a repo, compatible with huggingface_hub update_repo_settings
+func (h *RepoHandler) UpdateSettingsHF(ctx *gin.Context) {
+	var req types.HFRepoSettingsReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "Bad request format", "error", err)
+		hfError(ctx, errorx.BadRequest(err, nil))
+		return
+	}
+	namespace, name, err := common.GetNamespaceAndNameFromContext(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "Bad request format", "error", err)
+		hfError(ctx, err)
+		return
+	}
+
+	repo, err := h.c.UpdateRepo(ctx.Request.Context(), types.UpdateRepoReq{
+		Username:  httpbase.GetCurrentUser(ctx),
+		Namespace: namespace,
+		Name:      name,
+		RepoType:  common.RepoTypeFromContext(ctx),
+		Private:   req.Private,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "Failed to update repo settings", slog.Any("error", err))
+		hfError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, types.HFRepoSettingsReq{Private: &repo.Private})
+}
+
+// DeleteRepoHF deletes a model, dataset or space, compatible with huggingface_hub delete_repo
+func (h *RepoHandler) DeleteRepoHF(ctx *gin.Context) {
+	var req types.HFDeleteRepoReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "Bad request format", "error", err)
+		hfError(ctx, errorx.BadRequest(err, nil))
+		return
+	}
+	currentUser := httpbase.GetCurrentUser(ctx)
+	namespace := req.Organization
+	if namespace == "" {
+		namespace = currentUser
+	}
+	repoType, err := hfRepoType(req.Type)
+	if err != nil {
+		hfError(ctx, errorx.BadRequest(err, nil))
+		return
+	}
+
+	stdCtx := ctx.Request.Context()
+	switch repoType {
+	case types.ModelRepo:
+		err = h.m.Delete(stdCtx, namespace, req.Name, currentUser)
+	case types.DatasetRepo:
+		err = h.d.Delete(stdCtx, namespace, req.Name, currentUser)
+	case types.SpaceRepo:
+		err = h.s.Delete(stdCtx, namespace, req.Name, currentUser)
+	default:
+		err = errorx.BadRequest(fmt.Errorf("unsupported repo type: %s", repoType), nil)
+	}
+	if err != nil {
+		slog.ErrorContext(stdCtx, "Failed to delete repo", slog.String("repo_type", string(repoType)), slog.Any("error", err))
+		hfError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{})
+}
+
+// MoveRepoHF transfers a repo to another namespace, compatible with huggingface_hub move_repo.
+// Renaming is not supported as the repo name is kept by a transfer
+func (h *RepoHandler) MoveRepoHF(ctx *gin.Context) {
+	var req types.HFMoveRepoReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "Bad request format", "error", err)
+		hfError(ctx, errorx.BadRequest(err, nil))
+		return
+	}
+	repoType, err := hfRepoType(req.Type)
+	if err != nil {
+		hfError(ctx, errorx.BadRequest(err, nil))
+		return
+	}
+	namespace, name, err := common.GetNamespaceAndNameFromPath(req.FromRepo)
+	if err != nil {
+		hfError(ctx, errorx.BadRequest(err, errorx.Ctx().Set("fromRepo", req.FromRepo)))
+		return
+	}
+	newNamespace, newName, err := common.GetNamespaceAndNameFromPath(req.ToRepo)
+	if err != nil {
+		hfError(ctx, errorx.BadRequest(err, errorx.Ctx().Set("toRepo", req.ToRepo)))
+		return
+	}
+	if newName != name {
+		hfError(ctx, errorx.BadRequest(errors.New("renaming a repo is not supported"), errorx.Ctx().Set("toRepo", req.ToRepo)))
+		return
+	}
+
+	err = h.c.TransferOwnership(ctx.Request.Context(), types.TransferRepoReq{
+		RepoType:     repoType,
+		Namespace:    namespace,
+		Name:         name,
+		NewNamespace: newNamespace,
+		CurrentUser:  httpbase.GetCurrentUser(ctx),
+	})
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "Failed to move repo", slog.Any("error", err))
+		hfError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"url": req.ToRepo})
+}
@@ -0,0 +1,387 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"opencsg.com/csghub-server/api/httpbase"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+	"opencsg.com/csghub-server/common/utils/common"
+)
+
+// newHFCompatRouter registers the HF routes with the same paths as the api router, the repo mapping
+// middleware is replaced by setting the repo type, and a bearer token is authenticated as user u
+func newHFCompatRouter(h *RepoHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(func(ctx *gin.Context) {
+		if strings.HasPrefix(ctx.GetHeader("Authorization"), "Bearer ") {
+			httpbase.SetCurrentUser(ctx, "u")
+		}
+	})
+	repoType := func(t types.RepositoryType) gin.HandlerFunc {
+		return func(ctx *gin.Context) {
+			common.SetRepoTypeContext(ctx, t)
+		}
+	}
+	api := engine.Group("/hf/api")
+	for group, t := range map[string]types.RepositoryType{
+		"/models":   types.ModelRepo,
+		"/datasets": types.DatasetRepo,
+		"/spaces":   types.SpaceRepo,
+	} {
+		g := api.Group(group, repoType(t))
+		g.GET("", h.ListReposHF)
+		g.GET("/:namespace/:name/refs", h.RefsHF)
+		g.GET("/:namespace/:name/commits/*revision", h.CommitsHF)
+		g.POST("/:namespace/:name/branch/*branch_name", h.CreateBranchHF)
+		g.DELETE("/:namespace/:name/branch/*branch_name", h.DeleteBranchHF)
+		g.POST("/:namespace/:name/tag/*revision", h.CreateTagHF)
+		g.DELETE("/:namespace/:name/tag/*tag", h.DeleteTagHF)
+		g.PUT("/:namespace/:name/settings", h.UpdateSettingsHF)
+	}
+	api.DELETE("/repos/delete", h.DeleteRepoHF)
+	api.POST("/repos/move", h.MoveRepoHF)
+	return engine
+}
+
+// hfRecordedRequest is a request sent by huggingface_hub, the method, target and body are
+// recorded from the client, the response is what the client parses
+type hfRecordedRequest struct {
+	name   string
+	call   string
+	method string
+	target string
+	body   string
+	setup  func(rt *RepoTester)
+	code   int
+	header map[string]string
+	resp   string
+}
+
+func TestRepoHandler_HFCompatibility(t *testing.T) {
+	created := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	errRepoNotFound := fmt.Errorf("failed to find repo, error: %w", errorx.ErrDatabaseNoRows)
+
+	cases := []hfRecordedRequest{
+		{
+			name:   "list models with search author filter and sort",
+			call:   `list_models(search="bert", author="u", filter="text-classification", sort="downloads", limit=2)`,
+			method: http.MethodGet,
+			target: "/hf/api/models?search=bert&author=u&filter=text-classification&sort=downloads&direction=-1&limit=2",
+			setup: func(rt *RepoTester) {
+				repo := &database.Repository{
+					Path: "u/bert", DownloadCount: 10, Likes: 2,
+					Tags: []database.Tag{{Name: "text-classification", Category: "task"}, {Name: "pytorch", Category: "framework"}},
+				}
+				repo.CreatedAt = created
+				repo.UpdatedAt = created
+				rt.mocks.repo.EXPECT().PublicToUser(mock.Anything, types.ModelRepo, "u", &types.RepoFilter{
+					Search: "bert", Owner: "u", Sort: "most_download",
+					Tags: []types.TagReq{{Name: "text-classification"}},
+				}, 2, 1).Return([]*database.Repository{repo}, 3, nil)
+			},
+			code: http.StatusOK,
+			header: map[string]string{
+				"Link": `<http://localhost/hf/api/models?author=u&direction=-1&filter=text-classification&limit=2&p=2&search=bert&sort=downloads>; rel="next"`,
+			},
+			resp: `[{"id":"u/bert","author":"u","private":false,"downloads":10,"likes":2,
+				"tags":["text-classification","pytorch"],"pipeline_tag":"text-classification","library_name":"pytorch",
+				"createdAt":"2026-01-02T03:04:05Z","lastModified":"2026-01-02T03:04:05Z"}]`,
+		},
+		{
+			name:   "list datasets with prefixed filter",
+			call:   `list_datasets(filter="license:mit")`,
+			method: http.MethodGet,
+			target: "/hf/api/datasets?filter=license%3Amit",
+			setup: func(rt *RepoTester) {
+				rt.mocks.repo.EXPECT().PublicToUser(mock.Anything, types.DatasetRepo, "u", &types.RepoFilter{
+					Sort: "recently_update",
+					Tags: []types.TagReq{{Category: "license", Name: "mit"}},
+				}, 100, 1).Return(nil, 0, nil)
+			},
+			code: http.StatusOK,
+			resp: `[]`,
+		},
+		{
+			name:   "list models sorted by creation time",
+			call:   `list_models(sort="createdAt")`,
+			method: http.MethodGet,
+			target: "/hf/api/models?sort=createdAt",
+			setup: func(rt *RepoTester) {
+				rt.mocks.repo.EXPECT().PublicToUser(mock.Anything, types.ModelRepo, "u", &types.RepoFilter{
+					Sort: "recently_create",
+				}, 100, 1).Return(nil, 0, nil)
+			},
+			code: http.StatusOK,
+			resp: `[]`,
+		},
+		{
+			name:   "list repo refs",
+			call:   `list_repo_refs("u/r")`,
+			method: http.MethodGet,
+			target: "/hf/api/models/u/r/refs",
+			setup: func(rt *RepoTester) {
+				rt.mocks.repo.EXPECT().Branches(mock.Anything, &types.GetBranchesReq{
+					Namespace: "u", Name: "r", RepoType: types.ModelRepo, CurrentUser: "u",
+				}).Return([]types.Branch{{Name: "main", Commit: types.RepoBranchCommit{ID: "c1"}}}, nil)
+				rt.mocks.repo.EXPECT().GitTags(mock.Anything, &types.GetTagsReq{
+					Namespace: "u", Name: "r", RepoType: types.ModelRepo, CurrentUser: "u",
+				}).Return([]types.Tag{{Name: "v1.0", Commit: types.DatasetTagCommit{ID: "c0"}}}, nil)
+			},
+			code: http.StatusOK,
+			resp: `{"branches":[{"name":"main","ref":"refs/heads/main","targetCommit":"c1"}],"converts":[],
+				"tags":[{"name":"v1.0","ref":"refs/tags/v1.0","targetCommit":"c0"}]}`,
+		},
+		{
+			name:   "list repo refs of a missing repo",
+			call:   `list_repo_refs("u/missing")`,
+			method: http.MethodGet,
+			target: "/hf/api/models/u/missing/refs",
+			setup: func(rt *RepoTester) {
+				rt.mocks.repo.EXPECT().Branches(mock.Anything, mock.Anything).Return(nil, errRepoNotFound)
+			},
+			code:   http.StatusNotFound,
+			header: map[string]string{"X-Error-Code": "RepoNotFound"},
+			resp:   fmt.Sprintf(`{"error":%q}`, errRepoNotFound.Error()),
+		},
+		{
+			name:   "create branch with slash from a revision",
+			call:   `create_branch("u/r", branch="feat/x", revision="v1.0")`,
+			method: http.MethodPost,
+			target: "/hf/api/models/u/r/branch/feat%2Fx",
+			body:   `{"startingPoint": "v1.0"}`,
+			setup: func(rt *RepoTester) {
+				rt.mocks.repo.EXPECT().CreateBranch(mock.Anything, types.CreateBranchReq{
+					Namespace: "u", Name: "r", BranchName: "feat/x", CommitID: "v1.0",
+					RepoType: types.ModelRepo, CurrentUser: "u",
+				}).Return(nil)
+			},
+			code: http.StatusOK,
+			resp: `{}`,
+		},
+		{
+			name:   "create existing branch",
+			call:   `create_branch("u/r", repo_type="dataset", branch="dev")`,
+			method: http.MethodPost,
+			target: "/hf/api/datasets/u/r/branch/dev",
+			body:   `{}`,
+			setup: func(rt *RepoTester) {
+				rt.mocks.repo.EXPECT().CreateBranch(mock.Anything, types.CreateBranchReq{
+					Namespace: "u", Name: "r", BranchName: "dev",
+					RepoType: types.DatasetRepo, CurrentUser: "u",
+				}).Return(errorx.ErrAlreadyExists)
+			},
+			code: http.StatusConflict,
+			resp: `{"error":"the record already exists"}`,
+		},
+		{
+			name:   "delete branch",
+			call:   `delete_branch("u/r", repo_type="dataset", branch="dev")`,
+			method: http.MethodDelete,
+			target: "/hf/api/datasets/u/r/branch/dev",
+			setup: func(rt *RepoTester) {
+				rt.mocks.repo.EXPECT().DeleteBranch(mock.Anything, types.DeleteBranchReq{
+					Namespace: "u", Name: "r", BranchName: "dev",
+					RepoType: types.DatasetRepo, CurrentUser: "u",
+				}).Return(nil)
+			},
+			code: http.StatusOK,
+			resp: `{}`,
+		},
+		{
+			name:   "create tag",
+			call:   `create_tag("u/r", tag="v1.0", tag_message="release", revision="main")`,
+			method: http.MethodPost,
+			target: "/hf/api/models/u/r/tag/main",
+			body:   `{"tag": "v1.0", "message": "release"}`,
+			setup: func(rt *RepoTester) {
+				rt.mocks.repo.EXPECT().CreateGitTag(mock.Anything, types.CreateGitTagReq{
+					Namespace: "u", Name: "r", TagName: "v1.0", Revision: "main", Message: "release",
+					RepoType: types.ModelRepo, CurrentUser: "u",
+				}).Return(nil)
+			},
+			code: http.StatusOK,
+			resp: `{}`,
+		},
+		{
+			name:   "create tag on a missing revision",
+			call:   `create_tag("u/r", tag="v1.0", revision="missing")`,
+			method: http.MethodPost,
+			target: "/hf/api/models/u/r/tag/missing",
+			body:   `{"tag": "v1.0"}`,
+			setup: func(rt *RepoTester) {
+				rt.mocks.repo.EXPECT().CreateGitTag(mock.Anything, mock.Anything).Return(errorx.ErrNotFound)
+			},
+			code:   http.StatusNotFound,
+			header: map[string]string{"X-Error-Code": "RevisionNotFound"},
+			resp:   `{"error":"not found"}`,
+		},
+		{
+			name:   "delete tag",
+			call:   `delete_tag("u/r", tag="v1.0")`,
+			method: http.MethodDelete,
+			target: "/hf/api/models/u/r/tag/v1.0",
+			setup: func(rt *RepoTester) {
+				rt.mocks.repo.EXPECT().DeleteGitTag(mock.Anything, types.DeleteGitTagReq{
+					Namespace: "u", Name: "r", TagName: "v1.0",
+					RepoType: types.ModelRepo, CurrentUser: "u",
+				}).Return(nil)
+			},
+			code: http.StatusOK,
+			resp: `{}`,
+		},
+		{
+			name:   "list repo commits",
+			call:   `list_repo_commits("u/r")`,
+			method: http.MethodGet,
+			target: "/hf/api/models/u/r/commits/main",
+			setup: func(rt *RepoTester) {
+				rt.mocks.repo.EXPECT().Commits(mock.Anything, &types.GetCommitsReq{
+					Namespace: "u", Name: "r", Per: 50, Page: 1, Ref: "main",
+					RepoType: types.ModelRepo, CurrentUser: "u",
+				}).Return([]types.Commit{{
+					ID: "c1", Message: "add weights\n\nconverted from the pytorch checkpoint", AuthorName: "u", AuthoredDate: "2026-01-02T03:04:05Z",
+				}}, &types.RepoPageOpts{PageCount: 2, Total: 51}, nil)
+			},
+			code:   http.StatusOK,
+			header: map[string]string{"Link": `<http://localhost/hf/api/models/u/r/commits/main?p=2>; rel="next"`},
+			resp:   `[{"id":"c1","title":"add weights","message":"add weights\n\nconverted from the pytorch checkpoint","authors":[{"user":"u"}],"date":"2026-01-02T03:04:05Z"}]`,
+		},
+		{
+			name:   "list repo commits of a private repo without token",
+			call:   `list_repo_commits("u/private", token=False)`,
+			method: http.MethodGet,
+			target: "/hf/api/models/u/private/commits/main",
+			setup: func(rt *RepoTester) {
+				rt.mocks.repo.EXPECT().Commits(mock.Anything, mock.Anything).Return(nil, nil, errorx.ErrUnauthorized)
+			},
+			code: http.StatusUnauthorized,
+			resp: fmt.Sprintf(`{"error":%q}`, errorx.ErrUnauthorized.Error()),
+		},
+		{
+			name:   "update repo settings",
+			call:   `update_repo_settings("u/r", private=True)`,
+			method: http.MethodPut,
+			target: "/hf/api/models/u/r/settings",
+			body:   `{"private": true}`,
+			setup: func(rt *RepoTester) {
+				private := true
+				rt.mocks.repo.EXPECT().UpdateRepo(mock.Anything, types.UpdateRepoReq{
+					Username: "u", Namespace: "u", Name: "r", RepoType: types.ModelRepo, Private: &private,
+				}).Return(&database.Repository{Private: true}, nil)
+			},
+			code: http.StatusOK,
+			resp: `{"private":true}`,
+		},
+		{
+			name:   "delete model of the current user",
+			call:   `delete_repo("r")`,
+			method: http.MethodDelete,
+			target: "/hf/api/repos/delete",
+			body:   `{"name": "r", "organization": null}`,
+			setup: func(rt *RepoTester) {
+				rt.mocks.model.EXPECT().Delete(mock.Anything, "u", "r", "u").Return(nil)
+			},
+			code: http.StatusOK,
+			resp: `{}`,
+		},
+		{
+			name:   "delete dataset of an organization",
+			call:   `delete_repo("org/r", repo_type="dataset")`,
+			method: http.MethodDelete,
+			target: "/hf/api/repos/delete",
+			body:   `{"name": "r", "organization": "org", "type": "dataset"}`,
+			setup: func(rt *RepoTester) {
+				rt.mocks.dataset.EXPECT().Delete(mock.Anything, "org", "r", "u").Return(nil)
+			},
+			code: http.StatusOK,
+			resp: `{}`,
+		},
+		{
+			name:   "delete space",
+			call:   `delete_repo("r", repo_type="space")`,
+			method: http.MethodDelete,
+			target: "/hf/api/repos/delete",
+			body:   `{"name": "r", "type": "space"}`,
+			setup: func(rt *RepoTester) {
+				rt.mocks.space.EXPECT().Delete(mock.Anything, "u", "r", "u").Return(nil)
+			},
+			code: http.StatusOK,
+			resp: `{}`,
+		},
+		{
+			name:   "move repo to an organization",
+			call:   `move_repo(from_id="u/r", to_id="org/r")`,
+			method: http.MethodPost,
+			target: "/hf/api/repos/move",
+			body:   `{"fromRepo": "u/r", "toRepo": "org/r", "type": "model"}`,
+			setup: func(rt *RepoTester) {
+				rt.mocks.repo.EXPECT().TransferOwnership(mock.Anything, types.TransferRepoReq{
+					RepoType: types.ModelRepo, Namespace: "u", Name: "r", NewNamespace: "org", CurrentUser: "u",
+				}).Return(nil)
+			},
+			code: http.StatusOK,
+			resp: `{"url":"org/r"}`,
+		},
+		{
+			name:   "move repo to an occupied path",
+			call:   `move_repo(from_id="u/r", to_id="org/r", repo_type="dataset")`,
+			method: http.MethodPost,
+			target: "/hf/api/repos/move",
+			body:   `{"fromRepo": "u/r", "toRepo": "org/r", "type": "dataset"}`,
+			setup: func(rt *RepoTester) {
+				rt.mocks.repo.EXPECT().TransferOwnership(mock.Anything, mock.Anything).Return(errorx.ErrTransferTargetExists)
+			},
+			code: http.StatusConflict,
+			resp: fmt.Sprintf(`{"error":%q}`, errorx.ErrTransferTargetExists.Error()),
+		},
+		{
+			name:   "move repo with a new name",
+			call:   `move_repo(from_id="u/r", to_id="u/r2")`,
+			method: http.MethodPost,
+			target: "/hf/api/repos/move",
+			body:   `{"fromRepo": "u/r", "toRepo": "u/r2", "type": "model"}`,
+			code:   http.StatusBadRequest,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			tester := NewRepoTester(t)
+			tester.handler.config.APIServer.PublicDomain = "http://localhost"
+			if c.setup != nil {
+				c.setup(tester)
+			}
+			router := newHFCompatRouter(tester.handler)
+
+			req := httptest.NewRequest(c.method, c.target, strings.NewReader(c.body))
+			req.Header.Set("User-Agent", "huggingface_hub/0.24.6; python/3.10.12")
+			if !strings.Contains(c.call, "token=False") {
+				req.Header.Set("Authorization", "Bearer hf_token")
+			}
+			if c.body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			resp := httptest.NewRecorder()
+			router.ServeHTTP(resp, req)
+
+			require.Equal(t, c.code, resp.Code, c.call, resp.Body.String())
+			for k, v := range c.header {
+				require.Equal(t, v, resp.Header().Get(k), c.call)
+			}
+			if c.resp != "" {
+				require.JSONEq(t, c.resp, resp.Body.String(), c.call)
+			}
+		})
+	}
+}
@@ -0,0 +1,226 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+
+	"github.com/gin-gonic/gin"
+	"opencsg.com/csghub-server/api/httpbase"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+	"opencsg.com/csghub-server/common/utils/common"
+)
+
+// CreateRelease godoc
+// @Security     ApiKey
+// @Summary      Publish a release of an existing git tag
+// @Description  The first release of the repository is the latest one, the followers and likers of the repository are notified
+// @Tags         Repository
+// @Accept       json
+// @Produce      json
+// @Param        repo_type path string true "models or datasets" Enums(models,datasets)
+// @Param        namespace path string true "repo owner name"
+// @Param        name path string true "repo name"
+// @Param        body body types.CreateReleaseReq true "release"
+// @Success      200  {object}  types.Response{data=types.Release} "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      403  {object}  types.APIForbidden "Forbidden"
+// @Failure      404  {object}  types.APINotFound "Not found"
+// @Failure      409  {object}  types.APIBadRequest "Conflict"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /{repo_type}/{namespace}/{name}/releases [post]
+func (h *RepoHandler) CreateRelease(ctx *gin.Context) {
+	var req types.CreateReleaseReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "Bad request format", "error", err)
+		httpbase.BadRequestWithExt(ctx, errorx.ReqParamInvalid(err, nil))
+		return
+	}
+	namespace, name, err := common.GetNamespaceAndNameFromContext(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "Bad request format", "error", err)
+		httpbase.BadRequest(ctx, err.Error())
+		return
+	}
+	req.Namespace = namespace
+	req.Name = name
+	req.RepoType = common.RepoTypeFromContext(ctx)
+	req.CurrentUser = httpbase.GetCurrentUser(ctx)
+	release, err := h.c.CreateRelease(ctx.Request.Context(), &req)
+	if err != nil {
+		h.handleReleaseError(ctx, "Failed to create release", err)
+		return
+	}
+	httpbase.OK(ctx, release)
+}
+
+// ListReleases godoc
+// @Security     ApiKey
+// @Summary      List the releases of a repository, the newest first
+// @Tags         Repository
+// @Produce      json
+// @Param        repo_type path string true "models or datasets" Enums(models,datasets)
+// @Param        namespace path string true "repo owner name"
+// @Param        name path string true "repo name"
+// @Param        per query int false "per" default(20)
+// @Param        page query int false "page index" default(1)
+// @Success      200  {object}  types.ResponseWithTotal{data=[]types.Release,total=int} "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      403  {object}  types.APIForbidden "Forbidden"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /{repo_type}/{namespace}/{name}/releases [get]
+func (h *RepoHandler) ListReleases(ctx *gin.Context) {
+	namespace, name, err := common.GetNamespaceAndNameFromContext(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "Bad request format", "error", err)
+		httpbase.BadRequest(ctx, err.Error())
+		return
+	}
+	per, page, err := common.GetPerAndPageFromContext(ctx)
+	if err != nil {
+		httpbase.BadRequestWithExt(ctx, errorx.ReqParamInvalid(err, nil))
+		return
+	}
+	releases, total, err := h.c.ListReleases(ctx.Request.Context(), &types.ListReleasesReq{
+		Namespace:   namespace,
+		Name:        name,
+		RepoType:    common.RepoTypeFromContext(ctx),
+		CurrentUser: httpbase.GetCurrentUser(ctx),
+		Per:         per,
+		Page:        page,
+	})
+	if err != nil {
+		h.handleReleaseError(ctx, "Failed to list releases", err)
+		return
+	}
+	httpbase.OKWithTotal(ctx, releases, total)
+}
+
+// GetRelease godoc
+// @Security     ApiKey
+// @Summary      Get a release of a repository
+// @Tags         Repository
+// @Produce      json
+// @Param        repo_type path string true "models or datasets" Enums(models,datasets)
+// @Param        namespace path string true "repo owner name"
+// @Param        name path string true "repo name"
+// @Param        release path string true "release name, or latest for the latest release"
+// @Success      200  {object}  types.Response{data=types.Release} "OK"
+// @Failure      403  {object}  types.APIForbidden "Forbidden"
+// @Failure      404  {object}  types.APINotFound "Not found"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /{repo_type}/{namespace}/{name}/releases/{release} [get]
+func (h *RepoHandler) GetRelease(ctx *gin.Context) {
+	namespace, name, err := common.GetNamespaceAndNameFromContext(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "Bad request format", "error", err)
+		httpbase.BadRequest(ctx, err.Error())
+		return
+	}
+	release, err := h.c.GetRelease(ctx.Request.Context(), &types.GetReleaseReq{
+		Namespace:   namespace,
+		Name:        name,
+		RepoType:    common.RepoTypeFromContext(ctx),
+		CurrentUser: httpbase.GetCurrentUser(ctx),
+		ReleaseName: ctx.Param("release"),
+	})
+	if err != nil {
+		h.handleReleaseError(ctx, "Failed to get release", err)
+		return
+	}
+	httpbase.OK(ctx, release)
+}
+
+// UpdateRelease godoc
+// @Security     ApiKey
+// @Summary      Update the changelog, recommended files, deprecation or latest flag of a release
+// @Tags         Repository
+// @Accept       json
+// @Produce      json
+// @Param        repo_type path string true "models or datasets" Enums(models,datasets)
+// @Param        namespace path string true "repo owner name"
+// @Param        name path string true "repo name"
+// @Param        release path string true "release name"
+// @Param        body body types.UpdateReleaseReq true "the fields to update"
+// @Success      200  {object}  types.Response{data=types.Release} "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      403  {object}  types.APIForbidden "Forbidden"
+// @Failure      404  {object}  types.APINotFound "Not found"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /{repo_type}/{namespace}/{name}/releases/{release} [put]
+func (h *RepoHandler) UpdateRelease(ctx *gin.Context) {
+	var req types.UpdateReleaseReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "Bad request format", "error", err)
+		httpbase.BadRequestWithExt(ctx, errorx.ReqParamInvalid(err, nil))
+		return
+	}
+	namespace, name, err := common.GetNamespaceAndNameFromContext(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "Bad request format", "error", err)
+		httpbase.BadRequest(ctx, err.Error())
+		return
+	}
+	req.Namespace = namespace
+	req.Name = name
+	req.RepoType = common.RepoTypeFromContext(ctx)
+	req.CurrentUser = httpbase.GetCurrentUser(ctx)
+	req.ReleaseName = ctx.Param("release")
+	release, err := h.c.UpdateRelease(ctx.Request.Context(), &req)
+	if err != nil {
+		h.handleReleaseError(ctx, "Failed to update release", err)
+		return
+	}
+	httpbase.OK(ctx, release)
+}
+
+// DeleteRelease godoc
+// @Security     ApiKey
+// @Summary      Delete a release, the git tag is kept
+// @Tags         Repository
+// @Produce      json
+// @Param        repo_type path string true "models or datasets" Enums(models,datasets)
+// @Param        namespace path string true "repo owner name"
+// @Param        name path string true "repo name"
+// @Param        release path string true "release name"
+// @Success      200  {object}  types.Response{} "OK"
+// @Failure      403  {object}  types.APIForbidden "Forbidden"
+// @Failure      404  {object}  types.APINotFound "Not found"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /{repo_type}/{namespace}/{name}/releases/{release} [delete]
+func (h *RepoHandler) DeleteRelease(ctx *gin.Context) {
+	namespace, name, err := common.GetNamespaceAndNameFromContext(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "Bad request format", "error", err)
+		httpbase.BadRequest(ctx, err.Error())
+		return
+	}
+	err = h.c.DeleteRelease(ctx.Request.Context(), &types.DeleteReleaseReq{
+		Namespace:   namespace,
+		Name:        name,
+		RepoType:    common.RepoTypeFromContext(ctx),
+		CurrentUser: httpbase.GetCurrentUser(ctx),
+		ReleaseName: ctx.Param("release"),
+	})
+	if err != nil {
+		h.handleReleaseError(ctx, "Failed to delete release", err)
+		return
+	}
+	httpbase.OK(ctx, nil)
+}
+
+func (h *RepoHandler) handleReleaseError(ctx *gin.Context, msg string, err error) {
+	slog.ErrorContext(ctx.Request.Context(), msg, slog.String("repo_type", string(common.RepoTypeFromContext(ctx))), slog.Any("error", err))
+	switch {
+	case errors.Is(err, errorx.ErrBadRequest):
+		httpbase.BadRequestWithExt(ctx, err)
+	case errors.Is(err, errorx.ErrForbidden), errors.Is(err, errorx.ErrUserNotFound):
+		httpbase.ForbiddenError(ctx, err)
+	case errors.Is(err, errorx.ErrNotFound), errors.Is(err, errorx.ErrDatabaseNoRows):
+		httpbase.NotFoundError(ctx, err)
+	case errors.Is(err, errorx.ErrAlreadyExists):
+		httpbase.ConflictError(ctx, err)
+	default:
+		httpbase.ServerError(ctx, err)
+	}
+}
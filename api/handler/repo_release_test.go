@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+)
+
+func TestRepoHandler_CreateRelease(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		tester := NewRepoTester(t).WithHandleFunc(func(rp *RepoHandler) gin.HandlerFunc {
+			return rp.CreateRelease
+		})
+
+		release := &types.Release{Name: "v1", TagName: "v1.0", Latest: true, RecommendedFiles: []string{"model-q4.gguf"}}
+		tester.mocks.repo.EXPECT().CreateRelease(tester.Ctx(), &types.CreateReleaseReq{
+			Namespace:        "u",
+			Name:             "r",
+			RepoType:         types.ModelRepo,
+			CurrentUser:      "u",
+			ReleaseName:      "v1",
+			TagName:          "v1.0",
+			Changelog:        "## Changes",
+			RecommendedFiles: []string{"model-q4.gguf"},
+		}).Return(release, nil).Once()
+		tester.WithUser().WithKV("repo_type", types.ModelRepo).WithBody(t, &types.CreateReleaseReq{
+			ReleaseName:      "v1",
+			TagName:          "v1.0",
+			Changelog:        "## Changes",
+			RecommendedFiles: []string{"model-q4.gguf"},
+		}).Execute()
+		tester.ResponseEq(t, http.StatusOK, tester.OKText, release)
+	})
+
+	t.Run("missing tag name", func(t *testing.T) {
+		tester := NewRepoTester(t).WithHandleFunc(func(rp *RepoHandler) gin.HandlerFunc {
+			return rp.CreateRelease
+		})
+		tester.WithUser().WithBody(t, &types.CreateReleaseReq{ReleaseName: "v1"}).Execute()
+		tester.ResponseEqCode(t, http.StatusBadRequest)
+	})
+
+	t.Run("already exists", func(t *testing.T) {
+		tester := NewRepoTester(t).WithHandleFunc(func(rp *RepoHandler) gin.HandlerFunc {
+			return rp.CreateRelease
+		})
+		tester.mocks.repo.EXPECT().CreateRelease(mock.Anything, mock.Anything).Return(nil, errorx.ErrAlreadyExists).Once()
+		tester.WithUser().WithBody(t, &types.CreateReleaseReq{ReleaseName: "v1", TagName: "v1.0"}).Execute()
+		tester.ResponseEqCode(t, http.StatusConflict)
+	})
+}
+
+func TestRepoHandler_ListReleases(t *testing.T) {
+	tester := NewRepoTester(t).WithHandleFunc(func(rp *RepoHandler) gin.HandlerFunc {
+		return rp.ListReleases
+	})
+
+	releases := []types.Release{{Name: "v2", TagName: "v2.0", Latest: true}, {Name: "v1", TagName: "v1.0", Deprecated: true}}
+	tester.mocks.repo.EXPECT().ListReleases(tester.Ctx(), &types.ListReleasesReq{
+		Namespace:   "u",
+		Name:        "r",
+		RepoType:    types.DatasetRepo,
+		CurrentUser: "u",
+		Per:         10,
+		Page:        1,
+	}).Return(releases, 2, nil).Once()
+	tester.WithUser().WithKV("repo_type", types.DatasetRepo).AddPagination(1, 10).Execute()
+	tester.ResponseEqSimple(t, http.StatusOK, gin.H{
+		"msg":   "OK",
+		"data":  releases,
+		"total": 2,
+	})
+}
+
+func TestRepoHandler_GetRelease(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		tester := NewRepoTester(t).WithHandleFunc(func(rp *RepoHandler) gin.HandlerFunc {
+			return rp.GetRelease
+		})
+
+		release := &types.Release{Name: "v1", TagName: "v1.0", Latest: true}
+		tester.mocks.repo.EXPECT().GetRelease(tester.Ctx(), &types.GetReleaseReq{
+			Namespace:   "u",
+			Name:        "r",
+			RepoType:    types.ModelRepo,
+			CurrentUser: "u",
+			ReleaseName: types.LatestReleaseRef,
+		}).Return(release, nil).Once()
+		tester.WithUser().WithKV("repo_type", types.ModelRepo).WithParam("release", types.LatestReleaseRef).Execute()
+		tester.ResponseEq(t, http.StatusOK, tester.OKText, release)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		tester := NewRepoTester(t).WithHandleFunc(func(rp *RepoHandler) gin.HandlerFunc {
+			return rp.GetRelease
+		})
+		tester.mocks.repo.EXPECT().GetRelease(mock.Anything, mock.Anything).Return(nil, errorx.ErrNotFound).Once()
+		tester.WithParam("release", "v9").Execute()
+		tester.ResponseEqCode(t, http.StatusNotFound)
+	})
+}
+
+func TestRepoHandler_UpdateRelease(t *testing.T) {
+	tester := NewRepoTester(t).WithHandleFunc(func(rp *RepoHandler) gin.HandlerFunc {
+		return rp.UpdateRelease
+	})
+
+	deprecated, note := true, "use v2"
+	release := &types.Release{Name: "v1", TagName: "v1.0", Deprecated: true, DeprecationNote: note}
+	tester.mocks.repo.EXPECT().UpdateRelease(tester.Ctx(), &types.UpdateReleaseReq{
+		Namespace:       "u",
+		Name:            "r",
+		RepoType:        types.ModelRepo,
+		CurrentUser:     "u",
+		ReleaseName:     "v1",
+		Deprecated:      &deprecated,
+		DeprecationNote: &note,
+	}).Return(release, nil).Once()
+	tester.WithUser().WithKV("repo_type", types.ModelRepo).WithParam("release", "v1").WithBody(t, map[string]any{
+		"deprecated":       true,
+		"deprecation_note": note,
+	}).Execute()
+	tester.ResponseEq(t, http.StatusOK, tester.OKText, release)
+}
+
+func TestRepoHandler_DeleteRelease(t *testing.T) {
+	tester := NewRepoTester(t).WithHandleFunc(func(rp *RepoHandler) gin.HandlerFunc {
+		return rp.DeleteRelease
+	})
+
+	tester.mocks.repo.EXPECT().DeleteRelease(tester.Ctx(), &types.DeleteReleaseReq{
+		Namespace:   "u",
+		Name:        "r",
+		RepoType:    types.ModelRepo,
+		CurrentUser: "u",
+		ReleaseName: "v1",
+	}).Return(nil).Once()
+	tester.WithUser().WithKV("repo_type", types.ModelRepo).WithParam("release", "v1").Execute()
+	tester.ResponseEq(t, http.StatusOK, tester.OKText, nil)
+}
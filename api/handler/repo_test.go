@@ -42,6 +42,7 @@ type RepoTester struct {
 		repo     *mockcomponent.MockRepoComponent
 		model    *mockcomponent.MockModelComponent
 		dataset  *mockcomponent.MockDatasetComponent
+		space    *mockcomponent.MockSpaceComponent
 		mirror   *mockcomponent.MockMirrorComponent
 		workflow *workflow_mock.MockClient
 	}
@@ -52,6 +53,7 @@ func NewRepoTester(t *testing.T) *RepoTester {
 	tester.mocks.repo = mockcomponent.NewMockRepoComponent(t)
 	tester.mocks.model = mockcomponent.NewMockModelComponent(t)
 	tester.mocks.dataset = mockcomponent.NewMockDatasetComponent(t)
+	tester.mocks.space = mockcomponent.NewMockSpaceComponent(t)
 	tester.mocks.mirror = mockcomponent.NewMockMirrorComponent(t)
 	tester.mocks.workflow = workflow_mock.NewMockClient(t)
 	temporal.Assign(tester.mocks.workflow)
@@ -59,6 +61,7 @@ func NewRepoTester(t *testing.T) *RepoTester {
 		c:        tester.mocks.repo,
 		m:        tester.mocks.model,
 		d:        tester.mocks.dataset,
+		s:        tester.mocks.space,
 		mirror:   tester.mocks.mirror,
 		temporal: tester.mocks.workflow,
 		config: &config.Config{
@@ -558,6 +561,44 @@ func TestRepoHandler_LastCommit(t *testing.T) {
 	})
 }
 
+func TestRepoHandler_Card(t *testing.T) {
+	t.Run("forbidden", func(t *testing.T) {
+		tester := NewRepoTester(t).WithHandleFunc(func(rp *RepoHandler) gin.HandlerFunc {
+			return rp.Card
+		})
+
+		tester.mocks.repo.EXPECT().GetCard(mock.Anything, mock.Anything).Return(nil, errorx.ErrForbidden).Once()
+		tester.Execute()
+		require.Equal(t, http.StatusForbidden, tester.Response().Code)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		tester := NewRepoTester(t).WithHandleFunc(func(rp *RepoHandler) gin.HandlerFunc {
+			return rp.Card
+		})
+
+		tester.mocks.repo.EXPECT().GetCard(mock.Anything, mock.Anything).Return(nil, errorx.ErrNotFound).Once()
+		tester.Execute()
+		require.Equal(t, http.StatusNotFound, tester.Response().Code)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		tester := NewRepoTester(t).WithHandleFunc(func(rp *RepoHandler) gin.HandlerFunc {
+			return rp.Card
+		})
+
+		card := &types.RepoCard{RepoType: types.ModelRepo, License: "mit", BaseModel: []string{"foo/base"}}
+		tester.mocks.repo.EXPECT().GetCard(tester.Ctx(), &types.GetRepoCardReq{
+			Namespace:   "u",
+			Name:        "r",
+			RepoType:    types.ModelRepo,
+			CurrentUser: "u",
+		}).Return(card, nil).Once()
+		tester.WithUser().WithKV("repo_type", types.ModelRepo).Execute()
+		tester.ResponseEq(t, http.StatusOK, tester.OKText, card)
+	})
+}
+
 func TestRepoHandler_FileRaw(t *testing.T) {
 	tester := NewRepoTester(t).WithHandleFunc(func(rp *RepoHandler) gin.HandlerFunc {
 		return rp.FileRaw
@@ -604,6 +645,44 @@ func TestRepoHandler_FileInfo(t *testing.T) {
 
 }
 
+func TestRepoHandler_DownloadArchive(t *testing.T) {
+	tester := NewRepoTester(t).WithHandleFunc(func(rp *RepoHandler) gin.HandlerFunc {
+		return rp.DownloadArchive
+	})
+	tester.WithUser()
+	tester.WithKV("repo_type", types.ModelRepo)
+	tester.WithParam("ref", "/v1.0")
+	tester.WithQuery("format", "tar.gz").WithQuery("include_lfs", "true")
+	tester.WithQuery("include", "*.json").WithQuery("include", "onnx/**").WithQuery("exclude", "README.md")
+
+	tester.mocks.repo.EXPECT().DownloadArchive(tester.Ctx(), &types.DownloadArchiveReq{
+		RepoType: types.ModelRepo, Namespace: "u", Name: "r", Revision: "v1.0",
+		Format: types.ArchiveFormatTarGz, IncludeLfs: true,
+		Include: []string{"*.json", "onnx/**"}, Exclude: []string{"README.md"}, CurrentUser: "u",
+	}).Return(io.NopCloser(strings.NewReader("archive")), nil)
+
+	tester.Execute()
+	require.Equal(t, http.StatusOK, tester.Response().Code)
+	headers := tester.Response().Header()
+	require.Equal(t, "application/gzip", headers.Get("Content-Type"))
+	require.Equal(t, `attachment; filename=r-v1.0.tar.gz`, headers.Get("Content-Disposition"))
+	require.Equal(t, "archive", tester.Response().Body.String())
+}
+
+func TestRepoHandler_DownloadArchiveForbidden(t *testing.T) {
+	tester := NewRepoTester(t).WithHandleFunc(func(rp *RepoHandler) gin.HandlerFunc {
+		return rp.DownloadArchive
+	})
+	tester.WithKV("repo_type", types.DatasetRepo)
+
+	tester.mocks.repo.EXPECT().DownloadArchive(tester.Ctx(), &types.DownloadArchiveReq{
+		RepoType: types.DatasetRepo, Namespace: "u", Name: "r",
+	}).Return(nil, errorx.ErrForbidden)
+
+	tester.Execute()
+	require.Equal(t, http.StatusForbidden, tester.Response().Code)
+}
+
 func TestRepoHandler_DownloadCodeZip(t *testing.T) {
 	t.Run("success with ref", func(t *testing.T) {
 		tester := NewRepoTester(t).WithHandleFunc(func(rp *RepoHandler) gin.HandlerFunc {
@@ -2604,11 +2683,12 @@ func TestRepoHandler_CommitFiles(t *testing.T) {
 			},
 		},
 	}
-	tester.mocks.repo.EXPECT().CommitFiles(mock.Anything, req).Return(nil).Once()
+	resp := &types.CommitFilesResp{Files: []string{"file1"}}
+	tester.mocks.repo.EXPECT().CommitFiles(mock.Anything, req).Return(resp, nil).Once()
 	tester.WithParam("path", "CSG_u/r").WithKV("repo_type", types.ModelRepo).WithParam("revision", "main").WithBody(t, req).WithUser().Execute()
 
 	tester.ResponseEq(
-		t, 200, tester.OKText, nil,
+		t, 200, tester.OKText, resp,
 	)
 }
 
@@ -0,0 +1,197 @@
+package handler
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"opencsg.com/csghub-server/api/httpbase"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+	"opencsg.com/csghub-server/common/utils/common"
+	"opencsg.com/csghub-server/component"
+)
+
+// RepoTrafficHandler handles the traffic analytics requests of the repositories
+type RepoTrafficHandler struct {
+	c component.RepoTrafficComponent
+}
+
+func NewRepoTrafficHandler(c component.RepoTrafficComponent) *RepoTrafficHandler {
+	return &RepoTrafficHandler{
+		c: c,
+	}
+}
+
+// Stats godoc
+// @Security     ApiKey
+// @Summary      Get the traffic of a repository
+// @Description  Get the downloads, clones and unique users of a repository in the date range, and the daily time series, only the repository admins can view the traffic
+// @Tags         Repository
+// @Produce      json
+// @Param        repo_type path string true "repository type" Enums(models, datasets, codes, spaces, prompts, mcpservers)
+// @Param        namespace path string true "namespace"
+// @Param        name path string true "name"
+// @Param        start_date query string false "start date, like 2006-01-02, 30 days before the end date by default"
+// @Param        end_date query string false "end date, like 2006-01-02, today by default"
+// @Success      200  {object}  types.Response{data=types.RepoTrafficStats} "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      403  {object}  types.APIForbidden "Forbidden"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /traffic/{repo_type}/{namespace}/{name} [get]
+func (h *RepoTrafficHandler) Stats(ctx *gin.Context) {
+	req, ok := h.bindRequest(ctx)
+	if !ok {
+		return
+	}
+	stats, err := h.c.Stats(ctx.Request.Context(), req)
+	if err != nil {
+		h.handleError(ctx, req, err)
+		return
+	}
+	httpbase.OK(ctx, stats)
+}
+
+// TopFiles godoc
+// @Security     ApiKey
+// @Summary      Get the most downloaded files of a repository
+// @Tags         Repository
+// @Produce      json
+// @Param        repo_type path string true "repository type" Enums(models, datasets, codes, spaces, prompts, mcpservers)
+// @Param        namespace path string true "namespace"
+// @Param        name path string true "name"
+// @Param        start_date query string false "start date, like 2006-01-02, 30 days before the end date by default"
+// @Param        end_date query string false "end date, like 2006-01-02, today by default"
+// @Param        limit query int false "max number of the files" default(10)
+// @Success      200  {object}  types.Response{data=[]types.RepoTrafficFile} "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      403  {object}  types.APIForbidden "Forbidden"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /traffic/{repo_type}/{namespace}/{name}/files [get]
+func (h *RepoTrafficHandler) TopFiles(ctx *gin.Context) {
+	req, ok := h.bindRequest(ctx)
+	if !ok {
+		return
+	}
+	files, err := h.c.TopFiles(ctx.Request.Context(), req)
+	if err != nil {
+		h.handleError(ctx, req, err)
+		return
+	}
+	httpbase.OK(ctx, files)
+}
+
+// Regions godoc
+// @Security     ApiKey
+// @Summary      Get the traffic of a repository by region
+// @Tags         Repository
+// @Produce      json
+// @Param        repo_type path string true "repository type" Enums(models, datasets, codes, spaces, prompts, mcpservers)
+// @Param        namespace path string true "namespace"
+// @Param        name path string true "name"
+// @Param        start_date query string false "start date, like 2006-01-02, 30 days before the end date by default"
+// @Param        end_date query string false "end date, like 2006-01-02, today by default"
+// @Success      200  {object}  types.Response{data=[]types.RepoTrafficRegion} "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      403  {object}  types.APIForbidden "Forbidden"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /traffic/{repo_type}/{namespace}/{name}/regions [get]
+func (h *RepoTrafficHandler) Regions(ctx *gin.Context) {
+	req, ok := h.bindRequest(ctx)
+	if !ok {
+		return
+	}
+	regions, err := h.c.Regions(ctx.Request.Context(), req)
+	if err != nil {
+		h.handleError(ctx, req, err)
+		return
+	}
+	httpbase.OK(ctx, regions)
+}
+
+// Export godoc
+// @Security     ApiKey
+// @Summary      Export the daily traffic of a repository as CSV
+// @Tags         Repository
+// @Produce      text/csv
+// @Param        repo_type path string true "repository type" Enums(models, datasets, codes, spaces, prompts, mcpservers)
+// @Param        namespace path string true "namespace"
+// @Param        name path string true "name"
+// @Param        start_date query string false "start date, like 2006-01-02, 30 days before the end date by default"
+// @Param        end_date query string false "end date, like 2006-01-02, today by default"
+// @Success      200  {string}  string "CSV file"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      403  {object}  types.APIForbidden "Forbidden"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /traffic/{repo_type}/{namespace}/{name}/export [get]
+func (h *RepoTrafficHandler) Export(ctx *gin.Context) {
+	req, ok := h.bindRequest(ctx)
+	if !ok {
+		return
+	}
+	dailies, err := h.c.Dailies(ctx.Request.Context(), req)
+	if err != nil {
+		h.handleError(ctx, req, err)
+		return
+	}
+
+	filename := fmt.Sprintf("%s_%s_traffic.csv", req.Namespace, req.Name)
+	ctx.Header("Content-Type", "text/csv; charset=utf-8")
+	ctx.Header("Content-Disposition", "attachment; filename=\""+filename+"\"")
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Status(http.StatusOK)
+
+	writer := csv.NewWriter(ctx.Writer)
+	defer writer.Flush()
+	_ = writer.Write([]string{"Date", "Kind", "Path", "Region", "Count"})
+	for _, daily := range dailies {
+		_ = writer.Write([]string{
+			daily.Date,
+			string(daily.Kind),
+			escapeCSVCell(daily.Path),
+			escapeCSVCell(daily.Region),
+			strconv.FormatInt(daily.Count, 10),
+		})
+	}
+}
+
+func (h *RepoTrafficHandler) bindRequest(ctx *gin.Context) (*types.RepoTrafficReq, bool) {
+	var req types.RepoTrafficReq
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		httpbase.BadRequestWithExt(ctx, errorx.ReqParamInvalid(err, nil))
+		return nil, false
+	}
+	repoType, err := common.RepoTypeFromString(ctx.Param("repo_type"))
+	if err != nil {
+		httpbase.BadRequestWithExt(ctx, errorx.ReqParamInvalid(err, errorx.Ctx().Set("param", "repo_type")))
+		return nil, false
+	}
+	namespace, name, err := common.GetNamespaceAndNameFromContext(ctx)
+	if err != nil {
+		httpbase.BadRequestWithExt(ctx, errorx.ReqParamInvalid(err, nil))
+		return nil, false
+	}
+	req.RepoType = repoType
+	req.Namespace = namespace
+	req.Name = name
+	req.CurrentUser = httpbase.GetCurrentUser(ctx)
+	return &req, true
+}
+
+func (h *RepoTrafficHandler) handleError(ctx *gin.Context, req *types.RepoTrafficReq, err error) {
+	slog.ErrorContext(ctx.Request.Context(), "failed to get repo traffic", slog.Any("req", req), slog.Any("error", err))
+	switch {
+	case errors.Is(err, errorx.ErrReqParamInvalid):
+		httpbase.BadRequestWithExt(ctx, err)
+	case errors.Is(err, errorx.ErrForbidden) || errors.Is(err, errorx.ErrUserNotFound):
+		httpbase.ForbiddenError(ctx, err)
+	case errors.Is(err, errorx.ErrDatabaseNoRows):
+		httpbase.NotFoundError(ctx, err)
+	default:
+		httpbase.ServerError(ctx, err)
+	}
+}
@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	mockcomponent "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/component"
+	"opencsg.com/csghub-server/builder/testutil"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+)
+
+type RepoTrafficTester struct {
+	*testutil.GinTester
+	handler *RepoTrafficHandler
+	mocks   struct {
+		traffic *mockcomponent.MockRepoTrafficComponent
+	}
+}
+
+func NewRepoTrafficTester(t *testing.T) *RepoTrafficTester {
+	tester := &RepoTrafficTester{GinTester: testutil.NewGinTester()}
+	tester.mocks.traffic = mockcomponent.NewMockRepoTrafficComponent(t)
+	tester.handler = NewRepoTrafficHandler(tester.mocks.traffic)
+	return tester
+}
+
+func (t *RepoTrafficTester) WithHandleFunc(fn func(h *RepoTrafficHandler) gin.HandlerFunc) *RepoTrafficTester {
+	t.Handler(fn(t.handler))
+	t.WithParam("repo_type", "models").WithParam("namespace", "ns").WithParam("name", "n")
+	return t
+}
+
+func TestRepoTrafficHandler_Stats(t *testing.T) {
+	tester := NewRepoTrafficTester(t).WithHandleFunc(func(h *RepoTrafficHandler) gin.HandlerFunc {
+		return h.Stats
+	})
+	tester.WithUser()
+
+	stats := &types.RepoTrafficStats{
+		StartDate: "2026-10-01",
+		EndDate:   "2026-10-01",
+		Summary:   types.RepoTrafficSummary{Downloads: 3, Clones: 1, UniqueUsers: 2},
+		TimeSeries: []types.RepoTrafficPoint{
+			{Date: "2026-10-01", Downloads: 3, Clones: 1, UniqueUsers: 2},
+		},
+	}
+	tester.mocks.traffic.EXPECT().Stats(tester.Ctx(), &types.RepoTrafficReq{
+		RepoType:    types.ModelRepo,
+		Namespace:   "ns",
+		Name:        "n",
+		CurrentUser: "u",
+		StartDate:   "2026-10-01",
+		EndDate:     "2026-10-01",
+	}).Return(stats, nil)
+
+	tester.WithQuery("start_date", "2026-10-01").WithQuery("end_date", "2026-10-01").Execute()
+	tester.ResponseEq(t, http.StatusOK, tester.OKText, stats)
+}
+
+func TestRepoTrafficHandler_StatsErrors(t *testing.T) {
+	cases := []struct {
+		err  error
+		code int
+	}{
+		{errorx.ReqParamInvalid(errorx.ErrReqParamInvalid, nil), http.StatusBadRequest},
+		{errorx.ErrForbidden, http.StatusForbidden},
+		{errorx.ErrDatabaseNoRows, http.StatusNotFound},
+	}
+	for _, c := range cases {
+		tester := NewRepoTrafficTester(t).WithHandleFunc(func(h *RepoTrafficHandler) gin.HandlerFunc {
+			return h.Stats
+		})
+		tester.mocks.traffic.EXPECT().Stats(tester.Ctx(), mock.Anything).Return(nil, c.err)
+		tester.Execute()
+		tester.ResponseEqCode(t, c.code)
+	}
+}
+
+func TestRepoTrafficHandler_TopFiles(t *testing.T) {
+	tester := NewRepoTrafficTester(t).WithHandleFunc(func(h *RepoTrafficHandler) gin.HandlerFunc {
+		return h.TopFiles
+	})
+	tester.WithUser()
+
+	files := []types.RepoTrafficFile{{Path: "model.bin", Downloads: 5}}
+	tester.mocks.traffic.EXPECT().TopFiles(tester.Ctx(), &types.RepoTrafficReq{
+		RepoType:    types.ModelRepo,
+		Namespace:   "ns",
+		Name:        "n",
+		CurrentUser: "u",
+		Limit:       5,
+	}).Return(files, nil)
+
+	tester.WithQuery("limit", "5").Execute()
+	tester.ResponseEq(t, http.StatusOK, tester.OKText, files)
+}
+
+func TestRepoTrafficHandler_Regions(t *testing.T) {
+	tester := NewRepoTrafficTester(t).WithHandleFunc(func(h *RepoTrafficHandler) gin.HandlerFunc {
+		return h.Regions
+	})
+	tester.WithUser()
+
+	regions := []types.RepoTrafficRegion{{Region: "Shanghai", Hits: 5, UniqueUsers: 2}}
+	tester.mocks.traffic.EXPECT().Regions(tester.Ctx(), mock.Anything).Return(regions, nil)
+
+	tester.Execute()
+	tester.ResponseEq(t, http.StatusOK, tester.OKText, regions)
+}
+
+func TestRepoTrafficHandler_Export(t *testing.T) {
+	tester := NewRepoTrafficTester(t).WithHandleFunc(func(h *RepoTrafficHandler) gin.HandlerFunc {
+		return h.Export
+	})
+	tester.WithUser()
+
+	tester.mocks.traffic.EXPECT().Dailies(tester.Ctx(), mock.Anything).Return([]types.RepoTrafficDaily{
+		{Date: "2026-10-01", Kind: types.RepoTrafficResolve, Path: "model.bin", Region: "Shanghai", Count: 3},
+		{Date: "2026-10-01", Kind: types.RepoTrafficClone, Region: "unknown", Count: 1},
+		// the paths are user input, spreadsheets must not evaluate them as formulas
+		{Date: "2026-10-02", Kind: types.RepoTrafficResolve, Path: "=HYPERLINK(\"x\")", Region: "Shanghai", Count: 2},
+	}, nil)
+
+	tester.Execute()
+	resp := tester.Response()
+	require.Equal(t, http.StatusOK, resp.Code)
+	require.Contains(t, resp.Header().Get("Content-Type"), "text/csv")
+	require.Contains(t, resp.Header().Get("Content-Disposition"), "ns_n_traffic.csv")
+	require.Equal(t, "Date,Kind,Path,Region,Count\n"+
+		"2026-10-01,resolve,model.bin,Shanghai,3\n"+
+		"2026-10-01,clone,,unknown,1\n"+
+		"2026-10-02,resolve,\"'=HYPERLINK(\"\"x\"\")\",Shanghai,2\n", resp.Body.String())
+}
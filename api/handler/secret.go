@@ -0,0 +1,259 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"opencsg.com/csghub-server/api/httpbase"
+	"opencsg.com/csghub-server/common/config"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+	"opencsg.com/csghub-server/common/utils/common"
+	"opencsg.com/csghub-server/component"
+)
+
+func NewSecretHandler(config *config.Config) (*SecretHandler, error) {
+	c, err := component.NewSecretComponent(config)
+	if err != nil {
+		return nil, err
+	}
+	return &SecretHandler{c: c}, nil
+}
+
+type SecretHandler struct {
+	c component.SecretComponent
+}
+
+// ListSpaceSecrets godoc
+// @Security     ApiKey
+// @Summary      List the secrets of a space
+// @Description  the values are masked, only the last 4 characters of the long values are shown
+// @Tags         Space
+// @Produce      json
+// @Param        namespace path string true "namespace"
+// @Param        name path string true "name"
+// @Success      200  {object}  types.Response{data=[]types.Secret} "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      403  {object}  types.APIForbidden "Forbidden"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /spaces/{namespace}/{name}/secrets [get]
+func (h *SecretHandler) ListSpaceSecrets(ctx *gin.Context) {
+	req, ok := bindSpaceSecretReq(ctx, false)
+	if !ok {
+		return
+	}
+	secrets, err := h.c.ListSpaceSecrets(ctx.Request.Context(), req)
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "failed to list space secrets", slog.String("namespace", req.Namespace),
+			slog.String("name", req.Name), slog.Any("error", err))
+		handleSecretError(ctx, err)
+		return
+	}
+	httpbase.OK(ctx, secrets)
+}
+
+// SetSpaceSecret godoc
+// @Security     ApiKey
+// @Summary      Create or update a secret of a space
+// @Description  the secret takes effect the next time the space is deployed
+// @Tags         Space
+// @Accept       json
+// @Produce      json
+// @Param        namespace path string true "namespace"
+// @Param        name path string true "name"
+// @Param        key path string true "secret key, a valid environment variable name"
+// @Param        body body types.SecretReq true "secret value"
+// @Success      200  {object}  types.Response{data=types.Secret} "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      403  {object}  types.APIForbidden "Forbidden"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /spaces/{namespace}/{name}/secrets/{key} [put]
+func (h *SecretHandler) SetSpaceSecret(ctx *gin.Context) {
+	req, ok := bindSpaceSecretReq(ctx, true)
+	if !ok {
+		return
+	}
+	secret, err := h.c.SetSpaceSecret(ctx.Request.Context(), req)
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "failed to set space secret", slog.String("namespace", req.Namespace),
+			slog.String("name", req.Name), slog.String("key", req.Key), slog.Any("error", err))
+		handleSecretError(ctx, err)
+		return
+	}
+	httpbase.OK(ctx, secret)
+}
+
+// DeleteSpaceSecret godoc
+// @Security     ApiKey
+// @Summary      Delete a secret of a space
+// @Tags         Space
+// @Produce      json
+// @Param        namespace path string true "namespace"
+// @Param        name path string true "name"
+// @Param        key path string true "secret key"
+// @Success      200  {object}  types.Response{} "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      403  {object}  types.APIForbidden "Forbidden"
+// @Failure      404  {object}  types.APINotFound "Not found"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /spaces/{namespace}/{name}/secrets/{key} [delete]
+func (h *SecretHandler) DeleteSpaceSecret(ctx *gin.Context) {
+	req, ok := bindSpaceSecretReq(ctx, false)
+	if !ok {
+		return
+	}
+	req.Key = ctx.Param("key")
+	err := h.c.DeleteSpaceSecret(ctx.Request.Context(), req)
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "failed to delete space secret", slog.String("namespace", req.Namespace),
+			slog.String("name", req.Name), slog.String("key", req.Key), slog.Any("error", err))
+		handleSecretError(ctx, err)
+		return
+	}
+	httpbase.OK(ctx, nil)
+}
+
+// ListDeploySecrets godoc
+// @Security     ApiKey
+// @Summary      List the secrets of an inference, finetune or notebook deploy
+// @Description  the values are masked, only the last 4 characters of the long values are shown
+// @Tags         Deploy
+// @Produce      json
+// @Param        id path int true "deploy id"
+// @Success      200  {object}  types.Response{data=[]types.Secret} "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      403  {object}  types.APIForbidden "Forbidden"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /deploys/{id}/secrets [get]
+func (h *SecretHandler) ListDeploySecrets(ctx *gin.Context) {
+	req, ok := bindDeploySecretReq(ctx, false)
+	if !ok {
+		return
+	}
+	secrets, err := h.c.ListDeploySecrets(ctx.Request.Context(), req)
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "failed to list deploy secrets", slog.Int64("deploy_id", req.DeployID), slog.Any("error", err))
+		handleSecretError(ctx, err)
+		return
+	}
+	httpbase.OK(ctx, secrets)
+}
+
+// SetDeploySecret godoc
+// @Security     ApiKey
+// @Summary      Create or update a secret of an inference, finetune or notebook deploy
+// @Description  the secret takes effect the next time the deploy is started
+// @Tags         Deploy
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "deploy id"
+// @Param        key path string true "secret key, a valid environment variable name"
+// @Param        body body types.SecretReq true "secret value"
+// @Success      200  {object}  types.Response{data=types.Secret} "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      403  {object}  types.APIForbidden "Forbidden"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /deploys/{id}/secrets/{key} [put]
+func (h *SecretHandler) SetDeploySecret(ctx *gin.Context) {
+	req, ok := bindDeploySecretReq(ctx, true)
+	if !ok {
+		return
+	}
+	secret, err := h.c.SetDeploySecret(ctx.Request.Context(), req)
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "failed to set deploy secret", slog.Int64("deploy_id", req.DeployID),
+			slog.String("key", req.Key), slog.Any("error", err))
+		handleSecretError(ctx, err)
+		return
+	}
+	httpbase.OK(ctx, secret)
+}
+
+// DeleteDeploySecret godoc
+// @Security     ApiKey
+// @Summary      Delete a secret of an inference, finetune or notebook deploy
+// @Tags         Deploy
+// @Produce      json
+// @Param        id path int true "deploy id"
+// @Param        key path string true "secret key"
+// @Success      200  {object}  types.Response{} "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      403  {object}  types.APIForbidden "Forbidden"
+// @Failure      404  {object}  types.APINotFound "Not found"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /deploys/{id}/secrets/{key} [delete]
+func (h *SecretHandler) DeleteDeploySecret(ctx *gin.Context) {
+	req, ok := bindDeploySecretReq(ctx, false)
+	if !ok {
+		return
+	}
+	req.Key = ctx.Param("key")
+	err := h.c.DeleteDeploySecret(ctx.Request.Context(), req)
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "failed to delete deploy secret", slog.Int64("deploy_id", req.DeployID),
+			slog.String("key", req.Key), slog.Any("error", err))
+		handleSecretError(ctx, err)
+		return
+	}
+	httpbase.OK(ctx, nil)
+}
+
+func bindSpaceSecretReq(ctx *gin.Context, withValue bool) (*types.SecretReq, bool) {
+	namespace, name, err := common.GetNamespaceAndNameFromContext(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "Bad request format", "error", err)
+		httpbase.BadRequestWithExt(ctx, errorx.ReqParamInvalid(err, nil))
+		return nil, false
+	}
+	req, ok := bindSecretReq(ctx, withValue)
+	if !ok {
+		return nil, false
+	}
+	req.Namespace = namespace
+	req.Name = name
+	return req, true
+}
+
+func bindDeploySecretReq(ctx *gin.Context, withValue bool) (*types.SecretReq, bool) {
+	deployID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil || deployID <= 0 {
+		slog.ErrorContext(ctx.Request.Context(), "Bad request format", "error", err)
+		httpbase.BadRequestWithExt(ctx, errorx.ReqParamInvalid(err, errorx.Ctx().Set("param", "id")))
+		return nil, false
+	}
+	req, ok := bindSecretReq(ctx, withValue)
+	if !ok {
+		return nil, false
+	}
+	req.DeployID = deployID
+	return req, true
+}
+
+func bindSecretReq(ctx *gin.Context, withValue bool) (*types.SecretReq, bool) {
+	req := &types.SecretReq{}
+	if withValue {
+		if err := ctx.ShouldBindJSON(req); err != nil {
+			slog.ErrorContext(ctx.Request.Context(), "Bad request format", "error", err)
+			httpbase.BadRequestWithExt(ctx, errorx.ReqParamInvalid(err, nil))
+			return nil, false
+		}
+		req.Key = ctx.Param("key")
+	}
+	req.CurrentUser = httpbase.GetCurrentUser(ctx)
+	return req, true
+}
+
+func handleSecretError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, errorx.ErrForbidden):
+		httpbase.ForbiddenError(ctx, err)
+	case errors.Is(err, errorx.ErrDatabaseNoRows), errors.Is(err, errorx.ErrNotFound):
+		httpbase.NotFoundError(ctx, err)
+	case errors.Is(err, errorx.ErrReqParamInvalid):
+		httpbase.BadRequestWithExt(ctx, err)
+	default:
+		httpbase.ServerError(ctx, err)
+	}
+}
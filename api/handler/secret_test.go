@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	mockcomponent "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/component"
+	"opencsg.com/csghub-server/builder/testutil"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+)
+
+type SecretTester struct {
+	*testutil.GinTester
+	handler *SecretHandler
+	mocks   struct {
+		comp *mockcomponent.MockSecretComponent
+	}
+}
+
+func NewSecretTester(t *testing.T) *SecretTester {
+	tester := &SecretTester{GinTester: testutil.NewGinTester()}
+	tester.mocks.comp = mockcomponent.NewMockSecretComponent(t)
+	tester.handler = &SecretHandler{c: tester.mocks.comp}
+	return tester
+}
+
+func (t *SecretTester) WithHandleFunc(fn func(h *SecretHandler) gin.HandlerFunc) *SecretTester {
+	t.Handler(fn(t.handler))
+	return t
+}
+
+func TestSecretHandler_ListSpaceSecrets(t *testing.T) {
+	tester := NewSecretTester(t).WithHandleFunc(func(h *SecretHandler) gin.HandlerFunc {
+		return h.ListSpaceSecrets
+	})
+	tester.WithUser().WithParam("namespace", "ns").WithParam("name", "n")
+
+	secrets := []types.Secret{{Key: "HF_TOKEN", Value: "******cdef", KeyVersion: 1}}
+	tester.mocks.comp.EXPECT().ListSpaceSecrets(tester.Ctx(), &types.SecretReq{
+		CurrentUser: "u", Namespace: "ns", Name: "n",
+	}).Return(secrets, nil)
+
+	tester.Execute()
+	tester.ResponseEq(t, http.StatusOK, tester.OKText, secrets)
+}
+
+func TestSecretHandler_SetDeploySecret(t *testing.T) {
+	tester := NewSecretTester(t).WithHandleFunc(func(h *SecretHandler) gin.HandlerFunc {
+		return h.SetDeploySecret
+	})
+	tester.WithUser().WithParam("id", "3").WithParam("key", "HF_TOKEN")
+
+	secret := &types.Secret{Key: "HF_TOKEN", Value: "******cdef", KeyVersion: 1}
+	tester.mocks.comp.EXPECT().SetDeploySecret(tester.Ctx(), &types.SecretReq{
+		CurrentUser: "u", DeployID: 3, Key: "HF_TOKEN", Value: "hf_0123456789abcdef",
+	}).Return(secret, nil)
+
+	tester.WithBody(t, map[string]string{"value": "hf_0123456789abcdef"}).Execute()
+	tester.ResponseEq(t, http.StatusOK, tester.OKText, secret)
+}
+
+func TestSecretHandler_SetDeploySecretWithoutValue(t *testing.T) {
+	tester := NewSecretTester(t).WithHandleFunc(func(h *SecretHandler) gin.HandlerFunc {
+		return h.SetDeploySecret
+	})
+	tester.WithUser().WithParam("id", "3").WithParam("key", "HF_TOKEN")
+
+	tester.WithBody(t, map[string]string{}).Execute()
+	require.Equal(t, http.StatusBadRequest, tester.Response().Code)
+}
+
+func TestSecretHandler_DeleteDeploySecret(t *testing.T) {
+	tester := NewSecretTester(t).WithHandleFunc(func(h *SecretHandler) gin.HandlerFunc {
+		return h.DeleteDeploySecret
+	})
+	tester.WithUser().WithParam("id", "3").WithParam("key", "HF_TOKEN")
+
+	tester.mocks.comp.EXPECT().DeleteDeploySecret(tester.Ctx(), &types.SecretReq{
+		CurrentUser: "u", DeployID: 3, Key: "HF_TOKEN",
+	}).Return(errorx.ErrDatabaseNoRows)
+
+	tester.Execute()
+	require.Equal(t, http.StatusNotFound, tester.Response().Code)
+}
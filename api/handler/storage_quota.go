@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/gin-gonic/gin"
+	"opencsg.com/csghub-server/api/httpbase"
+	"opencsg.com/csghub-server/common/config"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+	"opencsg.com/csghub-server/component"
+)
+
+type StorageQuotaHandler struct {
+	storageQuota component.StorageQuotaComponent
+}
+
+func NewStorageQuotaHandler(config *config.Config) (*StorageQuotaHandler, error) {
+	sqc, err := component.NewStorageQuotaComponent(config)
+	if err != nil {
+		return nil, fmt.Errorf("error creating storage quota component:%w", err)
+	}
+	return &StorageQuotaHandler{
+		storageQuota: sqc,
+	}, nil
+}
+
+// GetStorageQuota      godoc
+// @Security     ApiKey
+// @Summary      get the storage quota and usage of a user or organization
+// @Tags         StorageQuota
+// @Accept       json
+// @Produce      json
+// @Param        namespace path string true "user or organization name"
+// @Success      200  {object}  types.Response{data=types.StorageQuota} "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /storage_quotas/{namespace} [get]
+func (h *StorageQuotaHandler) Get(ctx *gin.Context) {
+	req := types.GetStorageQuotaReq{
+		CurrentUser: httpbase.GetCurrentUser(ctx),
+		Namespace:   ctx.Param("namespace"),
+	}
+	quota, err := h.storageQuota.GetQuota(ctx.Request.Context(), req)
+	if err != nil {
+		if errors.Is(err, errorx.ErrForbidden) {
+			slog.WarnContext(ctx.Request.Context(), "not allowed to get storage quota", slog.Any("error", err), slog.Any("req", req))
+			httpbase.ForbiddenError(ctx, err)
+			return
+		}
+		slog.ErrorContext(ctx.Request.Context(), "failed to get storage quota", slog.Any("error", err), slog.Any("req", req))
+		httpbase.ServerError(ctx, err)
+		return
+	}
+	httpbase.OK(ctx, quota)
+}
+
+// SetStorageQuota      godoc
+// @Security     ApiKey
+// @Summary      override the storage quota of a user or organization
+// @Description  quota_bytes 0 means unlimited, null restores the default quota
+// @Tags         StorageQuota
+// @Accept       json
+// @Produce      json
+// @Param        namespace path string true "user or organization name"
+// @Param        req body types.SetStorageQuotaReq true "req"
+// @Success      200  {object}  types.Response{data=types.StorageQuota} "OK"
+// @Failure      400  {object}  types.APIBadRequest "Bad request"
+// @Failure      500  {object}  types.APIInternalServerError "Internal server error"
+// @Router       /storage_quotas/{namespace} [put]
+func (h *StorageQuotaHandler) Set(ctx *gin.Context) {
+	var req types.SetStorageQuotaReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "Bad request format", slog.Any("error", err))
+		httpbase.BadRequestWithExt(ctx, err)
+		return
+	}
+	req.CurrentUser = httpbase.GetCurrentUser(ctx)
+	req.Namespace = ctx.Param("namespace")
+
+	quota, err := h.storageQuota.SetQuota(ctx.Request.Context(), req)
+	if err != nil {
+		if errors.Is(err, errorx.ErrForbidden) {
+			slog.WarnContext(ctx.Request.Context(), "not allowed to set storage quota", slog.Any("error", err), slog.Any("req", req))
+			httpbase.ForbiddenError(ctx, err)
+			return
+		}
+		slog.ErrorContext(ctx.Request.Context(), "failed to set storage quota", slog.Any("error", err), slog.Any("req", req))
+		httpbase.ServerError(ctx, err)
+		return
+	}
+	httpbase.OK(ctx, quota)
+}
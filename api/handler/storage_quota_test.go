@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	mockcomponent "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/component"
+	"opencsg.com/csghub-server/builder/testutil"
+	"opencsg.com/csghub-server/common/types"
+)
+
+type StorageQuotaTester struct {
+	*testutil.GinTester
+	handler *StorageQuotaHandler
+	mocks   struct {
+		storageQuota *mockcomponent.MockStorageQuotaComponent
+	}
+}
+
+func NewStorageQuotaTester(t *testing.T) *StorageQuotaTester {
+	tester := &StorageQuotaTester{GinTester: testutil.NewGinTester()}
+	tester.mocks.storageQuota = mockcomponent.NewMockStorageQuotaComponent(t)
+
+	tester.handler = &StorageQuotaHandler{
+		storageQuota: tester.mocks.storageQuota,
+	}
+	tester.WithParam("namespace", "ns")
+	return tester
+}
+
+func (t *StorageQuotaTester) WithHandleFunc(fn func(h *StorageQuotaHandler) gin.HandlerFunc) *StorageQuotaTester {
+	t.Handler(fn(t.handler))
+	return t
+}
+
+func TestStorageQuotaHandler_Get(t *testing.T) {
+	tester := NewStorageQuotaTester(t).WithHandleFunc(func(h *StorageQuotaHandler) gin.HandlerFunc {
+		return h.Get
+	})
+	tester.WithUser()
+
+	quota := &types.StorageQuota{Namespace: "ns", QuotaBytes: 1000, UsedBytes: 100, LfsBytes: 100}
+	tester.mocks.storageQuota.EXPECT().GetQuota(tester.Ctx(), types.GetStorageQuotaReq{
+		CurrentUser: "u", Namespace: "ns",
+	}).Return(quota, nil)
+
+	tester.Execute()
+	tester.ResponseEq(t, 200, tester.OKText, quota)
+}
+
+func TestStorageQuotaHandler_Set(t *testing.T) {
+	tester := NewStorageQuotaTester(t).WithHandleFunc(func(h *StorageQuotaHandler) gin.HandlerFunc {
+		return h.Set
+	})
+	tester.WithUser()
+
+	quotaBytes := int64(2000)
+	quota := &types.StorageQuota{Namespace: "ns", QuotaBytes: 2000, IsOverridden: true}
+	tester.mocks.storageQuota.EXPECT().SetQuota(tester.Ctx(), types.SetStorageQuotaReq{
+		CurrentUser: "u", Namespace: "ns", QuotaBytes: &quotaBytes,
+	}).Return(quota, nil)
+
+	tester.WithBody(t, map[string]any{"quota_bytes": 2000}).Execute()
+	tester.ResponseEq(t, 200, tester.OKText, quota)
+}
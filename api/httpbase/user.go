@@ -15,6 +15,7 @@ const (
 	HeaderLanguageKey       = "Accept-Language"
 	AccessTokenNameCtxVar   = "accessTokenName"
 	IPctxVar                = "ip_address"
+	LfsDownloadOidsCtxVar   = "lfsDownloadOids"
 )
 
 type AuthType string
@@ -90,3 +91,12 @@ func SetIPAddress(ctx *gin.Context, ip string) {
 func GetIPAddress(ctx *gin.Context) string {
 	return ctx.GetString(IPctxVar)
 }
+
+// SetLfsDownloadOids saves the oids of the objects downloaded by the LFS batch request for the traffic events
+func SetLfsDownloadOids(ctx *gin.Context, oids []string) {
+	ctx.Set(LfsDownloadOidsCtxVar, oids)
+}
+
+func GetLfsDownloadOids(ctx *gin.Context) []string {
+	return ctx.GetStringSlice(LfsDownloadOidsCtxVar)
+}
@@ -0,0 +1,108 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"opencsg.com/csghub-server/api/httpbase"
+	"opencsg.com/csghub-server/common/types"
+	"opencsg.com/csghub-server/common/utils/common"
+	"opencsg.com/csghub-server/component"
+)
+
+// RepoTraffic publishes the traffic events of the successful file downloads, LFS batch downloads and clones
+// of the repositories
+func RepoTraffic(comp component.RepoTrafficComponent) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if c.Writer.Status() >= http.StatusBadRequest {
+			return
+		}
+		event := buildRepoTrafficEvent(c)
+		if event == nil {
+			return
+		}
+		if err := comp.PublishEvent(c.Request.Context(), event); err != nil {
+			slog.Debug("failed to publish repo traffic event", slog.Any("error", err))
+		}
+	}
+}
+
+func buildRepoTrafficEvent(c *gin.Context) *types.RepoTrafficEvent {
+	event := &types.RepoTrafficEvent{
+		Username:  httpbase.GetCurrentUser(c),
+		IPAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+		CreatedAt: time.Now(),
+	}
+	path := c.Request.URL.Path
+	switch {
+	case c.Request.Method == http.MethodGet && c.Param("file_path") != "" &&
+		(strings.Contains(path, "/resolve/") || strings.Contains(path, "/download/")):
+		event.Kind = types.RepoTrafficResolve
+		event.Path = strings.TrimPrefix(c.Param("file_path"), "/")
+	case c.Request.Method == http.MethodGet && c.Param("oid") != "" && strings.Contains(path, "/info/lfs/"):
+		event.Kind = types.RepoTrafficLFS
+		event.LfsOids = []string{c.Param("oid")}
+	case c.Request.Method == http.MethodPost && strings.HasSuffix(path, "/info/lfs/objects/batch"):
+		oids := httpbase.GetLfsDownloadOids(c)
+		if len(oids) == 0 {
+			return nil
+		}
+		event.Kind = types.RepoTrafficLFS
+		event.LfsOids = oids
+	case c.Request.Method == http.MethodPost && strings.HasSuffix(path, "/git-upload-pack"):
+		event.Kind = types.RepoTrafficClone
+	default:
+		return nil
+	}
+
+	event.RepoType = repoTrafficRepoType(c)
+	if event.RepoType == "" {
+		return nil
+	}
+	// the git routes set the repo path in the context
+	event.Namespace, event.Name = c.GetString("namespace"), c.GetString("name")
+	if event.Namespace == "" || event.Name == "" {
+		namespace, name, err := common.GetNamespaceAndNameFromContext(c)
+		if err != nil {
+			return nil
+		}
+		event.Namespace, event.Name = namespace, strings.TrimSuffix(name, ".git")
+	}
+	return event
+}
+
+// repoTrafficRepoType returns the repo type set by the git and the SDK routes, or the repo type in the route
+// path of the api routes like /api/v1/models/:namespace/:name/resolve/*file_path, the SDK routes without
+// the repo type are the model routes
+func repoTrafficRepoType(c *gin.Context) types.RepositoryType {
+	if v, ok := c.Get("repo_type"); ok {
+		switch repoType := v.(type) {
+		case types.RepositoryType:
+			return repoType
+		case string:
+			return types.RepositoryType(repoType)
+		}
+	}
+	segments := strings.Split(c.FullPath(), "/")
+	for i := 1; i < len(segments); i++ {
+		if segments[i] != ":namespace" {
+			continue
+		}
+		switch segments[i-1] {
+		case "hf", "ms", "csg":
+			return types.ModelRepo
+		}
+		repoType, err := common.RepoTypeFromString(segments[i-1])
+		if err != nil {
+			return ""
+		}
+		return repoType
+	}
+	return ""
+}
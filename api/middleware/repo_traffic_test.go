@@ -0,0 +1,128 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	mockcomponent "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/component"
+	"opencsg.com/csghub-server/api/httpbase"
+	"opencsg.com/csghub-server/common/types"
+)
+
+func TestRepoTraffic(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cases := []struct {
+		name    string
+		method  string
+		route   string
+		path    string
+		handler gin.HandlerFunc
+		want    *types.RepoTrafficEvent
+	}{
+		{
+			name:   "api resolve",
+			method: http.MethodGet,
+			route:  "/api/v1/models/:namespace/:name/resolve/*file_path",
+			path:   "/api/v1/models/ns/n/resolve/config.json",
+			want: &types.RepoTrafficEvent{
+				Kind: types.RepoTrafficResolve, RepoType: types.ModelRepo, Namespace: "ns", Name: "n", Path: "config.json",
+			},
+		},
+		{
+			name:   "sdk resolve",
+			method: http.MethodGet,
+			route:  "/hf/datasets/:namespace/:name/resolve/:branch/*file_path",
+			path:   "/hf/datasets/ns/n/resolve/main/data/train.csv",
+			handler: func(c *gin.Context) {
+				c.Set("repo_type", types.DatasetRepo)
+			},
+			want: &types.RepoTrafficEvent{
+				Kind: types.RepoTrafficResolve, RepoType: types.DatasetRepo, Namespace: "ns", Name: "n", Path: "data/train.csv",
+			},
+		},
+		{
+			name:   "clone",
+			method: http.MethodPost,
+			route:  "/:repo_type/:namespace/:name/git-upload-pack",
+			path:   "/models/ns/n.git/git-upload-pack",
+			handler: func(c *gin.Context) {
+				c.Set("repo_type", "model")
+				c.Set("namespace", "ns")
+				c.Set("name", "n")
+			},
+			want: &types.RepoTrafficEvent{
+				Kind: types.RepoTrafficClone, RepoType: types.ModelRepo, Namespace: "ns", Name: "n",
+			},
+		},
+		{
+			name:   "sdk lfs batch download",
+			method: http.MethodPost,
+			route:  "/hf/:namespace/:name/info/lfs/objects/batch",
+			path:   "/hf/ns/n.git/info/lfs/objects/batch",
+			handler: func(c *gin.Context) {
+				httpbase.SetLfsDownloadOids(c, []string{"oid1", "oid2"})
+			},
+			want: &types.RepoTrafficEvent{
+				Kind: types.RepoTrafficLFS, RepoType: types.ModelRepo, Namespace: "ns", Name: "n", LfsOids: []string{"oid1", "oid2"},
+			},
+		},
+		{
+			name:   "lfs batch upload",
+			method: http.MethodPost,
+			route:  "/hf/:namespace/:name/info/lfs/objects/batch",
+			path:   "/hf/ns/n.git/info/lfs/objects/batch",
+		},
+		{
+			name:   "failed resolve",
+			method: http.MethodGet,
+			route:  "/api/v1/models/:namespace/:name/resolve/*file_path",
+			path:   "/api/v1/models/ns/n/resolve/config.json",
+			handler: func(c *gin.Context) {
+				c.Status(http.StatusNotFound)
+			},
+		},
+		{
+			name:   "other route",
+			method: http.MethodGet,
+			route:  "/api/v1/models/:namespace/:name/blob/*file_path",
+			path:   "/api/v1/models/ns/n/blob/config.json",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			comp := mockcomponent.NewMockRepoTrafficComponent(t)
+			if c.want != nil {
+				comp.EXPECT().PublishEvent(mock.Anything, mock.Anything).RunAndReturn(
+					func(_ context.Context, event *types.RepoTrafficEvent) error {
+						require.NotZero(t, event.CreatedAt)
+						event.CreatedAt = c.want.CreatedAt
+						require.Equal(t, "u", event.Username)
+						event.Username = ""
+						event.IPAddress, event.UserAgent = "", ""
+						require.Equal(t, c.want, event)
+						return nil
+					})
+			}
+
+			engine := gin.New()
+			handlers := []gin.HandlerFunc{
+				func(ctx *gin.Context) { httpbase.SetCurrentUser(ctx, "u") },
+				RepoTraffic(comp),
+			}
+			if c.handler != nil {
+				handlers = append(handlers, c.handler)
+			}
+			engine.Handle(c.method, c.route, handlers...)
+
+			req := httptest.NewRequest(c.method, c.path, nil)
+			engine.ServeHTTP(httptest.NewRecorder(), req)
+		})
+	}
+}
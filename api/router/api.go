@@ -102,6 +102,10 @@ func NewRouter(config *config.Config, enableSwagger bool) (*gin.Engine, error) {
 	if err != nil {
 		return nil, fmt.Errorf("error creating activity log component: %w", err)
 	}
+	repoTrafficComp, err := component.NewRepoTrafficComponent(config, mqFactory)
+	if err != nil {
+		return nil, fmt.Errorf("error creating repo traffic component: %w", err)
+	}
 
 	gitHTTPHandler, err := handler.NewGitHTTPHandler(config)
 	if err != nil {
@@ -112,6 +116,7 @@ func NewRouter(config *config.Config, enableSwagger bool) (*gin.Engine, error) {
 	gitHTTP.Use(middleware.GitHTTPParamMiddleware())
 	gitHTTP.Use(middleware.GetCurrentUserFromHeader())
 	gitHTTP.Use(middleware.ActivityLog(config, activityLogComp))
+	gitHTTP.Use(middleware.RepoTraffic(repoTrafficComp))
 	{
 		gitHTTP.GET("/info/refs", gitHTTPHandler.InfoRefs)
 		gitHTTP.POST("/git-upload-pack", middleware.ContentEncoding(), gitHTTPHandler.GitUploadPack)
@@ -146,6 +151,7 @@ func NewRouter(config *config.Config, enableSwagger bool) (*gin.Engine, error) {
 
 	sdkGroup := r.Group("")
 	sdkGroup.Use(middleware.Authenticator(config))
+	sdkGroup.Use(middleware.RepoTraffic(repoTrafficComp))
 
 	if enableSwagger {
 		r.GET("/api/v1/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
@@ -194,6 +200,7 @@ func NewRouter(config *config.Config, enableSwagger bool) (*gin.Engine, error) {
 	r.Use(middleware.LocalizedErrorMiddleware())
 	r.Use(middleware.Authenticator(config))
 	r.Use(middleware.ActivityLog(config, activityLogComp))
+	r.Use(middleware.RepoTraffic(repoTrafficComp))
 	useAdvancedMiddleware(r, config)
 	err = createCustomValidator()
 	if err != nil {
@@ -235,8 +242,13 @@ func NewRouter(config *config.Config, enableSwagger bool) (*gin.Engine, error) {
 		return nil, fmt.Errorf("error creatring monitor handler: %v", err)
 	}
 
+	inferenceRolloutHandler, err := handler.NewInferenceRolloutHandler(config)
+	if err != nil {
+		return nil, fmt.Errorf("error creating inference rollout handler: %w", err)
+	}
+
 	// Model routes
-	createModelRoutes(config, apiGroup, middlewareCollection, modelHandler, repoCommonHandler, monitorHandler)
+	createModelRoutes(config, apiGroup, middlewareCollection, modelHandler, repoCommonHandler, monitorHandler, inferenceRolloutHandler)
 
 	// Dataset routes
 	createDatasetRoutes(config, apiGroup, middlewareCollection, dsHandler, repoCommonHandler)
@@ -307,6 +319,21 @@ func NewRouter(config *config.Config, enableSwagger bool) (*gin.Engine, error) {
 
 	createTokenRoutes(apiGroup, middlewareCollection, userProxyHandler)
 
+	storageQuotaHandler, err := handler.NewStorageQuotaHandler(config)
+	if err != nil {
+		return nil, fmt.Errorf("error creating storage quota handler:%w", err)
+	}
+	{
+		apiGroup.GET("/storage_quotas/:namespace", middlewareCollection.Auth.NeedLogin, storageQuotaHandler.Get)
+		apiGroup.PUT("/storage_quotas/:namespace", middlewareCollection.Auth.NeedAdmin, storageQuotaHandler.Set)
+	}
+
+	ociHandler, err := handler.NewOCIHandler(config)
+	if err != nil {
+		return nil, fmt.Errorf("error creating oci handler:%w", err)
+	}
+	createOCIRoutes(r, ociHandler)
+
 	sshKeyHandler, err := handler.NewSSHKeyHandler(config)
 	if err != nil {
 		return nil, fmt.Errorf("error creating user controller:%w", err)
@@ -467,9 +494,23 @@ func NewRouter(config *config.Config, enableSwagger bool) (*gin.Engine, error) {
 	if err != nil {
 		return nil, fmt.Errorf("error creating recomHandler,%w", err)
 	}
-	recomGroup := apiGroup.Group("/recom")
-	{
-		recomGroup.POST("opweight", middlewareCollection.License.Check, middlewareCollection.Auth.NeedAdmin, recomHandler.SetOpWeight)
+	createRecomRoutes(apiGroup, middlewareCollection, recomHandler)
+
+	followHandler, err := handler.NewFollowHandler(config)
+	if err != nil {
+		return nil, fmt.Errorf("error creating follow handler,%w", err)
+	}
+	createFollowRoutes(apiGroup, middlewareCollection, followHandler)
+
+	importHandler, err := handler.NewImportHandler(config)
+	if err != nil {
+		return nil, fmt.Errorf("error creating import handler,%w", err)
+	}
+	createImportRoutes(apiGroup, middlewareCollection, importHandler)
+
+	err = createRepoTrafficRoutes(apiGroup, middlewareCollection, repoTrafficComp)
+	if err != nil {
+		return nil, fmt.Errorf("error creating repo traffic routes:%w", err)
 	}
 
 	// telemetry
@@ -503,6 +544,7 @@ func NewRouter(config *config.Config, enableSwagger bool) (*gin.Engine, error) {
 		return nil, fmt.Errorf("error creating discussion handler:%w", err)
 	}
 	createDiscussionRoutes(apiGroup, middlewareCollection, discussionHandler)
+	createRepoAccessRoutes(apiGroup, middlewareCollection, repoCommonHandler)
 
 	// prompt
 	promptHandler, err := handler.NewPromptHandler(config)
@@ -580,6 +622,36 @@ func NewRouter(config *config.Config, enableSwagger bool) (*gin.Engine, error) {
 	}
 	createFinetuneRoutes(apiGroup, middlewareCollection, finetuneJobHandler)
 
+	logSearchHandler, err := handler.NewLogSearchHandler(config)
+	if err != nil {
+		return nil, fmt.Errorf("error creating log search handler: %w", err)
+	}
+	createLogSearchRoutes(apiGroup, middlewareCollection, logSearchHandler)
+
+	repoFileHandler, err := handler.NewRepoFileHandler(config)
+	if err != nil {
+		return nil, fmt.Errorf("error creating repo file handler: %w", err)
+	}
+	createRepoFileRoutes(apiGroup, repoFileHandler)
+
+	deployAlertHandler, err := handler.NewDeployAlertHandler(config)
+	if err != nil {
+		return nil, fmt.Errorf("error creating deploy alert handler: %w", err)
+	}
+	createDeployAlertRoutes(apiGroup, middlewareCollection, deployAlertHandler)
+
+	secretHandler, err := handler.NewSecretHandler(config)
+	if err != nil {
+		return nil, fmt.Errorf("error creating secret handler: %w", err)
+	}
+	createSecretRoutes(apiGroup, middlewareCollection, secretHandler)
+
+	credentialHandler, err := handler.NewCredentialHandler(config)
+	if err != nil {
+		return nil, fmt.Errorf("error creating credential handler: %w", err)
+	}
+	createCredentialRoutes(apiGroup, middlewareCollection, credentialHandler)
+
 	err = createForwardRoutes(apiGroup, config)
 	if err != nil {
 		return nil, fmt.Errorf("error creating forward routes:%w", err)
@@ -611,6 +683,135 @@ func createEvaluationRoutes(apiGroup *gin.RouterGroup, middlewareCollection midd
 	}
 }
 
+func createLogSearchRoutes(apiGroup *gin.RouterGroup, middlewareCollection middleware.MiddlewareCollection, logSearchHandler *handler.LogSearchHandler) {
+	deployLogGroup := apiGroup.Group("/deploys/:id/logs")
+	deployLogGroup.Use(middlewareCollection.Auth.NeedLogin)
+	{
+		deployLogGroup.GET("/search", logSearchHandler.SearchDeployLogs)
+		deployLogGroup.GET("/download", logSearchHandler.DownloadDeployLogs)
+	}
+	workflowLogGroup := apiGroup.Group("/workflows/:id/logs")
+	workflowLogGroup.Use(middlewareCollection.Auth.NeedLogin)
+	{
+		workflowLogGroup.GET("/search", logSearchHandler.SearchWorkflowLogs)
+		workflowLogGroup.GET("/download", logSearchHandler.DownloadWorkflowLogs)
+	}
+}
+
+func createRecomRoutes(apiGroup *gin.RouterGroup, middlewareCollection middleware.MiddlewareCollection, recomHandler *handler.RecomHandler) {
+	recomGroup := apiGroup.Group("/recom")
+	{
+		recomGroup.POST("opweight", middlewareCollection.License.Check, middlewareCollection.Auth.NeedAdmin, recomHandler.SetOpWeight)
+		recomGroup.GET("/feed", middlewareCollection.Auth.NeedLogin, recomHandler.Feed)
+		recomGroup.GET("/:repo_type/:namespace/:name/related", recomHandler.RelatedRepos)
+	}
+}
+
+func createImportRoutes(apiGroup *gin.RouterGroup, middlewareCollection middleware.MiddlewareCollection, importHandler handler.ImportHandler) {
+	importGroup := apiGroup.Group("/import")
+	importGroup.Use(middlewareCollection.Auth.NeedLogin)
+	{
+		importGroup.POST("", importHandler.Import)
+		importGroup.POST("/remote_repos", importHandler.GetRemoteRepos)
+		importGroup.POST("/gitlab_repos", importHandler.GetGitlabRepos)
+		importGroup.GET("/status", importHandler.ImportStatus)
+	}
+}
+
+func createFollowRoutes(apiGroup *gin.RouterGroup, middlewareCollection middleware.MiddlewareCollection, followHandler *handler.FollowHandler) {
+	followGroup := apiGroup.Group("/follows")
+	{
+		followGroup.POST("", middlewareCollection.Auth.NeedLogin, followHandler.Follow)
+		followGroup.DELETE("", middlewareCollection.Auth.NeedLogin, followHandler.Unfollow)
+		followGroup.GET("/stats", followHandler.Stats)
+		followGroup.GET("/followers", followHandler.Followers)
+		followGroup.GET("/following/:username", followHandler.Following)
+	}
+	feedGroup := apiGroup.Group("/feed")
+	feedGroup.Use(middlewareCollection.Auth.NeedLogin)
+	{
+		feedGroup.GET("", followHandler.Feed)
+		feedGroup.GET("/digest", followHandler.GetDigestSetting)
+		feedGroup.PUT("/digest", followHandler.UpdateDigestSetting)
+	}
+}
+
+func createRepoTrafficRoutes(apiGroup *gin.RouterGroup, middlewareCollection middleware.MiddlewareCollection, comp component.RepoTrafficComponent) error {
+	if err := comp.StartConsuming(); err != nil {
+		return fmt.Errorf("error starting repo traffic consumer: %w", err)
+	}
+
+	repoTrafficHandler := handler.NewRepoTrafficHandler(comp)
+	trafficGroup := apiGroup.Group("/traffic/:repo_type/:namespace/:name")
+	trafficGroup.Use(middlewareCollection.Auth.NeedLogin)
+	{
+		trafficGroup.GET("", repoTrafficHandler.Stats)
+		trafficGroup.GET("/files", repoTrafficHandler.TopFiles)
+		trafficGroup.GET("/regions", repoTrafficHandler.Regions)
+		trafficGroup.GET("/export", repoTrafficHandler.Export)
+	}
+	return nil
+}
+
+func createRepoFileRoutes(apiGroup *gin.RouterGroup, repoFileHandler *handler.RepoFileHandler) {
+	// anonymous users can search the files of the public repositories
+	apiGroup.GET("/repos/files/search", repoFileHandler.SearchFiles)
+}
+
+func createDeployAlertRoutes(apiGroup *gin.RouterGroup, middlewareCollection middleware.MiddlewareCollection, deployAlertHandler *handler.DeployAlertHandler) {
+	deployAlertGroup := apiGroup.Group("/deploys/:id")
+	deployAlertGroup.Use(middlewareCollection.Auth.NeedLogin)
+	{
+		deployAlertGroup.GET("/alert_rules", deployAlertHandler.ListRules)
+		deployAlertGroup.POST("/alert_rules", deployAlertHandler.CreateRule)
+		deployAlertGroup.PUT("/alert_rules/:rule_id", deployAlertHandler.UpdateRule)
+		deployAlertGroup.DELETE("/alert_rules/:rule_id", deployAlertHandler.DeleteRule)
+		deployAlertGroup.GET("/alert_events", deployAlertHandler.ListEvents)
+	}
+}
+
+func createSecretRoutes(apiGroup *gin.RouterGroup, middlewareCollection middleware.MiddlewareCollection, secretHandler *handler.SecretHandler) {
+	spaceSecretGroup := apiGroup.Group("/spaces/:namespace/:name/secrets")
+	spaceSecretGroup.Use(middleware.RepoType(types.SpaceRepo), middlewareCollection.Auth.NeedLogin)
+	{
+		spaceSecretGroup.GET("", secretHandler.ListSpaceSecrets)
+		spaceSecretGroup.PUT("/:key", secretHandler.SetSpaceSecret)
+		spaceSecretGroup.DELETE("/:key", secretHandler.DeleteSpaceSecret)
+	}
+
+	deploySecretGroup := apiGroup.Group("/deploys/:id/secrets")
+	deploySecretGroup.Use(middlewareCollection.Auth.NeedLogin)
+	{
+		deploySecretGroup.GET("", secretHandler.ListDeploySecrets)
+		deploySecretGroup.PUT("/:key", secretHandler.SetDeploySecret)
+		deploySecretGroup.DELETE("/:key", secretHandler.DeleteDeploySecret)
+	}
+}
+
+func createCredentialRoutes(apiGroup *gin.RouterGroup, middlewareCollection middleware.MiddlewareCollection, credentialHandler *handler.CredentialHandler) {
+	// the runtime routes skip the user authentication, they are authorized by the runtime credential token
+	runtimeGroup := apiGroup.Group("/agent/credentials/runtime")
+	{
+		runtimeGroup.GET("/:credential_name", credentialHandler.RuntimeGet)
+		runtimeGroup.POST("/refresh", credentialHandler.RuntimeRefresh)
+		runtimeGroup.POST("/session/revoke", credentialHandler.RuntimeRevokeSession)
+	}
+
+	credentialGroup := apiGroup.Group("/agent/credentials")
+	credentialGroup.Use(middlewareCollection.Auth.NeedLogin)
+	{
+		credentialGroup.GET("/providers", credentialHandler.ListProviders)
+		credentialGroup.POST("/verify", credentialHandler.Verify)
+		credentialGroup.POST("/grants", credentialHandler.CreateGrant)
+		credentialGroup.GET("", credentialHandler.List)
+		credentialGroup.POST("", credentialHandler.Create)
+		credentialGroup.GET("/:credential_name", credentialHandler.Get)
+		credentialGroup.PUT("/:credential_name", credentialHandler.Update)
+		credentialGroup.DELETE("/:credential_name", credentialHandler.Delete)
+		credentialGroup.POST("/:credential_name/rotate", credentialHandler.Rotate)
+	}
+}
+
 func createFinetuneRoutes(apiGroup *gin.RouterGroup, middlewareCollection middleware.MiddlewareCollection, finetuneJobHandler *handler.FinetuneHandler) {
 	ftGroup := apiGroup.Group("/finetunes")
 	ftGroup.Use(middlewareCollection.Auth.NeedLogin)
@@ -619,6 +820,9 @@ func createFinetuneRoutes(apiGroup *gin.RouterGroup, middlewareCollection middle
 		ftGroup.GET("/:id", finetuneJobHandler.GetFinetuneJob)
 		ftGroup.DELETE("/:id", finetuneJobHandler.DeleteFinetuneJob)
 		ftGroup.GET("/:id/logs", finetuneJobHandler.GetLogs)
+		ftGroup.GET("/:id/checkpoints", finetuneJobHandler.ListFinetuneCheckpoints)
+		ftGroup.GET("/:id/publish", finetuneJobHandler.GetFinetunePublication)
+		ftGroup.POST("/:id/publish", finetuneJobHandler.PublishFinetuneResult)
 	}
 }
 
@@ -627,7 +831,8 @@ func createModelRoutes(config *config.Config,
 	middlewareCollection middleware.MiddlewareCollection,
 	modelHandler *handler.ModelHandler,
 	repoCommonHandler *handler.RepoHandler,
-	monitorHandler *handler.MonitorHandler) {
+	monitorHandler *handler.MonitorHandler,
+	inferenceRolloutHandler *handler.InferenceRolloutHandler) {
 	// gin cache
 	memoryStore := persist.NewMemoryStore(2 * time.Minute)
 	// Models routes
@@ -658,6 +863,12 @@ func createModelRoutes(config *config.Config,
 		modelsGroup.GET("/:namespace/:name/all_files", cache.Cache(memoryStore, time.Minute*2, middleware.CacheRepoInfo()), repoCommonHandler.AllFiles)
 		modelsGroup.POST("/:namespace/:name/tags/:category", middlewareCollection.Auth.NeedPhoneVerified, repoCommonHandler.UpdateTags)
 		modelsGroup.GET("/:namespace/:name/last_commit", repoCommonHandler.LastCommit)
+		modelsGroup.GET("/:namespace/:name/card", repoCommonHandler.Card)
+		modelsGroup.GET("/:namespace/:name/releases", repoCommonHandler.ListReleases)
+		modelsGroup.POST("/:namespace/:name/releases", middlewareCollection.Auth.NeedLogin, repoCommonHandler.CreateRelease)
+		modelsGroup.GET("/:namespace/:name/releases/:release", repoCommonHandler.GetRelease)
+		modelsGroup.PUT("/:namespace/:name/releases/:release", middlewareCollection.Auth.NeedLogin, repoCommonHandler.UpdateRelease)
+		modelsGroup.DELETE("/:namespace/:name/releases/:release", middlewareCollection.Auth.NeedLogin, repoCommonHandler.DeleteRelease)
 		modelsGroup.GET("/:namespace/:name/commit/:commit_id", repoCommonHandler.CommitWithDiff)
 		modelsGroup.POST("/:namespace/:name/commit/:revision", middlewareCollection.Auth.NeedPhoneVerified, repoCommonHandler.CommitFiles)
 		modelsGroup.GET("/:namespace/:name/diff", repoCommonHandler.DiffBetweenTwoCommits)
@@ -679,6 +890,7 @@ func createModelRoutes(config *config.Config,
 		// 2. DownloadFile returns an object store url for lfs files, while SDKDownload redirects directly.
 		modelsGroup.GET("/:namespace/:name/download/*file_path", repoCommonHandler.DownloadFile)
 		modelsGroup.GET("/:namespace/:name/resolve/*file_path", repoCommonHandler.ResolveDownload)
+		modelsGroup.GET("/:namespace/:name/download_archive/refs/*ref", repoCommonHandler.DownloadArchive)
 		modelsGroup.POST("/:namespace/:name/raw/*file_path", middlewareCollection.Auth.NeedPhoneVerified, repoCommonHandler.CreateFile)
 		modelsGroup.PUT("/:namespace/:name/raw/*file_path", middlewareCollection.Auth.NeedLogin, repoCommonHandler.UpdateFile)
 		modelsGroup.DELETE("/:namespace/:name/raw/*file_path", middlewareCollection.Auth.NeedLogin, repoCommonHandler.DeleteFile)
@@ -719,6 +931,10 @@ func createModelRoutes(config *config.Config,
 		modelsDeployGroup.GET("/:namespace/:name/run/versions/:id", modelHandler.ListInferenceVersions)
 		modelsDeployGroup.POST("/:namespace/:name/run/versions/:id", modelHandler.CreateInferenceVersion)
 		modelsDeployGroup.DELETE("/:namespace/:name/run/versions/:id/:commit_id", modelHandler.DeleteInferenceVersion)
+		// progressive traffic rollout to an inference version
+		modelsDeployGroup.POST("/:namespace/:name/run/versions/:id/rollout", inferenceRolloutHandler.CreateRollout)
+		modelsDeployGroup.GET("/:namespace/:name/run/versions/:id/rollout", inferenceRolloutHandler.GetRollout)
+		modelsDeployGroup.POST("/:namespace/:name/run/versions/:id/rollout/cancel", inferenceRolloutHandler.CancelRollout)
 
 		// deploy model as finetune instance
 		modelsDeployGroup.POST("/:namespace/:name/finetune", modelHandler.FinetuneCreate)
@@ -809,6 +1025,12 @@ func createDatasetRoutes(
 		datasetsGroup.GET("/:namespace/:name/all_files", middleware.MustLogin(), cache.Cache(memoryStore, time.Minute*2, middleware.CacheRepoInfo()), repoCommonHandler.AllFiles)
 		datasetsGroup.POST("/:namespace/:name/tags/:category", middleware.MustLogin(), repoCommonHandler.UpdateTags)
 		datasetsGroup.GET("/:namespace/:name/last_commit", repoCommonHandler.LastCommit)
+		datasetsGroup.GET("/:namespace/:name/card", repoCommonHandler.Card)
+		datasetsGroup.GET("/:namespace/:name/releases", repoCommonHandler.ListReleases)
+		datasetsGroup.POST("/:namespace/:name/releases", middleware.MustLogin(), repoCommonHandler.CreateRelease)
+		datasetsGroup.GET("/:namespace/:name/releases/:release", repoCommonHandler.GetRelease)
+		datasetsGroup.PUT("/:namespace/:name/releases/:release", middleware.MustLogin(), repoCommonHandler.UpdateRelease)
+		datasetsGroup.DELETE("/:namespace/:name/releases/:release", middleware.MustLogin(), repoCommonHandler.DeleteRelease)
 		datasetsGroup.GET("/:namespace/:name/commit/:commit_id", middleware.MustLogin(), repoCommonHandler.CommitWithDiff)
 		datasetsGroup.POST("/:namespace/:name/commit/:revision", middlewareCollection.Auth.NeedPhoneVerified, repoCommonHandler.CommitFiles)
 		datasetsGroup.GET("/:namespace/:name/diff", middleware.MustLogin(), repoCommonHandler.DiffBetweenTwoCommits)
@@ -823,6 +1045,7 @@ func createDatasetRoutes(
 		datasetsGroup.GET("/:namespace/:name/blob/*file_path", repoCommonHandler.FileInfo)
 		datasetsGroup.GET("/:namespace/:name/download/*file_path", middleware.MustLogin(), repoCommonHandler.DownloadFile)
 		datasetsGroup.GET("/:namespace/:name/resolve/*file_path", middleware.MustLogin(), repoCommonHandler.ResolveDownload)
+		datasetsGroup.GET("/:namespace/:name/download_archive/refs/*ref", middleware.MustLogin(), repoCommonHandler.DownloadArchive)
 		datasetsGroup.PUT("/:namespace/:name/raw/*file_path", middleware.MustLogin(), repoCommonHandler.UpdateFile)
 		datasetsGroup.DELETE("/:namespace/:name/raw/*file_path", middleware.MustLogin(), repoCommonHandler.DeleteFile)
 		datasetsGroup.POST("/:namespace/:name/update_downloads", middleware.NeedAdmin(config), repoCommonHandler.UpdateDownloads)
@@ -971,6 +1194,7 @@ func createSpaceRoutes(config *config.Config,
 		spaces.GET("/:namespace/:name/blob/*file_path", repoCommonHandler.FileInfo)
 		spaces.GET("/:namespace/:name/download/*file_path", repoCommonHandler.DownloadFile)
 		spaces.GET("/:namespace/:name/resolve/*file_path", repoCommonHandler.ResolveDownload)
+		spaces.GET("/:namespace/:name/download_archive/refs/*ref", repoCommonHandler.DownloadArchive)
 		spaces.PUT("/:namespace/:name/raw/*file_path", middlewareCollection.Auth.NeedLogin, repoCommonHandler.UpdateFile)
 		spaces.DELETE("/:namespace/:name/raw/*file_path", middlewareCollection.Auth.NeedLogin, repoCommonHandler.DeleteFile)
 		spaces.POST("/:namespace/:name/update_downloads", middlewareCollection.Auth.NeedAdmin, repoCommonHandler.UpdateDownloads)
@@ -1189,7 +1413,9 @@ func createMappingRoutes(
 				hfModelAPIGroup.POST("/:namespace/:name/preupload/:revision", middleware.RepoMapping(types.ModelRepo), repoCommonHandler.PreuploadHF)
 				hfModelAPIGroup.POST("/:namespace/:name/commit/:revision", middleware.RepoMapping(types.ModelRepo), repoCommonHandler.CommitFilesHF)
 				hfModelAPIGroup.GET("/:namespace/:name/tree/:ref/*path_in_repo", middleware.RepoMapping(types.ModelRepo), modelHandler.SDKModelTree)
-
+				// compatible with HF repo listing and git refs api, used for sdk like this: huggingface_hub.list_models(search, author, filter)
+				hfModelAPIGroup.GET("", middleware.RepoType(types.ModelRepo), repoCommonHandler.ListReposHF)
+				createHFRepoRefRoutes(hfModelAPIGroup, middlewareCollection, repoCommonHandler, types.ModelRepo)
 			}
 			hfDSAPIGroup := hfAPIGroup.Group("/datasets")
 			{
@@ -1201,11 +1427,15 @@ func createMappingRoutes(
 				hfDSAPIGroup.GET("/:namespace/:name/resolve/:ref/.huggingface.yaml", middleware.RepoMapping(types.DatasetRepo), hfdsHandler.HandleHFYaml)
 				hfDSAPIGroup.POST("/:namespace/:name/preupload/:revision", middleware.RepoMapping(types.DatasetRepo), repoCommonHandler.PreuploadHF)
 				hfDSAPIGroup.POST("/:namespace/:name/commit/:revision", middleware.RepoMapping(types.DatasetRepo), repoCommonHandler.CommitFilesHF)
+				hfDSAPIGroup.GET("", middleware.RepoType(types.DatasetRepo), repoCommonHandler.ListReposHF)
+				createHFRepoRefRoutes(hfDSAPIGroup, middlewareCollection, repoCommonHandler, types.DatasetRepo)
 			}
 			hfSpaceAPIGroup := hfAPIGroup.Group("/spaces")
 			{
 				hfSpaceAPIGroup.GET("/:namespace/:name/revision/:ref", middleware.RepoMapping(types.SpaceRepo), repoCommonHandler.SDKListFiles)
 				hfSpaceAPIGroup.GET("/:namespace/:name", middleware.RepoMapping(types.SpaceRepo), repoCommonHandler.SDKListFiles)
+				hfSpaceAPIGroup.GET("", middleware.RepoType(types.SpaceRepo), repoCommonHandler.ListReposHF)
+				createHFRepoRefRoutes(hfSpaceAPIGroup, middlewareCollection, repoCommonHandler, types.SpaceRepo)
 			}
 			hfCodeAPIGroup := hfAPIGroup.Group("/codes")
 			{
@@ -1225,6 +1455,8 @@ func createMappingRoutes(
 			hfReposAPIGroup := hfAPIGroup.Group("/repos")
 			{
 				hfReposAPIGroup.POST("/create", middlewareCollection.Auth.NeedLogin, repoCommonHandler.CreateRepo)
+				hfReposAPIGroup.DELETE("/delete", middlewareCollection.Auth.NeedLogin, repoCommonHandler.DeleteRepoHF)
+				hfReposAPIGroup.POST("/move", middlewareCollection.Auth.NeedLogin, repoCommonHandler.MoveRepoHF)
 			}
 			hfAPIGroup.POST("/validate-yaml", middlewareCollection.Auth.NeedLogin, repoCommonHandler.ValidateYaml)
 		}
@@ -1232,6 +1464,35 @@ func createMappingRoutes(
 
 }
 
+// createHFRepoRefRoutes registers the HF compatible routes of git refs, commits and settings of a repo type.
+// Branch and tag names are wildcards because they may contain slashes
+func createHFRepoRefRoutes(
+	group *gin.RouterGroup,
+	middlewareCollection middleware.MiddlewareCollection,
+	repoCommonHandler *handler.RepoHandler,
+	repoType types.RepositoryType,
+) {
+	group.GET("/:namespace/:name/refs", middleware.RepoMapping(repoType), repoCommonHandler.RefsHF)
+	group.GET("/:namespace/:name/commits/*revision", middleware.RepoMapping(repoType), repoCommonHandler.CommitsHF)
+	group.POST("/:namespace/:name/branch/*branch_name", middlewareCollection.Auth.NeedLogin, middleware.RepoMapping(repoType), repoCommonHandler.CreateBranchHF)
+	group.DELETE("/:namespace/:name/branch/*branch_name", middlewareCollection.Auth.NeedLogin, middleware.RepoMapping(repoType), repoCommonHandler.DeleteBranchHF)
+	group.POST("/:namespace/:name/tag/*revision", middlewareCollection.Auth.NeedLogin, middleware.RepoMapping(repoType), repoCommonHandler.CreateTagHF)
+	group.DELETE("/:namespace/:name/tag/*tag", middlewareCollection.Auth.NeedLogin, middleware.RepoMapping(repoType), repoCommonHandler.DeleteTagHF)
+	group.PUT("/:namespace/:name/settings", middlewareCollection.Auth.NeedLogin, middleware.RepoMapping(repoType), repoCommonHandler.UpdateSettingsHF)
+}
+
+// createOCIRoutes serves the models with the OCI distribution api, the paths are fixed by the spec
+func createOCIRoutes(r *gin.Engine, ociHandler *handler.OCIHandler) {
+	ociGroup := r.Group("/v2")
+	{
+		ociGroup.GET("/", ociHandler.Ping)
+		ociGroup.GET("/:namespace/:name/manifests/:reference", ociHandler.GetManifest)
+		ociGroup.HEAD("/:namespace/:name/manifests/:reference", ociHandler.GetManifest)
+		ociGroup.GET("/:namespace/:name/blobs/:digest", ociHandler.GetBlob)
+		ociGroup.HEAD("/:namespace/:name/blobs/:digest", ociHandler.GetBlob)
+	}
+}
+
 func createMeteringRoutes(
 	apiGroup *gin.RouterGroup,
 	middlewareCollection middleware.MiddlewareCollection,
@@ -1257,6 +1518,27 @@ func createDiscussionRoutes(apiGroup *gin.RouterGroup, middlewareCollection midd
 	apiGroup.GET("/discussions/:id/comments", discussionHandler.ListDiscussionComments)
 	apiGroup.PUT("/discussions/:id/comments/:comment_id", middlewareCollection.Auth.NeedLogin, discussionHandler.UpdateComment)
 	apiGroup.DELETE("/discussions/:id/comments/:comment_id", middlewareCollection.Auth.NeedLogin, discussionHandler.DeleteComment)
+	apiGroup.PUT("/discussions/:id/status", middlewareCollection.Auth.NeedLogin, discussionHandler.UpdateDiscussionStatus)
+	apiGroup.PUT("/discussions/:id/pin", middlewareCollection.Auth.NeedLogin, discussionHandler.PinDiscussion)
+	apiGroup.POST("/discussions/:id/transfer", middlewareCollection.Auth.NeedLogin, discussionHandler.TransferDiscussion)
+	apiGroup.PUT("/discussions/:id/labels", middlewareCollection.Auth.NeedLogin, discussionHandler.SetDiscussionLabels)
+	apiGroup.PUT("/discussions/:id/comments/:comment_id/hidden", middlewareCollection.Auth.NeedLogin, discussionHandler.HideComment)
+	apiGroup.POST("/discussions/:id/comments/:comment_id/reactions", middlewareCollection.Auth.NeedLogin, discussionHandler.AddCommentReaction)
+	apiGroup.DELETE("/discussions/:id/comments/:comment_id/reactions/:emoji", middlewareCollection.Auth.NeedLogin, discussionHandler.RemoveCommentReaction)
+	apiGroup.GET("/:repo_type/:namespace/:name/discussion_labels", discussionHandler.ListDiscussionLabels)
+	apiGroup.POST("/:repo_type/:namespace/:name/discussion_labels", middlewareCollection.Auth.NeedLogin, discussionHandler.CreateDiscussionLabel)
+	apiGroup.PUT("/:repo_type/:namespace/:name/discussion_labels/:label_id", middlewareCollection.Auth.NeedLogin, discussionHandler.UpdateDiscussionLabel)
+	apiGroup.DELETE("/:repo_type/:namespace/:name/discussion_labels/:label_id", middlewareCollection.Auth.NeedLogin, discussionHandler.DeleteDiscussionLabel)
+}
+
+func createRepoAccessRoutes(apiGroup *gin.RouterGroup, middlewareCollection middleware.MiddlewareCollection, repoCommonHandler *handler.RepoHandler) {
+	apiGroup.GET("/:repo_type/:namespace/:name/access_policy", repoCommonHandler.GetAccessPolicy)
+	apiGroup.PUT("/:repo_type/:namespace/:name/access_policy", middlewareCollection.Auth.NeedLogin, repoCommonHandler.UpdateAccessPolicy)
+	apiGroup.GET("/:repo_type/:namespace/:name/access_request", middlewareCollection.Auth.NeedLogin, repoCommonHandler.GetAccessRequest)
+	apiGroup.POST("/:repo_type/:namespace/:name/access_request", middlewareCollection.Auth.NeedLogin, repoCommonHandler.CreateAccessRequest)
+	apiGroup.GET("/:repo_type/:namespace/:name/access_requests", middlewareCollection.Auth.NeedLogin, repoCommonHandler.ListAccessRequests)
+	apiGroup.GET("/:repo_type/:namespace/:name/access_requests/export", middlewareCollection.Auth.NeedLogin, repoCommonHandler.ExportAccessRequests)
+	apiGroup.PUT("/:repo_type/:namespace/:name/access_requests/:id", middlewareCollection.Auth.NeedLogin, repoCommonHandler.ReviewAccessRequest)
 }
 
 func createPromptRoutes(
@@ -1285,6 +1567,7 @@ func createPromptRoutes(
 		promptGrp.DELETE("/:namespace/:name", middlewareCollection.Auth.NeedLogin, promptHandler.Delete)
 
 		promptGrp.GET("/:namespace/:name/branches", promptHandler.Branches)
+		promptGrp.GET("/:namespace/:name/download_archive/refs/*ref", repoCommonHandler.DownloadArchive)
 		promptGrp.GET("/:namespace/:name/tags", promptHandler.Tags)
 		promptGrp.POST("/:namespace/:name/tags/:category", middlewareCollection.Auth.NeedLogin, promptHandler.UpdateTags)
 		promptGrp.POST("/:namespace/:name/update_downloads", middlewareCollection.Auth.NeedAdmin, promptHandler.UpdateDownloads)
@@ -0,0 +1,37 @@
+package router
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"opencsg.com/csghub-server/api/handler"
+	"opencsg.com/csghub-server/api/middleware"
+)
+
+func TestCreateCredentialRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	apiGroup := engine.Group("/api/v1")
+	mc := middleware.MiddlewareCollection{}
+	mc.Auth.NeedLogin = middleware.MustLogin()
+
+	require.NotPanics(t, func() {
+		createCredentialRoutes(apiGroup, mc, &handler.CredentialHandler{})
+	})
+
+	routes := engine.Routes()
+	requireRoute(t, routes, http.MethodGet, "/api/v1/agent/credentials/providers")
+	requireRoute(t, routes, http.MethodPost, "/api/v1/agent/credentials/verify")
+	requireRoute(t, routes, http.MethodPost, "/api/v1/agent/credentials/grants")
+	requireRoute(t, routes, http.MethodGet, "/api/v1/agent/credentials")
+	requireRoute(t, routes, http.MethodPost, "/api/v1/agent/credentials")
+	requireRoute(t, routes, http.MethodGet, "/api/v1/agent/credentials/:credential_name")
+	requireRoute(t, routes, http.MethodPut, "/api/v1/agent/credentials/:credential_name")
+	requireRoute(t, routes, http.MethodDelete, "/api/v1/agent/credentials/:credential_name")
+	requireRoute(t, routes, http.MethodPost, "/api/v1/agent/credentials/:credential_name/rotate")
+	requireRoute(t, routes, http.MethodGet, "/api/v1/agent/credentials/runtime/:credential_name")
+	requireRoute(t, routes, http.MethodPost, "/api/v1/agent/credentials/runtime/refresh")
+	requireRoute(t, routes, http.MethodPost, "/api/v1/agent/credentials/runtime/session/revoke")
+}
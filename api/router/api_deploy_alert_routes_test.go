@@ -0,0 +1,31 @@
+package router
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"opencsg.com/csghub-server/api/handler"
+	"opencsg.com/csghub-server/api/middleware"
+)
+
+func TestCreateDeployAlertRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	apiGroup := engine.Group("/api/v1")
+	mc := middleware.MiddlewareCollection{}
+	mc.Auth.NeedLogin = middleware.MustLogin()
+
+	require.NotPanics(t, func() {
+		createLogSearchRoutes(apiGroup, mc, &handler.LogSearchHandler{})
+		createDeployAlertRoutes(apiGroup, mc, &handler.DeployAlertHandler{})
+	})
+
+	routes := engine.Routes()
+	requireRoute(t, routes, http.MethodGet, "/api/v1/deploys/:id/alert_rules")
+	requireRoute(t, routes, http.MethodPost, "/api/v1/deploys/:id/alert_rules")
+	requireRoute(t, routes, http.MethodPut, "/api/v1/deploys/:id/alert_rules/:rule_id")
+	requireRoute(t, routes, http.MethodDelete, "/api/v1/deploys/:id/alert_rules/:rule_id")
+	requireRoute(t, routes, http.MethodGet, "/api/v1/deploys/:id/alert_events")
+}
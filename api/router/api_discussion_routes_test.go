@@ -0,0 +1,37 @@
+package router
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"opencsg.com/csghub-server/api/handler"
+	"opencsg.com/csghub-server/api/middleware"
+)
+
+func TestCreateDiscussionRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	apiGroup := engine.Group("/api/v1")
+	mc := middleware.MiddlewareCollection{}
+	mc.Auth.NeedLogin = middleware.MustLogin()
+	mc.Auth.NeedPhoneVerified = middleware.MustLogin()
+
+	require.NotPanics(t, func() {
+		createDiscussionRoutes(apiGroup, mc, &handler.DiscussionHandler{})
+	})
+
+	routes := engine.Routes()
+	requireRoute(t, routes, http.MethodPut, "/api/v1/discussions/:id/status")
+	requireRoute(t, routes, http.MethodPut, "/api/v1/discussions/:id/pin")
+	requireRoute(t, routes, http.MethodPost, "/api/v1/discussions/:id/transfer")
+	requireRoute(t, routes, http.MethodPut, "/api/v1/discussions/:id/labels")
+	requireRoute(t, routes, http.MethodPut, "/api/v1/discussions/:id/comments/:comment_id/hidden")
+	requireRoute(t, routes, http.MethodPost, "/api/v1/discussions/:id/comments/:comment_id/reactions")
+	requireRoute(t, routes, http.MethodDelete, "/api/v1/discussions/:id/comments/:comment_id/reactions/:emoji")
+	requireRoute(t, routes, http.MethodGet, "/api/v1/:repo_type/:namespace/:name/discussion_labels")
+	requireRoute(t, routes, http.MethodPost, "/api/v1/:repo_type/:namespace/:name/discussion_labels")
+	requireRoute(t, routes, http.MethodPut, "/api/v1/:repo_type/:namespace/:name/discussion_labels/:label_id")
+	requireRoute(t, routes, http.MethodDelete, "/api/v1/:repo_type/:namespace/:name/discussion_labels/:label_id")
+}
@@ -0,0 +1,33 @@
+package router
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"opencsg.com/csghub-server/api/handler"
+	"opencsg.com/csghub-server/api/middleware"
+)
+
+func TestCreateFollowRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	apiGroup := engine.Group("/api/v1")
+	mc := middleware.MiddlewareCollection{}
+	mc.Auth.NeedLogin = middleware.MustLogin()
+
+	require.NotPanics(t, func() {
+		createFollowRoutes(apiGroup, mc, &handler.FollowHandler{})
+	})
+
+	routes := engine.Routes()
+	requireRoute(t, routes, http.MethodPost, "/api/v1/follows")
+	requireRoute(t, routes, http.MethodDelete, "/api/v1/follows")
+	requireRoute(t, routes, http.MethodGet, "/api/v1/follows/stats")
+	requireRoute(t, routes, http.MethodGet, "/api/v1/follows/followers")
+	requireRoute(t, routes, http.MethodGet, "/api/v1/follows/following/:username")
+	requireRoute(t, routes, http.MethodGet, "/api/v1/feed")
+	requireRoute(t, routes, http.MethodGet, "/api/v1/feed/digest")
+	requireRoute(t, routes, http.MethodPut, "/api/v1/feed/digest")
+}
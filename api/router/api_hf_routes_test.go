@@ -0,0 +1,35 @@
+package router
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"opencsg.com/csghub-server/api/handler"
+	"opencsg.com/csghub-server/api/middleware"
+)
+
+func TestCreateMappingRoutes_HFRepoManagement(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+
+	createMappingRoutes(engine.Group(""), "/hf", middleware.MiddlewareCollection{}, &handler.HFDatasetHandler{},
+		&handler.RepoHandler{}, &handler.ModelHandler{}, &handler.UserHandler{}, &handler.GitHTTPHandler{})
+
+	routes := engine.Routes()
+	requireRoute(t, routes, http.MethodGet, "/hf/api/models")
+	requireRoute(t, routes, http.MethodGet, "/hf/api/datasets")
+	requireRoute(t, routes, http.MethodGet, "/hf/api/spaces")
+	for _, repoType := range []string{"models", "datasets", "spaces"} {
+		prefix := "/hf/api/" + repoType + "/:namespace/:name"
+		requireRoute(t, routes, http.MethodGet, prefix+"/refs")
+		requireRoute(t, routes, http.MethodGet, prefix+"/commits/*revision")
+		requireRoute(t, routes, http.MethodPost, prefix+"/branch/*branch_name")
+		requireRoute(t, routes, http.MethodDelete, prefix+"/branch/*branch_name")
+		requireRoute(t, routes, http.MethodPost, prefix+"/tag/*revision")
+		requireRoute(t, routes, http.MethodDelete, prefix+"/tag/*tag")
+		requireRoute(t, routes, http.MethodPut, prefix+"/settings")
+	}
+	requireRoute(t, routes, http.MethodDelete, "/hf/api/repos/delete")
+	requireRoute(t, routes, http.MethodPost, "/hf/api/repos/move")
+}
@@ -0,0 +1,35 @@
+package router
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"opencsg.com/csghub-server/api/middleware"
+)
+
+type fakeImportHandler struct{}
+
+func (fakeImportHandler) Import(*gin.Context)         {}
+func (fakeImportHandler) GetGitlabRepos(*gin.Context) {}
+func (fakeImportHandler) GetRemoteRepos(*gin.Context) {}
+func (fakeImportHandler) ImportStatus(*gin.Context)   {}
+
+func TestCreateImportRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	apiGroup := engine.Group("/api/v1")
+	mc := middleware.MiddlewareCollection{}
+	mc.Auth.NeedLogin = middleware.MustLogin()
+
+	require.NotPanics(t, func() {
+		createImportRoutes(apiGroup, mc, fakeImportHandler{})
+	})
+
+	routes := engine.Routes()
+	requireRoute(t, routes, http.MethodPost, "/api/v1/import")
+	requireRoute(t, routes, http.MethodPost, "/api/v1/import/remote_repos")
+	requireRoute(t, routes, http.MethodPost, "/api/v1/import/gitlab_repos")
+	requireRoute(t, routes, http.MethodGet, "/api/v1/import/status")
+}
@@ -0,0 +1,29 @@
+package router
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"opencsg.com/csghub-server/api/handler"
+	"opencsg.com/csghub-server/api/middleware"
+)
+
+func TestCreateLogSearchRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	apiGroup := engine.Group("/api/v1")
+	mc := middleware.MiddlewareCollection{}
+	mc.Auth.NeedLogin = middleware.MustLogin()
+
+	require.NotPanics(t, func() {
+		createLogSearchRoutes(apiGroup, mc, &handler.LogSearchHandler{})
+	})
+
+	routes := engine.Routes()
+	for _, kind := range []string{"deploys", "workflows"} {
+		requireRoute(t, routes, http.MethodGet, "/api/v1/"+kind+"/:id/logs/search")
+		requireRoute(t, routes, http.MethodGet, "/api/v1/"+kind+"/:id/logs/download")
+	}
+}
@@ -0,0 +1,29 @@
+package router
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"opencsg.com/csghub-server/api/handler"
+)
+
+func TestCreateOCIRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	// the git http routes share the root path with the oci routes
+	engine.GET("/:repo_type/:namespace/:name/info/refs", func(ctx *gin.Context) {})
+
+	require.NotPanics(t, func() {
+		createOCIRoutes(engine, &handler.OCIHandler{})
+	})
+
+	routes := engine.Routes()
+	requireRoute(t, routes, http.MethodGet, "/v2/")
+	for _, method := range []string{http.MethodGet, http.MethodHead} {
+		requireRoute(t, routes, method, "/v2/:namespace/:name/manifests/:reference")
+		requireRoute(t, routes, method, "/v2/:namespace/:name/blobs/:digest")
+	}
+	assertNoRoute(t, routes, http.MethodPut, "/v2/:namespace/:name/manifests/:reference")
+}
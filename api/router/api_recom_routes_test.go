@@ -0,0 +1,30 @@
+package router
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"opencsg.com/csghub-server/api/handler"
+	"opencsg.com/csghub-server/api/middleware"
+)
+
+func TestCreateRecomRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	apiGroup := engine.Group("/api/v1")
+	mc := middleware.MiddlewareCollection{}
+	mc.Auth.NeedLogin = middleware.MustLogin()
+	mc.Auth.NeedAdmin = middleware.MustLogin()
+	mc.License.Check = func(*gin.Context) {}
+
+	require.NotPanics(t, func() {
+		createRecomRoutes(apiGroup, mc, &handler.RecomHandler{})
+	})
+
+	routes := engine.Routes()
+	requireRoute(t, routes, http.MethodPost, "/api/v1/recom/opweight")
+	requireRoute(t, routes, http.MethodGet, "/api/v1/recom/feed")
+	requireRoute(t, routes, http.MethodGet, "/api/v1/recom/:repo_type/:namespace/:name/related")
+}
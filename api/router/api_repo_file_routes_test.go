@@ -0,0 +1,23 @@
+package router
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"opencsg.com/csghub-server/api/handler"
+)
+
+func TestCreateRepoFileRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	apiGroup := engine.Group("/api/v1")
+	apiGroup.POST("/repos/extra", func(*gin.Context) {})
+
+	require.NotPanics(t, func() {
+		createRepoFileRoutes(apiGroup, &handler.RepoFileHandler{})
+	})
+
+	requireRoute(t, engine.Routes(), http.MethodGet, "/api/v1/repos/files/search")
+}
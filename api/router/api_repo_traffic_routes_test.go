@@ -0,0 +1,29 @@
+package router
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	mockcomponent "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/component"
+	"opencsg.com/csghub-server/api/middleware"
+)
+
+func TestCreateRepoTrafficRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	apiGroup := engine.Group("/api/v1")
+	mc := middleware.MiddlewareCollection{}
+	mc.Auth.NeedLogin = middleware.MustLogin()
+
+	comp := mockcomponent.NewMockRepoTrafficComponent(t)
+	comp.EXPECT().StartConsuming().Return(nil)
+	require.NoError(t, createRepoTrafficRoutes(apiGroup, mc, comp))
+
+	routes := engine.Routes()
+	requireRoute(t, routes, http.MethodGet, "/api/v1/traffic/:repo_type/:namespace/:name")
+	requireRoute(t, routes, http.MethodGet, "/api/v1/traffic/:repo_type/:namespace/:name/files")
+	requireRoute(t, routes, http.MethodGet, "/api/v1/traffic/:repo_type/:namespace/:name/regions")
+	requireRoute(t, routes, http.MethodGet, "/api/v1/traffic/:repo_type/:namespace/:name/export")
+}
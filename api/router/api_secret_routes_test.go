@@ -0,0 +1,32 @@
+package router
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"opencsg.com/csghub-server/api/handler"
+	"opencsg.com/csghub-server/api/middleware"
+)
+
+func TestCreateSecretRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	apiGroup := engine.Group("/api/v1")
+	mc := middleware.MiddlewareCollection{}
+	mc.Auth.NeedLogin = middleware.MustLogin()
+
+	require.NotPanics(t, func() {
+		createDeployAlertRoutes(apiGroup, mc, &handler.DeployAlertHandler{})
+		createSecretRoutes(apiGroup, mc, &handler.SecretHandler{})
+	})
+
+	routes := engine.Routes()
+	requireRoute(t, routes, http.MethodGet, "/api/v1/spaces/:namespace/:name/secrets")
+	requireRoute(t, routes, http.MethodPut, "/api/v1/spaces/:namespace/:name/secrets/:key")
+	requireRoute(t, routes, http.MethodDelete, "/api/v1/spaces/:namespace/:name/secrets/:key")
+	requireRoute(t, routes, http.MethodGet, "/api/v1/deploys/:id/secrets")
+	requireRoute(t, routes, http.MethodPut, "/api/v1/deploys/:id/secrets/:key")
+	requireRoute(t, routes, http.MethodDelete, "/api/v1/deploys/:id/secrets/:key")
+}
@@ -31,6 +31,9 @@ type Activities struct {
 	repoComponent          component.RepoComponent
 	industryTag            component.IndustryTagComponent
 	asyncGenerationService aigatewaytask.AsyncGenerationService
+	inferenceRollout       component.InferenceRolloutComponent
+	lfsGC                  component.LfsGCComponent
+	deployAlert            component.DeployAlertComponent
 	stores                 stores
 
 	// Deploy reconcile
@@ -49,6 +52,9 @@ func NewActivities(
 	repoComponent component.RepoComponent,
 	industryTag component.IndustryTagComponent,
 	asyncGenerationService aigatewaytask.AsyncGenerationService,
+	inferenceRollout component.InferenceRolloutComponent,
+	lfsGC component.LfsGCComponent,
+	deployAlert component.DeployAlertComponent,
 ) *Activities {
 	stores := stores{
 		syncClientSetting: syncClientSetting,
@@ -67,6 +73,9 @@ func NewActivities(
 		repoComponent:          repoComponent,
 		industryTag:            industryTag,
 		asyncGenerationService: asyncGenerationService,
+		inferenceRollout:       inferenceRollout,
+		lfsGC:                  lfsGC,
+		deployAlert:            deployAlert,
 		deployer:               newDeployerForReconcile(cfg),
 		deployConfig:           common.BuildDeployConfig(cfg),
 	}
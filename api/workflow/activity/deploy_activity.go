@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
 	"net/url"
 	"strconv"
 	"strings"
@@ -14,10 +15,12 @@ import (
 
 	"go.temporal.io/sdk/activity"
 	"go.temporal.io/sdk/log"
+	"opencsg.com/csghub-server/builder/credential"
 	"opencsg.com/csghub-server/builder/deploy/common"
 	"opencsg.com/csghub-server/builder/deploy/imagebuilder"
 	"opencsg.com/csghub-server/builder/deploy/imagerunner"
 	"opencsg.com/csghub-server/builder/git/gitserver"
+	"opencsg.com/csghub-server/builder/secret"
 	"opencsg.com/csghub-server/builder/store/database"
 	"opencsg.com/csghub-server/common/errorx"
 	"opencsg.com/csghub-server/common/types"
@@ -54,6 +57,9 @@ type DeployActivity struct {
 	urs database.UserResourcesStore
 	mds database.MetadataStore
 	cls database.ClusterInfoStore
+	nss database.NamespaceStore
+	sm  secret.Manager
+	cb  credential.Broker
 }
 
 func NewDeployActivity(
@@ -70,6 +76,9 @@ func NewDeployActivity(
 	urs database.UserResourcesStore,
 	mds database.MetadataStore,
 	cls database.ClusterInfoStore,
+	nss database.NamespaceStore,
+	sm secret.Manager,
+	cb credential.Broker,
 ) *DeployActivity {
 	return &DeployActivity{
 		cfg: cfg,
@@ -85,6 +94,9 @@ func NewDeployActivity(
 		urs: urs,
 		mds: mds,
 		cls: cls,
+		nss: nss,
+		sm:  sm,
+		cb:  cb,
 	}
 }
 
@@ -606,6 +618,10 @@ func (a *DeployActivity) createDeployRequest(ctx context.Context, task *database
 	if err != nil {
 		return nil, fmt.Errorf("failed to make deploy env for deploy id %d task id %d error: %w", deployInfo.ID, task.ID, err)
 	}
+	secrets, err := a.deploySecrets(ctx, deployInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get secrets for deploy id %d task id %d error: %w", deployInfo.ID, task.ID, err)
+	}
 	targetID := deployInfo.SpaceID
 
 	if deployInfo.SpaceID == 0 && deployInfo.ModelID > 0 {
@@ -636,6 +652,7 @@ func (a *DeployActivity) createDeployRequest(ctx context.Context, task *database
 		Annotation:    annotationMap,
 		Hardware:      hardware,
 		Env:           envMap,
+		Secrets:       secrets,
 		GitPath:       deployInfo.GitPath,
 		GitRef:        deployInfo.GitBranch,
 		ImageID:       deployInfo.ImageID,
@@ -684,6 +701,48 @@ func (a *DeployActivity) stopBuild(buildTask *database.DeployTask, repoInfo comm
 	}
 }
 
+// deploySecrets returns the credentials of the owner namespace granted to the deploy and the secrets of the
+// space of the deploy, overridden by the secrets of the deploy itself
+func (a *DeployActivity) deploySecrets(ctx context.Context, deployInfo *database.Deploy) (types.SecretValues, error) {
+	secrets := types.SecretValues{}
+	if len(deployInfo.CredentialNames) > 0 {
+		if deployInfo.OwnerNamespace == "" {
+			return nil, fmt.Errorf("deploy %d has no owner namespace to inject credentials from", deployInfo.ID)
+		}
+		ns, err := a.nss.FindByPath(ctx, deployInfo.OwnerNamespace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find owner namespace %s, error: %w", deployInfo.OwnerNamespace, err)
+		}
+		creds, err := a.cb.Inject(ctx, credential.GrantRequest{
+			NamespaceUUID:   ns.UUID,
+			TaskID:          fmt.Sprintf("deploy-%d", deployInfo.ID),
+			AgentID:         "deployer",
+			CredentialNames: deployInfo.CredentialNames,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to inject credentials, error: %w", err)
+		}
+		for _, cred := range creds {
+			for field, value := range cred.Credential {
+				secrets[credential.EnvName(cred.CredentialName, field)] = value
+			}
+		}
+	}
+	if deployInfo.SpaceID > 0 {
+		values, err := a.sm.Values(ctx, types.SecretOwnerSpace, deployInfo.SpaceID)
+		if err != nil {
+			return nil, err
+		}
+		maps.Copy(secrets, values)
+	}
+	values, err := a.sm.Values(ctx, types.SecretOwnerDeploy, deployInfo.ID)
+	if err != nil {
+		return nil, err
+	}
+	maps.Copy(secrets, values)
+	return secrets, nil
+}
+
 // makeDeployEnv
 func (a *DeployActivity) makeDeployEnv(ctx context.Context, hardware types.HardWare, accessToken *database.AccessToken, deployInfo *database.Deploy, engineArgsTemplates []types.EngineArg, toolCallParsers map[string]string, repoInfo common.RepoInfo) (map[string]string, error) {
 	logger := a.getLogger(ctx)
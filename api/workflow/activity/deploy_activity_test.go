@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"opencsg.com/csghub-server/builder/credential"
 	"opencsg.com/csghub-server/builder/deploy/common"
 	"opencsg.com/csghub-server/builder/git/gitserver"
 	"opencsg.com/csghub-server/builder/store/database"
@@ -18,9 +19,11 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	mockcredential "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/builder/credential"
 	mockbuilder "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/builder/deploy/imagebuilder"
 	mockrunner "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/builder/deploy/imagerunner"
 	mock_git "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/builder/git/gitserver"
+	mocksecret "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/builder/secret"
 	mockdb "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/builder/store/database"
 	mockReporter "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/component/reporter"
 )
@@ -43,6 +46,9 @@ type testEnv struct {
 	mockConfig            *config.Config
 	mockDeployCfg         common.DeployConfig
 	mockClusterStore      *mockdb.MockClusterInfoStore
+	mockNamespaceStore    *mockdb.MockNamespaceStore
+	mockSecretManager     *mocksecret.MockManager
+	mockCredentialBroker  *mockcredential.MockBroker
 }
 
 func setupTest(t *testing.T) *testEnv {
@@ -63,6 +69,9 @@ func setupTest(t *testing.T) *testEnv {
 	mockConfig := &config.Config{}
 	mockDeployCfg := common.BuildDeployConfig(mockConfig)
 	mockClusterStore := mockdb.NewMockClusterInfoStore(t)
+	mockNamespaceStore := mockdb.NewMockNamespaceStore(t)
+	mockSecretManager := mocksecret.NewMockManager(t)
+	mockCredentialBroker := mockcredential.NewMockBroker(t)
 
 	// Create activities instance
 	activities := &DeployActivity{
@@ -78,6 +87,9 @@ func setupTest(t *testing.T) *testEnv {
 		rfs: mockRuntimeFrameworks,
 		urs: mockUrsStore,
 		cls: mockClusterStore,
+		nss: mockNamespaceStore,
+		sm:  mockSecretManager,
+		cb:  mockCredentialBroker,
 	}
 
 	return &testEnv{
@@ -96,6 +108,9 @@ func setupTest(t *testing.T) *testEnv {
 		mockConfig:            mockConfig,
 		mockDeployCfg:         mockDeployCfg,
 		mockClusterStore:      mockClusterStore,
+		mockNamespaceStore:    mockNamespaceStore,
+		mockSecretManager:     mockSecretManager,
+		mockCredentialBroker:  mockCredentialBroker,
 	}
 }
 
@@ -138,6 +153,25 @@ func TestActivities_createBuildRequest(t *testing.T) {
 	require.Equal(t, r.LastCommitID, "id")
 }
 
+func TestActivities_deploySecrets(t *testing.T) {
+	tester := setupTest(t)
+
+	// the credentials belong to the owner namespace of the deploy, not the user who created it
+	deploy := &database.Deploy{ID: 3, UserUUID: "user-uuid", OwnerNamespace: "org", CredentialNames: []string{"hf-prod"}}
+	tester.mockNamespaceStore.EXPECT().FindByPath(tester.ctx, "org").Return(database.Namespace{Path: "org", UUID: "org-uuid"}, nil)
+	tester.mockCredentialBroker.EXPECT().Inject(tester.ctx, credential.GrantRequest{
+		NamespaceUUID: "org-uuid", TaskID: "deploy-3", AgentID: "deployer", CredentialNames: []string{"hf-prod"},
+	}).Return([]types.RuntimeCredentialResponse{
+		{CredentialName: "hf-prod", Credential: map[string]string{"token": "hf_abc"}},
+	}, nil)
+	tester.mockSecretManager.EXPECT().Values(tester.ctx, types.SecretOwnerDeploy, int64(3)).
+		Return(map[string]string{"OTHER": "x"}, nil)
+
+	secrets, err := tester.activities.deploySecrets(tester.ctx, deploy)
+	require.NoError(t, err)
+	require.Equal(t, types.SecretValues{"HF_PROD_TOKEN": "hf_abc", "OTHER": "x"}, secrets)
+}
+
 // TestActivities_handleDeployError tests the handleDeployError method
 func TestActivities_handleDeployError(t *testing.T) {
 	tester := setupTest(t)
@@ -556,10 +590,20 @@ func TestDeploy(t *testing.T) {
 		Sdk:          "gradio",
 		RepositoryID: deploy.Repository.ID,
 	}, nil)
+	tester.mockSecretManager.EXPECT().Values(mock.Anything, types.SecretOwnerSpace, int64(1)).Return(map[string]string{
+		"TOKEN": "space", "API_KEY": "space",
+	}, nil)
+	tester.mockSecretManager.EXPECT().Values(mock.Anything, types.SecretOwnerDeploy, int64(1)).Return(map[string]string{
+		"TOKEN": "deploy",
+	}, nil)
 	tester.mockImageRunner.EXPECT().Run(mock.Anything, mock.MatchedBy(func(req *types.RunRequest) bool {
 		if req.DeployExtend.NodeAffinity == nil || len(req.DeployExtend.Tolerations) == 0 {
 			return false
 		}
+		// the secrets are passed apart from the plain env
+		if req.Secrets["TOKEN"] != "deploy" || req.Secrets["API_KEY"] != "space" || req.Env["TOKEN"] != "" {
+			return false
+		}
 		return req.DeployExtend.Tolerations[0].Key == "foo"
 	})).Return(&types.RunResponse{
 		DeployID: 0,
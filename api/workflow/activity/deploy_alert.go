@@ -0,0 +1,17 @@
+package activity
+
+import (
+	"context"
+
+	"opencsg.com/csghub-server/common/types"
+)
+
+func (a *Activities) EvaluateDeployAlerts(ctx context.Context) (*types.DeployAlertEvaluation, error) {
+	result, err := a.deployAlert.EvaluateRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	a.getLogger(ctx).Info("deploy alerts evaluated", "evaluated", result.Evaluated,
+		"fired", result.Fired, "resolved", result.Resolved, "failed", result.Failed, "skipped", result.Skipped)
+	return result, nil
+}
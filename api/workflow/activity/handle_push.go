@@ -60,6 +60,24 @@ func (a *Activities) SyncRepositoryPackage(ctx context.Context, req *types.Gitea
 	return a.callback.SyncRepositoryPackage(ctx, req)
 }
 
+func (a *Activities) RefreshSearchDocument(ctx context.Context, req *types.GiteaCallbackPushReq) error {
+	logger := activity.GetLogger(ctx)
+	logger.Info("[git_callback] refresh search document start", slog.Any("req", req))
+	return a.callback.RefreshSearchDocument(ctx, req)
+}
+
+func (a *Activities) RefreshRepoCard(ctx context.Context, req *types.GiteaCallbackPushReq) error {
+	logger := activity.GetLogger(ctx)
+	logger.Info("[git_callback] refresh repo card start", slog.Any("req", req))
+	return a.callback.RefreshRepoCard(ctx, req)
+}
+
+func (a *Activities) RecordFeedEvent(ctx context.Context, req *types.GiteaCallbackPushReq) error {
+	logger := activity.GetLogger(ctx)
+	logger.Info("[git_callback] record feed event start", slog.Any("req", req))
+	return a.callback.RecordFeedEvent(ctx, req)
+}
+
 func (a *Activities) SensitiveCheck(ctx context.Context, req *types.GiteaCallbackPushReq) error {
 	logger := activity.GetLogger(ctx)
 	logger.Info("[git_callback] sensitive check start", slog.Any("req", req))
@@ -0,0 +1,19 @@
+package activity
+
+import (
+	"context"
+
+	"opencsg.com/csghub-server/common/types"
+)
+
+func (a *Activities) ShiftInferenceRolloutTraffic(ctx context.Context, rolloutID int64, step int) error {
+	return a.inferenceRollout.ShiftRolloutTraffic(ctx, rolloutID, step)
+}
+
+func (a *Activities) CheckInferenceRolloutHealth(ctx context.Context, rolloutID int64) (*types.InferenceRolloutHealth, error) {
+	return a.inferenceRollout.CheckRolloutHealth(ctx, rolloutID)
+}
+
+func (a *Activities) FinishInferenceRollout(ctx context.Context, rolloutID int64, status types.InferenceRolloutStatus, reason string) error {
+	return a.inferenceRollout.FinishRollout(ctx, rolloutID, status, reason)
+}
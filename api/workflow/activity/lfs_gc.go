@@ -0,0 +1,32 @@
+package activity
+
+import (
+	"context"
+
+	"opencsg.com/csghub-server/common/types"
+)
+
+func (a *Activities) LfsGC(ctx context.Context) (*types.LfsGCReport, error) {
+	report, err := a.lfsGC.RunGC(ctx, types.LfsGCReq{})
+	if err != nil {
+		return nil, err
+	}
+	a.getLogger(ctx).Info("lfs gc finished", "scanned_objects", report.ScannedObjects,
+		"newly_marked", report.NewlyMarked, "deleted_objects", len(report.DeletedObjects),
+		"deleted_bytes", report.DeletedBytes, "failed_repos", len(report.FailedRepos))
+	// the report could be too large to be kept in the workflow history
+	return &types.LfsGCReport{
+		StartedAt:           report.StartedAt,
+		FinishedAt:          report.FinishedAt,
+		ScannedRepos:        report.ScannedRepos,
+		ReferencedObjects:   report.ReferencedObjects,
+		ScannedObjects:      report.ScannedObjects,
+		ScannedBytes:        report.ScannedBytes,
+		UnreferencedObjects: report.UnreferencedObjects,
+		UnreferencedBytes:   report.UnreferencedBytes,
+		NewlyMarked:         report.NewlyMarked,
+		Unmarked:            report.Unmarked,
+		DeletedBytes:        report.DeletedBytes,
+		FailedDeletions:     report.FailedDeletions,
+	}, nil
+}
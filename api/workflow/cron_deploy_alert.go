@@ -0,0 +1,34 @@
+package workflow
+
+import (
+	"time"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+	"opencsg.com/csghub-server/common/types"
+)
+
+func DeployAlertWorkflow(ctx workflow.Context) error {
+	logger := workflow.GetLogger(ctx)
+	logger.Info("deploy alert workflow started")
+
+	// the rules are evaluated again by the next scheduled run, so a failed run is not retried
+	retryPolicy := &temporal.RetryPolicy{
+		MaximumAttempts: 1,
+	}
+
+	options := workflow.ActivityOptions{
+		StartToCloseTimeout: time.Minute * 10,
+		RetryPolicy:         retryPolicy,
+	}
+
+	actCtx := workflow.WithActivityOptions(ctx, options)
+	var result types.DeployAlertEvaluation
+	err := workflow.ExecuteActivity(actCtx, activities.EvaluateDeployAlerts).Get(ctx, &result)
+	if err != nil {
+		logger.Error("failed to evaluate deploy alerts", "error", err)
+		return err
+	}
+	logger.Info("deploy alert workflow completed", "fired", result.Fired, "resolved", result.Resolved)
+	return nil
+}
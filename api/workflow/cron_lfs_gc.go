@@ -0,0 +1,34 @@
+package workflow
+
+import (
+	"time"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+	"opencsg.com/csghub-server/common/types"
+)
+
+func LfsGCWorkflow(ctx workflow.Context) error {
+	logger := workflow.GetLogger(ctx)
+	logger.Info("lfs gc workflow started")
+
+	// objects marked by an interrupted run are picked up by the next scheduled one
+	retryPolicy := &temporal.RetryPolicy{
+		MaximumAttempts: 1,
+	}
+
+	options := workflow.ActivityOptions{
+		StartToCloseTimeout: time.Hour * 12,
+		RetryPolicy:         retryPolicy,
+	}
+
+	actCtx := workflow.WithActivityOptions(ctx, options)
+	var report types.LfsGCReport
+	err := workflow.ExecuteActivity(actCtx, activities.LfsGC).Get(ctx, &report)
+	if err != nil {
+		logger.Error("failed to run lfs gc", "error", err)
+		return err
+	}
+	logger.Info("lfs gc workflow completed", "deleted_bytes", report.DeletedBytes, "failed_deletions", report.FailedDeletions)
+	return nil
+}
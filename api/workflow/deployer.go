@@ -17,10 +17,12 @@ import (
 	sdkTemporal "go.temporal.io/sdk/temporal"
 
 	"opencsg.com/csghub-server/api/workflow/activity"
+	"opencsg.com/csghub-server/builder/credential"
 	"opencsg.com/csghub-server/builder/deploy/common"
 	"opencsg.com/csghub-server/builder/deploy/imagebuilder"
 	"opencsg.com/csghub-server/builder/deploy/imagerunner"
 	"opencsg.com/csghub-server/builder/git/gitserver"
+	"opencsg.com/csghub-server/builder/secret"
 	"opencsg.com/csghub-server/builder/store/database"
 	"opencsg.com/csghub-server/builder/temporal"
 	"opencsg.com/csghub-server/common/config"
@@ -61,6 +63,9 @@ func StartDeployWorker(
 	urs database.UserResourcesStore,
 	mds database.MetadataStore,
 	cls database.ClusterInfoStore,
+	nss database.NamespaceStore,
+	sm secret.Manager,
+	cb credential.Broker,
 ) error {
 	w := temporalClient.NewWorker(DeployWorkflowQueue, worker.Options{
 		MaxConcurrentActivityExecutionSize:      cfg.Temporal.MaxConcurrentActivityExecutionSize,
@@ -68,7 +73,7 @@ func StartDeployWorker(
 		MaxConcurrentLocalActivityExecutionSize: cfg.Temporal.MaxConcurrentLocalActivityExecutionSize,
 	})
 	dcfg := common.BuildDeployConfig(cfg)
-	act := activity.NewDeployActivity(dcfg, lr, ib, ir, gs, ds, ts, ss, ms, rfs, urs, mds, cls)
+	act := activity.NewDeployActivity(dcfg, lr, ib, ir, gs, ds, ts, ss, ms, rfs, urs, mds, cls, nss, sm, cb)
 
 	w.RegisterActivity(act)
 	w.RegisterWorkflow(DeployWorkflow)
@@ -20,9 +20,11 @@ import (
 	"opencsg.com/csghub-server/common/types"
 
 	mockclient "opencsg.com/csghub-server/_mocks/go.temporal.io/sdk/client"
+	mockcredential "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/builder/credential"
 	mockbuilder "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/builder/deploy/imagebuilder"
 	mockrunner "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/builder/deploy/imagerunner"
 	mock_git "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/builder/git/gitserver"
+	mocksecret "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/builder/secret"
 	mockdb "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/builder/store/database"
 	mockReporter "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/component/reporter"
 )
@@ -72,6 +74,7 @@ func TestDeployWorkflowSuccess(t *testing.T) {
 	mockGitServer := mock_git.NewMockGitServer(t)
 	mockLogReporter := mockReporter.NewMockLogCollector(t)
 	mockClusterStore := mockdb.NewMockClusterInfoStore(t)
+	mockSecretManager := mocksecret.NewMockManager(t)
 	mockConfig := &config.Config{}
 	mockDeployCfg := common.BuildDeployConfig(mockConfig)
 	act := activity.NewDeployActivity(
@@ -88,6 +91,9 @@ func TestDeployWorkflowSuccess(t *testing.T) {
 		mockUrsStore,
 		mockMetadataStore,
 		mockClusterStore,
+		mockdb.NewMockNamespaceStore(t),
+		mockSecretManager,
+		mockcredential.NewMockBroker(t),
 	)
 	env := testSuite.NewTestWorkflowEnvironment()
 	env.RegisterWorkflow(DeployWorkflow)
@@ -165,6 +171,7 @@ func TestDeployWorkflowSuccess(t *testing.T) {
 		},
 	}, nil)
 	mockClusterStore.EXPECT().ByClusterID(mock.Anything, mock.Anything).Return(database.ClusterInfo{}, nil).Maybe()
+	mockSecretManager.EXPECT().Values(mock.Anything, mock.Anything, mock.Anything).Return(map[string]string{}, nil)
 
 	env.ExecuteWorkflow(DeployWorkflow, buildTask.ID, runTask.ID)
 
@@ -91,6 +91,24 @@ func HandlePushWorkflow(ctx workflow.Context, req *types.GiteaCallbackPushReq) e
 		return err
 	}
 
+	// Refresh search document: search indexing failure should not block other callback activities
+	err = workflow.ExecuteActivity(actCtx, activities.RefreshSearchDocument, req).Get(ctx, nil)
+	if err != nil {
+		logger.Error("[git_callback] failed to refresh search document", slog.Any("error", err), slog.Any("req", req))
+	}
+
+	// Refresh repo card: card parsing failure should not block other callback activities
+	err = workflow.ExecuteActivity(actCtx, activities.RefreshRepoCard, req).Get(ctx, nil)
+	if err != nil {
+		logger.Error("[git_callback] failed to refresh repo card", slog.Any("error", err), slog.Any("req", req))
+	}
+
+	// Record feed event: feed failure should not block other callback activities
+	err = workflow.ExecuteActivity(actCtx, activities.RecordFeedEvent, req).Get(ctx, nil)
+	if err != nil {
+		logger.Error("[git_callback] failed to record feed event", slog.Any("error", err), slog.Any("req", req))
+	}
+
 	// Calculate repo size
 	err = workflow.ExecuteActivity(actCtx, activities.CalculateRepoSize, req).Get(ctx, nil)
 	if err != nil {
@@ -0,0 +1,89 @@
+package workflow
+
+import (
+	"fmt"
+	"time"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+	"opencsg.com/csghub-server/common/types"
+)
+
+// InferenceRolloutWorkflowID is the id of the workflow driving the rollout, used to cancel it
+func InferenceRolloutWorkflowID(rolloutID int64) string {
+	return fmt.Sprintf("inference-rollout-%d", rolloutID)
+}
+
+// InferenceRolloutWorkflow shifts the traffic of an inference deploy to the canary version step by step.
+// After every step the canary is observed for the step interval, all traffic goes back to the stable
+// version if its error rate or latency breaches the limits, the rollout fails or it is cancelled.
+func InferenceRolloutWorkflow(ctx workflow.Context, rollout *types.InferenceRollout) error {
+	logger := workflow.GetLogger(ctx)
+	logger.Info("inference rollout workflow started", "rollout_id", rollout.ID, "deploy_id", rollout.DeployID)
+
+	// the canary revision may take a while to become ready before its first traffic shift
+	shiftCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: time.Minute,
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval:    time.Second * 30,
+			BackoffCoefficient: 1,
+			MaximumAttempts:    20,
+		},
+	})
+	checkCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: time.Minute,
+		RetryPolicy: &temporal.RetryPolicy{
+			MaximumAttempts: 3,
+		},
+	})
+	finish := func(status types.InferenceRolloutStatus, reason string) error {
+		// a disconnected context still rolls back after the workflow is cancelled
+		finishCtx, _ := workflow.NewDisconnectedContext(ctx)
+		finishCtx = workflow.WithActivityOptions(finishCtx, workflow.ActivityOptions{
+			StartToCloseTimeout: time.Minute,
+			RetryPolicy: &temporal.RetryPolicy{
+				MaximumAttempts: 5,
+			},
+		})
+		err := workflow.ExecuteActivity(finishCtx, activities.FinishInferenceRollout, rollout.ID, status, reason).Get(finishCtx, nil)
+		if err != nil {
+			logger.Error("failed to finish inference rollout", "rollout_id", rollout.ID, "status", status, "error", err)
+			return err
+		}
+		logger.Info("inference rollout workflow finished", "rollout_id", rollout.ID, "status", status, "reason", reason)
+		return nil
+	}
+	abort := func(err error, action string) error {
+		if temporal.IsCanceledError(err) {
+			return finish(types.InferenceRolloutCancelled, "rollout cancelled")
+		}
+		if finishErr := finish(types.InferenceRolloutFailed, fmt.Sprintf("failed to %s: %v", action, err)); finishErr != nil {
+			return finishErr
+		}
+		return err
+	}
+
+	interval := time.Duration(rollout.StepInterval) * time.Second
+	for step, percent := range rollout.Steps {
+		err := workflow.ExecuteActivity(shiftCtx, activities.ShiftInferenceRolloutTraffic, rollout.ID, step).Get(ctx, nil)
+		if err != nil {
+			return abort(err, fmt.Sprintf("shift %d%% traffic to canary", percent))
+		}
+
+		err = workflow.Sleep(ctx, interval)
+		if err != nil {
+			return abort(err, "wait for step interval")
+		}
+
+		var health types.InferenceRolloutHealth
+		err = workflow.ExecuteActivity(checkCtx, activities.CheckInferenceRolloutHealth, rollout.ID).Get(ctx, &health)
+		if err != nil {
+			return abort(err, "check canary health")
+		}
+		if !health.Healthy {
+			return finish(types.InferenceRolloutRolledBack, health.Reason)
+		}
+	}
+
+	return finish(types.InferenceRolloutSucceeded, "")
+}
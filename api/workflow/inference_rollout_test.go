@@ -0,0 +1,76 @@
+package workflow_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"opencsg.com/csghub-server/api/workflow"
+	"opencsg.com/csghub-server/common/types"
+)
+
+func TestWorkflow_InferenceRolloutWorkflow(t *testing.T) {
+	rollout := &types.InferenceRollout{ID: 1, Steps: []int{5, 50, 100}, StepInterval: 60}
+
+	t.Run("succeeded", func(t *testing.T) {
+		tester, err := newWorkflowTester(t)
+		require.NoError(t, err)
+
+		for step := range rollout.Steps {
+			tester.mocks.inferenceRollout.EXPECT().ShiftRolloutTraffic(mock.Anything, int64(1), step).Return(nil).Once()
+		}
+		tester.mocks.inferenceRollout.EXPECT().CheckRolloutHealth(mock.Anything, int64(1)).Return(
+			&types.InferenceRolloutHealth{Healthy: true}, nil).Times(3)
+		tester.mocks.inferenceRollout.EXPECT().FinishRollout(mock.Anything, int64(1), types.InferenceRolloutSucceeded, "").Return(nil)
+
+		tester.env.ExecuteWorkflow(workflow.InferenceRolloutWorkflow, rollout)
+		require.True(t, tester.env.IsWorkflowCompleted())
+		require.NoError(t, tester.env.GetWorkflowError())
+	})
+
+	t.Run("rolled back", func(t *testing.T) {
+		tester, err := newWorkflowTester(t)
+		require.NoError(t, err)
+
+		tester.mocks.inferenceRollout.EXPECT().ShiftRolloutTraffic(mock.Anything, int64(1), 0).Return(nil).Once()
+		tester.mocks.inferenceRollout.EXPECT().ShiftRolloutTraffic(mock.Anything, int64(1), 1).Return(nil).Once()
+		tester.mocks.inferenceRollout.EXPECT().CheckRolloutHealth(mock.Anything, int64(1)).Return(
+			&types.InferenceRolloutHealth{Healthy: true}, nil).Once()
+		tester.mocks.inferenceRollout.EXPECT().CheckRolloutHealth(mock.Anything, int64(1)).Return(
+			&types.InferenceRolloutHealth{Healthy: false, Reason: "error rate too high"}, nil).Once()
+		tester.mocks.inferenceRollout.EXPECT().FinishRollout(mock.Anything, int64(1), types.InferenceRolloutRolledBack, "error rate too high").Return(nil)
+
+		tester.env.ExecuteWorkflow(workflow.InferenceRolloutWorkflow, rollout)
+		require.True(t, tester.env.IsWorkflowCompleted())
+		require.NoError(t, tester.env.GetWorkflowError())
+	})
+
+	t.Run("shift failed", func(t *testing.T) {
+		tester, err := newWorkflowTester(t)
+		require.NoError(t, err)
+
+		tester.mocks.inferenceRollout.EXPECT().ShiftRolloutTraffic(mock.Anything, int64(1), 0).Return(errors.New("not ready"))
+		tester.mocks.inferenceRollout.EXPECT().FinishRollout(mock.Anything, int64(1), types.InferenceRolloutFailed, mock.Anything).Return(nil)
+
+		tester.env.ExecuteWorkflow(workflow.InferenceRolloutWorkflow, rollout)
+		require.True(t, tester.env.IsWorkflowCompleted())
+		require.Error(t, tester.env.GetWorkflowError())
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		tester, err := newWorkflowTester(t)
+		require.NoError(t, err)
+
+		tester.mocks.inferenceRollout.EXPECT().ShiftRolloutTraffic(mock.Anything, int64(1), 0).Return(nil).Once()
+		tester.mocks.inferenceRollout.EXPECT().FinishRollout(mock.Anything, int64(1), types.InferenceRolloutCancelled, "rollout cancelled").Return(nil)
+
+		tester.env.RegisterDelayedCallback(func() {
+			tester.env.CancelWorkflow()
+		}, 30*time.Second)
+		tester.env.ExecuteWorkflow(workflow.InferenceRolloutWorkflow, rollout)
+		require.True(t, tester.env.IsWorkflowCompleted())
+		require.NoError(t, tester.env.GetWorkflowError())
+	})
+}
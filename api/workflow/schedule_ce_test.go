@@ -8,8 +8,10 @@ import (
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"opencsg.com/csghub-server/api/workflow"
 	"opencsg.com/csghub-server/builder/multisync"
 	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/types"
 )
 
 func TestSchedule_CalcRecomScoreWorkflow(t *testing.T) {
@@ -50,3 +52,28 @@ func TestSchedule_SyncAsClient(t *testing.T) {
 	require.NoError(t, tester.cronEnv.GetWorkflowError())
 
 }
+
+func TestSchedule_LfsGCWorkflow(t *testing.T) {
+	tester, err := newWorkflowTester(t)
+	require.NoError(t, err)
+
+	tester.mocks.lfsGC.EXPECT().RunGC(mock.Anything, types.LfsGCReq{}).Return(&types.LfsGCReport{
+		DeletedObjects: []types.LfsGCObject{{ObjectKey: "lfs/aa/bb/cc", Size: 10}},
+		DeletedBytes:   10,
+	}, nil)
+	tester.cronEnv.ExecuteWorkflow(workflow.LfsGCWorkflow)
+	require.True(t, tester.cronEnv.IsWorkflowCompleted())
+	require.NoError(t, tester.cronEnv.GetWorkflowError())
+}
+
+func TestSchedule_DeployAlertWorkflow(t *testing.T) {
+	tester, err := newWorkflowTester(t)
+	require.NoError(t, err)
+
+	tester.mocks.deployAlert.EXPECT().EvaluateRules(mock.Anything).Return(&types.DeployAlertEvaluation{
+		Evaluated: 2, Fired: 1,
+	}, nil)
+	tester.cronEnv.ExecuteWorkflow(workflow.DeployAlertWorkflow)
+	require.True(t, tester.cronEnv.IsWorkflowCompleted())
+	require.NoError(t, tester.cronEnv.GetWorkflowError())
+}
@@ -104,6 +104,44 @@ func RegisterCronJobs(config *config.Config, temporalClient temporal.Client) err
 		return fmt.Errorf("unable to create deploy reconcile schedule, error:%w", err)
 	}
 
+	if config.LfsGC.Enable {
+		_, err = scheduler.Create(context.Background(), client.ScheduleOptions{
+			ID: "lfs-gc-schedule",
+			Spec: client.ScheduleSpec{
+				CronExpressions: []string{config.LfsGC.CronExpression},
+			},
+			Overlap: enumspb.SCHEDULE_OVERLAP_POLICY_SKIP,
+			Action: &client.ScheduleWorkflowAction{
+				ID:        "lfs-gc-workflow",
+				TaskQueue: CronJobQueueName,
+				Workflow:  LfsGCWorkflow,
+				Args:      []interface{}{},
+			},
+		})
+		if err != nil && err.Error() != types.AlreadyScheduledMessage {
+			return fmt.Errorf("unable to create lfs gc schedule, error:%w", err)
+		}
+	}
+
+	if config.DeployAlert.Enable {
+		_, err = scheduler.Create(context.Background(), client.ScheduleOptions{
+			ID: "deploy-alert-schedule",
+			Spec: client.ScheduleSpec{
+				CronExpressions: []string{config.DeployAlert.CronExpression},
+			},
+			Overlap: enumspb.SCHEDULE_OVERLAP_POLICY_SKIP,
+			Action: &client.ScheduleWorkflowAction{
+				ID:        "deploy-alert-workflow",
+				TaskQueue: CronJobQueueName,
+				Workflow:  DeployAlertWorkflow,
+				Args:      []interface{}{},
+			},
+		})
+		if err != nil && err.Error() != types.AlreadyScheduledMessage {
+			return fmt.Errorf("unable to create deploy alert schedule, error:%w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -117,4 +155,6 @@ func RegisterCronWorker(config *config.Config, temporalClient temporal.Client, a
 	wfWorker.RegisterWorkflow(DeletePendingDeletionWorkflow)
 	wfWorker.RegisterWorkflow(ProcessAIGatewayAsyncGenerationsWorkflow)
 	wfWorker.RegisterWorkflow(DeployReconcileWorkflow)
+	wfWorker.RegisterWorkflow(LfsGCWorkflow)
+	wfWorker.RegisterWorkflow(DeployAlertWorkflow)
 }
@@ -71,11 +71,23 @@ func StartWorkflow(cfg *config.Config, registerAsWorker bool) error {
 	if err != nil {
 		return err
 	}
+	inferenceRollout, err := component.NewInferenceRolloutComponent(cfg)
+	if err != nil {
+		return err
+	}
+	lfsGC, err := component.NewLfsGCComponent(cfg)
+	if err != nil {
+		return err
+	}
+	deployAlert, err := component.NewDeployAlertComponent(cfg)
+	if err != nil {
+		return err
+	}
 
 	return StartWorkflowDI(
 		cfg, gitcallback, recom,
 		gitserver, multisync, database.NewSyncClientSettingStore(), client,
-		rftScanner, repoComponent, industryTag, asyncGenSvc, registerAsWorker,
+		rftScanner, repoComponent, industryTag, asyncGenSvc, inferenceRollout, lfsGC, deployAlert, registerAsWorker,
 	)
 }
 
@@ -91,17 +103,21 @@ func StartWorkflowDI(
 	repoComponent component.RepoComponent,
 	industryTag component.IndustryTagComponent,
 	asyncGenerationService aigatewaytask.AsyncGenerationService,
+	inferenceRollout component.InferenceRolloutComponent,
+	lfsGC component.LfsGCComponent,
+	deployAlert component.DeployAlertComponent,
 	registerAsWorker bool,
 ) error {
 	if registerAsWorker {
 		worker := temporalClient.NewWorker(HandlePushQueueName, worker.Options{})
-		act := activity.NewActivities(cfg, callback, recom, gitServer, multisync, syncClientSetting, rftScanner, repoComponent, industryTag, asyncGenerationService)
+		act := activity.NewActivities(cfg, callback, recom, gitServer, multisync, syncClientSetting, rftScanner, repoComponent, industryTag, asyncGenerationService, inferenceRollout, lfsGC, deployAlert)
 		worker.RegisterActivity(act)
 
 		worker.RegisterWorkflow(HandlePushWorkflow)
 		worker.RegisterWorkflow(RuntimeFrameworkWorkflow)
 		worker.RegisterWorkflow(CalculateRepoSizeWorkflow)
 		worker.RegisterWorkflow(ScanRepoIndustryTagsWorkflow)
+		worker.RegisterWorkflow(InferenceRolloutWorkflow)
 		RegisterCronWorker(cfg, temporalClient, act)
 		err := RegisterCronJobs(cfg, temporalClient)
 		if err != nil {
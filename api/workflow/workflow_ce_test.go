@@ -35,6 +35,9 @@ type workflowTester struct {
 		accountComponent *mock_component.MockAccountingComponent
 		repoComponent    *mock_component.MockRepoComponent
 		industryTag      *mock_component.MockIndustryTagComponent
+		inferenceRollout *mock_component.MockInferenceRolloutComponent
+		lfsGC            *mock_component.MockLfsGCComponent
+		deployAlert      *mock_component.MockDeployAlertComponent
 		cache            *mock_cache.MockRedisClient
 	}
 }
@@ -63,6 +66,12 @@ func newWorkflowTester(t *testing.T) (*workflowTester, error) {
 	tester.mocks.repoComponent = mrp
 	mit := mock_component.NewMockIndustryTagComponent(t)
 	tester.mocks.industryTag = mit
+	mir := mock_component.NewMockInferenceRolloutComponent(t)
+	tester.mocks.inferenceRollout = mir
+	mlg := mock_component.NewMockLfsGCComponent(t)
+	tester.mocks.lfsGC = mlg
+	mda := mock_component.NewMockDeployAlertComponent(t)
+	tester.mocks.deployAlert = mda
 
 	mg := mock_git.NewMockGitServer(t)
 	tester.mocks.gitServer = mg
@@ -77,7 +86,7 @@ func newWorkflowTester(t *testing.T) (*workflowTester, error) {
 	mtc.EXPECT().GetScheduleClient().Return(tester.scheduler)
 
 	err := workflow.StartWorkflowDI(
-		cfg, mcb, mr, mg, mm, tester.mocks.stores.SyncClientSettingMock(), mtc, scanner, mrp, mit, nil, true,
+		cfg, mcb, mr, mg, mm, tester.mocks.stores.SyncClientSettingMock(), mtc, scanner, mrp, mit, nil, mir, mlg, mda, true,
 	)
 
 	if err != nil {
@@ -24,6 +24,9 @@ func TestWorkflow_HandlePushWorkflow(t *testing.T) {
 	tester.mocks.callback.EXPECT().UpdateRepoInfos(mock.Anything, &types.GiteaCallbackPushReq{}).Return(nil)
 	tester.mocks.callback.EXPECT().SensitiveCheck(mock.Anything, &types.GiteaCallbackPushReq{}).Return(nil)
 	tester.mocks.callback.EXPECT().MCPScan(mock.Anything, &types.GiteaCallbackPushReq{}).Return(nil)
+	tester.mocks.callback.EXPECT().RefreshSearchDocument(mock.Anything, &types.GiteaCallbackPushReq{}).Return(nil)
+	tester.mocks.callback.EXPECT().RefreshRepoCard(mock.Anything, &types.GiteaCallbackPushReq{}).Return(nil)
+	tester.mocks.callback.EXPECT().RecordFeedEvent(mock.Anything, &types.GiteaCallbackPushReq{}).Return(nil)
 	tester.mocks.callback.EXPECT().CalculateRepoSize(mock.Anything, &types.GiteaCallbackPushReq{}).Return(nil)
 
 	tester.env.ExecuteWorkflow(workflow.HandlePushWorkflow, &types.GiteaCallbackPushReq{})
@@ -47,6 +50,9 @@ func TestWorkflow_HandlePushWorkflowContinuesWhenPackageSyncFails(t *testing.T)
 	tester.mocks.callback.EXPECT().UpdateRepoInfos(mock.Anything, req).Return(nil)
 	tester.mocks.callback.EXPECT().SensitiveCheck(mock.Anything, req).Return(nil)
 	tester.mocks.callback.EXPECT().MCPScan(mock.Anything, req).Return(nil)
+	tester.mocks.callback.EXPECT().RefreshSearchDocument(mock.Anything, req).Return(nil)
+	tester.mocks.callback.EXPECT().RefreshRepoCard(mock.Anything, req).Return(nil)
+	tester.mocks.callback.EXPECT().RecordFeedEvent(mock.Anything, req).Return(nil)
 	tester.mocks.callback.EXPECT().CalculateRepoSize(mock.Anything, req).Return(nil)
 
 	tester.env.ExecuteWorkflow(workflow.HandlePushWorkflow, req)
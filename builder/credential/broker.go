@@ -0,0 +1,462 @@
+package credential
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/config"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+	"opencsg.com/csghub-server/common/utils/cryptox"
+	"opencsg.com/csghub-server/common/utils/trace"
+)
+
+// infoPrefix separates the keys derived for the credentials from the other users of the master key
+const infoPrefix = "csghub/credential/"
+
+// GrantRequest grants the credentials of a namespace to a task
+type GrantRequest struct {
+	NamespaceUUID   string
+	TaskID          string
+	AgentID         string
+	CredentialNames []string
+	// Duration is the lifetime of the grants, the default duration in config is used if it's zero
+	Duration time.Duration
+}
+
+// Broker keeps the credential values encrypted, and hands them out to the tasks through short-lived grants
+//
+// The plain values are only returned by Read, Refresh and Inject, and every one of the reads is written
+// to the credential audit log, a read is refused if its audit log can not be written.
+type Broker interface {
+	// Seal encrypts the credential values, and saves them as the secret of the credential, the secret is
+	// overwritten if the credential has one already
+	Seal(ctx context.Context, credential *database.Credential, values map[string]string) error
+	// Remove deletes the secret of the credential
+	Remove(ctx context.Context, credential *database.Credential) error
+	// Grant grants the credentials to a task, and returns the runtime token of the grant session
+	Grant(ctx context.Context, req GrantRequest) (*types.CreateTaskCredentialGrantResponse, error)
+	// ParseToken validates a runtime token and returns its grant session
+	ParseToken(token string) (*Session, error)
+	// Read returns the values of a credential granted to the session
+	Read(ctx context.Context, session *Session, credentialName string) (*types.RuntimeCredentialResponse, error)
+	// Refresh returns the latest values of a credential granted to the session, or of all the granted
+	// credentials if the name is empty, it's used by the tasks after the credentials are rotated
+	Refresh(ctx context.Context, session *Session, credentialName string) ([]types.RuntimeCredentialResponse, error)
+	// RevokeSession revokes all the grants of the session
+	RevokeSession(ctx context.Context, session *Session) error
+	// Inject grants the credentials to a platform task, like a mirror sync or a deploy, and reads them at once,
+	// the grant session is revoked after the read
+	Inject(ctx context.Context, req GrantRequest) ([]types.RuntimeCredentialResponse, error)
+	// InjectGitAuth injects the credential of the id to a platform task, and returns it as git basic auth
+	InjectGitAuth(ctx context.Context, credentialID int64, taskID, agentID string) (username, token string, err error)
+	// Audit writes an entry of the credential audit log
+	Audit(ctx context.Context, log *database.CredentialAuditLog) error
+}
+
+type brokerImpl struct {
+	keyring         *cryptox.Keyring
+	signingKey      []byte
+	defaultDuration time.Duration
+	maxDuration     time.Duration
+	credentialStore database.CredentialStore
+	secretStore     database.CredentialSecretStore
+	grantStore      database.TaskCredentialGrantStore
+	auditLogStore   database.CredentialAuditLogStore
+}
+
+func NewBroker(config *config.Config) (Broker, error) {
+	if config.Credential.SecretBackend != types.CredentialSecretBackendPostgresEncrypted {
+		return nil, fmt.Errorf("unsupported credential secret backend %q", config.Credential.SecretBackend)
+	}
+	masterKey, err := base64.StdEncoding.DecodeString(config.Credential.MasterKeyBase64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid credential master key, error: %w", err)
+	}
+	keyring, err := cryptox.NewKeyring(cryptox.KeyringConfig{
+		MasterKeys:    map[int][]byte{1: masterKey},
+		ActiveVersion: 1,
+		Salt:          []byte(config.Credential.Salt),
+		InfoPrefix:    infoPrefix,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create credential keyring, error: %w", err)
+	}
+	if config.Credential.RuntimeSessionSigningKey == "" {
+		return nil, errors.New("credential runtime session signing key is not configured")
+	}
+	return &brokerImpl{
+		keyring:         keyring,
+		signingKey:      []byte(config.Credential.RuntimeSessionSigningKey),
+		defaultDuration: time.Duration(config.Credential.RuntimeSessionDefaultDurationSeconds) * time.Second,
+		maxDuration:     time.Duration(config.Credential.RuntimeSessionMaxDurationSeconds) * time.Second,
+		credentialStore: database.NewCredentialStore(),
+		secretStore:     database.NewCredentialSecretStore(),
+		grantStore:      database.NewTaskCredentialGrantStore(),
+		auditLogStore:   database.NewCredentialAuditLogStore(),
+	}, nil
+}
+
+func (b *brokerImpl) Seal(ctx context.Context, credential *database.Credential, values map[string]string) error {
+	data, err := json.Marshal(values)
+	if err != nil {
+		return fmt.Errorf("failed to marshal credential values, error: %w", err)
+	}
+	version, ciphertext, err := b.keyring.Encrypt(data, credential.NamespaceUUID, credential.CredentialName)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt credential values, error: %w", err)
+	}
+	// the nonce is a part of the ciphertext
+	if credential.SecretRef != "" {
+		secretID, err := b.secretID(credential)
+		if err != nil {
+			return err
+		}
+		return b.secretStore.UpdateCipher(ctx, secretID, []byte(ciphertext), []byte{}, version, "")
+	}
+	secret, err := b.secretStore.Create(ctx, &database.CredentialSecret{
+		SecretCiphertext: []byte(ciphertext),
+		SecretNonce:      []byte{},
+		SecretVersion:    version,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save credential secret, error: %w", err)
+	}
+	credential.SecretBackend = types.CredentialSecretBackendPostgresEncrypted
+	credential.SecretRef = strconv.FormatInt(secret.ID, 10)
+	return nil
+}
+
+func (b *brokerImpl) Remove(ctx context.Context, credential *database.Credential) error {
+	if credential.SecretRef == "" {
+		return nil
+	}
+	secretID, err := b.secretID(credential)
+	if err != nil {
+		return err
+	}
+	return b.secretStore.Delete(ctx, secretID)
+}
+
+func (b *brokerImpl) open(ctx context.Context, credential *database.Credential) (map[string]string, error) {
+	secretID, err := b.secretID(credential)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := b.secretStore.FindByID(ctx, secretID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find credential secret, error: %w", err)
+	}
+	data, err := b.keyring.Decrypt(secret.SecretVersion, string(secret.SecretCiphertext), credential.NamespaceUUID, credential.CredentialName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt credential secret, error: %w", err)
+	}
+	values := map[string]string{}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal credential values, error: %w", err)
+	}
+	return values, nil
+}
+
+func (b *brokerImpl) secretID(credential *database.Credential) (int64, error) {
+	if credential.SecretBackend != types.CredentialSecretBackendPostgresEncrypted {
+		return 0, fmt.Errorf("unsupported credential secret backend %q", credential.SecretBackend)
+	}
+	secretID, err := strconv.ParseInt(credential.SecretRef, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid credential secret ref %q", credential.SecretRef)
+	}
+	return secretID, nil
+}
+
+func (b *brokerImpl) Grant(ctx context.Context, req GrantRequest) (*types.CreateTaskCredentialGrantResponse, error) {
+	duration := req.Duration
+	if duration == 0 {
+		duration = b.defaultDuration
+	}
+	if duration < 0 || duration > b.maxDuration {
+		return nil, errorx.ReqParamInvalid(errors.New("invalid grant duration"),
+			errorx.Ctx().Set("duration_secs", int(duration.Seconds())).Set("max_duration_secs", int(b.maxDuration.Seconds())))
+	}
+	if len(req.CredentialNames) == 0 {
+		return nil, errorx.ReqParamInvalid(errors.New("no credential to grant"), nil)
+	}
+
+	session := &Session{
+		ID:            uuid.NewString(),
+		NamespaceUUID: req.NamespaceUUID,
+		TaskID:        req.TaskID,
+		AgentID:       req.AgentID,
+		ExpiresAt:     time.Now().Add(duration),
+	}
+	if session.TaskID == "" {
+		session.TaskID = session.ID
+	}
+	var (
+		grants    []*database.TaskCredentialGrant
+		auditLogs []*database.CredentialAuditLog
+		names     []string
+	)
+	for _, name := range req.CredentialNames {
+		if slices.Contains(names, name) {
+			continue
+		}
+		names = append(names, name)
+		credential, err := b.credentialStore.FindByName(ctx, req.NamespaceUUID, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find credential %s, error: %w", name, err)
+		}
+		if !usable(credential) {
+			return nil, errorx.RuntimeCredentialGrantUnavailable(errors.New("credential is not active"),
+				errorx.Ctx().Set("credential_name", name))
+		}
+		grants = append(grants, &database.TaskCredentialGrant{
+			TaskID:       session.TaskID,
+			SessionID:    session.ID,
+			AgentID:      session.AgentID,
+			CredentialID: credential.ID,
+			ExpiresAt:    session.ExpiresAt,
+		})
+		auditLogs = append(auditLogs, b.auditLog(ctx, session, credential, types.CredentialAuditGrant, types.CredentialAuditSuccess, ""))
+	}
+	created, err := b.grantStore.CreateBatchWithAuditLogs(ctx, grants, auditLogs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create credential grants, error: %w", err)
+	}
+	token, err := signRuntimeToken(b.signingKey, session)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign runtime credential token, error: %w", err)
+	}
+
+	resp := &types.CreateTaskCredentialGrantResponse{
+		RuntimeCredentialToken: token,
+		TokenType:              types.RuntimeCredentialTokenType,
+		ExpiresAt:              session.ExpiresAt,
+	}
+	for i, grant := range created {
+		resp.Grants = append(resp.Grants, types.TaskCredentialGrantResponse{
+			ID:             grant.ID,
+			TaskID:         grant.TaskID,
+			SessionID:      grant.SessionID,
+			AgentID:        grant.AgentID,
+			CredentialName: names[i],
+			ExpiresAt:      grant.ExpiresAt,
+			CreatedAt:      grant.CreatedAt,
+		})
+	}
+	return resp, nil
+}
+
+func (b *brokerImpl) ParseToken(token string) (*Session, error) {
+	return parseRuntimeToken(b.signingKey, token)
+}
+
+func (b *brokerImpl) Read(ctx context.Context, session *Session, credentialName string) (*types.RuntimeCredentialResponse, error) {
+	credential, err := b.credentialStore.FindByName(ctx, session.NamespaceUUID, credentialName)
+	if err != nil {
+		if !errors.Is(err, errorx.ErrNotFound) {
+			return nil, fmt.Errorf("failed to find credential %s, error: %w", credentialName, err)
+		}
+		b.deny(ctx, session, &database.CredentialAuditLog{Action: types.CredentialAuditRead}, "credential not found")
+		return nil, errorx.RuntimeCredentialGrantUnavailable(err, errorx.Ctx().Set("credential_name", credentialName))
+	}
+	return b.read(ctx, session, credential, types.CredentialAuditRead)
+}
+
+func (b *brokerImpl) Refresh(ctx context.Context, session *Session, credentialName string) ([]types.RuntimeCredentialResponse, error) {
+	var credentials []*database.Credential
+	if credentialName != "" {
+		credential, err := b.credentialStore.FindByName(ctx, session.NamespaceUUID, credentialName)
+		if err != nil {
+			if !errors.Is(err, errorx.ErrNotFound) {
+				return nil, fmt.Errorf("failed to find credential %s, error: %w", credentialName, err)
+			}
+			b.deny(ctx, session, &database.CredentialAuditLog{Action: types.CredentialAuditRefresh}, "credential not found")
+			return nil, errorx.RuntimeCredentialGrantUnavailable(err, errorx.Ctx().Set("credential_name", credentialName))
+		}
+		credentials = append(credentials, credential)
+	} else {
+		grants, err := b.grantStore.ListValidBySession(ctx, session.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list credential grants, error: %w", err)
+		}
+		for _, grant := range grants {
+			credential, err := b.credentialStore.FindByID(ctx, grant.CredentialID)
+			if err != nil {
+				if errors.Is(err, errorx.ErrNotFound) {
+					// the credential is deleted after the grant
+					continue
+				}
+				return nil, fmt.Errorf("failed to find credential %d, error: %w", grant.CredentialID, err)
+			}
+			credentials = append(credentials, credential)
+		}
+	}
+
+	resps := make([]types.RuntimeCredentialResponse, 0, len(credentials))
+	for _, credential := range credentials {
+		resp, err := b.read(ctx, session, credential, types.CredentialAuditRefresh)
+		if err != nil {
+			return nil, err
+		}
+		resps = append(resps, *resp)
+	}
+	return resps, nil
+}
+
+func (b *brokerImpl) read(ctx context.Context, session *Session, credential *database.Credential, action string) (*types.RuntimeCredentialResponse, error) {
+	auditLog := b.auditLog(ctx, session, credential, action, "", "")
+	if credential.NamespaceUUID != session.NamespaceUUID {
+		b.deny(ctx, session, auditLog, "credential of another namespace")
+		return nil, errorx.RuntimeCredentialGrantUnavailable(errors.New("credential of another namespace"),
+			errorx.Ctx().Set("credential_name", credential.CredentialName))
+	}
+	grant, _, err := b.grantStore.FindValidBySessionAndCredentialID(ctx, session.ID, credential.ID)
+	if err != nil {
+		if !errors.Is(err, errorx.ErrNotFound) {
+			return nil, fmt.Errorf("failed to find credential grant, error: %w", err)
+		}
+		b.deny(ctx, session, auditLog, "credential not granted to the session")
+		return nil, errorx.RuntimeCredentialGrantUnavailable(err, errorx.Ctx().Set("credential_name", credential.CredentialName))
+	}
+	if !usable(credential) {
+		b.deny(ctx, session, auditLog, "credential is not active")
+		return nil, errorx.RuntimeCredentialGrantUnavailable(errors.New("credential is not active"),
+			errorx.Ctx().Set("credential_name", credential.CredentialName))
+	}
+
+	values, err := b.open(ctx, credential)
+	if err != nil {
+		auditLog.Result = types.CredentialAuditFailed
+		auditLog.Reason = err.Error()
+		if auditErr := b.Audit(ctx, auditLog); auditErr != nil {
+			slog.ErrorContext(ctx, "failed to write credential audit log", slog.Any("error", auditErr))
+		}
+		return nil, err
+	}
+	auditLog.Result = types.CredentialAuditSuccess
+	if err := b.Audit(ctx, auditLog); err != nil {
+		return nil, err
+	}
+
+	credential.LastUsedAt = time.Now()
+	if err := b.credentialStore.Update(ctx, credential); err != nil {
+		slog.ErrorContext(ctx, "failed to update last used time of credential", slog.Int64("credential_id", credential.ID),
+			slog.Any("error", err))
+	}
+	return &types.RuntimeCredentialResponse{
+		CredentialName: credential.CredentialName,
+		Provider:       credential.Provider,
+		AuthType:       credential.AuthType,
+		Credential:     values,
+		Metadata:       credential.Metadata,
+		ExpiresAt:      grant.ExpiresAt,
+	}, nil
+}
+
+func (b *brokerImpl) RevokeSession(ctx context.Context, session *Session) error {
+	if _, err := b.grantStore.RevokeBySessionID(ctx, session.ID); err != nil {
+		return fmt.Errorf("failed to revoke credential grants, error: %w", err)
+	}
+	return b.Audit(ctx, b.auditLog(ctx, session, nil, types.CredentialAuditRevoke, types.CredentialAuditSuccess, ""))
+}
+
+func (b *brokerImpl) Inject(ctx context.Context, req GrantRequest) ([]types.RuntimeCredentialResponse, error) {
+	grant, err := b.Grant(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	session, err := b.ParseToken(grant.RuntimeCredentialToken)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := b.RevokeSession(ctx, session); err != nil {
+			slog.ErrorContext(ctx, "failed to revoke injected credential session", slog.String("session_id", session.ID),
+				slog.Any("error", err))
+		}
+	}()
+
+	resps := make([]types.RuntimeCredentialResponse, 0, len(grant.Grants))
+	for _, g := range grant.Grants {
+		resp, err := b.Read(ctx, session, g.CredentialName)
+		if err != nil {
+			return nil, err
+		}
+		resps = append(resps, *resp)
+	}
+	return resps, nil
+}
+
+func (b *brokerImpl) InjectGitAuth(ctx context.Context, credentialID int64, taskID, agentID string) (string, string, error) {
+	cred, err := b.credentialStore.FindByID(ctx, credentialID)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to find credential %d, error: %w", credentialID, err)
+	}
+	resps, err := b.Inject(ctx, GrantRequest{
+		NamespaceUUID:   cred.NamespaceUUID,
+		TaskID:          taskID,
+		AgentID:         agentID,
+		CredentialNames: []string{cred.CredentialName},
+	})
+	if err != nil {
+		return "", "", err
+	}
+	username, token := GitAuth(resps[0].Credential)
+	return username, token, nil
+}
+
+func (b *brokerImpl) Audit(ctx context.Context, log *database.CredentialAuditLog) error {
+	if log.TraceID == "" {
+		log.TraceID, _ = trace.GetTraceIDFromContext(ctx)
+	}
+	if err := b.auditLogStore.Create(ctx, log); err != nil {
+		return fmt.Errorf("failed to write credential audit log, error: %w", err)
+	}
+	return nil
+}
+
+func (b *brokerImpl) auditLog(ctx context.Context, session *Session, credential *database.Credential, action, result, reason string) *database.CredentialAuditLog {
+	log := &database.CredentialAuditLog{
+		NamespaceUUID: session.NamespaceUUID,
+		AgentID:       session.AgentID,
+		TaskID:        session.TaskID,
+		SessionID:     session.ID,
+		Action:        action,
+		Result:        result,
+		Reason:        reason,
+	}
+	log.TraceID, _ = trace.GetTraceIDFromContext(ctx)
+	if credential != nil {
+		log.CredentialID = credential.ID
+		log.Provider = credential.Provider
+	}
+	return log
+}
+
+// deny writes the audit log of a refused read, the read is refused anyway if the log can not be written
+func (b *brokerImpl) deny(ctx context.Context, session *Session, log *database.CredentialAuditLog, reason string) {
+	log.NamespaceUUID = session.NamespaceUUID
+	log.AgentID = session.AgentID
+	log.TaskID = session.TaskID
+	log.SessionID = session.ID
+	log.Result = types.CredentialAuditDenied
+	log.Reason = reason
+	if err := b.Audit(ctx, log); err != nil {
+		slog.ErrorContext(ctx, "failed to write credential audit log", slog.Any("error", err))
+	}
+}
+
+func usable(credential *database.Credential) bool {
+	return credential.Status == types.CredentialStatusActive && credential.ArchivedAt.IsZero() &&
+		(credential.ExpiresAt.IsZero() || credential.ExpiresAt.After(time.Now()))
+}
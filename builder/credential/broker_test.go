@@ -0,0 +1,201 @@
+package credential
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	mockdb "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+	"opencsg.com/csghub-server/common/utils/cryptox"
+)
+
+type testBroker struct {
+	*brokerImpl
+	credentialStore *mockdb.MockCredentialStore
+	secretStore     *mockdb.MockCredentialSecretStore
+	grantStore      *mockdb.MockTaskCredentialGrantStore
+	auditLogStore   *mockdb.MockCredentialAuditLogStore
+}
+
+func newTestBroker(t *testing.T) *testBroker {
+	keyring, err := cryptox.NewKeyring(cryptox.KeyringConfig{
+		MasterKeys:    map[int][]byte{1: []byte("test-credential-master-key-00001")},
+		ActiveVersion: 1,
+		Salt:          []byte("test-credential-salt"),
+		InfoPrefix:    infoPrefix,
+	})
+	require.NoError(t, err)
+	b := &testBroker{
+		credentialStore: mockdb.NewMockCredentialStore(t),
+		secretStore:     mockdb.NewMockCredentialSecretStore(t),
+		grantStore:      mockdb.NewMockTaskCredentialGrantStore(t),
+		auditLogStore:   mockdb.NewMockCredentialAuditLogStore(t),
+	}
+	b.brokerImpl = &brokerImpl{
+		keyring:         keyring,
+		signingKey:      []byte("test-signing-key"),
+		defaultDuration: 15 * time.Minute,
+		maxDuration:     time.Hour,
+		credentialStore: b.credentialStore,
+		secretStore:     b.secretStore,
+		grantStore:      b.grantStore,
+		auditLogStore:   b.auditLogStore,
+	}
+	return b
+}
+
+// seal seals the values as the secret 9 of the credential, and returns the saved secret
+func (b *testBroker) seal(t *testing.T, credential *database.Credential, values map[string]string) *database.CredentialSecret {
+	var secret *database.CredentialSecret
+	b.secretStore.EXPECT().Create(mock.Anything, mock.Anything).RunAndReturn(
+		func(ctx context.Context, s *database.CredentialSecret) (*database.CredentialSecret, error) {
+			s.ID = 9
+			secret = s
+			return s, nil
+		}).Once()
+	require.NoError(t, b.Seal(context.TODO(), credential, values))
+	return secret
+}
+
+func TestBroker_GrantAndRead(t *testing.T) {
+	ctx := context.TODO()
+	b := newTestBroker(t)
+
+	credential := &database.Credential{ID: 1, NamespaceUUID: "ns", CredentialName: "hf", Provider: "huggingface",
+		AuthType: "token", Status: types.CredentialStatusActive}
+	secret := b.seal(t, credential, map[string]string{"token": "hf_abc"})
+	require.Equal(t, "9", credential.SecretRef)
+	require.Equal(t, types.CredentialSecretBackendPostgresEncrypted, credential.SecretBackend)
+	require.NotContains(t, string(secret.SecretCiphertext), "hf_abc")
+
+	b.credentialStore.EXPECT().FindByName(ctx, "ns", "hf").Return(credential, nil)
+	b.grantStore.EXPECT().CreateBatchWithAuditLogs(ctx, mock.Anything, mock.Anything).RunAndReturn(
+		func(ctx context.Context, grants []*database.TaskCredentialGrant, logs []*database.CredentialAuditLog) ([]database.TaskCredentialGrant, error) {
+			require.Len(t, grants, 1)
+			require.Equal(t, int64(1), grants[0].CredentialID)
+			require.Equal(t, "task", grants[0].TaskID)
+			require.Len(t, logs, 1)
+			require.Equal(t, types.CredentialAuditGrant, logs[0].Action)
+			return []database.TaskCredentialGrant{*grants[0]}, nil
+		})
+	grant, err := b.Grant(ctx, GrantRequest{NamespaceUUID: "ns", TaskID: "task", AgentID: "agent", CredentialNames: []string{"hf", "hf"}})
+	require.NoError(t, err)
+	require.Len(t, grant.Grants, 1)
+	require.Equal(t, "hf", grant.Grants[0].CredentialName)
+
+	session, err := b.ParseToken(grant.RuntimeCredentialToken)
+	require.NoError(t, err)
+	require.Equal(t, "ns", session.NamespaceUUID)
+	require.Equal(t, "agent", session.AgentID)
+
+	b.grantStore.EXPECT().FindValidBySessionAndCredentialID(ctx, session.ID, int64(1)).
+		Return(&database.TaskCredentialGrant{ExpiresAt: grant.ExpiresAt}, credential, nil)
+	b.secretStore.EXPECT().FindByID(ctx, int64(9)).Return(secret, nil)
+	b.auditLogStore.EXPECT().Create(ctx, mock.Anything).RunAndReturn(func(ctx context.Context, log *database.CredentialAuditLog) error {
+		require.Equal(t, types.CredentialAuditRead, log.Action)
+		require.Equal(t, types.CredentialAuditSuccess, log.Result)
+		require.Equal(t, session.ID, log.SessionID)
+		return nil
+	})
+	b.credentialStore.EXPECT().Update(ctx, credential).Return(nil)
+	resp, err := b.Read(ctx, session, "hf")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"token": "hf_abc"}, resp.Credential)
+	require.False(t, credential.LastUsedAt.IsZero())
+}
+
+func TestBroker_ReadNotGranted(t *testing.T) {
+	ctx := context.TODO()
+	b := newTestBroker(t)
+	session := &Session{ID: "s", NamespaceUUID: "ns", AgentID: "agent"}
+
+	credential := &database.Credential{ID: 1, NamespaceUUID: "ns", CredentialName: "hf", Status: types.CredentialStatusActive}
+	b.credentialStore.EXPECT().FindByName(ctx, "ns", "hf").Return(credential, nil)
+	b.grantStore.EXPECT().FindValidBySessionAndCredentialID(ctx, "s", int64(1)).Return(nil, nil, errorx.ErrNotFound)
+	b.auditLogStore.EXPECT().Create(ctx, mock.Anything).RunAndReturn(func(ctx context.Context, log *database.CredentialAuditLog) error {
+		require.Equal(t, types.CredentialAuditDenied, log.Result)
+		require.Equal(t, int64(1), log.CredentialID)
+		return nil
+	})
+	_, err := b.Read(ctx, session, "hf")
+	require.ErrorIs(t, err, errorx.ErrRuntimeCredentialGrantUnavailable)
+}
+
+func TestBroker_ReadRefusedWithoutAuditLog(t *testing.T) {
+	ctx := context.TODO()
+	b := newTestBroker(t)
+	session := &Session{ID: "s", NamespaceUUID: "ns"}
+
+	credential := &database.Credential{ID: 1, NamespaceUUID: "ns", CredentialName: "hf", Status: types.CredentialStatusActive}
+	secret := b.seal(t, credential, map[string]string{"token": "hf_abc"})
+	b.credentialStore.EXPECT().FindByName(ctx, "ns", "hf").Return(credential, nil)
+	b.grantStore.EXPECT().FindValidBySessionAndCredentialID(ctx, "s", int64(1)).Return(&database.TaskCredentialGrant{}, credential, nil)
+	b.secretStore.EXPECT().FindByID(ctx, int64(9)).Return(secret, nil)
+	b.auditLogStore.EXPECT().Create(ctx, mock.Anything).Return(errorx.ErrDatabaseFailure)
+	_, err := b.Read(ctx, session, "hf")
+	require.Error(t, err)
+}
+
+func TestBroker_GrantInvalid(t *testing.T) {
+	ctx := context.TODO()
+	b := newTestBroker(t)
+
+	_, err := b.Grant(ctx, GrantRequest{NamespaceUUID: "ns", CredentialNames: []string{"hf"}, Duration: 2 * time.Hour})
+	require.ErrorIs(t, err, errorx.ErrReqParamInvalid)
+
+	b.credentialStore.EXPECT().FindByName(ctx, "ns", "hf").Return(&database.Credential{Status: "revoked"}, nil)
+	_, err = b.Grant(ctx, GrantRequest{NamespaceUUID: "ns", CredentialNames: []string{"hf"}})
+	require.ErrorIs(t, err, errorx.ErrRuntimeCredentialGrantUnavailable)
+}
+
+func TestBroker_ParseTokenInvalid(t *testing.T) {
+	b := newTestBroker(t)
+
+	token, err := signRuntimeToken([]byte("another-key"), &Session{ID: "s", NamespaceUUID: "ns", ExpiresAt: time.Now().Add(time.Minute)})
+	require.NoError(t, err)
+	_, err = b.ParseToken(token)
+	require.ErrorIs(t, err, errorx.ErrRuntimeCredentialTokenInvalid)
+
+	token, err = signRuntimeToken(b.signingKey, &Session{ID: "s", NamespaceUUID: "ns", ExpiresAt: time.Now().Add(-time.Minute)})
+	require.NoError(t, err)
+	_, err = b.ParseToken(token)
+	require.ErrorIs(t, err, errorx.ErrRuntimeCredentialTokenInvalid)
+}
+
+func TestBroker_InjectGitAuth(t *testing.T) {
+	ctx := context.TODO()
+	b := newTestBroker(t)
+
+	credential := &database.Credential{ID: 1, NamespaceUUID: "ns", CredentialName: "gh", Provider: "github",
+		AuthType: "token", Status: types.CredentialStatusActive}
+	secret := b.seal(t, credential, map[string]string{"token": "ghp_abc"})
+
+	b.credentialStore.EXPECT().FindByID(ctx, int64(1)).Return(credential, nil)
+	b.credentialStore.EXPECT().FindByName(ctx, "ns", "gh").Return(credential, nil)
+	b.grantStore.EXPECT().CreateBatchWithAuditLogs(ctx, mock.Anything, mock.Anything).RunAndReturn(
+		func(ctx context.Context, grants []*database.TaskCredentialGrant, logs []*database.CredentialAuditLog) ([]database.TaskCredentialGrant, error) {
+			require.Equal(t, "mirror-task-1", grants[0].TaskID)
+			return []database.TaskCredentialGrant{*grants[0]}, nil
+		})
+	b.grantStore.EXPECT().FindValidBySessionAndCredentialID(ctx, mock.Anything, int64(1)).
+		Return(&database.TaskCredentialGrant{ExpiresAt: time.Now().Add(time.Minute)}, credential, nil)
+	b.secretStore.EXPECT().FindByID(ctx, int64(9)).Return(secret, nil)
+	b.credentialStore.EXPECT().Update(ctx, credential).Return(nil)
+	b.grantStore.EXPECT().RevokeBySessionID(ctx, mock.Anything).Return(int64(1), nil)
+	var actions []string
+	b.auditLogStore.EXPECT().Create(ctx, mock.Anything).RunAndReturn(func(ctx context.Context, log *database.CredentialAuditLog) error {
+		actions = append(actions, log.Action)
+		return nil
+	})
+
+	username, token, err := b.InjectGitAuth(ctx, 1, "mirror-task-1", "mirror")
+	require.NoError(t, err)
+	require.Equal(t, "oauth2", username)
+	require.Equal(t, "ghp_abc", token)
+	require.Equal(t, []string{types.CredentialAuditRead, types.CredentialAuditRevoke}, actions)
+}
@@ -0,0 +1,305 @@
+package credential
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+)
+
+// verifyFunc checks the credential against the api of the provider
+type verifyFunc func(ctx context.Context, client *http.Client, req *types.VerifyCredentialRequest) error
+
+type provider struct {
+	definition types.CredentialProviderDefinition
+	verify     verifyFunc
+}
+
+var providers = []provider{
+	{
+		definition: types.CredentialProviderDefinition{
+			Name:             "huggingface",
+			AuthTypes:        []string{"token"},
+			CredentialFields: []types.CredentialProviderField{{Name: "token", Required: true, Secret: true}},
+		},
+		verify: bearerVerifier("https://huggingface.co/api/whoami-v2", ""),
+	},
+	{
+		definition: types.CredentialProviderDefinition{
+			Name:             "modelscope",
+			AuthTypes:        []string{"token"},
+			CredentialFields: []types.CredentialProviderField{{Name: "token", Required: true, Secret: true}},
+		},
+	},
+	{
+		definition: types.CredentialProviderDefinition{
+			Name:             "github",
+			AuthTypes:        []string{"token"},
+			CredentialFields: []types.CredentialProviderField{{Name: "token", Required: true, Secret: true}},
+		},
+		verify: bearerVerifier("https://api.github.com/user", ""),
+	},
+	{
+		definition: types.CredentialProviderDefinition{
+			Name:             "gitlab",
+			AuthTypes:        []string{"token"},
+			CredentialFields: []types.CredentialProviderField{{Name: "token", Required: true, Secret: true}},
+			MetadataFields:   []types.CredentialProviderField{{Name: "base_url"}},
+		},
+		verify: gitlabVerifier,
+	},
+	{
+		// git is a personal access token of any git server, used by the mirrors
+		definition: types.CredentialProviderDefinition{
+			Name:      "git",
+			AuthTypes: []string{"basic"},
+			CredentialFields: []types.CredentialProviderField{
+				{Name: "username", Required: true},
+				{Name: "token", Required: true, Secret: true},
+			},
+			MetadataFields: []types.CredentialProviderField{{Name: "url"}},
+		},
+	},
+	{
+		definition: types.CredentialProviderDefinition{
+			Name:      "s3",
+			AuthTypes: []string{"access_key"},
+			CredentialFields: []types.CredentialProviderField{
+				{Name: "access_key_id", Required: true},
+				{Name: "secret_access_key", Required: true, Secret: true},
+			},
+			MetadataFields: []types.CredentialProviderField{
+				{Name: "endpoint", Required: true},
+				{Name: "region"},
+				{Name: "bucket"},
+			},
+		},
+	},
+	{
+		// openai is the api key of any openai compatible provider
+		definition: types.CredentialProviderDefinition{
+			Name:             "openai",
+			AuthTypes:        []string{"api_key"},
+			CredentialFields: []types.CredentialProviderField{{Name: "api_key", Required: true, Secret: true}},
+			MetadataFields:   []types.CredentialProviderField{{Name: "base_url"}},
+		},
+		verify: bearerVerifier("https://api.openai.com/v1", "/models"),
+	},
+}
+
+// Providers returns the definitions of the supported credential providers
+func Providers() []types.CredentialProviderDefinition {
+	res := make([]types.CredentialProviderDefinition, 0, len(providers))
+	for _, p := range providers {
+		definition := p.definition
+		definition.VerifyEnabled = p.verify != nil
+		res = append(res, definition)
+	}
+	return res
+}
+
+func findProvider(name string) (*provider, bool) {
+	for i := range providers {
+		if providers[i].definition.Name == name {
+			return &providers[i], true
+		}
+	}
+	return nil, false
+}
+
+// Validate checks the credential values and metadata against the definition of the provider
+func Validate(providerName, authType string, values map[string]string, metadata map[string]any) error {
+	if err := ValidateValues(providerName, authType, values); err != nil {
+		return err
+	}
+	return ValidateMetadata(providerName, metadata)
+}
+
+// ValidateValues checks the credential values against the definition of the provider
+func ValidateValues(providerName, authType string, values map[string]string) error {
+	p, err := getProvider(providerName)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(p.definition.AuthTypes, authType) {
+		return errorx.ReqParamInvalid(errors.New("unsupported auth type of the credential provider"),
+			errorx.Ctx().Set("provider", providerName).Set("auth_type", authType))
+	}
+	for key := range values {
+		if !slices.ContainsFunc(p.definition.CredentialFields, func(f types.CredentialProviderField) bool { return f.Name == key }) {
+			return errorx.ReqParamInvalid(errors.New("unknown credential field"), errorx.Ctx().Set("field", key))
+		}
+	}
+	for _, field := range p.definition.CredentialFields {
+		if field.Required && strings.TrimSpace(values[field.Name]) == "" {
+			return errorx.ReqParamInvalid(errors.New("credential field is required"), errorx.Ctx().Set("field", field.Name))
+		}
+	}
+	return nil
+}
+
+// ValidateMetadata checks the credential metadata against the definition of the provider, the metadata
+// fields not in the definition are kept as they are
+func ValidateMetadata(providerName string, metadata map[string]any) error {
+	p, err := getProvider(providerName)
+	if err != nil {
+		return err
+	}
+	for _, field := range p.definition.MetadataFields {
+		if field.Required && metadataString(metadata, field.Name) == "" {
+			return errorx.ReqParamInvalid(errors.New("metadata field is required"), errorx.Ctx().Set("field", field.Name))
+		}
+	}
+	return nil
+}
+
+func getProvider(name string) (*provider, error) {
+	p, ok := findProvider(name)
+	if !ok {
+		return nil, errorx.ReqParamInvalid(errors.New("unsupported credential provider"), errorx.Ctx().Set("provider", name))
+	}
+	return p, nil
+}
+
+func metadataString(metadata map[string]any, key string) string {
+	value, _ := metadata[key].(string)
+	return strings.TrimSpace(value)
+}
+
+// EnvName returns the name of the environment variable a credential field is injected as,
+// e.g. the token of the credential hf-prod is injected as HF_PROD_TOKEN
+func EnvName(credentialName, field string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		default:
+			return '_'
+		}
+	}, credentialName+"_"+field)
+	if name[0] >= '0' && name[0] <= '9' {
+		name = "_" + name
+	}
+	return name
+}
+
+// SupportsGitAuth reports whether the credentials of the provider can be used to clone git repositories
+func SupportsGitAuth(providerName string) bool {
+	p, ok := findProvider(providerName)
+	return ok && slices.ContainsFunc(p.definition.CredentialFields, func(f types.CredentialProviderField) bool {
+		return f.Name == "token"
+	})
+}
+
+// GitAuth returns the basic auth of a git server from the credential values
+func GitAuth(values map[string]string) (username, token string) {
+	username = values["username"]
+	if username == "" {
+		// the token based git servers accept any non-empty username
+		username = "oauth2"
+	}
+	return username, values["token"]
+}
+
+// Verify checks that the credential is accepted by the provider
+func Verify(ctx context.Context, client *http.Client, req *types.VerifyCredentialRequest) error {
+	if err := Validate(req.Provider, req.AuthType, req.Credential, req.Metadata); err != nil {
+		return err
+	}
+	p, _ := findProvider(req.Provider)
+	if p.verify == nil {
+		return errorx.ReqParamInvalid(errors.New("verification is not supported by the credential provider"),
+			errorx.Ctx().Set("provider", req.Provider))
+	}
+	return p.verify(ctx, client, req)
+}
+
+func bearerVerifier(defaultBaseURL, path string) verifyFunc {
+	return func(ctx context.Context, client *http.Client, req *types.VerifyCredentialRequest) error {
+		baseURL := metadataString(req.Metadata, "base_url")
+		if baseURL == "" {
+			baseURL = defaultBaseURL
+		}
+		token := req.Credential["token"]
+		if token == "" {
+			token = req.Credential["api_key"]
+		}
+		return verifyRequest(ctx, client, strings.TrimSuffix(baseURL, "/")+path, "Authorization", "Bearer "+token)
+	}
+}
+
+func gitlabVerifier(ctx context.Context, client *http.Client, req *types.VerifyCredentialRequest) error {
+	baseURL := metadataString(req.Metadata, "base_url")
+	if baseURL == "" {
+		baseURL = "https://gitlab.com"
+	}
+	return verifyRequest(ctx, client, strings.TrimSuffix(baseURL, "/")+"/api/v4/user", "PRIVATE-TOKEN", req.Credential["token"])
+}
+
+func verifyRequest(ctx context.Context, client *http.Client, rawURL, header, value string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errorx.CredentialVerifyURLInvalid(fmt.Errorf("invalid verification url %q", rawURL), errorx.Ctx().Set("url", rawURL))
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return errorx.CredentialVerifyURLInvalid(err, errorx.Ctx().Set("url", rawURL))
+	}
+	httpReq.Header.Set(header, value)
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return errorx.CredentialVerifyFailed(err, errorx.Ctx().Set("url", u.Redacted()))
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return errorx.CredentialVerifyTokenInvalid(fmt.Errorf("provider responded with status %d", resp.StatusCode), nil)
+	default:
+		return errorx.CredentialVerifyFailed(fmt.Errorf("provider responded with status %d", resp.StatusCode), nil)
+	}
+}
+
+// NewVerifyClient returns the http client of the verifications, it refuses to connect to the private and
+// internal addresses, as the verification urls of some providers are given by the users
+func NewVerifyClient() *http.Client {
+	return &http.Client{
+		Timeout: 10 * time.Second,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+		Transport: &http.Transport{
+			Proxy: http.ProxyFromEnvironment,
+			DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+				conn, err := (&net.Dialer{Timeout: 5 * time.Second}).DialContext(ctx, network, addr)
+				if err != nil {
+					return nil, err
+				}
+				if tcpAddr, ok := conn.RemoteAddr().(*net.TCPAddr); ok && isInternalIP(tcpAddr.IP) {
+					conn.Close()
+					return nil, fmt.Errorf("connection to %s is not allowed: private or internal IP address", tcpAddr.IP)
+				}
+				return conn, nil
+			},
+		},
+	}
+}
+
+func isInternalIP(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsUnspecified()
+}
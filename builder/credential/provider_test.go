@@ -0,0 +1,70 @@
+package credential
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+)
+
+func TestValidate(t *testing.T) {
+	require.NoError(t, Validate("s3", "access_key", map[string]string{"access_key_id": "id", "secret_access_key": "key"},
+		map[string]any{"endpoint": "https://s3.example.com"}))
+
+	cases := []struct {
+		provider, authType string
+		values             map[string]string
+		metadata           map[string]any
+	}{
+		{"unknown", "token", map[string]string{"token": "t"}, nil},
+		{"huggingface", "basic", map[string]string{"token": "t"}, nil},
+		{"huggingface", "token", map[string]string{"token": " "}, nil},
+		{"huggingface", "token", map[string]string{"token": "t", "other": "x"}, nil},
+		{"s3", "access_key", map[string]string{"access_key_id": "id", "secret_access_key": "key"}, nil},
+	}
+	for _, c := range cases {
+		err := Validate(c.provider, c.authType, c.values, c.metadata)
+		require.ErrorIs(t, err, errorx.ErrReqParamInvalid, c)
+	}
+}
+
+func TestEnvName(t *testing.T) {
+	require.Equal(t, "HF_PROD_TOKEN", EnvName("hf-prod", "token"))
+	require.Equal(t, "_3RD_API_KEY", EnvName("3rd", "api_key"))
+}
+
+func TestVerify(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v4/user", r.URL.Path)
+		if r.Header.Get("PRIVATE-TOKEN") != "good" {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.TODO()
+	req := &types.VerifyCredentialRequest{
+		Provider:   "gitlab",
+		AuthType:   "token",
+		Credential: map[string]string{"token": "good"},
+		Metadata:   map[string]any{"base_url": server.URL},
+	}
+	require.NoError(t, Verify(ctx, server.Client(), req))
+
+	req.Credential["token"] = "bad"
+	require.ErrorIs(t, Verify(ctx, server.Client(), req), errorx.ErrCredentialVerifyTokenInvalid)
+
+	req.Metadata["base_url"] = "ftp://gitlab.example.com"
+	require.ErrorIs(t, Verify(ctx, server.Client(), req), errorx.ErrCredentialVerifyURLInvalid)
+
+	// the verification client refuses to connect to the internal addresses
+	req.Metadata["base_url"] = server.URL
+	require.ErrorIs(t, Verify(ctx, NewVerifyClient(), req), errorx.ErrCredentialVerifyFailed)
+
+	req.Provider = "modelscope"
+	require.ErrorIs(t, Verify(ctx, server.Client(), req), errorx.ErrReqParamInvalid)
+}
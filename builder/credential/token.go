@@ -0,0 +1,59 @@
+package credential
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"opencsg.com/csghub-server/common/errorx"
+)
+
+// Session is a grant session, the runtime token of the session allows its holder to read the credentials
+// granted to the task until the grants expire or are revoked
+type Session struct {
+	ID            string
+	NamespaceUUID string
+	TaskID        string
+	AgentID       string
+	ExpiresAt     time.Time
+}
+
+type runtimeClaims struct {
+	NamespaceUUID string `json:"namespace_uuid"`
+	TaskID        string `json:"task_id"`
+	jwt.RegisteredClaims
+}
+
+func signRuntimeToken(key []byte, session *Session) (string, error) {
+	claims := runtimeClaims{
+		NamespaceUUID: session.NamespaceUUID,
+		TaskID:        session.TaskID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        session.ID,
+			Subject:   session.AgentID,
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ExpiresAt: jwt.NewNumericDate(session.ExpiresAt),
+		},
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(key)
+}
+
+func parseRuntimeToken(key []byte, token string) (*Session, error) {
+	claims := &runtimeClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (any, error) {
+		return key, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Name}), jwt.WithExpirationRequired())
+	if err != nil {
+		return nil, errorx.RuntimeCredentialTokenInvalid(err, nil)
+	}
+	if claims.ID == "" || claims.NamespaceUUID == "" {
+		return nil, errorx.RuntimeCredentialTokenInvalid(errors.New("runtime token without session"), nil)
+	}
+	return &Session{
+		ID:            claims.ID,
+		NamespaceUUID: claims.NamespaceUUID,
+		TaskID:        claims.TaskID,
+		AgentID:       claims.Subject,
+		ExpiresAt:     claims.ExpiresAt.Time,
+	}, nil
+}
@@ -188,6 +188,7 @@ func (d *deployer) dedicatedDeploy(ctx context.Context, dr types.DeployRequest)
 		NodeAffinity:     dr.NodeAffinity,
 		Tolerations:      dr.Tolerations,
 		PD:               dr.PD,
+		CredentialNames:  dr.CredentialNames,
 	}
 	updateDatabaseDeploy(deploy, dr)
 	err := d.deployTaskStore.CreateDeploy(ctx, deploy)
@@ -328,7 +329,7 @@ func (d *deployer) Logs(ctx context.Context, dr types.DeployRequest) (*MultiLogR
 		labels[types.StreamKeyInstanceName] = dr.InstanceName
 	}
 
-	buildLog, err := d.readLogsFromLoki(ctx, types.ReadLogRequest{
+	buildLog, err := d.readLogs(ctx, types.ReadLogRequest{
 		DeployID:  deployId,
 		StartTime: buildTask.CreatedAt,
 		Labels:    labels,
@@ -352,7 +353,7 @@ func (d *deployer) Logs(ctx context.Context, dr types.DeployRequest) (*MultiLogR
 		startTime = parseSinceTime(dr.Since)
 	}
 
-	runLog, err := d.readLogsFromLoki(ctx, types.ReadLogRequest{
+	runLog, err := d.readLogs(ctx, types.ReadLogRequest{
 		DeployID:  deployId,
 		StartTime: startTime,
 		Labels:    labels,
@@ -364,11 +365,11 @@ func (d *deployer) Logs(ctx context.Context, dr types.DeployRequest) (*MultiLogR
 	return NewMultiLogReader(buildLog, runLog), nil
 }
 
-func (d *deployer) readLogsFromLoki(ctx context.Context, params types.ReadLogRequest) (<-chan string, error) {
+func (d *deployer) readLogs(ctx context.Context, params types.ReadLogRequest) (<-chan string, error) {
 	if params.Limit < 1 {
 		params.Limit = loki.MaxLimit
 	}
-	log, err := d.lokiClient.StreamAllLogs(ctx, params.DeployID, params.StartTime, params.Labels, params.TimeLoc, params.Limit)
+	log, err := d.logSender.StreamAllLogs(ctx, params.DeployID, params.StartTime, params.Labels, params.TimeLoc, params.Limit)
 	if err != nil {
 		return nil, err
 	}
@@ -635,7 +636,7 @@ func (d *deployer) InstanceLogs(ctx context.Context, dr types.DeployRequest) (*M
 		startTime = parseSinceTime(dr.Since)
 	}
 
-	runLog, err := d.readLogsFromLoki(ctx, types.ReadLogRequest{
+	runLog, err := d.readLogs(ctx, types.ReadLogRequest{
 		DeployID:  deployId,
 		StartTime: startTime,
 		Labels:    labels,
@@ -668,7 +669,7 @@ func (d *deployer) InstanceLastLogs(ctx context.Context, dr types.DeployRequest)
 
 	labels[types.LogLabelCategoryKey] = string(types.LogCategoryContainer.String())
 
-	query := d.lokiClient.GenerateLabelQuery(labels)
+	query := d.logSender.GenerateLabelQuery(labels)
 	limit := dr.Limit
 
 	params := loki.QueryLastParams{
@@ -678,7 +679,7 @@ func (d *deployer) InstanceLastLogs(ctx context.Context, dr types.DeployRequest)
 		Direction: "backward",
 	}
 
-	return d.lokiClient.QueryLast(ctx, params)
+	return d.logSender.QueryLast(ctx, params)
 }
 
 func (d *deployer) ListCluster(ctx context.Context) ([]types.ClusterRes, error) {
@@ -1110,6 +1111,10 @@ func (d *deployer) SubmitFinetuneJob(ctx context.Context, req types.FinetuneReq)
 	if len(finetunedModelName) > 0 {
 		env["FINETUNED_MODEL_NAME"] = finetunedModelName
 	}
+	if req.Publish != nil {
+		// tell the trainer whether to upload the adapter only or merge it into the base model
+		env["FINETUNE_OUTPUT_ARTIFACT"] = string(req.Publish.Artifact)
+	}
 
 	common.UpdateEvaluationEnvHardware(env, req.Hardware)
 
@@ -1141,6 +1146,7 @@ func (d *deployer) SubmitFinetuneJob(ctx context.Context, req types.FinetuneReq)
 		ResourceName:       req.ResourceName,
 		FinetunedModelName: finetunedModelName,
 		Scheduler:          common.GenerateScheduler(cluster.VXPUConfig),
+		Secrets:            req.Secrets,
 		DeployExtend: types.DeployExtend{
 			NodeAffinity: req.NodeAffinity,
 			Tolerations:  req.Tolerations,
@@ -1182,7 +1188,7 @@ func (d *deployer) GetWorkflowLogsInStream(ctx context.Context, req types.Workfl
 		startTime = parseSinceTime(req.Since)
 	}
 
-	runLog, err := d.readLogsFromLoki(ctx, types.ReadLogRequest{
+	runLog, err := d.readLogs(ctx, types.ReadLogRequest{
 		DeployID:  req.PodName,
 		StartTime: startTime,
 		Labels:    labels,
@@ -1197,7 +1203,7 @@ func (d *deployer) GetWorkflowLogsInStream(ctx context.Context, req types.Workfl
 
 func (d *deployer) GetWorkflowLogsNonStream(ctx context.Context, req types.WorkflowLogReq, labels map[string]string) (*loki.LokiQueryResponse, error) {
 
-	query := d.lokiClient.GenerateLabelQuery(labels)
+	query := d.logSender.GenerateLabelQuery(labels)
 	var startTime = req.SubmitTime
 	if req.Since != "" {
 		startTime = parseSinceTime(req.Since)
@@ -1210,7 +1216,7 @@ func (d *deployer) GetWorkflowLogsNonStream(ctx context.Context, req types.Workf
 		Limit:     loki.MaxLimit,
 	}
 
-	return d.lokiClient.QueryRange(ctx, params)
+	return d.logSender.QueryRange(ctx, params)
 }
 
 func (d *deployer) CheckClusterHealthy(ctx context.Context, clusterId string) (bool, error) {
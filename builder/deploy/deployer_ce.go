@@ -40,7 +40,7 @@ type deployer struct {
 	deployConfig          common.DeployConfig
 	userStore             database.UserStore
 	clusterStore          database.ClusterInfoStore
-	lokiClient            sender.LogSender
+	logSender             sender.LogSender
 	logReporter           reporter.LogCollector
 	config                *config.Config
 }
@@ -67,7 +67,7 @@ func newDeployer(ib imagebuilder.Builder, ir imagerunner.Runner, c common.Deploy
 		deployConfig:          c,
 		userStore:             database.NewUserStore(),
 		clusterStore:          database.NewClusterInfoStore(),
-		lokiClient:            safeGetSender(logReporter),
+		logSender:             safeGetSender(logReporter),
 		logReporter:           logReporter,
 		argoWorkflowStore:     database.NewArgoWorkFlowStore(),
 		config:                config,
@@ -51,7 +51,7 @@ func TestDeployer_InstanceLastLogs(t *testing.T) {
 
 		d := &deployer{
 			deployTaskStore: mockDeployTaskStore,
-			lokiClient:      sender,
+			logSender:       sender,
 		}
 
 		dr := types.DeployRequest{
@@ -88,7 +88,7 @@ func TestDeployer_InstanceLastLogs(t *testing.T) {
 
 		d := &deployer{
 			deployTaskStore: mockDeployTaskStore,
-			lokiClient:      sender,
+			logSender:       sender,
 		}
 
 		dr := types.DeployRequest{
@@ -124,7 +124,7 @@ func TestDeployer_InstanceLastLogs(t *testing.T) {
 
 		d := &deployer{
 			deployTaskStore: mockDeployTaskStore,
-			lokiClient:      sender,
+			logSender:       sender,
 		}
 
 		dr := types.DeployRequest{
@@ -150,7 +150,7 @@ func TestDeployer_InstanceLastLogs(t *testing.T) {
 
 		d := &deployer{
 			deployTaskStore: mockDeployTaskStore,
-			lokiClient:      sender,
+			logSender:       sender,
 		}
 
 		dr := types.DeployRequest{
@@ -180,7 +180,7 @@ func TestDeployer_InstanceLastLogs(t *testing.T) {
 
 		d := &deployer{
 			deployTaskStore: mockDeployTaskStore,
-			lokiClient:      sender,
+			logSender:       sender,
 		}
 
 		dr := types.DeployRequest{
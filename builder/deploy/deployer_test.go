@@ -28,6 +28,7 @@ import (
 	"opencsg.com/csghub-server/common/config"
 	"opencsg.com/csghub-server/common/tests"
 	"opencsg.com/csghub-server/common/types"
+	"opencsg.com/csghub-server/component/reporter/sender"
 
 	corev1 "k8s.io/api/core/v1"
 )
@@ -503,7 +504,7 @@ func TestDeployer_Logs(t *testing.T) {
 			deployTaskStore: mockDeployTaskStore,
 			imageBuilder:    mockBuilder,
 			logReporter:     logReporter,
-			lokiClient:      sender,
+			logSender:       sender,
 		}
 
 		lreader, err := d.Logs(context.TODO(), dr)
@@ -663,7 +664,7 @@ func TestDeployer_InstanceLogs(t *testing.T) {
 	sender.EXPECT().StreamAllLogs(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, loki.MaxLimit).Return(ch, nil)
 	d := &deployer{
 		deployTaskStore: mockDeployTaskStore,
-		lokiClient:      sender,
+		logSender:       sender,
 	}
 	lreader, err := d.InstanceLogs(context.TODO(), dr)
 	require.Nil(t, err)
@@ -945,6 +946,8 @@ func TestDeployer_SubmitFinetune(t *testing.T) {
 				"DATASET_REVISION":        "dev",
 				"SWIFT_COMMAND":           "rlhf",
 			}, awfr.Templates[0].Env)
+			// the secrets are passed to the runner apart from the plain env
+			require.Equal(t, types.SecretValues{"WANDB_API_KEY": "wandb-key"}, awfr.Secrets)
 			return &types.ArgoWorkFlowRes{ID: 1}, nil
 		},
 	)
@@ -963,6 +966,7 @@ func TestDeployer_SubmitFinetune(t *testing.T) {
 		Revision:         "main",
 		DatasetRevision:  "dev",
 		SwiftCommand:     string(types.SwiftCommandRLHF),
+		Secrets:          types.SecretValues{"WANDB_API_KEY": "wandb-key"},
 	})
 	require.NoError(t, err)
 	require.Equal(t, &types.ArgoWorkFlowRes{ID: 1}, resp)
@@ -1027,7 +1031,7 @@ func TestDeployer_GetWorkflowLogsInStream(t *testing.T) {
 	sender.EXPECT().StreamAllLogs(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, loki.MaxLimit).Return(ch, nil)
 	d := &deployer{
 		deployTaskStore: mockDeployTaskStore,
-		lokiClient:      sender,
+		logSender:       sender,
 	}
 	lreader, err := d.GetWorkflowLogsInStream(context.TODO(), req, nil)
 	require.Nil(t, err)
@@ -1052,13 +1056,45 @@ func TestDeployer_GetWorkflowLogsNonStream(t *testing.T) {
 
 	d := &deployer{
 		deployTaskStore: mockDeployTaskStore,
-		lokiClient:      sender,
+		logSender:       sender,
 	}
 	resp, err := d.GetWorkflowLogsNonStream(context.TODO(), req, nil)
 	require.Nil(t, err)
 	require.NotNil(t, resp)
 }
 
+func TestDeployer_GetWorkflowLogsNonStream_FileBackend(t *testing.T) {
+	ctx := context.TODO()
+	cfg := &config.Config{}
+	cfg.TimeZone = "UTC"
+	cfg.LogCollector.Backend = sender.BackendFile
+	cfg.LogCollector.FileDir = t.TempDir()
+	logSender, err := sender.NewLogSender(types.ClientTypeCSGHUB, cfg)
+	require.Nil(t, err)
+
+	now := time.Now()
+	err = logSender.SendLogs(ctx, []types.LogEntry{
+		{
+			Timestamp: now.Add(-time.Minute),
+			Message:   "training started",
+			DeployID:  "pod1",
+			Category:  types.LogCategoryContainer,
+			PodInfo:   &types.PodInfo{PodName: "pod1"},
+		},
+	})
+	require.Nil(t, err)
+
+	d := &deployer{logSender: logSender}
+	req := types.WorkflowLogReq{
+		PodName:    "pod1",
+		SubmitTime: now.Add(-time.Hour),
+	}
+	resp, err := d.GetWorkflowLogsNonStream(ctx, req, map[string]string{types.StreamKeyInstanceName: "pod1"})
+	require.Nil(t, err)
+	require.Len(t, resp.Data.Result, 1)
+	require.Equal(t, "training started", resp.Data.Result[0].Values[0][1])
+}
+
 func TestDeployer_CheckHeartbeatTimeout(t *testing.T) {
 	ctx := context.TODO()
 	t.Run("should return true when a cluster times out", func(t *testing.T) {
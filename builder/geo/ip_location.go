@@ -5,8 +5,12 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"time"
 )
 
+// gaodeRequestTimeout bounds the ip location lookups, the callers wait for the lookups
+const gaodeRequestTimeout = 5 * time.Second
+
 type IPLocator interface {
 	GetIPLocation(ip string) (*IPLocation, error)
 }
@@ -20,24 +24,26 @@ type IPLocation struct {
 }
 
 type gaodeIPLocator struct {
-	host string
-	api  string
+	host   string
+	api    string
+	client *http.Client
 
 	Key string
 }
 
 func NewGaodeIPLocator(key string) IPLocator {
 	return &gaodeIPLocator{
-		host: "https://restapi.amap.com",
-		api:  "/v3/ip",
-		Key:  key,
+		host:   "https://restapi.amap.com",
+		api:    "/v3/ip",
+		client: &http.Client{Timeout: gaodeRequestTimeout},
+		Key:    key,
 	}
 }
 
 func (g *gaodeIPLocator) GetIPLocation(ip string) (*IPLocation, error) {
 	//see gaode api doc: https://lbs.amap.com/api/webservice/guide/api/ipconfig/
 	url := fmt.Sprintf("%s%s?ip=%s&key=%s", g.host, g.api, ip, g.Key)
-	resp, err := http.Get(url)
+	resp, err := g.client.Get(url)
 	if err != nil {
 		return nil, fmt.Errorf("failed to call gaode ip location api: %w", err)
 	}
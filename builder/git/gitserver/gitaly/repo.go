@@ -24,6 +24,7 @@ import (
 	"google.golang.org/grpc/metadata"
 	"opencsg.com/csghub-server/builder/git/gitserver"
 	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
 	"opencsg.com/csghub-server/common/utils/common"
 )
 
@@ -187,6 +188,57 @@ func (c *Client) GetArchive(ctx context.Context, req gitserver.GetArchiveReq) ([
 	return stripZipPrefix(buf.Bytes(), req.Name)
 }
 
+func (c *Client) GetArchiveReader(ctx context.Context, req gitserver.GetArchiveReq) (io.ReadCloser, error) {
+	format := gitalypb.GetArchiveRequest_ZIP
+	switch req.Format {
+	case "", types.ArchiveFormatZip:
+	case types.ArchiveFormatTarGz:
+		format = gitalypb.GetArchiveRequest_TAR_GZ
+	default:
+		return nil, errorx.BadRequest(fmt.Errorf("unsupported archive format %s", req.Format), nil)
+	}
+
+	relativePath, err := c.BuildRelativePath(ctx, req.RepoType, req.Namespace, req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	// no timeout here as it would break the download stream, like GetRepoFileReader
+	stream, err := c.repoClient.GetArchive(ctx, &gitalypb.GetArchiveRequest{
+		Repository: &gitalypb.Repository{
+			StorageName:  c.config.GitalyServer.Storage,
+			RelativePath: relativePath,
+		},
+		CommitId: req.Revision,
+		Prefix:   req.Name,
+		Format:   format,
+		Path:     []byte("."),
+	})
+	if err != nil {
+		return nil, errorx.GetArchiveFailed(err, errorx.Ctx().Set("namespace", req.Namespace).Set("name", req.Name).Set("revision", req.Revision))
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		defer pw.Close()
+		for {
+			resp, err := stream.Recv()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				pw.CloseWithError(errorx.GetArchiveFailed(err, errorx.Ctx().Set("namespace", req.Namespace).Set("name", req.Name).Set("revision", req.Revision)))
+				return
+			}
+			if _, err := pw.Write(resp.GetData()); err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+		}
+	}()
+	return pr, nil
+}
+
 func stripZipPrefix(zipData []byte, prefix string) ([]byte, error) {
 	zipReader, err := zip.NewReader(bytes.NewReader(zipData), int64(len(zipData)))
 	if err != nil {
@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"testing"
 	"time"
 
@@ -14,6 +15,7 @@ import (
 	gitalypb_mock "opencsg.com/csghub-server/_mocks/gitlab.com/gitlab-org/gitaly/v16/proto/go/gitalypb"
 	"opencsg.com/csghub-server/builder/git/gitserver"
 	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/errorx"
 	"opencsg.com/csghub-server/common/types"
 )
 
@@ -555,3 +557,44 @@ func TestGitalyRepo_GetLastCommitSize(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+func TestGitalyRepo_GetArchiveReader(t *testing.T) {
+	tester := newGitalyTester(t)
+	ctx := context.TODO()
+
+	tester.mocks.repoStore.EXPECT().FindByPath(mock.Anything, types.ModelRepo, "ns", "n").Return(&database.Repository{
+		ID:     1,
+		Hashed: false,
+	}, nil)
+	tester.mocks.repoClient.EXPECT().GetArchive(mock.Anything, &gitalypb.GetArchiveRequest{
+		Repository: &gitalypb.Repository{
+			StorageName:  "st",
+			RelativePath: "models_ns/n.git",
+		},
+		CommitId: "commit-id",
+		Prefix:   "n",
+		Format:   gitalypb.GetArchiveRequest_TAR_GZ,
+		Path:     []byte("."),
+	}).Return(&MockGrpcStreamClient[*gitalypb.GetArchiveResponse]{
+		data: []*gitalypb.GetArchiveResponse{
+			{Data: []byte("part1")},
+			{Data: []byte("part2")},
+		},
+	}, nil)
+
+	reader, err := tester.GetArchiveReader(ctx, gitserver.GetArchiveReq{
+		Namespace: "ns",
+		Name:      "n",
+		Revision:  "commit-id",
+		RepoType:  types.ModelRepo,
+		Format:    types.ArchiveFormatTarGz,
+	})
+	require.NoError(t, err)
+	defer reader.Close()
+	content, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	require.Equal(t, "part1part2", string(content))
+
+	_, err = tester.GetArchiveReader(ctx, gitserver.GetArchiveReq{Namespace: "ns", Name: "n", Format: "rar"})
+	require.ErrorIs(t, err, errorx.ErrBadRequest)
+}
@@ -0,0 +1,133 @@
+package gitaly
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"gitlab.com/gitlab-org/gitaly/v16/proto/go/gitalypb"
+	"opencsg.com/csghub-server/builder/git/gitserver"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+)
+
+func (c *Client) GetRepoTags(ctx context.Context, req gitserver.GetTagsReq) ([]types.Tag, error) {
+	var tags []types.Tag
+	relativePath, err := c.BuildRelativePath(ctx, req.RepoType, req.Namespace, req.Name)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	stream, err := c.refClient.FindAllTags(ctx, &gitalypb.FindAllTagsRequest{
+		Repository: &gitalypb.Repository{
+			StorageName:  c.config.GitalyServer.Storage,
+			RelativePath: relativePath,
+		},
+	})
+	if err != nil {
+		return nil, errorx.FindTagFailed(err, errorx.Ctx().
+			Set("repo_type", req.RepoType).
+			Set("path", relativePath),
+		)
+	}
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, errorx.FindTagFailed(err, errorx.Ctx().
+				Set("repo_type", req.RepoType).
+				Set("path", relativePath),
+			)
+		}
+		if resp == nil {
+			continue
+		}
+		for _, tag := range resp.Tags {
+			// the id of an annotated tag is the tag object, use the commit it points to instead
+			commitID := tag.Id
+			if tag.TargetCommit != nil {
+				commitID = tag.TargetCommit.Id
+			}
+			tags = append(tags, types.Tag{
+				Name:    string(tag.Name),
+				Message: string(tag.Message),
+				Commit: types.DatasetTagCommit{
+					ID: commitID,
+				},
+			})
+		}
+	}
+
+	return tags, nil
+}
+
+func (c *Client) CreateRepoTag(ctx context.Context, req gitserver.CreateTagReq) error {
+	repoType := fmt.Sprintf("%ss", string(req.RepoType))
+	relativePath, err := c.BuildRelativePath(ctx, req.RepoType, req.Namespace, req.Name)
+	if err != nil {
+		return err
+	}
+	createTagReq := &gitalypb.UserCreateTagRequest{
+		Repository: &gitalypb.Repository{
+			StorageName:  c.config.GitalyServer.Storage,
+			RelativePath: relativePath,
+			GlRepository: filepath.Join(repoType, req.Namespace, req.Name),
+		},
+		TagName:        []byte(req.TagName),
+		TargetRevision: []byte(req.Revision),
+		Message:        []byte(req.Message),
+		User: &gitalypb.User{
+			GlId:       "user-1",
+			Name:       []byte(req.Username),
+			GlUsername: req.Username,
+			Email:      []byte(req.Email),
+		},
+	}
+
+	_, err = c.operationClient.UserCreateTag(ctx, createTagReq)
+	if err != nil {
+		return errorx.CreateTagFailed(err, errorx.Ctx().
+			Set("repo_type", req.RepoType).
+			Set("path", relativePath).
+			Set("tag", req.TagName).
+			Set("revision", req.Revision),
+		)
+	}
+	return nil
+}
+
+func (c *Client) DeleteRepoTag(ctx context.Context, req gitserver.DeleteTagReq) error {
+	repoType := fmt.Sprintf("%ss", string(req.RepoType))
+	relativePath, err := c.BuildRelativePath(ctx, req.RepoType, req.Namespace, req.Name)
+	if err != nil {
+		return err
+	}
+	deleteTagReq := &gitalypb.UserDeleteTagRequest{
+		Repository: &gitalypb.Repository{
+			StorageName:  c.config.GitalyServer.Storage,
+			RelativePath: relativePath,
+			GlRepository: filepath.Join(repoType, req.Namespace, req.Name),
+		},
+		TagName: []byte(req.TagName),
+		User: &gitalypb.User{
+			GlId:       "user-1",
+			Name:       []byte(req.Username),
+			GlUsername: req.Username,
+			Email:      []byte(req.Email),
+		},
+	}
+
+	_, err = c.operationClient.UserDeleteTag(ctx, deleteTagReq)
+	if err != nil {
+		return errorx.DeleteTagFailed(err, errorx.Ctx().
+			Set("repo_type", req.RepoType).
+			Set("path", relativePath).
+			Set("tag", req.TagName),
+		)
+	}
+	return nil
+}
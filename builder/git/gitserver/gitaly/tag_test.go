@@ -0,0 +1,116 @@
+package gitaly
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gitlab.com/gitlab-org/gitaly/v16/proto/go/gitalypb"
+	"opencsg.com/csghub-server/builder/git/gitserver"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/types"
+)
+
+func TestGitalyTag_GetRepoTags(t *testing.T) {
+	tester := newGitalyTester(t)
+	ctx := context.TODO()
+
+	tester.mocks.repoStore.EXPECT().FindByPath(mock.Anything, types.ModelRepo, "ns", "n").Return(&database.Repository{
+		ID: 1,
+	}, nil)
+	tester.mocks.refClient.EXPECT().FindAllTags(mock.Anything, &gitalypb.FindAllTagsRequest{
+		Repository: &gitalypb.Repository{
+			StorageName:  "st",
+			RelativePath: "models_ns/n.git",
+		},
+	}).Return(&MockGrpcStreamClient[*gitalypb.FindAllTagsResponse]{
+		data: []*gitalypb.FindAllTagsResponse{
+			{Tags: []*gitalypb.Tag{
+				{Name: []byte("v1"), Id: "c1"},
+				{Name: []byte("v2"), Id: "t2", Message: []byte("release"), TargetCommit: &gitalypb.GitCommit{Id: "c2"}},
+			}},
+		},
+	}, nil)
+
+	tags, err := tester.GetRepoTags(ctx, gitserver.GetTagsReq{
+		Namespace: "ns",
+		Name:      "n",
+		RepoType:  types.ModelRepo,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []types.Tag{
+		{Name: "v1", Commit: types.DatasetTagCommit{ID: "c1"}},
+		{Name: "v2", Message: "release", Commit: types.DatasetTagCommit{ID: "c2"}},
+	}, tags)
+}
+
+func TestGitalyTag_CreateRepoTag(t *testing.T) {
+	tester := newGitalyTester(t)
+	ctx := context.TODO()
+
+	tester.mocks.repoStore.EXPECT().FindByPath(mock.Anything, types.ModelRepo, "ns", "n").Return(&database.Repository{
+		ID: 1,
+	}, nil)
+	tester.mocks.operationClient.EXPECT().UserCreateTag(ctx, &gitalypb.UserCreateTagRequest{
+		Repository: &gitalypb.Repository{
+			StorageName:  "st",
+			RelativePath: "models_ns/n.git",
+			GlRepository: "models/ns/n",
+		},
+		TagName:        []byte("v1"),
+		TargetRevision: []byte("main"),
+		Message:        []byte("release"),
+		User: &gitalypb.User{
+			GlId:       "user-1",
+			Name:       []byte("user"),
+			GlUsername: "user",
+			Email:      []byte("user@example.com"),
+		},
+	}).Return(&gitalypb.UserCreateTagResponse{}, nil)
+
+	err := tester.CreateRepoTag(ctx, gitserver.CreateTagReq{
+		Namespace: "ns",
+		Name:      "n",
+		RepoType:  types.ModelRepo,
+		TagName:   "v1",
+		Revision:  "main",
+		Message:   "release",
+		Username:  "user",
+		Email:     "user@example.com",
+	})
+	require.NoError(t, err)
+}
+
+func TestGitalyTag_DeleteRepoTag(t *testing.T) {
+	tester := newGitalyTester(t)
+	ctx := context.TODO()
+
+	tester.mocks.repoStore.EXPECT().FindByPath(mock.Anything, types.ModelRepo, "ns", "n").Return(&database.Repository{
+		ID: 1,
+	}, nil)
+	tester.mocks.operationClient.EXPECT().UserDeleteTag(ctx, &gitalypb.UserDeleteTagRequest{
+		Repository: &gitalypb.Repository{
+			StorageName:  "st",
+			RelativePath: "models_ns/n.git",
+			GlRepository: "models/ns/n",
+		},
+		TagName: []byte("v1"),
+		User: &gitalypb.User{
+			GlId:       "user-1",
+			Name:       []byte("user"),
+			GlUsername: "user",
+			Email:      []byte("user@example.com"),
+		},
+	}).Return(&gitalypb.UserDeleteTagResponse{}, nil)
+
+	err := tester.DeleteRepoTag(ctx, gitserver.DeleteTagReq{
+		Namespace: "ns",
+		Name:      "n",
+		RepoType:  types.ModelRepo,
+		TagName:   "v1",
+		Username:  "user",
+		Email:     "user@example.com",
+	})
+	require.NoError(t, err)
+}
@@ -23,11 +23,17 @@ type GitServer interface {
 	GetRepoBranchByName(ctx context.Context, req GetBranchReq) (*types.Branch, error)
 	DeleteRepoBranch(ctx context.Context, req DeleteBranchReq) error
 	SetDefaultBranch(ctx context.Context, req SetDefaultBranchReq) error
+	GetRepoTags(ctx context.Context, req GetTagsReq) ([]types.Tag, error)
+	CreateRepoTag(ctx context.Context, req CreateTagReq) error
+	DeleteRepoTag(ctx context.Context, req DeleteTagReq) error
 	GetRepoCommits(ctx context.Context, req GetRepoCommitsReq) ([]types.Commit, *types.RepoPageOpts, error)
 	GetRepoLastCommit(ctx context.Context, req GetRepoLastCommitReq) (*types.Commit, error)
 	GetSingleCommit(ctx context.Context, req GetRepoLastCommitReq) (*types.CommitResponse, error)
 	GetCommitDiff(ctx context.Context, req GetRepoLastCommitReq) ([]byte, error)
 	GetArchive(ctx context.Context, req GetArchiveReq) ([]byte, error)
+	// GetArchiveReader streams the archive of a revision, the entries are prefixed with the repo name
+	// and LFS files are archived as pointer files
+	GetArchiveReader(ctx context.Context, req GetArchiveReq) (io.ReadCloser, error)
 	GetRepoFileTree(ctx context.Context, req GetRepoInfoByPathReq) ([]*types.File, error)
 	GetTree(ctx context.Context, req types.GetTreeRequest) (*types.GetRepoFileTreeResp, error)
 	GetLogsTree(ctx context.Context, req types.GetLogsTreeRequest) (*types.LogsTreeResp, error)
@@ -140,6 +140,34 @@ type DeleteBranchReq struct {
 	Email     string               `json:"email"`
 }
 
+type GetTagsReq struct {
+	Namespace string               `json:"namespace"`
+	Name      string               `json:"name"`
+	RepoType  types.RepositoryType `json:"repo_type"`
+}
+
+type CreateTagReq struct {
+	Namespace string               `json:"namespace"`
+	Name      string               `json:"name"`
+	RepoType  types.RepositoryType `json:"repo_type"`
+	TagName   string               `json:"tag_name"`
+	// Revision is the branch, tag or commit id the new tag points to
+	Revision string `json:"revision"`
+	// Message makes the tag an annotated tag if not empty
+	Message  string `json:"message"`
+	Username string `json:"username"`
+	Email    string `json:"email"`
+}
+
+type DeleteTagReq struct {
+	Namespace string               `json:"namespace"`
+	Name      string               `json:"name"`
+	RepoType  types.RepositoryType `json:"repo_type"`
+	TagName   string               `json:"tag_name"`
+	Username  string               `json:"username"`
+	Email     string               `json:"email"`
+}
+
 type GetRepoCommitsReq struct {
 	Namespace string               `json:"namespace"`
 	Name      string               `json:"name"`
@@ -164,6 +192,8 @@ type GetArchiveReq struct {
 	Name      string               `json:"name"`
 	Revision  string               `json:"revision"`
 	RepoType  types.RepositoryType `json:"repo_type"`
+	// Format is only used by GetArchiveReader, GetArchive always returns a zip archive
+	Format types.ArchiveFormat `json:"format"`
 }
 
 // GetDiffBetweenTwoCommitsReq identifies the repository and revisions used to build a push callback.
@@ -0,0 +1,87 @@
+package importer
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+type giteaImporter struct {
+	client *http.Client
+}
+
+type giteaRepository struct {
+	Name          string   `json:"name"`
+	FullName      string   `json:"full_name"`
+	CloneURL      string   `json:"clone_url"`
+	Private       bool     `json:"private"`
+	Description   string   `json:"description"`
+	Topics        []string `json:"topics"`
+	DefaultBranch string   `json:"default_branch"`
+	// Licenses is detected since gitea 1.22
+	Licenses []string `json:"licenses"`
+}
+
+func (r giteaRepository) toRepository() Repository {
+	repo := Repository{
+		Name:          r.Name,
+		Path:          r.FullName,
+		ImportUrl:     r.CloneURL,
+		Private:       r.Private,
+		Description:   r.Description,
+		Topics:        r.Topics,
+		DefaultBranch: r.DefaultBranch,
+	}
+	if len(r.Licenses) > 0 {
+		repo.License = strings.ToLower(r.Licenses[0])
+	}
+	return repo
+}
+
+func (i *giteaImporter) header(accessToken string) http.Header {
+	header := http.Header{}
+	header.Set("Authorization", "token "+accessToken)
+	return header
+}
+
+// GetRepositoryList lists the repositories of the user, the user repositories api can not filter by name
+func (i *giteaImporter) GetRepositoryList(ctx context.Context, baseURL, accessToken, search string, page, per int) ([]Repository, error) {
+	return listRepositories(func(page, per int) ([]Repository, error) {
+		query := url.Values{}
+		query.Set("page", strconv.Itoa(page))
+		query.Set("limit", strconv.Itoa(per))
+		var resp []giteaRepository
+		err := doJSON(ctx, i.client, http.MethodGet, apiURL(baseURL, "/api/v1/user/repos", query), i.header(accessToken), nil, &resp)
+		if err != nil {
+			return nil, err
+		}
+		repos := make([]Repository, 0, len(resp))
+		for _, r := range resp {
+			repos = append(repos, r.toRepository())
+		}
+		return repos, nil
+	}, search, page, per)
+}
+
+func (i *giteaImporter) GetRepository(ctx context.Context, baseURL, accessToken, path string) (*Repository, error) {
+	var resp giteaRepository
+	err := doJSON(ctx, i.client, http.MethodGet, apiURL(baseURL, "/api/v1/repos/"+path, nil), i.header(accessToken), nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	repo := resp.toRepository()
+	return &repo, nil
+}
+
+func (i *giteaImporter) GetUser(ctx context.Context, baseURL, accessToken string) (*User, error) {
+	var resp struct {
+		Login string `json:"login"`
+	}
+	err := doJSON(ctx, i.client, http.MethodGet, apiURL(baseURL, "/api/v1/user", nil), i.header(accessToken), nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &User{Username: resp.Login}, nil
+}
@@ -0,0 +1,93 @@
+package importer
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// githubImporter imports from github.com or a GitHub Enterprise server, the base url is the api
+// root, e.g. https://api.github.com or https://github.example.com/api/v3
+type githubImporter struct {
+	client *http.Client
+}
+
+type githubRepository struct {
+	Name          string   `json:"name"`
+	FullName      string   `json:"full_name"`
+	CloneURL      string   `json:"clone_url"`
+	Private       bool     `json:"private"`
+	Description   string   `json:"description"`
+	Topics        []string `json:"topics"`
+	DefaultBranch string   `json:"default_branch"`
+	License       *struct {
+		SpdxID string `json:"spdx_id"`
+	} `json:"license"`
+}
+
+func (r githubRepository) toRepository() Repository {
+	repo := Repository{
+		Name:          r.Name,
+		Path:          r.FullName,
+		ImportUrl:     r.CloneURL,
+		Private:       r.Private,
+		Description:   r.Description,
+		Topics:        r.Topics,
+		DefaultBranch: r.DefaultBranch,
+	}
+	// NOASSERTION is returned for the licenses github can not recognize
+	if r.License != nil && r.License.SpdxID != "NOASSERTION" {
+		repo.License = strings.ToLower(r.License.SpdxID)
+	}
+	return repo
+}
+
+func (i *githubImporter) header(accessToken string) http.Header {
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+accessToken)
+	header.Set("X-GitHub-Api-Version", "2022-11-28")
+	return header
+}
+
+// GetRepositoryList lists the repositories of the user, the user repositories api can not filter by name
+func (i *githubImporter) GetRepositoryList(ctx context.Context, baseURL, accessToken, search string, page, per int) ([]Repository, error) {
+	return listRepositories(func(page, per int) ([]Repository, error) {
+		query := url.Values{}
+		query.Set("page", strconv.Itoa(page))
+		query.Set("per_page", strconv.Itoa(per))
+		query.Set("sort", "updated")
+		var resp []githubRepository
+		err := doJSON(ctx, i.client, http.MethodGet, apiURL(baseURL, "/user/repos", query), i.header(accessToken), nil, &resp)
+		if err != nil {
+			return nil, err
+		}
+		repos := make([]Repository, 0, len(resp))
+		for _, r := range resp {
+			repos = append(repos, r.toRepository())
+		}
+		return repos, nil
+	}, search, page, per)
+}
+
+func (i *githubImporter) GetRepository(ctx context.Context, baseURL, accessToken, path string) (*Repository, error) {
+	var resp githubRepository
+	err := doJSON(ctx, i.client, http.MethodGet, apiURL(baseURL, "/repos/"+path, nil), i.header(accessToken), nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	repo := resp.toRepository()
+	return &repo, nil
+}
+
+func (i *githubImporter) GetUser(ctx context.Context, baseURL, accessToken string) (*User, error) {
+	var resp struct {
+		Login string `json:"login"`
+	}
+	err := doJSON(ctx, i.client, http.MethodGet, apiURL(baseURL, "/user", nil), i.header(accessToken), nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &User{Username: resp.Login}, nil
+}
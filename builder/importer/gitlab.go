@@ -0,0 +1,93 @@
+package importer
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+type gitlabImporter struct {
+	client *http.Client
+}
+
+type gitlabProject struct {
+	Name              string   `json:"name"`
+	PathWithNamespace string   `json:"path_with_namespace"`
+	HTTPURLToRepo     string   `json:"http_url_to_repo"`
+	Visibility        string   `json:"visibility"`
+	Description       string   `json:"description"`
+	Topics            []string `json:"topics"`
+	DefaultBranch     string   `json:"default_branch"`
+	License           *struct {
+		Key string `json:"key"`
+	} `json:"license"`
+}
+
+func (p gitlabProject) toRepository() Repository {
+	repo := Repository{
+		Name:          p.Name,
+		Path:          p.PathWithNamespace,
+		ImportUrl:     p.HTTPURLToRepo,
+		Private:       p.Visibility != "public",
+		Description:   p.Description,
+		Topics:        p.Topics,
+		DefaultBranch: p.DefaultBranch,
+	}
+	if p.License != nil {
+		repo.License = p.License.Key
+	}
+	return repo
+}
+
+func (i *gitlabImporter) header(accessToken string) http.Header {
+	header := http.Header{}
+	header.Set("PRIVATE-TOKEN", accessToken)
+	return header
+}
+
+func (i *gitlabImporter) GetRepositoryList(ctx context.Context, baseURL, accessToken, search string, page, per int) ([]Repository, error) {
+	page, per = normalizePaging(page, per)
+	query := url.Values{}
+	query.Set("membership", "true")
+	query.Set("page", strconv.Itoa(page))
+	query.Set("per_page", strconv.Itoa(per))
+	query.Set("order_by", "last_activity_at")
+	if search != "" {
+		query.Set("search", search)
+	}
+	var resp []gitlabProject
+	err := doJSON(ctx, i.client, http.MethodGet, apiURL(baseURL, "/api/v4/projects", query), i.header(accessToken), nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	repos := make([]Repository, 0, len(resp))
+	for _, p := range resp {
+		repos = append(repos, p.toRepository())
+	}
+	return repos, nil
+}
+
+func (i *gitlabImporter) GetRepository(ctx context.Context, baseURL, accessToken, path string) (*Repository, error) {
+	query := url.Values{}
+	query.Set("license", "true")
+	var resp gitlabProject
+	rawURL := apiURL(baseURL, "/api/v4/projects/"+url.PathEscape(path), query)
+	err := doJSON(ctx, i.client, http.MethodGet, rawURL, i.header(accessToken), nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	repo := resp.toRepository()
+	return &repo, nil
+}
+
+func (i *gitlabImporter) GetUser(ctx context.Context, baseURL, accessToken string) (*User, error) {
+	var resp struct {
+		Username string `json:"username"`
+	}
+	err := doJSON(ctx, i.client, http.MethodGet, apiURL(baseURL, "/api/v4/user", nil), i.header(accessToken), nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &User{Username: resp.Username}, nil
+}
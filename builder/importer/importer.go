@@ -1,17 +1,204 @@
 package importer
 
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"opencsg.com/csghub-server/builder/credential"
+	"opencsg.com/csghub-server/common/types"
+)
+
+const (
+	// maxPageSize is the page size limit of most platforms
+	maxPageSize = 100
+	// maxSearchPages caps the pages scanned to search the platforms which can not filter the listing by name
+	maxSearchPages = 10
+)
+
+// ErrUnauthorized reports an access token rejected by the remote platform
+var ErrUnauthorized = errors.New("access token is rejected by the remote platform")
+
 type Importer interface {
-	GetRepositoryList(baseURL, accessToken, search string, page, per int) ([]Repository, error)
-	GetUser(baseURL, accessToken string) (*User, error)
+	// GetRepositoryList lists the repositories the owner of the access token can access
+	GetRepositoryList(ctx context.Context, baseURL, accessToken, search string, page, per int) ([]Repository, error)
+	// GetRepository returns the repository of the path, e.g. owner/name
+	GetRepository(ctx context.Context, baseURL, accessToken, path string) (*Repository, error)
+	GetUser(ctx context.Context, baseURL, accessToken string) (*User, error)
 }
 
 type Repository struct {
-	Name      string `json:"name"`
-	Path      string `json:"path"`
-	ImportUrl string `json:"import_url"`
-	Private   bool   `json:"private"`
+	Name          string   `json:"name"`
+	Path          string   `json:"path"`
+	ImportUrl     string   `json:"import_url"`
+	Private       bool     `json:"private"`
+	Description   string   `json:"description"`
+	Topics        []string `json:"topics"`
+	License       string   `json:"license"`
+	DefaultBranch string   `json:"default_branch"`
 }
 
 type User struct {
 	Username string `json:"username"`
 }
+
+// New returns the importer of the provider, the base urls are given by the users so the requests to the
+// internal addresses are rejected
+func New(provider types.ImportProvider) (Importer, error) {
+	return newImporter(provider, credential.NewVerifyClient())
+}
+
+func newImporter(provider types.ImportProvider, client *http.Client) (Importer, error) {
+	switch provider {
+	case types.ImportProviderGitLab:
+		return &gitlabImporter{client: client}, nil
+	case types.ImportProviderGitHub:
+		return &githubImporter{client: client}, nil
+	case types.ImportProviderGitea:
+		return &giteaImporter{client: client}, nil
+	case types.ImportProviderModelScope:
+		return &modelscopeImporter{client: client}, nil
+	default:
+		return nil, fmt.Errorf("unsupported import provider: %s", provider)
+	}
+}
+
+// DefaultBaseURL returns the public site of the provider, it is used when no base url is given
+func DefaultBaseURL(provider types.ImportProvider) string {
+	switch provider {
+	case types.ImportProviderGitLab:
+		return "https://gitlab.com"
+	case types.ImportProviderGitHub:
+		return "https://api.github.com"
+	case types.ImportProviderGitea:
+		return "https://gitea.com"
+	case types.ImportProviderModelScope:
+		return "https://www.modelscope.cn"
+	default:
+		return ""
+	}
+}
+
+// doJSON sends the request to the api of the remote platform and decodes the json response into out
+func doJSON(ctx context.Context, client *http.Client, method, rawURL string, header http.Header, body io.Reader, out any) error {
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, body)
+	if err != nil {
+		return fmt.Errorf("failed to create request, error: %w", err)
+	}
+	for key, values := range header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to request %s, error: %w", redact(req.URL), err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return fmt.Errorf("%w, status: %d", ErrUnauthorized, resp.StatusCode)
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("failed to request %s, status: %d, body: %s", redact(req.URL), resp.StatusCode, msg)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response of %s, error: %w", redact(req.URL), err)
+	}
+	return nil
+}
+
+// CheckImportURL checks the git url of the remote repository is served by the host of the base url, the
+// api host of GitHub, e.g. api.github.com, serves the git urls of github.com
+func CheckImportURL(baseURL, importURL string) error {
+	base, err := url.Parse(baseURL)
+	if err != nil {
+		return fmt.Errorf("invalid base url, error: %w", err)
+	}
+	u, err := url.Parse(importURL)
+	if err != nil {
+		return fmt.Errorf("invalid import url, error: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("unsupported scheme of import url: %q", u.Scheme)
+	}
+	host, baseHost := strings.ToLower(u.Hostname()), strings.ToLower(base.Hostname())
+	if host == "" || (host != baseHost && "api."+host != baseHost) {
+		return fmt.Errorf("host of import url %q does not match the base url %q", host, baseHost)
+	}
+	return nil
+}
+
+func apiURL(baseURL, path string, query url.Values) string {
+	u := strings.TrimSuffix(baseURL, "/") + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	return u
+}
+
+func redact(u *url.URL) string {
+	c := *u
+	c.RawQuery = ""
+	return c.Redacted()
+}
+
+// normalizePaging applies the defaults of the page and page size, the page size is capped at maxPageSize
+func normalizePaging(page, per int) (int, int) {
+	if page <= 0 {
+		page = 1
+	}
+	if per <= 0 {
+		per = 20
+	}
+	if per > maxPageSize {
+		per = maxPageSize
+	}
+	return page, per
+}
+
+// listRepositories returns the page of the repositories, the search is applied by paging through the
+// listing of the remote platform as list can not filter by name, at most maxSearchPages are scanned
+func listRepositories(list func(page, per int) ([]Repository, error), search string, page, per int) ([]Repository, error) {
+	page, per = normalizePaging(page, per)
+	if search == "" {
+		return list(page, per)
+	}
+
+	search = strings.ToLower(search)
+	skip := (page - 1) * per
+	repos := make([]Repository, 0, per)
+	for p := 1; p <= maxSearchPages; p++ {
+		items, err := list(p, maxPageSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, repo := range items {
+			if !strings.Contains(strings.ToLower(repo.Path), search) {
+				continue
+			}
+			if skip > 0 {
+				skip--
+				continue
+			}
+			repos = append(repos, repo)
+			if len(repos) == per {
+				return repos, nil
+			}
+		}
+		if len(items) < maxPageSize {
+			break
+		}
+	}
+	return repos, nil
+}
@@ -0,0 +1,226 @@
+package importer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"opencsg.com/csghub-server/common/types"
+)
+
+func newTestServer(t *testing.T, header, token string, routes map[string]any) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(header) != token {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		resp, ok := routes[r.Method+" "+r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(resp))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestNew(t *testing.T) {
+	for _, provider := range []types.ImportProvider{
+		types.ImportProviderGitLab, types.ImportProviderGitHub, types.ImportProviderGitea, types.ImportProviderModelScope,
+	} {
+		imp, err := New(provider)
+		require.NoError(t, err)
+		require.NotNil(t, imp)
+		require.NotEmpty(t, DefaultBaseURL(provider))
+	}
+	_, err := New("svn")
+	require.Error(t, err)
+}
+
+func TestGithubImporter(t *testing.T) {
+	server := newTestServer(t, "Authorization", "Bearer good", map[string]any{
+		"GET /user": map[string]any{"login": "octo"},
+		"GET /user/repos": []map[string]any{
+			{"name": "foo", "full_name": "octo/foo", "clone_url": "https://github.com/octo/foo.git", "private": true,
+				"topics": []string{"nlp"}, "license": map[string]any{"spdx_id": "MIT"}, "default_branch": "main"},
+			{"name": "bar", "full_name": "octo/bar", "license": map[string]any{"spdx_id": "NOASSERTION"}},
+		},
+		"GET /repos/octo/foo": map[string]any{"name": "foo", "full_name": "octo/foo", "description": "desc"},
+	})
+	ctx := context.TODO()
+	imp, err := newImporter(types.ImportProviderGitHub, server.Client())
+	require.NoError(t, err)
+
+	user, err := imp.GetUser(ctx, server.URL, "good")
+	require.NoError(t, err)
+	require.Equal(t, "octo", user.Username)
+
+	repos, err := imp.GetRepositoryList(ctx, server.URL, "good", "", 1, 10)
+	require.NoError(t, err)
+	require.Equal(t, []Repository{
+		{Name: "foo", Path: "octo/foo", ImportUrl: "https://github.com/octo/foo.git", Private: true,
+			Topics: []string{"nlp"}, License: "mit", DefaultBranch: "main"},
+		{Name: "bar", Path: "octo/bar"},
+	}, repos)
+
+	repos, err = imp.GetRepositoryList(ctx, server.URL, "good", "BAR", 1, 10)
+	require.NoError(t, err)
+	require.Len(t, repos, 1)
+
+	repo, err := imp.GetRepository(ctx, server.URL, "good", "octo/foo")
+	require.NoError(t, err)
+	require.Equal(t, "desc", repo.Description)
+
+	_, err = imp.GetUser(ctx, server.URL, "bad")
+	require.ErrorIs(t, err, ErrUnauthorized)
+}
+
+func TestGitlabImporter(t *testing.T) {
+	server := newTestServer(t, "PRIVATE-TOKEN", "good", map[string]any{
+		"GET /api/v4/projects": []map[string]any{
+			{"name": "foo", "path_with_namespace": "group/foo", "http_url_to_repo": "https://gitlab.com/group/foo.git",
+				"visibility": "internal", "topics": []string{"cv"}},
+		},
+		"GET /api/v4/projects/group/foo": map[string]any{"name": "foo", "path_with_namespace": "group/foo",
+			"visibility": "public", "license": map[string]any{"key": "apache-2.0"}},
+	})
+	ctx := context.TODO()
+	imp, err := newImporter(types.ImportProviderGitLab, server.Client())
+	require.NoError(t, err)
+
+	repos, err := imp.GetRepositoryList(ctx, server.URL, "good", "foo", 1, 10)
+	require.NoError(t, err)
+	require.Equal(t, []Repository{
+		{Name: "foo", Path: "group/foo", ImportUrl: "https://gitlab.com/group/foo.git", Private: true, Topics: []string{"cv"}},
+	}, repos)
+
+	repo, err := imp.GetRepository(ctx, server.URL, "good", "group/foo")
+	require.NoError(t, err)
+	require.False(t, repo.Private)
+	require.Equal(t, "apache-2.0", repo.License)
+}
+
+func TestGiteaImporter(t *testing.T) {
+	server := newTestServer(t, "Authorization", "token good", map[string]any{
+		"GET /api/v1/user": map[string]any{"login": "tea"},
+		"GET /api/v1/repos/tea/foo": map[string]any{"name": "foo", "full_name": "tea/foo",
+			"clone_url": "https://gitea.com/tea/foo.git", "licenses": []string{"MIT"}},
+	})
+	ctx := context.TODO()
+	imp, err := newImporter(types.ImportProviderGitea, server.Client())
+	require.NoError(t, err)
+
+	user, err := imp.GetUser(ctx, server.URL, "good")
+	require.NoError(t, err)
+	require.Equal(t, "tea", user.Username)
+
+	repo, err := imp.GetRepository(ctx, server.URL, "good", "tea/foo")
+	require.NoError(t, err)
+	require.Equal(t, "mit", repo.License)
+	require.Equal(t, "https://gitea.com/tea/foo.git", repo.ImportUrl)
+}
+
+func TestModelscopeImporter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		switch r.Method + " " + r.URL.Path {
+		case "POST /api/v1/login":
+			require.JSONEq(t, `{"AccessToken":"good"}`, string(body))
+			_, _ = w.Write([]byte(`{"Code":200,"Data":{"Username":"ms"}}`))
+		case "PUT /api/v1/models/":
+			require.JSONEq(t, `{"Path":"ms","PageNumber":1,"PageSize":10}`, string(body))
+			_, _ = w.Write([]byte(`{"Code":200,"Data":{"Models":[
+				{"Name":"foo","Path":"ms","Visibility":5,"License":"Apache-2.0","Tags":["nlp",{"Name":"llm"}]},
+				{"Name":"bar","Path":"ms","Visibility":1}]}}`))
+		case "GET /api/v1/models/ms/missing":
+			_, _ = w.Write([]byte(`{"Code":10010205001,"Message":"model not found"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	ctx := context.TODO()
+	imp, err := newImporter(types.ImportProviderModelScope, server.Client())
+	require.NoError(t, err)
+
+	repos, err := imp.GetRepositoryList(ctx, server.URL, "good", "", 1, 10)
+	require.NoError(t, err)
+	require.Equal(t, []Repository{
+		{Name: "foo", Path: "ms/foo", ImportUrl: server.URL + "/ms/foo.git", License: "apache-2.0",
+			Topics: []string{"nlp", "llm"}, DefaultBranch: "master"},
+		{Name: "bar", Path: "ms/bar", ImportUrl: server.URL + "/ms/bar.git", Private: true, DefaultBranch: "master"},
+	}, repos)
+
+	_, err = imp.GetRepository(ctx, server.URL, "good", "ms/missing")
+	require.ErrorContains(t, err, "model not found")
+}
+
+func TestNew_RejectInternalAddress(t *testing.T) {
+	server := newTestServer(t, "Authorization", "Bearer good", map[string]any{
+		"GET /user": map[string]any{"login": "octo"},
+	})
+	imp, err := New(types.ImportProviderGitHub)
+	require.NoError(t, err)
+
+	_, err = imp.GetUser(context.TODO(), server.URL, "good")
+	require.ErrorContains(t, err, "not allowed")
+}
+
+func TestCheckImportURL(t *testing.T) {
+	require.NoError(t, CheckImportURL("https://gitlab.example.com", "https://gitlab.example.com/group/foo.git"))
+	require.NoError(t, CheckImportURL("https://api.github.com", "https://github.com/octo/foo.git"))
+	require.NoError(t, CheckImportURL("https://github.example.com/api/v3", "https://GitHub.example.com/octo/foo.git"))
+	require.Error(t, CheckImportURL("https://gitlab.example.com", "http://10.0.0.1/group/foo.git"))
+	require.Error(t, CheckImportURL("https://gitlab.example.com", "file:///etc/passwd"))
+	require.Error(t, CheckImportURL("https://gitlab.example.com", "ssh://gitlab.example.com/group/foo.git"))
+}
+
+func TestListRepositories(t *testing.T) {
+	var pages []int
+	list := func(page, per int) ([]Repository, error) {
+		pages = append(pages, page)
+		if page > 2 {
+			return nil, nil
+		}
+		repos := make([]Repository, 0, per)
+		for i := range per {
+			repos = append(repos, Repository{Path: fmt.Sprintf("owner/repo-%d-%d", page, i)})
+		}
+		return repos, nil
+	}
+
+	// the listing is paged without a search
+	repos, err := listRepositories(list, "", 2, 10)
+	require.NoError(t, err)
+	require.Len(t, repos, 10)
+	require.Equal(t, []int{2}, pages)
+
+	// the search pages through the listing, the matches of the later pages are returned
+	pages = nil
+	repos, err = listRepositories(list, "REPO-2-1", 2, 5)
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 2}, pages)
+	require.Len(t, repos, 5)
+	require.Equal(t, "owner/repo-2-14", repos[0].Path)
+
+	// the search stops at the last page of the listing
+	pages = nil
+	repos, err = listRepositories(list, "missing", 1, 5)
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 2, 3}, pages)
+	require.Empty(t, repos)
+}
+
+func TestModelscopeTags(t *testing.T) {
+	var model modelscopeModel
+	require.NoError(t, json.Unmarshal([]byte(`{"Tags":null}`), &model))
+	require.Empty(t, model.Tags)
+	require.Error(t, json.Unmarshal([]byte(`{"Tags":"nlp"}`), &model))
+	require.Error(t, json.Unmarshal([]byte(`{"Tags":[1]}`), &model))
+}
@@ -0,0 +1,158 @@
+package importer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// modelscopeImporter imports the models of a ModelScope user, the git repositories of the models are
+// served by the site itself, e.g. https://www.modelscope.cn/owner/name.git
+type modelscopeImporter struct {
+	client *http.Client
+}
+
+// modelscopeVisibilityPublic is the visibility of the public models, the others are private or internal
+const modelscopeVisibilityPublic = 5
+
+type modelscopeResponse struct {
+	Code    int             `json:"Code"`
+	Message string          `json:"Message"`
+	Data    json.RawMessage `json:"Data"`
+}
+
+type modelscopeModel struct {
+	Name        string         `json:"Name"`
+	Path        string         `json:"Path"`
+	Description string         `json:"Description"`
+	License     string         `json:"License"`
+	Visibility  int            `json:"Visibility"`
+	Tags        modelscopeTags `json:"Tags"`
+}
+
+// modelscopeTags accepts the tags given as names or as objects with a name
+type modelscopeTags []string
+
+func (t *modelscopeTags) UnmarshalJSON(data []byte) error {
+	var items []json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil {
+		return fmt.Errorf("failed to decode modelscope tags, error: %w", err)
+	}
+	for _, item := range items {
+		var name string
+		if err := json.Unmarshal(item, &name); err == nil {
+			*t = append(*t, name)
+			continue
+		}
+		var obj struct {
+			Name string `json:"Name"`
+		}
+		if err := json.Unmarshal(item, &obj); err != nil {
+			return fmt.Errorf("failed to decode modelscope tag %s, error: %w", item, err)
+		}
+		if obj.Name != "" {
+			*t = append(*t, obj.Name)
+		}
+	}
+	return nil
+}
+
+func (i *modelscopeImporter) toRepository(baseURL string, m modelscopeModel) Repository {
+	path := m.Path + "/" + m.Name
+	return Repository{
+		Name:          m.Name,
+		Path:          path,
+		ImportUrl:     strings.TrimSuffix(baseURL, "/") + "/" + path + ".git",
+		Private:       m.Visibility != modelscopeVisibilityPublic,
+		Description:   m.Description,
+		Topics:        m.Tags,
+		License:       strings.ToLower(m.License),
+		DefaultBranch: "master",
+	}
+}
+
+func (i *modelscopeImporter) header(accessToken string) http.Header {
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+accessToken)
+	return header
+}
+
+func (i *modelscopeImporter) do(ctx context.Context, method, rawURL, accessToken string, body any, out *modelscopeResponse) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request, error: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+	if err := doJSON(ctx, i.client, method, rawURL, i.header(accessToken), reader, out); err != nil {
+		return err
+	}
+	if out.Code != http.StatusOK {
+		return fmt.Errorf("modelscope responded with code %d, message: %s", out.Code, out.Message)
+	}
+	return nil
+}
+
+// GetRepositoryList lists the models of the user, the models api can not filter by name
+func (i *modelscopeImporter) GetRepositoryList(ctx context.Context, baseURL, accessToken, search string, page, per int) ([]Repository, error) {
+	user, err := i.GetUser(ctx, baseURL, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	return listRepositories(func(page, per int) ([]Repository, error) {
+		body := map[string]any{
+			"Path":       user.Username,
+			"PageNumber": page,
+			"PageSize":   per,
+		}
+		var resp modelscopeResponse
+		if err := i.do(ctx, http.MethodPut, apiURL(baseURL, "/api/v1/models/", nil), accessToken, body, &resp); err != nil {
+			return nil, err
+		}
+		var data struct {
+			Models []modelscopeModel `json:"Models"`
+		}
+		if err := json.Unmarshal(resp.Data, &data); err != nil {
+			return nil, fmt.Errorf("failed to decode modelscope models, error: %w", err)
+		}
+		repos := make([]Repository, 0, len(data.Models))
+		for _, m := range data.Models {
+			repos = append(repos, i.toRepository(baseURL, m))
+		}
+		return repos, nil
+	}, search, page, per)
+}
+
+func (i *modelscopeImporter) GetRepository(ctx context.Context, baseURL, accessToken, path string) (*Repository, error) {
+	var resp modelscopeResponse
+	if err := i.do(ctx, http.MethodGet, apiURL(baseURL, "/api/v1/models/"+path, nil), accessToken, nil, &resp); err != nil {
+		return nil, err
+	}
+	var model modelscopeModel
+	if err := json.Unmarshal(resp.Data, &model); err != nil {
+		return nil, fmt.Errorf("failed to decode modelscope model, error: %w", err)
+	}
+	repo := i.toRepository(baseURL, model)
+	return &repo, nil
+}
+
+func (i *modelscopeImporter) GetUser(ctx context.Context, baseURL, accessToken string) (*User, error) {
+	var resp modelscopeResponse
+	body := map[string]string{"AccessToken": accessToken}
+	if err := i.do(ctx, http.MethodPost, apiURL(baseURL, "/api/v1/login", nil), accessToken, body, &resp); err != nil {
+		return nil, err
+	}
+	var data struct {
+		Username string `json:"Username"`
+	}
+	if err := json.Unmarshal(resp.Data, &data); err != nil {
+		return nil, fmt.Errorf("failed to decode modelscope user, error: %w", err)
+	}
+	return &User{Username: data.Username}, nil
+}
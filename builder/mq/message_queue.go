@@ -22,10 +22,17 @@ type MessageQueueFactory interface {
 var _ MessageQueueFactory = (*messageQueueFactoryImpl)(nil)
 
 type messageQueueFactoryImpl struct {
-	nats  MessageQueue
-	kafka MessageQueue
+	nats     MessageQueue
+	kafka    MessageQueue
+	postgres MessageQueue
 }
 
+const (
+	BackendNats     = "nats"
+	BackendKafka    = "kafka"
+	BackendPostgres = "postgres"
+)
+
 var (
 	globalMQFactory MessageQueueFactory
 	once            sync.Once
@@ -39,25 +46,45 @@ func GetOrInitMessageQueueFactory(config *config.Config) (MessageQueueFactory, e
 	return globalMQFactory, err
 }
 
+// mqBackend returns the configured backend, nats and kafka are preferred if the backend is not set
+func mqBackend(config *config.Config) string {
+	if len(config.MessageQueue.Backend) > 0 {
+		return config.MessageQueue.Backend
+	}
+	if len(config.Nats.URL) > 0 {
+		return BackendNats
+	}
+	if len(config.Kafka.Servers) > 0 {
+		return BackendKafka
+	}
+	return BackendPostgres
+}
+
 func initMQFactory(config *config.Config) (MessageQueueFactory, error) {
 	var err error
 	mqf := &messageQueueFactoryImpl{}
 
-	if len(config.Nats.URL) > 0 {
+	switch backend := mqBackend(config); backend {
+	case BackendNats:
 		mqf.nats, err = NewNats(config)
 		if err != nil {
 			return nil, err
 		}
 		slog.Info("[nats] message queue initialized successfully")
-		return mqf, nil
-	}
-
-	if len(config.Kafka.Servers) > 0 {
+	case BackendKafka:
 		mqf.kafka, err = NewKafka(config)
 		if err != nil {
 			return nil, err
 		}
 		slog.Info("[kafka] message queue initialized successfully")
+	case BackendPostgres:
+		mqf.postgres, err = NewPostgres(config)
+		if err != nil {
+			return nil, err
+		}
+		slog.Info("[postgres] message queue initialized successfully")
+	default:
+		return nil, fmt.Errorf("unknown message queue backend %s", backend)
 	}
 
 	return mqf, nil
@@ -70,5 +97,8 @@ func (f *messageQueueFactoryImpl) GetInstance() (MessageQueue, error) {
 	if f.kafka != nil {
 		return f.kafka, nil
 	}
+	if f.postgres != nil {
+		return f.postgres, nil
+	}
 	return nil, fmt.Errorf("no message queue instance found")
 }
@@ -0,0 +1,334 @@
+package mq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/uptrace/bun/driver/pgdriver"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/config"
+)
+
+var _ MessageQueue = (*Postgres)(nil)
+
+const (
+	// pgNotifyChannel is notified with the stream name once a message is published
+	pgNotifyChannel   = "csghub_mq"
+	pgFetchBatchSize  = 100
+	pgDeleteBatchSize = 1000
+	pgTrimInterval    = time.Minute
+)
+
+// Postgres is the message queue embedded in the database for the installs without nats or kafka,
+// consumers are woken up by LISTEN/NOTIFY and poll the database in case of a missed notification
+// or a redelivery. Topics are matched exactly, wildcards of nats subjects are not supported.
+type Postgres struct {
+	store        database.MQStore
+	listener     *pgdriver.Listener
+	pollInterval time.Duration
+	retention    time.Duration
+
+	mu      sync.Mutex
+	waiters map[string][]chan struct{}
+	done    chan struct{}
+}
+
+func NewPostgres(cfg *config.Config) (MessageQueue, error) {
+	db := database.GetDB()
+	if db == nil {
+		return nil, errors.New("[postgres] database is not initialized")
+	}
+	return NewPostgresWithDB(db,
+		time.Duration(cfg.MessageQueue.PollIntervalSeconds)*time.Second,
+		time.Duration(cfg.MessageQueue.RetentionHours)*time.Hour,
+	), nil
+}
+
+// NewPostgresWithDB creates the message queue on the database, LISTEN/NOTIFY is only used if the
+// database is connected by pgdriver, otherwise the consumers rely on polling
+func NewPostgresWithDB(db *database.DB, pollInterval, retention time.Duration) *Postgres {
+	if pollInterval <= 0 {
+		pollInterval = 5 * time.Second
+	}
+	p := &Postgres{
+		store:        database.NewMQStoreWithDB(db),
+		pollInterval: pollInterval,
+		retention:    retention,
+		waiters:      make(map[string][]chan struct{}),
+		done:         make(chan struct{}),
+	}
+	if _, ok := db.BunDB.Driver().(pgdriver.Driver); ok {
+		p.listener = pgdriver.NewListener(db.BunDB)
+		go p.listen()
+	}
+	go p.trimStreams()
+	return p
+}
+
+func (p *Postgres) listen() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	err := p.listener.Listen(ctx, pgNotifyChannel)
+	cancel()
+	if err != nil {
+		// the listener keeps the channel and listens on it once reconnected
+		slog.Warn("[postgres] failed to listen on notify channel, consumers poll the database until reconnected",
+			slog.String("channel", pgNotifyChannel), slog.Any("error", err))
+	}
+	for notification := range p.listener.CreateChannel() {
+		if notification.Channel == pgNotifyChannel {
+			p.wake(notification.Payload)
+		}
+	}
+}
+
+func (p *Postgres) wake(stream string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, waiter := range p.waiters[stream] {
+		select {
+		case waiter <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Close stops the consumers and the listener
+func (p *Postgres) Close() error {
+	close(p.done)
+	if p.listener != nil {
+		return p.listener.Close()
+	}
+	return nil
+}
+
+func (p *Postgres) Publish(topic string, data []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	streams, err := p.store.CreateMessage(ctx, topic, data)
+	if err != nil {
+		return fmt.Errorf("[postgres] failed to publish message to topic %s error: %w", topic, err)
+	}
+	if len(streams) == 0 {
+		return fmt.Errorf("[postgres] failed to publish message to topic %s error: no stream contains the topic", topic)
+	}
+	for _, stream := range streams {
+		err = p.store.Notify(ctx, pgNotifyChannel, stream)
+		if err != nil {
+			// the message is consumed by polling anyway
+			slog.Warn("[postgres] failed to notify consumers", slog.String("stream", stream), slog.Any("error", err))
+		}
+	}
+	return nil
+}
+
+func (p *Postgres) Subscribe(params SubscribeParams) error {
+	if len(params.Topics) == 0 || params.Callback == nil {
+		return fmt.Errorf("[postgres] topics and callback are required to subscribe stream %s", params.Group.StreamName)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	err := p.store.UpsertStream(ctx, &database.MQStream{
+		Name:          params.Group.StreamName,
+		Topics:        params.Topics,
+		MaxBytes:      max(params.MaxBytes, 0),
+		MaxAgeSeconds: int64(params.MaxAge.Seconds()),
+	})
+	if err != nil {
+		return fmt.Errorf("[postgres] failed to create or update stream %s error: %w", params.Group.StreamName, err)
+	}
+
+	consumer := &database.MQConsumer{
+		Stream:         params.Group.StreamName,
+		Name:           params.Group.ConsumerName,
+		Topics:         params.Topics,
+		AckWaitSeconds: int64(ackWaitOrDefault(params.AckWait).Seconds()),
+		MaxDeliver:     max(params.MaxDeliver, 0),
+	}
+	err = p.store.UpsertConsumer(ctx, consumer)
+	if err != nil {
+		return fmt.Errorf("[postgres] failed to create or update consumer %s error: %w", params.Group.ConsumerName, err)
+	}
+
+	waiter := make(chan struct{}, 1)
+	p.mu.Lock()
+	p.waiters[consumer.Stream] = append(p.waiters[consumer.Stream], waiter)
+	p.mu.Unlock()
+
+	go p.consume(consumer, params, waiter)
+	return nil
+}
+
+func (p *Postgres) consume(consumer *database.MQConsumer, params SubscribeParams, waiter chan struct{}) {
+	ticker := time.NewTicker(p.pollInterval)
+	defer ticker.Stop()
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		deliveries, err := p.store.ClaimDeliveries(ctx, consumer, pgFetchBatchSize)
+		cancel()
+		if err != nil {
+			slog.Error("[postgres] failed to fetch messages", slog.String("consumer", consumer.Name), slog.Any("error", err))
+		}
+		for _, delivery := range deliveries {
+			p.handle(consumer, params, delivery)
+		}
+		// keep fetching until the backlog is drained
+		if len(deliveries) == pgFetchBatchSize {
+			continue
+		}
+
+		select {
+		case <-p.done:
+			return
+		case <-waiter:
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *Postgres) handle(consumer *database.MQConsumer, params SubscribeParams, delivery database.MQDelivery) {
+	acker := &pgAcker{
+		store:      p.store,
+		deliveryID: delivery.ID,
+		ackWait:    time.Duration(consumer.AckWaitSeconds) * time.Second,
+	}
+	if delivery.Message == nil {
+		// the message is removed by the retention
+		_ = acker.Ack()
+		return
+	}
+
+	err := params.Callback(
+		delivery.Message.Data,
+		MessageMeta{
+			Topic: delivery.Message.Topic,
+			Acker: acker,
+		},
+	)
+	if err != nil {
+		slog.Error("[postgres] failed to invoke callback for message", slog.Any("topics", params.Topics), slog.Any("error", err))
+	}
+
+	if params.AutoACK {
+		action := ""
+		if err != nil && params.IsRedeliverForCBFailed {
+			err = acker.Nak()
+			action = "nak"
+		} else {
+			err = acker.Ack()
+			action = "ack"
+		}
+		if err != nil {
+			slog.Error(fmt.Sprintf("[postgres] failed to %s message", action),
+				slog.Any("topics", params.Topics), slog.Any("error", err))
+		}
+	}
+}
+
+func (p *Postgres) trimStreams() {
+	ticker := time.NewTicker(pgTrimInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.done:
+			return
+		case <-ticker.C:
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		streams, err := p.store.FindStreams(ctx)
+		if err != nil {
+			slog.Error("[postgres] failed to find streams to trim", slog.Any("error", err))
+		}
+		for i := range streams {
+			count, err := p.store.TrimStream(ctx, &streams[i], p.retention)
+			if err != nil {
+				slog.Error("[postgres] failed to trim stream", slog.String("stream", streams[i].Name), slog.Any("error", err))
+				continue
+			}
+			if count > 0 {
+				slog.Info("[postgres] stream trimmed", slog.String("stream", streams[i].Name), slog.Int("deleted_count", count))
+			}
+		}
+		cancel()
+	}
+}
+
+func (p *Postgres) PurgeStream(streamName string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	_, err := p.store.PurgeStream(ctx, streamName)
+	if err != nil {
+		return fmt.Errorf("[postgres] failed to purge stream %s error: %w", streamName, err)
+	}
+
+	slog.Info("[postgres] stream purged successfully", slog.String("stream", streamName))
+	return nil
+}
+
+func (p *Postgres) DeleteMessagesByFilter(streamName string, filter func(data []byte) bool) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	var (
+		afterID      int64
+		deletedCount int
+	)
+	for {
+		messages, err := p.store.ListMessages(ctx, streamName, afterID, pgDeleteBatchSize)
+		if err != nil {
+			return fmt.Errorf("[postgres] failed to list messages of stream %s error: %w", streamName, err)
+		}
+		var ids []int64
+		for _, msg := range messages {
+			if filter(msg.Data) {
+				ids = append(ids, msg.ID)
+			}
+		}
+		count, err := p.store.DeleteMessages(ctx, ids)
+		if err != nil {
+			return fmt.Errorf("[postgres] failed to delete messages of stream %s error: %w", streamName, err)
+		}
+		deletedCount += count
+		if len(messages) < pgDeleteBatchSize {
+			break
+		}
+		afterID = messages[len(messages)-1].ID
+	}
+
+	slog.Info("[postgres] messages deleted by filter",
+		slog.String("stream", streamName),
+		slog.Int("deleted_count", deletedCount))
+	return nil
+}
+
+var _ MessageAcker = (*pgAcker)(nil)
+
+type pgAcker struct {
+	store      database.MQStore
+	deliveryID int64
+	ackWait    time.Duration
+}
+
+func (a *pgAcker) Ack() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	return a.store.DeleteDelivery(ctx, a.deliveryID)
+}
+
+func (a *pgAcker) Nak() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	return a.store.DelayDelivery(ctx, a.deliveryID, 0)
+}
+
+func (a *pgAcker) InProgress() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	return a.store.DelayDelivery(ctx, a.deliveryID, a.ackWait)
+}
@@ -0,0 +1,147 @@
+package mq
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/config"
+	"opencsg.com/csghub-server/common/tests"
+)
+
+func TestMQBackend(t *testing.T) {
+	cfg := &config.Config{}
+	require.Equal(t, BackendPostgres, mqBackend(cfg))
+
+	cfg.Kafka.Servers = "localhost:9092"
+	require.Equal(t, BackendKafka, mqBackend(cfg))
+
+	cfg.Nats.URL = "nats://localhost:4222"
+	require.Equal(t, BackendNats, mqBackend(cfg))
+
+	cfg.MessageQueue.Backend = BackendPostgres
+	require.Equal(t, BackendPostgres, mqBackend(cfg))
+}
+
+type receivedMessages struct {
+	mu   sync.Mutex
+	data []string
+}
+
+func (r *receivedMessages) add(raw []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.data = append(r.data, string(raw))
+}
+
+func (r *receivedMessages) list() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string{}, r.data...)
+}
+
+func TestPostgres_PublishSubscribe(t *testing.T) {
+	db := tests.InitTransactionTestDB()
+	defer db.Close()
+	p := NewPostgresWithDB(db, 100*time.Millisecond, 0)
+	defer p.Close()
+
+	// no stream contains the topic yet
+	err := p.Publish("test.topic.a", []byte("lost"))
+	require.Error(t, err)
+
+	group := MQGroup{StreamName: "testStream", ConsumerName: "testConsumer"}
+	first := &receivedMessages{}
+	err = p.Subscribe(SubscribeParams{
+		Group:    group,
+		Topics:   []string{"test.topic.a", "test.topic.b"},
+		AutoACK:  true,
+		Callback: func(raw []byte, meta MessageMeta) error { first.add(raw); return nil },
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, p.Publish("test.topic.a", []byte("1")))
+	require.NoError(t, p.Publish("test.topic.b", []byte("2")))
+	require.Eventually(t, func() bool { return len(first.list()) == 2 }, 5*time.Second, 50*time.Millisecond)
+	require.Equal(t, []string{"1", "2"}, first.list())
+
+	// a new consumer gets all the messages kept in the stream
+	second := &receivedMessages{}
+	err = p.Subscribe(SubscribeParams{
+		Group:    MQGroup{StreamName: "testStream", ConsumerName: "anotherConsumer"},
+		Topics:   []string{"test.topic.b"},
+		AutoACK:  true,
+		Callback: func(raw []byte, meta MessageMeta) error { second.add(raw); return nil },
+	})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return len(second.list()) == 1 }, 5*time.Second, 50*time.Millisecond)
+	require.Equal(t, []string{"2"}, second.list())
+
+	// acknowledged messages are not delivered again
+	time.Sleep(300 * time.Millisecond)
+	require.Equal(t, 2, len(first.list()))
+}
+
+func TestPostgres_Redeliver(t *testing.T) {
+	db := tests.InitTransactionTestDB()
+	defer db.Close()
+	p := NewPostgresWithDB(db, 100*time.Millisecond, 0)
+	defer p.Close()
+
+	received := &receivedMessages{}
+	err := p.Subscribe(SubscribeParams{
+		Group:                  MQGroup{StreamName: "redeliverStream", ConsumerName: "redeliverConsumer"},
+		Topics:                 []string{"test.redeliver"},
+		AutoACK:                true,
+		IsRedeliverForCBFailed: true,
+		MaxDeliver:             3,
+		Callback: func(raw []byte, meta MessageMeta) error {
+			received.add(raw)
+			return errors.New("callback failed")
+		},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, p.Publish("test.redeliver", []byte("x")))
+	require.Eventually(t, func() bool { return len(received.list()) == 3 }, 5*time.Second, 50*time.Millisecond)
+	// no more deliveries after the max deliver
+	time.Sleep(500 * time.Millisecond)
+	require.Equal(t, 3, len(received.list()))
+}
+
+func TestPostgres_DeleteMessagesByFilterAndPurge(t *testing.T) {
+	db := tests.InitTransactionTestDB()
+	defer db.Close()
+	p := NewPostgresWithDB(db, 100*time.Millisecond, 0)
+	defer p.Close()
+
+	err := p.store.UpsertStream(t.Context(), &database.MQStream{Name: "deleteStream", Topics: []string{"test.delete"}})
+	require.NoError(t, err)
+	for _, data := range []string{"keep-1", "drop-1", "keep-2", "drop-2"} {
+		require.NoError(t, p.Publish("test.delete", []byte(data)))
+	}
+	err = p.DeleteMessagesByFilter("deleteStream", func(data []byte) bool {
+		return strings.HasPrefix(string(data), "drop")
+	})
+	require.NoError(t, err)
+
+	received := &receivedMessages{}
+	err = p.Subscribe(SubscribeParams{
+		Group:    MQGroup{StreamName: "deleteStream", ConsumerName: "deleteConsumer"},
+		Topics:   []string{"test.delete"},
+		AutoACK:  true,
+		Callback: func(raw []byte, meta MessageMeta) error { received.add(raw); return nil },
+	})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return len(received.list()) == 2 }, 5*time.Second, 50*time.Millisecond)
+	require.Equal(t, []string{"keep-1", "keep-2"}, received.list())
+
+	require.NoError(t, p.PurgeStream("deleteStream"))
+	messages, err := p.store.ListMessages(t.Context(), "deleteStream", 0, 10)
+	require.NoError(t, err)
+	require.Empty(t, messages)
+}
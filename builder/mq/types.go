@@ -41,6 +41,8 @@ const (
 	LfsResultSubject    string = "xnet.lfs.result"
 
 	ActivityLogSendSubject string = "activity.log.send"
+
+	RepoTrafficEventSubject string = "repo.traffic.event"
 )
 
 type MQGroup struct {
@@ -117,6 +119,10 @@ var (
 		StreamName:   "activityLogStream",
 		ConsumerName: "activityLogConsumer",
 	}
+	RepoTrafficEventGroup = MQGroup{
+		StreamName:   "repoTrafficEventStream",
+		ConsumerName: "repoTrafficEventConsumer",
+	}
 )
 
 type MessageMeta struct {
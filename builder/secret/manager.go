@@ -0,0 +1,383 @@
+package secret
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/config"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+	"opencsg.com/csghub-server/common/utils/common"
+	"opencsg.com/csghub-server/common/utils/cryptox"
+)
+
+// infoPrefix separates the keys derived for the resource secrets from the other users of the master keys
+const infoPrefix = "csghub/resource-secret/"
+
+var errNotConfigured = errors.New("secret master keys are not configured")
+
+// Manager keeps the secrets of the spaces and deploys encrypted at rest
+//
+// Every secret is encrypted with a key derived for its owner and key, so a ciphertext copied to
+// another row can not be decrypted. The values only leave the manager in plain through Values,
+// which is used to pass them to the runner.
+type Manager interface {
+	// List returns the secrets of the owner with the masked values
+	List(ctx context.Context, ownerType types.SecretOwnerType, ownerID int64) ([]types.Secret, error)
+	// Values returns the plain secret values of the owner
+	Values(ctx context.Context, ownerType types.SecretOwnerType, ownerID int64) (map[string]string, error)
+	// Set creates or updates a secret, and returns it with the masked value
+	Set(ctx context.Context, ownerType types.SecretOwnerType, ownerID int64, key, value string) (*types.Secret, error)
+	// Validate checks the secrets before the owner is created or updated, Replace fails the same way
+	Validate(values map[string]string) error
+	// Replace replaces all the secrets of the owner with the values
+	Replace(ctx context.Context, ownerType types.SecretOwnerType, ownerID int64, values map[string]string) error
+	Delete(ctx context.Context, ownerType types.SecretOwnerType, ownerID int64, key string) error
+	// Reencrypt re-encrypts the secrets of the retired key versions with the active one, and imports the
+	// plain space secrets stored before the encryption was introduced
+	Reencrypt(ctx context.Context, batchSize int) (*types.SecretReencryption, error)
+}
+
+type managerImpl struct {
+	keyring     *cryptox.Keyring
+	secretStore database.ResourceSecretStore
+	spaceStore  database.SpaceStore
+}
+
+func NewManager(config *config.Config) (Manager, error) {
+	keyring, err := NewKeyring(config)
+	if err != nil {
+		return nil, err
+	}
+	return NewManagerWithStores(keyring, database.NewResourceSecretStore(), database.NewSpaceStore()), nil
+}
+
+// NewManagerWithStores creates a manager with the keyring, a nil keyring fails all the encryption and decryption
+func NewManagerWithStores(keyring *cryptox.Keyring, secretStore database.ResourceSecretStore, spaceStore database.SpaceStore) Manager {
+	return &managerImpl{
+		keyring:     keyring,
+		secretStore: secretStore,
+		spaceStore:  spaceStore,
+	}
+}
+
+// NewKeyring creates the keyring of the master keys in config.Secret, it returns nil if no key is configured
+func NewKeyring(config *config.Config) (*cryptox.Keyring, error) {
+	keys, err := cryptox.ParseVersionedKeys(config.Secret.MasterKeysBase64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid secret master keys, error: %w", err)
+	}
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	keyring, err := cryptox.NewKeyring(cryptox.KeyringConfig{
+		MasterKeys:    keys,
+		ActiveVersion: config.Secret.ActiveKeyVersion,
+		Salt:          []byte(config.Secret.Salt),
+		InfoPrefix:    infoPrefix,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid secret master keys, error: %w", err)
+	}
+	return keyring, nil
+}
+
+func (m *managerImpl) List(ctx context.Context, ownerType types.SecretOwnerType, ownerID int64) ([]types.Secret, error) {
+	secrets, err := m.secretStore.ListByOwner(ctx, ownerType, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list secrets, error: %w", err)
+	}
+	res := make([]types.Secret, 0, len(secrets))
+	for i := range secrets {
+		value, err := m.decrypt(&secrets[i])
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, toSecret(&secrets[i], value))
+	}
+	return res, nil
+}
+
+func (m *managerImpl) Values(ctx context.Context, ownerType types.SecretOwnerType, ownerID int64) (map[string]string, error) {
+	secrets, err := m.secretStore.ListByOwner(ctx, ownerType, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list secrets, error: %w", err)
+	}
+	values := make(map[string]string, len(secrets))
+	for i := range secrets {
+		value, err := m.decrypt(&secrets[i])
+		if err != nil {
+			return nil, err
+		}
+		values[secrets[i].Key] = value
+	}
+	return values, nil
+}
+
+func (m *managerImpl) Set(ctx context.Context, ownerType types.SecretOwnerType, ownerID int64, key, value string) (*types.Secret, error) {
+	if err := validate(key, value); err != nil {
+		return nil, err
+	}
+	if m.keyring == nil {
+		return nil, errNotConfigured
+	}
+	_, err := m.secretStore.FindByKey(ctx, ownerType, ownerID, key)
+	if errors.Is(err, errorx.ErrDatabaseNoRows) {
+		secrets, err := m.secretStore.ListByOwner(ctx, ownerType, ownerID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list secrets, error: %w", err)
+		}
+		if len(secrets) >= types.MaxSecretsPerOwner {
+			return nil, errorx.ReqParamInvalid(fmt.Errorf("no more than %d secrets are allowed", types.MaxSecretsPerOwner),
+				errorx.Ctx().Set("key", key))
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to find secret, error: %w", err)
+	}
+
+	secret, err := m.encrypt(ownerType, ownerID, key, value)
+	if err != nil {
+		return nil, err
+	}
+	secret, err = m.secretStore.Upsert(ctx, secret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save secret, error: %w", err)
+	}
+	res := toSecret(secret, value)
+	return &res, nil
+}
+
+func (m *managerImpl) Validate(values map[string]string) error {
+	if err := ValidateValues(values); err != nil {
+		return err
+	}
+	if len(values) > 0 && m.keyring == nil {
+		return errNotConfigured
+	}
+	return nil
+}
+
+func (m *managerImpl) Replace(ctx context.Context, ownerType types.SecretOwnerType, ownerID int64, values map[string]string) error {
+	if err := m.Validate(values); err != nil {
+		return err
+	}
+	values, err := m.unmask(ctx, ownerType, ownerID, values)
+	if err != nil {
+		return err
+	}
+	secrets := make([]database.ResourceSecret, 0, len(values))
+	for key, value := range values {
+		if err := validate(key, value); err != nil {
+			return err
+		}
+		secret, err := m.encrypt(ownerType, ownerID, key, value)
+		if err != nil {
+			return err
+		}
+		secrets = append(secrets, *secret)
+	}
+	err = m.secretStore.ReplaceByOwner(ctx, ownerType, ownerID, secrets)
+	if err != nil {
+		return fmt.Errorf("failed to save secrets, error: %w", err)
+	}
+	return nil
+}
+
+// unmask keeps the stored values of the secrets sent back with the masked values, as the clients only
+// get the masked values and send them back with the secrets they did not change
+func (m *managerImpl) unmask(ctx context.Context, ownerType types.SecretOwnerType, ownerID int64, values map[string]string) (map[string]string, error) {
+	masked := false
+	for _, value := range values {
+		if types.IsMaskedSecret(value) {
+			masked = true
+			break
+		}
+	}
+	if !masked {
+		return values, nil
+	}
+	current, err := m.Values(ctx, ownerType, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[string]string, len(values))
+	for key, value := range values {
+		if stored, ok := current[key]; ok && value == types.MaskSecret(stored) {
+			value = stored
+		}
+		res[key] = value
+	}
+	return res, nil
+}
+
+func (m *managerImpl) Delete(ctx context.Context, ownerType types.SecretOwnerType, ownerID int64, key string) error {
+	_, err := m.secretStore.FindByKey(ctx, ownerType, ownerID, key)
+	if err != nil {
+		return fmt.Errorf("failed to find secret, error: %w", err)
+	}
+	err = m.secretStore.Delete(ctx, ownerType, ownerID, key)
+	if err != nil {
+		return fmt.Errorf("failed to delete secret, error: %w", err)
+	}
+	return nil
+}
+
+func (m *managerImpl) Reencrypt(ctx context.Context, batchSize int) (*types.SecretReencryption, error) {
+	if m.keyring == nil {
+		return nil, errNotConfigured
+	}
+	res := &types.SecretReencryption{ActiveKeyVersion: m.keyring.ActiveVersion()}
+
+	var afterID int64
+	for {
+		secrets, err := m.secretStore.ListNotInKeyVersion(ctx, m.keyring.ActiveVersion(), afterID, batchSize)
+		if err != nil {
+			return res, fmt.Errorf("failed to list secrets to re-encrypt, error: %w", err)
+		}
+		for i := range secrets {
+			secret := &secrets[i]
+			afterID = secret.ID
+			if err := m.reencrypt(ctx, secret); err != nil {
+				slog.ErrorContext(ctx, "failed to re-encrypt secret", slog.Int64("id", secret.ID), slog.Any("error", err))
+				res.Failed++
+				continue
+			}
+			res.Reencrypted++
+		}
+		if len(secrets) < batchSize {
+			break
+		}
+	}
+
+	afterID = 0
+	for {
+		spaces, err := m.spaceStore.ListWithPlainSecrets(ctx, afterID, batchSize)
+		if err != nil {
+			return res, fmt.Errorf("failed to list spaces with plain secrets, error: %w", err)
+		}
+		for _, space := range spaces {
+			afterID = space.ID
+			imported, err := m.importSpaceSecrets(ctx, &space)
+			if err != nil {
+				slog.ErrorContext(ctx, "failed to import space secrets", slog.Int64("space_id", space.ID), slog.Any("error", err))
+				res.Failed++
+				continue
+			}
+			res.Imported += imported
+		}
+		if len(spaces) < batchSize {
+			break
+		}
+	}
+	return res, nil
+}
+
+func (m *managerImpl) reencrypt(ctx context.Context, secret *database.ResourceSecret) error {
+	value, err := m.decrypt(secret)
+	if err != nil {
+		return err
+	}
+	encrypted, err := m.encrypt(secret.OwnerType, secret.OwnerID, secret.Key, value)
+	if err != nil {
+		return err
+	}
+	secret.Ciphertext = encrypted.Ciphertext
+	secret.KeyVersion = encrypted.KeyVersion
+	return m.secretStore.UpdateCiphertext(ctx, secret)
+}
+
+func (m *managerImpl) importSpaceSecrets(ctx context.Context, space *database.Space) (int, error) {
+	values, err := common.JsonStrToMap(space.Secrets)
+	if err != nil {
+		return 0, fmt.Errorf("invalid space secrets, error: %w", err)
+	}
+	secrets := make([]database.ResourceSecret, 0, len(values))
+	for key, value := range values {
+		if !types.ValidSecretKey(key) {
+			slog.WarnContext(ctx, "skip the space secret with an invalid key", slog.Int64("space_id", space.ID), slog.String("key", key))
+			continue
+		}
+		secret, err := m.encrypt(types.SecretOwnerSpace, space.ID, key, value)
+		if err != nil {
+			return 0, err
+		}
+		secrets = append(secrets, *secret)
+	}
+	err = m.secretStore.ImportSpaceSecrets(ctx, space.ID, secrets)
+	if err != nil {
+		return 0, err
+	}
+	return len(secrets), nil
+}
+
+func (m *managerImpl) encrypt(ownerType types.SecretOwnerType, ownerID int64, key, value string) (*database.ResourceSecret, error) {
+	if m.keyring == nil {
+		return nil, errNotConfigured
+	}
+	version, ciphertext, err := m.keyring.Encrypt([]byte(value), scope(ownerType, ownerID, key)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt secret %s, error: %w", key, err)
+	}
+	return &database.ResourceSecret{
+		OwnerType:  ownerType,
+		OwnerID:    ownerID,
+		Key:        key,
+		Ciphertext: ciphertext,
+		KeyVersion: version,
+	}, nil
+}
+
+func (m *managerImpl) decrypt(secret *database.ResourceSecret) (string, error) {
+	if m.keyring == nil {
+		return "", errNotConfigured
+	}
+	value, err := m.keyring.Decrypt(secret.KeyVersion, secret.Ciphertext, scope(secret.OwnerType, secret.OwnerID, secret.Key)...)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt secret %s, error: %w", secret.Key, err)
+	}
+	return string(value), nil
+}
+
+func scope(ownerType types.SecretOwnerType, ownerID int64, key string) []string {
+	return []string{string(ownerType), strconv.FormatInt(ownerID, 10), key}
+}
+
+// ValidateValues checks the keys and values of the secrets passed to the runner without being stored,
+// e.g. the secrets of a finetune job, so no master key is required
+func ValidateValues(values map[string]string) error {
+	if len(values) > types.MaxSecretsPerOwner {
+		return errorx.ReqParamInvalid(fmt.Errorf("no more than %d secrets are allowed", types.MaxSecretsPerOwner), nil)
+	}
+	for key, value := range values {
+		if err := validate(key, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validate(key, value string) error {
+	if !types.ValidSecretKey(key) {
+		return errorx.ReqParamInvalid(errors.New("secret key must be a valid environment variable name"),
+			errorx.Ctx().Set("key", key))
+	}
+	if len(value) > types.MaxSecretValueLength {
+		return errorx.ReqParamInvalid(fmt.Errorf("secret value must be no longer than %d bytes", types.MaxSecretValueLength),
+			errorx.Ctx().Set("key", key))
+	}
+	return nil
+}
+
+func toSecret(secret *database.ResourceSecret, value string) types.Secret {
+	return types.Secret{
+		Key:        secret.Key,
+		Value:      types.MaskSecret(value),
+		KeyVersion: secret.KeyVersion,
+		CreatedAt:  secret.CreatedAt,
+		UpdatedAt:  secret.UpdatedAt,
+	}
+}
@@ -0,0 +1,149 @@
+package secret
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	mockdb "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+	"opencsg.com/csghub-server/common/utils/cryptox"
+)
+
+var (
+	testKey1 = []byte("test-secret-master-key-000000001")
+	testKey2 = []byte("test-secret-master-key-000000002")
+)
+
+func newTestKeyring(t *testing.T, active int) *cryptox.Keyring {
+	keyring, err := cryptox.NewKeyring(cryptox.KeyringConfig{
+		MasterKeys:    map[int][]byte{1: testKey1, 2: testKey2},
+		ActiveVersion: active,
+		Salt:          []byte("test-secret-salt-0000"),
+		InfoPrefix:    infoPrefix,
+	})
+	require.NoError(t, err)
+	return keyring
+}
+
+func newTestManager(t *testing.T, active int) (*managerImpl, *mockdb.MockResourceSecretStore, *mockdb.MockSpaceStore) {
+	secretStore := mockdb.NewMockResourceSecretStore(t)
+	spaceStore := mockdb.NewMockSpaceStore(t)
+	m := NewManagerWithStores(newTestKeyring(t, active), secretStore, spaceStore).(*managerImpl)
+	return m, secretStore, spaceStore
+}
+
+func TestManager_SetAndList(t *testing.T) {
+	ctx := context.TODO()
+	m, secretStore, _ := newTestManager(t, 1)
+
+	var saved *database.ResourceSecret
+	secretStore.EXPECT().FindByKey(ctx, types.SecretOwnerSpace, int64(1), "HF_TOKEN").Return(nil, errorx.ErrDatabaseNoRows)
+	secretStore.EXPECT().ListByOwner(ctx, types.SecretOwnerSpace, int64(1)).Return(nil, nil).Once()
+	secretStore.EXPECT().Upsert(ctx, mock.Anything).RunAndReturn(func(ctx context.Context, s *database.ResourceSecret) (*database.ResourceSecret, error) {
+		saved = s
+		return s, nil
+	})
+	secret, err := m.Set(ctx, types.SecretOwnerSpace, 1, "HF_TOKEN", "hf_0123456789abcdef")
+	require.NoError(t, err)
+	require.Equal(t, "******cdef", secret.Value)
+	require.Equal(t, 1, saved.KeyVersion)
+	require.NotContains(t, saved.Ciphertext, "hf_0123456789abcdef")
+
+	secretStore.EXPECT().ListByOwner(ctx, types.SecretOwnerSpace, int64(1)).Return([]database.ResourceSecret{*saved}, nil)
+	secrets, err := m.List(ctx, types.SecretOwnerSpace, 1)
+	require.NoError(t, err)
+	require.Len(t, secrets, 1)
+	require.Equal(t, "******cdef", secrets[0].Value)
+
+	values, err := m.Values(ctx, types.SecretOwnerSpace, 1)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"HF_TOKEN": "hf_0123456789abcdef"}, values)
+}
+
+func TestManager_CiphertextBoundToOwner(t *testing.T) {
+	ctx := context.TODO()
+	m, secretStore, _ := newTestManager(t, 1)
+
+	secret, err := m.encrypt(types.SecretOwnerSpace, 1, "TOKEN", "abc")
+	require.NoError(t, err)
+	secret.OwnerID = 2
+	secretStore.EXPECT().ListByOwner(ctx, types.SecretOwnerSpace, int64(2)).Return([]database.ResourceSecret{*secret}, nil)
+	_, err = m.Values(ctx, types.SecretOwnerSpace, 2)
+	require.Error(t, err)
+}
+
+func TestManager_SetInvalid(t *testing.T) {
+	ctx := context.TODO()
+	m, _, _ := newTestManager(t, 1)
+
+	_, err := m.Set(ctx, types.SecretOwnerDeploy, 1, "1TOKEN", "abc")
+	require.ErrorIs(t, err, errorx.ErrReqParamInvalid)
+
+	m.keyring = nil
+	_, err = m.Set(ctx, types.SecretOwnerDeploy, 1, "TOKEN", "abc")
+	require.Error(t, err)
+}
+
+func TestManager_ReplaceKeepsMaskedValues(t *testing.T) {
+	ctx := context.TODO()
+	m, secretStore, _ := newTestManager(t, 1)
+
+	current, err := m.encrypt(types.SecretOwnerSpace, 1, "HF_TOKEN", "hf_0123456789abcdef")
+	require.NoError(t, err)
+	secretStore.EXPECT().ListByOwner(ctx, types.SecretOwnerSpace, int64(1)).Return([]database.ResourceSecret{*current}, nil)
+	var replaced []database.ResourceSecret
+	secretStore.EXPECT().ReplaceByOwner(ctx, types.SecretOwnerSpace, int64(1), mock.Anything).
+		RunAndReturn(func(ctx context.Context, ownerType types.SecretOwnerType, ownerID int64, secrets []database.ResourceSecret) error {
+			replaced = secrets
+			return nil
+		})
+
+	err = m.Replace(ctx, types.SecretOwnerSpace, 1, map[string]string{"HF_TOKEN": "******cdef", "OTHER": "xyz"})
+	require.NoError(t, err)
+	require.Len(t, replaced, 2)
+	values := map[string]string{}
+	for i := range replaced {
+		value, err := m.decrypt(&replaced[i])
+		require.NoError(t, err)
+		values[replaced[i].Key] = value
+	}
+	require.Equal(t, map[string]string{"HF_TOKEN": "hf_0123456789abcdef", "OTHER": "xyz"}, values)
+}
+
+func TestManager_Reencrypt(t *testing.T) {
+	ctx := context.TODO()
+	old, _, _ := newTestManager(t, 1)
+	m, secretStore, spaceStore := newTestManager(t, 2)
+
+	secret, err := old.encrypt(types.SecretOwnerDeploy, 5, "TOKEN", "abc")
+	require.NoError(t, err)
+	secret.ID = 7
+	secretStore.EXPECT().ListNotInKeyVersion(ctx, 2, int64(0), 10).Return([]database.ResourceSecret{*secret}, nil)
+	secretStore.EXPECT().UpdateCiphertext(ctx, mock.Anything).RunAndReturn(func(ctx context.Context, s *database.ResourceSecret) error {
+		require.Equal(t, int64(7), s.ID)
+		require.Equal(t, 2, s.KeyVersion)
+		value, err := m.decrypt(s)
+		require.NoError(t, err)
+		require.Equal(t, "abc", value)
+		return nil
+	})
+
+	spaceStore.EXPECT().ListWithPlainSecrets(ctx, int64(0), 10).Return([]database.Space{
+		{ID: 3, Secrets: `{"TOKEN":"plain","bad-key":"x"}`},
+	}, nil)
+	secretStore.EXPECT().ImportSpaceSecrets(ctx, int64(3), mock.Anything).RunAndReturn(func(ctx context.Context, spaceID int64, secrets []database.ResourceSecret) error {
+		require.Len(t, secrets, 1)
+		value, err := m.decrypt(&secrets[0])
+		require.NoError(t, err)
+		require.Equal(t, "plain", value)
+		return nil
+	})
+
+	res, err := m.Reencrypt(ctx, 10)
+	require.NoError(t, err)
+	require.Equal(t, &types.SecretReencryption{ActiveKeyVersion: 2, Reencrypted: 1, Imported: 1}, res)
+}
@@ -16,6 +16,7 @@ import (
 var sortBy = map[string]string{
 	"trending":        "popularity DESC NULLS LAST",
 	"recently_update": "updated_at DESC NULLS LAST",
+	"recently_create": "created_at DESC NULLS LAST",
 	"most_download":   "download_count DESC NULLS LAST",
 	"most_favorite":   "likes DESC NULLS LAST",
 	"most_star":       "star_count DESC NULLS LAST",
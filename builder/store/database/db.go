@@ -131,6 +131,7 @@ func NewDB(ctx context.Context, config DBConfig) (db *DB, err error) {
 	db.BunDB.RegisterModel((*RepositoryTag)(nil))
 	db.BunDB.RegisterModel((*CollectionRepository)(nil))
 	db.BunDB.RegisterModel((*RepositoryStatistics)(nil))
+	db.BunDB.RegisterModel((*DiscussionLabelRelation)(nil))
 	return
 }
 
@@ -0,0 +1,177 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/uptrace/bun"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+)
+
+type deployAlertStoreImpl struct {
+	db *DB
+}
+
+type DeployAlertStore interface {
+	CreateRule(ctx context.Context, rule *DeployAlertRule) (*DeployAlertRule, error)
+	UpdateRule(ctx context.Context, rule *DeployAlertRule) error
+	DeleteRule(ctx context.Context, id int64) error
+	FindRuleByID(ctx context.Context, id int64) (*DeployAlertRule, error)
+	ListRulesByDeployID(ctx context.Context, deployID int64) ([]DeployAlertRule, error)
+	// ListEnabledRules returns the enabled rules with their deploys after the rule id in the id order
+	ListEnabledRules(ctx context.Context, afterID int64, limit int) ([]DeployAlertRule, error)
+	// UpdateRuleState saves the evaluation result of the rule, and the event if the rule fired or resolved
+	UpdateRuleState(ctx context.Context, rule *DeployAlertRule, event *DeployAlertEvent) error
+	ListEventsByDeployID(ctx context.Context, deployID int64, per, page int) ([]DeployAlertEvent, int, error)
+}
+
+func NewDeployAlertStore() DeployAlertStore {
+	return &deployAlertStoreImpl{
+		db: defaultDB,
+	}
+}
+
+func NewDeployAlertStoreWithDB(db *DB) DeployAlertStore {
+	return &deployAlertStoreImpl{
+		db: db,
+	}
+}
+
+// DeployAlertRule watches a health metric of a deploy, see types.DeployAlertState for its states
+type DeployAlertRule struct {
+	ID              int64                     `bun:",pk,autoincrement" json:"id"`
+	DeployID        int64                     `bun:",notnull" json:"deploy_id"`
+	Deploy          *Deploy                   `bun:"rel:belongs-to,join:deploy_id=id" json:"deploy,omitempty"`
+	UserUUID        string                    `bun:",notnull" json:"user_uuid"`
+	Name            string                    `bun:",nullzero" json:"name"`
+	Metric          types.DeployAlertMetric   `bun:",notnull" json:"metric"`
+	Operator        types.DeployAlertOperator `bun:",notnull" json:"operator"`
+	Threshold       float64                   `bun:",notnull,default:0" json:"threshold"`
+	DurationSeconds int64                     `bun:",notnull,default:0" json:"duration_seconds"`
+	Window          string                    `bun:",notnull" json:"window"`
+	Enabled         bool                      `bun:",notnull,default:true" json:"enabled"`
+	State           types.DeployAlertState    `bun:",notnull" json:"state"`
+	LastValue       float64                   `bun:",notnull,default:0" json:"last_value"`
+	// PendingSince is when the threshold started to be breached
+	PendingSince    *time.Time `bun:",nullzero" json:"pending_since"`
+	LastEvaluatedAt *time.Time `bun:",nullzero" json:"last_evaluated_at"`
+	FiredAt         *time.Time `bun:",nullzero" json:"fired_at"`
+	ResolvedAt      *time.Time `bun:",nullzero" json:"resolved_at"`
+	times
+}
+
+type DeployAlertEvent struct {
+	ID        int64                   `bun:",pk,autoincrement" json:"id"`
+	RuleID    int64                   `bun:",notnull" json:"rule_id"`
+	DeployID  int64                   `bun:",notnull" json:"deploy_id"`
+	RuleName  string                  `bun:",nullzero" json:"rule_name"`
+	Metric    types.DeployAlertMetric `bun:",notnull" json:"metric"`
+	State     types.DeployAlertState  `bun:",notnull" json:"state"`
+	Value     float64                 `bun:",notnull,default:0" json:"value"`
+	Threshold float64                 `bun:",notnull,default:0" json:"threshold"`
+	CreatedAt time.Time               `bun:",nullzero,notnull,skipupdate,default:current_timestamp" json:"created_at"`
+}
+
+var deployAlertStateColumns = []string{"state", "last_value", "pending_since", "last_evaluated_at", "fired_at", "resolved_at", "updated_at"}
+
+func (s *deployAlertStoreImpl) CreateRule(ctx context.Context, rule *DeployAlertRule) (*DeployAlertRule, error) {
+	_, err := s.db.Core.NewInsert().Model(rule).Exec(ctx, rule)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create deploy alert rule, error: %w",
+			errorx.HandleDBError(err, errorx.Ctx().Set("deploy_id", rule.DeployID)))
+	}
+	return rule, nil
+}
+
+func (s *deployAlertStoreImpl) UpdateRule(ctx context.Context, rule *DeployAlertRule) error {
+	_, err := s.db.Core.NewUpdate().Model(rule).WherePK().Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to update deploy alert rule, error: %w",
+			errorx.HandleDBError(err, errorx.Ctx().Set("id", rule.ID)))
+	}
+	return nil
+}
+
+func (s *deployAlertStoreImpl) DeleteRule(ctx context.Context, id int64) error {
+	_, err := s.db.Core.NewDelete().Model((*DeployAlertRule)(nil)).Where("id = ?", id).Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to delete deploy alert rule, error: %w",
+			errorx.HandleDBError(err, errorx.Ctx().Set("id", id)))
+	}
+	return nil
+}
+
+func (s *deployAlertStoreImpl) FindRuleByID(ctx context.Context, id int64) (*DeployAlertRule, error) {
+	rule := &DeployAlertRule{}
+	err := s.db.Core.NewSelect().Model(rule).Where("id = ?", id).Scan(ctx)
+	if err != nil {
+		return nil, errorx.HandleDBError(err, errorx.Ctx().Set("id", id))
+	}
+	return rule, nil
+}
+
+func (s *deployAlertStoreImpl) ListRulesByDeployID(ctx context.Context, deployID int64) ([]DeployAlertRule, error) {
+	var rules []DeployAlertRule
+	err := s.db.Core.NewSelect().Model(&rules).
+		Where("deploy_id = ?", deployID).
+		Order("id ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, errorx.HandleDBError(err, errorx.Ctx().Set("deploy_id", deployID))
+	}
+	return rules, nil
+}
+
+func (s *deployAlertStoreImpl) ListEnabledRules(ctx context.Context, afterID int64, limit int) ([]DeployAlertRule, error) {
+	var rules []DeployAlertRule
+	// the rules of the deleted deploys are left out by the join
+	err := s.db.Core.NewSelect().Model(&rules).
+		Relation("Deploy").
+		Where("deploy_alert_rule.enabled = ?", true).
+		Where("deploy_alert_rule.id > ?", afterID).
+		Where("deploy.id IS NOT NULL").
+		Order("deploy_alert_rule.id ASC").
+		Limit(limit).
+		Scan(ctx)
+	if err != nil {
+		return nil, errorx.HandleDBError(err, nil)
+	}
+	return rules, nil
+}
+
+func (s *deployAlertStoreImpl) UpdateRuleState(ctx context.Context, rule *DeployAlertRule, event *DeployAlertEvent) error {
+	rule.UpdatedAt = time.Now()
+	err := s.db.Core.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+		_, err := tx.NewUpdate().Model(rule).Column(deployAlertStateColumns...).WherePK().Exec(ctx)
+		if err != nil {
+			return err
+		}
+		if event == nil {
+			return nil
+		}
+		_, err = tx.NewInsert().Model(event).Exec(ctx, event)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update deploy alert rule state, error: %w",
+			errorx.HandleDBError(err, errorx.Ctx().Set("id", rule.ID)))
+	}
+	return nil
+}
+
+func (s *deployAlertStoreImpl) ListEventsByDeployID(ctx context.Context, deployID int64, per, page int) ([]DeployAlertEvent, int, error) {
+	var events []DeployAlertEvent
+	total, err := s.db.Core.NewSelect().Model(&events).
+		Where("deploy_id = ?", deployID).
+		Order("id DESC").
+		Limit(per).
+		Offset((page - 1) * per).
+		ScanAndCount(ctx)
+	if err != nil {
+		return nil, 0, errorx.HandleDBError(err, errorx.Ctx().Set("deploy_id", deployID))
+	}
+	return events, total, nil
+}
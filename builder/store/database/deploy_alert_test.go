@@ -0,0 +1,118 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/tests"
+	"opencsg.com/csghub-server/common/types"
+)
+
+func TestDeployAlertStore_RuleCRUD(t *testing.T) {
+	db := tests.InitTestDB()
+	defer db.Close()
+	ctx := context.TODO()
+
+	store := database.NewDeployAlertStoreWithDB(db)
+	rule, err := store.CreateRule(ctx, &database.DeployAlertRule{
+		DeployID: 1, UserUUID: "u1", Name: "errors", Metric: types.DeployAlertMetricErrorRate,
+		Operator: types.DeployAlertOperatorGT, Threshold: 0.1, DurationSeconds: 300, Window: "5m",
+		Enabled: true, State: types.DeployAlertStateOK,
+	})
+	require.Nil(t, err)
+	_, err = store.CreateRule(ctx, &database.DeployAlertRule{
+		DeployID: 1, UserUUID: "u1", Metric: types.DeployAlertMetricRestarts,
+		Operator: types.DeployAlertOperatorGT, Threshold: 3, Window: "10m",
+		Enabled: true, State: types.DeployAlertStateOK,
+	})
+	require.Nil(t, err)
+
+	rule.Threshold = 0.2
+	err = store.UpdateRule(ctx, rule)
+	require.Nil(t, err)
+	found, err := store.FindRuleByID(ctx, rule.ID)
+	require.Nil(t, err)
+	require.Equal(t, 0.2, found.Threshold)
+	require.Equal(t, "errors", found.Name)
+
+	rules, err := store.ListRulesByDeployID(ctx, 1)
+	require.Nil(t, err)
+	require.Equal(t, 2, len(rules))
+	require.Equal(t, rule.ID, rules[0].ID)
+
+	err = store.DeleteRule(ctx, rule.ID)
+	require.Nil(t, err)
+	_, err = store.FindRuleByID(ctx, rule.ID)
+	require.ErrorIs(t, err, errorx.ErrDatabaseNoRows)
+}
+
+func TestDeployAlertStore_EvaluationState(t *testing.T) {
+	db := tests.InitTestDB()
+	defer db.Close()
+	ctx := context.TODO()
+
+	deploy := &database.Deploy{DeployName: "d1", SvcName: "svc1", UserUUID: "u1"}
+	err := database.NewDeployTaskStoreWithDB(db).CreateDeploy(ctx, deploy)
+	require.Nil(t, err)
+
+	store := database.NewDeployAlertStoreWithDB(db)
+	rule, err := store.CreateRule(ctx, &database.DeployAlertRule{
+		DeployID: deploy.ID, UserUUID: "u1", Metric: types.DeployAlertMetricLatency,
+		Operator: types.DeployAlertOperatorGT, Threshold: 500, Window: "5m",
+		Enabled: true, State: types.DeployAlertStateOK,
+	})
+	require.Nil(t, err)
+	// the rules of a deleted deploy and the disabled rules are not evaluated
+	_, err = store.CreateRule(ctx, &database.DeployAlertRule{
+		DeployID: deploy.ID + 100, UserUUID: "u1", Metric: types.DeployAlertMetricLatency,
+		Operator: types.DeployAlertOperatorGT, Threshold: 500, Window: "5m",
+		Enabled: true, State: types.DeployAlertStateOK,
+	})
+	require.Nil(t, err)
+	disabled, err := store.CreateRule(ctx, &database.DeployAlertRule{
+		DeployID: deploy.ID, UserUUID: "u1", Metric: types.DeployAlertMetricRestarts,
+		Operator: types.DeployAlertOperatorGT, Threshold: 1, Window: "5m",
+		Enabled: true, State: types.DeployAlertStateOK,
+	})
+	require.Nil(t, err)
+	disabled.Enabled = false
+	require.Nil(t, store.UpdateRule(ctx, disabled))
+
+	rules, err := store.ListEnabledRules(ctx, 0, 10)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(rules))
+	require.Equal(t, rule.ID, rules[0].ID)
+	require.Equal(t, "svc1", rules[0].Deploy.SvcName)
+	rules, err = store.ListEnabledRules(ctx, rule.ID, 10)
+	require.Nil(t, err)
+	require.Equal(t, 0, len(rules))
+
+	now := time.Now().Truncate(time.Second)
+	rule.State = types.DeployAlertStateFiring
+	rule.LastValue = 800
+	rule.FiredAt = &now
+	rule.LastEvaluatedAt = &now
+	err = store.UpdateRuleState(ctx, rule, &database.DeployAlertEvent{
+		RuleID: rule.ID, DeployID: deploy.ID, Metric: rule.Metric,
+		State: types.DeployAlertStateFiring, Value: 800, Threshold: 500,
+	})
+	require.Nil(t, err)
+	err = store.UpdateRuleState(ctx, rule, nil)
+	require.Nil(t, err)
+
+	found, err := store.FindRuleByID(ctx, rule.ID)
+	require.Nil(t, err)
+	require.Equal(t, types.DeployAlertStateFiring, found.State)
+	require.Equal(t, float64(800), found.LastValue)
+	require.True(t, found.FiredAt.Equal(now))
+
+	events, total, err := store.ListEventsByDeployID(ctx, deploy.ID, 10, 1)
+	require.Nil(t, err)
+	require.Equal(t, 1, total)
+	require.Equal(t, types.DeployAlertStateFiring, events[0].State)
+	require.Equal(t, float64(800), events[0].Value)
+}
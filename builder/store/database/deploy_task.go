@@ -73,6 +73,8 @@ type Deploy struct {
 	PD             *types.PDConfig      `bun:"type:jsonb,nullzero" json:"pd,omitempty"`
 	Timeout        int                  `json:"timeout,omitempty"`
 	StatusUpdateAt time.Time            `bun:",nullzero,notnull,default:current_timestamp" json:"status_update_at,omitempty"`
+	// CredentialNames are the credentials of the owner namespace (OwnerNamespace) injected into the deploy as secrets
+	CredentialNames []string `bun:"type:jsonb,nullzero" json:"credential_names,omitempty"`
 	times
 }
 
@@ -9,28 +9,57 @@ import (
 )
 
 type Discussion struct {
-	ID                 int64     `bun:"id,pk,autoincrement"`
-	UserID             int64     `bun:"user_id,notnull"`
-	User               *User     `bun:"rel:belongs-to,join:user_id=id"`
-	Title              string    `bun:"title,notnull"`
-	DiscussionableID   int64     `bun:"discussionable_id,notnull"`
-	DiscussionableType string    `bun:"discussionable_type,notnull"`
-	CommentCount       int64     `bun:"comment_count,notnull,default:0"`
-	DeletedAt          time.Time `bun:",nullzero"`
+	ID                 int64  `bun:"id,pk,autoincrement"`
+	UserID             int64  `bun:"user_id,notnull"`
+	User               *User  `bun:"rel:belongs-to,join:user_id=id"`
+	Title              string `bun:"title,notnull"`
+	DiscussionableID   int64  `bun:"discussionable_id,notnull"`
+	DiscussionableType string `bun:"discussionable_type,notnull"`
+	CommentCount       int64  `bun:"comment_count,notnull,default:0"`
+	// Status is one of open, closed and locked, see types.DiscussionStatus
+	Status    string            `bun:"status,notnull,default:'open'"`
+	Pinned    bool              `bun:"pinned,notnull,default:false"`
+	Labels    []DiscussionLabel `bun:"m2m:discussion_label_relations,join:Discussion=Label"`
+	DeletedAt time.Time         `bun:",nullzero"`
 	times
 }
 
 type Comment struct {
-	ID              int64     `bun:"id,pk,autoincrement"`
-	Content         string    `bun:"content"`
-	CommentableType string    `bun:"commentable_type,notnull"`
-	CommentableID   int64     `bun:"commentable_id,notnull"`
-	UserID          int64     `bun:"user_id,notnull"`
-	User            *User     `bun:"rel:belongs-to,join:user_id=id"`
-	DeletedAt       time.Time `bun:",nullzero"`
+	ID              int64  `bun:"id,pk,autoincrement"`
+	Content         string `bun:"content"`
+	CommentableType string `bun:"commentable_type,notnull"`
+	CommentableID   int64  `bun:"commentable_id,notnull"`
+	UserID          int64  `bun:"user_id,notnull"`
+	User            *User  `bun:"rel:belongs-to,join:user_id=id"`
+	// Hidden comments are hidden by the repo admins, the content is only visible to the admins and the author
+	Hidden    bool      `bun:"hidden,notnull,default:false"`
+	DeletedAt time.Time `bun:",nullzero"`
 	times
 }
 
+type CommentReaction struct {
+	ID        int64  `bun:"id,pk,autoincrement"`
+	CommentID int64  `bun:"comment_id,notnull"`
+	UserID    int64  `bun:"user_id,notnull"`
+	Emoji     string `bun:"emoji,notnull"`
+	times
+}
+
+// CommentReactionSummary is the count of an emoji reaction on a comment, and whether the given user reacted with it
+type CommentReactionSummary struct {
+	CommentID int64  `bun:"comment_id"`
+	Emoji     string `bun:"emoji"`
+	Count     int    `bun:"count"`
+	Reacted   bool   `bun:"reacted"`
+}
+
+// DiscussionFilter filters the discussions of a discussionable, empty fields match all
+type DiscussionFilter struct {
+	Status string
+	// Label is the name of a label of the discussions
+	Label string
+}
+
 const (
 	CommentableTypeDiscussion = "discussion"
 	CommentableTypeArticle    = "article"
@@ -48,14 +77,23 @@ type discussionStoreImpl struct {
 type DiscussionStore interface {
 	Create(ctx context.Context, discussion Discussion) (*Discussion, error)
 	FindByID(ctx context.Context, id int64) (*Discussion, error)
-	FindByDiscussionableID(ctx context.Context, discussionableType string, discussionableID int64, per int, page int) ([]Discussion, int, error)
+	FindByDiscussionableID(ctx context.Context, discussionableType string, discussionableID int64, filter DiscussionFilter, per int, page int) ([]Discussion, int, error)
 	UpdateByID(ctx context.Context, id int64, title string) error
+	UpdateStatus(ctx context.Context, id int64, status string) error
+	SetPinned(ctx context.Context, id int64, pinned bool) error
+	// Transfer moves the discussion to another repository and drops its labels which are scoped to the old repository
+	Transfer(ctx context.Context, id int64, repoID int64) error
 	DeleteByID(ctx context.Context, id int64) error
 	FindDiscussionComments(ctx context.Context, discussionID int64, per int, page int) ([]Comment, error)
 	CreateComment(ctx context.Context, comment Comment) (*Comment, error)
 	UpdateComment(ctx context.Context, id int64, content string) error
 	FindCommentByID(ctx context.Context, id int64) (*Comment, error)
 	DeleteComment(ctx context.Context, id int64) error
+	SetCommentHidden(ctx context.Context, id int64, hidden bool) error
+	AddReaction(ctx context.Context, reaction CommentReaction) error
+	DeleteReaction(ctx context.Context, commentID, userID int64, emoji string) error
+	// ListReactions returns the reaction counts of the comments, Reacted is set for the reactions of the given username
+	ListReactions(ctx context.Context, commentIDs []int64, username string) ([]CommentReactionSummary, error)
 }
 
 func NewDiscussionStore() DiscussionStore {
@@ -84,6 +122,7 @@ func (s *discussionStoreImpl) FindByID(ctx context.Context, id int64) (*Discussi
 	err := s.db.Core.NewSelect().Model(&discussion).
 		Where("discussion.id = ?", id).
 		Relation("User").
+		Relation("Labels").
 		Scan(ctx)
 	if err != nil {
 		err := errorx.HandleDBError(err, errorx.Ctx().
@@ -93,12 +132,24 @@ func (s *discussionStoreImpl) FindByID(ctx context.Context, id int64) (*Discussi
 	return &discussion, nil
 }
 
-func (s *discussionStoreImpl) FindByDiscussionableID(ctx context.Context, discussionableType string, discussionableID int64, per int, page int) ([]Discussion, int, error) {
+func (s *discussionStoreImpl) FindByDiscussionableID(ctx context.Context, discussionableType string, discussionableID int64, filter DiscussionFilter, per int, page int) ([]Discussion, int, error) {
 	discussions := []Discussion{}
 	q := s.db.Core.NewSelect().Model(&discussions).
 		Where("discussionable_type = ? AND discussionable_id = ?", discussionableType, discussionableID).
 		Relation("User").
-		Order("created_at DESC")
+		Relation("Labels")
+	if filter.Status != "" {
+		q = q.Where("discussion.status = ?", filter.Status)
+	}
+	if filter.Label != "" {
+		q = q.Where("EXISTS (?)", s.db.Core.NewSelect().
+			TableExpr("discussion_label_relations AS dlr").
+			Join("JOIN discussion_labels AS dl ON dl.id = dlr.label_id").
+			ColumnExpr("1").
+			Where("dlr.discussion_id = discussion.id").
+			Where("dl.name = ?", filter.Label))
+	}
+	q = q.Order("discussion.pinned DESC", "discussion.created_at DESC")
 
 	total, err := q.Count(ctx)
 	if err != nil {
@@ -127,6 +178,56 @@ func (s *discussionStoreImpl) UpdateByID(ctx context.Context, id int64, title st
 	return nil
 }
 
+func (s *discussionStoreImpl) UpdateStatus(ctx context.Context, id int64, status string) error {
+	_, err := s.db.Core.NewUpdate().Model(&Discussion{}).
+		Set("status = ?", status).
+		Set("updated_at = ?", time.Now()).
+		Where("id = ?", id).
+		Exec(ctx)
+	if err != nil {
+		return errorx.HandleDBError(err, errorx.Ctx().
+			Set("id", id))
+	}
+	return nil
+}
+
+func (s *discussionStoreImpl) SetPinned(ctx context.Context, id int64, pinned bool) error {
+	_, err := s.db.Core.NewUpdate().Model(&Discussion{}).
+		Set("pinned = ?", pinned).
+		Set("updated_at = ?", time.Now()).
+		Where("id = ?", id).
+		Exec(ctx)
+	if err != nil {
+		return errorx.HandleDBError(err, errorx.Ctx().
+			Set("id", id))
+	}
+	return nil
+}
+
+func (s *discussionStoreImpl) Transfer(ctx context.Context, id int64, repoID int64) error {
+	err := s.db.Operator.Core.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		_, err := tx.NewUpdate().Model(&Discussion{}).
+			Set("discussionable_id = ?", repoID).
+			Set("pinned = ?", false).
+			Set("updated_at = ?", time.Now()).
+			Where("id = ?", id).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+		_, err = tx.NewDelete().Model(&DiscussionLabelRelation{}).
+			Where("discussion_id = ?", id).
+			Exec(ctx)
+		return err
+	})
+	if err != nil {
+		return errorx.HandleDBError(err, errorx.Ctx().
+			Set("id", id).
+			Set("repo_id", repoID))
+	}
+	return nil
+}
+
 func (s *discussionStoreImpl) DeleteByID(ctx context.Context, id int64) error {
 	_, err := s.db.Core.NewDelete().Model(&Discussion{}).Where("id = ?", id).ForceDelete().Exec(ctx)
 	if err != nil {
@@ -241,3 +342,59 @@ func (s *discussionStoreImpl) DeleteComment(ctx context.Context, id int64) error
 	}
 	return nil
 }
+
+func (s *discussionStoreImpl) SetCommentHidden(ctx context.Context, id int64, hidden bool) error {
+	_, err := s.db.Core.NewUpdate().Model(&Comment{}).
+		Set("hidden = ?", hidden).
+		Where("id = ?", id).
+		Exec(ctx)
+	if err != nil {
+		return errorx.HandleDBError(err, errorx.Ctx().
+			Set("id", id))
+	}
+	return nil
+}
+
+func (s *discussionStoreImpl) AddReaction(ctx context.Context, reaction CommentReaction) error {
+	_, err := s.db.Core.NewInsert().Model(&reaction).
+		On("CONFLICT (comment_id, user_id, emoji) DO NOTHING").
+		Exec(ctx)
+	if err != nil {
+		return errorx.HandleDBError(err, errorx.Ctx().
+			Set("comment_id", reaction.CommentID).
+			Set("emoji", reaction.Emoji))
+	}
+	return nil
+}
+
+func (s *discussionStoreImpl) DeleteReaction(ctx context.Context, commentID, userID int64, emoji string) error {
+	_, err := s.db.Core.NewDelete().Model(&CommentReaction{}).
+		Where("comment_id = ? AND user_id = ? AND emoji = ?", commentID, userID, emoji).
+		Exec(ctx)
+	if err != nil {
+		return errorx.HandleDBError(err, errorx.Ctx().
+			Set("comment_id", commentID).
+			Set("emoji", emoji))
+	}
+	return nil
+}
+
+func (s *discussionStoreImpl) ListReactions(ctx context.Context, commentIDs []int64, username string) ([]CommentReactionSummary, error) {
+	summaries := []CommentReactionSummary{}
+	if len(commentIDs) == 0 {
+		return summaries, nil
+	}
+	err := s.db.Core.NewSelect().
+		TableExpr("comment_reactions AS cr").
+		Join("JOIN users AS u ON u.id = cr.user_id").
+		ColumnExpr("cr.comment_id, cr.emoji, count(*) AS count").
+		ColumnExpr("bool_or(u.username = ?) AS reacted", username).
+		Where("cr.comment_id IN (?)", bun.In(commentIDs)).
+		Group("cr.comment_id", "cr.emoji").
+		OrderExpr("min(cr.id)").
+		Scan(ctx, &summaries)
+	if err != nil {
+		return nil, errorx.HandleDBError(err, nil)
+	}
+	return summaries, nil
+}
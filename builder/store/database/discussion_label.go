@@ -0,0 +1,143 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/uptrace/bun"
+	"opencsg.com/csghub-server/common/errorx"
+)
+
+// DiscussionLabel is a label scoped to a repository for triaging its discussions
+type DiscussionLabel struct {
+	ID           int64  `bun:"id,pk,autoincrement"`
+	RepositoryID int64  `bun:"repository_id,notnull"`
+	Name         string `bun:"name,notnull"`
+	Color        string `bun:"color"`
+	Description  string `bun:"description"`
+	times
+}
+
+type DiscussionLabelRelation struct {
+	ID           int64            `bun:"id,pk,autoincrement"`
+	DiscussionID int64            `bun:"discussion_id,notnull"`
+	Discussion   *Discussion      `bun:"rel:belongs-to,join:discussion_id=id"`
+	LabelID      int64            `bun:"label_id,notnull"`
+	Label        *DiscussionLabel `bun:"rel:belongs-to,join:label_id=id"`
+}
+
+type discussionLabelStoreImpl struct {
+	db *DB
+}
+
+type DiscussionLabelStore interface {
+	Create(ctx context.Context, label DiscussionLabel) (*DiscussionLabel, error)
+	Update(ctx context.Context, label *DiscussionLabel) error
+	// Delete removes the label along with its relations to the discussions
+	Delete(ctx context.Context, id int64) error
+	FindByID(ctx context.Context, id int64) (*DiscussionLabel, error)
+	ListByRepoID(ctx context.Context, repoID int64) ([]DiscussionLabel, error)
+	// SetDiscussionLabels replaces the labels of the discussion
+	SetDiscussionLabels(ctx context.Context, discussionID int64, labelIDs []int64) error
+}
+
+func NewDiscussionLabelStore() DiscussionLabelStore {
+	return &discussionLabelStoreImpl{
+		db: defaultDB,
+	}
+}
+
+func NewDiscussionLabelStoreWithDB(db *DB) DiscussionLabelStore {
+	return &discussionLabelStoreImpl{
+		db: db,
+	}
+}
+
+func (s *discussionLabelStoreImpl) Create(ctx context.Context, label DiscussionLabel) (*DiscussionLabel, error) {
+	_, err := s.db.Core.NewInsert().Model(&label).Exec(ctx)
+	if err != nil {
+		return nil, errorx.HandleDBError(err, errorx.Ctx().
+			Set("repo_id", label.RepositoryID).
+			Set("name", label.Name))
+	}
+	return &label, nil
+}
+
+func (s *discussionLabelStoreImpl) Update(ctx context.Context, label *DiscussionLabel) error {
+	label.UpdatedAt = time.Now()
+	_, err := s.db.Core.NewUpdate().Model(label).
+		Column("name", "color", "description", "updated_at").
+		WherePK().
+		Exec(ctx)
+	if err != nil {
+		return errorx.HandleDBError(err, errorx.Ctx().
+			Set("id", label.ID).
+			Set("name", label.Name))
+	}
+	return nil
+}
+
+func (s *discussionLabelStoreImpl) Delete(ctx context.Context, id int64) error {
+	err := s.db.Operator.Core.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		_, err := tx.NewDelete().Model(&DiscussionLabelRelation{}).Where("label_id = ?", id).Exec(ctx)
+		if err != nil {
+			return err
+		}
+		_, err = tx.NewDelete().Model(&DiscussionLabel{}).Where("id = ?", id).Exec(ctx)
+		return err
+	})
+	if err != nil {
+		return errorx.HandleDBError(err, errorx.Ctx().
+			Set("id", id))
+	}
+	return nil
+}
+
+func (s *discussionLabelStoreImpl) FindByID(ctx context.Context, id int64) (*DiscussionLabel, error) {
+	label := DiscussionLabel{}
+	err := s.db.Core.NewSelect().Model(&label).Where("id = ?", id).Scan(ctx)
+	if err != nil {
+		return nil, errorx.HandleDBError(err, errorx.Ctx().
+			Set("id", id))
+	}
+	return &label, nil
+}
+
+func (s *discussionLabelStoreImpl) ListByRepoID(ctx context.Context, repoID int64) ([]DiscussionLabel, error) {
+	labels := []DiscussionLabel{}
+	err := s.db.Core.NewSelect().Model(&labels).
+		Where("repository_id = ?", repoID).
+		Order("name ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, errorx.HandleDBError(err, errorx.Ctx().
+			Set("repo_id", repoID))
+	}
+	return labels, nil
+}
+
+func (s *discussionLabelStoreImpl) SetDiscussionLabels(ctx context.Context, discussionID int64, labelIDs []int64) error {
+	err := s.db.Operator.Core.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		_, err := tx.NewDelete().Model(&DiscussionLabelRelation{}).Where("discussion_id = ?", discussionID).Exec(ctx)
+		if err != nil {
+			return err
+		}
+		if len(labelIDs) == 0 {
+			return nil
+		}
+		relations := make([]DiscussionLabelRelation, 0, len(labelIDs))
+		for _, labelID := range labelIDs {
+			relations = append(relations, DiscussionLabelRelation{
+				DiscussionID: discussionID,
+				LabelID:      labelID,
+			})
+		}
+		_, err = tx.NewInsert().Model(&relations).Exec(ctx)
+		return err
+	})
+	if err != nil {
+		return errorx.HandleDBError(err, errorx.Ctx().
+			Set("discussion_id", discussionID))
+	}
+	return nil
+}
@@ -38,10 +38,10 @@ func TestDiscussionStore_CRUD(t *testing.T) {
 	require.Nil(t, err)
 	require.Equal(t, "foo", ds.Title)
 
-	dss, _, err := store.FindByDiscussionableID(ctx, "zzz", 123, 10, 1)
+	dss, _, err := store.FindByDiscussionableID(ctx, "zzz", 123, database.DiscussionFilter{}, 10, 1)
 	require.Nil(t, err)
 	require.Equal(t, 1, len(dss))
-	dss, _, err = store.FindByDiscussionableID(ctx, "zzz", 456, 10, 1)
+	dss, _, err = store.FindByDiscussionableID(ctx, "zzz", 456, database.DiscussionFilter{}, 10, 1)
 	require.Nil(t, err)
 	require.Equal(t, 0, len(dss))
 
@@ -78,3 +78,93 @@ func TestDiscussionStore_CRUD(t *testing.T) {
 	require.NotNil(t, err)
 	require.True(t, errors.Is(err, errorx.ErrDatabaseNoRows))
 }
+
+func TestDiscussionStore_Workflow(t *testing.T) {
+	db := tests.InitTestDB()
+	defer db.Close()
+	ctx := context.TODO()
+
+	store := database.NewDiscussionStoreWithDB(db)
+	labelStore := database.NewDiscussionLabelStoreWithDB(db)
+
+	user := &database.User{Username: "foo", UUID: "foo-uuid"}
+	_, err := db.Core.NewInsert().Model(user).Exec(ctx)
+	require.Nil(t, err)
+
+	open, err := store.Create(ctx, database.Discussion{
+		Title:              "open",
+		DiscussionableType: database.DiscussionableTypeRepo,
+		DiscussionableID:   1,
+		UserID:             user.ID,
+		Status:             "open",
+	})
+	require.Nil(t, err)
+	pinned, err := store.Create(ctx, database.Discussion{
+		Title:              "pinned",
+		DiscussionableType: database.DiscussionableTypeRepo,
+		DiscussionableID:   1,
+		UserID:             user.ID,
+		Status:             "open",
+	})
+	require.Nil(t, err)
+	require.Nil(t, store.SetPinned(ctx, pinned.ID, true))
+	require.Nil(t, store.UpdateStatus(ctx, open.ID, "closed"))
+
+	bug, err := labelStore.Create(ctx, database.DiscussionLabel{RepositoryID: 1, Name: "bug", Color: "#d73a4a"})
+	require.Nil(t, err)
+	_, err = labelStore.Create(ctx, database.DiscussionLabel{RepositoryID: 1, Name: "bug"})
+	require.True(t, errors.Is(err, errorx.ErrDatabaseDuplicateKey))
+	require.Nil(t, labelStore.SetDiscussionLabels(ctx, open.ID, []int64{bug.ID}))
+
+	dss, total, err := store.FindByDiscussionableID(ctx, database.DiscussionableTypeRepo, 1, database.DiscussionFilter{}, 10, 1)
+	require.Nil(t, err)
+	require.Equal(t, 2, total)
+	require.Equal(t, "pinned", dss[0].Title)
+	require.Equal(t, "bug", dss[1].Labels[0].Name)
+
+	dss, _, err = store.FindByDiscussionableID(ctx, database.DiscussionableTypeRepo, 1, database.DiscussionFilter{Status: "closed"}, 10, 1)
+	require.Nil(t, err)
+	require.Len(t, dss, 1)
+	require.Equal(t, "open", dss[0].Title)
+
+	dss, _, err = store.FindByDiscussionableID(ctx, database.DiscussionableTypeRepo, 1, database.DiscussionFilter{Label: "bug"}, 10, 1)
+	require.Nil(t, err)
+	require.Len(t, dss, 1)
+	require.Equal(t, open.ID, dss[0].ID)
+
+	comment, err := store.CreateComment(ctx, database.Comment{
+		CommentableID:   open.ID,
+		CommentableType: database.CommentableTypeDiscussion,
+		Content:         "foobar",
+		UserID:          user.ID,
+	})
+	require.Nil(t, err)
+	require.Nil(t, store.SetCommentHidden(ctx, comment.ID, true))
+	comment, err = store.FindCommentByID(ctx, comment.ID)
+	require.Nil(t, err)
+	require.True(t, comment.Hidden)
+
+	reaction := database.CommentReaction{CommentID: comment.ID, UserID: user.ID, Emoji: "heart"}
+	require.Nil(t, store.AddReaction(ctx, reaction))
+	require.Nil(t, store.AddReaction(ctx, reaction))
+	summaries, err := store.ListReactions(ctx, []int64{comment.ID}, "foo")
+	require.Nil(t, err)
+	require.Equal(t, []database.CommentReactionSummary{
+		{CommentID: comment.ID, Emoji: "heart", Count: 1, Reacted: true},
+	}, summaries)
+	require.Nil(t, store.DeleteReaction(ctx, comment.ID, user.ID, "heart"))
+	summaries, err = store.ListReactions(ctx, []int64{comment.ID}, "foo")
+	require.Nil(t, err)
+	require.Empty(t, summaries)
+
+	require.Nil(t, store.Transfer(ctx, open.ID, 2))
+	ds, err := store.FindByID(ctx, open.ID)
+	require.Nil(t, err)
+	require.Equal(t, int64(2), ds.DiscussionableID)
+	require.Empty(t, ds.Labels)
+
+	require.Nil(t, labelStore.Delete(ctx, bug.ID))
+	labels, err := labelStore.ListByRepoID(ctx, 1)
+	require.Nil(t, err)
+	require.Empty(t, labels)
+}
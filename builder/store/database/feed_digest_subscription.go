@@ -0,0 +1,96 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"opencsg.com/csghub-server/common/errorx"
+)
+
+// FeedDigestSubscription is the setting of the activity feed email digest of a user
+type FeedDigestSubscription struct {
+	UserID     int64     `bun:",pk" json:"user_id"`
+	User       *User     `bun:"rel:belongs-to,join:user_id=id" json:"user,omitempty"`
+	Enabled    bool      `bun:",notnull" json:"enabled"`
+	LastSentAt time.Time `bun:",nullzero" json:"last_sent_at"`
+	times
+}
+
+type feedDigestSubscriptionStoreImpl struct {
+	db *DB
+}
+
+type FeedDigestSubscriptionStore interface {
+	// Get returns the subscription of the user, errorx.ErrDatabaseNoRows is returned if the user never
+	// changed the setting
+	Get(ctx context.Context, userID int64) (*FeedDigestSubscription, error)
+	SetEnabled(ctx context.Context, userID int64, enabled bool) error
+	// ListEnabled returns the enabled subscriptions ordered by the user id, the ones after the afterUserID
+	// are returned to page through all the subscriptions
+	ListEnabled(ctx context.Context, afterUserID int64, limit int) ([]FeedDigestSubscription, error)
+	UpdateLastSentAt(ctx context.Context, userID int64, sentAt time.Time) error
+}
+
+func NewFeedDigestSubscriptionStore() FeedDigestSubscriptionStore {
+	return &feedDigestSubscriptionStoreImpl{
+		db: defaultDB,
+	}
+}
+
+func NewFeedDigestSubscriptionStoreWithDB(db *DB) FeedDigestSubscriptionStore {
+	return &feedDigestSubscriptionStoreImpl{
+		db: db,
+	}
+}
+
+func (s *feedDigestSubscriptionStoreImpl) Get(ctx context.Context, userID int64) (*FeedDigestSubscription, error) {
+	var sub FeedDigestSubscription
+	err := s.db.Operator.Core.NewSelect().
+		Model(&sub).
+		Where("user_id = ?", userID).
+		Scan(ctx)
+	if err != nil {
+		return nil, errorx.HandleDBError(err, errorx.Ctx().Set("user_id", userID))
+	}
+	return &sub, nil
+}
+
+func (s *feedDigestSubscriptionStoreImpl) SetEnabled(ctx context.Context, userID int64, enabled bool) error {
+	sub := &FeedDigestSubscription{
+		UserID:  userID,
+		Enabled: enabled,
+	}
+	_, err := s.db.Operator.Core.NewInsert().
+		Model(sub).
+		On("CONFLICT (user_id) DO UPDATE").
+		Set("enabled = EXCLUDED.enabled").
+		Set("updated_at = EXCLUDED.updated_at").
+		Exec(ctx)
+	return errorx.HandleDBError(err, errorx.Ctx().Set("user_id", userID))
+}
+
+func (s *feedDigestSubscriptionStoreImpl) ListEnabled(ctx context.Context, afterUserID int64, limit int) ([]FeedDigestSubscription, error) {
+	var subs []FeedDigestSubscription
+	err := s.db.Operator.Core.NewSelect().
+		Model(&subs).
+		Relation("User").
+		Where("feed_digest_subscription.enabled = ?", true).
+		Where("feed_digest_subscription.user_id > ?", afterUserID).
+		Order("feed_digest_subscription.user_id ASC").
+		Limit(limit).
+		Scan(ctx)
+	if err != nil {
+		return nil, errorx.HandleDBError(err, nil)
+	}
+	return subs, nil
+}
+
+func (s *feedDigestSubscriptionStoreImpl) UpdateLastSentAt(ctx context.Context, userID int64, sentAt time.Time) error {
+	_, err := s.db.Operator.Core.NewUpdate().
+		Model((*FeedDigestSubscription)(nil)).
+		Set("last_sent_at = ?", sentAt).
+		Set("updated_at = ?", time.Now()).
+		Where("user_id = ?", userID).
+		Exec(ctx)
+	return errorx.HandleDBError(err, errorx.Ctx().Set("user_id", userID))
+}
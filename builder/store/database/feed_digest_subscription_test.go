@@ -0,0 +1,59 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/tests"
+)
+
+func TestFeedDigestSubscriptionStore_CRUD(t *testing.T) {
+	db := tests.InitTestDB()
+	defer db.Close()
+	ctx := context.TODO()
+
+	userStore := database.NewUserStoreWithDB(db)
+	var users []*database.User
+	for _, name := range []string{"u1", "u2", "u3"} {
+		user := &database.User{Username: name, NickName: name, Email: name + "@example.com"}
+		err := userStore.Create(ctx, user, &database.Namespace{Path: name})
+		require.Nil(t, err)
+		users = append(users, user)
+	}
+
+	store := database.NewFeedDigestSubscriptionStoreWithDB(db)
+	_, err := store.Get(ctx, users[0].ID)
+	require.ErrorIs(t, err, errorx.ErrDatabaseNoRows)
+
+	for _, user := range users {
+		require.Nil(t, store.SetEnabled(ctx, user.ID, true))
+	}
+	// the setting is updated on conflict
+	require.Nil(t, store.SetEnabled(ctx, users[1].ID, false))
+	sub, err := store.Get(ctx, users[1].ID)
+	require.Nil(t, err)
+	require.False(t, sub.Enabled)
+
+	subs, err := store.ListEnabled(ctx, 0, 10)
+	require.Nil(t, err)
+	require.Len(t, subs, 2)
+	require.Equal(t, users[0].ID, subs[0].UserID)
+	require.Equal(t, "u1", subs[0].User.Username)
+	require.Equal(t, users[2].ID, subs[1].UserID)
+
+	subs, err = store.ListEnabled(ctx, users[0].ID, 10)
+	require.Nil(t, err)
+	require.Len(t, subs, 1)
+	require.Equal(t, users[2].ID, subs[0].UserID)
+
+	sentAt := time.Now().Truncate(time.Second)
+	require.Nil(t, store.UpdateLastSentAt(ctx, users[0].ID, sentAt))
+	sub, err = store.Get(ctx, users[0].ID)
+	require.Nil(t, err)
+	require.True(t, sub.Enabled)
+	require.True(t, sentAt.Equal(sub.LastSentAt))
+}
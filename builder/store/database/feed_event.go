@@ -0,0 +1,116 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/uptrace/bun"
+	"opencsg.com/csghub-server/common/errorx"
+)
+
+// FeedEvent is an event of a repository shown in the activity feeds of the followers of the repository,
+// its namespace and its actor. The feeds are built on read from the follows of the users
+type FeedEvent struct {
+	ID        int64  `bun:",pk,autoincrement" json:"id"`
+	EventType string `bun:",notnull" json:"event_type"`
+	// the user who triggered the event, it's 0 if unknown, e.g. a tag pushed by git
+	ActorID int64 `bun:",nullzero" json:"actor_id"`
+	Actor   *User `bun:"rel:belongs-to,join:actor_id=id" json:"actor,omitempty"`
+	// the namespace of the repository, the username or the organization path
+	Namespace    string         `bun:",notnull" json:"namespace"`
+	RepositoryID int64          `bun:",notnull" json:"repository_id"`
+	Repository   *Repository    `bun:"rel:belongs-to,join:repository_id=id" json:"repository,omitempty"`
+	Payload      map[string]any `bun:",type:jsonb,nullzero" json:"payload"`
+	CreatedAt    time.Time      `bun:",nullzero,notnull,skipupdate,default:current_timestamp" json:"created_at"`
+}
+
+// FeedQuery selects the feed events of a user, the events of the private repositories are visible only
+// when their namespaces are in Namespaces or IsAdmin is true
+type FeedQuery struct {
+	UserID     int64
+	Namespaces []string
+	IsAdmin    bool
+	// only the events created after Since are returned if it's not zero
+	Since time.Time
+	Per   int
+	Page  int
+}
+
+// NewRepoFeedEvent creates the event of the repository, the namespace of the event is taken from the
+// repository path
+func NewRepoFeedEvent(eventType string, repo *Repository, actorID int64, payload map[string]any) *FeedEvent {
+	namespace, _, _ := strings.Cut(repo.Path, "/")
+	return &FeedEvent{
+		EventType:    eventType,
+		ActorID:      actorID,
+		Namespace:    namespace,
+		RepositoryID: repo.ID,
+		Payload:      payload,
+	}
+}
+
+type feedEventStoreImpl struct {
+	db *DB
+}
+
+type FeedEventStore interface {
+	Create(ctx context.Context, event *FeedEvent) error
+	// Feed returns the events of the entities followed by the user, the latest first
+	Feed(ctx context.Context, query FeedQuery) ([]FeedEvent, int, error)
+}
+
+func NewFeedEventStore() FeedEventStore {
+	return &feedEventStoreImpl{
+		db: defaultDB,
+	}
+}
+
+func NewFeedEventStoreWithDB(db *DB) FeedEventStore {
+	return &feedEventStoreImpl{
+		db: db,
+	}
+}
+
+func (s *feedEventStoreImpl) Create(ctx context.Context, event *FeedEvent) error {
+	_, err := s.db.Operator.Core.NewInsert().
+		Model(event).
+		Exec(ctx)
+	return errorx.HandleDBError(err, errorx.Ctx().Set("repo_id", event.RepositoryID))
+}
+
+func (s *feedEventStoreImpl) Feed(ctx context.Context, query FeedQuery) ([]FeedEvent, int, error) {
+	var events []FeedEvent
+	q := s.db.Operator.Core.NewSelect().
+		Model(&events).
+		Relation("Actor").
+		Relation("Repository").
+		Where("repository.id IS NOT NULL").
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Where("feed_event.repository_id IN (SELECT followable_id FROM follows WHERE user_id = ? AND followable_type = 'repository')", query.UserID).
+				WhereOr("feed_event.actor_id IN (SELECT followable_id FROM follows WHERE user_id = ? AND followable_type = 'user')", query.UserID).
+				WhereOr("feed_event.namespace IN (SELECT u.username FROM follows AS f JOIN users AS u ON u.id = f.followable_id WHERE f.user_id = ? AND f.followable_type = 'user')", query.UserID).
+				WhereOr("feed_event.namespace IN (SELECT o.path FROM follows AS f JOIN organizations AS o ON o.id = f.followable_id WHERE f.user_id = ? AND f.followable_type = 'organization')", query.UserID)
+		})
+	if !query.IsAdmin {
+		q.WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			q = q.Where("repository.private = ?", false)
+			for _, namespace := range query.Namespaces {
+				q = q.WhereOr("repository.path LIKE ? ESCAPE '\\'", fmt.Sprintf("%s/%%", escapeLikePattern(namespace)))
+			}
+			return q
+		})
+	}
+	if !query.Since.IsZero() {
+		q.Where("feed_event.created_at > ?", query.Since)
+	}
+	count, err := q.Order("feed_event.id DESC").
+		Limit(query.Per).
+		Offset((query.Page - 1) * query.Per).
+		ScanAndCount(ctx)
+	if err != nil {
+		return nil, 0, errorx.HandleDBError(err, errorx.Ctx().Set("user_id", query.UserID))
+	}
+	return events, count, nil
+}
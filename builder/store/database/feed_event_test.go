@@ -0,0 +1,92 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/tests"
+	"opencsg.com/csghub-server/common/types"
+)
+
+func TestFeedEventStore_Feed(t *testing.T) {
+	db := tests.InitTestDB()
+	defer db.Close()
+	ctx := context.TODO()
+
+	userStore := database.NewUserStoreWithDB(db)
+	actor := &database.User{Username: "actor", NickName: "actor", Email: "actor@example.com"}
+	err := userStore.Create(ctx, actor, &database.Namespace{Path: "actor"})
+	require.Nil(t, err)
+	follower := &database.User{Username: "follower", NickName: "follower", Email: "follower@example.com"}
+	err = userStore.Create(ctx, follower, &database.Namespace{Path: "follower"})
+	require.Nil(t, err)
+
+	publicRepo := &database.Repository{Path: "org1/public", GitPath: "models_org1/public", Name: "public",
+		RepositoryType: types.ModelRepo}
+	privateRepo := &database.Repository{Path: "org1/private", GitPath: "models_org1/private", Name: "private",
+		RepositoryType: types.ModelRepo, Private: true}
+	otherRepo := &database.Repository{Path: "other/repo", GitPath: "models_other/repo", Name: "repo",
+		RepositoryType: types.ModelRepo}
+	for _, repo := range []*database.Repository{publicRepo, privateRepo, otherRepo} {
+		_, err = db.Core.NewInsert().Model(repo).Exec(ctx)
+		require.Nil(t, err)
+	}
+
+	store := database.NewFeedEventStoreWithDB(db)
+	created := database.NewRepoFeedEvent(string(types.FeedEventRepoCreated), publicRepo, actor.ID, nil)
+	require.Equal(t, "org1", created.Namespace)
+	require.Nil(t, store.Create(ctx, created))
+	private := database.NewRepoFeedEvent(string(types.FeedEventRepoCreated), privateRepo, actor.ID, nil)
+	require.Nil(t, store.Create(ctx, private))
+	tagged := database.NewRepoFeedEvent(string(types.FeedEventTagCreated), publicRepo, 0, map[string]any{"tag": "v1"})
+	require.Nil(t, store.Create(ctx, tagged))
+	other := database.NewRepoFeedEvent(string(types.FeedEventTagCreated), otherRepo, 0, nil)
+	require.Nil(t, store.Create(ctx, other))
+
+	// no follows, no events
+	events, total, err := store.Feed(ctx, database.FeedQuery{UserID: follower.ID, Per: 10, Page: 1})
+	require.Nil(t, err)
+	require.Equal(t, 0, total)
+	require.Empty(t, events)
+
+	// the events of the repositories created by the followed actor, the private ones are hidden
+	require.Nil(t, database.NewFollowStoreWithDB(db).Add(ctx, follower.ID, string(types.FollowableUser), actor.ID))
+	events, total, err = store.Feed(ctx, database.FeedQuery{UserID: follower.ID, Per: 10, Page: 1})
+	require.Nil(t, err)
+	require.Equal(t, 1, total)
+	require.Equal(t, created.ID, events[0].ID)
+	require.Equal(t, "actor", events[0].Actor.Username)
+	require.Equal(t, "org1/public", events[0].Repository.Path)
+
+	// all the events of the followed repository, the latest first
+	require.Nil(t, database.NewFollowStoreWithDB(db).Add(ctx, follower.ID, string(types.FollowableRepository), publicRepo.ID))
+	events, total, err = store.Feed(ctx, database.FeedQuery{UserID: follower.ID, Per: 10, Page: 1})
+	require.Nil(t, err)
+	require.Equal(t, 2, total)
+	require.Equal(t, []int64{tagged.ID, created.ID}, []int64{events[0].ID, events[1].ID})
+	require.Equal(t, "v1", events[0].Payload["tag"])
+
+	// the private events are visible to the members of the namespace and the admins
+	events, total, err = store.Feed(ctx, database.FeedQuery{UserID: follower.ID, Namespaces: []string{"org1"}, Per: 10, Page: 1})
+	require.Nil(t, err)
+	require.Equal(t, 3, total)
+	require.Equal(t, private.ID, events[1].ID)
+	_, total, err = store.Feed(ctx, database.FeedQuery{UserID: follower.ID, IsAdmin: true, Per: 10, Page: 1})
+	require.Nil(t, err)
+	require.Equal(t, 3, total)
+
+	// paging
+	events, total, err = store.Feed(ctx, database.FeedQuery{UserID: follower.ID, IsAdmin: true, Per: 2, Page: 2})
+	require.Nil(t, err)
+	require.Equal(t, 3, total)
+	require.Len(t, events, 1)
+	require.Equal(t, created.ID, events[0].ID)
+
+	// only the events after since
+	_, total, err = store.Feed(ctx, database.FeedQuery{UserID: follower.ID, IsAdmin: true, Since: time.Now().Add(time.Hour), Per: 10, Page: 1})
+	require.Nil(t, err)
+	require.Equal(t, 0, total)
+}
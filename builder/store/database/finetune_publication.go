@@ -0,0 +1,85 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+)
+
+type finetunePublicationStoreImpl struct {
+	db *DB
+}
+
+type FinetunePublicationStore interface {
+	Create(ctx context.Context, pub *FinetunePublication) (*FinetunePublication, error)
+	FindByTaskID(ctx context.Context, taskID string) (*FinetunePublication, error)
+	// Update saves the publication only if its stored status is still from, it returns false when
+	// the status has been changed by someone else, e.g. a duplicated finished event of the job
+	Update(ctx context.Context, pub *FinetunePublication, from types.FinetunePublishStatus) (bool, error)
+}
+
+func NewFinetunePublicationStore() FinetunePublicationStore {
+	return &finetunePublicationStoreImpl{
+		db: defaultDB,
+	}
+}
+
+func NewFinetunePublicationStoreWithDB(db *DB) FinetunePublicationStore {
+	return &finetunePublicationStoreImpl{
+		db: db,
+	}
+}
+
+// FinetunePublication records where the output of a finetune workflow is published
+type FinetunePublication struct {
+	ID          int64                         `bun:",pk,autoincrement" json:"id"`
+	TaskID      string                        `bun:",notnull,unique" json:"task_id"`
+	Operator    string                        `bun:",notnull" json:"operator"`
+	TargetPath  string                        `bun:",notnull" json:"target_path"`
+	Artifact    types.FinetunePublishArtifact `bun:",notnull" json:"artifact"`
+	Checkpoints []string                      `bun:",type:jsonb" json:"checkpoints"`
+	Private     bool                          `bun:",notnull" json:"private"`
+	License     string                        `bun:"," json:"license"`
+	// Hyperparameters keeps the training settings used to fill the model card
+	Hyperparameters types.FinetuneHyperparameters `bun:",type:jsonb" json:"hyperparameters"`
+	Status          types.FinetunePublishStatus   `bun:",notnull" json:"status"`
+	Reason          string                        `bun:"," json:"reason"`
+	TargetRepoID    int64                         `bun:",nullzero" json:"target_repo_id"`
+	PublishedFiles  int                           `bun:",notnull,default:0" json:"published_files"`
+	times
+}
+
+func (s *finetunePublicationStoreImpl) Create(ctx context.Context, pub *FinetunePublication) (*FinetunePublication, error) {
+	res, err := s.db.Core.NewInsert().Model(pub).Exec(ctx, pub)
+	if err := assertAffectedOneRow(res, err); err != nil {
+		return nil, fmt.Errorf("failed to create finetune publication, error: %w", errorx.HandleDBError(err, errorx.Ctx().Set("task_id", pub.TaskID)))
+	}
+	return pub, nil
+}
+
+func (s *finetunePublicationStoreImpl) FindByTaskID(ctx context.Context, taskID string) (*FinetunePublication, error) {
+	var pub FinetunePublication
+	err := s.db.Core.NewSelect().Model(&pub).Where("task_id = ?", taskID).Scan(ctx)
+	if err != nil {
+		return nil, errorx.HandleDBError(err, errorx.Ctx().Set("task_id", taskID))
+	}
+	return &pub, nil
+}
+
+func (s *finetunePublicationStoreImpl) Update(ctx context.Context, pub *FinetunePublication, from types.FinetunePublishStatus) (bool, error) {
+	res, err := s.db.Core.NewUpdate().
+		Model(pub).
+		WherePK().
+		Where("status = ?", from).
+		Exec(ctx)
+	if err != nil {
+		return false, errorx.HandleDBError(err, errorx.Ctx().Set("task_id", pub.TaskID))
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows of finetune publication update, error: %w", err)
+	}
+	return affected > 0, nil
+}
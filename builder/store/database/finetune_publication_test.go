@@ -0,0 +1,64 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/tests"
+	"opencsg.com/csghub-server/common/types"
+)
+
+func TestFinetunePublicationStore_CRUD(t *testing.T) {
+	db := tests.InitTestDB()
+	defer db.Close()
+	ctx := context.TODO()
+
+	store := database.NewFinetunePublicationStoreWithDB(db)
+	_, err := store.FindByTaskID(ctx, "task1")
+	require.ErrorIs(t, err, errorx.ErrDatabaseNoRows)
+
+	pub, err := store.Create(ctx, &database.FinetunePublication{
+		TaskID:      "task1",
+		Operator:    "user",
+		TargetPath:  "ns/wukong-lora",
+		Artifact:    types.FinetunePublishAdapter,
+		Checkpoints: []string{"checkpoint-200"},
+		Hyperparameters: types.FinetuneHyperparameters{
+			Epochs:       3,
+			LearningRate: 0.0001,
+		},
+		Status: types.FinetunePublishPending,
+	})
+	require.Nil(t, err)
+
+	pub, err = store.FindByTaskID(ctx, "task1")
+	require.Nil(t, err)
+	require.Equal(t, "ns/wukong-lora", pub.TargetPath)
+	require.Equal(t, []string{"checkpoint-200"}, pub.Checkpoints)
+	require.Equal(t, 3, pub.Hyperparameters.Epochs)
+
+	// only one of the handlers of the same finished event takes the publication
+	pub.Status = types.FinetunePublishPublishing
+	updated, err := store.Update(ctx, pub, types.FinetunePublishPending)
+	require.Nil(t, err)
+	require.True(t, updated)
+	updated, err = store.Update(ctx, pub, types.FinetunePublishPending)
+	require.Nil(t, err)
+	require.False(t, updated)
+
+	pub.Status = types.FinetunePublishPublished
+	pub.TargetRepoID = 11
+	pub.PublishedFiles = 3
+	updated, err = store.Update(ctx, pub, types.FinetunePublishPublishing)
+	require.Nil(t, err)
+	require.True(t, updated)
+
+	pub, err = store.FindByTaskID(ctx, "task1")
+	require.Nil(t, err)
+	require.Equal(t, types.FinetunePublishPublished, pub.Status)
+	require.Equal(t, int64(11), pub.TargetRepoID)
+	require.Equal(t, 3, pub.PublishedFiles)
+}
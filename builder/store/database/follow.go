@@ -0,0 +1,166 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"opencsg.com/csghub-server/common/errorx"
+)
+
+// Follow is a user following another user, an organization or a repository
+type Follow struct {
+	ID             int64  `bun:",pk,autoincrement" json:"id"`
+	UserID         int64  `bun:",notnull" json:"user_id"`
+	User           *User  `bun:"rel:belongs-to,join:user_id=id" json:"user,omitempty"`
+	FollowableType string `bun:",notnull" json:"followable_type"`
+	FollowableID   int64  `bun:",notnull" json:"followable_id"`
+	times
+}
+
+// FollowTarget is a followed entity along with its display fields, Path is the username, the organization
+// path or the repository path
+type FollowTarget struct {
+	FollowableType string    `bun:"followable_type"`
+	FollowableID   int64     `bun:"followable_id"`
+	Path           string    `bun:"path"`
+	Nickname       string    `bun:"nickname"`
+	Avatar         string    `bun:"avatar"`
+	RepoType       string    `bun:"repo_type"`
+	CreatedAt      time.Time `bun:"created_at"`
+}
+
+type followStoreImpl struct {
+	db *DB
+}
+
+type FollowStore interface {
+	// Add follows the entity, following the same entity again is a no-op
+	Add(ctx context.Context, userID int64, followableType string, followableID int64) error
+	Delete(ctx context.Context, userID int64, followableType string, followableID int64) error
+	IsFollowing(ctx context.Context, userID int64, followableType string, followableID int64) (bool, error)
+	// ListFollowing returns the entities followed by the user, the latest followed first, the deleted
+	// entities are skipped
+	ListFollowing(ctx context.Context, userID int64, per, page int) ([]FollowTarget, int, error)
+	// ListFollowers returns the followers of the entity, the latest followed first
+	ListFollowers(ctx context.Context, followableType string, followableID int64, per, page int) ([]Follow, int, error)
+	CountFollowers(ctx context.Context, followableType string, followableID int64) (int, error)
+	CountFollowing(ctx context.Context, userID int64) (int, error)
+}
+
+func NewFollowStore() FollowStore {
+	return &followStoreImpl{
+		db: defaultDB,
+	}
+}
+
+func NewFollowStoreWithDB(db *DB) FollowStore {
+	return &followStoreImpl{
+		db: db,
+	}
+}
+
+func (s *followStoreImpl) Add(ctx context.Context, userID int64, followableType string, followableID int64) error {
+	follow := &Follow{
+		UserID:         userID,
+		FollowableType: followableType,
+		FollowableID:   followableID,
+	}
+	_, err := s.db.Operator.Core.NewInsert().
+		Model(follow).
+		On("CONFLICT (user_id, followable_type, followable_id) DO NOTHING").
+		Exec(ctx)
+	return errorx.HandleDBError(err, errorx.Ctx().Set("user_id", userID))
+}
+
+func (s *followStoreImpl) Delete(ctx context.Context, userID int64, followableType string, followableID int64) error {
+	_, err := s.db.Operator.Core.NewDelete().
+		Model((*Follow)(nil)).
+		Where("user_id = ?", userID).
+		Where("followable_type = ?", followableType).
+		Where("followable_id = ?", followableID).
+		Exec(ctx)
+	return errorx.HandleDBError(err, errorx.Ctx().Set("user_id", userID))
+}
+
+func (s *followStoreImpl) IsFollowing(ctx context.Context, userID int64, followableType string, followableID int64) (bool, error) {
+	exists, err := s.db.Operator.Core.NewSelect().
+		Model((*Follow)(nil)).
+		Where("user_id = ?", userID).
+		Where("followable_type = ?", followableType).
+		Where("followable_id = ?", followableID).
+		Exists(ctx)
+	if err != nil {
+		return false, errorx.HandleDBError(err, errorx.Ctx().Set("user_id", userID))
+	}
+	return exists, nil
+}
+
+func (s *followStoreImpl) ListFollowing(ctx context.Context, userID int64, per, page int) ([]FollowTarget, int, error) {
+	q := s.db.Operator.Core.NewSelect().
+		TableExpr("follows AS f").
+		Join("LEFT JOIN users AS u ON f.followable_type = 'user' AND u.id = f.followable_id AND u.deleted_at IS NULL").
+		Join("LEFT JOIN organizations AS o ON f.followable_type = 'organization' AND o.id = f.followable_id").
+		Join("LEFT JOIN repositories AS r ON f.followable_type = 'repository' AND r.id = f.followable_id").
+		Where("f.user_id = ?", userID).
+		Where("COALESCE(u.id, o.id, r.id) IS NOT NULL")
+
+	count, err := q.Count(ctx)
+	if err != nil {
+		return nil, 0, errorx.HandleDBError(err, errorx.Ctx().Set("user_id", userID))
+	}
+
+	var targets []FollowTarget
+	err = q.ColumnExpr("f.followable_type, f.followable_id, f.created_at").
+		ColumnExpr("COALESCE(u.username, o.path, r.path) AS path").
+		ColumnExpr("COALESCE(u.name, o.name, r.nickname, '') AS nickname").
+		ColumnExpr("COALESCE(u.avatar, o.logo, '') AS avatar").
+		ColumnExpr("COALESCE(r.repository_type, '') AS repo_type").
+		Order("f.id DESC").
+		Limit(per).
+		Offset((page-1)*per).
+		Scan(ctx, &targets)
+	if err != nil {
+		return nil, 0, errorx.HandleDBError(err, errorx.Ctx().Set("user_id", userID))
+	}
+	return targets, count, nil
+}
+
+func (s *followStoreImpl) ListFollowers(ctx context.Context, followableType string, followableID int64, per, page int) ([]Follow, int, error) {
+	var follows []Follow
+	count, err := s.db.Operator.Core.NewSelect().
+		Model(&follows).
+		Relation("User").
+		Where("follow.followable_type = ?", followableType).
+		Where("follow.followable_id = ?", followableID).
+		Order("follow.id DESC").
+		Limit(per).
+		Offset((page - 1) * per).
+		ScanAndCount(ctx)
+	if err != nil {
+		return nil, 0, errorx.HandleDBError(err, errorx.Ctx().Set("followable_id", followableID))
+	}
+	return follows, count, nil
+}
+
+func (s *followStoreImpl) CountFollowers(ctx context.Context, followableType string, followableID int64) (int, error) {
+	count, err := s.db.Operator.Core.NewSelect().
+		Model((*Follow)(nil)).
+		Where("followable_type = ?", followableType).
+		Where("followable_id = ?", followableID).
+		Count(ctx)
+	if err != nil {
+		return 0, errorx.HandleDBError(err, errorx.Ctx().Set("followable_id", followableID))
+	}
+	return count, nil
+}
+
+func (s *followStoreImpl) CountFollowing(ctx context.Context, userID int64) (int, error) {
+	count, err := s.db.Operator.Core.NewSelect().
+		Model((*Follow)(nil)).
+		Where("user_id = ?", userID).
+		Count(ctx)
+	if err != nil {
+		return 0, errorx.HandleDBError(err, errorx.Ctx().Set("user_id", userID))
+	}
+	return count, nil
+}
@@ -0,0 +1,98 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/tests"
+	"opencsg.com/csghub-server/common/types"
+)
+
+func TestFollowStore_CRUD(t *testing.T) {
+	db := tests.InitTestDB()
+	defer db.Close()
+	ctx := context.TODO()
+
+	userStore := database.NewUserStoreWithDB(db)
+	follower := &database.User{Username: "follower", NickName: "follower", Email: "follower@example.com"}
+	err := userStore.Create(ctx, follower, &database.Namespace{Path: "follower"})
+	require.Nil(t, err)
+	followee := &database.User{Username: "followee", NickName: "followee_name", Email: "followee@example.com"}
+	err = userStore.Create(ctx, followee, &database.Namespace{Path: "followee"})
+	require.Nil(t, err)
+
+	org := &database.Organization{Name: "org1", Nickname: "org1_name", Logo: "logo.png"}
+	err = database.NewOrgStoreWithDB(db).Create(ctx, org, &database.Namespace{Path: "org1"})
+	require.Nil(t, err)
+
+	repo := &database.Repository{
+		Path:           "org1/model1",
+		GitPath:        "models_org1/model1",
+		Name:           "model1",
+		Nickname:       "model1_name",
+		RepositoryType: types.ModelRepo,
+	}
+	_, err = db.Core.NewInsert().Model(repo).Exec(ctx)
+	require.Nil(t, err)
+
+	store := database.NewFollowStoreWithDB(db)
+	require.Nil(t, store.Add(ctx, follower.ID, string(types.FollowableUser), followee.ID))
+	require.Nil(t, store.Add(ctx, follower.ID, string(types.FollowableOrganization), org.ID))
+	require.Nil(t, store.Add(ctx, follower.ID, string(types.FollowableRepository), repo.ID))
+	// following again is a no-op
+	require.Nil(t, store.Add(ctx, follower.ID, string(types.FollowableRepository), repo.ID))
+	// the deleted entities are skipped in the following list
+	require.Nil(t, store.Add(ctx, follower.ID, string(types.FollowableRepository), 9999))
+
+	following, err := store.IsFollowing(ctx, follower.ID, string(types.FollowableUser), followee.ID)
+	require.Nil(t, err)
+	require.True(t, following)
+	following, err = store.IsFollowing(ctx, followee.ID, string(types.FollowableUser), follower.ID)
+	require.Nil(t, err)
+	require.False(t, following)
+
+	targets, total, err := store.ListFollowing(ctx, follower.ID, 10, 1)
+	require.Nil(t, err)
+	require.Equal(t, 3, total)
+	require.Len(t, targets, 3)
+	require.Equal(t, string(types.FollowableRepository), targets[0].FollowableType)
+	require.Equal(t, "org1/model1", targets[0].Path)
+	require.Equal(t, "model1_name", targets[0].Nickname)
+	require.Equal(t, string(types.ModelRepo), targets[0].RepoType)
+	require.Equal(t, string(types.FollowableOrganization), targets[1].FollowableType)
+	require.Equal(t, "org1", targets[1].Path)
+	require.Equal(t, "org1_name", targets[1].Nickname)
+	require.Equal(t, "logo.png", targets[1].Avatar)
+	require.Equal(t, string(types.FollowableUser), targets[2].FollowableType)
+	require.Equal(t, "followee", targets[2].Path)
+	require.Equal(t, "followee_name", targets[2].Nickname)
+
+	targets, total, err = store.ListFollowing(ctx, follower.ID, 2, 2)
+	require.Nil(t, err)
+	require.Equal(t, 3, total)
+	require.Len(t, targets, 1)
+	require.Equal(t, string(types.FollowableUser), targets[0].FollowableType)
+
+	follows, total, err := store.ListFollowers(ctx, string(types.FollowableRepository), repo.ID, 10, 1)
+	require.Nil(t, err)
+	require.Equal(t, 1, total)
+	require.Equal(t, follower.ID, follows[0].UserID)
+	require.Equal(t, "follower", follows[0].User.Username)
+
+	count, err := store.CountFollowers(ctx, string(types.FollowableUser), followee.ID)
+	require.Nil(t, err)
+	require.Equal(t, 1, count)
+	count, err = store.CountFollowing(ctx, follower.ID)
+	require.Nil(t, err)
+	require.Equal(t, 4, count)
+
+	require.Nil(t, store.Delete(ctx, follower.ID, string(types.FollowableUser), followee.ID))
+	following, err = store.IsFollowing(ctx, follower.ID, string(types.FollowableUser), followee.ID)
+	require.Nil(t, err)
+	require.False(t, following)
+	count, err = store.CountFollowers(ctx, string(types.FollowableUser), followee.ID)
+	require.Nil(t, err)
+	require.Equal(t, 0, count)
+}
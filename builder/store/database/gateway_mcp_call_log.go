@@ -0,0 +1,81 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+)
+
+// GatewayMCPCallLog records a call proxied by the aggregated MCP endpoint
+type GatewayMCPCallLog struct {
+	ID            int64                      `bun:",pk,autoincrement" json:"id"`
+	NamespaceUUID string                     `bun:",notnull" json:"namespace_uuid"`
+	Username      string                     `bun:",nullzero" json:"username"`
+	APIKeyID      int64                      `bun:",nullzero" json:"api_key_id"`
+	SessionID     string                     `bun:",nullzero" json:"session_id"`
+	MCPServerID   int64                      `bun:",notnull" json:"mcp_server_id"`
+	MCPServerName string                     `bun:",notnull" json:"mcp_server_name"`
+	Method        string                     `bun:",notnull" json:"method"`
+	Target        string                     `bun:",notnull" json:"target"`
+	Status        types.MCPGatewayCallStatus `bun:",notnull" json:"status"`
+	Error         string                     `bun:",nullzero" json:"error"`
+	LatencyMs     int64                      `bun:",notnull,default:0" json:"latency_ms"`
+	CreatedAt     time.Time                  `bun:",nullzero,notnull,skipupdate,default:current_timestamp" json:"created_at"`
+}
+
+// GatewayMCPCallLogStore provides database operations for GatewayMCPCallLog
+type GatewayMCPCallLogStore interface {
+	Create(ctx context.Context, log *GatewayMCPCallLog) error
+	List(ctx context.Context, filter types.MCPGatewayCallLogFilter) ([]GatewayMCPCallLog, int, error)
+}
+
+type gatewayMCPCallLogStoreImpl struct {
+	db *DB
+}
+
+// NewGatewayMCPCallLogStore creates a new GatewayMCPCallLogStore
+func NewGatewayMCPCallLogStore() GatewayMCPCallLogStore {
+	return &gatewayMCPCallLogStoreImpl{
+		db: defaultDB,
+	}
+}
+
+// NewGatewayMCPCallLogStoreWithDB creates a new GatewayMCPCallLogStore with a specific DB
+func NewGatewayMCPCallLogStoreWithDB(db *DB) GatewayMCPCallLogStore {
+	return &gatewayMCPCallLogStoreImpl{
+		db: db,
+	}
+}
+
+func (s *gatewayMCPCallLogStoreImpl) Create(ctx context.Context, log *GatewayMCPCallLog) error {
+	res, err := s.db.Core.NewInsert().Model(log).Exec(ctx, log)
+	if err = assertAffectedOneRow(res, err); err != nil {
+		return errorx.HandleDBError(err, errorx.Ctx().Set("target", log.Target))
+	}
+	return nil
+}
+
+// List returns the call logs of a namespace, newest first
+func (s *gatewayMCPCallLogStoreImpl) List(ctx context.Context, filter types.MCPGatewayCallLogFilter) ([]GatewayMCPCallLog, int, error) {
+	var logs []GatewayMCPCallLog
+	query := s.db.Core.NewSelect().
+		Model(&logs).
+		Where("namespace_uuid = ?", filter.NamespaceUUID)
+	if filter.ServerName != "" {
+		query = query.Where("mcp_server_name = ?", filter.ServerName)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	total, err := query.
+		Order("id DESC").
+		Limit(filter.Per).
+		Offset((filter.Page - 1) * filter.Per).
+		ScanAndCount(ctx)
+	if err != nil {
+		return nil, 0, errorx.HandleDBError(err, nil)
+	}
+	return logs, total, nil
+}
@@ -0,0 +1,49 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/tests"
+	"opencsg.com/csghub-server/common/types"
+)
+
+func TestGatewayMCPCallLogStore_CreateAndList(t *testing.T) {
+	db := tests.InitTestDB()
+	defer db.Close()
+	ctx := context.TODO()
+
+	store := database.NewGatewayMCPCallLogStoreWithDB(db)
+	logs := []*database.GatewayMCPCallLog{
+		{NamespaceUUID: "ns1", MCPServerID: 1, MCPServerName: "github", Method: types.MCPMethodCallTool,
+			Target: "github_search", Status: types.MCPGatewayCallSuccess, LatencyMs: 12},
+		{NamespaceUUID: "ns1", MCPServerID: 1, MCPServerName: "github", Method: types.MCPMethodCallTool,
+			Target: "github_delete_repo", Status: types.MCPGatewayCallDenied, Error: "not allowed"},
+		{NamespaceUUID: "ns1", MCPServerID: 2, MCPServerName: "slack", Method: types.MCPMethodGetPrompt,
+			Target: "slack_summary", Status: types.MCPGatewayCallError, Error: "timeout"},
+		{NamespaceUUID: "ns2", MCPServerID: 1, MCPServerName: "github", Method: types.MCPMethodCallTool,
+			Target: "github_search", Status: types.MCPGatewayCallSuccess},
+	}
+	for _, l := range logs {
+		require.Nil(t, store.Create(ctx, l))
+	}
+
+	res, total, err := store.List(ctx, types.MCPGatewayCallLogFilter{NamespaceUUID: "ns1", Per: 2, Page: 1})
+	require.Nil(t, err)
+	require.Equal(t, 3, total)
+	require.Len(t, res, 2)
+	require.Equal(t, "slack_summary", res[0].Target)
+	require.Equal(t, "github_delete_repo", res[1].Target)
+
+	res, total, err = store.List(ctx, types.MCPGatewayCallLogFilter{NamespaceUUID: "ns1", ServerName: "github", Per: 10, Page: 1})
+	require.Nil(t, err)
+	require.Equal(t, 2, total)
+	require.Len(t, res, 2)
+
+	res, total, err = store.List(ctx, types.MCPGatewayCallLogFilter{NamespaceUUID: "ns1", Status: types.MCPGatewayCallDenied, Per: 10, Page: 1})
+	require.Nil(t, err)
+	require.Equal(t, 1, total)
+	require.Equal(t, "not allowed", res[0].Error)
+}
@@ -23,6 +23,10 @@ type GatewayMCPServers struct {
 	Env         map[string]any `bun:",type:jsonb,nullzero" json:"env"`
 	Metadata    map[string]any `bun:",type:jsonb,nullzero" json:"metadata"`
 	ConfigHash  string         `bun:",unique" json:"config_hash"`
+	// OwnerNamespace is the user or organization the server is registered for,
+	// the server is shared with all the users if it's empty
+	OwnerNamespace string `bun:",nullzero" json:"owner_namespace"`
+	Disabled       bool   `bun:",notnull,default:false" json:"disabled"`
 	times
 }
 
@@ -56,6 +60,9 @@ type GatewayMCPServersStore interface {
 
 	GetMCPServer(ctx context.Context, id int64) (*GatewayMCPServers, error)
 	GetMCPServers(ctx context.Context) ([]GatewayMCPServers, error)
+	// ListForGateway returns the enabled servers shared with everyone or owned by one of the namespaces,
+	// the servers whose last capability inspection failed are skipped
+	ListForGateway(ctx context.Context, namespaces []string) ([]GatewayMCPServers, error)
 }
 
 // gatewayMCPServersStoreImpl is the implementation of GatewayMCPServersStore
@@ -170,6 +177,25 @@ func (s *gatewayMCPServersStoreImpl) GetMCPServers(ctx context.Context) ([]Gatew
 	return mcpServers, nil
 }
 
+func (s *gatewayMCPServersStoreImpl) ListForGateway(ctx context.Context, namespaces []string) ([]GatewayMCPServers, error) {
+	var mcpServers []GatewayMCPServers
+	query := s.db.Core.NewSelect().
+		Model(&mcpServers).
+		Join("LEFT JOIN gateway_mcp_server_capabilities AS c ON c.mcp_server_id = gateway_mcp_servers.id AND c.config_hash = gateway_mcp_servers.config_hash").
+		Where("gateway_mcp_servers.disabled = ?", false).
+		Where("c.status IS NULL OR c.status != ?", types.MCPServerStatusError)
+	if len(namespaces) > 0 {
+		query = query.Where("gateway_mcp_servers.owner_namespace IS NULL OR gateway_mcp_servers.owner_namespace IN (?)", bun.In(namespaces))
+	} else {
+		query = query.Where("gateway_mcp_servers.owner_namespace IS NULL")
+	}
+	err := query.OrderExpr("gateway_mcp_servers.id ASC").Scan(ctx)
+	if err != nil {
+		return nil, errorx.HandleDBError(err, map[string]any{"operation": "list_gateway_mcp_servers_for_gateway"})
+	}
+	return mcpServers, nil
+}
+
 func (s *gatewayMCPServersStoreImpl) applyGatewayMCPServerFilters(query *bun.SelectQuery, filter types.GatewayMCPServerFilter) *bun.SelectQuery {
 	if filter.Status != nil && *filter.Status != "" {
 		query = query.Where("status = ?", *filter.Status)
@@ -3,6 +3,7 @@ package database_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"opencsg.com/csghub-server/builder/store/database"
@@ -146,6 +147,55 @@ func TestGatewayMCPServersStore_GetMCPServers(t *testing.T) {
 	require.GreaterOrEqual(t, len(all), 0)
 }
 
+func TestGatewayMCPServersStore_ListForGateway(t *testing.T) {
+	db := tests.InitTestDB()
+	defer db.Close()
+	ctx := context.TODO()
+
+	store := database.NewGatewayMCPServersStoreWithDB(db)
+	capStore := database.NewGatewayMCPServerCapabilityStoreWithDB(db)
+
+	for _, s := range []*database.GatewayMCPServers{
+		{Name: "gw-shared", ConfigHash: "gw-h1"},
+		{Name: "gw-user", ConfigHash: "gw-h2", OwnerNamespace: "user"},
+		{Name: "gw-org", ConfigHash: "gw-h3", OwnerNamespace: "opencsg"},
+		{Name: "gw-other", ConfigHash: "gw-h4", OwnerNamespace: "other"},
+		{Name: "gw-disabled", ConfigHash: "gw-h5", Disabled: true},
+		{Name: "gw-broken", ConfigHash: "gw-h6"},
+	} {
+		s.Protocol = "streamable"
+		s.URL = "http://localhost/mcp"
+		created, err := store.Create(ctx, s)
+		require.NoError(t, err)
+		if s.Name == "gw-broken" {
+			err = capStore.CreateOrUpdate(ctx, &database.GatewayMCPServerCapability{
+				MCPServerID:   created.ID,
+				MCPServerName: created.Name,
+				ConfigHash:    created.ConfigHash,
+				Status:        string(types.MCPServerStatusError),
+				RefreshedAt:   time.Now(),
+				ExpiresAt:     time.Now().Add(time.Hour),
+			})
+			require.NoError(t, err)
+		}
+	}
+
+	names := func(servers []database.GatewayMCPServers) []string {
+		var res []string
+		for _, s := range servers {
+			res = append(res, s.Name)
+		}
+		return res
+	}
+	servers, err := store.ListForGateway(ctx, []string{"user", "opencsg"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"gw-shared", "gw-user", "gw-org"}, names(servers))
+
+	servers, err = store.ListForGateway(ctx, nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"gw-shared"}, names(servers))
+}
+
 func TestGatewayMCPServersStore_List_WithFilters(t *testing.T) {
 	db := tests.InitTestDB()
 	defer db.Close()
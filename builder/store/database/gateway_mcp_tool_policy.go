@@ -0,0 +1,78 @@
+package database
+
+import (
+	"context"
+
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+)
+
+// GatewayMCPToolPolicy is an allow or deny rule for a prefixed tool name bound to an API key,
+// the key is referenced by its access token id so the secret is never stored twice
+type GatewayMCPToolPolicy struct {
+	ID            int64                     `bun:",pk,autoincrement" json:"id"`
+	AccessTokenID int64                     `bun:",notnull" json:"access_token_id"`
+	Tool          string                    `bun:",notnull" json:"tool"`
+	Action        types.MCPToolPolicyAction `bun:",notnull" json:"action"`
+	times
+}
+
+// GatewayMCPToolPolicyStore provides database operations for GatewayMCPToolPolicy
+type GatewayMCPToolPolicyStore interface {
+	Create(ctx context.Context, policy *GatewayMCPToolPolicy) (*GatewayMCPToolPolicy, error)
+	ListByAccessTokenID(ctx context.Context, accessTokenID int64) ([]GatewayMCPToolPolicy, error)
+	Delete(ctx context.Context, accessTokenID, id int64) error
+}
+
+type gatewayMCPToolPolicyStoreImpl struct {
+	db *DB
+}
+
+// NewGatewayMCPToolPolicyStore creates a new GatewayMCPToolPolicyStore
+func NewGatewayMCPToolPolicyStore() GatewayMCPToolPolicyStore {
+	return &gatewayMCPToolPolicyStoreImpl{
+		db: defaultDB,
+	}
+}
+
+// NewGatewayMCPToolPolicyStoreWithDB creates a new GatewayMCPToolPolicyStore with a specific DB
+func NewGatewayMCPToolPolicyStoreWithDB(db *DB) GatewayMCPToolPolicyStore {
+	return &gatewayMCPToolPolicyStoreImpl{
+		db: db,
+	}
+}
+
+// Create inserts a new tool policy, the same tool of an API key can only have one policy
+func (s *gatewayMCPToolPolicyStoreImpl) Create(ctx context.Context, policy *GatewayMCPToolPolicy) (*GatewayMCPToolPolicy, error) {
+	res, err := s.db.Core.NewInsert().Model(policy).Exec(ctx, policy)
+	if err = assertAffectedOneRow(res, err); err != nil {
+		return nil, errorx.HandleDBError(err, errorx.Ctx().Set("tool", policy.Tool))
+	}
+	return policy, nil
+}
+
+// ListByAccessTokenID returns all tool policies bound to the API key
+func (s *gatewayMCPToolPolicyStoreImpl) ListByAccessTokenID(ctx context.Context, accessTokenID int64) ([]GatewayMCPToolPolicy, error) {
+	var policies []GatewayMCPToolPolicy
+	err := s.db.Core.NewSelect().
+		Model(&policies).
+		Where("access_token_id = ?", accessTokenID).
+		OrderExpr("id ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, errorx.HandleDBError(err, errorx.Ctx().Set("access_token_id", accessTokenID))
+	}
+	return policies, nil
+}
+
+// Delete removes a tool policy of the API key
+func (s *gatewayMCPToolPolicyStoreImpl) Delete(ctx context.Context, accessTokenID, id int64) error {
+	res, err := s.db.Core.NewDelete().
+		Model((*GatewayMCPToolPolicy)(nil)).
+		Where("id = ? AND access_token_id = ?", id, accessTokenID).
+		Exec(ctx)
+	if err = assertAffectedOneRow(res, err); err != nil {
+		return errorx.HandleDBError(err, errorx.Ctx().Set("id", id))
+	}
+	return nil
+}
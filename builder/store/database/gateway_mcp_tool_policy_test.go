@@ -0,0 +1,57 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/tests"
+	"opencsg.com/csghub-server/common/types"
+)
+
+func TestGatewayMCPToolPolicyStore_CRUD(t *testing.T) {
+	db := tests.InitTestDB()
+	defer db.Close()
+	ctx := context.TODO()
+
+	store := database.NewGatewayMCPToolPolicyStoreWithDB(db)
+	allow, err := store.Create(ctx, &database.GatewayMCPToolPolicy{
+		AccessTokenID: 1, Tool: "github_*", Action: types.MCPToolPolicyAllow,
+	})
+	require.Nil(t, err)
+	require.NotZero(t, allow.ID)
+	_, err = store.Create(ctx, &database.GatewayMCPToolPolicy{
+		AccessTokenID: 1, Tool: "github_delete_repo", Action: types.MCPToolPolicyDeny,
+	})
+	require.Nil(t, err)
+	_, err = store.Create(ctx, &database.GatewayMCPToolPolicy{
+		AccessTokenID: 2, Tool: "github_*", Action: types.MCPToolPolicyDeny,
+	})
+	require.Nil(t, err)
+
+	// a tool of an api key has only one policy
+	_, err = store.Create(ctx, &database.GatewayMCPToolPolicy{
+		AccessTokenID: 1, Tool: "github_*", Action: types.MCPToolPolicyDeny,
+	})
+	require.NotNil(t, err)
+
+	policies, err := store.ListByAccessTokenID(ctx, 1)
+	require.Nil(t, err)
+	require.Len(t, policies, 2)
+	require.Equal(t, "github_*", policies[0].Tool)
+	require.Equal(t, "github_delete_repo", policies[1].Tool)
+
+	// policies of other api keys can not be deleted
+	err = store.Delete(ctx, 2, allow.ID)
+	require.NotNil(t, err)
+	err = store.Delete(ctx, 1, allow.ID)
+	require.Nil(t, err)
+	policies, err = store.ListByAccessTokenID(ctx, 1)
+	require.Nil(t, err)
+	require.Len(t, policies, 1)
+
+	policies, err = store.ListByAccessTokenID(ctx, 3)
+	require.Nil(t, err)
+	require.Empty(t, policies)
+}
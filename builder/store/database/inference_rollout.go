@@ -0,0 +1,94 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+)
+
+type inferenceRolloutStoreImpl struct {
+	db *DB
+}
+
+type InferenceRolloutStore interface {
+	Create(ctx context.Context, rollout *InferenceRollout) (*InferenceRollout, error)
+	Update(ctx context.Context, rollout *InferenceRollout) error
+	FindByID(ctx context.Context, id int64) (*InferenceRollout, error)
+	// FindLatestByDeployID returns the most recent rollout of the deploy
+	FindLatestByDeployID(ctx context.Context, deployID int64) (*InferenceRollout, error)
+}
+
+func NewInferenceRolloutStore() InferenceRolloutStore {
+	return &inferenceRolloutStoreImpl{
+		db: defaultDB,
+	}
+}
+
+func NewInferenceRolloutStoreWithDB(db *DB) InferenceRolloutStore {
+	return &inferenceRolloutStoreImpl{
+		db: db,
+	}
+}
+
+// InferenceRollout is a progressive traffic shift from the stable to the canary version of an inference deploy
+type InferenceRollout struct {
+	ID           int64   `bun:",pk,autoincrement" json:"id"`
+	DeployID     int64   `bun:",notnull" json:"deploy_id"`
+	UserUUID     string  `bun:",notnull" json:"user_uuid"`
+	StableCommit string  `bun:",notnull" json:"stable_commit"`
+	CanaryCommit string  `bun:",notnull" json:"canary_commit"`
+	Steps        []int   `bun:",type:jsonb" json:"steps"`
+	StepInterval int     `bun:",notnull" json:"step_interval"`
+	MaxErrorRate float64 `bun:",notnull,default:0" json:"max_error_rate"`
+	MaxLatencyMs float64 `bun:",notnull,default:0" json:"max_latency_ms"`
+	// CurrentStep is the index of the step being observed, CurrentTraffic is 0 until the first shift
+	CurrentStep    int                          `bun:",notnull,default:0" json:"current_step"`
+	CurrentTraffic int                          `bun:",notnull,default:0" json:"current_traffic"`
+	Status         types.InferenceRolloutStatus `bun:",notnull" json:"status"`
+	Reason         string                       `bun:",nullzero" json:"reason"`
+	ErrorRate      float64                      `bun:",notnull,default:0" json:"error_rate"`
+	LatencyMs      float64                      `bun:",notnull,default:0" json:"latency_ms"`
+	times
+}
+
+func (s *inferenceRolloutStoreImpl) Create(ctx context.Context, rollout *InferenceRollout) (*InferenceRollout, error) {
+	_, err := s.db.Core.NewInsert().Model(rollout).Exec(ctx, rollout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create inference rollout, error: %w",
+			errorx.HandleDBError(err, errorx.Ctx().Set("deploy_id", rollout.DeployID)))
+	}
+	return rollout, nil
+}
+
+func (s *inferenceRolloutStoreImpl) Update(ctx context.Context, rollout *InferenceRollout) error {
+	_, err := s.db.Core.NewUpdate().Model(rollout).WherePK().Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to update inference rollout, error: %w",
+			errorx.HandleDBError(err, errorx.Ctx().Set("id", rollout.ID)))
+	}
+	return nil
+}
+
+func (s *inferenceRolloutStoreImpl) FindByID(ctx context.Context, id int64) (*InferenceRollout, error) {
+	rollout := &InferenceRollout{}
+	err := s.db.Core.NewSelect().Model(rollout).Where("id = ?", id).Scan(ctx)
+	if err != nil {
+		return nil, errorx.HandleDBError(err, errorx.Ctx().Set("id", id))
+	}
+	return rollout, nil
+}
+
+func (s *inferenceRolloutStoreImpl) FindLatestByDeployID(ctx context.Context, deployID int64) (*InferenceRollout, error) {
+	rollout := &InferenceRollout{}
+	err := s.db.Core.NewSelect().Model(rollout).
+		Where("deploy_id = ?", deployID).
+		Order("id DESC").
+		Limit(1).
+		Scan(ctx)
+	if err != nil {
+		return nil, errorx.HandleDBError(err, errorx.Ctx().Set("deploy_id", deployID))
+	}
+	return rollout, nil
+}
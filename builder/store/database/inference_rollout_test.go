@@ -0,0 +1,51 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/tests"
+	"opencsg.com/csghub-server/common/types"
+)
+
+func TestInferenceRolloutStore_CRUD(t *testing.T) {
+	db := tests.InitTestDB()
+	defer db.Close()
+	ctx := context.TODO()
+
+	store := database.NewInferenceRolloutStoreWithDB(db)
+
+	_, err := store.FindLatestByDeployID(ctx, 1)
+	require.ErrorIs(t, err, errorx.ErrDatabaseNoRows)
+
+	first, err := store.Create(ctx, &database.InferenceRollout{
+		DeployID: 1, UserUUID: "u1", StableCommit: "aaaaaaa", CanaryCommit: "bbbbbbb",
+		Steps: []int{5, 100}, StepInterval: 60, Status: types.InferenceRolloutRunning,
+	})
+	require.Nil(t, err)
+	second, err := store.Create(ctx, &database.InferenceRollout{
+		DeployID: 1, UserUUID: "u1", StableCommit: "bbbbbbb", CanaryCommit: "ccccccc",
+		Steps: []int{50, 100}, StepInterval: 60, Status: types.InferenceRolloutRunning,
+	})
+	require.Nil(t, err)
+
+	latest, err := store.FindLatestByDeployID(ctx, 1)
+	require.Nil(t, err)
+	require.Equal(t, second.ID, latest.ID)
+	require.Equal(t, []int{50, 100}, latest.Steps)
+
+	first.Status = types.InferenceRolloutRolledBack
+	first.Reason = "error rate too high"
+	first.CurrentTraffic = 5
+	err = store.Update(ctx, first)
+	require.Nil(t, err)
+
+	got, err := store.FindByID(ctx, first.ID)
+	require.Nil(t, err)
+	require.Equal(t, types.InferenceRolloutRolledBack, got.Status)
+	require.Equal(t, "error rate too high", got.Reason)
+	require.Equal(t, 5, got.CurrentTraffic)
+}
@@ -0,0 +1,167 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/uptrace/bun"
+	"opencsg.com/csghub-server/common/errorx"
+)
+
+type lfsGCObjectStoreImpl struct {
+	db *DB
+}
+
+type LfsGCObjectStore interface {
+	FindAll(ctx context.Context) ([]LfsGCObject, error)
+	// BatchCreate marks the objects as unreferenced, objects marked already keep their mark time
+	BatchCreate(ctx context.Context, objects []LfsGCObject) error
+	DeleteByObjectKeys(ctx context.Context, objectKeys []string) error
+	// DeleteUnreferenced drops the mark and the meta objects of an object in one transaction, the object
+	// is to be removed from the storage after the commit. It returns false and drops only the mark if the
+	// object was uploaded again since it was marked.
+	DeleteUnreferenced(ctx context.Context, object LfsGCObject) (bool, error)
+}
+
+func NewLfsGCObjectStore() LfsGCObjectStore {
+	return &lfsGCObjectStoreImpl{
+		db: defaultDB,
+	}
+}
+
+func NewLfsGCObjectStoreWithDB(db *DB) LfsGCObjectStore {
+	return &lfsGCObjectStoreImpl{
+		db: db,
+	}
+}
+
+// LfsGCObject is an LFS object found unreferenced by the garbage collection,
+// it's deleted from the storage once it stays unreferenced for the grace period
+type LfsGCObject struct {
+	ID        int64  `bun:",pk,autoincrement" json:"id"`
+	ObjectKey string `bun:",notnull,unique" json:"object_key"`
+	Oid       string `bun:",notnull" json:"oid"`
+	// RepositoryID is the owner of a migrated object, 0 for shared objects or deleted repositories
+	RepositoryID int64     `bun:",notnull,default:0" json:"repository_id"`
+	Size         int64     `bun:",notnull,default:0" json:"size"`
+	MarkedAt     time.Time `bun:",notnull" json:"marked_at"`
+	times
+}
+
+func (s *lfsGCObjectStoreImpl) FindAll(ctx context.Context) ([]LfsGCObject, error) {
+	var objects []LfsGCObject
+	err := s.db.Operator.Core.NewSelect().
+		Model(&objects).
+		Order("id ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, errorx.HandleDBError(err, nil)
+	}
+	return objects, nil
+}
+
+func (s *lfsGCObjectStoreImpl) BatchCreate(ctx context.Context, objects []LfsGCObject) error {
+	if len(objects) == 0 {
+		return nil
+	}
+	_, err := s.db.Operator.Core.NewInsert().
+		Model(&objects).
+		On("CONFLICT (object_key) DO NOTHING").
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to mark lfs gc objects, error: %w", errorx.HandleDBError(err, nil))
+	}
+	return nil
+}
+
+func (s *lfsGCObjectStoreImpl) DeleteByObjectKeys(ctx context.Context, objectKeys []string) error {
+	if len(objectKeys) == 0 {
+		return nil
+	}
+	_, err := s.db.Operator.Core.NewDelete().
+		Model((*LfsGCObject)(nil)).
+		Where("object_key IN (?)", bun.In(objectKeys)).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to unmark lfs gc objects, error: %w", errorx.HandleDBError(err, nil))
+	}
+	return nil
+}
+
+func (s *lfsGCObjectStoreImpl) DeleteUnreferenced(ctx context.Context, object LfsGCObject) (bool, error) {
+	deleted := false
+	err := s.db.Operator.Core.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		// lock the mark so concurrent collections don't delete the same object
+		var marked LfsGCObject
+		err := tx.NewSelect().
+			Model(&marked).
+			Where("object_key = ?", object.ObjectKey).
+			For("UPDATE").
+			Scan(ctx)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var metas []LfsMetaObject
+		q := tx.NewSelect().Model(&metas).Where("oid = ?", object.Oid)
+		switch {
+		case object.RepositoryID > 0:
+			q.Where("repository_id = ?", object.RepositoryID)
+		case strings.HasPrefix(object.ObjectKey, "lfs/"):
+			// the shared key is the storage of all the repositories not migrated
+			q.Where("repository_id NOT IN (SELECT id FROM repositories WHERE migrated = true)")
+		default:
+			// the owner of a migrated key is unknown only if the repository is deleted, the other repositories
+			// referencing the same oid store it under their own keys or the shared key and must keep their meta objects
+			q.Where("repository_id NOT IN (SELECT id FROM repositories)")
+		}
+		err = q.For("UPDATE").Scan(ctx)
+		if err != nil {
+			return err
+		}
+		for _, meta := range metas {
+			// uploaded again after it was found unreferenced
+			if meta.UpdatedAt.After(marked.MarkedAt) {
+				return s.unmark(ctx, tx, object.ObjectKey)
+			}
+		}
+		if len(metas) > 0 {
+			_, err = tx.NewDelete().
+				Model((*LfsMetaObject)(nil)).
+				Where("id IN (?)", bun.In(metaIDs(metas))).
+				Exec(ctx)
+			if err != nil {
+				return err
+			}
+		}
+		deleted = true
+		return s.unmark(ctx, tx, object.ObjectKey)
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to delete unreferenced lfs object '%s', error: %w", object.ObjectKey, errorx.HandleDBError(err, nil))
+	}
+	return deleted, nil
+}
+
+func (s *lfsGCObjectStoreImpl) unmark(ctx context.Context, tx bun.Tx, objectKey string) error {
+	_, err := tx.NewDelete().
+		Model((*LfsGCObject)(nil)).
+		Where("object_key = ?", objectKey).
+		Exec(ctx)
+	return err
+}
+
+func metaIDs(metas []LfsMetaObject) []int64 {
+	ids := make([]int64, 0, len(metas))
+	for _, meta := range metas {
+		ids = append(ids, meta.ID)
+	}
+	return ids
+}
@@ -0,0 +1,137 @@
+package database_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/tests"
+	"opencsg.com/csghub-server/common/types"
+)
+
+func TestLfsGCObjectStore_MarkAndUnmark(t *testing.T) {
+	db := tests.InitTestDB()
+	defer db.Close()
+	ctx := context.TODO()
+
+	store := database.NewLfsGCObjectStoreWithDB(db)
+	markedAt := time.Now().Add(-time.Hour).Truncate(time.Second)
+	err := store.BatchCreate(ctx, []database.LfsGCObject{
+		{ObjectKey: "lfs/aa/bb/cc", Oid: "aabbcc", Size: 10, MarkedAt: markedAt},
+		{ObjectKey: "repos/aa/bb/sha/dd", Oid: "dd", RepositoryID: 1, Size: 20, MarkedAt: markedAt},
+	})
+	require.Nil(t, err)
+
+	// marking again keeps the first mark time
+	err = store.BatchCreate(ctx, []database.LfsGCObject{
+		{ObjectKey: "lfs/aa/bb/cc", Oid: "aabbcc", Size: 10, MarkedAt: time.Now()},
+	})
+	require.Nil(t, err)
+
+	objects, err := store.FindAll(ctx)
+	require.Nil(t, err)
+	require.Equal(t, 2, len(objects))
+	require.Equal(t, "lfs/aa/bb/cc", objects[0].ObjectKey)
+	require.True(t, objects[0].MarkedAt.Equal(markedAt))
+	require.Equal(t, int64(1), objects[1].RepositoryID)
+
+	err = store.DeleteByObjectKeys(ctx, []string{"lfs/aa/bb/cc"})
+	require.Nil(t, err)
+	objects, err = store.FindAll(ctx)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(objects))
+	require.Equal(t, "repos/aa/bb/sha/dd", objects[0].ObjectKey)
+}
+
+func TestLfsGCObjectStore_DeleteUnreferenced(t *testing.T) {
+	db := tests.InitTestDB()
+	defer db.Close()
+	ctx := context.TODO()
+
+	store := database.NewLfsGCObjectStoreWithDB(db)
+	metaStore := database.NewLfsMetaObjectStoreWithDB(db)
+	_, err := metaStore.Create(ctx, database.LfsMetaObject{RepositoryID: 1, Oid: "aabbcc"})
+	require.Nil(t, err)
+	_, err = metaStore.Create(ctx, database.LfsMetaObject{RepositoryID: 1, Oid: "ddeeff"})
+	require.Nil(t, err)
+
+	// ddeeff was uploaded again after it was marked
+	markedAt := time.Now().Add(-time.Hour)
+	_, err = db.Core.NewUpdate().Model((*database.LfsMetaObject)(nil)).
+		Set("updated_at = ?", markedAt.Add(-time.Hour)).
+		Where("oid = ?", "aabbcc").
+		Exec(ctx)
+	require.Nil(t, err)
+	objects := []database.LfsGCObject{
+		{ObjectKey: "lfs/aa/bb/cc", Oid: "aabbcc", MarkedAt: markedAt},
+		{ObjectKey: "lfs/dd/ee/ff", Oid: "ddeeff", MarkedAt: markedAt},
+	}
+	err = store.BatchCreate(ctx, objects)
+	require.Nil(t, err)
+
+	deleted, err := store.DeleteUnreferenced(ctx, objects[1])
+	require.Nil(t, err)
+	require.False(t, deleted)
+
+	deleted, err = store.DeleteUnreferenced(ctx, objects[0])
+	require.Nil(t, err)
+	require.True(t, deleted)
+	_, err = metaStore.FindByOID(ctx, 1, "aabbcc")
+	require.NotNil(t, err)
+	_, err = metaStore.FindByOID(ctx, 1, "ddeeff")
+	require.Nil(t, err)
+
+	marked, err := store.FindAll(ctx)
+	require.Nil(t, err)
+	require.Empty(t, marked)
+}
+
+func TestLfsGCObjectStore_DeleteUnreferenced_DeletedMigratedRepo(t *testing.T) {
+	db := tests.InitTestDB()
+	defer db.Close()
+	ctx := context.TODO()
+
+	store := database.NewLfsGCObjectStoreWithDB(db)
+	metaStore := database.NewLfsMetaObjectStoreWithDB(db)
+	repoStore := database.NewRepoStoreWithDB(db)
+	repo, err := repoStore.CreateRepo(ctx, database.Repository{
+		Name:           "repo",
+		GitPath:        "models_ns/repo",
+		Path:           "ns/repo",
+		RepositoryType: types.ModelRepo,
+	})
+	require.Nil(t, err)
+
+	// the unmigrated repo and the deleted migrated repo share the oid
+	oid := strings.Repeat("a", 64)
+	_, err = metaStore.Create(ctx, database.LfsMetaObject{RepositoryID: repo.ID, Oid: oid})
+	require.Nil(t, err)
+	deletedRepoID := repo.ID + 1000
+	_, err = metaStore.Create(ctx, database.LfsMetaObject{RepositoryID: deletedRepoID, Oid: oid})
+	require.Nil(t, err)
+	_, err = db.Core.NewUpdate().Model((*database.LfsMetaObject)(nil)).
+		Set("updated_at = ?", time.Now().Add(-2*time.Hour)).
+		Where("oid = ?", oid).
+		Exec(ctx)
+	require.Nil(t, err)
+
+	// the key of the deleted migrated repo has no known owner
+	object := database.LfsGCObject{
+		ObjectKey: "repos/bb/cc/" + strings.Repeat("d", 64) + "/" + oid,
+		Oid:       oid,
+		MarkedAt:  time.Now().Add(-time.Hour),
+	}
+	err = store.BatchCreate(ctx, []database.LfsGCObject{object})
+	require.Nil(t, err)
+
+	deleted, err := store.DeleteUnreferenced(ctx, object)
+	require.Nil(t, err)
+	require.True(t, deleted)
+	_, err = metaStore.FindByOID(ctx, repo.ID, oid)
+	require.Nil(t, err)
+	_, err = metaStore.FindByOID(ctx, deletedRepoID, oid)
+	require.NotNil(t, err)
+}
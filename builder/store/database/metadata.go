@@ -20,6 +20,9 @@ type MetadataStore interface {
 	// UpdateModelArchType updates the model architecture type (dense/moe/hybrid)
 	// for a repository's metadata.
 	UpdateModelArchType(ctx context.Context, repoID int64, archType types.ModelArchType) error
+	// UpsertCard sets the card parsed from the README of a repository, the metadata is created if it
+	// doesn't exist, e.g. for the datasets. A nil card clears the existing one.
+	UpsertCard(ctx context.Context, repoID int64, card *types.RepoCard) error
 }
 
 func NewMetadataStore() MetadataStore {
@@ -35,19 +38,20 @@ func NewMetadataStoreWithDB(db *DB) MetadataStore {
 }
 
 type Metadata struct {
-	ID                int64                  `bun:",pk,autoincrement" json:"id"`
-	RepositoryID      int64                  `bun:",notnull,unique" json:"repository_id"`
-	Repository        *Repository            `bun:"rel:belongs-to,join:repository_id=id" json:"repository"`
-	ModelParams       float32                `bun:"," json:"model_params"`
-	TensorType        string                 `bun:"," json:"tensor_type"`
-	MiniGPUMemoryGB   float32                `bun:"," json:"mini_gpu_memory_gb"`
-	MiniGPUFinetuneGB float32                `bun:"," json:"mini_gpu_finetune_gb"`
-	Architecture      string                 `bun:"," json:"architecture"`
-	ModelType         string                 `bun:"," json:"model_type"`
-	ClassName         string                 `bun:"," json:"class_name"`
-	Quantizations     []types.Quantization   `bun:"type:jsonb" json:"quantizations,omitempty"`
-	ModelArchType     types.ModelArchType    `bun:"," json:"model_arch_type"`
+	ID                int64                   `bun:",pk,autoincrement" json:"id"`
+	RepositoryID      int64                   `bun:",notnull,unique" json:"repository_id"`
+	Repository        *Repository             `bun:"rel:belongs-to,join:repository_id=id" json:"repository"`
+	ModelParams       float32                 `bun:"," json:"model_params"`
+	TensorType        string                  `bun:"," json:"tensor_type"`
+	MiniGPUMemoryGB   float32                 `bun:"," json:"mini_gpu_memory_gb"`
+	MiniGPUFinetuneGB float32                 `bun:"," json:"mini_gpu_finetune_gb"`
+	Architecture      string                  `bun:"," json:"architecture"`
+	ModelType         string                  `bun:"," json:"model_type"`
+	ClassName         string                  `bun:"," json:"class_name"`
+	Quantizations     []types.Quantization    `bun:"type:jsonb" json:"quantizations,omitempty"`
+	ModelArchType     types.ModelArchType     `bun:"," json:"model_arch_type"`
 	PDRecommendation  *types.PDRecommendation `bun:"type:jsonb,nullzero" json:"pd_recommendation,omitempty"`
+	Card              *types.RepoCard         `bun:"type:jsonb,nullzero" json:"card,omitempty"`
 	times
 }
 
@@ -63,9 +67,12 @@ func (m *metadataStoreImpl) FindByRepoID(ctx context.Context, repoID int64) (*Me
 	}
 	return &metadata, nil
 }
+
+// Upsert saves the metadata read from the model files, the card is kept as it's updated by the README changes
 func (m *metadataStoreImpl) Upsert(ctx context.Context, metadata *Metadata) error {
 	_, err := m.db.Operator.Core.NewInsert().
 		Model(metadata).
+		ExcludeColumn("card").
 		On("CONFLICT (repository_id) DO UPDATE").
 		Exec(ctx)
 	return err
@@ -97,3 +104,18 @@ func (m *metadataStoreImpl) UpdateModelArchType(ctx context.Context, repoID int6
 	}
 	return nil
 }
+
+func (m *metadataStoreImpl) UpsertCard(ctx context.Context, repoID int64, card *types.RepoCard) error {
+	metadata := &Metadata{RepositoryID: repoID, Card: card}
+	_, err := m.db.Operator.Core.NewInsert().
+		Model(metadata).
+		Column("repository_id", "card").
+		On("CONFLICT (repository_id) DO UPDATE").
+		Set("card = EXCLUDED.card").
+		Set("updated_at = CURRENT_TIMESTAMP").
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("fail to upsert card, %w", err)
+	}
+	return nil
+}
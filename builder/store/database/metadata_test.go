@@ -177,3 +177,40 @@ func TestMetadata_UpdateModelArchType(t *testing.T) {
 	require.Nil(t, err)
 	require.Equal(t, types.ModelArchTypeDense, meta.ModelArchType)
 }
+
+func TestMetadata_UpsertCard(t *testing.T) {
+	db := tests.InitTestDB()
+	defer db.Close()
+	ctx := context.TODO()
+
+	store := database.NewMetadataStoreWithDB(db)
+	repo := &database.Repository{
+		ID:      1,
+		Path:    "foo/bar",
+		GitPath: "foo/bar2",
+	}
+	err := db.Core.NewInsert().Model(repo).Scan(ctx, repo)
+	require.Nil(t, err)
+
+	card := &types.RepoCard{RepoType: types.ModelRepo, License: "mit", BaseModel: []string{"foo/base"}}
+	err = store.UpsertCard(ctx, 1, card)
+	require.Nil(t, err)
+	meta, err := store.FindByRepoID(ctx, 1)
+	require.Nil(t, err)
+	require.Equal(t, card, meta.Card)
+
+	// the model metadata doesn't overwrite the card
+	err = store.Upsert(ctx, &database.Metadata{RepositoryID: 1, ModelParams: 7, TensorType: "BF16"})
+	require.Nil(t, err)
+	meta, err = store.FindByRepoID(ctx, 1)
+	require.Nil(t, err)
+	require.Equal(t, "BF16", meta.TensorType)
+	require.Equal(t, card, meta.Card)
+
+	err = store.UpsertCard(ctx, 1, nil)
+	require.Nil(t, err)
+	meta, err = store.FindByRepoID(ctx, 1)
+	require.Nil(t, err)
+	require.Nil(t, meta.Card)
+	require.Equal(t, "BF16", meta.TensorType)
+}
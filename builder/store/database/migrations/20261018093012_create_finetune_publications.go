@@ -0,0 +1,43 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/uptrace/bun"
+	"opencsg.com/csghub-server/common/types"
+)
+
+type FinetunePublication struct {
+	ID              int64                         `bun:",pk,autoincrement" json:"id"`
+	TaskID          string                        `bun:",notnull,unique" json:"task_id"`
+	Operator        string                        `bun:",notnull" json:"operator"`
+	TargetPath      string                        `bun:",notnull" json:"target_path"`
+	Artifact        types.FinetunePublishArtifact `bun:",notnull" json:"artifact"`
+	Checkpoints     []string                      `bun:",type:jsonb" json:"checkpoints"`
+	Private         bool                          `bun:",notnull" json:"private"`
+	License         string                        `bun:"," json:"license"`
+	Hyperparameters types.FinetuneHyperparameters `bun:",type:jsonb" json:"hyperparameters"`
+	Status          types.FinetunePublishStatus   `bun:",notnull" json:"status"`
+	Reason          string                        `bun:"," json:"reason"`
+	TargetRepoID    int64                         `bun:",nullzero" json:"target_repo_id"`
+	PublishedFiles  int                           `bun:",notnull,default:0" json:"published_files"`
+	times
+}
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		err := createTables(ctx, db, &FinetunePublication{})
+		if err != nil {
+			return err
+		}
+
+		_, err = db.NewCreateIndex().Model(&FinetunePublication{}).
+			Index("idx_finetune_publications_target_path").
+			Column("target_path").
+			IfNotExists().
+			Exec(ctx)
+		return err
+	}, func(ctx context.Context, db *bun.DB) error {
+		return dropTables(ctx, db, &FinetunePublication{})
+	})
+}
@@ -0,0 +1,66 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type GatewayMCPToolPolicy struct {
+	ID            int64  `bun:",pk,autoincrement" json:"id"`
+	AccessTokenID int64  `bun:",notnull" json:"access_token_id"`
+	Tool          string `bun:",notnull" json:"tool"`
+	Action        string `bun:",notnull" json:"action"`
+	times
+}
+
+type GatewayMCPCallLog struct {
+	ID            int64     `bun:",pk,autoincrement" json:"id"`
+	NamespaceUUID string    `bun:",notnull" json:"namespace_uuid"`
+	Username      string    `bun:",nullzero" json:"username"`
+	APIKeyID      int64     `bun:",nullzero" json:"api_key_id"`
+	SessionID     string    `bun:",nullzero" json:"session_id"`
+	MCPServerID   int64     `bun:",notnull" json:"mcp_server_id"`
+	MCPServerName string    `bun:",notnull" json:"mcp_server_name"`
+	Method        string    `bun:",notnull" json:"method"`
+	Target        string    `bun:",notnull" json:"target"`
+	Status        string    `bun:",notnull" json:"status"`
+	Error         string    `bun:",nullzero" json:"error"`
+	LatencyMs     int64     `bun:",notnull,default:0" json:"latency_ms"`
+	CreatedAt     time.Time `bun:",nullzero,notnull,skipupdate,default:current_timestamp" json:"created_at"`
+}
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		err := createTables(ctx, db, GatewayMCPToolPolicy{}, GatewayMCPCallLog{})
+		if err != nil {
+			return fmt.Errorf("create table gateway_mcp_tool_policies and gateway_mcp_call_logs fail: %w", err)
+		}
+
+		_, err = db.NewCreateIndex().
+			Model((*GatewayMCPToolPolicy)(nil)).
+			Index("uniq_gateway_mcp_tool_policies_access_token_id_tool").
+			Column("access_token_id", "tool").
+			Unique().
+			IfNotExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("create unique index uniq_gateway_mcp_tool_policies_access_token_id_tool fail: %w", err)
+		}
+
+		_, err = db.NewCreateIndex().
+			Model((*GatewayMCPCallLog)(nil)).
+			Index("idx_gateway_mcp_call_logs_namespace_uuid_created_at").
+			Column("namespace_uuid", "created_at").
+			IfNotExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("create index idx_gateway_mcp_call_logs_namespace_uuid_created_at fail: %w", err)
+		}
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		return dropTables(ctx, db, GatewayMCPToolPolicy{}, GatewayMCPCallLog{})
+	})
+}
@@ -0,0 +1,45 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+	"opencsg.com/csghub-server/common/types"
+)
+
+type ModelFileScan struct {
+	ID           int64                        `bun:",pk,autoincrement" json:"id"`
+	RepositoryID int64                        `bun:",notnull" json:"repository_id"`
+	RepoFileID   int64                        `bun:",notnull,unique" json:"repo_file_id"`
+	Path         string                       `bun:",notnull" json:"path"`
+	Branch       string                       `bun:",nullzero" json:"branch"`
+	FileSHA      string                       `bun:",nullzero" json:"file_sha"`
+	Status       string                       `bun:",notnull" json:"status"`
+	Format       string                       `bun:",notnull" json:"format"`
+	Message      string                       `bun:",nullzero" json:"message"`
+	Findings     []types.ModelFileScanFinding `bun:",type:jsonb" json:"findings"`
+	times
+}
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		err := createTables(ctx, db, ModelFileScan{})
+		if err != nil {
+			return fmt.Errorf("create table model_file_scans fail: %w", err)
+		}
+
+		_, err = db.NewCreateIndex().
+			Model((*ModelFileScan)(nil)).
+			Index("idx_model_file_scans_repository_id_path").
+			Column("repository_id", "path").
+			IfNotExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("create index idx_model_file_scans_repository_id_path fail: %w", err)
+		}
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		return dropTables(ctx, db, ModelFileScan{})
+	})
+}
@@ -0,0 +1,62 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+)
+
+type InferenceRollout struct {
+	ID             int64   `bun:",pk,autoincrement" json:"id"`
+	DeployID       int64   `bun:",notnull" json:"deploy_id"`
+	UserUUID       string  `bun:",notnull" json:"user_uuid"`
+	StableCommit   string  `bun:",notnull" json:"stable_commit"`
+	CanaryCommit   string  `bun:",notnull" json:"canary_commit"`
+	Steps          []int   `bun:",type:jsonb" json:"steps"`
+	StepInterval   int     `bun:",notnull" json:"step_interval"`
+	MaxErrorRate   float64 `bun:",notnull,default:0" json:"max_error_rate"`
+	MaxLatencyMs   float64 `bun:",notnull,default:0" json:"max_latency_ms"`
+	CurrentStep    int     `bun:",notnull,default:0" json:"current_step"`
+	CurrentTraffic int     `bun:",notnull,default:0" json:"current_traffic"`
+	Status         string  `bun:",notnull" json:"status"`
+	Reason         string  `bun:",nullzero" json:"reason"`
+	ErrorRate      float64 `bun:",notnull,default:0" json:"error_rate"`
+	LatencyMs      float64 `bun:",notnull,default:0" json:"latency_ms"`
+	times
+}
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		err := createTables(ctx, db, InferenceRollout{})
+		if err != nil {
+			return fmt.Errorf("create table inference_rollouts fail: %w", err)
+		}
+
+		_, err = db.NewCreateIndex().
+			Model((*InferenceRollout)(nil)).
+			Index("idx_inference_rollouts_deploy_id").
+			Column("deploy_id").
+			IfNotExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("create index idx_inference_rollouts_deploy_id fail: %w", err)
+		}
+
+		// at most one running rollout per deploy
+		_, err = db.NewCreateIndex().
+			Model((*InferenceRollout)(nil)).
+			Index("idx_inference_rollouts_running_deploy_id").
+			Column("deploy_id").
+			Unique().
+			Where("status = 'running'").
+			IfNotExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("create index idx_inference_rollouts_running_deploy_id fail: %w", err)
+		}
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		return dropTables(ctx, db, InferenceRollout{})
+	})
+}
@@ -0,0 +1,39 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+)
+
+type NamespaceStorageQuota struct {
+	ID            int64  `bun:",pk,autoincrement" json:"id"`
+	Namespace     string `bun:",notnull,unique" json:"namespace"`
+	QuotaBytes    *int64 `bun:"," json:"quota_bytes"`
+	GitBytes      int64  `bun:",notnull,default:0" json:"git_bytes"`
+	LfsBytes      int64  `bun:",notnull,default:0" json:"lfs_bytes"`
+	WarnedPercent int    `bun:",notnull,default:0" json:"warned_percent"`
+	times
+}
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		err := createTables(ctx, db, NamespaceStorageQuota{})
+		if err != nil {
+			return fmt.Errorf("create table namespace_storage_quotas fail: %w", err)
+		}
+		// the usage of a namespace is summed over repositories whose path starts with "namespace/"
+		_, err = db.ExecContext(ctx, "CREATE INDEX IF NOT EXISTS idx_repositories_path_pattern ON repositories (path text_pattern_ops)")
+		if err != nil {
+			return fmt.Errorf("create index idx_repositories_path_pattern fail: %w", err)
+		}
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		_, err := db.ExecContext(ctx, "DROP INDEX IF EXISTS idx_repositories_path_pattern")
+		if err != nil {
+			return fmt.Errorf("drop index idx_repositories_path_pattern fail: %w", err)
+		}
+		return dropTables(ctx, db, NamespaceStorageQuota{})
+	})
+}
@@ -0,0 +1,31 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type LfsGCObject struct {
+	ID           int64     `bun:",pk,autoincrement" json:"id"`
+	ObjectKey    string    `bun:",notnull,unique" json:"object_key"`
+	Oid          string    `bun:",notnull" json:"oid"`
+	RepositoryID int64     `bun:",notnull,default:0" json:"repository_id"`
+	Size         int64     `bun:",notnull,default:0" json:"size"`
+	MarkedAt     time.Time `bun:",notnull" json:"marked_at"`
+	times
+}
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		err := createTables(ctx, db, LfsGCObject{})
+		if err != nil {
+			return fmt.Errorf("create table lfs_gc_objects fail: %w", err)
+		}
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		return dropTables(ctx, db, LfsGCObject{})
+	})
+}
@@ -0,0 +1,91 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type MQStream struct {
+	bun.BaseModel `bun:"table:mq_streams"`
+	ID            int64    `bun:",pk,autoincrement" json:"id"`
+	Name          string   `bun:",notnull,unique" json:"name"`
+	Topics        []string `bun:",notnull,type:jsonb" json:"topics"`
+	MaxBytes      int64    `bun:",notnull,default:0" json:"max_bytes"`
+	MaxAgeSeconds int64    `bun:",notnull,default:0" json:"max_age_seconds"`
+	times
+}
+
+type MQConsumer struct {
+	bun.BaseModel  `bun:"table:mq_consumers"`
+	ID             int64    `bun:",pk,autoincrement" json:"id"`
+	Stream         string   `bun:",notnull,unique:idx_mq_consumers_stream_name" json:"stream"`
+	Name           string   `bun:",notnull,unique:idx_mq_consumers_stream_name" json:"name"`
+	Topics         []string `bun:",notnull,type:jsonb" json:"topics"`
+	AckWaitSeconds int64    `bun:",notnull,default:0" json:"ack_wait_seconds"`
+	MaxDeliver     int      `bun:",notnull,default:0" json:"max_deliver"`
+	times
+}
+
+type MQMessage struct {
+	bun.BaseModel `bun:"table:mq_messages"`
+	ID            int64     `bun:",pk,autoincrement" json:"id"`
+	Stream        string    `bun:",notnull" json:"stream"`
+	Topic         string    `bun:",notnull" json:"topic"`
+	Data          []byte    `bun:",notnull,type:bytea" json:"data"`
+	CreatedAt     time.Time `bun:",nullzero,notnull,skipupdate,default:current_timestamp" json:"created_at"`
+}
+
+type MQDelivery struct {
+	bun.BaseModel `bun:"table:mq_deliveries"`
+	ID            int64     `bun:",pk,autoincrement" json:"id"`
+	ConsumerID    int64     `bun:",notnull,unique:idx_mq_deliveries_consumer_message" json:"consumer_id"`
+	MessageID     int64     `bun:",notnull,unique:idx_mq_deliveries_consumer_message" json:"message_id"`
+	DeliverCount  int       `bun:",notnull,default:0" json:"deliver_count"`
+	VisibleAt     time.Time `bun:",notnull,default:current_timestamp" json:"visible_at"`
+	CreatedAt     time.Time `bun:",nullzero,notnull,skipupdate,default:current_timestamp" json:"created_at"`
+}
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		err := createTables(ctx, db, MQStream{}, MQConsumer{}, MQMessage{}, MQDelivery{})
+		if err != nil {
+			return fmt.Errorf("create message queue tables fail: %w", err)
+		}
+
+		_, err = db.NewCreateIndex().
+			Model((*MQMessage)(nil)).
+			Index("idx_mq_messages_stream_id").
+			Column("stream", "id").
+			IfNotExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("create index idx_mq_messages_stream_id fail: %w", err)
+		}
+
+		_, err = db.NewCreateIndex().
+			Model((*MQDelivery)(nil)).
+			Index("idx_mq_deliveries_consumer_visible_at").
+			Column("consumer_id", "visible_at").
+			IfNotExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("create index idx_mq_deliveries_consumer_visible_at fail: %w", err)
+		}
+
+		_, err = db.NewCreateIndex().
+			Model((*MQDelivery)(nil)).
+			Index("idx_mq_deliveries_message_id").
+			Column("message_id").
+			IfNotExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("create index idx_mq_deliveries_message_id fail: %w", err)
+		}
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		return dropTables(ctx, db, MQStream{}, MQConsumer{}, MQMessage{}, MQDelivery{})
+	})
+}
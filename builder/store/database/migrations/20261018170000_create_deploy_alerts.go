@@ -0,0 +1,73 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type DeployAlertRule struct {
+	ID              int64      `bun:",pk,autoincrement" json:"id"`
+	DeployID        int64      `bun:",notnull" json:"deploy_id"`
+	UserUUID        string     `bun:",notnull" json:"user_uuid"`
+	Name            string     `bun:",nullzero" json:"name"`
+	Metric          string     `bun:",notnull" json:"metric"`
+	Operator        string     `bun:",notnull" json:"operator"`
+	Threshold       float64    `bun:",notnull,default:0" json:"threshold"`
+	DurationSeconds int64      `bun:",notnull,default:0" json:"duration_seconds"`
+	Window          string     `bun:",notnull" json:"window"`
+	Enabled         bool       `bun:",notnull,default:true" json:"enabled"`
+	State           string     `bun:",notnull" json:"state"`
+	LastValue       float64    `bun:",notnull,default:0" json:"last_value"`
+	PendingSince    *time.Time `bun:",nullzero" json:"pending_since"`
+	LastEvaluatedAt *time.Time `bun:",nullzero" json:"last_evaluated_at"`
+	FiredAt         *time.Time `bun:",nullzero" json:"fired_at"`
+	ResolvedAt      *time.Time `bun:",nullzero" json:"resolved_at"`
+	times
+}
+
+type DeployAlertEvent struct {
+	ID        int64     `bun:",pk,autoincrement" json:"id"`
+	RuleID    int64     `bun:",notnull" json:"rule_id"`
+	DeployID  int64     `bun:",notnull" json:"deploy_id"`
+	RuleName  string    `bun:",nullzero" json:"rule_name"`
+	Metric    string    `bun:",notnull" json:"metric"`
+	State     string    `bun:",notnull" json:"state"`
+	Value     float64   `bun:",notnull,default:0" json:"value"`
+	Threshold float64   `bun:",notnull,default:0" json:"threshold"`
+	CreatedAt time.Time `bun:",nullzero,notnull,skipupdate,default:current_timestamp" json:"created_at"`
+}
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		err := createTables(ctx, db, DeployAlertRule{}, DeployAlertEvent{})
+		if err != nil {
+			return fmt.Errorf("create deploy alert tables fail: %w", err)
+		}
+
+		_, err = db.NewCreateIndex().
+			Model((*DeployAlertRule)(nil)).
+			Index("idx_deploy_alert_rules_deploy_id").
+			Column("deploy_id").
+			IfNotExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("create index idx_deploy_alert_rules_deploy_id fail: %w", err)
+		}
+
+		_, err = db.NewCreateIndex().
+			Model((*DeployAlertEvent)(nil)).
+			Index("idx_deploy_alert_events_deploy_id").
+			Column("deploy_id", "id").
+			IfNotExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("create index idx_deploy_alert_events_deploy_id fail: %w", err)
+		}
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		return dropTables(ctx, db, DeployAlertRule{}, DeployAlertEvent{})
+	})
+}
@@ -0,0 +1,51 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+)
+
+type ResourceSecret struct {
+	ID         int64  `bun:",pk,autoincrement" json:"id"`
+	OwnerType  string `bun:",notnull" json:"owner_type"`
+	OwnerID    int64  `bun:",notnull" json:"owner_id"`
+	Key        string `bun:",notnull" json:"key"`
+	Ciphertext string `bun:",notnull" json:"-"`
+	KeyVersion int    `bun:",notnull" json:"key_version"`
+	times
+}
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		err := createTables(ctx, db, ResourceSecret{})
+		if err != nil {
+			return fmt.Errorf("create resource secrets table fail: %w", err)
+		}
+
+		_, err = db.NewCreateIndex().
+			Model((*ResourceSecret)(nil)).
+			Index("idx_unique_resource_secrets_owner_key").
+			Column("owner_type", "owner_id", "key").
+			Unique().
+			IfNotExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("create index idx_unique_resource_secrets_owner_key fail: %w", err)
+		}
+
+		_, err = db.NewCreateIndex().
+			Model((*ResourceSecret)(nil)).
+			Index("idx_resource_secrets_key_version").
+			Column("key_version", "id").
+			IfNotExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("create index idx_resource_secrets_key_version fail: %w", err)
+		}
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		return dropTables(ctx, db, ResourceSecret{})
+	})
+}
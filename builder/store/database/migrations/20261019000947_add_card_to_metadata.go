@@ -0,0 +1,29 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+)
+
+// init registers the migration of the parsed README card column of the repository metadata.
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		if _, err := db.ExecContext(ctx, `
+			ALTER TABLE metadata
+			ADD COLUMN IF NOT EXISTS card JSONB
+		`); err != nil {
+			return fmt.Errorf("failed to add metadata card column: %w", err)
+		}
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		if _, err := db.ExecContext(ctx, `
+			ALTER TABLE metadata
+			DROP COLUMN IF EXISTS card
+		`); err != nil {
+			return fmt.Errorf("failed to drop metadata card column: %w", err)
+		}
+		return nil
+	})
+}
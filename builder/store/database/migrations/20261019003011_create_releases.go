@@ -0,0 +1,57 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+)
+
+type Release struct {
+	ID               int64    `bun:",pk,autoincrement" json:"id"`
+	RepositoryID     int64    `bun:",notnull" json:"repository_id"`
+	Name             string   `bun:",notnull" json:"name"`
+	TagName          string   `bun:",notnull" json:"tag_name"`
+	Title            string   `bun:"," json:"title"`
+	Changelog        string   `bun:",type:text" json:"changelog"`
+	RecommendedFiles []string `bun:",type:jsonb,nullzero" json:"recommended_files"`
+	Deprecated       bool     `bun:",notnull" json:"deprecated"`
+	DeprecationNote  string   `bun:"," json:"deprecation_note"`
+	Latest           bool     `bun:",notnull" json:"latest"`
+	AuthorID         int64    `bun:",notnull" json:"author_id"`
+	times
+}
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		err := createTables(ctx, db, Release{})
+		if err != nil {
+			return fmt.Errorf("create releases table fail: %w", err)
+		}
+		_, err = db.NewCreateIndex().
+			Model((*Release)(nil)).
+			Index("idx_releases_repository_id_name").
+			Column("repository_id", "name").
+			Unique().
+			IfNotExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("create index idx_releases_repository_id_name fail: %w", err)
+		}
+		// at most one latest release in a repository
+		_, err = db.NewCreateIndex().
+			Model((*Release)(nil)).
+			Index("idx_releases_repository_id_latest").
+			Column("repository_id").
+			Unique().
+			Where("latest").
+			IfNotExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("create index idx_releases_repository_id_latest fail: %w", err)
+		}
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		return dropTables(ctx, db, Release{})
+	})
+}
@@ -0,0 +1,84 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type RepoAccessPolicy struct {
+	ID                int64  `bun:",pk,autoincrement" json:"id"`
+	RepositoryID      int64  `bun:",notnull" json:"repository_id"`
+	ApprovalMode      string `bun:",notnull" json:"approval_mode"`
+	Terms             string `bun:",type:text" json:"terms"`
+	FormFields        []any  `bun:",type:jsonb,nullzero" json:"form_fields"`
+	ApprovalValidDays int    `bun:",notnull" json:"approval_valid_days"`
+	times
+}
+
+type RepoAccessRequest struct {
+	ID            int64             `bun:",pk,autoincrement" json:"id"`
+	RepositoryID  int64             `bun:",notnull" json:"repository_id"`
+	UserID        int64             `bun:",notnull" json:"user_id"`
+	Status        string            `bun:",notnull" json:"status"`
+	AcceptedTerms bool              `bun:",notnull" json:"accepted_terms"`
+	Form          map[string]string `bun:",type:jsonb,nullzero" json:"form"`
+	ReviewerID    int64             `bun:",nullzero" json:"reviewer_id"`
+	ReviewMsg     string            `bun:"," json:"review_msg"`
+	ReviewedAt    time.Time         `bun:",nullzero" json:"reviewed_at"`
+	ExpiresAt     time.Time         `bun:",nullzero" json:"expires_at"`
+	times
+}
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		if _, err := db.ExecContext(ctx, `
+			ALTER TABLE repositories
+			ADD COLUMN IF NOT EXISTS gated BOOLEAN NOT NULL DEFAULT FALSE
+		`); err != nil {
+			return fmt.Errorf("failed to add repository gated column: %w", err)
+		}
+		err := createTables(ctx, db, RepoAccessPolicy{}, RepoAccessRequest{})
+		if err != nil {
+			return fmt.Errorf("create repo access policy and request tables fail: %w", err)
+		}
+		indexes := []struct {
+			model   any
+			name    string
+			columns []string
+			unique  bool
+		}{
+			{(*RepoAccessPolicy)(nil), "idx_repo_access_policies_repository_id", []string{"repository_id"}, true},
+			{(*RepoAccessRequest)(nil), "idx_repo_access_requests_repository_id_user_id", []string{"repository_id", "user_id"}, true},
+			{(*RepoAccessRequest)(nil), "idx_repo_access_requests_repository_id_status", []string{"repository_id", "status"}, false},
+		}
+		for _, idx := range indexes {
+			q := db.NewCreateIndex().
+				Model(idx.model).
+				Index(idx.name).
+				Column(idx.columns...).
+				IfNotExists()
+			if idx.unique {
+				q = q.Unique()
+			}
+			if _, err := q.Exec(ctx); err != nil {
+				return fmt.Errorf("create index %s fail: %w", idx.name, err)
+			}
+		}
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		err := dropTables(ctx, db, RepoAccessPolicy{}, RepoAccessRequest{})
+		if err != nil {
+			return err
+		}
+		if _, err := db.ExecContext(ctx, `
+			ALTER TABLE repositories
+			DROP COLUMN IF EXISTS gated
+		`); err != nil {
+			return fmt.Errorf("failed to drop repository gated column: %w", err)
+		}
+		return nil
+	})
+}
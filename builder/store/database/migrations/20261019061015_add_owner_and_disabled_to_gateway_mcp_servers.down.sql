@@ -0,0 +1,13 @@
+SET statement_timeout = 0;
+
+--bun:split
+
+DROP INDEX IF EXISTS idx_gateway_mcp_servers_owner_namespace;
+
+--bun:split
+
+ALTER TABLE gateway_mcp_servers DROP COLUMN IF EXISTS disabled;
+
+--bun:split
+
+ALTER TABLE gateway_mcp_servers DROP COLUMN IF EXISTS owner_namespace;
@@ -0,0 +1,13 @@
+SET statement_timeout = 0;
+
+--bun:split
+
+ALTER TABLE gateway_mcp_servers ADD COLUMN IF NOT EXISTS owner_namespace VARCHAR;
+
+--bun:split
+
+ALTER TABLE gateway_mcp_servers ADD COLUMN IF NOT EXISTS disabled BOOLEAN NOT NULL DEFAULT FALSE;
+
+--bun:split
+
+CREATE INDEX IF NOT EXISTS idx_gateway_mcp_servers_owner_namespace ON gateway_mcp_servers (owner_namespace);
@@ -0,0 +1,9 @@
+SET statement_timeout = 0;
+
+--bun:split
+
+ALTER TABLE deploys DROP COLUMN IF EXISTS credential_names;
+
+--bun:split
+
+ALTER TABLE mirrors DROP COLUMN IF EXISTS credential_id;
@@ -0,0 +1,9 @@
+SET statement_timeout = 0;
+
+--bun:split
+
+ALTER TABLE deploys ADD COLUMN IF NOT EXISTS credential_names jsonb;
+
+--bun:split
+
+ALTER TABLE mirrors ADD COLUMN IF NOT EXISTS credential_id bigint;
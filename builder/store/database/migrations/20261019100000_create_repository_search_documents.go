@@ -0,0 +1,88 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+	"opencsg.com/csghub-server/common/config"
+)
+
+type RepositorySearchDocument struct {
+	RepositoryID int64  `bun:",pk" json:"repository_id"`
+	Readme       string `bun:",nullzero" json:"readme"`
+	Card         string `bun:",nullzero" json:"card"`
+	Tags         string `bun:",nullzero" json:"tags"`
+	FilePaths    string `bun:",nullzero" json:"file_paths"`
+	CJKTerms     string `bun:"cjk_terms,nullzero" json:"-"`
+	times
+}
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		err := createTables(ctx, db, RepositorySearchDocument{})
+		if err != nil {
+			return fmt.Errorf("create repository search documents table fail: %w", err)
+		}
+
+		// the full-text index is only available in postgresql
+		cfg, err := config.LoadConfig()
+		if err != nil {
+			return err
+		}
+		if cfg.Database.Driver != "pg" {
+			return nil
+		}
+		searchConfiguration := cfg.Database.SearchConfiguration
+
+		if _, err := db.Exec(`
+			ALTER TABLE repository_search_documents
+			ADD COLUMN IF NOT EXISTS search_vector tsvector
+		`); err != nil {
+			return fmt.Errorf("failed to add 'search_vector' column to repository_search_documents: %w", err)
+		}
+		if _, err := db.Exec(`
+			CREATE INDEX IF NOT EXISTS idx_repository_search_documents_search_vector
+			ON repository_search_documents
+			USING GIN (search_vector)
+		`); err != nil {
+			return fmt.Errorf("failed to create GIN index on 'repository_search_documents.search_vector': %w", err)
+		}
+
+		// the CJK terms are n-grams split by the application, they are indexed with the simple
+		// configuration so that CJK text can be searched even if zhparser is not installed
+		if _, err := db.Exec(`
+			CREATE OR REPLACE FUNCTION update_repository_search_document_vector() RETURNS trigger AS $$
+			BEGIN
+				NEW.search_vector :=
+					setweight(to_tsvector('` + searchConfiguration + `', COALESCE(NEW.card, '')), 'A')
+					|| setweight(to_tsvector('` + searchConfiguration + `', COALESCE(regexp_replace(NEW.tags, '[-/_]', ' ', 'g'), '')), 'A')
+					|| setweight(to_tsvector('` + searchConfiguration + `', COALESCE(NEW.readme, '')), 'B')
+					|| setweight(to_tsvector('simple', COALESCE(NEW.cjk_terms, '')), 'B')
+					|| setweight(to_tsvector('` + searchConfiguration + `', COALESCE(regexp_replace(NEW.file_paths, '[-/_.]', ' ', 'g'), '')), 'C');
+				RETURN NEW;
+			END;
+			$$ LANGUAGE plpgsql;
+
+			CREATE OR REPLACE TRIGGER repository_search_documents_search_vector_update
+			BEFORE INSERT OR UPDATE OF readme, card, tags, file_paths, cjk_terms
+			ON repository_search_documents
+			FOR EACH ROW
+			EXECUTE FUNCTION update_repository_search_document_vector();
+		`); err != nil {
+			return fmt.Errorf("failed to create trigger for 'repository_search_documents.search_vector': %w", err)
+		}
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		cfg, err := config.LoadConfig()
+		if err != nil {
+			return err
+		}
+		if cfg.Database.Driver == "pg" {
+			if _, err := db.Exec(`DROP FUNCTION IF EXISTS update_repository_search_document_vector() CASCADE`); err != nil {
+				return fmt.Errorf("failed to drop function update_repository_search_document_vector: %w", err)
+			}
+		}
+		return dropTables(ctx, db, RepositorySearchDocument{})
+	})
+}
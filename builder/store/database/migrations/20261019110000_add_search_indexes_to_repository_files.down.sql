@@ -0,0 +1,13 @@
+SET statement_timeout = 0;
+
+--bun:split
+
+DROP INDEX IF EXISTS idx_repository_files_lfs_relative_path;
+
+--bun:split
+
+DROP INDEX IF EXISTS idx_repository_files_size;
+
+--bun:split
+
+DROP INDEX IF EXISTS idx_repository_files_repo_branch_path;
@@ -0,0 +1,17 @@
+SET statement_timeout = 0;
+
+--bun:split
+
+CREATE INDEX IF NOT EXISTS idx_repository_files_lfs_relative_path
+    ON repository_files (lfs_relative_path)
+    WHERE lfs_relative_path IS NOT NULL;
+
+--bun:split
+
+CREATE INDEX IF NOT EXISTS idx_repository_files_size
+    ON repository_files (size);
+
+--bun:split
+
+CREATE INDEX IF NOT EXISTS idx_repository_files_repo_branch_path
+    ON repository_files (repository_id, branch, path);
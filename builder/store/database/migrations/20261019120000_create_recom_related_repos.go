@@ -0,0 +1,37 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+)
+
+// RecomRelatedRepo is the item-to-item recommendation score between two repositories
+type RecomRelatedRepo struct {
+	RepositoryID        int64   `bun:",pk" json:"repository_id"`
+	RelatedRepositoryID int64   `bun:",pk" json:"related_repository_id"`
+	Score               float64 `bun:",notnull" json:"score"`
+	times
+}
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		err := createTables(ctx, db, RecomRelatedRepo{})
+		if err != nil {
+			return fmt.Errorf("create table recom_related_repos fail: %w", err)
+		}
+		_, err = db.NewCreateIndex().
+			Model((*RecomRelatedRepo)(nil)).
+			Index("idx_recom_related_repos_updated_at").
+			Column("updated_at").
+			IfNotExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("create index idx_recom_related_repos_updated_at fail: %w", err)
+		}
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		return dropTables(ctx, db, RecomRelatedRepo{})
+	})
+}
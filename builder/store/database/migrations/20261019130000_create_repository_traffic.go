@@ -0,0 +1,39 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type RepositoryTrafficDaily struct {
+	RepositoryID int64     `bun:",pk" json:"repository_id"`
+	Date         time.Time `bun:",pk,type:date" json:"date"`
+	Kind         string    `bun:",pk" json:"kind"`
+	Path         string    `bun:",pk" json:"path"`
+	Region       string    `bun:",pk" json:"region"`
+	Count        int64     `bun:",notnull" json:"count"`
+	times
+}
+
+type RepositoryTrafficVisitor struct {
+	RepositoryID int64     `bun:",pk" json:"repository_id"`
+	Date         time.Time `bun:",pk,type:date" json:"date"`
+	VisitorID    string    `bun:",pk" json:"visitor_id"`
+	Region       string    `bun:",notnull" json:"region"`
+	times
+}
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		err := createTables(ctx, db, RepositoryTrafficDaily{}, RepositoryTrafficVisitor{})
+		if err != nil {
+			return fmt.Errorf("create repository traffic tables fail: %w", err)
+		}
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		return dropTables(ctx, db, RepositoryTrafficDaily{}, RepositoryTrafficVisitor{})
+	})
+}
@@ -0,0 +1,77 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type Follow struct {
+	ID             int64  `bun:",pk,autoincrement" json:"id"`
+	UserID         int64  `bun:",notnull" json:"user_id"`
+	FollowableType string `bun:",notnull" json:"followable_type"`
+	FollowableID   int64  `bun:",notnull" json:"followable_id"`
+	times
+}
+
+type FeedEvent struct {
+	ID           int64          `bun:",pk,autoincrement" json:"id"`
+	EventType    string         `bun:",notnull" json:"event_type"`
+	ActorID      int64          `bun:",nullzero" json:"actor_id"`
+	Namespace    string         `bun:",notnull" json:"namespace"`
+	RepositoryID int64          `bun:",notnull" json:"repository_id"`
+	Payload      map[string]any `bun:",type:jsonb,nullzero" json:"payload"`
+	CreatedAt    time.Time      `bun:",nullzero,notnull,skipupdate,default:current_timestamp" json:"created_at"`
+}
+
+type FeedDigestSubscription struct {
+	UserID     int64     `bun:",pk" json:"user_id"`
+	Enabled    bool      `bun:",notnull" json:"enabled"`
+	LastSentAt time.Time `bun:",nullzero" json:"last_sent_at"`
+	times
+}
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		err := createTables(ctx, db, Follow{}, FeedEvent{}, FeedDigestSubscription{})
+		if err != nil {
+			return fmt.Errorf("create follow and feed tables fail: %w", err)
+		}
+		_, err = db.NewCreateIndex().
+			Model((*Follow)(nil)).
+			Index("idx_follows_user_id_followable").
+			Column("user_id", "followable_type", "followable_id").
+			Unique().
+			IfNotExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("create index idx_follows_user_id_followable fail: %w", err)
+		}
+		_, err = db.NewCreateIndex().
+			Model((*Follow)(nil)).
+			Index("idx_follows_followable").
+			Column("followable_type", "followable_id").
+			IfNotExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("create index idx_follows_followable fail: %w", err)
+		}
+		for _, column := range []string{"repository_id", "actor_id", "namespace", "created_at"} {
+			index := "idx_feed_events_" + column
+			_, err = db.NewCreateIndex().
+				Model((*FeedEvent)(nil)).
+				Index(index).
+				Column(column).
+				IfNotExists().
+				Exec(ctx)
+			if err != nil {
+				return fmt.Errorf("create index %s fail: %w", index, err)
+			}
+		}
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		return dropTables(ctx, db, Follow{}, FeedEvent{}, FeedDigestSubscription{})
+	})
+}
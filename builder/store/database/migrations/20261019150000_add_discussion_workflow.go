@@ -0,0 +1,106 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+)
+
+type DiscussionLabel struct {
+	ID           int64  `bun:",pk,autoincrement" json:"id"`
+	RepositoryID int64  `bun:",notnull" json:"repository_id"`
+	Name         string `bun:",notnull" json:"name"`
+	Color        string `json:"color"`
+	Description  string `json:"description"`
+	times
+}
+
+type DiscussionLabelRelation struct {
+	ID           int64 `bun:",pk,autoincrement" json:"id"`
+	DiscussionID int64 `bun:",notnull" json:"discussion_id"`
+	LabelID      int64 `bun:",notnull" json:"label_id"`
+}
+
+type CommentReaction struct {
+	ID        int64  `bun:",pk,autoincrement" json:"id"`
+	CommentID int64  `bun:",notnull" json:"comment_id"`
+	UserID    int64  `bun:",notnull" json:"user_id"`
+	Emoji     string `bun:",notnull" json:"emoji"`
+	times
+}
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		if _, err := db.ExecContext(ctx, `
+			ALTER TABLE discussions
+			ADD COLUMN IF NOT EXISTS status VARCHAR NOT NULL DEFAULT 'open',
+			ADD COLUMN IF NOT EXISTS pinned BOOLEAN NOT NULL DEFAULT FALSE
+		`); err != nil {
+			return fmt.Errorf("failed to add discussion status and pinned columns: %w", err)
+		}
+		if _, err := db.ExecContext(ctx, `
+			ALTER TABLE comments
+			ADD COLUMN IF NOT EXISTS hidden BOOLEAN NOT NULL DEFAULT FALSE
+		`); err != nil {
+			return fmt.Errorf("failed to add comment hidden column: %w", err)
+		}
+		err := createTables(ctx, db, DiscussionLabel{}, DiscussionLabelRelation{}, CommentReaction{})
+		if err != nil {
+			return fmt.Errorf("create discussion label and comment reaction tables fail: %w", err)
+		}
+		indexes := []struct {
+			model   any
+			name    string
+			columns []string
+			unique  bool
+		}{
+			{(*DiscussionLabel)(nil), "idx_discussion_labels_repository_id_name", []string{"repository_id", "name"}, true},
+			{(*DiscussionLabelRelation)(nil), "idx_discussion_label_relations_discussion_id_label_id", []string{"discussion_id", "label_id"}, true},
+			{(*DiscussionLabelRelation)(nil), "idx_discussion_label_relations_label_id", []string{"label_id"}, false},
+			{(*CommentReaction)(nil), "idx_comment_reactions_comment_id_user_id_emoji", []string{"comment_id", "user_id", "emoji"}, true},
+		}
+		for _, idx := range indexes {
+			q := db.NewCreateIndex().
+				Model(idx.model).
+				Index(idx.name).
+				Column(idx.columns...).
+				IfNotExists()
+			if idx.unique {
+				q = q.Unique()
+			}
+			if _, err := q.Exec(ctx); err != nil {
+				return fmt.Errorf("create index %s fail: %w", idx.name, err)
+			}
+		}
+		_, err = db.NewCreateIndex().
+			Table("discussions").
+			Index("idx_discussions_status").
+			Column("status").
+			IfNotExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("create index idx_discussions_status fail: %w", err)
+		}
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		err := dropTables(ctx, db, DiscussionLabel{}, DiscussionLabelRelation{}, CommentReaction{})
+		if err != nil {
+			return err
+		}
+		if _, err := db.ExecContext(ctx, `
+			ALTER TABLE discussions
+			DROP COLUMN IF EXISTS status,
+			DROP COLUMN IF EXISTS pinned
+		`); err != nil {
+			return fmt.Errorf("failed to drop discussion status and pinned columns: %w", err)
+		}
+		if _, err := db.ExecContext(ctx, `
+			ALTER TABLE comments
+			DROP COLUMN IF EXISTS hidden
+		`); err != nil {
+			return fmt.Errorf("failed to drop comment hidden column: %w", err)
+		}
+		return nil
+	})
+}
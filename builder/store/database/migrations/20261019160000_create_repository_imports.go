@@ -0,0 +1,49 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+)
+
+type RepositoryImport struct {
+	ID           int64  `bun:",pk,autoincrement" json:"id"`
+	UserID       int64  `bun:",notnull" json:"user_id"`
+	Provider     string `bun:",notnull" json:"provider"`
+	SourcePath   string `bun:",notnull" json:"source_path"`
+	RepositoryID int64  `bun:",notnull" json:"repository_id"`
+	MirrorID     int64  `bun:",notnull" json:"mirror_id"`
+	times
+}
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		err := createTables(ctx, db, RepositoryImport{})
+		if err != nil {
+			return fmt.Errorf("create repository imports table fail: %w", err)
+		}
+		_, err = db.NewCreateIndex().
+			Model((*RepositoryImport)(nil)).
+			Index("idx_repository_imports_user_id_created_at").
+			Column("user_id", "created_at").
+			IfNotExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("create index idx_repository_imports_user_id_created_at fail: %w", err)
+		}
+		_, err = db.NewCreateIndex().
+			Model((*RepositoryImport)(nil)).
+			Index("idx_repository_imports_repository_id").
+			Column("repository_id").
+			Unique().
+			IfNotExists().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("create index idx_repository_imports_repository_id fail: %w", err)
+		}
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		return dropTables(ctx, db, RepositoryImport{})
+	})
+}
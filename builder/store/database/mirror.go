@@ -94,6 +94,8 @@ type Mirror struct {
 	RemoteUpdatedAt        time.Time              `bun:",nullzero" json:"remote_updated_at"`
 	CurrentTaskID          int64                  `bun:",nullzero" json:"current_task_id"`
 	CurrentTask            *MirrorTask            `bun:"rel:has-one,join:current_task_id=id" json:"current_task"`
+	// CredentialID is the credential the source is synced with, it takes the place of Username and AccessToken
+	CredentialID int64 `bun:",nullzero" json:"credential_id,omitempty"`
 
 	times
 }
@@ -0,0 +1,97 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+)
+
+type modelFileScanStoreImpl struct {
+	db *DB
+}
+
+type ModelFileScanStore interface {
+	Upsert(ctx context.Context, scan *ModelFileScan) error
+	// FindByRepoFileIDs returns the scan records of the given repository files
+	FindByRepoFileIDs(ctx context.Context, repoFileIDs []int64) ([]ModelFileScan, error)
+	// FindByPaths returns the scan records of the given file paths in a repository, newest first
+	FindByPaths(ctx context.Context, repoID int64, paths []string) ([]ModelFileScan, error)
+}
+
+func NewModelFileScanStore() ModelFileScanStore {
+	return &modelFileScanStoreImpl{
+		db: defaultDB,
+	}
+}
+
+func NewModelFileScanStoreWithDB(db *DB) ModelFileScanStore {
+	return &modelFileScanStoreImpl{
+		db: db,
+	}
+}
+
+// ModelFileScan is the security scan result of a model weight file
+type ModelFileScan struct {
+	ID           int64  `bun:",pk,autoincrement" json:"id"`
+	RepositoryID int64  `bun:",notnull" json:"repository_id"`
+	RepoFileID   int64  `bun:",notnull,unique" json:"repo_file_id"`
+	Path         string `bun:",notnull" json:"path"`
+	Branch       string `bun:",nullzero" json:"branch"`
+	// FileSHA is the git blob sha of the scanned file
+	FileSHA  string                       `bun:",nullzero" json:"file_sha"`
+	Status   types.ModelFileScanStatus    `bun:",notnull" json:"status"`
+	Format   types.ModelFileFormat        `bun:",notnull" json:"format"`
+	Message  string                       `bun:",nullzero" json:"message"`
+	Findings []types.ModelFileScanFinding `bun:",type:jsonb" json:"findings"`
+	times
+}
+
+func (s *modelFileScanStoreImpl) Upsert(ctx context.Context, scan *ModelFileScan) error {
+	_, err := s.db.Core.NewInsert().Model(scan).
+		On("CONFLICT (repo_file_id) DO UPDATE").
+		Set("status = EXCLUDED.status").
+		Set("format = EXCLUDED.format").
+		Set("message = EXCLUDED.message").
+		Set("findings = EXCLUDED.findings").
+		Set("updated_at = current_timestamp").
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to save model file scan, error: %w",
+			errorx.HandleDBError(err, errorx.Ctx().Set("repo_file_id", scan.RepoFileID)))
+	}
+	return nil
+}
+
+func (s *modelFileScanStoreImpl) FindByRepoFileIDs(ctx context.Context, repoFileIDs []int64) ([]ModelFileScan, error) {
+	var scans []ModelFileScan
+	if len(repoFileIDs) == 0 {
+		return scans, nil
+	}
+	err := s.db.Core.NewSelect().Model(&scans).
+		Where("repo_file_id IN (?)", bun.In(repoFileIDs)).
+		Scan(ctx)
+	if err != nil {
+		return nil, errorx.HandleDBError(err, nil)
+	}
+	return scans, nil
+}
+
+func (s *modelFileScanStoreImpl) FindByPaths(ctx context.Context, repoID int64, paths []string) ([]ModelFileScan, error) {
+	var scans []ModelFileScan
+	if len(paths) == 0 {
+		return scans, nil
+	}
+	err := s.db.Core.NewSelect().Model(&scans).
+		Where("repository_id = ?", repoID).
+		Where("path IN (?)", bun.In(paths)).
+		Order("id DESC").
+		Scan(ctx)
+	if err != nil {
+		return nil, errorx.HandleDBError(err, errorx.Ctx().Set("repository_id", repoID))
+	}
+	return scans, nil
+}
@@ -0,0 +1,408 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/uptrace/bun"
+	"opencsg.com/csghub-server/common/errorx"
+)
+
+type mqStoreImpl struct {
+	db *DB
+}
+
+// MQStore keeps the streams, consumers and messages of the message queue embedded in the database
+type MQStore interface {
+	// UpsertStream creates the stream or updates its topics and limits
+	UpsertStream(ctx context.Context, stream *MQStream) error
+	FindStreams(ctx context.Context) ([]MQStream, error)
+	// UpsertConsumer creates the durable consumer of a stream or updates its settings, a new consumer
+	// gets all the messages kept in the stream like the deliver all policy of nats
+	UpsertConsumer(ctx context.Context, consumer *MQConsumer) error
+	// CreateMessage appends the message to all the streams containing the topic and queues it
+	// for their consumers, the names of the streams are returned
+	CreateMessage(ctx context.Context, topic string, data []byte) ([]string, error)
+	// ClaimDeliveries takes the visible deliveries of the consumer, they stay invisible to the other
+	// instances of the consumer until the ack wait elapses
+	ClaimDeliveries(ctx context.Context, consumer *MQConsumer, limit int) ([]MQDelivery, error)
+	// DeleteDelivery acknowledges the delivery
+	DeleteDelivery(ctx context.Context, id int64) error
+	// DelayDelivery makes the delivery visible again after the delay
+	DelayDelivery(ctx context.Context, id int64, delay time.Duration) error
+	ListMessages(ctx context.Context, stream string, afterID int64, limit int) ([]MQMessage, error)
+	DeleteMessages(ctx context.Context, ids []int64) (int, error)
+	PurgeStream(ctx context.Context, stream string) (int, error)
+	// TrimStream removes the messages exceeding the max age or the max bytes of the stream,
+	// the default max age applies to the streams without one
+	TrimStream(ctx context.Context, stream *MQStream, defaultMaxAge time.Duration) (int, error)
+	Notify(ctx context.Context, channel, payload string) error
+}
+
+func NewMQStore() MQStore {
+	return &mqStoreImpl{
+		db: defaultDB,
+	}
+}
+
+func NewMQStoreWithDB(db *DB) MQStore {
+	return &mqStoreImpl{
+		db: db,
+	}
+}
+
+// MQStream groups the messages of some topics, messages are kept until they exceed the limits
+// no matter whether they are acknowledged
+type MQStream struct {
+	bun.BaseModel `bun:"table:mq_streams"`
+	ID            int64    `bun:",pk,autoincrement" json:"id"`
+	Name          string   `bun:",notnull,unique" json:"name"`
+	Topics        []string `bun:",notnull,type:jsonb" json:"topics"`
+	// MaxBytes is the total size of the messages kept in the stream, 0 means unlimited
+	MaxBytes int64 `bun:",notnull,default:0" json:"max_bytes"`
+	// MaxAgeSeconds 0 means the default retention of the message queue
+	MaxAgeSeconds int64 `bun:",notnull,default:0" json:"max_age_seconds"`
+	times
+}
+
+// MQConsumer is a durable consumer group of a stream, each message is delivered to one instance of the group
+type MQConsumer struct {
+	bun.BaseModel  `bun:"table:mq_consumers"`
+	ID             int64    `bun:",pk,autoincrement" json:"id"`
+	Stream         string   `bun:",notnull,unique:idx_mq_consumers_stream_name" json:"stream"`
+	Name           string   `bun:",notnull,unique:idx_mq_consumers_stream_name" json:"name"`
+	Topics         []string `bun:",notnull,type:jsonb" json:"topics"`
+	AckWaitSeconds int64    `bun:",notnull,default:0" json:"ack_wait_seconds"`
+	// MaxDeliver 0 means the message is redelivered until it's acknowledged
+	MaxDeliver int `bun:",notnull,default:0" json:"max_deliver"`
+	times
+}
+
+type MQMessage struct {
+	bun.BaseModel `bun:"table:mq_messages"`
+	ID            int64     `bun:",pk,autoincrement" json:"id"`
+	Stream        string    `bun:",notnull" json:"stream"`
+	Topic         string    `bun:",notnull" json:"topic"`
+	Data          []byte    `bun:",notnull,type:bytea" json:"data"`
+	CreatedAt     time.Time `bun:",nullzero,notnull,skipupdate,default:current_timestamp" json:"created_at"`
+}
+
+// MQDelivery is a message pending acknowledgement of a consumer, it's deleted once acknowledged
+type MQDelivery struct {
+	bun.BaseModel `bun:"table:mq_deliveries"`
+	ID            int64      `bun:",pk,autoincrement" json:"id"`
+	ConsumerID    int64      `bun:",notnull,unique:idx_mq_deliveries_consumer_message" json:"consumer_id"`
+	MessageID     int64      `bun:",notnull,unique:idx_mq_deliveries_consumer_message" json:"message_id"`
+	Message       *MQMessage `bun:"rel:belongs-to,join:message_id=id" json:"message"`
+	DeliverCount  int        `bun:",notnull,default:0" json:"deliver_count"`
+	VisibleAt     time.Time  `bun:",notnull,default:current_timestamp" json:"visible_at"`
+	CreatedAt     time.Time  `bun:",nullzero,notnull,skipupdate,default:current_timestamp" json:"created_at"`
+}
+
+func (s *mqStoreImpl) UpsertStream(ctx context.Context, stream *MQStream) error {
+	_, err := s.db.Operator.Core.NewInsert().
+		Model(stream).
+		On("CONFLICT (name) DO UPDATE").
+		Set("topics = EXCLUDED.topics").
+		Set("max_bytes = EXCLUDED.max_bytes").
+		Set("max_age_seconds = EXCLUDED.max_age_seconds").
+		Set("updated_at = current_timestamp").
+		Returning("id").
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to upsert mq stream %s, error: %w", stream.Name, errorx.HandleDBError(err, nil))
+	}
+	return nil
+}
+
+func (s *mqStoreImpl) FindStreams(ctx context.Context) ([]MQStream, error) {
+	var streams []MQStream
+	err := s.db.Operator.Core.NewSelect().
+		Model(&streams).
+		Order("id ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, errorx.HandleDBError(err, nil)
+	}
+	return streams, nil
+}
+
+func (s *mqStoreImpl) UpsertConsumer(ctx context.Context, consumer *MQConsumer) error {
+	err := s.db.Operator.Core.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		// lock the stream so that no message is published between creating the consumer and queueing the existing messages
+		var stream MQStream
+		err := tx.NewSelect().
+			Model(&stream).
+			Where("name = ?", consumer.Stream).
+			For("UPDATE").
+			Scan(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to lock mq stream %s, error: %w", consumer.Stream, err)
+		}
+
+		var exist MQConsumer
+		err = tx.NewSelect().
+			Model(&exist).
+			Where("stream = ? AND name = ?", consumer.Stream, consumer.Name).
+			Scan(ctx)
+		if err == nil {
+			consumer.ID = exist.ID
+			_, err = tx.NewUpdate().
+				Model(consumer).
+				Column("topics", "ack_wait_seconds", "max_deliver", "updated_at").
+				WherePK().
+				Exec(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to update mq consumer %s, error: %w", consumer.Name, err)
+			}
+			return nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("failed to find mq consumer %s, error: %w", consumer.Name, err)
+		}
+
+		_, err = tx.NewInsert().Model(consumer).Returning("id").Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to create mq consumer %s, error: %w", consumer.Name, err)
+		}
+		if len(consumer.Topics) == 0 {
+			return nil
+		}
+		_, err = tx.NewRaw(
+			"INSERT INTO mq_deliveries (consumer_id, message_id) SELECT ?, id FROM mq_messages WHERE stream = ? AND topic IN (?) ORDER BY id",
+			consumer.ID, consumer.Stream, bun.In(consumer.Topics),
+		).Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to queue existing messages for mq consumer %s, error: %w", consumer.Name, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return errorx.HandleDBError(err, nil)
+	}
+	return nil
+}
+
+func (s *mqStoreImpl) CreateMessage(ctx context.Context, topic string, data []byte) ([]string, error) {
+	topicJSON, err := json.Marshal([]string{topic})
+	if err != nil {
+		return nil, err
+	}
+	var streamNames []string
+	err = s.db.Operator.Core.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		var streams []MQStream
+		err := tx.NewSelect().
+			Model(&streams).
+			Where("topics @> ?::jsonb", string(topicJSON)).
+			Order("id ASC").
+			For("SHARE").
+			Scan(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to find mq streams of topic %s, error: %w", topic, err)
+		}
+		for _, stream := range streams {
+			message := &MQMessage{Stream: stream.Name, Topic: topic, Data: data}
+			_, err = tx.NewInsert().Model(message).Returning("id").Exec(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to create message of mq stream %s, error: %w", stream.Name, err)
+			}
+			_, err = tx.NewRaw(
+				"INSERT INTO mq_deliveries (consumer_id, message_id) SELECT id, ? FROM mq_consumers WHERE stream = ? AND topics @> ?::jsonb",
+				message.ID, stream.Name, string(topicJSON),
+			).Exec(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to queue message for consumers of mq stream %s, error: %w", stream.Name, err)
+			}
+			streamNames = append(streamNames, stream.Name)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errorx.HandleDBError(err, nil)
+	}
+	return streamNames, nil
+}
+
+func (s *mqStoreImpl) ClaimDeliveries(ctx context.Context, consumer *MQConsumer, limit int) ([]MQDelivery, error) {
+	var deliveries []MQDelivery
+	err := s.db.Operator.Core.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		query := tx.NewSelect().
+			Model(&deliveries).
+			Where("consumer_id = ?", consumer.ID).
+			Where("visible_at <= current_timestamp")
+		if consumer.MaxDeliver > 0 {
+			query = query.Where("deliver_count < ?", consumer.MaxDeliver)
+		}
+		err := query.Order("id ASC").
+			Limit(limit).
+			For("UPDATE SKIP LOCKED").
+			Scan(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to select deliveries of mq consumer %s, error: %w", consumer.Name, err)
+		}
+		if len(deliveries) == 0 {
+			return nil
+		}
+
+		ids := make([]int64, 0, len(deliveries))
+		messageIDs := make([]int64, 0, len(deliveries))
+		for _, delivery := range deliveries {
+			ids = append(ids, delivery.ID)
+			messageIDs = append(messageIDs, delivery.MessageID)
+		}
+		_, err = tx.NewUpdate().
+			Model((*MQDelivery)(nil)).
+			Set("deliver_count = deliver_count + 1").
+			Set("visible_at = current_timestamp + ? * interval '1 second'", consumer.AckWaitSeconds).
+			Where("id IN (?)", bun.In(ids)).
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to claim deliveries of mq consumer %s, error: %w", consumer.Name, err)
+		}
+
+		var messages []MQMessage
+		err = tx.NewSelect().
+			Model(&messages).
+			Where("id IN (?)", bun.In(messageIDs)).
+			Scan(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to select messages of mq consumer %s, error: %w", consumer.Name, err)
+		}
+		messageMap := make(map[int64]*MQMessage, len(messages))
+		for i := range messages {
+			messageMap[messages[i].ID] = &messages[i]
+		}
+		for i := range deliveries {
+			deliveries[i].DeliverCount++
+			deliveries[i].Message = messageMap[deliveries[i].MessageID]
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errorx.HandleDBError(err, nil)
+	}
+	return deliveries, nil
+}
+
+func (s *mqStoreImpl) DeleteDelivery(ctx context.Context, id int64) error {
+	_, err := s.db.Operator.Core.NewDelete().
+		Model((*MQDelivery)(nil)).
+		Where("id = ?", id).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to delete mq delivery %d, error: %w", id, errorx.HandleDBError(err, nil))
+	}
+	return nil
+}
+
+func (s *mqStoreImpl) DelayDelivery(ctx context.Context, id int64, delay time.Duration) error {
+	_, err := s.db.Operator.Core.NewUpdate().
+		Model((*MQDelivery)(nil)).
+		Set("visible_at = current_timestamp + ? * interval '1 millisecond'", delay.Milliseconds()).
+		Where("id = ?", id).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to delay mq delivery %d, error: %w", id, errorx.HandleDBError(err, nil))
+	}
+	return nil
+}
+
+func (s *mqStoreImpl) ListMessages(ctx context.Context, stream string, afterID int64, limit int) ([]MQMessage, error) {
+	var messages []MQMessage
+	err := s.db.Operator.Core.NewSelect().
+		Model(&messages).
+		Where("stream = ? AND id > ?", stream, afterID).
+		Order("id ASC").
+		Limit(limit).
+		Scan(ctx)
+	if err != nil {
+		return nil, errorx.HandleDBError(err, nil)
+	}
+	return messages, nil
+}
+
+func (s *mqStoreImpl) DeleteMessages(ctx context.Context, ids []int64) (int, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	return s.deleteMessagesIn(ctx, s.db.Operator.Core.NewSelect().
+		Model((*MQMessage)(nil)).
+		Column("id").
+		Where("id IN (?)", bun.In(ids)))
+}
+
+func (s *mqStoreImpl) PurgeStream(ctx context.Context, stream string) (int, error) {
+	return s.deleteMessagesIn(ctx, s.db.Operator.Core.NewSelect().
+		Model((*MQMessage)(nil)).
+		Column("id").
+		Where("stream = ?", stream))
+}
+
+func (s *mqStoreImpl) TrimStream(ctx context.Context, stream *MQStream, defaultMaxAge time.Duration) (int, error) {
+	maxAge := time.Duration(stream.MaxAgeSeconds) * time.Second
+	if maxAge <= 0 {
+		maxAge = defaultMaxAge
+	}
+	deleted := 0
+	if maxAge > 0 {
+		count, err := s.deleteMessagesIn(ctx, s.db.Operator.Core.NewSelect().
+			Model((*MQMessage)(nil)).
+			Column("id").
+			Where("stream = ?", stream.Name).
+			Where("created_at < current_timestamp - ? * interval '1 second'", int64(maxAge.Seconds())))
+		if err != nil {
+			return deleted, err
+		}
+		deleted += count
+	}
+	if stream.MaxBytes > 0 {
+		// the newest messages are kept until their total size reaches the limit
+		sizes := s.db.Operator.Core.NewSelect().
+			Model((*MQMessage)(nil)).
+			Column("id").
+			ColumnExpr("SUM(octet_length(data)) OVER (ORDER BY id DESC) AS total").
+			Where("stream = ?", stream.Name)
+		count, err := s.deleteMessagesIn(ctx, s.db.Operator.Core.NewSelect().
+			TableExpr("(?) AS sizes", sizes).
+			Column("id").
+			Where("total > ?", stream.MaxBytes))
+		if err != nil {
+			return deleted, err
+		}
+		deleted += count
+	}
+	return deleted, nil
+}
+
+// deleteMessagesIn deletes the messages selected by the query together with their pending deliveries,
+// both are deleted in one statement so that they are selected from the same snapshot
+func (s *mqStoreImpl) deleteMessagesIn(ctx context.Context, ids *bun.SelectQuery) (int, error) {
+	res, err := s.db.Operator.Core.NewDelete().
+		With("ids", ids).
+		With("deliveries", s.db.Operator.Core.NewDelete().
+			Model((*MQDelivery)(nil)).
+			Where("message_id IN (SELECT id FROM ids)")).
+		Model((*MQMessage)(nil)).
+		Where("id IN (SELECT id FROM ids)").
+		Exec(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete mq messages, error: %w", errorx.HandleDBError(err, nil))
+	}
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(deleted), nil
+}
+
+func (s *mqStoreImpl) Notify(ctx context.Context, channel, payload string) error {
+	_, err := s.db.Operator.Core.NewRaw("SELECT pg_notify(?, ?)", channel, payload).Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to notify channel %s, error: %w", channel, errorx.HandleDBError(err, nil))
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/tests"
+)
+
+func TestMQStore_PublishAndClaim(t *testing.T) {
+	db := tests.InitTestDB()
+	defer db.Close()
+	ctx := context.TODO()
+
+	store := database.NewMQStoreWithDB(db)
+	err := store.UpsertStream(ctx, &database.MQStream{Name: "s1", Topics: []string{"t.a", "t.b"}})
+	require.Nil(t, err)
+
+	streams, err := store.CreateMessage(ctx, "t.a", []byte("before"))
+	require.Nil(t, err)
+	require.Equal(t, []string{"s1"}, streams)
+	streams, err = store.CreateMessage(ctx, "t.c", []byte("nowhere"))
+	require.Nil(t, err)
+	require.Empty(t, streams)
+
+	// the new consumer gets the existing messages
+	consumer := &database.MQConsumer{Stream: "s1", Name: "c1", Topics: []string{"t.a"}, AckWaitSeconds: 30, MaxDeliver: 2}
+	err = store.UpsertConsumer(ctx, consumer)
+	require.Nil(t, err)
+	require.NotZero(t, consumer.ID)
+
+	_, err = store.CreateMessage(ctx, "t.a", []byte("after"))
+	require.Nil(t, err)
+	// the consumer doesn't subscribe the topic
+	_, err = store.CreateMessage(ctx, "t.b", []byte("skipped"))
+	require.Nil(t, err)
+
+	deliveries, err := store.ClaimDeliveries(ctx, consumer, 10)
+	require.Nil(t, err)
+	require.Equal(t, 2, len(deliveries))
+	require.Equal(t, "before", string(deliveries[0].Message.Data))
+	require.Equal(t, "after", string(deliveries[1].Message.Data))
+	require.Equal(t, 1, deliveries[0].DeliverCount)
+
+	// claimed deliveries are invisible until the ack wait elapses
+	claimed, err := store.ClaimDeliveries(ctx, consumer, 10)
+	require.Nil(t, err)
+	require.Empty(t, claimed)
+
+	err = store.DeleteDelivery(ctx, deliveries[0].ID)
+	require.Nil(t, err)
+	err = store.DelayDelivery(ctx, deliveries[1].ID, 0)
+	require.Nil(t, err)
+	claimed, err = store.ClaimDeliveries(ctx, consumer, 10)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(claimed))
+	require.Equal(t, deliveries[1].ID, claimed[0].ID)
+	require.Equal(t, 2, claimed[0].DeliverCount)
+
+	// no more deliveries after the max deliver
+	err = store.DelayDelivery(ctx, claimed[0].ID, 0)
+	require.Nil(t, err)
+	claimed, err = store.ClaimDeliveries(ctx, consumer, 10)
+	require.Nil(t, err)
+	require.Empty(t, claimed)
+
+	// resubscribing doesn't queue the acknowledged messages again
+	err = store.UpsertConsumer(ctx, &database.MQConsumer{Stream: "s1", Name: "c1", Topics: []string{"t.a"}, AckWaitSeconds: 30})
+	require.Nil(t, err)
+	claimed, err = store.ClaimDeliveries(ctx, consumer, 10)
+	require.Nil(t, err)
+	require.Empty(t, claimed)
+}
+
+func TestMQStore_DeleteAndTrim(t *testing.T) {
+	db := tests.InitTestDB()
+	defer db.Close()
+	ctx := context.TODO()
+
+	store := database.NewMQStoreWithDB(db)
+	stream := &database.MQStream{Name: "s2", Topics: []string{"t"}, MaxBytes: 6}
+	err := store.UpsertStream(ctx, stream)
+	require.Nil(t, err)
+	consumer := &database.MQConsumer{Stream: "s2", Name: "c2", Topics: []string{"t"}, AckWaitSeconds: 30}
+	err = store.UpsertConsumer(ctx, consumer)
+	require.Nil(t, err)
+	for _, data := range []string{"aaa", "bbb", "ccc", "ddd"} {
+		_, err = store.CreateMessage(ctx, "t", []byte(data))
+		require.Nil(t, err)
+	}
+
+	messages, err := store.ListMessages(ctx, "s2", 0, 2)
+	require.Nil(t, err)
+	require.Equal(t, 2, len(messages))
+	count, err := store.DeleteMessages(ctx, []int64{messages[0].ID})
+	require.Nil(t, err)
+	require.Equal(t, 1, count)
+
+	// only the newest 6 bytes are kept
+	count, err = store.TrimStream(ctx, stream, time.Hour)
+	require.Nil(t, err)
+	require.Equal(t, 1, count)
+	messages, err = store.ListMessages(ctx, "s2", 0, 10)
+	require.Nil(t, err)
+	require.Equal(t, 2, len(messages))
+	require.Equal(t, "ccc", string(messages[0].Data))
+
+	// deliveries of the deleted messages are deleted too
+	deliveries, err := store.ClaimDeliveries(ctx, consumer, 10)
+	require.Nil(t, err)
+	require.Equal(t, 2, len(deliveries))
+
+	count, err = store.PurgeStream(ctx, "s2")
+	require.Nil(t, err)
+	require.Equal(t, 2, count)
+
+	err = store.Notify(ctx, "csghub_mq", "s2")
+	require.Nil(t, err)
+}
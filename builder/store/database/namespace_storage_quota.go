@@ -0,0 +1,128 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"opencsg.com/csghub-server/common/errorx"
+)
+
+type namespaceStorageQuotaStoreImpl struct {
+	db *DB
+}
+
+type NamespaceStorageQuotaStore interface {
+	FindByNamespace(ctx context.Context, namespace string) (*NamespaceStorageQuota, error)
+	// SetQuota overrides the quota of the namespace, a nil quota falls back to the default one
+	SetQuota(ctx context.Context, namespace string, quotaBytes *int64) (*NamespaceStorageQuota, error)
+	// UpdateUsage saves the usage counters and the warned percent of the namespace
+	UpdateUsage(ctx context.Context, quota *NamespaceStorageQuota) error
+	// CalculateUsage sums the git and LFS bytes of all repositories in the namespace
+	CalculateUsage(ctx context.Context, namespace string) (gitBytes int64, lfsBytes int64, err error)
+}
+
+func NewNamespaceStorageQuotaStore() NamespaceStorageQuotaStore {
+	return &namespaceStorageQuotaStoreImpl{
+		db: defaultDB,
+	}
+}
+
+func NewNamespaceStorageQuotaStoreWithDB(db *DB) NamespaceStorageQuotaStore {
+	return &namespaceStorageQuotaStoreImpl{
+		db: db,
+	}
+}
+
+// NamespaceStorageQuota keeps the storage usage of a user or organization namespace and its quota override
+type NamespaceStorageQuota struct {
+	ID        int64  `bun:",pk,autoincrement" json:"id"`
+	Namespace string `bun:",notnull,unique" json:"namespace"`
+	// QuotaBytes is set by admins, nil means the default quota of the namespace type, 0 means unlimited
+	QuotaBytes *int64 `bun:"," json:"quota_bytes"`
+	GitBytes   int64  `bun:",notnull,default:0" json:"git_bytes"`
+	LfsBytes   int64  `bun:",notnull,default:0" json:"lfs_bytes"`
+	// WarnedPercent is the highest usage warning sent since the usage was last below it
+	WarnedPercent int `bun:",notnull,default:0" json:"warned_percent"`
+	times
+}
+
+func (q *NamespaceStorageQuota) UsedBytes() int64 {
+	return q.GitBytes + q.LfsBytes
+}
+
+func (s *namespaceStorageQuotaStoreImpl) FindByNamespace(ctx context.Context, namespace string) (*NamespaceStorageQuota, error) {
+	quota := &NamespaceStorageQuota{}
+	err := s.db.Core.NewSelect().Model(quota).Where("namespace = ?", namespace).Scan(ctx)
+	if err != nil {
+		return nil, errorx.HandleDBError(err, errorx.Ctx().Set("namespace", namespace))
+	}
+	return quota, nil
+}
+
+func (s *namespaceStorageQuotaStoreImpl) SetQuota(ctx context.Context, namespace string, quotaBytes *int64) (*NamespaceStorageQuota, error) {
+	quota := &NamespaceStorageQuota{
+		Namespace:  namespace,
+		QuotaBytes: quotaBytes,
+	}
+	_, err := s.db.Core.NewInsert().
+		Model(quota).
+		On("CONFLICT (namespace) DO UPDATE").
+		Set("quota_bytes = EXCLUDED.quota_bytes").
+		Set("updated_at = ?", time.Now()).
+		Returning("*").
+		Exec(ctx, quota)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set storage quota, error: %w",
+			errorx.HandleDBError(err, errorx.Ctx().Set("namespace", namespace)))
+	}
+	return quota, nil
+}
+
+func (s *namespaceStorageQuotaStoreImpl) UpdateUsage(ctx context.Context, quota *NamespaceStorageQuota) error {
+	_, err := s.db.Core.NewInsert().
+		Model(quota).
+		On("CONFLICT (namespace) DO UPDATE").
+		Set("git_bytes = EXCLUDED.git_bytes").
+		Set("lfs_bytes = EXCLUDED.lfs_bytes").
+		Set("warned_percent = EXCLUDED.warned_percent").
+		Set("updated_at = ?", time.Now()).
+		Returning("*").
+		Exec(ctx, quota)
+	if err != nil {
+		return fmt.Errorf("failed to update storage usage, error: %w",
+			errorx.HandleDBError(err, errorx.Ctx().Set("namespace", quota.Namespace)))
+	}
+	return nil
+}
+
+func (s *namespaceStorageQuotaStoreImpl) CalculateUsage(ctx context.Context, namespace string) (int64, int64, error) {
+	var gitBytes, lfsBytes int64
+	// branches share most of their objects, so the largest branch is the closest estimate of the git storage
+	repoGitSize := s.db.Core.NewSelect().
+		TableExpr("repository_statistics AS rs").
+		Join("JOIN repositories AS r ON r.id = rs.repository_id").
+		ColumnExpr("MAX(rs.non_lfs_size) AS size").
+		Where("r.path LIKE ? ESCAPE '\\'", fmt.Sprintf("%s/%%", escapeLikePattern(namespace))).
+		Where("r.deleted_at IS NULL").
+		Group("rs.repository_id")
+	err := s.db.Core.NewSelect().
+		TableExpr("(?) AS s", repoGitSize).
+		ColumnExpr("COALESCE(SUM(s.size), 0)").
+		Scan(ctx, &gitBytes)
+	if err != nil {
+		return 0, 0, errorx.HandleDBError(err, errorx.Ctx().Set("namespace", namespace))
+	}
+
+	err = s.db.Core.NewSelect().
+		TableExpr("lfs_meta_objects AS l").
+		Join("JOIN repositories AS r ON r.id = l.repository_id").
+		ColumnExpr("COALESCE(SUM(l.size), 0)").
+		Where("r.path LIKE ? ESCAPE '\\'", fmt.Sprintf("%s/%%", escapeLikePattern(namespace))).
+		Where("r.deleted_at IS NULL").
+		Scan(ctx, &lfsBytes)
+	if err != nil {
+		return 0, 0, errorx.HandleDBError(err, errorx.Ctx().Set("namespace", namespace))
+	}
+	return gitBytes, lfsBytes, nil
+}
@@ -0,0 +1,86 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/tests"
+)
+
+func TestNamespaceStorageQuotaStore_QuotaAndUsage(t *testing.T) {
+	db := tests.InitTestDB()
+	defer db.Close()
+	ctx := context.TODO()
+
+	store := database.NewNamespaceStorageQuotaStoreWithDB(db)
+
+	_, err := store.FindByNamespace(ctx, "ns")
+	require.ErrorIs(t, err, errorx.ErrDatabaseNoRows)
+
+	err = store.UpdateUsage(ctx, &database.NamespaceStorageQuota{
+		Namespace: "ns", GitBytes: 10, LfsBytes: 20, WarnedPercent: 80,
+	})
+	require.Nil(t, err)
+
+	quotaBytes := int64(100)
+	quota, err := store.SetQuota(ctx, "ns", &quotaBytes)
+	require.Nil(t, err)
+	require.Equal(t, int64(100), *quota.QuotaBytes)
+	// usage is kept when the quota changes
+	require.Equal(t, int64(30), quota.UsedBytes())
+
+	err = store.UpdateUsage(ctx, &database.NamespaceStorageQuota{Namespace: "ns", GitBytes: 5, LfsBytes: 5})
+	require.Nil(t, err)
+	quota, err = store.FindByNamespace(ctx, "ns")
+	require.Nil(t, err)
+	require.Equal(t, int64(100), *quota.QuotaBytes)
+	require.Equal(t, int64(10), quota.UsedBytes())
+	require.Equal(t, 0, quota.WarnedPercent)
+
+	quota, err = store.SetQuota(ctx, "ns", nil)
+	require.Nil(t, err)
+	require.Nil(t, quota.QuotaBytes)
+}
+
+func TestNamespaceStorageQuotaStore_CalculateUsage(t *testing.T) {
+	db := tests.InitTestDB()
+	defer db.Close()
+	ctx := context.TODO()
+
+	repoStore := database.NewRepoStoreWithDB(db)
+	repo1, err := repoStore.CreateRepo(ctx, database.Repository{UserID: 1, Path: "ns/r1", GitPath: "models_ns/r1", Name: "r1"})
+	require.Nil(t, err)
+	repo2, err := repoStore.CreateRepo(ctx, database.Repository{UserID: 1, Path: "ns/r2", GitPath: "models_ns/r2", Name: "r2"})
+	require.Nil(t, err)
+	other, err := repoStore.CreateRepo(ctx, database.Repository{UserID: 1, Path: "ns2/r1", GitPath: "models_ns2/r1", Name: "r1"})
+	require.Nil(t, err)
+
+	statsStore := database.NewRepositoryStatisticsStoreWithDB(db)
+	for _, stats := range []*database.RepositoryStatistics{
+		{RepositoryID: repo1.ID, Branch: "main", NonLfsSize: 100},
+		{RepositoryID: repo1.ID, Branch: "dev", NonLfsSize: 150},
+		{RepositoryID: repo2.ID, Branch: "main", NonLfsSize: 50},
+		{RepositoryID: other.ID, Branch: "main", NonLfsSize: 1000},
+	} {
+		require.Nil(t, statsStore.Create(ctx, stats))
+	}
+
+	lfsStore := database.NewLfsMetaObjectStoreWithDB(db)
+	for _, obj := range []database.LfsMetaObject{
+		{RepositoryID: repo1.ID, Oid: "a", Size: 1000},
+		{RepositoryID: repo2.ID, Oid: "b", Size: 2000},
+		{RepositoryID: other.ID, Oid: "c", Size: 4000},
+	} {
+		_, err = lfsStore.Create(ctx, obj)
+		require.Nil(t, err)
+	}
+
+	store := database.NewNamespaceStorageQuotaStoreWithDB(db)
+	gitBytes, lfsBytes, err := store.CalculateUsage(ctx, "ns")
+	require.Nil(t, err)
+	require.Equal(t, int64(200), gitBytes)
+	require.Equal(t, int64(3000), lfsBytes)
+}
@@ -3,8 +3,11 @@ package database
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/uptrace/bun"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
 )
 
 type recomStoreImpl struct {
@@ -18,6 +21,30 @@ type RecomStore interface {
 	LoadRepoOpWeights(ctx context.Context, repoIDs []int64) (map[int64]int, error)
 	UpsetOpWeights(ctx context.Context, repoID, weight int64) error
 	FindByRepoIDs(ctx context.Context, repoIDs []int64) ([]*RecomRepoScore, error)
+	// BatchGetLikeBaskets returns the public repositories liked by each user, the users are paged by user id
+	BatchGetLikeBaskets(ctx context.Context, lastUserID int64, batch int) ([]RecomBasket, error)
+	// BatchGetCollectionBaskets returns the public repositories of each public collection, the collections
+	// are paged by collection id
+	BatchGetCollectionBaskets(ctx context.Context, lastCollectionID int64, batch int) ([]RecomBasket, error)
+	// RepoDownloadsSince returns the download count of each repository since the given date
+	RepoDownloadsSince(ctx context.Context, since time.Time) (map[int64]int64, error)
+	UpsertRelatedRepos(ctx context.Context, items []*RecomRelatedRepo) error
+	// DeleteRelatedReposBefore deletes the related repositories not updated since the given time
+	DeleteRelatedReposBefore(ctx context.Context, t time.Time) error
+	// FindRelatedRepos returns the public related repositories of the repository in the score order
+	FindRelatedRepos(ctx context.Context, repoID int64, limit int) ([]*RecomRelatedRepo, error)
+	// RecommendForRepos sums the scores of the public repositories related to the given repositories, the
+	// given repositories are excluded from the result
+	RecommendForRepos(ctx context.Context, repoIDs []int64, repoType types.RepositoryType, limit int) ([]*RecomRelatedRepo, error)
+	// TopScoredRepos returns the public repositories with the highest total recommendation scores
+	TopScoredRepos(ctx context.Context, repoType types.RepositoryType, limit int) ([]*RecomRepoScore, error)
+}
+
+// RecomBasket is a group of repositories used together, like the repositories liked by a user or the
+// repositories of a collection
+type RecomBasket struct {
+	ID      int64
+	RepoIDs []int64
 }
 
 func NewRecomStore() RecomStore {
@@ -116,3 +143,177 @@ func (s *recomStoreImpl) FindByRepoIDs(ctx context.Context, repoIDs []int64) ([]
 		Scan(ctx, &items)
 	return items, err
 }
+
+func (s *recomStoreImpl) BatchGetLikeBaskets(ctx context.Context, lastUserID int64, batch int) ([]RecomBasket, error) {
+	var userIDs []int64
+	err := s.db.Operator.Core.NewSelect().Model((*UserLike)(nil)).
+		ColumnExpr("DISTINCT user_id").
+		Where("user_id > ? AND repo_id > 0", lastUserID).
+		Order("user_id ASC").
+		Limit(batch).
+		Scan(ctx, &userIDs)
+	if err != nil {
+		return nil, errorx.HandleDBError(err, errorx.Ctx().Set("last_user_id", lastUserID))
+	}
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+	var rows []recomBasketRow
+	err = s.db.Operator.Core.NewSelect().Model((*UserLike)(nil)).
+		ColumnExpr("user_like.user_id AS id, user_like.repo_id").
+		Join("JOIN repositories AS r ON r.id = user_like.repo_id").
+		Where("user_like.user_id IN (?)", bun.In(userIDs)).
+		Where("r.private = ?", false).
+		Order("user_like.user_id ASC", "user_like.id ASC").
+		Scan(ctx, &rows)
+	if err != nil {
+		return nil, errorx.HandleDBError(err, errorx.Ctx().Set("last_user_id", lastUserID))
+	}
+	return groupRecomBaskets(userIDs, rows), nil
+}
+
+func (s *recomStoreImpl) BatchGetCollectionBaskets(ctx context.Context, lastCollectionID int64, batch int) ([]RecomBasket, error) {
+	var collectionIDs []int64
+	err := s.db.Operator.Core.NewSelect().Model((*Collection)(nil)).
+		Column("id").
+		Where("id > ? AND private = ?", lastCollectionID, false).
+		Order("id ASC").
+		Limit(batch).
+		Scan(ctx, &collectionIDs)
+	if err != nil {
+		return nil, errorx.HandleDBError(err, errorx.Ctx().Set("last_collection_id", lastCollectionID))
+	}
+	if len(collectionIDs) == 0 {
+		return nil, nil
+	}
+	var rows []recomBasketRow
+	err = s.db.Operator.Core.NewSelect().Model((*CollectionRepository)(nil)).
+		ColumnExpr("collection_repository.collection_id AS id, collection_repository.repository_id AS repo_id").
+		Join("JOIN repositories AS r ON r.id = collection_repository.repository_id").
+		Where("collection_repository.collection_id IN (?)", bun.In(collectionIDs)).
+		Where("r.private = ?", false).
+		Order("collection_repository.collection_id ASC", "collection_repository.repository_id ASC").
+		Scan(ctx, &rows)
+	if err != nil {
+		return nil, errorx.HandleDBError(err, errorx.Ctx().Set("last_collection_id", lastCollectionID))
+	}
+	return groupRecomBaskets(collectionIDs, rows), nil
+}
+
+type recomBasketRow struct {
+	ID     int64 `bun:"id"`
+	RepoID int64 `bun:"repo_id"`
+}
+
+// groupRecomBaskets groups the rows into the baskets, both the ids and the rows are in the id order
+func groupRecomBaskets(ids []int64, rows []recomBasketRow) []RecomBasket {
+	baskets := make([]RecomBasket, 0, len(ids))
+	for _, id := range ids {
+		baskets = append(baskets, RecomBasket{ID: id})
+	}
+	index := 0
+	for _, row := range rows {
+		for baskets[index].ID != row.ID {
+			index++
+		}
+		baskets[index].RepoIDs = append(baskets[index].RepoIDs, row.RepoID)
+	}
+	return baskets
+}
+
+func (s *recomStoreImpl) RepoDownloadsSince(ctx context.Context, since time.Time) (map[int64]int64, error) {
+	var rows []struct {
+		RepositoryID int64 `bun:"repository_id"`
+		Count        int64 `bun:"count"`
+	}
+	err := s.db.Operator.Core.NewSelect().Model((*RepositoryDownload)(nil)).
+		ColumnExpr("repository_id, SUM(clone_count + click_download_count) AS count").
+		Where("date >= ?", since).
+		Group("repository_id").
+		Scan(ctx, &rows)
+	if err != nil {
+		return nil, errorx.HandleDBError(err, errorx.Ctx().Set("since", since))
+	}
+	downloads := make(map[int64]int64, len(rows))
+	for _, row := range rows {
+		downloads[row.RepositoryID] = row.Count
+	}
+	return downloads, nil
+}
+
+func (s *recomStoreImpl) UpsertRelatedRepos(ctx context.Context, items []*RecomRelatedRepo) error {
+	if len(items) == 0 {
+		return nil
+	}
+	_, err := s.db.Operator.Core.NewInsert().
+		Model(&items).
+		On("CONFLICT (repository_id, related_repository_id) DO UPDATE").
+		Set("score = EXCLUDED.score").
+		Set("updated_at = EXCLUDED.updated_at").
+		Exec(ctx)
+	return errorx.HandleDBError(err, nil)
+}
+
+func (s *recomStoreImpl) DeleteRelatedReposBefore(ctx context.Context, t time.Time) error {
+	_, err := s.db.Operator.Core.NewDelete().Model((*RecomRelatedRepo)(nil)).
+		Where("updated_at < ?", t).
+		Exec(ctx)
+	return errorx.HandleDBError(err, nil)
+}
+
+func (s *recomStoreImpl) FindRelatedRepos(ctx context.Context, repoID int64, limit int) ([]*RecomRelatedRepo, error) {
+	items := make([]*RecomRelatedRepo, 0)
+	err := s.db.Operator.Core.NewSelect().Model(&items).
+		Join("JOIN repositories AS r ON r.id = recom_related_repo.related_repository_id").
+		Where("recom_related_repo.repository_id = ?", repoID).
+		Where("r.private = ?", false).
+		Order("recom_related_repo.score DESC", "recom_related_repo.related_repository_id ASC").
+		Limit(limit).
+		Scan(ctx)
+	if err != nil {
+		return nil, errorx.HandleDBError(err, errorx.Ctx().Set("repo_id", repoID))
+	}
+	return items, nil
+}
+
+func (s *recomStoreImpl) RecommendForRepos(ctx context.Context, repoIDs []int64, repoType types.RepositoryType, limit int) ([]*RecomRelatedRepo, error) {
+	items := make([]*RecomRelatedRepo, 0)
+	if len(repoIDs) == 0 {
+		return items, nil
+	}
+	q := s.db.Operator.Core.NewSelect().Model((*RecomRelatedRepo)(nil)).
+		ColumnExpr("recom_related_repo.related_repository_id, SUM(recom_related_repo.score) AS score").
+		Join("JOIN repositories AS r ON r.id = recom_related_repo.related_repository_id").
+		Where("recom_related_repo.repository_id IN (?)", bun.In(repoIDs)).
+		Where("recom_related_repo.related_repository_id NOT IN (?)", bun.In(repoIDs)).
+		Where("r.private = ?", false)
+	if repoType != "" {
+		q.Where("r.repository_type = ?", repoType)
+	}
+	err := q.Group("recom_related_repo.related_repository_id").
+		Order("score DESC", "recom_related_repo.related_repository_id ASC").
+		Limit(limit).
+		Scan(ctx, &items)
+	if err != nil {
+		return nil, errorx.HandleDBError(err, errorx.Ctx().Set("repo_ids", repoIDs))
+	}
+	return items, nil
+}
+
+func (s *recomStoreImpl) TopScoredRepos(ctx context.Context, repoType types.RepositoryType, limit int) ([]*RecomRepoScore, error) {
+	items := make([]*RecomRepoScore, 0)
+	q := s.db.Operator.Core.NewSelect().Model(&items).
+		Join("JOIN repositories AS r ON r.id = recom_repo_score.repository_id").
+		Where("recom_repo_score.weight_name = ?", RecomWeightTotal).
+		Where("r.private = ?", false)
+	if repoType != "" {
+		q.Where("r.repository_type = ?", repoType)
+	}
+	err := q.Order("recom_repo_score.score DESC", "recom_repo_score.repository_id ASC").
+		Limit(limit).
+		Scan(ctx)
+	if err != nil {
+		return nil, errorx.HandleDBError(err, errorx.Ctx().Set("repo_type", repoType))
+	}
+	return items, nil
+}
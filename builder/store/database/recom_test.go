@@ -4,10 +4,12 @@ import (
 	"context"
 	"slices"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"opencsg.com/csghub-server/builder/store/database"
 	"opencsg.com/csghub-server/common/tests"
+	"opencsg.com/csghub-server/common/types"
 )
 
 func TestRecomStore_All(t *testing.T) {
@@ -148,3 +150,99 @@ func TestRecomStore_UpsetOpWeights(t *testing.T) {
 		require.True(t, ok)
 	})
 }
+
+func TestRecomStore_RelatedRepos(t *testing.T) {
+	db := tests.InitTestDB()
+	defer db.Close()
+	ctx := context.TODO()
+
+	store := database.NewRecomStoreWithDB(db)
+
+	repos := []*database.Repository{
+		{Path: "ns/a", GitPath: "ns/a", Name: "a", RepositoryType: types.ModelRepo},
+		{Path: "ns/b", GitPath: "ns/b", Name: "b", RepositoryType: types.DatasetRepo},
+		{Path: "ns/c", GitPath: "ns/c", Name: "c", RepositoryType: types.ModelRepo, Private: true},
+	}
+	for _, repo := range repos {
+		err := db.Core.NewInsert().Model(repo).Scan(ctx, repo)
+		require.Nil(t, err)
+	}
+	a, b, c := repos[0].ID, repos[1].ID, repos[2].ID
+	for _, like := range []*database.UserLike{
+		{UserID: 1, RepoID: a}, {UserID: 1, RepoID: b}, {UserID: 2, RepoID: c}, {UserID: 3, RepoID: b},
+	} {
+		_, err := db.Core.NewInsert().Model(like).Exec(ctx)
+		require.Nil(t, err)
+	}
+	collections := []*database.Collection{{Name: "public"}, {Name: "private", Private: true}}
+	for _, collection := range collections {
+		err := db.Core.NewInsert().Model(collection).Scan(ctx, collection)
+		require.Nil(t, err)
+		_, err = db.Core.NewInsert().Model(&database.CollectionRepository{CollectionID: collection.ID, RepositoryID: a}).Exec(ctx)
+		require.Nil(t, err)
+	}
+
+	baskets, err := store.BatchGetLikeBaskets(ctx, 0, 2)
+	require.Nil(t, err)
+	// the private repo liked by user 2 is excluded
+	require.Equal(t, []database.RecomBasket{{ID: 1, RepoIDs: []int64{a, b}}, {ID: 2}}, baskets)
+	baskets, err = store.BatchGetLikeBaskets(ctx, 2, 2)
+	require.Nil(t, err)
+	require.Equal(t, []database.RecomBasket{{ID: 3, RepoIDs: []int64{b}}}, baskets)
+
+	baskets, err = store.BatchGetCollectionBaskets(ctx, 0, 10)
+	require.Nil(t, err)
+	require.Equal(t, []database.RecomBasket{{ID: collections[0].ID, RepoIDs: []int64{a}}}, baskets)
+
+	now := time.Now().Truncate(time.Second)
+	_, err = db.Core.NewInsert().Model(&database.RepositoryDownload{RepositoryID: a, Date: now, CloneCount: 2, ClickDownloadCount: 3}).Exec(ctx)
+	require.Nil(t, err)
+	downloads, err := store.RepoDownloadsSince(ctx, now.AddDate(0, 0, -1))
+	require.Nil(t, err)
+	require.Equal(t, map[int64]int64{a: 5}, downloads)
+
+	related := func(repoID, relatedRepoID int64, score float64, updatedAt time.Time) *database.RecomRelatedRepo {
+		item := &database.RecomRelatedRepo{RepositoryID: repoID, RelatedRepositoryID: relatedRepoID, Score: score}
+		item.UpdatedAt = updatedAt
+		return item
+	}
+	err = store.UpsertRelatedRepos(ctx, []*database.RecomRelatedRepo{
+		related(a, b, 0.5, now.Add(-time.Hour)),
+		related(a, c, 0.9, now.Add(-time.Hour)),
+		related(b, a, 0.5, now.Add(-time.Hour)),
+	})
+	require.Nil(t, err)
+	err = store.UpsertRelatedRepos(ctx, []*database.RecomRelatedRepo{
+		related(a, b, 0.6, now),
+		related(a, c, 0.9, now),
+	})
+	require.Nil(t, err)
+	require.Nil(t, store.DeleteRelatedReposBefore(ctx, now))
+
+	items, err := store.FindRelatedRepos(ctx, a, 10)
+	require.Nil(t, err)
+	require.Len(t, items, 1)
+	require.Equal(t, b, items[0].RelatedRepositoryID)
+	require.Equal(t, 0.6, items[0].Score)
+	items, err = store.FindRelatedRepos(ctx, b, 10)
+	require.Nil(t, err)
+	require.Empty(t, items)
+
+	items, err = store.RecommendForRepos(ctx, []int64{a}, types.DatasetRepo, 10)
+	require.Nil(t, err)
+	require.Len(t, items, 1)
+	require.Equal(t, b, items[0].RelatedRepositoryID)
+	items, err = store.RecommendForRepos(ctx, []int64{a}, types.ModelRepo, 10)
+	require.Nil(t, err)
+	require.Empty(t, items)
+
+	err = store.UpsertScore(ctx, []*database.RecomRepoScore{
+		{RepositoryID: a, WeightName: database.RecomWeightTotal, Score: 1},
+		{RepositoryID: c, WeightName: database.RecomWeightTotal, Score: 10},
+	})
+	require.Nil(t, err)
+	top, err := store.TopScoredRepos(ctx, "", 10)
+	require.Nil(t, err)
+	require.Len(t, top, 1)
+	require.Equal(t, a, top[0].RepositoryID)
+}
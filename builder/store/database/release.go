@@ -0,0 +1,190 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+)
+
+// Release is a published version of the repository pointing to a git tag, at most one release of a
+// repository is the latest
+type Release struct {
+	ID               int64    `bun:",pk,autoincrement" json:"id"`
+	RepositoryID     int64    `bun:",notnull" json:"repository_id"`
+	Name             string   `bun:",notnull" json:"name"`
+	TagName          string   `bun:",notnull" json:"tag_name"`
+	Title            string   `bun:"," json:"title"`
+	Changelog        string   `bun:",type:text" json:"changelog"`
+	RecommendedFiles []string `bun:",type:jsonb,nullzero" json:"recommended_files"`
+	Deprecated       bool     `bun:",notnull" json:"deprecated"`
+	DeprecationNote  string   `bun:"," json:"deprecation_note"`
+	Latest           bool     `bun:",notnull" json:"latest"`
+	AuthorID         int64    `bun:",notnull" json:"author_id"`
+	Author           *User    `bun:"rel:belongs-to,join:author_id=id" json:"author,omitempty"`
+	times
+}
+
+type releaseStoreImpl struct {
+	db *DB
+}
+
+type ReleaseStore interface {
+	// Create saves the release, the other releases of the repository are not latest any more if the
+	// release is latest
+	Create(ctx context.Context, release *Release) error
+	// Update saves the release, the other releases of the repository are not latest any more if the
+	// release is latest
+	Update(ctx context.Context, release *Release) error
+	// Delete removes the release, the newest remaining release becomes the latest if the removed one was
+	Delete(ctx context.Context, repoID int64, name string) error
+	FindByName(ctx context.Context, repoID int64, name string) (*Release, error)
+	// FindByRef returns the release of the repository by the release name, or the latest release if the ref
+	// is types.LatestReleaseRef
+	FindByRef(ctx context.Context, repoType types.RepositoryType, repoPath, ref string) (*Release, error)
+	// ListByRepoID returns the releases of the repository, the newest first
+	ListByRepoID(ctx context.Context, repoID int64, per, page int) ([]Release, int, error)
+	CountByRepoID(ctx context.Context, repoID int64) (int, error)
+}
+
+func NewReleaseStore() ReleaseStore {
+	return &releaseStoreImpl{
+		db: defaultDB,
+	}
+}
+
+func NewReleaseStoreWithDB(db *DB) ReleaseStore {
+	return &releaseStoreImpl{
+		db: db,
+	}
+}
+
+func unsetLatestRelease(ctx context.Context, tx bun.Tx, repoID, keepID int64) error {
+	_, err := tx.NewUpdate().
+		Model((*Release)(nil)).
+		Set("latest = ?", false).
+		Where("repository_id = ?", repoID).
+		Where("id != ?", keepID).
+		Where("latest = ?", true).
+		Exec(ctx)
+	return err
+}
+
+func (s *releaseStoreImpl) Create(ctx context.Context, release *Release) error {
+	err := s.db.Operator.Core.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if release.Latest {
+			if err := unsetLatestRelease(ctx, tx, release.RepositoryID, 0); err != nil {
+				return fmt.Errorf("failed to unset latest release: %w", err)
+			}
+		}
+		_, err := tx.NewInsert().Model(release).Exec(ctx)
+		return err
+	})
+	return errorx.HandleDBError(err, errorx.Ctx().Set("release", release.Name))
+}
+
+func (s *releaseStoreImpl) Update(ctx context.Context, release *Release) error {
+	err := s.db.Operator.Core.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if release.Latest {
+			if err := unsetLatestRelease(ctx, tx, release.RepositoryID, release.ID); err != nil {
+				return fmt.Errorf("failed to unset latest release: %w", err)
+			}
+		}
+		_, err := tx.NewUpdate().Model(release).WherePK().ExcludeColumn("created_at").Exec(ctx)
+		return err
+	})
+	return errorx.HandleDBError(err, errorx.Ctx().Set("release", release.Name))
+}
+
+func (s *releaseStoreImpl) Delete(ctx context.Context, repoID int64, name string) error {
+	err := s.db.Operator.Core.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		var deleted Release
+		_, err := tx.NewDelete().
+			Model(&deleted).
+			Where("repository_id = ?", repoID).
+			Where("name = ?", name).
+			Returning("*").
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+		if !deleted.Latest {
+			return nil
+		}
+		newest := tx.NewSelect().
+			Model((*Release)(nil)).
+			Column("id").
+			Where("repository_id = ?", repoID).
+			Order("id DESC").
+			Limit(1)
+		_, err = tx.NewUpdate().
+			Model((*Release)(nil)).
+			Set("latest = ?", true).
+			Where("id IN (?)", newest).
+			Exec(ctx)
+		return err
+	})
+	return errorx.HandleDBError(err, errorx.Ctx().Set("release", name))
+}
+
+func (s *releaseStoreImpl) FindByName(ctx context.Context, repoID int64, name string) (*Release, error) {
+	var release Release
+	err := s.db.Operator.Core.NewSelect().
+		Model(&release).
+		Relation("Author").
+		Where("release.repository_id = ?", repoID).
+		Where("release.name = ?", name).
+		Scan(ctx)
+	if err != nil {
+		return nil, errorx.HandleDBError(err, errorx.Ctx().Set("release", name))
+	}
+	return &release, nil
+}
+
+func (s *releaseStoreImpl) FindByRef(ctx context.Context, repoType types.RepositoryType, repoPath, ref string) (*Release, error) {
+	var release Release
+	q := s.db.Operator.Core.NewSelect().
+		Model(&release).
+		Join("JOIN repositories AS r ON r.id = release.repository_id").
+		Where("r.repository_type = ?", repoType).
+		Where("LOWER(r.path) = LOWER(?)", repoPath)
+	if ref == types.LatestReleaseRef {
+		q = q.Where("release.latest = ?", true)
+	} else {
+		q = q.Where("release.name = ?", ref)
+	}
+	err := q.Limit(1).Scan(ctx)
+	if err != nil {
+		return nil, errorx.HandleDBError(err, errorx.Ctx().Set("release", ref))
+	}
+	return &release, nil
+}
+
+func (s *releaseStoreImpl) ListByRepoID(ctx context.Context, repoID int64, per, page int) ([]Release, int, error) {
+	var releases []Release
+	count, err := s.db.Operator.Core.NewSelect().
+		Model(&releases).
+		Relation("Author").
+		Where("release.repository_id = ?", repoID).
+		Order("release.id DESC").
+		Limit(per).
+		Offset((page - 1) * per).
+		ScanAndCount(ctx)
+	if err != nil {
+		return nil, 0, errorx.HandleDBError(err, errorx.Ctx().Set("repo_id", repoID))
+	}
+	return releases, count, nil
+}
+
+func (s *releaseStoreImpl) CountByRepoID(ctx context.Context, repoID int64) (int, error) {
+	count, err := s.db.Operator.Core.NewSelect().
+		Model((*Release)(nil)).
+		Where("repository_id = ?", repoID).
+		Count(ctx)
+	if err != nil {
+		return 0, errorx.HandleDBError(err, errorx.Ctx().Set("repo_id", repoID))
+	}
+	return count, nil
+}
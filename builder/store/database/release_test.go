@@ -0,0 +1,73 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/tests"
+	"opencsg.com/csghub-server/common/types"
+)
+
+func TestReleaseStore_CRUD(t *testing.T) {
+	db := tests.InitTestDB()
+	defer db.Close()
+	ctx := context.TODO()
+
+	repo := &database.Repository{
+		RepositoryType: types.ModelRepo,
+		GitPath:        "models_ns/n",
+		Path:           "ns/n",
+		Name:           "n",
+	}
+	_, err := db.Core.NewInsert().Model(repo).Exec(ctx, repo)
+	require.Nil(t, err)
+
+	store := database.NewReleaseStoreWithDB(db)
+	v1 := &database.Release{RepositoryID: repo.ID, Name: "v1", TagName: "v1", Latest: true, AuthorID: 1}
+	require.Nil(t, store.Create(ctx, v1))
+	v2 := &database.Release{RepositoryID: repo.ID, Name: "v2", TagName: "v2", Changelog: "## v2",
+		RecommendedFiles: []string{"model-q4.gguf"}, Latest: true, AuthorID: 1}
+	require.Nil(t, store.Create(ctx, v2))
+
+	err = store.Create(ctx, &database.Release{RepositoryID: repo.ID, Name: "v2", TagName: "v2", AuthorID: 1})
+	require.ErrorIs(t, err, errorx.ErrDatabaseDuplicateKey)
+
+	// the latest pointer moved to v2
+	release, err := store.FindByName(ctx, repo.ID, "v1")
+	require.Nil(t, err)
+	require.False(t, release.Latest)
+	release, err = store.FindByRef(ctx, types.ModelRepo, "NS/n", types.LatestReleaseRef)
+	require.Nil(t, err)
+	require.Equal(t, "v2", release.Name)
+	require.Equal(t, []string{"model-q4.gguf"}, release.RecommendedFiles)
+	release, err = store.FindByRef(ctx, types.ModelRepo, "ns/n", "v1")
+	require.Nil(t, err)
+	require.Equal(t, "v1", release.TagName)
+	_, err = store.FindByRef(ctx, types.DatasetRepo, "ns/n", "v1")
+	require.ErrorIs(t, err, errorx.ErrDatabaseNoRows)
+
+	v1.Latest = true
+	v1.Deprecated = true
+	require.Nil(t, store.Update(ctx, v1))
+	release, err = store.FindByName(ctx, repo.ID, "v2")
+	require.Nil(t, err)
+	require.False(t, release.Latest)
+
+	releases, total, err := store.ListByRepoID(ctx, repo.ID, 10, 1)
+	require.Nil(t, err)
+	require.Equal(t, 2, total)
+	require.Equal(t, "v2", releases[0].Name)
+	require.True(t, releases[1].Deprecated)
+
+	// the newest remaining release becomes the latest
+	require.Nil(t, store.Delete(ctx, repo.ID, "v1"))
+	release, err = store.FindByRef(ctx, types.ModelRepo, "ns/n", types.LatestReleaseRef)
+	require.Nil(t, err)
+	require.Equal(t, "v2", release.Name)
+	count, err := store.CountByRepoID(ctx, repo.ID)
+	require.Nil(t, err)
+	require.Equal(t, 1, count)
+}
@@ -0,0 +1,207 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/uptrace/bun"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+)
+
+// RepoAccessPolicy is the usage policy of a gated repository, the gated flag itself is kept in the
+// repositories table so the downloads of the other repositories need no extra query
+type RepoAccessPolicy struct {
+	ID                int64                        `bun:",pk,autoincrement" json:"id"`
+	RepositoryID      int64                        `bun:",notnull" json:"repository_id"`
+	ApprovalMode      types.RepoAccessApprovalMode `bun:",notnull" json:"approval_mode"`
+	Terms             string                       `bun:",type:text" json:"terms"`
+	FormFields        []types.RepoAccessFormField  `bun:",type:jsonb,nullzero" json:"form_fields"`
+	ApprovalValidDays int                          `bun:",notnull" json:"approval_valid_days"`
+	times
+}
+
+// RepoAccessRequest is the request of a user to download the files of a gated repository, a user has at
+// most one request per repository which is reset when requesting again
+type RepoAccessRequest struct {
+	ID            int64                         `bun:",pk,autoincrement" json:"id"`
+	RepositoryID  int64                         `bun:",notnull" json:"repository_id"`
+	UserID        int64                         `bun:",notnull" json:"user_id"`
+	User          *User                         `bun:"rel:belongs-to,join:user_id=id" json:"user"`
+	Status        types.RepoAccessRequestStatus `bun:",notnull" json:"status"`
+	AcceptedTerms bool                          `bun:",notnull" json:"accepted_terms"`
+	Form          map[string]string             `bun:",type:jsonb,nullzero" json:"form"`
+	ReviewerID    int64                         `bun:",nullzero" json:"reviewer_id"`
+	Reviewer      *User                         `bun:"rel:belongs-to,join:reviewer_id=id" json:"reviewer"`
+	ReviewMsg     string                        `bun:"," json:"review_msg"`
+	ReviewedAt    time.Time                     `bun:",nullzero" json:"reviewed_at"`
+	// ExpiresAt is the end of an approval, zero means it never expires
+	ExpiresAt time.Time `bun:",nullzero" json:"expires_at"`
+	times
+}
+
+// CurrentStatus returns the status of the request at the given time, an approval past its expiry time
+// is expired
+func (r *RepoAccessRequest) CurrentStatus(now time.Time) types.RepoAccessRequestStatus {
+	if r.Status == types.RepoAccessRequestApproved && !r.ExpiresAt.IsZero() && !r.ExpiresAt.After(now) {
+		return types.RepoAccessRequestExpired
+	}
+	return r.Status
+}
+
+type repoAccessStoreImpl struct {
+	db *DB
+}
+
+type RepoAccessStore interface {
+	// SavePolicy creates or updates the policy of the repository and sets the gated flag of the repository
+	SavePolicy(ctx context.Context, policy *RepoAccessPolicy, gated bool) error
+	FindPolicy(ctx context.Context, repoID int64) (*RepoAccessPolicy, error)
+	// SaveRequest creates the request, or replaces the previous request of the user to the repository
+	SaveRequest(ctx context.Context, request *RepoAccessRequest) error
+	UpdateRequest(ctx context.Context, request *RepoAccessRequest) error
+	FindRequest(ctx context.Context, repoID, userID int64) (*RepoAccessRequest, error)
+	FindRequestByID(ctx context.Context, repoID, id int64) (*RepoAccessRequest, error)
+	// ListRequests returns the requests of the repository in the status, all the requests if the status is
+	// empty, the newest first. All the matched requests are returned if per is 0
+	ListRequests(ctx context.Context, repoID int64, status types.RepoAccessRequestStatus, per, page int) ([]RepoAccessRequest, int, error)
+}
+
+func NewRepoAccessStore() RepoAccessStore {
+	return &repoAccessStoreImpl{
+		db: defaultDB,
+	}
+}
+
+func NewRepoAccessStoreWithDB(db *DB) RepoAccessStore {
+	return &repoAccessStoreImpl{
+		db: db,
+	}
+}
+
+func (s *repoAccessStoreImpl) SavePolicy(ctx context.Context, policy *RepoAccessPolicy, gated bool) error {
+	err := s.db.Operator.Core.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		_, err := tx.NewInsert().
+			Model(policy).
+			On("CONFLICT (repository_id) DO UPDATE").
+			Set("approval_mode = EXCLUDED.approval_mode").
+			Set("terms = EXCLUDED.terms").
+			Set("form_fields = EXCLUDED.form_fields").
+			Set("approval_valid_days = EXCLUDED.approval_valid_days").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("*").
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to save repo access policy: %w", err)
+		}
+		_, err = tx.NewUpdate().
+			Model((*Repository)(nil)).
+			Set("gated = ?", gated).
+			Where("id = ?", policy.RepositoryID).
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to update repo gated flag: %w", err)
+		}
+		return nil
+	})
+	return errorx.HandleDBError(err, errorx.Ctx().Set("repo_id", policy.RepositoryID))
+}
+
+func (s *repoAccessStoreImpl) FindPolicy(ctx context.Context, repoID int64) (*RepoAccessPolicy, error) {
+	var policy RepoAccessPolicy
+	err := s.db.Operator.Core.NewSelect().
+		Model(&policy).
+		Where("repository_id = ?", repoID).
+		Scan(ctx)
+	if err != nil {
+		return nil, errorx.HandleDBError(err, errorx.Ctx().Set("repo_id", repoID))
+	}
+	return &policy, nil
+}
+
+func (s *repoAccessStoreImpl) SaveRequest(ctx context.Context, request *RepoAccessRequest) error {
+	_, err := s.db.Operator.Core.NewInsert().
+		Model(request).
+		On("CONFLICT (repository_id, user_id) DO UPDATE").
+		Set("status = EXCLUDED.status").
+		Set("accepted_terms = EXCLUDED.accepted_terms").
+		Set("form = EXCLUDED.form").
+		Set("reviewer_id = EXCLUDED.reviewer_id").
+		Set("review_msg = EXCLUDED.review_msg").
+		Set("reviewed_at = EXCLUDED.reviewed_at").
+		Set("expires_at = EXCLUDED.expires_at").
+		Set("created_at = EXCLUDED.created_at").
+		Set("updated_at = EXCLUDED.updated_at").
+		Returning("*").
+		Exec(ctx)
+	return errorx.HandleDBError(err, errorx.Ctx().Set("repo_id", request.RepositoryID).Set("user_id", request.UserID))
+}
+
+func (s *repoAccessStoreImpl) UpdateRequest(ctx context.Context, request *RepoAccessRequest) error {
+	_, err := s.db.Operator.Core.NewUpdate().
+		Model(request).
+		WherePK().
+		Exec(ctx)
+	return errorx.HandleDBError(err, errorx.Ctx().Set("id", request.ID))
+}
+
+func (s *repoAccessStoreImpl) FindRequest(ctx context.Context, repoID, userID int64) (*RepoAccessRequest, error) {
+	var request RepoAccessRequest
+	err := s.db.Operator.Core.NewSelect().
+		Model(&request).
+		Where("repository_id = ?", repoID).
+		Where("user_id = ?", userID).
+		Scan(ctx)
+	if err != nil {
+		return nil, errorx.HandleDBError(err, errorx.Ctx().Set("repo_id", repoID).Set("user_id", userID))
+	}
+	return &request, nil
+}
+
+func (s *repoAccessStoreImpl) FindRequestByID(ctx context.Context, repoID, id int64) (*RepoAccessRequest, error) {
+	var request RepoAccessRequest
+	err := s.db.Operator.Core.NewSelect().
+		Model(&request).
+		Relation("User").
+		Where("repo_access_request.repository_id = ?", repoID).
+		Where("repo_access_request.id = ?", id).
+		Scan(ctx)
+	if err != nil {
+		return nil, errorx.HandleDBError(err, errorx.Ctx().Set("repo_id", repoID).Set("id", id))
+	}
+	return &request, nil
+}
+
+func (s *repoAccessStoreImpl) ListRequests(ctx context.Context, repoID int64, status types.RepoAccessRequestStatus, per, page int) ([]RepoAccessRequest, int, error) {
+	var requests []RepoAccessRequest
+	query := s.db.Operator.Core.NewSelect().
+		Model(&requests).
+		Relation("User").
+		Relation("Reviewer").
+		Where("repo_access_request.repository_id = ?", repoID)
+	now := time.Now()
+	switch status {
+	case "":
+	case types.RepoAccessRequestApproved:
+		query = query.Where("repo_access_request.status = ?", status).
+			WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+				return q.Where("repo_access_request.expires_at IS NULL").
+					WhereOr("repo_access_request.expires_at > ?", now)
+			})
+	case types.RepoAccessRequestExpired:
+		query = query.Where("repo_access_request.status = ?", types.RepoAccessRequestApproved).
+			Where("repo_access_request.expires_at <= ?", now)
+	default:
+		query = query.Where("repo_access_request.status = ?", status)
+	}
+	query = query.Order("repo_access_request.id DESC")
+	if per > 0 {
+		query = query.Limit(per).Offset((page - 1) * per)
+	}
+	count, err := query.ScanAndCount(ctx)
+	if err != nil {
+		return nil, 0, errorx.HandleDBError(err, errorx.Ctx().Set("repo_id", repoID))
+	}
+	return requests, count, nil
+}
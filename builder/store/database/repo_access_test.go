@@ -0,0 +1,122 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/tests"
+	"opencsg.com/csghub-server/common/types"
+)
+
+func TestRepoAccessStore_Policy(t *testing.T) {
+	db := tests.InitTestDB()
+	defer db.Close()
+	ctx := context.TODO()
+
+	repo := &database.Repository{
+		RepositoryType: types.ModelRepo,
+		GitPath:        "models_ns/n",
+		Path:           "ns/n",
+		Name:           "n",
+	}
+	_, err := db.Core.NewInsert().Model(repo).Exec(ctx, repo)
+	require.Nil(t, err)
+
+	store := database.NewRepoAccessStoreWithDB(db)
+	_, err = store.FindPolicy(ctx, repo.ID)
+	require.ErrorIs(t, err, errorx.ErrDatabaseNoRows)
+
+	policy := &database.RepoAccessPolicy{
+		RepositoryID: repo.ID,
+		ApprovalMode: types.RepoAccessApprovalManual,
+		Terms:        "no commercial use",
+		FormFields:   []types.RepoAccessFormField{{Name: "company", Required: true}},
+	}
+	require.Nil(t, store.SavePolicy(ctx, policy, true))
+	repoStore := database.NewRepoStoreWithDB(db)
+	r, err := repoStore.FindById(ctx, repo.ID)
+	require.Nil(t, err)
+	require.True(t, r.Gated)
+
+	require.Nil(t, store.SavePolicy(ctx, &database.RepoAccessPolicy{
+		RepositoryID:      repo.ID,
+		ApprovalMode:      types.RepoAccessApprovalAuto,
+		ApprovalValidDays: 30,
+	}, false))
+	policy, err = store.FindPolicy(ctx, repo.ID)
+	require.Nil(t, err)
+	require.Equal(t, types.RepoAccessApprovalAuto, policy.ApprovalMode)
+	require.Equal(t, 30, policy.ApprovalValidDays)
+	require.Empty(t, policy.FormFields)
+	r, err = repoStore.FindById(ctx, repo.ID)
+	require.Nil(t, err)
+	require.False(t, r.Gated)
+}
+
+func TestRepoAccessStore_Requests(t *testing.T) {
+	db := tests.InitTestDB()
+	defer db.Close()
+	ctx := context.TODO()
+
+	store := database.NewRepoAccessStoreWithDB(db)
+	pending := &database.RepoAccessRequest{
+		RepositoryID: 1, UserID: 1, Status: types.RepoAccessRequestPending,
+		AcceptedTerms: true, Form: map[string]string{"company": "opencsg"},
+	}
+	require.Nil(t, store.SaveRequest(ctx, pending))
+	approved := &database.RepoAccessRequest{
+		RepositoryID: 1, UserID: 2, Status: types.RepoAccessRequestApproved,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	require.Nil(t, store.SaveRequest(ctx, approved))
+	expired := &database.RepoAccessRequest{
+		RepositoryID: 1, UserID: 3, Status: types.RepoAccessRequestApproved,
+		ExpiresAt: time.Now().Add(-time.Hour),
+	}
+	require.Nil(t, store.SaveRequest(ctx, expired))
+	require.Equal(t, types.RepoAccessRequestExpired, expired.CurrentStatus(time.Now()))
+	require.Nil(t, store.SaveRequest(ctx, &database.RepoAccessRequest{
+		RepositoryID: 2, UserID: 1, Status: types.RepoAccessRequestApproved,
+	}))
+
+	request, err := store.FindRequest(ctx, 1, 1)
+	require.Nil(t, err)
+	require.Equal(t, "opencsg", request.Form["company"])
+	_, err = store.FindRequest(ctx, 1, 4)
+	require.ErrorIs(t, err, errorx.ErrDatabaseNoRows)
+
+	requests, total, err := store.ListRequests(ctx, 1, "", 10, 1)
+	require.Nil(t, err)
+	require.Equal(t, 3, total)
+	require.Equal(t, expired.ID, requests[0].ID)
+	_, total, err = store.ListRequests(ctx, 1, types.RepoAccessRequestApproved, 10, 1)
+	require.Nil(t, err)
+	require.Equal(t, 1, total)
+	requests, total, err = store.ListRequests(ctx, 1, types.RepoAccessRequestExpired, 0, 0)
+	require.Nil(t, err)
+	require.Equal(t, 1, total)
+	require.Equal(t, int64(3), requests[0].UserID)
+
+	// requesting again replaces the previous request
+	require.Nil(t, store.SaveRequest(ctx, &database.RepoAccessRequest{
+		RepositoryID: 1, UserID: 3, Status: types.RepoAccessRequestPending, AcceptedTerms: true,
+	}))
+	request, err = store.FindRequestByID(ctx, 1, expired.ID)
+	require.Nil(t, err)
+	require.Equal(t, types.RepoAccessRequestPending, request.Status)
+	require.True(t, request.ExpiresAt.IsZero())
+
+	request.Status = types.RepoAccessRequestRejected
+	request.ReviewerID = 1
+	request.ReviewMsg = "unknown company"
+	require.Nil(t, store.UpdateRequest(ctx, request))
+	_, total, err = store.ListRequests(ctx, 1, types.RepoAccessRequestRejected, 10, 1)
+	require.Nil(t, err)
+	require.Equal(t, 1, total)
+	_, err = store.FindRequestByID(ctx, 2, expired.ID)
+	require.ErrorIs(t, err, errorx.ErrDatabaseNoRows)
+}
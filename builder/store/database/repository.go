@@ -107,6 +107,8 @@ type RepoStore interface {
 	BatchUpdate(ctx context.Context, repos []*Repository) error
 	FindByRepoTypeAndPaths(ctx context.Context, repoType types.RepositoryType, path []string) ([]Repository, error)
 	FindUnhashedRepos(ctx context.Context, batchSize int, lastID int64) ([]Repository, error)
+	// FindAfterID pages through all repositories by id, so concurrent deletions don't shift the pages
+	FindAfterID(ctx context.Context, batchSize int, lastID int64) ([]Repository, error)
 	UpdateRepoSensitiveCheckStatus(ctx context.Context, repoID int64, status types.SensitiveCheckStatus) error
 	GetReposBySearch(ctx context.Context, search string, repoType types.RepositoryType, page, pageSize int) ([]*Repository, int, error)
 	// GetRepositoriesWithoutStatistics returns repositories without associated RepositoryStatistics
@@ -211,6 +213,10 @@ type Repository struct {
 	XnetEnabled                bool                       `bun:"," json:"xnet_enabled"`
 	CurrentXnetMigrationTaskID int64                      `bun:"," json:"current_xnet_migration_task_id"`
 	CurrentXnetMigrationTask   *XnetMigrationTask         `bun:"rel:has-one,join:current_xnet_migration_task_id=id" json:"current_xnet_migration_task"`
+	// Gated repositories require an approved access request to download the LFS files, see RepoAccessPolicy
+	Gated bool `bun:",notnull,default:false" json:"gated"`
+	// SearchSnippet is the highlighted fragment of the search document of the repository in the search results
+	SearchSnippet string `bun:"-" json:"search_snippet,omitempty"`
 
 	// updated_at timestamp will be updated only if files changed
 	times
@@ -1538,6 +1544,17 @@ func (s *repoStoreImpl) SearchRepoWithCache(ctx context.Context, q *bun.SelectQu
 		}
 	}
 
+	if s.DbDriver == "pg" {
+		snippets, err := NewRepositorySearchDocumentStoreWithDB(s.db, s.config).Snippets(ctx, ids, filter.Search)
+		if err != nil {
+			// the search results are still returned without the snippets
+			slog.Warn("failed to get search snippets", "error", err)
+		}
+		for _, repo := range orderedRepos {
+			repo.SearchSnippet = snippets[repo.ID]
+		}
+	}
+
 	return orderedRepos, count, nil
 }
 
@@ -1609,7 +1626,15 @@ func buildVectorSearchQuery(
 			)::tsquery
 		)
 	`
-	q.Where(`repository.search_vector @@ `+tsQuerySub, searchConfiguration, input)
+	// the repositories are also matched by their search documents, which are ranked lower than the names
+	// and the descriptions
+	text, cjk := splitSearchText(input)
+	q.Join("LEFT JOIN repository_search_documents AS rsd ON rsd.repository_id = repository.id")
+	q.ColumnExpr("rsd.search_vector AS document_search_vector")
+	q.WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.Where(`repository.search_vector @@ `+tsQuerySub, searchConfiguration, input).
+			WhereOr("rsd.search_vector @@ "+documentTSQuery, searchConfiguration, text, cjk)
+	})
 	q.Limit(limit)
 
 	oq := db.NewSelect().
@@ -1620,8 +1645,8 @@ func buildVectorSearchQuery(
 				r.search_vector,
 				`+tsQuerySub+`,
 				32
-			) AS rank
-		`, searchConfiguration, input)
+			) + COALESCE(ts_rank_cd(r.document_search_vector, `+documentTSQuery+`, 32), 0) * 0.5 AS rank
+		`, searchConfiguration, input, searchConfiguration, text, cjk)
 	oq.OrderExpr("rank DESC")
 
 	return oq
@@ -2083,6 +2108,17 @@ func (s *repoStoreImpl) FindUnhashedRepos(ctx context.Context, batchSize int, la
 	return res, err
 }
 
+func (s *repoStoreImpl) FindAfterID(ctx context.Context, batchSize int, lastID int64) ([]Repository, error) {
+	var res []Repository
+	err := s.db.Operator.Core.NewSelect().
+		Model(&res).
+		Where("id > ?", lastID).
+		Limit(batchSize).
+		Order("id ASC").
+		Scan(ctx)
+	return res, err
+}
+
 func (s *repoStoreImpl) GetReposBySearch(ctx context.Context, search string, repoType types.RepositoryType, page, pageSize int) ([]*Repository, int, error) {
 	var (
 		res   []*Repository
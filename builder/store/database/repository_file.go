@@ -2,9 +2,13 @@ package database
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
+	"strings"
 	"time"
 
+	"github.com/uptrace/bun"
+	"opencsg.com/csghub-server/common/errorx"
 	"opencsg.com/csghub-server/common/types"
 )
 
@@ -31,6 +35,14 @@ type RepoFileStore interface {
 	BatchGetUnchcked(ctx context.Context, repoID, lastRepoFileID, batch int64) ([]*RepositoryFile, error)
 	Exists(ctx context.Context, file RepositoryFile) (bool, error)
 	ExistsSensitiveCheckRecord(ctx context.Context, repoID int64, branch string, status types.SensitiveCheckStatus) (bool, error)
+	// Search returns the files of the default branches of the repositories visible to the user, the private
+	// repositories are visible to the admin, and the ones under the given owner namespaces
+	Search(ctx context.Context, req *types.SearchRepoFileReq, ownerNamespaces []string, isAdmin bool) ([]RepositoryFile, int, error)
+	// DeleteStale deletes the files of the repository branch which are not in the current files of the branch,
+	// matched by the path and the commit sha, with their sensitive check records and model file scans
+	DeleteStale(ctx context.Context, repoID int64, branch string, current []RepositoryFile) (int, error)
+	// PathsByLfsRelativePaths returns the file paths of the LFS objects of the repository by their relative paths
+	PathsByLfsRelativePaths(ctx context.Context, repoID int64, relativePaths []string) (map[string]string, error)
 }
 
 func NewRepoFileStore() RepoFileStore {
@@ -90,3 +102,149 @@ func (s *repoFileStoreImpl) ExistsSensitiveCheckRecord(ctx context.Context, repo
 		Where("rf.repository_id = ? and rf.branch = ? and repository_file_check.status = ?", repoID, branch, status).
 		Exists(ctx)
 }
+
+func (s *repoFileStoreImpl) DeleteStale(ctx context.Context, repoID int64, branch string, current []RepositoryFile) (int, error) {
+	type fileKey struct{ path, commitSha string }
+	currentFiles := make(map[fileKey]bool, len(current))
+	for _, file := range current {
+		currentFiles[fileKey{file.Path, file.CommitSha}] = true
+	}
+	var files []RepositoryFile
+	err := s.db.Operator.Core.NewSelect().Model(&files).
+		Column("id", "path", "commit_sha").
+		Where("repository_id = ? and branch = ?", repoID, branch).
+		Scan(ctx)
+	if err != nil {
+		return 0, errorx.HandleDBError(err, errorx.Ctx().Set("repo_id", repoID))
+	}
+	var staleIDs []int64
+	for _, file := range files {
+		if !currentFiles[fileKey{file.Path, file.CommitSha}] {
+			staleIDs = append(staleIDs, file.ID)
+		}
+	}
+	if len(staleIDs) == 0 {
+		return 0, nil
+	}
+
+	err = s.db.Operator.Core.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		for start := 0; start < len(staleIDs); start += deleteStaleBatch {
+			ids := staleIDs[start:min(start+deleteStaleBatch, len(staleIDs))]
+			if _, err := tx.NewDelete().Model((*RepositoryFileCheck)(nil)).Where("repo_file_id IN (?)", bun.In(ids)).Exec(ctx); err != nil {
+				return err
+			}
+			if _, err := tx.NewDelete().Model((*ModelFileScan)(nil)).Where("repo_file_id IN (?)", bun.In(ids)).Exec(ctx); err != nil {
+				return err
+			}
+			if _, err := tx.NewDelete().Model((*RepositoryFile)(nil)).Where("id IN (?)", bun.In(ids)).Exec(ctx); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, errorx.HandleDBError(err, errorx.Ctx().Set("repo_id", repoID))
+	}
+	return len(staleIDs), nil
+}
+
+// deleteStaleBatch is the max number of the stale files deleted by one statement
+const deleteStaleBatch = 1000
+
+func (s *repoFileStoreImpl) PathsByLfsRelativePaths(ctx context.Context, repoID int64, relativePaths []string) (map[string]string, error) {
+	paths := make(map[string]string, len(relativePaths))
+	if len(relativePaths) == 0 {
+		return paths, nil
+	}
+	var files []RepositoryFile
+	err := s.db.Operator.Core.NewSelect().Model(&files).
+		Column("path", "lfs_relative_path").
+		Where("repository_id = ?", repoID).
+		Where("lfs_relative_path IN (?)", bun.In(relativePaths)).
+		Order("id DESC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		// the latest file wins if the object is referenced by several paths
+		if _, ok := paths[file.LfsRelativePath]; !ok {
+			paths[file.LfsRelativePath] = file.Path
+		}
+	}
+	return paths, nil
+}
+
+func (s *repoFileStoreImpl) Search(ctx context.Context, req *types.SearchRepoFileReq, ownerNamespaces []string, isAdmin bool) ([]RepositoryFile, int, error) {
+	var files []RepositoryFile
+	q := s.db.Operator.Core.NewSelect().
+		Model(&files).
+		Relation("Repository").
+		Where("repository_file.branch = repository.default_branch").
+		// only the latest record of a path is searched, the older ones are kept until the files of the
+		// repository are generated again
+		Where(`NOT EXISTS (SELECT 1 FROM repository_files AS newer WHERE newer.repository_id = repository_file.repository_id
+			AND newer.branch = repository_file.branch AND newer.path = repository_file.path AND newer.id > repository_file.id)`)
+	if req.RepoType != "" {
+		q.Where("repository.repository_type = ?", req.RepoType)
+	}
+	if req.Glob != "" {
+		q.Where("repository_file.path LIKE ? ESCAPE '\\'", globToLikePattern(req.Glob))
+	}
+	if req.Extension != "" {
+		q.Where("repository_file.path LIKE ? ESCAPE '\\'", "%."+escapeLikePattern(strings.TrimPrefix(req.Extension, ".")))
+	}
+	if req.MinSize > 0 {
+		q.Where("repository_file.size >= ?", req.MinSize)
+	}
+	if req.MaxSize > 0 {
+		q.Where("repository_file.size <= ?", req.MaxSize)
+	}
+	if req.LFS != nil {
+		if *req.LFS {
+			q.Where("repository_file.lfs_relative_path IS NOT NULL")
+		} else {
+			q.Where("repository_file.lfs_relative_path IS NULL")
+		}
+	}
+	if req.LfsOID != "" {
+		q.Where("repository_file.lfs_relative_path = ?", types.Pointer{Oid: req.LfsOID}.RelativePath())
+	}
+	if !isAdmin {
+		q.WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			q = q.Where("repository.private = ?", false)
+			for _, namespace := range ownerNamespaces {
+				q = q.WhereOr("repository.path LIKE ? ESCAPE '\\'", fmt.Sprintf("%s/%%", escapeLikePattern(namespace)))
+			}
+			return q
+		})
+	}
+	count, err := q.Order("repository_file.id ASC").
+		Limit(req.Per).
+		Offset((req.Page - 1) * req.Per).
+		ScanAndCount(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to search repository files, error: %w", err)
+	}
+	return files, count, nil
+}
+
+// globToLikePattern converts the glob to the LIKE pattern, `*` matches any characters and `?` matches a
+// single character
+func globToLikePattern(glob string) string {
+	var b strings.Builder
+	for _, r := range glob {
+		switch r {
+		case '*':
+			b.WriteRune('%')
+		case '?':
+			b.WriteRune('_')
+		case '\\', '%', '_':
+			b.WriteRune('\\')
+			b.WriteRune(r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
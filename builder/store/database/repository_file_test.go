@@ -136,3 +136,101 @@ func TestRepoFileStore_Exists(t *testing.T) {
 	require.False(t, exist)
 
 }
+
+func TestRepoFileStore_Search(t *testing.T) {
+	db := tests.InitTestDB()
+	defer db.Close()
+	ctx := context.TODO()
+
+	store := database.NewRepoFileStoreWithDB(db)
+
+	repos := []*database.Repository{
+		{Path: "ns/public", GitPath: "ns/public", Name: "public", RepositoryType: types.ModelRepo, DefaultBranch: "main"},
+		{Path: "org/private", GitPath: "org/private", Name: "private", RepositoryType: types.DatasetRepo, DefaultBranch: "main", Private: true},
+	}
+	for _, repo := range repos {
+		err := db.Core.NewInsert().Model(repo).Scan(ctx, repo)
+		require.Nil(t, err)
+	}
+	oid := "ab12cd34ef56ab12cd34ef56ab12cd34ef56ab12cd34ef56ab12cd34ef56ab12"
+	files := []*database.RepositoryFile{
+		{RepositoryID: repos[0].ID, Path: "m-Q4_K_M.gguf", FileType: "file", Size: 5 << 30, Branch: "main", LfsRelativePath: types.Pointer{Oid: oid}.RelativePath()},
+		{RepositoryID: repos[0].ID, Path: "m-Q4_K_M.gguf", FileType: "file", Size: 5 << 30, Branch: "dev"},
+		{RepositoryID: repos[0].ID, Path: "mQ4xKxM.gguf", FileType: "file", Size: 5 << 30, Branch: "main"},
+		{RepositoryID: repos[1].ID, Path: "train/part-0.parquet", FileType: "file", Size: 1 << 20, Branch: "main"},
+		{RepositoryID: repos[1].ID, Path: "test/part-0.parquet", FileType: "file", Size: 1 << 20, Branch: "main"},
+	}
+	for _, file := range files {
+		require.Nil(t, store.Create(ctx, file))
+	}
+
+	search := func(req *types.SearchRepoFileReq, ownerNamespaces []string, isAdmin bool) ([]string, int) {
+		req.Per, req.Page = 10, 1
+		result, total, err := store.Search(ctx, req, ownerNamespaces, isAdmin)
+		require.Nil(t, err)
+		var paths []string
+		for _, file := range result {
+			paths = append(paths, file.Repository.Path+":"+file.Path)
+		}
+		return paths, total
+	}
+
+	paths, total := search(&types.SearchRepoFileReq{Glob: "*Q4_K_M.gguf", MinSize: 4 << 30}, nil, false)
+	require.Equal(t, 1, total)
+	require.Equal(t, []string{"ns/public:m-Q4_K_M.gguf"}, paths)
+
+	paths, _ = search(&types.SearchRepoFileReq{LfsOID: oid}, nil, false)
+	require.Equal(t, []string{"ns/public:m-Q4_K_M.gguf"}, paths)
+
+	lfs := false
+	paths, _ = search(&types.SearchRepoFileReq{LFS: &lfs, RepoType: types.ModelRepo}, nil, false)
+	require.Equal(t, []string{"ns/public:mQ4xKxM.gguf"}, paths)
+
+	paths, _ = search(&types.SearchRepoFileReq{Glob: "train/*", Extension: ".parquet"}, nil, false)
+	require.Empty(t, paths)
+	paths, _ = search(&types.SearchRepoFileReq{Glob: "train/*", Extension: ".parquet"}, []string{"org"}, false)
+	require.Equal(t, []string{"org/private:train/part-0.parquet"}, paths)
+	_, total = search(&types.SearchRepoFileReq{Extension: "parquet"}, nil, true)
+	require.Equal(t, 2, total)
+
+	// only the latest record of a path is found
+	require.Nil(t, store.Create(ctx, &database.RepositoryFile{
+		RepositoryID: repos[1].ID, Path: "test/part-0.parquet", FileType: "file", Size: 2 << 20, Branch: "main", CommitSha: "sha2",
+	}))
+	result, total, err := store.Search(ctx, &types.SearchRepoFileReq{Glob: "test/*", Per: 10, Page: 1}, nil, true)
+	require.Nil(t, err)
+	require.Equal(t, 1, total)
+	require.Equal(t, "sha2", result[0].CommitSha)
+}
+
+func TestRepoFileStore_DeleteStale(t *testing.T) {
+	db := tests.InitTestDB()
+	defer db.Close()
+	ctx := context.TODO()
+
+	store := database.NewRepoFileStoreWithDB(db)
+	files := []*database.RepositoryFile{
+		{RepositoryID: 1, Path: "a.txt", FileType: "file", Branch: "main", CommitSha: "sha1"},
+		{RepositoryID: 1, Path: "a.txt", FileType: "file", Branch: "main", CommitSha: "sha2"},
+		{RepositoryID: 1, Path: "deleted.txt", FileType: "file", Branch: "main", CommitSha: "sha3"},
+		{RepositoryID: 1, Path: "deleted.txt", FileType: "file", Branch: "dev", CommitSha: "sha3"},
+		{RepositoryID: 2, Path: "deleted.txt", FileType: "file", Branch: "main", CommitSha: "sha3"},
+	}
+	for _, file := range files {
+		require.Nil(t, store.Create(ctx, file))
+	}
+	_, err := db.Core.NewInsert().Model(&database.RepositoryFileCheck{RepoFileID: files[2].ID, Status: types.SensitiveCheckFail}).Exec(ctx)
+	require.Nil(t, err)
+
+	removed, err := store.DeleteStale(ctx, 1, "main", []database.RepositoryFile{{Path: "a.txt", CommitSha: "sha2"}})
+	require.Nil(t, err)
+	require.Equal(t, 2, removed)
+
+	var ids []int64
+	err = db.Core.NewSelect().Model((*database.RepositoryFile)(nil)).Column("id").Order("id ASC").Scan(ctx, &ids)
+	require.Nil(t, err)
+	require.Equal(t, []int64{files[1].ID, files[3].ID, files[4].ID}, ids)
+	exists, err := store.ExistsSensitiveCheckRecord(ctx, 1, "main", types.SensitiveCheckFail)
+	require.Nil(t, err)
+	require.False(t, exists)
+}
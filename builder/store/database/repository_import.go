@@ -0,0 +1,73 @@
+package database
+
+import (
+	"context"
+
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+)
+
+// RepositoryImport records a repository imported by a user from a remote platform, the transfer itself
+// is done by the mirror of the repository
+type RepositoryImport struct {
+	ID           int64                `bun:",pk,autoincrement" json:"id"`
+	UserID       int64                `bun:",notnull" json:"user_id"`
+	Provider     types.ImportProvider `bun:",notnull" json:"provider"`
+	SourcePath   string               `bun:",notnull" json:"source_path"`
+	RepositoryID int64                `bun:",notnull" json:"repository_id"`
+	Repository   *Repository          `bun:"rel:belongs-to,join:repository_id=id" json:"repository,omitempty"`
+	MirrorID     int64                `bun:",notnull" json:"mirror_id"`
+	Mirror       *Mirror              `bun:"rel:belongs-to,join:mirror_id=id" json:"mirror,omitempty"`
+	times
+}
+
+type repositoryImportStoreImpl struct {
+	db *DB
+}
+
+type RepositoryImportStore interface {
+	Create(ctx context.Context, repoImport *RepositoryImport) error
+	// ListByUserID returns the imports of the user along with the repositories and the current tasks of
+	// the mirrors, the latest first
+	ListByUserID(ctx context.Context, userID int64, per, page int) ([]RepositoryImport, int, error)
+}
+
+func NewRepositoryImportStore() RepositoryImportStore {
+	return &repositoryImportStoreImpl{
+		db: defaultDB,
+	}
+}
+
+func NewRepositoryImportStoreWithDB(db *DB) RepositoryImportStore {
+	return &repositoryImportStoreImpl{
+		db: db,
+	}
+}
+
+func (s *repositoryImportStoreImpl) Create(ctx context.Context, repoImport *RepositoryImport) error {
+	_, err := s.db.Operator.Core.NewInsert().Model(repoImport).Exec(ctx, repoImport)
+	if err != nil {
+		return errorx.HandleDBError(err, errorx.Ctx().
+			Set("repo_id", repoImport.RepositoryID).
+			Set("source_path", repoImport.SourcePath))
+	}
+	return nil
+}
+
+func (s *repositoryImportStoreImpl) ListByUserID(ctx context.Context, userID int64, per, page int) ([]RepositoryImport, int, error) {
+	var imports []RepositoryImport
+	count, err := s.db.Operator.Core.NewSelect().
+		Model(&imports).
+		Relation("Repository").
+		Relation("Mirror").
+		Relation("Mirror.CurrentTask").
+		Where("repository_import.user_id = ?", userID).
+		Order("repository_import.id DESC").
+		Limit(per).
+		Offset((page - 1) * per).
+		ScanAndCount(ctx)
+	if err != nil {
+		return nil, 0, errorx.HandleDBError(err, errorx.Ctx().Set("user_id", userID))
+	}
+	return imports, count, nil
+}
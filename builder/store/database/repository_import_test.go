@@ -0,0 +1,62 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/tests"
+	"opencsg.com/csghub-server/common/types"
+)
+
+func TestRepositoryImportStore_CreateAndList(t *testing.T) {
+	db := tests.InitTestDB()
+	defer db.Close()
+	ctx := context.TODO()
+
+	repo := &database.Repository{
+		RepositoryType: types.CodeRepo,
+		GitPath:        "codes_ns/n",
+		Path:           "ns/n",
+		Name:           "n",
+	}
+	_, err := db.Core.NewInsert().Model(repo).Exec(ctx, repo)
+	require.Nil(t, err)
+
+	mirror, err := database.NewMirrorStoreWithDB(db).Create(ctx, &database.Mirror{
+		RepositoryID: repo.ID,
+		SourceUrl:    "https://github.com/foo/n.git",
+		Status:       types.MirrorQueued,
+	})
+	require.Nil(t, err)
+	task := &database.MirrorTask{MirrorID: mirror.ID, Status: types.MirrorRepoSyncStart, Progress: 30}
+	_, err = db.Core.NewInsert().Model(task).Exec(ctx, task)
+	require.Nil(t, err)
+	_, err = db.Core.NewUpdate().Model(&database.Mirror{ID: mirror.ID, CurrentTaskID: task.ID}).
+		Column("current_task_id").WherePK().Exec(ctx)
+	require.Nil(t, err)
+
+	store := database.NewRepositoryImportStoreWithDB(db)
+	err = store.Create(ctx, &database.RepositoryImport{
+		UserID:       1,
+		Provider:     types.ImportProviderGitHub,
+		SourcePath:   "foo/n",
+		RepositoryID: repo.ID,
+		MirrorID:     mirror.ID,
+	})
+	require.Nil(t, err)
+
+	imports, total, err := store.ListByUserID(ctx, 1, 10, 1)
+	require.Nil(t, err)
+	require.Equal(t, 1, total)
+	require.Equal(t, "foo/n", imports[0].SourcePath)
+	require.Equal(t, "ns/n", imports[0].Repository.Path)
+	require.Equal(t, task.ID, imports[0].Mirror.CurrentTask.ID)
+	require.Equal(t, 30, imports[0].Mirror.CurrentTask.Progress)
+
+	imports, total, err = store.ListByUserID(ctx, 2, 10, 1)
+	require.Nil(t, err)
+	require.Equal(t, 0, total)
+	require.Empty(t, imports)
+}
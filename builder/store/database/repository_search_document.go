@@ -0,0 +1,201 @@
+package database
+
+import (
+	"context"
+	"strings"
+	"unicode"
+
+	"github.com/uptrace/bun"
+	"opencsg.com/csghub-server/common/config"
+	"opencsg.com/csghub-server/common/errorx"
+)
+
+// snippetOptions are the ts_headline options of the search snippets, the text of the documents is html
+// escaped when it's saved, so the snippets are safe to be rendered as html
+const snippetOptions = `MaxFragments=2, MaxWords=30, MinWords=10, FragmentDelimiter=" ... ", StartSel=<em>, StopSel=</em>`
+
+type repositorySearchDocumentStoreImpl struct {
+	db                  *DB
+	dbDriver            string
+	searchConfiguration string
+}
+
+type RepositorySearchDocumentStore interface {
+	// Upsert saves the search document of the repository, the CJK terms are generated from the document text
+	Upsert(ctx context.Context, doc *RepositorySearchDocument) error
+	Delete(ctx context.Context, repoID int64) error
+	// Snippets returns the highlighted fragments of the documents of the repositories matching the search
+	Snippets(ctx context.Context, repoIDs []int64, search string) (map[int64]string, error)
+}
+
+func NewRepositorySearchDocumentStore(cfg *config.Config) RepositorySearchDocumentStore {
+	return NewRepositorySearchDocumentStoreWithDB(defaultDB, cfg)
+}
+
+func NewRepositorySearchDocumentStoreWithDB(db *DB, cfg *config.Config) RepositorySearchDocumentStore {
+	return &repositorySearchDocumentStoreImpl{
+		db:                  db,
+		dbDriver:            cfg.Database.Driver,
+		searchConfiguration: cfg.Database.SearchConfiguration,
+	}
+}
+
+// RepositorySearchDocument is the full-text search document of a repository, it's built from the README,
+// the card fields in the README metadata, the tags and the file paths of the default branch
+type RepositorySearchDocument struct {
+	RepositoryID int64  `bun:",pk" json:"repository_id"`
+	Readme       string `bun:",nullzero" json:"readme"`
+	Card         string `bun:",nullzero" json:"card"`
+	Tags         string `bun:",nullzero" json:"tags"`
+	FilePaths    string `bun:",nullzero" json:"file_paths"`
+	// CJKTerms are the n-grams of the CJK text of the document, the text search configuration can not split
+	// CJK words without zhparser
+	CJKTerms string `bun:"cjk_terms,nullzero" json:"-"`
+	times
+}
+
+func (s *repositorySearchDocumentStoreImpl) Upsert(ctx context.Context, doc *RepositorySearchDocument) error {
+	doc.CJKTerms = strings.Join(cjkTerms(doc.Card, doc.Tags, doc.Readme), " ")
+	_, err := s.db.Core.NewInsert().Model(doc).
+		On("CONFLICT (repository_id) DO UPDATE").
+		Set("readme = EXCLUDED.readme").
+		Set("card = EXCLUDED.card").
+		Set("tags = EXCLUDED.tags").
+		Set("file_paths = EXCLUDED.file_paths").
+		Set("cjk_terms = EXCLUDED.cjk_terms").
+		Set("updated_at = current_timestamp").
+		Exec(ctx)
+	if err != nil {
+		return errorx.HandleDBError(err, errorx.Ctx().Set("repo_id", doc.RepositoryID))
+	}
+	return nil
+}
+
+func (s *repositorySearchDocumentStoreImpl) Delete(ctx context.Context, repoID int64) error {
+	_, err := s.db.Core.NewDelete().Model((*RepositorySearchDocument)(nil)).
+		Where("repository_id = ?", repoID).
+		Exec(ctx)
+	if err != nil {
+		return errorx.HandleDBError(err, errorx.Ctx().Set("repo_id", repoID))
+	}
+	return nil
+}
+
+func (s *repositorySearchDocumentStoreImpl) Snippets(ctx context.Context, repoIDs []int64, search string) (map[int64]string, error) {
+	snippets := make(map[int64]string, len(repoIDs))
+	// the full-text index is only available in postgresql
+	if len(repoIDs) == 0 || s.dbDriver != "pg" {
+		return snippets, nil
+	}
+	var rows []struct {
+		RepositoryID int64  `bun:"repository_id"`
+		Snippet      string `bun:"snippet"`
+	}
+	text, cjk := splitSearchText(search)
+	err := s.db.Core.NewSelect().
+		TableExpr("repository_search_documents AS rsd").
+		Column("rsd.repository_id").
+		// the fragments are taken from all the fields, so the repositories matched by a tag or a file path
+		// also get a snippet, the tags and the paths are split into words like in the search vector
+		ColumnExpr("ts_headline(?, concat_ws(' ', rsd.readme, rsd.card, regexp_replace(rsd.tags, '[-/_]', ' ', 'g'), "+
+			"regexp_replace(rsd.file_paths, '[-/_.]', ' ', 'g')), "+documentTSQuery+", ?) AS snippet",
+			s.searchConfiguration, s.searchConfiguration, text, cjk, snippetOptions).
+		Where("rsd.repository_id IN (?)", bun.In(repoIDs)).
+		Where("rsd.search_vector @@ "+documentTSQuery, s.searchConfiguration, text, cjk).
+		Scan(ctx, &rows)
+	if err != nil {
+		return nil, errorx.HandleDBError(err, errorx.Ctx().Set("repo_ids", repoIDs))
+	}
+	for _, row := range rows {
+		snippets[row.RepositoryID] = row.Snippet
+	}
+	return snippets, nil
+}
+
+// documentTSQuery matches the words of the search text with the search configuration, and the CJK n-grams
+// of the search text with the simple configuration, the arguments are the search configuration, the text
+// and the CJK query returned by splitSearchText
+const documentTSQuery = `(plainto_tsquery(?, ?) && to_tsquery('simple', ?))`
+
+// splitSearchText splits the search text into the non CJK text, and the tsquery of the CJK n-grams
+func splitSearchText(search string) (text, cjkQuery string) {
+	var (
+		b     strings.Builder
+		terms []string
+	)
+	for _, run := range splitCJKRuns(search, func(s string) { b.WriteString(s) }) {
+		runes := []rune(run)
+		if len(runes) == 1 {
+			// a single character is matched as the prefix of the n-grams
+			terms = append(terms, run+":*")
+			continue
+		}
+		terms = append(terms, bigrams(runes)...)
+	}
+	return strings.TrimSpace(b.String()), strings.Join(terms, " & ")
+}
+
+// cjkTerms returns the bigrams of the CJK text of the texts, the single characters are kept as they are
+func cjkTerms(texts ...string) []string {
+	var terms []string
+	seen := make(map[string]bool)
+	for _, text := range texts {
+		for _, run := range splitCJKRuns(text, nil) {
+			runes := []rune(run)
+			candidates := []string{run}
+			if len(runes) > 1 {
+				candidates = bigrams(runes)
+			}
+			for _, term := range candidates {
+				if !seen[term] {
+					seen[term] = true
+					terms = append(terms, term)
+				}
+			}
+		}
+	}
+	return terms
+}
+
+// splitCJKRuns returns the continuous CJK runs of the text, the other text is passed to other, separated by
+// spaces where the CJK runs were
+func splitCJKRuns(text string, other func(string)) []string {
+	var (
+		runs  []string
+		start = -1
+	)
+	for i, r := range text {
+		if isCJK(r) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		if start >= 0 {
+			runs = append(runs, text[start:i])
+			start = -1
+			if other != nil {
+				other(" ")
+			}
+		}
+		if other != nil {
+			other(string(r))
+		}
+	}
+	if start >= 0 {
+		runs = append(runs, text[start:])
+	}
+	return runs
+}
+
+func bigrams(runes []rune) []string {
+	grams := make([]string, 0, len(runes)-1)
+	for i := 0; i+1 < len(runes); i++ {
+		grams = append(grams, string(runes[i:i+2]))
+	}
+	return grams
+}
+
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
@@ -0,0 +1,23 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitSearchText(t *testing.T) {
+	text, cjk := splitSearchText("chinese 医疗问答 qa 药")
+	require.Equal(t, "chinese   qa", text)
+	require.Equal(t, "医疗 & 疗问 & 问答 & 药:*", cjk)
+
+	text, cjk = splitSearchText("medical qa")
+	require.Equal(t, "medical qa", text)
+	require.Empty(t, cjk)
+}
+
+func TestCJKTerms(t *testing.T) {
+	require.Equal(t, []string{"医疗", "疗问", "问答", "药", "数据", "据集"},
+		cjkTerms("医疗问答, 药", "医疗 数据集"))
+	require.Empty(t, cjkTerms("no cjk text"))
+}
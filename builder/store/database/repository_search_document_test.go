@@ -0,0 +1,65 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/config"
+	"opencsg.com/csghub-server/common/tests"
+)
+
+func TestRepositorySearchDocumentStore_UpsertAndSnippets(t *testing.T) {
+	db := tests.InitTestDB()
+	defer db.Close()
+	ctx := context.TODO()
+	cfg, err := config.LoadConfig()
+	require.Nil(t, err)
+	store := database.NewRepositorySearchDocumentStoreWithDB(db, cfg)
+
+	err = store.Upsert(ctx, &database.RepositorySearchDocument{
+		RepositoryID: 1,
+		Readme:       "A question answering dataset of medical conversations",
+		Card:         "question-answering zh",
+	})
+	require.Nil(t, err)
+	err = store.Upsert(ctx, &database.RepositorySearchDocument{
+		RepositoryID: 1,
+		Readme:       "中文医疗问答数据集, collected from medical forums",
+		Card:         "question-answering zh",
+	})
+	require.Nil(t, err)
+	err = store.Upsert(ctx, &database.RepositorySearchDocument{
+		RepositoryID: 2,
+		Readme:       "An image classification model",
+	})
+	require.Nil(t, err)
+
+	snippets, err := store.Snippets(ctx, []int64{1, 2}, "medical forums")
+	require.Nil(t, err)
+	require.Len(t, snippets, 1)
+	require.Contains(t, snippets[1], "<em>")
+
+	snippets, err = store.Snippets(ctx, []int64{1, 2}, "医疗问答")
+	require.Nil(t, err)
+	require.Len(t, snippets, 1)
+	require.Contains(t, snippets, int64(1))
+
+	// the repositories matched by a file path only also get a snippet
+	err = store.Upsert(ctx, &database.RepositorySearchDocument{
+		RepositoryID: 3,
+		Readme:       "An image classification model",
+		FilePaths:    "README.md tokenizer.json",
+	})
+	require.Nil(t, err)
+	snippets, err = store.Snippets(ctx, []int64{3}, "tokenizer")
+	require.Nil(t, err)
+	require.Contains(t, snippets[3], "<em>tokenizer</em>")
+
+	err = store.Delete(ctx, 1)
+	require.Nil(t, err)
+	snippets, err = store.Snippets(ctx, []int64{1, 2}, "medical")
+	require.Nil(t, err)
+	require.Empty(t, snippets)
+}
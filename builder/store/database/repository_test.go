@@ -1987,6 +1987,36 @@ func TestRepoStore_FindUnhashedRepos(t *testing.T) {
 	}
 }
 
+func TestRepoStore_FindAfterID(t *testing.T) {
+	db := tests.InitTestDB()
+	defer db.Close()
+	ctx := context.TODO()
+
+	rs := database.NewRepoStoreWithDB(db)
+	var ids []int64
+	for i := 0; i < 5; i++ {
+		repo, err := rs.CreateRepo(ctx, database.Repository{
+			UserID:         1,
+			Path:           fmt.Sprintf("ns/name_%d", i),
+			GitPath:        fmt.Sprintf("datasets_ns/name_%d", i),
+			Name:           fmt.Sprintf("name_%d", i),
+			RepositoryType: types.DatasetRepo,
+		})
+		require.Nil(t, err)
+		ids = append(ids, repo.ID)
+	}
+
+	repos, err := rs.FindAfterID(ctx, 2, ids[1])
+	require.Nil(t, err)
+	require.Equal(t, 2, len(repos))
+	require.Equal(t, ids[2], repos[0].ID)
+	require.Equal(t, ids[3], repos[1].ID)
+
+	repos, err = rs.FindAfterID(ctx, 2, ids[4])
+	require.Nil(t, err)
+	require.Empty(t, repos)
+}
+
 func TestRepoStore_PublicToUserWithCacheFailed(t *testing.T) {
 	db := tests.InitTestDB()
 	defer db.Close()
@@ -0,0 +1,226 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+)
+
+// RepositoryTrafficDaily is the daily hit count of a repository by the kind of the hits, the file path and
+// the region of the clients, the path is empty for the clones
+type RepositoryTrafficDaily struct {
+	RepositoryID int64     `bun:",pk" json:"repository_id"`
+	Date         time.Time `bun:",pk,type:date" json:"date"`
+	Kind         string    `bun:",pk" json:"kind"`
+	Path         string    `bun:",pk" json:"path"`
+	Region       string    `bun:",pk" json:"region"`
+	Count        int64     `bun:",notnull" json:"count"`
+	times
+}
+
+// RepositoryTrafficVisitor is a distinct user or anonymous client of a repository in a day, the region is the
+// region of the first hit of the day
+type RepositoryTrafficVisitor struct {
+	RepositoryID int64     `bun:",pk" json:"repository_id"`
+	Date         time.Time `bun:",pk,type:date" json:"date"`
+	VisitorID    string    `bun:",pk" json:"visitor_id"`
+	Region       string    `bun:",notnull" json:"region"`
+	times
+}
+
+type RepositoryTrafficCount struct {
+	Date  time.Time `bun:"date"`
+	Kind  string    `bun:"kind"`
+	Count int64     `bun:"count"`
+}
+
+type RepositoryTrafficVisitorCount struct {
+	Date  time.Time `bun:"date"`
+	Count int64     `bun:"count"`
+}
+
+type RepositoryTrafficFile struct {
+	Path  string `bun:"path"`
+	Count int64  `bun:"count"`
+}
+
+type RepositoryTrafficRegion struct {
+	Region   string `bun:"region"`
+	Count    int64  `bun:"count"`
+	Visitors int64  `bun:"visitors"`
+}
+
+type repositoryTrafficStoreImpl struct {
+	db *DB
+}
+
+// RepositoryTrafficStore keeps the daily traffic aggregates of the repositories, the date ranges of the
+// queries are inclusive
+type RepositoryTrafficStore interface {
+	// AddDailies adds the counts to the daily aggregates
+	AddDailies(ctx context.Context, items []RepositoryTrafficDaily) error
+	// AddVisitors saves the visitors, the existing visitors of the day are ignored
+	AddVisitors(ctx context.Context, items []RepositoryTrafficVisitor) error
+	// Counts returns the hit counts of the repository by date and kind
+	Counts(ctx context.Context, repoID int64, start, end time.Time) ([]RepositoryTrafficCount, error)
+	// VisitorCounts returns the distinct visitors of the repository by date
+	VisitorCounts(ctx context.Context, repoID int64, start, end time.Time) ([]RepositoryTrafficVisitorCount, error)
+	// UniqueVisitors returns the distinct visitors of the repository in the date range
+	UniqueVisitors(ctx context.Context, repoID int64, start, end time.Time) (int64, error)
+	// TopFiles returns the most downloaded files of the repository, the clones are excluded
+	TopFiles(ctx context.Context, repoID int64, start, end time.Time, limit int) ([]RepositoryTrafficFile, error)
+	// Regions returns the hits and the distinct visitors of the repository by region
+	Regions(ctx context.Context, repoID int64, start, end time.Time) ([]RepositoryTrafficRegion, error)
+	// Dailies returns the daily aggregates of the repository in the date order
+	Dailies(ctx context.Context, repoID int64, start, end time.Time) ([]RepositoryTrafficDaily, error)
+}
+
+func NewRepositoryTrafficStore() RepositoryTrafficStore {
+	return &repositoryTrafficStoreImpl{
+		db: defaultDB,
+	}
+}
+
+func NewRepositoryTrafficStoreWithDB(db *DB) RepositoryTrafficStore {
+	return &repositoryTrafficStoreImpl{
+		db: db,
+	}
+}
+
+func (s *repositoryTrafficStoreImpl) AddDailies(ctx context.Context, items []RepositoryTrafficDaily) error {
+	if len(items) == 0 {
+		return nil
+	}
+	_, err := s.db.Operator.Core.NewInsert().
+		Model(&items).
+		On("CONFLICT (repository_id, date, kind, path, region) DO UPDATE").
+		Set("count = repository_traffic_daily.count + EXCLUDED.count").
+		Set("updated_at = EXCLUDED.updated_at").
+		Exec(ctx)
+	return errorx.HandleDBError(err, nil)
+}
+
+func (s *repositoryTrafficStoreImpl) AddVisitors(ctx context.Context, items []RepositoryTrafficVisitor) error {
+	if len(items) == 0 {
+		return nil
+	}
+	_, err := s.db.Operator.Core.NewInsert().
+		Model(&items).
+		On("CONFLICT (repository_id, date, visitor_id) DO NOTHING").
+		Exec(ctx)
+	return errorx.HandleDBError(err, nil)
+}
+
+func (s *repositoryTrafficStoreImpl) Counts(ctx context.Context, repoID int64, start, end time.Time) ([]RepositoryTrafficCount, error) {
+	var counts []RepositoryTrafficCount
+	err := s.db.Operator.Core.NewSelect().
+		Model((*RepositoryTrafficDaily)(nil)).
+		Column("date", "kind").
+		ColumnExpr("SUM(count) AS count").
+		Where("repository_id = ?", repoID).
+		Where("date >= ? AND date <= ?", start.Format(time.DateOnly), end.Format(time.DateOnly)).
+		Group("date", "kind").
+		Order("date ASC").
+		Scan(ctx, &counts)
+	if err != nil {
+		return nil, errorx.HandleDBError(err, errorx.Ctx().Set("repo_id", repoID))
+	}
+	return counts, nil
+}
+
+func (s *repositoryTrafficStoreImpl) VisitorCounts(ctx context.Context, repoID int64, start, end time.Time) ([]RepositoryTrafficVisitorCount, error) {
+	var counts []RepositoryTrafficVisitorCount
+	err := s.db.Operator.Core.NewSelect().
+		Model((*RepositoryTrafficVisitor)(nil)).
+		Column("date").
+		ColumnExpr("COUNT(*) AS count").
+		Where("repository_id = ?", repoID).
+		Where("date >= ? AND date <= ?", start.Format(time.DateOnly), end.Format(time.DateOnly)).
+		Group("date").
+		Order("date ASC").
+		Scan(ctx, &counts)
+	if err != nil {
+		return nil, errorx.HandleDBError(err, errorx.Ctx().Set("repo_id", repoID))
+	}
+	return counts, nil
+}
+
+func (s *repositoryTrafficStoreImpl) UniqueVisitors(ctx context.Context, repoID int64, start, end time.Time) (int64, error) {
+	var count int64
+	err := s.db.Operator.Core.NewSelect().
+		Model((*RepositoryTrafficVisitor)(nil)).
+		ColumnExpr("COUNT(DISTINCT visitor_id)").
+		Where("repository_id = ?", repoID).
+		Where("date >= ? AND date <= ?", start.Format(time.DateOnly), end.Format(time.DateOnly)).
+		Scan(ctx, &count)
+	if err != nil {
+		return 0, errorx.HandleDBError(err, errorx.Ctx().Set("repo_id", repoID))
+	}
+	return count, nil
+}
+
+func (s *repositoryTrafficStoreImpl) TopFiles(ctx context.Context, repoID int64, start, end time.Time, limit int) ([]RepositoryTrafficFile, error) {
+	var files []RepositoryTrafficFile
+	err := s.db.Operator.Core.NewSelect().
+		Model((*RepositoryTrafficDaily)(nil)).
+		Column("path").
+		ColumnExpr("SUM(count) AS count").
+		Where("repository_id = ?", repoID).
+		Where("date >= ? AND date <= ?", start.Format(time.DateOnly), end.Format(time.DateOnly)).
+		Where("kind != ?", types.RepoTrafficClone).
+		Group("path").
+		OrderExpr("count DESC, path ASC").
+		Limit(limit).
+		Scan(ctx, &files)
+	if err != nil {
+		return nil, errorx.HandleDBError(err, errorx.Ctx().Set("repo_id", repoID))
+	}
+	return files, nil
+}
+
+func (s *repositoryTrafficStoreImpl) Regions(ctx context.Context, repoID int64, start, end time.Time) ([]RepositoryTrafficRegion, error) {
+	hits := s.db.Operator.Core.NewSelect().
+		Model((*RepositoryTrafficDaily)(nil)).
+		Column("region").
+		ColumnExpr("SUM(count) AS count").
+		Where("repository_id = ?", repoID).
+		Where("date >= ? AND date <= ?", start.Format(time.DateOnly), end.Format(time.DateOnly)).
+		Group("region")
+	visitors := s.db.Operator.Core.NewSelect().
+		Model((*RepositoryTrafficVisitor)(nil)).
+		Column("region").
+		ColumnExpr("COUNT(DISTINCT visitor_id) AS visitors").
+		Where("repository_id = ?", repoID).
+		Where("date >= ? AND date <= ?", start.Format(time.DateOnly), end.Format(time.DateOnly)).
+		Group("region")
+
+	var regions []RepositoryTrafficRegion
+	err := s.db.Operator.Core.NewSelect().
+		With("hits", hits).
+		With("visitors", visitors).
+		TableExpr("hits").
+		ColumnExpr("hits.region, hits.count, COALESCE(visitors.visitors, 0) AS visitors").
+		Join("LEFT JOIN visitors ON visitors.region = hits.region").
+		OrderExpr("hits.count DESC, hits.region ASC").
+		Scan(ctx, &regions)
+	if err != nil {
+		return nil, errorx.HandleDBError(err, errorx.Ctx().Set("repo_id", repoID))
+	}
+	return regions, nil
+}
+
+func (s *repositoryTrafficStoreImpl) Dailies(ctx context.Context, repoID int64, start, end time.Time) ([]RepositoryTrafficDaily, error) {
+	var dailies []RepositoryTrafficDaily
+	err := s.db.Operator.Core.NewSelect().
+		Model(&dailies).
+		Where("repository_id = ?", repoID).
+		Where("date >= ? AND date <= ?", start.Format(time.DateOnly), end.Format(time.DateOnly)).
+		Order("date ASC", "kind ASC", "path ASC", "region ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, errorx.HandleDBError(err, errorx.Ctx().Set("repo_id", repoID))
+	}
+	return dailies, nil
+}
@@ -0,0 +1,75 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/tests"
+)
+
+func TestRepositoryTrafficStore(t *testing.T) {
+	db := tests.InitTestDB()
+	defer db.Close()
+	ctx := context.TODO()
+
+	store := database.NewRepositoryTrafficStoreWithDB(db)
+
+	day1 := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	day2 := day1.AddDate(0, 0, 1)
+	dailies := []database.RepositoryTrafficDaily{
+		{RepositoryID: 1, Date: day1, Kind: "resolve", Path: "a.bin", Region: "Shanghai", Count: 2},
+		{RepositoryID: 1, Date: day1, Kind: "lfs", Path: "b.bin", Region: "Beijing", Count: 1},
+		{RepositoryID: 1, Date: day2, Kind: "clone", Path: "", Region: "Shanghai", Count: 4},
+		{RepositoryID: 2, Date: day1, Kind: "resolve", Path: "a.bin", Region: "Shanghai", Count: 9},
+	}
+	require.Nil(t, store.AddDailies(ctx, dailies))
+	// the counts are added to the existing rows
+	require.Nil(t, store.AddDailies(ctx, dailies[:1]))
+
+	visitors := []database.RepositoryTrafficVisitor{
+		{RepositoryID: 1, Date: day1, VisitorID: "user:a", Region: "Shanghai"},
+		{RepositoryID: 1, Date: day1, VisitorID: "user:b", Region: "Beijing"},
+		{RepositoryID: 1, Date: day2, VisitorID: "user:a", Region: "Shanghai"},
+	}
+	require.Nil(t, store.AddVisitors(ctx, visitors))
+	require.Nil(t, store.AddVisitors(ctx, visitors[:1]))
+
+	counts, err := store.Counts(ctx, 1, day1, day2)
+	require.Nil(t, err)
+	require.Len(t, counts, 3)
+	got := make(map[string]int64)
+	for _, count := range counts {
+		got[count.Date.Format(time.DateOnly)+"|"+count.Kind] = count.Count
+	}
+	require.Equal(t, map[string]int64{"2026-10-01|resolve": 4, "2026-10-01|lfs": 1, "2026-10-02|clone": 4}, got)
+
+	visitorCounts, err := store.VisitorCounts(ctx, 1, day1, day2)
+	require.Nil(t, err)
+	require.Len(t, visitorCounts, 2)
+	require.Equal(t, int64(2), visitorCounts[0].Count)
+	require.Equal(t, int64(1), visitorCounts[1].Count)
+
+	unique, err := store.UniqueVisitors(ctx, 1, day1, day2)
+	require.Nil(t, err)
+	require.Equal(t, int64(2), unique)
+
+	files, err := store.TopFiles(ctx, 1, day1, day2, 10)
+	require.Nil(t, err)
+	require.Equal(t, []database.RepositoryTrafficFile{{Path: "a.bin", Count: 4}, {Path: "b.bin", Count: 1}}, files)
+
+	regions, err := store.Regions(ctx, 1, day1, day2)
+	require.Nil(t, err)
+	require.Equal(t, []database.RepositoryTrafficRegion{
+		{Region: "Shanghai", Count: 8, Visitors: 1},
+		{Region: "Beijing", Count: 1, Visitors: 1},
+	}, regions)
+
+	rows, err := store.Dailies(ctx, 1, day1, day1)
+	require.Nil(t, err)
+	require.Len(t, rows, 2)
+	require.Equal(t, "lfs", rows[0].Kind)
+	require.Equal(t, "resolve", rows[1].Kind)
+}
@@ -0,0 +1,176 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/uptrace/bun"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+)
+
+type resourceSecretStoreImpl struct {
+	db *DB
+}
+
+type ResourceSecretStore interface {
+	ListByOwner(ctx context.Context, ownerType types.SecretOwnerType, ownerID int64) ([]ResourceSecret, error)
+	FindByKey(ctx context.Context, ownerType types.SecretOwnerType, ownerID int64, key string) (*ResourceSecret, error)
+	// Upsert creates the secret, or replaces the value of the existing secret with the same key
+	Upsert(ctx context.Context, secret *ResourceSecret) (*ResourceSecret, error)
+	// ReplaceByOwner replaces all the secrets of the owner
+	ReplaceByOwner(ctx context.Context, ownerType types.SecretOwnerType, ownerID int64, secrets []ResourceSecret) error
+	Delete(ctx context.Context, ownerType types.SecretOwnerType, ownerID int64, key string) error
+	// ListNotInKeyVersion returns the secrets encrypted with the other key versions after the id in the id order
+	ListNotInKeyVersion(ctx context.Context, keyVersion int, afterID int64, limit int) ([]ResourceSecret, error)
+	UpdateCiphertext(ctx context.Context, secret *ResourceSecret) error
+	// ImportSpaceSecrets saves the plain secrets stored in the space before the encryption was introduced,
+	// and clears them from the space. The secrets set since then are kept.
+	ImportSpaceSecrets(ctx context.Context, spaceID int64, secrets []ResourceSecret) error
+}
+
+func NewResourceSecretStore() ResourceSecretStore {
+	return &resourceSecretStoreImpl{
+		db: defaultDB,
+	}
+}
+
+func NewResourceSecretStoreWithDB(db *DB) ResourceSecretStore {
+	return &resourceSecretStoreImpl{
+		db: db,
+	}
+}
+
+// ResourceSecret is a secret of a space or a deploy, the value is encrypted with the master key of KeyVersion
+type ResourceSecret struct {
+	ID         int64                 `bun:",pk,autoincrement" json:"id"`
+	OwnerType  types.SecretOwnerType `bun:",notnull" json:"owner_type"`
+	OwnerID    int64                 `bun:",notnull" json:"owner_id"`
+	Key        string                `bun:",notnull" json:"key"`
+	Ciphertext string                `bun:",notnull" json:"-"`
+	KeyVersion int                   `bun:",notnull" json:"key_version"`
+	times
+}
+
+func (s *resourceSecretStoreImpl) ListByOwner(ctx context.Context, ownerType types.SecretOwnerType, ownerID int64) ([]ResourceSecret, error) {
+	var secrets []ResourceSecret
+	err := s.db.Core.NewSelect().Model(&secrets).
+		Where("owner_type = ? AND owner_id = ?", ownerType, ownerID).
+		Order("key ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, errorx.HandleDBError(err, errorx.Ctx().Set("owner_type", ownerType).Set("owner_id", ownerID))
+	}
+	return secrets, nil
+}
+
+func (s *resourceSecretStoreImpl) FindByKey(ctx context.Context, ownerType types.SecretOwnerType, ownerID int64, key string) (*ResourceSecret, error) {
+	secret := &ResourceSecret{}
+	err := s.db.Core.NewSelect().Model(secret).
+		Where("owner_type = ? AND owner_id = ? AND key = ?", ownerType, ownerID, key).
+		Scan(ctx)
+	if err != nil {
+		return nil, errorx.HandleDBError(err, errorx.Ctx().Set("owner_type", ownerType).Set("owner_id", ownerID).Set("key", key))
+	}
+	return secret, nil
+}
+
+func (s *resourceSecretStoreImpl) Upsert(ctx context.Context, secret *ResourceSecret) (*ResourceSecret, error) {
+	secret.UpdatedAt = time.Now()
+	_, err := s.db.Core.NewInsert().Model(secret).
+		On("CONFLICT (owner_type, owner_id, key) DO UPDATE").
+		Set("ciphertext = EXCLUDED.ciphertext").
+		Set("key_version = EXCLUDED.key_version").
+		Set("updated_at = EXCLUDED.updated_at").
+		Returning("*").
+		Exec(ctx, secret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upsert resource secret, error: %w",
+			errorx.HandleDBError(err, errorx.Ctx().Set("owner_type", secret.OwnerType).Set("owner_id", secret.OwnerID).Set("key", secret.Key)))
+	}
+	return secret, nil
+}
+
+func (s *resourceSecretStoreImpl) ReplaceByOwner(ctx context.Context, ownerType types.SecretOwnerType, ownerID int64, secrets []ResourceSecret) error {
+	err := s.db.Core.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+		_, err := tx.NewDelete().Model((*ResourceSecret)(nil)).
+			Where("owner_type = ? AND owner_id = ?", ownerType, ownerID).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+		if len(secrets) == 0 {
+			return nil
+		}
+		_, err = tx.NewInsert().Model(&secrets).Exec(ctx)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to replace resource secrets, error: %w",
+			errorx.HandleDBError(err, errorx.Ctx().Set("owner_type", ownerType).Set("owner_id", ownerID)))
+	}
+	return nil
+}
+
+func (s *resourceSecretStoreImpl) Delete(ctx context.Context, ownerType types.SecretOwnerType, ownerID int64, key string) error {
+	_, err := s.db.Core.NewDelete().Model((*ResourceSecret)(nil)).
+		Where("owner_type = ? AND owner_id = ? AND key = ?", ownerType, ownerID, key).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to delete resource secret, error: %w",
+			errorx.HandleDBError(err, errorx.Ctx().Set("owner_type", ownerType).Set("owner_id", ownerID).Set("key", key)))
+	}
+	return nil
+}
+
+func (s *resourceSecretStoreImpl) ListNotInKeyVersion(ctx context.Context, keyVersion int, afterID int64, limit int) ([]ResourceSecret, error) {
+	var secrets []ResourceSecret
+	err := s.db.Core.NewSelect().Model(&secrets).
+		Where("key_version <> ?", keyVersion).
+		Where("id > ?", afterID).
+		Order("id ASC").
+		Limit(limit).
+		Scan(ctx)
+	if err != nil {
+		return nil, errorx.HandleDBError(err, errorx.Ctx().Set("key_version", keyVersion))
+	}
+	return secrets, nil
+}
+
+func (s *resourceSecretStoreImpl) UpdateCiphertext(ctx context.Context, secret *ResourceSecret) error {
+	secret.UpdatedAt = time.Now()
+	_, err := s.db.Core.NewUpdate().Model(secret).
+		Column("ciphertext", "key_version", "updated_at").
+		WherePK().
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to update resource secret ciphertext, error: %w",
+			errorx.HandleDBError(err, errorx.Ctx().Set("id", secret.ID)))
+	}
+	return nil
+}
+
+func (s *resourceSecretStoreImpl) ImportSpaceSecrets(ctx context.Context, spaceID int64, secrets []ResourceSecret) error {
+	err := s.db.Core.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+		if len(secrets) > 0 {
+			_, err := tx.NewInsert().Model(&secrets).
+				On("CONFLICT (owner_type, owner_id, key) DO NOTHING").
+				Exec(ctx)
+			if err != nil {
+				return err
+			}
+		}
+		_, err := tx.NewUpdate().Model((*Space)(nil)).
+			Set("secrets = ?", "").
+			Where("id = ?", spaceID).
+			Exec(ctx)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to import space secrets, error: %w",
+			errorx.HandleDBError(err, errorx.Ctx().Set("space_id", spaceID)))
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/tests"
+	"opencsg.com/csghub-server/common/types"
+)
+
+func TestResourceSecretStore_CRUD(t *testing.T) {
+	db := tests.InitTestDB()
+	defer db.Close()
+	ctx := context.TODO()
+
+	store := database.NewResourceSecretStoreWithDB(db)
+	_, err := store.Upsert(ctx, &database.ResourceSecret{
+		OwnerType: types.SecretOwnerDeploy, OwnerID: 1, Key: "TOKEN", Ciphertext: "c1", KeyVersion: 1,
+	})
+	require.Nil(t, err)
+	_, err = store.Upsert(ctx, &database.ResourceSecret{
+		OwnerType: types.SecretOwnerDeploy, OwnerID: 1, Key: "TOKEN", Ciphertext: "c2", KeyVersion: 2,
+	})
+	require.Nil(t, err)
+	_, err = store.Upsert(ctx, &database.ResourceSecret{
+		OwnerType: types.SecretOwnerSpace, OwnerID: 1, Key: "TOKEN", Ciphertext: "c3", KeyVersion: 1,
+	})
+	require.Nil(t, err)
+
+	found, err := store.FindByKey(ctx, types.SecretOwnerDeploy, 1, "TOKEN")
+	require.Nil(t, err)
+	require.Equal(t, "c2", found.Ciphertext)
+	require.Equal(t, 2, found.KeyVersion)
+
+	err = store.ReplaceByOwner(ctx, types.SecretOwnerDeploy, 1, []database.ResourceSecret{
+		{OwnerType: types.SecretOwnerDeploy, OwnerID: 1, Key: "B", Ciphertext: "b", KeyVersion: 2},
+		{OwnerType: types.SecretOwnerDeploy, OwnerID: 1, Key: "A", Ciphertext: "a", KeyVersion: 2},
+	})
+	require.Nil(t, err)
+	secrets, err := store.ListByOwner(ctx, types.SecretOwnerDeploy, 1)
+	require.Nil(t, err)
+	require.Len(t, secrets, 2)
+	require.Equal(t, "A", secrets[0].Key)
+	require.Equal(t, "B", secrets[1].Key)
+
+	err = store.Delete(ctx, types.SecretOwnerDeploy, 1, "A")
+	require.Nil(t, err)
+	_, err = store.FindByKey(ctx, types.SecretOwnerDeploy, 1, "A")
+	require.ErrorIs(t, err, errorx.ErrDatabaseNoRows)
+
+	secrets, err = store.ListNotInKeyVersion(ctx, 2, 0, 10)
+	require.Nil(t, err)
+	require.Len(t, secrets, 1)
+	require.Equal(t, "c3", secrets[0].Ciphertext)
+
+	secrets[0].Ciphertext = "c4"
+	secrets[0].KeyVersion = 2
+	err = store.UpdateCiphertext(ctx, &secrets[0])
+	require.Nil(t, err)
+	secrets, err = store.ListNotInKeyVersion(ctx, 2, 0, 10)
+	require.Nil(t, err)
+	require.Empty(t, secrets)
+}
+
+func TestResourceSecretStore_ImportSpaceSecrets(t *testing.T) {
+	db := tests.InitTestDB()
+	defer db.Close()
+	ctx := context.TODO()
+
+	spaceStore := database.NewSpaceStoreWithDB(db)
+	repo := &database.Repository{Path: "ns/n", GitPath: "spaces_ns/n", Name: "n", RepositoryType: types.SpaceRepo}
+	_, err := db.Core.NewInsert().Model(repo).Exec(ctx)
+	require.Nil(t, err)
+	space, err := spaceStore.Create(ctx, database.Space{RepositoryID: repo.ID, Secrets: `{"TOKEN":"plain"}`})
+	require.Nil(t, err)
+
+	spaces, err := spaceStore.ListWithPlainSecrets(ctx, 0, 10)
+	require.Nil(t, err)
+	require.Len(t, spaces, 1)
+
+	store := database.NewResourceSecretStoreWithDB(db)
+	_, err = store.Upsert(ctx, &database.ResourceSecret{
+		OwnerType: types.SecretOwnerSpace, OwnerID: space.ID, Key: "TOKEN", Ciphertext: "newer", KeyVersion: 1,
+	})
+	require.Nil(t, err)
+	err = store.ImportSpaceSecrets(ctx, space.ID, []database.ResourceSecret{
+		{OwnerType: types.SecretOwnerSpace, OwnerID: space.ID, Key: "TOKEN", Ciphertext: "imported", KeyVersion: 1},
+		{OwnerType: types.SecretOwnerSpace, OwnerID: space.ID, Key: "OTHER", Ciphertext: "imported", KeyVersion: 1},
+	})
+	require.Nil(t, err)
+
+	found, err := store.FindByKey(ctx, types.SecretOwnerSpace, space.ID, "TOKEN")
+	require.Nil(t, err)
+	require.Equal(t, "newer", found.Ciphertext)
+	secrets, err := store.ListByOwner(ctx, types.SecretOwnerSpace, space.ID)
+	require.Nil(t, err)
+	require.Len(t, secrets, 2)
+
+	spaces, err = spaceStore.ListWithPlainSecrets(ctx, 0, 10)
+	require.Nil(t, err)
+	require.Empty(t, spaces)
+}
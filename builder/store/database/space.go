@@ -30,6 +30,8 @@ type SpaceStore interface {
 	ByOrgPath(ctx context.Context, namespace string, per, page int, onlyPublic bool) (spaces []Space, total int, err error)
 	ListByPath(ctx context.Context, paths []string) ([]Space, error)
 	CreateAndUpdateRepoPath(ctx context.Context, input Space, path string) (*Space, error)
+	// ListWithPlainSecrets returns the spaces still holding the plain secrets after the id in the id order
+	ListWithPlainSecrets(ctx context.Context, afterID int64, limit int) ([]Space, error)
 }
 
 func NewSpaceStore() SpaceStore {
@@ -218,6 +220,22 @@ func (s *spaceStoreImpl) ByOrgPath(ctx context.Context, namespace string, per, p
 	return
 }
 
+func (s *spaceStoreImpl) ListWithPlainSecrets(ctx context.Context, afterID int64, limit int) ([]Space, error) {
+	var spaces []Space
+	err := s.db.Operator.Core.
+		NewSelect().
+		Model(&spaces).
+		Where("secrets <> ''").
+		Where("id > ?", afterID).
+		Order("id ASC").
+		Limit(limit).
+		Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list spaces with plain secrets, error: %w", errorx.HandleDBError(err, nil))
+	}
+	return spaces, nil
+}
+
 func (s *spaceStoreImpl) ListByPath(ctx context.Context, paths []string) ([]Space, error) {
 	var spaces []Space
 	err := s.db.Operator.Core.
@@ -70,6 +70,15 @@ type RecomRepoScore struct {
 	times
 }
 
+// RecomRelatedRepo is the item-to-item recommendation score of a related repository, it's calculated
+// offline from the co-usage of the repositories
+type RecomRelatedRepo struct {
+	RepositoryID        int64   `bun:",pk" json:"repository_id"`
+	RelatedRepositoryID int64   `bun:",pk" json:"related_repository_id"`
+	Score               float64 `bun:",notnull" json:"score"`
+	times
+}
+
 /* tables for client events */
 type Event struct {
 	ID        int64     `bun:",pk,autoincrement" json:"id"`
@@ -32,6 +32,7 @@ type UserStore interface {
 	UpdateVerifyStatus(ctx context.Context, uuid string, status types.VerifyStatus) error
 	UpdateLabels(ctx context.Context, uuid string, labels []string) error
 	FindByUUIDs(ctx context.Context, uuids []string) ([]*User, error)
+	FindByUsernames(ctx context.Context, usernames []string) ([]User, error)
 	SoftDeleteUserAndRelations(ctx context.Context, input User, req types.CloseAccountReq) (err error)
 	IndexWithDeleted(ctx context.Context) (users []User, err error)
 	FindByUsernameWithDeleted(ctx context.Context, username string) (User, error)
@@ -476,6 +477,22 @@ func (s *UserStoreImpl) FindByUUIDs(ctx context.Context, uuids []string) ([]*Use
 	return users, nil
 }
 
+func (s *UserStoreImpl) FindByUsernames(ctx context.Context, usernames []string) ([]User, error) {
+	users := []User{}
+	if len(usernames) == 0 {
+		return users, nil
+	}
+
+	err := s.db.Operator.Core.NewSelect().
+		Model(&users).
+		Where("username IN (?)", bun.In(usernames)).
+		Scan(ctx)
+	if err != nil {
+		return nil, errorx.HandleDBError(err, nil)
+	}
+	return users, nil
+}
+
 func (s *UserStoreImpl) IndexWithDeleted(ctx context.Context) (users []User, err error) {
 	err = s.db.Operator.Core.NewSelect().Model(&users).WhereAllWithDeleted().Scan(ctx, &users)
 	err = errorx.HandleDBError(err, nil)
@@ -20,6 +20,10 @@ type UserLikesStore interface {
 	Delete(ctx context.Context, userId, repoId int64) error
 	IsExist(ctx context.Context, username string, repoId int64) (exists bool, err error)
 	IsExistCollection(ctx context.Context, username string, collectionId int64) (exists bool, err error)
+	// LikedRepoIDs returns the ids of the repositories liked by the user, the latest liked first
+	LikedRepoIDs(ctx context.Context, userID int64, limit int) ([]int64, error)
+	// Likers returns the users liking the repository
+	Likers(ctx context.Context, repoID int64) ([]User, error)
 }
 
 func NewUserLikesStore() UserLikesStore {
@@ -175,3 +179,25 @@ func (r *userLikesStoreImpl) IsExistCollection(ctx context.Context, username str
 		Exists(ctx)
 	return exists, errorx.HandleDBError(err, nil)
 }
+
+func (r *userLikesStoreImpl) LikedRepoIDs(ctx context.Context, userID int64, limit int) ([]int64, error) {
+	var repoIDs []int64
+	err := r.db.Operator.Core.NewSelect().Model((*UserLike)(nil)).
+		Column("repo_id").
+		Where("user_id = ? AND repo_id > 0", userID).
+		Order("id DESC").
+		Limit(limit).
+		Scan(ctx, &repoIDs)
+	return repoIDs, errorx.HandleDBError(err, nil)
+}
+
+func (r *userLikesStoreImpl) Likers(ctx context.Context, repoID int64) ([]User, error) {
+	var users []User
+	err := r.db.Operator.Core.NewSelect().Model(&users).
+		Where("id IN (?)", r.db.Operator.Core.NewSelect().Model((*UserLike)(nil)).
+			Column("user_id").
+			Where("repo_id = ?", repoID)).
+		Order("id").
+		Scan(ctx)
+	return users, errorx.HandleDBError(err, nil)
+}
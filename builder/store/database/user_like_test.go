@@ -162,6 +162,35 @@ func TestUserLikeStore_IsExist(t *testing.T) {
 	require.Equal(t, true, isExist)
 }
 
+func TestUserLikeStore_Likers(t *testing.T) {
+	db := tests.InitTestDB()
+	defer db.Close()
+	ctx := context.TODO()
+
+	ulikeStore := database.NewUserLikesStoreWithDB(db)
+	userStore := database.NewUserStoreWithDB(db)
+	repoStore := database.NewRepoStoreWithDB(db)
+
+	_, err := repoStore.CreateRepo(ctx, database.Repository{ID: 2})
+	require.Nil(t, err)
+	for _, user := range []database.User{
+		{ID: 1, Username: "u1", UUID: "uuid1"},
+		{ID: 2, Username: "u2", UUID: "uuid2"},
+	} {
+		err = userStore.Create(ctx, &user, &database.Namespace{Path: user.Username})
+		require.Nil(t, err)
+	}
+
+	require.Nil(t, ulikeStore.Add(ctx, 1, 2))
+	require.Nil(t, ulikeStore.Add(ctx, 2, 2))
+	require.Nil(t, ulikeStore.Delete(ctx, 2, 2))
+
+	users, err := ulikeStore.Likers(ctx, 2)
+	require.Nil(t, err)
+	require.Len(t, users, 1)
+	require.Equal(t, "uuid1", users[0].UUID)
+}
+
 func TestUserLikeStore_IsExistCollection(t *testing.T) {
 	db := tests.InitTestDB()
 	defer db.Close()
@@ -0,0 +1,53 @@
+package cron
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/spf13/cobra"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/config"
+	"opencsg.com/csghub-server/component"
+)
+
+var cmdCalcRelatedRepos = &cobra.Command{
+	Use:   "calc-related-repos",
+	Short: "the cmd to calculate the related repositories from the co-usage of the repositories",
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) (err error) {
+		config, err := config.LoadConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load config,%w", err)
+		}
+
+		dbConfig := database.DBConfig{
+			Dialect: database.DatabaseDialect(config.Database.Driver),
+			DSN:     config.Database.DSN,
+		}
+
+		if err := database.InitDB(dbConfig); err != nil {
+			slog.Error("failed to initialize database", slog.Any("error", err))
+			return fmt.Errorf("database initialization failed: %w", err)
+		}
+		ctx := context.WithValue(cmd.Context(), "config", config)
+		cmd.SetContext(ctx)
+		return
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		config, ok := ctx.Value("config").(*config.Config)
+		if !ok {
+			slog.Error("config not found in context")
+			return
+		}
+		c, err := component.NewRecomComponent(config)
+		if err != nil {
+			slog.Error("failed to create recom component", "err", err)
+			return
+		}
+		err = c.CalculateRelatedRepos(cmd.Context())
+		if err != nil {
+			slog.Error("failed to calculate related repos", "err", err)
+		}
+	},
+}
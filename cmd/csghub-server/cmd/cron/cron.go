@@ -7,6 +7,8 @@ import (
 func init() {
 	// add subcommands here
 	Cmd.AddCommand(cmdCalcRecomScore)
+	Cmd.AddCommand(cmdCalcRelatedRepos)
+	Cmd.AddCommand(cmdSendFeedDigest)
 	Cmd.AddCommand(cmdGenTelemetry)
 }
 
@@ -0,0 +1,53 @@
+package cron
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/spf13/cobra"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/config"
+	"opencsg.com/csghub-server/component"
+)
+
+var cmdSendFeedDigest = &cobra.Command{
+	Use:   "send-feed-digest",
+	Short: "the cmd to email the activity feed digests to the subscribed users",
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) (err error) {
+		config, err := config.LoadConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load config,%w", err)
+		}
+
+		dbConfig := database.DBConfig{
+			Dialect: database.DatabaseDialect(config.Database.Driver),
+			DSN:     config.Database.DSN,
+		}
+
+		if err := database.InitDB(dbConfig); err != nil {
+			slog.Error("failed to initialize database", slog.Any("error", err))
+			return fmt.Errorf("database initialization failed: %w", err)
+		}
+		ctx := context.WithValue(cmd.Context(), "config", config)
+		cmd.SetContext(ctx)
+		return
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		config, ok := ctx.Value("config").(*config.Config)
+		if !ok {
+			slog.Error("config not found in context")
+			return
+		}
+		c, err := component.NewFollowComponent(config)
+		if err != nil {
+			slog.Error("failed to create follow component", "err", err)
+			return
+		}
+		err = c.SendDigests(cmd.Context())
+		if err != nil {
+			slog.Error("failed to send feed digests", "err", err)
+		}
+	},
+}
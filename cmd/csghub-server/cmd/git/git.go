@@ -8,6 +8,7 @@ func init() {
 	Cmd.AddCommand(generateLfsMetaObjectsCmd)
 	Cmd.AddCommand(cloneProjectStorageCmd)
 	Cmd.AddCommand(replicateRepositoryCmd)
+	Cmd.AddCommand(lfsGCCmd)
 }
 
 var Cmd = &cobra.Command{
@@ -0,0 +1,69 @@
+package git
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/spf13/cobra"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/config"
+	"opencsg.com/csghub-server/common/types"
+	"opencsg.com/csghub-server/component"
+)
+
+var lfsGCReq = types.LfsGCReq{}
+
+func init() {
+	lfsGCCmd.Flags().BoolVar(&lfsGCReq.DryRun, "dry-run", false, "only report the objects to be marked and deleted")
+	lfsGCCmd.Flags().DurationVar(&lfsGCReq.GracePeriod, "grace-period", 0, "override the configured grace period, e.g. 72h")
+}
+
+var lfsGCCmd = &cobra.Command{
+	Use:   "lfs-gc",
+	Short: "delete the lfs objects no longer referenced by any repository",
+	Long: `Collect the lfs pointers in all repositories and mark the unreferenced lfs objects,
+the objects stayed marked for longer than the grace period are deleted from the storage.
+The report is printed as json.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) (err error) {
+		config, err := config.LoadConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load config,%w", err)
+		}
+
+		dbConfig := database.DBConfig{
+			Dialect: database.DatabaseDialect(config.Database.Driver),
+			DSN:     config.Database.DSN,
+		}
+
+		if err := database.InitDB(dbConfig); err != nil {
+			slog.Error("failed to initialize database", slog.Any("error", err))
+			return fmt.Errorf("database initialization failed: %w", err)
+		}
+		ctx := context.WithValue(cmd.Context(), "config", config)
+		cmd.SetContext(ctx)
+		return
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		config, ok := ctx.Value("config").(*config.Config)
+		if !ok {
+			return fmt.Errorf("config not found in context")
+		}
+
+		lfsGC, err := component.NewLfsGCComponent(config)
+		if err != nil {
+			return fmt.Errorf("failed to create lfs gc component, error: %w", err)
+		}
+		report, err := lfsGC.RunGC(ctx, lfsGCReq)
+		if err != nil {
+			return fmt.Errorf("failed to run lfs gc, error: %w", err)
+		}
+
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	},
+}
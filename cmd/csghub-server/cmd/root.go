@@ -22,6 +22,7 @@ import (
 	"opencsg.com/csghub-server/cmd/csghub-server/cmd/moderation"
 	"opencsg.com/csghub-server/cmd/csghub-server/cmd/notification"
 	"opencsg.com/csghub-server/cmd/csghub-server/cmd/scaffold"
+	"opencsg.com/csghub-server/cmd/csghub-server/cmd/secret"
 	"opencsg.com/csghub-server/cmd/csghub-server/cmd/start"
 	"opencsg.com/csghub-server/cmd/csghub-server/cmd/sync"
 	"opencsg.com/csghub-server/cmd/csghub-server/cmd/trigger"
@@ -80,6 +81,7 @@ func init() {
 		version.Cmd,
 		errorx.Cmd,
 		temporal_worker.Cmd,
+		secret.Cmd,
 	)
 
 	addCommands()
@@ -0,0 +1,78 @@
+package secret
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/spf13/cobra"
+	"opencsg.com/csghub-server/builder/secret"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/config"
+)
+
+var batchSize int
+
+func init() {
+	reencryptCmd.Flags().IntVar(&batchSize, "batch-size", 100, "the number of the secrets re-encrypted in a batch")
+}
+
+var reencryptCmd = &cobra.Command{
+	Use:   "reencrypt",
+	Short: "re-encrypt the secrets with the active master key",
+	Long: `Re-encrypt the secrets encrypted with the retired master keys using the active one, and import
+the plain space secrets stored before the encryption was introduced. Run it after changing
+secret.active_key_version, the retired keys must be kept in the config until it succeeds.
+The summary is printed as json.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) (err error) {
+		config, err := config.LoadConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load config,%w", err)
+		}
+
+		dbConfig := database.DBConfig{
+			Dialect: database.DatabaseDialect(config.Database.Driver),
+			DSN:     config.Database.DSN,
+		}
+
+		if err := database.InitDB(dbConfig); err != nil {
+			slog.Error("failed to initialize database", slog.Any("error", err))
+			return fmt.Errorf("database initialization failed: %w", err)
+		}
+		ctx := context.WithValue(cmd.Context(), "config", config)
+		cmd.SetContext(ctx)
+		return
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		config, ok := ctx.Value("config").(*config.Config)
+		if !ok {
+			return fmt.Errorf("config not found in context")
+		}
+		if batchSize <= 0 {
+			return fmt.Errorf("batch size must be positive")
+		}
+
+		manager, err := secret.NewManager(config)
+		if err != nil {
+			return fmt.Errorf("failed to create secret manager, error: %w", err)
+		}
+		res, err := manager.Reencrypt(ctx, batchSize)
+		if err != nil {
+			return fmt.Errorf("failed to re-encrypt secrets, error: %w", err)
+		}
+
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(res)
+		if err != nil {
+			return err
+		}
+		if res.Failed > 0 {
+			return fmt.Errorf("failed to re-encrypt %d secrets, see the logs for details", res.Failed)
+		}
+		return nil
+	},
+}
@@ -0,0 +1,17 @@
+package secret
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	Cmd.AddCommand(reencryptCmd)
+}
+
+var Cmd = &cobra.Command{
+	Use:   "secret",
+	Short: "space and deploy secrets related commands",
+	Run: func(cmd *cobra.Command, args []string) {
+		_ = cmd.Help()
+	},
+}
@@ -8,6 +8,7 @@ import (
 
 	"opencsg.com/csghub-server/moderation/checker"
 
+	"opencsg.com/csghub-server/builder/credential"
 	"opencsg.com/csghub-server/builder/deploy"
 	"opencsg.com/csghub-server/builder/deploy/common"
 	"opencsg.com/csghub-server/builder/deploy/imagebuilder"
@@ -21,6 +22,7 @@ import (
 	"go.temporal.io/sdk/client"
 	"go.temporal.io/sdk/log"
 	serverworkflow "opencsg.com/csghub-server/api/workflow"
+	"opencsg.com/csghub-server/builder/secret"
 	"opencsg.com/csghub-server/builder/store/database"
 	"opencsg.com/csghub-server/builder/temporal"
 	"opencsg.com/csghub-server/common/config"
@@ -138,7 +140,16 @@ var cmdLaunch = &cobra.Command{
 		urs := database.NewUserResourcesStore()
 		mds := database.NewMetadataStore()
 		cls := database.NewClusterInfoStore()
-		err = serverworkflow.StartDeployWorker(cmd.Context(), cfg, temporalClient, lr, ib, ir, gitserver, ds, ts, ss, ms, rfs, urs, mds, cls)
+		nss := database.NewNamespaceStore()
+		sm, err := secret.NewManager(cfg)
+		if err != nil {
+			return fmt.Errorf("failed to create secret manager, error: %w", err)
+		}
+		cb, err := credential.NewBroker(cfg)
+		if err != nil {
+			return fmt.Errorf("failed to create credential broker, error: %w", err)
+		}
+		err = serverworkflow.StartDeployWorker(cmd.Context(), cfg, temporalClient, lr, ib, ir, gitserver, ds, ts, ss, ms, rfs, urs, mds, cls, nss, sm, cb)
 		if err != nil {
 			return fmt.Errorf("failed to start deploy worker, error: %w", err)
 		}
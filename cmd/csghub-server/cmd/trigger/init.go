@@ -19,6 +19,7 @@ func init() {
 		fixRepoSourceCmd,
 		migrateRepoPathCmd,
 		calculateRepoSizeCmd,
+		searchDocumentsCmd,
 	)
 	addCommands()
 }
@@ -0,0 +1,73 @@
+package trigger
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/spf13/cobra"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/config"
+	"opencsg.com/csghub-server/component/callback"
+)
+
+var searchDocumentRepoIDs []int64
+
+func init() {
+	searchDocumentsCmd.Flags().Int64SliceVar(&searchDocumentRepoIDs, "repo-ids", nil,
+		"ids of the repositories to rebuild the search documents of, all the repositories by default")
+}
+
+// searchDocumentsCmd builds the full-text search documents of the existing repositories, the documents are
+// only refreshed by the pushes to the default branch
+var searchDocumentsCmd = &cobra.Command{
+	Use:   "search-documents",
+	Short: "rebuild the full-text search documents of repositories",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		config, err := config.LoadConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		gitCallback, err := callback.NewGitCallback(config)
+		if err != nil {
+			return fmt.Errorf("failed to create git callback component: %w", err)
+		}
+
+		ctx := context.Background()
+		rebuild := func(repo *database.Repository) {
+			if err := gitCallback.RebuildSearchDocument(ctx, repo); err != nil {
+				slog.Error("failed to rebuild search document, skip", slog.String("repo", repo.Path), slog.Any("error", err))
+				return
+			}
+			slog.Info("search document rebuilt", slog.String("repo", repo.Path), slog.String("type", string(repo.RepositoryType)))
+		}
+
+		if len(searchDocumentRepoIDs) > 0 {
+			for _, id := range searchDocumentRepoIDs {
+				repo, err := rs.FindById(ctx, id)
+				if err != nil {
+					slog.Error("fail to find repository, skip", slog.Int64("repo_id", id), slog.Any("error", err))
+					continue
+				}
+				rebuild(repo)
+			}
+			return nil
+		}
+
+		var lastRepoID int64
+		batch := 100
+		for {
+			repos, err := rs.BatchGet(ctx, lastRepoID, batch, nil)
+			if err != nil {
+				return err
+			}
+			for i := range repos {
+				rebuild(&repos[i])
+			}
+			if len(repos) < batch {
+				return nil
+			}
+			lastRepoID = repos[len(repos)-1].ID
+		}
+	},
+}
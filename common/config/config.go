@@ -266,6 +266,17 @@ type Config struct {
 		Servers string `env:"OPENCSG_SERVERS_KAFKA_SERVERS" default:""`
 	}
 
+	// MessageQueue selects the backend of the message queue, the queue embedded in the database
+	// serves the single node and air-gapped installs without nats or kafka
+	MessageQueue struct {
+		// valid values: nats, kafka, postgres, it's detected from the nats url and kafka servers if empty
+		Backend string `env:"STARHUB_SERVER_MESSAGE_QUEUE_BACKEND" default:""`
+		// consumers of the postgres backend poll the database in case of missed notifications and redeliveries
+		PollIntervalSeconds int `env:"STARHUB_SERVER_MESSAGE_QUEUE_POLL_INTERVAL_SECONDS" default:"5"`
+		// messages of the postgres backend are removed after the retention if the stream has no max age
+		RetentionHours int `env:"STARHUB_SERVER_MESSAGE_QUEUE_RETENTION_HOURS" default:"168"`
+	}
+
 	Accounting struct {
 		Host                         string `env:"OPENCSG_ACCOUNTING_SERVER_HOST" default:"http://localhost"`
 		Port                         int    `env:"OPENCSG_ACCOUNTING_SERVER_PORT" default:"8086"`
@@ -298,6 +309,11 @@ type Config struct {
 		VaultNamespace                   string `env:"OPENCSG_CREDENTIAL_VAULT_NAMESPACE" default:""`
 		VaultKVDefaultMount              string `env:"OPENCSG_CREDENTIAL_VAULT_KV_DEFAULT_MOUNT" default:"secret"`
 		VaultTimeoutSeconds              int    `env:"OPENCSG_CREDENTIAL_VAULT_TIMEOUT_SECONDS" default:"5"`
+
+		// Salt is the hkdf salt of the keys derived from the master key, at least 16 bytes
+		Salt string `env:"OPENCSG_CREDENTIAL_SALT" default:"opencsg-credential-salt"`
+		// RuntimeSessionDefaultDurationSeconds is used when a task grant does not ask for a duration
+		RuntimeSessionDefaultDurationSeconds int `env:"OPENCSG_CREDENTIAL_RUNTIME_SESSION_DEFAULT_DURATION_SECONDS" default:"900"`
 	}
 
 	MultiSync struct {
@@ -489,6 +505,41 @@ type Config struct {
 		WelcomeMessage string `env:"STARHUB_SERVER_GIT_WELCOME_MESSAGE" default:"Welcome to OpenCSG!"`
 	}
 
+	// StorageQuota limits the git and LFS bytes stored in each user or organization namespace
+	StorageQuota struct {
+		Enable bool `env:"STARHUB_SERVER_STORAGE_QUOTA_ENABLE" default:"false"`
+		// default quota in bytes, 0 means unlimited, admins can override it per namespace
+		DefaultUserQuota int64 `env:"STARHUB_SERVER_STORAGE_QUOTA_DEFAULT_USER" default:"107374182400"`
+		DefaultOrgQuota  int64 `env:"STARHUB_SERVER_STORAGE_QUOTA_DEFAULT_ORG" default:"1099511627776"`
+	}
+
+	// DeployAlert evaluates the alert rules defined by the users on the health metrics of their deploys
+	DeployAlert struct {
+		Enable         bool   `env:"STARHUB_SERVER_DEPLOY_ALERT_ENABLE" default:"false"`
+		CronExpression string `env:"STARHUB_SERVER_DEPLOY_ALERT_CRON_EXPRESSION" default:"* * * * *"`
+		BatchSize      int    `env:"STARHUB_SERVER_DEPLOY_ALERT_BATCH_SIZE" default:"100"`
+	}
+
+	// Secret encrypts the secrets of the spaces and deploys at rest
+	Secret struct {
+		// MasterKeysBase64 lists the master keys as version:base64-key entries, keep the retired
+		// versions until the secrets are re-encrypted. The default decodes to
+		// "opencsg-secret-dev-key-000000001" for local/dev use; override in production.
+		MasterKeysBase64 []string `env:"STARHUB_SERVER_SECRET_MASTER_KEYS_BASE64" default:"[1:b3BlbmNzZy1zZWNyZXQtZGV2LWtleS0wMDAwMDAwMDE=]"`
+		// ActiveKeyVersion is the version new secrets are encrypted with, run `secret reencrypt` after changing it
+		ActiveKeyVersion int    `env:"STARHUB_SERVER_SECRET_ACTIVE_KEY_VERSION" default:"1"`
+		Salt             string `env:"STARHUB_SERVER_SECRET_SALT" default:"opencsg-secret-salt"`
+	}
+
+	// LfsGC removes LFS objects which are no longer referenced by any repository
+	LfsGC struct {
+		Enable         bool   `env:"STARHUB_SERVER_LFS_GC_ENABLE" default:"false"`
+		CronExpression string `env:"STARHUB_SERVER_LFS_GC_CRON_EXPRESSION" default:"0 18 * * 6"`
+		// an object is deleted only after it stayed unreferenced for the whole grace period
+		GracePeriodHours int `env:"STARHUB_SERVER_LFS_GC_GRACE_PERIOD_HOURS" default:"168"`
+		BatchSize        int `env:"STARHUB_SERVER_LFS_GC_BATCH_SIZE" default:"1000"`
+	}
+
 	AIGateway struct {
 		Port                                 int    `env:"OPENCSG_AIGATEWAY_PORT" default:"8094"`
 		AdvertiseAddr                        string `env:"OPENCSG_AIGATEWAY_ADVERTISE_ADDR" default:""`
@@ -592,9 +643,13 @@ type Config struct {
 
 	MCPScan struct {
 		Enable bool `env:"STARHUB_SERVER_MCP_SCAN_ENABLE" default:"false"`
-		// tool_poison
+		// tool_poison, secret_detection, dangerous_code, hidden_instruction, vulnerable_dependency
 		Plugins     []string `env:"STARHUB_SERVER_MCP_SCAN_PLUGIN_OPTIONS" default:""`
 		Temperature float64  `env:"STARHUB_SERVER_MCP_SCAN_TEMPERATURE" default:"0.2"`
+		// lowest risk level kept in the scan report: low, medium, high or critical
+		ReportLevel string `env:"STARHUB_SERVER_MCP_SCAN_REPORT_LEVEL" default:"critical"`
+		// offline advisory database used by the vulnerable_dependency plugin
+		AdvisoryDBFile string `env:"STARHUB_SERVER_MCP_SCAN_ADVISORY_DB_FILE" default:""`
 	}
 
 	Notification struct {
@@ -650,6 +705,7 @@ type Config struct {
 		MemoryUsageMetric    string   `env:"STARHUB_SERVER_PROMETHEUS_MEMORY_USAGE_METRIC" default:"container_memory_usage_bytes"`
 		RequestCountMetric   string   `env:"STARHUB_SERVER_PROMETHEUS_REQUEST_COUNT_METRIC" default:"revision_request_count"`
 		RequestLatencyMetric string   `env:"STARHUB_SERVER_PROMETHEUS_REQUEST_LATENCY_METRIC" default:"revision_app_request_latencies_bucket"`
+		RestartCountMetric   string   `env:"STARHUB_SERVER_PROMETHEUS_RESTART_COUNT_METRIC" default:"kube_pod_container_status_restarts_total"`
 		MetricKeys           []string `env:"STARHUB_SERVER_PROMETHEUS_METRIC_KEYS" default:"[pod,service_name,namespace,http_response_status_code,response_code_class,le]"`
 	}
 
@@ -734,6 +790,14 @@ type Config struct {
 		LineSeparator          string `env:"STARHUB_SERVER_LOGCOLLECTOR_LINE_SEPARATOR" default:"\\n"`
 		MaxStoreTimeDay        int    `env:"STARHUB_SERVER_LOGCOLLECTOR_MAX_STORE_TIME_DAY" default:"7"`
 		QueryLastReportTimeout int    `env:"STARHUB_SERVER_LOGCOLLECTOR_QUERY_LAST_REPORT_TIMEOUT" default:"300"`
+		// the log storage backend, one of loki, elasticsearch, opensearch and file
+		Backend       string `env:"STARHUB_SERVER_LOGCOLLECTOR_BACKEND" default:"loki"`
+		ESURL         string `env:"STARHUB_SERVER_LOGCOLLECTOR_ES_URL" default:"http://localhost:9200"`
+		ESUsername    string `env:"STARHUB_SERVER_LOGCOLLECTOR_ES_USERNAME" default:""`
+		ESPassword    string `env:"STARHUB_SERVER_LOGCOLLECTOR_ES_PASSWORD" default:""`
+		ESIndexPrefix string `env:"STARHUB_SERVER_LOGCOLLECTOR_ES_INDEX_PREFIX" default:"csghub-logs"`
+		// the directory of the file backend, it must be shared by logcollector and the servers searching logs
+		FileDir string `env:"STARHUB_SERVER_LOGCOLLECTOR_FILE_DIR" default:"/var/log/csghub"`
 	}
 
 	FederationAdapter struct {
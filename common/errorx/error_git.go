@@ -48,6 +48,11 @@ const (
 	gitCreateForkFailed
 	gitGetArchiveFailed
 	gitInvalidURL
+	gitStorageQuotaExceeded
+	// git tag related errors
+	gitFindTagFailed
+	gitCreateTagFailed
+	gitDeleteTagFailed
 )
 
 var (
@@ -511,6 +516,54 @@ var (
 	//
 	// zh-HK: Git URL 無效
 	ErrGitInvalidURL error = CustomError{prefix: errGitPrefix, code: gitInvalidURL}
+	// the namespace storage quota is exceeded
+	//
+	// Description: The git and LFS storage used by the user or organization would exceed its storage quota. Remove unused repositories or files, or ask an administrator to raise the quota.
+	//
+	// Description_ZH: 用户或组织使用的 git 和 LFS 存储将超出其存储配额。请删除不再使用的仓库或文件，或联系管理员提高配额。
+	//
+	// en-US: Storage quota exceeded
+	//
+	// zh-CN: 存储配额已超出
+	//
+	// zh-HK: 存儲配額已超出
+	ErrGitStorageQuotaExceeded error = CustomError{prefix: errGitPrefix, code: gitStorageQuotaExceeded}
+	// failed to find git tags
+	//
+	// Description: An error occurred while listing or searching the tags of the repository.
+	//
+	// Description_ZH: 列出或查找仓库的标签时发生错误。
+	//
+	// en-US: Failed to find tag
+	//
+	// zh-CN: 查找标签失败
+	//
+	// zh-HK: 查找標籤失敗
+	ErrGitFindTagFailed error = CustomError{prefix: errGitPrefix, code: gitFindTagFailed}
+	// failed to create a git tag
+	//
+	// Description: The attempt to create a tag failed. The tag may already exist or the target revision can not be found.
+	//
+	// Description_ZH: 创建标签失败。标签可能已存在，或者找不到目标版本。
+	//
+	// en-US: Failed to create tag
+	//
+	// zh-CN: 创建标签失败
+	//
+	// zh-HK: 創建標籤失敗
+	ErrGitCreateTagFailed error = CustomError{prefix: errGitPrefix, code: gitCreateTagFailed}
+	// failed to delete a git tag
+	//
+	// Description: The attempt to delete a tag failed. The tag may not exist.
+	//
+	// Description_ZH: 删除标签失败。该标签可能不存在。
+	//
+	// en-US: Failed to delete tag
+	//
+	// zh-CN: 删除标签失败
+	//
+	// zh-HK: 刪除標籤失敗
+	ErrGitDeleteTagFailed error = CustomError{prefix: errGitPrefix, code: gitDeleteTagFailed}
 	// --- GIT-ERR-xxx: Git/Upload, Download, Resource Synchronization ---
 	// using git in xnet-enabled repository error
 	//
@@ -785,3 +838,38 @@ func GitInvalidURL(err error) error {
 		err:    err,
 	}
 }
+
+func StorageQuotaExceeded(ctx context) error {
+	return CustomError{
+		prefix:  errGitPrefix,
+		code:    gitStorageQuotaExceeded,
+		context: ctx,
+	}
+}
+
+func FindTagFailed(err error, ctx context) error {
+	return CustomError{
+		prefix:  errGitPrefix,
+		code:    gitFindTagFailed,
+		err:     err,
+		context: ctx,
+	}
+}
+
+func CreateTagFailed(err error, ctx context) error {
+	return CustomError{
+		prefix:  errGitPrefix,
+		code:    gitCreateTagFailed,
+		err:     err,
+		context: ctx,
+	}
+}
+
+func DeleteTagFailed(err error, ctx context) error {
+	return CustomError{
+		prefix:  errGitPrefix,
+		code:    gitDeleteTagFailed,
+		err:     err,
+		context: ctx,
+	}
+}
@@ -12,6 +12,8 @@ const (
 	codeCommitIDEmptyErr
 	codeTrafficInvalidErr
 	codeInvalidCommitIDErr
+	codeInferenceRolloutInProgressErr
+	codeInvalidRolloutStepsErr
 )
 
 var (
@@ -124,4 +126,26 @@ var (
 	//
 	//zh-HK: 沒有其他有效修訂版本
 	ErrNoOtherValidRevision error = CustomError{prefix: errServerlessPrefix, code: codeTrafficInvalidErr}
+
+	// Description: Another traffic rollout of the deploy is still running.
+	//
+	// Description_ZH: 该部署实例已有正在进行的流量发布
+	//
+	// en-US: A traffic rollout is already in progress for this deploy.
+	//
+	// zh-CN: 该部署实例已有正在进行的流量发布
+	//
+	// zh-HK: 該部署實例已有正在進行的流量發布
+	ErrInferenceRolloutInProgress error = CustomError{prefix: errServerlessPrefix, code: codeInferenceRolloutInProgressErr}
+
+	// Description: The rollout steps must be ascending traffic percents between 1 and 100 ending with 100.
+	//
+	// Description_ZH: 发布步骤必须是1到100之间递增的流量百分比, 且最后一步为100
+	//
+	// en-US: The rollout steps are invalid.
+	//
+	// zh-CN: 发布步骤无效
+	//
+	// zh-HK: 發布步驟無效
+	ErrInvalidRolloutSteps error = CustomError{prefix: errServerlessPrefix, code: codeInvalidRolloutStepsErr}
 )
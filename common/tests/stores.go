@@ -72,6 +72,21 @@ type MockStores struct {
 	AccountSyncQuotaStatement database.AccountSyncQuotaStatementStore
 	AccountPrice              database.AccountPriceStore
 	AgentTemplate             database.AgentTemplateStore
+	ModelFileScan             database.ModelFileScanStore
+	InferenceRollout          database.InferenceRolloutStore
+	NamespaceStorageQuota     database.NamespaceStorageQuotaStore
+	LfsGCObject               database.LfsGCObjectStore
+	DeployAlert               database.DeployAlertStore
+	ResourceSecret            database.ResourceSecretStore
+	Credential                database.CredentialStore
+	RepositorySearchDocument  database.RepositorySearchDocumentStore
+	Follow                    database.FollowStore
+	FeedEvent                 database.FeedEventStore
+	FeedDigestSubscription    database.FeedDigestSubscriptionStore
+	DiscussionLabel           database.DiscussionLabelStore
+	RepositoryImport          database.RepositoryImportStore
+	Release                   database.ReleaseStore
+	RepoAccess                database.RepoAccessStore
 }
 
 func NewMockStores(t interface {
@@ -144,6 +159,21 @@ func NewMockStores(t interface {
 		AccountSyncQuotaStatement: mockdb.NewMockAccountSyncQuotaStatementStore(t),
 		AccountPrice:              mockdb.NewMockAccountPriceStore(t),
 		AgentTemplate:             mockdb.NewMockAgentTemplateStore(t),
+		ModelFileScan:             mockdb.NewMockModelFileScanStore(t),
+		InferenceRollout:          mockdb.NewMockInferenceRolloutStore(t),
+		NamespaceStorageQuota:     mockdb.NewMockNamespaceStorageQuotaStore(t),
+		LfsGCObject:               mockdb.NewMockLfsGCObjectStore(t),
+		DeployAlert:               mockdb.NewMockDeployAlertStore(t),
+		ResourceSecret:            mockdb.NewMockResourceSecretStore(t),
+		Credential:                mockdb.NewMockCredentialStore(t),
+		RepositorySearchDocument:  mockdb.NewMockRepositorySearchDocumentStore(t),
+		Follow:                    mockdb.NewMockFollowStore(t),
+		FeedEvent:                 mockdb.NewMockFeedEventStore(t),
+		FeedDigestSubscription:    mockdb.NewMockFeedDigestSubscriptionStore(t),
+		DiscussionLabel:           mockdb.NewMockDiscussionLabelStore(t),
+		RepositoryImport:          mockdb.NewMockRepositoryImportStore(t),
+		Release:                   mockdb.NewMockReleaseStore(t),
+		RepoAccess:                mockdb.NewMockRepoAccessStore(t),
 	}
 }
 
@@ -406,3 +436,63 @@ func (s *MockStores) AccountSyncQuotaStatementMock() *mockdb.MockAccountSyncQuot
 func (s *MockStores) AccountPriceMock() *mockdb.MockAccountPriceStore {
 	return s.AccountPrice.(*mockdb.MockAccountPriceStore)
 }
+
+func (s *MockStores) ModelFileScanMock() *mockdb.MockModelFileScanStore {
+	return s.ModelFileScan.(*mockdb.MockModelFileScanStore)
+}
+
+func (s *MockStores) InferenceRolloutMock() *mockdb.MockInferenceRolloutStore {
+	return s.InferenceRollout.(*mockdb.MockInferenceRolloutStore)
+}
+
+func (s *MockStores) NamespaceStorageQuotaMock() *mockdb.MockNamespaceStorageQuotaStore {
+	return s.NamespaceStorageQuota.(*mockdb.MockNamespaceStorageQuotaStore)
+}
+
+func (s *MockStores) LfsGCObjectMock() *mockdb.MockLfsGCObjectStore {
+	return s.LfsGCObject.(*mockdb.MockLfsGCObjectStore)
+}
+
+func (s *MockStores) DeployAlertMock() *mockdb.MockDeployAlertStore {
+	return s.DeployAlert.(*mockdb.MockDeployAlertStore)
+}
+
+func (s *MockStores) ResourceSecretMock() *mockdb.MockResourceSecretStore {
+	return s.ResourceSecret.(*mockdb.MockResourceSecretStore)
+}
+
+func (s *MockStores) CredentialMock() *mockdb.MockCredentialStore {
+	return s.Credential.(*mockdb.MockCredentialStore)
+}
+
+func (s *MockStores) RepositorySearchDocumentMock() *mockdb.MockRepositorySearchDocumentStore {
+	return s.RepositorySearchDocument.(*mockdb.MockRepositorySearchDocumentStore)
+}
+
+func (s *MockStores) FollowMock() *mockdb.MockFollowStore {
+	return s.Follow.(*mockdb.MockFollowStore)
+}
+
+func (s *MockStores) FeedEventMock() *mockdb.MockFeedEventStore {
+	return s.FeedEvent.(*mockdb.MockFeedEventStore)
+}
+
+func (s *MockStores) FeedDigestSubscriptionMock() *mockdb.MockFeedDigestSubscriptionStore {
+	return s.FeedDigestSubscription.(*mockdb.MockFeedDigestSubscriptionStore)
+}
+
+func (s *MockStores) DiscussionLabelMock() *mockdb.MockDiscussionLabelStore {
+	return s.DiscussionLabel.(*mockdb.MockDiscussionLabelStore)
+}
+
+func (s *MockStores) RepositoryImportMock() *mockdb.MockRepositoryImportStore {
+	return s.RepositoryImport.(*mockdb.MockRepositoryImportStore)
+}
+
+func (s *MockStores) ReleaseMock() *mockdb.MockReleaseStore {
+	return s.Release.(*mockdb.MockReleaseStore)
+}
+
+func (s *MockStores) RepoAccessMock() *mockdb.MockRepoAccessStore {
+	return s.RepoAccess.(*mockdb.MockRepoAccessStore)
+}
@@ -49,6 +49,7 @@ func newBun(ctx context.Context, config database.DBConfig, useTxdb bool) (bunDB
 
 	bunDB.RegisterModel((*database.RepositoryTag)(nil))
 	bunDB.RegisterModel((*database.CollectionRepository)(nil))
+	bunDB.RegisterModel((*database.DiscussionLabelRelation)(nil))
 	return
 }
 
@@ -134,6 +134,7 @@ var (
 	SceneEvaluation           SceneType = 14 // model evaluation
 	SceneModelServerless      SceneType = 15 // serverless and external model text from aigateway
 	SceneMultiModalServerless SceneType = 16 // Multi modal model from aigateway for image/audio/video/ocr
+	SceneMCPToolCall          SceneType = 17 // mcp tool call from aigateway mcp gateway
 	// starship
 	SceneStarship SceneType = 20 // starship is deprecated
 	SceneGuiAgent SceneType = 22 // gui agent is deprecated
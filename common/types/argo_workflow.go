@@ -124,6 +124,8 @@ type ArgoWorkFlowReq struct {
 	FinetunedModelName string             `json:"finetuned_model_name,omitempty"`
 	Nodes              []Node             `json:"nodes"`
 	Scheduler          *Scheduler         `json:"scheduler,omitempty"`
+	// Secrets are loaded by all the templates from a kubernetes secret named after the task id
+	Secrets SecretValues `json:"secrets,omitempty"`
 
 	DeployExtend
 }
@@ -0,0 +1,88 @@
+package types
+
+// RepoCard is the structured metadata of the README front matter, the fields follow the model card and the
+// dataset card of Hugging Face, the fields not belonging to the repository type are always empty
+type RepoCard struct {
+	RepoType    RepositoryType `json:"repo_type"`
+	License     string         `json:"license,omitempty"`
+	LicenseName string         `json:"license_name,omitempty"`
+	LicenseLink string         `json:"license_link,omitempty"`
+	Language    []string       `json:"language,omitempty"`
+	Tags        []string       `json:"tags,omitempty"`
+
+	// model card
+	BaseModel []string `json:"base_model,omitempty"`
+	// BaseModelRelation is how the model derives from the base models, e.g. finetune, adapter, quantized or merge
+	BaseModelRelation string           `json:"base_model_relation,omitempty"`
+	Datasets          []string         `json:"datasets,omitempty"`
+	PipelineTag       string           `json:"pipeline_tag,omitempty"`
+	LibraryName       string           `json:"library_name,omitempty"`
+	ModelIndex        []CardModelIndex `json:"model_index,omitempty"`
+
+	// dataset card
+	TaskCategories []string            `json:"task_categories,omitempty"`
+	SizeCategories []string            `json:"size_categories,omitempty"`
+	Configs        []CardDatasetConfig `json:"configs,omitempty"`
+
+	// Warnings is the README metadata not matching the card schema, it's saved with the card so that the
+	// warnings of the README pushed by git are returned too
+	Warnings []CardWarning `json:"warnings,omitempty"`
+}
+
+// CardModelIndex is the evaluation results of a model, it's the model-index field of the model card
+type CardModelIndex struct {
+	Name    string           `json:"name"`
+	Results []CardEvalResult `json:"results"`
+}
+
+type CardEvalResult struct {
+	Task    CardEvalTask     `json:"task"`
+	Dataset CardEvalDataset  `json:"dataset"`
+	Metrics []CardEvalMetric `json:"metrics"`
+}
+
+type CardEvalTask struct {
+	Type string `json:"type"`
+	Name string `json:"name,omitempty"`
+}
+
+type CardEvalDataset struct {
+	Type   string `json:"type"`
+	Name   string `json:"name"`
+	Config string `json:"config,omitempty"`
+	Split  string `json:"split,omitempty"`
+}
+
+type CardEvalMetric struct {
+	Type string `json:"type"`
+	Name string `json:"name,omitempty"`
+	// Value is a number in most cards, some cards use strings like "85.2%"
+	Value    any  `json:"value"`
+	Verified bool `json:"verified,omitempty"`
+}
+
+// CardDatasetConfig is a subset of the dataset, it's an item of the configs field of the dataset card
+type CardDatasetConfig struct {
+	ConfigName string          `json:"config_name"`
+	Default    bool            `json:"default,omitempty"`
+	DataFiles  []CardDataFiles `json:"data_files"`
+}
+
+// CardDataFiles is the file patterns of a split, the split is empty if the data files are not split
+type CardDataFiles struct {
+	Split string   `json:"split,omitempty"`
+	Path  []string `json:"path"`
+}
+
+// CardWarning is a README metadata value not matching the card schema, it doesn't block the commit
+type CardWarning struct {
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+type GetRepoCardReq struct {
+	Namespace   string         `json:"-"`
+	Name        string         `json:"-"`
+	RepoType    RepositoryType `json:"-"`
+	CurrentUser string         `json:"-"`
+}
@@ -26,6 +26,7 @@ type Code struct {
 	Name                 string               `json:"name"`
 	Nickname             string               `json:"nickname"`
 	Description          string               `json:"description"`
+	SearchSnippet        string               `json:"search_snippet,omitempty"`
 	Likes                int64                `json:"likes"`
 	Downloads            int64                `json:"downloads"`
 	Path                 string               `json:"path"`
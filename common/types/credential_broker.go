@@ -2,6 +2,36 @@ package types
 
 import "time"
 
+const (
+	CredentialStatusActive = "active"
+	// CredentialSecretBackendPostgresEncrypted keeps the credential values encrypted in the credential_secrets table
+	CredentialSecretBackendPostgresEncrypted = "postgres_encrypted"
+	RuntimeCredentialTokenType               = "Bearer"
+)
+
+// the actions and results written to the credential audit log
+const (
+	CredentialAuditCreate  = "create"
+	CredentialAuditUpdate  = "update"
+	CredentialAuditRotate  = "rotate"
+	CredentialAuditDelete  = "delete"
+	CredentialAuditGrant   = "grant"
+	CredentialAuditRead    = "read"
+	CredentialAuditRefresh = "refresh"
+	CredentialAuditRevoke  = "revoke"
+
+	CredentialAuditSuccess = "success"
+	CredentialAuditDenied  = "denied"
+	CredentialAuditFailed  = "failed"
+)
+
+// CredentialOwnerReq locates the namespace of the credentials, which is the namespace of the current user
+// if Namespace is empty
+type CredentialOwnerReq struct {
+	CurrentUser string `json:"-"`
+	Namespace   string `json:"-"`
+}
+
 type Credential struct {
 	ID             int64          `json:"id"`
 	CredentialName string         `json:"credential_name"`
@@ -36,6 +36,7 @@ type Dataset struct {
 	Name                 string               `json:"name"`
 	Nickname             string               `json:"nickname"`
 	Description          string               `json:"description"`
+	SearchSnippet        string               `json:"search_snippet,omitempty"`
 	Likes                int64                `json:"likes"`
 	Downloads            int64                `json:"downloads"`
 	Path                 string               `json:"path"`
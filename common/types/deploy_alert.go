@@ -0,0 +1,124 @@
+package types
+
+import "time"
+
+// DeployAlertMetric is the deploy health metric watched by an alert rule
+type DeployAlertMetric string
+
+const (
+	// DeployAlertMetricErrorRate is the ratio of the 5xx requests in the window, between 0 and 1
+	DeployAlertMetricErrorRate DeployAlertMetric = "error_rate"
+	// DeployAlertMetricLatency is the p95 request latency in the window in milliseconds
+	DeployAlertMetricLatency DeployAlertMetric = "latency_p95"
+	// DeployAlertMetricRestarts is the number of the container restarts in the window
+	DeployAlertMetricRestarts DeployAlertMetric = "restarts"
+)
+
+func (m DeployAlertMetric) Valid() bool {
+	switch m {
+	case DeployAlertMetricErrorRate, DeployAlertMetricLatency, DeployAlertMetricRestarts:
+		return true
+	}
+	return false
+}
+
+// DeployAlertOperator compares the metric value with the threshold
+type DeployAlertOperator string
+
+const (
+	DeployAlertOperatorGT DeployAlertOperator = "gt"
+	DeployAlertOperatorLT DeployAlertOperator = "lt"
+)
+
+// Breached tells whether the value breaches the threshold
+func (o DeployAlertOperator) Breached(value, threshold float64) bool {
+	if o == DeployAlertOperatorLT {
+		return value < threshold
+	}
+	return value > threshold
+}
+
+// DeployAlertState is the state of an alert rule
+//
+// A rule goes pending once its threshold is breached, and fires when the breach lasts for the
+// duration of the rule. A firing rule is resolved back to ok once the threshold is no longer breached.
+type DeployAlertState string
+
+const (
+	DeployAlertStateOK       DeployAlertState = "ok"
+	DeployAlertStatePending  DeployAlertState = "pending"
+	DeployAlertStateFiring   DeployAlertState = "firing"
+	DeployAlertStateResolved DeployAlertState = "resolved"
+)
+
+const (
+	DefaultDeployAlertWindow = "5m"
+	// MaxDeployAlertRules is the max number of the alert rules of a deploy
+	MaxDeployAlertRules = 20
+)
+
+type DeployAlertRuleReq struct {
+	CurrentUser string `json:"-"`
+	DeployID    int64  `json:"-"`
+	RuleID      int64  `json:"-"`
+	Name        string `json:"name"`
+	// Metric is one of error_rate, latency_p95 and restarts
+	Metric DeployAlertMetric `json:"metric" binding:"required"`
+	// Operator is gt or lt, defaults to gt
+	Operator  DeployAlertOperator `json:"operator"`
+	Threshold float64             `json:"threshold"`
+	// DurationSeconds is how long the threshold must be breached before the alert fires, 0 fires at once
+	DurationSeconds int64 `json:"duration_seconds"`
+	// Window is the range of the rate and quantile queries in the prometheus format, defaults to 5m
+	Window  string `json:"window"`
+	Enabled *bool  `json:"enabled"`
+}
+
+type DeployAlertRule struct {
+	ID              int64               `json:"id"`
+	DeployID        int64               `json:"deploy_id"`
+	Name            string              `json:"name"`
+	Metric          DeployAlertMetric   `json:"metric"`
+	Operator        DeployAlertOperator `json:"operator"`
+	Threshold       float64             `json:"threshold"`
+	DurationSeconds int64               `json:"duration_seconds"`
+	Window          string              `json:"window"`
+	Enabled         bool                `json:"enabled"`
+	State           DeployAlertState    `json:"state"`
+	LastValue       float64             `json:"last_value"`
+	LastEvaluatedAt *time.Time          `json:"last_evaluated_at,omitempty"`
+	FiredAt         *time.Time          `json:"fired_at,omitempty"`
+	ResolvedAt      *time.Time          `json:"resolved_at,omitempty"`
+	CreatedAt       time.Time           `json:"created_at"`
+	UpdatedAt       time.Time           `json:"updated_at"`
+}
+
+type DeployAlertEventsReq struct {
+	CurrentUser string `json:"-"`
+	DeployID    int64  `json:"-"`
+	Per         int    `json:"per"`
+	Page        int    `json:"page"`
+}
+
+// DeployAlertEvent records an alert rule firing or being resolved
+type DeployAlertEvent struct {
+	ID        int64             `json:"id"`
+	RuleID    int64             `json:"rule_id"`
+	DeployID  int64             `json:"deploy_id"`
+	RuleName  string            `json:"rule_name"`
+	Metric    DeployAlertMetric `json:"metric"`
+	State     DeployAlertState  `json:"state"`
+	Value     float64           `json:"value"`
+	Threshold float64           `json:"threshold"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+// DeployAlertEvaluation summarizes a round of the alert rule evaluation
+type DeployAlertEvaluation struct {
+	Evaluated int `json:"evaluated"`
+	Fired     int `json:"fired"`
+	Resolved  int `json:"resolved"`
+	Failed    int `json:"failed"`
+	// Skipped counts the rules of deploys without a service yet
+	Skipped int `json:"skipped"`
+}
@@ -1,9 +1,29 @@
 package types
 
 import (
+	"slices"
 	"time"
 )
 
+type DiscussionStatus string
+
+const (
+	DiscussionStatusOpen   DiscussionStatus = "open"
+	DiscussionStatusClosed DiscussionStatus = "closed"
+	// DiscussionStatusLocked discussions only accept the comments and reactions of the repo admins
+	DiscussionStatusLocked DiscussionStatus = "locked"
+)
+
+// DiscussionReactions are the emoji allowed in the reactions of the discussion comments
+var DiscussionReactions = []string{"+1", "-1", "laugh", "hooray", "confused", "heart", "rocket", "eyes"}
+
+func IsValidDiscussionReaction(emoji string) bool {
+	return slices.Contains(DiscussionReactions, emoji)
+}
+
+// MaxDiscussionMentions is the max number of the users notified for the @username mentions in a comment
+const MaxDiscussionMentions = 20
+
 type CreateRepoDiscussionRequest struct {
 	Title       string         `json:"title" binding:"required"`
 	RepoType    RepositoryType `json:"-"`
@@ -33,8 +53,11 @@ type CreateDiscussionResponse struct {
 	Title string                   `json:"title"`
 	// DiscussionableID   int64     `json:"discussionable_id"`
 	// DiscussionableType string    `json:"discussionable_type"`
-	CommentCount int64     `json:"comment_count"`
-	CreatedAt    time.Time `json:"created_at"`
+	CommentCount int64             `json:"comment_count"`
+	Status       DiscussionStatus  `json:"status"`
+	Pinned       bool              `json:"pinned"`
+	Labels       []DiscussionLabel `json:"labels"`
+	CreatedAt    time.Time         `json:"created_at"`
 	// UpdatedAt    time.Time `json:"updated_at"`
 }
 
@@ -70,6 +93,9 @@ type ShowDiscussionResponse struct {
 	Title        string                   `json:"title"`
 	User         *DiscussionResponse_User `json:"user"`
 	CommentCount int64                    `json:"comment_count"`
+	Status       DiscussionStatus         `json:"status"`
+	Pinned       bool                     `json:"pinned"`
+	Labels       []DiscussionLabel        `json:"labels"`
 	Comments     *CommentsWithPagination  `json:"comments,omitempty"`
 }
 
@@ -81,17 +107,30 @@ type CommentsWithPagination struct {
 }
 
 type DiscussionResponse_Comment struct {
-	ID        int64                    `json:"id"`
-	Content   string                   `json:"content"`
-	User      *DiscussionResponse_User `json:"user"`
-	CreatedAt time.Time                `json:"created_at"`
+	ID      int64                    `json:"id"`
+	Content string                   `json:"content"`
+	User    *DiscussionResponse_User `json:"user"`
+	// Hidden comments have an empty content except for the repo admins and the author
+	Hidden    bool              `json:"hidden"`
+	Reactions []CommentReaction `json:"reactions,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+type CommentReaction struct {
+	Emoji string `json:"emoji"`
+	Count int    `json:"count"`
+	// Reacted is true if the current user reacted with the emoji
+	Reacted bool `json:"reacted"`
 }
 
 type ListRepoDiscussionRequest struct {
-	RepoType    RepositoryType `json:"-"`
-	Namespace   string         `json:"-"`
-	Name        string         `json:"-"`
-	CurrentUser string         `json:"-"`
+	RepoType    RepositoryType   `json:"-"`
+	Namespace   string           `json:"-"`
+	Name        string           `json:"-"`
+	CurrentUser string           `json:"-"`
+	Status      DiscussionStatus `json:"-" form:"status" binding:"omitempty,oneof=open closed locked"`
+	// Label filters the discussions by the label name
+	Label string `json:"-" form:"label"`
 }
 
 type ListRepoDiscussionResponse struct {
@@ -147,3 +186,83 @@ func (req *UpdateCommentRequest) GetSensitiveFields() []SensitiveField {
 		},
 	}
 }
+
+type DiscussionLabel struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Color       string `json:"color"`
+	Description string `json:"description"`
+}
+
+// DiscussionLabelRequest creates or updates a label of the repository RepoType/Namespace/Name
+type DiscussionLabelRequest struct {
+	RepoType    RepositoryType `json:"-"`
+	Namespace   string         `json:"-"`
+	Name        string         `json:"-"`
+	CurrentUser string         `json:"-"`
+	LabelID     int64          `json:"-"`
+	Label       string         `json:"label" binding:"required,max=50"`
+	Color       string         `json:"color" binding:"omitempty,hexcolor"`
+	Description string         `json:"description" binding:"max=255"`
+}
+
+// DiscussionLabelRequest implements SensitiveRequestV2
+var _ SensitiveRequestV2 = (*DiscussionLabelRequest)(nil)
+
+func (req *DiscussionLabelRequest) GetSensitiveFields() []SensitiveField {
+	return []SensitiveField{
+		{
+			Name: "label",
+			Value: func() string {
+				return req.Label
+			},
+			Scenario: ScenarioCommentDetection,
+		},
+		{
+			Name: "description",
+			Value: func() string {
+				return req.Description
+			},
+			Scenario: ScenarioCommentDetection,
+		},
+	}
+}
+
+type SetDiscussionLabelsRequest struct {
+	ID          int64   `json:"-"`
+	CurrentUser string  `json:"-"`
+	LabelIDs    []int64 `json:"label_ids"`
+}
+
+type UpdateDiscussionStatusRequest struct {
+	ID          int64            `json:"-"`
+	CurrentUser string           `json:"-"`
+	Status      DiscussionStatus `json:"status" binding:"required,oneof=open closed locked"`
+}
+
+type PinDiscussionRequest struct {
+	ID          int64  `json:"-"`
+	CurrentUser string `json:"-"`
+	Pinned      bool   `json:"pinned"`
+}
+
+// TransferDiscussionRequest moves a discussion to the repository RepoType/Namespace/Name
+type TransferDiscussionRequest struct {
+	ID          int64          `json:"-"`
+	CurrentUser string         `json:"-"`
+	RepoType    RepositoryType `json:"repo_type" binding:"required"`
+	Namespace   string         `json:"namespace" binding:"required"`
+	Name        string         `json:"name" binding:"required"`
+}
+
+type HideCommentRequest struct {
+	ID          int64  `json:"-"`
+	CurrentUser string `json:"-"`
+	Hidden      bool   `json:"hidden"`
+}
+
+type CommentReactionRequest struct {
+	CommentID   int64  `json:"-"`
+	CurrentUser string `json:"-"`
+	Emoji       string `json:"emoji" binding:"required"`
+}
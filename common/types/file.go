@@ -20,6 +20,8 @@ type File struct {
 	// whether file is previewable
 	PreviewCode FilePreviewCode `json:"preview_code,omitempty"`
 	XnetEnabled bool            `json:"xnet_enabled"`
+	// security verdict of model weight files, nil if the file has not been scanned
+	SecurityScan *ModelFileScanVerdict `json:"security_scan,omitempty"`
 }
 
 type CreateFileReq struct {
@@ -47,7 +49,11 @@ type CreateFileReq struct {
 	StartSha       string         `json:"start_sha"`
 }
 
-type CreateFileResp struct{}
+type CreateFileResp struct {
+	// CardWarnings is the README metadata not matching the card schema, it's only set for the README committed
+	// through the api, the warnings of the README pushed by git are returned by the card api
+	CardWarnings []CardWarning `json:"card_warnings,omitempty"`
+}
 
 type UpdateFileReq struct {
 	//will use login username, ignore username from http request body
@@ -310,6 +316,8 @@ const (
 
 type CommitFilesResp struct {
 	Files []string `json:"files"`
+	// CardWarnings is the README metadata not matching the card schema, see CreateFileResp
+	CardWarnings []CardWarning `json:"card_warnings,omitempty"`
 }
 
 type CommitHeader struct {
@@ -344,3 +352,30 @@ type CommitRequest struct {
 	LFSFiles []CommitLFSFile `json:"lfsFiles,omitempty"`
 	Files    []CommitFile    `json:"files,omitempty"`
 }
+
+// SearchRepoFileReq searches the files of all the repositories indexed in the repository_files table,
+// in the glob `*` matches any characters including `/` and `?` matches a single character
+type SearchRepoFileReq struct {
+	CurrentUser string         `json:"-"`
+	Glob        string         `json:"glob" form:"glob"`
+	Extension   string         `json:"extension" form:"extension"`
+	MinSize     int64          `json:"min_size" form:"min_size"`
+	MaxSize     int64          `json:"max_size" form:"max_size"`
+	LFS         *bool          `json:"lfs" form:"lfs"`
+	LfsOID      string         `json:"lfs_oid" form:"lfs_oid"`
+	RepoType    RepositoryType `json:"repo_type" form:"repo_type"`
+	Per         int            `json:"per"`
+	Page        int            `json:"page"`
+}
+
+type RepoFileSearchResult struct {
+	RepoType  RepositoryType `json:"repo_type"`
+	RepoPath  string         `json:"repo_path"`
+	Private   bool           `json:"private"`
+	Path      string         `json:"path"`
+	Size      int64          `json:"size"`
+	Branch    string         `json:"branch"`
+	CommitSha string         `json:"commit_sha"`
+	LFS       bool           `json:"lfs"`
+	LfsOID    string         `json:"lfs_oid,omitempty"`
+}
@@ -61,6 +61,10 @@ type FinetuneReq struct {
 	SwiftCommand       string  `json:"swift_command,omitempty"`
 	Agent              string  `json:"agent,omitempty"`
 	Nodes              []Node  `json:"-"`
+	// Publish optionally registers the trained weights in a model repo once the job succeeds
+	Publish *FinetunePublishConfig `json:"publish,omitempty"`
+	// Secrets are injected into the finetune pod as env variables through a kubernetes secret, they are not stored
+	Secrets SecretValues `json:"secrets,omitempty"`
 
 	DeployExtend
 }
@@ -104,3 +108,63 @@ type WorkflowLogReq struct {
 	SubmitTime  time.Time
 	TaskID      string
 }
+
+type FinetunePublishArtifact string
+
+const (
+	// FinetunePublishAdapter publishes the trained adapter weights only, e.g. LoRA
+	FinetunePublishAdapter FinetunePublishArtifact = "adapter"
+	// FinetunePublishMerged publishes the adapter merged into the base model weights
+	FinetunePublishMerged FinetunePublishArtifact = "merged"
+)
+
+type FinetunePublishStatus string
+
+const (
+	FinetunePublishPending    FinetunePublishStatus = "pending"
+	FinetunePublishPublishing FinetunePublishStatus = "publishing"
+	FinetunePublishPublished  FinetunePublishStatus = "published"
+	FinetunePublishFailed     FinetunePublishStatus = "failed"
+)
+
+// FinetuneCheckpointPrefix is the directory prefix trainers use for intermediate checkpoints
+const FinetuneCheckpointPrefix = "checkpoint-"
+
+type FinetunePublishConfig struct {
+	// TargetRepo is the model repo path in namespace/name format, created when it does not exist
+	TargetRepo string                  `json:"target_repo" binding:"required"`
+	Artifact   FinetunePublishArtifact `json:"artifact"`
+	// Checkpoints lists intermediate checkpoint directories to publish along with the final weights, e.g. checkpoint-500
+	Checkpoints []string `json:"checkpoints,omitempty"`
+	Private     bool     `json:"private"`
+	License     string   `json:"license,omitempty"`
+}
+
+type FinetunePublishReq struct {
+	ID          int64  `json:"-"`
+	CurrentUser string `json:"-"`
+	FinetunePublishConfig
+}
+
+type FinetuneHyperparameters struct {
+	Epochs       int     `json:"epochs"`
+	LearningRate float64 `json:"learning_rate"`
+	CustomArgs   string  `json:"custom_args,omitempty"`
+	SwiftCommand string  `json:"swift_command,omitempty"`
+}
+
+type FinetunePublishRes struct {
+	ID              int64                   `json:"id"`
+	TaskID          string                  `json:"task_id"`
+	TargetRepo      string                  `json:"target_repo"`
+	Artifact        FinetunePublishArtifact `json:"artifact"`
+	Checkpoints     []string                `json:"checkpoints"`
+	Status          FinetunePublishStatus   `json:"status"`
+	Reason          string                  `json:"reason,omitempty"`
+	PublishedFiles  int                     `json:"published_files"`
+	BaseModel       string                  `json:"base_model"`
+	Dataset         string                  `json:"dataset"`
+	Hyperparameters FinetuneHyperparameters `json:"hyperparameters"`
+	CreatedAt       time.Time               `json:"created_at"`
+	UpdatedAt       time.Time               `json:"updated_at"`
+}
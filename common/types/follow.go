@@ -0,0 +1,89 @@
+package types
+
+import "time"
+
+// FollowableType is the type of the entities which can be followed
+type FollowableType string
+
+const (
+	FollowableUser         FollowableType = "user"
+	FollowableOrganization FollowableType = "organization"
+	FollowableRepository   FollowableType = "repository"
+)
+
+// FeedEventType is the type of the events in the personal activity feed
+type FeedEventType string
+
+const (
+	// FeedEventRepoCreated is a new repository created in the namespace of a user or an organization
+	FeedEventRepoCreated FeedEventType = "repo_created"
+	// FeedEventTagCreated is a new git tag pushed to a repository
+	FeedEventTagCreated FeedEventType = "tag_created"
+	// FeedEventSpaceDeployed is a new version of a space deployed and running
+	FeedEventSpaceDeployed FeedEventType = "space_deployed"
+	// FeedEventDiscussionCreated is a new discussion opened on a repository
+	FeedEventDiscussionCreated FeedEventType = "discussion_created"
+	// FeedEventReleaseCreated is a new release published on a repository
+	FeedEventReleaseCreated FeedEventType = "release_created"
+)
+
+const (
+	// FeedDigestLookback is the max time range of the events in the first digest of a user
+	FeedDigestLookback = 7 * 24 * time.Hour
+	// MaxFeedDigestEvents is the max number of the events in a digest email
+	MaxFeedDigestEvents = 20
+)
+
+// FollowReq identifies the entity to follow, Name is the username or the organization path for the users
+// and the organizations, and the repository name along with RepoType and Namespace for the repositories
+type FollowReq struct {
+	CurrentUser string         `json:"-"`
+	Type        FollowableType `json:"type" form:"type" binding:"required,oneof=user organization repository"`
+	RepoType    RepositoryType `json:"repo_type" form:"repo_type"`
+	Namespace   string         `json:"namespace" form:"namespace"`
+	Name        string         `json:"name" form:"name" binding:"required"`
+}
+
+// Following is an entity followed by a user
+type Following struct {
+	Type FollowableType `json:"type"`
+	// username, organization path or repository path
+	Path      string         `json:"path"`
+	Nickname  string         `json:"nickname,omitempty"`
+	Avatar    string         `json:"avatar,omitempty"`
+	RepoType  RepositoryType `json:"repo_type,omitempty"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+type Follower struct {
+	Username  string    `json:"username"`
+	Nickname  string    `json:"nickname"`
+	Avatar    string    `json:"avatar"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type FollowStats struct {
+	Followers   int  `json:"followers"`
+	Following   int  `json:"following"`
+	IsFollowing bool `json:"is_following"`
+}
+
+type FeedEvent struct {
+	ID        int64          `json:"id"`
+	Type      FeedEventType  `json:"type"`
+	Actor     string         `json:"actor,omitempty"`
+	RepoType  RepositoryType `json:"repo_type"`
+	RepoPath  string         `json:"repo_path"`
+	Payload   map[string]any `json:"payload,omitempty"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+type FeedDigestSetting struct {
+	Enabled    bool       `json:"enabled"`
+	LastSentAt *time.Time `json:"last_sent_at,omitempty"`
+}
+
+type UpdateFeedDigestSettingReq struct {
+	CurrentUser string `json:"-"`
+	Enabled     bool   `json:"enabled"`
+}
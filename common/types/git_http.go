@@ -135,6 +135,14 @@ type ObjectError struct {
 	Message string `json:"message"`
 }
 
+// BatchError is the body of a failed LFS batch response
+// https://github.com/git-lfs/git-lfs/blob/main/docs/api/batch.md#response-errors
+type BatchError struct {
+	Message       string `json:"message"`
+	Documentation string `json:"documentation_url,omitempty"`
+	RequestID     string `json:"request_id,omitempty"`
+}
+
 type SSHAllowedReq struct {
 	Namespace     string         `json:"namespace"`
 	Name          string         `json:"name"`
@@ -0,0 +1,81 @@
+package types
+
+import "time"
+
+// HFListReposReq is the query of huggingface_hub list_models and list_datasets
+type HFListReposReq struct {
+	Search string   `form:"search"`
+	Author string   `form:"author"`
+	Filter []string `form:"filter"`
+	// Sort is one of downloads, likes, lastModified, createdAt and trending_score
+	Sort  string `form:"sort"`
+	Limit int    `form:"limit"`
+	// Page is the page number used by the next page link
+	Page int `form:"p"`
+}
+
+type HFRepoInfo struct {
+	ID           string    `json:"id"`
+	Author       string    `json:"author,omitempty"`
+	Private      bool      `json:"private"`
+	Downloads    int64     `json:"downloads"`
+	Likes        int64     `json:"likes"`
+	Tags         []string  `json:"tags"`
+	PipelineTag  string    `json:"pipeline_tag,omitempty"`
+	LibraryName  string    `json:"library_name,omitempty"`
+	CreatedAt    time.Time `json:"createdAt"`
+	LastModified time.Time `json:"lastModified"`
+}
+
+type HFGitRef struct {
+	Name         string `json:"name"`
+	Ref          string `json:"ref"`
+	TargetCommit string `json:"targetCommit"`
+}
+
+// HFGitRefs is the response of huggingface_hub list_repo_refs
+type HFGitRefs struct {
+	Branches []HFGitRef `json:"branches"`
+	Converts []HFGitRef `json:"converts"`
+	Tags     []HFGitRef `json:"tags"`
+}
+
+type HFCommitAuthor struct {
+	User string `json:"user"`
+}
+
+// HFGitCommit is an item of the response of huggingface_hub list_repo_commits
+type HFGitCommit struct {
+	ID      string           `json:"id"`
+	Title   string           `json:"title"`
+	Message string           `json:"message"`
+	Authors []HFCommitAuthor `json:"authors"`
+	Date    string           `json:"date"`
+}
+
+type HFCreateBranchReq struct {
+	StartingPoint string `json:"startingPoint"`
+}
+
+type HFCreateTagReq struct {
+	Tag     string `json:"tag" binding:"required"`
+	Message string `json:"message"`
+}
+
+type HFRepoSettingsReq struct {
+	Private *bool `json:"private"`
+}
+
+type HFDeleteRepoReq struct {
+	Name         string `json:"name" binding:"required"`
+	Organization string `json:"organization"`
+	// Type is model, dataset or space, default to model
+	Type string `json:"type"`
+}
+
+type HFMoveRepoReq struct {
+	FromRepo string `json:"fromRepo" binding:"required"`
+	ToRepo   string `json:"toRepo" binding:"required"`
+	// Type is model, dataset or space, default to model
+	Type string `json:"type"`
+}
@@ -1,10 +1,23 @@
 package types
 
+// ImportProvider is the remote platform repositories are imported from
+type ImportProvider string
+
+const (
+	ImportProviderGitLab     ImportProvider = "gitlab"
+	ImportProviderGitHub     ImportProvider = "github"
+	ImportProviderGitea      ImportProvider = "gitea"
+	ImportProviderModelScope ImportProvider = "modelscope"
+)
+
 type ImportReq struct {
+	// Provider defaults to gitlab for the clients created before the other providers were supported
+	Provider    ImportProvider  `json:"provider"`
 	ImportRepos []ImportBaseReq `json:"import_repos"`
 	CurrentUser string          `json:"-"`
-	BaseURL     string          `json:"base_url" validate:"required,url"`
-	AccessToken string          `json:"access_token" validate:"required"`
+	// BaseURL defaults to the public site of the provider
+	BaseURL     string `json:"base_url" validate:"omitempty,url"`
+	AccessToken string `json:"access_token" validate:"required"`
 }
 
 type ImportSingleRepoReq struct {
@@ -21,6 +34,8 @@ type ImportBaseReq struct {
 	Path       string `json:"path"`
 	SourcePath string `json:"source_path"`
 	Private    bool   `json:"private"`
+	// RepoType of the local repository, defaults to model for modelscope and code for the git providers
+	RepoType RepositoryType `json:"repo_type"`
 }
 
 type GetGitlabReposReq struct {
@@ -32,6 +47,16 @@ type GetGitlabReposReq struct {
 	Page        int
 }
 
+type GetRemoteReposReq struct {
+	CurrentUser string         `json:"-"`
+	Provider    ImportProvider `json:"provider" binding:"required,oneof=gitlab github gitea modelscope"`
+	BaseURL     string         `json:"base_url" binding:"omitempty,url"`
+	AccessToken string         `json:"access_token" binding:"required"`
+	Search      string         `json:"search"`
+	Per         int            `json:"per"`
+	Page        int            `json:"page"`
+}
+
 type ImportStatusReq struct {
 	CurrentUser string `json:"-"`
 	Per         int    `json:"per"`
@@ -39,9 +64,16 @@ type ImportStatusReq struct {
 }
 
 type ImportedRepository struct {
-	SourcePath string `json:"source_path"`
-	LocalPath  string `json:"local_path"`
-	Status     string `json:"status"`
+	Provider   ImportProvider `json:"provider"`
+	RepoType   RepositoryType `json:"repo_type"`
+	SourcePath string         `json:"source_path"`
+	LocalPath  string         `json:"local_path"`
+	Status     string         `json:"status"`
+	// Phase is the stage of the transfer, the repository is transferred before the lfs files
+	Phase MirrorSyncPhase `json:"phase,omitempty"`
+	// Result is set once the transfer is finished, e.g. success or failed
+	Result   MirrorSyncResult `json:"result,omitempty"`
+	Progress int              `json:"progress"`
 }
 
 type ImportStatusResp struct {
@@ -50,8 +82,12 @@ type ImportStatusResp struct {
 }
 
 type RemoteRepository struct {
-	Path      string `json:"path"`
-	Name      string `json:"name"`
-	SourceURL string `json:"source_url"`
-	Private   bool   `json:"private"`
+	Path          string   `json:"path"`
+	Name          string   `json:"name"`
+	SourceURL     string   `json:"source_url"`
+	Private       bool     `json:"private"`
+	Description   string   `json:"description"`
+	Topics        []string `json:"topics"`
+	License       string   `json:"license"`
+	DefaultBranch string   `json:"default_branch"`
 }
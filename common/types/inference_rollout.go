@@ -0,0 +1,73 @@
+package types
+
+import "time"
+
+// InferenceRolloutStatus is the state of a progressive traffic rollout of an inference version
+type InferenceRolloutStatus string
+
+const (
+	InferenceRolloutRunning    InferenceRolloutStatus = "running"
+	InferenceRolloutSucceeded  InferenceRolloutStatus = "succeeded"
+	InferenceRolloutRolledBack InferenceRolloutStatus = "rolled_back"
+	InferenceRolloutCancelled  InferenceRolloutStatus = "cancelled"
+	InferenceRolloutFailed     InferenceRolloutStatus = "failed"
+)
+
+// IsFinished reports whether the rollout reached a final state
+func (s InferenceRolloutStatus) IsFinished() bool {
+	return s != InferenceRolloutRunning
+}
+
+var (
+	// DefaultInferenceRolloutSteps are the canary traffic percents used when none are given
+	DefaultInferenceRolloutSteps = []int{5, 25, 50, 100}
+	// DefaultInferenceRolloutStepInterval is the default observation window of every step in seconds
+	DefaultInferenceRolloutStepInterval = 300
+	// MinInferenceRolloutStepInterval keeps the observation window larger than the prometheus scrape interval
+	MinInferenceRolloutStepInterval = 60
+)
+
+type CreateInferenceRolloutReq struct {
+	CurrentUser string `json:"-"`
+	DeployID    int64  `json:"-"`
+	// CommitID is the canary version, a new revision is created if it is not deployed yet
+	CommitID string `json:"commit_id" binding:"required"`
+	// StableCommitID is the version traffic falls back to, defaults to the version serving the most traffic
+	StableCommitID string `json:"stable_commit_id"`
+	// Steps are the ascending canary traffic percents, the last one must be 100
+	Steps []int `json:"steps"`
+	// StepInterval is the time in seconds to observe the canary before moving to the next step
+	StepInterval int `json:"step_interval"`
+	// MaxErrorRate is the highest ratio of 5xx responses of the canary in [0, 1], 0 disables the check
+	MaxErrorRate float64 `json:"max_error_rate"`
+	// MaxLatencyMs is the highest p95 request latency of the canary in milliseconds, 0 disables the check
+	MaxLatencyMs float64 `json:"max_latency_ms"`
+}
+
+type InferenceRollout struct {
+	ID             int64                  `json:"id"`
+	DeployID       int64                  `json:"deploy_id"`
+	StableCommit   string                 `json:"stable_commit"`
+	CanaryCommit   string                 `json:"canary_commit"`
+	Steps          []int                  `json:"steps"`
+	StepInterval   int                    `json:"step_interval"`
+	MaxErrorRate   float64                `json:"max_error_rate"`
+	MaxLatencyMs   float64                `json:"max_latency_ms"`
+	CurrentStep    int                    `json:"current_step"`
+	CurrentTraffic int                    `json:"current_traffic"`
+	Status         InferenceRolloutStatus `json:"status"`
+	Reason         string                 `json:"reason,omitempty"`
+	// ErrorRate and LatencyMs are the canary metrics observed by the last health check
+	ErrorRate float64   `json:"error_rate"`
+	LatencyMs float64   `json:"latency_ms"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// InferenceRolloutHealth is the result of checking the canary metrics against the rollout thresholds
+type InferenceRolloutHealth struct {
+	Healthy   bool    `json:"healthy"`
+	Reason    string  `json:"reason,omitempty"`
+	ErrorRate float64 `json:"error_rate"`
+	LatencyMs float64 `json:"latency_ms"`
+}
@@ -0,0 +1,43 @@
+package types
+
+import "time"
+
+type LfsGCReq struct {
+	// DryRun only reports what would be marked and deleted, nothing is changed
+	DryRun bool `json:"dry_run"`
+	// GracePeriod overrides the configured grace period when it's positive
+	GracePeriod time.Duration `json:"grace_period"`
+}
+
+type LfsGCObject struct {
+	ObjectKey string    `json:"object_key"`
+	Oid       string    `json:"oid"`
+	Size      int64     `json:"size"`
+	MarkedAt  time.Time `json:"marked_at"`
+}
+
+type LfsGCReport struct {
+	DryRun     bool      `json:"dry_run"`
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+
+	ScannedRepos int `json:"scanned_repos"`
+	// FailedRepos are the repositories whose LFS pointers can't be listed,
+	// objects which may belong to them are kept
+	FailedRepos       []string `json:"failed_repos,omitempty"`
+	ReferencedObjects int      `json:"referenced_objects"`
+
+	ScannedObjects      int   `json:"scanned_objects"`
+	ScannedBytes        int64 `json:"scanned_bytes"`
+	UnreferencedObjects int   `json:"unreferenced_objects"`
+	UnreferencedBytes   int64 `json:"unreferenced_bytes"`
+	NewlyMarked         int   `json:"newly_marked"`
+	// Unmarked are the marked objects which are referenced again or gone from the storage
+	Unmarked int `json:"unmarked"`
+
+	// DeletedObjects are the objects unreferenced for longer than the grace period,
+	// in a dry run they are the objects which would be deleted
+	DeletedObjects  []LfsGCObject `json:"deleted_objects,omitempty"`
+	DeletedBytes    int64         `json:"deleted_bytes"`
+	FailedDeletions int           `json:"failed_deletions"`
+}
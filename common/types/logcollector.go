@@ -168,3 +168,60 @@ var (
 	ClientTypeCSGHUB       ClientType = "csghub"
 	ClientTypeLogCollector ClientType = "logcollector"
 )
+
+const (
+	LogSearchForward  = "forward"
+	LogSearchBackward = "backward"
+
+	// LogSearchTypeBuild selects the image build logs of a deploy instead of the run logs
+	LogSearchTypeBuild = "build"
+)
+
+// LogSearchQuery filters the logs of a log backend, entries are paged by the timestamp cursor
+type LogSearchQuery struct {
+	Labels map[string]string
+	Start  time.Time
+	End    time.Time
+	// Text is matched as a substring of the line
+	Text string
+	// Regex is evaluated by the backend, Elasticsearch uses the Lucene regex syntax
+	Regex string
+	Level LogLevel
+	Pod   string
+	Limit int
+	// Cursor is the timestamp in nanoseconds of the last entry of the previous page
+	Cursor    string
+	Direction string
+}
+
+type LogSearchEntry struct {
+	Timestamp time.Time         `json:"timestamp"`
+	Pod       string            `json:"pod,omitempty"`
+	Level     LogLevel          `json:"level,omitempty"`
+	Line      string            `json:"line"`
+	Labels    map[string]string `json:"-"`
+}
+
+type LogSearchResult struct {
+	Entries []LogSearchEntry `json:"entries"`
+	// NextCursor is empty on the last page
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// LogSearchReq searches the logs of a deploy or a workflow
+type LogSearchReq struct {
+	ID          int64  `json:"-"`
+	CurrentUser string `json:"-"`
+	// LogType is build for the image build logs of a deploy, the run logs are searched by default
+	LogType   string    `form:"log_type"`
+	CommitID  string    `form:"commit_id"`
+	Start     time.Time `form:"start" time_format:"2006-01-02T15:04:05Z07:00"`
+	End       time.Time `form:"end" time_format:"2006-01-02T15:04:05Z07:00"`
+	Text      string    `form:"text"`
+	Regex     string    `form:"regex"`
+	Level     LogLevel  `form:"level"`
+	Pod       string    `form:"pod"`
+	Limit     int       `form:"limit"`
+	Cursor    string    `form:"cursor"`
+	Direction string    `form:"direction"`
+}
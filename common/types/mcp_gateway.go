@@ -124,3 +124,77 @@ type GatewayMCPServerInspectTarget struct {
 	Headers    map[string]any `json:"headers,omitempty"`
 	ConfigHash string         `json:"config_hash"`
 }
+
+// MCPToolPolicyAction is the effect of a gateway MCP tool policy.
+type MCPToolPolicyAction string
+
+const (
+	MCPToolPolicyAllow MCPToolPolicyAction = "allow"
+	MCPToolPolicyDeny  MCPToolPolicyAction = "deny"
+)
+
+// MCPToolPolicyWildcard matches every tool of the bound MCP servers.
+const MCPToolPolicyWildcard = "*"
+
+// MCPGatewayCallStatus is the outcome of a call proxied by the aggregated MCP endpoint.
+type MCPGatewayCallStatus string
+
+const (
+	MCPGatewayCallSuccess MCPGatewayCallStatus = "success"
+	MCPGatewayCallError   MCPGatewayCallStatus = "error"
+	MCPGatewayCallDenied  MCPGatewayCallStatus = "denied"
+)
+
+// MCP methods recorded in the gateway call log.
+const (
+	MCPMethodCallTool     = "tools/call"
+	MCPMethodReadResource = "resources/read"
+	MCPMethodGetPrompt    = "prompts/get"
+)
+
+// CreateMCPToolPolicyReq binds an allow or deny rule for a prefixed tool name to an API key.
+// Tool may be "*" or end with "*" to match every tool with that prefix, e.g. "github_*".
+type CreateMCPToolPolicyReq struct {
+	APIKeyID    int64               `json:"api_key_id" binding:"required"`
+	Tool        string              `json:"tool" binding:"required"`
+	Action      MCPToolPolicyAction `json:"action" binding:"required,oneof=allow deny"`
+	CurrentUser string              `json:"-"`
+}
+
+// MCPToolPolicy is the view of a tool policy bound to an API key.
+type MCPToolPolicy struct {
+	ID        int64               `json:"id"`
+	Tool      string              `json:"tool"`
+	Action    MCPToolPolicyAction `json:"action"`
+	CreatedAt time.Time           `json:"created_at"`
+}
+
+// MCPGatewayCaller identifies who opened an aggregated MCP session.
+// APIKey is the key the session is authorized with, it is only used to find its access token.
+type MCPGatewayCaller struct {
+	Username      string
+	NamespaceUUID string
+	APIKey        string
+}
+
+// MCPGatewayCallLogFilter represents filters for listing aggregated MCP call logs.
+type MCPGatewayCallLogFilter struct {
+	NamespaceUUID string               `json:"-"`
+	ServerName    string               `json:"server_name" form:"server_name"`
+	Status        MCPGatewayCallStatus `json:"status" form:"status"`
+	Per           int                  `json:"-"`
+	Page          int                  `json:"-"`
+}
+
+// MCPGatewayCallLog is one tool call, resource read or prompt get proxied by the aggregated MCP endpoint.
+type MCPGatewayCallLog struct {
+	ID         int64                `json:"id"`
+	SessionID  string               `json:"session_id"`
+	ServerName string               `json:"server_name"`
+	Method     string               `json:"method"`
+	Target     string               `json:"target"`
+	Status     MCPGatewayCallStatus `json:"status"`
+	Error      string               `json:"error,omitempty"`
+	LatencyMs  int64                `json:"latency_ms"`
+	CreatedAt  time.Time            `json:"created_at"`
+}
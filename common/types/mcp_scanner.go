@@ -22,6 +22,27 @@ type ScannerIssue struct {
 	Description string `json:"description"`
 	Level       Level  `json:"level"`
 	Suggestion  string `json:"suggestion"`
+	// Line is the 1-based line of the finding, static plugins only
+	Line int `json:"line,omitempty"`
+}
+
+// levelRank orders risk levels from low to critical
+var levelRank = map[Level]int{
+	LevelLow:      1,
+	LevelMedium:   2,
+	LevelHigh:     3,
+	LevelCritical: 4,
+}
+
+// Valid reports whether l is a known risk level
+func (l Level) Valid() bool {
+	_, ok := levelRank[l]
+	return ok
+}
+
+// AtLeast reports whether l is as severe as min
+func (l Level) AtLeast(min Level) bool {
+	return levelRank[l] >= levelRank[min]
 }
 
 var TextFileExtensions = []string{
@@ -53,5 +74,9 @@ var TextFileExtensions = []string{
 type PluginName string
 
 const (
-	ToolPoisoningPluginName PluginName = "tool_poisoning"
+	ToolPoisoningPluginName        PluginName = "tool_poisoning"
+	SecretDetectionPluginName      PluginName = "secret_detection"
+	DangerousCodePluginName        PluginName = "dangerous_code"
+	HiddenInstructionPluginName    PluginName = "hidden_instruction"
+	VulnerableDependencyPluginName PluginName = "vulnerable_dependency"
 )
@@ -19,6 +19,9 @@ type CreateMirrorReq struct {
 	CurrentUser     string         `json:"current_user"`
 	RepoType        RepositoryType `json:"repo_type"`
 	SyncLfs         bool           `json:"sync_lfs"`
+	// CredentialName is a credential of the namespace the source is synced with, it can not be used with
+	// Username and AccessToken
+	CredentialName string `json:"credential_name,omitempty"`
 	// Urgent routes the next synchronization through the urgent mirror queues.
 	Urgent bool `json:"urgent,omitempty"`
 	// SkipSourcePath skips setting HFPath/MSPath/GithubPath on the repository.
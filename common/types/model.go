@@ -172,6 +172,7 @@ type Model struct {
 	Name          string     `json:"name"`
 	Nickname      string     `json:"nickname"`
 	Description   string     `json:"description"`
+	SearchSnippet string     `json:"search_snippet,omitempty"`
 	Likes         int64      `json:"likes"`
 	Downloads     int64      `json:"downloads"`
 	Path          string     `json:"path"`
@@ -290,6 +291,9 @@ type ModelRunReq struct {
 	PD *PDConfig `json:"pd,omitempty"`
 	// OwnerNamespace is optional. If set, the inference is created under this namespace (user or org) for billing and listing; path {namespace} remains the model's owner.
 	OwnerNamespace string `json:"owner_namespace,omitempty"`
+	// CredentialNames are the credentials of the owner namespace injected into the inference as secrets,
+	// e.g. the token of the credential hf-prod is injected as HF_PROD_TOKEN
+	CredentialNames []string `json:"credential_names,omitempty"`
 }
 
 var _ SensitiveRequestV2 = (*ModelRunReq)(nil)
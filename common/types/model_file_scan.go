@@ -0,0 +1,76 @@
+package types
+
+import (
+	"path"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ModelFileScanStatus is the security verdict of a model weight file
+type ModelFileScanStatus string
+
+const (
+	// ModelFileScanSafe means no dangerous content was found
+	ModelFileScanSafe ModelFileScanStatus = "safe"
+	// ModelFileScanSuspicious means the file imports callables that are not known to be safe
+	ModelFileScanSuspicious ModelFileScanStatus = "suspicious"
+	// ModelFileScanUnsafe means the file can run arbitrary code when loaded
+	ModelFileScanUnsafe ModelFileScanStatus = "unsafe"
+	// ModelFileScanInvalid means the file structure is malformed
+	ModelFileScanInvalid ModelFileScanStatus = "invalid"
+	// ModelFileScanSkipped means the file format is not supported by the scanner
+	ModelFileScanSkipped ModelFileScanStatus = "skipped"
+	// ModelFileScanError means the file could not be read
+	ModelFileScanError ModelFileScanStatus = "error"
+)
+
+// ModelFileFormat is the serialization format detected by the model file scanner
+type ModelFileFormat string
+
+const (
+	ModelFileFormatPickle      ModelFileFormat = "pickle"
+	ModelFileFormatPyTorchZip  ModelFileFormat = "pytorch_zip"
+	ModelFileFormatSafetensors ModelFileFormat = "safetensors"
+	ModelFileFormatGGUF        ModelFileFormat = "gguf"
+	ModelFileFormatUnknown     ModelFileFormat = "unknown"
+)
+
+// ModelScanFileExts are the file extensions checked by the model file scanner
+var ModelScanFileExts = []string{".bin", ".pt", ".pth", ".pkl", ".pickle", ".ckpt", ".safetensors", ".gguf"}
+
+// IsModelScanFile reports whether the file is a model weight file checked by the model file scanner
+func IsModelScanFile(filePath string) bool {
+	ext := path.Ext(filePath)
+	return slices.ContainsFunc(ModelScanFileExts, func(modelExt string) bool {
+		return strings.EqualFold(ext, modelExt)
+	})
+}
+
+// ModelFileScanFinding is a single problem found in a model file
+type ModelFileScanFinding struct {
+	// Entry is the pickle entry inside a pytorch zip archive, empty for plain files
+	Entry string `json:"entry,omitempty"`
+	// Module and Name are the callable imported by the pickle stream
+	Module   string              `json:"module,omitempty"`
+	Name     string              `json:"name,omitempty"`
+	Severity ModelFileScanStatus `json:"severity"`
+	Message  string              `json:"message"`
+}
+
+// ModelFileScanResult is the result of scanning a single model file
+type ModelFileScanResult struct {
+	Status   ModelFileScanStatus    `json:"status"`
+	Format   ModelFileFormat        `json:"format"`
+	Message  string                 `json:"message,omitempty"`
+	Findings []ModelFileScanFinding `json:"findings,omitempty"`
+}
+
+// ModelFileScanVerdict is the per-file verdict shown in the repo file tree
+type ModelFileScanVerdict struct {
+	Status    ModelFileScanStatus    `json:"status"`
+	Format    ModelFileFormat        `json:"format"`
+	Message   string                 `json:"message,omitempty"`
+	Findings  []ModelFileScanFinding `json:"findings,omitempty"`
+	ScannedAt time.Time              `json:"scanned_at"`
+}
@@ -9,6 +9,7 @@ const (
 	RelationAdapter              ModelRelation     = "adapter"
 	RelationMerge                ModelRelation     = "merge"
 	RelationQuantized            ModelRelation     = "quantized"
+	RelationTrainingDataset      ModelRelation     = "dataset"
 	RelationAdd                  RelationOperation = "add"
 	RelationDelete               RelationOperation = "delete"
 	MetaDataKeyTag               string            = "task"
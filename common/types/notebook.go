@@ -10,6 +10,8 @@ type CreateNotebookReq struct {
 	MinReplica         int    `json:"min_replica" validate:"min=0"`
 	RuntimeFrameworkID int64  `json:"runtime_framework_id"`
 	OrderDetailID      int64  `json:"order_detail_id"`
+	// CredentialNames are the credentials of the owner namespace injected into the notebook as secrets
+	CredentialNames []string `json:"credential_names,omitempty"`
 }
 
 type NotebookRes struct {
@@ -193,4 +193,165 @@ const (
 	// @PayloadFields user_email, user_name, language, event_type, issue_id, issue_title, comment
 	// @BuildTags saas
 	MessageScenarioGitlabIssueFeedback MessageScenario = "gitlab-issue-feedback"
+
+	// storage quota usage warning notification
+	// @Scenario storage-quota
+	// @Channels internal-message, email
+	// @PayloadFields namespace, percent, used_bytes, quota_bytes
+	// @Template {
+	//  "email": {
+	//    "en-US": {
+	//      "title": "Storage of {{.namespace}} is {{.percent}}% full",
+	//      "content": "The repositories of {{.namespace}} use {{.percent}}% of the storage quota. Pushes and uploads will be rejected once the quota is exceeded."
+	//    },
+	//    "zh-CN": {
+	//      "title": "{{.namespace}} 的存储已使用 {{.percent}}%",
+	//      "content": "{{.namespace}} 的仓库已使用存储配额的 {{.percent}}%，超出配额后推送和上传将被拒绝。"
+	//    },
+	//    "zh-HK": {
+	//      "title": "{{.namespace}} 的存儲已使用 {{.percent}}%",
+	//      "content": "{{.namespace}} 的倉庫已使用存儲配額的 {{.percent}}%，超出配額後推送和上傳將被拒絕。"
+	//    },
+	//  },
+	// }
+	// @BuildTags ce
+	MessageScenarioStorageQuota MessageScenario = "storage-quota"
+
+	// deploy alert rule firing or resolved notification
+	// @Scenario deploy-alert
+	// @Channels internal-message, email
+	// @PayloadFields deploy_name, deploy_id, deploy_type, git_path, rule_name, metric, operator, value, threshold, state
+	// @Template {
+	//  "email": {
+	//    "en-US": {
+	//      "title": "Alert {{.rule_name}} of {{.deploy_name}} is {{.state}}",
+	//      "content": "The {{.metric}} of {{.deploy_name}} is {{.value}}, the alert threshold is {{.operator}} {{.threshold}}."
+	//    },
+	//    "zh-CN": {
+	//      "title": "{{.deploy_name}} 的告警 {{.rule_name}}{{if eq .state \"firing\"}}已触发{{else}}已恢复{{end}}",
+	//      "content": "{{.deploy_name}} 的 {{.metric}} 当前为 {{.value}}，告警阈值为 {{.operator}} {{.threshold}}。"
+	//    },
+	//    "zh-HK": {
+	//      "title": "{{.deploy_name}} 的告警 {{.rule_name}}{{if eq .state \"firing\"}}已觸發{{else}}已恢復{{end}}",
+	//      "content": "{{.deploy_name}} 的 {{.metric}} 當前為 {{.value}}，告警閾值為 {{.operator}} {{.threshold}}。"
+	//    },
+	//  },
+	// }
+	// @BuildTags ce
+	MessageScenarioDeployAlert MessageScenario = "deploy-alert"
+
+	// activity feed email digest of the followed users, organizations and repositories
+	// @Scenario feed-digest
+	// @Channels email
+	// @PayloadFields total, events
+	// @Template {
+	//  "email": {
+	//    "en-US": {
+	//      "title": "{{.total}} new activities from the accounts and repositories you follow",
+	//      "content": "{{range .events}}{{.repo_path}}: {{.type}}{{end}}"
+	//    },
+	//    "zh-CN": {
+	//      "title": "你关注的账号和仓库有 {{.total}} 条新动态",
+	//      "content": "{{range .events}}{{.repo_path}}: {{.type}}{{end}}"
+	//    },
+	//    "zh-HK": {
+	//      "title": "你關注的賬號和倉庫有 {{.total}} 條新動態",
+	//      "content": "{{range .events}}{{.repo_path}}: {{.type}}{{end}}"
+	//    },
+	//  },
+	// }
+	// @BuildTags ce
+	MessageScenarioFeedDigest MessageScenario = "feed-digest"
+
+	// @username mentions in the discussion comments
+	// @Scenario discussion-mention
+	// @Channels internal-message, email
+	// @PayloadFields repo_type, repo_path, title, sender
+	// @Template {
+	//  "email": {
+	//    "en-US": {
+	//      "title": "{{.sender}} mentioned you in {{.repo_path}}",
+	//      "content": "{{.sender}} mentioned you in the discussion \"{{.title}}\" of the {{.repo_type}} {{.repo_path}}"
+	//    },
+	//    "zh-CN": {
+	//      "title": "{{.sender}} 在 {{.repo_path}} 中提到了你",
+	//      "content": "{{.sender}} 在{{.repo_type}} {{.repo_path}} 的讨论“{{.title}}”中提到了你"
+	//    },
+	//    "zh-HK": {
+	//      "title": "{{.sender}} 在 {{.repo_path}} 中提到了你",
+	//      "content": "{{.sender}} 在{{.repo_type}} {{.repo_path}} 的討論「{{.title}}」中提到了你"
+	//    },
+	//  },
+	// }
+	// @BuildTags ce
+	MessageScenarioDiscussionMention MessageScenario = "discussion-mention"
+
+	// new release of the followed or liked repositories
+	// @Scenario repo-release
+	// @Channels internal-message, email
+	// @PayloadFields repo_type, repo_path, release_name, title
+	// @Template {
+	//  "email": {
+	//    "en-US": {
+	//      "title": "{{.repo_path}} released {{.release_name}}",
+	//      "content": "The {{.repo_type}} {{.repo_path}} you follow or like released {{.release_name}}: {{.title}}"
+	//    },
+	//    "zh-CN": {
+	//      "title": "{{.repo_path}} 发布了 {{.release_name}}",
+	//      "content": "你关注或点赞的{{.repo_type}} {{.repo_path}} 发布了 {{.release_name}}：{{.title}}"
+	//    },
+	//    "zh-HK": {
+	//      "title": "{{.repo_path}} 發佈了 {{.release_name}}",
+	//      "content": "你關注或點讚的{{.repo_type}} {{.repo_path}} 發佈了 {{.release_name}}：{{.title}}"
+	//    },
+	//  },
+	// }
+	// @BuildTags ce
+	MessageScenarioRepoRelease MessageScenario = "repo-release"
+
+	// new access request of a gated repository, sent to the owner of the repository namespace
+	// @Scenario repo-access-request
+	// @Channels internal-message, email
+	// @PayloadFields repo_type, repo_path, username, status
+	// @Template {
+	//  "email": {
+	//    "en-US": {
+	//      "title": "{{.username}} requested access to {{.repo_path}}",
+	//      "content": "{{.username}} requested access to the {{.repo_type}} {{.repo_path}}, the request is {{.status}}"
+	//    },
+	//    "zh-CN": {
+	//      "title": "{{.username}} 申请访问 {{.repo_path}}",
+	//      "content": "{{.username}} 申请访问{{.repo_type}} {{.repo_path}}，申请状态：{{.status}}"
+	//    },
+	//    "zh-HK": {
+	//      "title": "{{.username}} 申請訪問 {{.repo_path}}",
+	//      "content": "{{.username}} 申請訪問{{.repo_type}} {{.repo_path}}，申請狀態：{{.status}}"
+	//    },
+	//  },
+	// }
+	// @BuildTags ce
+	MessageScenarioRepoAccessRequest MessageScenario = "repo-access-request"
+
+	// decision on the access request of a gated repository, sent to the requester
+	// @Scenario repo-access-review
+	// @Channels internal-message, email
+	// @PayloadFields repo_type, repo_path, status, review_msg
+	// @Template {
+	//  "email": {
+	//    "en-US": {
+	//      "title": "Your access request to {{.repo_path}} is {{.status}}",
+	//      "content": "Your access request to the {{.repo_type}} {{.repo_path}} is {{.status}}. {{.review_msg}}"
+	//    },
+	//    "zh-CN": {
+	//      "title": "你对 {{.repo_path}} 的访问申请已{{.status}}",
+	//      "content": "你对{{.repo_type}} {{.repo_path}} 的访问申请已{{.status}}。{{.review_msg}}"
+	//    },
+	//    "zh-HK": {
+	//      "title": "你對 {{.repo_path}} 的訪問申請已{{.status}}",
+	//      "content": "你對{{.repo_type}} {{.repo_path}} 的訪問申請已{{.status}}。{{.review_msg}}"
+	//    },
+	//  },
+	// }
+	// @BuildTags ce
+	MessageScenarioRepoAccessReview MessageScenario = "repo-access-review"
 )
@@ -0,0 +1,116 @@
+package types
+
+import (
+	"io"
+)
+
+const (
+	OCIManifestMediaType = "application/vnd.oci.image.manifest.v1+json"
+	// OCIModelArtifactType and the media types below follow the CNCF ModelPack specification
+	OCIModelArtifactType          = "application/vnd.cncf.model.manifest.v1+json"
+	OCIModelConfigMediaType       = "application/vnd.cncf.model.config.v1+json"
+	OCIModelWeightMediaType       = "application/vnd.cncf.model.weight.v1.raw"
+	OCIModelWeightConfigMediaType = "application/vnd.cncf.model.weight.config.v1.raw"
+	OCIModelDocMediaType          = "application/vnd.cncf.model.doc.v1.raw"
+
+	// OCIAnnotationTitle is used by oras and containerd to name the pulled files
+	OCIAnnotationTitle    = "org.opencontainers.image.title"
+	OCIAnnotationFilepath = "org.cncf.model.filepath"
+	OCIAnnotationRevision = "org.opencontainers.image.revision"
+	OCIAnnotationSource   = "org.opencontainers.image.source"
+
+	OCIDistributionAPIVersion = "registry/2.0"
+	// OCIDefaultTag is resolved to the default branch of the repository
+	OCIDefaultTag = "latest"
+)
+
+// OCI distribution spec error codes
+const (
+	OCIErrNameUnknown     = "NAME_UNKNOWN"
+	OCIErrManifestUnknown = "MANIFEST_UNKNOWN"
+	OCIErrBlobUnknown     = "BLOB_UNKNOWN"
+	OCIErrDigestInvalid   = "DIGEST_INVALID"
+	OCIErrUnauthorized    = "UNAUTHORIZED"
+	OCIErrDenied          = "DENIED"
+	OCIErrUnsupported     = "UNSUPPORTED"
+)
+
+type OCIDescriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+type OCIManifest struct {
+	SchemaVersion int               `json:"schemaVersion"`
+	MediaType     string            `json:"mediaType"`
+	ArtifactType  string            `json:"artifactType,omitempty"`
+	Config        OCIDescriptor     `json:"config"`
+	Layers        []OCIDescriptor   `json:"layers"`
+	Annotations   map[string]string `json:"annotations,omitempty"`
+}
+
+// OCIModelConfig is the config blob of a model artifact, it's built from the model metadata
+type OCIModelConfig struct {
+	Descriptor OCIModelDescriptor `json:"descriptor"`
+	Config     OCIModelProperties `json:"config"`
+	ModelFS    OCIModelFS         `json:"modelfs"`
+}
+
+type OCIModelDescriptor struct {
+	Name        string   `json:"name"`
+	Revision    string   `json:"revision"`
+	Description string   `json:"description,omitempty"`
+	Licenses    []string `json:"licenses,omitempty"`
+}
+
+type OCIModelProperties struct {
+	Architecture string `json:"architecture,omitempty"`
+	ParamSize    string `json:"paramSize,omitempty"`
+	Precision    string `json:"precision,omitempty"`
+}
+
+type OCIModelFS struct {
+	Type    string   `json:"type"`
+	DiffIDs []string `json:"diffIds"`
+}
+
+type OCIManifestReq struct {
+	Namespace string
+	Name      string
+	// Reference is a tag, branch, commit or the digest of a manifest
+	Reference   string
+	CurrentUser string
+}
+
+type OCIManifestResp struct {
+	Digest    string
+	MediaType string
+	Content   []byte
+}
+
+type OCIBlobReq struct {
+	Namespace   string
+	Name        string
+	Digest      string
+	CurrentUser string
+}
+
+// OCIBlobResp contains one of Content, Reader and DownloadURL
+type OCIBlobResp struct {
+	Descriptor  OCIDescriptor
+	Content     []byte
+	Reader      io.ReadCloser
+	DownloadURL string
+}
+
+type OCIError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Detail  any    `json:"detail,omitempty"`
+}
+
+type OCIErrorRes struct {
+	Errors []OCIError `json:"errors"`
+}
@@ -0,0 +1,36 @@
+package types
+
+import "time"
+
+const (
+	DefaultRecomLimit = 10
+	MaxRecomLimit     = 50
+)
+
+type RelatedReposReq struct {
+	RepoType    RepositoryType `json:"-"`
+	Namespace   string         `json:"-"`
+	Name        string         `json:"-"`
+	CurrentUser string         `json:"-"`
+	Limit       int            `json:"limit" form:"limit"`
+}
+
+type RecomFeedReq struct {
+	CurrentUser string         `json:"-"`
+	RepoType    RepositoryType `json:"repo_type" form:"repo_type"`
+	Limit       int            `json:"limit" form:"limit"`
+}
+
+// RecomRepo is a recommended repository, the score is the item-to-item score for the related repositories
+// and the user feed, or the total recommendation score for the trending fallback of the feed
+type RecomRepo struct {
+	ID             int64          `json:"id"`
+	Path           string         `json:"path"`
+	RepositoryType RepositoryType `json:"repository_type"`
+	Nickname       string         `json:"nickname"`
+	Description    string         `json:"description"`
+	Likes          int64          `json:"likes"`
+	DownloadCount  int64          `json:"download_count"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	Score          float64        `json:"score"`
+}
@@ -0,0 +1,74 @@
+package types
+
+import "time"
+
+// LatestReleaseRef is the ref resolving to the latest release of the repository in the download urls,
+// e.g. resolve/latest/config.json
+const LatestReleaseRef = "latest"
+
+// Release is a published version of the repository, it points to a git tag
+type Release struct {
+	ID        int64  `json:"id"`
+	Name      string `json:"name"`
+	TagName   string `json:"tag_name"`
+	Title     string `json:"title"`
+	Changelog string `json:"changelog"`
+	// RecommendedFiles is the files or quantizations the consumers should use, e.g. model-q4_k_m.gguf
+	RecommendedFiles []string  `json:"recommended_files"`
+	Deprecated       bool      `json:"deprecated"`
+	DeprecationNote  string    `json:"deprecation_note,omitempty"`
+	Latest           bool      `json:"latest"`
+	Author           string    `json:"author"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+type CreateReleaseReq struct {
+	Namespace   string         `json:"-"`
+	Name        string         `json:"-"`
+	RepoType    RepositoryType `json:"-"`
+	CurrentUser string         `json:"-"`
+	ReleaseName string         `json:"name" binding:"required"`
+	// TagName is the existing git tag of the release
+	TagName          string   `json:"tag_name" binding:"required"`
+	Title            string   `json:"title"`
+	Changelog        string   `json:"changelog"`
+	RecommendedFiles []string `json:"recommended_files"`
+	// Latest marks the release as the latest one, the first release of the repository is always the latest
+	Latest bool `json:"latest"`
+}
+
+// UpdateReleaseReq updates the release, the nil fields are unchanged, the git tag of a release can not be changed
+type UpdateReleaseReq struct {
+	Namespace        string         `json:"-"`
+	Name             string         `json:"-"`
+	RepoType         RepositoryType `json:"-"`
+	CurrentUser      string         `json:"-"`
+	ReleaseName      string         `json:"-"`
+	Title            *string        `json:"title"`
+	Changelog        *string        `json:"changelog"`
+	RecommendedFiles *[]string      `json:"recommended_files"`
+	Deprecated       *bool          `json:"deprecated"`
+	DeprecationNote  *string        `json:"deprecation_note"`
+	// Latest can only be set to true, the latest pointer moves to another release by updating that release
+	Latest *bool `json:"latest"`
+}
+
+type GetReleaseReq struct {
+	Namespace   string         `json:"-"`
+	Name        string         `json:"-"`
+	RepoType    RepositoryType `json:"-"`
+	CurrentUser string         `json:"-"`
+	ReleaseName string         `json:"-"`
+}
+
+type DeleteReleaseReq = GetReleaseReq
+
+type ListReleasesReq struct {
+	Namespace   string         `json:"-"`
+	Name        string         `json:"-"`
+	RepoType    RepositoryType `json:"-"`
+	CurrentUser string         `json:"-"`
+	Per         int            `json:"-"`
+	Page        int            `json:"-"`
+}
@@ -172,9 +172,10 @@ type Tag struct {
 }
 
 type CreateBranchReq struct {
-	Namespace   string         `json:"namespace"`
-	Name        string         `json:"name"`
-	BranchName  string         `json:"branch_name"`
+	Namespace  string `json:"namespace"`
+	Name       string `json:"name"`
+	BranchName string `json:"branch_name"`
+	// CommitID is the commit, branch or tag the new branch starts from, default to the default branch
 	CommitID    string         `json:"commit_id"`
 	RepoType    RepositoryType `json:"-"`
 	CurrentUser string         `json:"-"`
@@ -188,6 +189,25 @@ type DeleteBranchReq struct {
 	CurrentUser string         `json:"-"`
 }
 
+type CreateGitTagReq struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	TagName   string `json:"tag_name"`
+	// Revision is the commit, branch or tag the new tag points to, default to the default branch
+	Revision    string         `json:"revision"`
+	Message     string         `json:"message"`
+	RepoType    RepositoryType `json:"-"`
+	CurrentUser string         `json:"-"`
+}
+
+type DeleteGitTagReq struct {
+	Namespace   string         `json:"namespace"`
+	Name        string         `json:"name"`
+	TagName     string         `json:"tag_name"`
+	RepoType    RepositoryType `json:"-"`
+	CurrentUser string         `json:"-"`
+}
+
 type Repository struct {
 	HTTPCloneURL string `json:"http_clone_url"`
 	SSHCloneURL  string `json:"ssh_clone_url"`
@@ -278,6 +298,10 @@ type DeployRequest struct {
 	Message             string     `json:"message,omitempty"`
 	SupportFunctionCall bool       `json:"support_function_call,omitempty"`
 	OwnerNamespace      string     `json:"owner_namespace,omitempty"`
+	// InferenceRollout is the latest progressive traffic rollout of the inference deploy
+	InferenceRollout *InferenceRollout `json:"inference_rollout,omitempty"`
+	// CredentialNames are the credentials of the owner namespace injected into the deploy as secrets
+	CredentialNames []string `json:"credential_names,omitempty"`
 
 	Since    string `json:"since,omitempty"`
 	Limit    int    `json:"limit,omitempty"`
@@ -463,6 +487,28 @@ type DownloadRepoZipReq struct {
 	Revision  string         `json:"revision"`
 }
 
+type ArchiveFormat string
+
+const (
+	ArchiveFormatZip   ArchiveFormat = "zip"
+	ArchiveFormatTarGz ArchiveFormat = "tar.gz"
+)
+
+type DownloadArchiveReq struct {
+	RepoType  RepositoryType `json:"-"`
+	Namespace string         `json:"-"`
+	Name      string         `json:"-"`
+	Revision  string         `json:"-"`
+	// Format is zip or tar.gz, default to zip
+	Format ArchiveFormat `form:"format"`
+	// IncludeLfs archives the content of LFS files instead of the pointer files
+	IncludeLfs bool `form:"include_lfs"`
+	// Include and Exclude are glob patterns of the file paths, like *.json or onnx/**
+	Include     []string `form:"include"`
+	Exclude     []string `form:"exclude"`
+	CurrentUser string   `json:"-"`
+}
+
 type BatchRepoExtraReq struct {
 	RepoIDs []int64 `json:"repo_ids" binding:"required"`
 }
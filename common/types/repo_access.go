@@ -0,0 +1,113 @@
+package types
+
+import "time"
+
+// RepoAccessApprovalMode decides how the access requests of a gated repository are approved
+type RepoAccessApprovalMode string
+
+const (
+	// RepoAccessApprovalAuto approves the access requests once the terms are accepted and the form is filled
+	RepoAccessApprovalAuto RepoAccessApprovalMode = "auto"
+	// RepoAccessApprovalManual keeps the access requests pending until a repository admin reviews them
+	RepoAccessApprovalManual RepoAccessApprovalMode = "manual"
+)
+
+type RepoAccessRequestStatus string
+
+const (
+	RepoAccessRequestPending  RepoAccessRequestStatus = "pending"
+	RepoAccessRequestApproved RepoAccessRequestStatus = "approved"
+	RepoAccessRequestRejected RepoAccessRequestStatus = "rejected"
+	// RepoAccessRequestExpired is an approved request past its expiry time, it is not stored
+	RepoAccessRequestExpired RepoAccessRequestStatus = "expired"
+)
+
+const (
+	RepoAccessReviewApprove = "approve"
+	RepoAccessReviewReject  = "reject"
+)
+
+// RepoAccessFormField is a custom field the users fill in when requesting access to a gated repository
+type RepoAccessFormField struct {
+	Name     string `json:"name" binding:"required"`
+	Label    string `json:"label"`
+	Required bool   `json:"required"`
+}
+
+// RepoAccessPolicy is the usage policy of a repository, the LFS files of a gated repository can only be
+// downloaded by the users with an approved access request
+type RepoAccessPolicy struct {
+	Gated        bool                   `json:"gated"`
+	ApprovalMode RepoAccessApprovalMode `json:"approval_mode"`
+	// Terms is the license or the terms of use the users must accept
+	Terms      string                `json:"terms"`
+	FormFields []RepoAccessFormField `json:"form_fields"`
+	// ApprovalValidDays is how long an approval lasts, 0 means it never expires
+	ApprovalValidDays int        `json:"approval_valid_days"`
+	UpdatedAt         *time.Time `json:"updated_at,omitempty"`
+}
+
+type GetRepoAccessPolicyReq struct {
+	Namespace   string         `json:"-"`
+	Name        string         `json:"-"`
+	RepoType    RepositoryType `json:"-"`
+	CurrentUser string         `json:"-"`
+}
+
+type UpdateRepoAccessPolicyReq struct {
+	Namespace         string                 `json:"-"`
+	Name              string                 `json:"-"`
+	RepoType          RepositoryType         `json:"-"`
+	CurrentUser       string                 `json:"-"`
+	Gated             bool                   `json:"gated"`
+	ApprovalMode      RepoAccessApprovalMode `json:"approval_mode" binding:"omitempty,oneof=auto manual"`
+	Terms             string                 `json:"terms"`
+	FormFields        []RepoAccessFormField  `json:"form_fields" binding:"dive"`
+	ApprovalValidDays int                    `json:"approval_valid_days" binding:"gte=0"`
+}
+
+type RepoAccessRequest struct {
+	ID            int64                   `json:"id"`
+	Username      string                  `json:"username"`
+	Email         string                  `json:"email,omitempty"`
+	Status        RepoAccessRequestStatus `json:"status"`
+	AcceptedTerms bool                    `json:"accepted_terms"`
+	Form          map[string]string       `json:"form"`
+	Reviewer      string                  `json:"reviewer,omitempty"`
+	ReviewMsg     string                  `json:"review_msg,omitempty"`
+	ReviewedAt    *time.Time              `json:"reviewed_at,omitempty"`
+	ExpiresAt     *time.Time              `json:"expires_at,omitempty"`
+	CreatedAt     time.Time               `json:"created_at"`
+	UpdatedAt     time.Time               `json:"updated_at"`
+}
+
+type CreateRepoAccessRequestReq struct {
+	Namespace   string            `json:"-"`
+	Name        string            `json:"-"`
+	RepoType    RepositoryType    `json:"-"`
+	CurrentUser string            `json:"-"`
+	AcceptTerms bool              `json:"accept_terms"`
+	Form        map[string]string `json:"form"`
+}
+
+type GetRepoAccessRequestReq = GetRepoAccessPolicyReq
+
+type ReviewRepoAccessRequestReq struct {
+	Namespace   string         `json:"-"`
+	Name        string         `json:"-"`
+	RepoType    RepositoryType `json:"-"`
+	CurrentUser string         `json:"-"`
+	ID          int64          `json:"-"`
+	Action      string         `json:"action" binding:"required,oneof=approve reject"`
+	ReviewMsg   string         `json:"review_msg"`
+}
+
+type ListRepoAccessRequestsReq struct {
+	Namespace   string                  `json:"-"`
+	Name        string                  `json:"-"`
+	RepoType    RepositoryType          `json:"-"`
+	CurrentUser string                  `json:"-"`
+	Status      RepoAccessRequestStatus `json:"status"`
+	Per         int                     `json:"per"`
+	Page        int                     `json:"page"`
+}
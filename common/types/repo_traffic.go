@@ -0,0 +1,86 @@
+package types
+
+import "time"
+
+type RepoTrafficKind string
+
+const (
+	RepoTrafficResolve RepoTrafficKind = "resolve"
+	RepoTrafficLFS     RepoTrafficKind = "lfs"
+	RepoTrafficClone   RepoTrafficKind = "clone"
+)
+
+const (
+	DefaultRepoTrafficDays     = 30
+	MaxRepoTrafficDays         = 366
+	DefaultRepoTrafficTopFiles = 10
+	MaxRepoTrafficTopFiles     = 100
+)
+
+// RepoTrafficEvent is a download hit of a repository, the path is the file path for the resolve hits and
+// the LFS oids are the objects requested by the LFS batch download
+type RepoTrafficEvent struct {
+	Kind      RepoTrafficKind `json:"kind"`
+	RepoType  RepositoryType  `json:"repo_type"`
+	Namespace string          `json:"namespace"`
+	Name      string          `json:"name"`
+	Path      string          `json:"path,omitempty"`
+	LfsOids   []string        `json:"lfs_oids,omitempty"`
+	Username  string          `json:"username,omitempty"`
+	IPAddress string          `json:"ip_address"`
+	UserAgent string          `json:"user_agent"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+type RepoTrafficReq struct {
+	RepoType    RepositoryType `json:"-"`
+	Namespace   string         `json:"-"`
+	Name        string         `json:"-"`
+	CurrentUser string         `json:"-"`
+	// StartDate and EndDate are the inclusive date range in the format of 2006-01-02, the last 30 days by default
+	StartDate string `json:"start_date" form:"start_date"`
+	EndDate   string `json:"end_date" form:"end_date"`
+	Limit     int    `json:"limit" form:"limit"`
+}
+
+type RepoTrafficSummary struct {
+	Downloads   int64 `json:"downloads"`
+	Clones      int64 `json:"clones"`
+	UniqueUsers int64 `json:"unique_users"`
+}
+
+type RepoTrafficPoint struct {
+	Date        string `json:"date"`
+	Downloads   int64  `json:"downloads"`
+	Clones      int64  `json:"clones"`
+	UniqueUsers int64  `json:"unique_users"`
+}
+
+// RepoTrafficStats is the traffic of a repository in the date range, the downloads are the resolve and LFS
+// hits, the time series has a point for every day of the range
+type RepoTrafficStats struct {
+	StartDate  string             `json:"start_date"`
+	EndDate    string             `json:"end_date"`
+	Summary    RepoTrafficSummary `json:"summary"`
+	TimeSeries []RepoTrafficPoint `json:"time_series"`
+}
+
+type RepoTrafficFile struct {
+	Path      string `json:"path"`
+	Downloads int64  `json:"downloads"`
+}
+
+type RepoTrafficRegion struct {
+	Region      string `json:"region"`
+	Hits        int64  `json:"hits"`
+	UniqueUsers int64  `json:"unique_users"`
+}
+
+// RepoTrafficDaily is a row of the daily traffic aggregates of a repository
+type RepoTrafficDaily struct {
+	Date   string          `json:"date"`
+	Kind   RepoTrafficKind `json:"kind"`
+	Path   string          `json:"path"`
+	Region string          `json:"region"`
+	Count  int64           `json:"count"`
+}
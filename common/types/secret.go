@@ -0,0 +1,103 @@
+package types
+
+import (
+	"log/slog"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SecretOwnerType is the kind of the resource a secret belongs to
+type SecretOwnerType string
+
+const (
+	SecretOwnerSpace SecretOwnerType = "space"
+	// SecretOwnerDeploy is used by the inference, finetune and notebook deploys
+	SecretOwnerDeploy SecretOwnerType = "deploy"
+)
+
+const (
+	// MaxSecretsPerOwner is the max number of the secrets of a space or a deploy
+	MaxSecretsPerOwner = 100
+	// MaxSecretValueLength is the max length of a secret value in bytes
+	MaxSecretValueLength = 64 * 1024
+	secretMask           = "******"
+)
+
+// secretKeyRegexp allows the names of the environment variables, which are also valid kubernetes secret keys
+var secretKeyRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]{0,127}$`)
+
+func ValidSecretKey(key string) bool {
+	return secretKeyRegexp.MatchString(key)
+}
+
+// MaskSecret hides a secret value, only the last 4 characters of the long values are kept as a hint
+func MaskSecret(value string) string {
+	if len(value) < 16 {
+		return secretMask
+	}
+	return secretMask + value[len(value)-4:]
+}
+
+// IsMaskedSecret reports whether the value looks like a masked secret value
+func IsMaskedSecret(value string) bool {
+	return strings.HasPrefix(value, secretMask)
+}
+
+// SecretValues holds the plain secret values passed to the runner, the values are masked when printed
+type SecretValues map[string]string
+
+func (s SecretValues) String() string {
+	masked := make([]string, 0, len(s))
+	for key := range s {
+		masked = append(masked, key+":"+secretMask)
+	}
+	sort.Strings(masked)
+	return "map[" + strings.Join(masked, " ") + "]"
+}
+
+func (s SecretValues) LogValue() slog.Value {
+	return slog.StringValue(s.String())
+}
+
+func (s SecretValues) masked() SecretValues {
+	if s == nil {
+		return nil
+	}
+	masked := make(SecretValues, len(s))
+	for key := range s {
+		masked[key] = secretMask
+	}
+	return masked
+}
+
+type SecretReq struct {
+	CurrentUser string `json:"-"`
+	// Namespace and Name locate the space of a space secret
+	Namespace string `json:"-"`
+	Name      string `json:"-"`
+	// DeployID locates the deploy of a deploy secret
+	DeployID int64  `json:"-"`
+	Key      string `json:"-"`
+	Value    string `json:"value" binding:"required"`
+}
+
+// Secret is the view of a stored secret, the value is always masked
+type Secret struct {
+	Key        string    `json:"key"`
+	Value      string    `json:"value"`
+	KeyVersion int       `json:"key_version"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// SecretReencryption summarizes a round of re-encrypting the secrets with the active master key
+type SecretReencryption struct {
+	ActiveKeyVersion int `json:"active_key_version"`
+	// Reencrypted is the number of the secrets moved from a retired key version
+	Reencrypted int `json:"reencrypted"`
+	// Imported is the number of the plain space secrets stored before the encryption was introduced
+	Imported int `json:"imported"`
+	Failed   int `json:"failed"`
+}
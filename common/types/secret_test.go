@@ -0,0 +1,30 @@
+package types
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecretValues_Logged(t *testing.T) {
+	secrets := SecretValues{"HF_TOKEN": "hf_plain_token_value"}
+
+	for _, newHandler := range []func(buf *bytes.Buffer) slog.Handler{
+		func(buf *bytes.Buffer) slog.Handler { return slog.NewJSONHandler(buf, nil) },
+		func(buf *bytes.Buffer) slog.Handler { return slog.NewTextHandler(buf, nil) },
+	} {
+		var buf bytes.Buffer
+		logger := slog.New(newHandler(&buf))
+		logger.Info("run", slog.Any("req", &RunRequest{SvcName: "svc", Secrets: secrets}))
+		logger.Info("svc", slog.Any("req", &SVCRequest{SvcName: "svc", Secrets: secrets}))
+		logger.Info("secrets", slog.Any("secrets", secrets))
+
+		require.NotContains(t, buf.String(), "hf_plain_token_value")
+		require.Contains(t, buf.String(), "HF_TOKEN")
+		require.Contains(t, buf.String(), "svc")
+	}
+	// the requests sent to the runner keep the values
+	require.Equal(t, "hf_plain_token_value", secrets["HF_TOKEN"])
+}
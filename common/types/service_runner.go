@@ -2,6 +2,7 @@ package types
 
 import (
 	"io"
+	"log/slog"
 	"time"
 
 	"k8s.io/client-go/kubernetes"
@@ -49,6 +50,7 @@ type (
 
 		Hardware   HardWare          `json:"hardware,omitempty"`   // resource requirements
 		Env        map[string]string `json:"env,omitempty"`        // runtime env variables
+		Secrets    SecretValues      `json:"secrets,omitempty"`    // secret env variables, injected from a kubernetes secret
 		Annotation map[string]string `json:"annotation,omitempty"` // resource annotations
 
 		RuntimeFramework string     `json:"runtime_framework"` // runtime framework of image, TGI/vllm/Pipeline/Deepspeed/LLamacpp
@@ -221,6 +223,7 @@ type (
 		ImageID       string            `json:"image_id" binding:"required"`
 		Hardware      HardWare          `json:"hardware,omitempty"`
 		Env           map[string]string `json:"env,omitempty"`
+		Secrets       SecretValues      `json:"secrets,omitempty"`
 		Annotation    map[string]string `json:"annotation,omitempty"`
 		DeployID      int64             `json:"deploy_id" binding:"required"`
 		RepoType      string            `json:"repo_type"`
@@ -297,3 +300,20 @@ type (
 		Reason         string    `json:"reason"`
 	}
 )
+
+// LogValue masks the secrets when the request is logged, the json handler of slog
+// marshals the nested secrets without calling their String method
+func (r RunRequest) LogValue() slog.Value {
+	type runRequest RunRequest
+	logged := runRequest(r)
+	logged.Secrets = r.Secrets.masked()
+	return slog.AnyValue(logged)
+}
+
+// LogValue masks the secrets when the request is logged
+func (r SVCRequest) LogValue() slog.Value {
+	type svcRequest SVCRequest
+	logged := svcRequest(r)
+	logged.Secrets = r.Secrets.masked()
+	return slog.AnyValue(logged)
+}
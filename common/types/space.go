@@ -88,6 +88,7 @@ type Space struct {
 	Name          string      `json:"name,omitempty" example:"space_name_1"`
 	Nickname      string      `json:"nickname,omitempty" example:""`
 	Description   string      `json:"description,omitempty" example:""`
+	SearchSnippet string      `json:"search_snippet,omitempty"`
 	Path          string      `json:"path" example:"user_or_org_name/space_name_1"`
 	License       string      `json:"license,omitempty" example:"MIT"`
 	Tags          []RepoTag   `json:"tags,omitempty"`
@@ -0,0 +1,29 @@
+package types
+
+import "time"
+
+// StorageQuotaWarnPercents are the usage levels at which the namespace owners are notified, in ascending order
+var StorageQuotaWarnPercents = []int{80, 95}
+
+type GetStorageQuotaReq struct {
+	CurrentUser string `json:"-"`
+	Namespace   string `json:"-"`
+}
+
+type SetStorageQuotaReq struct {
+	CurrentUser string `json:"-"`
+	Namespace   string `json:"-"`
+	// QuotaBytes overrides the default quota, 0 means unlimited, null falls back to the default quota
+	QuotaBytes *int64 `json:"quota_bytes" binding:"omitempty,min=0"`
+}
+
+type StorageQuota struct {
+	Namespace string `json:"namespace"`
+	// QuotaBytes is the effective quota, 0 means unlimited
+	QuotaBytes   int64     `json:"quota_bytes"`
+	IsOverridden bool      `json:"is_overridden"`
+	UsedBytes    int64     `json:"used_bytes"`
+	GitBytes     int64     `json:"git_bytes"`
+	LfsBytes     int64     `json:"lfs_bytes"`
+	UpdatedAt    time.Time `json:"updated_at,omitempty"`
+}
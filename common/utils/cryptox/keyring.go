@@ -0,0 +1,111 @@
+package cryptox
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrUnknownKeyVersion indicates a value was encrypted with a master key version the keyring does not hold.
+var ErrUnknownKeyVersion = errors.New("unknown cryptox master key version")
+
+// KeyringConfig defines the versioned master keys of a Keyring.
+type KeyringConfig struct {
+	// MasterKeys maps key versions to high-entropy master key material.
+	MasterKeys map[int][]byte
+	// ActiveVersion is the key version new values are encrypted with; it must be present in MasterKeys.
+	ActiveVersion int
+	// Salt is shared by all key versions, see Config.Salt.
+	Salt []byte
+	// InfoPrefix namespaces derived keys for one application purpose, see Config.InfoPrefix.
+	InfoPrefix string
+}
+
+// Keyring encrypts with the active master key version and decrypts with any version it holds.
+//
+// Rotating a master key means adding a new version, making it active and re-encrypting the
+// stored values; the retired versions must be kept until no value encrypted with them is left.
+// Callers store the returned key version next to the ciphertext. A Keyring is immutable after
+// NewKeyring returns and is safe for concurrent use.
+type Keyring struct {
+	ciphers map[int]*Cipher
+	active  int
+}
+
+// NewKeyring creates one Cipher per master key version.
+func NewKeyring(cfg KeyringConfig) (*Keyring, error) {
+	if _, ok := cfg.MasterKeys[cfg.ActiveVersion]; !ok {
+		return nil, fmt.Errorf("%w: active key version %d has no master key", ErrInvalidConfig, cfg.ActiveVersion)
+	}
+
+	ciphers := make(map[int]*Cipher, len(cfg.MasterKeys))
+	for version, key := range cfg.MasterKeys {
+		c, err := NewCipher(Config{
+			MasterKey:  key,
+			Salt:       cfg.Salt,
+			InfoPrefix: cfg.InfoPrefix,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("key version %d: %w", version, err)
+		}
+		ciphers[version] = c
+	}
+
+	return &Keyring{
+		ciphers: ciphers,
+		active:  cfg.ActiveVersion,
+	}, nil
+}
+
+// ActiveVersion returns the key version new values are encrypted with.
+func (k *Keyring) ActiveVersion() int {
+	return k.active
+}
+
+// Encrypt encrypts plaintext for scope with the active key and returns the key version with the ciphertext.
+func (k *Keyring) Encrypt(plaintext []byte, scope ...string) (int, string, error) {
+	ciphertext, err := k.ciphers[k.active].Encrypt(plaintext, scope...)
+	if err != nil {
+		return 0, "", err
+	}
+	return k.active, ciphertext, nil
+}
+
+// Decrypt decrypts a ciphertext encrypted with the key version for scope.
+func (k *Keyring) Decrypt(version int, encodedCiphertext string, scope ...string) ([]byte, error) {
+	c, ok := k.ciphers[version]
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownKeyVersion, version)
+	}
+	return c.Decrypt(encodedCiphertext, scope...)
+}
+
+// ParseVersionedKeys parses master keys written as "version:base64-key" entries.
+func ParseVersionedKeys(entries []string) (map[int][]byte, error) {
+	keys := make(map[int][]byte, len(entries))
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		versionPart, keyPart, found := strings.Cut(entry, ":")
+		if !found {
+			return nil, fmt.Errorf("%w: master key entry must be version:base64-key", ErrInvalidConfig)
+		}
+		version, err := strconv.Atoi(versionPart)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("%w: invalid master key version %q", ErrInvalidConfig, versionPart)
+		}
+		if _, ok := keys[version]; ok {
+			return nil, fmt.Errorf("%w: duplicated master key version %d", ErrInvalidConfig, version)
+		}
+		key, err := base64.StdEncoding.DecodeString(keyPart)
+		if err != nil {
+			return nil, fmt.Errorf("%w: master key version %d is not base64 encoded: %v", ErrInvalidConfig, version, err)
+		}
+		keys[version] = key
+	}
+	return keys, nil
+}
@@ -0,0 +1,105 @@
+package cryptox
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+)
+
+var testMasterKeyV2 = []byte("fedcba9876543210fedcba9876543210")
+
+// TestKeyringRotation verifies values encrypted with a retired version still decrypt after rotation.
+func TestKeyringRotation(t *testing.T) {
+	old, err := NewKeyring(KeyringConfig{
+		MasterKeys:    map[int][]byte{1: testMasterKey},
+		ActiveVersion: 1,
+		Salt:          testSalt,
+		InfoPrefix:    "test/",
+	})
+	if err != nil {
+		t.Fatalf("NewKeyring() error = %v", err)
+	}
+	version, ciphertext, err := old.Encrypt([]byte("secret"), "space", "1")
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+	if version != 1 {
+		t.Fatalf("Encrypt() version = %d, want 1", version)
+	}
+
+	rotated, err := NewKeyring(KeyringConfig{
+		MasterKeys:    map[int][]byte{1: testMasterKey, 2: testMasterKeyV2},
+		ActiveVersion: 2,
+		Salt:          testSalt,
+		InfoPrefix:    "test/",
+	})
+	if err != nil {
+		t.Fatalf("NewKeyring() error = %v", err)
+	}
+	plaintext, err := rotated.Decrypt(version, ciphertext, "space", "1")
+	if err != nil {
+		t.Fatalf("Decrypt() error = %v", err)
+	}
+	if string(plaintext) != "secret" {
+		t.Fatalf("Decrypt() = %q, want secret", plaintext)
+	}
+
+	version, ciphertext, err = rotated.Encrypt(plaintext, "space", "1")
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+	if version != 2 {
+		t.Fatalf("Encrypt() version = %d, want 2", version)
+	}
+	if _, err := old.Decrypt(version, ciphertext, "space", "1"); !errors.Is(err, ErrUnknownKeyVersion) {
+		t.Fatalf("Decrypt() error = %v, want ErrUnknownKeyVersion", err)
+	}
+	if _, err := rotated.Decrypt(1, ciphertext, "space", "1"); !errors.Is(err, ErrInvalidCiphertext) {
+		t.Fatalf("Decrypt() with the wrong version error = %v, want ErrInvalidCiphertext", err)
+	}
+}
+
+// TestNewKeyringValidatesConfig verifies the active version must have a valid master key.
+func TestNewKeyringValidatesConfig(t *testing.T) {
+	_, err := NewKeyring(KeyringConfig{
+		MasterKeys:    map[int][]byte{1: testMasterKey},
+		ActiveVersion: 2,
+		Salt:          testSalt,
+	})
+	if !errors.Is(err, ErrInvalidConfig) {
+		t.Fatalf("NewKeyring() missing active version error = %v, want ErrInvalidConfig", err)
+	}
+
+	_, err = NewKeyring(KeyringConfig{
+		MasterKeys:    map[int][]byte{1: testMasterKey, 2: []byte("short")},
+		ActiveVersion: 1,
+		Salt:          testSalt,
+	})
+	if !errors.Is(err, ErrInvalidConfig) {
+		t.Fatalf("NewKeyring() short retired key error = %v, want ErrInvalidConfig", err)
+	}
+}
+
+// TestParseVersionedKeys verifies the version:base64-key entries are decoded and validated.
+func TestParseVersionedKeys(t *testing.T) {
+	encoded := base64.StdEncoding.EncodeToString(testMasterKey)
+	keys, err := ParseVersionedKeys([]string{"1:" + encoded, " ", "3:" + encoded})
+	if err != nil {
+		t.Fatalf("ParseVersionedKeys() error = %v", err)
+	}
+	if len(keys) != 2 || string(keys[1]) != string(testMasterKey) || string(keys[3]) != string(testMasterKey) {
+		t.Fatalf("ParseVersionedKeys() = %v", keys)
+	}
+
+	for _, entries := range [][]string{
+		{encoded},
+		{"x:" + encoded},
+		{"0:" + encoded},
+		{"1:" + encoded, "1:" + encoded},
+		{"1:not base64"},
+	} {
+		if _, err := ParseVersionedKeys(entries); !errors.Is(err, ErrInvalidConfig) {
+			t.Fatalf("ParseVersionedKeys(%v) error = %v, want ErrInvalidConfig", entries, err)
+		}
+	}
+}
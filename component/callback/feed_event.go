@@ -0,0 +1,41 @@
+package callback
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/types"
+)
+
+// RecordFeedEvent records the new tags pushed to the repository for the activity feeds of the followers,
+// the pushes to the branches and the deleted tags are ignored
+func (c *gitCallbackComponentImpl) RecordFeedEvent(ctx context.Context, req *types.GiteaCallbackPushReq) error {
+	tag, ok := strings.CutPrefix(req.Ref, "refs/tags/")
+	if !ok || tag == "" || strings.Trim(req.HeadCommit.Id, "0") == "" {
+		return nil
+	}
+	splits := strings.Split(req.Repository.FullName, "/")
+	if len(splits) != 2 {
+		slog.Warn("invalid callback repo full name for feed event", slog.String("full_name", req.Repository.FullName))
+		return nil
+	}
+	fullNamespace, repoName := splits[0], splits[1]
+	repoType, namespace, _ := strings.Cut(fullNamespace, "_")
+	adjustedRepoType := types.RepositoryType(strings.TrimRight(repoType, "s"))
+
+	repo, err := c.repoStore.FindByPath(ctx, adjustedRepoType, namespace, repoName)
+	if err != nil {
+		return fmt.Errorf("failed to find repo %s/%s/%s, error: %w", adjustedRepoType, namespace, repoName, err)
+	}
+	event := database.NewRepoFeedEvent(string(types.FeedEventTagCreated), repo, 0, map[string]any{
+		"tag":    tag,
+		"commit": req.HeadCommit.Id,
+	})
+	if err := c.feedEventStore.Create(ctx, event); err != nil {
+		return fmt.Errorf("failed to record tag feed event, error: %w", err)
+	}
+	return nil
+}
@@ -33,6 +33,10 @@ type GitCallbackComponent interface {
 	MCPScan(ctx context.Context, req *types.GiteaCallbackPushReq) error
 	CalculateRepoSize(ctx context.Context, req *types.GiteaCallbackPushReq) error
 	SyncRepositoryPackage(ctx context.Context, req *types.GiteaCallbackPushReq) error
+	RefreshSearchDocument(ctx context.Context, req *types.GiteaCallbackPushReq) error
+	RebuildSearchDocument(ctx context.Context, repo *database.Repository) error
+	RefreshRepoCard(ctx context.Context, req *types.GiteaCallbackPushReq) error
+	RecordFeedEvent(ctx context.Context, req *types.GiteaCallbackPushReq) error
 }
 
 type gitCallbackComponentImpl struct {
@@ -61,6 +65,10 @@ type gitCallbackComponentImpl struct {
 	mcpScanner                component.MCPScannerComponent
 	repositoryStatisticsStore database.RepositoryStatisticsStore
 	repositoryPackageSyncer   component.RepositoryPackageSyncer
+	storageQuota              component.StorageQuotaComponent
+	searchDocumentStore       database.RepositorySearchDocumentStore
+	feedEventStore            database.FeedEventStore
+	metadataStore             database.MetadataStore
 	// set visibility if file content is sensitive
 	setRepoVisibility bool
 	maxPromptFS       int64
@@ -113,6 +121,10 @@ func NewGitCallback(config *config.Config) (*gitCallbackComponentImpl, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to create repository package s3 client: %w", err)
 	}
+	sqc, err := component.NewStorageQuotaComponent(config)
+	if err != nil {
+		return nil, err
+	}
 	return &gitCallbackComponentImpl{
 		config:                    config,
 		gitServer:                 gs,
@@ -140,6 +152,10 @@ func NewGitCallback(config *config.Config) (*gitCallbackComponentImpl, error) {
 		mcpScanner:                mcpScanner,
 		repositoryStatisticsStore: repositoryStatisticsStore,
 		repositoryPackageSyncer:   component.NewRepositoryPackageSyncer(config, rs, gs, s3Client),
+		storageQuota:              sqc,
+		searchDocumentStore:       database.NewRepositorySearchDocumentStore(config),
+		feedEventStore:            database.NewFeedEventStore(),
+		metadataStore:             database.NewMetadataStore(),
 	}, nil
 }
 
@@ -770,5 +786,12 @@ func (c *gitCallbackComponentImpl) CalculateRepoSize(ctx context.Context, req *t
 
 	slog.Info("calculated repo size for branch", slog.Any("repo_id", repo.ID), slog.String("branch", branchName), slog.Any("total_size", totalSize), slog.Any("non_lfs_size", nonLfsSize), slog.Any("lfs_size", lfsSize), slog.Any("last_commit_size", lastCommitSize))
 
+	if c.storageQuota != nil {
+		err = c.storageQuota.RefreshUsage(ctx, namespace)
+		if err != nil {
+			slog.Error("failed to refresh namespace storage usage", slog.Any("error", err), slog.String("namespace", namespace))
+		}
+	}
+
 	return nil
 }
@@ -13,6 +13,7 @@ import (
 	mockcomponent "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/component"
 	"opencsg.com/csghub-server/builder/git/gitserver"
 	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/errorx"
 	"opencsg.com/csghub-server/common/types"
 )
 
@@ -393,7 +394,207 @@ func TestGitCallbackComponentImpl_CalculateRepoSize(t *testing.T) {
 		gc.mocks.stores.RepositoryStatisticsMock().EXPECT().FindByRepositoryIDAndBranch(ctx, repo.ID, branchName).Return(existingStats, nil)
 		gc.mocks.stores.RepositoryStatisticsMock().EXPECT().Update(ctx, mock.Anything).Return(nil)
 
+		// the namespace usage is refreshed after the repo size changed
+		storageQuota := mockcomponent.NewMockStorageQuotaComponent(t)
+		gc.storageQuota = storageQuota
+		storageQuota.EXPECT().RefreshUsage(ctx, "namespace").Return(nil)
+
 		err := gc.CalculateRepoSize(context.Background(), req)
 		assert.NoError(t, err)
 	})
 }
+
+func TestGitCallbackComponentImpl_RefreshSearchDocument(t *testing.T) {
+	ctx := mock.Anything
+	repo := &database.Repository{ID: 1, Path: "namespace/repo", DefaultBranch: "main", RepositoryType: types.ModelRepo}
+
+	t.Run("should refresh the search document of the default branch", func(t *testing.T) {
+		gc := initializeTestGitCallbackComponent(context.Background(), t)
+		req := &types.GiteaCallbackPushReq{
+			Ref: "refs/heads/main",
+			Repository: types.GiteaCallbackPushReq_Repository{
+				FullName: "models_namespace/repo",
+			},
+		}
+		gc.mocks.stores.RepoMock().EXPECT().FindByPath(ctx, types.ModelRepo, "namespace", "repo").Return(repo, nil)
+		gc.mocks.gitServer.EXPECT().GetRepoFileRaw(ctx, gitserver.GetRepoInfoByPathReq{
+			Namespace: "namespace",
+			Name:      "repo",
+			Ref:       "main",
+			Path:      types.ReadmeFileName,
+			RepoType:  types.ModelRepo,
+		}).Return("---\nlicense: apache-2.0\nlanguage:\n  - zh\n---\n# 医疗问答 <b>model</b>\n", nil)
+		gc.mocks.stores.RepoMock().EXPECT().Tags(ctx, int64(1)).Return([]database.Tag{
+			{Name: "text-generation", ShowName: "文本生成"},
+			{Name: "pytorch", ShowName: "pytorch"},
+		}, nil)
+		// the paths come from the tree of the default branch, the deleted files are not in it
+		gc.mocks.gitServer.EXPECT().GetTree(ctx, types.GetTreeRequest{
+			Namespace: "namespace",
+			Name:      "repo",
+			RepoType:  types.ModelRepo,
+			Ref:       "main",
+			Recursive: true,
+			Limit:     types.MaxFileTreeSize,
+		}).Return(&types.GetRepoFileTreeResp{
+			Files: []*types.File{
+				{Path: "README.md", Type: "file"},
+				{Path: "configs", Type: "dir"},
+			},
+			Cursor: "next",
+		}, nil).Once()
+		gc.mocks.gitServer.EXPECT().GetTree(ctx, types.GetTreeRequest{
+			Namespace: "namespace",
+			Name:      "repo",
+			RepoType:  types.ModelRepo,
+			Ref:       "main",
+			Recursive: true,
+			Limit:     types.MaxFileTreeSize,
+			Cursor:    "next",
+		}).Return(&types.GetRepoFileTreeResp{
+			Files: []*types.File{{Path: "configs/config.json", Type: "file"}},
+		}, nil).Once()
+		gc.mocks.stores.RepositorySearchDocumentMock().EXPECT().Upsert(ctx, &database.RepositorySearchDocument{
+			RepositoryID: 1,
+			Readme:       "# 医疗问答 &lt;b&gt;model&lt;/b&gt;",
+			Card:         "zh apache-2.0",
+			Tags:         "text-generation 文本生成 pytorch",
+			FilePaths:    "README.md configs/config.json",
+		}).Return(nil)
+
+		err := gc.RefreshSearchDocument(context.Background(), req)
+		require.NoError(t, err)
+	})
+
+	t.Run("should skip the pushes to the other branches", func(t *testing.T) {
+		gc := initializeTestGitCallbackComponent(context.Background(), t)
+		req := &types.GiteaCallbackPushReq{
+			Ref: "refs/heads/dev",
+			Repository: types.GiteaCallbackPushReq_Repository{
+				FullName: "models_namespace/repo",
+			},
+		}
+		gc.mocks.stores.RepoMock().EXPECT().FindByPath(ctx, types.ModelRepo, "namespace", "repo").Return(repo, nil)
+
+		err := gc.RefreshSearchDocument(context.Background(), req)
+		require.NoError(t, err)
+	})
+}
+
+func TestSplitReadmeMeta(t *testing.T) {
+	meta, body := splitReadmeMeta("\ufeff---\nlicense: mit\n---\nbody")
+	require.Equal(t, "\nlicense: mit", meta)
+	require.Equal(t, "body", body)
+
+	meta, body = splitReadmeMeta("# title\n---\n")
+	require.Empty(t, meta)
+	require.Equal(t, "# title\n---\n", body)
+}
+
+func TestGitCallbackComponentImpl_RecordFeedEvent(t *testing.T) {
+	ctx := mock.Anything
+
+	t.Run("should record the new tag", func(t *testing.T) {
+		gc := initializeTestGitCallbackComponent(context.Background(), t)
+		req := &types.GiteaCallbackPushReq{
+			Ref:        "refs/tags/v1.0",
+			HeadCommit: types.GiteaCallbackPushReq_HeadCommit{Id: "abc123"},
+			Repository: types.GiteaCallbackPushReq_Repository{
+				FullName: "models_namespace/repo",
+			},
+		}
+		gc.mocks.stores.RepoMock().EXPECT().FindByPath(ctx, types.ModelRepo, "namespace", "repo").
+			Return(&database.Repository{ID: 1, Path: "namespace/repo"}, nil)
+		gc.mocks.stores.FeedEventMock().EXPECT().Create(ctx, &database.FeedEvent{
+			EventType:    string(types.FeedEventTagCreated),
+			Namespace:    "namespace",
+			RepositoryID: 1,
+			Payload:      map[string]any{"tag": "v1.0", "commit": "abc123"},
+		}).Return(nil)
+
+		err := gc.RecordFeedEvent(context.Background(), req)
+		require.NoError(t, err)
+	})
+
+	t.Run("should skip the branches and the deleted tags", func(t *testing.T) {
+		gc := initializeTestGitCallbackComponent(context.Background(), t)
+		for _, req := range []*types.GiteaCallbackPushReq{
+			{Ref: "refs/heads/main", HeadCommit: types.GiteaCallbackPushReq_HeadCommit{Id: "abc123"}},
+			{Ref: "refs/tags/v1.0", HeadCommit: types.GiteaCallbackPushReq_HeadCommit{Id: "0000000000000000000000000000000000000000"}},
+		} {
+			req.Repository.FullName = "models_namespace/repo"
+			err := gc.RecordFeedEvent(context.Background(), req)
+			require.NoError(t, err)
+		}
+	})
+}
+
+func TestGitCallbackComponentImpl_RefreshRepoCard(t *testing.T) {
+	ctx := mock.Anything
+	repo := &database.Repository{ID: 1, Path: "namespace/repo", DefaultBranch: "main", RepositoryType: types.ModelRepo}
+	newReq := func(commit types.GiteaCallbackPushReq_Commit) *types.GiteaCallbackPushReq {
+		return &types.GiteaCallbackPushReq{
+			Ref:     "refs/heads/main",
+			Commits: []types.GiteaCallbackPushReq_Commit{commit},
+			Repository: types.GiteaCallbackPushReq_Repository{
+				FullName: "models_namespace/repo",
+			},
+		}
+	}
+	readmeReq := gitserver.GetRepoInfoByPathReq{
+		Namespace: "namespace",
+		Name:      "repo",
+		Ref:       "main",
+		Path:      types.ReadmeFileName,
+		RepoType:  types.ModelRepo,
+	}
+
+	t.Run("should save the card of the changed readme", func(t *testing.T) {
+		gc := initializeTestGitCallbackComponent(context.Background(), t)
+		gc.mocks.stores.RepoMock().EXPECT().FindByPath(ctx, types.ModelRepo, "namespace", "repo").Return(repo, nil)
+		gc.mocks.gitServer.EXPECT().GetRepoFileRaw(ctx, readmeReq).
+			Return("---\nlicense: mit\nbase_model: foo/base\n---\n# model\n", nil)
+		gc.mocks.stores.MetadataMock().EXPECT().UpsertCard(ctx, int64(1), &types.RepoCard{
+			RepoType:  types.ModelRepo,
+			License:   "mit",
+			BaseModel: []string{"foo/base"},
+		}).Return(nil)
+
+		err := gc.RefreshRepoCard(context.Background(), newReq(types.GiteaCallbackPushReq_Commit{Modified: []string{types.ReadmeFileName}}))
+		require.NoError(t, err)
+	})
+
+	t.Run("should save the warnings with the card", func(t *testing.T) {
+		gc := initializeTestGitCallbackComponent(context.Background(), t)
+		gc.mocks.stores.RepoMock().EXPECT().FindByPath(ctx, types.ModelRepo, "namespace", "repo").Return(repo, nil)
+		gc.mocks.gitServer.EXPECT().GetRepoFileRaw(ctx, readmeReq).
+			Return("---\nlicense: mit\npipeline_tag: chat\n---\n# model\n", nil)
+		gc.mocks.stores.MetadataMock().EXPECT().UpsertCard(ctx, int64(1), &types.RepoCard{
+			RepoType:    types.ModelRepo,
+			License:     "mit",
+			PipelineTag: "chat",
+			Warnings:    []types.CardWarning{{Field: "pipeline_tag", Message: `unknown pipeline tag "chat"`}},
+		}).Return(nil)
+
+		err := gc.RefreshRepoCard(context.Background(), newReq(types.GiteaCallbackPushReq_Commit{Modified: []string{types.ReadmeFileName}}))
+		require.NoError(t, err)
+	})
+
+	t.Run("should clear the card of the removed readme", func(t *testing.T) {
+		gc := initializeTestGitCallbackComponent(context.Background(), t)
+		gc.mocks.stores.RepoMock().EXPECT().FindByPath(ctx, types.ModelRepo, "namespace", "repo").Return(repo, nil)
+		gc.mocks.gitServer.EXPECT().GetRepoFileRaw(ctx, readmeReq).Return("", errorx.GitFileNotFound(errors.New("not found"), nil))
+		gc.mocks.stores.MetadataMock().EXPECT().UpsertCard(ctx, int64(1), (*types.RepoCard)(nil)).Return(nil)
+
+		err := gc.RefreshRepoCard(context.Background(), newReq(types.GiteaCallbackPushReq_Commit{Removed: []string{types.ReadmeFileName}}))
+		require.NoError(t, err)
+	})
+
+	t.Run("should skip the pushes not changing the readme", func(t *testing.T) {
+		gc := initializeTestGitCallbackComponent(context.Background(), t)
+		gc.mocks.stores.RepoMock().EXPECT().FindByPath(ctx, types.ModelRepo, "namespace", "repo").Return(repo, nil)
+
+		err := gc.RefreshRepoCard(context.Background(), newReq(types.GiteaCallbackPushReq_Commit{Added: []string{"config.json"}}))
+		require.NoError(t, err)
+	})
+}
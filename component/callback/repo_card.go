@@ -0,0 +1,65 @@
+package callback
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+	"opencsg.com/csghub-server/component/cardparser"
+)
+
+// RefreshRepoCard parses the README of the default branch into the card of the repository and saves it with
+// its warnings in the repository metadata, the card is cleared if the README is removed
+func (c *gitCallbackComponentImpl) RefreshRepoCard(ctx context.Context, req *types.GiteaCallbackPushReq) error {
+	splits := strings.Split(req.Repository.FullName, "/")
+	if len(splits) != 2 {
+		slog.Warn("invalid callback repo full name for repo card refresh", slog.String("full_name", req.Repository.FullName))
+		return nil
+	}
+	fullNamespace, repoName := splits[0], splits[1]
+	repoType, namespace, _ := strings.Cut(fullNamespace, "_")
+	adjustedRepoType := types.RepositoryType(strings.TrimRight(repoType, "s"))
+
+	repo, err := c.repoStore.FindByPath(ctx, adjustedRepoType, namespace, repoName)
+	if err != nil {
+		return fmt.Errorf("failed to find repo %s/%s/%s, error: %w", adjustedRepoType, namespace, repoName, err)
+	}
+	branch := strings.TrimPrefix(req.Ref, "refs/heads/")
+	if branch != repo.DefaultBranch || !readmeChanged(req.Commits) {
+		return nil
+	}
+
+	readme, err := c.getFileRaw(repoType, namespace, repoName, branch, types.ReadmeFileName)
+	if err != nil {
+		if errors.Is(err, errorx.ErrGitFileNotFound) {
+			return c.metadataStore.UpsertCard(ctx, repo.ID, nil)
+		}
+		return err
+	}
+	card, warnings, err := cardparser.Parse(adjustedRepoType, readme)
+	if err != nil {
+		// the card of the last valid README is kept
+		slog.Warn("failed to parse repo card", slog.String("repo", repo.Path), slog.Any("error", err))
+		return nil
+	}
+	card.Warnings = warnings
+	if err := c.metadataStore.UpsertCard(ctx, repo.ID, card); err != nil {
+		return fmt.Errorf("failed to save repo card, error: %w", err)
+	}
+	return nil
+}
+
+func readmeChanged(commits []types.GiteaCallbackPushReq_Commit) bool {
+	for _, commit := range commits {
+		if slices.Contains(commit.Added, types.ReadmeFileName) || slices.Contains(commit.Modified, types.ReadmeFileName) ||
+			slices.Contains(commit.Removed, types.ReadmeFileName) {
+			return true
+		}
+	}
+	return false
+}
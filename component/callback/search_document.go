@@ -0,0 +1,177 @@
+package callback
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+)
+
+// maxSearchDocumentFiles is the max number of the file paths in the search document of a repository
+const maxSearchDocumentFiles = 1000
+
+// RefreshSearchDocument rebuilds the full-text search document of the repository from the README, the tags
+// and the files of the default branch, the pushes to the other branches are ignored
+func (c *gitCallbackComponentImpl) RefreshSearchDocument(ctx context.Context, req *types.GiteaCallbackPushReq) error {
+	splits := strings.Split(req.Repository.FullName, "/")
+	if len(splits) != 2 {
+		slog.Warn("invalid callback repo full name for search document refresh", slog.String("full_name", req.Repository.FullName))
+		return nil
+	}
+	fullNamespace, repoName := splits[0], splits[1]
+	repoType, namespace, _ := strings.Cut(fullNamespace, "_")
+	adjustedRepoType := types.RepositoryType(strings.TrimRight(repoType, "s"))
+
+	repo, err := c.repoStore.FindByPath(ctx, adjustedRepoType, namespace, repoName)
+	if err != nil {
+		return fmt.Errorf("failed to find repo %s/%s/%s, error: %w", adjustedRepoType, namespace, repoName, err)
+	}
+	branch := strings.TrimPrefix(req.Ref, "refs/heads/")
+	if branch != repo.DefaultBranch {
+		return nil
+	}
+	return c.RebuildSearchDocument(ctx, repo)
+}
+
+// RebuildSearchDocument rebuilds the full-text search document of the repository from its default branch,
+// it's also used to backfill the documents of the repositories pushed before the documents existed
+func (c *gitCallbackComponentImpl) RebuildSearchDocument(ctx context.Context, repo *database.Repository) error {
+	namespace, repoName := repo.NamespaceAndName()
+	readme, err := c.getFileRaw(string(repo.RepositoryType), namespace, repoName, repo.DefaultBranch, types.ReadmeFileName)
+	if err != nil && !errors.Is(err, errorx.ErrGitFileNotFound) {
+		return err
+	}
+	tags, err := c.repoStore.Tags(ctx, repo.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get repo tags, error: %w", err)
+	}
+	paths, err := c.searchDocumentPaths(ctx, repo)
+	if err != nil {
+		return err
+	}
+
+	doc := buildSearchDocument(repo.ID, readme, tags, paths)
+	if err := c.searchDocumentStore.Upsert(ctx, doc); err != nil {
+		return fmt.Errorf("failed to save repo search document, error: %w", err)
+	}
+	return nil
+}
+
+// searchDocumentPaths returns the file paths in the tree of the default branch, so the deleted files are not
+// searchable, at most maxSearchDocumentFiles paths are returned
+func (c *gitCallbackComponentImpl) searchDocumentPaths(ctx context.Context, repo *database.Repository) ([]string, error) {
+	namespace, repoName := repo.NamespaceAndName()
+	var (
+		paths  []string
+		cursor string
+	)
+	for len(paths) < maxSearchDocumentFiles {
+		resp, err := c.gitServer.GetTree(ctx, types.GetTreeRequest{
+			Namespace: namespace,
+			Name:      repoName,
+			RepoType:  repo.RepositoryType,
+			Ref:       repo.DefaultBranch,
+			Recursive: true,
+			Limit:     types.MaxFileTreeSize,
+			Cursor:    cursor,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get repo file tree, error: %w", err)
+		}
+		if resp == nil {
+			break
+		}
+		for _, file := range resp.Files {
+			if file.Type == "dir" || len(paths) >= maxSearchDocumentFiles {
+				continue
+			}
+			paths = append(paths, file.Path)
+		}
+		cursor = resp.Cursor
+		if cursor == "" {
+			break
+		}
+	}
+	return paths, nil
+}
+
+// buildSearchDocument builds the search document, the text is html escaped as the search snippets are
+// rendered as html
+func buildSearchDocument(repoID int64, readme string, tags []database.Tag, paths []string) *database.RepositorySearchDocument {
+	meta, body := splitReadmeMeta(readme)
+	var card []string
+	fields := make(map[string]any)
+	if err := yaml.Unmarshal([]byte(meta), &fields); err != nil {
+		slog.Warn("failed to parse readme metadata for search document", slog.Int64("repo_id", repoID), slog.Any("error", err))
+	} else {
+		card = cardValues(fields, card)
+	}
+	tagNames := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tagNames = append(tagNames, tag.Name)
+		if tag.ShowName != "" && tag.ShowName != tag.Name {
+			tagNames = append(tagNames, tag.ShowName)
+		}
+	}
+	return &database.RepositorySearchDocument{
+		RepositoryID: repoID,
+		Readme:       html.EscapeString(strings.TrimSpace(body)),
+		Card:         html.EscapeString(strings.Join(card, " ")),
+		Tags:         html.EscapeString(strings.Join(tagNames, " ")),
+		FilePaths:    html.EscapeString(strings.Join(paths, " ")),
+	}
+}
+
+// splitReadmeMeta splits the README into the yaml metadata between the leading "---" lines and the body
+func splitReadmeMeta(readme string) (meta, body string) {
+	content := strings.TrimLeft(readme, "\ufeff\r\n\t ")
+	if !strings.HasPrefix(content, "---") {
+		return "", readme
+	}
+	rest := content[3:]
+	end := strings.Index(rest, "\n---")
+	if end < 0 {
+		return "", readme
+	}
+	meta, body = rest[:end], rest[end+4:]
+	if i := strings.IndexByte(body, '\n'); i >= 0 {
+		body = body[i+1:]
+	} else {
+		body = ""
+	}
+	return meta, body
+}
+
+// cardValues appends the string and number values of the card fields in the key order
+func cardValues(value any, values []string) []string {
+	switch v := value.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			values = cardValues(v[k], values)
+		}
+	case []any:
+		for _, item := range v {
+			values = cardValues(item, values)
+		}
+	case string:
+		if s := strings.TrimSpace(v); s != "" {
+			values = append(values, s)
+		}
+	case int, int64, float64:
+		values = append(values, fmt.Sprint(v))
+	}
+	return values
+}
@@ -64,6 +64,9 @@ func NewTestGitCallbackComponent(config *config.Config, stores *tests.MockStores
 		tagStore:                  stores.Tag,
 		tagRuleStore:              stores.TagRule,
 		repositoryStatisticsStore: stores.RepositoryStatistics,
+		searchDocumentStore:       stores.RepositorySearchDocument,
+		feedEventStore:            stores.FeedEvent,
+		metadataStore:             stores.Metadata,
 	}
 }
 
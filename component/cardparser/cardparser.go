@@ -0,0 +1,441 @@
+package cardparser
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	"opencsg.com/csghub-server/common/types"
+)
+
+// fieldParser parses the value of a front matter field into the card
+type fieldParser func(p *parser, field string, value any)
+
+var (
+	commonFields = map[string]fieldParser{
+		"license":      parseLicense,
+		"license_name": func(p *parser, field string, value any) { p.card.LicenseName, _ = p.str(field, value) },
+		"license_link": func(p *parser, field string, value any) { p.card.LicenseLink, _ = p.str(field, value) },
+		"language":     parseLanguage,
+		"tags":         func(p *parser, field string, value any) { p.card.Tags = p.strList(field, value) },
+	}
+	modelFields = map[string]fieldParser{
+		"base_model":          parseBaseModel,
+		"base_model_relation": parseBaseModelRelation,
+		"datasets":            func(p *parser, field string, value any) { p.card.Datasets = p.strList(field, value) },
+		"pipeline_tag":        parsePipelineTag,
+		"library_name":        func(p *parser, field string, value any) { p.card.LibraryName, _ = p.str(field, value) },
+		"model-index":         parseModelIndex,
+	}
+	datasetFields = map[string]fieldParser{
+		"task_categories": func(p *parser, field string, value any) { p.card.TaskCategories = p.strList(field, value) },
+		"size_categories": parseSizeCategories,
+		"configs":         parseConfigs,
+	}
+)
+
+var (
+	languagePattern  = regexp.MustCompile(`^[a-z]{2,3}([-_][a-zA-Z0-9]+)*$`)
+	repoPathPattern  = regexp.MustCompile(`^[\w.-]+/[\w.-]+$`)
+	baseModelRelates = []string{"adapter", "merge", "quantized", "finetune"}
+)
+
+// Parse parses the README front matter into the card of the repository type. The values of a wrong type
+// are dropped and the values not matching the schema are kept, both are reported as warnings. An error is
+// returned only if the front matter is not a valid yaml mapping.
+func Parse(repoType types.RepositoryType, readme string) (*types.RepoCard, []types.CardWarning, error) {
+	p := &parser{card: &types.RepoCard{RepoType: repoType}}
+	meta, ok := FrontMatter(readme)
+	if !ok || strings.TrimSpace(meta) == "" {
+		p.warn("", "the README has no metadata, add the yaml metadata between the --- lines at the beginning of the README")
+		return p.card, p.warnings, nil
+	}
+
+	values := make(map[string]any)
+	if err := yaml.Unmarshal([]byte(meta), &values); err != nil {
+		return nil, nil, fmt.Errorf("invalid yaml metadata, error: %w", err)
+	}
+
+	fields := fieldsOf(repoType)
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		if values[key] == nil {
+			continue
+		}
+		if parse, ok := fields[key]; ok {
+			parse(p, key, values[key])
+			continue
+		}
+		if other := otherCardOf(repoType, key); other != "" {
+			p.warn(key, "%s is a field of the %s card, it's ignored in the %s card", key, other, repoType)
+		}
+	}
+	p.finish()
+	return p.card, p.warnings, nil
+}
+
+// FrontMatter returns the yaml metadata between the --- lines at the beginning of the README
+func FrontMatter(readme string) (string, bool) {
+	readme = strings.TrimPrefix(readme, "\ufeff")
+	readme = strings.ReplaceAll(readme, "\r\n", "\n")
+	// the blank lines before the metadata are tolerated, the same as the tag parser
+	readme = strings.TrimLeft(readme, " \t\n")
+	if !strings.HasPrefix(readme, "---\n") {
+		return "", false
+	}
+	rest := readme[len("---\n"):]
+	if strings.HasPrefix(rest, "---") {
+		return "", true
+	}
+	end := strings.Index(rest, "\n---")
+	if end < 0 {
+		return "", false
+	}
+	return rest[:end], true
+}
+
+func fieldsOf(repoType types.RepositoryType) map[string]fieldParser {
+	fields := make(map[string]fieldParser, len(commonFields)+len(modelFields))
+	for k, v := range commonFields {
+		fields[k] = v
+	}
+	var extra map[string]fieldParser
+	switch repoType {
+	case types.ModelRepo:
+		extra = modelFields
+	case types.DatasetRepo:
+		extra = datasetFields
+	}
+	for k, v := range extra {
+		fields[k] = v
+	}
+	return fields
+}
+
+// otherCardOf returns the card type the field belongs to if it's not a field of the repository type
+func otherCardOf(repoType types.RepositoryType, field string) types.RepositoryType {
+	if _, ok := modelFields[field]; ok && repoType != types.ModelRepo {
+		return types.ModelRepo
+	}
+	if _, ok := datasetFields[field]; ok && repoType != types.DatasetRepo {
+		return types.DatasetRepo
+	}
+	return ""
+}
+
+type parser struct {
+	card     *types.RepoCard
+	warnings []types.CardWarning
+}
+
+func (p *parser) warn(field, format string, args ...any) {
+	p.warnings = append(p.warnings, types.CardWarning{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+func (p *parser) str(field string, value any) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return strings.TrimSpace(v), true
+	case int, int64, float64, bool:
+		// yaml turns the unquoted values like 2.0 or true into numbers and booleans
+		return fmt.Sprint(v), true
+	default:
+		p.warn(field, "%s should be a string", field)
+		return "", false
+	}
+}
+
+// strList accepts a string or a list of strings, the items of other types are dropped
+func (p *parser) strList(field string, value any) []string {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case string:
+		if s := strings.TrimSpace(v); s != "" {
+			return []string{s}
+		}
+		return nil
+	case []any:
+		res := make([]string, 0, len(v))
+		for i, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				p.warn(field, "%s[%d] should be a string", field, i)
+				continue
+			}
+			if s = strings.TrimSpace(s); s != "" && !slices.Contains(res, s) {
+				res = append(res, s)
+			}
+		}
+		return res
+	default:
+		p.warn(field, "%s should be a string or a list of strings", field)
+		return nil
+	}
+}
+
+func (p *parser) mapping(field string, value any) (map[string]any, bool) {
+	if value == nil {
+		p.warn(field, "%s is required", field)
+		return nil, false
+	}
+	m, ok := value.(map[string]any)
+	if !ok {
+		p.warn(field, "%s should be a mapping", field)
+	}
+	return m, ok
+}
+
+func (p *parser) list(field string, value any) ([]any, bool) {
+	if value == nil {
+		p.warn(field, "%s is required", field)
+		return nil, false
+	}
+	l, ok := value.([]any)
+	if !ok {
+		p.warn(field, "%s should be a list", field)
+	}
+	return l, ok
+}
+
+// finish checks the fields depending on each other
+func (p *parser) finish() {
+	if p.card.License == "other" && p.card.LicenseName == "" {
+		p.warn("license_name", "license_name is required when the license is other")
+	}
+	if p.card.BaseModelRelation != "" && len(p.card.BaseModel) == 0 {
+		p.warn("base_model_relation", "base_model_relation is set but base_model is empty")
+	}
+}
+
+func parseLicense(p *parser, field string, value any) {
+	license, ok := p.str(field, value)
+	if !ok {
+		return
+	}
+	license = strings.ToLower(license)
+	p.card.License = license
+	if !slices.Contains(knownLicenses, license) {
+		p.warn(field, "unknown license %q, use a license identifier like apache-2.0, or other with license_name and license_link", license)
+	}
+}
+
+func parseLanguage(p *parser, field string, value any) {
+	p.card.Language = p.strList(field, value)
+	for _, lang := range p.card.Language {
+		if !languagePattern.MatchString(lang) {
+			p.warn(field, "%q is not an ISO 639 language code", lang)
+		}
+	}
+}
+
+func parseBaseModel(p *parser, field string, value any) {
+	p.card.BaseModel = p.strList(field, value)
+	for _, model := range p.card.BaseModel {
+		if !repoPathPattern.MatchString(model) {
+			p.warn(field, "base model %q should be the path of a model, e.g. namespace/name", model)
+		}
+	}
+}
+
+func parseBaseModelRelation(p *parser, field string, value any) {
+	relation, ok := p.str(field, value)
+	if !ok {
+		return
+	}
+	p.card.BaseModelRelation = relation
+	if !slices.Contains(baseModelRelates, relation) {
+		p.warn(field, "unknown base model relation %q, it should be one of %s", relation, strings.Join(baseModelRelates, ", "))
+	}
+}
+
+func parsePipelineTag(p *parser, field string, value any) {
+	tag, ok := p.str(field, value)
+	if !ok {
+		return
+	}
+	p.card.PipelineTag = tag
+	if !slices.Contains(knownPipelineTags, tag) {
+		p.warn(field, "unknown pipeline tag %q", tag)
+	}
+}
+
+func parseSizeCategories(p *parser, field string, value any) {
+	p.card.SizeCategories = p.strList(field, value)
+	for _, size := range p.card.SizeCategories {
+		if !slices.Contains(knownSizeCategories, size) {
+			p.warn(field, "unknown size category %q", size)
+		}
+	}
+}
+
+func parseModelIndex(p *parser, field string, value any) {
+	items, ok := p.list(field, value)
+	if !ok {
+		return
+	}
+	for i, item := range items {
+		itemField := fmt.Sprintf("%s[%d]", field, i)
+		m, ok := p.mapping(itemField, item)
+		if !ok {
+			continue
+		}
+		index := types.CardModelIndex{}
+		if name, ok := m["name"]; ok {
+			index.Name, _ = p.str(itemField+".name", name)
+		}
+		if index.Name == "" {
+			p.warn(itemField+".name", "the name of the model is required")
+		}
+		results, ok := p.list(itemField+".results", m["results"])
+		if !ok {
+			continue
+		}
+		for j, result := range results {
+			if r, ok := parseEvalResult(p, fmt.Sprintf("%s.results[%d]", itemField, j), result); ok {
+				index.Results = append(index.Results, r)
+			}
+		}
+		p.card.ModelIndex = append(p.card.ModelIndex, index)
+	}
+}
+
+// parseEvalResult returns false if the result has no task type or no metric, which makes it meaningless
+func parseEvalResult(p *parser, field string, value any) (types.CardEvalResult, bool) {
+	var result types.CardEvalResult
+	m, ok := p.mapping(field, value)
+	if !ok {
+		return result, false
+	}
+	if task, ok := p.mapping(field+".task", m["task"]); ok {
+		result.Task.Type = optionalStr(p, field+".task.type", task["type"])
+		result.Task.Name = optionalStr(p, field+".task.name", task["name"])
+	}
+	if result.Task.Type == "" {
+		p.warn(field+".task.type", "the task type of the result is required, the result is ignored")
+		return result, false
+	}
+	if dataset, ok := p.mapping(field+".dataset", m["dataset"]); ok {
+		result.Dataset.Type = optionalStr(p, field+".dataset.type", dataset["type"])
+		result.Dataset.Name = optionalStr(p, field+".dataset.name", dataset["name"])
+		result.Dataset.Config = optionalStr(p, field+".dataset.config", dataset["config"])
+		result.Dataset.Split = optionalStr(p, field+".dataset.split", dataset["split"])
+		if result.Dataset.Type == "" || result.Dataset.Name == "" {
+			p.warn(field+".dataset", "the type and the name of the dataset are required")
+		}
+	}
+	var metrics []any
+	if m["metrics"] != nil {
+		metrics, _ = p.list(field+".metrics", m["metrics"])
+	}
+	for k, metric := range metrics {
+		metricField := fmt.Sprintf("%s.metrics[%d]", field, k)
+		mm, ok := p.mapping(metricField, metric)
+		if !ok {
+			continue
+		}
+		item := types.CardEvalMetric{
+			Type:  optionalStr(p, metricField+".type", mm["type"]),
+			Name:  optionalStr(p, metricField+".name", mm["name"]),
+			Value: mm["value"],
+		}
+		item.Verified, _ = mm["verified"].(bool)
+		if item.Type == "" || item.Value == nil {
+			p.warn(metricField, "the type and the value of the metric are required, the metric is ignored")
+			continue
+		}
+		result.Metrics = append(result.Metrics, item)
+	}
+	if len(result.Metrics) == 0 {
+		p.warn(field+".metrics", "the result has no metric, the result is ignored")
+		return result, false
+	}
+	return result, true
+}
+
+func optionalStr(p *parser, field string, value any) string {
+	if value == nil {
+		return ""
+	}
+	s, _ := p.str(field, value)
+	return s
+}
+
+func parseConfigs(p *parser, field string, value any) {
+	items, ok := p.list(field, value)
+	if !ok {
+		return
+	}
+	defaults := 0
+	for i, item := range items {
+		itemField := fmt.Sprintf("%s[%d]", field, i)
+		m, ok := p.mapping(itemField, item)
+		if !ok {
+			continue
+		}
+		config := types.CardDatasetConfig{ConfigName: optionalStr(p, itemField+".config_name", m["config_name"])}
+		if config.ConfigName == "" {
+			p.warn(itemField+".config_name", "config_name is required, the config is ignored")
+			continue
+		}
+		if slices.ContainsFunc(p.card.Configs, func(c types.CardDatasetConfig) bool { return c.ConfigName == config.ConfigName }) {
+			p.warn(itemField+".config_name", "duplicated config %q, the config is ignored", config.ConfigName)
+			continue
+		}
+		config.Default, _ = m["default"].(bool)
+		if config.Default {
+			defaults++
+		}
+		config.DataFiles = parseDataFiles(p, itemField+".data_files", m["data_files"])
+		if len(config.DataFiles) == 0 {
+			p.warn(itemField+".data_files", "the config %q has no data files", config.ConfigName)
+		}
+		p.card.Configs = append(p.card.Configs, config)
+	}
+	if defaults > 1 {
+		p.warn(field, "only one config can be the default")
+	}
+}
+
+// parseDataFiles accepts a path, a list of paths or a list of splits with their paths
+func parseDataFiles(p *parser, field string, value any) []types.CardDataFiles {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case string:
+		return []types.CardDataFiles{{Path: []string{v}}}
+	case []any:
+		if len(v) > 0 {
+			if _, ok := v[0].(string); ok {
+				return []types.CardDataFiles{{Path: p.strList(field, v)}}
+			}
+		}
+		var res []types.CardDataFiles
+		for i, item := range v {
+			itemField := fmt.Sprintf("%s[%d]", field, i)
+			m, ok := p.mapping(itemField, item)
+			if !ok {
+				continue
+			}
+			files := types.CardDataFiles{
+				Split: optionalStr(p, itemField+".split", m["split"]),
+				Path:  p.strList(itemField+".path", m["path"]),
+			}
+			if len(files.Path) == 0 {
+				p.warn(itemField+".path", "the path of the data files is required")
+				continue
+			}
+			res = append(res, files)
+		}
+		return res
+	default:
+		p.warn(field, "data_files should be a path, a list of paths or a list of splits")
+		return nil
+	}
+}
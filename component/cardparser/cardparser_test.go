@@ -0,0 +1,133 @@
+package cardparser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"opencsg.com/csghub-server/common/types"
+)
+
+const modelReadme = `---
+license: Apache-2.0
+language:
+- en
+- zh
+base_model: Qwen/Qwen2.5-7B
+base_model_relation: finetune
+datasets:
+- opencsg/chinese-fineweb
+pipeline_tag: text-generation
+library_name: transformers
+configs:
+- config_name: default
+model-index:
+- name: csg-7b
+  results:
+  - task:
+      type: text-generation
+    dataset:
+      type: cmmlu
+      name: CMMLU
+      split: test
+    metrics:
+    - type: accuracy
+      value: 71.5
+      verified: true
+  - task:
+      name: missing type
+    metrics:
+    - type: accuracy
+      value: 1
+---
+# csg-7b
+`
+
+func TestParse_ModelCard(t *testing.T) {
+	card, warnings, err := Parse(types.ModelRepo, modelReadme)
+	require.NoError(t, err)
+	require.Equal(t, &types.RepoCard{
+		RepoType:          types.ModelRepo,
+		License:           "apache-2.0",
+		Language:          []string{"en", "zh"},
+		BaseModel:         []string{"Qwen/Qwen2.5-7B"},
+		BaseModelRelation: "finetune",
+		Datasets:          []string{"opencsg/chinese-fineweb"},
+		PipelineTag:       "text-generation",
+		LibraryName:       "transformers",
+		ModelIndex: []types.CardModelIndex{{
+			Name: "csg-7b",
+			Results: []types.CardEvalResult{{
+				Task:    types.CardEvalTask{Type: "text-generation"},
+				Dataset: types.CardEvalDataset{Type: "cmmlu", Name: "CMMLU", Split: "test"},
+				Metrics: []types.CardEvalMetric{{Type: "accuracy", Value: 71.5, Verified: true}},
+			}},
+		}},
+	}, card)
+	require.Equal(t, []types.CardWarning{
+		{Field: "configs", Message: "configs is a field of the dataset card, it's ignored in the model card"},
+		{Field: "model-index[0].results[1].task.type", Message: "the task type of the result is required, the result is ignored"},
+	}, warnings)
+}
+
+func TestParse_DatasetCard(t *testing.T) {
+	readme := "\n---\nlicense: other\nsize_categories: 10K<n<100K\ntask_categories: [text-generation]\n" +
+		"base_model: foo\nconfigs:\n" +
+		"- config_name: default\n  default: true\n  data_files: data/*.parquet\n" +
+		"- config_name: zh\n  data_files:\n  - split: train\n    path: zh/train-*\n  - split: test\n    path: [zh/test-*]\n" +
+		"- config_name: zh\n  data_files: x\n" +
+		"- data_files: y\n---\n"
+	card, warnings, err := Parse(types.DatasetRepo, readme)
+	require.NoError(t, err)
+	require.Equal(t, &types.RepoCard{
+		RepoType:       types.DatasetRepo,
+		License:        "other",
+		SizeCategories: []string{"10K<n<100K"},
+		TaskCategories: []string{"text-generation"},
+		Configs: []types.CardDatasetConfig{
+			{ConfigName: "default", Default: true, DataFiles: []types.CardDataFiles{{Path: []string{"data/*.parquet"}}}},
+			{ConfigName: "zh", DataFiles: []types.CardDataFiles{
+				{Split: "train", Path: []string{"zh/train-*"}},
+				{Split: "test", Path: []string{"zh/test-*"}},
+			}},
+		},
+	}, card)
+	require.Equal(t, []types.CardWarning{
+		{Field: "base_model", Message: "base_model is a field of the model card, it's ignored in the dataset card"},
+		{Field: "configs[2].config_name", Message: `duplicated config "zh", the config is ignored`},
+		{Field: "configs[3].config_name", Message: "config_name is required, the config is ignored"},
+		{Field: "license_name", Message: "license_name is required when the license is other"},
+	}, warnings)
+}
+
+func TestParse_Warnings(t *testing.T) {
+	readme := "---\nlicense: [mit]\nlanguage: English\nbase_model: [llama, 1]\npipeline_tag: chat\n---\n"
+	card, warnings, err := Parse(types.ModelRepo, readme)
+	require.NoError(t, err)
+	require.Empty(t, card.License)
+	require.Equal(t, []string{"llama"}, card.BaseModel)
+	require.Equal(t, "chat", card.PipelineTag)
+	require.Equal(t, []types.CardWarning{
+		{Field: "base_model", Message: "base_model[1] should be a string"},
+		{Field: "base_model", Message: `base model "llama" should be the path of a model, e.g. namespace/name`},
+		{Field: "language", Message: `"English" is not an ISO 639 language code`},
+		{Field: "license", Message: "license should be a string"},
+		{Field: "pipeline_tag", Message: `unknown pipeline tag "chat"`},
+	}, warnings)
+
+	card, warnings, err = Parse(types.CodeRepo, "# no metadata\n---\n")
+	require.NoError(t, err)
+	require.Equal(t, &types.RepoCard{RepoType: types.CodeRepo}, card)
+	require.Len(t, warnings, 1)
+
+	_, _, err = Parse(types.ModelRepo, "---\nlicense: [mit\n---\n")
+	require.Error(t, err)
+}
+
+func TestFrontMatter(t *testing.T) {
+	meta, ok := FrontMatter("\ufeff---\r\nlicense: mit\r\n---\r\nbody")
+	require.True(t, ok)
+	require.Equal(t, "license: mit", meta)
+
+	_, ok = FrontMatter("# title\n---\nlicense: mit\n---\n")
+	require.False(t, ok)
+}
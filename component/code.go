@@ -316,6 +316,7 @@ func (c *codeComponentImpl) Index(ctx context.Context, filter *types.RepoFilter,
 			Name:             repo.Name,
 			Nickname:         repo.Nickname,
 			Description:      repo.Description,
+			SearchSnippet:    repo.SearchSnippet,
 			Likes:            repo.Likes,
 			Downloads:        repo.DownloadCount,
 			Path:             repo.Path,
@@ -0,0 +1,349 @@
+package component
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"time"
+
+	"opencsg.com/csghub-server/builder/credential"
+	"opencsg.com/csghub-server/builder/git/membership"
+	"opencsg.com/csghub-server/builder/rpc"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/config"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+)
+
+var credentialNameRegex = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]{0,63}$`)
+
+// CredentialComponent manages the credentials of the users and organizations, like the hub tokens, s3 keys,
+// git access tokens and the api keys of the model providers, and grants them to the mirror syncs, deploys
+// and agent sessions
+type CredentialComponent interface {
+	ListProviders(ctx context.Context) []types.CredentialProviderDefinition
+	// Verify checks that the credential is accepted by its provider, the credential is not saved
+	Verify(ctx context.Context, req *types.VerifyCredentialRequest) error
+	Create(ctx context.Context, owner types.CredentialOwnerReq, req *types.CreateCredentialRequest) (*types.Credential, error)
+	List(ctx context.Context, owner types.CredentialOwnerReq, filter types.CredentialFilter, per, page int) ([]types.Credential, int, error)
+	Get(ctx context.Context, owner types.CredentialOwnerReq, name string) (*types.Credential, error)
+	Update(ctx context.Context, owner types.CredentialOwnerReq, name string, req *types.UpdateCredentialRequest) (*types.Credential, error)
+	// Rotate replaces the credential values, the tasks granted the credential get the new values on refresh
+	Rotate(ctx context.Context, owner types.CredentialOwnerReq, name string, req *types.RotateCredentialRequest) (*types.Credential, error)
+	Delete(ctx context.Context, owner types.CredentialOwnerReq, name string) error
+	// CreateGrant grants the credentials to a task, and returns the runtime token the task reads them with
+	CreateGrant(ctx context.Context, owner types.CredentialOwnerReq, req *types.CreateTaskCredentialGrantRequest) (*types.CreateTaskCredentialGrantResponse, error)
+	RuntimeGet(ctx context.Context, token, name string) (*types.RuntimeCredentialResponse, error)
+	RuntimeRefresh(ctx context.Context, token string, req *types.RuntimeRefreshRequest) ([]types.RuntimeCredentialResponse, error)
+	RuntimeRevokeSession(ctx context.Context, token string, req *types.RuntimeSessionRevokeRequest) error
+}
+
+type credentialComponentImpl struct {
+	broker          credential.Broker
+	credentialStore database.CredentialStore
+	userSvcClient   rpc.UserSvcClient
+	verifyClient    *http.Client
+}
+
+func NewCredentialComponent(config *config.Config) (CredentialComponent, error) {
+	broker, err := credential.NewBroker(config)
+	if err != nil {
+		return nil, err
+	}
+	return &credentialComponentImpl{
+		broker:          broker,
+		credentialStore: database.NewCredentialStore(),
+		userSvcClient: rpc.NewUserSvcHttpClient(fmt.Sprintf("%s:%d", config.User.Host, config.User.Port),
+			rpc.AuthWithApiKey(config.APIToken)),
+		verifyClient: credential.NewVerifyClient(),
+	}, nil
+}
+
+func (c *credentialComponentImpl) ListProviders(ctx context.Context) []types.CredentialProviderDefinition {
+	return credential.Providers()
+}
+
+func (c *credentialComponentImpl) Verify(ctx context.Context, req *types.VerifyCredentialRequest) error {
+	return credential.Verify(ctx, c.verifyClient, req)
+}
+
+func (c *credentialComponentImpl) Create(ctx context.Context, owner types.CredentialOwnerReq, req *types.CreateCredentialRequest) (*types.Credential, error) {
+	ns, err := c.checkNamespace(ctx, owner, true)
+	if err != nil {
+		return nil, err
+	}
+	if !credentialNameRegex.MatchString(req.CredentialName) {
+		return nil, errorx.ReqParamInvalid(errors.New("invalid credential name"), errorx.Ctx().Set("credential_name", req.CredentialName))
+	}
+	if err := credential.Validate(req.Provider, req.AuthType, req.Credential, req.Metadata); err != nil {
+		return nil, err
+	}
+	_, err = c.credentialStore.FindByName(ctx, ns.UUID, req.CredentialName)
+	if err == nil {
+		return nil, errorx.CredentialNameAlreadyExists(errors.New("credential name already exists"),
+			errorx.Ctx().Set("credential_name", req.CredentialName))
+	}
+	if !errors.Is(err, errorx.ErrNotFound) {
+		return nil, fmt.Errorf("failed to find credential, error: %w", err)
+	}
+
+	cred := &database.Credential{
+		NamespaceUUID:  ns.UUID,
+		CredentialName: req.CredentialName,
+		Provider:       req.Provider,
+		AuthType:       req.AuthType,
+		Description:    req.Description,
+		Metadata:       req.Metadata,
+		Status:         types.CredentialStatusActive,
+	}
+	if err := c.broker.Seal(ctx, cred, req.Credential); err != nil {
+		return nil, err
+	}
+	if _, err := c.credentialStore.Create(ctx, cred); err != nil {
+		if removeErr := c.broker.Remove(ctx, cred); removeErr != nil {
+			slog.ErrorContext(ctx, "failed to remove the secret of the credential not created", slog.Any("error", removeErr))
+		}
+		return nil, fmt.Errorf("failed to create credential, error: %w", err)
+	}
+	c.audit(ctx, owner, cred, types.CredentialAuditCreate)
+	return toCredential(cred), nil
+}
+
+func (c *credentialComponentImpl) List(ctx context.Context, owner types.CredentialOwnerReq, filter types.CredentialFilter, per, page int) ([]types.Credential, int, error) {
+	ns, err := c.checkNamespace(ctx, owner, false)
+	if err != nil {
+		return nil, 0, err
+	}
+	creds, total, err := c.credentialStore.ListByUser(ctx, ns.UUID, filter, per, page)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list credentials, error: %w", err)
+	}
+	res := make([]types.Credential, 0, len(creds))
+	for i := range creds {
+		res = append(res, *toCredential(&creds[i]))
+	}
+	return res, total, nil
+}
+
+func (c *credentialComponentImpl) Get(ctx context.Context, owner types.CredentialOwnerReq, name string) (*types.Credential, error) {
+	cred, err := c.findCredential(ctx, owner, name, false)
+	if err != nil {
+		return nil, err
+	}
+	return toCredential(cred), nil
+}
+
+func (c *credentialComponentImpl) Update(ctx context.Context, owner types.CredentialOwnerReq, name string, req *types.UpdateCredentialRequest) (*types.Credential, error) {
+	cred, err := c.findCredential(ctx, owner, name, true)
+	if err != nil {
+		return nil, err
+	}
+	if req.Description != nil {
+		cred.Description = *req.Description
+	}
+	if req.Metadata != nil {
+		if err := credential.ValidateMetadata(cred.Provider, *req.Metadata); err != nil {
+			return nil, err
+		}
+		cred.Metadata = *req.Metadata
+	}
+	cred.UpdatedAt = time.Now()
+	if err := c.credentialStore.Update(ctx, cred); err != nil {
+		return nil, err
+	}
+	c.audit(ctx, owner, cred, types.CredentialAuditUpdate)
+	return toCredential(cred), nil
+}
+
+func (c *credentialComponentImpl) Rotate(ctx context.Context, owner types.CredentialOwnerReq, name string, req *types.RotateCredentialRequest) (*types.Credential, error) {
+	cred, err := c.findCredential(ctx, owner, name, true)
+	if err != nil {
+		return nil, err
+	}
+	if err := credential.ValidateValues(cred.Provider, cred.AuthType, req.Credential); err != nil {
+		return nil, err
+	}
+	if err := c.broker.Seal(ctx, cred, req.Credential); err != nil {
+		return nil, err
+	}
+	cred.UpdatedAt = time.Now()
+	if err := c.credentialStore.Update(ctx, cred); err != nil {
+		return nil, err
+	}
+	c.audit(ctx, owner, cred, types.CredentialAuditRotate)
+	return toCredential(cred), nil
+}
+
+func (c *credentialComponentImpl) Delete(ctx context.Context, owner types.CredentialOwnerReq, name string) error {
+	cred, err := c.findCredential(ctx, owner, name, true)
+	if err != nil {
+		return err
+	}
+	if err := c.credentialStore.Delete(ctx, cred.ID); err != nil {
+		return fmt.Errorf("failed to delete credential, error: %w", err)
+	}
+	if err := c.broker.Remove(ctx, cred); err != nil {
+		slog.ErrorContext(ctx, "failed to delete the secret of credential", slog.Int64("credential_id", cred.ID), slog.Any("error", err))
+	}
+	c.audit(ctx, owner, cred, types.CredentialAuditDelete)
+	return nil
+}
+
+func (c *credentialComponentImpl) CreateGrant(ctx context.Context, owner types.CredentialOwnerReq, req *types.CreateTaskCredentialGrantRequest) (*types.CreateTaskCredentialGrantResponse, error) {
+	ns, err := c.checkNamespace(ctx, owner, true)
+	if err != nil {
+		return nil, err
+	}
+	return c.broker.Grant(ctx, credential.GrantRequest{
+		NamespaceUUID:   ns.UUID,
+		TaskID:          req.TaskID,
+		AgentID:         req.AgentID,
+		CredentialNames: req.CredentialNames,
+		Duration:        time.Duration(req.DurationSecs) * time.Second,
+	})
+}
+
+func (c *credentialComponentImpl) RuntimeGet(ctx context.Context, token, name string) (*types.RuntimeCredentialResponse, error) {
+	session, err := c.broker.ParseToken(token)
+	if err != nil {
+		return nil, err
+	}
+	return c.broker.Read(ctx, session, name)
+}
+
+func (c *credentialComponentImpl) RuntimeRefresh(ctx context.Context, token string, req *types.RuntimeRefreshRequest) ([]types.RuntimeCredentialResponse, error) {
+	session, err := c.broker.ParseToken(token)
+	if err != nil {
+		return nil, err
+	}
+	return c.broker.Refresh(ctx, session, req.CredentialName)
+}
+
+func (c *credentialComponentImpl) RuntimeRevokeSession(ctx context.Context, token string, req *types.RuntimeSessionRevokeRequest) error {
+	session, err := c.broker.ParseToken(token)
+	if err != nil {
+		return err
+	}
+	// a runtime token can only revoke its own session
+	if session.ID != req.SessionID {
+		return errorx.ErrForbidden
+	}
+	return c.broker.RevokeSession(ctx, session)
+}
+
+// checkNamespace returns the namespace of the credentials after checking the current user can read them,
+// or write them if write is true, the credentials of an organization are shared by its members
+func (c *credentialComponentImpl) checkNamespace(ctx context.Context, owner types.CredentialOwnerReq, write bool) (*rpc.Namespace, error) {
+	path := owner.Namespace
+	if path == "" {
+		path = owner.CurrentUser
+	}
+	ns, err := c.userSvcClient.GetNameSpaceInfo(ctx, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get namespace %s, error: %w", path, err)
+	}
+	if ns.NSType != string(database.OrgNamespace) {
+		if ns.Path != owner.CurrentUser {
+			return nil, errorx.ErrForbidden
+		}
+		return ns, nil
+	}
+
+	role, err := c.userSvcClient.GetMemberRole(ctx, ns.Path, owner.CurrentUser)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get member role of %s, error: %w", ns.Path, err)
+	}
+	if (write && !role.CanWrite()) || !role.CanRead() {
+		return nil, errorx.ErrForbidden
+	}
+	return ns, nil
+}
+
+func (c *credentialComponentImpl) findCredential(ctx context.Context, owner types.CredentialOwnerReq, name string, write bool) (*database.Credential, error) {
+	ns, err := c.checkNamespace(ctx, owner, write)
+	if err != nil {
+		return nil, err
+	}
+	cred, err := c.credentialStore.FindByName(ctx, ns.UUID, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find credential %s, error: %w", name, err)
+	}
+	return cred, nil
+}
+
+// audit writes the management actions to the audit log with the current user as the agent
+func (c *credentialComponentImpl) audit(ctx context.Context, owner types.CredentialOwnerReq, cred *database.Credential, action string) {
+	err := c.broker.Audit(ctx, &database.CredentialAuditLog{
+		NamespaceUUID: cred.NamespaceUUID,
+		AgentID:       owner.CurrentUser,
+		CredentialID:  cred.ID,
+		Provider:      cred.Provider,
+		Action:        action,
+		Result:        types.CredentialAuditSuccess,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to write credential audit log", slog.String("action", action),
+			slog.Int64("credential_id", cred.ID), slog.Any("error", err))
+	}
+}
+
+func toCredential(cred *database.Credential) *types.Credential {
+	res := &types.Credential{
+		ID:             cred.ID,
+		CredentialName: cred.CredentialName,
+		NamespaceUUID:  cred.NamespaceUUID,
+		Provider:       cred.Provider,
+		AuthType:       cred.AuthType,
+		Description:    cred.Description,
+		SecretBackend:  cred.SecretBackend,
+		Metadata:       cred.Metadata,
+		Status:         cred.Status,
+		CreatedAt:      cred.CreatedAt,
+		UpdatedAt:      cred.UpdatedAt,
+	}
+	if !cred.ExpiresAt.IsZero() {
+		res.ExpiresAt = &cred.ExpiresAt
+	}
+	if !cred.LastUsedAt.IsZero() {
+		res.LastUsedAt = &cred.LastUsedAt
+	}
+	if !cred.ArchivedAt.IsZero() {
+		res.ArchivedAt = &cred.ArchivedAt
+	}
+	return res
+}
+
+// checkDeployCredentials checks the credentials injected into a deploy exist in the owner namespace of the
+// deploy and can be read by the current user, they are granted to the deploy when it starts
+func checkDeployCredentials(ctx context.Context, repoComponent RepoComponent, credentialStore database.CredentialStore, currentUser, namespace string, names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+	if namespace != currentUser {
+		canRead, err := repoComponent.CheckCurrentUserPermission(ctx, currentUser, namespace, membership.RoleRead)
+		if err != nil {
+			return fmt.Errorf("failed to check namespace permission, error: %w", err)
+		}
+		if !canRead {
+			return errorx.ErrForbiddenMsg("users do not have permission to use the credentials of this namespace")
+		}
+	}
+	namespaceUUID, err := repoComponent.GetNamespaceBillingUUID(ctx, namespace)
+	if err != nil {
+		return fmt.Errorf("failed to find namespace %s, error: %w", namespace, err)
+	}
+	for _, name := range names {
+		_, err := credentialStore.FindByName(ctx, namespaceUUID, name)
+		if errors.Is(err, errorx.ErrNotFound) {
+			return errorx.ReqParamInvalid(errors.New("credential does not exist in the owner namespace"),
+				errorx.Ctx().Set("credential_name", name).Set("namespace", namespace))
+		}
+		if err != nil {
+			return fmt.Errorf("failed to find credential %s, error: %w", name, err)
+		}
+	}
+	return nil
+}
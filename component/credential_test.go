@@ -0,0 +1,162 @@
+package component
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	mockcredential "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/builder/credential"
+	mockrpc "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/builder/rpc"
+	mockdb "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/builder/credential"
+	"opencsg.com/csghub-server/builder/git/membership"
+	"opencsg.com/csghub-server/builder/rpc"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+)
+
+type testCredentialComponent struct {
+	*credentialComponentImpl
+	broker          *mockcredential.MockBroker
+	credentialStore *mockdb.MockCredentialStore
+	userSvcClient   *mockrpc.MockUserSvcClient
+}
+
+func newTestCredentialComponent(t *testing.T) *testCredentialComponent {
+	c := &testCredentialComponent{
+		broker:          mockcredential.NewMockBroker(t),
+		credentialStore: mockdb.NewMockCredentialStore(t),
+		userSvcClient:   mockrpc.NewMockUserSvcClient(t),
+	}
+	c.credentialComponentImpl = &credentialComponentImpl{
+		broker:          c.broker,
+		credentialStore: c.credentialStore,
+		userSvcClient:   c.userSvcClient,
+	}
+	return c
+}
+
+func TestCredentialComponent_Create(t *testing.T) {
+	ctx := context.TODO()
+	c := newTestCredentialComponent(t)
+	owner := types.CredentialOwnerReq{CurrentUser: "user"}
+	c.userSvcClient.EXPECT().GetNameSpaceInfo(ctx, "user").Return(&rpc.Namespace{Path: "user", UUID: "uuid", NSType: "user"}, nil)
+	c.credentialStore.EXPECT().FindByName(ctx, "uuid", "hf").Return(nil, errorx.ErrNotFound)
+	c.broker.EXPECT().Seal(ctx, mock.Anything, map[string]string{"token": "hf_abc"}).RunAndReturn(
+		func(ctx context.Context, cred *database.Credential, values map[string]string) error {
+			cred.SecretBackend = types.CredentialSecretBackendPostgresEncrypted
+			cred.SecretRef = "9"
+			return nil
+		})
+	c.credentialStore.EXPECT().Create(ctx, mock.Anything).RunAndReturn(func(ctx context.Context, cred *database.Credential) (*database.Credential, error) {
+		require.Equal(t, "9", cred.SecretRef)
+		cred.ID = 1
+		return cred, nil
+	})
+	c.broker.EXPECT().Audit(ctx, mock.Anything).RunAndReturn(func(ctx context.Context, log *database.CredentialAuditLog) error {
+		require.Equal(t, types.CredentialAuditCreate, log.Action)
+		require.Equal(t, int64(1), log.CredentialID)
+		return nil
+	})
+
+	cred, err := c.Create(ctx, owner, &types.CreateCredentialRequest{
+		CredentialName: "hf", Provider: "huggingface", AuthType: "token", Credential: map[string]string{"token": "hf_abc"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), cred.ID)
+	require.Equal(t, "uuid", cred.NamespaceUUID)
+	require.Equal(t, types.CredentialStatusActive, cred.Status)
+}
+
+func TestCredentialComponent_CreateDuplicated(t *testing.T) {
+	ctx := context.TODO()
+	c := newTestCredentialComponent(t)
+	owner := types.CredentialOwnerReq{CurrentUser: "user"}
+	c.userSvcClient.EXPECT().GetNameSpaceInfo(ctx, "user").Return(&rpc.Namespace{Path: "user", UUID: "uuid", NSType: "user"}, nil)
+	c.credentialStore.EXPECT().FindByName(ctx, "uuid", "hf").Return(&database.Credential{ID: 1}, nil)
+
+	_, err := c.Create(ctx, owner, &types.CreateCredentialRequest{
+		CredentialName: "hf", Provider: "huggingface", AuthType: "token", Credential: map[string]string{"token": "hf_abc"},
+	})
+	require.ErrorIs(t, err, errorx.ErrCredentialNameAlreadyExists)
+}
+
+func TestCredentialComponent_OrgPermission(t *testing.T) {
+	ctx := context.TODO()
+	c := newTestCredentialComponent(t)
+	owner := types.CredentialOwnerReq{CurrentUser: "user", Namespace: "org"}
+	c.userSvcClient.EXPECT().GetNameSpaceInfo(ctx, "org").Return(&rpc.Namespace{Path: "org", UUID: "org-uuid", NSType: "organization"}, nil)
+	c.userSvcClient.EXPECT().GetMemberRole(ctx, "org", "user").Return(membership.RoleRead, nil)
+
+	c.credentialStore.EXPECT().ListByUser(ctx, "org-uuid", types.CredentialFilter{}, 10, 1).
+		Return([]database.Credential{{ID: 1, CredentialName: "s3"}}, 1, nil)
+	creds, total, err := c.List(ctx, owner, types.CredentialFilter{}, 10, 1)
+	require.NoError(t, err)
+	require.Equal(t, 1, total)
+	require.Equal(t, "s3", creds[0].CredentialName)
+
+	err = c.Delete(ctx, owner, "s3")
+	require.ErrorIs(t, err, errorx.ErrForbidden)
+}
+
+func TestCredentialComponent_OtherUserForbidden(t *testing.T) {
+	ctx := context.TODO()
+	c := newTestCredentialComponent(t)
+	c.userSvcClient.EXPECT().GetNameSpaceInfo(ctx, "other").Return(&rpc.Namespace{Path: "other", UUID: "other-uuid", NSType: "user"}, nil)
+
+	_, err := c.Get(ctx, types.CredentialOwnerReq{CurrentUser: "user", Namespace: "other"}, "hf")
+	require.ErrorIs(t, err, errorx.ErrForbidden)
+}
+
+func TestCredentialComponent_Rotate(t *testing.T) {
+	ctx := context.TODO()
+	c := newTestCredentialComponent(t)
+	owner := types.CredentialOwnerReq{CurrentUser: "user"}
+	c.userSvcClient.EXPECT().GetNameSpaceInfo(ctx, "user").Return(&rpc.Namespace{Path: "user", UUID: "uuid", NSType: "user"}, nil)
+	cred := &database.Credential{ID: 1, NamespaceUUID: "uuid", CredentialName: "hf", Provider: "huggingface", AuthType: "token",
+		SecretBackend: types.CredentialSecretBackendPostgresEncrypted, SecretRef: "9", Status: types.CredentialStatusActive}
+	c.credentialStore.EXPECT().FindByName(ctx, "uuid", "hf").Return(cred, nil)
+
+	_, err := c.Rotate(ctx, owner, "hf", &types.RotateCredentialRequest{Credential: map[string]string{"api_key": "x"}})
+	require.ErrorIs(t, err, errorx.ErrReqParamInvalid)
+
+	c.broker.EXPECT().Seal(ctx, cred, map[string]string{"token": "hf_new"}).Return(nil)
+	c.credentialStore.EXPECT().Update(ctx, cred).Return(nil)
+	c.broker.EXPECT().Audit(ctx, mock.Anything).Return(nil)
+	_, err = c.Rotate(ctx, owner, "hf", &types.RotateCredentialRequest{Credential: map[string]string{"token": "hf_new"}})
+	require.NoError(t, err)
+}
+
+func TestCredentialComponent_CreateGrant(t *testing.T) {
+	ctx := context.TODO()
+	c := newTestCredentialComponent(t)
+	owner := types.CredentialOwnerReq{CurrentUser: "user"}
+	c.userSvcClient.EXPECT().GetNameSpaceInfo(ctx, "user").Return(&rpc.Namespace{Path: "user", UUID: "uuid", NSType: "user"}, nil)
+
+	resp := &types.CreateTaskCredentialGrantResponse{RuntimeCredentialToken: "token"}
+	c.broker.EXPECT().Grant(ctx, credential.GrantRequest{
+		NamespaceUUID: "uuid", TaskID: "task", AgentID: "agent", CredentialNames: []string{"hf"}, Duration: time.Minute,
+	}).Return(resp, nil)
+	res, err := c.CreateGrant(ctx, owner, &types.CreateTaskCredentialGrantRequest{
+		TaskID: "task", AgentID: "agent", CredentialNames: []string{"hf"}, DurationSecs: 60,
+	})
+	require.NoError(t, err)
+	require.Equal(t, resp, res)
+}
+
+func TestCredentialComponent_RuntimeRevokeSession(t *testing.T) {
+	ctx := context.TODO()
+	c := newTestCredentialComponent(t)
+	session := &credential.Session{ID: "s", NamespaceUUID: "uuid"}
+	c.broker.EXPECT().ParseToken("token").Return(session, nil)
+
+	err := c.RuntimeRevokeSession(ctx, "token", &types.RuntimeSessionRevokeRequest{SessionID: "other"})
+	require.ErrorIs(t, err, errorx.ErrForbidden)
+
+	c.broker.EXPECT().RevokeSession(ctx, session).Return(nil)
+	err = c.RuntimeRevokeSession(ctx, "token", &types.RuntimeSessionRevokeRequest{SessionID: "s"})
+	require.NoError(t, err)
+}
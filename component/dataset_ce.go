@@ -92,22 +92,23 @@ func (c *datasetComponentImpl) commonIndex(ctx context.Context, filter *types.Re
 		}
 
 		resDatasets = append(resDatasets, &types.Dataset{
-			ID:           dataset.ID,
-			Name:         repo.Name,
-			Nickname:     repo.Nickname,
-			Description:  repo.Description,
-			Likes:        repo.Likes,
-			Downloads:    repo.DownloadCount,
-			Path:         repo.Path,
-			RepositoryID: repo.ID,
-			Private:      repo.Private,
-			Tags:         tags,
-			CreatedAt:    dataset.CreatedAt,
-			UpdatedAt:    repo.UpdatedAt,
-			Source:       repo.Source,
-			SyncStatus:   repo.SyncStatus,
-			License:      repo.License,
-			Repository:   common.BuildCloneInfo(c.config, dataset.Repository),
+			ID:            dataset.ID,
+			Name:          repo.Name,
+			Nickname:      repo.Nickname,
+			Description:   repo.Description,
+			SearchSnippet: repo.SearchSnippet,
+			Likes:         repo.Likes,
+			Downloads:     repo.DownloadCount,
+			Path:          repo.Path,
+			RepositoryID:  repo.ID,
+			Private:       repo.Private,
+			Tags:          tags,
+			CreatedAt:     dataset.CreatedAt,
+			UpdatedAt:     repo.UpdatedAt,
+			Source:        repo.Source,
+			SyncStatus:    repo.SyncStatus,
+			License:       repo.License,
+			Repository:    common.BuildCloneInfo(c.config, dataset.Repository),
 			User: types.User{
 				Username: dataset.Repository.User.Username,
 				Nickname: dataset.Repository.User.NickName,
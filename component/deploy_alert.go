@@ -0,0 +1,498 @@
+package component
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	dcommon "opencsg.com/csghub-server/builder/deploy/common"
+	"opencsg.com/csghub-server/builder/prometheus"
+	"opencsg.com/csghub-server/builder/rpc"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/config"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+)
+
+// maxDeployAlertDuration is the longest time a threshold could be breached before the alert fires
+const maxDeployAlertDuration = 24 * 60 * 60
+
+// deployAlertWindowRegexp accepts the prometheus durations of a single unit, e.g. 5m
+var deployAlertWindowRegexp = regexp.MustCompile(`^[1-9][0-9]*[smhd]$`)
+
+// DeployAlertComponent manages the alert rules of the deploys, and evaluates the rules on the
+// prometheus metrics periodically to notify the users when their alerts fire or resolve
+type DeployAlertComponent interface {
+	ListRules(ctx context.Context, req types.DeployActReq) ([]types.DeployAlertRule, error)
+	CreateRule(ctx context.Context, req *types.DeployAlertRuleReq) (*types.DeployAlertRule, error)
+	UpdateRule(ctx context.Context, req *types.DeployAlertRuleReq) (*types.DeployAlertRule, error)
+	DeleteRule(ctx context.Context, req *types.DeployAlertRuleReq) error
+	ListEvents(ctx context.Context, req *types.DeployAlertEventsReq) ([]types.DeployAlertEvent, int, error)
+	// EvaluateRules evaluates all the enabled rules once, it's called by the scheduled workflow
+	EvaluateRules(ctx context.Context) (*types.DeployAlertEvaluation, error)
+}
+
+type deployAlertComponentImpl struct {
+	config                *config.Config
+	alertStore            database.DeployAlertStore
+	deployTaskStore       database.DeployTaskStore
+	promClient            prometheus.PrometheusClient
+	userSvcClient         rpc.UserSvcClient
+	notificationSvcClient rpc.NotificationSvcClient
+	k8sNameSpace          string
+	metrics               metricNames
+}
+
+func NewDeployAlertComponent(config *config.Config) (DeployAlertComponent, error) {
+	return &deployAlertComponentImpl{
+		config:          config,
+		alertStore:      database.NewDeployAlertStore(),
+		deployTaskStore: database.NewDeployTaskStore(),
+		promClient:      prometheus.NewPrometheusClient(config),
+		userSvcClient: rpc.NewUserSvcHttpClient(fmt.Sprintf("%s:%d", config.User.Host, config.User.Port),
+			rpc.AuthWithApiKey(config.APIToken)),
+		notificationSvcClient: rpc.NewNotificationSvcHttpClient(fmt.Sprintf("%s:%d", config.Notification.Host, config.Notification.Port),
+			rpc.AuthWithApiKey(config.APIToken)),
+		k8sNameSpace: config.Cluster.SpaceNamespace,
+		metrics: metricNames{
+			requestCount:   config.Prometheus.RequestCountMetric,
+			requestLatency: config.Prometheus.RequestLatencyMetric,
+			restartCount:   config.Prometheus.RestartCountMetric,
+		},
+	}, nil
+}
+
+func (c *deployAlertComponentImpl) ListRules(ctx context.Context, req types.DeployActReq) ([]types.DeployAlertRule, error) {
+	_, err := c.checkDeploy(ctx, req.CurrentUser, req.DeployID)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := c.alertStore.ListRulesByDeployID(ctx, req.DeployID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list alert rules of deploy %d, error: %w", req.DeployID, err)
+	}
+	result := make([]types.DeployAlertRule, 0, len(rules))
+	for _, rule := range rules {
+		result = append(result, toDeployAlertRule(&rule))
+	}
+	return result, nil
+}
+
+func (c *deployAlertComponentImpl) CreateRule(ctx context.Context, req *types.DeployAlertRuleReq) (*types.DeployAlertRule, error) {
+	deploy, err := c.checkDeploy(ctx, req.CurrentUser, req.DeployID)
+	if err != nil {
+		return nil, err
+	}
+	err = validateDeployAlertRule(req)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := c.alertStore.ListRulesByDeployID(ctx, req.DeployID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list alert rules of deploy %d, error: %w", req.DeployID, err)
+	}
+	if len(rules) >= types.MaxDeployAlertRules {
+		return nil, errorx.ReqParamInvalid(fmt.Errorf("a deploy could have at most %d alert rules", types.MaxDeployAlertRules),
+			errorx.Ctx().Set("deploy_id", req.DeployID))
+	}
+	user, err := c.userSvcClient.GetUserByName(ctx, req.CurrentUser)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user %s, error: %w", req.CurrentUser, err)
+	}
+
+	rule := &database.DeployAlertRule{
+		DeployID: deploy.ID,
+		UserUUID: user.UUID,
+		Enabled:  true,
+		State:    types.DeployAlertStateOK,
+	}
+	applyDeployAlertRuleReq(rule, req)
+	rule, err = c.alertStore.CreateRule(ctx, rule)
+	if err != nil {
+		return nil, err
+	}
+	result := toDeployAlertRule(rule)
+	return &result, nil
+}
+
+func (c *deployAlertComponentImpl) UpdateRule(ctx context.Context, req *types.DeployAlertRuleReq) (*types.DeployAlertRule, error) {
+	rule, err := c.findRule(ctx, req.CurrentUser, req.DeployID, req.RuleID)
+	if err != nil {
+		return nil, err
+	}
+	err = validateDeployAlertRule(req)
+	if err != nil {
+		return nil, err
+	}
+	applyDeployAlertRuleReq(rule, req)
+	if !rule.Enabled {
+		// a disabled rule starts over when it's enabled again
+		rule.State = types.DeployAlertStateOK
+		rule.PendingSince = nil
+	}
+	err = c.alertStore.UpdateRule(ctx, rule)
+	if err != nil {
+		return nil, err
+	}
+	result := toDeployAlertRule(rule)
+	return &result, nil
+}
+
+func (c *deployAlertComponentImpl) DeleteRule(ctx context.Context, req *types.DeployAlertRuleReq) error {
+	rule, err := c.findRule(ctx, req.CurrentUser, req.DeployID, req.RuleID)
+	if err != nil {
+		return err
+	}
+	return c.alertStore.DeleteRule(ctx, rule.ID)
+}
+
+func (c *deployAlertComponentImpl) ListEvents(ctx context.Context, req *types.DeployAlertEventsReq) ([]types.DeployAlertEvent, int, error) {
+	_, err := c.checkDeploy(ctx, req.CurrentUser, req.DeployID)
+	if err != nil {
+		return nil, 0, err
+	}
+	events, total, err := c.alertStore.ListEventsByDeployID(ctx, req.DeployID, req.Per, req.Page)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list alert events of deploy %d, error: %w", req.DeployID, err)
+	}
+	result := make([]types.DeployAlertEvent, 0, len(events))
+	for _, event := range events {
+		result = append(result, types.DeployAlertEvent{
+			ID:        event.ID,
+			RuleID:    event.RuleID,
+			DeployID:  event.DeployID,
+			RuleName:  event.RuleName,
+			Metric:    event.Metric,
+			State:     event.State,
+			Value:     event.Value,
+			Threshold: event.Threshold,
+			CreatedAt: event.CreatedAt,
+		})
+	}
+	return result, total, nil
+}
+
+func (c *deployAlertComponentImpl) EvaluateRules(ctx context.Context) (*types.DeployAlertEvaluation, error) {
+	batchSize := c.config.DeployAlert.BatchSize
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+	result := &types.DeployAlertEvaluation{}
+	var afterID int64
+	for {
+		rules, err := c.alertStore.ListEnabledRules(ctx, afterID, batchSize)
+		if err != nil {
+			return result, fmt.Errorf("failed to list enabled alert rules, error: %w", err)
+		}
+		for i := range rules {
+			rule := &rules[i]
+			// the deploy is not started yet, there are no metrics to evaluate
+			if rule.Deploy.SvcName == "" {
+				result.Skipped++
+				continue
+			}
+			transition, err := c.evaluateRule(ctx, rule, time.Now())
+			if err != nil {
+				result.Failed++
+				slog.ErrorContext(ctx, "failed to evaluate deploy alert rule", slog.Int64("rule_id", rule.ID),
+					slog.Int64("deploy_id", rule.DeployID), slog.Any("error", err))
+				continue
+			}
+			result.Evaluated++
+			switch transition {
+			case types.DeployAlertStateFiring:
+				result.Fired++
+			case types.DeployAlertStateResolved:
+				result.Resolved++
+			}
+		}
+		if len(rules) < batchSize {
+			return result, nil
+		}
+		afterID = rules[len(rules)-1].ID
+	}
+}
+
+// evaluateRule moves the rule to its next state by the current metric value, and returns firing
+// or resolved if the alert fired or resolved in this round
+func (c *deployAlertComponentImpl) evaluateRule(ctx context.Context, rule *database.DeployAlertRule, now time.Time) (types.DeployAlertState, error) {
+	var (
+		value    float64
+		breached bool
+		err      error
+	)
+	// the alerts of a stopped deploy are resolved as it serves nothing
+	if rule.Deploy.Status != dcommon.Stopped && rule.Deploy.Status != dcommon.Deleted {
+		value, err = c.queryMetric(ctx, rule, now)
+		if err != nil {
+			return "", err
+		}
+		breached = rule.Operator.Breached(value, rule.Threshold)
+	}
+
+	var transition types.DeployAlertState
+	switch {
+	case breached && rule.State != types.DeployAlertStateFiring:
+		if rule.State != types.DeployAlertStatePending || rule.PendingSince == nil {
+			rule.State = types.DeployAlertStatePending
+			rule.PendingSince = &now
+		}
+		if now.Sub(*rule.PendingSince) >= time.Duration(rule.DurationSeconds)*time.Second {
+			rule.State = types.DeployAlertStateFiring
+			rule.FiredAt = &now
+			transition = types.DeployAlertStateFiring
+		}
+	case !breached && rule.State == types.DeployAlertStateFiring:
+		rule.State = types.DeployAlertStateOK
+		rule.PendingSince = nil
+		rule.ResolvedAt = &now
+		transition = types.DeployAlertStateResolved
+	case !breached:
+		rule.State = types.DeployAlertStateOK
+		rule.PendingSince = nil
+	}
+	rule.LastValue = value
+	rule.LastEvaluatedAt = &now
+
+	var event *database.DeployAlertEvent
+	if transition != "" {
+		event = &database.DeployAlertEvent{
+			RuleID:    rule.ID,
+			DeployID:  rule.DeployID,
+			RuleName:  rule.Name,
+			Metric:    rule.Metric,
+			State:     transition,
+			Value:     value,
+			Threshold: rule.Threshold,
+		}
+	}
+	err = c.alertStore.UpdateRuleState(ctx, rule, event)
+	if err != nil {
+		return "", err
+	}
+	if transition != "" {
+		err = c.sendAlertNotification(ctx, rule, transition)
+		if err != nil {
+			// the alert is kept in the events even if the notification is lost
+			slog.ErrorContext(ctx, "failed to send deploy alert notification", slog.Int64("rule_id", rule.ID),
+				slog.String("state", string(transition)), slog.Any("error", err))
+		}
+	}
+	return transition, nil
+}
+
+// queryMetric returns the current value of the metric of the rule, the request metrics are
+// selected by the knative service and the restarts by the pods of the service
+func (c *deployAlertComponentImpl) queryMetric(ctx context.Context, rule *database.DeployAlertRule, at time.Time) (float64, error) {
+	svcName := rule.Deploy.SvcName
+	selector := fmt.Sprintf("namespace='%s',service_name='%s'", c.k8sNameSpace, svcName)
+	switch rule.Metric {
+	case types.DeployAlertMetricErrorRate:
+		if strings.HasSuffix(c.metrics.requestCount, "_bucket") {
+			selector += ",le='+Inf'"
+		}
+		total, err := queryPromScalar(ctx, c.promClient, fmt.Sprintf("sum(increase(%s{%s}[%s]))", c.metrics.requestCount, selector, rule.Window), at)
+		if err != nil {
+			return 0, err
+		}
+		if total <= 0 {
+			return 0, nil
+		}
+		failed, err := queryPromScalar(ctx, c.promClient, fmt.Sprintf("sum(increase(%s{%s,response_code_class='5xx'}[%s]))",
+			c.metrics.requestCount, selector, rule.Window), at)
+		if err != nil {
+			return 0, err
+		}
+		return failed / total, nil
+	case types.DeployAlertMetricLatency:
+		return queryPromScalar(ctx, c.promClient, fmt.Sprintf("histogram_quantile(0.95, sum by (le) (rate(%s{%s}[%s])))",
+			c.metrics.requestLatency, selector, rule.Window), at)
+	case types.DeployAlertMetricRestarts:
+		return queryPromScalar(ctx, c.promClient, fmt.Sprintf("sum(increase(%s{namespace='%s',pod=~'%s-.*'}[%s]))",
+			c.metrics.restartCount, c.k8sNameSpace, svcName, rule.Window), at)
+	default:
+		return 0, fmt.Errorf("unknown alert metric %s", rule.Metric)
+	}
+}
+
+func (c *deployAlertComponentImpl) sendAlertNotification(ctx context.Context, rule *database.DeployAlertRule, state types.DeployAlertState) error {
+	deployType, url := deployAlertPage(rule.Deploy)
+	payload := map[string]any{
+		"deploy_name": rule.Deploy.DeployName,
+		"deploy_id":   rule.DeployID,
+		"git_path":    rule.Deploy.GitPath,
+		"deploy_type": deployType,
+	}
+	payload["rule_name"] = rule.Name
+	payload["metric"] = string(rule.Metric)
+	payload["operator"] = deployAlertOperatorSymbol(rule.Operator)
+	payload["value"] = formatDeployAlertValue(rule.LastValue)
+	payload["threshold"] = formatDeployAlertValue(rule.Threshold)
+	payload["state"] = string(state)
+
+	userUUIDs := []string{rule.UserUUID}
+	if rule.Deploy.UserUUID != "" && rule.Deploy.UserUUID != rule.UserUUID {
+		userUUIDs = append(userUUIDs, rule.Deploy.UserUUID)
+	}
+	msg := types.NotificationMessage{
+		UserUUIDs:        userUUIDs,
+		NotificationType: types.NotificationDeploymentManagement,
+		ClickActionURL:   url,
+		Template:         string(types.MessageScenarioDeployAlert),
+		Payload:          payload,
+	}
+	msgBytes, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message, err: %w", err)
+	}
+	notificationMsg := types.MessageRequest{
+		Scenario:   types.MessageScenarioDeployAlert,
+		Parameters: string(msgBytes),
+		Priority:   types.MessagePriorityHigh,
+	}
+
+	var sendErr error
+	retryCount := c.config.Notification.NotificationRetryCount
+	for i := range retryCount {
+		if sendErr = c.notificationSvcClient.Send(ctx, &notificationMsg); sendErr == nil {
+			break
+		}
+		if i < retryCount-1 {
+			slog.Warn("failed to send notification, retrying", "notification_msg", notificationMsg, "attempt", i+1, "error", sendErr.Error())
+		}
+	}
+	if sendErr != nil {
+		return fmt.Errorf("failed to send notification after %d attempts, err: %w", retryCount, sendErr)
+	}
+	return nil
+}
+
+func (c *deployAlertComponentImpl) checkDeploy(ctx context.Context, currentUser string, deployID int64) (*database.Deploy, error) {
+	deploy, err := c.deployTaskStore.GetDeployByID(ctx, deployID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deploy %d, error: %w", deployID, err)
+	}
+	_, err = checkOwnerOrOrgMemberPermission(ctx, c.userSvcClient, currentUser, deploy.UserUUID)
+	if err != nil {
+		return nil, errorx.Forbidden(err, errorx.Ctx().Set("deploy_id", deployID))
+	}
+	return deploy, nil
+}
+
+// findRule returns the rule of the deploy, the rules of other deploys are not found
+func (c *deployAlertComponentImpl) findRule(ctx context.Context, currentUser string, deployID, ruleID int64) (*database.DeployAlertRule, error) {
+	_, err := c.checkDeploy(ctx, currentUser, deployID)
+	if err != nil {
+		return nil, err
+	}
+	rule, err := c.alertStore.FindRuleByID(ctx, ruleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get alert rule %d, error: %w", ruleID, err)
+	}
+	if rule.DeployID != deployID {
+		return nil, errorx.ErrNotFound
+	}
+	return rule, nil
+}
+
+func validateDeployAlertRule(req *types.DeployAlertRuleReq) error {
+	if !req.Metric.Valid() {
+		return errorx.ReqParamInvalid(fmt.Errorf("unknown metric %s", req.Metric), errorx.Ctx().Set("param", "metric"))
+	}
+	if req.Operator == "" {
+		req.Operator = types.DeployAlertOperatorGT
+	}
+	if req.Operator != types.DeployAlertOperatorGT && req.Operator != types.DeployAlertOperatorLT {
+		return errorx.ReqParamInvalid(fmt.Errorf("unknown operator %s", req.Operator), errorx.Ctx().Set("param", "operator"))
+	}
+	if req.Threshold < 0 || math.IsNaN(req.Threshold) || math.IsInf(req.Threshold, 0) {
+		return errorx.ReqParamInvalid(errors.New("threshold must not be negative"), errorx.Ctx().Set("param", "threshold"))
+	}
+	if req.Metric == types.DeployAlertMetricErrorRate && req.Threshold > 1 {
+		return errorx.ReqParamInvalid(errors.New("error rate threshold must be between 0 and 1"), errorx.Ctx().Set("param", "threshold"))
+	}
+	if req.DurationSeconds < 0 || req.DurationSeconds > maxDeployAlertDuration {
+		return errorx.ReqParamInvalid(fmt.Errorf("duration must be between 0 and %d seconds", maxDeployAlertDuration),
+			errorx.Ctx().Set("param", "duration_seconds"))
+	}
+	if req.Window == "" {
+		req.Window = types.DefaultDeployAlertWindow
+	}
+	if !deployAlertWindowRegexp.MatchString(req.Window) {
+		return errorx.ReqParamInvalid(fmt.Errorf("invalid window %s", req.Window), errorx.Ctx().Set("param", "window"))
+	}
+	return nil
+}
+
+func applyDeployAlertRuleReq(rule *database.DeployAlertRule, req *types.DeployAlertRuleReq) {
+	rule.Name = req.Name
+	if rule.Name == "" {
+		rule.Name = string(req.Metric)
+	}
+	rule.Metric = req.Metric
+	rule.Operator = req.Operator
+	rule.Threshold = req.Threshold
+	rule.DurationSeconds = req.DurationSeconds
+	rule.Window = req.Window
+	if req.Enabled != nil {
+		rule.Enabled = *req.Enabled
+	}
+}
+
+func toDeployAlertRule(rule *database.DeployAlertRule) types.DeployAlertRule {
+	return types.DeployAlertRule{
+		ID:              rule.ID,
+		DeployID:        rule.DeployID,
+		Name:            rule.Name,
+		Metric:          rule.Metric,
+		Operator:        rule.Operator,
+		Threshold:       rule.Threshold,
+		DurationSeconds: rule.DurationSeconds,
+		Window:          rule.Window,
+		Enabled:         rule.Enabled,
+		State:           rule.State,
+		LastValue:       rule.LastValue,
+		LastEvaluatedAt: rule.LastEvaluatedAt,
+		FiredAt:         rule.FiredAt,
+		ResolvedAt:      rule.ResolvedAt,
+		CreatedAt:       rule.CreatedAt,
+		UpdatedAt:       rule.UpdatedAt,
+	}
+}
+
+func deployAlertOperatorSymbol(op types.DeployAlertOperator) string {
+	if op == types.DeployAlertOperatorLT {
+		return "<"
+	}
+	return ">"
+}
+
+// formatDeployAlertValue keeps 4 decimals at most for the notifications
+func formatDeployAlertValue(v float64) string {
+	return strconv.FormatFloat(math.Round(v*10000)/10000, 'f', -1, 64)
+}
+
+// deployAlertPage returns the type name and the page of the deploy the same as the deploy notifications
+func deployAlertPage(deploy *database.Deploy) (deployType string, url string) {
+	switch deploy.Type {
+	case types.SpaceType:
+		return "space", fmt.Sprintf("/spaces/%s", deploy.GitPath)
+	case types.InferenceType:
+		return "inference", fmt.Sprintf("/endpoints/%s/%d", deploy.GitPath, deploy.ID)
+	case types.FinetuneType:
+		return "finetune", fmt.Sprintf("/finetune/%s/%s/%d", deploy.GitPath, deploy.DeployName, deploy.ID)
+	case types.EvaluationType:
+		return "evaluation", ""
+	case types.ServerlessType:
+		return "serverless", ""
+	default:
+		return "", ""
+	}
+}
@@ -0,0 +1,205 @@
+package component
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	prometheus_mock "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/builder/prometheus"
+	mockrpc "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/builder/rpc"
+	mockdb "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/builder/store/database"
+	dcommon "opencsg.com/csghub-server/builder/deploy/common"
+	"opencsg.com/csghub-server/builder/rpc"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/config"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+)
+
+type testDeployAlertComponent struct {
+	*deployAlertComponentImpl
+	alertStore      *mockdb.MockDeployAlertStore
+	deployTaskStore *mockdb.MockDeployTaskStore
+	promClient      *prometheus_mock.MockPrometheusClient
+	userSvcClient   *mockrpc.MockUserSvcClient
+	notification    *mockrpc.MockNotificationSvcClient
+}
+
+func newTestDeployAlertComponent(t *testing.T) *testDeployAlertComponent {
+	cfg := &config.Config{}
+	cfg.DeployAlert.BatchSize = 2
+	cfg.Notification.NotificationRetryCount = 1
+	c := &testDeployAlertComponent{
+		alertStore:      mockdb.NewMockDeployAlertStore(t),
+		deployTaskStore: mockdb.NewMockDeployTaskStore(t),
+		promClient:      prometheus_mock.NewMockPrometheusClient(t),
+		userSvcClient:   mockrpc.NewMockUserSvcClient(t),
+		notification:    mockrpc.NewMockNotificationSvcClient(t),
+	}
+	c.deployAlertComponentImpl = &deployAlertComponentImpl{
+		config:                cfg,
+		alertStore:            c.alertStore,
+		deployTaskStore:       c.deployTaskStore,
+		promClient:            c.promClient,
+		userSvcClient:         c.userSvcClient,
+		notificationSvcClient: c.notification,
+		k8sNameSpace:          "spaces",
+		metrics: metricNames{
+			requestCount:   "revision_request_count",
+			requestLatency: "revision_app_request_latencies_bucket",
+			restartCount:   "kube_pod_container_status_restarts_total",
+		},
+	}
+	return c
+}
+
+func (c *testDeployAlertComponent) expectOwner(ctx context.Context, username, uuid string) {
+	c.userSvcClient.EXPECT().GetUserByName(ctx, username).Return(&types.User{UUID: uuid, Username: username}, nil)
+	c.userSvcClient.EXPECT().GetNameSpaceInfoByUUID(ctx, uuid).Return(&rpc.Namespace{UUID: uuid, NSType: "user"}, nil)
+}
+
+func promScalar(v string) *types.PrometheusResponse {
+	return &types.PrometheusResponse{Data: types.PrometheusData{Result: []types.PrometheusResult{{Value: []any{1.0, v}}}}}
+}
+
+func TestDeployAlertComponent_CreateRule(t *testing.T) {
+	ctx := context.TODO()
+	c := newTestDeployAlertComponent(t)
+	c.deployTaskStore.EXPECT().GetDeployByID(ctx, int64(1)).Return(&database.Deploy{ID: 1, UserUUID: "uuid"}, nil)
+	c.expectOwner(ctx, "user", "uuid")
+	c.alertStore.EXPECT().ListRulesByDeployID(ctx, int64(1)).Return(nil, nil)
+	c.alertStore.EXPECT().CreateRule(ctx, &database.DeployAlertRule{
+		DeployID: 1, UserUUID: "uuid", Name: "latency_p95", Metric: types.DeployAlertMetricLatency,
+		Operator: types.DeployAlertOperatorGT, Threshold: 500, DurationSeconds: 120, Window: "5m",
+		Enabled: true, State: types.DeployAlertStateOK,
+	}).RunAndReturn(func(ctx context.Context, r *database.DeployAlertRule) (*database.DeployAlertRule, error) {
+		r.ID = 3
+		return r, nil
+	})
+
+	rule, err := c.CreateRule(ctx, &types.DeployAlertRuleReq{
+		CurrentUser: "user", DeployID: 1, Metric: types.DeployAlertMetricLatency, Threshold: 500, DurationSeconds: 120,
+	})
+	require.Nil(t, err)
+	require.Equal(t, int64(3), rule.ID)
+	require.Equal(t, types.DeployAlertStateOK, rule.State)
+}
+
+func TestDeployAlertComponent_CreateRuleInvalid(t *testing.T) {
+	ctx := context.TODO()
+	cases := []types.DeployAlertRuleReq{
+		{Metric: "cpu"},
+		{Metric: types.DeployAlertMetricErrorRate, Threshold: 2},
+		{Metric: types.DeployAlertMetricRestarts, Operator: "eq"},
+		{Metric: types.DeployAlertMetricRestarts, Window: "5m) or vector(1"},
+		{Metric: types.DeployAlertMetricRestarts, DurationSeconds: -1},
+	}
+	for _, req := range cases {
+		c := newTestDeployAlertComponent(t)
+		c.deployTaskStore.EXPECT().GetDeployByID(ctx, int64(1)).Return(&database.Deploy{ID: 1, UserUUID: "uuid"}, nil)
+		c.expectOwner(ctx, "user", "uuid")
+		req.CurrentUser = "user"
+		req.DeployID = 1
+		_, err := c.CreateRule(ctx, &req)
+		require.True(t, errors.Is(err, errorx.ErrReqParamInvalid), "%v", req)
+	}
+}
+
+func TestDeployAlertComponent_UpdateRuleOfOtherDeploy(t *testing.T) {
+	ctx := context.TODO()
+	c := newTestDeployAlertComponent(t)
+	c.deployTaskStore.EXPECT().GetDeployByID(ctx, int64(1)).Return(&database.Deploy{ID: 1, UserUUID: "uuid"}, nil)
+	c.expectOwner(ctx, "user", "uuid")
+	c.alertStore.EXPECT().FindRuleByID(ctx, int64(3)).Return(&database.DeployAlertRule{ID: 3, DeployID: 2}, nil)
+
+	_, err := c.UpdateRule(ctx, &types.DeployAlertRuleReq{
+		CurrentUser: "user", DeployID: 1, RuleID: 3, Metric: types.DeployAlertMetricRestarts,
+	})
+	require.True(t, errors.Is(err, errorx.ErrNotFound))
+}
+
+func TestDeployAlertComponent_EvaluateRules(t *testing.T) {
+	ctx := context.TODO()
+	c := newTestDeployAlertComponent(t)
+	deploy := &database.Deploy{ID: 1, UserUUID: "uuid", SvcName: "svc", DeployName: "d1",
+		Status: dcommon.Running, Type: types.InferenceType, GitPath: "ns/model"}
+	pendingSince := time.Now().Add(-10 * time.Minute)
+	rules := []database.DeployAlertRule{
+		// fires as the breach lasted longer than the duration
+		{ID: 1, DeployID: 1, Deploy: deploy, UserUUID: "uuid", Name: "errors", Metric: types.DeployAlertMetricErrorRate,
+			Operator: types.DeployAlertOperatorGT, Threshold: 0.1, DurationSeconds: 300, Window: "5m",
+			Enabled: true, State: types.DeployAlertStatePending, PendingSince: &pendingSince},
+		// resolves as the latency is back under the threshold
+		{ID: 2, DeployID: 1, Deploy: deploy, UserUUID: "uuid", Name: "latency", Metric: types.DeployAlertMetricLatency,
+			Operator: types.DeployAlertOperatorGT, Threshold: 500, Window: "5m",
+			Enabled: true, State: types.DeployAlertStateFiring},
+	}
+	c.alertStore.EXPECT().ListEnabledRules(ctx, int64(0), 2).Return(rules, nil)
+	restartRule := database.DeployAlertRule{ID: 3, DeployID: 1, Deploy: deploy, UserUUID: "uuid", Name: "restarts",
+		Metric: types.DeployAlertMetricRestarts, Operator: types.DeployAlertOperatorGT, Threshold: 3, DurationSeconds: 60,
+		Window: "10m", Enabled: true, State: types.DeployAlertStateOK}
+	// the deploy is still building, the rule is skipped
+	buildingRule := database.DeployAlertRule{ID: 4, DeployID: 2, Deploy: &database.Deploy{ID: 2, Status: dcommon.Building},
+		UserUUID: "uuid", Name: "errors", Metric: types.DeployAlertMetricErrorRate, Operator: types.DeployAlertOperatorGT,
+		Threshold: 0.1, Window: "5m", Enabled: true, State: types.DeployAlertStateOK}
+	c.alertStore.EXPECT().ListEnabledRules(ctx, int64(2), 2).Return([]database.DeployAlertRule{restartRule, buildingRule}, nil)
+	c.alertStore.EXPECT().ListEnabledRules(ctx, int64(4), 2).Return(nil, nil)
+
+	c.promClient.EXPECT().QueryInstant(ctx, "sum(increase(revision_request_count{namespace='spaces',service_name='svc'}[5m]))", mock.Anything).
+		Return(promScalar("100"), nil)
+	c.promClient.EXPECT().QueryInstant(ctx, "sum(increase(revision_request_count{namespace='spaces',service_name='svc',response_code_class='5xx'}[5m]))", mock.Anything).
+		Return(promScalar("20"), nil)
+	c.promClient.EXPECT().QueryInstant(ctx, "histogram_quantile(0.95, sum by (le) (rate(revision_app_request_latencies_bucket{namespace='spaces',service_name='svc'}[5m])))", mock.Anything).
+		Return(promScalar("120"), nil)
+	c.promClient.EXPECT().QueryInstant(ctx, "sum(increase(kube_pod_container_status_restarts_total{namespace='spaces',pod=~'svc-.*'}[10m]))", mock.Anything).
+		Return(promScalar("5"), nil)
+
+	c.alertStore.EXPECT().UpdateRuleState(ctx, mock.MatchedBy(func(r *database.DeployAlertRule) bool {
+		return r.ID == 1 && r.State == types.DeployAlertStateFiring && r.FiredAt != nil && r.LastValue == 0.2
+	}), &database.DeployAlertEvent{RuleID: 1, DeployID: 1, RuleName: "errors", Metric: types.DeployAlertMetricErrorRate,
+		State: types.DeployAlertStateFiring, Value: 0.2, Threshold: 0.1}).Return(nil)
+	c.alertStore.EXPECT().UpdateRuleState(ctx, mock.MatchedBy(func(r *database.DeployAlertRule) bool {
+		return r.ID == 2 && r.State == types.DeployAlertStateOK && r.ResolvedAt != nil
+	}), &database.DeployAlertEvent{RuleID: 2, DeployID: 1, RuleName: "latency", Metric: types.DeployAlertMetricLatency,
+		State: types.DeployAlertStateResolved, Value: 120, Threshold: 500}).Return(nil)
+	// the breach of the restarts just started, so the rule is pending without an event
+	c.alertStore.EXPECT().UpdateRuleState(ctx, mock.MatchedBy(func(r *database.DeployAlertRule) bool {
+		return r.ID == 3 && r.State == types.DeployAlertStatePending && r.PendingSince != nil
+	}), (*database.DeployAlertEvent)(nil)).Return(nil)
+
+	var states []string
+	c.notification.EXPECT().Send(ctx, mock.MatchedBy(func(req *types.MessageRequest) bool {
+		return req.Scenario == types.MessageScenarioDeployAlert
+	})).RunAndReturn(func(ctx context.Context, req *types.MessageRequest) error {
+		var msg types.NotificationMessage
+		require.Nil(t, json.Unmarshal([]byte(req.Parameters), &msg))
+		require.Equal(t, []string{"uuid"}, msg.UserUUIDs)
+		require.Equal(t, "/endpoints/ns/model/1", msg.ClickActionURL)
+		require.Equal(t, "d1", msg.Payload["deploy_name"])
+		states = append(states, msg.Payload["state"].(string))
+		return nil
+	}).Times(2)
+
+	result, err := c.EvaluateRules(ctx)
+	require.Nil(t, err)
+	require.Equal(t, &types.DeployAlertEvaluation{Evaluated: 3, Fired: 1, Resolved: 1, Skipped: 1}, result)
+	require.Equal(t, []string{"firing", "resolved"}, states)
+}
+
+func TestDeployAlertComponent_EvaluateStoppedDeploy(t *testing.T) {
+	ctx := context.TODO()
+	c := newTestDeployAlertComponent(t)
+	deploy := &database.Deploy{ID: 1, UserUUID: "uuid", SvcName: "svc", Status: dcommon.Stopped}
+	rule := &database.DeployAlertRule{ID: 1, DeployID: 1, Deploy: deploy, UserUUID: "uuid", Metric: types.DeployAlertMetricRestarts,
+		Operator: types.DeployAlertOperatorGT, Threshold: 1, Window: "5m", Enabled: true, State: types.DeployAlertStatePending}
+
+	c.alertStore.EXPECT().UpdateRuleState(ctx, rule, (*database.DeployAlertEvent)(nil)).Return(nil)
+	transition, err := c.evaluateRule(ctx, rule, time.Now())
+	require.Nil(t, err)
+	require.Equal(t, types.DeployAlertState(""), transition)
+	require.Equal(t, types.DeployAlertStateOK, rule.State)
+}
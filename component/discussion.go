@@ -20,8 +20,10 @@ import (
 type discussionComponentImpl struct {
 	repoCompo             RepoComponent
 	discussionStore       database.DiscussionStore
+	discussionLabelStore  database.DiscussionLabelStore
 	repoStore             database.RepoStore
 	userStore             database.UserStore
+	feedEventStore        database.FeedEventStore
 	notificationSvcClient rpc.NotificationSvcClient
 	config                *config.Config
 }
@@ -54,6 +56,18 @@ type DiscussionComponent interface {
 	UpdateComment(ctx context.Context, currentUser string, id int64, content string) error
 	DeleteComment(ctx context.Context, currentUser string, id int64) error
 	ListDiscussionComments(ctx context.Context, currentUser string, discussionID int64, per int, page int) ([]*types.DiscussionResponse_Comment, int, error)
+	// UpdateDiscussionStatus closes or reopens a discussion by its owner or the repo writers, only the repo admins can lock or unlock it
+	UpdateDiscussionStatus(ctx context.Context, req types.UpdateDiscussionStatusRequest) error
+	PinDiscussion(ctx context.Context, req types.PinDiscussionRequest) error
+	TransferDiscussion(ctx context.Context, req types.TransferDiscussionRequest) error
+	SetDiscussionLabels(ctx context.Context, req types.SetDiscussionLabelsRequest) ([]types.DiscussionLabel, error)
+	ListDiscussionLabels(ctx context.Context, req types.ListRepoDiscussionRequest) ([]types.DiscussionLabel, error)
+	CreateDiscussionLabel(ctx context.Context, req types.DiscussionLabelRequest) (*types.DiscussionLabel, error)
+	UpdateDiscussionLabel(ctx context.Context, req types.DiscussionLabelRequest) (*types.DiscussionLabel, error)
+	DeleteDiscussionLabel(ctx context.Context, req types.DiscussionLabelRequest) error
+	HideComment(ctx context.Context, req types.HideCommentRequest) error
+	AddCommentReaction(ctx context.Context, req types.CommentReactionRequest) error
+	RemoveCommentReaction(ctx context.Context, req types.CommentReactionRequest) error
 }
 
 func NewDiscussionComponent(config *config.Config) (DiscussionComponent, error) {
@@ -67,6 +81,8 @@ func NewDiscussionComponent(config *config.Config) (DiscussionComponent, error)
 	return &discussionComponentImpl{
 		repoCompo:       repoCompo,
 		discussionStore: ds, repoStore: rs, userStore: us,
+		discussionLabelStore: database.NewDiscussionLabelStore(),
+		feedEventStore:       database.NewFeedEventStore(),
 		notificationSvcClient: rpc.NewNotificationSvcHttpClient(fmt.Sprintf("%s:%d", config.Notification.Host, config.Notification.Port),
 			rpc.AuthWithApiKey(config.APIToken)),
 		config: config,
@@ -92,10 +108,15 @@ func (c *discussionComponentImpl) CreateRepoDiscussion(ctx context.Context, req
 		DiscussionableID:   repo.ID,
 		DiscussionableType: database.DiscussionableTypeRepo,
 		UserID:             user.ID,
+		Status:             string(types.DiscussionStatusOpen),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create discussion: %w", err)
 	}
+	recordFeedEvent(ctx, c.feedEventStore, database.NewRepoFeedEvent(string(types.FeedEventDiscussionCreated), repo, user.ID, map[string]any{
+		"discussion_id": discussion.ID,
+		"title":         discussion.Title,
+	}))
 	resp := &types.CreateDiscussionResponse{
 		ID: discussion.ID,
 		User: &types.DiscussionResponse_User{
@@ -105,6 +126,8 @@ func (c *discussionComponentImpl) CreateRepoDiscussion(ctx context.Context, req
 		},
 		Title:        discussion.Title,
 		CommentCount: discussion.CommentCount,
+		Status:       types.DiscussionStatus(discussion.Status),
+		Labels:       toDiscussionLabels(discussion.Labels),
 		CreatedAt:    discussion.CreatedAt,
 	}
 	return resp, nil
@@ -121,7 +144,7 @@ func (c *discussionComponentImpl) GetDiscussion(ctx context.Context, currentUser
 		return nil, fmt.Errorf("discussion '%d' is not a repo discussion", id)
 	}
 
-	_, err = c.checkRepoReadAccess(ctx, discussion.DiscussionableID, currentUser)
+	repo, err := c.checkRepoReadAccess(ctx, discussion.DiscussionableID, currentUser)
 	if err != nil {
 		return nil, err
 	}
@@ -130,33 +153,17 @@ func (c *discussionComponentImpl) GetDiscussion(ctx context.Context, currentUser
 	if err != nil && err != sql.ErrNoRows {
 		return nil, fmt.Errorf("failed to find discussion comments by discussion id '%d': %w", discussion.ID, err)
 	}
-	commentsData := make([]*types.DiscussionResponse_Comment, 0, len(comments))
-	for _, comment := range comments {
-		var user *types.DiscussionResponse_User
-		if comment.User == nil {
-			user = &types.DiscussionResponse_User{
-				ID:       0,
-				Username: "deleted user",
-				Avatar:   "",
-			}
-		} else {
-			user = &types.DiscussionResponse_User{
-				ID:       comment.User.ID,
-				Username: comment.User.Username,
-				Avatar:   comment.User.Avatar,
-			}
-		}
-		commentsData = append(commentsData, &types.DiscussionResponse_Comment{
-			ID:        comment.ID,
-			Content:   comment.Content,
-			User:      user,
-			CreatedAt: comment.CreatedAt,
-		})
+	commentsData, err := c.buildComments(ctx, repo, currentUser, comments)
+	if err != nil {
+		return nil, err
 	}
 
 	resp := &types.ShowDiscussionResponse{
-		ID:    discussion.ID,
-		Title: discussion.Title,
+		ID:     discussion.ID,
+		Title:  discussion.Title,
+		Status: types.DiscussionStatus(discussion.Status),
+		Pinned: discussion.Pinned,
+		Labels: toDiscussionLabels(discussion.Labels),
 		User: &types.DiscussionResponse_User{
 			ID:       discussion.User.ID,
 			Username: discussion.User.Username,
@@ -187,6 +194,9 @@ func (c *discussionComponentImpl) UpdateDiscussion(ctx context.Context, req type
 	if discussion.UserID != user.ID {
 		return errorx.ErrForbiddenMsg(fmt.Sprintf("user '%s' is not the owner of the discussion '%d'", req.CurrentUser, req.ID))
 	}
+	if err := c.checkDiscussionNotLocked(ctx, discussion, req.CurrentUser); err != nil {
+		return err
+	}
 	err = c.discussionStore.UpdateByID(ctx, req.ID, req.Title)
 	if err != nil {
 		return fmt.Errorf("failed to update discussion by id '%d': %w", req.ID, err)
@@ -220,7 +230,11 @@ func (c *discussionComponentImpl) ListRepoDiscussions(ctx context.Context, req t
 		return nil, 0, err
 	}
 
-	discussions, total, err := c.discussionStore.FindByDiscussionableID(ctx, database.DiscussionableTypeRepo, repo.ID, per, page)
+	filter := database.DiscussionFilter{
+		Status: string(req.Status),
+		Label:  req.Label,
+	}
+	discussions, total, err := c.discussionStore.FindByDiscussionableID(ctx, database.DiscussionableTypeRepo, repo.ID, filter, per, page)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to list repo discussions by repo type '%s', namespace '%s', name '%s': %w", req.RepoType, req.Namespace, req.Name, err)
 	}
@@ -233,6 +247,9 @@ func (c *discussionComponentImpl) ListRepoDiscussions(ctx context.Context, req t
 			ID:           discussion.ID,
 			Title:        discussion.Title,
 			CommentCount: discussion.CommentCount,
+			Status:       types.DiscussionStatus(discussion.Status),
+			Pinned:       discussion.Pinned,
+			Labels:       toDiscussionLabels(discussion.Labels),
 			CreatedAt:    discussion.CreatedAt,
 			User: &types.DiscussionResponse_User{
 				ID:       discussion.User.ID,
@@ -261,6 +278,9 @@ func (c *discussionComponentImpl) CreateDiscussionComment(ctx context.Context, r
 	if err != nil {
 		return nil, err
 	}
+	if err := c.checkNotLocked(ctx, discussion, repo, req.CurrentUser); err != nil {
+		return nil, err
+	}
 
 	//get user by username
 	user, err := c.userStore.FindByUsername(ctx, req.CurrentUser)
@@ -277,17 +297,7 @@ func (c *discussionComponentImpl) CreateDiscussionComment(ctx context.Context, r
 	if err != nil {
 		return nil, fmt.Errorf("failed to create discussion comment: %w", err)
 	}
-	if user.UUID != discussion.User.UUID {
-		go func() {
-			notificationCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-			defer cancel()
-			err = c.sendCommentMessage(notificationCtx, repo.RepositoryType, repo.Path, user.UUID, []string{discussion.User.UUID})
-			if err != nil {
-				slog.Error("failed to send comment message", slog.String("repoPath", repo.Path), slog.String("repoType", string(repo.RepositoryType)),
-					slog.String("senderUUID", user.UUID), slog.Any("userUUIDs", []string{discussion.User.UUID}), slog.Any("err", err))
-			}
-		}()
-	}
+	go c.notifyComment(repo, discussion, &user, req.Content)
 	return &types.CreateCommentResponse{
 		ID:              comment.ID,
 		CommentableID:   comment.CommentableID,
@@ -313,7 +323,7 @@ func (c *discussionComponentImpl) UpdateComment(ctx context.Context, currentUser
 	}
 
 	// Get the discussion associated with the comment
-	_, err = c.discussionStore.FindByID(ctx, comment.CommentableID)
+	discussion, err := c.discussionStore.FindByID(ctx, comment.CommentableID)
 	if err != nil {
 		return fmt.Errorf("failed to find discussion by id '%d': %w", comment.CommentableID, err)
 	}
@@ -322,6 +332,9 @@ func (c *discussionComponentImpl) UpdateComment(ctx context.Context, currentUser
 	if comment.UserID != user.ID {
 		return errorx.ErrForbiddenMsg(fmt.Sprintf("user '%s' is not the owner of the comment '%d'", currentUser, id))
 	}
+	if err := c.checkDiscussionNotLocked(ctx, discussion, currentUser); err != nil {
+		return err
+	}
 	err = c.discussionStore.UpdateComment(ctx, id, content)
 	if err != nil {
 		return fmt.Errorf("failed to update comment by id '%d': %w", id, err)
@@ -361,7 +374,7 @@ func (c *discussionComponentImpl) ListDiscussionComments(ctx context.Context, cu
 		return nil, 0, fmt.Errorf("discussion '%d' is not a repo discussion", discussion.ID)
 	}
 	// Get the repository associated with the discussion
-	_, err = c.checkRepoReadAccess(ctx, discussion.DiscussionableID, currentUser)
+	repo, err := c.checkRepoReadAccess(ctx, discussion.DiscussionableID, currentUser)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -370,28 +383,9 @@ func (c *discussionComponentImpl) ListDiscussionComments(ctx context.Context, cu
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to find discussion comments by discussion id '%d': %w", discussionID, err)
 	}
-	resp := make([]*types.DiscussionResponse_Comment, 0, len(comments))
-	for _, comment := range comments {
-		var user *types.DiscussionResponse_User
-		if comment.User == nil {
-			user = &types.DiscussionResponse_User{
-				ID:       0,
-				Username: "deleted user",
-				Avatar:   "",
-			}
-		} else {
-			user = &types.DiscussionResponse_User{
-				ID:       comment.User.ID,
-				Username: comment.User.Username,
-				Avatar:   comment.User.Avatar,
-			}
-		}
-		resp = append(resp, &types.DiscussionResponse_Comment{
-			ID:        comment.ID,
-			Content:   comment.Content,
-			User:      user,
-			CreatedAt: comment.CreatedAt,
-		})
+	resp, err := c.buildComments(ctx, repo, currentUser, comments)
+	if err != nil {
+		return nil, 0, err
 	}
 	return resp, int(discussion.CommentCount), nil
 }
@@ -412,13 +406,16 @@ func (c *discussionComponentImpl) sendCommentMessage(ctx context.Context, repoTy
 			"repo_type": repoType,
 		},
 	}
+	return c.sendNotification(ctx, types.MessageScenarioDiscussion, msg)
+}
 
+func (c *discussionComponentImpl) sendNotification(ctx context.Context, scenario types.MessageScenario, msg types.NotificationMessage) error {
 	msgBytes, err := json.Marshal(msg)
 	if err != nil {
 		return fmt.Errorf("failed to marshal message, err: %w", err)
 	}
 	notificationMsg := types.MessageRequest{
-		Scenario:   types.MessageScenarioDiscussion,
+		Scenario:   scenario,
 		Parameters: string(msgBytes),
 		Priority:   types.MessagePriorityHigh,
 	}
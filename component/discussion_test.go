@@ -41,11 +41,13 @@ func TestDiscussionComponent_CreateDisucssion(t *testing.T) {
 		mockUserStore := mockdb.NewMockUserStore(t)
 		mockDiscussionStore := mockdb.NewMockDiscussionStore(t)
 		mockRepoComponent := mockcomp.NewMockRepoComponent(t)
+		mockFeedEventStore := mockdb.NewMockFeedEventStore(t)
 		// new discussionComponentImpl from mock db store
 		comp := &discussionComponentImpl{
 			repoStore:       mockRepoStore,
 			userStore:       mockUserStore,
 			discussionStore: mockDiscussionStore,
+			feedEventStore:  mockFeedEventStore,
 			repoCompo:       mockRepoComponent,
 		}
 		mockRepoStore.EXPECT().FindByPath(mock.Anything, types.ModelRepo, "namespace", "name").Return(repo, nil).Once()
@@ -58,11 +60,18 @@ func TestDiscussionComponent_CreateDisucssion(t *testing.T) {
 			DiscussionableID:   repo.ID,
 			DiscussionableType: database.DiscussionableTypeRepo,
 			UserID:             user.ID,
+			Status:             string(types.DiscussionStatusOpen),
 		}
 		dbdisc := disc
 		dbdisc.ID = 1
 		dbdisc.CreatedAt = time.Now()
 		mockDiscussionStore.EXPECT().Create(mock.Anything, disc).Return(&dbdisc, nil).Once()
+		mockFeedEventStore.EXPECT().Create(mock.Anything, &database.FeedEvent{
+			EventType:    string(types.FeedEventDiscussionCreated),
+			ActorID:      user.ID,
+			RepositoryID: repo.ID,
+			Payload:      map[string]any{"discussion_id": dbdisc.ID, "title": dbdisc.Title},
+		}).Return(nil).Once()
 
 		actualDisc, err := comp.CreateRepoDiscussion(context.TODO(), req)
 		require.Nil(t, err)
@@ -71,6 +80,8 @@ func TestDiscussionComponent_CreateDisucssion(t *testing.T) {
 			ID:           1,
 			Title:        "test discussion",
 			CommentCount: 0,
+			Status:       types.DiscussionStatusOpen,
+			Labels:       []types.DiscussionLabel{},
 			CreatedAt:    dbdisc.CreatedAt,
 			User: &types.DiscussionResponse_User{
 				ID:       1,
@@ -145,6 +156,9 @@ func TestDiscussionComponent_GetDiscussion(t *testing.T) {
 	}
 	// Updated to include pagination parameters: per=10, page=1
 	mockDiscussionStore.EXPECT().FindDiscussionComments(mock.Anything, int64(1), 10, 1).Return(comments, nil).Once()
+	mockDiscussionStore.EXPECT().ListReactions(mock.Anything, []int64{1}, "user").Return([]database.CommentReactionSummary{
+		{CommentID: 1, Emoji: "heart", Count: 2, Reacted: true},
+	}, nil).Once()
 
 	// Updated to include pagination parameters
 	resp, err := comp.GetDiscussion(context.TODO(), "user", int64(1), 10, 1)
@@ -160,6 +174,7 @@ func TestDiscussionComponent_GetDiscussion(t *testing.T) {
 	require.Equal(t, "test comment", resp.Comments.Data[0].Content)
 	require.Equal(t, "user", resp.Comments.Data[0].User.Username)
 	require.Equal(t, "avatar", resp.Comments.Data[0].User.Avatar)
+	require.Equal(t, []types.CommentReaction{{Emoji: "heart", Count: 2, Reacted: true}}, resp.Comments.Data[0].Reactions)
 }
 
 func TestDiscussionComponent_UpdateDisussion(t *testing.T) {
@@ -278,13 +293,18 @@ func TestDiscussionComponent_ListRepoDiscussions(t *testing.T) {
 		UserID:             2,
 		User:               nil,
 	})
-	mockDiscussionStore.EXPECT().FindByDiscussionableID(mock.Anything, database.DiscussionableTypeRepo, repo.ID, 10, 1).Return(discussions, 1, nil).Once()
+	mockDiscussionStore.EXPECT().FindByDiscussionableID(mock.Anything, database.DiscussionableTypeRepo, repo.ID, database.DiscussionFilter{
+		Status: "open",
+		Label:  "bug",
+	}, 10, 1).Return(discussions, 1, nil).Once()
 
 	resp, _, err := comp.ListRepoDiscussions(context.TODO(), types.ListRepoDiscussionRequest{
 		RepoType:    types.ModelRepo,
 		Namespace:   "namespace",
 		Name:        "name",
 		CurrentUser: "user",
+		Status:      types.DiscussionStatusOpen,
+		Label:       "bug",
 	}, 10, 1)
 	require.Nil(t, err)
 	require.Len(t, resp.Discussions, 1)
@@ -519,6 +539,7 @@ func TestDiscussionComponent_ListDiscussionComments(t *testing.T) {
 	mockRepoStore.EXPECT().FindById(mock.Anything, int64(1)).Return(&database.Repository{ID: 1}, nil).Once()
 	mockRepoComponent.EXPECT().AllowReadAccessRepo(mock.Anything, &database.Repository{ID: 1}, "user").Return(true, nil).Once()
 	mockDiscussionStore.EXPECT().FindDiscussionComments(mock.Anything, discussionID, 10, 1).Return(comments, nil).Once()
+	mockDiscussionStore.EXPECT().ListReactions(mock.Anything, []int64{1, 2}, "user").Return(nil, nil).Once()
 
 	resp, total, err := comp.ListDiscussionComments(context.TODO(), "user", discussionID, 10, 1)
 	require.Nil(t, err)
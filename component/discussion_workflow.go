@@ -0,0 +1,558 @@
+package component
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+)
+
+// discussionMentionRegexp matches the @username mentions, but not the email addresses
+var discussionMentionRegexp = regexp.MustCompile(`(?:^|[^\w@])@(\w[\w.-]*)`)
+
+func parseMentions(content string) []string {
+	var usernames []string
+	for _, match := range discussionMentionRegexp.FindAllStringSubmatch(content, -1) {
+		username := strings.TrimRight(match[1], ".-")
+		if username == "" || slices.Contains(usernames, username) {
+			continue
+		}
+		usernames = append(usernames, username)
+		if len(usernames) >= types.MaxDiscussionMentions {
+			break
+		}
+	}
+	return usernames
+}
+
+func toDiscussionLabels(labels []database.DiscussionLabel) []types.DiscussionLabel {
+	resp := make([]types.DiscussionLabel, 0, len(labels))
+	for _, label := range labels {
+		resp = append(resp, toDiscussionLabel(&label))
+	}
+	return resp
+}
+
+func toDiscussionLabel(label *database.DiscussionLabel) types.DiscussionLabel {
+	return types.DiscussionLabel{
+		ID:          label.ID,
+		Name:        label.Name,
+		Color:       label.Color,
+		Description: label.Description,
+	}
+}
+
+// findRepoDiscussion returns the discussion and its repository
+func (c *discussionComponentImpl) findRepoDiscussion(ctx context.Context, id int64) (*database.Discussion, *database.Repository, error) {
+	discussion, err := c.discussionStore.FindByID(ctx, id)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find discussion by id '%d': %w", id, err)
+	}
+	//TOOD: support other discussionable type, like collection
+	if discussion.DiscussionableType != database.DiscussionableTypeRepo {
+		return nil, nil, fmt.Errorf("discussion '%d' is not a repo discussion", id)
+	}
+	repo, err := c.repoStore.FindById(ctx, discussion.DiscussionableID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find repository by id '%d': %w", discussion.DiscussionableID, err)
+	}
+	return discussion, repo, nil
+}
+
+func (c *discussionComponentImpl) repoPermission(ctx context.Context, repo *database.Repository, currentUser string) (*types.UserRepoPermission, error) {
+	permission, err := c.repoCompo.GetUserRepoPermission(ctx, currentUser, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user '%s' permission of repo '%s': %w", currentUser, repo.Path, err)
+	}
+	return permission, nil
+}
+
+func (c *discussionComponentImpl) checkRepoAdmin(ctx context.Context, repo *database.Repository, currentUser string) error {
+	permission, err := c.repoPermission(ctx, repo, currentUser)
+	if err != nil {
+		return err
+	}
+	if !permission.CanAdmin {
+		return errorx.ErrForbiddenMsg(fmt.Sprintf("user '%s' is not an admin of repository '%s'", currentUser, repo.Path))
+	}
+	return nil
+}
+
+func (c *discussionComponentImpl) checkRepoWrite(ctx context.Context, repo *database.Repository, currentUser string) error {
+	permission, err := c.repoPermission(ctx, repo, currentUser)
+	if err != nil {
+		return err
+	}
+	if !permission.CanWrite {
+		return errorx.ErrForbiddenMsg(fmt.Sprintf("user '%s' does not have write access to repository '%s'", currentUser, repo.Path))
+	}
+	return nil
+}
+
+// checkNotLocked only allows the repo admins to comment or react in the locked discussions
+func (c *discussionComponentImpl) checkNotLocked(ctx context.Context, discussion *database.Discussion, repo *database.Repository, currentUser string) error {
+	if discussion.Status != string(types.DiscussionStatusLocked) {
+		return nil
+	}
+	permission, err := c.repoPermission(ctx, repo, currentUser)
+	if err != nil {
+		return err
+	}
+	if !permission.CanAdmin {
+		return errorx.ErrForbiddenMsg(fmt.Sprintf("discussion '%d' is locked", discussion.ID))
+	}
+	return nil
+}
+
+// checkDiscussionNotLocked is checkNotLocked for the callers without the repository of the discussion, the
+// repository is only loaded for the locked discussions
+func (c *discussionComponentImpl) checkDiscussionNotLocked(ctx context.Context, discussion *database.Discussion, currentUser string) error {
+	if discussion.Status != string(types.DiscussionStatusLocked) {
+		return nil
+	}
+	repo, err := c.repoStore.FindById(ctx, discussion.DiscussionableID)
+	if err != nil {
+		return fmt.Errorf("failed to find repository by id '%d': %w", discussion.DiscussionableID, err)
+	}
+	return c.checkNotLocked(ctx, discussion, repo, currentUser)
+}
+
+// buildComments converts the comments to the responses with their reactions, the content of the hidden comments
+// is only visible to the repo admins and the author
+func (c *discussionComponentImpl) buildComments(ctx context.Context, repo *database.Repository, currentUser string, comments []database.Comment) ([]*types.DiscussionResponse_Comment, error) {
+	resp := make([]*types.DiscussionResponse_Comment, 0, len(comments))
+	if len(comments) == 0 {
+		return resp, nil
+	}
+
+	canSeeHidden := false
+	hasHidden := slices.ContainsFunc(comments, func(comment database.Comment) bool { return comment.Hidden })
+	if hasHidden && currentUser != "" {
+		permission, err := c.repoPermission(ctx, repo, currentUser)
+		if err != nil {
+			return nil, err
+		}
+		canSeeHidden = permission.CanAdmin
+	}
+
+	commentIDs := make([]int64, 0, len(comments))
+	for _, comment := range comments {
+		commentIDs = append(commentIDs, comment.ID)
+	}
+	summaries, err := c.discussionStore.ListReactions(ctx, commentIDs, currentUser)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list comment reactions: %w", err)
+	}
+	reactions := make(map[int64][]types.CommentReaction)
+	for _, summary := range summaries {
+		reactions[summary.CommentID] = append(reactions[summary.CommentID], types.CommentReaction{
+			Emoji:   summary.Emoji,
+			Count:   summary.Count,
+			Reacted: summary.Reacted,
+		})
+	}
+
+	for _, comment := range comments {
+		var user *types.DiscussionResponse_User
+		if comment.User == nil {
+			user = &types.DiscussionResponse_User{
+				ID:       0,
+				Username: "deleted user",
+				Avatar:   "",
+			}
+		} else {
+			user = &types.DiscussionResponse_User{
+				ID:       comment.User.ID,
+				Username: comment.User.Username,
+				Avatar:   comment.User.Avatar,
+			}
+		}
+		content := comment.Content
+		if comment.Hidden && !canSeeHidden && (comment.User == nil || comment.User.Username != currentUser) {
+			content = ""
+		}
+		resp = append(resp, &types.DiscussionResponse_Comment{
+			ID:        comment.ID,
+			Content:   content,
+			User:      user,
+			Hidden:    comment.Hidden,
+			Reactions: reactions[comment.ID],
+			CreatedAt: comment.CreatedAt,
+		})
+	}
+	return resp, nil
+}
+
+func (c *discussionComponentImpl) UpdateDiscussionStatus(ctx context.Context, req types.UpdateDiscussionStatusRequest) error {
+	discussion, repo, err := c.findRepoDiscussion(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if discussion.Status == string(req.Status) {
+		return nil
+	}
+
+	permission, err := c.repoPermission(ctx, repo, req.CurrentUser)
+	if err != nil {
+		return err
+	}
+	if req.Status == types.DiscussionStatusLocked || discussion.Status == string(types.DiscussionStatusLocked) {
+		if !permission.CanAdmin {
+			return errorx.ErrForbiddenMsg(fmt.Sprintf("user '%s' is not an admin of repository '%s'", req.CurrentUser, repo.Path))
+		}
+	} else {
+		isOwner := discussion.User != nil && discussion.User.Username == req.CurrentUser
+		if !isOwner && !permission.CanWrite {
+			return errorx.ErrForbiddenMsg(fmt.Sprintf("user '%s' is not the owner of the discussion '%d'", req.CurrentUser, req.ID))
+		}
+	}
+
+	err = c.discussionStore.UpdateStatus(ctx, req.ID, string(req.Status))
+	if err != nil {
+		return fmt.Errorf("failed to update status of discussion '%d': %w", req.ID, err)
+	}
+	return nil
+}
+
+func (c *discussionComponentImpl) PinDiscussion(ctx context.Context, req types.PinDiscussionRequest) error {
+	_, repo, err := c.findRepoDiscussion(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if err := c.checkRepoAdmin(ctx, repo, req.CurrentUser); err != nil {
+		return err
+	}
+	err = c.discussionStore.SetPinned(ctx, req.ID, req.Pinned)
+	if err != nil {
+		return fmt.Errorf("failed to pin discussion '%d': %w", req.ID, err)
+	}
+	return nil
+}
+
+// TransferDiscussion moves a discussion to another repository, the user must be an admin of both repositories
+func (c *discussionComponentImpl) TransferDiscussion(ctx context.Context, req types.TransferDiscussionRequest) error {
+	_, repo, err := c.findRepoDiscussion(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if err := c.checkRepoAdmin(ctx, repo, req.CurrentUser); err != nil {
+		return err
+	}
+	target, err := c.repoStore.FindByPath(ctx, req.RepoType, req.Namespace, req.Name)
+	if err != nil {
+		return fmt.Errorf("failed to find repo by path '%s/%s/%s': %w", req.RepoType, req.Namespace, req.Name, err)
+	}
+	if target.ID == repo.ID {
+		return errorx.ReqParamInvalid(errors.New("discussion is already in the target repository"), errorx.Ctx().
+			Set("id", req.ID))
+	}
+	if err := c.checkRepoAdmin(ctx, target, req.CurrentUser); err != nil {
+		return err
+	}
+	err = c.discussionStore.Transfer(ctx, req.ID, target.ID)
+	if err != nil {
+		return fmt.Errorf("failed to transfer discussion '%d' to repo '%s': %w", req.ID, target.Path, err)
+	}
+	return nil
+}
+
+func (c *discussionComponentImpl) SetDiscussionLabels(ctx context.Context, req types.SetDiscussionLabelsRequest) ([]types.DiscussionLabel, error) {
+	_, repo, err := c.findRepoDiscussion(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.checkRepoWrite(ctx, repo, req.CurrentUser); err != nil {
+		return nil, err
+	}
+
+	repoLabels, err := c.discussionLabelStore.ListByRepoID(ctx, repo.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list discussion labels of repo '%s': %w", repo.Path, err)
+	}
+	var (
+		labelIDs []int64
+		labels   []database.DiscussionLabel
+	)
+	for _, id := range req.LabelIDs {
+		if slices.Contains(labelIDs, id) {
+			continue
+		}
+		idx := slices.IndexFunc(repoLabels, func(label database.DiscussionLabel) bool { return label.ID == id })
+		if idx < 0 {
+			return nil, errorx.ReqParamInvalid(fmt.Errorf("label '%d' does not belong to repository '%s'", id, repo.Path), errorx.Ctx().
+				Set("label_id", id))
+		}
+		labelIDs = append(labelIDs, id)
+		labels = append(labels, repoLabels[idx])
+	}
+
+	err = c.discussionLabelStore.SetDiscussionLabels(ctx, req.ID, labelIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set labels of discussion '%d': %w", req.ID, err)
+	}
+	return toDiscussionLabels(labels), nil
+}
+
+func (c *discussionComponentImpl) ListDiscussionLabels(ctx context.Context, req types.ListRepoDiscussionRequest) ([]types.DiscussionLabel, error) {
+	repo, err := c.repoStore.FindByPath(ctx, req.RepoType, req.Namespace, req.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo by path '%s/%s/%s': %w", req.RepoType, req.Namespace, req.Name, err)
+	}
+	allow, err := c.repoCompo.AllowReadAccessRepo(ctx, repo, req.CurrentUser)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check if user can access repo: %w", err)
+	}
+	if !allow {
+		return nil, errorx.ErrForbiddenMsg(fmt.Sprintf("user '%s' does not have access to repository '%s'", req.CurrentUser, repo.Path))
+	}
+	labels, err := c.discussionLabelStore.ListByRepoID(ctx, repo.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list discussion labels of repo '%s': %w", repo.Path, err)
+	}
+	return toDiscussionLabels(labels), nil
+}
+
+// findWritableLabelRepo returns the repository of the label request after checking the write access of the user
+func (c *discussionComponentImpl) findWritableLabelRepo(ctx context.Context, req types.DiscussionLabelRequest) (*database.Repository, error) {
+	repo, err := c.repoStore.FindByPath(ctx, req.RepoType, req.Namespace, req.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo by path '%s/%s/%s': %w", req.RepoType, req.Namespace, req.Name, err)
+	}
+	if err := c.checkRepoWrite(ctx, repo, req.CurrentUser); err != nil {
+		return nil, err
+	}
+	return repo, nil
+}
+
+func (c *discussionComponentImpl) findRepoLabel(ctx context.Context, repo *database.Repository, labelID int64) (*database.DiscussionLabel, error) {
+	label, err := c.discussionLabelStore.FindByID(ctx, labelID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find discussion label '%d': %w", labelID, err)
+	}
+	if label.RepositoryID != repo.ID {
+		return nil, fmt.Errorf("label '%d' does not belong to repository '%s': %w", labelID, repo.Path, errorx.ErrDatabaseNoRows)
+	}
+	return label, nil
+}
+
+func (c *discussionComponentImpl) CreateDiscussionLabel(ctx context.Context, req types.DiscussionLabelRequest) (*types.DiscussionLabel, error) {
+	repo, err := c.findWritableLabelRepo(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	label, err := c.discussionLabelStore.Create(ctx, database.DiscussionLabel{
+		RepositoryID: repo.ID,
+		Name:         req.Label,
+		Color:        req.Color,
+		Description:  req.Description,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create discussion label '%s' of repo '%s': %w", req.Label, repo.Path, err)
+	}
+	resp := toDiscussionLabel(label)
+	return &resp, nil
+}
+
+func (c *discussionComponentImpl) UpdateDiscussionLabel(ctx context.Context, req types.DiscussionLabelRequest) (*types.DiscussionLabel, error) {
+	repo, err := c.findWritableLabelRepo(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	label, err := c.findRepoLabel(ctx, repo, req.LabelID)
+	if err != nil {
+		return nil, err
+	}
+	label.Name = req.Label
+	label.Color = req.Color
+	label.Description = req.Description
+	err = c.discussionLabelStore.Update(ctx, label)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update discussion label '%d': %w", req.LabelID, err)
+	}
+	resp := toDiscussionLabel(label)
+	return &resp, nil
+}
+
+func (c *discussionComponentImpl) DeleteDiscussionLabel(ctx context.Context, req types.DiscussionLabelRequest) error {
+	repo, err := c.findWritableLabelRepo(ctx, req)
+	if err != nil {
+		return err
+	}
+	if _, err := c.findRepoLabel(ctx, repo, req.LabelID); err != nil {
+		return err
+	}
+	err = c.discussionLabelStore.Delete(ctx, req.LabelID)
+	if err != nil {
+		return fmt.Errorf("failed to delete discussion label '%d': %w", req.LabelID, err)
+	}
+	return nil
+}
+
+// findCommentRepo returns the comment, its discussion and the repository of the discussion
+func (c *discussionComponentImpl) findCommentRepo(ctx context.Context, commentID int64) (*database.Comment, *database.Discussion, *database.Repository, error) {
+	comment, err := c.discussionStore.FindCommentByID(ctx, commentID)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to find comment by id '%d': %w", commentID, err)
+	}
+	discussion, repo, err := c.findRepoDiscussion(ctx, comment.CommentableID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return comment, discussion, repo, nil
+}
+
+func (c *discussionComponentImpl) HideComment(ctx context.Context, req types.HideCommentRequest) error {
+	_, _, repo, err := c.findCommentRepo(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if err := c.checkRepoAdmin(ctx, repo, req.CurrentUser); err != nil {
+		return err
+	}
+	err = c.discussionStore.SetCommentHidden(ctx, req.ID, req.Hidden)
+	if err != nil {
+		return fmt.Errorf("failed to hide comment '%d': %w", req.ID, err)
+	}
+	return nil
+}
+
+// prepareReaction validates the reaction request and returns the id of the current user
+func (c *discussionComponentImpl) prepareReaction(ctx context.Context, req types.CommentReactionRequest, checkLocked bool) (int64, error) {
+	if !types.IsValidDiscussionReaction(req.Emoji) {
+		return 0, errorx.ReqParamInvalid(fmt.Errorf("unsupported reaction '%s'", req.Emoji), errorx.Ctx().
+			Set("emoji", req.Emoji))
+	}
+	_, discussion, repo, err := c.findCommentRepo(ctx, req.CommentID)
+	if err != nil {
+		return 0, err
+	}
+	allow, err := c.repoCompo.AllowReadAccessRepo(ctx, repo, req.CurrentUser)
+	if err != nil {
+		return 0, fmt.Errorf("failed to check if user can access repo: %w", err)
+	}
+	if !allow {
+		return 0, errorx.ErrForbiddenMsg(fmt.Sprintf("user '%s' does not have access to repository '%s'", req.CurrentUser, repo.Path))
+	}
+	if checkLocked {
+		if err := c.checkNotLocked(ctx, discussion, repo, req.CurrentUser); err != nil {
+			return 0, err
+		}
+	}
+	user, err := c.userStore.FindByUsername(ctx, req.CurrentUser)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find user by username '%s': %w", req.CurrentUser, err)
+	}
+	return user.ID, nil
+}
+
+func (c *discussionComponentImpl) AddCommentReaction(ctx context.Context, req types.CommentReactionRequest) error {
+	userID, err := c.prepareReaction(ctx, req, true)
+	if err != nil {
+		return err
+	}
+	err = c.discussionStore.AddReaction(ctx, database.CommentReaction{
+		CommentID: req.CommentID,
+		UserID:    userID,
+		Emoji:     req.Emoji,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add reaction to comment '%d': %w", req.CommentID, err)
+	}
+	return nil
+}
+
+func (c *discussionComponentImpl) RemoveCommentReaction(ctx context.Context, req types.CommentReactionRequest) error {
+	userID, err := c.prepareReaction(ctx, req, false)
+	if err != nil {
+		return err
+	}
+	err = c.discussionStore.DeleteReaction(ctx, req.CommentID, userID, req.Emoji)
+	if err != nil {
+		return fmt.Errorf("failed to remove reaction from comment '%d': %w", req.CommentID, err)
+	}
+	return nil
+}
+
+// notifyComment notifies the users mentioned in the comment, and the owner of the discussion about the reply
+func (c *discussionComponentImpl) notifyComment(repo *database.Repository, discussion *database.Discussion, sender *database.User, content string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	mentioned, err := c.mentionedUserUUIDs(ctx, repo, sender, content)
+	if err != nil {
+		slog.Error("failed to find mentioned users", slog.String("repoPath", repo.Path), slog.Int64("discussionID", discussion.ID), slog.Any("err", err))
+	}
+	if len(mentioned) > 0 {
+		err = c.sendMentionMessage(ctx, repo, discussion, sender, mentioned)
+		if err != nil {
+			slog.Error("failed to send mention message", slog.String("repoPath", repo.Path), slog.String("repoType", string(repo.RepositoryType)),
+				slog.String("senderUUID", sender.UUID), slog.Any("userUUIDs", mentioned), slog.Any("err", err))
+		}
+	}
+
+	// the mentioned owner has been notified
+	if discussion.User == nil || discussion.User.UUID == sender.UUID || slices.Contains(mentioned, discussion.User.UUID) {
+		return
+	}
+	err = c.sendCommentMessage(ctx, repo.RepositoryType, repo.Path, sender.UUID, []string{discussion.User.UUID})
+	if err != nil {
+		slog.Error("failed to send comment message", slog.String("repoPath", repo.Path), slog.String("repoType", string(repo.RepositoryType)),
+			slog.String("senderUUID", sender.UUID), slog.Any("userUUIDs", []string{discussion.User.UUID}), slog.Any("err", err))
+	}
+}
+
+// mentionedUserUUIDs returns the uuids of the users mentioned in the content who can read the repository
+func (c *discussionComponentImpl) mentionedUserUUIDs(ctx context.Context, repo *database.Repository, sender *database.User, content string) ([]string, error) {
+	usernames := parseMentions(content)
+	if len(usernames) == 0 {
+		return nil, nil
+	}
+	users, err := c.userStore.FindByUsernames(ctx, usernames)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find users by usernames: %w", err)
+	}
+	var uuids []string
+	for _, user := range users {
+		if user.ID == sender.ID {
+			continue
+		}
+		if repo.Private {
+			allow, err := c.repoCompo.AllowReadAccessRepo(ctx, repo, user.Username)
+			if err != nil || !allow {
+				continue
+			}
+		}
+		uuids = append(uuids, user.UUID)
+	}
+	return uuids, nil
+}
+
+func (c *discussionComponentImpl) sendMentionMessage(ctx context.Context, repo *database.Repository, discussion *database.Discussion, sender *database.User, userUUIDs []string) error {
+	repoUrl := GetRepoUrl(repo.RepositoryType, repo.Path)
+	msg := types.NotificationMessage{
+		MsgUUID:          uuid.New().String(),
+		UserUUIDs:        userUUIDs,
+		SenderUUID:       sender.UUID,
+		NotificationType: types.NotificationComment,
+		CreateAt:         time.Now(),
+		ClickActionURL:   fmt.Sprintf("%s/community", repoUrl),
+		Template:         string(types.MessageScenarioDiscussionMention),
+		Payload: map[string]any{
+			"repo_type": repo.RepositoryType,
+			"repo_path": repo.Path,
+			"title":     discussion.Title,
+			"sender":    sender.Username,
+		},
+	}
+	return c.sendNotification(ctx, types.MessageScenarioDiscussionMention, msg)
+}
@@ -0,0 +1,350 @@
+package component
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	mockrpc "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/builder/rpc"
+	mockdb "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/builder/store/database"
+	mockcomp "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/component"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/config"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+)
+
+type discussionWorkflowTester struct {
+	comp            *discussionComponentImpl
+	repoStore       *mockdb.MockRepoStore
+	userStore       *mockdb.MockUserStore
+	discussionStore *mockdb.MockDiscussionStore
+	labelStore      *mockdb.MockDiscussionLabelStore
+	repoComponent   *mockcomp.MockRepoComponent
+	notification    *mockrpc.MockNotificationSvcClient
+}
+
+func newDiscussionWorkflowTester(t *testing.T) *discussionWorkflowTester {
+	tester := &discussionWorkflowTester{
+		repoStore:       mockdb.NewMockRepoStore(t),
+		userStore:       mockdb.NewMockUserStore(t),
+		discussionStore: mockdb.NewMockDiscussionStore(t),
+		labelStore:      mockdb.NewMockDiscussionLabelStore(t),
+		repoComponent:   mockcomp.NewMockRepoComponent(t),
+		notification:    mockrpc.NewMockNotificationSvcClient(t),
+	}
+	cfg := &config.Config{}
+	cfg.Notification.NotificationRetryCount = 1
+	tester.comp = &discussionComponentImpl{
+		repoStore:             tester.repoStore,
+		userStore:             tester.userStore,
+		discussionStore:       tester.discussionStore,
+		discussionLabelStore:  tester.labelStore,
+		repoCompo:             tester.repoComponent,
+		notificationSvcClient: tester.notification,
+		config:                cfg,
+	}
+	return tester
+}
+
+// expectDiscussion mocks the lookup of the discussion 1 in the repository 1
+func (tester *discussionWorkflowTester) expectDiscussion(status types.DiscussionStatus) (*database.Discussion, *database.Repository) {
+	repo := &database.Repository{ID: 1, Path: "ns/n", RepositoryType: types.ModelRepo}
+	discussion := &database.Discussion{
+		ID:                 1,
+		Title:              "test discussion",
+		DiscussionableID:   repo.ID,
+		DiscussionableType: database.DiscussionableTypeRepo,
+		UserID:             1,
+		User:               &database.User{ID: 1, Username: "owner", UUID: "owner-uuid"},
+		Status:             string(status),
+	}
+	tester.discussionStore.EXPECT().FindByID(mock.Anything, discussion.ID).Return(discussion, nil).Once()
+	tester.repoStore.EXPECT().FindById(mock.Anything, repo.ID).Return(repo, nil).Once()
+	return discussion, repo
+}
+
+func (tester *discussionWorkflowTester) expectPermission(repo *database.Repository, username string, permission types.UserRepoPermission) {
+	tester.repoComponent.EXPECT().GetUserRepoPermission(mock.Anything, username, repo).Return(&permission, nil).Once()
+}
+
+func TestParseMentions(t *testing.T) {
+	require.Equal(t, []string{"foo", "bar.baz", "qux"}, parseMentions("@foo thanks, cc @bar.baz. and (@qux) @foo"))
+	require.Empty(t, parseMentions("mail me at foo@example.com"))
+	require.Empty(t, parseMentions("no mentions"))
+}
+
+func TestDiscussionComponent_UpdateDiscussionStatus(t *testing.T) {
+	t.Run("owner closes the discussion", func(t *testing.T) {
+		tester := newDiscussionWorkflowTester(t)
+		_, repo := tester.expectDiscussion(types.DiscussionStatusOpen)
+		tester.expectPermission(repo, "owner", types.UserRepoPermission{CanRead: true})
+		tester.discussionStore.EXPECT().UpdateStatus(mock.Anything, int64(1), "closed").Return(nil).Once()
+
+		err := tester.comp.UpdateDiscussionStatus(context.TODO(), types.UpdateDiscussionStatusRequest{
+			ID: 1, CurrentUser: "owner", Status: types.DiscussionStatusClosed,
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("other users cannot close the discussion", func(t *testing.T) {
+		tester := newDiscussionWorkflowTester(t)
+		_, repo := tester.expectDiscussion(types.DiscussionStatusOpen)
+		tester.expectPermission(repo, "other", types.UserRepoPermission{CanRead: true})
+
+		err := tester.comp.UpdateDiscussionStatus(context.TODO(), types.UpdateDiscussionStatusRequest{
+			ID: 1, CurrentUser: "other", Status: types.DiscussionStatusClosed,
+		})
+		require.ErrorIs(t, err, errorx.ErrForbidden)
+	})
+
+	t.Run("only the admins can lock the discussion", func(t *testing.T) {
+		tester := newDiscussionWorkflowTester(t)
+		_, repo := tester.expectDiscussion(types.DiscussionStatusOpen)
+		tester.expectPermission(repo, "owner", types.UserRepoPermission{CanRead: true, CanWrite: true})
+
+		err := tester.comp.UpdateDiscussionStatus(context.TODO(), types.UpdateDiscussionStatusRequest{
+			ID: 1, CurrentUser: "owner", Status: types.DiscussionStatusLocked,
+		})
+		require.ErrorIs(t, err, errorx.ErrForbidden)
+
+		tester = newDiscussionWorkflowTester(t)
+		_, repo = tester.expectDiscussion(types.DiscussionStatusOpen)
+		tester.expectPermission(repo, "admin", types.UserRepoPermission{CanRead: true, CanWrite: true, CanAdmin: true})
+		tester.discussionStore.EXPECT().UpdateStatus(mock.Anything, int64(1), "locked").Return(nil).Once()
+
+		err = tester.comp.UpdateDiscussionStatus(context.TODO(), types.UpdateDiscussionStatusRequest{
+			ID: 1, CurrentUser: "admin", Status: types.DiscussionStatusLocked,
+		})
+		require.NoError(t, err)
+	})
+}
+
+func TestDiscussionComponent_PinDiscussion(t *testing.T) {
+	tester := newDiscussionWorkflowTester(t)
+	_, repo := tester.expectDiscussion(types.DiscussionStatusOpen)
+	tester.expectPermission(repo, "admin", types.UserRepoPermission{CanRead: true, CanWrite: true, CanAdmin: true})
+	tester.discussionStore.EXPECT().SetPinned(mock.Anything, int64(1), true).Return(nil).Once()
+
+	err := tester.comp.PinDiscussion(context.TODO(), types.PinDiscussionRequest{ID: 1, CurrentUser: "admin", Pinned: true})
+	require.NoError(t, err)
+}
+
+func TestDiscussionComponent_TransferDiscussion(t *testing.T) {
+	tester := newDiscussionWorkflowTester(t)
+	_, repo := tester.expectDiscussion(types.DiscussionStatusOpen)
+	target := &database.Repository{ID: 2, Path: "ns/other", RepositoryType: types.ModelRepo}
+	tester.expectPermission(repo, "admin", types.UserRepoPermission{CanAdmin: true})
+	tester.repoStore.EXPECT().FindByPath(mock.Anything, types.ModelRepo, "ns", "other").Return(target, nil).Once()
+	tester.expectPermission(target, "admin", types.UserRepoPermission{CanAdmin: true})
+	tester.discussionStore.EXPECT().Transfer(mock.Anything, int64(1), target.ID).Return(nil).Once()
+
+	err := tester.comp.TransferDiscussion(context.TODO(), types.TransferDiscussionRequest{
+		ID: 1, CurrentUser: "admin", RepoType: types.ModelRepo, Namespace: "ns", Name: "other",
+	})
+	require.NoError(t, err)
+
+	tester = newDiscussionWorkflowTester(t)
+	_, repo = tester.expectDiscussion(types.DiscussionStatusOpen)
+	tester.expectPermission(repo, "admin", types.UserRepoPermission{CanAdmin: true})
+	tester.repoStore.EXPECT().FindByPath(mock.Anything, types.ModelRepo, "ns", "other").Return(target, nil).Once()
+	tester.expectPermission(target, "admin", types.UserRepoPermission{CanRead: true})
+
+	err = tester.comp.TransferDiscussion(context.TODO(), types.TransferDiscussionRequest{
+		ID: 1, CurrentUser: "admin", RepoType: types.ModelRepo, Namespace: "ns", Name: "other",
+	})
+	require.ErrorIs(t, err, errorx.ErrForbidden)
+}
+
+func TestDiscussionComponent_SetDiscussionLabels(t *testing.T) {
+	tester := newDiscussionWorkflowTester(t)
+	_, repo := tester.expectDiscussion(types.DiscussionStatusOpen)
+	tester.expectPermission(repo, "writer", types.UserRepoPermission{CanRead: true, CanWrite: true})
+	tester.labelStore.EXPECT().ListByRepoID(mock.Anything, repo.ID).Return([]database.DiscussionLabel{
+		{ID: 1, RepositoryID: repo.ID, Name: "bug"},
+		{ID: 2, RepositoryID: repo.ID, Name: "question"},
+	}, nil).Once()
+	tester.labelStore.EXPECT().SetDiscussionLabels(mock.Anything, int64(1), []int64{2}).Return(nil).Once()
+
+	labels, err := tester.comp.SetDiscussionLabels(context.TODO(), types.SetDiscussionLabelsRequest{
+		ID: 1, CurrentUser: "writer", LabelIDs: []int64{2, 2},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []types.DiscussionLabel{{ID: 2, Name: "question"}}, labels)
+
+	tester = newDiscussionWorkflowTester(t)
+	_, repo = tester.expectDiscussion(types.DiscussionStatusOpen)
+	tester.expectPermission(repo, "writer", types.UserRepoPermission{CanRead: true, CanWrite: true})
+	tester.labelStore.EXPECT().ListByRepoID(mock.Anything, repo.ID).Return([]database.DiscussionLabel{}, nil).Once()
+
+	_, err = tester.comp.SetDiscussionLabels(context.TODO(), types.SetDiscussionLabelsRequest{
+		ID: 1, CurrentUser: "writer", LabelIDs: []int64{3},
+	})
+	require.ErrorIs(t, err, errorx.ErrReqParamInvalid)
+}
+
+func TestDiscussionComponent_DiscussionLabels(t *testing.T) {
+	tester := newDiscussionWorkflowTester(t)
+	repo := &database.Repository{ID: 1, Path: "ns/n"}
+	req := types.DiscussionLabelRequest{
+		RepoType: types.ModelRepo, Namespace: "ns", Name: "n", CurrentUser: "writer",
+		Label: "bug", Color: "#d73a4a",
+	}
+
+	tester.repoStore.EXPECT().FindByPath(mock.Anything, types.ModelRepo, "ns", "n").Return(repo, nil).Times(3)
+	tester.repoComponent.EXPECT().GetUserRepoPermission(mock.Anything, "writer", repo).
+		Return(&types.UserRepoPermission{CanRead: true, CanWrite: true}, nil).Times(3)
+
+	tester.labelStore.EXPECT().Create(mock.Anything, database.DiscussionLabel{
+		RepositoryID: repo.ID, Name: "bug", Color: "#d73a4a",
+	}).Return(&database.DiscussionLabel{ID: 1, RepositoryID: repo.ID, Name: "bug", Color: "#d73a4a"}, nil).Once()
+	label, err := tester.comp.CreateDiscussionLabel(context.TODO(), req)
+	require.NoError(t, err)
+	require.Equal(t, &types.DiscussionLabel{ID: 1, Name: "bug", Color: "#d73a4a"}, label)
+
+	// the label of another repository
+	req.LabelID = 2
+	tester.labelStore.EXPECT().FindByID(mock.Anything, int64(2)).Return(&database.DiscussionLabel{ID: 2, RepositoryID: 3}, nil).Once()
+	_, err = tester.comp.UpdateDiscussionLabel(context.TODO(), req)
+	require.ErrorIs(t, err, errorx.ErrDatabaseNoRows)
+
+	req.LabelID = 1
+	tester.labelStore.EXPECT().FindByID(mock.Anything, int64(1)).Return(&database.DiscussionLabel{ID: 1, RepositoryID: repo.ID}, nil).Once()
+	tester.labelStore.EXPECT().Delete(mock.Anything, int64(1)).Return(nil).Once()
+	err = tester.comp.DeleteDiscussionLabel(context.TODO(), req)
+	require.NoError(t, err)
+}
+
+func TestDiscussionComponent_HideComment(t *testing.T) {
+	tester := newDiscussionWorkflowTester(t)
+	tester.discussionStore.EXPECT().FindCommentByID(mock.Anything, int64(5)).Return(&database.Comment{ID: 5, CommentableID: 1}, nil).Once()
+	_, repo := tester.expectDiscussion(types.DiscussionStatusOpen)
+	tester.expectPermission(repo, "admin", types.UserRepoPermission{CanAdmin: true})
+	tester.discussionStore.EXPECT().SetCommentHidden(mock.Anything, int64(5), true).Return(nil).Once()
+
+	err := tester.comp.HideComment(context.TODO(), types.HideCommentRequest{ID: 5, CurrentUser: "admin", Hidden: true})
+	require.NoError(t, err)
+}
+
+func TestDiscussionComponent_BuildHiddenComments(t *testing.T) {
+	tester := newDiscussionWorkflowTester(t)
+	repo := &database.Repository{ID: 1, Path: "ns/n"}
+	comments := []database.Comment{
+		{ID: 1, Content: "spam", Hidden: true, User: &database.User{ID: 2, Username: "spammer"}},
+		{ID: 2, Content: "hello", User: &database.User{ID: 3, Username: "user"}},
+	}
+	tester.expectPermission(repo, "user", types.UserRepoPermission{CanRead: true})
+	tester.discussionStore.EXPECT().ListReactions(mock.Anything, []int64{1, 2}, "user").Return(nil, nil).Once()
+
+	resp, err := tester.comp.buildComments(context.TODO(), repo, "user", comments)
+	require.NoError(t, err)
+	require.True(t, resp[0].Hidden)
+	require.Empty(t, resp[0].Content)
+	require.Equal(t, "hello", resp[1].Content)
+
+	// the author can see the hidden comment
+	tester.expectPermission(repo, "spammer", types.UserRepoPermission{CanRead: true})
+	tester.discussionStore.EXPECT().ListReactions(mock.Anything, []int64{1, 2}, "spammer").Return(nil, nil).Once()
+	resp, err = tester.comp.buildComments(context.TODO(), repo, "spammer", comments)
+	require.NoError(t, err)
+	require.Equal(t, "spam", resp[0].Content)
+}
+
+func TestDiscussionComponent_CommentReactions(t *testing.T) {
+	tester := newDiscussionWorkflowTester(t)
+	tester.discussionStore.EXPECT().FindCommentByID(mock.Anything, int64(5)).Return(&database.Comment{ID: 5, CommentableID: 1}, nil).Once()
+	_, repo := tester.expectDiscussion(types.DiscussionStatusOpen)
+	tester.repoComponent.EXPECT().AllowReadAccessRepo(mock.Anything, repo, "user").Return(true, nil).Once()
+	tester.userStore.EXPECT().FindByUsername(mock.Anything, "user").Return(database.User{ID: 3}, nil).Once()
+	tester.discussionStore.EXPECT().AddReaction(mock.Anything, database.CommentReaction{CommentID: 5, UserID: 3, Emoji: "heart"}).Return(nil).Once()
+
+	err := tester.comp.AddCommentReaction(context.TODO(), types.CommentReactionRequest{CommentID: 5, CurrentUser: "user", Emoji: "heart"})
+	require.NoError(t, err)
+
+	err = tester.comp.AddCommentReaction(context.TODO(), types.CommentReactionRequest{CommentID: 5, CurrentUser: "user", Emoji: "poop"})
+	require.ErrorIs(t, err, errorx.ErrReqParamInvalid)
+
+	// locked discussions only accept the reactions of the admins
+	tester.discussionStore.EXPECT().FindCommentByID(mock.Anything, int64(5)).Return(&database.Comment{ID: 5, CommentableID: 1}, nil).Once()
+	_, repo = tester.expectDiscussion(types.DiscussionStatusLocked)
+	tester.repoComponent.EXPECT().AllowReadAccessRepo(mock.Anything, repo, "user").Return(true, nil).Once()
+	tester.expectPermission(repo, "user", types.UserRepoPermission{CanRead: true})
+	err = tester.comp.AddCommentReaction(context.TODO(), types.CommentReactionRequest{CommentID: 5, CurrentUser: "user", Emoji: "heart"})
+	require.ErrorIs(t, err, errorx.ErrForbidden)
+
+	tester.discussionStore.EXPECT().FindCommentByID(mock.Anything, int64(5)).Return(&database.Comment{ID: 5, CommentableID: 1}, nil).Once()
+	_, repo = tester.expectDiscussion(types.DiscussionStatusLocked)
+	tester.repoComponent.EXPECT().AllowReadAccessRepo(mock.Anything, repo, "user").Return(true, nil).Once()
+	tester.userStore.EXPECT().FindByUsername(mock.Anything, "user").Return(database.User{ID: 3}, nil).Once()
+	tester.discussionStore.EXPECT().DeleteReaction(mock.Anything, int64(5), int64(3), "heart").Return(nil).Once()
+	err = tester.comp.RemoveCommentReaction(context.TODO(), types.CommentReactionRequest{CommentID: 5, CurrentUser: "user", Emoji: "heart"})
+	require.NoError(t, err)
+}
+
+func TestDiscussionComponent_CreateCommentInLockedDiscussion(t *testing.T) {
+	tester := newDiscussionWorkflowTester(t)
+	_, repo := tester.expectDiscussion(types.DiscussionStatusLocked)
+	tester.repoComponent.EXPECT().AllowReadAccessRepo(mock.Anything, repo, "user").Return(true, nil).Once()
+	tester.expectPermission(repo, "user", types.UserRepoPermission{CanRead: true})
+
+	_, err := tester.comp.CreateDiscussionComment(context.TODO(), types.CreateCommentRequest{
+		Content: "hi", CommentableID: 1, CurrentUser: "user",
+	})
+	require.ErrorIs(t, err, errorx.ErrForbidden)
+}
+
+func TestDiscussionComponent_NotifyComment(t *testing.T) {
+	tester := newDiscussionWorkflowTester(t)
+	repo := &database.Repository{ID: 1, Path: "ns/n", RepositoryType: types.ModelRepo, Private: true}
+	discussion := &database.Discussion{ID: 1, Title: "test discussion", User: &database.User{ID: 1, Username: "owner", UUID: "owner-uuid"}}
+	sender := &database.User{ID: 2, Username: "sender", UUID: "sender-uuid"}
+
+	tester.userStore.EXPECT().FindByUsernames(mock.Anything, []string{"owner", "outsider", "sender"}).Return([]database.User{
+		{ID: 1, Username: "owner", UUID: "owner-uuid"},
+		{ID: 3, Username: "outsider", UUID: "outsider-uuid"},
+		{ID: 2, Username: "sender", UUID: "sender-uuid"},
+	}, nil).Once()
+	tester.repoComponent.EXPECT().AllowReadAccessRepo(mock.Anything, repo, "owner").Return(true, nil).Once()
+	tester.repoComponent.EXPECT().AllowReadAccessRepo(mock.Anything, repo, "outsider").Return(false, nil).Once()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	// the mentioned owner only receives the mention message
+	tester.notification.EXPECT().Send(mock.Anything, mock.MatchedBy(func(msg *types.MessageRequest) bool {
+		defer wg.Done()
+		return msg.Scenario == types.MessageScenarioDiscussionMention
+	})).Return(nil).Once()
+
+	tester.comp.notifyComment(repo, discussion, sender, "@owner @outsider @sender please check")
+	wg.Wait()
+}
+
+func TestDiscussionComponent_UpdateInLockedDiscussion(t *testing.T) {
+	tester := newDiscussionWorkflowTester(t)
+	_, repo := tester.expectDiscussion(types.DiscussionStatusLocked)
+	tester.userStore.EXPECT().FindByUsername(mock.Anything, "owner").Return(database.User{ID: 1, Username: "owner"}, nil).Once()
+	tester.expectPermission(repo, "owner", types.UserRepoPermission{CanRead: true, CanWrite: true})
+
+	err := tester.comp.UpdateDiscussion(context.TODO(), types.UpdateDiscussionRequest{ID: 1, Title: "new title", CurrentUser: "owner"})
+	require.ErrorIs(t, err, errorx.ErrForbidden)
+
+	// the comments in the locked discussions can't be edited by their authors either
+	_, repo = tester.expectDiscussion(types.DiscussionStatusLocked)
+	tester.userStore.EXPECT().FindByUsername(mock.Anything, "user").Return(database.User{ID: 2, Username: "user"}, nil).Once()
+	tester.discussionStore.EXPECT().FindCommentByID(mock.Anything, int64(2)).Return(&database.Comment{ID: 2, CommentableID: 1, UserID: 2}, nil).Once()
+	tester.expectPermission(repo, "user", types.UserRepoPermission{CanRead: true})
+
+	err = tester.comp.UpdateComment(context.TODO(), "user", 2, "edited")
+	require.ErrorIs(t, err, errorx.ErrForbidden)
+
+	// the repo admins can still edit them
+	_, repo = tester.expectDiscussion(types.DiscussionStatusLocked)
+	tester.userStore.EXPECT().FindByUsername(mock.Anything, "admin").Return(database.User{ID: 3, Username: "admin"}, nil).Once()
+	tester.discussionStore.EXPECT().FindCommentByID(mock.Anything, int64(3)).Return(&database.Comment{ID: 3, CommentableID: 1, UserID: 3}, nil).Once()
+	tester.expectPermission(repo, "admin", types.UserRepoPermission{CanRead: true, CanWrite: true, CanAdmin: true})
+	tester.discussionStore.EXPECT().UpdateComment(mock.Anything, int64(3), "edited").Return(nil).Once()
+
+	require.NoError(t, tester.comp.UpdateComment(context.TODO(), "admin", 3, "edited"))
+}
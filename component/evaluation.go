@@ -124,6 +124,9 @@ func (c *evaluationComponentImpl) CreateEvaluation(ctx context.Context, req type
 		if err != nil {
 			return nil, fmt.Errorf("cannot find model, %w", err)
 		}
+		if err = c.repoComponent.CheckGatedAccess(ctx, m.Repository, operatorUsername); err != nil {
+			return nil, err
+		}
 		req.Revisions = append(req.Revisions, m.Repository.DefaultBranch)
 	}
 
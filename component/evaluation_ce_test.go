@@ -71,6 +71,7 @@ t.Run("create evaluation without resource id", func(t *testing.T) {
 				},
 			}, nil,
 		).Maybe()
+		c.mocks.components.repo.EXPECT().CheckGatedAccess(ctx, mock.Anything, req.Username).Return(nil).Maybe()
 		c.mocks.stores.RepoMock().EXPECT().FindByPath(ctx, types.DatasetRepo, "opencsg", "hellaswag").Return(&database.Repository{
 			ID:            1,
 			DefaultBranch: "main",
@@ -107,6 +108,7 @@ t.Run("create evaluation without resource id", func(t *testing.T) {
 				},
 			}, nil,
 		).Maybe()
+		c.mocks.components.repo.EXPECT().CheckGatedAccess(ctx, mock.Anything, req.Username).Return(nil).Maybe()
 		c.mocks.stores.RepoMock().EXPECT().FindByPath(ctx, types.DatasetRepo, "opencsg", "hellaswag").Return(&database.Repository{
 			ID:            1,
 			DefaultBranch: "main",
@@ -196,6 +198,7 @@ c.mocks.stores.SpaceResourceMock().EXPECT().FindByID(ctx, int64(1)).Return(&data
 				},
 			}, nil,
 		).Once()
+		c.mocks.components.repo.EXPECT().CheckGatedAccess(ctx, mock.Anything, invalidReq.Username).Return(nil).Once()
 		c.mocks.stores.AccessTokenMock().EXPECT().FindByUID(ctx, int64(1)).Return(&database.AccessToken{Token: "foo"}, nil).Once()
 		_, err := c.CreateEvaluation(ctx, invalidReq)
 		require.Error(t, err)
@@ -229,6 +232,7 @@ c.mocks.stores.SpaceResourceMock().EXPECT().FindByID(ctx, int64(1)).Return(&data
 				},
 			}, nil,
 		).Once()
+		c.mocks.components.repo.EXPECT().CheckGatedAccess(ctx, mock.Anything, invalidReq.Username).Return(nil).Once()
 		c.mocks.stores.AccessTokenMock().EXPECT().FindByUID(ctx, int64(1)).Return(&database.AccessToken{Token: "foo"}, nil).Once()
 		_, err := c.CreateEvaluation(ctx, invalidReq)
 		require.Error(t, err)
@@ -306,6 +310,7 @@ c.mocks.stores.SpaceResourceMock().EXPECT().FindByID(ctx, int64(1)).Return(&data
 				},
 			}, nil,
 		).Maybe()
+		c.mocks.components.repo.EXPECT().CheckGatedAccess(ctx, mock.Anything, "testuser").Return(nil).Maybe()
 		c.mocks.stores.AccessTokenMock().EXPECT().FindByUID(ctx, int64(1)).Return(&database.AccessToken{Token: "foo"}, nil)
 		c.mocks.stores.RepoMock().EXPECT().FindByPath(ctx, types.DatasetRepo, "opencsg", "hellaswag").Return(&database.Repository{
 			ID:            1,
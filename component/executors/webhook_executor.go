@@ -6,6 +6,7 @@ import (
 	"log/slog"
 	"reflect"
 
+	"opencsg.com/csghub-server/builder/store/database"
 	"opencsg.com/csghub-server/common/types"
 )
 
@@ -29,3 +30,20 @@ func RegisterWebHookExecutor(eventType types.WebHookEventType, newExecutor WebHo
 
 	return nil
 }
+
+// WorkflowFinishedHandler is called once a runner workflow of the registered task type reaches a finished phase.
+type WorkflowFinishedHandler func(ctx context.Context, wf *database.ArgoWorkflow) error
+
+var WorkflowFinishedHandlers map[types.TaskType]WorkflowFinishedHandler = make(map[types.TaskType]WorkflowFinishedHandler)
+
+func RegisterWorkflowFinishedHandler(taskType types.TaskType, handler WorkflowFinishedHandler) error {
+	if _, ok := WorkflowFinishedHandlers[taskType]; ok {
+		return fmt.Errorf("workflow finished handler already registered for task type %s", taskType)
+	}
+
+	WorkflowFinishedHandlers[taskType] = handler
+
+	slog.Info("workflow finished handler registered", slog.Any("task_type", taskType))
+
+	return nil
+}
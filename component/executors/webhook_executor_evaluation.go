@@ -69,6 +69,7 @@ func (h *argoWorkflowExecutorImpl) ProcessEvent(ctx context.Context, event *type
 		if err != nil {
 			return fmt.Errorf("failed to update argo workflow: %w", err)
 		}
+		h.notifyWorkflowFinished(ctx, &wf)
 
 	default:
 		return fmt.Errorf("unknown event type: %s", event.EventType)
@@ -76,3 +77,21 @@ func (h *argoWorkflowExecutorImpl) ProcessEvent(ctx context.Context, event *type
 
 	return nil
 }
+
+func (h *argoWorkflowExecutorImpl) notifyWorkflowFinished(ctx context.Context, wf *database.ArgoWorkflow) {
+	if _, finished := types.WorkFlowFinished[wf.Status]; !finished {
+		return
+	}
+	handler, ok := WorkflowFinishedHandlers[wf.TaskType]
+	if !ok {
+		return
+	}
+	// handlers may take long, e.g. copying model weights, so do not block the webhook dispatcher
+	go func(wf database.ArgoWorkflow) {
+		err := handler(context.Background(), &wf)
+		if err != nil {
+			slog.Error("failed to handle finished workflow", slog.Any("task_id", wf.TaskId),
+				slog.Any("task_type", wf.TaskType), slog.Any("error", err))
+		}
+	}(*wf)
+}
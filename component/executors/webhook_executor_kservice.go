@@ -27,6 +27,7 @@ type kserviceExecutorImpl struct {
 	cfg                   *config.Config
 	deployTaskStore       database.DeployTaskStore
 	notificationSvcClient rpc.NotificationSvcClient
+	feedEventStore        database.FeedEventStore
 }
 
 var _ KServiceExecutor = (*kserviceExecutorImpl)(nil)
@@ -38,6 +39,7 @@ func NewKServiceExecutor(config *config.Config) (KServiceExecutor, error) {
 		cfg:                   config,
 		deployTaskStore:       database.NewDeployTaskStore(),
 		notificationSvcClient: notificationSvcClient,
+		feedEventStore:        database.NewFeedEventStore(),
 	}
 	// register the kservice executor for webhook callback func ProcessEvent
 	err := RegisterWebHookExecutor(types.RunnerServiceCreate, executor)
@@ -141,13 +143,27 @@ func (k *kserviceExecutorImpl) updateDeployStatus(ctx context.Context, event *ty
 	}
 
 	if event.Status == common.Running && oldStatus != common.Running {
-		go k.handleDeployRunning(event.TaskID, deploy)
+		go k.handleDeployRunning(event.TaskID, deploy, isDeployInProgress(oldStatus))
 	}
 
 	return nil
 }
 
-func (k *kserviceExecutorImpl) handleDeployRunning(sourceDeployTaskID int64, deploy *database.Deploy) {
+// isDeployInProgress reports whether the deploy is being built or started, the deploys coming back from
+// sleeping, stopped or failed states are not new versions
+func isDeployInProgress(status int) bool {
+	switch status {
+	case common.Pending, common.BuildInQueue, common.Building, common.BuildSuccess, common.BuildSkip,
+		common.Deploying, common.Startup:
+		return true
+	default:
+		return false
+	}
+}
+
+// handleDeployRunning runs the actions of the deploy which starts running, deployed is true if the deploy
+// is a new version rather than a wake up or restart
+func (k *kserviceExecutorImpl) handleDeployRunning(sourceDeployTaskID int64, deploy *database.Deploy, deployed bool) {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
@@ -155,6 +171,10 @@ func (k *kserviceExecutorImpl) handleDeployRunning(sourceDeployTaskID int64, dep
 		slog.Error("failed to send notification", slog.Any("err", err))
 	}
 
+	if deployed && deploy.Type == types.SpaceType && deploy.RepoID > 0 {
+		k.recordSpaceDeployed(ctx, deploy)
+	}
+
 	if deploybuilder.DeployRunningCallback == nil {
 		return
 	}
@@ -164,6 +184,24 @@ func (k *kserviceExecutorImpl) handleDeployRunning(sourceDeployTaskID int64, dep
 	}
 }
 
+// recordSpaceDeployed records the new running version of the space for the activity feeds of the followers
+func (k *kserviceExecutorImpl) recordSpaceDeployed(ctx context.Context, deploy *database.Deploy) {
+	namespace, _, _ := strings.Cut(deploy.GitPath, "/")
+	event := &database.FeedEvent{
+		EventType:    string(types.FeedEventSpaceDeployed),
+		ActorID:      deploy.UserID,
+		Namespace:    namespace,
+		RepositoryID: deploy.RepoID,
+		Payload: map[string]any{
+			"deploy_id": deploy.ID,
+			"branch":    deploy.GitBranch,
+		},
+	}
+	if err := k.feedEventStore.Create(ctx, event); err != nil {
+		slog.Error("failed to record space deployed feed event", slog.Any("deploy_id", deploy.ID), slog.Any("err", err))
+	}
+}
+
 func (k *kserviceExecutorImpl) sendNotification(ctx context.Context, deploy *database.Deploy) error {
 	payload, url := buildDeployNotification(deploy)
 
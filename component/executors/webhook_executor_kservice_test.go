@@ -559,6 +559,50 @@ func TestKServiceExecutor_updateDeployStatus_noHandleDeployRunningWhenAlreadyRun
 	require.NoError(t, err)
 }
 
+func TestKServiceExecutor_handleDeployRunning_recordSpaceDeployed(t *testing.T) {
+	cfg, err := config.LoadConfig()
+	require.Nil(t, err)
+
+	for _, c := range []struct {
+		oldStatus int
+		recorded  bool
+	}{
+		{oldStatus: common.Deploying, recorded: true},
+		{oldStatus: common.Startup, recorded: true},
+		{oldStatus: common.Sleeping, recorded: false},
+		{oldStatus: common.Stopped, recorded: false},
+		{oldStatus: common.RunTimeError, recorded: false},
+	} {
+		t.Run(fmt.Sprintf("old status %d", c.oldStatus), func(t *testing.T) {
+			mockNotificationRpc := mockrpc.NewMockNotificationSvcClient(t)
+			mockNotificationRpc.EXPECT().Send(mock.Anything, mock.Anything).Return(nil)
+			feedEventStore := mockdb.NewMockFeedEventStore(t)
+			deploy := &database.Deploy{
+				ID:        int64(1),
+				Type:      types.SpaceType,
+				RepoID:    int64(2),
+				UserID:    int64(3),
+				GitPath:   "ns/n",
+				GitBranch: "main",
+				UserUUID:  "user1",
+			}
+			if c.recorded {
+				feedEventStore.EXPECT().Create(mock.Anything, mock.MatchedBy(func(event *database.FeedEvent) bool {
+					return event.EventType == string(types.FeedEventSpaceDeployed) &&
+						event.Namespace == "ns" && event.RepositoryID == 2 && event.ActorID == 3
+				})).Return(nil).Once()
+			}
+
+			executor := &kserviceExecutorImpl{
+				cfg:                   cfg,
+				notificationSvcClient: mockNotificationRpc,
+				feedEventStore:        feedEventStore,
+			}
+			executor.handleDeployRunning(100, deploy, isDeployInProgress(c.oldStatus))
+		})
+	}
+}
+
 func TestKServiceExecutor_updateDeployStatus_appendClusterNode(t *testing.T) {
 	ctx := context.TODO()
 	cfg, err := config.LoadConfig()
@@ -9,10 +9,14 @@ import (
 	"strings"
 
 	"opencsg.com/csghub-server/builder/deploy"
+	"opencsg.com/csghub-server/builder/git"
+	"opencsg.com/csghub-server/builder/git/gitserver"
 	"opencsg.com/csghub-server/builder/git/membership"
 	"opencsg.com/csghub-server/builder/loki"
 	"opencsg.com/csghub-server/builder/rpc"
+	"opencsg.com/csghub-server/builder/secret"
 	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/builder/store/s3"
 	"opencsg.com/csghub-server/common/config"
 	"opencsg.com/csghub-server/common/errorx"
 	"opencsg.com/csghub-server/common/types"
@@ -35,6 +39,11 @@ type finetuneComponentImpl struct {
 	repoComponent         RepoComponent
 	userSvcClient         rpc.UserSvcClient
 	clusterStore          database.ClusterInfoStore
+	publicationStore      database.FinetunePublicationStore
+	modelComponent        ModelComponent
+	modelTreeComponent    ModelTreeComponent
+	gitServer             gitserver.GitServer
+	s3Client              s3.Client
 }
 
 type FinetuneComponent interface {
@@ -46,6 +55,10 @@ type FinetuneComponent interface {
 	CheckUserPermission(ctx context.Context, req types.FinetuneLogReq) (bool, *database.ArgoWorkflow, error)
 	ReadJobLogsNonStream(ctx context.Context, req types.FinetuneLogReq) (string, error)
 	ReadJobLogsInStream(ctx context.Context, req types.FinetuneLogReq) (*deploy.MultiLogReader, error)
+	PublishFinetuneResult(ctx context.Context, req types.FinetunePublishReq) (*types.FinetunePublishRes, error)
+	GetFinetunePublication(ctx context.Context, req types.FinetuneLogReq) (*types.FinetunePublishRes, error)
+	ListFinetuneCheckpoints(ctx context.Context, req types.FinetuneLogReq) ([]string, error)
+	HandleFinetuneFinished(ctx context.Context, wf *database.ArgoWorkflow) error
 }
 
 func NewFinetuneComponent(config *config.Config) (FinetuneComponent, error) {
@@ -74,6 +87,23 @@ func NewFinetuneComponent(config *config.Config) (FinetuneComponent, error) {
 	userSvcAddr := fmt.Sprintf("%s:%d", config.User.Host, config.User.Port)
 	c.userSvcClient = rpc.NewUserSvcHttpClient(userSvcAddr, rpc.AuthWithApiKey(config.APIToken))
 	c.clusterStore = database.NewClusterInfoStore()
+	c.publicationStore = database.NewFinetunePublicationStore()
+	c.modelComponent, err = NewModelComponent(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create model component, %w", err)
+	}
+	c.modelTreeComponent, err = newFinetuneModelTreeComponent(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create model tree component, %w", err)
+	}
+	c.gitServer, err = git.NewGitServer(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create git server, %w", err)
+	}
+	c.s3Client, err = s3.NewMinio(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to init s3 client, %w", err)
+	}
 	return c, nil
 }
 
@@ -107,6 +137,9 @@ func (c *finetuneComponentImpl) CreateFinetuneJob(ctx context.Context, req types
 	if err != nil {
 		return nil, fmt.Errorf("cannot find model, %w", err)
 	}
+	if err = c.repoComponent.CheckGatedAccess(ctx, model.Repository, operatorUsername); err != nil {
+		return nil, err
+	}
 	req.Revision = model.Repository.DefaultBranch
 
 	// Query dataset repo so an omitted dataset revision can fall back to its default branch.
@@ -118,6 +151,9 @@ func (c *finetuneComponentImpl) CreateFinetuneJob(ctx context.Context, req types
 	if err != nil {
 		return nil, fmt.Errorf("cannot find dataset repo, %w", err)
 	}
+	if err = c.repoComponent.CheckGatedAccess(ctx, datasetRepo, operatorUsername); err != nil {
+		return nil, err
+	}
 	req.DatasetRevision = strings.TrimSpace(req.DatasetRevision)
 	if req.DatasetRevision == "" {
 		req.DatasetRevision = datasetRepo.DefaultBranch
@@ -144,6 +180,17 @@ func (c *finetuneComponentImpl) CreateFinetuneJob(ctx context.Context, req types
 		billingUUID = resolved
 	}
 
+	if req.Publish != nil {
+		err = c.validatePublishConfig(ctx, operatorUsername, req.Publish)
+		if err != nil {
+			return nil, err
+		}
+	}
+	err = secret.ValidateValues(req.Secrets)
+	if err != nil {
+		return nil, err
+	}
+
 	req.Token = token.Token
 	var hardware types.HardWare
 	if req.ResourceId != 0 {
@@ -198,7 +245,17 @@ func (c *finetuneComponentImpl) CreateFinetuneJob(ctx context.Context, req types
 	req.TaskType = types.TaskTypeFinetune
 	req.DownloadEndpoint = c.config.Model.DownloadEndpoint
 	slog.Debug("submit finetune request to deployer", slog.Any("req", req))
-	return c.deployer.SubmitFinetuneJob(ctx, req)
+	res, err := c.deployer.SubmitFinetuneJob(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if req.Publish != nil {
+		err = c.createPublication(ctx, operatorUsername, res.TaskId, req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to save finetune publish settings, error: %w", err)
+		}
+	}
+	return res, nil
 }
 
 func (c *finetuneComponentImpl) DeleteFinetuneJob(ctx context.Context, req types.ArgoWorkFlowDeleteReq) error {
@@ -0,0 +1,11 @@
+//go:build !ee && !saas
+
+package component
+
+import "opencsg.com/csghub-server/common/config"
+
+// newFinetuneModelTreeComponent returns no model tree as the model tree is not maintained in this edition,
+// the lineage of a published finetune result is kept in the base_model field of its model card
+func newFinetuneModelTreeComponent(config *config.Config) (ModelTreeComponent, error) {
+	return nil, nil
+}
@@ -0,0 +1,598 @@
+package component
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/minio/minio-go/v7"
+	"gopkg.in/yaml.v3"
+	"opencsg.com/csghub-server/builder/git/gitserver"
+	"opencsg.com/csghub-server/builder/git/gitserver/gitaly"
+	"opencsg.com/csghub-server/builder/git/membership"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+	"opencsg.com/csghub-server/common/utils/common"
+)
+
+// validatePublishConfig normalizes the publish settings of a finetune job and checks
+// the operator is allowed to write into the target namespace.
+func (c *finetuneComponentImpl) validatePublishConfig(ctx context.Context, operator string, cfg *types.FinetunePublishConfig) error {
+	cfg.TargetRepo = strings.TrimSpace(cfg.TargetRepo)
+	parts := strings.Split(cfg.TargetRepo, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return errorx.BadRequest(fmt.Errorf("invalid target repo format: %s", cfg.TargetRepo), errorx.Ctx().Set("target_repo", cfg.TargetRepo))
+	}
+	switch cfg.Artifact {
+	case "":
+		cfg.Artifact = types.FinetunePublishAdapter
+	case types.FinetunePublishAdapter, types.FinetunePublishMerged:
+	default:
+		return errorx.BadRequest(fmt.Errorf("invalid publish artifact: %s", cfg.Artifact), errorx.Ctx().Set("artifact", cfg.Artifact))
+	}
+	for _, ckpt := range cfg.Checkpoints {
+		if !strings.HasPrefix(ckpt, types.FinetuneCheckpointPrefix) || strings.Contains(ckpt, "/") {
+			return errorx.BadRequest(fmt.Errorf("invalid checkpoint name: %s", ckpt), errorx.Ctx().Set("checkpoint", ckpt))
+		}
+	}
+
+	canWrite, err := c.repoComponent.CheckCurrentUserPermission(ctx, operator, parts[0], membership.RoleWrite)
+	if err != nil {
+		return fmt.Errorf("failed to check target namespace permission, error: %w", err)
+	}
+	if !canWrite {
+		return errorx.ErrForbiddenMsg("users do not have permission to publish finetune result into this namespace")
+	}
+	return nil
+}
+
+func (c *finetuneComponentImpl) createPublication(ctx context.Context, operator, taskID string, req types.FinetuneReq) error {
+	_, err := c.publicationStore.Create(ctx, &database.FinetunePublication{
+		TaskID:      taskID,
+		Operator:    operator,
+		TargetPath:  req.Publish.TargetRepo,
+		Artifact:    req.Publish.Artifact,
+		Checkpoints: req.Publish.Checkpoints,
+		Private:     req.Publish.Private,
+		License:     req.Publish.License,
+		Hyperparameters: types.FinetuneHyperparameters{
+			Epochs:       req.Epochs,
+			LearningRate: req.LearningRate,
+			CustomArgs:   req.CustomeArgs,
+			SwiftCommand: req.SwiftCommand,
+		},
+		Status: types.FinetunePublishPending,
+	})
+	return err
+}
+
+// PublishFinetuneResult publishes the output of a succeeded finetune job into a model repo,
+// it can be used for jobs created without publish settings or to retry a failed publication.
+// The files are copied in the background, the returned publication is pending.
+func (c *finetuneComponentImpl) PublishFinetuneResult(ctx context.Context, req types.FinetunePublishReq) (*types.FinetunePublishRes, error) {
+	allow, wf, err := c.CheckUserPermission(ctx, types.FinetuneLogReq{ID: req.ID, CurrentUser: req.CurrentUser})
+	if err != nil {
+		return nil, err
+	}
+	if !allow {
+		return nil, errorx.ErrForbidden
+	}
+	if wf.Status != v1alpha1.WorkflowSucceeded {
+		return nil, errorx.BadRequest(fmt.Errorf("finetune job %d is not succeeded", wf.ID), errorx.Ctx().Set("status", wf.Status))
+	}
+	err = c.validatePublishConfig(ctx, req.CurrentUser, &req.FinetunePublishConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	pub, err := c.publicationStore.FindByTaskID(ctx, wf.TaskId)
+	if err != nil && !errors.Is(err, errorx.ErrDatabaseNoRows) {
+		return nil, fmt.Errorf("failed to find finetune publication, error: %w", err)
+	}
+	if pub == nil {
+		pub = &database.FinetunePublication{TaskID: wf.TaskId}
+	}
+	if pub.Status == types.FinetunePublishPublishing {
+		return nil, errorx.BadRequest(errors.New("finetune result is being published"), errorx.Ctx().Set("task_id", wf.TaskId))
+	}
+	pub.Operator = req.CurrentUser
+	pub.TargetPath = req.TargetRepo
+	pub.Artifact = req.Artifact
+	pub.Checkpoints = req.Checkpoints
+	pub.Private = req.Private
+	pub.License = req.License
+	from := pub.Status
+	pub.Status = types.FinetunePublishPending
+	pub.Reason = ""
+	if pub.ID == 0 {
+		pub, err = c.publicationStore.Create(ctx, pub)
+	} else {
+		var updated bool
+		updated, err = c.publicationStore.Update(ctx, pub, from)
+		if err == nil && !updated {
+			return nil, errorx.BadRequest(errors.New("finetune result is being published"), errorx.Ctx().Set("task_id", wf.TaskId))
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to save finetune publication, error: %w", err)
+	}
+
+	res := c.buildPublishRes(wf, pub)
+	// copying the model weights may take long, so do not block the request
+	go func(wf database.ArgoWorkflow, pub database.FinetunePublication) {
+		_, err := c.publish(context.Background(), &wf, &pub)
+		if err != nil {
+			slog.Error("failed to publish finetune result", slog.Any("task_id", wf.TaskId), slog.Any("error", err))
+		}
+	}(*wf, *pub)
+	return res, nil
+}
+
+// GetFinetunePublication returns the publish state of a finetune job
+func (c *finetuneComponentImpl) GetFinetunePublication(ctx context.Context, req types.FinetuneLogReq) (*types.FinetunePublishRes, error) {
+	allow, wf, err := c.CheckUserPermission(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if !allow {
+		return nil, errorx.ErrForbidden
+	}
+	pub, err := c.publicationStore.FindByTaskID(ctx, wf.TaskId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find finetune publication, error: %w", err)
+	}
+	return c.buildPublishRes(wf, pub), nil
+}
+
+// ListFinetuneCheckpoints lists the intermediate checkpoints saved by a finetune job
+func (c *finetuneComponentImpl) ListFinetuneCheckpoints(ctx context.Context, req types.FinetuneLogReq) ([]string, error) {
+	allow, wf, err := c.CheckUserPermission(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if !allow {
+		return nil, errorx.ErrForbidden
+	}
+	srcRepo, err := c.findResultRepo(ctx, wf)
+	if err != nil {
+		return nil, err
+	}
+	files, err := c.listResultFiles(ctx, srcRepo)
+	if err != nil {
+		return nil, err
+	}
+	checkpoints := []string{}
+	for _, f := range files {
+		dir, _, found := strings.Cut(f.Path, "/")
+		if found && strings.HasPrefix(dir, types.FinetuneCheckpointPrefix) && !slices.Contains(checkpoints, dir) {
+			checkpoints = append(checkpoints, dir)
+		}
+	}
+	slices.SortFunc(checkpoints, compareCheckpoints)
+	return checkpoints, nil
+}
+
+// HandleFinetuneFinished publishes the result of a finished finetune job if it was created with publish settings
+func (c *finetuneComponentImpl) HandleFinetuneFinished(ctx context.Context, wf *database.ArgoWorkflow) error {
+	pub, err := c.publicationStore.FindByTaskID(ctx, wf.TaskId)
+	if err != nil {
+		if errors.Is(err, errorx.ErrDatabaseNoRows) {
+			return nil
+		}
+		return fmt.Errorf("failed to find finetune publication, error: %w", err)
+	}
+	if pub.Status != types.FinetunePublishPending {
+		return nil
+	}
+	if wf.Status != v1alpha1.WorkflowSucceeded {
+		pub.Status = types.FinetunePublishFailed
+		pub.Reason = fmt.Sprintf("finetune job finished with status %s", wf.Status)
+		_, err = c.publicationStore.Update(ctx, pub, types.FinetunePublishPending)
+		return err
+	}
+	_, err = c.publish(ctx, wf, pub)
+	return err
+}
+
+// publish moves the pending publication into publishing and commits the result files, it returns false
+// without publishing if the publication has been taken by another handler
+func (c *finetuneComponentImpl) publish(ctx context.Context, wf *database.ArgoWorkflow, pub *database.FinetunePublication) (bool, error) {
+	pub.Status = types.FinetunePublishPublishing
+	updated, err := c.publicationStore.Update(ctx, pub, types.FinetunePublishPending)
+	if err != nil {
+		return false, fmt.Errorf("failed to update finetune publication status, error: %w", err)
+	}
+	if !updated {
+		slog.Info("finetune publication is taken by another handler", slog.Any("task_id", wf.TaskId))
+		return false, nil
+	}
+
+	targetRepo, count, pubErr := c.publishFiles(ctx, wf, pub)
+	if pubErr != nil {
+		slog.Error("failed to publish finetune result", slog.Any("task_id", wf.TaskId),
+			slog.Any("target", pub.TargetPath), slog.Any("error", pubErr))
+		pub.Status = types.FinetunePublishFailed
+		pub.Reason = pubErr.Error()
+	} else {
+		pub.Status = types.FinetunePublishPublished
+		pub.Reason = ""
+		pub.TargetRepoID = targetRepo.ID
+		pub.PublishedFiles = count
+		c.recordLineage(ctx, wf, pub, targetRepo)
+	}
+	_, err = c.publicationStore.Update(ctx, pub, types.FinetunePublishPublishing)
+	if err != nil {
+		return true, fmt.Errorf("failed to update finetune publication status, error: %w", err)
+	}
+	return true, pubErr
+}
+
+// recordLineage adds the published model under its base model and training datasets in the model tree
+func (c *finetuneComponentImpl) recordLineage(ctx context.Context, wf *database.ArgoWorkflow, pub *database.FinetunePublication, targetRepo *database.Repository) {
+	if c.modelTreeComponent == nil {
+		return
+	}
+	var relations []*types.ModelNode
+	if baseModel := firstOrEmpty(wf.RepoIds); baseModel != "" {
+		if node := c.lineageNode(ctx, types.ModelRepo, baseModel, finetuneRelation(pub.Artifact)); node != nil {
+			relations = append(relations, node)
+		}
+	}
+	for _, dataset := range wf.Datasets {
+		if node := c.lineageNode(ctx, types.DatasetRepo, dataset, types.RelationTrainingDataset); node != nil {
+			relations = append(relations, node)
+		}
+	}
+	if len(relations) == 0 {
+		return
+	}
+	c.modelTreeComponent.ProcessModelTree(ctx, relations, *targetRepo)
+}
+
+// lineageNode returns the model tree node of a source repo of the finetune result, or nil if the repo is not found
+func (c *finetuneComponentImpl) lineageNode(ctx context.Context, repoType types.RepositoryType, repoPath string, relation types.ModelRelation) *types.ModelNode {
+	namespace, name, _ := strings.Cut(repoPath, "/")
+	repo, err := c.repoStore.FindByPath(ctx, repoType, namespace, name)
+	if err != nil {
+		slog.Warn("failed to find source repo of finetune result, skip recording lineage", slog.Any("repo_type", repoType),
+			slog.Any("path", repoPath), slog.Any("error", err))
+		return nil
+	}
+	return &types.ModelNode{
+		ID:        repo.ID,
+		Path:      repo.Path,
+		Relation:  relation,
+		Operation: types.RelationAdd,
+	}
+}
+
+func (c *finetuneComponentImpl) publishFiles(ctx context.Context, wf *database.ArgoWorkflow, pub *database.FinetunePublication) (*database.Repository, int, error) {
+	srcRepo, err := c.findResultRepo(ctx, wf)
+	if err != nil {
+		return nil, 0, err
+	}
+	srcNamespace, srcName, _ := strings.Cut(wf.ResultURL, "/")
+	files, err := c.listResultFiles(ctx, srcRepo)
+	if err != nil {
+		return nil, 0, err
+	}
+	selected, err := selectPublishFiles(files, pub.Artifact, pub.Checkpoints)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	targetRepo, err := c.ensurePublishTarget(ctx, wf, pub)
+	if err != nil {
+		return nil, 0, err
+	}
+	targetNamespace, targetName, _ := strings.Cut(pub.TargetPath, "/")
+	// the target repo may exist already, e.g. published before or created with a README
+	targetFiles, err := c.gitServer.GetRepoAllFiles(ctx, gitserver.GetRepoAllFilesReq{
+		Namespace: targetNamespace,
+		Name:      targetName,
+		Ref:       targetRepo.DefaultBranch,
+		RepoType:  types.ModelRepo,
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list files of publish target repo %s, error: %w", pub.TargetPath, err)
+	}
+	existing := make(map[string]bool, len(targetFiles))
+	for _, f := range targetFiles {
+		existing[f.Path] = true
+	}
+	commitAction := func(p string) types.CommitAction {
+		if existing[p] {
+			return types.CommitActionUpdate
+		}
+		return types.CommitActionCreate
+	}
+
+	var commitFiles []types.CommitFileReq
+	for _, f := range selected {
+		raw, err := c.gitServer.GetRepoFileRaw(ctx, gitserver.GetRepoInfoByPathReq{
+			Namespace: srcNamespace,
+			Name:      srcName,
+			Ref:       srcRepo.DefaultBranch,
+			Path:      f.Path,
+			RepoType:  types.ModelRepo,
+		})
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to read file %s from finetune result, error: %w", f.Path, err)
+		}
+		if pointer, err := gitaly.ReadPointerFromBuffer([]byte(raw)); err == nil {
+			err = c.copyLfsObject(ctx, srcRepo, targetRepo, pointer.Oid)
+			if err != nil {
+				return nil, 0, err
+			}
+		}
+		commitFiles = append(commitFiles, types.CommitFileReq{
+			Path:    f.Path,
+			Action:  commitAction(f.Path),
+			Content: base64.StdEncoding.EncodeToString([]byte(raw)),
+		})
+	}
+
+	card, err := buildFinetuneModelCard(wf, pub)
+	if err != nil {
+		return nil, 0, err
+	}
+	commitFiles = append(commitFiles, types.CommitFileReq{
+		Path:    types.ReadmeFileName,
+		Action:  commitAction(types.ReadmeFileName),
+		Content: base64.StdEncoding.EncodeToString([]byte(card)),
+	})
+
+	_, err = c.repoComponent.CommitFiles(ctx, types.CommitFilesReq{
+		Namespace:   targetNamespace,
+		Name:        targetName,
+		RepoType:    types.ModelRepo,
+		Revision:    targetRepo.DefaultBranch,
+		CurrentUser: pub.Operator,
+		Message:     fmt.Sprintf("Publish %s from finetune job %s", pub.Artifact, wf.TaskName),
+		Files:       commitFiles,
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to commit finetune result into %s, error: %w", pub.TargetPath, err)
+	}
+	return targetRepo, len(selected), nil
+}
+
+func (c *finetuneComponentImpl) findResultRepo(ctx context.Context, wf *database.ArgoWorkflow) (*database.Repository, error) {
+	namespace, name, found := strings.Cut(wf.ResultURL, "/")
+	if !found {
+		return nil, fmt.Errorf("invalid finetune result repo %s", wf.ResultURL)
+	}
+	repo, err := c.repoStore.FindByPath(ctx, types.ModelRepo, namespace, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find finetune result repo %s, error: %w", wf.ResultURL, err)
+	}
+	return repo, nil
+}
+
+// listResultFiles lists the files of the default branch of the result repo, which is the branch the files
+// are read from when published
+func (c *finetuneComponentImpl) listResultFiles(ctx context.Context, srcRepo *database.Repository) ([]*types.File, error) {
+	namespace, name, _ := strings.Cut(srcRepo.Path, "/")
+	files, err := c.gitServer.GetRepoAllFiles(ctx, gitserver.GetRepoAllFilesReq{
+		Namespace: namespace,
+		Name:      name,
+		Ref:       srcRepo.DefaultBranch,
+		RepoType:  types.ModelRepo,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files of finetune result repo %s, error: %w", srcRepo.Path, err)
+	}
+	return files, nil
+}
+
+// ensurePublishTarget returns the target model repo, the repo is created with the base model recorded
+// when it does not exist yet
+func (c *finetuneComponentImpl) ensurePublishTarget(ctx context.Context, wf *database.ArgoWorkflow, pub *database.FinetunePublication) (*database.Repository, error) {
+	namespace, name, _ := strings.Cut(pub.TargetPath, "/")
+	repo, err := c.repoStore.FindByPath(ctx, types.ModelRepo, namespace, name)
+	if err == nil {
+		return repo, nil
+	}
+	if !errors.Is(err, errorx.ErrDatabaseNoRows) {
+		return nil, fmt.Errorf("failed to find publish target repo %s, error: %w", pub.TargetPath, err)
+	}
+
+	license := pub.License
+	if license == "" {
+		license = "apache-2.0"
+	}
+	model, err := c.modelComponent.Create(ctx, &types.CreateModelReq{
+		BaseModel: firstOrEmpty(wf.RepoIds),
+		CreateRepoReq: types.CreateRepoReq{
+			Username:    pub.Operator,
+			Namespace:   namespace,
+			Name:        name,
+			Description: fmt.Sprintf("Finetuned from %s", firstOrEmpty(wf.RepoIds)),
+			Private:     pub.Private,
+			License:     license,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create publish target repo %s, error: %w", pub.TargetPath, err)
+	}
+	return c.repoStore.FindById(ctx, model.RepositoryID)
+}
+
+func (c *finetuneComponentImpl) copyLfsObject(ctx context.Context, srcRepo, targetRepo *database.Repository, oid string) error {
+	srcKey := common.BuildLfsPath(srcRepo.ID, oid, srcRepo.Migrated)
+	targetKey := common.BuildLfsPath(targetRepo.ID, oid, targetRepo.Migrated)
+	if srcKey == targetKey {
+		return nil
+	}
+	_, err := c.s3Client.CopyObject(ctx,
+		minio.CopyDestOptions{
+			Bucket: c.config.S3.Bucket,
+			Object: targetKey,
+		},
+		minio.CopySrcOptions{
+			Bucket: c.config.S3.Bucket,
+			Object: srcKey,
+		})
+	if err != nil {
+		return fmt.Errorf("failed to copy lfs object %s, error: %w", oid, err)
+	}
+	return nil
+}
+
+func (c *finetuneComponentImpl) buildPublishRes(wf *database.ArgoWorkflow, pub *database.FinetunePublication) *types.FinetunePublishRes {
+	return &types.FinetunePublishRes{
+		ID:              pub.ID,
+		TaskID:          pub.TaskID,
+		TargetRepo:      pub.TargetPath,
+		Artifact:        pub.Artifact,
+		Checkpoints:     pub.Checkpoints,
+		Status:          pub.Status,
+		Reason:          pub.Reason,
+		PublishedFiles:  pub.PublishedFiles,
+		BaseModel:       firstOrEmpty(wf.RepoIds),
+		Dataset:         firstOrEmpty(wf.Datasets),
+		Hyperparameters: pub.Hyperparameters,
+		CreatedAt:       pub.CreatedAt,
+		UpdatedAt:       pub.UpdatedAt,
+	}
+}
+
+// selectPublishFiles picks the final weights from the finetune result repo and the requested checkpoints.
+// The trainer-generated README is skipped as the model card is regenerated on publish.
+func selectPublishFiles(files []*types.File, artifact types.FinetunePublishArtifact, checkpoints []string) ([]*types.File, error) {
+	var selected []*types.File
+	foundCheckpoints := map[string]bool{}
+	hasConfig := false
+	for _, f := range files {
+		if f.Path == types.ReadmeFileName {
+			continue
+		}
+		dir, _, inDir := strings.Cut(f.Path, "/")
+		if inDir && strings.HasPrefix(dir, types.FinetuneCheckpointPrefix) {
+			if slices.Contains(checkpoints, dir) {
+				foundCheckpoints[dir] = true
+				selected = append(selected, f)
+			}
+			continue
+		}
+		if !inDir {
+			switch {
+			case artifact == types.FinetunePublishAdapter && f.Path == types.AdapterConfigFileName:
+				hasConfig = true
+			case artifact == types.FinetunePublishMerged && f.Path == types.ModelConfigFileName:
+				hasConfig = true
+			}
+		}
+		selected = append(selected, f)
+	}
+	if !hasConfig {
+		configFile := types.AdapterConfigFileName
+		if artifact == types.FinetunePublishMerged {
+			configFile = types.ModelConfigFileName
+		}
+		return nil, fmt.Errorf("finetune result does not contain %s for %s artifact", configFile, artifact)
+	}
+	for _, ckpt := range checkpoints {
+		if !foundCheckpoints[ckpt] {
+			return nil, fmt.Errorf("checkpoint %s not found in finetune result", ckpt)
+		}
+	}
+	return selected, nil
+}
+
+type finetuneCardMeta struct {
+	License           string   `yaml:"license,omitempty"`
+	BaseModel         string   `yaml:"base_model,omitempty"`
+	BaseModelRelation string   `yaml:"base_model_relation,omitempty"`
+	Datasets          []string `yaml:"datasets,omitempty"`
+	Tags              []string `yaml:"tags,omitempty"`
+}
+
+// buildFinetuneModelCard renders the README of a published finetune result. The base_model and
+// datasets front matter fields are picked up by the push callback to record the model lineage.
+func buildFinetuneModelCard(wf *database.ArgoWorkflow, pub *database.FinetunePublication) (string, error) {
+	relation := finetuneRelation(pub.Artifact)
+	baseModel := firstOrEmpty(wf.RepoIds)
+	meta := finetuneCardMeta{
+		License:           pub.License,
+		BaseModel:         baseModel,
+		BaseModelRelation: string(relation),
+		Datasets:          wf.Datasets,
+		Tags:              []string{string(types.TaskTypeFinetune)},
+	}
+	frontMatter, err := yaml.Marshal(meta)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal model card metadata, error: %w", err)
+	}
+
+	var card strings.Builder
+	card.WriteString("---\n")
+	card.Write(frontMatter)
+	card.WriteString("---\n\n")
+	card.WriteString("# " + path.Base(pub.TargetPath) + "\n\n")
+	card.WriteString(fmt.Sprintf("This model is the %s of [%s](/models/%s) finetuned on", pub.Artifact, baseModel, baseModel))
+	for i, ds := range wf.Datasets {
+		if i > 0 {
+			card.WriteString(",")
+		}
+		card.WriteString(fmt.Sprintf(" [%s](/datasets/%s)", ds, ds))
+	}
+	card.WriteString(fmt.Sprintf(" by finetune job `%s`.\n\n", wf.TaskName))
+
+	// hyperparameters are unknown for results published manually from jobs created without publish settings
+	hp := pub.Hyperparameters
+	if hp.Epochs > 0 || hp.LearningRate > 0 {
+		card.WriteString("## Training hyperparameters\n\n")
+		card.WriteString("| Parameter | Value |\n")
+		card.WriteString("| --- | --- |\n")
+		card.WriteString(fmt.Sprintf("| epochs | %d |\n", hp.Epochs))
+		card.WriteString(fmt.Sprintf("| learning_rate | %s |\n", strconv.FormatFloat(hp.LearningRate, 'g', -1, 64)))
+		if hp.SwiftCommand != "" {
+			card.WriteString(fmt.Sprintf("| swift_command | %s |\n", hp.SwiftCommand))
+		}
+		if hp.CustomArgs != "" {
+			card.WriteString(fmt.Sprintf("| custom_args | `%s` |\n", strings.ReplaceAll(hp.CustomArgs, "|", "\\|")))
+		}
+	}
+
+	if len(pub.Checkpoints) > 0 {
+		card.WriteString("\n## Checkpoints\n\n")
+		for _, ckpt := range pub.Checkpoints {
+			card.WriteString(fmt.Sprintf("- `%s/`\n", ckpt))
+		}
+	}
+	return card.String(), nil
+}
+
+// finetuneRelation returns the relation of a published finetune result to its base model
+func finetuneRelation(artifact types.FinetunePublishArtifact) types.ModelRelation {
+	if artifact == types.FinetunePublishMerged {
+		return types.RelationFinetune
+	}
+	return types.RelationAdapter
+}
+
+// compareCheckpoints orders checkpoint-<step> directories by training step
+func compareCheckpoints(a, b string) int {
+	stepA, errA := strconv.Atoi(strings.TrimPrefix(a, types.FinetuneCheckpointPrefix))
+	stepB, errB := strconv.Atoi(strings.TrimPrefix(b, types.FinetuneCheckpointPrefix))
+	if errA != nil || errB != nil {
+		return strings.Compare(a, b)
+	}
+	return stepA - stepB
+}
+
+func firstOrEmpty(items []string) string {
+	if len(items) == 0 {
+		return ""
+	}
+	return items[0]
+}
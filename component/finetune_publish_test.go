@@ -0,0 +1,265 @@
+package component
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/minio/minio-go/v7"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	mockgit "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/builder/git/gitserver"
+	mockrpc "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/builder/rpc"
+	mockdb "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/builder/store/database"
+	mocks3 "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/builder/store/s3"
+	mockComps "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/component"
+	"opencsg.com/csghub-server/builder/git/gitserver"
+	"opencsg.com/csghub-server/builder/git/membership"
+	"opencsg.com/csghub-server/builder/rpc"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/config"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+)
+
+type testFinetunePublishMocks struct {
+	publicationStore *mockdb.MockFinetunePublicationStore
+	workflowStore    *mockdb.MockArgoWorkFlowStore
+	userSvcClient    *mockrpc.MockUserSvcClient
+	repoStore        *mockdb.MockRepoStore
+	gitServer        *mockgit.MockGitServer
+	s3Client         *mocks3.MockClient
+	repoComponent    *mockComps.MockRepoComponent
+	modelComponent   *mockComps.MockModelComponent
+	modelTree        *mockComps.MockModelTreeComponent
+}
+
+func newTestFinetunePublishComponent(t *testing.T) (*finetuneComponentImpl, *testFinetunePublishMocks) {
+	cfg := &config.Config{}
+	cfg.S3.Bucket = "lfs"
+	m := &testFinetunePublishMocks{
+		publicationStore: mockdb.NewMockFinetunePublicationStore(t),
+		workflowStore:    mockdb.NewMockArgoWorkFlowStore(t),
+		userSvcClient:    mockrpc.NewMockUserSvcClient(t),
+		repoStore:        mockdb.NewMockRepoStore(t),
+		gitServer:        mockgit.NewMockGitServer(t),
+		s3Client:         mocks3.NewMockClient(t),
+		repoComponent:    mockComps.NewMockRepoComponent(t),
+		modelComponent:   mockComps.NewMockModelComponent(t),
+		modelTree:        mockComps.NewMockModelTreeComponent(t),
+	}
+	c := &finetuneComponentImpl{
+		config:             cfg,
+		publicationStore:   m.publicationStore,
+		workflowStore:      m.workflowStore,
+		userSvcClient:      m.userSvcClient,
+		repoStore:          m.repoStore,
+		gitServer:          m.gitServer,
+		s3Client:           m.s3Client,
+		repoComponent:      m.repoComponent,
+		modelComponent:     m.modelComponent,
+		modelTreeComponent: m.modelTree,
+	}
+	return c, m
+}
+
+const testLfsPointer = "version https://git-lfs.github.com/spec/v1\noid sha256:4d7a214614ab2935c943f9e0ff69d22eadbb8f32b1258daaa5e2ca24d17e2393\nsize 1024\n"
+
+func TestFinetuneComponent_HandleFinetuneFinished_Publish(t *testing.T) {
+	ctx := context.TODO()
+	c, m := newTestFinetunePublishComponent(t)
+
+	wf := &database.ArgoWorkflow{
+		TaskId:    "task1",
+		TaskName:  "sft-wukong",
+		Status:    v1alpha1.WorkflowSucceeded,
+		RepoIds:   []string{"opencsg/wukong"},
+		Datasets:  []string{"opencsg/hellaswag"},
+		ResultURL: "ns/wukong-finetuned-20261018",
+	}
+	pub := &database.FinetunePublication{
+		ID:          1,
+		TaskID:      "task1",
+		Operator:    "user",
+		TargetPath:  "ns/wukong-lora",
+		Artifact:    types.FinetunePublishAdapter,
+		Checkpoints: []string{"checkpoint-200"},
+		Hyperparameters: types.FinetuneHyperparameters{
+			Epochs:       3,
+			LearningRate: 0.0001,
+			CustomArgs:   "--lora_rank 8",
+		},
+		Status: types.FinetunePublishPending,
+	}
+	m.publicationStore.EXPECT().FindByTaskID(ctx, "task1").Return(pub, nil)
+	m.publicationStore.EXPECT().Update(ctx, pub, types.FinetunePublishPending).Return(true, nil).Once()
+	m.publicationStore.EXPECT().Update(ctx, pub, types.FinetunePublishPublishing).Return(true, nil).Once()
+
+	m.repoStore.EXPECT().FindByPath(ctx, types.ModelRepo, "ns", "wukong-finetuned-20261018").Return(&database.Repository{
+		ID: 10, Path: "ns/wukong-finetuned-20261018", DefaultBranch: "output", Migrated: true,
+	}, nil)
+	targetRepo := &database.Repository{ID: 11, Path: "ns/wukong-lora", DefaultBranch: "main", Migrated: true}
+	m.repoStore.EXPECT().FindByPath(ctx, types.ModelRepo, "ns", "wukong-lora").Return(targetRepo, nil)
+	m.gitServer.EXPECT().GetRepoAllFiles(ctx, gitserver.GetRepoAllFilesReq{
+		Namespace: "ns",
+		Name:      "wukong-lora",
+		Ref:       "main",
+		RepoType:  types.ModelRepo,
+	}).Return([]*types.File{{Path: ".gitattributes"}, {Path: "README.md"}}, nil)
+	m.gitServer.EXPECT().GetRepoAllFiles(ctx, gitserver.GetRepoAllFilesReq{
+		Namespace: "ns",
+		Name:      "wukong-finetuned-20261018",
+		Ref:       "output",
+		RepoType:  types.ModelRepo,
+	}).Return([]*types.File{
+		{Path: "README.md"},
+		{Path: "adapter_config.json"},
+		{Path: "adapter_model.safetensors"},
+		{Path: "checkpoint-100/adapter_model.safetensors"},
+		{Path: "checkpoint-200/adapter_model.safetensors"},
+	}, nil)
+	for _, p := range []string{"adapter_config.json", "adapter_model.safetensors", "checkpoint-200/adapter_model.safetensors"} {
+		content := "{}"
+		if strings.HasSuffix(p, ".safetensors") {
+			content = testLfsPointer
+		}
+		m.gitServer.EXPECT().GetRepoFileRaw(ctx, gitserver.GetRepoInfoByPathReq{
+			Namespace: "ns",
+			Name:      "wukong-finetuned-20261018",
+			Ref:       "output",
+			Path:      p,
+			RepoType:  types.ModelRepo,
+		}).Return(content, nil)
+	}
+	m.s3Client.EXPECT().CopyObject(ctx, mock.MatchedBy(func(dst minio.CopyDestOptions) bool {
+		return dst.Bucket == "lfs" && strings.HasSuffix(dst.Object, "4d7a214614ab2935c943f9e0ff69d22eadbb8f32b1258daaa5e2ca24d17e2393")
+	}), mock.Anything).Return(minio.UploadInfo{}, nil).Times(2)
+	m.repoComponent.EXPECT().CommitFiles(ctx, mock.MatchedBy(func(req types.CommitFilesReq) bool {
+		if req.Namespace != "ns" || req.Name != "wukong-lora" || req.CurrentUser != "user" || len(req.Files) != 4 {
+			return false
+		}
+		readme := req.Files[3]
+		content, _ := base64.StdEncoding.DecodeString(readme.Content)
+		return req.Files[0].Action == types.CommitActionCreate &&
+			readme.Path == types.ReadmeFileName && readme.Action == types.CommitActionUpdate &&
+			strings.Contains(string(content), "base_model: opencsg/wukong") &&
+			strings.Contains(string(content), "base_model_relation: adapter") &&
+			strings.Contains(string(content), "- opencsg/hellaswag") &&
+			strings.Contains(string(content), "| epochs | 3 |")
+	})).Return(nil, nil)
+	m.repoStore.EXPECT().FindByPath(ctx, types.ModelRepo, "opencsg", "wukong").Return(&database.Repository{
+		ID: 1, Path: "opencsg/wukong",
+	}, nil)
+	m.repoStore.EXPECT().FindByPath(ctx, types.DatasetRepo, "opencsg", "hellaswag").Return(&database.Repository{
+		ID: 2, Path: "opencsg/hellaswag",
+	}, nil)
+	m.modelTree.EXPECT().ProcessModelTree(ctx, []*types.ModelNode{
+		{ID: 1, Path: "opencsg/wukong", Relation: types.RelationAdapter, Operation: types.RelationAdd},
+		{ID: 2, Path: "opencsg/hellaswag", Relation: types.RelationTrainingDataset, Operation: types.RelationAdd},
+	}, *targetRepo).Return()
+
+	err := c.HandleFinetuneFinished(ctx, wf)
+	require.Nil(t, err)
+	require.Equal(t, types.FinetunePublishPublished, pub.Status)
+	require.Equal(t, int64(11), pub.TargetRepoID)
+	require.Equal(t, 3, pub.PublishedFiles)
+}
+
+func TestFinetuneComponent_PublishFinetuneResult(t *testing.T) {
+	ctx := context.TODO()
+	c, m := newTestFinetunePublishComponent(t)
+
+	m.workflowStore.EXPECT().FindByID(ctx, int64(1)).Return(database.ArgoWorkflow{
+		ID: 1, TaskId: "task1", UserUUID: "uuid", Status: v1alpha1.WorkflowSucceeded,
+	}, nil)
+	m.userSvcClient.EXPECT().GetUserByName(ctx, "user").Return(&types.User{UUID: "uuid"}, nil)
+	m.userSvcClient.EXPECT().GetNameSpaceInfoByUUID(ctx, "uuid").Return(&rpc.Namespace{UUID: "uuid"}, nil)
+	m.repoComponent.EXPECT().CheckCurrentUserPermission(ctx, "user", "ns", membership.RoleWrite).Return(true, nil)
+	m.publicationStore.EXPECT().FindByTaskID(ctx, "task1").Return(nil, errorx.ErrDatabaseNoRows)
+	m.publicationStore.EXPECT().Create(ctx, mock.Anything).RunAndReturn(
+		func(ctx context.Context, pub *database.FinetunePublication) (*database.FinetunePublication, error) {
+			pub.ID = 1
+			return pub, nil
+		})
+	// the result is published in the background, here the publication is taken by another handler
+	published := make(chan struct{})
+	m.publicationStore.EXPECT().Update(mock.Anything, mock.Anything, types.FinetunePublishPending).
+		Run(func(ctx context.Context, pub *database.FinetunePublication, from types.FinetunePublishStatus) {
+			close(published)
+		}).Return(false, nil)
+
+	res, err := c.PublishFinetuneResult(ctx, types.FinetunePublishReq{
+		ID:          1,
+		CurrentUser: "user",
+		FinetunePublishConfig: types.FinetunePublishConfig{
+			TargetRepo: "ns/wukong-lora",
+		},
+	})
+	require.Nil(t, err)
+	require.Equal(t, types.FinetunePublishPending, res.Status)
+	require.Equal(t, types.FinetunePublishAdapter, res.Artifact)
+	<-published
+}
+
+func TestFinetuneComponent_HandleFinetuneFinished_JobFailed(t *testing.T) {
+	ctx := context.TODO()
+	c, m := newTestFinetunePublishComponent(t)
+
+	pub := &database.FinetunePublication{ID: 1, TaskID: "task1", Status: types.FinetunePublishPending}
+	m.publicationStore.EXPECT().FindByTaskID(ctx, "task1").Return(pub, nil)
+	m.publicationStore.EXPECT().Update(ctx, pub, types.FinetunePublishPending).Return(true, nil)
+
+	err := c.HandleFinetuneFinished(ctx, &database.ArgoWorkflow{TaskId: "task1", Status: v1alpha1.WorkflowFailed})
+	require.Nil(t, err)
+	require.Equal(t, types.FinetunePublishFailed, pub.Status)
+}
+
+func TestFinetuneComponent_HandleFinetuneFinished_Duplicated(t *testing.T) {
+	ctx := context.TODO()
+	c, m := newTestFinetunePublishComponent(t)
+
+	// the publication has been taken by the handler of the other finished event
+	pub := &database.FinetunePublication{ID: 1, TaskID: "task1", Status: types.FinetunePublishPending}
+	m.publicationStore.EXPECT().FindByTaskID(ctx, "task1").Return(pub, nil)
+	m.publicationStore.EXPECT().Update(ctx, pub, types.FinetunePublishPending).Return(false, nil)
+
+	err := c.HandleFinetuneFinished(ctx, &database.ArgoWorkflow{TaskId: "task1", Status: v1alpha1.WorkflowSucceeded})
+	require.Nil(t, err)
+}
+
+func TestFinetuneComponent_HandleFinetuneFinished_NoPublication(t *testing.T) {
+	ctx := context.TODO()
+	c, m := newTestFinetunePublishComponent(t)
+
+	m.publicationStore.EXPECT().FindByTaskID(ctx, "task1").Return(nil, errorx.ErrDatabaseNoRows)
+
+	err := c.HandleFinetuneFinished(ctx, &database.ArgoWorkflow{TaskId: "task1", Status: v1alpha1.WorkflowSucceeded})
+	require.Nil(t, err)
+}
+
+func TestFinetuneComponent_selectPublishFiles(t *testing.T) {
+	files := []*types.File{
+		{Path: "README.md"},
+		{Path: "config.json"},
+		{Path: "model.safetensors"},
+		{Path: "checkpoint-100/model.safetensors"},
+	}
+
+	selected, err := selectPublishFiles(files, types.FinetunePublishMerged, nil)
+	require.Nil(t, err)
+	require.Len(t, selected, 2)
+
+	_, err = selectPublishFiles(files, types.FinetunePublishAdapter, nil)
+	require.ErrorContains(t, err, types.AdapterConfigFileName)
+
+	_, err = selectPublishFiles(files, types.FinetunePublishMerged, []string{"checkpoint-300"})
+	require.ErrorContains(t, err, "checkpoint-300")
+}
+
+func TestFinetuneComponent_compareCheckpoints(t *testing.T) {
+	require.Negative(t, compareCheckpoints("checkpoint-500", "checkpoint-1000"))
+	require.Positive(t, compareCheckpoints("checkpoint-1000", "checkpoint-500"))
+	require.Zero(t, compareCheckpoints("checkpoint-final", "checkpoint-final"))
+}
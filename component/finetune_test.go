@@ -124,10 +124,12 @@ func TestEvaluationComponent_CreateFinetune(t *testing.T) {
 	modelStore.EXPECT().FindByPath(ctx, "opencsg", "wukong").Return(&database.Model{
 		Repository: &database.Repository{DefaultBranch: "main"},
 	}, nil)
+	repoComp.EXPECT().CheckGatedAccess(ctx, mock.Anything, req.Username).Return(nil)
 
 	repoStore.EXPECT().FindByPath(ctx, types.DatasetRepo, "opencsg", "hellaswag").Return(&database.Repository{
 		DefaultBranch: "main",
 	}, nil)
+	repoComp.EXPECT().CheckGatedAccess(ctx, mock.Anything, req.Username).Return(nil)
 
 	tokenStore.EXPECT().FindByUID(ctx, int64(1)).Return(&database.AccessToken{Token: "foo"}, nil)
 
@@ -200,10 +202,12 @@ func TestFinetuneComponent_CreateFinetuneJob_UsesRequestedDatasetRevision(t *tes
 	modelStore.EXPECT().FindByPath(ctx, "opencsg", "wukong").Return(&database.Model{
 		Repository: &database.Repository{DefaultBranch: "main"},
 	}, nil)
+	repoComp.EXPECT().CheckGatedAccess(ctx, mock.Anything, req.Username).Return(nil)
 
 	repoStore.EXPECT().FindByPath(ctx, types.DatasetRepo, "opencsg", "hellaswag").Return(&database.Repository{
 		DefaultBranch: "main",
 	}, nil)
+	repoComp.EXPECT().CheckGatedAccess(ctx, mock.Anything, req.Username).Return(nil)
 
 	tokenStore.EXPECT().FindByUID(ctx, int64(1)).Return(&database.AccessToken{Token: "foo"}, nil)
 
@@ -221,6 +225,60 @@ func TestFinetuneComponent_CreateFinetuneJob_UsesRequestedDatasetRevision(t *tes
 	require.Nil(t, e)
 }
 
+func TestFinetuneComponent_CreateFinetuneJob_InvalidSecret(t *testing.T) {
+	req := types.FinetuneReq{
+		Username:           "testuser",
+		TaskName:           "testtask",
+		RuntimeFrameworkId: 1,
+		ResourceId:         4,
+		ModelId:            "opencsg/wukong",
+		DatasetId:          "opencsg/hellaswag",
+		Secrets:            types.SecretValues{"1-invalid": "v"},
+	}
+
+	ctx := context.TODO()
+	cfg := &config.Config{}
+
+	mockDeployer := mockdeploy.NewMockDeployer(t)
+	mockUser := mockdb.NewMockUserStore(t)
+	modelStore := mockdb.NewMockModelStore(t)
+	spaceResStore := mockdb.NewMockSpaceResourceStore(t)
+	datasetStore := mockdb.NewMockDatasetStore(t)
+	mirrorStore := mockdb.NewMockMirrorStore(t)
+	tokenStore := mockdb.NewMockAccessTokenStore(t)
+	repoStore := mockdb.NewMockRepoStore(t)
+	frameStore := mockdb.NewMockRuntimeFrameworksStore(t)
+	argoStore := mockdb.NewMockArgoWorkFlowStore(t)
+	acctComp := mockComps.NewMockAccountingComponent(t)
+	repoComp := mockComps.NewMockRepoComponent(t)
+
+	c := NewTestFinetuneComponent(cfg, mockDeployer, mockUser, modelStore, spaceResStore, datasetStore, mirrorStore,
+		tokenStore, repoStore, frameStore, argoStore, acctComp, repoComp, nil, nil)
+
+	mockUser.EXPECT().FindByUsername(ctx, req.Username).Return(database.User{
+		Username: req.Username,
+		UUID:     req.Username,
+		ID:       1,
+		RoleMask: "admin",
+	}, nil).Once()
+	modelStore.EXPECT().FindByPath(ctx, "opencsg", "wukong").Return(&database.Model{
+		Repository: &database.Repository{DefaultBranch: "main"},
+	}, nil)
+	repoStore.EXPECT().FindByPath(ctx, types.DatasetRepo, "opencsg", "hellaswag").Return(&database.Repository{
+		DefaultBranch: "main",
+	}, nil)
+	repoComp.EXPECT().CheckGatedAccess(ctx, mock.Anything, req.Username).Return(nil)
+	tokenStore.EXPECT().FindByUID(ctx, int64(1)).Return(&database.AccessToken{Token: "foo"}, nil)
+	frameStore.EXPECT().FindEnabledByID(ctx, int64(1)).Return(&database.RuntimeFramework{ID: 1}, nil)
+	repoComp.EXPECT().GetNamespaceBillingUUID(ctx, "testuser").Return("testuser", nil)
+
+	// rejected before the job is submitted
+	e, err := c.CreateFinetuneJob(ctx, req)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "valid environment variable name")
+	require.Nil(t, e)
+}
+
 func TestFinetuneComponent_CreateFinetuneJob_NonAdminNamespace(t *testing.T) {
 	ctx := context.TODO()
 	cfg := &config.Config{}
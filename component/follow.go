@@ -0,0 +1,405 @@
+package component
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"log/slog"
+	"time"
+
+	"opencsg.com/csghub-server/builder/rpc"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/config"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+)
+
+// feedDigestBatchSize is the number of the digest subscriptions loaded at a time
+const feedDigestBatchSize = 100
+
+type FollowComponent interface {
+	Follow(ctx context.Context, req *types.FollowReq) error
+	Unfollow(ctx context.Context, req *types.FollowReq) error
+	// Following returns the users, organizations and repositories followed by the user
+	Following(ctx context.Context, username string, per, page int) ([]types.Following, int, error)
+	Followers(ctx context.Context, req *types.FollowReq, per, page int) ([]types.Follower, int, error)
+	// Stats returns the follower count of the entity and whether the current user follows it, the following
+	// count is set for the users only
+	Stats(ctx context.Context, req *types.FollowReq) (*types.FollowStats, error)
+	// Feed returns the events of the entities followed by the current user, the events of the repositories
+	// the user can not read are skipped
+	Feed(ctx context.Context, currentUser string, per, page int) ([]types.FeedEvent, int, error)
+	GetDigestSetting(ctx context.Context, currentUser string) (*types.FeedDigestSetting, error)
+	UpdateDigestSetting(ctx context.Context, req *types.UpdateFeedDigestSettingReq) error
+	// SendDigests emails the new feed events since the last digest to the users subscribed to the digest
+	SendDigests(ctx context.Context) error
+}
+
+type followComponentImpl struct {
+	config                *config.Config
+	userStore             database.UserStore
+	orgStore              database.OrgStore
+	repoStore             database.RepoStore
+	followStore           database.FollowStore
+	feedEventStore        database.FeedEventStore
+	digestStore           database.FeedDigestSubscriptionStore
+	repoComponent         RepoComponent
+	userSvcClient         rpc.UserSvcClient
+	notificationSvcClient rpc.NotificationSvcClient
+}
+
+func NewFollowComponent(config *config.Config) (FollowComponent, error) {
+	repoComponent, err := NewRepoComponentImpl(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create repo component, error: %w", err)
+	}
+	return &followComponentImpl{
+		config:         config,
+		userStore:      database.NewUserStore(),
+		orgStore:       database.NewOrgStore(),
+		repoStore:      database.NewRepoStore(),
+		followStore:    database.NewFollowStore(),
+		feedEventStore: database.NewFeedEventStore(),
+		digestStore:    database.NewFeedDigestSubscriptionStore(),
+		repoComponent:  repoComponent,
+		userSvcClient: rpc.NewUserSvcHttpClient(fmt.Sprintf("%s:%d", config.User.Host, config.User.Port),
+			rpc.AuthWithApiKey(config.APIToken)),
+		notificationSvcClient: rpc.NewNotificationSvcHttpClient(fmt.Sprintf("%s:%d", config.Notification.Host, config.Notification.Port),
+			rpc.AuthWithApiKey(config.APIToken)),
+	}, nil
+}
+
+// recordFeedEvent saves the event for the activity feeds, the failures are only logged as the feeds should
+// never block the operations producing the events
+func recordFeedEvent(ctx context.Context, store database.FeedEventStore, event *database.FeedEvent) {
+	if err := store.Create(ctx, event); err != nil {
+		slog.WarnContext(ctx, "failed to record feed event", slog.Any("event", event), slog.Any("error", err))
+	}
+}
+
+// resolveFollowable returns the id of the followed entity, the repositories need the read permission of
+// the current user
+func (c *followComponentImpl) resolveFollowable(ctx context.Context, req *types.FollowReq) (int64, error) {
+	switch req.Type {
+	case types.FollowableUser:
+		user, err := c.userStore.FindByUsername(ctx, req.Name)
+		if err != nil {
+			return 0, fmt.Errorf("failed to find user %s, error: %w", req.Name, err)
+		}
+		return user.ID, nil
+	case types.FollowableOrganization:
+		org, err := c.orgStore.FindByPath(ctx, req.Name)
+		if err != nil {
+			return 0, fmt.Errorf("failed to find organization %s, error: %w", req.Name, err)
+		}
+		return org.ID, nil
+	case types.FollowableRepository:
+		repo, err := c.repoStore.FindByPath(ctx, req.RepoType, req.Namespace, req.Name)
+		if err != nil {
+			return 0, fmt.Errorf("failed to find repo %s/%s/%s, error: %w", req.RepoType, req.Namespace, req.Name, err)
+		}
+		allow, err := c.repoComponent.AllowReadAccessRepo(ctx, repo, req.CurrentUser)
+		if err != nil {
+			return 0, fmt.Errorf("failed to check repo permission, error: %w", err)
+		}
+		if !allow {
+			return 0, errorx.ErrForbiddenMsg("users do not have permission to read this repo")
+		}
+		return repo.ID, nil
+	default:
+		return 0, errorx.ReqParamInvalid(fmt.Errorf("unknown followable type %s", req.Type), errorx.Ctx().Set("param", "type"))
+	}
+}
+
+func (c *followComponentImpl) Follow(ctx context.Context, req *types.FollowReq) error {
+	user, err := c.userStore.FindByUsername(ctx, req.CurrentUser)
+	if err != nil {
+		return errorx.ErrUserNotFound
+	}
+	if req.Type == types.FollowableUser && req.Name == user.Username {
+		return errorx.ReqParamInvalid(errors.New("users can not follow themselves"), errorx.Ctx().Set("param", "name"))
+	}
+	id, err := c.resolveFollowable(ctx, req)
+	if err != nil {
+		return err
+	}
+	if err := c.followStore.Add(ctx, user.ID, string(req.Type), id); err != nil {
+		return fmt.Errorf("failed to follow %s %s, error: %w", req.Type, req.Name, err)
+	}
+	return nil
+}
+
+func (c *followComponentImpl) Unfollow(ctx context.Context, req *types.FollowReq) error {
+	user, err := c.userStore.FindByUsername(ctx, req.CurrentUser)
+	if err != nil {
+		return errorx.ErrUserNotFound
+	}
+	id, err := c.resolveFollowable(ctx, req)
+	if err != nil {
+		return err
+	}
+	if err := c.followStore.Delete(ctx, user.ID, string(req.Type), id); err != nil {
+		return fmt.Errorf("failed to unfollow %s %s, error: %w", req.Type, req.Name, err)
+	}
+	return nil
+}
+
+func (c *followComponentImpl) Following(ctx context.Context, username string, per, page int) ([]types.Following, int, error) {
+	user, err := c.userStore.FindByUsername(ctx, username)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find user %s, error: %w", username, err)
+	}
+	targets, total, err := c.followStore.ListFollowing(ctx, user.ID, per, page)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list following of user %s, error: %w", username, err)
+	}
+	followings := make([]types.Following, 0, len(targets))
+	for _, target := range targets {
+		followings = append(followings, types.Following{
+			Type:      types.FollowableType(target.FollowableType),
+			Path:      target.Path,
+			Nickname:  target.Nickname,
+			Avatar:    target.Avatar,
+			RepoType:  types.RepositoryType(target.RepoType),
+			CreatedAt: target.CreatedAt,
+		})
+	}
+	return followings, total, nil
+}
+
+func (c *followComponentImpl) Followers(ctx context.Context, req *types.FollowReq, per, page int) ([]types.Follower, int, error) {
+	id, err := c.resolveFollowable(ctx, req)
+	if err != nil {
+		return nil, 0, err
+	}
+	follows, total, err := c.followStore.ListFollowers(ctx, string(req.Type), id, per, page)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list followers of %s %s, error: %w", req.Type, req.Name, err)
+	}
+	followers := make([]types.Follower, 0, len(follows))
+	for _, follow := range follows {
+		if follow.User == nil {
+			continue
+		}
+		followers = append(followers, types.Follower{
+			Username:  follow.User.Username,
+			Nickname:  follow.User.NickName,
+			Avatar:    follow.User.Avatar,
+			CreatedAt: follow.CreatedAt,
+		})
+	}
+	return followers, total, nil
+}
+
+func (c *followComponentImpl) Stats(ctx context.Context, req *types.FollowReq) (*types.FollowStats, error) {
+	id, err := c.resolveFollowable(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	var stats types.FollowStats
+	stats.Followers, err = c.followStore.CountFollowers(ctx, string(req.Type), id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count followers, error: %w", err)
+	}
+	if req.Type == types.FollowableUser {
+		stats.Following, err = c.followStore.CountFollowing(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count following, error: %w", err)
+		}
+	}
+	if req.CurrentUser != "" {
+		user, err := c.userStore.FindByUsername(ctx, req.CurrentUser)
+		if err != nil {
+			return nil, errorx.ErrUserNotFound
+		}
+		stats.IsFollowing, err = c.followStore.IsFollowing(ctx, user.ID, string(req.Type), id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check following, error: %w", err)
+		}
+	}
+	return &stats, nil
+}
+
+// feedQuery builds the feed query of the user with the namespaces whose private repositories are visible
+// to the user
+func (c *followComponentImpl) feedQuery(ctx context.Context, username string, userID int64) (database.FeedQuery, error) {
+	user, err := c.userSvcClient.GetUserInfo(ctx, username, username)
+	if err != nil {
+		return database.FeedQuery{}, fmt.Errorf("failed to get user info, error: %w", err)
+	}
+	namespaces, isAdmin := buildAccessibleNamespaces(user)
+	return database.FeedQuery{
+		UserID:     userID,
+		Namespaces: namespaces,
+		IsAdmin:    isAdmin,
+	}, nil
+}
+
+func (c *followComponentImpl) Feed(ctx context.Context, currentUser string, per, page int) ([]types.FeedEvent, int, error) {
+	user, err := c.userStore.FindByUsername(ctx, currentUser)
+	if err != nil {
+		return nil, 0, errorx.ErrUserNotFound
+	}
+	query, err := c.feedQuery(ctx, currentUser, user.ID)
+	if err != nil {
+		return nil, 0, err
+	}
+	query.Per, query.Page = per, page
+	events, total, err := c.feedEventStore.Feed(ctx, query)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get feed of user %s, error: %w", currentUser, err)
+	}
+	return toFeedEvents(events), total, nil
+}
+
+func toFeedEvents(events []database.FeedEvent) []types.FeedEvent {
+	result := make([]types.FeedEvent, 0, len(events))
+	for _, event := range events {
+		item := types.FeedEvent{
+			ID:        event.ID,
+			Type:      types.FeedEventType(event.EventType),
+			Payload:   event.Payload,
+			CreatedAt: event.CreatedAt,
+		}
+		if event.Actor != nil {
+			item.Actor = event.Actor.Username
+		}
+		if event.Repository != nil {
+			item.RepoType = event.Repository.RepositoryType
+			item.RepoPath = event.Repository.Path
+		}
+		result = append(result, item)
+	}
+	return result
+}
+
+func (c *followComponentImpl) GetDigestSetting(ctx context.Context, currentUser string) (*types.FeedDigestSetting, error) {
+	user, err := c.userStore.FindByUsername(ctx, currentUser)
+	if err != nil {
+		return nil, errorx.ErrUserNotFound
+	}
+	sub, err := c.digestStore.Get(ctx, user.ID)
+	if errors.Is(err, errorx.ErrDatabaseNoRows) {
+		return &types.FeedDigestSetting{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get feed digest setting, error: %w", err)
+	}
+	setting := &types.FeedDigestSetting{Enabled: sub.Enabled}
+	if !sub.LastSentAt.IsZero() {
+		setting.LastSentAt = &sub.LastSentAt
+	}
+	return setting, nil
+}
+
+func (c *followComponentImpl) UpdateDigestSetting(ctx context.Context, req *types.UpdateFeedDigestSettingReq) error {
+	user, err := c.userStore.FindByUsername(ctx, req.CurrentUser)
+	if err != nil {
+		return errorx.ErrUserNotFound
+	}
+	if err := c.digestStore.SetEnabled(ctx, user.ID, req.Enabled); err != nil {
+		return fmt.Errorf("failed to update feed digest setting, error: %w", err)
+	}
+	return nil
+}
+
+func (c *followComponentImpl) SendDigests(ctx context.Context) error {
+	var afterUserID int64
+	for {
+		subs, err := c.digestStore.ListEnabled(ctx, afterUserID, feedDigestBatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to list feed digest subscriptions, error: %w", err)
+		}
+		for _, sub := range subs {
+			if err := c.sendDigest(ctx, &sub); err != nil {
+				slog.ErrorContext(ctx, "failed to send feed digest", slog.Int64("user_id", sub.UserID), slog.Any("error", err))
+			}
+		}
+		if len(subs) < feedDigestBatchSize {
+			return nil
+		}
+		afterUserID = subs[len(subs)-1].UserID
+	}
+}
+
+// sendDigest sends the events since the last digest, at most FeedDigestLookback ago, nothing is sent if
+// there is no new event
+func (c *followComponentImpl) sendDigest(ctx context.Context, sub *database.FeedDigestSubscription) error {
+	if sub.User == nil {
+		return nil
+	}
+	now := time.Now()
+	since := now.Add(-types.FeedDigestLookback)
+	if sub.LastSentAt.After(since) {
+		since = sub.LastSentAt
+	}
+	query, err := c.feedQuery(ctx, sub.User.Username, sub.UserID)
+	if err != nil {
+		return err
+	}
+	query.Since = since
+	query.Per, query.Page = types.MaxFeedDigestEvents, 1
+	events, total, err := c.feedEventStore.Feed(ctx, query)
+	if err != nil {
+		return fmt.Errorf("failed to get feed of user %s, error: %w", sub.User.Username, err)
+	}
+	if len(events) == 0 {
+		return nil
+	}
+
+	items := make([]map[string]any, 0, len(events))
+	for _, event := range toFeedEvents(events) {
+		item := map[string]any{
+			"type":      string(event.Type),
+			"actor":     html.EscapeString(event.Actor),
+			"repo_type": string(event.RepoType),
+			"repo_path": html.EscapeString(event.RepoPath),
+		}
+		for key, value := range event.Payload {
+			if s, ok := value.(string); ok {
+				value = html.EscapeString(s)
+			}
+			item[key] = value
+		}
+		items = append(items, item)
+	}
+	msg := types.NotificationMessage{
+		UserUUIDs:        []string{sub.User.UUID},
+		NotificationType: types.NotificationSystem,
+		ClickActionURL:   "/feed",
+		Template:         string(types.MessageScenarioFeedDigest),
+		Payload: map[string]any{
+			"total":  total,
+			"events": items,
+		},
+	}
+	msgBytes, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message, err: %w", err)
+	}
+	notificationMsg := types.MessageRequest{
+		Scenario:   types.MessageScenarioFeedDigest,
+		Parameters: string(msgBytes),
+		Priority:   types.MessagePriorityNormal,
+	}
+
+	var sendErr error
+	retryCount := c.config.Notification.NotificationRetryCount
+	for i := range retryCount {
+		if sendErr = c.notificationSvcClient.Send(ctx, &notificationMsg); sendErr == nil {
+			break
+		}
+		if i < retryCount-1 {
+			slog.Warn("failed to send notification, retrying", "notification_msg", notificationMsg, "attempt", i+1, "error", sendErr.Error())
+		}
+	}
+	if sendErr != nil {
+		return fmt.Errorf("failed to send notification after %d attempts, err: %w", retryCount, sendErr)
+	}
+	if err := c.digestStore.UpdateLastSentAt(ctx, sub.UserID, now); err != nil {
+		return fmt.Errorf("failed to update feed digest sent time, error: %w", err)
+	}
+	return nil
+}
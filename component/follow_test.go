@@ -0,0 +1,237 @@
+package component
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	mockrpc "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/builder/rpc"
+	mockdb "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/builder/store/database"
+	mockcomponent "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/component"
+	"opencsg.com/csghub-server/builder/rpc"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/config"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+)
+
+type followTester struct {
+	*followComponentImpl
+	userStore             *mockdb.MockUserStore
+	orgStore              *mockdb.MockOrgStore
+	repoStore             *mockdb.MockRepoStore
+	followStore           *mockdb.MockFollowStore
+	feedEventStore        *mockdb.MockFeedEventStore
+	digestStore           *mockdb.MockFeedDigestSubscriptionStore
+	repoComponent         *mockcomponent.MockRepoComponent
+	userSvcClient         *mockrpc.MockUserSvcClient
+	notificationSvcClient *mockrpc.MockNotificationSvcClient
+}
+
+func newFollowTester(t *testing.T) *followTester {
+	tester := &followTester{
+		userStore:             mockdb.NewMockUserStore(t),
+		orgStore:              mockdb.NewMockOrgStore(t),
+		repoStore:             mockdb.NewMockRepoStore(t),
+		followStore:           mockdb.NewMockFollowStore(t),
+		feedEventStore:        mockdb.NewMockFeedEventStore(t),
+		digestStore:           mockdb.NewMockFeedDigestSubscriptionStore(t),
+		repoComponent:         mockcomponent.NewMockRepoComponent(t),
+		userSvcClient:         mockrpc.NewMockUserSvcClient(t),
+		notificationSvcClient: mockrpc.NewMockNotificationSvcClient(t),
+	}
+	cfg := &config.Config{}
+	cfg.Notification.NotificationRetryCount = 2
+	tester.followComponentImpl = &followComponentImpl{
+		config:                cfg,
+		userStore:             tester.userStore,
+		orgStore:              tester.orgStore,
+		repoStore:             tester.repoStore,
+		followStore:           tester.followStore,
+		feedEventStore:        tester.feedEventStore,
+		digestStore:           tester.digestStore,
+		repoComponent:         tester.repoComponent,
+		userSvcClient:         tester.userSvcClient,
+		notificationSvcClient: tester.notificationSvcClient,
+	}
+	return tester
+}
+
+func TestFollowComponent_Follow(t *testing.T) {
+	ctx := context.TODO()
+
+	t.Run("repository", func(t *testing.T) {
+		tester := newFollowTester(t)
+		repo := &database.Repository{ID: 3, Path: "ns/n"}
+		tester.userStore.EXPECT().FindByUsername(ctx, "u").Return(database.User{ID: 1, Username: "u"}, nil)
+		tester.repoStore.EXPECT().FindByPath(ctx, types.ModelRepo, "ns", "n").Return(repo, nil)
+		tester.repoComponent.EXPECT().AllowReadAccessRepo(ctx, repo, "u").Return(true, nil)
+		tester.followStore.EXPECT().Add(ctx, int64(1), "repository", int64(3)).Return(nil)
+
+		err := tester.Follow(ctx, &types.FollowReq{
+			CurrentUser: "u", Type: types.FollowableRepository, RepoType: types.ModelRepo, Namespace: "ns", Name: "n",
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("private repository", func(t *testing.T) {
+		tester := newFollowTester(t)
+		repo := &database.Repository{ID: 3, Path: "ns/n", Private: true}
+		tester.userStore.EXPECT().FindByUsername(ctx, "u").Return(database.User{ID: 1, Username: "u"}, nil)
+		tester.repoStore.EXPECT().FindByPath(ctx, types.ModelRepo, "ns", "n").Return(repo, nil)
+		tester.repoComponent.EXPECT().AllowReadAccessRepo(ctx, repo, "u").Return(false, nil)
+
+		err := tester.Follow(ctx, &types.FollowReq{
+			CurrentUser: "u", Type: types.FollowableRepository, RepoType: types.ModelRepo, Namespace: "ns", Name: "n",
+		})
+		require.ErrorIs(t, err, errorx.ErrForbidden)
+	})
+
+	t.Run("organization", func(t *testing.T) {
+		tester := newFollowTester(t)
+		tester.userStore.EXPECT().FindByUsername(ctx, "u").Return(database.User{ID: 1, Username: "u"}, nil)
+		tester.orgStore.EXPECT().FindByPath(ctx, "org").Return(database.Organization{ID: 5}, nil)
+		tester.followStore.EXPECT().Add(ctx, int64(1), "organization", int64(5)).Return(nil)
+
+		err := tester.Follow(ctx, &types.FollowReq{CurrentUser: "u", Type: types.FollowableOrganization, Name: "org"})
+		require.NoError(t, err)
+	})
+
+	t.Run("themselves", func(t *testing.T) {
+		tester := newFollowTester(t)
+		tester.userStore.EXPECT().FindByUsername(ctx, "u").Return(database.User{ID: 1, Username: "u"}, nil)
+
+		err := tester.Follow(ctx, &types.FollowReq{CurrentUser: "u", Type: types.FollowableUser, Name: "u"})
+		require.ErrorIs(t, err, errorx.ErrReqParamInvalid)
+	})
+}
+
+func TestFollowComponent_Unfollow(t *testing.T) {
+	ctx := context.TODO()
+	tester := newFollowTester(t)
+	tester.userStore.EXPECT().FindByUsername(ctx, "u").Return(database.User{ID: 1, Username: "u"}, nil)
+	tester.userStore.EXPECT().FindByUsername(ctx, "bob").Return(database.User{ID: 2, Username: "bob"}, nil)
+	tester.followStore.EXPECT().Delete(ctx, int64(1), "user", int64(2)).Return(nil)
+
+	err := tester.Unfollow(ctx, &types.FollowReq{CurrentUser: "u", Type: types.FollowableUser, Name: "bob"})
+	require.NoError(t, err)
+}
+
+func TestFollowComponent_Following(t *testing.T) {
+	ctx := context.TODO()
+	tester := newFollowTester(t)
+	createdAt := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	tester.userStore.EXPECT().FindByUsername(ctx, "u").Return(database.User{ID: 1, Username: "u"}, nil)
+	tester.followStore.EXPECT().ListFollowing(ctx, int64(1), 10, 1).Return([]database.FollowTarget{
+		{FollowableType: "repository", FollowableID: 3, Path: "ns/n", Nickname: "N", RepoType: "model", CreatedAt: createdAt},
+		{FollowableType: "user", FollowableID: 2, Path: "bob", Avatar: "a.png", CreatedAt: createdAt},
+	}, 2, nil)
+
+	followings, total, err := tester.Following(ctx, "u", 10, 1)
+	require.NoError(t, err)
+	require.Equal(t, 2, total)
+	require.Equal(t, []types.Following{
+		{Type: types.FollowableRepository, Path: "ns/n", Nickname: "N", RepoType: types.ModelRepo, CreatedAt: createdAt},
+		{Type: types.FollowableUser, Path: "bob", Avatar: "a.png", CreatedAt: createdAt},
+	}, followings)
+}
+
+func TestFollowComponent_Stats(t *testing.T) {
+	ctx := context.TODO()
+	tester := newFollowTester(t)
+	tester.userStore.EXPECT().FindByUsername(ctx, "bob").Return(database.User{ID: 2, Username: "bob"}, nil)
+	tester.userStore.EXPECT().FindByUsername(ctx, "u").Return(database.User{ID: 1, Username: "u"}, nil)
+	tester.followStore.EXPECT().CountFollowers(ctx, "user", int64(2)).Return(5, nil)
+	tester.followStore.EXPECT().CountFollowing(ctx, int64(2)).Return(3, nil)
+	tester.followStore.EXPECT().IsFollowing(ctx, int64(1), "user", int64(2)).Return(true, nil)
+
+	stats, err := tester.Stats(ctx, &types.FollowReq{CurrentUser: "u", Type: types.FollowableUser, Name: "bob"})
+	require.NoError(t, err)
+	require.Equal(t, &types.FollowStats{Followers: 5, Following: 3, IsFollowing: true}, stats)
+}
+
+func TestFollowComponent_Feed(t *testing.T) {
+	ctx := context.TODO()
+	tester := newFollowTester(t)
+	createdAt := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	tester.userStore.EXPECT().FindByUsername(ctx, "u").Return(database.User{ID: 1, Username: "u"}, nil)
+	tester.userSvcClient.EXPECT().GetUserInfo(ctx, "u", "u").Return(&rpc.User{
+		Username: "u",
+		Orgs:     []rpc.Organization{{Name: "org"}},
+	}, nil)
+	tester.feedEventStore.EXPECT().Feed(ctx, database.FeedQuery{
+		UserID: 1, Namespaces: []string{"u", "org"}, Per: 10, Page: 1,
+	}).Return([]database.FeedEvent{
+		{
+			ID: 7, EventType: "discussion_created", Actor: &database.User{Username: "bob"},
+			Repository: &database.Repository{Path: "org/n", RepositoryType: types.DatasetRepo},
+			Payload:    map[string]any{"title": "t"}, CreatedAt: createdAt,
+		},
+	}, 1, nil)
+
+	events, total, err := tester.Feed(ctx, "u", 10, 1)
+	require.NoError(t, err)
+	require.Equal(t, 1, total)
+	require.Equal(t, []types.FeedEvent{
+		{
+			ID: 7, Type: types.FeedEventDiscussionCreated, Actor: "bob", RepoType: types.DatasetRepo, RepoPath: "org/n",
+			Payload: map[string]any{"title": "t"}, CreatedAt: createdAt,
+		},
+	}, events)
+}
+
+func TestFollowComponent_GetDigestSetting(t *testing.T) {
+	ctx := context.TODO()
+	tester := newFollowTester(t)
+	tester.userStore.EXPECT().FindByUsername(ctx, "u").Return(database.User{ID: 1, Username: "u"}, nil)
+	tester.digestStore.EXPECT().Get(ctx, int64(1)).Return(nil, errorx.ErrDatabaseNoRows)
+
+	setting, err := tester.GetDigestSetting(ctx, "u")
+	require.NoError(t, err)
+	require.Equal(t, &types.FeedDigestSetting{}, setting)
+}
+
+func TestFollowComponent_SendDigests(t *testing.T) {
+	ctx := context.TODO()
+	tester := newFollowTester(t)
+	lastSentAt := time.Now().Add(-time.Hour)
+	tester.digestStore.EXPECT().ListEnabled(ctx, int64(0), feedDigestBatchSize).Return([]database.FeedDigestSubscription{
+		{UserID: 1, User: &database.User{Username: "u", UUID: "uuid-u"}, LastSentAt: lastSentAt},
+		{UserID: 2, User: &database.User{Username: "v", UUID: "uuid-v"}},
+	}, nil)
+	tester.userSvcClient.EXPECT().GetUserInfo(ctx, "u", "u").Return(&rpc.User{Username: "u"}, nil)
+	tester.userSvcClient.EXPECT().GetUserInfo(ctx, "v", "v").Return(&rpc.User{Username: "v"}, nil)
+	tester.feedEventStore.EXPECT().Feed(ctx, mock.MatchedBy(func(q database.FeedQuery) bool {
+		return q.UserID == 1 && q.Since.Equal(lastSentAt) && q.Per == types.MaxFeedDigestEvents
+	})).Return([]database.FeedEvent{
+		{
+			EventType: "discussion_created", Actor: &database.User{Username: "bob"},
+			Repository: &database.Repository{Path: "ns/n", RepositoryType: types.ModelRepo},
+			Payload:    map[string]any{"title": "<b>t</b>"},
+		},
+	}, 1, nil)
+	// no new event for the user v, nothing is sent
+	tester.feedEventStore.EXPECT().Feed(ctx, mock.MatchedBy(func(q database.FeedQuery) bool {
+		return q.UserID == 2 && time.Since(q.Since) >= types.FeedDigestLookback
+	})).Return(nil, 0, nil)
+	tester.notificationSvcClient.EXPECT().Send(ctx, mock.Anything).RunAndReturn(func(_ context.Context, req *types.MessageRequest) error {
+		require.Equal(t, types.MessageScenarioFeedDigest, req.Scenario)
+		var msg types.NotificationMessage
+		require.NoError(t, json.Unmarshal([]byte(req.Parameters), &msg))
+		require.Equal(t, []string{"uuid-u"}, msg.UserUUIDs)
+		require.Equal(t, float64(1), msg.Payload["total"])
+		events := msg.Payload["events"].([]any)
+		require.Len(t, events, 1)
+		require.Equal(t, map[string]any{
+			"type": "discussion_created", "actor": "bob", "repo_type": "model", "repo_path": "ns/n", "title": "&lt;b&gt;t&lt;/b&gt;",
+		}, events[0])
+		return nil
+	}).Once()
+	tester.digestStore.EXPECT().UpdateLastSentAt(ctx, int64(1), mock.Anything).Return(nil)
+
+	require.NoError(t, tester.SendDigests(ctx))
+}
@@ -42,6 +42,7 @@ type gitHTTPComponentImpl struct {
 	repoComponent      RepoComponent
 	mirrorStore        database.MirrorStore
 	xnetClient         rpc.XnetSvcClient
+	storageQuota       *storageQuotaChecker
 }
 
 type GitHTTPComponent interface {
@@ -172,6 +173,11 @@ func (c *gitHTTPComponentImpl) GitReceivePack(ctx context.Context, req types.Git
 	if !allowed {
 		return errorx.ErrForbidden
 	}
+	// chunked pushes have no content length, they are only rejected when the namespace is already over quota
+	err = c.storageQuota.CheckQuota(ctx, req.Namespace, req.ContentLength)
+	if err != nil {
+		return err
+	}
 	err = c.gitServer.ReceivePack(ctx, gitserver.ReceivePackReq{
 		Namespace:   req.Namespace,
 		Name:        req.Name,
@@ -260,6 +266,27 @@ func (c *gitHTTPComponentImpl) lfsBatchUploadInfo(ctx context.Context, req types
 		exists[f.Oid] = &f
 	}
 
+	var incomingBytes int64
+	for _, obj := range req.Objects {
+		if _, ok := exists[obj.Oid]; !ok && obj.Valid() {
+			incomingBytes += obj.Size
+		}
+	}
+	if incomingBytes > 0 {
+		err = c.storageQuota.CheckQuota(ctx, req.Namespace, incomingBytes)
+		if errors.Is(err, errorx.ErrGitStorageQuotaExceeded) {
+			return nil, &errorx.HTTPError{
+				StatusCode: http.StatusInsufficientStorage,
+				Message: types.BatchError{
+					Message: fmt.Sprintf("Uploading %d bytes exceeds the storage quota of namespace %s.", incomingBytes, req.Namespace),
+				},
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	useMultipart := slices.Contains(req.Transfers, "multipart")
 	useXnet := false
 	transfer = "basic"
@@ -390,6 +417,15 @@ func (c *gitHTTPComponentImpl) LFSBatch(ctx context.Context, req types.BatchRequ
 	if err != nil {
 		return nil, err
 	}
+	if req.Operation == types.LFSBatchDownload && repo.Gated {
+		err = c.repoComponent.CheckGatedAccess(ctx, repo, req.CurrentUser)
+		if err != nil {
+			if errors.Is(err, errorx.ErrUserNotFound) {
+				return nil, errorx.ErrUnauthorized
+			}
+			return nil, err
+		}
+	}
 
 	var resp *types.BatchResponse
 	switch req.Operation {
@@ -730,6 +766,12 @@ func (c *gitHTTPComponentImpl) LfsDownload(ctx context.Context, req types.Downlo
 	if !allowed {
 		return nil, errors.New("you have no permission to access this repo")
 	}
+	if repo.Gated {
+		err = c.repoComponent.CheckGatedAccess(ctx, repo, req.CurrentUser)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	_, err = c.lfsMetaObjectStore.FindByOID(ctx, repo.ID, pointer.Oid)
 	if err != nil {
@@ -37,6 +37,7 @@ func NewGitHTTPComponentImpl(config *config.Config) (GitHTTPComponent, error) {
 	c.lfsLockStore = database.NewLfsLockStore()
 	c.userStore = database.NewUserStore()
 	c.mirrorStore = database.NewMirrorStore()
+	c.storageQuota = newStorageQuotaChecker(config, database.NewNamespaceStorageQuotaStore(), database.NewNamespaceStore())
 	c.repoComponent, err = NewRepoComponentImpl(config)
 	if err != nil {
 		return nil, err
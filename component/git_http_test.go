@@ -732,6 +732,25 @@ func TestGitHTTPComponent_LfsDownload(t *testing.T) {
 
 }
 
+func TestGitHTTPComponent_LfsDownloadGated(t *testing.T) {
+	ctx := context.TODO()
+	gc := initializeTestGitHTTPComponent(ctx, t)
+
+	repo := &database.Repository{ID: 123, Gated: true}
+	gc.mocks.stores.RepoMock().EXPECT().FindByPath(ctx, types.ModelRepo, "ns", "n").Return(repo, nil)
+	gc.mocks.components.repo.EXPECT().AllowReadAccess(ctx, types.ModelRepo, "ns", "n", "user").Return(true, nil)
+	gc.mocks.components.repo.EXPECT().CheckGatedAccess(ctx, repo, "user").Return(errorx.ErrForbidden)
+
+	_, err := gc.LfsDownload(ctx, types.DownloadRequest{
+		Oid:         "a3f8e1b4f77bb24e508906c6972f81928f0d926e6daef1b29d12e348b8a3547e",
+		Namespace:   "ns",
+		Name:        "n",
+		RepoType:    types.ModelRepo,
+		CurrentUser: "user",
+	})
+	require.ErrorIs(t, err, errorx.ErrForbidden)
+}
+
 func TestGitHTTPComponent_CompleteMultipartUpload(t *testing.T) {
 	ctx := context.TODO()
 	gc := initializeTestGitHTTPComponent(ctx, t)
@@ -821,3 +840,62 @@ func TestGitHTTPComponent_CompleteMultipartUpload_GenericError(t *testing.T) {
 	require.Contains(t, err.Error(), "complete multipart upload failed")
 	require.Contains(t, err.Error(), "network timeout")
 }
+
+func TestGitHTTPComponent_BatchStorageQuotaExceeded(t *testing.T) {
+	ctx := context.TODO()
+	gc := initializeTestGitHTTPComponent(ctx, t)
+	oid := "3c7ce6cd03018d584e3f52543d1263aeec16945b071fc8d7bceccd6e658b120a"
+	gc.config.StorageQuota.Enable = true
+	gc.config.StorageQuota.DefaultUserQuota = 1000
+
+	gc.mocks.stores.RepoMock().EXPECT().FindByPath(ctx, types.ModelRepo, "ns", "n").Return(&database.Repository{ID: 123}, nil)
+	gc.mocks.components.repo.EXPECT().AllowWriteAccess(ctx, types.ModelRepo, "ns", "n", "user").Return(true, nil)
+	gc.mocks.stores.LfsMetaObjectMock().EXPECT().FindByRepoID(ctx, int64(123)).Return([]database.LfsMetaObject{}, nil)
+	gc.mocks.stores.NamespaceMock().EXPECT().FindByPath(ctx, "ns").Return(database.Namespace{
+		Path: "ns", NamespaceType: database.UserNamespace,
+	}, nil)
+	gc.mocks.stores.NamespaceStorageQuotaMock().EXPECT().FindByNamespace(ctx, "ns").Return(&database.NamespaceStorageQuota{
+		Namespace: "ns", GitBytes: 100, LfsBytes: 800,
+	}, nil)
+	gc.mocks.stores.NamespaceStorageQuotaMock().EXPECT().CalculateUsage(ctx, "ns").Return(int64(100), int64(800), nil)
+	gc.mocks.stores.NamespaceStorageQuotaMock().EXPECT().UpdateUsage(ctx, mock.Anything).Return(nil)
+
+	_, err := gc.LFSBatch(ctx, types.BatchRequest{
+		Operation:   types.LFSBatchUpload,
+		Namespace:   "ns",
+		Name:        "n",
+		RepoType:    types.ModelRepo,
+		CurrentUser: "user",
+		Objects:     []types.Pointer{{Oid: oid, Size: 200}},
+	})
+	httpErr := &errorx.HTTPError{}
+	require.ErrorAs(t, err, &httpErr)
+	require.Equal(t, http.StatusInsufficientStorage, httpErr.StatusCode)
+}
+
+func TestGitHTTPComponent_GitReceivePackStorageQuotaExceeded(t *testing.T) {
+	ctx := context.TODO()
+	gc := initializeTestGitHTTPComponent(ctx, t)
+	overridden := int64(1000)
+	gc.config.StorageQuota.Enable = true
+
+	gc.mocks.stores.RepoMock().EXPECT().FindByPath(ctx, types.ModelRepo, "ns", "n").Return(&database.Repository{}, nil)
+	gc.mocks.components.repo.EXPECT().AllowWriteAccess(ctx, types.ModelRepo, "ns", "n", "user").Return(true, nil)
+	gc.mocks.stores.UserMock().EXPECT().FindByUsername(ctx, "user").Return(database.User{}, nil)
+	gc.mocks.stores.NamespaceMock().EXPECT().FindByPath(ctx, "ns").Return(database.Namespace{
+		Path: "ns", NamespaceType: database.OrgNamespace,
+	}, nil)
+	gc.mocks.stores.NamespaceStorageQuotaMock().EXPECT().FindByNamespace(ctx, "ns").Return(&database.NamespaceStorageQuota{
+		Namespace: "ns", QuotaBytes: &overridden, GitBytes: 1001,
+	}, nil)
+	gc.mocks.stores.NamespaceStorageQuotaMock().EXPECT().CalculateUsage(ctx, "ns").Return(int64(1001), int64(0), nil)
+	gc.mocks.stores.NamespaceStorageQuotaMock().EXPECT().UpdateUsage(ctx, mock.Anything).Return(nil)
+
+	err := gc.GitReceivePack(ctx, types.GitUploadPackReq{
+		Namespace:   "ns",
+		Name:        "n",
+		RepoType:    types.ModelRepo,
+		CurrentUser: "user",
+	})
+	require.ErrorIs(t, err, errorx.ErrGitStorageQuotaExceeded)
+}
@@ -8,7 +8,7 @@ import (
 	"opencsg.com/csghub-server/common/types"
 )
 
-// ErrInvalidPath reports an invalid GitLab import path.
+// ErrInvalidPath reports an invalid import path.
 var ErrInvalidPath = errors.New("invalid path")
 
 // ErrRepoAlreadyExists reports an import target repository conflict.
@@ -16,9 +16,13 @@ var ErrRepoAlreadyExists = errors.New("repository already exists")
 
 // ImportComponent defines repository import operations shared by all editions.
 type ImportComponent interface {
+	// Import creates the local repositories and queues their transfer from the remote platform
 	Import(ctx context.Context, req types.ImportReq) error
 	GetGitlabRepos(ctx context.Context, req *types.GetGitlabReposReq) ([]types.RemoteRepository, error)
-	ImportStatus(ctx context.Context, req types.ImportStatusReq) ([]types.ImportedRepository, error)
+	// GetRemoteRepos lists the repositories of the access token owner on the remote platform
+	GetRemoteRepos(ctx context.Context, req *types.GetRemoteReposReq) ([]types.RemoteRepository, error)
+	// ImportStatus returns the page of the repositories imported by the user and the total count
+	ImportStatus(ctx context.Context, req types.ImportStatusReq) ([]types.ImportedRepository, int, error)
 }
 
 // NewImportComponentImpl keeps the legacy import component constructor available across editions.
@@ -4,34 +4,290 @@ package component
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
 
 	"opencsg.com/csghub-server/builder/importer"
 	"opencsg.com/csghub-server/builder/store/database"
 	"opencsg.com/csghub-server/common/config"
+	"opencsg.com/csghub-server/common/errorx"
 	"opencsg.com/csghub-server/common/types"
 )
 
 type importComponentImpl struct {
-	repoStore         database.RepoStore
-	userStore         database.UserStore
-	importer          importer.Importer
+	repoStore             database.RepoStore
+	userStore             database.UserStore
+	tagStore              database.TagStore
+	repositoryImportStore database.RepositoryImportStore
+	mirrorComponent       MirrorComponent
+	// newImporter returns the importer of the remote platform
+	newImporter       func(provider types.ImportProvider) (importer.Importer, error)
 	mirrorSourceStore database.MirrorSourceStore
 }
 
-// NewImportComponent returns the CE import component stub.
+// NewImportComponent returns the component importing repositories from GitLab, GitHub, Gitea and ModelScope,
+// the repositories are transferred by the mirror tasks
 func NewImportComponent(config *config.Config) (ImportComponent, error) {
-	c := &importComponentImpl{}
+	mirrorComponent, err := NewMirrorComponent(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create mirror component, error: %w", err)
+	}
+	c := &importComponentImpl{
+		repoStore:             database.NewRepoStore(),
+		userStore:             database.NewUserStore(),
+		tagStore:              database.NewTagStore(),
+		repositoryImportStore: database.NewRepositoryImportStore(),
+		mirrorComponent:       mirrorComponent,
+		newImporter:           importer.New,
+		mirrorSourceStore:     database.NewMirrorSourceStore(),
+	}
 	return c, nil
 }
 
 func (c *importComponentImpl) Import(ctx context.Context, req types.ImportReq) error {
+	if req.Provider == "" {
+		req.Provider = types.ImportProviderGitLab
+	}
+	imp, err := c.newImporter(req.Provider)
+	if err != nil {
+		return errorx.ReqParamInvalid(err, errorx.Ctx().Set("provider", req.Provider))
+	}
+	if len(req.ImportRepos) == 0 {
+		return errorx.ReqParamInvalid(errors.New("no repository to import"), nil)
+	}
+	user, err := c.userStore.FindByUsername(ctx, req.CurrentUser)
+	if err != nil {
+		return errorx.ErrUserNotFound
+	}
+	baseURL := importBaseURL(req.Provider, req.BaseURL)
+	remoteUser, err := imp.GetUser(ctx, baseURL, req.AccessToken)
+	if err != nil {
+		return remoteError(err, req.Provider)
+	}
+
+	// a failed repository does not stop the others
+	var errs []error
+	for _, repo := range req.ImportRepos {
+		err := c.importRepo(ctx, imp, req, baseURL, user.ID, remoteUser.Username, repo)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to import repository", slog.String("provider", string(req.Provider)),
+				slog.String("source_path", repo.SourcePath), slog.String("path", repo.Path), slog.Any("error", err))
+			errs = append(errs, fmt.Errorf("failed to import %s, error: %w", repo.SourcePath, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// importRepo creates the local repository with the metadata of the remote repository and a mirror
+// transferring its git and lfs objects
+func (c *importComponentImpl) importRepo(ctx context.Context, imp importer.Importer, req types.ImportReq, baseURL string, userID int64, remoteUsername string, repo types.ImportBaseReq) error {
+	namespace, name, err := splitImportPath(repo.Path)
+	if err != nil {
+		return err
+	}
+	repoType, err := importRepoType(req.Provider, repo.RepoType)
+	if err != nil {
+		return err
+	}
+	remote, err := imp.GetRepository(ctx, baseURL, req.AccessToken, repo.SourcePath)
+	if err != nil {
+		return remoteError(err, req.Provider)
+	}
+	// the mirror clones the repository without the checks of the importer client
+	if err := importer.CheckImportURL(baseURL, remote.ImportUrl); err != nil {
+		return errorx.ReqParamInvalid(err, errorx.Ctx().Set("source_path", repo.SourcePath))
+	}
+	sourceNamespace, sourceName := remote.Path, remote.Name
+	if idx := strings.LastIndex(remote.Path, "/"); idx > 0 {
+		sourceNamespace = remote.Path[:idx]
+	}
+
+	private := repo.Private
+	createTargetRepo := true
+	mirror, err := c.mirrorComponent.CreateMirrorRepo(ctx, types.CreateMirrorRepoReq{
+		SourceNamespace:   sourceNamespace,
+		SourceName:        sourceName,
+		RepoType:          repoType,
+		Private:           &private,
+		CreateTargetRepo:  &createTargetRepo,
+		DefaultBranch:     remote.DefaultBranch,
+		SourceGitCloneUrl: remote.ImportUrl,
+		Username:          remoteUsername,
+		AccessToken:       req.AccessToken,
+		Description:       remote.Description,
+		License:           remote.License,
+		CurrentUser:       req.CurrentUser,
+		ForkNamespace:     namespace,
+		ForkName:          name,
+		// the source path is only known for the model hubs
+		SkipSourcePath: req.Provider != types.ImportProviderModelScope,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := c.setTopicTags(ctx, mirror.RepositoryID, repoType, remote.Topics); err != nil {
+		// the tags can be edited later, so the import goes on
+		slog.WarnContext(ctx, "failed to set the topics of the imported repository as tags",
+			slog.Int64("repo_id", mirror.RepositoryID), slog.Any("error", err))
+	}
+
+	err = c.repositoryImportStore.Create(ctx, &database.RepositoryImport{
+		UserID:       userID,
+		Provider:     req.Provider,
+		SourcePath:   remote.Path,
+		RepositoryID: mirror.RepositoryID,
+		MirrorID:     mirror.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record the import, error: %w", err)
+	}
 	return nil
 }
 
-func (c *importComponentImpl) ImportStatus(ctx context.Context, req types.ImportStatusReq) ([]types.ImportedRepository, error) {
-	return nil, nil
+// setTopicTags adds the topics of the remote repository as the task tags, which is the category of the
+// tags in the readme metadata
+func (c *importComponentImpl) setTopicTags(ctx context.Context, repoID int64, repoType types.RepositoryType, topics []string) error {
+	if len(topics) == 0 {
+		return nil
+	}
+	scope := getTagScopeByRepoType(repoType)
+	tagIDs := make([]int64, 0, len(topics))
+	for _, topic := range topics {
+		topic = strings.TrimSpace(topic)
+		if topic == "" {
+			continue
+		}
+		tag, err := c.tagStore.FindOrCreate(ctx, database.Tag{
+			Name:     topic,
+			Category: string(types.TaskCategory),
+			Scope:    scope,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to find or create tag %s, error: %w", topic, err)
+		}
+		tagIDs = append(tagIDs, tag.ID)
+	}
+	return c.tagStore.UpsertRepoTags(ctx, repoID, nil, tagIDs)
+}
+
+func (c *importComponentImpl) ImportStatus(ctx context.Context, req types.ImportStatusReq) ([]types.ImportedRepository, int, error) {
+	user, err := c.userStore.FindByUsername(ctx, req.CurrentUser)
+	if err != nil {
+		return nil, 0, errorx.ErrUserNotFound
+	}
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.Per <= 0 {
+		req.Per = 20
+	}
+	imports, total, err := c.repositoryImportStore.ListByUserID(ctx, user.ID, req.Per, req.Page)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list imports, error: %w", err)
+	}
+
+	res := make([]types.ImportedRepository, 0, len(imports))
+	for _, imp := range imports {
+		item := types.ImportedRepository{
+			Provider:   imp.Provider,
+			SourcePath: imp.SourcePath,
+			Status:     string(types.MirrorSyncOverallNoTask),
+		}
+		if imp.Repository != nil {
+			item.RepoType = imp.Repository.RepositoryType
+			item.LocalPath = imp.Repository.Path
+		}
+		if imp.Mirror != nil {
+			status := resolveMirrorSyncStatus(*imp.Mirror)
+			item.Status = string(status.Status)
+			item.Phase = status.Phase
+			item.Result = status.Result
+			if imp.Mirror.CurrentTask != nil {
+				item.Progress = imp.Mirror.CurrentTask.Progress
+			}
+		}
+		res = append(res, item)
+	}
+	return res, total, nil
 }
 
 func (c *importComponentImpl) GetGitlabRepos(ctx context.Context, req *types.GetGitlabReposReq) ([]types.RemoteRepository, error) {
-	return nil, nil
+	return c.GetRemoteRepos(ctx, &types.GetRemoteReposReq{
+		CurrentUser: req.CurrentUser,
+		Provider:    types.ImportProviderGitLab,
+		BaseURL:     req.BaseURL,
+		AccessToken: req.AccessToken,
+		Search:      req.Search,
+		Per:         req.Per,
+		Page:        req.Page,
+	})
+}
+
+func (c *importComponentImpl) GetRemoteRepos(ctx context.Context, req *types.GetRemoteReposReq) ([]types.RemoteRepository, error) {
+	imp, err := c.newImporter(req.Provider)
+	if err != nil {
+		return nil, errorx.ReqParamInvalid(err, errorx.Ctx().Set("provider", req.Provider))
+	}
+	repos, err := imp.GetRepositoryList(ctx, importBaseURL(req.Provider, req.BaseURL), req.AccessToken, req.Search, req.Page, req.Per)
+	if err != nil {
+		return nil, remoteError(err, req.Provider)
+	}
+	res := make([]types.RemoteRepository, 0, len(repos))
+	for _, repo := range repos {
+		res = append(res, types.RemoteRepository{
+			Path:          repo.Path,
+			Name:          repo.Name,
+			SourceURL:     repo.ImportUrl,
+			Private:       repo.Private,
+			Description:   repo.Description,
+			Topics:        repo.Topics,
+			License:       repo.License,
+			DefaultBranch: repo.DefaultBranch,
+		})
+	}
+	return res, nil
+}
+
+func importBaseURL(provider types.ImportProvider, baseURL string) string {
+	if baseURL == "" {
+		return importer.DefaultBaseURL(provider)
+	}
+	return baseURL
+}
+
+// importRepoType returns the type of the local repository, modelscope hosts models and the git platforms
+// host code by default
+func importRepoType(provider types.ImportProvider, repoType types.RepositoryType) (types.RepositoryType, error) {
+	if repoType == "" {
+		if provider == types.ImportProviderModelScope {
+			return types.ModelRepo, nil
+		}
+		return types.CodeRepo, nil
+	}
+	switch repoType {
+	case types.ModelRepo, types.DatasetRepo, types.CodeRepo:
+		return repoType, nil
+	default:
+		return "", errorx.ReqParamInvalid(errors.New("unsupported repository type to import"),
+			errorx.Ctx().Set("repo_type", repoType))
+	}
+}
+
+// splitImportPath splits the local path of the imported repository into the namespace and the name
+func splitImportPath(p string) (string, string, error) {
+	namespace, name, ok := strings.Cut(strings.TrimSpace(p), "/")
+	if !ok || namespace == "" || name == "" || strings.Contains(name, "/") {
+		return "", "", errorx.ReqParamInvalid(ErrInvalidPath, errorx.Ctx().Set("path", p))
+	}
+	return namespace, name, nil
+}
+
+func remoteError(err error, provider types.ImportProvider) error {
+	if errors.Is(err, importer.ErrUnauthorized) {
+		return errorx.MirrorSourceRepoAuthInvalid(err, errorx.Ctx().Set("provider", provider))
+	}
+	return fmt.Errorf("failed to request %s, error: %w", provider, err)
 }
@@ -0,0 +1,188 @@
+//go:build !ee && !saas
+
+package component
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	mockcomponent "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/component"
+	"opencsg.com/csghub-server/builder/importer"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+)
+
+func TestImportComponent_Import(t *testing.T) {
+	ctx := context.TODO()
+	ic := initializeTestImportComponent(ctx, t)
+	mirrorComp := mockcomponent.NewMockMirrorComponent(t)
+	ic.mirrorComponent = mirrorComp
+
+	ic.mocks.stores.UserMock().EXPECT().FindByUsername(ctx, "user").Return(database.User{ID: 1, Username: "user"}, nil)
+	ic.mocks.importer.EXPECT().GetUser(ctx, "https://api.github.com", "token").Return(&importer.User{Username: "octo"}, nil)
+	ic.mocks.importer.EXPECT().GetRepository(ctx, "https://api.github.com", "token", "octo/repo").Return(&importer.Repository{
+		Name:          "repo",
+		Path:          "octo/repo",
+		ImportUrl:     "https://github.com/octo/repo.git",
+		Description:   "desc",
+		Topics:        []string{"nlp"},
+		License:       "mit",
+		DefaultBranch: "dev",
+	}, nil)
+	private, createTargetRepo := true, true
+	mirrorComp.EXPECT().CreateMirrorRepo(ctx, types.CreateMirrorRepoReq{
+		SourceNamespace:   "octo",
+		SourceName:        "repo",
+		RepoType:          types.CodeRepo,
+		Private:           &private,
+		CreateTargetRepo:  &createTargetRepo,
+		DefaultBranch:     "dev",
+		SourceGitCloneUrl: "https://github.com/octo/repo.git",
+		Username:          "octo",
+		AccessToken:       "token",
+		Description:       "desc",
+		License:           "mit",
+		CurrentUser:       "user",
+		ForkNamespace:     "ns",
+		ForkName:          "repo",
+		SkipSourcePath:    true,
+	}).Return(&database.Mirror{ID: 2, RepositoryID: 3}, nil)
+	ic.mocks.stores.TagMock().EXPECT().FindOrCreate(ctx, database.Tag{
+		Name: "nlp", Category: "task", Scope: types.CodeTagScope,
+	}).Return(&database.Tag{ID: 4}, nil)
+	ic.mocks.stores.TagMock().EXPECT().UpsertRepoTags(ctx, int64(3), []int64(nil), []int64{4}).Return(nil)
+	ic.mocks.stores.RepositoryImportMock().EXPECT().Create(ctx, &database.RepositoryImport{
+		UserID:       1,
+		Provider:     types.ImportProviderGitHub,
+		SourcePath:   "octo/repo",
+		RepositoryID: 3,
+		MirrorID:     2,
+	}).Return(nil)
+
+	err := ic.Import(ctx, types.ImportReq{
+		Provider:    types.ImportProviderGitHub,
+		CurrentUser: "user",
+		AccessToken: "token",
+		ImportRepos: []types.ImportBaseReq{
+			{Path: "ns/repo", SourcePath: "octo/repo", Private: true},
+			{Path: "invalid", SourcePath: "octo/other"},
+		},
+	})
+	require.ErrorIs(t, err, errorx.ErrReqParamInvalid)
+	require.ErrorIs(t, err, ErrInvalidPath)
+}
+
+func TestImportComponent_ImportUrlHostMismatch(t *testing.T) {
+	ctx := context.TODO()
+	ic := initializeTestImportComponent(ctx, t)
+
+	ic.mocks.stores.UserMock().EXPECT().FindByUsername(ctx, "user").Return(database.User{ID: 1}, nil)
+	ic.mocks.importer.EXPECT().GetUser(ctx, "https://gitea.example.com", "token").Return(&importer.User{Username: "tea"}, nil)
+	ic.mocks.importer.EXPECT().GetRepository(ctx, "https://gitea.example.com", "token", "tea/repo").Return(&importer.Repository{
+		Name:      "repo",
+		Path:      "tea/repo",
+		ImportUrl: "http://10.0.0.1/tea/repo.git",
+	}, nil)
+
+	err := ic.Import(ctx, types.ImportReq{
+		Provider:    types.ImportProviderGitea,
+		BaseURL:     "https://gitea.example.com",
+		CurrentUser: "user",
+		AccessToken: "token",
+		ImportRepos: []types.ImportBaseReq{{Path: "ns/repo", SourcePath: "tea/repo"}},
+	})
+	require.ErrorIs(t, err, errorx.ErrReqParamInvalid)
+}
+
+func TestImportComponent_ImportUnauthorized(t *testing.T) {
+	ctx := context.TODO()
+	ic := initializeTestImportComponent(ctx, t)
+
+	ic.mocks.stores.UserMock().EXPECT().FindByUsername(ctx, "user").Return(database.User{ID: 1}, nil)
+	ic.mocks.importer.EXPECT().GetUser(ctx, "https://gitea.example.com", "bad").Return(nil, importer.ErrUnauthorized)
+
+	err := ic.Import(ctx, types.ImportReq{
+		Provider:    types.ImportProviderGitea,
+		BaseURL:     "https://gitea.example.com",
+		CurrentUser: "user",
+		AccessToken: "bad",
+		ImportRepos: []types.ImportBaseReq{{Path: "ns/repo", SourcePath: "foo/repo"}},
+	})
+	require.ErrorIs(t, err, errorx.ErrMirrorSourceRepoAuthInvalid)
+}
+
+func TestImportComponent_ImportStatus(t *testing.T) {
+	ctx := context.TODO()
+	ic := initializeTestImportComponent(ctx, t)
+
+	ic.mocks.stores.UserMock().EXPECT().FindByUsername(ctx, "user").Return(database.User{ID: 1}, nil)
+	ic.mocks.stores.RepositoryImportMock().EXPECT().ListByUserID(ctx, int64(1), 20, 1).Return([]database.RepositoryImport{
+		{
+			Provider:   types.ImportProviderModelScope,
+			SourcePath: "foo/model",
+			Repository: &database.Repository{Path: "ns/model", RepositoryType: types.ModelRepo},
+			Mirror: &database.Mirror{
+				ID:            2,
+				CurrentTaskID: 3,
+				CurrentTask:   &database.MirrorTask{ID: 3, MirrorID: 2, Status: types.MirrorLfsSyncStart, Progress: 40},
+			},
+		},
+		{Provider: types.ImportProviderGitLab, SourcePath: "foo/code"},
+	}, 2, nil)
+
+	res, total, err := ic.ImportStatus(ctx, types.ImportStatusReq{CurrentUser: "user"})
+	require.Nil(t, err)
+	require.Equal(t, 2, total)
+	require.Equal(t, []types.ImportedRepository{
+		{
+			Provider:   types.ImportProviderModelScope,
+			RepoType:   types.ModelRepo,
+			SourcePath: "foo/model",
+			LocalPath:  "ns/model",
+			Status:     string(types.MirrorSyncOverallRunning),
+			Phase:      types.MirrorSyncPhaseLFS,
+			Progress:   40,
+		},
+		{
+			Provider:   types.ImportProviderGitLab,
+			SourcePath: "foo/code",
+			Status:     string(types.MirrorSyncOverallNoTask),
+		},
+	}, res)
+}
+
+func TestImportComponent_GetRemoteRepos(t *testing.T) {
+	ctx := context.TODO()
+	ic := initializeTestImportComponent(ctx, t)
+
+	ic.mocks.importer.EXPECT().GetRepositoryList(ctx, "https://gitlab.com", "token", "foo", 1, 10).Return([]importer.Repository{
+		{Name: "foo", Path: "group/foo", ImportUrl: "https://gitlab.com/group/foo.git", Private: true, Topics: []string{"a"}},
+	}, nil)
+
+	res, err := ic.GetGitlabRepos(ctx, &types.GetGitlabReposReq{AccessToken: "token", Search: "foo", Page: 1, Per: 10})
+	require.Nil(t, err)
+	require.Equal(t, []types.RemoteRepository{
+		{Name: "foo", Path: "group/foo", SourceURL: "https://gitlab.com/group/foo.git", Private: true, Topics: []string{"a"}},
+	}, res)
+
+	ic.mocks.importer.EXPECT().GetRepositoryList(ctx, "https://www.modelscope.cn", "token", "", 1, 10).Return(nil, errors.New("boom"))
+	_, err = ic.GetRemoteRepos(ctx, &types.GetRemoteReposReq{Provider: types.ImportProviderModelScope, AccessToken: "token", Page: 1, Per: 10})
+	require.NotNil(t, err)
+	require.NotErrorIs(t, err, errorx.ErrMirrorSourceRepoAuthInvalid)
+}
+
+func TestImportRepoType(t *testing.T) {
+	repoType, err := importRepoType(types.ImportProviderModelScope, "")
+	require.Nil(t, err)
+	require.Equal(t, types.ModelRepo, repoType)
+
+	repoType, err = importRepoType(types.ImportProviderGitHub, "")
+	require.Nil(t, err)
+	require.Equal(t, types.CodeRepo, repoType)
+
+	_, err = importRepoType(types.ImportProviderGitHub, types.SpaceRepo)
+	require.ErrorIs(t, err, errorx.ErrReqParamInvalid)
+}
@@ -0,0 +1,446 @@
+package component
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	dcommon "opencsg.com/csghub-server/builder/deploy/common"
+	"opencsg.com/csghub-server/builder/deploy/imagerunner"
+	"opencsg.com/csghub-server/builder/prometheus"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/config"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+	"opencsg.com/csghub-server/common/utils/common"
+)
+
+// InferenceRolloutComponent shifts the traffic of an inference deploy from its stable version to a
+// canary version step by step, and rolls back when the canary breaches the error rate or latency limits.
+//
+// The steps are driven by a temporal workflow, which calls ShiftRolloutTraffic, CheckRolloutHealth and
+// FinishRollout.
+type InferenceRolloutComponent interface {
+	CreateRollout(ctx context.Context, req types.CreateInferenceRolloutReq) (*types.InferenceRollout, error)
+	// GetRollout returns the latest rollout of the deploy, nil if the deploy was never rolled out
+	GetRollout(ctx context.Context, req types.DeployActReq) (*types.InferenceRollout, error)
+	// CancelRollout checks the permission and returns the latest rollout of the deploy to cancel
+	CancelRollout(ctx context.Context, req types.DeployActReq) (*types.InferenceRollout, error)
+	ShiftRolloutTraffic(ctx context.Context, rolloutID int64, step int) error
+	CheckRolloutHealth(ctx context.Context, rolloutID int64) (*types.InferenceRolloutHealth, error)
+	// FinishRollout records the final status, all traffic goes back to the stable version unless the rollout succeeded
+	FinishRollout(ctx context.Context, rolloutID int64, status types.InferenceRolloutStatus, reason string) error
+}
+
+type inferenceRolloutComponentImpl struct {
+	repoComponent   RepoComponent
+	deployTaskStore database.DeployTaskStore
+	rolloutStore    database.InferenceRolloutStore
+	imageRunner     imagerunner.Runner
+	promClient      prometheus.PrometheusClient
+	k8sNameSpace    string
+	metrics         metricNames
+}
+
+func NewInferenceRolloutComponent(config *config.Config) (InferenceRolloutComponent, error) {
+	repoComponent, err := NewRepoComponent(config)
+	if err != nil {
+		return nil, err
+	}
+	dc := dcommon.BuildDeployConfig(config)
+	ir, err := imagerunner.NewRemoteRunner(dc.ImageRunnerURL, dc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create image runner, error: %w", err)
+	}
+	return &inferenceRolloutComponentImpl{
+		repoComponent:   repoComponent,
+		deployTaskStore: database.NewDeployTaskStore(),
+		rolloutStore:    database.NewInferenceRolloutStore(),
+		imageRunner:     ir,
+		promClient:      prometheus.NewPrometheusClient(config),
+		k8sNameSpace:    config.Cluster.SpaceNamespace,
+		metrics: metricNames{
+			requestCount:   config.Prometheus.RequestCountMetric,
+			requestLatency: config.Prometheus.RequestLatencyMetric,
+		},
+	}, nil
+}
+
+func (c *inferenceRolloutComponentImpl) CreateRollout(ctx context.Context, req types.CreateInferenceRolloutReq) (*types.InferenceRollout, error) {
+	user, deploy, err := c.repoComponent.CheckDeployPermissionForUser(ctx, types.DeployActReq{
+		CurrentUser: req.CurrentUser,
+		DeployID:    req.DeployID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if deploy.Status != dcommon.Running {
+		return nil, errorx.ErrDeployStatusNotMatchErr
+	}
+
+	latest, err := c.latestRollout(ctx, deploy.ID)
+	if err != nil {
+		return nil, err
+	}
+	if latest != nil && latest.Status == types.InferenceRolloutRunning {
+		return nil, errorx.ErrInferenceRolloutInProgress
+	}
+
+	if len(req.Steps) == 0 {
+		req.Steps = types.DefaultInferenceRolloutSteps
+	}
+	if !validRolloutSteps(req.Steps) {
+		return nil, errorx.ErrInvalidRolloutSteps
+	}
+	if req.StepInterval == 0 {
+		req.StepInterval = types.DefaultInferenceRolloutStepInterval
+	}
+	if req.StepInterval < types.MinInferenceRolloutStepInterval {
+		return nil, errorx.ReqParamInvalid(fmt.Errorf("step interval must be at least %d seconds", types.MinInferenceRolloutStepInterval),
+			errorx.Ctx().Set("param", "step_interval"))
+	}
+	if req.MaxErrorRate < 0 || req.MaxErrorRate > 1 {
+		return nil, errorx.ReqParamInvalid(errors.New("max error rate must be between 0 and 1"),
+			errorx.Ctx().Set("param", "max_error_rate"))
+	}
+	if req.MaxLatencyMs < 0 {
+		return nil, errorx.ReqParamInvalid(errors.New("max latency must not be negative"),
+			errorx.Ctx().Set("param", "max_latency_ms"))
+	}
+
+	canary, err := common.ShortenCommitID7(req.CommitID)
+	if err != nil {
+		return nil, errorx.ErrInvalidCommitID
+	}
+	versions, err := c.imageRunner.ListKsvcVersions(ctx, deploy.ClusterID, deploy.SvcName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list inference versions, error: %w", err)
+	}
+	stable, err := stableRolloutCommit(req.StableCommitID, versions)
+	if err != nil {
+		return nil, err
+	}
+	if stable == canary {
+		return nil, errorx.ReqParamInvalid(errors.New("canary version is the same as the stable version"),
+			errorx.Ctx().Set("param", "commit_id"))
+	}
+
+	// the record is created first so that the unique index on running rollouts
+	// rejects a concurrent rollout of the same deploy before any revision is touched
+	rollout, err := c.rolloutStore.Create(ctx, &database.InferenceRollout{
+		DeployID:     deploy.ID,
+		UserUUID:     user.UUID,
+		StableCommit: stable,
+		CanaryCommit: canary,
+		Steps:        req.Steps,
+		StepInterval: req.StepInterval,
+		MaxErrorRate: req.MaxErrorRate,
+		MaxLatencyMs: req.MaxLatencyMs,
+		Status:       types.InferenceRolloutRunning,
+	})
+	if err != nil {
+		if errors.Is(err, errorx.ErrDatabaseDuplicateKey) {
+			return nil, errorx.ErrInferenceRolloutInProgress
+		}
+		return nil, err
+	}
+
+	if !slices.ContainsFunc(versions, func(v types.KsvcRevisionInfo) bool { return v.Commit == canary }) {
+		err = c.imageRunner.CreateRevisions(ctx, &types.CreateRevisionReq{
+			ClusterID:      deploy.ClusterID,
+			SvcName:        deploy.SvcName,
+			Commit:         canary,
+			InitialTraffic: 0,
+		})
+		if err != nil {
+			rollout.Status = types.InferenceRolloutFailed
+			rollout.Reason = fmt.Sprintf("failed to create canary version: %s", err.Error())
+			if uerr := c.rolloutStore.Update(ctx, rollout); uerr != nil {
+				slog.Error("failed to mark inference rollout failed", slog.Int64("rollout_id", rollout.ID), slog.Any("error", uerr))
+			}
+			return nil, fmt.Errorf("failed to create canary version, error: %w", err)
+		}
+	}
+
+	return inferenceRolloutToType(rollout), nil
+}
+
+func (c *inferenceRolloutComponentImpl) GetRollout(ctx context.Context, req types.DeployActReq) (*types.InferenceRollout, error) {
+	_, deploy, err := c.repoComponent.CheckDeployPermissionForUser(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	rollout, err := c.latestRollout(ctx, deploy.ID)
+	if err != nil {
+		return nil, err
+	}
+	return inferenceRolloutToType(rollout), nil
+}
+
+func (c *inferenceRolloutComponentImpl) CancelRollout(ctx context.Context, req types.DeployActReq) (*types.InferenceRollout, error) {
+	return c.GetRollout(ctx, req)
+}
+
+func (c *inferenceRolloutComponentImpl) ShiftRolloutTraffic(ctx context.Context, rolloutID int64, step int) error {
+	rollout, deploy, err := c.rolloutAndDeploy(ctx, rolloutID)
+	if err != nil {
+		return err
+	}
+	if step < 0 || step >= len(rollout.Steps) {
+		return fmt.Errorf("rollout %d has no step %d", rolloutID, step)
+	}
+
+	versions, err := c.imageRunner.ListKsvcVersions(ctx, deploy.ClusterID, deploy.SvcName)
+	if err != nil {
+		return fmt.Errorf("failed to list inference versions, error: %w", err)
+	}
+	idx := slices.IndexFunc(versions, func(v types.KsvcRevisionInfo) bool { return v.Commit == rollout.CanaryCommit })
+	if idx < 0 {
+		return errorx.ErrRevisionNotFound
+	}
+	if !versions[idx].IsReady {
+		// the workflow retries until the canary revision is ready
+		return fmt.Errorf("canary version %s is not ready, reason: %s", rollout.CanaryCommit, versions[idx].Reason)
+	}
+
+	percent := rollout.Steps[step]
+	err = c.imageRunner.SetVersionsTraffic(ctx, deploy.ClusterID, deploy.SvcName, rolloutTraffic(rollout, percent))
+	if err != nil {
+		return fmt.Errorf("failed to set canary traffic to %d%%, error: %w", percent, err)
+	}
+
+	rollout.CurrentStep = step
+	rollout.CurrentTraffic = percent
+	return c.rolloutStore.Update(ctx, rollout)
+}
+
+func (c *inferenceRolloutComponentImpl) CheckRolloutHealth(ctx context.Context, rolloutID int64) (*types.InferenceRolloutHealth, error) {
+	rollout, deploy, err := c.rolloutAndDeploy(ctx, rolloutID)
+	if err != nil {
+		return nil, err
+	}
+	versions, err := c.imageRunner.ListKsvcVersions(ctx, deploy.ClusterID, deploy.SvcName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list inference versions, error: %w", err)
+	}
+	idx := slices.IndexFunc(versions, func(v types.KsvcRevisionInfo) bool { return v.Commit == rollout.CanaryCommit })
+	if idx < 0 {
+		return &types.InferenceRolloutHealth{Reason: "canary version is gone"}, nil
+	}
+	revision := versions[idx].RevisionName
+	window := fmt.Sprintf("%ds", rollout.StepInterval)
+	now := time.Now()
+
+	health := &types.InferenceRolloutHealth{Healthy: true}
+	if rollout.MaxErrorRate > 0 {
+		health.ErrorRate, err = c.canaryErrorRate(ctx, revision, window, now)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if rollout.MaxLatencyMs > 0 {
+		health.LatencyMs, err = c.canaryLatency(ctx, revision, window, now)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	switch {
+	case rollout.MaxErrorRate > 0 && health.ErrorRate > rollout.MaxErrorRate:
+		health.Healthy = false
+		health.Reason = fmt.Sprintf("error rate %.4f exceeds the limit %.4f", health.ErrorRate, rollout.MaxErrorRate)
+	case rollout.MaxLatencyMs > 0 && health.LatencyMs > rollout.MaxLatencyMs:
+		health.Healthy = false
+		health.Reason = fmt.Sprintf("p95 latency %.0fms exceeds the limit %.0fms", health.LatencyMs, rollout.MaxLatencyMs)
+	}
+
+	rollout.ErrorRate = health.ErrorRate
+	rollout.LatencyMs = health.LatencyMs
+	err = c.rolloutStore.Update(ctx, rollout)
+	if err != nil {
+		return nil, err
+	}
+	return health, nil
+}
+
+func (c *inferenceRolloutComponentImpl) FinishRollout(ctx context.Context, rolloutID int64, status types.InferenceRolloutStatus, reason string) error {
+	rollout, deploy, err := c.rolloutAndDeploy(ctx, rolloutID)
+	if err != nil {
+		return err
+	}
+	if status != types.InferenceRolloutSucceeded && rollout.CurrentTraffic > 0 {
+		err = c.imageRunner.SetVersionsTraffic(ctx, deploy.ClusterID, deploy.SvcName, rolloutTraffic(rollout, 0))
+		if err != nil {
+			return fmt.Errorf("failed to roll traffic back to stable version %s, error: %w", rollout.StableCommit, err)
+		}
+		rollout.CurrentTraffic = 0
+	}
+	if status == types.InferenceRolloutRolledBack {
+		slog.WarnContext(ctx, "inference rollout rolled back", slog.Int64("rollout_id", rollout.ID),
+			slog.Int64("deploy_id", rollout.DeployID), slog.String("canary", rollout.CanaryCommit), slog.String("reason", reason))
+	}
+	rollout.Status = status
+	rollout.Reason = reason
+	return c.rolloutStore.Update(ctx, rollout)
+}
+
+func (c *inferenceRolloutComponentImpl) latestRollout(ctx context.Context, deployID int64) (*database.InferenceRollout, error) {
+	rollout, err := c.rolloutStore.FindLatestByDeployID(ctx, deployID)
+	if errors.Is(err, errorx.ErrDatabaseNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get inference rollout, error: %w", err)
+	}
+	return rollout, nil
+}
+
+// checkNoRunningRollout rejects changes to the revisions or traffic of an inference
+// deploy while a rollout is shifting its traffic.
+func checkNoRunningRollout(ctx context.Context, rolloutStore database.InferenceRolloutStore, deployID int64) error {
+	rollout, err := rolloutStore.FindLatestByDeployID(ctx, deployID)
+	if errors.Is(err, errorx.ErrDatabaseNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get inference rollout, error: %w", err)
+	}
+	if rollout.Status == types.InferenceRolloutRunning {
+		return errorx.ErrInferenceRolloutInProgress
+	}
+	return nil
+}
+
+func (c *inferenceRolloutComponentImpl) rolloutAndDeploy(ctx context.Context, rolloutID int64) (*database.InferenceRollout, *database.Deploy, error) {
+	rollout, err := c.rolloutStore.FindByID(ctx, rolloutID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get inference rollout %d, error: %w", rolloutID, err)
+	}
+	deploy, err := c.deployTaskStore.GetDeployByID(ctx, rollout.DeployID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get deploy %d, error: %w", rollout.DeployID, err)
+	}
+	return rollout, deploy, nil
+}
+
+// canaryErrorRate returns the ratio of 5xx responses served by the canary revision within the window
+func (c *inferenceRolloutComponentImpl) canaryErrorRate(ctx context.Context, revision, window string, at time.Time) (float64, error) {
+	selector := fmt.Sprintf("namespace='%s',revision_name='%s'", c.k8sNameSpace, revision)
+	if strings.HasSuffix(c.metrics.requestCount, "_bucket") {
+		selector += ",le='+Inf'"
+	}
+	total, err := c.queryScalar(ctx, fmt.Sprintf("sum(increase(%s{%s}[%s]))", c.metrics.requestCount, selector, window), at)
+	if err != nil {
+		return 0, err
+	}
+	if total <= 0 {
+		return 0, nil
+	}
+	failed, err := c.queryScalar(ctx, fmt.Sprintf("sum(increase(%s{%s,response_code_class='5xx'}[%s]))",
+		c.metrics.requestCount, selector, window), at)
+	if err != nil {
+		return 0, err
+	}
+	return failed / total, nil
+}
+
+// canaryLatency returns the p95 request latency of the canary revision within the window in milliseconds
+func (c *inferenceRolloutComponentImpl) canaryLatency(ctx context.Context, revision, window string, at time.Time) (float64, error) {
+	query := fmt.Sprintf("histogram_quantile(0.95, sum by (le) (rate(%s{namespace='%s',revision_name='%s'}[%s])))",
+		c.metrics.requestLatency, c.k8sNameSpace, revision, window)
+	return c.queryScalar(ctx, query, at)
+}
+
+func (c *inferenceRolloutComponentImpl) queryScalar(ctx context.Context, query string, at time.Time) (float64, error) {
+	return queryPromScalar(ctx, c.promClient, query, at)
+}
+
+// queryPromScalar runs an instant query returning a single sample, no data counts as 0
+func queryPromScalar(ctx context.Context, promClient prometheus.PrometheusClient, query string, at time.Time) (float64, error) {
+	resp, err := promClient.QueryInstant(ctx, query, at)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query prometheus, query: %s, error: %w", query, err)
+	}
+	if len(resp.Data.Result) == 0 {
+		return 0, nil
+	}
+	values, err := convertToArrayFloat64(resp.Data.Result[0].Value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid prometheus result, query: %s, error: %w", query, err)
+	}
+	if math.IsNaN(values[1]) || math.IsInf(values[1], 0) {
+		return 0, nil
+	}
+	return values[1], nil
+}
+
+// validRolloutSteps checks the steps are ascending percents in (0, 100] ending with 100
+func validRolloutSteps(steps []int) bool {
+	for i, s := range steps {
+		if s <= 0 || s > 100 || (i > 0 && s <= steps[i-1]) {
+			return false
+		}
+	}
+	return len(steps) > 0 && steps[len(steps)-1] == 100
+}
+
+// stableRolloutCommit resolves the version traffic falls back to, the one serving the most traffic by default
+func stableRolloutCommit(commitID string, versions []types.KsvcRevisionInfo) (string, error) {
+	if commitID != "" {
+		stable, err := common.ShortenCommitID7(commitID)
+		if err != nil {
+			return "", errorx.ErrInvalidCommitID
+		}
+		if !slices.ContainsFunc(versions, func(v types.KsvcRevisionInfo) bool { return v.Commit == stable }) {
+			return "", errorx.ErrRevisionNotFound
+		}
+		return stable, nil
+	}
+	var stable *types.KsvcRevisionInfo
+	for i := range versions {
+		if stable == nil || versions[i].TrafficPercent > stable.TrafficPercent {
+			stable = &versions[i]
+		}
+	}
+	if stable == nil || stable.TrafficPercent == 0 {
+		return "", errorx.ErrRevisionNotFound
+	}
+	return stable.Commit, nil
+}
+
+// rolloutTraffic sends percent of the traffic to the canary and the rest to the stable version
+func rolloutTraffic(rollout *database.InferenceRollout, percent int) []types.TrafficReq {
+	return []types.TrafficReq{
+		{Commit: rollout.CanaryCommit, TrafficPercent: int64(percent)},
+		{Commit: rollout.StableCommit, TrafficPercent: int64(100 - percent)},
+	}
+}
+
+func inferenceRolloutToType(rollout *database.InferenceRollout) *types.InferenceRollout {
+	if rollout == nil {
+		return nil
+	}
+	return &types.InferenceRollout{
+		ID:             rollout.ID,
+		DeployID:       rollout.DeployID,
+		StableCommit:   rollout.StableCommit,
+		CanaryCommit:   rollout.CanaryCommit,
+		Steps:          rollout.Steps,
+		StepInterval:   rollout.StepInterval,
+		MaxErrorRate:   rollout.MaxErrorRate,
+		MaxLatencyMs:   rollout.MaxLatencyMs,
+		CurrentStep:    rollout.CurrentStep,
+		CurrentTraffic: rollout.CurrentTraffic,
+		Status:         rollout.Status,
+		Reason:         rollout.Reason,
+		ErrorRate:      rollout.ErrorRate,
+		LatencyMs:      rollout.LatencyMs,
+		CreatedAt:      rollout.CreatedAt,
+		UpdatedAt:      rollout.UpdatedAt,
+	}
+}
@@ -0,0 +1,254 @@
+package component
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	mock_imagerunner "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/builder/deploy/imagerunner"
+	prometheus_mock "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/builder/prometheus"
+	dcommon "opencsg.com/csghub-server/builder/deploy/common"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+)
+
+type testInferenceRolloutWithMocks struct {
+	*inferenceRolloutComponentImpl
+	repo        *testRepoWithMocks
+	imageRunner *mock_imagerunner.MockRunner
+	promClient  *prometheus_mock.MockPrometheusClient
+}
+
+func initializeTestInferenceRolloutComponent(ctx context.Context, t *testing.T) *testInferenceRolloutWithMocks {
+	repo := initializeTestRepoComponent(ctx, t)
+	ir := mock_imagerunner.NewMockRunner(t)
+	pc := prometheus_mock.NewMockPrometheusClient(t)
+	return &testInferenceRolloutWithMocks{
+		inferenceRolloutComponentImpl: &inferenceRolloutComponentImpl{
+			repoComponent:   repo.repoComponentImpl,
+			deployTaskStore: repo.mocks.stores.DeployTask,
+			rolloutStore:    repo.mocks.stores.InferenceRollout,
+			imageRunner:     ir,
+			promClient:      pc,
+			k8sNameSpace:    "spaces",
+			metrics: metricNames{
+				requestCount:   "revision_request_count",
+				requestLatency: "revision_app_request_latencies_bucket",
+			},
+		},
+		repo:        repo,
+		imageRunner: ir,
+		promClient:  pc,
+	}
+}
+
+func TestInferenceRolloutComponent_CreateRollout(t *testing.T) {
+	ctx := context.TODO()
+	rc := initializeTestInferenceRolloutComponent(ctx, t)
+	stores := rc.repo.mocks.stores
+
+	stores.UserMock().EXPECT().FindByUsername(ctx, "user").Return(database.User{ID: 1, UUID: "uuid"}, nil)
+	stores.DeployTaskMock().EXPECT().GetDeployByID(ctx, int64(1)).Return(&database.Deploy{
+		ID: 1, UserID: 1, ClusterID: "cluster", SvcName: "svc", Status: dcommon.Running,
+	}, nil)
+	stores.InferenceRolloutMock().EXPECT().FindLatestByDeployID(ctx, int64(1)).Return(nil, errorx.ErrDatabaseNoRows)
+	rc.imageRunner.EXPECT().ListKsvcVersions(ctx, "cluster", "svc").Return([]types.KsvcRevisionInfo{
+		{Commit: "aaaaaaa", TrafficPercent: 100, IsReady: true},
+	}, nil)
+	rc.imageRunner.EXPECT().CreateRevisions(ctx, &types.CreateRevisionReq{
+		ClusterID: "cluster", SvcName: "svc", Commit: "bbbbbbb",
+	}).Return(nil)
+	stores.InferenceRolloutMock().EXPECT().Create(ctx, &database.InferenceRollout{
+		DeployID: 1, UserUUID: "uuid", StableCommit: "aaaaaaa", CanaryCommit: "bbbbbbb",
+		Steps: types.DefaultInferenceRolloutSteps, StepInterval: types.DefaultInferenceRolloutStepInterval,
+		MaxErrorRate: 0.05, Status: types.InferenceRolloutRunning,
+	}).RunAndReturn(func(ctx context.Context, r *database.InferenceRollout) (*database.InferenceRollout, error) {
+		r.ID = 2
+		return r, nil
+	})
+
+	rollout, err := rc.CreateRollout(ctx, types.CreateInferenceRolloutReq{
+		CurrentUser: "user", DeployID: 1, CommitID: "bbbbbbbccc", MaxErrorRate: 0.05,
+	})
+	require.Nil(t, err)
+	require.Equal(t, int64(2), rollout.ID)
+	require.Equal(t, "aaaaaaa", rollout.StableCommit)
+	require.Equal(t, types.InferenceRolloutRunning, rollout.Status)
+}
+
+func TestInferenceRolloutComponent_CreateRolloutInvalid(t *testing.T) {
+	ctx := context.TODO()
+	rc := initializeTestInferenceRolloutComponent(ctx, t)
+	stores := rc.repo.mocks.stores
+
+	stores.UserMock().EXPECT().FindByUsername(ctx, "user").Return(database.User{ID: 1}, nil)
+	stores.DeployTaskMock().EXPECT().GetDeployByID(ctx, int64(1)).Return(&database.Deploy{
+		ID: 1, UserID: 1, Status: dcommon.Running,
+	}, nil)
+	stores.InferenceRolloutMock().EXPECT().FindLatestByDeployID(ctx, int64(1)).Return(&database.InferenceRollout{
+		Status: types.InferenceRolloutSucceeded,
+	}, nil)
+
+	for _, steps := range [][]int{{5, 50}, {50, 25, 100}, {0, 100}, {5, 100, 100}} {
+		_, err := rc.CreateRollout(ctx, types.CreateInferenceRolloutReq{
+			CurrentUser: "user", DeployID: 1, CommitID: "bbbbbbb", Steps: steps,
+		})
+		require.ErrorIs(t, err, errorx.ErrInvalidRolloutSteps, steps)
+	}
+}
+
+func TestInferenceRolloutComponent_CreateRolloutInProgress(t *testing.T) {
+	ctx := context.TODO()
+	rc := initializeTestInferenceRolloutComponent(ctx, t)
+	stores := rc.repo.mocks.stores
+
+	stores.UserMock().EXPECT().FindByUsername(ctx, "user").Return(database.User{ID: 1}, nil)
+	stores.DeployTaskMock().EXPECT().GetDeployByID(ctx, int64(1)).Return(&database.Deploy{
+		ID: 1, UserID: 1, Status: dcommon.Running,
+	}, nil)
+	stores.InferenceRolloutMock().EXPECT().FindLatestByDeployID(ctx, int64(1)).Return(&database.InferenceRollout{
+		Status: types.InferenceRolloutRunning,
+	}, nil)
+
+	_, err := rc.CreateRollout(ctx, types.CreateInferenceRolloutReq{CurrentUser: "user", DeployID: 1, CommitID: "bbbbbbb"})
+	require.ErrorIs(t, err, errorx.ErrInferenceRolloutInProgress)
+}
+
+func TestInferenceRolloutComponent_CreateRolloutConcurrent(t *testing.T) {
+	ctx := context.TODO()
+	rc := initializeTestInferenceRolloutComponent(ctx, t)
+	stores := rc.repo.mocks.stores
+
+	stores.UserMock().EXPECT().FindByUsername(ctx, "user").Return(database.User{ID: 1, UUID: "uuid"}, nil)
+	stores.DeployTaskMock().EXPECT().GetDeployByID(ctx, int64(1)).Return(&database.Deploy{
+		ID: 1, UserID: 1, ClusterID: "cluster", SvcName: "svc", Status: dcommon.Running,
+	}, nil)
+	stores.InferenceRolloutMock().EXPECT().FindLatestByDeployID(ctx, int64(1)).Return(nil, errorx.ErrDatabaseNoRows)
+	rc.imageRunner.EXPECT().ListKsvcVersions(ctx, "cluster", "svc").Return([]types.KsvcRevisionInfo{
+		{Commit: "aaaaaaa", TrafficPercent: 100, IsReady: true},
+	}, nil)
+	// another rollout of the same deploy was created in between
+	stores.InferenceRolloutMock().EXPECT().Create(ctx, mock.Anything).Return(nil, errorx.ErrDatabaseDuplicateKey)
+
+	_, err := rc.CreateRollout(ctx, types.CreateInferenceRolloutReq{CurrentUser: "user", DeployID: 1, CommitID: "bbbbbbb"})
+	require.ErrorIs(t, err, errorx.ErrInferenceRolloutInProgress)
+}
+
+func TestInferenceRolloutComponent_CreateRolloutCanaryFailed(t *testing.T) {
+	ctx := context.TODO()
+	rc := initializeTestInferenceRolloutComponent(ctx, t)
+	stores := rc.repo.mocks.stores
+
+	stores.UserMock().EXPECT().FindByUsername(ctx, "user").Return(database.User{ID: 1, UUID: "uuid"}, nil)
+	stores.DeployTaskMock().EXPECT().GetDeployByID(ctx, int64(1)).Return(&database.Deploy{
+		ID: 1, UserID: 1, ClusterID: "cluster", SvcName: "svc", Status: dcommon.Running,
+	}, nil)
+	stores.InferenceRolloutMock().EXPECT().FindLatestByDeployID(ctx, int64(1)).Return(nil, errorx.ErrDatabaseNoRows)
+	rc.imageRunner.EXPECT().ListKsvcVersions(ctx, "cluster", "svc").Return([]types.KsvcRevisionInfo{
+		{Commit: "aaaaaaa", TrafficPercent: 100, IsReady: true},
+	}, nil)
+	stores.InferenceRolloutMock().EXPECT().Create(ctx, mock.Anything).RunAndReturn(
+		func(ctx context.Context, r *database.InferenceRollout) (*database.InferenceRollout, error) {
+			r.ID = 2
+			return r, nil
+		})
+	rc.imageRunner.EXPECT().CreateRevisions(ctx, mock.Anything).Return(errors.New("boom"))
+	stores.InferenceRolloutMock().EXPECT().Update(ctx, mock.MatchedBy(func(r *database.InferenceRollout) bool {
+		return r.ID == 2 && r.Status == types.InferenceRolloutFailed
+	})).Return(nil)
+
+	_, err := rc.CreateRollout(ctx, types.CreateInferenceRolloutReq{CurrentUser: "user", DeployID: 1, CommitID: "bbbbbbb"})
+	require.NotNil(t, err)
+}
+
+func TestInferenceRolloutComponent_ShiftRolloutTraffic(t *testing.T) {
+	ctx := context.TODO()
+	rc := initializeTestInferenceRolloutComponent(ctx, t)
+	stores := rc.repo.mocks.stores
+
+	rollout := &database.InferenceRollout{
+		ID: 2, DeployID: 1, StableCommit: "aaaaaaa", CanaryCommit: "bbbbbbb", Steps: []int{5, 25, 100},
+	}
+	stores.InferenceRolloutMock().EXPECT().FindByID(ctx, int64(2)).Return(rollout, nil)
+	stores.DeployTaskMock().EXPECT().GetDeployByID(ctx, int64(1)).Return(&database.Deploy{
+		ID: 1, ClusterID: "cluster", SvcName: "svc",
+	}, nil)
+	rc.imageRunner.EXPECT().ListKsvcVersions(ctx, "cluster", "svc").Return([]types.KsvcRevisionInfo{
+		{Commit: "aaaaaaa", TrafficPercent: 95, IsReady: true},
+		{Commit: "bbbbbbb", TrafficPercent: 5, IsReady: true},
+	}, nil)
+	rc.imageRunner.EXPECT().SetVersionsTraffic(ctx, "cluster", "svc", []types.TrafficReq{
+		{Commit: "bbbbbbb", TrafficPercent: 25},
+		{Commit: "aaaaaaa", TrafficPercent: 75},
+	}).Return(nil)
+	stores.InferenceRolloutMock().EXPECT().Update(ctx, mock.MatchedBy(func(r *database.InferenceRollout) bool {
+		return r.CurrentStep == 1 && r.CurrentTraffic == 25
+	})).Return(nil)
+
+	err := rc.ShiftRolloutTraffic(ctx, 2, 1)
+	require.Nil(t, err)
+}
+
+func TestInferenceRolloutComponent_CheckRolloutHealth(t *testing.T) {
+	ctx := context.TODO()
+	rc := initializeTestInferenceRolloutComponent(ctx, t)
+	stores := rc.repo.mocks.stores
+
+	stores.InferenceRolloutMock().EXPECT().FindByID(ctx, int64(2)).Return(&database.InferenceRollout{
+		ID: 2, DeployID: 1, CanaryCommit: "bbbbbbb", StepInterval: 60, MaxErrorRate: 0.05, MaxLatencyMs: 500,
+	}, nil)
+	stores.DeployTaskMock().EXPECT().GetDeployByID(ctx, int64(1)).Return(&database.Deploy{
+		ID: 1, ClusterID: "cluster", SvcName: "svc",
+	}, nil)
+	rc.imageRunner.EXPECT().ListKsvcVersions(ctx, "cluster", "svc").Return([]types.KsvcRevisionInfo{
+		{Commit: "bbbbbbb", RevisionName: "svc-00002", IsReady: true},
+	}, nil)
+	sample := func(v string) *types.PrometheusResponse {
+		return &types.PrometheusResponse{Data: types.PrometheusData{Result: []types.PrometheusResult{{Value: []any{1.0, v}}}}}
+	}
+	rc.promClient.EXPECT().QueryInstant(ctx,
+		"sum(increase(revision_request_count{namespace='spaces',revision_name='svc-00002'}[60s]))", mock.Anything,
+	).Return(sample("200"), nil)
+	rc.promClient.EXPECT().QueryInstant(ctx,
+		"sum(increase(revision_request_count{namespace='spaces',revision_name='svc-00002',response_code_class='5xx'}[60s]))", mock.Anything,
+	).Return(sample("20"), nil)
+	rc.promClient.EXPECT().QueryInstant(ctx,
+		"histogram_quantile(0.95, sum by (le) (rate(revision_app_request_latencies_bucket{namespace='spaces',revision_name='svc-00002'}[60s])))", mock.Anything,
+	).Return(sample("NaN"), nil)
+	stores.InferenceRolloutMock().EXPECT().Update(ctx, mock.MatchedBy(func(r *database.InferenceRollout) bool {
+		return r.ErrorRate == 0.1 && r.LatencyMs == 0
+	})).Return(nil)
+
+	health, err := rc.CheckRolloutHealth(ctx, 2)
+	require.Nil(t, err)
+	require.False(t, health.Healthy)
+	require.Equal(t, 0.1, health.ErrorRate)
+	require.Contains(t, health.Reason, "error rate")
+}
+
+func TestInferenceRolloutComponent_FinishRollout(t *testing.T) {
+	ctx := context.TODO()
+	rc := initializeTestInferenceRolloutComponent(ctx, t)
+	stores := rc.repo.mocks.stores
+
+	stores.InferenceRolloutMock().EXPECT().FindByID(ctx, int64(2)).Return(&database.InferenceRollout{
+		ID: 2, DeployID: 1, StableCommit: "aaaaaaa", CanaryCommit: "bbbbbbb", CurrentTraffic: 25,
+		Status: types.InferenceRolloutRunning,
+	}, nil)
+	stores.DeployTaskMock().EXPECT().GetDeployByID(ctx, int64(1)).Return(&database.Deploy{
+		ID: 1, ClusterID: "cluster", SvcName: "svc",
+	}, nil)
+	rc.imageRunner.EXPECT().SetVersionsTraffic(ctx, "cluster", "svc", []types.TrafficReq{
+		{Commit: "bbbbbbb", TrafficPercent: 0},
+		{Commit: "aaaaaaa", TrafficPercent: 100},
+	}).Return(nil)
+	stores.InferenceRolloutMock().EXPECT().Update(ctx, mock.MatchedBy(func(r *database.InferenceRollout) bool {
+		return r.Status == types.InferenceRolloutRolledBack && r.CurrentTraffic == 0 && r.Reason == "too slow"
+	})).Return(nil)
+
+	err := rc.FinishRollout(ctx, 2, types.InferenceRolloutRolledBack, "too slow")
+	require.Nil(t, err)
+}
@@ -0,0 +1,310 @@
+package component
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"opencsg.com/csghub-server/builder/git"
+	"opencsg.com/csghub-server/builder/git/gitserver"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/builder/store/s3"
+	"opencsg.com/csghub-server/common/config"
+	"opencsg.com/csghub-server/common/types"
+	"opencsg.com/csghub-server/common/utils/common"
+)
+
+const defaultLfsGCBatchSize = 1000
+
+// lfsGCPrefixes are the storage prefixes of the LFS objects,
+// see common.BuildLfsPath for the layout of shared and migrated objects
+var lfsGCPrefixes = []string{"lfs/", "repos/"}
+
+var (
+	sharedLfsKeyRegexp   = regexp.MustCompile(`^lfs/([0-9a-f]{2})/([0-9a-f]{2})/([0-9a-f]{60})$`)
+	migratedLfsKeyRegexp = regexp.MustCompile(`^repos/[0-9a-f]{2}/[0-9a-f]{2}/[0-9a-f]{64}/([0-9a-f]{64})$`)
+)
+
+type LfsGCComponent interface {
+	// RunGC deletes the LFS objects which stayed unreferenced by all repositories for the grace period
+	RunGC(ctx context.Context, req types.LfsGCReq) (*types.LfsGCReport, error)
+}
+
+type lfsGCComponentImpl struct {
+	config        *config.Config
+	repoStore     database.RepoStore
+	gcObjectStore database.LfsGCObjectStore
+	gitServer     gitserver.GitServer
+	s3Client      s3.Client
+}
+
+func NewLfsGCComponent(config *config.Config) (LfsGCComponent, error) {
+	gitServer, err := git.NewGitServer(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create git server, error: %w", err)
+	}
+	s3Client, err := s3.NewMinio(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to init s3 client, error: %w", err)
+	}
+	return &lfsGCComponentImpl{
+		config:        config,
+		repoStore:     database.NewRepoStore(),
+		gcObjectStore: database.NewLfsGCObjectStore(),
+		gitServer:     gitServer,
+		s3Client:      s3Client,
+	}, nil
+}
+
+// lfsReferences are the object keys reachable from the git history of the scanned repositories
+type lfsReferences struct {
+	keys map[string]struct{}
+	// repoIDs maps the key prefix of migrated repositories to their ids
+	repoIDs map[string]int64
+	// protectedPrefixes are the key prefixes of migrated repositories which failed to be scanned
+	protectedPrefixes []string
+	// keepShared is set when an unmigrated repository failed to be scanned,
+	// the owners of shared objects are unknown then
+	keepShared bool
+}
+
+func (c *lfsGCComponentImpl) RunGC(ctx context.Context, req types.LfsGCReq) (*types.LfsGCReport, error) {
+	gracePeriod := req.GracePeriod
+	if gracePeriod <= 0 {
+		gracePeriod = time.Duration(c.config.LfsGC.GracePeriodHours) * time.Hour
+	}
+	now := time.Now()
+	cutoff := now.Add(-gracePeriod)
+	report := &types.LfsGCReport{
+		DryRun:    req.DryRun,
+		StartedAt: now,
+	}
+
+	refs, err := c.collectReferences(ctx, report)
+	if err != nil {
+		return nil, err
+	}
+	unreferenced, err := c.findUnreferenced(ctx, refs, report)
+	if err != nil {
+		return nil, err
+	}
+
+	marked, err := c.gcObjectStore.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find marked lfs objects, error: %w", err)
+	}
+	var toDelete []database.LfsGCObject
+	var toUnmark []string
+	for _, m := range marked {
+		if _, ok := unreferenced[m.ObjectKey]; !ok {
+			toUnmark = append(toUnmark, m.ObjectKey)
+			continue
+		}
+		delete(unreferenced, m.ObjectKey)
+		if !m.MarkedAt.After(cutoff) {
+			toDelete = append(toDelete, m)
+		}
+	}
+	// the objects left are unreferenced for the first time
+	toMark := make([]database.LfsGCObject, 0, len(unreferenced))
+	for _, obj := range unreferenced {
+		obj.MarkedAt = now
+		toMark = append(toMark, obj)
+	}
+	report.NewlyMarked = len(toMark)
+	report.Unmarked = len(toUnmark)
+
+	if req.DryRun {
+		for _, obj := range toDelete {
+			report.DeletedObjects = append(report.DeletedObjects, toLfsGCObject(obj))
+			report.DeletedBytes += obj.Size
+		}
+		report.FinishedAt = time.Now()
+		return report, nil
+	}
+
+	batchSize := c.batchSize()
+	for start := 0; start < len(toMark); start += batchSize {
+		err = c.gcObjectStore.BatchCreate(ctx, toMark[start:min(start+batchSize, len(toMark))])
+		if err != nil {
+			return nil, err
+		}
+	}
+	for start := 0; start < len(toUnmark); start += batchSize {
+		err = c.gcObjectStore.DeleteByObjectKeys(ctx, toUnmark[start:min(start+batchSize, len(toUnmark))])
+		if err != nil {
+			return nil, err
+		}
+	}
+	c.deleteObjects(ctx, toDelete, report)
+	report.FinishedAt = time.Now()
+	return report, nil
+}
+
+func (c *lfsGCComponentImpl) batchSize() int {
+	if c.config.LfsGC.BatchSize > 0 {
+		return c.config.LfsGC.BatchSize
+	}
+	return defaultLfsGCBatchSize
+}
+
+// collectReferences lists the LFS pointers in all objects of every repository, including the
+// ones only reachable from deleted branches or old commits, so forks keep the objects they share
+func (c *lfsGCComponentImpl) collectReferences(ctx context.Context, report *types.LfsGCReport) (*lfsReferences, error) {
+	refs := &lfsReferences{
+		keys:    make(map[string]struct{}),
+		repoIDs: make(map[string]int64),
+	}
+	var lastID int64
+	for {
+		repos, err := c.repoStore.FindAfterID(ctx, c.batchSize(), lastID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find repositories, error: %w", err)
+		}
+		if len(repos) == 0 {
+			break
+		}
+		lastID = repos[len(repos)-1].ID
+
+		for _, repo := range repos {
+			report.ScannedRepos++
+			// the key prefix of migrated repositories, an empty oid leaves the trailing slash
+			repoPrefix := common.BuildLfsPath(repo.ID, "", true)
+			if repo.Migrated {
+				refs.repoIDs[repoPrefix] = repo.ID
+			}
+			pointers, err := c.listPointers(ctx, repo)
+			if err != nil {
+				slog.ErrorContext(ctx, "failed to list lfs pointers of repository, keep its objects",
+					slog.String("repo", repo.Path), slog.Any("error", err))
+				report.FailedRepos = append(report.FailedRepos, repo.Path)
+				if repo.Migrated {
+					refs.protectedPrefixes = append(refs.protectedPrefixes, repoPrefix)
+				} else {
+					refs.keepShared = true
+				}
+				continue
+			}
+			for _, p := range pointers {
+				if len(p.FileOid) != 64 {
+					continue
+				}
+				refs.keys[common.BuildLfsPath(repo.ID, p.FileOid, repo.Migrated)] = struct{}{}
+			}
+		}
+	}
+	report.ReferencedObjects = len(refs.keys)
+	return refs, nil
+}
+
+func (c *lfsGCComponentImpl) listPointers(ctx context.Context, repo database.Repository) ([]*types.LFSPointer, error) {
+	namespace, name, found := strings.Cut(repo.Path, "/")
+	if !found {
+		return nil, fmt.Errorf("invalid repository path '%s'", repo.Path)
+	}
+	return c.gitServer.GetRepoAllLfsPointers(ctx, gitserver.GetRepoAllFilesReq{
+		Namespace: namespace,
+		Name:      name,
+		Ref:       repo.DefaultBranch,
+		RepoType:  repo.RepositoryType,
+	})
+}
+
+// findUnreferenced lists all LFS objects in the storage and returns the unreferenced ones by key
+func (c *lfsGCComponentImpl) findUnreferenced(ctx context.Context, refs *lfsReferences, report *types.LfsGCReport) (map[string]database.LfsGCObject, error) {
+	unreferenced := make(map[string]database.LfsGCObject)
+	for _, prefix := range lfsGCPrefixes {
+		objectsCh := c.s3Client.ListObjects(ctx, c.config.S3.Bucket, minio.ListObjectsOptions{
+			Prefix:    prefix,
+			Recursive: true,
+		})
+		for obj := range objectsCh {
+			if obj.Err != nil {
+				return nil, fmt.Errorf("failed to list lfs objects, error: %w", obj.Err)
+			}
+			gcObj, ok := c.parseObjectKey(obj.Key, refs)
+			// not an LFS object, e.g. the temporary uploads
+			if !ok {
+				continue
+			}
+			report.ScannedObjects++
+			report.ScannedBytes += obj.Size
+			if _, referenced := refs.keys[obj.Key]; referenced || refs.isProtected(obj.Key) {
+				continue
+			}
+			gcObj.Size = obj.Size
+			unreferenced[obj.Key] = gcObj
+			report.UnreferencedObjects++
+			report.UnreferencedBytes += obj.Size
+		}
+	}
+	return unreferenced, nil
+}
+
+func (c *lfsGCComponentImpl) parseObjectKey(key string, refs *lfsReferences) (database.LfsGCObject, bool) {
+	if m := sharedLfsKeyRegexp.FindStringSubmatch(key); m != nil {
+		return database.LfsGCObject{ObjectKey: key, Oid: m[1] + m[2] + m[3]}, true
+	}
+	if m := migratedLfsKeyRegexp.FindStringSubmatch(key); m != nil {
+		return database.LfsGCObject{
+			ObjectKey: key,
+			Oid:       m[1],
+			// unknown if the repository is deleted
+			RepositoryID: refs.repoIDs[strings.TrimSuffix(key, m[1])],
+		}, true
+	}
+	return database.LfsGCObject{}, false
+}
+
+func (r *lfsReferences) isProtected(key string) bool {
+	if strings.HasPrefix(key, "lfs/") {
+		return r.keepShared
+	}
+	for _, prefix := range r.protectedPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// deleteObjects removes a batch of objects from the storage together with their meta objects,
+// so they are uploaded again instead of being taken as existing. The objects are unreferenced by
+// the git history scanned in this run, the ones uploaded again after the scan are kept by their
+// meta objects. An object is removed from the storage only after its meta objects are deleted,
+// if the removal fails it's found unreferenced again by the next run.
+func (c *lfsGCComponentImpl) deleteObjects(ctx context.Context, objects []database.LfsGCObject, report *types.LfsGCReport) {
+	for _, obj := range objects {
+		deleted, err := c.gcObjectStore.DeleteUnreferenced(ctx, obj)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to delete lfs object", slog.String("key", obj.ObjectKey), slog.Any("error", err))
+			report.FailedDeletions++
+			continue
+		}
+		if !deleted {
+			report.Unmarked++
+			continue
+		}
+		err = c.s3Client.RemoveObject(ctx, c.config.S3.Bucket, obj.ObjectKey, minio.RemoveObjectOptions{})
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to remove lfs object from storage", slog.String("key", obj.ObjectKey), slog.Any("error", err))
+			report.FailedDeletions++
+			continue
+		}
+		report.DeletedObjects = append(report.DeletedObjects, toLfsGCObject(obj))
+		report.DeletedBytes += obj.Size
+	}
+}
+
+func toLfsGCObject(obj database.LfsGCObject) types.LfsGCObject {
+	return types.LfsGCObject{
+		ObjectKey: obj.ObjectKey,
+		Oid:       obj.Oid,
+		Size:      obj.Size,
+		MarkedAt:  obj.MarkedAt,
+	}
+}
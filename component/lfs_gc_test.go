@@ -0,0 +1,197 @@
+package component
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	mockgit "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/builder/git/gitserver"
+	mocks3 "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/builder/store/s3"
+	"opencsg.com/csghub-server/builder/git/gitserver"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/tests"
+	"opencsg.com/csghub-server/common/types"
+	"opencsg.com/csghub-server/common/utils/common"
+)
+
+type testLfsGCWithMocks struct {
+	*lfsGCComponentImpl
+	stores    *tests.MockStores
+	gitServer *mockgit.MockGitServer
+	s3Client  *mocks3.MockClient
+}
+
+func initializeTestLfsGCComponent(t *testing.T) *testLfsGCWithMocks {
+	config := ProvideTestConfig()
+	config.LfsGC.GracePeriodHours = 24
+	config.S3.Bucket = "bucket"
+	stores := tests.NewMockStores(t)
+	gitServer := mockgit.NewMockGitServer(t)
+	s3Client := mocks3.NewMockClient(t)
+	return &testLfsGCWithMocks{
+		lfsGCComponentImpl: &lfsGCComponentImpl{
+			config:        config,
+			repoStore:     stores.Repo,
+			gcObjectStore: stores.LfsGCObject,
+			gitServer:     gitServer,
+			s3Client:      s3Client,
+		},
+		stores:    stores,
+		gitServer: gitServer,
+		s3Client:  s3Client,
+	}
+}
+
+func lfsGCTestOid(c string) string {
+	return strings.Repeat(c, 64)
+}
+
+func objectInfoCh(objects ...minio.ObjectInfo) <-chan minio.ObjectInfo {
+	ch := make(chan minio.ObjectInfo, len(objects))
+	for _, obj := range objects {
+		ch <- obj
+	}
+	close(ch)
+	return ch
+}
+
+// mockLfsGCScan sets up an unmigrated repository referencing oid a and a migrated repository referencing oid b,
+// the storage also has the unreferenced objects c, d and e
+func mockLfsGCScan(ctx context.Context, gc *testLfsGCWithMocks, repo1Err error) {
+	gc.stores.RepoMock().EXPECT().FindAfterID(ctx, defaultLfsGCBatchSize, int64(0)).Return([]database.Repository{
+		{ID: 1, Path: "ns/r1", RepositoryType: types.ModelRepo, DefaultBranch: "main"},
+		{ID: 2, Path: "ns/r2", RepositoryType: types.DatasetRepo, DefaultBranch: "main", Migrated: true},
+	}, nil)
+	gc.stores.RepoMock().EXPECT().FindAfterID(ctx, defaultLfsGCBatchSize, int64(2)).Return(nil, nil)
+	gc.gitServer.EXPECT().GetRepoAllLfsPointers(ctx, gitserver.GetRepoAllFilesReq{
+		Namespace: "ns", Name: "r1", Ref: "main", RepoType: types.ModelRepo,
+	}).Return([]*types.LFSPointer{{FileOid: lfsGCTestOid("a")}}, repo1Err)
+	gc.gitServer.EXPECT().GetRepoAllLfsPointers(ctx, gitserver.GetRepoAllFilesReq{
+		Namespace: "ns", Name: "r2", Ref: "main", RepoType: types.DatasetRepo,
+	}).Return([]*types.LFSPointer{{FileOid: lfsGCTestOid("b")}}, nil)
+
+	gc.s3Client.EXPECT().ListObjects(ctx, "bucket", minio.ListObjectsOptions{Prefix: "lfs/", Recursive: true}).Return(objectInfoCh(
+		minio.ObjectInfo{Key: common.BuildLfsPath(1, lfsGCTestOid("a"), false), Size: 1},
+		minio.ObjectInfo{Key: common.BuildLfsPath(1, lfsGCTestOid("c"), false), Size: 3},
+		minio.ObjectInfo{Key: common.BuildLfsPath(1, lfsGCTestOid("d"), false), Size: 4},
+		minio.ObjectInfo{Key: "lfs/tmp/upload", Size: 100},
+	))
+	gc.s3Client.EXPECT().ListObjects(ctx, "bucket", minio.ListObjectsOptions{Prefix: "repos/", Recursive: true}).Return(objectInfoCh(
+		minio.ObjectInfo{Key: common.BuildLfsPath(2, lfsGCTestOid("b"), true), Size: 2},
+		minio.ObjectInfo{Key: common.BuildLfsPath(2, lfsGCTestOid("e"), true), Size: 5},
+	))
+
+	gc.stores.LfsGCObjectMock().EXPECT().FindAll(ctx).Return([]database.LfsGCObject{
+		{ObjectKey: common.BuildLfsPath(1, lfsGCTestOid("c"), false), Oid: lfsGCTestOid("c"), Size: 3, MarkedAt: time.Now().Add(-48 * time.Hour)},
+		{ObjectKey: common.BuildLfsPath(2, lfsGCTestOid("e"), true), Oid: lfsGCTestOid("e"), RepositoryID: 2, Size: 5, MarkedAt: time.Now().Add(-time.Hour)},
+		{ObjectKey: common.BuildLfsPath(1, lfsGCTestOid("f"), false), Oid: lfsGCTestOid("f"), MarkedAt: time.Now().Add(-48 * time.Hour)},
+	}, nil)
+}
+
+func TestLfsGCComponent_RunGC(t *testing.T) {
+	ctx := context.TODO()
+	gc := initializeTestLfsGCComponent(t)
+	mockLfsGCScan(ctx, gc, nil)
+
+	keyC := common.BuildLfsPath(1, lfsGCTestOid("c"), false)
+	gc.stores.LfsGCObjectMock().EXPECT().BatchCreate(ctx, mock.MatchedBy(func(objects []database.LfsGCObject) bool {
+		return len(objects) == 1 && objects[0].Oid == lfsGCTestOid("d") && objects[0].Size == 4 && !objects[0].MarkedAt.IsZero()
+	})).Return(nil)
+	gc.stores.LfsGCObjectMock().EXPECT().DeleteByObjectKeys(ctx, []string{common.BuildLfsPath(1, lfsGCTestOid("f"), false)}).Return(nil)
+	gc.stores.LfsGCObjectMock().EXPECT().DeleteUnreferenced(ctx, mock.MatchedBy(func(obj database.LfsGCObject) bool {
+		return obj.ObjectKey == keyC
+	})).Return(true, nil)
+	gc.s3Client.EXPECT().RemoveObject(ctx, "bucket", keyC, minio.RemoveObjectOptions{}).Return(nil)
+
+	report, err := gc.RunGC(ctx, types.LfsGCReq{})
+	require.Nil(t, err)
+	require.Equal(t, 2, report.ScannedRepos)
+	require.Equal(t, 2, report.ReferencedObjects)
+	require.Equal(t, 5, report.ScannedObjects)
+	require.Equal(t, 3, report.UnreferencedObjects)
+	require.Equal(t, int64(12), report.UnreferencedBytes)
+	require.Equal(t, 1, report.NewlyMarked)
+	require.Equal(t, 1, report.Unmarked)
+	require.Equal(t, 1, len(report.DeletedObjects))
+	require.Equal(t, keyC, report.DeletedObjects[0].ObjectKey)
+	require.Equal(t, int64(3), report.DeletedBytes)
+}
+
+func TestLfsGCComponent_RunGCKeepsObjectsReferencedAgain(t *testing.T) {
+	ctx := context.TODO()
+	gc := initializeTestLfsGCComponent(t)
+	mockLfsGCScan(ctx, gc, nil)
+
+	keyC := common.BuildLfsPath(1, lfsGCTestOid("c"), false)
+	gc.stores.LfsGCObjectMock().EXPECT().BatchCreate(ctx, mock.Anything).Return(nil)
+	gc.stores.LfsGCObjectMock().EXPECT().DeleteByObjectKeys(ctx, []string{common.BuildLfsPath(1, lfsGCTestOid("f"), false)}).Return(nil)
+	// c was uploaded to the new repository 3 after the scan, so it's only unmarked
+	gc.stores.LfsGCObjectMock().EXPECT().DeleteUnreferenced(ctx, mock.MatchedBy(func(obj database.LfsGCObject) bool {
+		return obj.ObjectKey == keyC
+	})).Return(false, nil)
+
+	report, err := gc.RunGC(ctx, types.LfsGCReq{})
+	require.Nil(t, err)
+	require.Equal(t, 2, report.Unmarked)
+	require.Empty(t, report.DeletedObjects)
+}
+
+func TestLfsGCComponent_RunGCRemoveObjectFailed(t *testing.T) {
+	ctx := context.TODO()
+	gc := initializeTestLfsGCComponent(t)
+	mockLfsGCScan(ctx, gc, nil)
+
+	keyC := common.BuildLfsPath(1, lfsGCTestOid("c"), false)
+	gc.stores.LfsGCObjectMock().EXPECT().BatchCreate(ctx, mock.Anything).Return(nil)
+	gc.stores.LfsGCObjectMock().EXPECT().DeleteByObjectKeys(ctx, mock.Anything).Return(nil)
+	gc.stores.LfsGCObjectMock().EXPECT().DeleteUnreferenced(ctx, mock.Anything).Return(true, nil)
+	// the object left in the storage is found unreferenced again by the next run
+	gc.s3Client.EXPECT().RemoveObject(ctx, "bucket", keyC, minio.RemoveObjectOptions{}).Return(errors.New("storage unavailable"))
+
+	report, err := gc.RunGC(ctx, types.LfsGCReq{})
+	require.Nil(t, err)
+	require.Equal(t, 1, report.FailedDeletions)
+	require.Empty(t, report.DeletedObjects)
+}
+
+func TestLfsGCComponent_RunGCDryRun(t *testing.T) {
+	ctx := context.TODO()
+	gc := initializeTestLfsGCComponent(t)
+	mockLfsGCScan(ctx, gc, nil)
+
+	report, err := gc.RunGC(ctx, types.LfsGCReq{DryRun: true})
+	require.Nil(t, err)
+	require.True(t, report.DryRun)
+	require.Equal(t, 1, report.NewlyMarked)
+	require.Equal(t, 1, report.Unmarked)
+	require.Equal(t, 1, len(report.DeletedObjects))
+	require.Equal(t, lfsGCTestOid("c"), report.DeletedObjects[0].Oid)
+
+	// a shorter grace period makes the recently marked object deletable too
+	gc = initializeTestLfsGCComponent(t)
+	mockLfsGCScan(ctx, gc, nil)
+	report, err = gc.RunGC(ctx, types.LfsGCReq{DryRun: true, GracePeriod: time.Minute})
+	require.Nil(t, err)
+	require.Equal(t, 2, len(report.DeletedObjects))
+	require.Equal(t, int64(8), report.DeletedBytes)
+}
+
+func TestLfsGCComponent_RunGCKeepsSharedObjectsOfFailedRepos(t *testing.T) {
+	ctx := context.TODO()
+	gc := initializeTestLfsGCComponent(t)
+	mockLfsGCScan(ctx, gc, errors.New("gitaly unavailable"))
+
+	report, err := gc.RunGC(ctx, types.LfsGCReq{DryRun: true})
+	require.Nil(t, err)
+	require.Equal(t, []string{"ns/r1"}, report.FailedRepos)
+	// only the migrated object e is unreferenced, and it's not marked long enough
+	require.Equal(t, 1, report.UnreferencedObjects)
+	require.Equal(t, 0, report.NewlyMarked)
+	require.Equal(t, 2, report.Unmarked)
+	require.Empty(t, report.DeletedObjects)
+}
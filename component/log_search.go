@@ -0,0 +1,179 @@
+package component
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"opencsg.com/csghub-server/builder/rpc"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/config"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+	"opencsg.com/csghub-server/component/reporter/sender"
+)
+
+// LogSearchComponent searches and downloads the logs of the deploys and the workflows from the
+// configured log backend
+type LogSearchComponent interface {
+	SearchDeployLogs(ctx context.Context, req *types.LogSearchReq) (*types.LogSearchResult, error)
+	// DownloadDeployLogs returns all the logs of the deploy matched by the filters in gzip
+	DownloadDeployLogs(ctx context.Context, req *types.LogSearchReq) (io.ReadCloser, error)
+	SearchWorkflowLogs(ctx context.Context, req *types.LogSearchReq) (*types.LogSearchResult, error)
+	// DownloadWorkflowLogs returns all the logs of the workflow matched by the filters in gzip
+	DownloadWorkflowLogs(ctx context.Context, req *types.LogSearchReq) (io.ReadCloser, error)
+}
+
+type logSearchComponentImpl struct {
+	logSender       sender.LogSender
+	deployTaskStore database.DeployTaskStore
+	workflowStore   database.ArgoWorkFlowStore
+	userSvcClient   rpc.UserSvcClient
+}
+
+func NewLogSearchComponent(config *config.Config) (LogSearchComponent, error) {
+	logSender, err := sender.NewLogSender(types.ClientTypeCSGHUB, config)
+	if logSender == nil {
+		return nil, fmt.Errorf("failed to create log sender: %w", err)
+	}
+	if err != nil {
+		slog.Error("failed to create log sender", slog.String("backend", config.LogCollector.Backend), slog.Any("error", err))
+	}
+	userSvcAddr := fmt.Sprintf("%s:%d", config.User.Host, config.User.Port)
+	return &logSearchComponentImpl{
+		logSender:       logSender,
+		deployTaskStore: database.NewDeployTaskStore(),
+		workflowStore:   database.NewArgoWorkFlowStore(),
+		userSvcClient:   rpc.NewUserSvcHttpClient(userSvcAddr, rpc.AuthWithApiKey(config.APIToken)),
+	}, nil
+}
+
+func (c *logSearchComponentImpl) SearchDeployLogs(ctx context.Context, req *types.LogSearchReq) (*types.LogSearchResult, error) {
+	q, err := c.deployLogQuery(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return c.search(ctx, q)
+}
+
+func (c *logSearchComponentImpl) DownloadDeployLogs(ctx context.Context, req *types.LogSearchReq) (io.ReadCloser, error) {
+	q, err := c.deployLogQuery(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return c.download(ctx, q)
+}
+
+func (c *logSearchComponentImpl) SearchWorkflowLogs(ctx context.Context, req *types.LogSearchReq) (*types.LogSearchResult, error) {
+	q, err := c.workflowLogQuery(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return c.search(ctx, q)
+}
+
+func (c *logSearchComponentImpl) DownloadWorkflowLogs(ctx context.Context, req *types.LogSearchReq) (io.ReadCloser, error) {
+	q, err := c.workflowLogQuery(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return c.download(ctx, q)
+}
+
+// deployLogQuery selects the run logs of the deploy or its image build logs, the logs are searched
+// from the creation of the deploy by default
+func (c *logSearchComponentImpl) deployLogQuery(ctx context.Context, req *types.LogSearchReq) (*types.LogSearchQuery, error) {
+	deploy, err := c.deployTaskStore.GetDeployByID(ctx, req.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deploy %d, error: %w", req.ID, err)
+	}
+	_, err = checkOwnerOrOrgMemberPermission(ctx, c.userSvcClient, req.CurrentUser, deploy.UserUUID)
+	if err != nil {
+		return nil, errorx.Forbidden(err, errorx.Ctx().Set("deploy_id", req.ID))
+	}
+
+	logType := types.LogLabelDeploy
+	if req.LogType == types.LogSearchTypeBuild {
+		logType = types.LogLabelImageBuilder
+	}
+	labels := map[string]string{
+		types.LogLabelTypeKey:   logType,
+		types.StreamKeyDeployID: strconv.FormatInt(deploy.ID, 10),
+	}
+	if req.CommitID != "" {
+		labels[types.StreamKeyDeployCommitID] = req.CommitID
+	}
+	return newLogSearchQuery(req, labels, deploy.CreatedAt)
+}
+
+// workflowLogQuery selects the logs of the workflow pod, the logs are searched from the
+// submission of the workflow by default
+func (c *logSearchComponentImpl) workflowLogQuery(ctx context.Context, req *types.LogSearchReq) (*types.LogSearchQuery, error) {
+	wf, err := c.workflowStore.FindByID(ctx, req.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workflow %d, error: %w", req.ID, errorx.HandleDBError(err, errorx.Ctx().Set("workflow_id", req.ID)))
+	}
+	_, err = checkOwnerOrOrgMemberPermission(ctx, c.userSvcClient, req.CurrentUser, wf.UserUUID)
+	if err != nil {
+		return nil, errorx.Forbidden(err, errorx.Ctx().Set("workflow_id", req.ID))
+	}
+
+	labels := map[string]string{
+		types.StreamKeyInstanceName: wf.TaskId,
+	}
+	return newLogSearchQuery(req, labels, wf.SubmitTime)
+}
+
+func newLogSearchQuery(req *types.LogSearchReq, labels map[string]string, start time.Time) (*types.LogSearchQuery, error) {
+	q := &types.LogSearchQuery{
+		Labels:    labels,
+		Start:     req.Start,
+		End:       req.End,
+		Text:      req.Text,
+		Regex:     req.Regex,
+		Level:     req.Level,
+		Pod:       req.Pod,
+		Limit:     req.Limit,
+		Cursor:    req.Cursor,
+		Direction: req.Direction,
+	}
+	if q.Start.IsZero() {
+		q.Start = start
+	}
+	err := sender.NormalizeSearchQuery(q)
+	if err != nil {
+		return nil, errorx.BadRequest(err, errorx.Ctx().Set("id", req.ID))
+	}
+	return q, nil
+}
+
+func (c *logSearchComponentImpl) search(ctx context.Context, q *types.LogSearchQuery) (*types.LogSearchResult, error) {
+	result, err := c.logSender.SearchLogs(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search logs, error: %w", err)
+	}
+	return result, nil
+}
+
+// download writes all the pages of the query in the gzip stream, the pages always go forward
+func (c *logSearchComponentImpl) download(ctx context.Context, q *types.LogSearchQuery) (io.ReadCloser, error) {
+	q.Cursor = ""
+	q.Direction = types.LogSearchForward
+	pr, pw := io.Pipe()
+	go func() {
+		gz := gzip.NewWriter(pw)
+		err := sender.ExportLogs(ctx, c.logSender, q, gz)
+		if err == nil {
+			err = gz.Close()
+		}
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to write log download", slog.Any("labels", q.Labels), slog.Any("error", err))
+		}
+		pw.CloseWithError(err)
+	}()
+	return pr, nil
+}
@@ -0,0 +1,126 @@
+package component
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	mockrpc "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/builder/rpc"
+	mockdb "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/builder/store/database"
+	mocksender "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/component/reporter/sender"
+	"opencsg.com/csghub-server/builder/rpc"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+)
+
+type testLogSearchComponent struct {
+	*logSearchComponentImpl
+	logSender       *mocksender.MockLogSender
+	deployTaskStore *mockdb.MockDeployTaskStore
+	workflowStore   *mockdb.MockArgoWorkFlowStore
+	userSvcClient   *mockrpc.MockUserSvcClient
+}
+
+func newTestLogSearchComponent(t *testing.T) *testLogSearchComponent {
+	c := &testLogSearchComponent{
+		logSender:       mocksender.NewMockLogSender(t),
+		deployTaskStore: mockdb.NewMockDeployTaskStore(t),
+		workflowStore:   mockdb.NewMockArgoWorkFlowStore(t),
+		userSvcClient:   mockrpc.NewMockUserSvcClient(t),
+	}
+	c.logSearchComponentImpl = &logSearchComponentImpl{
+		logSender:       c.logSender,
+		deployTaskStore: c.deployTaskStore,
+		workflowStore:   c.workflowStore,
+		userSvcClient:   c.userSvcClient,
+	}
+	return c
+}
+
+func (c *testLogSearchComponent) expectOwner(ctx context.Context, username, uuid string) {
+	c.userSvcClient.EXPECT().GetUserByName(ctx, username).Return(&types.User{UUID: uuid, Username: username}, nil)
+	c.userSvcClient.EXPECT().GetNameSpaceInfoByUUID(ctx, uuid).Return(&rpc.Namespace{UUID: uuid, NSType: "user"}, nil)
+}
+
+func TestLogSearchComponent_SearchDeployLogs(t *testing.T) {
+	ctx := context.TODO()
+	c := newTestLogSearchComponent(t)
+	createdAt := time.Now().Add(-time.Hour)
+	deploy := &database.Deploy{ID: 1, UserUUID: "uuid"}
+	deploy.CreatedAt = createdAt
+	c.deployTaskStore.EXPECT().GetDeployByID(ctx, int64(1)).Return(deploy, nil)
+	c.expectOwner(ctx, "user", "uuid")
+
+	expected := &types.LogSearchResult{Entries: []types.LogSearchEntry{{Line: "ERROR failed"}}}
+	c.logSender.EXPECT().SearchLogs(ctx, mock.MatchedBy(func(q *types.LogSearchQuery) bool {
+		return q.Labels[types.LogLabelTypeKey] == types.LogLabelImageBuilder &&
+			q.Labels[types.StreamKeyDeployID] == "1" &&
+			q.Labels[types.StreamKeyDeployCommitID] == "abc" &&
+			q.Start.Equal(createdAt) && q.Level == types.LogLevelError && q.Limit == 10
+	})).Return(expected, nil)
+
+	result, err := c.SearchDeployLogs(ctx, &types.LogSearchReq{
+		ID: 1, CurrentUser: "user", LogType: types.LogSearchTypeBuild, CommitID: "abc", Level: types.LogLevelError, Limit: 10,
+	})
+	require.NoError(t, err)
+	require.Equal(t, expected, result)
+}
+
+func TestLogSearchComponent_SearchDeployLogsForbidden(t *testing.T) {
+	ctx := context.TODO()
+	c := newTestLogSearchComponent(t)
+	c.deployTaskStore.EXPECT().GetDeployByID(ctx, int64(1)).Return(&database.Deploy{ID: 1, UserUUID: "owner"}, nil)
+	c.userSvcClient.EXPECT().GetUserByName(ctx, "user").Return(&types.User{UUID: "uuid", Username: "user"}, nil)
+	c.userSvcClient.EXPECT().GetNameSpaceInfoByUUID(ctx, "owner").Return(&rpc.Namespace{UUID: "owner", NSType: "user"}, nil)
+
+	_, err := c.SearchDeployLogs(ctx, &types.LogSearchReq{ID: 1, CurrentUser: "user"})
+	require.True(t, errors.Is(err, errorx.ErrForbidden))
+}
+
+func TestLogSearchComponent_SearchWorkflowLogsInvalidRegex(t *testing.T) {
+	ctx := context.TODO()
+	c := newTestLogSearchComponent(t)
+	c.workflowStore.EXPECT().FindByID(ctx, int64(2)).Return(database.ArgoWorkflow{ID: 2, UserUUID: "uuid", TaskId: "task"}, nil)
+	c.expectOwner(ctx, "user", "uuid")
+
+	_, err := c.SearchWorkflowLogs(ctx, &types.LogSearchReq{ID: 2, CurrentUser: "user", Regex: "("})
+	require.True(t, errors.Is(err, errorx.ErrBadRequest))
+}
+
+func TestLogSearchComponent_DownloadWorkflowLogs(t *testing.T) {
+	ctx := context.TODO()
+	c := newTestLogSearchComponent(t)
+	submitTime := time.Now().Add(-time.Hour).UTC()
+	c.workflowStore.EXPECT().FindByID(ctx, int64(2)).Return(database.ArgoWorkflow{ID: 2, UserUUID: "uuid", TaskId: "task", SubmitTime: submitTime}, nil)
+	c.expectOwner(ctx, "user", "uuid")
+
+	c.logSender.EXPECT().SearchLogs(ctx, mock.MatchedBy(func(q *types.LogSearchQuery) bool {
+		return q.Cursor == "" && q.Labels[types.StreamKeyInstanceName] == "task"
+	})).Return(&types.LogSearchResult{
+		Entries:    []types.LogSearchEntry{{Timestamp: submitTime, Pod: "task", Line: "line 1"}},
+		NextCursor: "1",
+	}, nil).Once()
+	c.logSender.EXPECT().SearchLogs(ctx, mock.MatchedBy(func(q *types.LogSearchQuery) bool {
+		return q.Cursor == "1"
+	})).Return(&types.LogSearchResult{
+		Entries: []types.LogSearchEntry{{Timestamp: submitTime.Add(time.Second), Pod: "task", Line: "line 2"}},
+	}, nil).Once()
+
+	reader, err := c.DownloadWorkflowLogs(ctx, &types.LogSearchReq{ID: 2, CurrentUser: "user", Cursor: "5", Direction: types.LogSearchBackward})
+	require.NoError(t, err)
+	defer reader.Close()
+	gz, err := gzip.NewReader(reader)
+	require.NoError(t, err)
+	data, err := io.ReadAll(gz)
+	require.NoError(t, err)
+	require.Equal(t,
+		submitTime.Format(time.RFC3339Nano)+" task | line 1\n"+
+			submitTime.Add(time.Second).Format(time.RFC3339Nano)+" task | line 2\n",
+		string(data))
+}
@@ -26,6 +26,8 @@ type mcpScannerComponentImpl struct {
 	gitServer      gitserver.GitServer
 	plugins        []MCPScannerPlugin
 	llmTemperature float64
+	reportLevel    types.Level
+	advisoryDBFile string
 }
 
 func NewMCPScannerComponent(config *config.Config) MCPScannerComponent {
@@ -47,6 +49,8 @@ func NewMCPScannerComponent(config *config.Config) MCPScannerComponent {
 		gitServer:      gitServer,
 		plugins:        plugins,
 		llmTemperature: config.MCPScan.Temperature,
+		reportLevel:    mcpScanReportLevel(config.MCPScan.ReportLevel),
+		advisoryDBFile: config.MCPScan.AdvisoryDBFile,
 	}
 	// Initialize plugins
 	pluginTypes := config.MCPScan.Plugins
@@ -55,30 +59,59 @@ func NewMCPScannerComponent(config *config.Config) MCPScannerComponent {
 	return scanner
 }
 
-func (scanner *mcpScannerComponentImpl) parsePlugins(ctx context.Context, pluginNames []string) {
-	summaryPrompt, err := scanner.promptStore.Get(ctx, types.PromptPrefixKind("mcp_scan_summary"))
-	if err != nil {
-		return
-	}
-	llmConfig, err := scanner.llmConfigStore.GetByType(ctx, 8)
-	if err != nil {
-		return
+// mcpScanReportLevel returns the configured report level, an unknown level falls back to critical
+func mcpScanReportLevel(level string) types.Level {
+	reportLevel := types.Level(level)
+	if !reportLevel.Valid() {
+		slog.Error("invalid mcp scan report level, fall back to critical", slog.String("level", level))
+		return types.LevelCritical
 	}
-	llmClinet := llm.NewClient()
+	return reportLevel
+}
+
+func (scanner *mcpScannerComponentImpl) parsePlugins(ctx context.Context, pluginNames []string) {
 	for _, pluginType := range pluginNames {
 		var plugin MCPScannerPlugin
 		switch pluginType {
 		case "tool_poison":
-			prompt, err := scanner.promptStore.Get(ctx, types.PromptPrefixKind("tool_poison"))
+			plugin = scanner.newToolPoisoningPlugin(ctx)
+		case string(types.SecretDetectionPluginName):
+			plugin = newSecretDetectionPlugin()
+		case string(types.DangerousCodePluginName):
+			plugin = newDangerousCodePlugin()
+		case string(types.HiddenInstructionPluginName):
+			plugin = newHiddenInstructionPlugin()
+		case string(types.VulnerableDependencyPluginName):
+			db, err := loadAdvisoryDB(scanner.advisoryDBFile)
 			if err != nil {
-				return
+				slog.Error("failed to load mcp scan advisory database", slog.String("file", scanner.advisoryDBFile), slog.Any("error", err))
+				continue
 			}
-			plugin = newToolPoisoningPlugin(llmClinet, prompt, summaryPrompt, llmConfig, scanner.llmTemperature)
+			plugin = newVulnerableDependencyPlugin(db)
 		default:
-			return
+			slog.Warn("unknown mcp scan plugin", slog.String("plugin", pluginType))
 		}
-		scanner.plugins = append(scanner.plugins, plugin)
+		if plugin != nil {
+			scanner.plugins = append(scanner.plugins, plugin)
+		}
+	}
+}
+
+// newToolPoisoningPlugin creates the llm based plugin, it returns nil when the prompts or llm config are missing
+func (scanner *mcpScannerComponentImpl) newToolPoisoningPlugin(ctx context.Context) MCPScannerPlugin {
+	summaryPrompt, err := scanner.promptStore.Get(ctx, types.PromptPrefixKind("mcp_scan_summary"))
+	if err != nil {
+		return nil
+	}
+	llmConfig, err := scanner.llmConfigStore.GetByType(ctx, 8)
+	if err != nil {
+		return nil
+	}
+	prompt, err := scanner.promptStore.Get(ctx, types.PromptPrefixKind("tool_poison"))
+	if err != nil {
+		return nil
 	}
+	return newToolPoisoningPlugin(llm.NewClient(), prompt, summaryPrompt, llmConfig, scanner.llmTemperature)
 }
 
 func (scanner *mcpScannerComponentImpl) Scan(ctx context.Context, namespace, name string) ([]types.ScannerIssue, error) {
@@ -113,7 +146,7 @@ func (scanner *mcpScannerComponentImpl) Scan(ctx context.Context, namespace, nam
 			return nil, err
 		}
 		for _, issue := range issues {
-			if issue.Level == types.LevelCritical {
+			if issue.Level.AtLeast(scanner.reportLevel) {
 				result = append(result, issue)
 			}
 		}
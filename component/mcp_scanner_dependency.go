@@ -0,0 +1,240 @@
+package component
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"opencsg.com/csghub-server/common/types"
+)
+
+// dependency ecosystems of the offline advisory database
+const (
+	ecosystemPyPI = "pypi"
+	ecosystemNPM  = "npm"
+	ecosystemGo   = "go"
+)
+
+// advisory is a known vulnerable version range of a package, Affected uses
+// comma separated constraints such as ">= 1.0, < 1.4.2"
+type advisory struct {
+	ID        string      `json:"id"`
+	Ecosystem string      `json:"ecosystem"`
+	Package   string      `json:"package"`
+	Affected  []string    `json:"affected"`
+	Level     types.Level `json:"severity"`
+	Summary   string      `json:"summary"`
+	FixedIn   string      `json:"fixed_in"`
+
+	constraints []version.Constraints
+}
+
+// advisoryDB is the offline advisory database, indexed by ecosystem and lower-cased package name
+type advisoryDB struct {
+	advisories map[string][]*advisory
+}
+
+type advisoryDBFile struct {
+	Advisories []*advisory `json:"advisories"`
+}
+
+// loadAdvisoryDB reads the offline advisory database, the scanner never downloads advisories
+func loadAdvisoryDB(file string) (*advisoryDB, error) {
+	if file == "" {
+		return nil, fmt.Errorf("advisory database file is not configured")
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read advisory database, %w", err)
+	}
+	return parseAdvisoryDB(data)
+}
+
+func parseAdvisoryDB(data []byte) (*advisoryDB, error) {
+	var f advisoryDBFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse advisory database, %w", err)
+	}
+	db := &advisoryDB{advisories: make(map[string][]*advisory)}
+	for _, adv := range f.Advisories {
+		for _, affected := range adv.Affected {
+			c, err := version.NewConstraint(affected)
+			if err != nil {
+				return nil, fmt.Errorf("invalid affected range %q of advisory %s, %w", affected, adv.ID, err)
+			}
+			adv.constraints = append(adv.constraints, c)
+		}
+		key := advisoryKey(adv.Ecosystem, adv.Package)
+		db.advisories[key] = append(db.advisories[key], adv)
+	}
+	return db, nil
+}
+
+func advisoryKey(ecosystem, pkg string) string {
+	if ecosystem == ecosystemPyPI {
+		// pypi names are case insensitive and treat -, _ and . the same
+		pkg = strings.NewReplacer("_", "-", ".", "-").Replace(pkg)
+	}
+	return strings.ToLower(ecosystem) + "/" + strings.ToLower(pkg)
+}
+
+// match returns the advisories affecting the given package version
+func (db *advisoryDB) match(ecosystem, pkg, ver string) []*advisory {
+	v, err := version.NewVersion(ver)
+	if err != nil {
+		return nil
+	}
+	var matched []*advisory
+	for _, adv := range db.advisories[advisoryKey(ecosystem, pkg)] {
+		for _, c := range adv.constraints {
+			if c.Check(v) {
+				matched = append(matched, adv)
+				break
+			}
+		}
+	}
+	return matched
+}
+
+// dependency is a pinned dependency found in a manifest
+type dependency struct {
+	ecosystem string
+	name      string
+	version   string
+	line      int
+}
+
+// vulnerableDependencyPlugin checks dependency manifests against the offline advisory database
+type vulnerableDependencyPlugin struct {
+	db *advisoryDB
+}
+
+func newVulnerableDependencyPlugin(db *advisoryDB) MCPScannerPlugin {
+	return &vulnerableDependencyPlugin{db: db}
+}
+
+func (p *vulnerableDependencyPlugin) Check(ctx context.Context, files []*types.File) ([]types.ScannerIssue, error) {
+	issues := make([]types.ScannerIssue, 0)
+	for _, file := range files {
+		var deps []dependency
+		switch base := path.Base(file.Path); {
+		case base == "requirements.txt" || (strings.HasPrefix(base, "requirements") && strings.HasSuffix(base, ".txt")):
+			deps = parseRequirementsTxt(file.Content)
+		case base == "package.json":
+			deps = parsePackageJSON(file.Content)
+		case base == "go.mod":
+			deps = parseGoMod(file.Content)
+		default:
+			continue
+		}
+		for _, dep := range deps {
+			for _, adv := range p.db.match(dep.ecosystem, dep.name, dep.version) {
+				suggestion := fmt.Sprintf("Upgrade %s to a version that is not affected.", dep.name)
+				if adv.FixedIn != "" {
+					suggestion = fmt.Sprintf("Upgrade %s to %s or later.", dep.name, adv.FixedIn)
+				}
+				level := adv.Level
+				if level == "" {
+					level = types.LevelHigh
+				}
+				issues = append(issues, types.ScannerIssue{
+					Title:       fmt.Sprintf("Vulnerable dependency %s@%s", dep.name, dep.version),
+					FilePath:    file.Path,
+					RiskType:    "vulnerable dependency",
+					Description: fmt.Sprintf("%s: %s", adv.ID, adv.Summary),
+					Level:       level,
+					Suggestion:  suggestion,
+					Line:        dep.line,
+				})
+			}
+		}
+	}
+	return issues, nil
+}
+
+func (p *vulnerableDependencyPlugin) GetName() types.PluginName {
+	return types.VulnerableDependencyPluginName
+}
+
+var requirementRe = regexp.MustCompile(`^\s*([A-Za-z0-9][A-Za-z0-9._-]*)(\[[^\]]*\])?\s*===?\s*([A-Za-z0-9.!+_-]+)`)
+
+// parseRequirementsTxt returns the pinned requirements, ranges can not be resolved offline
+func parseRequirementsTxt(content string) []dependency {
+	var deps []dependency
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	line := 0
+	for scanner.Scan() {
+		line++
+		text, _, _ := strings.Cut(scanner.Text(), "#")
+		m := requirementRe.FindStringSubmatch(text)
+		if m == nil {
+			continue
+		}
+		deps = append(deps, dependency{ecosystem: ecosystemPyPI, name: m[1], version: m[3], line: line})
+	}
+	return deps
+}
+
+var npmVersionRe = regexp.MustCompile(`^[\^~=v]*(\d+(\.\d+){0,2}([-+][0-9A-Za-z.-]+)?)$`)
+
+// parsePackageJSON returns the dependencies with an exact or caret/tilde version, the
+// lowest version allowed by the range is checked
+func parsePackageJSON(content string) []dependency {
+	var manifest struct {
+		Dependencies    map[string]string `json:"dependencies"`
+		DevDependencies map[string]string `json:"devDependencies"`
+	}
+	if err := json.Unmarshal([]byte(content), &manifest); err != nil {
+		return nil
+	}
+	var deps []dependency
+	for _, m := range []map[string]string{manifest.Dependencies, manifest.DevDependencies} {
+		// sort the names so the findings are reported in the same order on every scan
+		for _, name := range slices.Sorted(maps.Keys(m)) {
+			ver := m[name]
+			vm := npmVersionRe.FindStringSubmatch(strings.TrimSpace(ver))
+			if vm == nil {
+				continue
+			}
+			deps = append(deps, dependency{ecosystem: ecosystemNPM, name: name, version: vm[1], line: lineOfKey(content, name)})
+		}
+	}
+	return deps
+}
+
+func lineOfKey(content, key string) int {
+	idx := strings.Index(content, `"`+key+`"`)
+	if idx < 0 {
+		return 0
+	}
+	return lineOfOffset(content, idx)
+}
+
+var goRequireRe = regexp.MustCompile(`^\s*(require\s+)?([A-Za-z0-9.\-_~/]+\.[A-Za-z0-9.\-_~/]+)\s+(v[0-9][^\s]*)`)
+
+func parseGoMod(content string) []dependency {
+	var deps []dependency
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := scanner.Text()
+		if strings.HasPrefix(strings.TrimSpace(text), "module ") || strings.HasPrefix(strings.TrimSpace(text), "go ") {
+			continue
+		}
+		m := goRequireRe.FindStringSubmatch(text)
+		if m == nil {
+			continue
+		}
+		deps = append(deps, dependency{ecosystem: ecosystemGo, name: m[2], version: m[3], line: line})
+	}
+	return deps
+}
@@ -0,0 +1,257 @@
+package component
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"opencsg.com/csghub-server/common/types"
+)
+
+// staticRule is a deterministic pattern checked by the static mcp scanner plugins
+type staticRule struct {
+	title       string
+	riskType    string
+	level       types.Level
+	pattern     *regexp.Regexp
+	suggestion  string
+	extensions  []string
+	maskMatched bool
+	// quoteMatched escapes invisible characters so they can be seen in the report
+	quoteMatched bool
+}
+
+func (r *staticRule) appliesTo(path string) bool {
+	if len(r.extensions) == 0 {
+		return true
+	}
+	ext := strings.ToLower(filepath.Ext(path))
+	for _, e := range r.extensions {
+		if e == ext {
+			return true
+		}
+	}
+	return false
+}
+
+// checkStaticRules reports one issue per rule match, at most one per rule and line
+func checkStaticRules(files []*types.File, rules []staticRule, skip func(match string) bool) []types.ScannerIssue {
+	issues := make([]types.ScannerIssue, 0)
+	for _, file := range files {
+		if ignoreFile(file) {
+			continue
+		}
+		for i := range rules {
+			rule := &rules[i]
+			if !rule.appliesTo(file.Path) {
+				continue
+			}
+			reported := map[int]bool{}
+			for _, loc := range rule.pattern.FindAllStringIndex(file.Content, -1) {
+				match := file.Content[loc[0]:loc[1]]
+				if skip != nil && skip(match) {
+					continue
+				}
+				line := lineOfOffset(file.Content, loc[0])
+				if reported[line] {
+					continue
+				}
+				reported[line] = true
+				shown := strings.TrimSpace(match)
+				if rule.maskMatched {
+					shown = maskSecret(shown)
+				}
+				if rule.quoteMatched {
+					shown = strconv.QuoteToASCII(shown)
+				}
+				issues = append(issues, types.ScannerIssue{
+					Title:       rule.title,
+					FilePath:    file.Path,
+					RiskType:    rule.riskType,
+					Description: fmt.Sprintf("%s found at line %d: %s", rule.title, line, shown),
+					Level:       rule.level,
+					Suggestion:  rule.suggestion,
+					Line:        line,
+				})
+			}
+		}
+	}
+	return issues
+}
+
+func lineOfOffset(content string, offset int) int {
+	return strings.Count(content[:offset], "\n") + 1
+}
+
+// maskSecret keeps only the first and last characters of a secret so reports do not leak it again
+func maskSecret(s string) string {
+	if len(s) <= 8 {
+		return strings.Repeat("*", len(s))
+	}
+	return s[:4] + strings.Repeat("*", len(s)-8) + s[len(s)-4:]
+}
+
+var (
+	pythonExtensions = []string{".py"}
+	jsExtensions     = []string{".js", ".ts", ".jsx", ".tsx"}
+	goExtensions     = []string{".go"}
+)
+
+// secretDetectionPlugin finds credentials hard-coded in the mcp server sources
+type secretDetectionPlugin struct {
+	rules []staticRule
+}
+
+// placeholders commonly used in examples, they are not real secrets
+var secretPlaceholderRe = regexp.MustCompile(`(?i)(your[_-]|xxxx|\*\*\*\*|<[a-z_]+>|\$\{|changeme|example|placeholder|dummy)`)
+
+func newSecretDetectionPlugin() MCPScannerPlugin {
+	suggestion := "Remove the secret from the repository, rotate it and read it from environment variables or a secret store instead."
+	rule := func(title string, level types.Level, pattern string) staticRule {
+		return staticRule{
+			title:       title,
+			riskType:    "hard-coded secret",
+			level:       level,
+			pattern:     regexp.MustCompile(pattern),
+			suggestion:  suggestion,
+			maskMatched: true,
+		}
+	}
+	return &secretDetectionPlugin{
+		rules: []staticRule{
+			rule("AWS access key", types.LevelCritical, `\b(AKIA|ASIA)[0-9A-Z]{16}\b`),
+			rule("GitHub token", types.LevelCritical, `\b(gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{60,})\b`),
+			rule("OpenAI API key", types.LevelCritical, `\bsk-(proj-)?[A-Za-z0-9_-]{32,}\b`),
+			rule("Slack token", types.LevelCritical, `\bxox[baprs]-[A-Za-z0-9-]{10,}\b`),
+			rule("Google API key", types.LevelCritical, `\bAIza[0-9A-Za-z_-]{35}\b`),
+			rule("Private key", types.LevelCritical, `-----BEGIN (RSA |EC |DSA |OPENSSH |PGP )?PRIVATE KEY( BLOCK)?-----`),
+			rule("Hard-coded credential", types.LevelHigh, `(?i)\b(api[_-]?key|secret[_-]?key|access[_-]?token|auth[_-]?token|password|passwd)\b["']?\s*[:=]\s*["'][^"'\s]{12,}["']`),
+		},
+	}
+}
+
+func (p *secretDetectionPlugin) Check(ctx context.Context, files []*types.File) ([]types.ScannerIssue, error) {
+	return checkStaticRules(files, p.rules, secretPlaceholderRe.MatchString), nil
+}
+
+func (p *secretDetectionPlugin) GetName() types.PluginName {
+	return types.SecretDetectionPluginName
+}
+
+// dangerousCodePlugin finds shell/exec calls and network egress in python, typescript/javascript and go sources
+type dangerousCodePlugin struct {
+	rules []staticRule
+}
+
+func newDangerousCodePlugin() MCPScannerPlugin {
+	execSuggestion := "Avoid executing commands built from tool arguments; use a fixed allow list of commands and pass arguments without a shell."
+	netSuggestion := "Make sure outbound requests only go to documented endpoints and never carry user data or credentials elsewhere."
+	rule := func(title, riskType string, level types.Level, extensions []string, pattern, suggestion string) staticRule {
+		return staticRule{
+			title:      title,
+			riskType:   riskType,
+			level:      level,
+			pattern:    regexp.MustCompile(pattern),
+			suggestion: suggestion,
+			extensions: extensions,
+		}
+	}
+	return &dangerousCodePlugin{
+		rules: []staticRule{
+			// download and execute, in any text file including shell scripts and docs
+			rule("Remote script piped to shell", "command execution", types.LevelCritical, nil,
+				`(curl|wget)\s[^\n|]*\|\s*(sudo\s+)?(ba|z)?sh\b`, execSuggestion),
+
+			rule("Shell command execution", "command execution", types.LevelHigh, pythonExtensions,
+				`\bsubprocess\.(run|call|check_call|check_output|Popen)\([^)]*shell\s*=\s*True`, execSuggestion),
+			rule("Shell command execution", "command execution", types.LevelHigh, pythonExtensions,
+				`\bos\.(system|popen|exec[lv]p?e?)\(`, execSuggestion),
+			rule("Dynamic code evaluation", "code injection", types.LevelHigh, pythonExtensions,
+				`(^|[^.\w])(eval|exec)\(`, execSuggestion),
+			rule("Unsafe deserialization", "code injection", types.LevelMedium, pythonExtensions,
+				`\b(pickle|marshal)\.loads?\(`, execSuggestion),
+			rule("Network egress", "network egress", types.LevelLow, pythonExtensions,
+				`\b(requests|httpx)\.(get|post|put|patch|delete|request)\(|\burllib\.request\.urlopen\(|\bsocket\.socket\(`, netSuggestion),
+
+			rule("Shell command execution", "command execution", types.LevelHigh, jsExtensions,
+				`\b(child_process|execSync|spawnSync)\b|\bexec\(\s*[`+"`"+`'"]`, execSuggestion),
+			rule("Dynamic code evaluation", "code injection", types.LevelHigh, jsExtensions,
+				`(^|[^.\w])eval\(|\bnew\s+Function\(`, execSuggestion),
+			rule("Network egress", "network egress", types.LevelLow, jsExtensions,
+				`(^|[^.\w])fetch\(|\baxios(\.\w+)?\(|\bhttps?\.request\(|\bnet\.connect\(`, netSuggestion),
+
+			rule("Shell command execution", "command execution", types.LevelHigh, goExtensions,
+				`\bexec\.Command(Context)?\(\s*(ctx,\s*)?"(ba|z)?sh"`, execSuggestion),
+			rule("Command execution", "command execution", types.LevelMedium, goExtensions,
+				`\bexec\.Command(Context)?\(|\bsyscall\.Exec\(`, execSuggestion),
+			rule("Network egress", "network egress", types.LevelLow, goExtensions,
+				`\bhttp\.(Get|Post|PostForm|NewRequest(WithContext)?)\(|\bnet\.Dial(Timeout)?\(`, netSuggestion),
+		},
+	}
+}
+
+func (p *dangerousCodePlugin) Check(ctx context.Context, files []*types.File) ([]types.ScannerIssue, error) {
+	return checkStaticRules(files, p.rules, nil), nil
+}
+
+func (p *dangerousCodePlugin) GetName() types.PluginName {
+	return types.DangerousCodePluginName
+}
+
+// hiddenInstructionPlugin finds invisible unicode and prompt injection markers hidden in tool descriptions
+type hiddenInstructionPlugin struct {
+	rules []staticRule
+}
+
+func newHiddenInstructionPlugin() MCPScannerPlugin {
+	suggestion := "Tool descriptions must only describe the tool; remove hidden characters and instructions addressed to the model."
+	sourceExtensions := []string{".py", ".js", ".ts", ".jsx", ".tsx", ".go", ".json", ".yaml", ".yml", ".toml"}
+	return &hiddenInstructionPlugin{
+		rules: []staticRule{
+			{
+				title:        "Unicode tag characters",
+				riskType:     "hidden instruction",
+				level:        types.LevelCritical,
+				pattern:      regexp.MustCompile(`[\x{E0000}-\x{E007F}]+`),
+				suggestion:   suggestion,
+				quoteMatched: true,
+			},
+			{
+				title:        "Bidirectional control characters",
+				riskType:     "hidden instruction",
+				level:        types.LevelHigh,
+				pattern:      regexp.MustCompile(`[\x{202A}-\x{202E}\x{2066}-\x{2069}]`),
+				suggestion:   suggestion,
+				quoteMatched: true,
+			},
+			{
+				title:        "Zero-width characters",
+				riskType:     "hidden instruction",
+				level:        types.LevelMedium,
+				pattern:      regexp.MustCompile(`[\x{200B}-\x{200F}\x{2060}-\x{2064}]|.\x{FEFF}`),
+				suggestion:   suggestion,
+				quoteMatched: true,
+			},
+			{
+				title:    "Prompt injection marker",
+				riskType: "prompt injection",
+				level:    types.LevelHigh,
+				pattern: regexp.MustCompile(`(?i)<\s*/?\s*(important|system|instructions?)\s*>|ignore\s+(all\s+)?(previous|prior|above)\s+instructions` +
+					`|do\s+not\s+(tell|inform|mention\s+(this\s+)?to)\s+the\s+user|<\|im_start\|>|\[INST\]`),
+				suggestion: suggestion,
+				extensions: sourceExtensions,
+			},
+		},
+	}
+}
+
+func (p *hiddenInstructionPlugin) Check(ctx context.Context, files []*types.File) ([]types.ScannerIssue, error) {
+	return checkStaticRules(files, p.rules, nil), nil
+}
+
+func (p *hiddenInstructionPlugin) GetName() types.PluginName {
+	return types.HiddenInstructionPluginName
+}
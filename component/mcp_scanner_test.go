@@ -75,3 +75,10 @@ func TestMCPScannerComponent_Scan(t *testing.T) {
 		})
 	}
 }
+
+func TestMCPScannerComponent_mcpScanReportLevel(t *testing.T) {
+	assert.Equal(t, types.LevelLow, mcpScanReportLevel("low"))
+	assert.Equal(t, types.LevelCritical, mcpScanReportLevel("critical"))
+	assert.Equal(t, types.LevelCritical, mcpScanReportLevel("severe"))
+	assert.Equal(t, types.LevelCritical, mcpScanReportLevel(""))
+}
@@ -11,6 +11,7 @@ import (
 	"strings"
 	"sync"
 
+	"opencsg.com/csghub-server/builder/credential"
 	"opencsg.com/csghub-server/builder/git/membership"
 	"opencsg.com/csghub-server/builder/store/database"
 	"opencsg.com/csghub-server/builder/workhub"
@@ -42,6 +43,7 @@ type mirrorComponentImpl struct {
 	syncCacheMu                 sync.Mutex
 	syncCache                   mirrorcache.Cache
 	mirrorNamespaceMappingStore database.MirrorNamespaceMappingStore
+	credentialStore             database.CredentialStore
 }
 
 type MirrorComponent interface {
@@ -341,6 +343,7 @@ func NewMirrorComponent(config *config.Config) (MirrorComponent, error) {
 	c.userStore = database.NewUserStore()
 	c.config = config
 	c.mirrorNamespaceMappingStore = database.NewMirrorNamespaceMappingStore()
+	c.credentialStore = database.NewCredentialStore()
 	return c, nil
 }
 
@@ -374,6 +377,13 @@ func (m *mirrorComponentImpl) CreateMirror(ctx context.Context, req types.Create
 	if !admin {
 		return nil, fmt.Errorf("users do not have permission to create mirror for this repo")
 	}
+	var credentialID int64
+	if req.CredentialName != "" {
+		credentialID, err = m.mirrorCredentialID(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	repo, err := m.repoStore.FindByPath(ctx, req.RepoType, req.Namespace, req.Name)
 	if err != nil {
@@ -395,6 +405,12 @@ func (m *mirrorComponentImpl) CreateMirror(ctx context.Context, req types.Create
 					Set("source url", req.SourceUrl),
 			)
 		}
+		if credentialID != 0 && existingMirror.CredentialID != credentialID {
+			existingMirror.CredentialID = credentialID
+			if err := m.mirrorStore.Update(ctx, existingMirror); err != nil {
+				return nil, fmt.Errorf("failed to update mirror credential, error: %w", err)
+			}
+		}
 		var usernamePtr, accessTokenPtr *string
 		if req.Username != "" {
 			usernamePtr = &req.Username
@@ -422,6 +438,7 @@ func (m *mirrorComponentImpl) CreateMirror(ctx context.Context, req types.Create
 	mirror.Username = req.Username
 	mirror.PushUrl = repo.HTTPCloneURL
 	mirror.AccessToken = req.AccessToken
+	mirror.CredentialID = credentialID
 	mirror.SourceRepoPath = req.SourceRepoPath
 
 	mirror.RepositoryID = repo.ID
@@ -446,6 +463,28 @@ func (m *mirrorComponentImpl) CreateMirror(ctx context.Context, req types.Create
 	return reqMirror, nil
 }
 
+// mirrorCredentialID returns the id of the credential the mirror source is synced with, the credential
+// must belong to the namespace of the target repository
+func (m *mirrorComponentImpl) mirrorCredentialID(ctx context.Context, req types.CreateMirrorReq) (int64, error) {
+	if req.Username != "" || req.AccessToken != "" {
+		return 0, errorx.ReqParamInvalid(errors.New("credential name can not be used with username and access token"),
+			errorx.Ctx().Set("credential_name", req.CredentialName))
+	}
+	ns, err := m.namespaceStore.FindByPath(ctx, req.Namespace)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find namespace %s, error: %w", req.Namespace, err)
+	}
+	cred, err := m.credentialStore.FindByName(ctx, ns.UUID, req.CredentialName)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find credential %s, error: %w", req.CredentialName, err)
+	}
+	if !credential.SupportsGitAuth(cred.Provider) {
+		return 0, errorx.ReqParamInvalid(errors.New("credential can not be used to sync git repositories"),
+			errorx.Ctx().Set("credential_name", req.CredentialName).Set("provider", cred.Provider))
+	}
+	return cred.ID, nil
+}
+
 // MirrorFromSaas enqueues one workhub sync for an existing on-prem repository backed by a SaaS Git source.
 func (m *mirrorComponentImpl) MirrorFromSaas(ctx context.Context, req types.MirrorFromSaasReq) (*types.MirrorFromSaasResponse, error) {
 	repo, err := m.repoStore.FindByPath(ctx, req.RepoType, req.Namespace, req.Name)
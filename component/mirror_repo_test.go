@@ -524,6 +524,52 @@ func TestMirrorComponent_CreateMirror(t *testing.T) {
 	}, fakeStore.inputs[0].Mirror)
 }
 
+// TestMirrorComponent_CreateMirrorWithCredential verifies the mirror is bound to a credential of the target namespace.
+func TestMirrorComponent_CreateMirrorWithCredential(t *testing.T) {
+	ctx := context.TODO()
+	mc := initializeTestMirrorComponent(ctx, t)
+	fakeStore := &fakeMirrorRepoStore{}
+	mc.mirrorRepoStore = fakeStore
+
+	repo := &database.Repository{
+		ID:             123,
+		Path:           "ns/n",
+		HTTPCloneURL:   "https://opencsg.com/models/ns/n.git",
+		RepositoryType: types.ModelRepo,
+	}
+
+	mc.mocks.components.repo.EXPECT().CheckCurrentUserPermission(ctx, "user", "ns", membership.RoleAdmin).Return(true, nil)
+	mc.mocks.stores.NamespaceMock().EXPECT().FindByPath(ctx, "ns").Return(database.Namespace{UUID: "ns-uuid"}, nil)
+	mc.mocks.stores.CredentialMock().EXPECT().FindByName(ctx, "ns-uuid", "gh").Return(&database.Credential{
+		ID: 7, CredentialName: "gh", Provider: "github",
+	}, nil)
+	mc.mocks.stores.RepoMock().EXPECT().FindByPath(ctx, types.ModelRepo, "ns", "n").Return(repo, nil)
+	mc.mocks.stores.MirrorMock().EXPECT().FindByRepoID(ctx, repo.ID).Return(nil, sql.ErrNoRows)
+	mc.mocks.stores.MirrorSourceMock().EXPECT().Get(ctx, int64(321)).Return(&database.MirrorSource{
+		SourceName: "github",
+	}, nil)
+
+	req := types.CreateMirrorReq{
+		SourceUrl:      "https://github.com/upstream/repo",
+		CurrentUser:    "user",
+		Namespace:      "ns",
+		Name:           "n",
+		RepoType:       types.ModelRepo,
+		MirrorSourceID: 321,
+		SourceRepoPath: "upstream/repo",
+		CredentialName: "gh",
+	}
+	_, err := mc.CreateMirror(ctx, req)
+	require.NoError(t, err)
+	require.Len(t, fakeStore.inputs, 1)
+	require.Equal(t, int64(7), fakeStore.inputs[0].Mirror.CredentialID)
+	require.Empty(t, fakeStore.inputs[0].Mirror.AccessToken)
+
+	req.Username, req.AccessToken = "source-user", "source-token"
+	_, err = mc.CreateMirror(ctx, req)
+	require.ErrorIs(t, err, errorx.ErrReqParamInvalid)
+}
+
 // TestMirrorComponent_CreateMirrorRequeuesSameSource verifies repeated creation starts a fresh sync for the existing source.
 func TestMirrorComponent_CreateMirrorRequeuesSameSource(t *testing.T) {
 	ctx := context.TODO()
@@ -138,6 +138,8 @@ func NewModelComponent(config *config.Config) (ModelComponent, error) {
 	c.lfsMetaObjectStore = database.NewLfsMetaObjectStore()
 	c.inferenceArchStore = database.NewInferenceArchStore()
 	c.metadataStore = database.NewMetadataStore()
+	c.inferenceRolloutStore = database.NewInferenceRolloutStore()
+	c.credentialStore = database.NewCredentialStore()
 
 	c.clusterComponent, err = NewClusterComponent(config)
 	if err != nil {
@@ -182,6 +184,8 @@ type modelComponentImpl struct {
 	clusterComponent          ClusterComponent
 	inferenceArchStore        database.InferenceArchStore
 	metadataStore             database.MetadataStore
+	inferenceRolloutStore     database.InferenceRolloutStore
+	credentialStore           database.CredentialStore
 }
 
 func (c *modelComponentImpl) Index(ctx context.Context, filter *types.RepoFilter, per, page int, needOpWeight bool) ([]*types.Model, int, error) {
@@ -247,22 +251,23 @@ func (c *modelComponentImpl) Index(ctx context.Context, filter *types.RepoFilter
 			}
 		}
 		resModels = append(resModels, &types.Model{
-			ID:           model.ID,
-			Name:         repo.Name,
-			Nickname:     repo.Nickname,
-			Description:  repo.Description,
-			Likes:        repo.Likes,
-			Downloads:    repo.DownloadCount,
-			Path:         repo.Path,
-			RepositoryID: repo.ID,
-			Private:      repo.Private,
-			CreatedAt:    model.CreatedAt,
-			Tags:         tags,
-			UpdatedAt:    repo.UpdatedAt,
-			Source:       repo.Source,
-			SyncStatus:   repo.SyncStatus,
-			License:      repo.License,
-			Repository:   common.BuildCloneInfo(c.config, model.Repository),
+			ID:            model.ID,
+			Name:          repo.Name,
+			Nickname:      repo.Nickname,
+			Description:   repo.Description,
+			SearchSnippet: repo.SearchSnippet,
+			Likes:         repo.Likes,
+			Downloads:     repo.DownloadCount,
+			Path:          repo.Path,
+			RepositoryID:  repo.ID,
+			Private:       repo.Private,
+			CreatedAt:     model.CreatedAt,
+			Tags:          tags,
+			UpdatedAt:     repo.UpdatedAt,
+			Source:        repo.Source,
+			SyncStatus:    repo.SyncStatus,
+			License:       repo.License,
+			Repository:    common.BuildCloneInfo(c.config, model.Repository),
 			MultiSource: types.MultiSource{
 				HFPath:  model.Repository.HFPath,
 				MSPath:  model.Repository.MSPath,
@@ -311,20 +316,21 @@ func (c *modelComponentImpl) IndexV2(ctx context.Context, filter *types.RepoFilt
 	for _, repo := range repos {
 		modelID := modelMap[repo.ID]
 		resModels = append(resModels, &types.Model{
-			ID:           modelID,
-			Name:         repo.Name,
-			Nickname:     repo.Nickname,
-			Description:  repo.Description,
-			Likes:        repo.Likes,
-			Downloads:    repo.DownloadCount,
-			Path:         repo.Path,
-			RepositoryID: repo.ID,
-			Private:      repo.Private,
-			CreatedAt:    repo.CreatedAt,
-			UpdatedAt:    repo.UpdatedAt,
-			Source:       repo.Source,
-			SyncStatus:   repo.SyncStatus,
-			License:      repo.License,
+			ID:            modelID,
+			Name:          repo.Name,
+			Nickname:      repo.Nickname,
+			Description:   repo.Description,
+			SearchSnippet: repo.SearchSnippet,
+			Likes:         repo.Likes,
+			Downloads:     repo.DownloadCount,
+			Path:          repo.Path,
+			RepositoryID:  repo.ID,
+			Private:       repo.Private,
+			CreatedAt:     repo.CreatedAt,
+			UpdatedAt:     repo.UpdatedAt,
+			Source:        repo.Source,
+			SyncStatus:    repo.SyncStatus,
+			License:       repo.License,
 		})
 	}
 
@@ -1207,6 +1213,10 @@ func (c *modelComponentImpl) Deploy(ctx context.Context, deployReq types.DeployA
 	if err != nil {
 		return -1, fmt.Errorf("cannot find model, %w", err)
 	}
+	// the deploy pulls the model files, so a gated model needs an approved access request
+	if err = c.repoComponent.CheckGatedAccess(ctx, m.Repository, deployReq.CurrentUser); err != nil {
+		return -1, err
+	}
 	if req.Revision == "" {
 		req.Revision = m.Repository.DefaultBranch
 	}
@@ -1258,6 +1268,10 @@ func (c *modelComponentImpl) Deploy(ctx context.Context, deployReq types.DeployA
 		}
 		billingUUID = resolved
 	}
+	err = checkDeployCredentials(ctx, c.repoComponent, c.credentialStore, deployReq.CurrentUser, ownerNamespace, req.CredentialNames)
+	if err != nil {
+		return -1, err
+	}
 
 	if deployReq.DeployType == types.InferenceType {
 		existing, err := c.deployTaskStore.FindActiveDeployByNameAndType(ctx, billingUUID, req.DeployName, types.InferenceType)
@@ -1330,6 +1344,7 @@ func (c *modelComponentImpl) Deploy(ctx context.Context, deployReq types.DeployA
 		Variables:        varStr,
 		EngineArgs:       req.EngineArgs,
 		OwnerNamespace:   ownerNamespace,
+		CredentialNames:  req.CredentialNames,
 		DeployExtend: types.DeployExtend{
 			NodeAffinity: exclusiveResp.NodeAffinity,
 			Tolerations:  exclusiveResp.Tolerations,
@@ -9,6 +9,7 @@ import (
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"opencsg.com/csghub-server/builder/git/membership"
 	"opencsg.com/csghub-server/builder/store/database"
 	"opencsg.com/csghub-server/common/errorx"
 	"opencsg.com/csghub-server/common/types"
@@ -25,6 +26,7 @@ func TestModelComponent_Deploy(t *testing.T) {
 			Path: "foo",
 		},
 	}, nil)
+	mc.mocks.components.repo.EXPECT().CheckGatedAccess(ctx, mock.Anything, "user").Return(nil)
 	mc.mocks.stores.DeployTaskMock().EXPECT().GetServerlessDeployByRepID(ctx, int64(1)).Return(
 		nil, nil,
 	)
@@ -80,6 +82,7 @@ func TestModelComponent_Deploy_OwnerNamespace_BillingUUIDError(t *testing.T) {
 			Path: "foo",
 		},
 	}, nil)
+	mc.mocks.components.repo.EXPECT().CheckGatedAccess(ctx, mock.Anything, "user").Return(nil)
 	mc.mocks.stores.DeployTaskMock().EXPECT().GetServerlessDeployByRepID(ctx, int64(1)).Return(nil, nil)
 	mc.mocks.stores.UserMock().EXPECT().FindByUsername(ctx, "user").Return(database.User{
 		RoleMask: "admin",
@@ -116,6 +119,7 @@ func TestModelComponent_Deploy_InferenceType_DuplicateName(t *testing.T) {
 			Path: "foo",
 		},
 	}, nil)
+	mc.mocks.components.repo.EXPECT().CheckGatedAccess(ctx, mock.Anything, "user").Return(nil)
 	mc.mocks.stores.UserMock().EXPECT().FindByUsername(ctx, "user").Return(database.User{
 		RoleMask: "admin",
 		UUID:     "user-uuid",
@@ -145,6 +149,48 @@ func TestModelComponent_Deploy_InferenceType_DuplicateName(t *testing.T) {
 	require.Equal(t, int64(-1), id)
 }
 
+func TestModelComponent_Deploy_CredentialNotFound(t *testing.T) {
+	ctx := context.TODO()
+	mc := initializeTestModelComponent(ctx, t)
+
+	mc.mocks.stores.ModelMock().EXPECT().FindByPath(ctx, "ns", "n").Return(&database.Model{
+		RepositoryID: int64(123),
+		Repository: &database.Repository{
+			ID:   1,
+			Path: "foo",
+		},
+	}, nil)
+	mc.mocks.components.repo.EXPECT().CheckGatedAccess(ctx, mock.Anything, "user").Return(nil)
+	mc.mocks.stores.UserMock().EXPECT().FindByUsername(ctx, "user").Return(database.User{
+		RoleMask: "admin",
+		UUID:     "user-uuid",
+	}, nil)
+	mc.mocks.stores.RuntimeFrameworkMock().EXPECT().FindEnabledByID(ctx, int64(11)).Return(
+		&database.RuntimeFramework{}, nil,
+	)
+	mc.mocks.components.repo.EXPECT().GetNamespaceBillingUUID(ctx, "ns").Return("ns-uuid", nil)
+	mc.mocks.components.repo.EXPECT().CheckCurrentUserPermission(ctx, "user", "ns", membership.RoleRead).Return(true, nil)
+	// the credentials are checked in the owner namespace of the deploy before it is created
+	mc.mocks.stores.CredentialMock().EXPECT().FindByName(ctx, "ns-uuid", "hf-prod").Return(nil, errorx.ErrNotFound)
+
+	id, err := mc.Deploy(ctx, types.DeployActReq{
+		Namespace:   "ns",
+		Name:        "n",
+		CurrentUser: "user",
+		DeployType:  types.InferenceType,
+	}, types.ModelRunReq{
+		RuntimeFrameworkID: 11,
+		ResourceID:         123,
+		ClusterID:          "cluster",
+		DeployName:         "my-deploy",
+		OwnerNamespace:     "ns",
+		CredentialNames:    []string{"hf-prod"},
+	})
+	require.Error(t, err)
+	require.ErrorIs(t, err, errorx.ErrReqParamInvalid)
+	require.Equal(t, int64(-1), id)
+}
+
 func TestModelComponent_Deploy_InferenceType_NoDuplicate(t *testing.T) {
 	ctx := context.TODO()
 	mc := initializeTestModelComponent(ctx, t)
@@ -156,6 +202,7 @@ func TestModelComponent_Deploy_InferenceType_NoDuplicate(t *testing.T) {
 			Path: "foo",
 		},
 	}, nil)
+	mc.mocks.components.repo.EXPECT().CheckGatedAccess(ctx, mock.Anything, "user").Return(nil)
 	mc.mocks.stores.UserMock().EXPECT().FindByUsername(ctx, "user").Return(database.User{
 		RoleMask: "admin",
 		UUID:     "user-uuid",
@@ -196,3 +243,29 @@ func TestModelComponent_Deploy_InferenceType_NoDuplicate(t *testing.T) {
 	require.Nil(t, err)
 	require.Equal(t, int64(222), id)
 }
+
+func TestModelComponent_Deploy_GatedAccessDenied(t *testing.T) {
+	ctx := context.TODO()
+	mc := initializeTestModelComponent(ctx, t)
+
+	repository := &database.Repository{ID: 1, Path: "foo", Gated: true}
+	mc.mocks.stores.ModelMock().EXPECT().FindByPath(ctx, "ns", "n").Return(&database.Model{
+		RepositoryID: int64(123),
+		Repository:   repository,
+	}, nil)
+	mc.mocks.components.repo.EXPECT().CheckGatedAccess(ctx, repository, "user").Return(
+		errorx.ErrForbiddenMsg("the repository is gated, request access to download its files"),
+	)
+
+	id, err := mc.Deploy(ctx, types.DeployActReq{
+		Namespace:   "ns",
+		Name:        "n",
+		CurrentUser: "user",
+		DeployType:  types.InferenceType,
+	}, types.ModelRunReq{
+		RuntimeFrameworkID: 11,
+		ResourceID:         123,
+	})
+	require.ErrorIs(t, err, errorx.ErrForbidden)
+	require.Equal(t, int64(-1), id)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/alibabacloud-go/tea/tea"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	dcommon "opencsg.com/csghub-server/builder/deploy/common"
 	"opencsg.com/csghub-server/builder/git/gitserver"
 	"opencsg.com/csghub-server/builder/git/membership"
 	"opencsg.com/csghub-server/builder/store/database"
@@ -1244,3 +1245,21 @@ func TestModelComponent_IndexV2_Empty(t *testing.T) {
 	require.Equal(t, 0, total)
 	require.Len(t, data, 0)
 }
+
+func TestModelComponent_UpdateInferenceVersionTrafficRolloutInProgress(t *testing.T) {
+	ctx := context.TODO()
+	mc := initializeTestModelComponent(ctx, t)
+
+	req := types.DeployActReq{CurrentUser: "user", DeployID: 1}
+	mc.mocks.components.repo.EXPECT().CheckDeployPermissionForUser(ctx, req).Return(
+		&database.User{ID: 1}, &database.Deploy{ID: 1, Status: dcommon.Running}, nil,
+	)
+	mc.mocks.stores.InferenceRolloutMock().EXPECT().FindLatestByDeployID(ctx, int64(1)).Return(&database.InferenceRollout{
+		Status: types.InferenceRolloutRunning,
+	}, nil)
+
+	err := mc.UpdateInferenceVersionTraffic(ctx, req, []types.UpdateInferenceVersionTrafficReq{
+		{CommitID: "aaaaaaa", TrafficPercent: 100},
+	})
+	require.ErrorIs(t, err, errorx.ErrInferenceRolloutInProgress)
+}
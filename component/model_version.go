@@ -22,6 +22,9 @@ func (c *modelComponentImpl) CreateInferenceVersion(ctx context.Context, req typ
 	if deploy.Status != dcommon.Running {
 		return errorx.ErrDeployStatusNotMatchErr
 	}
+	if err := checkNoRunningRollout(ctx, c.inferenceRolloutStore, deploy.ID); err != nil {
+		return err
+	}
 
 	if req.TrafficPercent > 100 || req.TrafficPercent < 0 {
 		return errorx.ErrTrafficInvalid
@@ -82,6 +85,9 @@ func (c *modelComponentImpl) UpdateInferenceVersionTraffic(ctx context.Context,
 	if deploy.Status != dcommon.Running {
 		return errorx.ErrDeployStatusNotMatchErr
 	}
+	if err := checkNoRunningRollout(ctx, c.inferenceRolloutStore, deploy.ID); err != nil {
+		return err
+	}
 
 	params := []types.TrafficReq{}
 	for _, item := range req {
@@ -107,6 +113,9 @@ func (c *modelComponentImpl) DeleteInferenceVersion(ctx context.Context, verReq
 	if deploy.Status != dcommon.Running {
 		return errorx.ErrDeployStatusNotMatchErr
 	}
+	if err := checkNoRunningRollout(ctx, c.inferenceRolloutStore, deploy.ID); err != nil {
+		return err
+	}
 
 	shortCommitId, err := common.ShortenCommitID7(commitID)
 	if err != nil {
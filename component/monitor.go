@@ -44,6 +44,7 @@ type metricNames struct {
 	memoryUsage    string
 	requestCount   string
 	requestLatency string
+	restartCount   string
 	metricKeys     []string
 }
 
@@ -37,6 +37,7 @@ func NewNotebookComponent(config *config.Config) (NotebookComponent, error) {
 	c.userStore = database.NewUserStore()
 	c.runtimeFrameworksStore = database.NewRuntimeFrameworksStore()
 	c.spaceResourceStore = database.NewSpaceResourceStore()
+	c.credentialStore = database.NewCredentialStore()
 	repoComponent, err := NewRepoComponent(config)
 	if err != nil {
 		return nil, err
@@ -51,6 +52,7 @@ type notebookComponentImpl struct {
 	userStore              database.UserStore
 	runtimeFrameworksStore database.RuntimeFrameworksStore
 	spaceResourceStore     database.SpaceResourceStore
+	credentialStore        database.CredentialStore
 	repoComponent          RepoComponent
 }
 
@@ -73,6 +75,10 @@ func (c *notebookComponentImpl) CreateNotebook(ctx context.Context, req *types.C
 			return nil, errorx.ErrForbiddenMsg("users do not have permission to create notebook in this namespace")
 		}
 	}
+	err = checkDeployCredentials(ctx, c.repoComponent, c.credentialStore, req.CurrentUser, req.OwnerNamespace, req.CredentialNames)
+	if err != nil {
+		return nil, err
+	}
 
 	frame, err := c.runtimeFrameworksStore.FindEnabledByID(ctx, req.RuntimeFrameworkID)
 	if err != nil {
@@ -145,6 +151,7 @@ func (c *notebookComponentImpl) CreateNotebook(ctx context.Context, req *types.C
 		OrderDetailID:    req.OrderDetailID,
 		SKU:              strconv.FormatInt(resource.ID, 10),
 		OwnerNamespace:   req.OwnerNamespace,
+		CredentialNames:  req.CredentialNames,
 		DeployExtend: types.DeployExtend{
 			NodeAffinity: exclusiveResp.NodeAffinity,
 			Tolerations:  exclusiveResp.Tolerations,
@@ -0,0 +1,445 @@
+package component
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"opencsg.com/csghub-server/builder/git"
+	"opencsg.com/csghub-server/builder/git/gitserver"
+	"opencsg.com/csghub-server/builder/store/cache"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/config"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+)
+
+// ociArtifactTTL is how long a synthesized artifact and its digests are cached,
+// a blob can only be pulled by digest while the artifact it belongs to is cached
+const ociArtifactTTL = 24 * time.Hour
+
+var ociDigestRegexp = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+
+type OCIComponent interface {
+	// GetManifest synthesizes the OCI manifest of a model revision, the reference is a tag,
+	// branch or commit of the repo, or the digest of a manifest returned before
+	GetManifest(ctx context.Context, req *types.OCIManifestReq) (*types.OCIManifestResp, error)
+	// StatBlob returns the descriptor of a blob without reading it
+	StatBlob(ctx context.Context, req *types.OCIBlobReq) (*types.OCIDescriptor, error)
+	// GetBlob returns the config blob, a reader of a git file or a signed url of an LFS file
+	GetBlob(ctx context.Context, req *types.OCIBlobReq) (*types.OCIBlobResp, error)
+}
+
+type ociComponentImpl struct {
+	config        *config.Config
+	repoStore     database.RepoStore
+	metadataStore database.MetadataStore
+	repoComponent RepoComponent
+	gitServer     gitserver.GitServer
+	cache         cache.RedisClient
+}
+
+// ociArtifact is a synthesized model artifact of a commit, the manifest and config are kept as
+// bytes so that the digests stay stable while the artifact is cached
+type ociArtifact struct {
+	Commit         string                     `json:"commit"`
+	Manifest       []byte                     `json:"manifest"`
+	ManifestDigest string                     `json:"manifest_digest"`
+	Config         []byte                     `json:"config"`
+	ConfigDigest   string                     `json:"config_digest"`
+	Files          map[string]ociArtifactFile `json:"files"`
+}
+
+type ociArtifactFile struct {
+	Path      string `json:"path"`
+	Lfs       bool   `json:"lfs"`
+	Size      int64  `json:"size"`
+	MediaType string `json:"media_type"`
+}
+
+func NewOCIComponent(config *config.Config) (OCIComponent, error) {
+	gitServer, err := git.NewGitServer(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create git server, error: %w", err)
+	}
+	repoComponent, err := NewRepoComponentImpl(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create repo component, error: %w", err)
+	}
+	redisClient, err := cache.NewCache(context.Background(), cache.RedisConfig{
+		Addr:     config.Redis.Endpoint,
+		Username: config.Redis.User,
+		Password: config.Redis.Password,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create redis cache, error: %w", err)
+	}
+	return &ociComponentImpl{
+		config:        config,
+		repoStore:     database.NewRepoStore(),
+		metadataStore: database.NewMetadataStore(),
+		repoComponent: repoComponent,
+		gitServer:     gitServer,
+		cache:         redisClient,
+	}, nil
+}
+
+func (c *ociComponentImpl) GetManifest(ctx context.Context, req *types.OCIManifestReq) (*types.OCIManifestResp, error) {
+	repo, err := c.findReadableModel(ctx, req.Namespace, req.Name, req.CurrentUser)
+	if err != nil {
+		return nil, err
+	}
+
+	var artifact *ociArtifact
+	if strings.HasPrefix(req.Reference, "sha256:") {
+		artifact, err = c.findArtifactByDigest(ctx, repo, req.Reference)
+		if err != nil {
+			return nil, err
+		}
+		if artifact == nil || artifact.ManifestDigest != req.Reference {
+			return nil, fmt.Errorf("manifest '%s' not found, error: %w", req.Reference, errorx.ErrNotFound)
+		}
+	} else {
+		revision := req.Reference
+		if revision == types.OCIDefaultTag {
+			revision = repo.DefaultBranch
+		}
+		artifact, err = c.artifactOfRevision(ctx, repo, revision)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &types.OCIManifestResp{
+		Digest:    artifact.ManifestDigest,
+		MediaType: types.OCIManifestMediaType,
+		Content:   artifact.Manifest,
+	}, nil
+}
+
+func (c *ociComponentImpl) StatBlob(ctx context.Context, req *types.OCIBlobReq) (*types.OCIDescriptor, error) {
+	_, artifact, err := c.findBlob(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return artifact.descriptor(req.Digest), nil
+}
+
+func (c *ociComponentImpl) GetBlob(ctx context.Context, req *types.OCIBlobReq) (*types.OCIBlobResp, error) {
+	repo, artifact, err := c.findBlob(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	resp := &types.OCIBlobResp{Descriptor: *artifact.descriptor(req.Digest)}
+	if req.Digest == artifact.ConfigDigest {
+		resp.Content = artifact.Config
+		return resp, nil
+	}
+
+	file := artifact.Files[req.Digest]
+	namespace, name := repo.NamespaceAndName()
+	reader, _, downloadURL, err := c.repoComponent.SDKDownloadFile(ctx, &types.GetFileReq{
+		Namespace: namespace,
+		Name:      name,
+		Path:      file.Path,
+		Ref:       artifact.Commit,
+		Lfs:       file.Lfs,
+		SaveAs:    filepath.Base(file.Path),
+		RepoType:  types.ModelRepo,
+	}, req.CurrentUser)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download blob '%s', error: %w", req.Digest, err)
+	}
+	resp.Reader = reader
+	resp.DownloadURL = downloadURL
+	return resp, nil
+}
+
+func (c *ociComponentImpl) findReadableModel(ctx context.Context, namespace, name, currentUser string) (*database.Repository, error) {
+	repo, err := c.repoStore.FindByPath(ctx, types.ModelRepo, namespace, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find model, error: %w", err)
+	}
+	canRead, err := c.repoComponent.AllowReadAccessRepo(ctx, repo, currentUser)
+	if err != nil {
+		if errors.Is(err, errorx.ErrUserNotFound) {
+			return nil, errorx.ErrUnauthorized
+		}
+		return nil, fmt.Errorf("failed to check read access of model, error: %w", err)
+	}
+	if !canRead {
+		return nil, errorx.ErrForbiddenMsg("users do not have permission to pull this model")
+	}
+	return repo, nil
+}
+
+func (c *ociComponentImpl) findBlob(ctx context.Context, req *types.OCIBlobReq) (*database.Repository, *ociArtifact, error) {
+	if !ociDigestRegexp.MatchString(req.Digest) {
+		return nil, nil, errorx.BadRequest(fmt.Errorf("invalid digest '%s'", req.Digest), nil)
+	}
+	repo, err := c.findReadableModel(ctx, req.Namespace, req.Name, req.CurrentUser)
+	if err != nil {
+		return nil, nil, err
+	}
+	artifact, err := c.findArtifactByDigest(ctx, repo, req.Digest)
+	if err != nil {
+		return nil, nil, err
+	}
+	if artifact == nil || artifact.descriptor(req.Digest) == nil {
+		return nil, nil, fmt.Errorf("blob '%s' not found, error: %w", req.Digest, errorx.ErrNotFound)
+	}
+	return repo, artifact, nil
+}
+
+// findArtifactByDigest returns the cached artifact which contains the digest, the artifact of the
+// default branch is synthesized if the digest is unknown, nil is returned if it's still not found
+func (c *ociComponentImpl) findArtifactByDigest(ctx context.Context, repo *database.Repository, digest string) (*ociArtifact, error) {
+	commit, err := c.cache.Get(ctx, ociCacheKey(repo.ID, digest))
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, fmt.Errorf("failed to get digest from cache, error: %w", err)
+	}
+	if commit != "" {
+		return c.artifactOfCommit(ctx, repo, commit)
+	}
+
+	artifact, err := c.artifactOfRevision(ctx, repo, repo.DefaultBranch)
+	if err != nil {
+		return nil, err
+	}
+	if artifact.ManifestDigest == digest || artifact.descriptor(digest) != nil {
+		return artifact, nil
+	}
+	return nil, nil
+}
+
+func (c *ociComponentImpl) artifactOfRevision(ctx context.Context, repo *database.Repository, revision string) (*ociArtifact, error) {
+	namespace, name := repo.NamespaceAndName()
+	commit, err := c.gitServer.GetRepoLastCommit(ctx, gitserver.GetRepoLastCommitReq{
+		Namespace: namespace,
+		Name:      name,
+		Ref:       revision,
+		RepoType:  types.ModelRepo,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find revision '%s', error: %w", revision, err)
+	}
+	if commit == nil || commit.ID == "" {
+		return nil, fmt.Errorf("manifest '%s' not found, error: %w", revision, errorx.ErrNotFound)
+	}
+	return c.artifactOfCommit(ctx, repo, commit.ID)
+}
+
+func (c *ociComponentImpl) artifactOfCommit(ctx context.Context, repo *database.Repository, commit string) (*ociArtifact, error) {
+	key := ociCacheKey(repo.ID, commit)
+	cached, err := c.cache.Get(ctx, key)
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, fmt.Errorf("failed to get artifact from cache, error: %w", err)
+	}
+	if cached != "" {
+		var artifact ociArtifact
+		if err := json.Unmarshal([]byte(cached), &artifact); err == nil {
+			return &artifact, nil
+		}
+	}
+
+	artifact, err := c.buildArtifact(ctx, repo, commit)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(artifact)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal artifact, error: %w", err)
+	}
+	err = c.cache.SetEx(ctx, key, string(data), ociArtifactTTL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to cache artifact, error: %w", err)
+	}
+	digests := []string{artifact.ManifestDigest, artifact.ConfigDigest}
+	for digest := range artifact.Files {
+		digests = append(digests, digest)
+	}
+	for _, digest := range digests {
+		err = c.cache.SetEx(ctx, ociCacheKey(repo.ID, digest), commit, ociArtifactTTL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to cache digest, error: %w", err)
+		}
+	}
+	return artifact, nil
+}
+
+// buildArtifact synthesizes the manifest of a commit with one layer per file, the digest of an
+// LFS file is its oid, the digests of other files are computed from the git contents
+func (c *ociComponentImpl) buildArtifact(ctx context.Context, repo *database.Repository, commit string) (*ociArtifact, error) {
+	namespace, name := repo.NamespaceAndName()
+	files, err := getAllFiles(ctx, namespace, name, "", types.ModelRepo, commit, c.gitServer.GetTree)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Path < files[j].Path
+	})
+
+	artifact := &ociArtifact{
+		Commit: commit,
+		Files:  make(map[string]ociArtifactFile, len(files)),
+	}
+	manifest := types.OCIManifest{
+		SchemaVersion: 2,
+		MediaType:     types.OCIManifestMediaType,
+		ArtifactType:  types.OCIModelArtifactType,
+		Layers:        make([]types.OCIDescriptor, 0, len(files)),
+		Annotations: map[string]string{
+			types.OCIAnnotationRevision: commit,
+			types.OCIAnnotationSource:   fmt.Sprintf("%s/models/%s", c.config.APIServer.PublicDomain, repo.Path),
+		},
+	}
+	var diffIDs []string
+	for _, file := range files {
+		digest, size := "sha256:"+file.LfsSHA256, file.Size
+		if !file.Lfs {
+			digest, size, err = c.gitFileDigest(ctx, namespace, name, commit, file.Path)
+			if err != nil {
+				return nil, err
+			}
+		}
+		layer := types.OCIDescriptor{
+			MediaType: ociLayerMediaType(file),
+			Digest:    digest,
+			Size:      size,
+			Annotations: map[string]string{
+				types.OCIAnnotationTitle:    file.Path,
+				types.OCIAnnotationFilepath: file.Path,
+			},
+		}
+		manifest.Layers = append(manifest.Layers, layer)
+		diffIDs = append(diffIDs, digest)
+		artifact.Files[digest] = ociArtifactFile{
+			Path:      file.Path,
+			Lfs:       file.Lfs,
+			Size:      size,
+			MediaType: layer.MediaType,
+		}
+	}
+
+	artifact.Config, err = c.buildModelConfig(ctx, repo, commit, diffIDs)
+	if err != nil {
+		return nil, err
+	}
+	artifact.ConfigDigest = ociDigest(artifact.Config)
+	manifest.Config = types.OCIDescriptor{
+		MediaType: types.OCIModelConfigMediaType,
+		Digest:    artifact.ConfigDigest,
+		Size:      int64(len(artifact.Config)),
+	}
+	artifact.Manifest, err = json.Marshal(manifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal manifest, error: %w", err)
+	}
+	artifact.ManifestDigest = ociDigest(artifact.Manifest)
+	return artifact, nil
+}
+
+func (c *ociComponentImpl) buildModelConfig(ctx context.Context, repo *database.Repository, commit string, diffIDs []string) ([]byte, error) {
+	modelConfig := types.OCIModelConfig{
+		Descriptor: types.OCIModelDescriptor{
+			Name:        repo.Path,
+			Revision:    commit,
+			Description: repo.Description,
+		},
+		ModelFS: types.OCIModelFS{
+			Type:    "layers",
+			DiffIDs: diffIDs,
+		},
+	}
+	if repo.License != "" {
+		modelConfig.Descriptor.Licenses = []string{repo.License}
+	}
+	metadata, err := c.metadataStore.FindByRepoID(ctx, repo.ID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to find model metadata, error: %w", err)
+	}
+	if metadata != nil {
+		modelConfig.Config.Architecture = metadata.Architecture
+		modelConfig.Config.Precision = metadata.TensorType
+		if metadata.ModelParams > 0 {
+			modelConfig.Config.ParamSize = strconv.FormatFloat(float64(metadata.ModelParams), 'f', -1, 32) + "B"
+		}
+	}
+	data, err := json.Marshal(modelConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal model config, error: %w", err)
+	}
+	return data, nil
+}
+
+func (c *ociComponentImpl) gitFileDigest(ctx context.Context, namespace, name, commit, path string) (string, int64, error) {
+	reader, _, err := c.gitServer.GetRepoFileReader(ctx, gitserver.GetRepoInfoByPathReq{
+		Namespace: namespace,
+		Name:      name,
+		Ref:       commit,
+		Path:      path,
+		RepoType:  types.ModelRepo,
+	})
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to read file '%s', error: %w", path, err)
+	}
+	defer reader.Close()
+	hash := sha256.New()
+	size, err := io.Copy(hash, reader)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to read file '%s', error: %w", path, err)
+	}
+	return "sha256:" + hex.EncodeToString(hash.Sum(nil)), size, nil
+}
+
+// descriptor returns the descriptor of the config or a layer, nil if the digest is not in the artifact
+func (a *ociArtifact) descriptor(digest string) *types.OCIDescriptor {
+	if digest == a.ConfigDigest {
+		return &types.OCIDescriptor{
+			MediaType: types.OCIModelConfigMediaType,
+			Digest:    digest,
+			Size:      int64(len(a.Config)),
+		}
+	}
+	file, ok := a.Files[digest]
+	if !ok {
+		return nil
+	}
+	return &types.OCIDescriptor{
+		MediaType: file.MediaType,
+		Digest:    digest,
+		Size:      file.Size,
+	}
+}
+
+func ociLayerMediaType(file *types.File) string {
+	switch {
+	case file.Lfs:
+		return types.OCIModelWeightMediaType
+	case strings.EqualFold(filepath.Ext(file.Path), ".md"), strings.HasPrefix(strings.ToUpper(filepath.Base(file.Path)), "LICENSE"):
+		return types.OCIModelDocMediaType
+	default:
+		return types.OCIModelWeightConfigMediaType
+	}
+}
+
+func ociDigest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+func ociCacheKey(repoID int64, ref string) string {
+	return fmt.Sprintf("oci:%d:%s", repoID, ref)
+}
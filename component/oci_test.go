@@ -0,0 +1,203 @@
+package component
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	mockgit "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/builder/git/gitserver"
+	mockcache "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/builder/store/cache"
+	mockcomponent "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/component"
+	"opencsg.com/csghub-server/builder/git/gitserver"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/tests"
+	"opencsg.com/csghub-server/common/types"
+)
+
+type testOCIWithMocks struct {
+	*ociComponentImpl
+	stores        *tests.MockStores
+	repoComponent *mockcomponent.MockRepoComponent
+	gitServer     *mockgit.MockGitServer
+}
+
+func initializeTestOCIComponent(t *testing.T) *testOCIWithMocks {
+	config := ProvideTestConfig()
+	config.APIServer.PublicDomain = "https://hub.example.com"
+	stores := tests.NewMockStores(t)
+	repoComponent := mockcomponent.NewMockRepoComponent(t)
+	gitServer := mockgit.NewMockGitServer(t)
+	// the cache is backed by a map so that the artifacts survive between requests
+	cached := map[string]string{}
+	redisClient := mockcache.NewMockRedisClient(t)
+	redisClient.EXPECT().Get(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, key string) (string, error) {
+		if value, ok := cached[key]; ok {
+			return value, nil
+		}
+		return "", redis.Nil
+	}).Maybe()
+	redisClient.EXPECT().SetEx(mock.Anything, mock.Anything, mock.Anything, ociArtifactTTL).RunAndReturn(
+		func(ctx context.Context, key, value string, expiration time.Duration) error {
+			cached[key] = value
+			return nil
+		}).Maybe()
+	return &testOCIWithMocks{
+		ociComponentImpl: &ociComponentImpl{
+			config:        config,
+			repoStore:     stores.Repo,
+			metadataStore: stores.Metadata,
+			repoComponent: repoComponent,
+			gitServer:     gitServer,
+			cache:         redisClient,
+		},
+		stores:        stores,
+		repoComponent: repoComponent,
+		gitServer:     gitServer,
+	}
+}
+
+func ociTestDigest(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// mockOCIModel mocks a public model whose main branch points to commit c1
+func mockOCIModel(ctx context.Context, oci *testOCIWithMocks, user string) *database.Repository {
+	repo := &database.Repository{
+		ID: 1, Path: "ns/n", RepositoryType: types.ModelRepo, DefaultBranch: "main",
+		Description: "a model", License: "apache-2.0",
+	}
+	oci.stores.RepoMock().EXPECT().FindByPath(ctx, types.ModelRepo, "ns", "n").Return(repo, nil)
+	oci.repoComponent.EXPECT().AllowReadAccessRepo(ctx, repo, user).Return(true, nil)
+	oci.gitServer.EXPECT().GetRepoLastCommit(ctx, gitserver.GetRepoLastCommitReq{
+		Namespace: "ns", Name: "n", Ref: "main", RepoType: types.ModelRepo,
+	}).Return(&types.Commit{ID: "c1"}, nil).Maybe()
+	return repo
+}
+
+// mockOCIBuild mocks the git contents of commit c1, it can only be synthesized once
+func mockOCIBuild(ctx context.Context, oci *testOCIWithMocks) {
+	oci.gitServer.EXPECT().GetTree(ctx, types.GetTreeRequest{
+		Namespace: "ns", Name: "n", Ref: "c1", RepoType: types.ModelRepo, Recursive: true, Limit: types.MaxFileTreeSize,
+	}).Return(&types.GetRepoFileTreeResp{Files: []*types.File{
+		{Path: "model.safetensors", Type: "file", Lfs: true, LfsSHA256: strings.Repeat("a", 64), Size: 100},
+		{Path: "config.json", Type: "file", Size: 2},
+		{Path: "docs", Type: "dir"},
+		{Path: "README.md", Type: "file", Size: 6},
+	}}, nil).Once()
+	for path, content := range map[string]string{"config.json": "{}", "README.md": "# card"} {
+		oci.gitServer.EXPECT().GetRepoFileReader(ctx, gitserver.GetRepoInfoByPathReq{
+			Namespace: "ns", Name: "n", Ref: "c1", Path: path, RepoType: types.ModelRepo,
+		}).Return(io.NopCloser(strings.NewReader(content)), int64(len(content)), nil).Once()
+	}
+	oci.stores.MetadataMock().EXPECT().FindByRepoID(ctx, int64(1)).Return(&database.Metadata{
+		Architecture: "LlamaForCausalLM", TensorType: "BF16", ModelParams: 7.5,
+	}, nil).Once()
+}
+
+func TestOCIComponent_GetManifest(t *testing.T) {
+	ctx := context.TODO()
+	oci := initializeTestOCIComponent(t)
+	mockOCIModel(ctx, oci, "u")
+	mockOCIBuild(ctx, oci)
+
+	resp, err := oci.GetManifest(ctx, &types.OCIManifestReq{Namespace: "ns", Name: "n", Reference: "latest", CurrentUser: "u"})
+	require.Nil(t, err)
+	require.Equal(t, types.OCIManifestMediaType, resp.MediaType)
+	require.Equal(t, ociTestDigest(string(resp.Content)), resp.Digest)
+
+	var manifest types.OCIManifest
+	require.Nil(t, json.Unmarshal(resp.Content, &manifest))
+	require.Equal(t, types.OCIModelArtifactType, manifest.ArtifactType)
+	require.Equal(t, "c1", manifest.Annotations[types.OCIAnnotationRevision])
+	require.Equal(t, "https://hub.example.com/models/ns/n", manifest.Annotations[types.OCIAnnotationSource])
+	require.Equal(t, []types.OCIDescriptor{
+		{MediaType: types.OCIModelDocMediaType, Digest: ociTestDigest("# card"), Size: 6, Annotations: map[string]string{
+			types.OCIAnnotationTitle: "README.md", types.OCIAnnotationFilepath: "README.md",
+		}},
+		{MediaType: types.OCIModelWeightConfigMediaType, Digest: ociTestDigest("{}"), Size: 2, Annotations: map[string]string{
+			types.OCIAnnotationTitle: "config.json", types.OCIAnnotationFilepath: "config.json",
+		}},
+		{MediaType: types.OCIModelWeightMediaType, Digest: "sha256:" + strings.Repeat("a", 64), Size: 100, Annotations: map[string]string{
+			types.OCIAnnotationTitle: "model.safetensors", types.OCIAnnotationFilepath: "model.safetensors",
+		}},
+	}, manifest.Layers)
+
+	// the manifest of the same commit is served from the cache by digest
+	byDigest, err := oci.GetManifest(ctx, &types.OCIManifestReq{Namespace: "ns", Name: "n", Reference: resp.Digest, CurrentUser: "u"})
+	require.Nil(t, err)
+	require.Equal(t, resp, byDigest)
+
+	_, err = oci.GetManifest(ctx, &types.OCIManifestReq{Namespace: "ns", Name: "n", Reference: ociTestDigest("other"), CurrentUser: "u"})
+	require.ErrorIs(t, err, errorx.ErrNotFound)
+
+	oci.gitServer.EXPECT().GetRepoLastCommit(ctx, gitserver.GetRepoLastCommitReq{
+		Namespace: "ns", Name: "n", Ref: "missing", RepoType: types.ModelRepo,
+	}).Return(&types.Commit{}, nil)
+	_, err = oci.GetManifest(ctx, &types.OCIManifestReq{Namespace: "ns", Name: "n", Reference: "missing", CurrentUser: "u"})
+	require.ErrorIs(t, err, errorx.ErrNotFound)
+}
+
+func TestOCIComponent_GetBlob(t *testing.T) {
+	ctx := context.TODO()
+	oci := initializeTestOCIComponent(t)
+	mockOCIModel(ctx, oci, "u")
+	mockOCIBuild(ctx, oci)
+
+	lfsDigest := "sha256:" + strings.Repeat("a", 64)
+	oci.repoComponent.EXPECT().SDKDownloadFile(ctx, &types.GetFileReq{
+		Namespace: "ns", Name: "n", Path: "model.safetensors", Ref: "c1", Lfs: true,
+		SaveAs: "model.safetensors", RepoType: types.ModelRepo,
+	}, "u").Return(nil, 0, "https://s3.example.com/lfs", nil)
+	blob, err := oci.GetBlob(ctx, &types.OCIBlobReq{Namespace: "ns", Name: "n", Digest: lfsDigest, CurrentUser: "u"})
+	require.Nil(t, err)
+	require.Equal(t, "https://s3.example.com/lfs", blob.DownloadURL)
+	require.Equal(t, int64(100), blob.Descriptor.Size)
+
+	desc, err := oci.StatBlob(ctx, &types.OCIBlobReq{Namespace: "ns", Name: "n", Digest: ociTestDigest("{}"), CurrentUser: "u"})
+	require.Nil(t, err)
+	require.Equal(t, &types.OCIDescriptor{MediaType: types.OCIModelWeightConfigMediaType, Digest: ociTestDigest("{}"), Size: 2}, desc)
+
+	manifest, err := oci.GetManifest(ctx, &types.OCIManifestReq{Namespace: "ns", Name: "n", Reference: "main", CurrentUser: "u"})
+	require.Nil(t, err)
+	var m types.OCIManifest
+	require.Nil(t, json.Unmarshal(manifest.Content, &m))
+	config, err := oci.GetBlob(ctx, &types.OCIBlobReq{Namespace: "ns", Name: "n", Digest: m.Config.Digest, CurrentUser: "u"})
+	require.Nil(t, err)
+	var modelConfig types.OCIModelConfig
+	require.Nil(t, json.Unmarshal(config.Content, &modelConfig))
+	require.Equal(t, types.OCIModelConfig{
+		Descriptor: types.OCIModelDescriptor{Name: "ns/n", Revision: "c1", Description: "a model", Licenses: []string{"apache-2.0"}},
+		Config:     types.OCIModelProperties{Architecture: "LlamaForCausalLM", ParamSize: "7.5B", Precision: "BF16"},
+		ModelFS:    types.OCIModelFS{Type: "layers", DiffIDs: []string{ociTestDigest("# card"), ociTestDigest("{}"), lfsDigest}},
+	}, modelConfig)
+
+	_, err = oci.GetBlob(ctx, &types.OCIBlobReq{Namespace: "ns", Name: "n", Digest: ociTestDigest("other"), CurrentUser: "u"})
+	require.ErrorIs(t, err, errorx.ErrNotFound)
+
+	_, err = oci.GetBlob(ctx, &types.OCIBlobReq{Namespace: "ns", Name: "n", Digest: "sha256:bad", CurrentUser: "u"})
+	require.ErrorIs(t, err, errorx.ErrBadRequest)
+}
+
+func TestOCIComponent_Unauthorized(t *testing.T) {
+	ctx := context.TODO()
+	oci := initializeTestOCIComponent(t)
+	repo := &database.Repository{ID: 1, Path: "ns/n", RepositoryType: types.ModelRepo, Private: true}
+	oci.stores.RepoMock().EXPECT().FindByPath(ctx, types.ModelRepo, "ns", "n").Return(repo, nil)
+	oci.repoComponent.EXPECT().AllowReadAccessRepo(ctx, repo, "").Return(false, errorx.ErrUserNotFound)
+	oci.repoComponent.EXPECT().AllowReadAccessRepo(ctx, repo, "other").Return(false, nil)
+
+	_, err := oci.GetManifest(ctx, &types.OCIManifestReq{Namespace: "ns", Name: "n", Reference: "latest"})
+	require.ErrorIs(t, err, errorx.ErrUnauthorized)
+	_, err = oci.GetManifest(ctx, &types.OCIManifestReq{Namespace: "ns", Name: "n", Reference: "latest", CurrentUser: "other"})
+	require.ErrorIs(t, err, errorx.ErrForbidden)
+}
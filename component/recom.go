@@ -12,13 +12,16 @@ import (
 	"opencsg.com/csghub-server/builder/git/gitserver"
 	"opencsg.com/csghub-server/builder/store/database"
 	"opencsg.com/csghub-server/common/config"
+	"opencsg.com/csghub-server/common/types"
 )
 
 type recomComponentImpl struct {
-	recomStore database.RecomStore
-	userStore  database.UserStore
-	repoStore  database.RepoStore
-	gitServer  gitserver.GitServer
+	recomStore     database.RecomStore
+	userStore      database.UserStore
+	repoStore      database.RepoStore
+	userLikesStore database.UserLikesStore
+	gitServer      gitserver.GitServer
+	repoComponent  RepoComponent
 }
 
 type RecomComponent interface {
@@ -26,6 +29,12 @@ type RecomComponent interface {
 	// loop through repositories and calculate the recom score of the repository
 	CalculateRecomScore(ctx context.Context, batchSize int) error
 	// CalcTotalScore(ctx context.Context, repo *database.Repository, weights map[string]string) (float64, error)
+	// CalculateRelatedRepos calculates the item-to-item recommendations from the co-usage of the repositories
+	CalculateRelatedRepos(ctx context.Context) error
+	// RelatedRepos returns the repositories used together with the repository
+	RelatedRepos(ctx context.Context, req *types.RelatedReposReq) ([]types.RecomRepo, error)
+	// Feed returns the repositories recommended to the user by the repositories liked by the user
+	Feed(ctx context.Context, req *types.RecomFeedReq) ([]types.RecomRepo, error)
 }
 
 func NewRecomComponent(cfg *config.Config) (RecomComponent, error) {
@@ -34,11 +43,18 @@ func NewRecomComponent(cfg *config.Config) (RecomComponent, error) {
 		return nil, fmt.Errorf("failed to init git server,%w", err)
 	}
 
+	repoComponent, err := NewRepoComponentImpl(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to init repo component,%w", err)
+	}
+
 	return &recomComponentImpl{
-		recomStore: database.NewRecomStore(),
-		userStore:  database.NewUserStore(),
-		repoStore:  database.NewRepoStore(),
-		gitServer:  gs,
+		recomStore:     database.NewRecomStore(),
+		userStore:      database.NewUserStore(),
+		repoStore:      database.NewRepoStore(),
+		userLikesStore: database.NewUserLikesStore(),
+		gitServer:      gs,
+		repoComponent:  repoComponent,
 	}, nil
 }
 
@@ -0,0 +1,262 @@
+package component
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"sort"
+	"time"
+
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+)
+
+const (
+	recomBasketBatchSize = 500
+	// the big baskets carry little signal about the co-usage, and the pairs grow quadratically
+	maxRecomBasketSize = 100
+	// the max number of the related repositories saved for each repository
+	maxRecomRelatedRepos = 20
+	// a collection is curated, so it's a stronger signal than the likes of a user
+	recomLikeWeight       = 1.0
+	recomCollectionWeight = 2.0
+	// the downloads of the recent days boost the score of the popular related repositories
+	recomDownloadDays = 30
+	// the max number of the liked repositories used as the seeds of the user feed
+	maxRecomFeedSeeds = 100
+)
+
+// recomCoUsage counts the weighted co-usage of the repositories in the baskets
+type recomCoUsage struct {
+	pairs map[[2]int64]float64
+	repos map[int64]float64
+}
+
+func newRecomCoUsage() *recomCoUsage {
+	return &recomCoUsage{
+		pairs: make(map[[2]int64]float64),
+		repos: make(map[int64]float64),
+	}
+}
+
+func (u *recomCoUsage) add(repoIDs []int64, weight float64) {
+	if len(repoIDs) > maxRecomBasketSize {
+		repoIDs = repoIDs[len(repoIDs)-maxRecomBasketSize:]
+	}
+	for i, a := range repoIDs {
+		u.repos[a] += weight
+		for _, b := range repoIDs[i+1:] {
+			if a == b {
+				continue
+			}
+			if a > b {
+				u.pairs[[2]int64{b, a}] += weight
+			} else {
+				u.pairs[[2]int64{a, b}] += weight
+			}
+		}
+	}
+}
+
+// relatedRepos scores the related repositories by the cosine similarity of the co-usage, boosted by the
+// recent downloads of the related repository, and keeps the top ones of each repository
+func (u *recomCoUsage) relatedRepos(downloads map[int64]int64, updatedAt time.Time) []*database.RecomRelatedRepo {
+	related := make(map[int64][]*database.RecomRelatedRepo)
+	score := func(from, to int64, similarity float64) {
+		related[from] = append(related[from], &database.RecomRelatedRepo{
+			RepositoryID:        from,
+			RelatedRepositoryID: to,
+			Score:               similarity * (1 + math.Log1p(float64(downloads[to]))/10),
+		})
+	}
+	for pair, weight := range u.pairs {
+		similarity := weight / math.Sqrt(u.repos[pair[0]]*u.repos[pair[1]])
+		score(pair[0], pair[1], similarity)
+		score(pair[1], pair[0], similarity)
+	}
+
+	var items []*database.RecomRelatedRepo
+	for _, repos := range related {
+		sort.Slice(repos, func(i, j int) bool {
+			if repos[i].Score != repos[j].Score {
+				return repos[i].Score > repos[j].Score
+			}
+			return repos[i].RelatedRepositoryID < repos[j].RelatedRepositoryID
+		})
+		if len(repos) > maxRecomRelatedRepos {
+			repos = repos[:maxRecomRelatedRepos]
+		}
+		for _, repo := range repos {
+			repo.UpdatedAt = updatedAt
+			items = append(items, repo)
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].RepositoryID != items[j].RepositoryID {
+			return items[i].RepositoryID < items[j].RepositoryID
+		}
+		return items[i].Score > items[j].Score
+	})
+	return items
+}
+
+// CalculateRelatedRepos rebuilds the related repositories from the co-usage in the likes of the users and
+// the public collections, the related repositories not found in this run are removed
+func (rc *recomComponentImpl) CalculateRelatedRepos(ctx context.Context) error {
+	startedAt := time.Now().Truncate(time.Second)
+	usage := newRecomCoUsage()
+
+	var lastUserID int64
+	for {
+		baskets, err := rc.recomStore.BatchGetLikeBaskets(ctx, lastUserID, recomBasketBatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to get like baskets, error: %w", err)
+		}
+		for _, basket := range baskets {
+			usage.add(basket.RepoIDs, recomLikeWeight)
+			lastUserID = basket.ID
+		}
+		if len(baskets) < recomBasketBatchSize {
+			break
+		}
+	}
+
+	var lastCollectionID int64
+	for {
+		baskets, err := rc.recomStore.BatchGetCollectionBaskets(ctx, lastCollectionID, recomBasketBatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to get collection baskets, error: %w", err)
+		}
+		for _, basket := range baskets {
+			usage.add(basket.RepoIDs, recomCollectionWeight)
+			lastCollectionID = basket.ID
+		}
+		if len(baskets) < recomBasketBatchSize {
+			break
+		}
+	}
+
+	downloads, err := rc.recomStore.RepoDownloadsSince(ctx, startedAt.AddDate(0, 0, -recomDownloadDays))
+	if err != nil {
+		return fmt.Errorf("failed to get repo downloads, error: %w", err)
+	}
+
+	items := usage.relatedRepos(downloads, startedAt)
+	for start := 0; start < len(items); start += recomBasketBatchSize {
+		end := min(start+recomBasketBatchSize, len(items))
+		if err := rc.recomStore.UpsertRelatedRepos(ctx, items[start:end]); err != nil {
+			return fmt.Errorf("failed to save related repos, error: %w", err)
+		}
+	}
+	if err := rc.recomStore.DeleteRelatedReposBefore(ctx, startedAt); err != nil {
+		return fmt.Errorf("failed to delete outdated related repos, error: %w", err)
+	}
+	slog.Info("calculate related repos success", slog.Int("repos", len(usage.repos)), slog.Int("related_repos", len(items)))
+	return nil
+}
+
+func (rc *recomComponentImpl) RelatedRepos(ctx context.Context, req *types.RelatedReposReq) ([]types.RecomRepo, error) {
+	repo, err := rc.repoStore.FindByPath(ctx, req.RepoType, req.Namespace, req.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo, error: %w", err)
+	}
+	canRead, err := rc.repoComponent.AllowReadAccessRepo(ctx, repo, req.CurrentUser)
+	if err != nil {
+		return nil, err
+	}
+	if !canRead {
+		return nil, errorx.ErrForbiddenMsg("users do not have permission to access this repo")
+	}
+
+	items, err := rc.recomStore.FindRelatedRepos(ctx, repo.ID, recomLimit(req.Limit))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find related repos, error: %w", err)
+	}
+	scores := make([]repoScore, 0, len(items))
+	for _, item := range items {
+		scores = append(scores, repoScore{repoID: item.RelatedRepositoryID, score: item.Score})
+	}
+	return rc.recomRepos(ctx, scores)
+}
+
+func (rc *recomComponentImpl) Feed(ctx context.Context, req *types.RecomFeedReq) ([]types.RecomRepo, error) {
+	user, err := rc.userStore.FindByUsername(ctx, req.CurrentUser)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user, error: %w", err)
+	}
+	repoIDs, err := rc.userLikesStore.LikedRepoIDs(ctx, user.ID, maxRecomFeedSeeds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get liked repos, error: %w", err)
+	}
+	items, err := rc.recomStore.RecommendForRepos(ctx, repoIDs, req.RepoType, recomLimit(req.Limit))
+	if err != nil {
+		return nil, fmt.Errorf("failed to recommend repos, error: %w", err)
+	}
+	scores := make([]repoScore, 0, len(items))
+	for _, item := range items {
+		scores = append(scores, repoScore{repoID: item.RelatedRepositoryID, score: item.Score})
+	}
+	if len(scores) == 0 {
+		// the users without any co-usage signals see the trending repositories
+		topScores, err := rc.recomStore.TopScoredRepos(ctx, req.RepoType, recomLimit(req.Limit))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get top scored repos, error: %w", err)
+		}
+		for _, item := range topScores {
+			scores = append(scores, repoScore{repoID: item.RepositoryID, score: item.Score})
+		}
+	}
+	return rc.recomRepos(ctx, scores)
+}
+
+type repoScore struct {
+	repoID int64
+	score  float64
+}
+
+// recomRepos loads the repositories of the scores and keeps the order of the scores
+func (rc *recomComponentImpl) recomRepos(ctx context.Context, scores []repoScore) ([]types.RecomRepo, error) {
+	result := make([]types.RecomRepo, 0, len(scores))
+	if len(scores) == 0 {
+		return result, nil
+	}
+	repoIDs := make([]int64, 0, len(scores))
+	for _, s := range scores {
+		repoIDs = append(repoIDs, s.repoID)
+	}
+	repos, err := rc.repoStore.FindByIds(ctx, repoIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repos, error: %w", err)
+	}
+	repoMap := make(map[int64]*database.Repository, len(repos))
+	for _, repo := range repos {
+		repoMap[repo.ID] = repo
+	}
+	for _, s := range scores {
+		repo, ok := repoMap[s.repoID]
+		if !ok {
+			continue
+		}
+		result = append(result, types.RecomRepo{
+			ID:             repo.ID,
+			Path:           repo.Path,
+			RepositoryType: repo.RepositoryType,
+			Nickname:       repo.Nickname,
+			Description:    repo.Description,
+			Likes:          repo.Likes,
+			DownloadCount:  repo.DownloadCount,
+			UpdatedAt:      repo.UpdatedAt,
+			Score:          s.score,
+		})
+	}
+	return result, nil
+}
+
+func recomLimit(limit int) int {
+	if limit <= 0 {
+		return types.DefaultRecomLimit
+	}
+	return min(limit, types.MaxRecomLimit)
+}
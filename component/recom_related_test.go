@@ -0,0 +1,143 @@
+package component
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+)
+
+func TestRecomComponent_CalculateRelatedRepos(t *testing.T) {
+	ctx := context.TODO()
+	rc := initializeTestRecomComponent(ctx, t)
+
+	rc.mocks.stores.RecomMock().EXPECT().BatchGetLikeBaskets(ctx, int64(0), recomBasketBatchSize).Return([]database.RecomBasket{
+		{ID: 1, RepoIDs: []int64{1, 2, 3}},
+		{ID: 2, RepoIDs: []int64{1, 2}},
+	}, nil)
+	rc.mocks.stores.RecomMock().EXPECT().BatchGetCollectionBaskets(ctx, int64(0), recomBasketBatchSize).Return([]database.RecomBasket{
+		{ID: 10, RepoIDs: []int64{2, 3}},
+	}, nil)
+	rc.mocks.stores.RecomMock().EXPECT().RepoDownloadsSince(ctx, mock.Anything).Return(map[int64]int64{}, nil)
+	var saved []*database.RecomRelatedRepo
+	rc.mocks.stores.RecomMock().EXPECT().UpsertRelatedRepos(ctx, mock.Anything).RunAndReturn(
+		func(ctx context.Context, items []*database.RecomRelatedRepo) error {
+			saved = append(saved, items...)
+			return nil
+		})
+	rc.mocks.stores.RecomMock().EXPECT().DeleteRelatedReposBefore(ctx, mock.Anything).Return(nil)
+
+	err := rc.CalculateRelatedRepos(ctx)
+	require.Nil(t, err)
+
+	related := make(map[int64][]int64)
+	scores := make(map[[2]int64]float64)
+	for _, item := range saved {
+		related[item.RepositoryID] = append(related[item.RepositoryID], item.RelatedRepositoryID)
+		scores[[2]int64{item.RepositoryID, item.RelatedRepositoryID}] = item.Score
+	}
+	require.Equal(t, map[int64][]int64{1: {2, 3}, 2: {3, 1}, 3: {2, 1}}, related)
+	// the co-usage of 2 and 3 is 1 like and 1 collection, repo 2 is used 4 times and repo 3 is used 3 times
+	require.InDelta(t, 3/3.4641, scores[[2]int64{2, 3}], 0.001)
+	require.Equal(t, scores[[2]int64{2, 3}], scores[[2]int64{3, 2}])
+}
+
+func TestRecomCoUsage_RelatedRepos(t *testing.T) {
+	usage := newRecomCoUsage()
+	usage.add([]int64{1, 2}, recomLikeWeight)
+	usage.add([]int64{1, 3}, recomLikeWeight)
+
+	now := time.Now()
+	items := usage.relatedRepos(map[int64]int64{3: 100}, now)
+	require.Len(t, items, 4)
+	// repo 3 is boosted by the downloads
+	require.Equal(t, int64(1), items[0].RepositoryID)
+	require.Equal(t, int64(3), items[0].RelatedRepositoryID)
+	require.Greater(t, items[0].Score, items[1].Score)
+	require.Equal(t, now, items[0].UpdatedAt)
+}
+
+func TestRecomComponent_RelatedRepos(t *testing.T) {
+	ctx := context.TODO()
+	rc := initializeTestRecomComponent(ctx, t)
+
+	repo := &database.Repository{ID: 1, Path: "ns/n", Private: true}
+	rc.mocks.stores.RepoMock().EXPECT().FindByPath(ctx, types.ModelRepo, "ns", "n").Return(repo, nil)
+	rc.mocks.components.repo.EXPECT().AllowReadAccessRepo(ctx, repo, "user").Return(true, nil)
+	rc.mocks.stores.RecomMock().EXPECT().FindRelatedRepos(ctx, int64(1), types.DefaultRecomLimit).Return([]*database.RecomRelatedRepo{
+		{RepositoryID: 1, RelatedRepositoryID: 3, Score: 0.9},
+		{RepositoryID: 1, RelatedRepositoryID: 2, Score: 0.5},
+	}, nil)
+	rc.mocks.stores.RepoMock().EXPECT().FindByIds(ctx, []int64{3, 2}).Return([]*database.Repository{
+		{ID: 2, Path: "ns/b", RepositoryType: types.DatasetRepo},
+		{ID: 3, Path: "ns/c", RepositoryType: types.ModelRepo},
+	}, nil)
+
+	repos, err := rc.RelatedRepos(ctx, &types.RelatedReposReq{
+		RepoType:    types.ModelRepo,
+		Namespace:   "ns",
+		Name:        "n",
+		CurrentUser: "user",
+	})
+	require.Nil(t, err)
+	require.Equal(t, []types.RecomRepo{
+		{ID: 3, Path: "ns/c", RepositoryType: types.ModelRepo, Score: 0.9},
+		{ID: 2, Path: "ns/b", RepositoryType: types.DatasetRepo, Score: 0.5},
+	}, repos)
+}
+
+func TestRecomComponent_RelatedReposForbidden(t *testing.T) {
+	ctx := context.TODO()
+	rc := initializeTestRecomComponent(ctx, t)
+
+	repo := &database.Repository{ID: 1, Path: "ns/n", Private: true}
+	rc.mocks.stores.RepoMock().EXPECT().FindByPath(ctx, types.ModelRepo, "ns", "n").Return(repo, nil)
+	rc.mocks.components.repo.EXPECT().AllowReadAccessRepo(ctx, repo, "user").Return(false, nil)
+
+	_, err := rc.RelatedRepos(ctx, &types.RelatedReposReq{
+		RepoType:    types.ModelRepo,
+		Namespace:   "ns",
+		Name:        "n",
+		CurrentUser: "user",
+	})
+	require.ErrorIs(t, err, errorx.ErrForbidden)
+}
+
+func TestRecomComponent_Feed(t *testing.T) {
+	ctx := context.TODO()
+
+	t.Run("related to the liked repos", func(t *testing.T) {
+		rc := initializeTestRecomComponent(ctx, t)
+		rc.mocks.stores.UserMock().EXPECT().FindByUsername(ctx, "user").Return(database.User{ID: 5}, nil)
+		rc.mocks.stores.UserLikesMock().EXPECT().LikedRepoIDs(ctx, int64(5), maxRecomFeedSeeds).Return([]int64{1, 2}, nil)
+		rc.mocks.stores.RecomMock().EXPECT().RecommendForRepos(ctx, []int64{1, 2}, types.ModelRepo, 5).Return([]*database.RecomRelatedRepo{
+			{RelatedRepositoryID: 3, Score: 1.2},
+		}, nil)
+		rc.mocks.stores.RepoMock().EXPECT().FindByIds(ctx, []int64{3}).Return([]*database.Repository{{ID: 3, Path: "ns/c"}}, nil)
+
+		repos, err := rc.Feed(ctx, &types.RecomFeedReq{CurrentUser: "user", RepoType: types.ModelRepo, Limit: 5})
+		require.Nil(t, err)
+		require.Equal(t, []types.RecomRepo{{ID: 3, Path: "ns/c", Score: 1.2}}, repos)
+	})
+
+	t.Run("trending without likes", func(t *testing.T) {
+		rc := initializeTestRecomComponent(ctx, t)
+		rc.mocks.stores.UserMock().EXPECT().FindByUsername(ctx, "user").Return(database.User{ID: 5}, nil)
+		rc.mocks.stores.UserLikesMock().EXPECT().LikedRepoIDs(ctx, int64(5), maxRecomFeedSeeds).Return(nil, nil)
+		rc.mocks.stores.RecomMock().EXPECT().RecommendForRepos(ctx, []int64(nil), types.RepositoryType(""), types.MaxRecomLimit).
+			Return([]*database.RecomRelatedRepo{}, nil)
+		rc.mocks.stores.RecomMock().EXPECT().TopScoredRepos(ctx, types.RepositoryType(""), types.MaxRecomLimit).Return([]*database.RecomRepoScore{
+			{RepositoryID: 4, Score: 300},
+		}, nil)
+		rc.mocks.stores.RepoMock().EXPECT().FindByIds(ctx, []int64{4}).Return([]*database.Repository{{ID: 4, Path: "ns/d"}}, nil)
+
+		repos, err := rc.Feed(ctx, &types.RecomFeedReq{CurrentUser: "user", Limit: 100})
+		require.Nil(t, err)
+		require.Equal(t, []types.RecomRepo{{ID: 4, Path: "ns/d", Score: 300}}, repos)
+	})
+}
@@ -43,6 +43,7 @@ import (
 	"opencsg.com/csghub-server/common/errorx"
 	"opencsg.com/csghub-server/common/types"
 	"opencsg.com/csghub-server/common/utils/common"
+	"opencsg.com/csghub-server/component/cardparser"
 	"opencsg.com/csghub-server/mq"
 )
 
@@ -66,6 +67,9 @@ type repoComponentImpl struct {
 	namespaceStore                 database.NamespaceStore
 	repoStore                      database.RepoStore
 	repoFileStore                  database.RepoFileStore
+	modelFileScanStore             database.ModelFileScanStore
+	inferenceRolloutStore          database.InferenceRolloutStore
+	storageQuota                   *storageQuotaChecker
 	repoRelationsStore             database.RepoRelationsStore
 	repoStatisticsStore            database.RepositoryStatisticsStore
 	mirrorStore                    database.MirrorStore
@@ -92,6 +96,7 @@ type repoComponentImpl struct {
 	lfsMetaObjectStore             database.LfsMetaObjectStore
 	userResourcesStore             database.UserResourcesStore
 	recomStore                     database.RecomStore
+	feedEventStore                 database.FeedEventStore
 	multiSyncClient                multisync.Client
 	sysMQ                          mq.MessageQueue
 	mirrorTaskStore                database.MirrorTaskStore
@@ -106,6 +111,10 @@ type repoComponentImpl struct {
 	clusterComponent               ClusterComponent
 	modelStore                     database.ModelStore
 	tagStore                       database.TagStore
+	metadataStore                  database.MetadataStore
+	releaseStore                   database.ReleaseStore
+	followStore                    database.FollowStore
+	repoAccessStore                database.RepoAccessStore
 	packageReader                  func(ctx context.Context, repoType types.RepositoryType, repoID int64, branch, commitID string) ([]byte, bool)
 	packageWriter                  func(ctx context.Context, repoType types.RepositoryType, repoID int64, commitID string, archive []byte) error
 	extendRepoImpl
@@ -131,6 +140,13 @@ type RepoComponent interface {
 	InternalDownloadFile(ctx context.Context, req *types.GetFileReq) (io.ReadCloser, int64, string, error)
 	Branches(ctx context.Context, req *types.GetBranchesReq) ([]types.Branch, error)
 	Tags(ctx context.Context, req *types.GetTagsReq) ([]database.Tag, error)
+	// CreateBranch creates a git branch, it fails with errorx.ErrAlreadyExists if the branch exists
+	CreateBranch(ctx context.Context, req types.CreateBranchReq) error
+	DeleteBranch(ctx context.Context, req types.DeleteBranchReq) error
+	GitTags(ctx context.Context, req *types.GetTagsReq) ([]types.Tag, error)
+	// CreateGitTag creates a git tag, it fails with errorx.ErrAlreadyExists if the tag exists
+	CreateGitTag(ctx context.Context, req types.CreateGitTagReq) error
+	DeleteGitTag(ctx context.Context, req types.DeleteGitTagReq) error
 	UpdateTags(ctx context.Context, namespace, name string, repoType types.RepositoryType, category, currentUser string, tags []string) error
 	Tree(ctx context.Context, req *types.GetFileReq) ([]*types.File, error)
 	TreeV2(ctx context.Context, req *types.GetTreeRequest) (*types.GetRepoFileTreeResp, error)
@@ -191,9 +207,34 @@ type RepoComponent interface {
 	RemoteDiff(ctx context.Context, req types.GetDiffBetweenCommitsReq) ([]types.RemoteDiffs, error)
 	SendAssetManagementMsg(ctx context.Context, req types.RepoNotificationReq) error
 	Preupload(ctx context.Context, req types.PreuploadReq) (*types.PreuploadResp, error)
-	CommitFiles(ctx context.Context, req types.CommitFilesReq) error
+	CommitFiles(ctx context.Context, req types.CommitFilesReq) (*types.CommitFilesResp, error)
 	IsExists(ctx context.Context, repoType types.RepositoryType, namespace, name string) (bool, error)
-	ValidateYaml(ctx context.Context, req types.ValidateYamlReq) error
+	ValidateYaml(ctx context.Context, req types.ValidateYamlReq) ([]types.CardWarning, error)
+	// GetCard returns the structured metadata of the README of the default branch
+	GetCard(ctx context.Context, req *types.GetRepoCardReq) (*types.RepoCard, error)
+	// CreateRelease publishes a release of an existing git tag, the followers and likers of the repository are notified
+	CreateRelease(ctx context.Context, req *types.CreateReleaseReq) (*types.Release, error)
+	UpdateRelease(ctx context.Context, req *types.UpdateReleaseReq) (*types.Release, error)
+	DeleteRelease(ctx context.Context, req *types.DeleteReleaseReq) error
+	// GetRelease returns the release by name, or the latest release if the name is types.LatestReleaseRef
+	GetRelease(ctx context.Context, req *types.GetReleaseReq) (*types.Release, error)
+	ListReleases(ctx context.Context, req *types.ListReleasesReq) ([]types.Release, int, error)
+	// GetAccessPolicy returns the usage policy of the repository, the terms and the form are shown to the users
+	// requesting access
+	GetAccessPolicy(ctx context.Context, req *types.GetRepoAccessPolicyReq) (*types.RepoAccessPolicy, error)
+	// UpdateAccessPolicy gates or ungates the repository, only the repository admins can update the policy
+	UpdateAccessPolicy(ctx context.Context, req *types.UpdateRepoAccessPolicyReq) (*types.RepoAccessPolicy, error)
+	// CreateAccessRequest requests access to a gated repository, the request is approved at once if the
+	// approval mode of the policy is auto
+	CreateAccessRequest(ctx context.Context, req *types.CreateRepoAccessRequestReq) (*types.RepoAccessRequest, error)
+	// GetAccessRequest returns the access request of the current user
+	GetAccessRequest(ctx context.Context, req *types.GetRepoAccessRequestReq) (*types.RepoAccessRequest, error)
+	// ListAccessRequests returns the approval queue of the repository, all the requests are returned if req.Per is 0
+	ListAccessRequests(ctx context.Context, req *types.ListRepoAccessRequestsReq) ([]types.RepoAccessRequest, int, error)
+	ReviewAccessRequest(ctx context.Context, req *types.ReviewRepoAccessRequestReq) (*types.RepoAccessRequest, error)
+	// CheckGatedAccess returns an error unless the user can download the LFS files of the repository, the
+	// repository admins and writers bypass the gate
+	CheckGatedAccess(ctx context.Context, repo *database.Repository, username string) error
 	ParseNDJson(ctx *gin.Context) (*types.CommitFilesReq, error)
 	IsSyncing(ctx context.Context, repoType types.RepositoryType, namespace, name string) (bool, error)
 	ChangePath(ctx context.Context, req types.ChangePathReq) error
@@ -212,6 +253,8 @@ type RepoComponent interface {
 	SyncRepositoryPackage(ctx context.Context, repo *database.Repository, namespace, name, branch string) error
 	// DownloadRepoZip downloads a supported repository as a zip archive.
 	DownloadRepoZip(ctx context.Context, req types.DownloadRepoZipReq, currentUser string) ([]byte, error)
+	// DownloadArchive streams a repository revision as a zip or tar.gz archive, optionally with the LFS content
+	DownloadArchive(ctx context.Context, req *types.DownloadArchiveReq) (io.ReadCloser, error)
 	advancedRepoInterface
 }
 
@@ -340,6 +383,7 @@ func (c *repoComponentImpl) CreateRepo(ctx context.Context, req types.CreateRepo
 		}
 	}
 
+	recordFeedEvent(ctx, c.feedEventStore, database.NewRepoFeedEvent(string(types.FeedEventRepoCreated), newDBRepo, user.ID, nil))
 	return gitRepo, newDBRepo, commitFilesReq, nil
 }
 
@@ -508,6 +552,11 @@ func (c *repoComponentImpl) DeleteRepo(ctx context.Context, req types.DeleteRepo
 		return nil, fmt.Errorf("fail to delete repo in database, error: %w", err)
 	}
 
+	err = c.storageQuota.refreshUsage(ctx, req.Namespace)
+	if err != nil {
+		slog.Error("fail to refresh storage usage after deleting repo", slog.Any("req", req), slog.Any("error", err))
+	}
+
 	// trigger lfs cleanup asynchronously
 	if len(lfsMetas) > 0 {
 		go func() {
@@ -891,6 +940,9 @@ func (c *repoComponentImpl) CreateFile(ctx context.Context, req *types.CreateFil
 	}
 
 	var resp types.CreateFileResp
+	if req.FilePath == types.ReadmeFileName && !useLfs {
+		resp.CardWarnings = readmeCardWarnings(req.RepoType, req.Content)
+	}
 	return &resp, nil
 }
 
@@ -1015,6 +1067,9 @@ func (c *repoComponentImpl) UpdateFile(ctx context.Context, req *types.UpdateFil
 	}
 
 	resp := new(types.UpdateFileResp)
+	if req.FilePath == types.ReadmeFileName && !useLfs {
+		resp.CardWarnings = readmeCardWarnings(req.RepoType, req.Content)
+	}
 	return resp, nil
 }
 
@@ -1267,6 +1322,11 @@ func (c *repoComponentImpl) DownloadFile(ctx context.Context, req *types.GetFile
 	if !permission.CanRead {
 		return nil, 0, "", errorx.ErrForbiddenMsg("users do not have permission to download file in this repo")
 	}
+	if req.Lfs {
+		if err = c.CheckGatedAccess(ctx, repo, req.CurrentUser); err != nil {
+			return nil, 0, "", err
+		}
+	}
 
 	err = c.repoStore.UpdateRepoFileDownloads(ctx, repo, time.Now(), 1)
 	if err != nil {
@@ -1446,6 +1506,7 @@ func (c *repoComponentImpl) Tree(ctx context.Context, req *types.GetFileReq) ([]
 	if err != nil {
 		return nil, fmt.Errorf("failed to get git %s repository file tree, error: %w", req.RepoType, err)
 	}
+	c.attachModelFileScans(ctx, repo.ID, tree)
 	return tree, nil
 }
 
@@ -1533,9 +1594,51 @@ func (c *repoComponentImpl) TreeV2(ctx context.Context, req *types.GetTreeReques
 	if err != nil {
 		return nil, fmt.Errorf("failed to get git %s repository file tree, error: %w", req.RepoType, err)
 	}
+	if tree != nil {
+		c.attachModelFileScans(ctx, repo.ID, tree.Files)
+	}
 	return tree, nil
 }
 
+// attachModelFileScans sets the security verdict of the model weight files in the tree,
+// a verdict is only shown when it was made for the same version of the file
+func (c *repoComponentImpl) attachModelFileScans(ctx context.Context, repoID int64, files []*types.File) {
+	var paths []string
+	for _, f := range files {
+		if f.Type != "dir" && types.IsModelScanFile(f.Path) {
+			paths = append(paths, f.Path)
+		}
+	}
+	if len(paths) == 0 {
+		return
+	}
+	scans, err := c.modelFileScanStore.FindByPaths(ctx, repoID, paths)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to get model file scans", slog.Int64("repo_id", repoID), slog.Any("error", err))
+		return
+	}
+	// scans are sorted newest first
+	verdicts := make(map[string]*types.ModelFileScanVerdict, len(scans))
+	for _, scan := range scans {
+		key := scan.Path + "@" + scan.FileSHA
+		if _, ok := verdicts[key]; ok {
+			continue
+		}
+		verdicts[key] = &types.ModelFileScanVerdict{
+			Status:    scan.Status,
+			Format:    scan.Format,
+			Message:   scan.Message,
+			Findings:  scan.Findings,
+			ScannedAt: scan.UpdatedAt,
+		}
+	}
+	for _, f := range files {
+		if v, ok := verdicts[f.Path+"@"+f.SHA]; ok {
+			f.SecurityScan = v
+		}
+	}
+}
+
 func (c *repoComponentImpl) LogsTree(ctx context.Context, req *types.GetLogsTreeRequest) (*types.LogsTreeResp, error) {
 	repo, err := c.repoStore.FindByPath(ctx, req.RepoType, req.Namespace, req.Name)
 	if err != nil {
@@ -1677,6 +1780,8 @@ func (c *repoComponentImpl) SDKListFiles(ctx context.Context, repoType types.Rep
 }
 
 // IsLfs reports whether a repository file is an LFS pointer and returns its downloadable content size.
+// The git refs take precedence over the releases, a release name in req.Ref is only resolved when the ref
+// is not found in git, and it's replaced with its git tag, so the following download of req uses the tag too.
 func (c *repoComponentImpl) IsLfs(ctx context.Context, req *types.GetFileReq) (bool, int64, error) {
 	getFileRawReq := gitserver.GetRepoInfoByPathReq{
 		Namespace: req.Namespace,
@@ -1686,6 +1791,17 @@ func (c *repoComponentImpl) IsLfs(ctx context.Context, req *types.GetFileReq) (b
 		RepoType:  req.RepoType,
 	}
 	content, err := c.git.GetRepoFileRaw(ctx, getFileRawReq)
+	if err != nil && isRepositoryContentNotFound(err) && req.Ref != "" {
+		ref, resolveErr := c.resolveReleaseRef(ctx, req.RepoType, req.Namespace, req.Name, req.Ref)
+		if resolveErr != nil {
+			return false, -1, resolveErr
+		}
+		if ref != req.Ref {
+			req.Ref = ref
+			getFileRawReq.Ref = ref
+			content, err = c.git.GetRepoFileRaw(ctx, getFileRawReq)
+		}
+	}
 	if err != nil {
 		if isRepositoryContentNotFound(err) {
 			return false, -1, errorx.ErrNotFound
@@ -1723,6 +1839,12 @@ func (c *repoComponentImpl) HeadDownloadFile(ctx context.Context, req *types.Get
 	if req.Ref == "" {
 		req.Ref = repo.DefaultBranch
 	}
+	if req.Ref != repo.DefaultBranch {
+		req.Ref, err = c.resolveReleaseRef(ctx, req.RepoType, req.Namespace, req.Name, req.Ref)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
 	getFileContentReq := gitserver.GetRepoInfoByPathReq{
 		Namespace: req.Namespace,
 		Name:      req.Name,
@@ -1780,6 +1902,11 @@ func (c *repoComponentImpl) SDKDownloadFile(ctx context.Context, req *types.GetF
 	if !canRead {
 		return nil, 0, "", errorx.ErrForbiddenMsg("users do not have permission to download file in this repo")
 	}
+	if req.Lfs {
+		if err = c.CheckGatedAccess(ctx, repo, userName); err != nil {
+			return nil, 0, "", err
+		}
+	}
 
 	if req.CountDownload {
 		err = c.repoStore.UpdateRepoFileDownloads(ctx, repo, time.Now(), 1)
@@ -2556,30 +2683,31 @@ func (c *repoComponentImpl) Preupload(ctx context.Context, req types.PreuploadRe
 	return &resp, nil
 }
 
-func (c *repoComponentImpl) CommitFiles(ctx context.Context, req types.CommitFilesReq) error {
+func (c *repoComponentImpl) CommitFiles(ctx context.Context, req types.CommitFilesReq) (*types.CommitFilesResp, error) {
 	var (
-		files    []gitserver.CommitFile
-		lfsFiles []types.Pointer
+		files         []gitserver.CommitFile
+		lfsFiles      []types.Pointer
+		incomingBytes int64
 	)
 	repo, err := c.repoStore.FindByPath(ctx, req.RepoType, req.Namespace, req.Name)
 	if err != nil {
-		return fmt.Errorf("failed to find repo, err: %w", err)
+		return nil, fmt.Errorf("failed to find repo, err: %w", err)
 	}
 	if repo == nil {
-		return errors.New("repo not found")
+		return nil, errors.New("repo not found")
 	}
 
 	permission, err := c.GetUserRepoPermission(ctx, req.CurrentUser, repo)
 	if err != nil {
-		return fmt.Errorf("failed to get user repo permission, error: %w", err)
+		return nil, fmt.Errorf("failed to get user repo permission, error: %w", err)
 	}
 	if !permission.CanWrite {
-		return errorx.ErrForbiddenMsg("users do not have permission to get diff bewtween two commits in this repo")
+		return nil, errorx.ErrForbiddenMsg("users do not have permission to get diff bewtween two commits in this repo")
 	}
 
 	user, err := c.userStore.FindByUsername(ctx, req.CurrentUser)
 	if err != nil {
-		return fmt.Errorf("failed to find user, err: %w", err)
+		return nil, fmt.Errorf("failed to find user, err: %w", err)
 	}
 	existFiles, err := c.git.GetRepoAllFiles(ctx, gitserver.GetRepoAllFilesReq{
 		Namespace: req.Namespace,
@@ -2588,7 +2716,7 @@ func (c *repoComponentImpl) CommitFiles(ctx context.Context, req types.CommitFil
 		Ref:       req.Revision,
 	})
 	if err != nil {
-		return fmt.Errorf("failed to get repo files, err: %w", err)
+		return nil, fmt.Errorf("failed to get repo files, err: %w", err)
 	}
 
 	for _, file := range req.Files {
@@ -2606,7 +2734,7 @@ func (c *repoComponentImpl) CommitFiles(ctx context.Context, req types.CommitFil
 		case types.CommitActionDelete:
 			action = gitserver.CommitActionDelete
 		default:
-			return fmt.Errorf("invalid action: %s", file.Action)
+			return nil, fmt.Errorf("invalid action: %s", file.Action)
 		}
 		cleanedContent := cleanBase64(file.Content)
 
@@ -2617,8 +2745,9 @@ func (c *repoComponentImpl) CommitFiles(ctx context.Context, req types.CommitFil
 		})
 		content, err := base64.StdEncoding.DecodeString(cleanedContent)
 		if err != nil {
-			return fmt.Errorf("failed to decode content, err: %w", err)
+			return nil, fmt.Errorf("failed to decode content, err: %w", err)
 		}
+		incomingBytes += int64(len(content))
 		p, err := gitaly.ReadPointerFromBuffer(content)
 		if err != nil {
 			continue
@@ -2626,6 +2755,14 @@ func (c *repoComponentImpl) CommitFiles(ctx context.Context, req types.CommitFil
 		lfsFiles = append(lfsFiles, p)
 	}
 
+	// commits only deleting files are always allowed to free up space
+	if incomingBytes > 0 {
+		err = c.storageQuota.CheckQuota(ctx, req.Namespace, incomingBytes)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	for _, lfsFile := range lfsFiles {
 		if repo.XnetEnabled {
 			lfsExistReq := &types.XetFileExistsReq{
@@ -2633,7 +2770,7 @@ func (c *repoComponentImpl) CommitFiles(ctx context.Context, req types.CommitFil
 				ObjectKey: lfsFile.Oid,
 			}
 			if lfsFileExist, err := c.xnetClient.FileExists(ctx, lfsExistReq); err != nil || !lfsFileExist {
-				return fmt.Errorf("failed to request xnet, exist:%t err: %w", lfsFileExist, err)
+				return nil, fmt.Errorf("failed to request xnet, exist:%t err: %w", lfsFileExist, err)
 			}
 		}
 		_, err := c.lfsMetaObjectStore.UpdateOrCreate(ctx, database.LfsMetaObject{
@@ -2643,7 +2780,7 @@ func (c *repoComponentImpl) CommitFiles(ctx context.Context, req types.CommitFil
 			RepositoryID: repo.ID,
 		})
 		if err != nil {
-			return fmt.Errorf("failed to update or create lfs meta object, err: %w", err)
+			return nil, fmt.Errorf("failed to update or create lfs meta object, err: %w", err)
 		}
 	}
 
@@ -2658,12 +2795,20 @@ func (c *repoComponentImpl) CommitFiles(ctx context.Context, req types.CommitFil
 		Files:     files,
 	})
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if supportedRepositoryPackageType(req.RepoType) && c.repositoryPackageSyncer != nil {
 		c.asyncSyncRepositoryPackage(ctx, repo, req.Namespace, req.Name, req.Revision)
 	}
-	return nil
+
+	resp := &types.CommitFilesResp{}
+	for _, file := range files {
+		resp.Files = append(resp.Files, file.Path)
+		if file.Action != gitserver.CommitActionDelete && file.Path == types.ReadmeFileName {
+			resp.CardWarnings = readmeCardWarnings(req.RepoType, file.Content)
+		}
+	}
+	return resp, nil
 }
 
 func (c *repoComponentImpl) IsExists(ctx context.Context, repoType types.RepositoryType, namespace, name string) (bool, error) {
@@ -2832,11 +2977,12 @@ func GetRepoUrl(repoType types.RepositoryType, repoPath string) string {
 	}
 }
 
-// ValidateYaml
-func (c *repoComponentImpl) ValidateYaml(ctx context.Context, req types.ValidateYamlReq) error {
+// ValidateYaml validates the README metadata against the card schema of the repository type, an error is
+// returned for the invalid yaml and the warnings for the values not matching the schema
+func (c *repoComponentImpl) ValidateYaml(ctx context.Context, req types.ValidateYamlReq) ([]types.CardWarning, error) {
 	meta := metaText(req.Content)
 	if len(meta) == 0 {
-		return nil
+		return nil, nil
 	}
 
 	categoryContents := make(map[string]any)
@@ -2844,9 +2990,13 @@ func (c *repoComponentImpl) ValidateYaml(ctx context.Context, req types.Validate
 	err := yaml.Unmarshal([]byte(meta), categoryContents)
 	if err != nil {
 		slog.Error("error unmarshall meta for tags", slog.Any("error", err), slog.String("meta", meta))
-		return err
+		return nil, err
 	}
-	return nil
+	_, warnings, err := cardparser.Parse(req.RepoType, req.Content)
+	if err != nil {
+		return nil, err
+	}
+	return warnings, nil
 }
 
 func metaText(readme string) string {
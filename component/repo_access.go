@@ -0,0 +1,431 @@
+package component
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+)
+
+// repoAccessFormValueMaxLength limits the length of an answer in the access request form
+const repoAccessFormValueMaxLength = 1000
+
+// findRepoForAdmin returns the repo and the user if the user can admin the repo
+func (c *repoComponentImpl) findRepoForAdmin(ctx context.Context, repoType types.RepositoryType, namespace, name, currentUser string) (*database.Repository, *database.User, error) {
+	repo, err := c.repoStore.FindByPath(ctx, repoType, namespace, name)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find repo, error: %w", err)
+	}
+	user, err := c.userStore.FindByUsername(ctx, currentUser)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find user, error: %w", err)
+	}
+	permission, err := c.GetUserRepoPermission(ctx, currentUser, repo)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get user repo permission, error: %w", err)
+	}
+	if !permission.CanAdmin {
+		return nil, nil, errorx.ErrForbiddenMsg("users do not have permission to manage the access of this repo")
+	}
+	return repo, &user, nil
+}
+
+// findRepoAccessPolicy returns the policy of the repository, or the default policy if it has never been set
+func (c *repoComponentImpl) findRepoAccessPolicy(ctx context.Context, repo *database.Repository) (*database.RepoAccessPolicy, error) {
+	policy, err := c.repoAccessStore.FindPolicy(ctx, repo.ID)
+	if err != nil {
+		if errors.Is(err, errorx.ErrDatabaseNoRows) {
+			return &database.RepoAccessPolicy{
+				RepositoryID: repo.ID,
+				ApprovalMode: types.RepoAccessApprovalManual,
+			}, nil
+		}
+		return nil, fmt.Errorf("failed to find repo access policy, error: %w", err)
+	}
+	return policy, nil
+}
+
+func toRepoAccessPolicy(repo *database.Repository, policy *database.RepoAccessPolicy) *types.RepoAccessPolicy {
+	p := &types.RepoAccessPolicy{
+		Gated:             repo.Gated,
+		ApprovalMode:      policy.ApprovalMode,
+		Terms:             policy.Terms,
+		FormFields:        policy.FormFields,
+		ApprovalValidDays: policy.ApprovalValidDays,
+	}
+	if p.FormFields == nil {
+		p.FormFields = []types.RepoAccessFormField{}
+	}
+	if !policy.UpdatedAt.IsZero() {
+		p.UpdatedAt = &policy.UpdatedAt
+	}
+	return p
+}
+
+// toRepoAccessRequest converts the request, it's only shown to the requester and the repository admins
+func toRepoAccessRequest(request *database.RepoAccessRequest, now time.Time) *types.RepoAccessRequest {
+	r := &types.RepoAccessRequest{
+		ID:            request.ID,
+		Status:        request.CurrentStatus(now),
+		AcceptedTerms: request.AcceptedTerms,
+		Form:          request.Form,
+		ReviewMsg:     request.ReviewMsg,
+		CreatedAt:     request.CreatedAt,
+		UpdatedAt:     request.UpdatedAt,
+	}
+	if r.Form == nil {
+		r.Form = map[string]string{}
+	}
+	if request.User != nil {
+		r.Username = request.User.Username
+		r.Email = request.User.Email
+	}
+	if request.Reviewer != nil {
+		r.Reviewer = request.Reviewer.Username
+	}
+	if !request.ReviewedAt.IsZero() {
+		r.ReviewedAt = &request.ReviewedAt
+	}
+	if !request.ExpiresAt.IsZero() {
+		r.ExpiresAt = &request.ExpiresAt
+	}
+	return r
+}
+
+// approvalExpiresAt returns the end of an approval made at now, zero if the approvals never expire
+func approvalExpiresAt(policy *database.RepoAccessPolicy, now time.Time) time.Time {
+	if policy.ApprovalValidDays <= 0 {
+		return time.Time{}
+	}
+	return now.AddDate(0, 0, policy.ApprovalValidDays)
+}
+
+// validateRepoAccessForm keeps the answers of the fields defined in the policy and checks the required ones
+func validateRepoAccessForm(fields []types.RepoAccessFormField, form map[string]string) (map[string]string, error) {
+	answers := make(map[string]string, len(fields))
+	for _, field := range fields {
+		value := strings.TrimSpace(form[field.Name])
+		if value == "" {
+			if field.Required {
+				return nil, errorx.BadRequest(fmt.Errorf("form field '%s' is required", field.Name), nil)
+			}
+			continue
+		}
+		if len(value) > repoAccessFormValueMaxLength {
+			return nil, errorx.BadRequest(fmt.Errorf("form field '%s' is longer than %d characters", field.Name, repoAccessFormValueMaxLength), nil)
+		}
+		answers[field.Name] = value
+	}
+	return answers, nil
+}
+
+func (c *repoComponentImpl) GetAccessPolicy(ctx context.Context, req *types.GetRepoAccessPolicyReq) (*types.RepoAccessPolicy, error) {
+	repo, err := c.findRepoForRead(ctx, req.RepoType, req.Namespace, req.Name, req.CurrentUser)
+	if err != nil {
+		return nil, err
+	}
+	policy, err := c.findRepoAccessPolicy(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+	return toRepoAccessPolicy(repo, policy), nil
+}
+
+func (c *repoComponentImpl) UpdateAccessPolicy(ctx context.Context, req *types.UpdateRepoAccessPolicyReq) (*types.RepoAccessPolicy, error) {
+	repo, _, err := c.findRepoForAdmin(ctx, req.RepoType, req.Namespace, req.Name, req.CurrentUser)
+	if err != nil {
+		return nil, err
+	}
+	if req.ApprovalMode == "" {
+		req.ApprovalMode = types.RepoAccessApprovalManual
+	}
+	names := make(map[string]bool, len(req.FormFields))
+	for _, field := range req.FormFields {
+		if names[field.Name] {
+			return nil, errorx.BadRequest(fmt.Errorf("duplicate form field '%s'", field.Name), nil)
+		}
+		names[field.Name] = true
+	}
+
+	policy := &database.RepoAccessPolicy{
+		RepositoryID:      repo.ID,
+		ApprovalMode:      req.ApprovalMode,
+		Terms:             req.Terms,
+		FormFields:        req.FormFields,
+		ApprovalValidDays: req.ApprovalValidDays,
+	}
+	err = c.repoAccessStore.SavePolicy(ctx, policy, req.Gated)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save repo access policy, error: %w", err)
+	}
+	repo.Gated = req.Gated
+	return toRepoAccessPolicy(repo, policy), nil
+}
+
+func (c *repoComponentImpl) CreateAccessRequest(ctx context.Context, req *types.CreateRepoAccessRequestReq) (*types.RepoAccessRequest, error) {
+	if req.CurrentUser == "" {
+		return nil, errorx.ErrUserNotFound
+	}
+	repo, err := c.findRepoForRead(ctx, req.RepoType, req.Namespace, req.Name, req.CurrentUser)
+	if err != nil {
+		return nil, err
+	}
+	if !repo.Gated {
+		return nil, errorx.BadRequest(errors.New("the repository is not gated"), nil)
+	}
+	user, err := c.userStore.FindByUsername(ctx, req.CurrentUser)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user, error: %w", err)
+	}
+	policy, err := c.findRepoAccessPolicy(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+	if policy.Terms != "" && !req.AcceptTerms {
+		return nil, errorx.BadRequest(errors.New("the terms of the repository must be accepted"), nil)
+	}
+	answers, err := validateRepoAccessForm(policy.FormFields, req.Form)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	previous, err := c.repoAccessStore.FindRequest(ctx, repo.ID, user.ID)
+	if err != nil && !errors.Is(err, errorx.ErrDatabaseNoRows) {
+		return nil, fmt.Errorf("failed to find access request, error: %w", err)
+	}
+	if previous != nil {
+		status := previous.CurrentStatus(now)
+		if status == types.RepoAccessRequestPending || status == types.RepoAccessRequestApproved {
+			return nil, fmt.Errorf("access request of user '%s' is %s, error: %w", user.Username, status, errorx.ErrAlreadyExists)
+		}
+	}
+
+	request := &database.RepoAccessRequest{
+		RepositoryID:  repo.ID,
+		UserID:        user.ID,
+		Status:        types.RepoAccessRequestPending,
+		AcceptedTerms: req.AcceptTerms,
+		Form:          answers,
+	}
+	if policy.ApprovalMode == types.RepoAccessApprovalAuto {
+		request.Status = types.RepoAccessRequestApproved
+		request.ReviewedAt = now
+		request.ExpiresAt = approvalExpiresAt(policy, now)
+	}
+	err = c.repoAccessStore.SaveRequest(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save access request, error: %w", err)
+	}
+	request.User = &user
+
+	go c.notifyAccessRequest(repo, request)
+	return toRepoAccessRequest(request, now), nil
+}
+
+func (c *repoComponentImpl) GetAccessRequest(ctx context.Context, req *types.GetRepoAccessRequestReq) (*types.RepoAccessRequest, error) {
+	if req.CurrentUser == "" {
+		return nil, errorx.ErrUserNotFound
+	}
+	repo, err := c.findRepoForRead(ctx, req.RepoType, req.Namespace, req.Name, req.CurrentUser)
+	if err != nil {
+		return nil, err
+	}
+	user, err := c.userStore.FindByUsername(ctx, req.CurrentUser)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user, error: %w", err)
+	}
+	request, err := c.repoAccessStore.FindRequest(ctx, repo.ID, user.ID)
+	if err != nil {
+		if errors.Is(err, errorx.ErrDatabaseNoRows) {
+			return nil, fmt.Errorf("access request of user '%s' not found, error: %w", user.Username, errorx.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to find access request, error: %w", err)
+	}
+	request.User = &user
+	return toRepoAccessRequest(request, time.Now()), nil
+}
+
+func (c *repoComponentImpl) ListAccessRequests(ctx context.Context, req *types.ListRepoAccessRequestsReq) ([]types.RepoAccessRequest, int, error) {
+	switch req.Status {
+	case "", types.RepoAccessRequestPending, types.RepoAccessRequestApproved, types.RepoAccessRequestRejected, types.RepoAccessRequestExpired:
+	default:
+		return nil, 0, errorx.BadRequest(fmt.Errorf("invalid access request status '%s'", req.Status), nil)
+	}
+	repo, _, err := c.findRepoForAdmin(ctx, req.RepoType, req.Namespace, req.Name, req.CurrentUser)
+	if err != nil {
+		return nil, 0, err
+	}
+	requests, total, err := c.repoAccessStore.ListRequests(ctx, repo.ID, req.Status, req.Per, req.Page)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list access requests, error: %w", err)
+	}
+	now := time.Now()
+	result := make([]types.RepoAccessRequest, 0, len(requests))
+	for _, request := range requests {
+		result = append(result, *toRepoAccessRequest(&request, now))
+	}
+	return result, total, nil
+}
+
+func (c *repoComponentImpl) ReviewAccessRequest(ctx context.Context, req *types.ReviewRepoAccessRequestReq) (*types.RepoAccessRequest, error) {
+	repo, reviewer, err := c.findRepoForAdmin(ctx, req.RepoType, req.Namespace, req.Name, req.CurrentUser)
+	if err != nil {
+		return nil, err
+	}
+	request, err := c.repoAccessStore.FindRequestByID(ctx, repo.ID, req.ID)
+	if err != nil {
+		if errors.Is(err, errorx.ErrDatabaseNoRows) {
+			return nil, fmt.Errorf("access request '%d' not found, error: %w", req.ID, errorx.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to find access request, error: %w", err)
+	}
+
+	now := time.Now()
+	switch req.Action {
+	case types.RepoAccessReviewApprove:
+		policy, err := c.findRepoAccessPolicy(ctx, repo)
+		if err != nil {
+			return nil, err
+		}
+		request.Status = types.RepoAccessRequestApproved
+		request.ExpiresAt = approvalExpiresAt(policy, now)
+	case types.RepoAccessReviewReject:
+		// rejecting an approved request revokes the access
+		request.Status = types.RepoAccessRequestRejected
+		request.ExpiresAt = time.Time{}
+	default:
+		return nil, errorx.BadRequest(fmt.Errorf("invalid review action '%s'", req.Action), nil)
+	}
+	request.ReviewerID = reviewer.ID
+	request.ReviewMsg = req.ReviewMsg
+	request.ReviewedAt = now
+	err = c.repoAccessStore.UpdateRequest(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update access request '%d', error: %w", request.ID, err)
+	}
+	request.Reviewer = reviewer
+
+	go c.notifyAccessReview(repo, request, reviewer)
+	return toRepoAccessRequest(request, now), nil
+}
+
+func (c *repoComponentImpl) CheckGatedAccess(ctx context.Context, repo *database.Repository, username string) error {
+	if !repo.Gated {
+		return nil
+	}
+	if username == "" {
+		return errorx.ErrUserNotFound
+	}
+	permission, err := c.GetUserRepoPermission(ctx, username, repo)
+	if err != nil {
+		return fmt.Errorf("failed to get user repo permission, error: %w", err)
+	}
+	if permission.CanWrite {
+		return nil
+	}
+	user, err := c.userStore.FindByUsername(ctx, username)
+	if err != nil {
+		return fmt.Errorf("failed to find user, error: %w", err)
+	}
+	request, err := c.repoAccessStore.FindRequest(ctx, repo.ID, user.ID)
+	if err != nil {
+		if errors.Is(err, errorx.ErrDatabaseNoRows) {
+			return errorx.ErrForbiddenMsg("the repository is gated, request access to download its files")
+		}
+		return fmt.Errorf("failed to find access request, error: %w", err)
+	}
+	status := request.CurrentStatus(time.Now())
+	if status != types.RepoAccessRequestApproved {
+		return errorx.ErrForbiddenMsg(fmt.Sprintf("the repository is gated and the access request is %s", status))
+	}
+	return nil
+}
+
+// notifyAccessRequest notifies the owner of the repository namespace about the new access request
+func (c *repoComponentImpl) notifyAccessRequest(repo *database.Repository, request *database.RepoAccessRequest) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	namespace, _ := repo.NamespaceAndName()
+	ns, err := c.namespaceStore.FindByPath(ctx, namespace)
+	if err != nil {
+		slog.Error("failed to find namespace of access request", slog.String("repo_path", repo.Path), slog.Any("error", err))
+		return
+	}
+	// the namespace user is the owner of a personal namespace or the creator of an organization
+	if ns.User.UUID == "" || ns.User.ID == request.UserID {
+		return
+	}
+	c.sendRepoAccessNotification(ctx, types.MessageScenarioRepoAccessRequest, types.NotificationMessage{
+		UserUUIDs:      []string{ns.User.UUID},
+		SenderUUID:     request.User.UUID,
+		ClickActionURL: fmt.Sprintf("%s/settings", GetRepoUrl(repo.RepositoryType, repo.Path)),
+		Payload: map[string]any{
+			"repo_type": repo.RepositoryType,
+			"repo_path": repo.Path,
+			"username":  request.User.Username,
+			"status":    request.Status,
+		},
+	})
+}
+
+// notifyAccessReview notifies the requester about the decision on the access request
+func (c *repoComponentImpl) notifyAccessReview(repo *database.Repository, request *database.RepoAccessRequest, reviewer *database.User) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if request.User == nil || request.User.UUID == "" {
+		return
+	}
+	c.sendRepoAccessNotification(ctx, types.MessageScenarioRepoAccessReview, types.NotificationMessage{
+		UserUUIDs:      []string{request.User.UUID},
+		SenderUUID:     reviewer.UUID,
+		ClickActionURL: GetRepoUrl(repo.RepositoryType, repo.Path),
+		Payload: map[string]any{
+			"repo_type":  repo.RepositoryType,
+			"repo_path":  repo.Path,
+			"status":     request.Status,
+			"review_msg": request.ReviewMsg,
+		},
+	})
+}
+
+func (c *repoComponentImpl) sendRepoAccessNotification(ctx context.Context, scenario types.MessageScenario, msg types.NotificationMessage) {
+	msg.MsgUUID = uuid.New().String()
+	msg.NotificationType = types.NotificationSystem
+	msg.CreateAt = time.Now()
+	msg.Template = string(scenario)
+	msgBytes, err := json.Marshal(msg)
+	if err != nil {
+		slog.Error("failed to marshal repo access message", slog.String("scenario", string(scenario)), slog.Any("error", err))
+		return
+	}
+	notificationMsg := types.MessageRequest{
+		Scenario:   scenario,
+		Parameters: string(msgBytes),
+		Priority:   types.MessagePriorityHigh,
+	}
+
+	retryCount := c.config.Notification.NotificationRetryCount
+	for i := range retryCount {
+		if err = c.notificationSvcClient.Send(ctx, &notificationMsg); err == nil {
+			break
+		}
+		if i < retryCount-1 {
+			slog.Warn("failed to send notification, retrying", "notification_msg", notificationMsg, "attempt", i+1, "error", err.Error())
+		}
+	}
+	if err != nil {
+		slog.Error("failed to send repo access message", slog.String("scenario", string(scenario)),
+			slog.Any("userUUIDs", msg.UserUUIDs), slog.Any("error", err))
+	}
+}
@@ -0,0 +1,265 @@
+package component
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	mockrpc "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/builder/rpc"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+)
+
+func mockGatedRepo(ctx context.Context, repo *testRepoWithMocks) *database.Repository {
+	gated := &database.Repository{
+		ID: 1, Path: "ns/n", RepositoryType: types.ModelRepo, DefaultBranch: "main", Gated: true,
+	}
+	repo.mocks.stores.RepoMock().EXPECT().FindByPath(ctx, types.ModelRepo, "ns", "n").Return(gated, nil)
+	repo.mocks.stores.UserMock().EXPECT().FindByUsername(ctx, "u").Return(database.User{
+		ID: 2, Username: "u", UUID: "u-uuid", Email: "u@example.com",
+	}, nil)
+	return gated
+}
+
+func TestRepoComponent_UpdateAccessPolicy(t *testing.T) {
+	ctx := context.TODO()
+	repo := initializeTestRepoComponent(ctx, t)
+	mockRepoRefOwner(ctx, repo.mocks.stores)
+
+	_, err := repo.UpdateAccessPolicy(ctx, &types.UpdateRepoAccessPolicyReq{
+		Namespace: "ns", Name: "n", RepoType: types.ModelRepo, CurrentUser: "ns", Gated: true,
+		FormFields: []types.RepoAccessFormField{{Name: "company"}, {Name: "company"}},
+	})
+	require.ErrorIs(t, err, errorx.ErrBadRequest)
+
+	repo.mocks.stores.RepoAccessMock().EXPECT().SavePolicy(ctx, &database.RepoAccessPolicy{
+		ApprovalMode:      types.RepoAccessApprovalManual,
+		Terms:             "no commercial use",
+		FormFields:        []types.RepoAccessFormField{{Name: "company", Required: true}},
+		ApprovalValidDays: 30,
+	}, true).Return(nil)
+	policy, err := repo.UpdateAccessPolicy(ctx, &types.UpdateRepoAccessPolicyReq{
+		Namespace: "ns", Name: "n", RepoType: types.ModelRepo, CurrentUser: "ns", Gated: true,
+		Terms:             "no commercial use",
+		FormFields:        []types.RepoAccessFormField{{Name: "company", Required: true}},
+		ApprovalValidDays: 30,
+	})
+	require.Nil(t, err)
+	require.True(t, policy.Gated)
+	require.Equal(t, types.RepoAccessApprovalManual, policy.ApprovalMode)
+}
+
+func TestRepoComponent_GetAccessPolicy(t *testing.T) {
+	ctx := context.TODO()
+	repo := initializeTestRepoComponent(ctx, t)
+	repo.mocks.stores.RepoMock().EXPECT().FindByPath(ctx, types.ModelRepo, "ns", "n").Return(&database.Repository{
+		ID: 1, Path: "ns/n",
+	}, nil)
+	repo.mocks.stores.RepoAccessMock().EXPECT().FindPolicy(ctx, int64(1)).Return(nil, errorx.ErrDatabaseNoRows)
+
+	policy, err := repo.GetAccessPolicy(ctx, &types.GetRepoAccessPolicyReq{
+		Namespace: "ns", Name: "n", RepoType: types.ModelRepo,
+	})
+	require.Nil(t, err)
+	require.Equal(t, &types.RepoAccessPolicy{
+		ApprovalMode: types.RepoAccessApprovalManual,
+		FormFields:   []types.RepoAccessFormField{},
+	}, policy)
+}
+
+func TestRepoComponent_CreateAccessRequest(t *testing.T) {
+	ctx := context.TODO()
+	repo := initializeTestRepoComponent(ctx, t)
+	notification := mockrpc.NewMockNotificationSvcClient(t)
+	repo.notificationSvcClient = notification
+	repo.config.Notification.NotificationRetryCount = 1
+	mockGatedRepo(ctx, repo)
+	repo.mocks.stores.RepoAccessMock().EXPECT().FindPolicy(ctx, int64(1)).Return(&database.RepoAccessPolicy{
+		ApprovalMode:      types.RepoAccessApprovalAuto,
+		Terms:             "no commercial use",
+		FormFields:        []types.RepoAccessFormField{{Name: "company", Required: true}, {Name: "purpose"}},
+		ApprovalValidDays: 30,
+	}, nil)
+
+	req := &types.CreateRepoAccessRequestReq{
+		Namespace: "ns", Name: "n", RepoType: types.ModelRepo, CurrentUser: "u",
+		Form: map[string]string{"company": "opencsg"},
+	}
+	_, err := repo.CreateAccessRequest(ctx, req)
+	require.ErrorIs(t, err, errorx.ErrBadRequest)
+
+	req.AcceptTerms = true
+	req.Form = map[string]string{"purpose": "research"}
+	_, err = repo.CreateAccessRequest(ctx, req)
+	require.ErrorIs(t, err, errorx.ErrBadRequest)
+
+	req.Form = map[string]string{"company": " opencsg ", "unknown": "x"}
+	repo.mocks.stores.RepoAccessMock().EXPECT().FindRequest(ctx, int64(1), int64(2)).Return(&database.RepoAccessRequest{
+		Status: types.RepoAccessRequestPending,
+	}, nil).Once()
+	_, err = repo.CreateAccessRequest(ctx, req)
+	require.ErrorIs(t, err, errorx.ErrAlreadyExists)
+
+	// an expired approval can be renewed
+	repo.mocks.stores.RepoAccessMock().EXPECT().FindRequest(ctx, int64(1), int64(2)).Return(&database.RepoAccessRequest{
+		Status: types.RepoAccessRequestApproved, ExpiresAt: time.Now().Add(-time.Hour),
+	}, nil).Once()
+	repo.mocks.stores.RepoAccessMock().EXPECT().SaveRequest(ctx, mock.MatchedBy(func(request *database.RepoAccessRequest) bool {
+		return request.Status == types.RepoAccessRequestApproved && request.AcceptedTerms &&
+			request.ExpiresAt.After(time.Now().AddDate(0, 0, 29)) &&
+			len(request.Form) == 1 && request.Form["company"] == "opencsg"
+	})).Return(nil)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	repo.mocks.stores.NamespaceMock().EXPECT().FindByPath(mock.Anything, "ns").Return(database.Namespace{
+		Path: "ns", User: database.User{ID: 1, UUID: "owner-uuid"},
+	}, nil)
+	notification.EXPECT().Send(mock.Anything, mock.MatchedBy(func(msg *types.MessageRequest) bool {
+		defer wg.Done()
+		return msg.Scenario == types.MessageScenarioRepoAccessRequest
+	})).Return(nil).Once()
+
+	request, err := repo.CreateAccessRequest(ctx, req)
+	require.Nil(t, err)
+	require.Equal(t, types.RepoAccessRequestApproved, request.Status)
+	require.Equal(t, "u", request.Username)
+	require.NotNil(t, request.ExpiresAt)
+	wg.Wait()
+}
+
+func TestRepoComponent_CreateAccessRequestNotGated(t *testing.T) {
+	ctx := context.TODO()
+	repo := initializeTestRepoComponent(ctx, t)
+	repo.mocks.stores.RepoMock().EXPECT().FindByPath(ctx, types.ModelRepo, "ns", "n").Return(&database.Repository{
+		ID: 1, Path: "ns/n",
+	}, nil)
+
+	_, err := repo.CreateAccessRequest(ctx, &types.CreateRepoAccessRequestReq{
+		Namespace: "ns", Name: "n", RepoType: types.ModelRepo, CurrentUser: "u", AcceptTerms: true,
+	})
+	require.ErrorIs(t, err, errorx.ErrBadRequest)
+
+	_, err = repo.CreateAccessRequest(ctx, &types.CreateRepoAccessRequestReq{
+		Namespace: "ns", Name: "n", RepoType: types.ModelRepo,
+	})
+	require.ErrorIs(t, err, errorx.ErrUserNotFound)
+}
+
+func TestRepoComponent_ReviewAccessRequest(t *testing.T) {
+	ctx := context.TODO()
+	repo := initializeTestRepoComponent(ctx, t)
+	notification := mockrpc.NewMockNotificationSvcClient(t)
+	repo.notificationSvcClient = notification
+	repo.config.Notification.NotificationRetryCount = 1
+	mockRepoRefOwner(ctx, repo.mocks.stores)
+
+	repo.mocks.stores.RepoAccessMock().EXPECT().FindRequestByID(ctx, int64(0), int64(5)).Return(nil, errorx.ErrDatabaseNoRows).Once()
+	_, err := repo.ReviewAccessRequest(ctx, &types.ReviewRepoAccessRequestReq{
+		Namespace: "ns", Name: "n", RepoType: types.ModelRepo, CurrentUser: "ns", ID: 5, Action: types.RepoAccessReviewApprove,
+	})
+	require.ErrorIs(t, err, errorx.ErrNotFound)
+
+	repo.mocks.stores.RepoAccessMock().EXPECT().FindRequestByID(ctx, int64(0), int64(5)).Return(&database.RepoAccessRequest{
+		ID: 5, Status: types.RepoAccessRequestPending, User: &database.User{ID: 2, Username: "u", UUID: "u-uuid"},
+	}, nil)
+	repo.mocks.stores.RepoAccessMock().EXPECT().FindPolicy(ctx, int64(0)).Return(&database.RepoAccessPolicy{
+		ApprovalMode: types.RepoAccessApprovalManual, ApprovalValidDays: 7,
+	}, nil)
+	repo.mocks.stores.RepoAccessMock().EXPECT().UpdateRequest(ctx, mock.MatchedBy(func(request *database.RepoAccessRequest) bool {
+		return request.Status == types.RepoAccessRequestApproved && request.ReviewMsg == "welcome" &&
+			!request.ReviewedAt.IsZero() && request.ExpiresAt.After(time.Now().AddDate(0, 0, 6))
+	})).Return(nil)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	notification.EXPECT().Send(mock.Anything, mock.MatchedBy(func(msg *types.MessageRequest) bool {
+		defer wg.Done()
+		return msg.Scenario == types.MessageScenarioRepoAccessReview
+	})).Return(nil).Once()
+
+	request, err := repo.ReviewAccessRequest(ctx, &types.ReviewRepoAccessRequestReq{
+		Namespace: "ns", Name: "n", RepoType: types.ModelRepo, CurrentUser: "ns", ID: 5,
+		Action: types.RepoAccessReviewApprove, ReviewMsg: "welcome",
+	})
+	require.Nil(t, err)
+	require.Equal(t, types.RepoAccessRequestApproved, request.Status)
+	require.Equal(t, "ns", request.Reviewer)
+	wg.Wait()
+}
+
+func TestRepoComponent_ListAccessRequests(t *testing.T) {
+	ctx := context.TODO()
+	repo := initializeTestRepoComponent(ctx, t)
+
+	_, _, err := repo.ListAccessRequests(ctx, &types.ListRepoAccessRequestsReq{
+		Namespace: "ns", Name: "n", RepoType: types.ModelRepo, CurrentUser: "ns", Status: "unknown",
+	})
+	require.ErrorIs(t, err, errorx.ErrBadRequest)
+
+	mockRepoRefOwner(ctx, repo.mocks.stores)
+	repo.mocks.stores.RepoAccessMock().EXPECT().ListRequests(ctx, int64(0), types.RepoAccessRequestApproved, 10, 1).Return([]database.RepoAccessRequest{
+		{ID: 2, Status: types.RepoAccessRequestApproved, User: &database.User{Username: "u", Email: "u@example.com"}},
+		{ID: 1, Status: types.RepoAccessRequestApproved, ExpiresAt: time.Now().Add(-time.Hour)},
+	}, 2, nil)
+	requests, total, err := repo.ListAccessRequests(ctx, &types.ListRepoAccessRequestsReq{
+		Namespace: "ns", Name: "n", RepoType: types.ModelRepo, CurrentUser: "ns",
+		Status: types.RepoAccessRequestApproved, Per: 10, Page: 1,
+	})
+	require.Nil(t, err)
+	require.Equal(t, 2, total)
+	require.Equal(t, "u@example.com", requests[0].Email)
+	require.Equal(t, map[string]string{}, requests[0].Form)
+	require.Equal(t, types.RepoAccessRequestExpired, requests[1].Status)
+}
+
+func TestRepoComponent_CheckGatedAccess(t *testing.T) {
+	ctx := context.TODO()
+	repo := initializeTestRepoComponent(ctx, t)
+
+	require.Nil(t, repo.CheckGatedAccess(ctx, &database.Repository{Path: "ns/n"}, ""))
+	gated := &database.Repository{ID: 1, Path: "ns/n", Gated: true}
+	require.ErrorIs(t, repo.CheckGatedAccess(ctx, gated, ""), errorx.ErrUserNotFound)
+
+	// the owner bypasses the gate
+	repo.mocks.stores.UserMock().EXPECT().FindByUsername(ctx, "ns").Return(database.User{ID: 1, Username: "ns"}, nil)
+	repo.mocks.stores.NamespaceMock().EXPECT().FindByPath(ctx, "ns").Return(database.Namespace{
+		Path: "ns", NamespaceType: "user",
+	}, nil)
+	require.Nil(t, repo.CheckGatedAccess(ctx, gated, "ns"))
+
+	repo.mocks.stores.UserMock().EXPECT().FindByUsername(ctx, "u").Return(database.User{ID: 2, Username: "u"}, nil)
+	repo.mocks.stores.RepoAccessMock().EXPECT().FindRequest(ctx, int64(1), int64(2)).Return(nil, errorx.ErrDatabaseNoRows).Once()
+	require.ErrorIs(t, repo.CheckGatedAccess(ctx, gated, "u"), errorx.ErrForbidden)
+
+	repo.mocks.stores.RepoAccessMock().EXPECT().FindRequest(ctx, int64(1), int64(2)).Return(&database.RepoAccessRequest{
+		Status: types.RepoAccessRequestApproved, ExpiresAt: time.Now().Add(-time.Minute),
+	}, nil).Once()
+	require.ErrorIs(t, repo.CheckGatedAccess(ctx, gated, "u"), errorx.ErrForbidden)
+
+	repo.mocks.stores.RepoAccessMock().EXPECT().FindRequest(ctx, int64(1), int64(2)).Return(&database.RepoAccessRequest{
+		Status: types.RepoAccessRequestApproved,
+	}, nil).Once()
+	require.Nil(t, repo.CheckGatedAccess(ctx, gated, "u"))
+}
+
+func TestRepoComponent_SDKDownloadFileGated(t *testing.T) {
+	ctx := context.TODO()
+	repo := initializeTestRepoComponent(ctx, t)
+	mockGatedRepo(ctx, repo)
+	repo.mocks.stores.NamespaceMock().EXPECT().FindByPath(ctx, "ns").Return(database.Namespace{
+		Path: "ns", NamespaceType: "user",
+	}, nil)
+	repo.mocks.stores.RepoAccessMock().EXPECT().FindRequest(ctx, int64(1), int64(2)).Return(&database.RepoAccessRequest{
+		Status: types.RepoAccessRequestPending,
+	}, nil)
+
+	_, _, _, err := repo.SDKDownloadFile(ctx, &types.GetFileReq{
+		Namespace: "ns", Name: "n", Path: "model.safetensors", Lfs: true, RepoType: types.ModelRepo,
+	}, "u")
+	require.ErrorIs(t, err, errorx.ErrForbidden)
+}
@@ -0,0 +1,234 @@
+package component
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"path"
+	"time"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"github.com/minio/minio-go/v7"
+	"opencsg.com/csghub-server/builder/git/gitserver"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+	"opencsg.com/csghub-server/common/utils/common"
+)
+
+// DownloadArchive streams the archive of a repo revision, the archive is built by gitaly unless
+// the LFS content is included or the paths are filtered, then it's built file by file
+func (c *repoComponentImpl) DownloadArchive(ctx context.Context, req *types.DownloadArchiveReq) (io.ReadCloser, error) {
+	if req.Format == "" {
+		req.Format = types.ArchiveFormatZip
+	}
+	if req.Format != types.ArchiveFormatZip && req.Format != types.ArchiveFormatTarGz {
+		return nil, errorx.BadRequest(fmt.Errorf("unsupported archive format %s", req.Format), nil)
+	}
+	for _, pattern := range append(req.Include, req.Exclude...) {
+		if !doublestar.ValidatePattern(pattern) {
+			return nil, errorx.BadRequest(fmt.Errorf("invalid glob pattern %s", pattern), nil)
+		}
+	}
+
+	repo, err := c.repoStore.FindByPath(ctx, req.RepoType, req.Namespace, req.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo, error: %w", err)
+	}
+	canRead, err := c.AllowReadAccessRepo(ctx, repo, req.CurrentUser)
+	if err != nil {
+		return nil, err
+	}
+	if !canRead {
+		return nil, errorx.ErrForbiddenMsg("users do not have permission to download archive of this repo")
+	}
+	if req.IncludeLfs && repo.XnetEnabled {
+		return nil, errorx.BadRequest(errors.New("LFS content of xnet enabled repos can not be archived"), nil)
+	}
+	if req.IncludeLfs {
+		if err = c.CheckGatedAccess(ctx, repo, req.CurrentUser); err != nil {
+			return nil, err
+		}
+	}
+
+	commitID, err := c.resolveRevision(ctx, repo, req.Revision)
+	if err != nil {
+		return nil, err
+	}
+
+	if !req.IncludeLfs && len(req.Include) == 0 && len(req.Exclude) == 0 {
+		reader, err := c.git.GetArchiveReader(ctx, gitserver.GetArchiveReq{
+			Namespace: req.Namespace,
+			Name:      req.Name,
+			Revision:  commitID,
+			RepoType:  req.RepoType,
+			Format:    req.Format,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get archive of git %s repository, error: %w", req.RepoType, err)
+		}
+		return reader, nil
+	}
+
+	allFiles, err := getAllFiles(ctx, req.Namespace, req.Name, "", req.RepoType, commitID, c.git.GetTree)
+	if err != nil {
+		return nil, err
+	}
+	var files []*types.File
+	for _, file := range allFiles {
+		if matchArchivePath(file.Path, req.Include, req.Exclude) {
+			files = append(files, file)
+		}
+	}
+	if len(files) == 0 {
+		return nil, errorx.BadRequest(errors.New("no files matched the include and exclude patterns"), nil)
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		err := c.writeArchive(ctx, pw, repo, req, commitID, files)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to write repo archive", slog.String("repo", repo.Path), slog.String("revision", commitID), slog.Any("error", err))
+		}
+		pw.CloseWithError(err)
+	}()
+	return pr, nil
+}
+
+// matchArchivePath matches any of the include patterns and none of the exclude patterns,
+// all the paths match if there's no include pattern
+func matchArchivePath(filePath string, include, exclude []string) bool {
+	for _, pattern := range exclude {
+		if ok, _ := doublestar.Match(pattern, filePath); ok {
+			return false
+		}
+	}
+	if len(include) == 0 {
+		return true
+	}
+	for _, pattern := range include {
+		if ok, _ := doublestar.Match(pattern, filePath); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *repoComponentImpl) writeArchive(ctx context.Context, w io.Writer, repo *database.Repository, req *types.DownloadArchiveReq, commitID string, files []*types.File) error {
+	archive := newArchiveWriter(req.Format, w)
+	for _, file := range files {
+		reader, size, err := c.openArchiveFile(ctx, repo, req, commitID, file)
+		if err != nil {
+			archive.Close()
+			return err
+		}
+		// the entries are prefixed with the repo name like the archives of gitaly
+		err = archive.WriteFile(path.Join(req.Name, file.Path), size, reader)
+		reader.Close()
+		if err != nil {
+			archive.Close()
+			return fmt.Errorf("failed to archive file %s, error: %w", file.Path, err)
+		}
+	}
+	return archive.Close()
+}
+
+func (c *repoComponentImpl) openArchiveFile(ctx context.Context, repo *database.Repository, req *types.DownloadArchiveReq, commitID string, file *types.File) (io.ReadCloser, int64, error) {
+	if file.Lfs && req.IncludeLfs {
+		objectKey := common.BuildLfsPath(repo.ID, file.LfsSHA256, repo.Migrated)
+		object, err := c.s3Client.GetObject(ctx, c.lfsBucket, objectKey, minio.GetObjectOptions{})
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to get LFS object of file %s, error: %w", file.Path, err)
+		}
+		return object, file.Size, nil
+	}
+
+	reader, _, err := c.git.GetRepoFileReader(ctx, gitserver.GetRepoInfoByPathReq{
+		Namespace: req.Namespace,
+		Name:      req.Name,
+		Ref:       commitID,
+		Path:      file.Path,
+		RepoType:  req.RepoType,
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read file %s, error: %w", file.Path, err)
+	}
+	size := file.Size
+	if file.Lfs {
+		size = int64(file.LfsPointerSize)
+	}
+	return reader, size, nil
+}
+
+type archiveWriter interface {
+	WriteFile(name string, size int64, r io.Reader) error
+	Close() error
+}
+
+func newArchiveWriter(format types.ArchiveFormat, w io.Writer) archiveWriter {
+	if format == types.ArchiveFormatTarGz {
+		gw := gzip.NewWriter(w)
+		return &tarGzArchiveWriter{gw: gw, tw: tar.NewWriter(gw)}
+	}
+	return &zipArchiveWriter{zw: zip.NewWriter(w)}
+}
+
+type zipArchiveWriter struct {
+	zw *zip.Writer
+}
+
+func (a *zipArchiveWriter) WriteFile(name string, size int64, r io.Reader) error {
+	header := &zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Modified: time.Now(),
+	}
+	header.SetMode(0644)
+	// large files are mostly model weights and compressed data which are not worth compressing again
+	if size > 100<<20 {
+		header.Method = zip.Store
+	}
+	fw, err := a.zw.CreateHeader(header)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(fw, r)
+	return err
+}
+
+func (a *zipArchiveWriter) Close() error {
+	return a.zw.Close()
+}
+
+type tarGzArchiveWriter struct {
+	gw *gzip.Writer
+	tw *tar.Writer
+}
+
+func (a *tarGzArchiveWriter) WriteFile(name string, size int64, r io.Reader) error {
+	err := a.tw.WriteHeader(&tar.Header{
+		Name:     name,
+		Mode:     0644,
+		Size:     size,
+		Typeflag: tar.TypeReg,
+		ModTime:  time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+	_, err = io.CopyN(a.tw, r, size)
+	return err
+}
+
+func (a *tarGzArchiveWriter) Close() error {
+	if err := a.tw.Close(); err != nil {
+		a.gw.Close()
+		return err
+	}
+	return a.gw.Close()
+}
@@ -0,0 +1,127 @@
+package component
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"opencsg.com/csghub-server/builder/git/gitserver"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+)
+
+func mockArchiveRepo(ctx context.Context, repo *testRepoWithMocks) {
+	repo.mocks.stores.RepoMock().EXPECT().FindByPath(ctx, types.ModelRepo, "ns", "n").Return(&database.Repository{
+		ID: 1, Path: "ns/n", RepositoryType: types.ModelRepo, DefaultBranch: "main",
+	}, nil)
+	repo.mocks.gitServer.EXPECT().GetRepoLastCommit(ctx, gitserver.GetRepoLastCommitReq{
+		Namespace: "ns", Name: "n", Ref: "main", RepoType: types.ModelRepo,
+	}).Return(&types.Commit{ID: "c1"}, nil)
+}
+
+func mockArchiveTree(ctx context.Context, repo *testRepoWithMocks) {
+	repo.mocks.gitServer.EXPECT().GetTree(ctx, types.GetTreeRequest{
+		Namespace: "ns", Name: "n", Ref: "c1", RepoType: types.ModelRepo, Recursive: true, Limit: types.MaxFileTreeSize,
+	}).Return(&types.GetRepoFileTreeResp{Files: []*types.File{
+		{Path: "config.json", Type: "file", Size: 2},
+		{Path: "onnx", Type: "dir"},
+		{Path: "onnx/model.onnx", Type: "file", Lfs: true, LfsSHA256: strings.Repeat("a", 64), Size: 100, LfsPointerSize: 7},
+		{Path: "README.md", Type: "file", Size: 6},
+	}}, nil)
+}
+
+func TestRepoComponent_DownloadArchive(t *testing.T) {
+	ctx := context.TODO()
+	repo := initializeTestRepoComponent(ctx, t)
+	mockArchiveRepo(ctx, repo)
+
+	repo.mocks.gitServer.EXPECT().GetArchiveReader(ctx, gitserver.GetArchiveReq{
+		Namespace: "ns", Name: "n", Revision: "c1", RepoType: types.ModelRepo, Format: types.ArchiveFormatZip,
+	}).Return(io.NopCloser(strings.NewReader("zip")), nil)
+
+	reader, err := repo.DownloadArchive(ctx, &types.DownloadArchiveReq{
+		RepoType: types.ModelRepo, Namespace: "ns", Name: "n",
+	})
+	require.Nil(t, err)
+	content, err := io.ReadAll(reader)
+	require.Nil(t, err)
+	require.Equal(t, "zip", string(content))
+}
+
+func TestRepoComponent_DownloadArchiveFiltered(t *testing.T) {
+	ctx := context.TODO()
+	repo := initializeTestRepoComponent(ctx, t)
+	mockArchiveRepo(ctx, repo)
+	mockArchiveTree(ctx, repo)
+
+	for path, content := range map[string]string{"config.json": "{}", "onnx/model.onnx": "pointer"} {
+		repo.mocks.gitServer.EXPECT().GetRepoFileReader(mock.Anything, gitserver.GetRepoInfoByPathReq{
+			Namespace: "ns", Name: "n", Ref: "c1", Path: path, RepoType: types.ModelRepo,
+		}).Return(io.NopCloser(strings.NewReader(content)), int64(len(content)), nil)
+	}
+
+	reader, err := repo.DownloadArchive(ctx, &types.DownloadArchiveReq{
+		RepoType: types.ModelRepo, Namespace: "ns", Name: "n", Format: types.ArchiveFormatTarGz,
+		Include: []string{"*.json", "onnx/**"}, Exclude: []string{"README.md"},
+	})
+	require.Nil(t, err)
+	defer reader.Close()
+
+	gr, err := gzip.NewReader(reader)
+	require.Nil(t, err)
+	tr := tar.NewReader(gr)
+	entries := map[string]string{}
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.Nil(t, err)
+		content, err := io.ReadAll(tr)
+		require.Nil(t, err)
+		entries[header.Name] = string(content)
+	}
+	require.Equal(t, map[string]string{"n/config.json": "{}", "n/onnx/model.onnx": "pointer"}, entries)
+}
+
+func TestRepoComponent_DownloadArchiveLfsError(t *testing.T) {
+	ctx := context.TODO()
+	repo := initializeTestRepoComponent(ctx, t)
+	mockArchiveRepo(ctx, repo)
+	mockArchiveTree(ctx, repo)
+
+	repo.mocks.s3Client.EXPECT().GetObject(mock.Anything, mock.Anything, "lfs/aa/aa/"+strings.Repeat("a", 60), mock.Anything).
+		Return(nil, errors.New("s3 error"))
+
+	reader, err := repo.DownloadArchive(ctx, &types.DownloadArchiveReq{
+		RepoType: types.ModelRepo, Namespace: "ns", Name: "n", IncludeLfs: true, Include: []string{"onnx/*"},
+	})
+	require.Nil(t, err)
+	_, err = io.ReadAll(reader)
+	require.ErrorContains(t, err, "s3 error")
+}
+
+func TestRepoComponent_DownloadArchiveBadRequest(t *testing.T) {
+	ctx := context.TODO()
+	repo := initializeTestRepoComponent(ctx, t)
+
+	_, err := repo.DownloadArchive(ctx, &types.DownloadArchiveReq{Namespace: "ns", Name: "n", Format: "rar"})
+	require.ErrorIs(t, err, errorx.ErrBadRequest)
+	_, err = repo.DownloadArchive(ctx, &types.DownloadArchiveReq{Namespace: "ns", Name: "n", Include: []string{"[a"}})
+	require.ErrorIs(t, err, errorx.ErrBadRequest)
+}
+
+func TestMatchArchivePath(t *testing.T) {
+	require.True(t, matchArchivePath("a/b.json", nil, nil))
+	require.True(t, matchArchivePath("a/b.json", []string{"**/*.json"}, nil))
+	require.False(t, matchArchivePath("a/b.json", []string{"*.json"}, nil))
+	require.False(t, matchArchivePath("a/b.json", nil, []string{"a/**"}))
+	require.False(t, matchArchivePath("a/b.json", []string{"**/*.json"}, []string{"a/*"}))
+}
@@ -0,0 +1,78 @@
+package component
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"opencsg.com/csghub-server/builder/git/gitserver"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+	"opencsg.com/csghub-server/component/cardparser"
+)
+
+// GetCard returns the card parsed from the README of the default branch, the card saved by the git callback
+// is returned if any, otherwise the README is parsed at once for the repositories not pushed since the card
+// was introduced
+func (c *repoComponentImpl) GetCard(ctx context.Context, req *types.GetRepoCardReq) (*types.RepoCard, error) {
+	repo, err := c.repoStore.FindByPath(ctx, req.RepoType, req.Namespace, req.Name)
+	if errors.Is(err, errorx.ErrDatabaseNoRows) {
+		return nil, errorx.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo, error: %w", err)
+	}
+	permission, err := c.GetUserRepoPermission(ctx, req.CurrentUser, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user repo permission, error: %w", err)
+	}
+	if !permission.CanRead {
+		return nil, errorx.ErrForbiddenMsg("users do not have permission to get card of this repo")
+	}
+
+	metadata, err := c.metadataStore.FindByRepoID(ctx, repo.ID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to find repo metadata, error: %w", err)
+	}
+	if err == nil && metadata.Card != nil {
+		return metadata.Card, nil
+	}
+
+	readme, err := c.git.GetRepoFileRaw(ctx, gitserver.GetRepoInfoByPathReq{
+		Namespace: req.Namespace,
+		Name:      req.Name,
+		Ref:       repo.DefaultBranch,
+		Path:      types.ReadmeFileName,
+		RepoType:  req.RepoType,
+	})
+	if err != nil {
+		if errors.Is(err, errorx.ErrGitFileNotFound) {
+			return &types.RepoCard{RepoType: req.RepoType}, nil
+		}
+		return nil, fmt.Errorf("failed to get readme, error: %w", err)
+	}
+	card, warnings, err := cardparser.Parse(req.RepoType, readme)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to parse repo card", slog.String("repo", repo.Path), slog.Any("error", err))
+		return &types.RepoCard{RepoType: req.RepoType}, nil
+	}
+	card.Warnings = warnings
+	return card, nil
+}
+
+// readmeCardWarnings validates the base64 encoded README committed to the repository, the invalid yaml is
+// reported as a warning too as it doesn't block the commit
+func readmeCardWarnings(repoType types.RepositoryType, content string) []types.CardWarning {
+	readme, err := base64.StdEncoding.DecodeString(cleanBase64(content))
+	if err != nil {
+		return nil
+	}
+	_, warnings, err := cardparser.Parse(repoType, string(readme))
+	if err != nil {
+		return []types.CardWarning{{Message: err.Error()}}
+	}
+	return warnings
+}
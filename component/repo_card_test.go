@@ -0,0 +1,114 @@
+package component
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"opencsg.com/csghub-server/builder/git/gitserver"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+)
+
+func TestRepoComponent_GetCard(t *testing.T) {
+	req := &types.GetRepoCardReq{Namespace: "ns", Name: "n", RepoType: types.ModelRepo, CurrentUser: "user"}
+	r := &database.Repository{ID: 123, Private: true, DefaultBranch: "main"}
+
+	t.Run("repo not found", func(t *testing.T) {
+		ctx := context.TODO()
+		repo := initializeTestRepoComponent(ctx, t)
+		repo.mocks.stores.RepoMock().EXPECT().FindByPath(ctx, types.ModelRepo, "ns", "n").Return(nil, errorx.ErrDatabaseNoRows)
+
+		_, err := repo.GetCard(ctx, req)
+		require.ErrorIs(t, err, errorx.ErrNotFound)
+	})
+
+	t.Run("saved card", func(t *testing.T) {
+		ctx := context.TODO()
+		repo := initializeTestRepoComponent(ctx, t)
+		repo.mocks.stores.RepoMock().EXPECT().FindByPath(ctx, types.ModelRepo, "ns", "n").Return(r, nil)
+		mockUserRepoAdminPermission(ctx, repo.mocks.stores, "user")
+		card := &types.RepoCard{RepoType: types.ModelRepo, License: "mit"}
+		repo.mocks.stores.MetadataMock().EXPECT().FindByRepoID(ctx, int64(123)).Return(&database.Metadata{Card: card}, nil)
+
+		res, err := repo.GetCard(ctx, req)
+		require.NoError(t, err)
+		require.Equal(t, card, res)
+	})
+
+	t.Run("parse the readme of the repo not pushed", func(t *testing.T) {
+		ctx := context.TODO()
+		repo := initializeTestRepoComponent(ctx, t)
+		repo.mocks.stores.RepoMock().EXPECT().FindByPath(ctx, types.ModelRepo, "ns", "n").Return(r, nil)
+		mockUserRepoAdminPermission(ctx, repo.mocks.stores, "user")
+		repo.mocks.stores.MetadataMock().EXPECT().FindByRepoID(ctx, int64(123)).Return(nil, sql.ErrNoRows)
+		repo.mocks.gitServer.EXPECT().GetRepoFileRaw(ctx, gitserver.GetRepoInfoByPathReq{
+			Namespace: "ns",
+			Name:      "n",
+			Ref:       "main",
+			Path:      types.ReadmeFileName,
+			RepoType:  types.ModelRepo,
+		}).Return("---\nbase_model: foo/base\n---\n", nil)
+
+		res, err := repo.GetCard(ctx, req)
+		require.NoError(t, err)
+		require.Equal(t, &types.RepoCard{RepoType: types.ModelRepo, BaseModel: []string{"foo/base"}}, res)
+	})
+
+	t.Run("saved card with warnings", func(t *testing.T) {
+		ctx := context.TODO()
+		repo := initializeTestRepoComponent(ctx, t)
+		repo.mocks.stores.RepoMock().EXPECT().FindByPath(ctx, types.ModelRepo, "ns", "n").Return(r, nil)
+		mockUserRepoAdminPermission(ctx, repo.mocks.stores, "user")
+		card := &types.RepoCard{
+			RepoType: types.ModelRepo,
+			Warnings: []types.CardWarning{{Field: "pipeline_tag", Message: `unknown pipeline tag "chat"`}},
+		}
+		repo.mocks.stores.MetadataMock().EXPECT().FindByRepoID(ctx, int64(123)).Return(&database.Metadata{Card: card}, nil)
+
+		res, err := repo.GetCard(ctx, req)
+		require.NoError(t, err)
+		require.Equal(t, card.Warnings, res.Warnings)
+	})
+
+	t.Run("no readme", func(t *testing.T) {
+		ctx := context.TODO()
+		repo := initializeTestRepoComponent(ctx, t)
+		repo.mocks.stores.RepoMock().EXPECT().FindByPath(ctx, types.ModelRepo, "ns", "n").Return(r, nil)
+		mockUserRepoAdminPermission(ctx, repo.mocks.stores, "user")
+		repo.mocks.stores.MetadataMock().EXPECT().FindByRepoID(ctx, int64(123)).Return(&database.Metadata{}, nil)
+		repo.mocks.gitServer.EXPECT().GetRepoFileRaw(ctx, gitserver.GetRepoInfoByPathReq{
+			Namespace: "ns",
+			Name:      "n",
+			Ref:       "main",
+			Path:      types.ReadmeFileName,
+			RepoType:  types.ModelRepo,
+		}).Return("", errorx.GitFileNotFound(errors.New("not found"), nil))
+
+		res, err := repo.GetCard(ctx, req)
+		require.NoError(t, err)
+		require.Equal(t, &types.RepoCard{RepoType: types.ModelRepo}, res)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		ctx := context.TODO()
+		repo := initializeTestRepoComponent(ctx, t)
+		repo.mocks.stores.RepoMock().EXPECT().FindByPath(ctx, types.ModelRepo, "ns", "n").Return(r, nil)
+
+		_, err := repo.GetCard(ctx, &types.GetRepoCardReq{Namespace: "ns", Name: "n", RepoType: types.ModelRepo})
+		require.ErrorIs(t, err, errorx.ErrForbidden)
+	})
+}
+
+func TestReadmeCardWarnings(t *testing.T) {
+	content := base64.StdEncoding.EncodeToString([]byte("---\nlicense: mit\npipeline_tag: chat\n---\n"))
+	require.Equal(t, []types.CardWarning{{Field: "pipeline_tag", Message: `unknown pipeline tag "chat"`}},
+		readmeCardWarnings(types.ModelRepo, content))
+
+	content = base64.StdEncoding.EncodeToString([]byte("---\nlicense: [mit\n---\n"))
+	require.Len(t, readmeCardWarnings(types.ModelRepo, content), 1)
+}
@@ -33,6 +33,8 @@ func NewRepoComponent(config *config.Config) (RepoComponent, error) {
 	c.tagStore = database.NewTagStore()
 	c.repoStore = database.NewRepoStore()
 	c.repoFileStore = database.NewRepoFileStore()
+	c.modelFileScanStore = database.NewModelFileScanStore()
+	c.inferenceRolloutStore = database.NewInferenceRolloutStore()
 	c.repoRelationsStore = database.NewRepoRelationsStore()
 	c.repoStatisticsStore = database.NewRepositoryStatisticsStore()
 	c.userLikesStore = database.NewUserLikesStore()
@@ -43,6 +45,7 @@ func NewRepoComponent(config *config.Config) (RepoComponent, error) {
 	c.syncClientSettingStore = database.NewSyncClientSettingStore()
 	c.fileStore = database.NewFileStore()
 	c.mirrorTaskStore = database.NewMirrorTaskStore()
+	c.storageQuota = newStorageQuotaChecker(config, database.NewNamespaceStorageQuotaStore(), c.namespaceStore)
 	var err error
 	c.git, err = git.NewGitServer(config)
 	if err != nil {
@@ -84,6 +87,11 @@ func NewRepoComponent(config *config.Config) (RepoComponent, error) {
 	c.lfsMetaObjectStore = database.NewLfsMetaObjectStore()
 	c.userResourcesStore = database.NewUserResourcesStore()
 	c.recomStore = database.NewRecomStore()
+	c.feedEventStore = database.NewFeedEventStore()
+	c.metadataStore = database.NewMetadataStore()
+	c.releaseStore = database.NewReleaseStore()
+	c.followStore = database.NewFollowStore()
+	c.repoAccessStore = database.NewRepoAccessStore()
 	c.config = config
 	syncClientSettingStore := database.NewSyncClientSettingStore()
 	setting, err := syncClientSettingStore.First(context.Background())
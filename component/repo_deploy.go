@@ -444,6 +444,11 @@ func (c *repoComponentImpl) DeleteDeploy(ctx context.Context, delReq types.Deplo
 	if err != nil {
 		return err
 	}
+	if deploy.Type == types.InferenceType {
+		if err := checkNoRunningRollout(ctx, c.inferenceRolloutStore, deploy.ID); err != nil {
+			return err
+		}
+	}
 
 	// delete service
 	deployRepo := types.DeployRequest{
@@ -592,6 +597,14 @@ func (c *repoComponentImpl) DeployDetail(ctx context.Context, detailReq types.De
 	}
 	resDeploy.PD = deploy.PD
 
+	if deploy.Type == types.InferenceType {
+		rollout, err := c.inferenceRolloutStore.FindLatestByDeployID(ctx, deploy.ID)
+		if err != nil && !errors.Is(err, errorx.ErrDatabaseNoRows) {
+			slog.WarnContext(ctx, "failed to get inference rollout", slog.Int64("deploy_id", deploy.ID), slog.Any("error", err))
+		}
+		resDeploy.InferenceRollout = inferenceRolloutToType(rollout)
+	}
+
 	return &resDeploy, nil
 }
 
@@ -924,6 +937,11 @@ func (c *repoComponentImpl) DeployUpdate(ctx context.Context, updateReq types.De
 	if err != nil {
 		return fmt.Errorf("failed to check permission for update deploy, %w", err)
 	}
+	if deploy.Type == types.InferenceType {
+		if err := checkNoRunningRollout(ctx, c.inferenceRolloutStore, deploy.ID); err != nil {
+			return err
+		}
+	}
 	// check user balance if resource changed
 	if req.ResourceID != nil {
 		// don't support switch reserved resource
@@ -2,26 +2,36 @@ package component
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"regexp"
+	"strings"
 	"sync"
 
 	"opencsg.com/csghub-server/builder/git"
 	"opencsg.com/csghub-server/builder/git/gitserver"
+	"opencsg.com/csghub-server/builder/rpc"
 	"opencsg.com/csghub-server/builder/store/database"
 	"opencsg.com/csghub-server/common/config"
+	"opencsg.com/csghub-server/common/errorx"
 	"opencsg.com/csghub-server/common/types"
 )
 
+var lfsOIDRegexp = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
 type repoFileComponentImpl struct {
 	repoFileStore database.RepoFileStore
 	repoStore     database.RepoStore
 	gitServer     gitserver.GitServer
+	userSvcClient rpc.UserSvcClient
 }
 
 type RepoFileComponent interface {
 	GenRepoFileRecords(ctx context.Context, repoType types.RepositoryType, namespace, name string) error
 	GenRepoFileRecordsBatch(ctx context.Context, repoType types.RepositoryType, lastRepoID int64, concurrency int) error
+	// SearchFiles searches the files across the repositories visible to the current user
+	SearchFiles(ctx context.Context, req *types.SearchRepoFileReq) ([]types.RepoFileSearchResult, int, error)
 }
 
 func NewRepoFileComponent(conf *config.Config) (RepoFileComponent, error) {
@@ -35,6 +45,8 @@ func NewRepoFileComponent(conf *config.Config) (RepoFileComponent, error) {
 	}
 
 	c.gitServer = gs
+	c.userSvcClient = rpc.NewUserSvcHttpClient(fmt.Sprintf("%s:%d", conf.User.Host, conf.User.Port),
+		rpc.AuthWithApiKey(conf.APIToken))
 	return c, nil
 }
 func (c *repoFileComponentImpl) GenRepoFileRecords(ctx context.Context, repoType types.RepositoryType, namespace, name string) error {
@@ -94,7 +106,12 @@ func (c *repoFileComponentImpl) GenRepoFileRecordsBatch(ctx context.Context, rep
 
 func (c *repoFileComponentImpl) createRepoFileRecords(ctx context.Context, repo database.Repository) error {
 	namespace, name := repo.NamespaceAndName()
-	var cursor string
+	var (
+		cursor  string
+		current []database.RepositoryFile
+		// listed is true when the whole tree is listed, the stale files are only removed then
+		listed bool
+	)
 
 	for {
 		var files []*types.File
@@ -135,6 +152,7 @@ func (c *repoFileComponentImpl) createRepoFileRecords(ctx context.Context, repo
 				LfsRelativePath: file.LfsRelativePath,
 				Branch:          repo.DefaultBranch,
 			}
+			current = append(current, rf)
 
 			var exists bool
 			var err error
@@ -156,9 +174,89 @@ func (c *repoFileComponentImpl) createRepoFileRecords(ctx context.Context, repo
 		}
 
 		if resp.Cursor == "" {
+			listed = true
 			break
 		}
 	}
 
+	if listed {
+		if err := c.removeStaleRepoFiles(ctx, repo, current); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeStaleRepoFiles removes the records of the files deleted from the default branch and the older
+// versions of the files, so they are not found by the file search
+func (c *repoFileComponentImpl) removeStaleRepoFiles(ctx context.Context, repo database.Repository, current []database.RepositoryFile) error {
+	removed, err := c.repoFileStore.DeleteStale(ctx, repo.ID, repo.DefaultBranch, current)
+	if err != nil {
+		return fmt.Errorf("failed to remove stale repository files, error: %w", err)
+	}
+	if removed > 0 {
+		slog.Info("stale repository files removed", slog.Any("repo_id", repo.ID), slog.Int("count", removed))
+	}
+	return nil
+}
+
+func (c *repoFileComponentImpl) SearchFiles(ctx context.Context, req *types.SearchRepoFileReq) ([]types.RepoFileSearchResult, int, error) {
+	req.LfsOID = strings.ToLower(strings.TrimPrefix(req.LfsOID, "sha256:"))
+	if err := validateSearchRepoFileReq(req); err != nil {
+		return nil, 0, err
+	}
+
+	var (
+		ownerNamespaces []string
+		isAdmin         bool
+	)
+	if req.CurrentUser != "" {
+		user, err := c.userSvcClient.GetUserInfo(ctx, req.CurrentUser, req.CurrentUser)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to get user info, error: %w", err)
+		}
+		ownerNamespaces, isAdmin = buildAccessibleNamespaces(user)
+	}
+	files, total, err := c.repoFileStore.Search(ctx, req, ownerNamespaces, isAdmin)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// the visibility is filtered by the store like in RepoComponent.PublicToUser, so the total matches the
+	// files the user can read
+	results := make([]types.RepoFileSearchResult, 0, len(files))
+	for _, file := range files {
+		repo := file.Repository
+		if repo == nil {
+			continue
+		}
+		results = append(results, types.RepoFileSearchResult{
+			RepoType:  repo.RepositoryType,
+			RepoPath:  repo.Path,
+			Private:   repo.Private,
+			Path:      file.Path,
+			Size:      file.Size,
+			Branch:    file.Branch,
+			CommitSha: file.CommitSha,
+			LFS:       file.LfsRelativePath != "",
+			LfsOID:    strings.ReplaceAll(file.LfsRelativePath, "/", ""),
+		})
+	}
+	return results, total, nil
+}
+
+func validateSearchRepoFileReq(req *types.SearchRepoFileReq) error {
+	if req.RepoType != "" && !req.RepoType.IsValid() {
+		return errorx.ReqParamInvalid(fmt.Errorf("invalid repo type %s", req.RepoType), errorx.Ctx().Set("param", "repo_type"))
+	}
+	if req.MinSize < 0 || req.MaxSize < 0 {
+		return errorx.ReqParamInvalid(errors.New("size must not be negative"), errorx.Ctx().Set("param", "min_size"))
+	}
+	if req.MaxSize > 0 && req.MinSize > req.MaxSize {
+		return errorx.ReqParamInvalid(errors.New("min_size must not be greater than max_size"), errorx.Ctx().Set("param", "min_size"))
+	}
+	if req.LfsOID != "" && !lfsOIDRegexp.MatchString(req.LfsOID) {
+		return errorx.ReqParamInvalid(errors.New("lfs_oid must be a sha256 hex string"), errorx.Ctx().Set("param", "lfs_oid"))
+	}
 	return nil
 }
@@ -6,7 +6,9 @@ import (
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"opencsg.com/csghub-server/builder/rpc"
 	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/errorx"
 	"opencsg.com/csghub-server/common/types"
 )
 
@@ -34,6 +36,12 @@ func TestRepoFileComponent_GenRepoFileRecords(t *testing.T) {
 		Path:         "foo.go",
 		FileType:     "go",
 	}).Return(nil)
+	// the records of the files not in the tree any more are removed
+	rc.mocks.stores.RepoFileMock().EXPECT().DeleteStale(ctx, int64(1), "", []database.RepositoryFile{{
+		RepositoryID: 1,
+		Path:         "foo.go",
+		FileType:     "go",
+	}}).Return(1, nil)
 
 	err := rc.GenRepoFileRecords(ctx, types.ModelRepo, "ns", "n")
 	require.Nil(t, err)
@@ -69,7 +77,60 @@ func TestRepoFileComponent_GenRepoFileRecordsBatch(t *testing.T) {
 		Path:         "foo.go",
 		FileType:     "go",
 	}).Return(nil)
+	// the records of the files not in the tree any more are removed
+	rc.mocks.stores.RepoFileMock().EXPECT().DeleteStale(ctx, int64(1), "", []database.RepositoryFile{{
+		RepositoryID: 1,
+		Path:         "foo.go",
+		FileType:     "go",
+	}}).Return(1, nil)
 
 	err := rc.GenRepoFileRecordsBatch(ctx, types.ModelRepo, 1, 10)
 	require.Nil(t, err)
 }
+
+func TestRepoFileComponent_SearchFiles(t *testing.T) {
+	ctx := context.TODO()
+	rc := initializeTestRepoFileComponent(ctx, t)
+
+	oid := "ab12cd34ef56ab12cd34ef56ab12cd34ef56ab12cd34ef56ab12cd34ef56ab12"
+	req := &types.SearchRepoFileReq{
+		CurrentUser: "user",
+		LfsOID:      "sha256:" + oid,
+		Per:         10,
+		Page:        1,
+	}
+	rc.mocks.userSvcClient.EXPECT().GetUserInfo(ctx, "user", "user").Return(&rpc.User{
+		Username: "user",
+		Orgs:     []rpc.Organization{{Name: "org1"}},
+	}, nil)
+	public := &database.Repository{ID: 1, Path: "ns/public", RepositoryType: types.ModelRepo}
+	private := &database.Repository{ID: 2, Path: "org1/private", RepositoryType: types.DatasetRepo, Private: true}
+	// the private repositories are filtered by the store, so the total is the count of the readable files
+	rc.mocks.stores.RepoFileMock().EXPECT().Search(ctx, req, []string{"user", "org1"}, false).Return([]database.RepositoryFile{
+		{Path: "a.gguf", Size: 100, Branch: "main", LfsRelativePath: "ab/12/" + oid[4:], Repository: public},
+		{Path: "b.gguf", Size: 100, Branch: "main", LfsRelativePath: "ab/12/" + oid[4:], Repository: private},
+	}, 12, nil)
+
+	files, total, err := rc.SearchFiles(ctx, req)
+	require.Nil(t, err)
+	require.Equal(t, oid, req.LfsOID)
+	require.Equal(t, 12, total)
+	require.Equal(t, []types.RepoFileSearchResult{
+		{RepoType: types.ModelRepo, RepoPath: "ns/public", Path: "a.gguf", Size: 100, Branch: "main", LFS: true, LfsOID: oid},
+		{RepoType: types.DatasetRepo, RepoPath: "org1/private", Private: true, Path: "b.gguf", Size: 100, Branch: "main", LFS: true, LfsOID: oid},
+	}, files)
+}
+
+func TestRepoFileComponent_SearchFilesInvalidParam(t *testing.T) {
+	ctx := context.TODO()
+	rc := initializeTestRepoFileComponent(ctx, t)
+
+	for _, req := range []*types.SearchRepoFileReq{
+		{LfsOID: "abc"},
+		{MinSize: 10, MaxSize: 1},
+		{RepoType: "foo"},
+	} {
+		_, _, err := rc.SearchFiles(ctx, req)
+		require.ErrorIs(t, err, errorx.ErrReqParamInvalid)
+	}
+}
@@ -0,0 +1,228 @@
+package component
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"opencsg.com/csghub-server/builder/git/gitserver"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+)
+
+// findRepoForRefWrite returns the repo and the current user if the user can write the repo
+func (c *repoComponentImpl) findRepoForRefWrite(ctx context.Context, repoType types.RepositoryType, namespace, name, currentUser string) (*database.Repository, *database.User, error) {
+	repo, err := c.repoStore.FindByPath(ctx, repoType, namespace, name)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find repo, error: %w", err)
+	}
+	user, err := c.userStore.FindByUsername(ctx, currentUser)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find user, error: %w", err)
+	}
+	permission, err := c.GetUserRepoPermission(ctx, currentUser, repo)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get user repo permission, error: %w", err)
+	}
+	if !permission.CanWrite {
+		return nil, nil, errorx.ErrForbiddenMsg("users do not have permission to update refs in this repo")
+	}
+	return repo, &user, nil
+}
+
+// resolveRevision returns the commit id of a commit, branch or tag
+func (c *repoComponentImpl) resolveRevision(ctx context.Context, repo *database.Repository, revision string) (string, error) {
+	if revision == "" {
+		revision = repo.DefaultBranch
+	}
+	namespace, name := repo.NamespaceAndName()
+	commit, err := c.git.GetRepoLastCommit(ctx, gitserver.GetRepoLastCommitReq{
+		Namespace: namespace,
+		Name:      name,
+		Ref:       revision,
+		RepoType:  repo.RepositoryType,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to find revision '%s', error: %w", revision, err)
+	}
+	if commit == nil || commit.ID == "" {
+		return "", fmt.Errorf("revision '%s' not found, error: %w", revision, errorx.ErrNotFound)
+	}
+	return commit.ID, nil
+}
+
+func (c *repoComponentImpl) CreateBranch(ctx context.Context, req types.CreateBranchReq) error {
+	repo, _, err := c.findRepoForRefWrite(ctx, req.RepoType, req.Namespace, req.Name, req.CurrentUser)
+	if err != nil {
+		return err
+	}
+
+	// gitaly overwrites an existing branch silently, so check it first
+	branch, err := c.git.GetRepoBranchByName(ctx, gitserver.GetBranchReq{
+		Namespace: req.Namespace,
+		Name:      req.Name,
+		Ref:       req.BranchName,
+		RepoType:  req.RepoType,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to find branch '%s', error: %w", req.BranchName, err)
+	}
+	if branch != nil {
+		return fmt.Errorf("branch '%s' already exists, error: %w", req.BranchName, errorx.ErrAlreadyExists)
+	}
+
+	commitID, err := c.resolveRevision(ctx, repo, req.CommitID)
+	if err != nil {
+		return err
+	}
+	err = c.git.CreateBranch(ctx, gitserver.CreateBranchReq{
+		Namespace:  req.Namespace,
+		Name:       req.Name,
+		BranchName: req.BranchName,
+		CommitID:   commitID,
+		RepoType:   req.RepoType,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create branch '%s', error: %w", req.BranchName, err)
+	}
+	return nil
+}
+
+func (c *repoComponentImpl) DeleteBranch(ctx context.Context, req types.DeleteBranchReq) error {
+	repo, user, err := c.findRepoForRefWrite(ctx, req.RepoType, req.Namespace, req.Name, req.CurrentUser)
+	if err != nil {
+		return err
+	}
+	if req.BranchName == repo.DefaultBranch {
+		return errorx.BadRequest(fmt.Errorf("can not delete the default branch '%s'", req.BranchName), nil)
+	}
+
+	branch, err := c.git.GetRepoBranchByName(ctx, gitserver.GetBranchReq{
+		Namespace: req.Namespace,
+		Name:      req.Name,
+		Ref:       req.BranchName,
+		RepoType:  req.RepoType,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to find branch '%s', error: %w", req.BranchName, err)
+	}
+	if branch == nil {
+		return fmt.Errorf("branch '%s' not found, error: %w", req.BranchName, errorx.ErrNotFound)
+	}
+
+	err = c.git.DeleteRepoBranch(ctx, gitserver.DeleteBranchReq{
+		Namespace: req.Namespace,
+		Name:      req.Name,
+		Ref:       req.BranchName,
+		RepoType:  req.RepoType,
+		Username:  user.Username,
+		Email:     user.Email,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete branch '%s', error: %w", req.BranchName, err)
+	}
+	return nil
+}
+
+// GitTags returns the tags of the git repository, unlike Tags which returns the labels of the repo
+func (c *repoComponentImpl) GitTags(ctx context.Context, req *types.GetTagsReq) ([]types.Tag, error) {
+	repo, err := c.repoStore.FindByPath(ctx, req.RepoType, req.Namespace, req.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo, error: %w", err)
+	}
+
+	permission, err := c.GetUserRepoPermission(ctx, req.CurrentUser, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user repo permission, error: %w", err)
+	}
+	if !permission.CanRead {
+		return nil, errorx.ErrForbiddenMsg("users do not have permission to get tags in this repo")
+	}
+
+	tags, err := c.git.GetRepoTags(ctx, gitserver.GetTagsReq{
+		Namespace: req.Namespace,
+		Name:      req.Name,
+		RepoType:  req.RepoType,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get git %s repository tags, error: %w", req.RepoType, err)
+	}
+	return tags, nil
+}
+
+func (c *repoComponentImpl) findGitTag(ctx context.Context, repoType types.RepositoryType, namespace, name, tagName string) (bool, error) {
+	tags, err := c.git.GetRepoTags(ctx, gitserver.GetTagsReq{
+		Namespace: namespace,
+		Name:      name,
+		RepoType:  repoType,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to get git %s repository tags, error: %w", repoType, err)
+	}
+	return slices.ContainsFunc(tags, func(tag types.Tag) bool {
+		return tag.Name == tagName
+	}), nil
+}
+
+func (c *repoComponentImpl) CreateGitTag(ctx context.Context, req types.CreateGitTagReq) error {
+	repo, user, err := c.findRepoForRefWrite(ctx, req.RepoType, req.Namespace, req.Name, req.CurrentUser)
+	if err != nil {
+		return err
+	}
+
+	exists, err := c.findGitTag(ctx, req.RepoType, req.Namespace, req.Name, req.TagName)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("tag '%s' already exists, error: %w", req.TagName, errorx.ErrAlreadyExists)
+	}
+
+	commitID, err := c.resolveRevision(ctx, repo, req.Revision)
+	if err != nil {
+		return err
+	}
+	err = c.git.CreateRepoTag(ctx, gitserver.CreateTagReq{
+		Namespace: req.Namespace,
+		Name:      req.Name,
+		RepoType:  req.RepoType,
+		TagName:   req.TagName,
+		Revision:  commitID,
+		Message:   req.Message,
+		Username:  user.Username,
+		Email:     user.Email,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create tag '%s', error: %w", req.TagName, err)
+	}
+	return nil
+}
+
+func (c *repoComponentImpl) DeleteGitTag(ctx context.Context, req types.DeleteGitTagReq) error {
+	_, user, err := c.findRepoForRefWrite(ctx, req.RepoType, req.Namespace, req.Name, req.CurrentUser)
+	if err != nil {
+		return err
+	}
+
+	exists, err := c.findGitTag(ctx, req.RepoType, req.Namespace, req.Name, req.TagName)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("tag '%s' not found, error: %w", req.TagName, errorx.ErrNotFound)
+	}
+
+	err = c.git.DeleteRepoTag(ctx, gitserver.DeleteTagReq{
+		Namespace: req.Namespace,
+		Name:      req.Name,
+		RepoType:  req.RepoType,
+		TagName:   req.TagName,
+		Username:  user.Username,
+		Email:     user.Email,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete tag '%s', error: %w", req.TagName, err)
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+package component
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"opencsg.com/csghub-server/builder/git/gitserver"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/tests"
+	"opencsg.com/csghub-server/common/types"
+)
+
+func mockRepoRefOwner(ctx context.Context, stores *tests.MockStores) {
+	stores.RepoMock().EXPECT().FindByPath(ctx, types.ModelRepo, "ns", "n").Return(&database.Repository{
+		Path: "ns/n", RepositoryType: types.ModelRepo, DefaultBranch: "main",
+	}, nil)
+	stores.UserMock().EXPECT().FindByUsername(ctx, "ns").Return(database.User{
+		Username: "ns", Email: "ns@example.com",
+	}, nil)
+	stores.NamespaceMock().EXPECT().FindByPath(ctx, "ns").Return(database.Namespace{
+		Path: "ns", NamespaceType: "user",
+	}, nil)
+}
+
+func TestRepoComponent_CreateBranch(t *testing.T) {
+	ctx := context.TODO()
+	repo := initializeTestRepoComponent(ctx, t)
+	mockRepoRefOwner(ctx, repo.mocks.stores)
+
+	repo.mocks.gitServer.EXPECT().GetRepoBranchByName(ctx, gitserver.GetBranchReq{
+		Namespace: "ns", Name: "n", Ref: "dev", RepoType: types.ModelRepo,
+	}).Return(nil, nil).Once()
+	repo.mocks.gitServer.EXPECT().GetRepoLastCommit(ctx, gitserver.GetRepoLastCommitReq{
+		Namespace: "ns", Name: "n", Ref: "main", RepoType: types.ModelRepo,
+	}).Return(&types.Commit{ID: "c1"}, nil)
+	repo.mocks.gitServer.EXPECT().CreateBranch(ctx, gitserver.CreateBranchReq{
+		Namespace: "ns", Name: "n", BranchName: "dev", CommitID: "c1", RepoType: types.ModelRepo,
+	}).Return(nil)
+
+	req := types.CreateBranchReq{
+		Namespace: "ns", Name: "n", BranchName: "dev", RepoType: types.ModelRepo, CurrentUser: "ns",
+	}
+	err := repo.CreateBranch(ctx, req)
+	require.Nil(t, err)
+
+	repo.mocks.gitServer.EXPECT().GetRepoBranchByName(ctx, gitserver.GetBranchReq{
+		Namespace: "ns", Name: "n", Ref: "dev", RepoType: types.ModelRepo,
+	}).Return(&types.Branch{Name: "dev"}, nil).Once()
+	err = repo.CreateBranch(ctx, req)
+	require.ErrorIs(t, err, errorx.ErrAlreadyExists)
+}
+
+func TestRepoComponent_CreateBranchForbidden(t *testing.T) {
+	ctx := context.TODO()
+	repo := initializeTestRepoComponent(ctx, t)
+	repo.mocks.stores.RepoMock().EXPECT().FindByPath(ctx, types.ModelRepo, "ns", "n").Return(&database.Repository{
+		Path: "ns/n", RepositoryType: types.ModelRepo,
+	}, nil)
+	repo.mocks.stores.UserMock().EXPECT().FindByUsername(ctx, "other").Return(database.User{Username: "other"}, nil)
+	repo.mocks.stores.NamespaceMock().EXPECT().FindByPath(ctx, "ns").Return(database.Namespace{
+		Path: "ns", NamespaceType: "user",
+	}, nil)
+
+	err := repo.CreateBranch(ctx, types.CreateBranchReq{
+		Namespace: "ns", Name: "n", BranchName: "dev", RepoType: types.ModelRepo, CurrentUser: "other",
+	})
+	require.ErrorIs(t, err, errorx.ErrForbidden)
+}
+
+func TestRepoComponent_DeleteBranch(t *testing.T) {
+	ctx := context.TODO()
+	repo := initializeTestRepoComponent(ctx, t)
+	mockRepoRefOwner(ctx, repo.mocks.stores)
+
+	err := repo.DeleteBranch(ctx, types.DeleteBranchReq{
+		Namespace: "ns", Name: "n", BranchName: "main", RepoType: types.ModelRepo, CurrentUser: "ns",
+	})
+	require.ErrorIs(t, err, errorx.ErrBadRequest)
+
+	repo.mocks.gitServer.EXPECT().GetRepoBranchByName(ctx, gitserver.GetBranchReq{
+		Namespace: "ns", Name: "n", Ref: "dev", RepoType: types.ModelRepo,
+	}).Return(&types.Branch{Name: "dev"}, nil)
+	repo.mocks.gitServer.EXPECT().DeleteRepoBranch(ctx, gitserver.DeleteBranchReq{
+		Namespace: "ns", Name: "n", Ref: "dev", RepoType: types.ModelRepo,
+		Username: "ns", Email: "ns@example.com",
+	}).Return(nil)
+	err = repo.DeleteBranch(ctx, types.DeleteBranchReq{
+		Namespace: "ns", Name: "n", BranchName: "dev", RepoType: types.ModelRepo, CurrentUser: "ns",
+	})
+	require.Nil(t, err)
+}
+
+func TestRepoComponent_CreateGitTag(t *testing.T) {
+	ctx := context.TODO()
+	repo := initializeTestRepoComponent(ctx, t)
+	mockRepoRefOwner(ctx, repo.mocks.stores)
+
+	repo.mocks.gitServer.EXPECT().GetRepoTags(ctx, gitserver.GetTagsReq{
+		Namespace: "ns", Name: "n", RepoType: types.ModelRepo,
+	}).Return([]types.Tag{{Name: "v1"}}, nil)
+
+	err := repo.CreateGitTag(ctx, types.CreateGitTagReq{
+		Namespace: "ns", Name: "n", TagName: "v1", RepoType: types.ModelRepo, CurrentUser: "ns",
+	})
+	require.ErrorIs(t, err, errorx.ErrAlreadyExists)
+
+	repo.mocks.gitServer.EXPECT().GetRepoLastCommit(ctx, gitserver.GetRepoLastCommitReq{
+		Namespace: "ns", Name: "n", Ref: "missing", RepoType: types.ModelRepo,
+	}).Return(&types.Commit{}, nil)
+	err = repo.CreateGitTag(ctx, types.CreateGitTagReq{
+		Namespace: "ns", Name: "n", TagName: "v2", Revision: "missing", RepoType: types.ModelRepo, CurrentUser: "ns",
+	})
+	require.ErrorIs(t, err, errorx.ErrNotFound)
+
+	repo.mocks.gitServer.EXPECT().GetRepoLastCommit(ctx, gitserver.GetRepoLastCommitReq{
+		Namespace: "ns", Name: "n", Ref: "dev", RepoType: types.ModelRepo,
+	}).Return(&types.Commit{ID: "c2"}, nil)
+	repo.mocks.gitServer.EXPECT().CreateRepoTag(ctx, gitserver.CreateTagReq{
+		Namespace: "ns", Name: "n", RepoType: types.ModelRepo, TagName: "v2", Revision: "c2", Message: "release",
+		Username: "ns", Email: "ns@example.com",
+	}).Return(nil)
+	err = repo.CreateGitTag(ctx, types.CreateGitTagReq{
+		Namespace: "ns", Name: "n", TagName: "v2", Revision: "dev", Message: "release", RepoType: types.ModelRepo, CurrentUser: "ns",
+	})
+	require.Nil(t, err)
+}
+
+func TestRepoComponent_DeleteGitTag(t *testing.T) {
+	ctx := context.TODO()
+	repo := initializeTestRepoComponent(ctx, t)
+	mockRepoRefOwner(ctx, repo.mocks.stores)
+
+	repo.mocks.gitServer.EXPECT().GetRepoTags(ctx, gitserver.GetTagsReq{
+		Namespace: "ns", Name: "n", RepoType: types.ModelRepo,
+	}).Return([]types.Tag{{Name: "v1"}}, nil)
+
+	err := repo.DeleteGitTag(ctx, types.DeleteGitTagReq{
+		Namespace: "ns", Name: "n", TagName: "v2", RepoType: types.ModelRepo, CurrentUser: "ns",
+	})
+	require.ErrorIs(t, err, errorx.ErrNotFound)
+
+	repo.mocks.gitServer.EXPECT().DeleteRepoTag(ctx, gitserver.DeleteTagReq{
+		Namespace: "ns", Name: "n", RepoType: types.ModelRepo, TagName: "v1",
+		Username: "ns", Email: "ns@example.com",
+	}).Return(nil)
+	err = repo.DeleteGitTag(ctx, types.DeleteGitTagReq{
+		Namespace: "ns", Name: "n", TagName: "v1", RepoType: types.ModelRepo, CurrentUser: "ns",
+	})
+	require.Nil(t, err)
+}
+
+func TestRepoComponent_GitTags(t *testing.T) {
+	ctx := context.TODO()
+	repo := initializeTestRepoComponent(ctx, t)
+	repo.mocks.stores.RepoMock().EXPECT().FindByPath(ctx, types.ModelRepo, "ns", "n").Return(&database.Repository{
+		Path: "ns/n", RepositoryType: types.ModelRepo,
+	}, nil)
+	repo.mocks.gitServer.EXPECT().GetRepoTags(ctx, gitserver.GetTagsReq{
+		Namespace: "ns", Name: "n", RepoType: types.ModelRepo,
+	}).Return([]types.Tag{{Name: "v1"}}, nil)
+
+	tags, err := repo.GitTags(ctx, &types.GetTagsReq{Namespace: "ns", Name: "n", RepoType: types.ModelRepo})
+	require.Nil(t, err)
+	require.Equal(t, []types.Tag{{Name: "v1"}}, tags)
+}
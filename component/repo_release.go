@@ -0,0 +1,388 @@
+package component
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"opencsg.com/csghub-server/builder/git/gitserver"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+)
+
+// releaseNameRegexp matches the release names usable as a ref of the download urls, e.g. v1.2 or 2024-06-rc1
+var releaseNameRegexp = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,99}$`)
+
+func validateReleaseName(name string) error {
+	if !releaseNameRegexp.MatchString(name) {
+		return errorx.BadRequest(fmt.Errorf("invalid release name '%s', it must start with a letter or a digit and contain only letters, digits, '.', '_' or '-'", name), nil)
+	}
+	if name == types.LatestReleaseRef {
+		return errorx.BadRequest(fmt.Errorf("release name '%s' is reserved", name), nil)
+	}
+	return nil
+}
+
+func toRelease(release *database.Release) *types.Release {
+	r := &types.Release{
+		ID:               release.ID,
+		Name:             release.Name,
+		TagName:          release.TagName,
+		Title:            release.Title,
+		Changelog:        release.Changelog,
+		RecommendedFiles: release.RecommendedFiles,
+		Deprecated:       release.Deprecated,
+		DeprecationNote:  release.DeprecationNote,
+		Latest:           release.Latest,
+		CreatedAt:        release.CreatedAt,
+		UpdatedAt:        release.UpdatedAt,
+	}
+	if r.RecommendedFiles == nil {
+		r.RecommendedFiles = []string{}
+	}
+	if release.Author != nil {
+		r.Author = release.Author.Username
+	}
+	return r
+}
+
+// findRepoForRead returns the repo if the user can read it
+func (c *repoComponentImpl) findRepoForRead(ctx context.Context, repoType types.RepositoryType, namespace, name, currentUser string) (*database.Repository, error) {
+	repo, err := c.repoStore.FindByPath(ctx, repoType, namespace, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo, error: %w", err)
+	}
+	canRead, err := c.AllowReadAccessRepo(ctx, repo, currentUser)
+	if err != nil {
+		return nil, err
+	}
+	if !canRead {
+		return nil, errorx.ErrForbiddenMsg("users do not have permission to get releases in this repo")
+	}
+	return repo, nil
+}
+
+// findRelease returns the release by name, or the latest release if the name is types.LatestReleaseRef
+func (c *repoComponentImpl) findRelease(ctx context.Context, repo *database.Repository, name string) (*database.Release, error) {
+	var (
+		release *database.Release
+		err     error
+	)
+	if name == types.LatestReleaseRef {
+		release, err = c.releaseStore.FindByRef(ctx, repo.RepositoryType, repo.Path, name)
+	} else {
+		release, err = c.releaseStore.FindByName(ctx, repo.ID, name)
+	}
+	if err != nil {
+		if errors.Is(err, errorx.ErrDatabaseNoRows) {
+			return nil, fmt.Errorf("release '%s' not found, error: %w", name, errorx.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to find release '%s', error: %w", name, err)
+	}
+	return release, nil
+}
+
+// resolveReleaseRef returns the git tag of the release if the ref is a release name or types.LatestReleaseRef,
+// otherwise the ref itself
+func (c *repoComponentImpl) resolveReleaseRef(ctx context.Context, repoType types.RepositoryType, namespace, name, ref string) (string, error) {
+	if ref == "" {
+		return ref, nil
+	}
+	release, err := c.releaseStore.FindByRef(ctx, repoType, fmt.Sprintf("%s/%s", namespace, name), ref)
+	if err != nil {
+		if errors.Is(err, errorx.ErrDatabaseNoRows) {
+			return ref, nil
+		}
+		return "", fmt.Errorf("failed to find release '%s', error: %w", ref, err)
+	}
+	return release.TagName, nil
+}
+
+// checkReleaseRefs checks the tag of the release exists, and the release name is not used by another tag or
+// a branch, as the release names are resolved as the refs of the download urls
+func (c *repoComponentImpl) checkReleaseRefs(ctx context.Context, req *types.CreateReleaseReq) error {
+	tags, err := c.git.GetRepoTags(ctx, gitserver.GetTagsReq{
+		Namespace: req.Namespace,
+		Name:      req.Name,
+		RepoType:  req.RepoType,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get git %s repository tags, error: %w", req.RepoType, err)
+	}
+	if !slices.ContainsFunc(tags, func(tag types.Tag) bool { return tag.Name == req.TagName }) {
+		return fmt.Errorf("tag '%s' not found, error: %w", req.TagName, errorx.ErrNotFound)
+	}
+	// the release named after its own tag resolves to the same commit
+	if req.ReleaseName != req.TagName && slices.ContainsFunc(tags, func(tag types.Tag) bool { return tag.Name == req.ReleaseName }) {
+		return errorx.BadRequest(fmt.Errorf("release name '%s' is used by a tag", req.ReleaseName), nil)
+	}
+
+	branch, err := c.git.GetRepoBranchByName(ctx, gitserver.GetBranchReq{
+		Namespace: req.Namespace,
+		Name:      req.Name,
+		Ref:       req.ReleaseName,
+		RepoType:  req.RepoType,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to find branch '%s', error: %w", req.ReleaseName, err)
+	}
+	if branch != nil {
+		return errorx.BadRequest(fmt.Errorf("release name '%s' is used by a branch", req.ReleaseName), nil)
+	}
+	return nil
+}
+
+func (c *repoComponentImpl) CreateRelease(ctx context.Context, req *types.CreateReleaseReq) (*types.Release, error) {
+	if err := validateReleaseName(req.ReleaseName); err != nil {
+		return nil, err
+	}
+	repo, user, err := c.findRepoForRefWrite(ctx, req.RepoType, req.Namespace, req.Name, req.CurrentUser)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.checkReleaseRefs(ctx, req); err != nil {
+		return nil, err
+	}
+
+	count, err := c.releaseStore.CountByRepoID(ctx, repo.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count releases, error: %w", err)
+	}
+	release := &database.Release{
+		RepositoryID:     repo.ID,
+		Name:             req.ReleaseName,
+		TagName:          req.TagName,
+		Title:            req.Title,
+		Changelog:        req.Changelog,
+		RecommendedFiles: req.RecommendedFiles,
+		Latest:           req.Latest || count == 0,
+		AuthorID:         user.ID,
+	}
+	err = c.releaseStore.Create(ctx, release)
+	if err != nil {
+		if errors.Is(err, errorx.ErrDatabaseDuplicateKey) {
+			return nil, fmt.Errorf("release '%s' already exists, error: %w", req.ReleaseName, errorx.ErrAlreadyExists)
+		}
+		return nil, fmt.Errorf("failed to create release '%s', error: %w", req.ReleaseName, err)
+	}
+	release.Author = user
+
+	recordFeedEvent(ctx, c.feedEventStore, database.NewRepoFeedEvent(string(types.FeedEventReleaseCreated), repo, user.ID, map[string]any{
+		"release_name": release.Name,
+		"title":        release.Title,
+	}))
+	c.createReleaseSyncVersion(ctx, repo, release)
+	go c.notifyRelease(repo, release, user)
+	return toRelease(release), nil
+}
+
+func (c *repoComponentImpl) UpdateRelease(ctx context.Context, req *types.UpdateReleaseReq) (*types.Release, error) {
+	repo, _, err := c.findRepoForRefWrite(ctx, req.RepoType, req.Namespace, req.Name, req.CurrentUser)
+	if err != nil {
+		return nil, err
+	}
+	release, err := c.findRelease(ctx, repo, req.ReleaseName)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Latest != nil {
+		if !*req.Latest && release.Latest {
+			return nil, errorx.BadRequest(errors.New("can not unset the latest release, set another release as the latest instead"), nil)
+		}
+		release.Latest = *req.Latest
+	}
+	if req.Title != nil {
+		release.Title = *req.Title
+	}
+	if req.Changelog != nil {
+		release.Changelog = *req.Changelog
+	}
+	if req.RecommendedFiles != nil {
+		release.RecommendedFiles = *req.RecommendedFiles
+	}
+	if req.Deprecated != nil {
+		release.Deprecated = *req.Deprecated
+	}
+	if req.DeprecationNote != nil {
+		release.DeprecationNote = *req.DeprecationNote
+	}
+	if !release.Deprecated {
+		release.DeprecationNote = ""
+	}
+	err = c.releaseStore.Update(ctx, release)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update release '%s', error: %w", release.Name, err)
+	}
+	return toRelease(release), nil
+}
+
+func (c *repoComponentImpl) DeleteRelease(ctx context.Context, req *types.DeleteReleaseReq) error {
+	repo, _, err := c.findRepoForRefWrite(ctx, req.RepoType, req.Namespace, req.Name, req.CurrentUser)
+	if err != nil {
+		return err
+	}
+	release, err := c.findRelease(ctx, repo, req.ReleaseName)
+	if err != nil {
+		return err
+	}
+	err = c.releaseStore.Delete(ctx, repo.ID, release.Name)
+	if err != nil {
+		return fmt.Errorf("failed to delete release '%s', error: %w", release.Name, err)
+	}
+	return nil
+}
+
+func (c *repoComponentImpl) GetRelease(ctx context.Context, req *types.GetReleaseReq) (*types.Release, error) {
+	repo, err := c.findRepoForRead(ctx, req.RepoType, req.Namespace, req.Name, req.CurrentUser)
+	if err != nil {
+		return nil, err
+	}
+	release, err := c.findRelease(ctx, repo, req.ReleaseName)
+	if err != nil {
+		return nil, err
+	}
+	return toRelease(release), nil
+}
+
+func (c *repoComponentImpl) ListReleases(ctx context.Context, req *types.ListReleasesReq) ([]types.Release, int, error) {
+	repo, err := c.findRepoForRead(ctx, req.RepoType, req.Namespace, req.Name, req.CurrentUser)
+	if err != nil {
+		return nil, 0, err
+	}
+	releases, total, err := c.releaseStore.ListByRepoID(ctx, repo.ID, req.Per, req.Page)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list releases, error: %w", err)
+	}
+	result := make([]types.Release, 0, len(releases))
+	for _, release := range releases {
+		result = append(result, *toRelease(&release))
+	}
+	return result, total, nil
+}
+
+// createReleaseSyncVersion publishes the release of a public repository to the multi sync clients, the
+// failures are only logged as the release has been saved
+func (c *repoComponentImpl) createReleaseSyncVersion(ctx context.Context, repo *database.Repository, release *database.Release) {
+	if repo.Private {
+		return
+	}
+	changeLog := fmt.Sprintf("release %s", release.Name)
+	if release.Changelog != "" {
+		changeLog = fmt.Sprintf("%s\n\n%s", changeLog, release.Changelog)
+	}
+	err := c.syncVersionStore.Create(ctx, &database.SyncVersion{
+		SourceID:       types.SyncVersionSourceOpenCSG,
+		RepoPath:       repo.Path,
+		RepoType:       repo.RepositoryType,
+		LastModifiedAt: time.Now(),
+		ChangeLog:      changeLog,
+	})
+	if err != nil {
+		slog.Error("failed to create sync version of release", slog.String("repo_path", repo.Path),
+			slog.String("release", release.Name), slog.Any("error", err))
+	}
+}
+
+// releaseAudience returns the uuids of the followers and the likers of the repository who can read it
+func (c *repoComponentImpl) releaseAudience(ctx context.Context, repo *database.Repository, author *database.User) ([]string, error) {
+	var users []database.User
+	for page := 1; ; page++ {
+		follows, total, err := c.followStore.ListFollowers(ctx, string(types.FollowableRepository), repo.ID, 100, page)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list followers, error: %w", err)
+		}
+		for _, follow := range follows {
+			if follow.User != nil {
+				users = append(users, *follow.User)
+			}
+		}
+		if len(follows) == 0 || page*100 >= total {
+			break
+		}
+	}
+	likers, err := c.userLikesStore.Likers(ctx, repo.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list likers, error: %w", err)
+	}
+	users = append(users, likers...)
+
+	seen := map[int64]bool{author.ID: true}
+	var uuids []string
+	for _, user := range users {
+		if seen[user.ID] || user.UUID == "" {
+			continue
+		}
+		seen[user.ID] = true
+		if repo.Private {
+			allow, err := c.AllowReadAccessRepo(ctx, repo, user.Username)
+			if err != nil || !allow {
+				continue
+			}
+		}
+		uuids = append(uuids, user.UUID)
+	}
+	return uuids, nil
+}
+
+// notifyRelease notifies the followers and the likers of the repository about the new release
+func (c *repoComponentImpl) notifyRelease(repo *database.Repository, release *database.Release, author *database.User) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	userUUIDs, err := c.releaseAudience(ctx, repo, author)
+	if err != nil {
+		slog.Error("failed to find release audience", slog.String("repo_path", repo.Path), slog.String("release", release.Name), slog.Any("error", err))
+		return
+	}
+	if len(userUUIDs) == 0 {
+		return
+	}
+	msg := types.NotificationMessage{
+		MsgUUID:          uuid.New().String(),
+		UserUUIDs:        userUUIDs,
+		SenderUUID:       author.UUID,
+		NotificationType: types.NotificationSystem,
+		CreateAt:         time.Now(),
+		ClickActionURL:   fmt.Sprintf("%s/releases/%s", GetRepoUrl(repo.RepositoryType, repo.Path), release.Name),
+		Template:         string(types.MessageScenarioRepoRelease),
+		Payload: map[string]any{
+			"repo_type":    repo.RepositoryType,
+			"repo_path":    repo.Path,
+			"release_name": release.Name,
+			"title":        release.Title,
+		},
+	}
+	msgBytes, err := json.Marshal(msg)
+	if err != nil {
+		slog.Error("failed to marshal release message", slog.String("repo_path", repo.Path), slog.Any("error", err))
+		return
+	}
+	notificationMsg := types.MessageRequest{
+		Scenario:   types.MessageScenarioRepoRelease,
+		Parameters: string(msgBytes),
+		Priority:   types.MessagePriorityNormal,
+	}
+
+	retryCount := c.config.Notification.NotificationRetryCount
+	for i := range retryCount {
+		if err = c.notificationSvcClient.Send(ctx, &notificationMsg); err == nil {
+			break
+		}
+		if i < retryCount-1 {
+			slog.Warn("failed to send notification, retrying", "notification_msg", notificationMsg, "attempt", i+1, "error", err.Error())
+		}
+	}
+	if err != nil {
+		slog.Error("failed to send release message", slog.String("repo_path", repo.Path), slog.String("release", release.Name),
+			slog.Any("userUUIDs", userUUIDs), slog.Any("error", err))
+	}
+}
@@ -0,0 +1,263 @@
+package component
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	mockrpc "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/builder/rpc"
+	"opencsg.com/csghub-server/builder/git/gitserver"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+)
+
+func TestRepoComponent_CreateRelease(t *testing.T) {
+	ctx := context.TODO()
+	repo := initializeTestRepoComponent(ctx, t)
+	notification := mockrpc.NewMockNotificationSvcClient(t)
+	repo.notificationSvcClient = notification
+	repo.config.Notification.NotificationRetryCount = 1
+	mockRepoRefOwner(ctx, repo.mocks.stores)
+
+	repo.mocks.gitServer.EXPECT().GetRepoTags(ctx, gitserver.GetTagsReq{
+		Namespace: "ns", Name: "n", RepoType: types.ModelRepo,
+	}).Return([]types.Tag{{Name: "v1.0"}}, nil)
+	repo.mocks.gitServer.EXPECT().GetRepoBranchByName(ctx, gitserver.GetBranchReq{
+		Namespace: "ns", Name: "n", Ref: "v1", RepoType: types.ModelRepo,
+	}).Return(nil, nil)
+	repo.mocks.stores.ReleaseMock().EXPECT().CountByRepoID(ctx, int64(0)).Return(0, nil)
+	repo.mocks.stores.ReleaseMock().EXPECT().Create(ctx, mock.MatchedBy(func(release *database.Release) bool {
+		return release.Name == "v1" && release.TagName == "v1.0" && release.Latest
+	})).Return(nil)
+	repo.mocks.stores.FeedEventMock().EXPECT().Create(ctx, mock.MatchedBy(func(event *database.FeedEvent) bool {
+		return event.EventType == string(types.FeedEventReleaseCreated) && event.Payload["release_name"] == "v1"
+	})).Return(nil)
+	repo.mocks.stores.SyncVersionMock().EXPECT().Create(ctx, mock.MatchedBy(func(version *database.SyncVersion) bool {
+		return version.RepoPath == "ns/n" && version.ChangeLog == "release v1\n\n## Changes"
+	})).Return(nil)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	repo.mocks.stores.FollowMock().EXPECT().ListFollowers(mock.Anything, string(types.FollowableRepository), int64(0), 100, 1).
+		Return([]database.Follow{{User: &database.User{ID: 2, UUID: "follower-uuid"}}}, 1, nil)
+	repo.mocks.stores.UserLikesMock().EXPECT().Likers(mock.Anything, int64(0)).
+		Return([]database.User{{ID: 2, UUID: "follower-uuid"}, {ID: 3, UUID: "liker-uuid"}}, nil)
+	notification.EXPECT().Send(mock.Anything, mock.MatchedBy(func(msg *types.MessageRequest) bool {
+		defer wg.Done()
+		return msg.Scenario == types.MessageScenarioRepoRelease
+	})).Return(nil).Once()
+
+	release, err := repo.CreateRelease(ctx, &types.CreateReleaseReq{
+		Namespace: "ns", Name: "n", RepoType: types.ModelRepo, CurrentUser: "ns",
+		ReleaseName: "v1", TagName: "v1.0", Changelog: "## Changes",
+	})
+	require.Nil(t, err)
+	require.Equal(t, "v1", release.Name)
+	require.Equal(t, "ns", release.Author)
+	require.True(t, release.Latest)
+	require.Equal(t, []string{}, release.RecommendedFiles)
+	wg.Wait()
+}
+
+func TestRepoComponent_CreateReleaseInvalid(t *testing.T) {
+	ctx := context.TODO()
+	repo := initializeTestRepoComponent(ctx, t)
+
+	for _, name := range []string{"", "latest", "-v1", "v 1"} {
+		_, err := repo.CreateRelease(ctx, &types.CreateReleaseReq{
+			Namespace: "ns", Name: "n", RepoType: types.ModelRepo, CurrentUser: "ns",
+			ReleaseName: name, TagName: "v1.0",
+		})
+		require.ErrorIs(t, err, errorx.ErrBadRequest, name)
+	}
+
+	mockRepoRefOwner(ctx, repo.mocks.stores)
+	repo.mocks.gitServer.EXPECT().GetRepoTags(ctx, gitserver.GetTagsReq{
+		Namespace: "ns", Name: "n", RepoType: types.ModelRepo,
+	}).Return([]types.Tag{{Name: "v1.0"}}, nil)
+	_, err := repo.CreateRelease(ctx, &types.CreateReleaseReq{
+		Namespace: "ns", Name: "n", RepoType: types.ModelRepo, CurrentUser: "ns",
+		ReleaseName: "v2", TagName: "v2.0",
+	})
+	require.ErrorIs(t, err, errorx.ErrNotFound)
+}
+
+func TestRepoComponent_CreateReleaseNameUsedByRef(t *testing.T) {
+	ctx := context.TODO()
+	repo := initializeTestRepoComponent(ctx, t)
+	mockRepoRefOwner(ctx, repo.mocks.stores)
+	repo.mocks.gitServer.EXPECT().GetRepoTags(ctx, gitserver.GetTagsReq{
+		Namespace: "ns", Name: "n", RepoType: types.ModelRepo,
+	}).Return([]types.Tag{{Name: "v1.0"}, {Name: "v2.0"}}, nil)
+
+	// the name of another tag
+	_, err := repo.CreateRelease(ctx, &types.CreateReleaseReq{
+		Namespace: "ns", Name: "n", RepoType: types.ModelRepo, CurrentUser: "ns",
+		ReleaseName: "v2.0", TagName: "v1.0",
+	})
+	require.ErrorIs(t, err, errorx.ErrBadRequest)
+
+	// the name of a branch
+	repo.mocks.gitServer.EXPECT().GetRepoBranchByName(ctx, gitserver.GetBranchReq{
+		Namespace: "ns", Name: "n", Ref: "main", RepoType: types.ModelRepo,
+	}).Return(&types.Branch{Name: "main"}, nil)
+	_, err = repo.CreateRelease(ctx, &types.CreateReleaseReq{
+		Namespace: "ns", Name: "n", RepoType: types.ModelRepo, CurrentUser: "ns",
+		ReleaseName: "main", TagName: "v1.0",
+	})
+	require.ErrorIs(t, err, errorx.ErrBadRequest)
+}
+
+func TestRepoComponent_UpdateRelease(t *testing.T) {
+	ctx := context.TODO()
+	repo := initializeTestRepoComponent(ctx, t)
+	mockRepoRefOwner(ctx, repo.mocks.stores)
+
+	repo.mocks.stores.ReleaseMock().EXPECT().FindByName(ctx, int64(0), "v1").Return(&database.Release{
+		Name: "v1", TagName: "v1.0", Latest: true,
+	}, nil)
+	unset := false
+	_, err := repo.UpdateRelease(ctx, &types.UpdateReleaseReq{
+		Namespace: "ns", Name: "n", RepoType: types.ModelRepo, CurrentUser: "ns",
+		ReleaseName: "v1", Latest: &unset,
+	})
+	require.ErrorIs(t, err, errorx.ErrBadRequest)
+
+	deprecated, note := true, "use v2"
+	repo.mocks.stores.ReleaseMock().EXPECT().Update(ctx, mock.MatchedBy(func(release *database.Release) bool {
+		return release.Deprecated && release.DeprecationNote == "use v2" && release.Latest
+	})).Return(nil)
+	release, err := repo.UpdateRelease(ctx, &types.UpdateReleaseReq{
+		Namespace: "ns", Name: "n", RepoType: types.ModelRepo, CurrentUser: "ns",
+		ReleaseName: "v1", Deprecated: &deprecated, DeprecationNote: &note,
+	})
+	require.Nil(t, err)
+	require.True(t, release.Deprecated)
+	require.Equal(t, "use v2", release.DeprecationNote)
+}
+
+func TestRepoComponent_DeleteRelease(t *testing.T) {
+	ctx := context.TODO()
+	repo := initializeTestRepoComponent(ctx, t)
+	mockRepoRefOwner(ctx, repo.mocks.stores)
+
+	repo.mocks.stores.ReleaseMock().EXPECT().FindByName(ctx, int64(0), "v2").Return(nil, errorx.ErrDatabaseNoRows).Once()
+	err := repo.DeleteRelease(ctx, &types.DeleteReleaseReq{
+		Namespace: "ns", Name: "n", RepoType: types.ModelRepo, CurrentUser: "ns", ReleaseName: "v2",
+	})
+	require.ErrorIs(t, err, errorx.ErrNotFound)
+
+	repo.mocks.stores.ReleaseMock().EXPECT().FindByRef(ctx, types.ModelRepo, "ns/n", types.LatestReleaseRef).Return(&database.Release{
+		Name: "v1", TagName: "v1.0", Latest: true,
+	}, nil)
+	repo.mocks.stores.ReleaseMock().EXPECT().Delete(ctx, int64(0), "v1").Return(nil)
+	err = repo.DeleteRelease(ctx, &types.DeleteReleaseReq{
+		Namespace: "ns", Name: "n", RepoType: types.ModelRepo, CurrentUser: "ns", ReleaseName: types.LatestReleaseRef,
+	})
+	require.Nil(t, err)
+}
+
+func TestRepoComponent_ListReleases(t *testing.T) {
+	ctx := context.TODO()
+	repo := initializeTestRepoComponent(ctx, t)
+	repo.mocks.stores.RepoMock().EXPECT().FindByPath(ctx, types.ModelRepo, "ns", "n").Return(&database.Repository{
+		ID: 1, Path: "ns/n", RepositoryType: types.ModelRepo,
+	}, nil)
+
+	repo.mocks.stores.ReleaseMock().EXPECT().ListByRepoID(ctx, int64(1), 10, 1).Return([]database.Release{
+		{Name: "v2", TagName: "v2.0", Latest: true, RecommendedFiles: []string{"model-q4.gguf"}, Author: &database.User{Username: "u"}},
+		{Name: "v1", TagName: "v1.0", Deprecated: true},
+	}, 2, nil)
+	releases, total, err := repo.ListReleases(ctx, &types.ListReleasesReq{
+		Namespace: "ns", Name: "n", RepoType: types.ModelRepo, Per: 10, Page: 1,
+	})
+	require.Nil(t, err)
+	require.Equal(t, 2, total)
+	require.Equal(t, []string{"model-q4.gguf"}, releases[0].RecommendedFiles)
+	require.Equal(t, "u", releases[0].Author)
+	require.True(t, releases[1].Deprecated)
+
+	repo.mocks.stores.ReleaseMock().EXPECT().FindByName(ctx, int64(1), "v1").Return(&database.Release{Name: "v1", TagName: "v1.0"}, nil)
+	release, err := repo.GetRelease(ctx, &types.GetReleaseReq{
+		Namespace: "ns", Name: "n", RepoType: types.ModelRepo, ReleaseName: "v1",
+	})
+	require.Nil(t, err)
+	require.Equal(t, "v1.0", release.TagName)
+}
+
+func TestRepoComponent_IsLfsRelease(t *testing.T) {
+	ctx := context.TODO()
+	repo := initializeTestRepoComponent(ctx, t)
+	req := &types.GetFileReq{
+		Namespace: "ns",
+		Name:      "n",
+		Ref:       types.LatestReleaseRef,
+		Path:      "config.json",
+		RepoType:  types.ModelRepo,
+	}
+	// the release is only resolved when the ref is not a git ref
+	repo.mocks.gitServer.EXPECT().GetRepoFileRaw(ctx, gitserver.GetRepoInfoByPathReq{
+		Namespace: "ns",
+		Name:      "n",
+		Ref:       types.LatestReleaseRef,
+		Path:      "config.json",
+		RepoType:  types.ModelRepo,
+	}).Return("", errorx.ErrGitFileNotFound)
+	repo.mocks.stores.ReleaseMock().EXPECT().FindByRef(ctx, types.ModelRepo, "ns/n", types.LatestReleaseRef).Return(&database.Release{
+		Name: "v1", TagName: "v1.0", Latest: true,
+	}, nil)
+	repo.mocks.gitServer.EXPECT().GetRepoFileRaw(ctx, gitserver.GetRepoInfoByPathReq{
+		Namespace: "ns",
+		Name:      "n",
+		Ref:       "v1.0",
+		Path:      "config.json",
+		RepoType:  types.ModelRepo,
+	}).Return("{}", nil)
+
+	lfs, size, err := repo.IsLfs(ctx, req)
+	require.Nil(t, err)
+	require.False(t, lfs)
+	require.Equal(t, int64(2), size)
+	require.Equal(t, "v1.0", req.Ref)
+}
+
+func TestRepoComponent_HeadDownloadFileRelease(t *testing.T) {
+	ctx := context.TODO()
+	repo := initializeTestRepoComponent(ctx, t)
+	repo.mocks.stores.RepoMock().EXPECT().FindByPath(ctx, types.ModelRepo, "ns", "n").Return(&database.Repository{
+		DefaultBranch: "main",
+	}, nil)
+	repo.mocks.stores.ReleaseMock().EXPECT().FindByRef(ctx, types.ModelRepo, "ns/n", "v1").Return(&database.Release{
+		Name: "v1", TagName: "v1.0",
+	}, nil)
+
+	file := &types.File{Name: "config.json"}
+	repo.mocks.gitServer.EXPECT().GetRepoFileContents(ctx, gitserver.GetRepoInfoByPathReq{
+		Namespace: "ns",
+		Name:      "n",
+		Ref:       "v1.0",
+		Path:      "config.json",
+		RepoType:  types.ModelRepo,
+	}).Return(file, nil)
+	commit := &types.Commit{ID: "c1"}
+	repo.mocks.gitServer.EXPECT().GetRepoLastCommit(ctx, gitserver.GetRepoLastCommitReq{
+		Namespace: "ns",
+		Name:      "n",
+		Ref:       "v1.0",
+		RepoType:  types.ModelRepo,
+	}).Return(commit, nil)
+
+	f, c, err := repo.HeadDownloadFile(ctx, &types.GetFileReq{
+		Namespace: "ns",
+		Name:      "n",
+		Ref:       "v1",
+		Path:      "config.json",
+		RepoType:  types.ModelRepo,
+	}, "user")
+	require.Nil(t, err)
+	require.Equal(t, file, f)
+	require.Equal(t, commit, c)
+}
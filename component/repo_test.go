@@ -66,6 +66,11 @@ func TestRepoComponent_CreateRepo(t *testing.T) {
 		RepositoryType: types.ModelRepo,
 	}
 	repo.mocks.stores.RepoMock().EXPECT().CreateRepo(ctx, mock.AnythingOfType("database.Repository")).Return(dbrepo, nil)
+	repo.mocks.stores.FeedEventMock().EXPECT().Create(ctx, &database.FeedEvent{
+		EventType: string(types.FeedEventRepoCreated),
+		ActorID:   123,
+		Namespace: "ns",
+	}).Return(nil)
 	r1, r2, _, err := repo.CreateRepo(ctx, types.CreateRepoReq{
 		Username:      "user",
 		Namespace:     "ns",
@@ -360,7 +365,14 @@ func TestRepoComponent_CreateFile(t *testing.T) {
 
 				resp, err := repo.CreateFile(ctx, req)
 				require.Nil(t, err)
-				require.Equal(t, &types.CreateFileResp{}, resp)
+				expected := &types.CreateFileResp{}
+				if c.path == types.ReadmeFileName && !c.useLFS {
+					// the empty readme has no metadata
+					expected.CardWarnings = []types.CardWarning{{
+						Message: "the README has no metadata, add the yaml metadata between the --- lines at the beginning of the README",
+					}}
+				}
+				require.Equal(t, expected, resp)
 			}
 		})
 	}
@@ -462,7 +474,13 @@ func TestRepoComponent_UpdateFile(t *testing.T) {
 			} else {
 				resp, err := repo.UpdateFile(ctx, req)
 				require.Nil(t, err)
-				require.Equal(t, &types.UpdateFileResp{}, resp)
+				expected := &types.UpdateFileResp{}
+				if c.path == types.ReadmeFileName && !c.useLFS {
+					expected.CardWarnings = []types.CardWarning{{
+						Message: "the README has no metadata, add the yaml metadata between the --- lines at the beginning of the README",
+					}}
+				}
+				require.Equal(t, expected, resp)
 			}
 		})
 	}
@@ -1012,6 +1030,7 @@ func TestRepoComponent_IsLFSNotFound(t *testing.T) {
 				Path:      "p",
 				RepoType:  types.ModelRepo,
 			}
+			repo.mocks.stores.ReleaseMock().EXPECT().FindByRef(ctx, types.ModelRepo, "ns/n", "main").Return(nil, errorx.ErrDatabaseNoRows)
 			repo.mocks.gitServer.EXPECT().GetRepoFileRaw(ctx, gitserver.GetRepoInfoByPathReq{
 				Namespace: req.Namespace,
 				Name:      req.Name,
@@ -1037,6 +1056,7 @@ func TestRepoComponent_HeadDownloadFile(t *testing.T) {
 	repo.mocks.stores.RepoMock().EXPECT().FindByPath(ctx, types.ModelRepo, "ns", "n").Return(
 		mockedRepo, nil,
 	)
+	repo.mocks.stores.ReleaseMock().EXPECT().FindByRef(ctx, types.ModelRepo, "ns/n", "main").Return(nil, errorx.ErrDatabaseNoRows)
 
 	file := &types.File{Name: "zzz"}
 	repo.mocks.gitServer.EXPECT().GetRepoFileContents(ctx, gitserver.GetRepoInfoByPathReq{
@@ -1487,6 +1507,31 @@ func TestRepoComponent_DeleteDeploy(t *testing.T) {
 
 }
 
+func TestRepoComponent_DeleteDeployRolloutInProgress(t *testing.T) {
+	ctx := context.TODO()
+	repo := initializeTestRepoComponent(ctx, t)
+	mockUserRepoAdminPermission(ctx, repo.mocks.stores, "user")
+	repo.mocks.stores.DeployTaskMock().EXPECT().GetDeployByID(ctx, int64(3)).Return(&database.Deploy{
+		ID:        3,
+		RepoID:    1,
+		UserUUID:  "uuid",
+		Type:      types.InferenceType,
+		ClusterID: "cluster",
+	}, nil)
+	repo.mocks.stores.InferenceRolloutMock().EXPECT().FindLatestByDeployID(ctx, int64(3)).Return(&database.InferenceRollout{
+		Status: types.InferenceRolloutRunning,
+	}, nil)
+
+	err := repo.DeleteDeploy(ctx, types.DeployActReq{
+		RepoType:    types.ModelRepo,
+		Namespace:   "ns",
+		Name:        "n",
+		CurrentUser: "user",
+		DeployID:    3,
+	})
+	require.ErrorIs(t, err, errorx.ErrInferenceRolloutInProgress)
+}
+
 func TestRepoComponent_DeployDetail(t *testing.T) {
 	ctx := context.TODO()
 	repo := initializeTestRepoComponent(ctx, t)
@@ -1496,6 +1541,7 @@ func TestRepoComponent_DeployDetail(t *testing.T) {
 		Zone: "z",
 	}, nil)
 	repo.mocks.stores.DeployTaskMock().EXPECT().GetDeployByID(ctx, int64(1)).Return(&database.Deploy{
+		ID:            1,
 		RepoID:        1,
 		UserUUID:      "uuid",
 		OrderDetailID: 11,
@@ -1505,9 +1551,14 @@ func TestRepoComponent_DeployDetail(t *testing.T) {
 		Type:          types.InferenceType,
 		SecureLevel:   types.EndpointPublic,
 	}, nil)
+	repo.mocks.stores.InferenceRolloutMock().EXPECT().FindLatestByDeployID(ctx, int64(1)).Return(&database.InferenceRollout{
+		ID: 3, DeployID: 1, StableCommit: "aaaaaaa", CanaryCommit: "bbbbbbb", Steps: []int{5, 100},
+		StepInterval: 60, CurrentTraffic: 5, Status: types.InferenceRolloutRunning,
+	}, nil)
 
 	repo.mocks.deployer.EXPECT().CheckClusterHealthy(ctx, "cluster").Return(true, nil)
 	repo.mocks.deployer.EXPECT().GetReplica(ctx, types.DeployRequest{
+		DeployID:  1,
 		Namespace: "ns",
 		Name:      "n",
 		ClusterID: "cluster",
@@ -1525,6 +1576,7 @@ func TestRepoComponent_DeployDetail(t *testing.T) {
 	})
 	require.Nil(t, err)
 	require.Equal(t, types.DeployRequest{
+		DeployID:       1,
 		RepoID:         1,
 		ActualReplica:  1,
 		DesiredReplica: 2,
@@ -1538,6 +1590,10 @@ func TestRepoComponent_DeployDetail(t *testing.T) {
 		Type:           types.InferenceType,
 		UserUUID:       "uuid",
 		OwnerNamespace: "",
+		InferenceRollout: &types.InferenceRollout{
+			ID: 3, DeployID: 1, StableCommit: "aaaaaaa", CanaryCommit: "bbbbbbb", Steps: []int{5, 100},
+			StepInterval: 60, CurrentTraffic: 5, Status: types.InferenceRolloutRunning,
+		},
 	}, *dp)
 
 }
@@ -2501,6 +2557,34 @@ func TestRepoComponent_TreeV2(t *testing.T) {
 
 }
 
+func TestRepoComponent_TreeV2ModelFileScan(t *testing.T) {
+	ctx := context.TODO()
+	repoComp := initializeTestRepoComponent(ctx, t)
+
+	repo := &database.Repository{ID: 1, Path: "ns/repo_name", Source: types.LocalSource}
+	repoComp.mocks.stores.RepoMock().EXPECT().FindByPath(mock.Anything, types.ModelRepo, "ns", "repo_name").Return(repo, nil)
+
+	tree := &types.GetRepoFileTreeResp{Files: []*types.File{
+		{Path: "README.md", Type: "file", SHA: "s1"},
+		{Path: "model.bin", Type: "file", SHA: "s2"},
+		{Path: "model.safetensors", Type: "file", SHA: "s3"},
+	}}
+	req := &types.GetTreeRequest{Namespace: "ns", Name: "repo_name", RepoType: types.ModelRepo, Limit: 100}
+	repoComp.mocks.gitServer.EXPECT().GetTree(mock.Anything, *req).Return(tree, nil)
+	repoComp.mocks.stores.ModelFileScanMock().EXPECT().FindByPaths(mock.Anything, int64(1), []string{"model.bin", "model.safetensors"}).Return([]database.ModelFileScan{
+		{Path: "model.bin", FileSHA: "s2", Status: types.ModelFileScanUnsafe, Format: types.ModelFileFormatPickle},
+		{Path: "model.bin", FileSHA: "s2", Status: types.ModelFileScanSafe, Format: types.ModelFileFormatPickle},
+		// verdict of an older version of the file
+		{Path: "model.safetensors", FileSHA: "s0", Status: types.ModelFileScanInvalid, Format: types.ModelFileFormatSafetensors},
+	}, nil)
+
+	actualTree, err := repoComp.TreeV2(ctx, req)
+	require.Nil(t, err)
+	require.Nil(t, actualTree.Files[0].SecurityScan)
+	require.Equal(t, types.ModelFileScanUnsafe, actualTree.Files[1].SecurityScan.Status)
+	require.Nil(t, actualTree.Files[2].SecurityScan)
+}
+
 func TestRepoComponent_TreeV2Remote(t *testing.T) {
 	ctx := context.TODO()
 	repoComp := initializeTestRepoComponent(ctx, t)
@@ -3798,8 +3882,9 @@ func TestRepoComponent_CommitFiles(t *testing.T) {
 		},
 	}).Return(nil)
 
-	err := repoComp.CommitFiles(ctx, req)
+	resp, err := repoComp.CommitFiles(ctx, req)
 	require.Equal(t, nil, err)
+	require.Equal(t, &types.CommitFilesResp{Files: []string{"a.go"}}, resp)
 }
 
 func TestRepoComponent_CommitFilesIgnoresPackageSyncFailure(t *testing.T) {
@@ -3862,7 +3947,7 @@ func TestRepoComponent_CommitFilesIgnoresPackageSyncFailure(t *testing.T) {
 	}).Return(nil)
 	syncDone := expectAsyncPackageBranchResolveFailure(t, repoComp, types.SkillRepo, ns.Path, req.Name, req.Revision)
 
-	err := repoComp.CommitFiles(ctx, req)
+	_, err := repoComp.CommitFiles(ctx, req)
 	require.NoError(t, err)
 	requireAsyncPackageSyncAttempt(t, syncDone)
 }
@@ -0,0 +1,516 @@
+package component
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/netip"
+	"sync"
+	"time"
+
+	"opencsg.com/csghub-server/builder/geo"
+	bldmq "opencsg.com/csghub-server/builder/mq"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/config"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+)
+
+const (
+	repoTrafficBatchSize     = 200
+	repoTrafficFlushInterval = 10 * time.Second
+	// repoTrafficRegionCacheSize is the max number of the cached ip regions, the cache is reset when it's full
+	repoTrafficRegionCacheSize = 10000
+	repoTrafficUnknownRegion   = "unknown"
+	// repoTrafficAckWait covers the flush interval and the write timeout, the messages are acked after they
+	// are written
+	repoTrafficAckWait = 2 * time.Minute
+)
+
+type RepoTrafficComponent interface {
+	PublishEvent(ctx context.Context, event *types.RepoTrafficEvent) error
+	StartConsuming() error
+	// Stats returns the summary and the daily time series of the repository traffic
+	Stats(ctx context.Context, req *types.RepoTrafficReq) (*types.RepoTrafficStats, error)
+	TopFiles(ctx context.Context, req *types.RepoTrafficReq) ([]types.RepoTrafficFile, error)
+	Regions(ctx context.Context, req *types.RepoTrafficReq) ([]types.RepoTrafficRegion, error)
+	// Dailies returns the daily aggregates of the repository traffic for the export
+	Dailies(ctx context.Context, req *types.RepoTrafficReq) ([]types.RepoTrafficDaily, error)
+}
+
+type repoTrafficComponentImpl struct {
+	repoStore     database.RepoStore
+	repoFileStore database.RepoFileStore
+	trafficStore  database.RepositoryTrafficStore
+	repoComponent RepoComponent
+	mq            bldmq.MessageQueue
+	ipLocator     geo.IPLocator
+	regionMu      sync.Mutex
+	regions       map[string]string
+	eventCh       chan *repoTrafficMsg
+	eventBuffer   []*repoTrafficMsg
+	flushTicker   *time.Ticker
+}
+
+// repoTrafficMsg is a consumed traffic event waiting to be written, the region is resolved by the consumer
+// so that the ip lookups don't block the writer
+type repoTrafficMsg struct {
+	event  *types.RepoTrafficEvent
+	region string
+	acker  bldmq.MessageAcker
+}
+
+var defaultRepoTrafficComponent RepoTrafficComponent
+
+func NewRepoTrafficComponent(config *config.Config, mqFactory bldmq.MessageQueueFactory) (RepoTrafficComponent, error) {
+	if defaultRepoTrafficComponent != nil {
+		return defaultRepoTrafficComponent, nil
+	}
+
+	mq, err := mqFactory.GetInstance()
+	if err != nil {
+		return nil, err
+	}
+	repoComponent, err := NewRepoComponentImpl(config)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &repoTrafficComponentImpl{
+		repoStore:     database.NewRepoStore(),
+		repoFileStore: database.NewRepoFileStore(),
+		trafficStore:  database.NewRepositoryTrafficStore(),
+		repoComponent: repoComponent,
+		mq:            mq,
+		regions:       make(map[string]string),
+		eventCh:       make(chan *repoTrafficMsg, 1000),
+		eventBuffer:   make([]*repoTrafficMsg, 0, repoTrafficBatchSize),
+		flushTicker:   time.NewTicker(repoTrafficFlushInterval),
+	}
+	// the default key is a placeholder, the regions are unknown without a real lbs service key
+	if config.LBSServiceKey != "" && config.LBSServiceKey != "123456" {
+		c.ipLocator = geo.NewGaodeIPLocator(config.LBSServiceKey)
+	}
+
+	go c.runBatchWriter()
+	defaultRepoTrafficComponent = c
+	return c, nil
+}
+
+func (c *repoTrafficComponentImpl) PublishEvent(ctx context.Context, event *types.RepoTrafficEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return c.mq.Publish(bldmq.RepoTrafficEventSubject, data)
+}
+
+func (c *repoTrafficComponentImpl) StartConsuming() error {
+	return c.mq.Subscribe(bldmq.SubscribeParams{
+		Group:    bldmq.RepoTrafficEventGroup,
+		Topics:   []string{bldmq.RepoTrafficEventSubject},
+		AutoACK:  false,
+		AckWait:  repoTrafficAckWait,
+		Callback: c.handleEventMsg,
+	})
+}
+
+func (c *repoTrafficComponentImpl) handleEventMsg(raw []byte, meta bldmq.MessageMeta) error {
+	var event types.RepoTrafficEvent
+	if err := json.Unmarshal(raw, &event); err != nil {
+		slog.Error("failed to unmarshal repo traffic event", slog.Any("error", err))
+		// the malformed message is never redelivered
+		ackRepoTrafficMsg(&repoTrafficMsg{acker: meta.Acker}, true)
+		return err
+	}
+	c.eventCh <- &repoTrafficMsg{event: &event, region: c.region(event.IPAddress), acker: meta.Acker}
+	return nil
+}
+
+// ackRepoTrafficMsg acks the written message, or naks it to have it redelivered
+func ackRepoTrafficMsg(msg *repoTrafficMsg, written bool) {
+	if msg.acker == nil {
+		return
+	}
+	action := "ack"
+	var err error
+	if written {
+		err = msg.acker.Ack()
+	} else {
+		action = "nak"
+		err = msg.acker.Nak()
+	}
+	if err != nil {
+		slog.Error(fmt.Sprintf("failed to %s repo traffic event", action), slog.Any("error", err))
+	}
+}
+
+func (c *repoTrafficComponentImpl) runBatchWriter() {
+	defer func() {
+		if r := recover(); r != nil {
+			slog.Error("repo traffic batch writer panicked, restarting", slog.Any("panic", r))
+			go c.runBatchWriter()
+		}
+	}()
+
+	for {
+		select {
+		case msg := <-c.eventCh:
+			c.eventBuffer = append(c.eventBuffer, msg)
+			if len(c.eventBuffer) >= repoTrafficBatchSize {
+				c.flushBuffer()
+			}
+		case <-c.flushTicker.C:
+			c.flushBuffer()
+		}
+	}
+}
+
+func (c *repoTrafficComponentImpl) flushBuffer() {
+	if len(c.eventBuffer) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	batch := make([]*repoTrafficMsg, len(c.eventBuffer))
+	copy(batch, c.eventBuffer)
+	c.eventBuffer = c.eventBuffer[:0]
+
+	err := c.rollup(ctx, batch)
+	if err != nil {
+		slog.Error("failed to roll up repo traffic events", slog.Any("error", err), slog.Int("count", len(batch)))
+	}
+	for _, msg := range batch {
+		ackRepoTrafficMsg(msg, err == nil)
+	}
+}
+
+type repoTrafficDailyKey struct {
+	repoID int64
+	date   string
+	kind   types.RepoTrafficKind
+	path   string
+	region string
+}
+
+type repoTrafficVisitorKey struct {
+	repoID    int64
+	date      string
+	visitorID string
+}
+
+// rollup adds the events to the daily aggregates, the hits of the same day, file and region are merged into
+// one row, and the visitors of the events are saved for the unique user counts. The days are in UTC.
+func (c *repoTrafficComponentImpl) rollup(ctx context.Context, msgs []*repoTrafficMsg) error {
+	repos := make(map[string]*database.Repository)
+	counts := make(map[repoTrafficDailyKey]int64)
+	visitors := make(map[repoTrafficVisitorKey]string)
+	var keys []repoTrafficDailyKey
+	for _, msg := range msgs {
+		event, region := msg.event, msg.region
+		repoPath := fmt.Sprintf("%s/%s/%s", event.RepoType, event.Namespace, event.Name)
+		repo, ok := repos[repoPath]
+		if !ok {
+			var err error
+			repo, err = c.repoStore.FindByPath(ctx, event.RepoType, event.Namespace, event.Name)
+			if err != nil {
+				slog.Warn("failed to find repo of traffic event", slog.String("repo", repoPath), slog.Any("error", err))
+			}
+			repos[repoPath] = repo
+		}
+		if repo == nil {
+			continue
+		}
+
+		date := event.CreatedAt.UTC().Format(time.DateOnly)
+		paths, err := c.eventPaths(ctx, repo.ID, event)
+		if err != nil {
+			return err
+		}
+		for _, path := range paths {
+			key := repoTrafficDailyKey{repoID: repo.ID, date: date, kind: event.Kind, path: path, region: region}
+			if _, ok := counts[key]; !ok {
+				keys = append(keys, key)
+			}
+			counts[key]++
+		}
+		visitorKey := repoTrafficVisitorKey{repoID: repo.ID, date: date, visitorID: repoTrafficVisitorID(event)}
+		if _, ok := visitors[visitorKey]; !ok {
+			visitors[visitorKey] = region
+		}
+	}
+
+	now := time.Now()
+	dailies := make([]database.RepositoryTrafficDaily, 0, len(keys))
+	for _, key := range keys {
+		date, _ := time.Parse(time.DateOnly, key.date)
+		daily := database.RepositoryTrafficDaily{
+			RepositoryID: key.repoID,
+			Date:         date,
+			Kind:         string(key.kind),
+			Path:         key.path,
+			Region:       key.region,
+			Count:        counts[key],
+		}
+		daily.UpdatedAt = now
+		dailies = append(dailies, daily)
+	}
+	if err := c.trafficStore.AddDailies(ctx, dailies); err != nil {
+		return fmt.Errorf("failed to add repo traffic dailies, error: %w", err)
+	}
+
+	visitorRows := make([]database.RepositoryTrafficVisitor, 0, len(visitors))
+	for key, region := range visitors {
+		date, _ := time.Parse(time.DateOnly, key.date)
+		visitorRows = append(visitorRows, database.RepositoryTrafficVisitor{
+			RepositoryID: key.repoID,
+			Date:         date,
+			VisitorID:    key.visitorID,
+			Region:       region,
+		})
+	}
+	if err := c.trafficStore.AddVisitors(ctx, visitorRows); err != nil {
+		return fmt.Errorf("failed to add repo traffic visitors, error: %w", err)
+	}
+	return nil
+}
+
+// eventPaths returns the file paths of the event, the LFS objects are mapped to the file paths by their oids,
+// the oid is used as the path if the object is not found in the repository files
+func (c *repoTrafficComponentImpl) eventPaths(ctx context.Context, repoID int64, event *types.RepoTrafficEvent) ([]string, error) {
+	switch event.Kind {
+	case types.RepoTrafficClone:
+		return []string{""}, nil
+	case types.RepoTrafficLFS:
+		relativePaths := make([]string, 0, len(event.LfsOids))
+		for _, oid := range event.LfsOids {
+			relativePaths = append(relativePaths, types.Pointer{Oid: oid}.RelativePath())
+		}
+		files, err := c.repoFileStore.PathsByLfsRelativePaths(ctx, repoID, relativePaths)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find the files of lfs objects, error: %w", err)
+		}
+		paths := make([]string, 0, len(event.LfsOids))
+		for i, oid := range event.LfsOids {
+			if path, ok := files[relativePaths[i]]; ok {
+				paths = append(paths, path)
+			} else {
+				paths = append(paths, oid)
+			}
+		}
+		return paths, nil
+	default:
+		return []string{event.Path}, nil
+	}
+}
+
+// repoTrafficVisitorID returns the user name for the signed in users, or an anonymous id hashed from the ip
+// address and the user agent, the ip addresses are never saved
+func repoTrafficVisitorID(event *types.RepoTrafficEvent) string {
+	if event.Username != "" {
+		return "user:" + event.Username
+	}
+	sum := sha256.Sum256([]byte(event.IPAddress + "|" + event.UserAgent))
+	return "anon:" + hex.EncodeToString(sum[:16])
+}
+
+// region returns the province of the ip address, the private addresses and the failed lookups are unknown
+func (c *repoTrafficComponentImpl) region(ip string) string {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil || addr.IsPrivate() || addr.IsLoopback() || addr.IsUnspecified() || c.ipLocator == nil {
+		return repoTrafficUnknownRegion
+	}
+
+	c.regionMu.Lock()
+	region, ok := c.regions[ip]
+	c.regionMu.Unlock()
+	if ok {
+		return region
+	}
+
+	region = repoTrafficUnknownRegion
+	loc, err := c.ipLocator.GetIPLocation(ip)
+	if err != nil {
+		slog.Debug("failed to get ip location of repo traffic event", slog.String("ip", ip), slog.Any("error", err))
+	} else if loc.Province != "" {
+		region = loc.Province
+	} else if loc.Nation != "" {
+		region = loc.Nation
+	}
+
+	c.regionMu.Lock()
+	if len(c.regions) >= repoTrafficRegionCacheSize {
+		c.regions = make(map[string]string)
+	}
+	c.regions[ip] = region
+	c.regionMu.Unlock()
+	return region
+}
+
+// checkTrafficAccess returns the repository if the user is an admin of the repository, and the date range of
+// the request
+func (c *repoTrafficComponentImpl) checkTrafficAccess(ctx context.Context, req *types.RepoTrafficReq) (*database.Repository, time.Time, time.Time, error) {
+	start, end, err := repoTrafficDateRange(req)
+	if err != nil {
+		return nil, start, end, err
+	}
+	repo, err := c.repoStore.FindByPath(ctx, req.RepoType, req.Namespace, req.Name)
+	if err != nil {
+		return nil, start, end, fmt.Errorf("failed to find repo, error: %w", err)
+	}
+	allowed, err := c.repoComponent.AllowAdminAccess(ctx, req.RepoType, req.Namespace, req.Name, req.CurrentUser)
+	if err != nil {
+		return nil, start, end, fmt.Errorf("failed to check repo permission, error: %w", err)
+	}
+	if !allowed {
+		return nil, start, end, errorx.ErrForbiddenMsg("users do not have permission to view the traffic of this repo")
+	}
+	return repo, start, end, nil
+}
+
+// repoTrafficDateRange parses the date range of the request, the last 30 days by default
+func repoTrafficDateRange(req *types.RepoTrafficReq) (time.Time, time.Time, error) {
+	now := time.Now().UTC()
+	end := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if req.EndDate != "" {
+		t, err := time.Parse(time.DateOnly, req.EndDate)
+		if err != nil {
+			return end, end, errorx.ReqParamInvalid(err, errorx.Ctx().Set("param", "end_date"))
+		}
+		end = t
+	}
+	start := end.AddDate(0, 0, 1-types.DefaultRepoTrafficDays)
+	if req.StartDate != "" {
+		t, err := time.Parse(time.DateOnly, req.StartDate)
+		if err != nil {
+			return start, end, errorx.ReqParamInvalid(err, errorx.Ctx().Set("param", "start_date"))
+		}
+		start = t
+	}
+	if start.After(end) {
+		return start, end, errorx.ReqParamInvalid(fmt.Errorf("start_date %s is after end_date %s", start.Format(time.DateOnly), end.Format(time.DateOnly)),
+			errorx.Ctx().Set("param", "start_date"))
+	}
+	if end.Sub(start) >= types.MaxRepoTrafficDays*24*time.Hour {
+		return start, end, errorx.ReqParamInvalid(fmt.Errorf("the date range should be at most %d days", types.MaxRepoTrafficDays),
+			errorx.Ctx().Set("param", "start_date"))
+	}
+	return start, end, nil
+}
+
+func (c *repoTrafficComponentImpl) Stats(ctx context.Context, req *types.RepoTrafficReq) (*types.RepoTrafficStats, error) {
+	repo, start, end, err := c.checkTrafficAccess(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	counts, err := c.trafficStore.Counts(ctx, repo.ID, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repo traffic counts, error: %w", err)
+	}
+	visitorCounts, err := c.trafficStore.VisitorCounts(ctx, repo.ID, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repo traffic visitor counts, error: %w", err)
+	}
+	uniqueUsers, err := c.trafficStore.UniqueVisitors(ctx, repo.ID, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repo traffic unique visitors, error: %w", err)
+	}
+
+	stats := &types.RepoTrafficStats{
+		StartDate: start.Format(time.DateOnly),
+		EndDate:   end.Format(time.DateOnly),
+		Summary:   types.RepoTrafficSummary{UniqueUsers: uniqueUsers},
+	}
+	points := make(map[string]*types.RepoTrafficPoint)
+	for date := start; !date.After(end); date = date.AddDate(0, 0, 1) {
+		stats.TimeSeries = append(stats.TimeSeries, types.RepoTrafficPoint{Date: date.Format(time.DateOnly)})
+	}
+	for i := range stats.TimeSeries {
+		points[stats.TimeSeries[i].Date] = &stats.TimeSeries[i]
+	}
+	for _, count := range counts {
+		point, ok := points[count.Date.Format(time.DateOnly)]
+		if !ok {
+			continue
+		}
+		if count.Kind == string(types.RepoTrafficClone) {
+			point.Clones += count.Count
+			stats.Summary.Clones += count.Count
+		} else {
+			point.Downloads += count.Count
+			stats.Summary.Downloads += count.Count
+		}
+	}
+	for _, count := range visitorCounts {
+		if point, ok := points[count.Date.Format(time.DateOnly)]; ok {
+			point.UniqueUsers = count.Count
+		}
+	}
+	return stats, nil
+}
+
+func (c *repoTrafficComponentImpl) TopFiles(ctx context.Context, req *types.RepoTrafficReq) ([]types.RepoTrafficFile, error) {
+	repo, start, end, err := c.checkTrafficAccess(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	limit := req.Limit
+	if limit <= 0 {
+		limit = types.DefaultRepoTrafficTopFiles
+	}
+	if limit > types.MaxRepoTrafficTopFiles {
+		limit = types.MaxRepoTrafficTopFiles
+	}
+	files, err := c.trafficStore.TopFiles(ctx, repo.ID, start, end, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repo traffic top files, error: %w", err)
+	}
+	result := make([]types.RepoTrafficFile, 0, len(files))
+	for _, file := range files {
+		result = append(result, types.RepoTrafficFile{Path: file.Path, Downloads: file.Count})
+	}
+	return result, nil
+}
+
+func (c *repoTrafficComponentImpl) Regions(ctx context.Context, req *types.RepoTrafficReq) ([]types.RepoTrafficRegion, error) {
+	repo, start, end, err := c.checkTrafficAccess(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	regions, err := c.trafficStore.Regions(ctx, repo.ID, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repo traffic regions, error: %w", err)
+	}
+	result := make([]types.RepoTrafficRegion, 0, len(regions))
+	for _, region := range regions {
+		result = append(result, types.RepoTrafficRegion{Region: region.Region, Hits: region.Count, UniqueUsers: region.Visitors})
+	}
+	return result, nil
+}
+
+func (c *repoTrafficComponentImpl) Dailies(ctx context.Context, req *types.RepoTrafficReq) ([]types.RepoTrafficDaily, error) {
+	repo, start, end, err := c.checkTrafficAccess(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	dailies, err := c.trafficStore.Dailies(ctx, repo.ID, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repo traffic dailies, error: %w", err)
+	}
+	result := make([]types.RepoTrafficDaily, 0, len(dailies))
+	for _, daily := range dailies {
+		result = append(result, types.RepoTrafficDaily{
+			Date:   daily.Date.Format(time.DateOnly),
+			Kind:   types.RepoTrafficKind(daily.Kind),
+			Path:   daily.Path,
+			Region: daily.Region,
+			Count:  daily.Count,
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,226 @@
+package component
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	mockgeo "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/builder/geo"
+	mockmq "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/builder/mq"
+	mockdb "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/builder/store/database"
+	mockcomponent "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/component"
+	"opencsg.com/csghub-server/builder/geo"
+	bldmq "opencsg.com/csghub-server/builder/mq"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+)
+
+type repoTrafficTester struct {
+	*repoTrafficComponentImpl
+	repoStore     *mockdb.MockRepoStore
+	repoFileStore *mockdb.MockRepoFileStore
+	trafficStore  *mockdb.MockRepositoryTrafficStore
+	repoComponent *mockcomponent.MockRepoComponent
+	mq            *mockmq.MockMessageQueue
+	ipLocator     *mockgeo.MockIPLocator
+}
+
+func newRepoTrafficTester(t *testing.T) *repoTrafficTester {
+	tester := &repoTrafficTester{
+		repoStore:     mockdb.NewMockRepoStore(t),
+		repoFileStore: mockdb.NewMockRepoFileStore(t),
+		trafficStore:  mockdb.NewMockRepositoryTrafficStore(t),
+		repoComponent: mockcomponent.NewMockRepoComponent(t),
+		mq:            mockmq.NewMockMessageQueue(t),
+		ipLocator:     mockgeo.NewMockIPLocator(t),
+	}
+	tester.repoTrafficComponentImpl = &repoTrafficComponentImpl{
+		repoStore:     tester.repoStore,
+		repoFileStore: tester.repoFileStore,
+		trafficStore:  tester.trafficStore,
+		repoComponent: tester.repoComponent,
+		mq:            tester.mq,
+		ipLocator:     tester.ipLocator,
+		regions:       make(map[string]string),
+		eventCh:       make(chan *repoTrafficMsg, 10),
+	}
+	return tester
+}
+
+func TestRepoTrafficComponent_PublishEvent(t *testing.T) {
+	tester := newRepoTrafficTester(t)
+	event := &types.RepoTrafficEvent{Kind: types.RepoTrafficClone, RepoType: types.ModelRepo, Namespace: "ns", Name: "n"}
+	data, err := json.Marshal(event)
+	require.NoError(t, err)
+	tester.mq.EXPECT().Publish(bldmq.RepoTrafficEventSubject, data).Return(nil)
+
+	require.NoError(t, tester.PublishEvent(context.Background(), event))
+
+	acker := mockmq.NewMockMessageAcker(t)
+	require.NoError(t, tester.handleEventMsg(data, bldmq.MessageMeta{Acker: acker}))
+	msg := <-tester.eventCh
+	require.Equal(t, event, msg.event)
+	require.Equal(t, repoTrafficUnknownRegion, msg.region)
+	require.Equal(t, acker, msg.acker)
+
+	// the malformed messages are acked, they are never redelivered
+	acker.EXPECT().Ack().Return(nil).Once()
+	require.Error(t, tester.handleEventMsg([]byte("{"), bldmq.MessageMeta{Acker: acker}))
+}
+
+func TestRepoTrafficComponent_FlushBuffer(t *testing.T) {
+	tester := newRepoTrafficTester(t)
+	event := &types.RepoTrafficEvent{Kind: types.RepoTrafficClone, RepoType: types.ModelRepo, Namespace: "ns", Name: "n",
+		CreatedAt: time.Now()}
+	acker := mockmq.NewMockMessageAcker(t)
+	tester.eventBuffer = []*repoTrafficMsg{{event: event, region: repoTrafficUnknownRegion, acker: acker}}
+
+	// the messages are acked only after they are written
+	tester.repoStore.EXPECT().FindByPath(mock.Anything, types.ModelRepo, "ns", "n").Return(&database.Repository{ID: 1}, nil)
+	tester.trafficStore.EXPECT().AddDailies(mock.Anything, mock.Anything).Return(nil).Once()
+	tester.trafficStore.EXPECT().AddVisitors(mock.Anything, mock.Anything).Return(nil).Once()
+	acker.EXPECT().Ack().Return(nil).Once()
+	tester.flushBuffer()
+	require.Empty(t, tester.eventBuffer)
+
+	// the messages are redelivered if the write fails
+	tester.eventBuffer = []*repoTrafficMsg{{event: event, region: repoTrafficUnknownRegion, acker: acker}}
+	tester.trafficStore.EXPECT().AddDailies(mock.Anything, mock.Anything).Return(errorx.ErrDatabaseNoRows).Once()
+	acker.EXPECT().Nak().Return(nil).Once()
+	tester.flushBuffer()
+	require.Empty(t, tester.eventBuffer)
+}
+
+func TestRepoTrafficComponent_Rollup(t *testing.T) {
+	ctx := context.TODO()
+	tester := newRepoTrafficTester(t)
+
+	createdAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	// the events are bucketed by the UTC days
+	localCreatedAt := time.Date(2026, 10, 2, 2, 0, 0, 0, time.FixedZone("CST", 8*3600))
+	date := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	oid := "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	events := []*types.RepoTrafficEvent{
+		{Kind: types.RepoTrafficResolve, RepoType: types.ModelRepo, Namespace: "ns", Name: "n", Path: "config.json",
+			Username: "alice", IPAddress: "1.2.3.4", CreatedAt: createdAt},
+		{Kind: types.RepoTrafficResolve, RepoType: types.ModelRepo, Namespace: "ns", Name: "n", Path: "config.json",
+			Username: "alice", IPAddress: "1.2.3.4", CreatedAt: localCreatedAt},
+		{Kind: types.RepoTrafficLFS, RepoType: types.ModelRepo, Namespace: "ns", Name: "n", LfsOids: []string{oid, "missing"},
+			IPAddress: "10.0.0.1", UserAgent: "git-lfs", CreatedAt: createdAt},
+		{Kind: types.RepoTrafficClone, RepoType: types.ModelRepo, Namespace: "ns", Name: "n",
+			IPAddress: "10.0.0.1", UserAgent: "git", CreatedAt: createdAt},
+		{Kind: types.RepoTrafficClone, RepoType: types.ModelRepo, Namespace: "ns", Name: "deleted",
+			IPAddress: "10.0.0.1", CreatedAt: createdAt},
+	}
+
+	tester.repoStore.EXPECT().FindByPath(ctx, types.ModelRepo, "ns", "n").Return(&database.Repository{ID: 1}, nil).Once()
+	tester.repoStore.EXPECT().FindByPath(ctx, types.ModelRepo, "ns", "deleted").Return(nil, errorx.ErrDatabaseNoRows).Once()
+	tester.ipLocator.EXPECT().GetIPLocation("1.2.3.4").Return(&geo.IPLocation{Province: "Shanghai"}, nil).Once()
+	relativePath := types.Pointer{Oid: oid}.RelativePath()
+	tester.repoFileStore.EXPECT().PathsByLfsRelativePaths(ctx, int64(1), []string{relativePath, types.Pointer{Oid: "missing"}.RelativePath()}).
+		Return(map[string]string{relativePath: "model.safetensors"}, nil)
+
+	tester.trafficStore.EXPECT().AddDailies(ctx, mock.Anything).RunAndReturn(func(_ context.Context, dailies []database.RepositoryTrafficDaily) error {
+		got := make(map[string]int64)
+		for _, daily := range dailies {
+			require.Equal(t, int64(1), daily.RepositoryID)
+			require.Equal(t, date, daily.Date)
+			got[daily.Kind+"|"+daily.Path+"|"+daily.Region] = daily.Count
+		}
+		require.Equal(t, map[string]int64{
+			"resolve|config.json|Shanghai":  2,
+			"lfs|model.safetensors|unknown": 1,
+			"lfs|missing|unknown":           1,
+			"clone||unknown":                1,
+		}, got)
+		return nil
+	})
+	tester.trafficStore.EXPECT().AddVisitors(ctx, mock.Anything).RunAndReturn(func(_ context.Context, visitors []database.RepositoryTrafficVisitor) error {
+		var ids []string
+		for _, visitor := range visitors {
+			ids = append(ids, visitor.VisitorID+"|"+visitor.Region)
+		}
+		sort.Strings(ids)
+		require.Len(t, ids, 3)
+		require.Contains(t, ids, "user:alice|Shanghai")
+		require.Contains(t, ids, repoTrafficVisitorID(events[2])+"|unknown")
+		require.Contains(t, ids, repoTrafficVisitorID(events[3])+"|unknown")
+		return nil
+	})
+
+	msgs := make([]*repoTrafficMsg, 0, len(events))
+	for _, event := range events {
+		msgs = append(msgs, &repoTrafficMsg{event: event, region: tester.region(event.IPAddress)})
+	}
+	require.NoError(t, tester.rollup(ctx, msgs))
+}
+
+func TestRepoTrafficComponent_Stats(t *testing.T) {
+	ctx := context.TODO()
+	tester := newRepoTrafficTester(t)
+
+	start := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 10, 2, 0, 0, 0, 0, time.UTC)
+	req := &types.RepoTrafficReq{
+		RepoType: types.ModelRepo, Namespace: "ns", Name: "n", CurrentUser: "u",
+		StartDate: "2026-10-01", EndDate: "2026-10-02",
+	}
+	tester.repoStore.EXPECT().FindByPath(ctx, types.ModelRepo, "ns", "n").Return(&database.Repository{ID: 1}, nil)
+	tester.repoComponent.EXPECT().AllowAdminAccess(ctx, types.ModelRepo, "ns", "n", "u").Return(true, nil)
+	tester.trafficStore.EXPECT().Counts(ctx, int64(1), start, end).Return([]database.RepositoryTrafficCount{
+		{Date: start, Kind: "resolve", Count: 3},
+		{Date: start, Kind: "lfs", Count: 2},
+		{Date: end, Kind: "clone", Count: 1},
+	}, nil)
+	tester.trafficStore.EXPECT().VisitorCounts(ctx, int64(1), start, end).Return([]database.RepositoryTrafficVisitorCount{
+		{Date: start, Count: 2},
+		{Date: end, Count: 1},
+	}, nil)
+	tester.trafficStore.EXPECT().UniqueVisitors(ctx, int64(1), start, end).Return(2, nil)
+
+	stats, err := tester.Stats(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, &types.RepoTrafficStats{
+		StartDate: "2026-10-01",
+		EndDate:   "2026-10-02",
+		Summary:   types.RepoTrafficSummary{Downloads: 5, Clones: 1, UniqueUsers: 2},
+		TimeSeries: []types.RepoTrafficPoint{
+			{Date: "2026-10-01", Downloads: 5, UniqueUsers: 2},
+			{Date: "2026-10-02", Clones: 1, UniqueUsers: 1},
+		},
+	}, stats)
+}
+
+func TestRepoTrafficComponent_Forbidden(t *testing.T) {
+	ctx := context.TODO()
+	tester := newRepoTrafficTester(t)
+
+	req := &types.RepoTrafficReq{RepoType: types.ModelRepo, Namespace: "ns", Name: "n", CurrentUser: "u"}
+	tester.repoStore.EXPECT().FindByPath(ctx, types.ModelRepo, "ns", "n").Return(&database.Repository{ID: 1}, nil)
+	tester.repoComponent.EXPECT().AllowAdminAccess(ctx, types.ModelRepo, "ns", "n", "u").Return(false, nil)
+
+	_, err := tester.TopFiles(ctx, req)
+	require.ErrorIs(t, err, errorx.ErrForbidden)
+}
+
+func TestRepoTrafficDateRange(t *testing.T) {
+	start, end, err := repoTrafficDateRange(&types.RepoTrafficReq{EndDate: "2026-10-30"})
+	require.NoError(t, err)
+	require.Equal(t, "2026-10-01", start.Format(time.DateOnly))
+	require.Equal(t, "2026-10-30", end.Format(time.DateOnly))
+
+	for _, req := range []*types.RepoTrafficReq{
+		{StartDate: "2026/10/01"},
+		{StartDate: "2026-10-02", EndDate: "2026-10-01"},
+		{StartDate: "2025-01-01", EndDate: "2026-10-01"},
+	} {
+		_, _, err := repoTrafficDateRange(req)
+		require.ErrorIs(t, err, errorx.ErrReqParamInvalid)
+	}
+}
@@ -54,9 +54,12 @@ func NewLogCollector(config *config.Config, clientID types.ClientType) (LogColle
 	logCollectorCfg := Config(config)
 	logChan := make(chan types.LogEntry, logCollectorCfg.BatchSize*10) // Large buffer
 
-	logSender, err := sender.NewLokiClient(logCollectorCfg.LokiURL, clientID, config)
+	logSender, err := sender.NewLogSender(clientID, config)
 	if err != nil {
-		slog.Error("failed to create loki client sender", slog.Any("error", err))
+		slog.Error("failed to create log sender", slog.String("backend", config.LogCollector.Backend), slog.Any("error", err))
+	}
+	if logSender == nil {
+		return nil, fmt.Errorf("failed to create log sender: %w", err)
 	}
 
 	collector := &logCollector{
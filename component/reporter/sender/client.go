@@ -2,9 +2,11 @@ package sender
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"opencsg.com/csghub-server/builder/loki"
+	"opencsg.com/csghub-server/common/config"
 	"opencsg.com/csghub-server/common/types"
 )
 
@@ -21,4 +23,27 @@ type LogSender interface {
 	QueryRange(ctx context.Context, params loki.QueryRangeParams) (*loki.LokiQueryResponse, error)
 	GenerateLabelQuery(labels map[string]string) string
 	QueryLast(ctx context.Context, params loki.QueryLastParams) (*loki.LokiQueryResponse, error)
+	// SearchLogs returns a page of the logs matched by the query, the query is normalized by NormalizeSearchQuery
+	SearchLogs(ctx context.Context, q *types.LogSearchQuery) (*types.LogSearchResult, error)
+}
+
+const (
+	BackendLoki          = "loki"
+	BackendElasticsearch = "elasticsearch"
+	BackendOpenSearch    = "opensearch"
+	BackendFile          = "file"
+)
+
+// NewLogSender creates the log sender of the configured log backend
+func NewLogSender(clientID types.ClientType, config *config.Config) (LogSender, error) {
+	switch config.LogCollector.Backend {
+	case BackendLoki, "":
+		return NewLokiClient(config.LogCollector.LokiURL, clientID, config)
+	case BackendElasticsearch, BackendOpenSearch:
+		return NewESClient(config.LogCollector.ESURL, clientID, config)
+	case BackendFile:
+		return NewFileClient(config.LogCollector.FileDir, clientID, config)
+	default:
+		return nil, fmt.Errorf("unsupported log backend %s", config.LogCollector.Backend)
+	}
 }
@@ -0,0 +1,402 @@
+package sender
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"opencsg.com/csghub-server/builder/loki"
+	"opencsg.com/csghub-server/common/config"
+	"opencsg.com/csghub-server/common/types"
+)
+
+const (
+	esIndexDayLayout = "2006.01.02"
+	esTimeFormat     = "strict_date_optional_time_nanos"
+	// esMaxRawLength is the max length of the lines matched by the regex filters, keyword fields
+	// are limited to 32766 bytes by lucene
+	esMaxRawLength = 8191
+)
+
+// esDocument is the log document of the daily indices
+type esDocument struct {
+	Timestamp string            `json:"@timestamp"`
+	Level     types.LogLevel    `json:"level,omitempty"`
+	Labels    map[string]string `json:"labels"`
+	Message   string            `json:"message"`
+}
+
+type esSearchResponse struct {
+	Hits struct {
+		Hits []struct {
+			Source esDocument `json:"_source"`
+		} `json:"hits"`
+	} `json:"hits"`
+}
+
+type esBulkResponse struct {
+	Errors bool `json:"errors"`
+	Items  []map[string]struct {
+		Status int `json:"status"`
+		Error  *struct {
+			Type   string `json:"type"`
+			Reason string `json:"reason"`
+		} `json:"error,omitempty"`
+	} `json:"items"`
+}
+
+// esClient implements the LogSender interface on Elasticsearch or OpenSearch, logs are written to
+// the daily indices <prefix>-YYYY.MM.DD and the expired indices are deleted by the client
+type esClient struct {
+	clientID          types.ClientType
+	acceptLabelPrefix string
+	url               string
+	username          string
+	password          string
+	indexPrefix       string
+	httpClient        *http.Client
+	timeLoc           *time.Location
+	lineSeparator     string
+	maxStoreTimeDay   int
+
+	mu          sync.Mutex
+	templateSet bool
+	lastClean   time.Time
+}
+
+// NewESClient creates a log sender on Elasticsearch or OpenSearch
+func NewESClient(esURL string, clientID types.ClientType, config *config.Config) (LogSender, error) {
+	if _, err := url.ParseRequestURI(esURL); err != nil {
+		return nil, fmt.Errorf("invalid elasticsearch url %s: %w", esURL, err)
+	}
+	timeLoc, err := time.LoadLocation(config.TimeZone)
+	if err != nil {
+		slog.Error("failed to create elasticsearch client by TimeZone error", slog.Any("error", err))
+	}
+	return &esClient{
+		clientID:          clientID,
+		acceptLabelPrefix: config.LogCollector.AcceptLabelPrefix,
+		url:               strings.TrimSuffix(esURL, "/"),
+		username:          config.LogCollector.ESUsername,
+		password:          config.LogCollector.ESPassword,
+		indexPrefix:       config.LogCollector.ESIndexPrefix,
+		httpClient:        &http.Client{Timeout: loki.DefaultTimeout},
+		timeLoc:           timeLoc,
+		lineSeparator:     config.LogCollector.LineSeparator,
+		maxStoreTimeDay:   config.LogCollector.MaxStoreTimeDay,
+	}, err
+}
+
+func (c *esClient) do(ctx context.Context, method, path, contentType string, body []byte, result any) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.url+path, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create elasticsearch request: %w", err)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if c.username != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to send request to elasticsearch: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		data, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, fmt.Errorf("elasticsearch %s %s failed with status %d: %s", method, path, resp.StatusCode, string(data))
+	}
+	if result != nil {
+		if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+			return resp.StatusCode, fmt.Errorf("failed to decode elasticsearch response: %w", err)
+		}
+	}
+	return resp.StatusCode, nil
+}
+
+func (c *esClient) index(t time.Time) string {
+	return fmt.Sprintf("%s-%s", c.indexPrefix, t.UTC().Format(esIndexDayLayout))
+}
+
+// ensureTemplate maps the labels as keywords and the timestamp in nanoseconds for the daily indices
+func (c *esClient) ensureTemplate(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.templateSet {
+		return nil
+	}
+	template := map[string]any{
+		"index_patterns": []string{c.indexPrefix + "-*"},
+		"template": map[string]any{
+			"mappings": map[string]any{
+				"dynamic_templates": []any{
+					map[string]any{
+						"labels": map[string]any{
+							"path_match": "labels.*",
+							"mapping":    map[string]any{"type": "keyword"},
+						},
+					},
+				},
+				"properties": map[string]any{
+					"@timestamp": map[string]any{"type": "date_nanos"},
+					"level":      map[string]any{"type": "keyword"},
+					"message": map[string]any{
+						"type": "text",
+						"fields": map[string]any{
+							"raw": map[string]any{"type": "keyword", "ignore_above": esMaxRawLength},
+						},
+					},
+				},
+			},
+		},
+	}
+	body, err := json.Marshal(template)
+	if err != nil {
+		return err
+	}
+	_, err = c.do(ctx, http.MethodPut, "/_index_template/"+url.PathEscape(c.indexPrefix), "application/json", body, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create index template: %w", err)
+	}
+	c.templateSet = true
+	return nil
+}
+
+// SendLogs writes the log entries by the bulk api
+func (c *esClient) SendLogs(ctx context.Context, entries []types.LogEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	err := c.ensureTemplate(ctx)
+	if err != nil {
+		return err
+	}
+
+	var body bytes.Buffer
+	encoder := json.NewEncoder(&body)
+	for _, entry := range entries {
+		level := entry.Level
+		if level == "" {
+			level = DetectLogLevel(entry.Message)
+		}
+		err = encoder.Encode(map[string]any{"index": map[string]string{"_index": c.index(entry.Timestamp)}})
+		if err != nil {
+			return err
+		}
+		err = encoder.Encode(esDocument{
+			Timestamp: entry.Timestamp.UTC().Format(time.RFC3339Nano),
+			Level:     level,
+			Labels:    logEntryToMap(c.clientID, c.acceptLabelPrefix, &entry),
+			Message:   entry.Message,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	var resp esBulkResponse
+	_, err = c.do(ctx, http.MethodPost, "/_bulk", "application/x-ndjson", body.Bytes(), &resp)
+	if err != nil {
+		return fmt.Errorf("failed to push logs to elasticsearch: %w", err)
+	}
+	if resp.Errors {
+		for _, item := range resp.Items {
+			for _, result := range item {
+				if result.Error != nil {
+					return fmt.Errorf("failed to push logs to elasticsearch: %s: %s", result.Error.Type, result.Error.Reason)
+				}
+			}
+		}
+		return fmt.Errorf("failed to push logs to elasticsearch")
+	}
+
+	slog.Debug("Successfully sent logs to elasticsearch",
+		slog.Int("entries_count", len(entries)),
+		slog.String("client_id", c.clientID.String()))
+	c.cleanExpired(ctx)
+	return nil
+}
+
+// cleanExpired deletes the daily indices older than the max store days
+func (c *esClient) cleanExpired(ctx context.Context) {
+	c.mu.Lock()
+	if c.maxStoreTimeDay <= 0 || time.Since(c.lastClean) < fileCleanInterval {
+		c.mu.Unlock()
+		return
+	}
+	c.lastClean = time.Now()
+	c.mu.Unlock()
+
+	var indices []struct {
+		Index string `json:"index"`
+	}
+	_, err := c.do(ctx, http.MethodGet, "/_cat/indices/"+url.PathEscape(c.indexPrefix)+"-*?format=json&h=index", "", nil, &indices)
+	if err != nil {
+		slog.Error("failed to list log indices", slog.Any("error", err))
+		return
+	}
+	expired := c.index(time.Now().AddDate(0, 0, -c.maxStoreTimeDay))
+	for _, index := range indices {
+		day := strings.TrimPrefix(index.Index, c.indexPrefix+"-")
+		if _, err := time.Parse(esIndexDayLayout, day); err != nil || index.Index >= expired {
+			continue
+		}
+		_, err = c.do(ctx, http.MethodDelete, "/"+url.PathEscape(index.Index), "", nil, nil)
+		if err != nil {
+			slog.Error("failed to delete expired log index", slog.String("index", index.Index), slog.Any("error", err))
+		}
+	}
+}
+
+// Health checks the cluster is not red
+func (c *esClient) Health(ctx context.Context) error {
+	var health struct {
+		Status string `json:"status"`
+	}
+	_, err := c.do(ctx, http.MethodGet, "/_cluster/health", "", nil, &health)
+	if err != nil {
+		return err
+	}
+	if health.Status == "red" {
+		return fmt.Errorf("elasticsearch cluster status is red")
+	}
+	return nil
+}
+
+// GetLastReportedTimestamp searches the latest log of this client
+func (c *esClient) GetLastReportedTimestamp(ctx context.Context) (time.Time, error) {
+	if c.clientID == "" {
+		return time.Time{}, fmt.Errorf("no client ID provided")
+	}
+	result, err := c.SearchLogs(ctx, &types.LogSearchQuery{
+		Labels:    map[string]string{"client_id": c.clientID.String()},
+		Limit:     1,
+		Direction: types.LogSearchBackward,
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+	if len(result.Entries) == 0 {
+		slog.Info("No previous logs found for this client_id, will start from the beginning.", "client_id", c.clientID)
+		return time.Time{}, nil
+	}
+	// Add one nanosecond to avoid fetching the same log entry again
+	return result.Entries[0].Timestamp.Add(time.Nanosecond), nil
+}
+
+// searchBody builds the bool query of the search, the text is matched as a phrase of the analyzed
+// message and the regex is matched against the whole line in the lucene regex syntax
+func (c *esClient) searchBody(q *types.LogSearchQuery) map[string]any {
+	start, end := searchRange(q)
+	timeRange := map[string]any{"format": esTimeFormat}
+	cursor, _ := parseCursor(q.Cursor)
+	if !start.IsZero() {
+		op := "gte"
+		if !cursor.IsZero() && q.Direction == types.LogSearchForward {
+			op = "gt"
+		}
+		timeRange[op] = start.UTC().Format(time.RFC3339Nano)
+	}
+	if !end.IsZero() {
+		op := "lte"
+		if !cursor.IsZero() && q.Direction == types.LogSearchBackward {
+			op = "lt"
+		}
+		timeRange[op] = end.UTC().Format(time.RFC3339Nano)
+	}
+
+	filters := []any{
+		map[string]any{"range": map[string]any{"@timestamp": timeRange}},
+	}
+	for k, v := range searchLabels(q) {
+		filters = append(filters, map[string]any{"term": map[string]any{"labels." + k: v}})
+	}
+	if q.Level != "" {
+		filters = append(filters, map[string]any{"term": map[string]any{"level": q.Level}})
+	}
+	if q.Text != "" {
+		filters = append(filters, map[string]any{"match_phrase": map[string]any{"message": q.Text}})
+	}
+	if q.Regex != "" {
+		filters = append(filters, map[string]any{"regexp": map[string]any{"message.raw": ".*(" + q.Regex + ").*"}})
+	}
+
+	order := "asc"
+	if q.Direction == types.LogSearchBackward {
+		order = "desc"
+	}
+	return map[string]any{
+		"size":  fetchLimit(q),
+		"sort":  []any{map[string]any{"@timestamp": map[string]any{"order": order}}},
+		"query": map[string]any{"bool": map[string]any{"filter": filters}},
+	}
+}
+
+func (c *esClient) SearchLogs(ctx context.Context, q *types.LogSearchQuery) (*types.LogSearchResult, error) {
+	err := NormalizeSearchQuery(q)
+	if err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(c.searchBody(q))
+	if err != nil {
+		return nil, err
+	}
+	var resp esSearchResponse
+	path := "/" + url.PathEscape(c.indexPrefix) + "-*/_search?ignore_unavailable=true&allow_no_indices=true"
+	_, err = c.do(ctx, http.MethodPost, path, "application/json", body, &resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search logs from elasticsearch: %w", err)
+	}
+
+	entries := make([]types.LogSearchEntry, 0, len(resp.Hits.Hits))
+	for _, hit := range resp.Hits.Hits {
+		t, err := time.Parse(time.RFC3339Nano, hit.Source.Timestamp)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse timestamp from elasticsearch: %w", err)
+		}
+		entries = append(entries, types.LogSearchEntry{
+			Timestamp: t,
+			Pod:       hit.Source.Labels[types.StreamKeyInstanceName],
+			Level:     hit.Source.Level,
+			Line:      hit.Source.Message,
+			Labels:    hit.Source.Labels,
+		})
+	}
+	return pageEntries(q, entries, len(entries) >= fetchLimit(q)), nil
+}
+
+func (c *esClient) StreamAllLogs(
+	ctx context.Context,
+	id string,
+	start time.Time,
+	labels map[string]string,
+	timeLoc *time.Location,
+	limit int) (chan string, error) {
+	labels[types.StreamKeyDeployID] = id
+	if timeLoc == nil {
+		timeLoc = c.timeLoc
+	}
+	return tailBySearch(ctx, c, labels, start, timeLoc, c.lineSeparator), nil
+}
+
+func (c *esClient) QueryRange(ctx context.Context, params loki.QueryRangeParams) (*loki.LokiQueryResponse, error) {
+	return queryBySearch(ctx, c, params.Query, params.Start, params.End, params.Since, params.Limit, params.Direction)
+}
+
+func (c *esClient) GenerateLabelQuery(labels map[string]string) string {
+	return labelQuery(labels)
+}
+
+func (c *esClient) QueryLast(ctx context.Context, params loki.QueryLastParams) (*loki.LokiQueryResponse, error) {
+	return queryBySearch(ctx, c, params.Query, time.Time{}, time.Time{}, params.Since, params.Limit, params.Direction)
+}
@@ -0,0 +1,145 @@
+package sender
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"opencsg.com/csghub-server/common/config"
+	"opencsg.com/csghub-server/common/types"
+)
+
+func newTestESClient(t *testing.T, handler http.HandlerFunc) *esClient {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	cfg := &config.Config{}
+	cfg.TimeZone = "UTC"
+	cfg.LogCollector.AcceptLabelPrefix = "csghub_"
+	cfg.LogCollector.ESIndexPrefix = "logs"
+	cfg.LogCollector.ESUsername = "elastic"
+	cfg.LogCollector.ESPassword = "secret"
+	cfg.LogCollector.MaxStoreTimeDay = 7
+	s, err := NewESClient(server.URL, types.ClientTypeLogCollector, cfg)
+	require.NoError(t, err)
+	return s.(*esClient)
+}
+
+func TestESClient_SendLogs(t *testing.T) {
+	ts := time.Date(2026, 10, 18, 1, 2, 3, 4, time.UTC)
+	var requests []string
+	c := newTestESClient(t, func(w http.ResponseWriter, r *http.Request) {
+		user, password, ok := r.BasicAuth()
+		require.True(t, ok)
+		require.Equal(t, "elastic", user)
+		require.Equal(t, "secret", password)
+		requests = append(requests, r.Method+" "+r.URL.Path)
+
+		switch r.URL.Path {
+		case "/_index_template/logs":
+			var template map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&template))
+			require.Equal(t, []any{"logs-*"}, template["index_patterns"])
+			_, _ = w.Write([]byte(`{"acknowledged":true}`))
+		case "/_bulk":
+			require.Equal(t, "application/x-ndjson", r.Header.Get("Content-Type"))
+			scanner := bufio.NewScanner(r.Body)
+			require.True(t, scanner.Scan())
+			require.JSONEq(t, `{"index":{"_index":"logs-2026.10.18"}}`, scanner.Text())
+			require.True(t, scanner.Scan())
+			var doc esDocument
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &doc))
+			require.Equal(t, "2026-10-18T01:02:03.000000004Z", doc.Timestamp)
+			require.Equal(t, types.LogLevelError, doc.Level)
+			require.Equal(t, "1", doc.Labels[types.StreamKeyDeployID])
+			require.Equal(t, "pod-a", doc.Labels[types.StreamKeyInstanceName])
+			_, _ = w.Write([]byte(`{"errors":false,"items":[]}`))
+		case "/_cat/indices/logs-*":
+			today := time.Now().UTC().Format(esIndexDayLayout)
+			_, _ = w.Write([]byte(`[{"index":"logs-2020.01.01"},{"index":"logs-` + today + `"},{"index":"logs-archive"}]`))
+		default:
+			_, _ = w.Write([]byte(`{}`))
+		}
+	})
+
+	err := c.SendLogs(context.Background(), []types.LogEntry{testLogEntry(ts, "1", "pod-a", "ERROR failed")})
+	require.NoError(t, err)
+	require.Equal(t, []string{"PUT /_index_template/logs", "POST /_bulk", "GET /_cat/indices/logs-*", "DELETE /logs-2020.01.01"}, requests)
+}
+
+func TestESClient_SendLogsError(t *testing.T) {
+	c := newTestESClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/_bulk" {
+			_, _ = w.Write([]byte(`{"errors":true,"items":[{"index":{"status":400,"error":{"type":"mapper_parsing_exception","reason":"bad doc"}}}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	})
+	err := c.SendLogs(context.Background(), []types.LogEntry{testLogEntry(time.Now(), "1", "pod-a", "line")})
+	require.ErrorContains(t, err, "bad doc")
+}
+
+func TestESClient_SearchLogs(t *testing.T) {
+	start := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	cursor := start.Add(time.Minute)
+	c := newTestESClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/logs-*/_search", r.URL.Path)
+		require.Equal(t, "true", r.URL.Query().Get("ignore_unavailable"))
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		query := string(body)
+		require.Contains(t, query, `"size":3`)
+		require.Contains(t, query, `"order":"desc"`)
+		require.Contains(t, query, `"gte":"2026-10-18T00:00:00Z"`)
+		require.Contains(t, query, `"lt":"2026-10-18T00:01:00Z"`)
+		require.Contains(t, query, `{"term":{"labels.csghub_deploy_id":"1"}}`)
+		require.Contains(t, query, `{"term":{"labels.pod_name":"pod-a"}}`)
+		require.Contains(t, query, `{"term":{"level":"error"}}`)
+		require.Contains(t, query, `{"match_phrase":{"message":"memory"}}`)
+		require.Contains(t, query, `{"regexp":{"message.raw":".*(id=[0-9]+).*"}}`)
+
+		_, _ = w.Write([]byte(`{"hits":{"hits":[
+			{"_source":{"@timestamp":"2026-10-18T00:00:30.000000001Z","level":"error","labels":{"pod_name":"pod-a"},"message":"memory id=2"}},
+			{"_source":{"@timestamp":"2026-10-18T00:00:20Z","level":"error","labels":{"pod_name":"pod-a"},"message":"memory id=1"}},
+			{"_source":{"@timestamp":"2026-10-18T00:00:10Z","level":"error","labels":{"pod_name":"pod-a"},"message":"memory id=0"}}
+		]}}`))
+	})
+
+	result, err := c.SearchLogs(context.Background(), &types.LogSearchQuery{
+		Labels:    map[string]string{types.StreamKeyDeployID: "1"},
+		Start:     start,
+		End:       start.Add(time.Hour),
+		Text:      "memory",
+		Regex:     "id=[0-9]+",
+		Level:     types.LogLevelError,
+		Pod:       "pod-a",
+		Limit:     2,
+		Cursor:    formatCursor(cursor),
+		Direction: types.LogSearchBackward,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"memory id=2", "memory id=1"}, lines(result))
+	require.Equal(t, "pod-a", result.Entries[0].Pod)
+	require.Equal(t, formatCursor(start.Add(20*time.Second)), result.NextCursor)
+}
+
+func TestESClient_Health(t *testing.T) {
+	status := "green"
+	c := newTestESClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/_cluster/health", r.URL.Path)
+		_, _ = w.Write([]byte(`{"status":"` + status + `"}`))
+	})
+	require.NoError(t, c.Health(context.Background()))
+	status = "red"
+	require.Error(t, c.Health(context.Background()))
+}
+
+func formatCursor(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
@@ -0,0 +1,342 @@
+package sender
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"opencsg.com/csghub-server/builder/loki"
+	"opencsg.com/csghub-server/common/config"
+	"opencsg.com/csghub-server/common/types"
+)
+
+const (
+	fileDayLayout = "2006-01-02"
+	// fileNoDeploy is the file of the logs without a deploy id, e.g. the platform logs
+	fileNoDeploy      = "_"
+	fileCleanInterval = time.Hour
+)
+
+var unsafeFileNameRegexp = regexp.MustCompile(`[^A-Za-z0-9_.-]`)
+
+// fileRecord is one line of the log files
+type fileRecord struct {
+	Timestamp int64             `json:"ts"`
+	Level     types.LogLevel    `json:"level,omitempty"`
+	Labels    map[string]string `json:"labels"`
+	Line      string            `json:"line"`
+}
+
+// fileClient implements the LogSender interface on the local files for the installs without a log
+// storage, logs are written to <dir>/<day>/<deploy id>.jsonl so that the logs of a deploy are
+// searched without scanning the logs of others
+type fileClient struct {
+	clientID          types.ClientType
+	acceptLabelPrefix string
+	dir               string
+	timeLoc           *time.Location
+	lineSeparator     string
+	maxStoreTimeDay   int
+
+	mu        sync.Mutex
+	lastClean time.Time
+}
+
+// NewFileClient creates a log sender writing logs to the local directory
+func NewFileClient(dir string, clientID types.ClientType, config *config.Config) (LogSender, error) {
+	if dir == "" {
+		return nil, errors.New("log file directory is not configured")
+	}
+	err := os.MkdirAll(dir, 0o755)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create log file directory %s: %w", dir, err)
+	}
+	timeLoc, err := time.LoadLocation(config.TimeZone)
+	if err != nil {
+		slog.Error("failed to create file log client by TimeZone error", slog.Any("error", err))
+	}
+	return &fileClient{
+		clientID:          clientID,
+		acceptLabelPrefix: config.LogCollector.AcceptLabelPrefix,
+		dir:               dir,
+		timeLoc:           timeLoc,
+		lineSeparator:     config.LogCollector.LineSeparator,
+		maxStoreTimeDay:   config.LogCollector.MaxStoreTimeDay,
+	}, err
+}
+
+func (c *fileClient) filePath(day time.Time, deployID string) string {
+	name := fileNoDeploy
+	if deployID != "" {
+		name = unsafeFileNameRegexp.ReplaceAllString(deployID, "_")
+	}
+	return filepath.Join(c.dir, day.UTC().Format(fileDayLayout), name+".jsonl")
+}
+
+// SendLogs appends the log entries to the files of their days and deploys
+func (c *fileClient) SendLogs(ctx context.Context, entries []types.LogEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	files := make(map[string][]byte)
+	var paths []string
+	for _, entry := range entries {
+		level := entry.Level
+		if level == "" {
+			level = DetectLogLevel(entry.Message)
+		}
+		data, err := json.Marshal(fileRecord{
+			Timestamp: entry.Timestamp.UnixNano(),
+			Level:     level,
+			Labels:    logEntryToMap(c.clientID, c.acceptLabelPrefix, &entry),
+			Line:      entry.Message,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to marshal log entry: %w", err)
+		}
+		path := c.filePath(entry.Timestamp, entry.DeployID)
+		if _, ok := files[path]; !ok {
+			paths = append(paths, path)
+		}
+		files[path] = append(append(files[path], data...), '\n')
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, path := range paths {
+		err := appendFile(path, files[path])
+		if err != nil {
+			return fmt.Errorf("failed to write logs to file %s: %w", path, err)
+		}
+	}
+	c.cleanExpired()
+	return nil
+}
+
+func appendFile(path string, data []byte) error {
+	err := os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// cleanExpired removes the days older than the max store days, it must be called with the lock held
+func (c *fileClient) cleanExpired() {
+	if c.maxStoreTimeDay <= 0 || time.Since(c.lastClean) < fileCleanInterval {
+		return
+	}
+	c.lastClean = time.Now()
+	expired := time.Now().UTC().AddDate(0, 0, -c.maxStoreTimeDay).Format(fileDayLayout)
+	days, err := os.ReadDir(c.dir)
+	if err != nil {
+		slog.Error("failed to read log file directory", slog.String("dir", c.dir), slog.Any("error", err))
+		return
+	}
+	for _, day := range days {
+		if !day.IsDir() || !isDay(day.Name()) || day.Name() >= expired {
+			continue
+		}
+		err = os.RemoveAll(filepath.Join(c.dir, day.Name()))
+		if err != nil {
+			slog.Error("failed to remove expired logs", slog.String("day", day.Name()), slog.Any("error", err))
+		}
+	}
+}
+
+func isDay(name string) bool {
+	_, err := time.Parse(fileDayLayout, name)
+	return err == nil
+}
+
+// Health checks the log directory is accessible
+func (c *fileClient) Health(ctx context.Context) error {
+	info, err := os.Stat(c.dir)
+	if err != nil {
+		return fmt.Errorf("failed to access log file directory: %w", err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("log file path %s is not a directory", c.dir)
+	}
+	return nil
+}
+
+// GetLastReportedTimestamp scans the files of the latest day with the logs of this client
+func (c *fileClient) GetLastReportedTimestamp(ctx context.Context) (time.Time, error) {
+	if c.clientID == "" {
+		return time.Time{}, fmt.Errorf("no client ID provided")
+	}
+	days, err := c.days(time.Time{}, time.Now())
+	if err != nil {
+		return time.Time{}, err
+	}
+	for i := len(days) - 1; i >= 0; i-- {
+		var last int64
+		files, err := filepath.Glob(filepath.Join(c.dir, days[i], "*.jsonl"))
+		if err != nil {
+			return time.Time{}, err
+		}
+		for _, file := range files {
+			err = scanFile(file, func(record *fileRecord) bool {
+				if record.Labels["client_id"] == c.clientID.String() && record.Timestamp > last {
+					last = record.Timestamp
+				}
+				return true
+			})
+			if err != nil {
+				return time.Time{}, err
+			}
+		}
+		if last > 0 {
+			// Add one nanosecond to avoid fetching the same log entry again
+			return time.Unix(0, last).Add(time.Nanosecond), nil
+		}
+	}
+	slog.Info("No previous logs found for this client_id, will start from the beginning.", "client_id", c.clientID)
+	return time.Time{}, nil
+}
+
+// days returns the sorted day directories in the time range
+func (c *fileClient) days(start, end time.Time) ([]string, error) {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read log file directory: %w", err)
+	}
+	var days []string
+	for _, entry := range entries {
+		if !entry.IsDir() || !isDay(entry.Name()) {
+			continue
+		}
+		if !start.IsZero() && entry.Name() < start.UTC().Format(fileDayLayout) {
+			continue
+		}
+		if !end.IsZero() && entry.Name() > end.UTC().Format(fileDayLayout) {
+			continue
+		}
+		days = append(days, entry.Name())
+	}
+	sort.Strings(days)
+	return days, nil
+}
+
+func scanFile(path string, fn func(record *fileRecord) bool) error {
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var record fileRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			// skip the line partially written by a crash
+			continue
+		}
+		if !fn(&record) {
+			break
+		}
+	}
+	return scanner.Err()
+}
+
+// SearchLogs scans the files of the days in the range, only the files of the deploy are read if
+// the deploy id label is given
+func (c *fileClient) SearchLogs(ctx context.Context, q *types.LogSearchQuery) (*types.LogSearchResult, error) {
+	err := NormalizeSearchQuery(q)
+	if err != nil {
+		return nil, err
+	}
+	matcher, err := newEntryMatcher(q)
+	if err != nil {
+		return nil, err
+	}
+	start, end := searchRange(q)
+	days, err := c.days(start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []types.LogSearchEntry
+	for _, day := range days {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		var files []string
+		if deployID, ok := q.Labels[types.StreamKeyDeployID]; ok {
+			dayTime, _ := time.Parse(fileDayLayout, day)
+			files = []string{c.filePath(dayTime, deployID)}
+		} else {
+			files, err = filepath.Glob(filepath.Join(c.dir, day, "*.jsonl"))
+			if err != nil {
+				return nil, err
+			}
+		}
+		for _, file := range files {
+			err = scanFile(file, func(record *fileRecord) bool {
+				entry := types.LogSearchEntry{
+					Timestamp: time.Unix(0, record.Timestamp),
+					Pod:       record.Labels[types.StreamKeyInstanceName],
+					Level:     record.Level,
+					Line:      record.Line,
+					Labels:    record.Labels,
+				}
+				if matcher.match(&entry) {
+					entries = append(entries, entry)
+				}
+				return true
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to read log file %s: %w", file, err)
+			}
+		}
+	}
+	return pageEntries(q, entries, false), nil
+}
+
+func (c *fileClient) StreamAllLogs(
+	ctx context.Context,
+	id string,
+	start time.Time,
+	labels map[string]string,
+	timeLoc *time.Location,
+	limit int) (chan string, error) {
+	labels[types.StreamKeyDeployID] = id
+	if timeLoc == nil {
+		timeLoc = c.timeLoc
+	}
+	return tailBySearch(ctx, c, labels, start, timeLoc, c.lineSeparator), nil
+}
+
+func (c *fileClient) QueryRange(ctx context.Context, params loki.QueryRangeParams) (*loki.LokiQueryResponse, error) {
+	return queryBySearch(ctx, c, params.Query, params.Start, params.End, params.Since, params.Limit, params.Direction)
+}
+
+func (c *fileClient) GenerateLabelQuery(labels map[string]string) string {
+	return labelQuery(labels)
+}
+
+func (c *fileClient) QueryLast(ctx context.Context, params loki.QueryLastParams) (*loki.LokiQueryResponse, error) {
+	return queryBySearch(ctx, c, params.Query, time.Time{}, time.Time{}, params.Since, params.Limit, params.Direction)
+}
@@ -0,0 +1,148 @@
+package sender
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"opencsg.com/csghub-server/builder/loki"
+	"opencsg.com/csghub-server/common/config"
+	"opencsg.com/csghub-server/common/types"
+)
+
+func newTestFileClient(t *testing.T) *fileClient {
+	cfg := &config.Config{}
+	cfg.TimeZone = "UTC"
+	cfg.LogCollector.AcceptLabelPrefix = "csghub_"
+	cfg.LogCollector.LineSeparator = "\n"
+	cfg.LogCollector.MaxStoreTimeDay = 7
+	s, err := NewFileClient(t.TempDir(), types.ClientTypeLogCollector, cfg)
+	require.NoError(t, err)
+	return s.(*fileClient)
+}
+
+func testLogEntry(t time.Time, deployID, pod, msg string) types.LogEntry {
+	return types.LogEntry{
+		Timestamp: t,
+		Message:   msg,
+		DeployID:  deployID,
+		Category:  types.LogCategoryContainer,
+		Labels:    map[string]string{types.LogLabelTypeKey: types.LogLabelDeploy},
+		PodInfo:   &types.PodInfo{PodName: pod},
+	}
+}
+
+func TestFileClient_SearchLogs(t *testing.T) {
+	ctx := context.Background()
+	c := newTestFileClient(t)
+	base := time.Now().Add(-time.Hour).Truncate(time.Second)
+	err := c.SendLogs(ctx, []types.LogEntry{
+		testLogEntry(base, "1", "pod-a", "INFO model loaded"),
+		testLogEntry(base.Add(time.Second), "1", "pod-b", "ERROR out of memory"),
+		testLogEntry(base.Add(2*time.Second), "1", "pod-a", "WARN slow request id=42"),
+		testLogEntry(base.Add(3*time.Second), "2", "pod-c", "ERROR other deploy"),
+		testLogEntry(base.Add(-48*time.Hour), "1", "pod-a", "INFO yesterday"),
+	})
+	require.NoError(t, err)
+	require.NoError(t, c.Health(ctx))
+
+	labels := map[string]string{types.StreamKeyDeployID: "1"}
+	result, err := c.SearchLogs(ctx, &types.LogSearchQuery{Labels: labels, Start: base})
+	require.NoError(t, err)
+	require.Equal(t, []string{"INFO model loaded", "ERROR out of memory", "WARN slow request id=42"}, lines(result))
+	require.Equal(t, "pod-b", result.Entries[1].Pod)
+	require.Equal(t, types.LogLevelError, result.Entries[1].Level)
+
+	result, err = c.SearchLogs(ctx, &types.LogSearchQuery{Labels: labels, Start: base.Add(-72 * time.Hour)})
+	require.NoError(t, err)
+	require.Equal(t, "INFO yesterday", result.Entries[0].Line)
+
+	result, err = c.SearchLogs(ctx, &types.LogSearchQuery{Labels: labels, Start: base, Level: types.LogLevelError})
+	require.NoError(t, err)
+	require.Equal(t, []string{"ERROR out of memory"}, lines(result))
+
+	result, err = c.SearchLogs(ctx, &types.LogSearchQuery{Labels: labels, Start: base, Pod: "pod-a", Regex: `id=\d+`})
+	require.NoError(t, err)
+	require.Equal(t, []string{"WARN slow request id=42"}, lines(result))
+
+	result, err = c.SearchLogs(ctx, &types.LogSearchQuery{Start: base, Text: "ERROR"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"ERROR out of memory", "ERROR other deploy"}, lines(result))
+
+	// pages backward
+	q := &types.LogSearchQuery{Labels: labels, Start: base, Limit: 2, Direction: types.LogSearchBackward}
+	result, err = c.SearchLogs(ctx, q)
+	require.NoError(t, err)
+	require.Equal(t, []string{"WARN slow request id=42", "ERROR out of memory"}, lines(result))
+	require.NotEmpty(t, result.NextCursor)
+	q.Cursor = result.NextCursor
+	result, err = c.SearchLogs(ctx, q)
+	require.NoError(t, err)
+	require.Equal(t, []string{"INFO model loaded"}, lines(result))
+	require.Empty(t, result.NextCursor)
+
+	last, err := c.GetLastReportedTimestamp(ctx)
+	require.NoError(t, err)
+	require.Equal(t, base.Add(3*time.Second).Add(time.Nanosecond), last)
+}
+
+func TestFileClient_QueryRange(t *testing.T) {
+	ctx := context.Background()
+	c := newTestFileClient(t)
+	base := time.Now().Add(-time.Minute)
+	err := c.SendLogs(ctx, []types.LogEntry{
+		testLogEntry(base, "1", "pod-a", "line 1"),
+		testLogEntry(base.Add(time.Second), "1", "pod-b", "line 2"),
+	})
+	require.NoError(t, err)
+
+	query := c.GenerateLabelQuery(map[string]string{types.StreamKeyDeployID: "1", types.LogLabelTypeKey: types.LogLabelDeploy})
+	resp, err := c.QueryRange(ctx, loki.QueryRangeParams{Query: query, Start: base, Direction: "forward", Limit: loki.MaxLimit})
+	require.NoError(t, err)
+	require.Len(t, resp.Data.Result, 2)
+	require.Equal(t, "pod-a", resp.Data.Result[0].Stream[types.StreamKeyInstanceName])
+	require.Equal(t, "line 1", resp.Data.Result[0].Values[0][1])
+
+	resp, err = c.QueryLast(ctx, loki.QueryLastParams{Query: query, Limit: 1, Direction: "backward"})
+	require.NoError(t, err)
+	require.Len(t, resp.Data.Result, 1)
+	require.Equal(t, "line 2", resp.Data.Result[0].Values[0][1])
+}
+
+func TestFileClient_ExportLogs(t *testing.T) {
+	ctx := context.Background()
+	c := newTestFileClient(t)
+	base := time.Now().Add(-time.Minute).UTC()
+	var entries []types.LogEntry
+	for i := range 5 {
+		entries = append(entries, testLogEntry(base.Add(time.Duration(i)*time.Millisecond), "1", "pod-a", "line"))
+	}
+	require.NoError(t, c.SendLogs(ctx, entries))
+
+	var buf bytes.Buffer
+	err := ExportLogs(ctx, c, &types.LogSearchQuery{Labels: map[string]string{types.StreamKeyDeployID: "1"}, Start: base}, &buf)
+	require.NoError(t, err)
+	exported := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Len(t, exported, 5)
+	require.Equal(t, base.Format(time.RFC3339Nano)+" pod-a | line", exported[0])
+}
+
+func TestFileClient_CleanExpired(t *testing.T) {
+	c := newTestFileClient(t)
+	expired := filepath.Join(c.dir, time.Now().UTC().AddDate(0, 0, -8).Format(fileDayLayout))
+	kept := filepath.Join(c.dir, time.Now().UTC().AddDate(0, 0, -6).Format(fileDayLayout))
+	other := filepath.Join(c.dir, "other")
+	for _, dir := range []string{expired, kept, other} {
+		require.NoError(t, os.MkdirAll(dir, 0o755))
+	}
+
+	c.cleanExpired()
+	require.NoDirExists(t, expired)
+	require.DirExists(t, kept)
+	require.DirExists(t, other)
+}
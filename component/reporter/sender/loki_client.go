@@ -46,13 +46,17 @@ func NewLokiClient(url string, clientID types.ClientType, config *config.Config)
 	}, err
 }
 
+func (c *lokiClient) logEntryToMap(entry *types.LogEntry) map[string]string {
+	return logEntryToMap(c.clientID, c.acceptLabelPrefix, entry)
+}
+
 // logEntryToMap Create a unique key for each stream based on labels
 // Priority: entry.PodInfo.Labels > entry.Labels > default labels
-func (c *lokiClient) logEntryToMap(entry *types.LogEntry) map[string]string {
+func logEntryToMap(clientID types.ClientType, acceptLabelPrefix string, entry *types.LogEntry) map[string]string {
 	labelCount := 0
 	// must have labels
 	labels := map[string]string{
-		"client_id":                 c.clientID.String(),
+		"client_id":                 clientID.String(),
 		"category":                  entry.Category.String(),
 		types.StreamKeyDeployID:     entry.DeployID,
 		types.StreamKeyDeployTaskID: entry.Labels[types.StreamKeyDeployTaskID],
@@ -73,7 +77,7 @@ func (c *lokiClient) logEntryToMap(entry *types.LogEntry) map[string]string {
 			if labelCount > types.MaxLabelCount {
 				break
 			}
-			if strings.HasPrefix(key, c.acceptLabelPrefix) && value != "" {
+			if strings.HasPrefix(key, acceptLabelPrefix) && value != "" {
 				labels[key] = value
 				labelCount++
 			}
@@ -235,6 +239,17 @@ func (c *lokiClient) createStreamKey(labels map[string]string) string {
 }
 
 func (c *lokiClient) formatPodIdentifier(streamMap map[string]string) string {
+	return formatPodIdentifier(streamMap)
+}
+
+func (c *lokiClient) formatLokiLog(lokiLog *loki.LokiPushRequest, timeLoc *time.Location) string {
+	if nil == timeLoc {
+		timeLoc = c.timeLoc
+	}
+	return formatLokiLog(lokiLog, timeLoc, c.lineSeparator)
+}
+
+func formatPodIdentifier(streamMap map[string]string) string {
 	category := types.LogCategrory(streamMap["category"])
 	if category == types.LogCategoryPlatform {
 		return types.LogCategoryPlatform.String()
@@ -253,13 +268,13 @@ func (c *lokiClient) formatPodIdentifier(streamMap map[string]string) string {
 	return podIdentifier
 }
 
-func (c *lokiClient) formatLokiLog(lokiLog *loki.LokiPushRequest, timeLoc *time.Location) string {
+func formatLokiLog(lokiLog *loki.LokiPushRequest, timeLoc *time.Location, lineSeparator string) string {
 	if nil == timeLoc {
-		timeLoc = c.timeLoc
+		timeLoc = time.Local
 	}
 	var bulkLog strings.Builder
 	for _, stream := range lokiLog.Streams {
-		podIdentifier := c.formatPodIdentifier(stream.Stream)
+		podIdentifier := formatPodIdentifier(stream.Stream)
 		for _, valuePair := range stream.Values {
 			loglist := strings.Split(valuePair[1], "\n")
 			for _, log := range loglist {
@@ -271,7 +286,7 @@ func (c *lokiClient) formatLokiLog(lokiLog *loki.LokiPushRequest, timeLoc *time.
 				if len(lineParts) < 2 {
 					formattedLog := fmt.Sprintf("%s | %s", podIdentifier, log)
 					bulkLog.WriteString(formattedLog)
-					bulkLog.WriteString(c.lineSeparator)
+					bulkLog.WriteString(lineSeparator)
 					continue
 				}
 
@@ -286,11 +301,11 @@ func (c *lokiClient) formatLokiLog(lokiLog *loki.LokiPushRequest, timeLoc *time.
 
 				formattedLog := fmt.Sprintf("%s | %s %s", podIdentifier, formattedTime, lineParts[1])
 				bulkLog.WriteString(formattedLog)
-				bulkLog.WriteString(c.lineSeparator)
+				bulkLog.WriteString(lineSeparator)
 			}
 		}
 	}
-	return strings.TrimSuffix(bulkLog.String(), c.lineSeparator)
+	return strings.TrimSuffix(bulkLog.String(), lineSeparator)
 }
 
 func (c *lokiClient) StreamAllLogs(
@@ -360,3 +375,59 @@ func (c *lokiClient) QueryLast(ctx context.Context, params loki.QueryLastParams)
 
 	return c.lokiClient.QueryLast(ctx, params)
 }
+
+// SearchLogs filters the lines by the LogQL line filters and merges the streams by timestamp
+func (c *lokiClient) SearchLogs(ctx context.Context, q *types.LogSearchQuery) (*types.LogSearchResult, error) {
+	err := NormalizeSearchQuery(q)
+	if err != nil {
+		return nil, err
+	}
+	start, end := searchRange(q)
+	limit := fetchLimit(q)
+	resp, err := c.lokiClient.QueryRange(ctx, loki.QueryRangeParams{
+		Query:     c.searchQuery(q),
+		Start:     start,
+		End:       end,
+		Limit:     limit,
+		Direction: q.Direction,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search logs from loki: %w", err)
+	}
+
+	var entries []types.LogSearchEntry
+	for _, stream := range resp.Data.Result {
+		for _, value := range stream.Values {
+			if len(value) < 2 {
+				continue
+			}
+			ns, err := strconv.ParseInt(value[0], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse timestamp from Loki: %w", err)
+			}
+			entries = append(entries, types.LogSearchEntry{
+				Timestamp: time.Unix(0, ns),
+				Pod:       stream.Stream[types.StreamKeyInstanceName],
+				Level:     DetectLogLevel(value[1]),
+				Line:      value[1],
+				Labels:    stream.Stream,
+			})
+		}
+	}
+	return pageEntries(q, entries, len(entries) >= limit), nil
+}
+
+func (c *lokiClient) searchQuery(q *types.LogSearchQuery) string {
+	var queryBuilder strings.Builder
+	queryBuilder.WriteString(labelQuery(searchLabels(q)))
+	if q.Text != "" {
+		fmt.Fprintf(&queryBuilder, " |= %s", strconv.Quote(q.Text))
+	}
+	if q.Regex != "" {
+		fmt.Fprintf(&queryBuilder, " |~ %s", strconv.Quote(q.Regex))
+	}
+	if q.Level != "" {
+		fmt.Fprintf(&queryBuilder, " |~ %s", strconv.Quote(levelPatterns[q.Level]))
+	}
+	return queryBuilder.String()
+}
@@ -600,3 +600,39 @@ func TestNewLokiClient_ReturnsLogSender(t *testing.T) {
 	require.NoError(t, err)
 	assert.NotNil(t, sender)
 }
+
+func Test_lokiClient_SearchLogs(t *testing.T) {
+	mockClient := mock_loki.NewMockClient(t)
+	c := &lokiClient{lokiClient: mockClient}
+	start := time.Unix(100, 0)
+	end := time.Unix(200, 0)
+
+	resp := &loki.LokiQueryResponse{}
+	resp.Data.ResultType = "streams"
+	resp.Data.Result = []loki.LokiStream{
+		{Stream: map[string]string{"pod_name": "pod-a", "container_name": "a"}, Values: [][]string{{"110", "ERROR 1"}, {"130", "ERROR 3"}}},
+		{Stream: map[string]string{"pod_name": "pod-a", "container_name": "b"}, Values: [][]string{{"120", "ERROR 2"}}},
+	}
+	mockClient.EXPECT().QueryRange(mock.Anything, loki.QueryRangeParams{
+		Query:     `{csghub_deploy_id="1",pod_name="pod-a"} |= "out of \"memory\"" |~ "id=\\d+" |~ "(?i)\\b(error|fatal|panic|exception)\\b"`,
+		Start:     start,
+		End:       end,
+		Limit:     3,
+		Direction: types.LogSearchForward,
+	}).Return(resp, nil)
+
+	result, err := c.SearchLogs(context.Background(), &types.LogSearchQuery{
+		Labels: map[string]string{types.StreamKeyDeployID: "1"},
+		Start:  start,
+		End:    end,
+		Text:   `out of "memory"`,
+		Regex:  `id=\d+`,
+		Level:  types.LogLevelError,
+		Pod:    "pod-a",
+		Limit:  2,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"ERROR 1", "ERROR 2"}, lines(result))
+	require.Equal(t, types.LogLevelError, result.Entries[0].Level)
+	require.Equal(t, "120", result.NextCursor)
+}
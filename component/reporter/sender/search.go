@@ -0,0 +1,356 @@
+package sender
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"opencsg.com/csghub-server/builder/loki"
+	"opencsg.com/csghub-server/common/types"
+)
+
+const (
+	DefaultSearchLimit = 100
+	// tailInterval is how often the backends without a tail api poll the new logs
+	tailInterval = 2 * time.Second
+)
+
+// levelPatterns detects the level of a line by its keywords, the levels are checked in the order of levelOrder
+var (
+	levelPatterns = map[types.LogLevel]string{
+		types.LogLevelError: `(?i)\b(error|fatal|panic|exception)\b`,
+		types.LogLevelWarn:  `(?i)\b(warn|warning)\b`,
+		types.LogLevelInfo:  `(?i)\binfo\b`,
+		types.LogLevelDebug: `(?i)\b(debug|trace)\b`,
+	}
+	levelOrder  = []types.LogLevel{types.LogLevelError, types.LogLevelWarn, types.LogLevelInfo, types.LogLevelDebug}
+	levelRegexp = map[types.LogLevel]*regexp.Regexp{}
+)
+
+func init() {
+	for level, pattern := range levelPatterns {
+		levelRegexp[level] = regexp.MustCompile(pattern)
+	}
+}
+
+// DetectLogLevel returns the level of the first matched level keyword in the line
+func DetectLogLevel(line string) types.LogLevel {
+	for _, level := range levelOrder {
+		if levelRegexp[level].MatchString(line) {
+			return level
+		}
+	}
+	return ""
+}
+
+// NormalizeSearchQuery validates the query and fills the default limit, direction and end time
+func NormalizeSearchQuery(q *types.LogSearchQuery) error {
+	if q.Limit <= 0 {
+		q.Limit = DefaultSearchLimit
+	}
+	if q.Limit > loki.MaxLimit {
+		q.Limit = loki.MaxLimit
+	}
+	if q.Direction == "" {
+		q.Direction = types.LogSearchForward
+	}
+	if q.Direction != types.LogSearchForward && q.Direction != types.LogSearchBackward {
+		return fmt.Errorf("invalid direction %s", q.Direction)
+	}
+	if q.Level != "" {
+		if _, ok := levelPatterns[q.Level]; !ok {
+			return fmt.Errorf("invalid log level %s", q.Level)
+		}
+	}
+	if q.Regex != "" {
+		if _, err := regexp.Compile(q.Regex); err != nil {
+			return fmt.Errorf("invalid regex %s, error: %w", q.Regex, err)
+		}
+	}
+	if q.End.IsZero() {
+		q.End = time.Now()
+	}
+	if !q.Start.IsZero() && q.Start.After(q.End) {
+		return errors.New("start time is after end time")
+	}
+	if _, err := parseCursor(q.Cursor); err != nil {
+		return err
+	}
+	return nil
+}
+
+func parseCursor(cursor string) (time.Time, error) {
+	if cursor == "" {
+		return time.Time{}, nil
+	}
+	ns, err := strconv.ParseInt(cursor, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid cursor %s", cursor)
+	}
+	return time.Unix(0, ns), nil
+}
+
+// searchLabels returns the labels of the query with the pod filter
+func searchLabels(q *types.LogSearchQuery) map[string]string {
+	labels := maps.Clone(q.Labels)
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	if q.Pod != "" {
+		labels[types.StreamKeyInstanceName] = q.Pod
+	}
+	return labels
+}
+
+// searchRange returns the time range of the page, the cursor entry is excluded by pageEntries
+func searchRange(q *types.LogSearchQuery) (time.Time, time.Time) {
+	start, end := q.Start, q.End
+	cursor, _ := parseCursor(q.Cursor)
+	if cursor.IsZero() {
+		return start, end
+	}
+	if q.Direction == types.LogSearchBackward {
+		return start, cursor
+	}
+	return cursor, end
+}
+
+// fetchLimit is the number of entries read from the backend for a page, one more than the page
+// to tell whether there are more entries after it
+func fetchLimit(q *types.LogSearchQuery) int {
+	return min(q.Limit+1, loki.MaxLimit)
+}
+
+// pageEntries sorts the entries in the direction of the query and returns the page after the cursor,
+// more tells that the backend has more entries than the fetched ones
+func pageEntries(q *types.LogSearchQuery, entries []types.LogSearchEntry, more bool) *types.LogSearchResult {
+	cursor, _ := parseCursor(q.Cursor)
+	backward := q.Direction == types.LogSearchBackward
+	sort.SliceStable(entries, func(i, j int) bool {
+		if backward {
+			return entries[i].Timestamp.After(entries[j].Timestamp)
+		}
+		return entries[i].Timestamp.Before(entries[j].Timestamp)
+	})
+
+	result := &types.LogSearchResult{Entries: []types.LogSearchEntry{}}
+	for i, entry := range entries {
+		if !cursor.IsZero() {
+			if backward && !entry.Timestamp.Before(cursor) {
+				continue
+			}
+			if !backward && !entry.Timestamp.After(cursor) {
+				continue
+			}
+		}
+		result.Entries = append(result.Entries, entry)
+		if len(result.Entries) == q.Limit {
+			more = more || i < len(entries)-1
+			break
+		}
+	}
+	if more && len(result.Entries) > 0 {
+		result.NextCursor = strconv.FormatInt(result.Entries[len(result.Entries)-1].Timestamp.UnixNano(), 10)
+	}
+	return result
+}
+
+// entryMatcher filters the entries of the backends which can't filter the lines themselves
+type entryMatcher struct {
+	q      *types.LogSearchQuery
+	labels map[string]string
+	regex  *regexp.Regexp
+}
+
+func newEntryMatcher(q *types.LogSearchQuery) (*entryMatcher, error) {
+	m := &entryMatcher{q: q, labels: searchLabels(q)}
+	if q.Regex != "" {
+		regex, err := regexp.Compile(q.Regex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regex %s, error: %w", q.Regex, err)
+		}
+		m.regex = regex
+	}
+	return m, nil
+}
+
+func (m *entryMatcher) match(entry *types.LogSearchEntry) bool {
+	start, end := searchRange(m.q)
+	if !start.IsZero() && entry.Timestamp.Before(start) {
+		return false
+	}
+	if !end.IsZero() && entry.Timestamp.After(end) {
+		return false
+	}
+	for k, v := range m.labels {
+		if entry.Labels[k] != v {
+			return false
+		}
+	}
+	if m.q.Level != "" && entry.Level != m.q.Level {
+		return false
+	}
+	if m.q.Text != "" && !strings.Contains(entry.Line, m.q.Text) {
+		return false
+	}
+	if m.regex != nil && !m.regex.MatchString(entry.Line) {
+		return false
+	}
+	return true
+}
+
+// ExportLogs writes all the logs matched by the query in pages, one line per entry
+func ExportLogs(ctx context.Context, s LogSender, q *types.LogSearchQuery, w io.Writer) error {
+	query := *q
+	query.Limit = loki.MaxLimit
+	for {
+		result, err := s.SearchLogs(ctx, &query)
+		if err != nil {
+			return err
+		}
+		for _, entry := range result.Entries {
+			pod := entry.Pod
+			if pod == "" {
+				pod = "-"
+			}
+			_, err = fmt.Fprintf(w, "%s %s | %s\n", entry.Timestamp.Format(time.RFC3339Nano), pod, strings.TrimRight(entry.Line, "\n"))
+			if err != nil {
+				return err
+			}
+		}
+		if result.NextCursor == "" {
+			return nil
+		}
+		query.Cursor = result.NextCursor
+	}
+}
+
+// labelQuery builds the label selector in the LogQL format so that the backends share the queries of the callers
+func labelQuery(labels map[string]string) string {
+	keys := slices.Sorted(maps.Keys(labels))
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", k, strconv.Quote(labels[k])))
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+var labelPairRegexp = regexp.MustCompile(`([A-Za-z_][A-Za-z0-9_]*)\s*=\s*("(?:[^"\\]|\\.)*")`)
+
+// parseLabelQuery parses the label selector built by labelQuery or the loki client
+func parseLabelQuery(query string) map[string]string {
+	labels := map[string]string{}
+	for _, match := range labelPairRegexp.FindAllStringSubmatch(query, -1) {
+		value, err := strconv.Unquote(match[2])
+		if err != nil {
+			value = strings.Trim(match[2], `"`)
+		}
+		labels[match[1]] = value
+	}
+	return labels
+}
+
+// queryBySearch serves the loki range queries by the search of the backend, the range defaults to
+// the last hour before the end as loki does
+func queryBySearch(ctx context.Context, s LogSender, query string, start, end time.Time, since time.Duration, limit int, direction string) (*loki.LokiQueryResponse, error) {
+	if limit <= 0 {
+		limit = loki.MaxLimit
+	}
+	if direction == "" {
+		direction = types.LogSearchBackward
+	}
+	if end.IsZero() {
+		end = time.Now()
+	}
+	if start.IsZero() {
+		if since <= 0 {
+			since = time.Hour
+		}
+		start = end.Add(-since)
+	}
+	q := &types.LogSearchQuery{
+		Labels:    parseLabelQuery(query),
+		Start:     start,
+		End:       end,
+		Limit:     limit,
+		Direction: direction,
+	}
+	result, err := s.SearchLogs(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	resp := &loki.LokiQueryResponse{Status: "success"}
+	resp.Data.ResultType = "streams"
+	resp.Data.Result = toLokiStreams(result.Entries)
+	return resp, nil
+}
+
+// toLokiStreams groups the entries by their labels, the order of the entries is kept in each stream
+func toLokiStreams(entries []types.LogSearchEntry) []loki.LokiStream {
+	var streams []loki.LokiStream
+	index := map[string]int{}
+	for _, entry := range entries {
+		key := labelQuery(entry.Labels)
+		i, ok := index[key]
+		if !ok {
+			i = len(streams)
+			index[key] = i
+			streams = append(streams, loki.LokiStream{Stream: entry.Labels})
+		}
+		streams[i].Values = append(streams[i].Values, []string{strconv.FormatInt(entry.Timestamp.UnixNano(), 10), entry.Line})
+	}
+	return streams
+}
+
+// tailBySearch polls the new logs of the labels for the backends without a tail api
+func tailBySearch(ctx context.Context, s LogSender, labels map[string]string, start time.Time, timeLoc *time.Location, lineSeparator string) chan string {
+	ch := make(chan string)
+	go func() {
+		defer close(ch)
+		q := &types.LogSearchQuery{
+			Labels:    labels,
+			Start:     start,
+			Limit:     loki.MaxLimit,
+			Direction: types.LogSearchForward,
+		}
+		ticker := time.NewTicker(tailInterval)
+		defer ticker.Stop()
+		for {
+			q.End = time.Now()
+			result, err := s.SearchLogs(ctx, q)
+			if err != nil {
+				slog.Error("failed to poll logs", slog.Any("labels", labels), slog.Any("error", err))
+			} else if len(result.Entries) > 0 {
+				formattedLogs := formatLokiLog(&loki.LokiPushRequest{Streams: toLokiStreams(result.Entries)}, timeLoc, lineSeparator)
+				q.Cursor = strconv.FormatInt(result.Entries[len(result.Entries)-1].Timestamp.UnixNano(), 10)
+				if formattedLogs != "" {
+					select {
+					case ch <- formattedLogs:
+					case <-ctx.Done():
+						return
+					}
+				}
+				// keep reading until the backlog is drained
+				if result.NextCursor != "" {
+					continue
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return ch
+}
@@ -0,0 +1,98 @@
+package sender
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"opencsg.com/csghub-server/builder/loki"
+	"opencsg.com/csghub-server/common/types"
+)
+
+func TestDetectLogLevel(t *testing.T) {
+	require.Equal(t, types.LogLevelError, DetectLogLevel("panic: runtime error"))
+	require.Equal(t, types.LogLevelError, DetectLogLevel("level=warn msg=\"retry on error\""))
+	require.Equal(t, types.LogLevelWarn, DetectLogLevel("WARNING: disk is almost full"))
+	require.Equal(t, types.LogLevelInfo, DetectLogLevel("INFO server started"))
+	require.Equal(t, types.LogLevelDebug, DetectLogLevel("trace id=1"))
+	require.Equal(t, types.LogLevel(""), DetectLogLevel("errors=0 information"))
+}
+
+func TestNormalizeSearchQuery(t *testing.T) {
+	q := &types.LogSearchQuery{}
+	require.NoError(t, NormalizeSearchQuery(q))
+	require.Equal(t, DefaultSearchLimit, q.Limit)
+	require.Equal(t, types.LogSearchForward, q.Direction)
+	require.False(t, q.End.IsZero())
+
+	q = &types.LogSearchQuery{Limit: loki.MaxLimit + 1}
+	require.NoError(t, NormalizeSearchQuery(q))
+	require.Equal(t, loki.MaxLimit, q.Limit)
+
+	invalids := []*types.LogSearchQuery{
+		{Direction: "up"},
+		{Level: "fatal"},
+		{Regex: "("},
+		{Cursor: "abc"},
+		{Start: time.Now(), End: time.Now().Add(-time.Hour)},
+	}
+	for _, q := range invalids {
+		require.Error(t, NormalizeSearchQuery(q), "%+v", q)
+	}
+}
+
+func TestPageEntries(t *testing.T) {
+	base := time.Unix(100, 0)
+	var entries []types.LogSearchEntry
+	for i := range 5 {
+		entries = append(entries, types.LogSearchEntry{Timestamp: base.Add(time.Duration(i) * time.Second), Line: strconv.Itoa(i)})
+	}
+
+	q := &types.LogSearchQuery{Limit: 2, Direction: types.LogSearchForward}
+	result := pageEntries(q, entries, false)
+	require.Equal(t, []string{"0", "1"}, lines(result))
+	require.Equal(t, strconv.FormatInt(base.Add(time.Second).UnixNano(), 10), result.NextCursor)
+
+	q.Cursor = result.NextCursor
+	result = pageEntries(q, entries, false)
+	require.Equal(t, []string{"2", "3"}, lines(result))
+
+	q.Cursor = result.NextCursor
+	result = pageEntries(q, entries, false)
+	require.Equal(t, []string{"4"}, lines(result))
+	require.Empty(t, result.NextCursor)
+
+	q = &types.LogSearchQuery{Limit: 3, Direction: types.LogSearchBackward}
+	result = pageEntries(q, entries, false)
+	require.Equal(t, []string{"4", "3", "2"}, lines(result))
+	q.Cursor = result.NextCursor
+	result = pageEntries(q, entries, false)
+	require.Equal(t, []string{"1", "0"}, lines(result))
+	require.Empty(t, result.NextCursor)
+
+	// the backend has more entries than fetched
+	q = &types.LogSearchQuery{Limit: 10, Direction: types.LogSearchForward}
+	result = pageEntries(q, entries, true)
+	require.Len(t, result.Entries, 5)
+	require.Equal(t, strconv.FormatInt(base.Add(4*time.Second).UnixNano(), 10), result.NextCursor)
+}
+
+func TestLabelQuery(t *testing.T) {
+	labels := map[string]string{
+		types.StreamKeyDeployID:     "1",
+		types.StreamKeyInstanceName: `pod-"a"`,
+	}
+	query := labelQuery(labels)
+	require.Equal(t, `{csghub_deploy_id="1",pod_name="pod-\"a\""}`, query)
+	require.Equal(t, labels, parseLabelQuery(query))
+	require.Equal(t, map[string]string{"a": "1", "b": "x"}, parseLabelQuery(`{a="1", b = "x"}`))
+}
+
+func lines(result *types.LogSearchResult) []string {
+	var lines []string
+	for _, entry := range result.Entries {
+		lines = append(lines, entry.Line)
+	}
+	return lines
+}
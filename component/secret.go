@@ -0,0 +1,133 @@
+package component
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"opencsg.com/csghub-server/builder/rpc"
+	"opencsg.com/csghub-server/builder/secret"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/config"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+)
+
+// SecretComponent manages the encrypted secrets of the spaces and the inference, finetune and notebook
+// deploys, the secrets are injected into the pods as a kubernetes secret when they are deployed
+type SecretComponent interface {
+	ListSpaceSecrets(ctx context.Context, req *types.SecretReq) ([]types.Secret, error)
+	SetSpaceSecret(ctx context.Context, req *types.SecretReq) (*types.Secret, error)
+	DeleteSpaceSecret(ctx context.Context, req *types.SecretReq) error
+	ListDeploySecrets(ctx context.Context, req *types.SecretReq) ([]types.Secret, error)
+	SetDeploySecret(ctx context.Context, req *types.SecretReq) (*types.Secret, error)
+	DeleteDeploySecret(ctx context.Context, req *types.SecretReq) error
+}
+
+type secretComponentImpl struct {
+	secretManager   secret.Manager
+	spaceStore      database.SpaceStore
+	deployTaskStore database.DeployTaskStore
+	repoComponent   RepoComponent
+	userSvcClient   rpc.UserSvcClient
+}
+
+func NewSecretComponent(config *config.Config) (SecretComponent, error) {
+	secretManager, err := secret.NewManager(config)
+	if err != nil {
+		return nil, err
+	}
+	repoComponent, err := NewRepoComponentImpl(config)
+	if err != nil {
+		return nil, err
+	}
+	return &secretComponentImpl{
+		secretManager:   secretManager,
+		spaceStore:      database.NewSpaceStore(),
+		deployTaskStore: database.NewDeployTaskStore(),
+		repoComponent:   repoComponent,
+		userSvcClient: rpc.NewUserSvcHttpClient(fmt.Sprintf("%s:%d", config.User.Host, config.User.Port),
+			rpc.AuthWithApiKey(config.APIToken)),
+	}, nil
+}
+
+func (c *secretComponentImpl) ListSpaceSecrets(ctx context.Context, req *types.SecretReq) ([]types.Secret, error) {
+	space, err := c.checkSpace(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return c.secretManager.List(ctx, types.SecretOwnerSpace, space.ID)
+}
+
+func (c *secretComponentImpl) SetSpaceSecret(ctx context.Context, req *types.SecretReq) (*types.Secret, error) {
+	space, err := c.checkSpace(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return c.secretManager.Set(ctx, types.SecretOwnerSpace, space.ID, req.Key, req.Value)
+}
+
+func (c *secretComponentImpl) DeleteSpaceSecret(ctx context.Context, req *types.SecretReq) error {
+	space, err := c.checkSpace(ctx, req)
+	if err != nil {
+		return err
+	}
+	return c.secretManager.Delete(ctx, types.SecretOwnerSpace, space.ID, req.Key)
+}
+
+func (c *secretComponentImpl) ListDeploySecrets(ctx context.Context, req *types.SecretReq) ([]types.Secret, error) {
+	deploy, err := c.checkDeploy(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return c.secretManager.List(ctx, types.SecretOwnerDeploy, deploy.ID)
+}
+
+func (c *secretComponentImpl) SetDeploySecret(ctx context.Context, req *types.SecretReq) (*types.Secret, error) {
+	deploy, err := c.checkDeploy(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return c.secretManager.Set(ctx, types.SecretOwnerDeploy, deploy.ID, req.Key, req.Value)
+}
+
+func (c *secretComponentImpl) DeleteDeploySecret(ctx context.Context, req *types.SecretReq) error {
+	deploy, err := c.checkDeploy(ctx, req)
+	if err != nil {
+		return err
+	}
+	return c.secretManager.Delete(ctx, types.SecretOwnerDeploy, deploy.ID, req.Key)
+}
+
+// checkSpace returns the space if the current user could write to it
+func (c *secretComponentImpl) checkSpace(ctx context.Context, req *types.SecretReq) (*database.Space, error) {
+	space, err := c.spaceStore.FindByPath(ctx, req.Namespace, req.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find space %s/%s, error: %w", req.Namespace, req.Name, err)
+	}
+	permission, err := c.repoComponent.GetUserRepoPermission(ctx, req.CurrentUser, space.Repository)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user permission of space %s/%s, error: %w", req.Namespace, req.Name, err)
+	}
+	if !permission.CanWrite {
+		return nil, errorx.ErrForbiddenMsg("users do not have permission to manage the secrets of this space")
+	}
+	return space, nil
+}
+
+// checkDeploy returns the deploy if the current user owns it, the secrets of a space deploy are managed on the space
+func (c *secretComponentImpl) checkDeploy(ctx context.Context, req *types.SecretReq) (*database.Deploy, error) {
+	deploy, err := c.deployTaskStore.GetDeployByID(ctx, req.DeployID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deploy %d, error: %w", req.DeployID, err)
+	}
+	_, err = checkOwnerOrOrgMemberPermission(ctx, c.userSvcClient, req.CurrentUser, deploy.UserUUID)
+	if err != nil {
+		return nil, errorx.Forbidden(err, errorx.Ctx().Set("deploy_id", req.DeployID))
+	}
+	if deploy.SpaceID > 0 {
+		return nil, errorx.ReqParamInvalid(errors.New("the secrets of a space deploy are managed on the space"),
+			errorx.Ctx().Set("deploy_id", req.DeployID))
+	}
+	return deploy, nil
+}
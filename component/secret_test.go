@@ -0,0 +1,114 @@
+package component
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	mockrpc "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/builder/rpc"
+	mocksecret "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/builder/secret"
+	mockdb "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/builder/store/database"
+	mockcomponent "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/component"
+	"opencsg.com/csghub-server/builder/rpc"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+	"opencsg.com/csghub-server/common/utils/cryptox"
+)
+
+type testSecretComponent struct {
+	*secretComponentImpl
+	secretManager   *mocksecret.MockManager
+	spaceStore      *mockdb.MockSpaceStore
+	deployTaskStore *mockdb.MockDeployTaskStore
+	repoComponent   *mockcomponent.MockRepoComponent
+	userSvcClient   *mockrpc.MockUserSvcClient
+}
+
+func newTestSecretComponent(t *testing.T) *testSecretComponent {
+	c := &testSecretComponent{
+		secretManager:   mocksecret.NewMockManager(t),
+		spaceStore:      mockdb.NewMockSpaceStore(t),
+		deployTaskStore: mockdb.NewMockDeployTaskStore(t),
+		repoComponent:   mockcomponent.NewMockRepoComponent(t),
+		userSvcClient:   mockrpc.NewMockUserSvcClient(t),
+	}
+	c.secretComponentImpl = &secretComponentImpl{
+		secretManager:   c.secretManager,
+		spaceStore:      c.spaceStore,
+		deployTaskStore: c.deployTaskStore,
+		repoComponent:   c.repoComponent,
+		userSvcClient:   c.userSvcClient,
+	}
+	return c
+}
+
+// newTestSecretKeyring returns a keyring with a fixed master key for the tests of the secrets
+func newTestSecretKeyring(t *testing.T) *cryptox.Keyring {
+	keyring, err := cryptox.NewKeyring(cryptox.KeyringConfig{
+		MasterKeys:    map[int][]byte{1: []byte("test-secret-master-key-000000001")},
+		ActiveVersion: 1,
+		Salt:          []byte("test-secret-salt-0000"),
+		InfoPrefix:    "test/",
+	})
+	require.NoError(t, err)
+	return keyring
+}
+
+func TestSecretComponent_SpaceSecrets(t *testing.T) {
+	ctx := context.TODO()
+	c := newTestSecretComponent(t)
+	space := &database.Space{ID: 1, Repository: &database.Repository{ID: 2}}
+	c.spaceStore.EXPECT().FindByPath(ctx, "ns", "n").Return(space, nil)
+	c.repoComponent.EXPECT().GetUserRepoPermission(ctx, "user", space.Repository).Return(&types.UserRepoPermission{CanRead: true, CanWrite: true}, nil)
+
+	c.secretManager.EXPECT().List(ctx, types.SecretOwnerSpace, int64(1)).Return([]types.Secret{{Key: "TOKEN", Value: "******"}}, nil)
+	secrets, err := c.ListSpaceSecrets(ctx, &types.SecretReq{CurrentUser: "user", Namespace: "ns", Name: "n"})
+	require.NoError(t, err)
+	require.Equal(t, []types.Secret{{Key: "TOKEN", Value: "******"}}, secrets)
+
+	c.secretManager.EXPECT().Set(ctx, types.SecretOwnerSpace, int64(1), "TOKEN", "abc").Return(&types.Secret{Key: "TOKEN", Value: "******"}, nil)
+	secret, err := c.SetSpaceSecret(ctx, &types.SecretReq{CurrentUser: "user", Namespace: "ns", Name: "n", Key: "TOKEN", Value: "abc"})
+	require.NoError(t, err)
+	require.Equal(t, "******", secret.Value)
+
+	c.secretManager.EXPECT().Delete(ctx, types.SecretOwnerSpace, int64(1), "TOKEN").Return(nil)
+	err = c.DeleteSpaceSecret(ctx, &types.SecretReq{CurrentUser: "user", Namespace: "ns", Name: "n", Key: "TOKEN"})
+	require.NoError(t, err)
+}
+
+func TestSecretComponent_SpaceSecretsForbidden(t *testing.T) {
+	ctx := context.TODO()
+	c := newTestSecretComponent(t)
+	space := &database.Space{ID: 1, Repository: &database.Repository{ID: 2}}
+	c.spaceStore.EXPECT().FindByPath(ctx, "ns", "n").Return(space, nil)
+	c.repoComponent.EXPECT().GetUserRepoPermission(ctx, "user", space.Repository).Return(&types.UserRepoPermission{CanRead: true}, nil)
+
+	_, err := c.ListSpaceSecrets(ctx, &types.SecretReq{CurrentUser: "user", Namespace: "ns", Name: "n"})
+	require.ErrorIs(t, err, errorx.ErrForbidden)
+}
+
+func TestSecretComponent_DeploySecrets(t *testing.T) {
+	ctx := context.TODO()
+	c := newTestSecretComponent(t)
+	c.deployTaskStore.EXPECT().GetDeployByID(ctx, int64(3)).Return(&database.Deploy{ID: 3, UserUUID: "uuid"}, nil)
+	c.userSvcClient.EXPECT().GetUserByName(ctx, "user").Return(&types.User{UUID: "uuid", Username: "user"}, nil)
+	c.userSvcClient.EXPECT().GetNameSpaceInfoByUUID(ctx, "uuid").Return(&rpc.Namespace{UUID: "uuid", NSType: "user"}, nil)
+
+	c.secretManager.EXPECT().Set(ctx, types.SecretOwnerDeploy, int64(3), "HF_TOKEN", "hf_0123456789abcdef").
+		Return(&types.Secret{Key: "HF_TOKEN", Value: "******cdef"}, nil)
+	secret, err := c.SetDeploySecret(ctx, &types.SecretReq{CurrentUser: "user", DeployID: 3, Key: "HF_TOKEN", Value: "hf_0123456789abcdef"})
+	require.NoError(t, err)
+	require.Equal(t, "******cdef", secret.Value)
+}
+
+func TestSecretComponent_DeploySecretsOfSpace(t *testing.T) {
+	ctx := context.TODO()
+	c := newTestSecretComponent(t)
+	c.deployTaskStore.EXPECT().GetDeployByID(ctx, int64(3)).Return(&database.Deploy{ID: 3, UserUUID: "uuid", SpaceID: 1}, nil)
+	c.userSvcClient.EXPECT().GetUserByName(ctx, "user").Return(&types.User{UUID: "uuid", Username: "user"}, nil)
+	c.userSvcClient.EXPECT().GetNameSpaceInfoByUUID(ctx, "uuid").Return(&rpc.Namespace{UUID: "uuid", NSType: "user"}, nil)
+
+	_, err := c.ListDeploySecrets(ctx, &types.SecretReq{CurrentUser: "user", DeployID: 3})
+	require.ErrorIs(t, err, errorx.ErrReqParamInvalid)
+}
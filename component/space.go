@@ -94,6 +94,15 @@ func (c *spaceComponentImpl) Create(ctx context.Context, req types.CreateSpaceRe
 	req.Nickname = nickname
 	req.RepoType = types.SpaceRepo
 	req.Readme = generateReadmeData(req.License)
+	secrets, err := parseSpaceSecrets(req.Secrets)
+	if err != nil {
+		return nil, err
+	}
+	// the secrets are saved after the space is created, so they are checked before creating anything
+	err = c.secretManager.Validate(secrets)
+	if err != nil {
+		return nil, err
+	}
 	resource, err := c.spaceResourceStore.FindByID(ctx, req.ResourceID)
 	if err != nil {
 		return nil, errorx.ErrResourceNotFound
@@ -133,7 +142,6 @@ func (c *spaceComponentImpl) Create(ctx context.Context, req types.CreateSpaceRe
 		CoverImageUrl: req.CoverImageUrl,
 		Env:           req.Env,
 		Hardware:      resource.Resources,
-		Secrets:       req.Secrets,
 		SKU:           strconv.FormatInt(resource.ID, 10),
 		Variables:     req.Variables,
 		Template:      req.Template,
@@ -145,9 +153,15 @@ func (c *spaceComponentImpl) Create(ctx context.Context, req types.CreateSpaceRe
 	repoPath := path.Join(req.Namespace, req.Name)
 	resSpace, err := c.spaceStore.CreateAndUpdateRepoPath(ctx, dbSpace, repoPath)
 	if err != nil {
-		slog.Error("failed to create new space in db", slog.Any("req", req), slog.String("error", err.Error()))
+		slog.Error("failed to create new space in db", slog.String("path", repoPath), slog.String("error", err.Error()))
 		return nil, fmt.Errorf("failed to create new space in db, error: %w", err)
 	}
+	if len(secrets) > 0 {
+		err = c.secretManager.Replace(ctx, types.SecretOwnerSpace, resSpace.ID, secrets)
+		if err != nil {
+			return nil, fmt.Errorf("failed to save space secrets, error: %w", err)
+		}
+	}
 	if commitFilesReq != nil {
 		_ = c.git.CommitFiles(ctx, *commitFilesReq)
 	}
@@ -156,7 +170,7 @@ func (c *spaceComponentImpl) Create(ctx context.Context, req types.CreateSpaceRe
 
 	err = c.createSpaceDefaultFiles(ctx, dbRepo, req, templatePath)
 	if err != nil {
-		slog.Error("failed to create new space default files", slog.Any("req", req), slog.Any("error", err))
+		slog.Error("failed to create new space default files", slog.String("path", repoPath), slog.Any("error", err))
 		return nil, errorx.ErrSpaceInitFailed
 	}
 
@@ -171,7 +185,7 @@ func (c *spaceComponentImpl) Create(ctx context.Context, req types.CreateSpaceRe
 		Template:      resSpace.Template,
 		Env:           req.Env,
 		Hardware:      resource.Resources,
-		Secrets:       req.Secrets,
+		Secrets:       maskSpaceSecrets(secrets),
 		Variables:     resSpace.Variables,
 		CoverImageUrl: resSpace.CoverImageUrl,
 		Endpoint:      "",
@@ -474,7 +488,7 @@ func (c *spaceComponentImpl) Show(ctx context.Context, namespace, name, currentU
 	}
 	if permission.CanWrite {
 		resSpace.Env = space.Env
-		resSpace.Secrets = space.Secrets
+		resSpace.Secrets = c.maskedSpaceSecrets(ctx, space)
 	}
 	if needOpWeight {
 		c.addOpWeightToSpaces(ctx, []int64{resSpace.RepositoryID}, []*types.Space{resSpace})
@@ -485,6 +499,18 @@ func (c *spaceComponentImpl) Show(ctx context.Context, namespace, name, currentU
 
 func (c *spaceComponentImpl) Update(ctx context.Context, req *types.UpdateSpaceReq) (*types.Space, error) {
 	req.RepoType = types.SpaceRepo
+	var secrets map[string]string
+	if req.Secrets != nil {
+		var err error
+		secrets, err = parseSpaceSecrets(*req.Secrets)
+		if err != nil {
+			return nil, err
+		}
+		err = c.secretManager.Validate(secrets)
+		if err != nil {
+			return nil, err
+		}
+	}
 	if req.ResourceID != nil {
 		resource, err := c.spaceResourceStore.FindByID(ctx, *req.ResourceID)
 		if err != nil {
@@ -514,6 +540,13 @@ func (c *spaceComponentImpl) Update(ctx context.Context, req *types.UpdateSpaceR
 		return nil, fmt.Errorf("failed to merge update space request, error: %w", err)
 	}
 
+	// the secrets are replaced first, so the plain secrets cleared by the merge are kept if it fails
+	if req.Secrets != nil {
+		err = c.secretManager.Replace(ctx, types.SecretOwnerSpace, space.ID, secrets)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update space secrets, error: %w", err)
+		}
+	}
 	err = c.spaceStore.Update(ctx, *space)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update database space, error: %w", err)
@@ -532,7 +565,7 @@ func (c *spaceComponentImpl) Update(ctx context.Context, req *types.UpdateSpaceR
 		Template:      space.Template,
 		Env:           space.Env,
 		Hardware:      space.Hardware,
-		Secrets:       space.Secrets,
+		Secrets:       c.maskedSpaceSecrets(ctx, space),
 		Variables:     space.Variables,
 		CoverImageUrl: space.CoverImageUrl,
 		License:       dbRepo.License,
@@ -697,12 +730,12 @@ func (c *spaceComponentImpl) convertReposToSpaces(ctx context.Context, repos []*
 			Name:          space.Repository.Name,
 			Nickname:      space.Repository.Nickname,
 			Description:   space.Repository.Description,
+			SearchSnippet: repo.SearchSnippet,
 			Path:          space.Repository.Path,
 			Sdk:           space.Sdk,
 			SdkVersion:    space.SdkVersion,
 			Template:      space.Template,
 			Hardware:      space.Hardware,
-			Secrets:       space.Secrets,
 			CoverImageUrl: space.CoverImageUrl,
 			License:       space.Repository.License,
 			Private:       space.Repository.Private,
@@ -865,7 +898,6 @@ func (c *spaceComponentImpl) ListByPath(ctx context.Context, paths []string) ([]
 			Template:      data.Template,
 			Env:           data.Env,
 			Hardware:      data.Hardware,
-			Secrets:       data.Secrets,
 			CoverImageUrl: data.CoverImageUrl,
 			License:       data.Repository.License,
 			Private:       data.Repository.Private,
@@ -1053,7 +1085,6 @@ func (c *spaceComponentImpl) Deploy(ctx context.Context, namespace, name, curren
 		Template:      space.Template,
 		Env:           space.Env,
 		Hardware:      space.Hardware,
-		Secret:        space.Secrets,
 		RepoID:        space.Repository.ID,
 		ModelID:       0,
 		UserID:        userID,
@@ -1363,7 +1394,8 @@ func (c *spaceComponentImpl) mergeUpdateSpaceRequest(ctx context.Context, space
 		space.Env = *req.Env
 	}
 	if req.Secrets != nil {
-		space.Secrets = *req.Secrets
+		// the secrets are saved encrypted apart from the space, the plain ones stored before are dropped
+		space.Secrets = ""
 	}
 	if req.Template != nil {
 		space.Template = *req.Template
@@ -1599,3 +1631,49 @@ const (
 	SpaceStatusNoNGINXConf = "NoNGINXConf"
 	ResourceUnhealthy      = "ResourceUnhealthy"
 )
+
+// parseSpaceSecrets parses the secrets of a space in the json object format
+func parseSpaceSecrets(secrets string) (map[string]string, error) {
+	values, err := common.JsonStrToMap(secrets)
+	if err != nil {
+		return nil, errorx.ReqParamInvalid(errors.New("secrets must be a json object of strings"), errorx.Ctx().Set("param", "secrets"))
+	}
+	return values, nil
+}
+
+func maskSpaceSecrets(values map[string]string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	masked := make(map[string]string, len(values))
+	for key, value := range values {
+		masked[key] = types.MaskSecret(value)
+	}
+	data, _ := json.Marshal(masked)
+	return string(data)
+}
+
+// maskedSpaceSecrets returns the masked secrets of the space in the json object format, including
+// the plain secrets stored before the encryption and not imported yet
+func (c *spaceComponentImpl) maskedSpaceSecrets(ctx context.Context, space *database.Space) string {
+	values, err := common.JsonStrToMap(space.Secrets)
+	if err != nil {
+		values = map[string]string{}
+	}
+	secrets, err := c.secretManager.List(ctx, types.SecretOwnerSpace, space.ID)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to list space secrets", slog.Int64("space_id", space.ID), slog.Any("error", err))
+	}
+	masked := make(map[string]string, len(values)+len(secrets))
+	for key, value := range values {
+		masked[key] = types.MaskSecret(value)
+	}
+	for _, secret := range secrets {
+		masked[secret.Key] = secret.Value
+	}
+	if len(masked) == 0 {
+		return ""
+	}
+	data, _ := json.Marshal(masked)
+	return string(data)
+}
@@ -10,6 +10,7 @@ import (
 	"opencsg.com/csghub-server/builder/git"
 	"opencsg.com/csghub-server/builder/git/gitserver"
 	"opencsg.com/csghub-server/builder/rpc"
+	"opencsg.com/csghub-server/builder/secret"
 	"opencsg.com/csghub-server/builder/store/database"
 	"opencsg.com/csghub-server/common/config"
 	"opencsg.com/csghub-server/common/types"
@@ -47,6 +48,10 @@ func NewSpaceComponent(config *config.Config) (SpaceComponent, error) {
 	}
 	c.templateStore = database.NewSpaceTemplateStore()
 	c.rfs = database.NewRuntimeFrameworksStore()
+	c.secretManager, err = secret.NewManager(config)
+	if err != nil {
+		return nil, err
+	}
 	return c, nil
 }
 
@@ -69,6 +74,7 @@ type spaceComponentImpl struct {
 	recomStore          database.RecomStore
 	templateStore       database.SpaceTemplateStore
 	rfs                 database.RuntimeFrameworksStore
+	secretManager       secret.Manager
 }
 
 func (c *spaceComponentImpl) checkResourcePurchasableForUpdate(ctx context.Context, req types.UpdateSpaceReq, resource *database.SpaceResource) error {
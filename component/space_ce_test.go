@@ -14,6 +14,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"opencsg.com/csghub-server/builder/git/gitserver"
 	"opencsg.com/csghub-server/builder/rpc"
+	"opencsg.com/csghub-server/builder/secret"
 	"opencsg.com/csghub-server/builder/store/database"
 	"opencsg.com/csghub-server/common/errorx"
 	"opencsg.com/csghub-server/common/types"
@@ -22,6 +23,7 @@ import (
 func TestSpaceComponent_Create(t *testing.T) {
 	ctx := context.TODO()
 	sc := initializeTestSpaceComponent(ctx, t)
+	sc.secretManager = secret.NewManagerWithStores(newTestSecretKeyring(t), sc.mocks.stores.ResourceSecret, sc.mocks.stores.Space)
 
 	sc.mocks.stores.SpaceResourceMock().EXPECT().FindByID(ctx, int64(1)).Return(&database.SpaceResource{
 		ID:        1,
@@ -68,10 +70,12 @@ func TestSpaceComponent_Create(t *testing.T) {
 		SdkVersion:   "v1",
 		Env:          "env",
 		Hardware:     `{"memory": "foo"}`,
-		Secrets:      "sss",
 		SKU:          "1",
 		ClusterID:    "cluster",
-	}, "ns/n").Return(&database.Space{}, nil)
+	}, "ns/n").Return(&database.Space{ID: 1}, nil)
+	sc.mocks.stores.ResourceSecretMock().EXPECT().ReplaceByOwner(ctx, types.SecretOwnerSpace, int64(1), mock.MatchedBy(func(secrets []database.ResourceSecret) bool {
+		return len(secrets) == 1 && secrets[0].Key == "TOKEN" && secrets[0].Ciphertext != "sss"
+	})).Return(nil)
 
 	commitReq := gitserver.CommitFilesReq{
 		Namespace: "ns",
@@ -106,7 +110,7 @@ func TestSpaceComponent_Create(t *testing.T) {
 		Sdk:        types.STREAMLIT.Name,
 		SdkVersion: "v1",
 		Env:        "env",
-		Secrets:    "sss",
+		Secrets:    `{"TOKEN":"sss"}`,
 		ResourceID: 1,
 		ClusterID:  "cluster",
 		CreateRepoReq: types.CreateRepoReq{
@@ -126,7 +130,7 @@ func TestSpaceComponent_Create(t *testing.T) {
 		Sdk:        "streamlit",
 		SdkVersion: "v1",
 		Env:        "env",
-		Secrets:    "sss",
+		Secrets:    `{"TOKEN":"******"}`,
 		Hardware:   `{"memory": "foo"}`,
 		Creator:    "user",
 		Path:       "ns/n",
@@ -169,6 +173,7 @@ func TestSpaceComponent_Update(t *testing.T) {
 		Hardware: `{"memory": "foo"}`,
 		SKU:      "12",
 	}).Return(nil)
+	sc.mocks.stores.ResourceSecretMock().EXPECT().ListByOwner(ctx, types.SecretOwnerSpace, int64(321)).Return(nil, nil)
 
 	space, err := sc.Update(ctx, &types.UpdateSpaceReq{
 		ResourceID: tea.Int64(12),
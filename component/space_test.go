@@ -16,6 +16,7 @@ import (
 	"opencsg.com/csghub-server/builder/deploy/common"
 	"opencsg.com/csghub-server/builder/git/gitserver"
 	"opencsg.com/csghub-server/builder/git/membership"
+	"opencsg.com/csghub-server/builder/secret"
 	"opencsg.com/csghub-server/builder/store/database"
 	"opencsg.com/csghub-server/common/errorx"
 	"opencsg.com/csghub-server/common/types"
@@ -1030,9 +1031,30 @@ func TestSpaceComponent_StatusByPaths(t *testing.T) {
 	})
 }
 
+func TestSpaceComponent_CreateInvalidSecrets(t *testing.T) {
+	ctx := context.TODO()
+	sc := initializeTestSpaceComponent(ctx, t)
+	sc.secretManager = secret.NewManagerWithStores(newTestSecretKeyring(t), sc.mocks.stores.ResourceSecret, sc.mocks.stores.Space)
+
+	// nothing is created when the secrets are invalid
+	_, err := sc.Create(ctx, types.CreateSpaceReq{
+		Sdk:        types.GRADIO.Name,
+		Secrets:    `{"1-TOKEN":"value"}`,
+		ResourceID: 1,
+		ClusterID:  "cluster",
+		CreateRepoReq: types.CreateRepoReq{
+			Namespace: "ns",
+			Name:      "n",
+			Username:  "user",
+		},
+	})
+	require.NotNil(t, err)
+}
+
 func TestSpaceComponent_CreateGradio(t *testing.T) {
 	ctx := context.TODO()
 	sc := initializeTestSpaceComponent(ctx, t)
+	sc.secretManager = secret.NewManagerWithStores(newTestSecretKeyring(t), sc.mocks.stores.ResourceSecret, sc.mocks.stores.Space)
 
 	sc.mocks.stores.SpaceResourceMock().EXPECT().FindByID(ctx, int64(1)).Return(&database.SpaceResource{
 		ID:        1,
@@ -1067,15 +1089,20 @@ func TestSpaceComponent_CreateGradio(t *testing.T) {
 		SdkVersion:   "6.2.0",
 		Env:          "env",
 		Hardware:     `{"memory": "foo"}`,
-		Secrets:      "sss",
 		SKU:          "1",
 		ClusterID:    "cluster",
 	}, "ns/n").Return(&database.Space{
+		ID:           1,
 		RepositoryID: 321,
 		Repository:   &database.Repository{ID: 321, Path: "ns/n"},
 	}, nil)
 
 	sc.mocks.gitServer.EXPECT().CommitFiles(ctx, gitserver.CommitFilesReq{}).Return(nil).Once()
+	// the secrets are saved encrypted apart from the space
+	sc.mocks.stores.ResourceSecretMock().EXPECT().ReplaceByOwner(ctx, types.SecretOwnerSpace, int64(1), mock.MatchedBy(func(secrets []database.ResourceSecret) bool {
+		return len(secrets) == 1 && secrets[0].Key == "HF_TOKEN" && secrets[0].KeyVersion == 1 &&
+			!strings.Contains(secrets[0].Ciphertext, "hf_0123456789abcdef")
+	})).Return(nil).Once()
 
 	sc.mocks.gitServer.EXPECT().CommitFiles(mock.Anything, mock.Anything).Return(nil)
 
@@ -1095,7 +1122,7 @@ func TestSpaceComponent_CreateGradio(t *testing.T) {
 		Sdk:        types.GRADIO.Name,
 		SdkVersion: "",
 		Env:        "env",
-		Secrets:    "sss",
+		Secrets:    `{"HF_TOKEN":"hf_0123456789abcdef"}`,
 		ResourceID: 1,
 		ClusterID:  "cluster",
 		CreateRepoReq: types.CreateRepoReq{
@@ -1115,7 +1142,7 @@ func TestSpaceComponent_CreateGradio(t *testing.T) {
 		Sdk:        "gradio",
 		SdkVersion: "6.2.0",
 		Env:        "env",
-		Secrets:    "sss",
+		Secrets:    `{"HF_TOKEN":"******cdef"}`,
 		Hardware:   `{"memory": "foo"}`,
 		Creator:    "user",
 		Path:       "ns/n",
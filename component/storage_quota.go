@@ -0,0 +1,282 @@
+package component
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"opencsg.com/csghub-server/builder/git/membership"
+	"opencsg.com/csghub-server/builder/rpc"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/config"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/types"
+)
+
+type StorageQuotaComponent interface {
+	GetQuota(ctx context.Context, req types.GetStorageQuotaReq) (*types.StorageQuota, error)
+	// SetQuota overrides the quota of a namespace, only admins are allowed
+	SetQuota(ctx context.Context, req types.SetStorageQuotaReq) (*types.StorageQuota, error)
+	// CheckQuota returns errorx.ErrGitStorageQuotaExceeded if storing extra bytes in the namespace exceeds its quota
+	CheckQuota(ctx context.Context, namespace string, incomingBytes int64) error
+	// RefreshUsage recalculates the storage usage of the namespace and warns the owner when it gets close to the quota
+	RefreshUsage(ctx context.Context, namespace string) error
+}
+
+type storageQuotaComponentImpl struct {
+	*storageQuotaChecker
+	config                *config.Config
+	userStore             database.UserStore
+	repoComponent         RepoComponent
+	notificationSvcClient rpc.NotificationSvcClient
+}
+
+func NewStorageQuotaComponent(config *config.Config) (StorageQuotaComponent, error) {
+	repoComponent, err := NewRepoComponent(config)
+	if err != nil {
+		return nil, err
+	}
+	return &storageQuotaComponentImpl{
+		storageQuotaChecker: newStorageQuotaChecker(config, database.NewNamespaceStorageQuotaStore(), database.NewNamespaceStore()),
+		config:              config,
+		userStore:           database.NewUserStore(),
+		repoComponent:       repoComponent,
+		notificationSvcClient: rpc.NewNotificationSvcHttpClient(fmt.Sprintf("%s:%d", config.Notification.Host, config.Notification.Port),
+			rpc.AuthWithApiKey(config.APIToken)),
+	}, nil
+}
+
+// storageQuotaChecker is shared by the components which write into repositories
+type storageQuotaChecker struct {
+	config         *config.Config
+	quotaStore     database.NamespaceStorageQuotaStore
+	namespaceStore database.NamespaceStore
+}
+
+func newStorageQuotaChecker(config *config.Config, quotaStore database.NamespaceStorageQuotaStore, namespaceStore database.NamespaceStore) *storageQuotaChecker {
+	return &storageQuotaChecker{
+		config:         config,
+		quotaStore:     quotaStore,
+		namespaceStore: namespaceStore,
+	}
+}
+
+// quotaBytes returns the effective quota of the namespace, 0 means unlimited
+func (c *storageQuotaChecker) quotaBytes(ns database.Namespace, quota *database.NamespaceStorageQuota) int64 {
+	if quota != nil && quota.QuotaBytes != nil {
+		return *quota.QuotaBytes
+	}
+	if ns.NamespaceType == database.OrgNamespace {
+		return c.config.StorageQuota.DefaultOrgQuota
+	}
+	return c.config.StorageQuota.DefaultUserQuota
+}
+
+// findQuota returns the quota record of the namespace, the record is nil if the usage was never calculated
+func (c *storageQuotaChecker) findQuota(ctx context.Context, namespace string) (database.Namespace, *database.NamespaceStorageQuota, error) {
+	ns, err := c.namespaceStore.FindByPath(ctx, namespace)
+	if err != nil {
+		return ns, nil, fmt.Errorf("failed to find namespace '%s', error: %w", namespace, err)
+	}
+	quota, err := c.quotaStore.FindByNamespace(ctx, ns.Path)
+	if err != nil {
+		if errors.Is(err, errorx.ErrDatabaseNoRows) {
+			return ns, nil, nil
+		}
+		return ns, nil, fmt.Errorf("failed to find storage quota of namespace '%s', error: %w", namespace, err)
+	}
+	return ns, quota, nil
+}
+
+// CheckQuota rejects when the usage plus the incoming bytes is over the quota,
+// so a namespace already over its quota is rejected even if the incoming size is unknown
+func (c *storageQuotaChecker) CheckQuota(ctx context.Context, namespace string, incomingBytes int64) error {
+	if !c.config.StorageQuota.Enable {
+		return nil
+	}
+	ns, quota, err := c.findQuota(ctx, namespace)
+	if err != nil {
+		return err
+	}
+	quotaBytes := c.quotaBytes(ns, quota)
+	if quotaBytes <= 0 {
+		return nil
+	}
+
+	// the usage counters are refreshed by the push callback, they are recalculated here only if the usage
+	// was never calculated or before rejecting, as they may be stale after a push was rejected
+	if quota == nil || quota.UsedBytes()+max(incomingBytes, 0) > quotaBytes {
+		quota, err = c.refreshCounters(ctx, ns, quota)
+		if err != nil {
+			return err
+		}
+	}
+	usedBytes := quota.UsedBytes()
+	if usedBytes+max(incomingBytes, 0) > quotaBytes {
+		return errorx.StorageQuotaExceeded(errorx.Ctx().
+			Set("namespace", ns.Path).
+			Set("used_bytes", usedBytes).
+			Set("quota_bytes", quotaBytes))
+	}
+	return nil
+}
+
+// refreshCounters recalculates and saves the usage counters of the namespace, the warned percent is kept
+func (c *storageQuotaChecker) refreshCounters(ctx context.Context, ns database.Namespace, quota *database.NamespaceStorageQuota) (*database.NamespaceStorageQuota, error) {
+	if quota == nil {
+		quota = &database.NamespaceStorageQuota{Namespace: ns.Path}
+	}
+	var err error
+	quota.GitBytes, quota.LfsBytes, err = c.quotaStore.CalculateUsage(ctx, ns.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate storage usage of namespace '%s', error: %w", ns.Path, err)
+	}
+	err = c.quotaStore.UpdateUsage(ctx, quota)
+	if err != nil {
+		return nil, err
+	}
+	return quota, nil
+}
+
+// refreshUsage recalculates the usage counters of the namespace after its repositories were deleted
+func (c *storageQuotaChecker) refreshUsage(ctx context.Context, namespace string) error {
+	if !c.config.StorageQuota.Enable {
+		return nil
+	}
+	ns, quota, err := c.findQuota(ctx, namespace)
+	if err != nil {
+		return err
+	}
+	_, err = c.refreshCounters(ctx, ns, quota)
+	return err
+}
+
+func (c *storageQuotaComponentImpl) GetQuota(ctx context.Context, req types.GetStorageQuotaReq) (*types.StorageQuota, error) {
+	allowed, err := c.repoComponent.CheckCurrentUserPermission(ctx, req.CurrentUser, req.Namespace, membership.RoleRead)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check permission, error: %w", err)
+	}
+	if !allowed {
+		return nil, errorx.ErrForbidden
+	}
+	ns, quota, err := c.findQuota(ctx, req.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	if quota == nil {
+		quota = &database.NamespaceStorageQuota{Namespace: ns.Path}
+	}
+	quota.GitBytes, quota.LfsBytes, err = c.quotaStore.CalculateUsage(ctx, ns.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate storage usage of namespace '%s', error: %w", ns.Path, err)
+	}
+	return c.toStorageQuota(ns, quota), nil
+}
+
+func (c *storageQuotaComponentImpl) SetQuota(ctx context.Context, req types.SetStorageQuotaReq) (*types.StorageQuota, error) {
+	user, err := c.userStore.FindByUsername(ctx, req.CurrentUser)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user, error: %w", err)
+	}
+	if !user.CanAdmin() {
+		return nil, errorx.ErrForbiddenMsg("only admin can set storage quota")
+	}
+	ns, err := c.namespaceStore.FindByPath(ctx, req.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find namespace '%s', error: %w", req.Namespace, err)
+	}
+	quota, err := c.quotaStore.SetQuota(ctx, ns.Path, req.QuotaBytes)
+	if err != nil {
+		return nil, err
+	}
+	return c.toStorageQuota(ns, quota), nil
+}
+
+func (c *storageQuotaComponentImpl) RefreshUsage(ctx context.Context, namespace string) error {
+	ns, quota, err := c.findQuota(ctx, namespace)
+	if err != nil {
+		return err
+	}
+	if quota == nil {
+		quota = &database.NamespaceStorageQuota{Namespace: ns.Path}
+	}
+	quota.GitBytes, quota.LfsBytes, err = c.quotaStore.CalculateUsage(ctx, ns.Path)
+	if err != nil {
+		return fmt.Errorf("failed to calculate storage usage of namespace '%s', error: %w", ns.Path, err)
+	}
+
+	var warnPercent int
+	quotaBytes := c.quotaBytes(ns, quota)
+	if c.config.StorageQuota.Enable && quotaBytes > 0 {
+		for _, p := range types.StorageQuotaWarnPercents {
+			if quota.UsedBytes()*100 >= quotaBytes*int64(p) {
+				warnPercent = p
+			}
+		}
+	}
+	// warn only once per level, and again after the usage dropped below it
+	if warnPercent > quota.WarnedPercent {
+		err = c.sendQuotaWarning(ctx, ns, quota, quotaBytes, warnPercent)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to send storage quota warning", slog.String("namespace", ns.Path), slog.Any("error", err))
+		}
+	}
+	quota.WarnedPercent = warnPercent
+	return c.quotaStore.UpdateUsage(ctx, quota)
+}
+
+func (c *storageQuotaComponentImpl) sendQuotaWarning(ctx context.Context, ns database.Namespace, quota *database.NamespaceStorageQuota, quotaBytes int64, percent int) error {
+	// the namespace user is the owner of a personal namespace or the creator of an organization
+	if ns.User.UUID == "" {
+		return fmt.Errorf("no owner found for namespace '%s'", ns.Path)
+	}
+	msg := types.NotificationMessage{
+		UserUUIDs:        []string{ns.User.UUID},
+		NotificationType: types.NotificationAssetManagement,
+		Template:         string(types.MessageScenarioStorageQuota),
+		Payload: map[string]any{
+			"namespace":   ns.Path,
+			"percent":     percent,
+			"used_bytes":  quota.UsedBytes(),
+			"quota_bytes": quotaBytes,
+		},
+	}
+	msgBytes, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message, err: %w", err)
+	}
+	notificationMsg := types.MessageRequest{
+		Scenario:   types.MessageScenarioStorageQuota,
+		Parameters: string(msgBytes),
+		Priority:   types.MessagePriorityHigh,
+	}
+
+	var sendErr error
+	retryCount := c.config.Notification.NotificationRetryCount
+	for i := range retryCount {
+		if sendErr = c.notificationSvcClient.Send(ctx, &notificationMsg); sendErr == nil {
+			break
+		}
+		if i < retryCount-1 {
+			slog.Warn("failed to send notification, retrying", "notification_msg", notificationMsg, "attempt", i+1, "error", sendErr.Error())
+		}
+	}
+	if sendErr != nil {
+		return fmt.Errorf("failed to send notification after %d attempts, err: %w", retryCount, sendErr)
+	}
+	return nil
+}
+
+func (c *storageQuotaComponentImpl) toStorageQuota(ns database.Namespace, quota *database.NamespaceStorageQuota) *types.StorageQuota {
+	return &types.StorageQuota{
+		Namespace:    ns.Path,
+		QuotaBytes:   c.quotaBytes(ns, quota),
+		IsOverridden: quota.QuotaBytes != nil,
+		UsedBytes:    quota.UsedBytes(),
+		GitBytes:     quota.GitBytes,
+		LfsBytes:     quota.LfsBytes,
+		UpdatedAt:    quota.UpdatedAt,
+	}
+}
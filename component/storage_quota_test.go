@@ -0,0 +1,269 @@
+package component
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	mockrpc "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/builder/rpc"
+	mockcomponent "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/component"
+	"opencsg.com/csghub-server/builder/git/membership"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/errorx"
+	"opencsg.com/csghub-server/common/tests"
+	"opencsg.com/csghub-server/common/types"
+)
+
+type testStorageQuotaWithMocks struct {
+	*storageQuotaComponentImpl
+	stores       *tests.MockStores
+	repo         *mockcomponent.MockRepoComponent
+	notification *mockrpc.MockNotificationSvcClient
+}
+
+func initializeTestStorageQuotaComponent(t *testing.T) *testStorageQuotaWithMocks {
+	config := ProvideTestConfig()
+	config.StorageQuota.Enable = true
+	config.StorageQuota.DefaultUserQuota = 1000
+	config.StorageQuota.DefaultOrgQuota = 5000
+	config.Notification.NotificationRetryCount = 1
+	stores := tests.NewMockStores(t)
+	repo := mockcomponent.NewMockRepoComponent(t)
+	notification := mockrpc.NewMockNotificationSvcClient(t)
+	return &testStorageQuotaWithMocks{
+		storageQuotaComponentImpl: &storageQuotaComponentImpl{
+			storageQuotaChecker:   newStorageQuotaChecker(config, stores.NamespaceStorageQuota, stores.Namespace),
+			config:                config,
+			userStore:             stores.User,
+			repoComponent:         repo,
+			notificationSvcClient: notification,
+		},
+		stores:       stores,
+		repo:         repo,
+		notification: notification,
+	}
+}
+
+func TestStorageQuotaComponent_CheckQuota(t *testing.T) {
+	ctx := context.TODO()
+	unlimited := int64(0)
+
+	cases := []struct {
+		name          string
+		namespace     database.Namespace
+		quota         *database.NamespaceStorageQuota
+		incomingBytes int64
+		exceeded      bool
+	}{
+		{
+			name:          "user default quota",
+			namespace:     database.Namespace{Path: "ns", NamespaceType: database.UserNamespace},
+			quota:         &database.NamespaceStorageQuota{GitBytes: 100, LfsBytes: 800},
+			incomingBytes: 100,
+		},
+		{
+			name:          "user default quota exceeded",
+			namespace:     database.Namespace{Path: "ns", NamespaceType: database.UserNamespace},
+			quota:         &database.NamespaceStorageQuota{GitBytes: 100, LfsBytes: 800},
+			incomingBytes: 101,
+			exceeded:      true,
+		},
+		{
+			name:          "org default quota",
+			namespace:     database.Namespace{Path: "ns", NamespaceType: database.OrgNamespace},
+			quota:         &database.NamespaceStorageQuota{GitBytes: 100, LfsBytes: 800},
+			incomingBytes: 4000,
+		},
+		{
+			name:          "overridden as unlimited",
+			namespace:     database.Namespace{Path: "ns", NamespaceType: database.UserNamespace},
+			quota:         &database.NamespaceStorageQuota{QuotaBytes: &unlimited, LfsBytes: 1 << 40},
+			incomingBytes: 1 << 40,
+		},
+		{
+			name:      "already over quota",
+			namespace: database.Namespace{Path: "ns", NamespaceType: database.UserNamespace},
+			quota:     &database.NamespaceStorageQuota{LfsBytes: 1001},
+			exceeded:  true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			sc := initializeTestStorageQuotaComponent(t)
+			sc.stores.NamespaceMock().EXPECT().FindByPath(ctx, "ns").Return(c.namespace, nil)
+			sc.stores.NamespaceStorageQuotaMock().EXPECT().FindByNamespace(ctx, "ns").Return(c.quota, nil)
+			if c.exceeded {
+				// the counters are recalculated before rejecting
+				sc.stores.NamespaceStorageQuotaMock().EXPECT().CalculateUsage(ctx, "ns").Return(c.quota.GitBytes, c.quota.LfsBytes, nil)
+				sc.stores.NamespaceStorageQuotaMock().EXPECT().UpdateUsage(ctx, c.quota).Return(nil)
+			}
+
+			err := sc.CheckQuota(ctx, "ns", c.incomingBytes)
+			if c.exceeded {
+				require.ErrorIs(t, err, errorx.ErrGitStorageQuotaExceeded)
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestStorageQuotaComponent_CheckQuotaWithoutUsage(t *testing.T) {
+	ctx := context.TODO()
+	sc := initializeTestStorageQuotaComponent(t)
+
+	sc.stores.NamespaceMock().EXPECT().FindByPath(ctx, "ns").Return(database.Namespace{
+		Path: "ns", NamespaceType: database.UserNamespace,
+	}, nil)
+	sc.stores.NamespaceStorageQuotaMock().EXPECT().FindByNamespace(ctx, "ns").Return(nil, errorx.ErrDatabaseNoRows)
+	sc.stores.NamespaceStorageQuotaMock().EXPECT().CalculateUsage(ctx, "ns").Return(int64(500), int64(400), nil)
+	sc.stores.NamespaceStorageQuotaMock().EXPECT().UpdateUsage(ctx, &database.NamespaceStorageQuota{
+		Namespace: "ns", GitBytes: 500, LfsBytes: 400,
+	}).Return(nil)
+
+	err := sc.CheckQuota(ctx, "ns", 200)
+	require.ErrorIs(t, err, errorx.ErrGitStorageQuotaExceeded)
+}
+
+func TestStorageQuotaComponent_CheckQuotaStaleUsage(t *testing.T) {
+	ctx := context.TODO()
+	sc := initializeTestStorageQuotaComponent(t)
+
+	sc.stores.NamespaceMock().EXPECT().FindByPath(ctx, "ns").Return(database.Namespace{
+		Path: "ns", NamespaceType: database.UserNamespace,
+	}, nil)
+	// the recorded usage includes the objects of a rejected push
+	sc.stores.NamespaceStorageQuotaMock().EXPECT().FindByNamespace(ctx, "ns").Return(&database.NamespaceStorageQuota{
+		Namespace: "ns", LfsBytes: 1001,
+	}, nil)
+	sc.stores.NamespaceStorageQuotaMock().EXPECT().CalculateUsage(ctx, "ns").Return(int64(100), int64(0), nil)
+	sc.stores.NamespaceStorageQuotaMock().EXPECT().UpdateUsage(ctx, &database.NamespaceStorageQuota{
+		Namespace: "ns", GitBytes: 100,
+	}).Return(nil)
+
+	err := sc.CheckQuota(ctx, "ns", 200)
+	require.Nil(t, err)
+}
+
+func TestStorageQuotaComponent_CheckQuotaDisabled(t *testing.T) {
+	sc := initializeTestStorageQuotaComponent(t)
+	sc.config.StorageQuota.Enable = false
+
+	err := sc.CheckQuota(context.TODO(), "ns", 1<<40)
+	require.Nil(t, err)
+}
+
+func TestStorageQuotaComponent_refreshUsage(t *testing.T) {
+	ctx := context.TODO()
+	sc := initializeTestStorageQuotaComponent(t)
+
+	// the usage drops after a repository was deleted, the warned percent is kept for the push callback
+	sc.stores.NamespaceMock().EXPECT().FindByPath(ctx, "ns").Return(database.Namespace{
+		Path: "ns", NamespaceType: database.UserNamespace,
+	}, nil)
+	sc.stores.NamespaceStorageQuotaMock().EXPECT().FindByNamespace(ctx, "ns").Return(&database.NamespaceStorageQuota{
+		Namespace: "ns", LfsBytes: 900, WarnedPercent: 90,
+	}, nil)
+	sc.stores.NamespaceStorageQuotaMock().EXPECT().CalculateUsage(ctx, "ns").Return(int64(10), int64(20), nil)
+	sc.stores.NamespaceStorageQuotaMock().EXPECT().UpdateUsage(ctx, &database.NamespaceStorageQuota{
+		Namespace: "ns", GitBytes: 10, LfsBytes: 20, WarnedPercent: 90,
+	}).Return(nil)
+
+	err := sc.refreshUsage(ctx, "ns")
+	require.Nil(t, err)
+}
+
+func TestStorageQuotaComponent_RefreshUsage(t *testing.T) {
+	ctx := context.TODO()
+
+	cases := []struct {
+		name          string
+		warnedPercent int
+		lfsBytes      int64
+		notifyPercent int
+		warnPercent   int
+	}{
+		{name: "below warning", lfsBytes: 700},
+		{name: "reach 80%", lfsBytes: 850, notifyPercent: 80, warnPercent: 80},
+		{name: "already warned", warnedPercent: 80, lfsBytes: 900, warnPercent: 80},
+		{name: "reach 95%", warnedPercent: 80, lfsBytes: 950, notifyPercent: 95, warnPercent: 95},
+		{name: "drop below warning", warnedPercent: 95, lfsBytes: 500},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			sc := initializeTestStorageQuotaComponent(t)
+			sc.stores.NamespaceMock().EXPECT().FindByPath(ctx, "ns").Return(database.Namespace{
+				Path: "ns", NamespaceType: database.UserNamespace, User: database.User{UUID: "uuid"},
+			}, nil)
+			sc.stores.NamespaceStorageQuotaMock().EXPECT().FindByNamespace(ctx, "ns").Return(&database.NamespaceStorageQuota{
+				Namespace: "ns", WarnedPercent: c.warnedPercent,
+			}, nil)
+			sc.stores.NamespaceStorageQuotaMock().EXPECT().CalculateUsage(ctx, "ns").Return(int64(0), c.lfsBytes, nil)
+			if c.notifyPercent > 0 {
+				sc.notification.EXPECT().Send(ctx, mock.MatchedBy(func(req *types.MessageRequest) bool {
+					var msg types.NotificationMessage
+					if err := json.Unmarshal([]byte(req.Parameters), &msg); err != nil {
+						return false
+					}
+					return req.Scenario == types.MessageScenarioStorageQuota &&
+						msg.UserUUIDs[0] == "uuid" &&
+						msg.Payload["percent"] == float64(c.notifyPercent)
+				})).Return(nil)
+			}
+			sc.stores.NamespaceStorageQuotaMock().EXPECT().UpdateUsage(ctx, &database.NamespaceStorageQuota{
+				Namespace: "ns", LfsBytes: c.lfsBytes, WarnedPercent: c.warnPercent,
+			}).Return(nil)
+
+			err := sc.RefreshUsage(ctx, "ns")
+			require.Nil(t, err)
+		})
+	}
+}
+
+func TestStorageQuotaComponent_GetQuota(t *testing.T) {
+	ctx := context.TODO()
+	sc := initializeTestStorageQuotaComponent(t)
+
+	sc.repo.EXPECT().CheckCurrentUserPermission(ctx, "user", "org", membership.RoleRead).Return(true, nil)
+	sc.stores.NamespaceMock().EXPECT().FindByPath(ctx, "org").Return(database.Namespace{
+		Path: "org", NamespaceType: database.OrgNamespace,
+	}, nil)
+	sc.stores.NamespaceStorageQuotaMock().EXPECT().FindByNamespace(ctx, "org").Return(&database.NamespaceStorageQuota{
+		Namespace: "org", GitBytes: 10, LfsBytes: 20,
+	}, nil)
+	sc.stores.NamespaceStorageQuotaMock().EXPECT().CalculateUsage(ctx, "org").Return(int64(100), int64(200), nil)
+
+	quota, err := sc.GetQuota(ctx, types.GetStorageQuotaReq{CurrentUser: "user", Namespace: "org"})
+	require.Nil(t, err)
+	require.Equal(t, &types.StorageQuota{
+		Namespace: "org", QuotaBytes: 5000, UsedBytes: 300, GitBytes: 100, LfsBytes: 200,
+	}, quota)
+}
+
+func TestStorageQuotaComponent_SetQuota(t *testing.T) {
+	ctx := context.TODO()
+	sc := initializeTestStorageQuotaComponent(t)
+	quotaBytes := int64(2000)
+
+	sc.stores.UserMock().EXPECT().FindByUsername(ctx, "user").Return(database.User{}, nil).Once()
+	_, err := sc.SetQuota(ctx, types.SetStorageQuotaReq{CurrentUser: "user", Namespace: "ns", QuotaBytes: &quotaBytes})
+	require.ErrorIs(t, err, errorx.ErrForbidden)
+
+	sc.stores.UserMock().EXPECT().FindByUsername(ctx, "admin").Return(database.User{RoleMask: "admin"}, nil)
+	sc.stores.NamespaceMock().EXPECT().FindByPath(ctx, "ns").Return(database.Namespace{
+		Path: "ns", NamespaceType: database.UserNamespace,
+	}, nil)
+	sc.stores.NamespaceStorageQuotaMock().EXPECT().SetQuota(ctx, "ns", &quotaBytes).Return(&database.NamespaceStorageQuota{
+		Namespace: "ns", QuotaBytes: &quotaBytes, LfsBytes: 1500,
+	}, nil)
+
+	quota, err := sc.SetQuota(ctx, types.SetStorageQuotaReq{CurrentUser: "admin", Namespace: "ns", QuotaBytes: &quotaBytes})
+	require.Nil(t, err)
+	require.Equal(t, int64(2000), quota.QuotaBytes)
+	require.True(t, quota.IsOverridden)
+	require.Equal(t, int64(1500), quota.UsedBytes)
+}
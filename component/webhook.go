@@ -51,6 +51,16 @@ func NewWebHookComponent(config *config.Config, mqFactory bldmq.MessageQueueFact
 		return nil, fmt.Errorf("failed to create evaluation executor error: %w", err)
 	}
 
+	// publish finetune results once jobs finish
+	finetune, err := NewFinetuneComponent(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create finetune component error: %w", err)
+	}
+	err = executors.RegisterWorkflowFinishedHandler(types.TaskTypeFinetune, finetune.HandleFinetuneFinished)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register finetune finished handler error: %w", err)
+	}
+
 	// init kservice executor
 	_, err = executors.NewKServiceExecutor(config)
 	if err != nil {
@@ -1184,9 +1184,9 @@ func initializeTestRecomComponent(ctx context.Context, t interface {
 	config := ProvideTestConfig()
 	mockStores := tests.NewMockStores(t)
 	mockGitServer := gitserver.NewMockGitServer(t)
-	componentRecomComponentImpl := NewTestRecomComponent(config, mockStores, mockGitServer)
-	mockAccountingComponent := component.NewMockAccountingComponent(t)
 	mockRepoComponent := component.NewMockRepoComponent(t)
+	componentRecomComponentImpl := NewTestRecomComponent(config, mockStores, mockGitServer, mockRepoComponent)
+	mockAccountingComponent := component.NewMockAccountingComponent(t)
 	mockTagComponent := component.NewMockTagComponent(t)
 	mockSpaceComponent := component.NewMockSpaceComponent(t)
 	mockRuntimeArchitectureComponent := component.NewMockRuntimeArchitectureComponent(t)
@@ -1568,7 +1568,8 @@ func initializeTestRepoFileComponent(ctx context.Context, t interface {
 	config := ProvideTestConfig()
 	mockStores := tests.NewMockStores(t)
 	mockGitServer := gitserver.NewMockGitServer(t)
-	componentRepoFileComponentImpl := NewTestRepoFileComponent(config, mockStores, mockGitServer)
+	mockUserSvcClient := rpc.NewMockUserSvcClient(t)
+	componentRepoFileComponentImpl := NewTestRepoFileComponent(config, mockStores, mockGitServer, mockUserSvcClient)
 	mockAccountingComponent := component.NewMockAccountingComponent(t)
 	mockRepoComponent := component.NewMockRepoComponent(t)
 	mockTagComponent := component.NewMockTagComponent(t)
@@ -1585,7 +1586,6 @@ func initializeTestRepoFileComponent(ctx context.Context, t interface {
 		sensitive:           mockSensitiveComponent,
 		cluster:             mockClusterComponent,
 	}
-	mockUserSvcClient := rpc.NewMockUserSvcClient(t)
 	mockXnetSvcClient := rpc.NewMockXnetSvcClient(t)
 	mockClient := s3.NewMockClient(t)
 	mockDeployer := deploy.NewMockDeployer(t)
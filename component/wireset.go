@@ -33,6 +33,7 @@ import (
 	"opencsg.com/csghub-server/builder/store/s3"
 	"opencsg.com/csghub-server/common/config"
 	"opencsg.com/csghub-server/common/tests"
+	"opencsg.com/csghub-server/common/types"
 	"opencsg.com/csghub-server/mirror/cache"
 )
 
@@ -267,6 +268,8 @@ func NewTestModelComponent(
 		clusterComponent:          clusterComponent,
 		inferenceArchStore:        stores.InferenceArch,
 		metadataStore:             stores.Metadata,
+		inferenceRolloutStore:     stores.InferenceRollout,
+		credentialStore:           stores.Credential,
 	}
 }
 
@@ -308,6 +311,7 @@ func NewTestGitHTTPComponent(
 		s3Core:             s3Core,
 		mirrorStore:        stores.Mirror,
 		xnetClient:         xnetClient,
+		storageQuota:       newStorageQuotaChecker(config, stores.NamespaceStorageQuota, stores.Namespace),
 	}
 }
 
@@ -317,9 +321,11 @@ func NewTestDiscussionComponent(
 	stores *tests.MockStores,
 ) *discussionComponentImpl {
 	return &discussionComponentImpl{
-		repoStore:       stores.Repo,
-		userStore:       stores.User,
-		discussionStore: stores.Discussion,
+		repoStore:            stores.Repo,
+		userStore:            stores.User,
+		discussionStore:      stores.Discussion,
+		discussionLabelStore: stores.DiscussionLabel,
+		feedEventStore:       stores.FeedEvent,
 	}
 }
 
@@ -357,6 +363,7 @@ func NewTestMirrorComponent(config *config.Config, stores *tests.MockStores, rep
 		userStore:                   stores.User,
 		config:                      config,
 		mirrorNamespaceMappingStore: stores.MirrorNamespaceMapping,
+		credentialStore:             stores.Credential,
 	}
 }
 
@@ -498,12 +505,14 @@ func NewTestTagComponent(config *config.Config, stores *tests.MockStores, sensit
 
 var TagComponentSet = wire.NewSet(NewTestTagComponent)
 
-func NewTestRecomComponent(config *config.Config, stores *tests.MockStores, gitServer gitserver.GitServer) *recomComponentImpl {
+func NewTestRecomComponent(config *config.Config, stores *tests.MockStores, gitServer gitserver.GitServer, repoComponent RepoComponent) *recomComponentImpl {
 	return &recomComponentImpl{
-		recomStore: stores.Recom,
-		userStore:  stores.User,
-		repoStore:  stores.Repo,
-		gitServer:  gitServer,
+		recomStore:     stores.Recom,
+		userStore:      stores.User,
+		repoStore:      stores.Repo,
+		userLikesStore: stores.UserLikes,
+		gitServer:      gitServer,
+		repoComponent:  repoComponent,
 	}
 }
 
@@ -581,11 +590,12 @@ func NewTestHFDatasetComponent(config *config.Config, stores *tests.MockStores,
 
 var HFDatasetComponentSet = wire.NewSet(NewTestHFDatasetComponent)
 
-func NewTestRepoFileComponent(config *config.Config, stores *tests.MockStores, gitServer gitserver.GitServer) *repoFileComponentImpl {
+func NewTestRepoFileComponent(config *config.Config, stores *tests.MockStores, gitServer gitserver.GitServer, userSvcClient rpc.UserSvcClient) *repoFileComponentImpl {
 	return &repoFileComponentImpl{
 		repoFileStore: stores.RepoFile,
 		repoStore:     stores.Repo,
 		gitServer:     gitServer,
+		userSvcClient: userSvcClient,
 	}
 }
 
@@ -648,11 +658,15 @@ func NewTestLicenseComponent(config *config.Config, stores *tests.MockStores, ke
 
 var LicenseComponentSet = wire.NewSet(NewTestLicenseComponent)
 
-func NewTestImportComponent(config *config.Config, stores *tests.MockStores, importer importer.Importer) *importComponentImpl {
+func NewTestImportComponent(config *config.Config, stores *tests.MockStores, imp importer.Importer) *importComponentImpl {
 	return &importComponentImpl{
-		userStore:         stores.User,
-		repoStore:         stores.Repo,
-		importer:          importer,
+		userStore:             stores.User,
+		repoStore:             stores.Repo,
+		tagStore:              stores.Tag,
+		repositoryImportStore: stores.RepositoryImport,
+		newImporter: func(provider types.ImportProvider) (importer.Importer, error) {
+			return imp, nil
+		},
 		mirrorSourceStore: stores.MirrorSource,
 	}
 }
@@ -697,6 +711,7 @@ func NewTestMCPScannerComponent(config *config.Config, stores *tests.MockStores,
 		llmConfigStore: stores.LLMConfig,
 		promptStore:    stores.PromptPrefix,
 		gitServer:      gitServer,
+		reportLevel:    types.LevelCritical,
 	}
 }
 
@@ -733,6 +748,7 @@ func NewTestNotebookComponent(
 		deployTaskStore:        stores.DeployTask,
 		spaceResourceStore:     stores.SpaceResource,
 		runtimeFrameworksStore: stores.RuntimeFramework,
+		credentialStore:        stores.Credential,
 	}
 }
 
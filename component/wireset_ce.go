@@ -20,6 +20,7 @@ import (
 	"opencsg.com/csghub-server/builder/git/gitserver"
 	"opencsg.com/csghub-server/builder/multisync"
 	"opencsg.com/csghub-server/builder/rpc"
+	"opencsg.com/csghub-server/builder/secret"
 	"opencsg.com/csghub-server/builder/store/s3"
 	"opencsg.com/csghub-server/common/config"
 	"opencsg.com/csghub-server/common/tests"
@@ -84,6 +85,7 @@ func NewTestSpaceComponent(
 		userSvcClient:       userSvcClient,
 		deployTaskStore:     stores.DeployTask,
 		rfs:                 stores.RuntimeFramework,
+		secretManager:       secret.NewManagerWithStores(nil, stores.ResourceSecret, stores.Space),
 	}
 }
 
@@ -137,10 +139,19 @@ func NewTestRepoComponent(config *config.Config, stores *tests.MockStores, rpcUs
 		accountSyncQuotaStatementStore: stores.AccountSyncQuotaStatement,
 		accountPriceStore:              stores.AccountPrice,
 		recomStore:                     stores.Recom,
+		feedEventStore:                 stores.FeedEvent,
+		metadataStore:                  stores.Metadata,
+		releaseStore:                   stores.Release,
+		followStore:                    stores.Follow,
+		repoAccessStore:                stores.RepoAccess,
+		userLikesStore:                 stores.UserLikes,
 		xnetClient:                     xnetClient,
 		clusterComponent:               clusterComponent,
 		repoStatisticsStore:            stores.RepositoryStatistics,
 		modelStore:                     stores.Model,
 		tagStore:                       stores.Tag,
+		modelFileScanStore:             stores.ModelFileScan,
+		inferenceRolloutStore:          stores.InferenceRollout,
+		storageQuota:                   newStorageQuotaChecker(config, stores.NamespaceStorageQuota, stores.Namespace),
 	}
 }
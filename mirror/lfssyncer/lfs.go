@@ -21,6 +21,7 @@ import (
 	"go.temporal.io/sdk/client"
 	"golang.org/x/sync/errgroup"
 	"opencsg.com/csghub-server/api/workflow"
+	"opencsg.com/csghub-server/builder/credential"
 	"opencsg.com/csghub-server/builder/git"
 	"opencsg.com/csghub-server/builder/git/gitserver"
 	"opencsg.com/csghub-server/builder/git/gitserver/gitaly"
@@ -78,6 +79,7 @@ type LfsSyncWorker struct {
 	mu                 sync.Mutex
 	httpClient         *http.Client
 	msgSender          hook.MessageSender
+	credentialBroker   credential.Broker
 	git                gitserver.GitServer
 	workflowClient     temporal.Client
 }
@@ -252,6 +254,10 @@ func NewLfsSyncWorker(config *config.Config) (*LfsSyncWorker, error) {
 		return nil, newError
 	}
 	w.workflowClient = temporal.GetClient()
+	w.credentialBroker, err = credential.NewBroker(config)
+	if err != nil {
+		return nil, fmt.Errorf("fail to create credential broker,error:%w", err)
+	}
 
 	return w, nil
 }
@@ -259,6 +265,15 @@ func NewLfsSyncWorker(config *config.Config) (*LfsSyncWorker, error) {
 // SyncLFS refreshes LFS metadata for the synced commit, then downloads missing
 // objects and publishes the repository head.
 func (w *LfsSyncWorker) SyncLFS(ctx context.Context, mt *database.MirrorTask) error {
+	if mt.Mirror != nil && mt.Mirror.CredentialID != 0 {
+		// the source auth of the credential is only kept in memory, and never saved to the mirror
+		username, accessToken, err := w.credentialBroker.InjectGitAuth(ctx, mt.Mirror.CredentialID,
+			reposyncer.MirrorCredentialTaskID(mt), reposyncer.MirrorCredentialAgentID)
+		if err != nil {
+			return fmt.Errorf("failed to inject mirror credential: %w", err)
+		}
+		mt.Mirror.Username, mt.Mirror.AccessToken = username, accessToken
+	}
 	ctx = w.withLFSContext(ctx, mt)
 	if err := w.refreshLfsMetaObjects(ctx, mt); err != nil {
 		return err
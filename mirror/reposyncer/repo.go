@@ -11,6 +11,7 @@ import (
 	"strings"
 	"time"
 
+	"opencsg.com/csghub-server/builder/credential"
 	"opencsg.com/csghub-server/builder/git"
 	"opencsg.com/csghub-server/builder/git/gitserver"
 	"opencsg.com/csghub-server/builder/rpc"
@@ -30,6 +31,14 @@ var MirrorStatusToMessageTypeMapping = map[types.MirrorTaskStatus]string{
 	types.MirrorRepoTooLarge:    "repo_too_large",
 }
 
+// MirrorCredentialAgentID is the agent the mirror credentials are granted to in the credential audit log
+const MirrorCredentialAgentID = "mirror"
+
+// MirrorCredentialTaskID returns the task the mirror credentials are granted to for the mirror task
+func MirrorCredentialTaskID(mt *database.MirrorTask) string {
+	return fmt.Sprintf("mirror-task-%d", mt.ID)
+}
+
 type commitCheckpointStore interface {
 	UpdateCommitCheckpoint(ctx context.Context, taskID int64, beforeCommitID, afterCommitID string) (database.MirrorTask, error)
 }
@@ -44,6 +53,7 @@ type RepoSyncWorker struct {
 	config                 *config.Config
 	msgSender              hook.MessageSender
 	httpClient             *http.Client
+	credentialBroker       credential.Broker
 }
 
 func NewRepoSyncWorker(config *config.Config) (*RepoSyncWorker, error) {
@@ -60,6 +70,10 @@ func NewRepoSyncWorker(config *config.Config) (*RepoSyncWorker, error) {
 	w.llmConfigStore = database.NewLLMConfigStore(config)
 	w.syncClientSettingStore = database.NewSyncClientSettingStore()
 	w.mirrorTaskStore = database.NewMirrorTaskStore()
+	w.credentialBroker, err = credential.NewBroker(config)
+	if err != nil {
+		return nil, fmt.Errorf("fail to create credential broker,error:%w", err)
+	}
 	w.config = config
 	msgSender := hook.NewMessageSender(
 		fmt.Sprintf("%s:%d", config.Notification.Host, config.Notification.Port),
@@ -149,12 +163,20 @@ func (w *RepoSyncWorker) SyncRepo(
 		slog.Any("name", name),
 	)
 
+	username, accessToken := mirror.Username, mirror.AccessToken
+	if mirror.CredentialID != 0 {
+		username, accessToken, err = w.credentialBroker.InjectGitAuth(ctx, mirror.CredentialID, MirrorCredentialTaskID(mt), MirrorCredentialAgentID)
+		if err != nil {
+			return mt, fmt.Errorf("failed to inject mirror credential: %w", err)
+		}
+	}
+
 	req := gitserver.MirrorSyncReq{
 		Namespace:    namespace,
 		Name:         name,
 		CloneUrl:     mirror.SourceUrl,
-		Username:     mirror.Username,
-		AccessToken:  mirror.AccessToken,
+		Username:     username,
+		AccessToken:  accessToken,
 		RepoType:     mirror.Repository.RepositoryType,
 		RelativePath: relativePath,
 	}
@@ -166,7 +188,7 @@ func (w *RepoSyncWorker) SyncRepo(
 		req.MirrorToken = syncClientSetting.Token
 	}
 
-	if err := w.checkSourceURL(ctx, mirror.SourceUrl, mirror.Username, mirror.AccessToken); err != nil {
+	if err := w.checkSourceURL(ctx, mirror.SourceUrl, username, accessToken); err != nil {
 		return mt, err
 	}
 
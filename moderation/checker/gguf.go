@@ -0,0 +1,279 @@
+package checker
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"opencsg.com/csghub-server/common/types"
+)
+
+const (
+	ggufDefaultAlignment = 32
+	// limits well above real models, they only stop malformed counts from exhausting memory
+	maxGGUFTensors     = 1 << 20
+	maxGGUFMetadataKVs = 1 << 20
+	maxGGUFString      = 1 << 24
+	maxGGUFArrayDepth  = 4
+	maxGGUFDims        = 4
+)
+
+// gguf metadata value types
+const (
+	ggufTypeUint8 uint32 = iota
+	ggufTypeInt8
+	ggufTypeUint16
+	ggufTypeInt16
+	ggufTypeUint32
+	ggufTypeInt32
+	ggufTypeFloat32
+	ggufTypeBool
+	ggufTypeString
+	ggufTypeArray
+	ggufTypeUint64
+	ggufTypeInt64
+	ggufTypeFloat64
+)
+
+var ggufScalarSizes = map[uint32]int64{
+	ggufTypeUint8: 1, ggufTypeInt8: 1, ggufTypeBool: 1,
+	ggufTypeUint16: 2, ggufTypeInt16: 2,
+	ggufTypeUint32: 4, ggufTypeInt32: 4, ggufTypeFloat32: 4,
+	ggufTypeUint64: 8, ggufTypeInt64: 8, ggufTypeFloat64: 8,
+}
+
+// ggmlTypeSizes maps ggml tensor types to their block size in elements and bytes
+var ggmlTypeSizes = map[uint32][2]uint64{
+	0: {1, 4}, 1: {1, 2}, 2: {32, 18}, 3: {32, 20}, 6: {32, 22}, 7: {32, 24}, 8: {32, 34}, 9: {32, 36},
+	10: {256, 84}, 11: {256, 110}, 12: {256, 144}, 13: {256, 176}, 14: {256, 210}, 15: {256, 292},
+	16: {256, 66}, 17: {256, 74}, 18: {256, 98}, 19: {256, 50}, 20: {32, 18}, 21: {256, 110},
+	22: {256, 82}, 23: {256, 136}, 24: {1, 1}, 25: {1, 2}, 26: {1, 4}, 27: {1, 8}, 28: {1, 8},
+	29: {256, 56}, 30: {1, 2}, 34: {256, 54}, 35: {256, 66}, 39: {32, 17},
+}
+
+// ggufReader reads the little endian gguf header sequentially and tracks the offset
+type ggufReader struct {
+	r   *bufio.Reader
+	pos int64
+}
+
+func (g *ggufReader) read(v any) error {
+	if err := binary.Read(g.r, binary.LittleEndian, v); err != nil {
+		return err
+	}
+	g.pos += int64(binary.Size(v))
+	return nil
+}
+
+func (g *ggufReader) uint32() (uint32, error) {
+	var v uint32
+	err := g.read(&v)
+	return v, err
+}
+
+func (g *ggufReader) uint64() (uint64, error) {
+	var v uint64
+	err := g.read(&v)
+	return v, err
+}
+
+func (g *ggufReader) skip(n int64) error {
+	discarded, err := io.CopyN(io.Discard, g.r, n)
+	g.pos += discarded
+	if err == nil && discarded != n {
+		err = io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+func (g *ggufReader) string() (string, error) {
+	n, err := g.uint64()
+	if err != nil {
+		return "", err
+	}
+	if n > maxGGUFString {
+		return "", fmt.Errorf("string length %d is too large", n)
+	}
+	buf := make([]byte, n)
+	if _, err := io.ReadFull(g.r, buf); err != nil {
+		return "", err
+	}
+	g.pos += int64(n)
+	return string(buf), nil
+}
+
+// value reads a metadata value, only uint32 values are returned since
+// general.alignment is the only key needed for validation
+func (g *ggufReader) value(typ uint32, depth int) (uint64, error) {
+	if size, ok := ggufScalarSizes[typ]; ok {
+		if typ == ggufTypeUint32 {
+			v, err := g.uint32()
+			return uint64(v), err
+		}
+		return 0, g.skip(size)
+	}
+	switch typ {
+	case ggufTypeString:
+		_, err := g.string()
+		return 0, err
+	case ggufTypeArray:
+		if depth >= maxGGUFArrayDepth {
+			return 0, errors.New("metadata arrays are nested too deeply")
+		}
+		elemType, err := g.uint32()
+		if err != nil {
+			return 0, err
+		}
+		count, err := g.uint64()
+		if err != nil {
+			return 0, err
+		}
+		if size, ok := ggufScalarSizes[elemType]; ok {
+			if count > uint64(1<<40)/uint64(size) {
+				return 0, fmt.Errorf("array length %d is too large", count)
+			}
+			return 0, g.skip(int64(count) * size)
+		}
+		for i := uint64(0); i < count; i++ {
+			if _, err := g.value(elemType, depth+1); err != nil {
+				return 0, err
+			}
+		}
+		return 0, nil
+	default:
+		return 0, fmt.Errorf("unknown metadata value type %d", typ)
+	}
+}
+
+// validateGGUF checks the gguf header, metadata and tensor infos, and that every
+// tensor is aligned and lies within the data section of the file
+func validateGGUF(r io.ReaderAt, size int64) types.ModelFileScanResult {
+	res, err := checkGGUF(r, size)
+	if err != nil {
+		return types.ModelFileScanResult{Status: types.ModelFileScanInvalid, Format: types.ModelFileFormatGGUF, Message: err.Error()}
+	}
+	return res
+}
+
+func checkGGUF(r io.ReaderAt, size int64) (types.ModelFileScanResult, error) {
+	res := types.ModelFileScanResult{Status: types.ModelFileScanSafe, Format: types.ModelFileFormatGGUF}
+	g := &ggufReader{r: bufio.NewReader(io.NewSectionReader(r, 0, size))}
+
+	magic := make([]byte, 4)
+	if _, err := io.ReadFull(g.r, magic); err != nil || string(magic) != string(ggufMagic) {
+		return res, errors.New("missing GGUF magic")
+	}
+	g.pos = 4
+	version, err := g.uint32()
+	if err != nil {
+		return res, fmt.Errorf("failed to read version, %w", err)
+	}
+	if version != 2 && version != 3 {
+		return res, fmt.Errorf("unsupported GGUF version %d", version)
+	}
+	tensorCount, err := g.uint64()
+	if err != nil {
+		return res, fmt.Errorf("failed to read tensor count, %w", err)
+	}
+	kvCount, err := g.uint64()
+	if err != nil {
+		return res, fmt.Errorf("failed to read metadata count, %w", err)
+	}
+	if tensorCount > maxGGUFTensors || kvCount > maxGGUFMetadataKVs {
+		return res, fmt.Errorf("tensor count %d or metadata count %d is out of range", tensorCount, kvCount)
+	}
+
+	alignment := uint64(ggufDefaultAlignment)
+	for i := uint64(0); i < kvCount; i++ {
+		key, err := g.string()
+		if err != nil {
+			return res, fmt.Errorf("failed to read metadata key %d, %w", i, err)
+		}
+		typ, err := g.uint32()
+		if err != nil {
+			return res, fmt.Errorf("failed to read type of metadata %q, %w", key, err)
+		}
+		v, err := g.value(typ, 0)
+		if err != nil {
+			return res, fmt.Errorf("failed to read metadata %q, %w", key, err)
+		}
+		if key == "general.alignment" {
+			if typ != ggufTypeUint32 || v == 0 || v&(v-1) != 0 {
+				return res, fmt.Errorf("general.alignment must be a power of two uint32")
+			}
+			alignment = v
+		}
+	}
+
+	type tensorInfo struct {
+		name   string
+		offset uint64
+		bytes  uint64
+		known  bool
+	}
+	tensors := make([]tensorInfo, 0, tensorCount)
+	for i := uint64(0); i < tensorCount; i++ {
+		name, err := g.string()
+		if err != nil {
+			return res, fmt.Errorf("failed to read tensor name %d, %w", i, err)
+		}
+		nDims, err := g.uint32()
+		if err != nil {
+			return res, fmt.Errorf("failed to read dims of tensor %q, %w", name, err)
+		}
+		if nDims == 0 || nDims > maxGGUFDims {
+			return res, fmt.Errorf("tensor %q has %d dimensions", name, nDims)
+		}
+		numel := uint64(1)
+		for d := uint32(0); d < nDims; d++ {
+			dim, err := g.uint64()
+			if err != nil {
+				return res, fmt.Errorf("failed to read dims of tensor %q, %w", name, err)
+			}
+			if dim != 0 && numel > (1<<62)/dim {
+				return res, fmt.Errorf("tensor %q is too large", name)
+			}
+			numel *= dim
+		}
+		ggmlType, err := g.uint32()
+		if err != nil {
+			return res, fmt.Errorf("failed to read type of tensor %q, %w", name, err)
+		}
+		offset, err := g.uint64()
+		if err != nil {
+			return res, fmt.Errorf("failed to read offset of tensor %q, %w", name, err)
+		}
+		if offset%alignment != 0 {
+			return res, fmt.Errorf("tensor %q offset %d is not aligned to %d", name, offset, alignment)
+		}
+		t := tensorInfo{name: name, offset: offset}
+		if blk, ok := ggmlTypeSizes[ggmlType]; ok {
+			if numel%blk[0] != 0 {
+				return res, fmt.Errorf("tensor %q has %d elements which is not a multiple of block size %d", name, numel, blk[0])
+			}
+			t.bytes, t.known = numel/blk[0]*blk[1], true
+		}
+		tensors = append(tensors, t)
+	}
+
+	dataStart := (uint64(g.pos) + alignment - 1) / alignment * alignment
+	if dataStart > uint64(size) {
+		return res, fmt.Errorf("data section starts at %d beyond file size %d", dataStart, size)
+	}
+	dataSize := uint64(size) - dataStart
+	unknown := 0
+	for _, t := range tensors {
+		if t.offset > dataSize || (t.known && t.bytes > dataSize-t.offset) {
+			return res, fmt.Errorf("tensor %q exceeds the data section", t.name)
+		}
+		if !t.known {
+			unknown++
+		}
+	}
+	if unknown > 0 {
+		res.Message = fmt.Sprintf("size of %d tensors with unknown ggml types was not checked", unknown)
+	}
+	return res, nil
+}
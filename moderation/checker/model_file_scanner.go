@@ -0,0 +1,144 @@
+package checker
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"opencsg.com/csghub-server/common/types"
+)
+
+var (
+	zipMagic  = []byte("PK\x03\x04")
+	ggufMagic = []byte("GGUF")
+)
+
+// maxLegacyTorchPickles bounds the pickles read from a legacy (non-zip) torch file,
+// which stores the magic number, protocol version, sys info, the model and the
+// storage keys as consecutive pickles followed by raw tensor data
+const maxLegacyTorchPickles = 5
+
+// ModelFileScanner checks model weight files for code executed on load and for malformed structure.
+//
+// Pickle based files (.bin, .pt, .pth, .pkl, .pickle, .ckpt) are disassembled without being
+// loaded and every imported callable is classified, safetensors and GGUF files are validated
+// against their format specification.
+type ModelFileScanner interface {
+	Scan(ctx context.Context, filePath string, r io.ReaderAt, size int64) types.ModelFileScanResult
+}
+
+type modelFileScannerImpl struct{}
+
+func NewModelFileScanner() ModelFileScanner {
+	return &modelFileScannerImpl{}
+}
+
+func (s *modelFileScannerImpl) Scan(ctx context.Context, filePath string, r io.ReaderAt, size int64) types.ModelFileScanResult {
+	head := make([]byte, 4)
+	n, err := r.ReadAt(head, 0)
+	if err != nil && err != io.EOF {
+		return types.ModelFileScanResult{Status: types.ModelFileScanError, Format: types.ModelFileFormatUnknown,
+			Message: fmt.Sprintf("failed to read file header, %v", err)}
+	}
+	head = head[:n]
+
+	switch ext := strings.ToLower(path.Ext(filePath)); {
+	case ext == ".safetensors":
+		return validateSafetensors(r, size)
+	case ext == ".gguf" || bytes.Equal(head, ggufMagic):
+		return validateGGUF(r, size)
+	case bytes.Equal(head, zipMagic):
+		return s.scanTorchZip(ctx, r, size)
+	case len(head) > 0 && head[0] == pickleProto:
+		return s.scanPickleFile(ctx, r, size)
+	case ext == ".pkl" || ext == ".pickle":
+		// protocol 0 and 1 pickles have no PROTO header
+		return s.scanPickleFile(ctx, r, size)
+	default:
+		return types.ModelFileScanResult{Status: types.ModelFileScanSkipped, Format: types.ModelFileFormatUnknown,
+			Message: "file is neither a pickle, pytorch zip, safetensors nor gguf file"}
+	}
+}
+
+// scanPickleFile scans a plain pickle file, or a legacy torch file made of consecutive pickles
+func (s *modelFileScannerImpl) scanPickleFile(ctx context.Context, r io.ReaderAt, size int64) types.ModelFileScanResult {
+	br := bufio.NewReader(io.NewSectionReader(r, 0, size))
+	var findings []types.ModelFileScanFinding
+	for i := 0; i < maxLegacyTorchPickles; i++ {
+		if ctx.Err() != nil {
+			return scanResult(types.ModelFileFormatPickle, findings, ctx.Err())
+		}
+		if i > 0 {
+			next, err := br.Peek(1)
+			if err != nil || next[0] != pickleProto {
+				break
+			}
+		}
+		globals, err := disassemblePickle(br)
+		findings = append(findings, classifyGlobals("", globals)...)
+		if err != nil {
+			return scanResult(types.ModelFileFormatPickle, findings, err)
+		}
+	}
+	return scanResult(types.ModelFileFormatPickle, findings, nil)
+}
+
+// scanTorchZip scans every pickle inside a pytorch zip archive, e.g. archive/data.pkl
+func (s *modelFileScannerImpl) scanTorchZip(ctx context.Context, r io.ReaderAt, size int64) types.ModelFileScanResult {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return types.ModelFileScanResult{Status: types.ModelFileScanInvalid, Format: types.ModelFileFormatPyTorchZip,
+			Message: fmt.Sprintf("invalid zip archive, %v", err)}
+	}
+	var findings []types.ModelFileScanFinding
+	scanned := 0
+	for _, f := range zr.File {
+		if !strings.HasSuffix(f.Name, ".pkl") {
+			continue
+		}
+		if ctx.Err() != nil {
+			return scanResult(types.ModelFileFormatPyTorchZip, findings, ctx.Err())
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return scanResult(types.ModelFileFormatPyTorchZip, findings, fmt.Errorf("failed to open %s, %w", f.Name, err))
+		}
+		globals, err := disassemblePickle(bufio.NewReader(rc))
+		_ = rc.Close()
+		findings = append(findings, classifyGlobals(f.Name, globals)...)
+		if err != nil {
+			return scanResult(types.ModelFileFormatPyTorchZip, findings, fmt.Errorf("%s: %w", f.Name, err))
+		}
+		scanned++
+	}
+	res := scanResult(types.ModelFileFormatPyTorchZip, findings, nil)
+	if scanned == 0 {
+		res.Message = "no pickle found in zip archive"
+	}
+	return res
+}
+
+// scanResult builds the verdict from the findings, a disassembly error makes an otherwise
+// clean file invalid since the remaining stream could not be checked
+func scanResult(format types.ModelFileFormat, findings []types.ModelFileScanFinding, err error) types.ModelFileScanResult {
+	res := types.ModelFileScanResult{Status: types.ModelFileScanSafe, Format: format, Findings: findings}
+	for _, f := range findings {
+		if f.Severity == types.ModelFileScanUnsafe {
+			res.Status = types.ModelFileScanUnsafe
+			break
+		}
+		res.Status = types.ModelFileScanSuspicious
+	}
+	if err != nil {
+		res.Message = fmt.Sprintf("failed to disassemble pickle, %v", err)
+		if res.Status != types.ModelFileScanUnsafe {
+			res.Status = types.ModelFileScanInvalid
+		}
+	}
+	return res
+}
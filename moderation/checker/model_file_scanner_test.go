@@ -0,0 +1,181 @@
+package checker
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"opencsg.com/csghub-server/common/types"
+)
+
+var (
+	// pickle.dumps of an object reducing to os.system("id"), protocol 4
+	pickleOSSystem = []byte("\x80\x04\x95\x1d\x00\x00\x00\x00\x00\x00\x00\x8c\x05posix\x94\x8c\x06system\x94\x93\x94\x8c\x02id\x94\x85\x94R\x94.")
+	// pickle.dumps of an object reducing to builtins.eval("1+1"), protocol 2
+	pickleEval = []byte("\x80\x02c__builtin__\neval\nq\x00X\x03\x00\x00\x001+1q\x01\x85q\x02Rq\x03.")
+	// pickle.dumps(collections.OrderedDict(a=[1, 2.0, "x", b"y"]), protocol=4)
+	pickleOrderedDict = []byte("\x80\x04\x95>\x00\x00\x00\x00\x00\x00\x00\x8c\x0bcollections\x94\x8c\x0bOrderedDict\x94\x93\x94)R\x94\x8c\x01a\x94]\x94(K\x01G@\x00\x00\x00\x00\x00\x00\x00\x8c\x01x\x94C\x01y\x94es.")
+	// STACK_GLOBAL whose arguments are fetched back from the memo
+	pickleMemoStackGlobal = []byte("\x80\x04\x8c\x0asubprocess\x94\x8c\x05Popen\x940h\x00h\x01\x93N\x85R.")
+	// STACK_GLOBAL whose module is uncovered by a POP, "os" "x" POP "system" STACK_GLOBAL
+	picklePopStackGlobal = []byte("\x80\x04\x8c\x02os\x8c\x01x0\x8c\x06system\x93N\x85R.")
+)
+
+func scanBytes(name string, data []byte) types.ModelFileScanResult {
+	return NewModelFileScanner().Scan(context.Background(), name, bytes.NewReader(data), int64(len(data)))
+}
+
+func TestModelFileScanner_Pickle(t *testing.T) {
+	res := scanBytes("model.pkl", pickleOSSystem)
+	require.Equal(t, types.ModelFileScanUnsafe, res.Status)
+	require.Equal(t, types.ModelFileFormatPickle, res.Format)
+	require.Len(t, res.Findings, 1)
+	require.Equal(t, "posix", res.Findings[0].Module)
+	require.Equal(t, "system", res.Findings[0].Name)
+
+	res = scanBytes("pytorch_model.bin", pickleEval)
+	require.Equal(t, types.ModelFileScanUnsafe, res.Status)
+	require.Equal(t, "__builtin__", res.Findings[0].Module)
+	require.Equal(t, "eval", res.Findings[0].Name)
+
+	res = scanBytes("model.ckpt", pickleMemoStackGlobal)
+	require.Equal(t, types.ModelFileScanUnsafe, res.Status)
+	require.Equal(t, "subprocess", res.Findings[0].Module)
+	require.Equal(t, "Popen", res.Findings[0].Name)
+
+	res = scanBytes("model.pth", picklePopStackGlobal)
+	require.Equal(t, types.ModelFileScanUnsafe, res.Status)
+	require.Equal(t, "os", res.Findings[0].Module)
+	require.Equal(t, "system", res.Findings[0].Name)
+
+	res = scanBytes("state.pt", pickleOrderedDict)
+	require.Equal(t, types.ModelFileScanSafe, res.Status)
+	require.Empty(t, res.Findings)
+
+	res = scanBytes("proto0.pickle", []byte("csklearn.linear_model\nLogisticRegression\n)R."))
+	require.Equal(t, types.ModelFileScanSuspicious, res.Status)
+	require.Equal(t, "LogisticRegression", res.Findings[0].Name)
+
+	res = scanBytes("truncated.pkl", pickleOrderedDict[:20])
+	require.Equal(t, types.ModelFileScanInvalid, res.Status)
+
+	res = scanBytes("weights.bin", []byte("raw tensor bytes"))
+	require.Equal(t, types.ModelFileScanSkipped, res.Status)
+}
+
+func TestModelFileScanner_PickleMemoLimit(t *testing.T) {
+	// every LONG_BINPUT stores a new memo entry with only 5 bytes of input
+	data := []byte("\x80\x04N")
+	for i := 0; i <= maxPickleMemo; i++ {
+		data = append(data, pickleLongBinPut)
+		data = binary.LittleEndian.AppendUint32(data, uint32(i))
+	}
+	data = append(data, pickleStop)
+	res := scanBytes("model.pkl", data)
+	require.Equal(t, types.ModelFileScanInvalid, res.Status)
+	require.Contains(t, res.Message, "too many memo entries")
+}
+
+func TestModelFileScanner_LegacyTorch(t *testing.T) {
+	// legacy torch files store several pickles back to back followed by raw data
+	data := append(append(append([]byte{}, pickleOrderedDict...), pickleOSSystem...), 0x00, 0x01, 0x02)
+	res := scanBytes("pytorch_model.bin", data)
+	require.Equal(t, types.ModelFileScanUnsafe, res.Status)
+	require.Len(t, res.Findings, 1)
+}
+
+func TestModelFileScanner_TorchZip(t *testing.T) {
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+	w, err := zw.Create("archive/data.pkl")
+	require.Nil(t, err)
+	_, err = w.Write(pickleEval)
+	require.Nil(t, err)
+	w, err = zw.Create("archive/data/0")
+	require.Nil(t, err)
+	_, err = w.Write([]byte{1, 2, 3, 4})
+	require.Nil(t, err)
+	require.Nil(t, zw.Close())
+
+	res := scanBytes("model.pt", buf.Bytes())
+	require.Equal(t, types.ModelFileScanUnsafe, res.Status)
+	require.Equal(t, types.ModelFileFormatPyTorchZip, res.Format)
+	require.Equal(t, "archive/data.pkl", res.Findings[0].Entry)
+}
+
+func safetensorsFile(header string, dataSize int) []byte {
+	buf := make([]byte, 8, 8+len(header)+dataSize)
+	binary.LittleEndian.PutUint64(buf, uint64(len(header)))
+	buf = append(buf, header...)
+	return append(buf, make([]byte, dataSize)...)
+}
+
+func TestModelFileScanner_Safetensors(t *testing.T) {
+	valid := `{"__metadata__":{"format":"pt"},"a":{"dtype":"F16","shape":[2,3],"data_offsets":[0,12]},"b":{"dtype":"BF16","shape":[2],"data_offsets":[12,16]}}  `
+	res := scanBytes("model.safetensors", safetensorsFile(valid, 16))
+	require.Equal(t, types.ModelFileScanSafe, res.Status, res.Message)
+
+	cases := map[string][]byte{
+		"trailing data":  safetensorsFile(valid, 20),
+		"overlap":        safetensorsFile(`{"a":{"dtype":"F32","shape":[2],"data_offsets":[0,8]},"b":{"dtype":"F32","shape":[1],"data_offsets":[4,8]}}`, 8),
+		"shape mismatch": safetensorsFile(`{"a":{"dtype":"F32","shape":[3],"data_offsets":[0,8]}}`, 8),
+		"unknown dtype":  safetensorsFile(`{"a":{"dtype":"X","shape":[1],"data_offsets":[0,1]}}`, 1),
+		"not json":       safetensorsFile(`import os`, 0),
+		"header size":    {0xff, 0xff, 0xff, 0xff, 0, 0, 0, 0, '{', '}'},
+	}
+	for name, data := range cases {
+		res := scanBytes("model.safetensors", data)
+		require.Equal(t, types.ModelFileScanInvalid, res.Status, name)
+	}
+}
+
+type ggufBuilder struct {
+	bytes.Buffer
+}
+
+func (b *ggufBuilder) put(v any) *ggufBuilder {
+	_ = binary.Write(b, binary.LittleEndian, v)
+	return b
+}
+
+func (b *ggufBuilder) str(s string) *ggufBuilder {
+	b.put(uint64(len(s)))
+	b.WriteString(s)
+	return b
+}
+
+func ggufFile(tensorOffset uint64, dataSize int) []byte {
+	b := &ggufBuilder{}
+	b.WriteString("GGUF")
+	b.put(uint32(3)).put(uint64(1)).put(uint64(2))
+	// metadata
+	b.str("general.architecture").put(ggufTypeString).str("llama")
+	b.str("tokenizer.ggml.tokens").put(ggufTypeArray).put(ggufTypeString).put(uint64(2)).str("a").str("b")
+	// one F32 tensor of 4x2 elements
+	b.str("token_embd.weight").put(uint32(2)).put(uint64(4)).put(uint64(2)).put(uint32(0)).put(tensorOffset)
+	for b.Len()%ggufDefaultAlignment != 0 {
+		b.WriteByte(0)
+	}
+	b.Write(make([]byte, dataSize))
+	return b.Bytes()
+}
+
+func TestModelFileScanner_GGUF(t *testing.T) {
+	res := scanBytes("model.gguf", ggufFile(0, 32))
+	require.Equal(t, types.ModelFileScanSafe, res.Status, res.Message)
+	require.Equal(t, types.ModelFileFormatGGUF, res.Format)
+
+	res = scanBytes("model.gguf", ggufFile(0, 16))
+	require.Equal(t, types.ModelFileScanInvalid, res.Status)
+	require.Contains(t, res.Message, "exceeds the data section")
+
+	res = scanBytes("model.gguf", ggufFile(8, 64))
+	require.Equal(t, types.ModelFileScanInvalid, res.Status)
+	require.Contains(t, res.Message, "not aligned")
+
+	res = scanBytes("model.gguf", []byte("GGUF\x09\x00\x00\x00"))
+	require.Equal(t, types.ModelFileScanInvalid, res.Status)
+}
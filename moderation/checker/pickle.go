@@ -0,0 +1,449 @@
+package checker
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"opencsg.com/csghub-server/common/types"
+)
+
+// pickle opcodes, see https://github.com/python/cpython/blob/main/Lib/pickletools.py
+const (
+	pickleMark           = '('
+	pickleStop           = '.'
+	picklePop            = '0'
+	picklePopMark        = '1'
+	pickleDup            = '2'
+	pickleFloat          = 'F'
+	pickleInt            = 'I'
+	pickleBinInt         = 'J'
+	pickleBinInt1        = 'K'
+	pickleLong           = 'L'
+	pickleBinInt2        = 'M'
+	pickleNone           = 'N'
+	picklePersID         = 'P'
+	pickleBinPersID      = 'Q'
+	pickleReduce         = 'R'
+	pickleString         = 'S'
+	pickleBinString      = 'T'
+	pickleShortBinString = 'U'
+	pickleUnicode        = 'V'
+	pickleBinUnicode     = 'X'
+	pickleAppend         = 'a'
+	pickleBuild          = 'b'
+	pickleGlobal         = 'c'
+	pickleDict           = 'd'
+	pickleEmptyDict      = '}'
+	pickleAppends        = 'e'
+	pickleGet            = 'g'
+	pickleBinGet         = 'h'
+	pickleInst           = 'i'
+	pickleLongBinGet     = 'j'
+	pickleList           = 'l'
+	pickleEmptyList      = ']'
+	pickleObj            = 'o'
+	picklePut            = 'p'
+	pickleBinPut         = 'q'
+	pickleLongBinPut     = 'r'
+	pickleSetItem        = 's'
+	pickleTuple          = 't'
+	pickleEmptyTuple     = ')'
+	pickleSetItems       = 'u'
+	pickleBinFloat       = 'G'
+
+	// protocol 2
+	pickleProto    = 0x80
+	pickleNewObj   = 0x81
+	pickleExt1     = 0x82
+	pickleExt2     = 0x83
+	pickleExt4     = 0x84
+	pickleTuple1   = 0x85
+	pickleTuple2   = 0x86
+	pickleTuple3   = 0x87
+	pickleNewTrue  = 0x88
+	pickleNewFalse = 0x89
+	pickleLong1    = 0x8a
+	pickleLong4    = 0x8b
+
+	// protocol 3
+	pickleBinBytes      = 'B'
+	pickleShortBinBytes = 'C'
+
+	// protocol 4
+	pickleShortBinUnicode = 0x8c
+	pickleBinUnicode8     = 0x8d
+	pickleBinBytes8       = 0x8e
+	pickleEmptySet        = 0x8f
+	pickleAddItems        = 0x90
+	pickleFrozenSet       = 0x91
+	pickleNewObjEx        = 0x92
+	pickleStackGlobal     = 0x93
+	pickleMemoize         = 0x94
+	pickleFrame           = 0x95
+
+	// protocol 5
+	pickleByteArray8     = 0x96
+	pickleNextBuffer     = 0x97
+	pickleReadonlyBuffer = 0x98
+)
+
+const (
+	// maxPickleLine bounds the text arguments of protocol 0 opcodes
+	maxPickleLine = 64 * 1024
+	// maxPickleName bounds the strings remembered as possible STACK_GLOBAL arguments
+	maxPickleName = 1024
+	// maxPickleOps bounds the opcodes of a single pickle stream
+	maxPickleOps = 50_000_000
+	// maxPickleMemo bounds the memo entries of a single pickle stream, a PUT takes only a few
+	// bytes so a hostile stream could otherwise grow the memo to hundreds of millions of entries
+	maxPickleMemo = 1_000_000
+	// maxPickleStack bounds the values tracked on the top of the stack, POP may uncover
+	// the values pushed before so more than the two STACK_GLOBAL arguments are kept
+	maxPickleStack = 64
+)
+
+// pickleImport is a callable imported by GLOBAL, INST or STACK_GLOBAL,
+// an empty module means the import could not be resolved statically
+type pickleImport struct {
+	module string
+	name   string
+	opcode string
+}
+
+// disassemblePickle reads a single pickle stream up to its STOP opcode and returns the imports it makes.
+//
+// The stream is never executed, only the values needed to resolve STACK_GLOBAL
+// (strings pushed directly or through the memo) are tracked.
+func disassemblePickle(r *bufio.Reader) ([]pickleImport, error) {
+	d := &pickleDisassembler{r: r, memo: map[uint64]*string{}}
+	for i := 0; i < maxPickleOps; i++ {
+		op, err := r.ReadByte()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return d.imports, errors.New("unexpected end of pickle stream")
+			}
+			return d.imports, err
+		}
+		stop, err := d.step(op)
+		if err != nil {
+			return d.imports, fmt.Errorf("opcode 0x%02x: %w", op, err)
+		}
+		if stop {
+			return d.imports, nil
+		}
+	}
+	return d.imports, errors.New("too many opcodes in pickle stream")
+}
+
+type pickleDisassembler struct {
+	r       *bufio.Reader
+	imports []pickleImport
+	// strs holds the values on the top of the stack, nil marks any value other than a string
+	strs []*string
+	memo map[uint64]*string
+}
+
+func (d *pickleDisassembler) step(op byte) (stop bool, err error) {
+	switch op {
+	case pickleStop:
+		return true, nil
+
+	// no argument, the result is not a string
+	case picklePop:
+		if len(d.strs) > 0 {
+			d.strs = d.strs[:len(d.strs)-1]
+		}
+	case pickleMark, picklePopMark:
+		// the values before the mark can not be STACK_GLOBAL arguments until the mark is popped,
+		// and POP_MARK drops the values after it, the tracked values are cleared on both
+		d.strs = d.strs[:0]
+	case pickleNone, pickleBinPersID, pickleReduce,
+		pickleAppend, pickleBuild, pickleDict, pickleEmptyDict, pickleAppends, pickleList,
+		pickleEmptyList, pickleObj, pickleSetItem, pickleTuple, pickleEmptyTuple, pickleSetItems,
+		pickleNewObj, pickleTuple1, pickleTuple2, pickleTuple3, pickleNewTrue, pickleNewFalse,
+		pickleEmptySet, pickleAddItems, pickleFrozenSet, pickleNewObjEx, pickleNextBuffer, pickleReadonlyBuffer:
+		d.push(nil)
+	case pickleDup:
+		if len(d.strs) > 0 {
+			d.push(d.strs[len(d.strs)-1])
+		}
+
+	// text arguments
+	case pickleInt, pickleLong, pickleFloat, picklePersID:
+		_, err = d.readLine()
+		d.push(nil)
+	case pickleString:
+		var line string
+		if line, err = d.readLine(); err == nil {
+			s := strings.Trim(line, `'"`)
+			d.pushString(s)
+		}
+	case pickleUnicode:
+		var line string
+		if line, err = d.readLine(); err == nil {
+			d.pushString(line)
+		}
+	case pickleGlobal, pickleInst:
+		var module, name string
+		if module, err = d.readLine(); err != nil {
+			return false, err
+		}
+		if name, err = d.readLine(); err != nil {
+			return false, err
+		}
+		opcode := "GLOBAL"
+		if op == pickleInst {
+			opcode = "INST"
+		}
+		d.imports = append(d.imports, pickleImport{module: module, name: name, opcode: opcode})
+		d.push(nil)
+	case pickleGet:
+		var line string
+		if line, err = d.readLine(); err == nil {
+			var idx uint64
+			if idx, err = strconv.ParseUint(line, 10, 64); err == nil {
+				d.push(d.memo[idx])
+			}
+		}
+	case picklePut:
+		var line string
+		if line, err = d.readLine(); err == nil {
+			var idx uint64
+			if idx, err = strconv.ParseUint(line, 10, 64); err == nil {
+				err = d.memoize(idx)
+			}
+		}
+
+	// fixed size binary arguments
+	case pickleProto:
+		err = d.skip(1)
+	case pickleBinInt1:
+		err = d.skip(1)
+		d.push(nil)
+	case pickleBinInt2:
+		err = d.skip(2)
+		d.push(nil)
+	case pickleBinInt:
+		err = d.skip(4)
+		d.push(nil)
+	case pickleExt1, pickleExt2, pickleExt4:
+		// extension codes import callables registered with copyreg, which can not be resolved statically
+		size := map[byte]int64{pickleExt1: 1, pickleExt2: 2, pickleExt4: 4}[op]
+		err = d.skip(size)
+		d.imports = append(d.imports, pickleImport{opcode: "EXT"})
+		d.push(nil)
+	case pickleBinFloat:
+		err = d.skip(8)
+		d.push(nil)
+	case pickleFrame:
+		err = d.skip(8)
+	case pickleBinGet:
+		var idx uint64
+		if idx, err = d.readUint(1); err == nil {
+			d.push(d.memo[idx])
+		}
+	case pickleLongBinGet:
+		var idx uint64
+		if idx, err = d.readUint(4); err == nil {
+			d.push(d.memo[idx])
+		}
+	case pickleBinPut:
+		var idx uint64
+		if idx, err = d.readUint(1); err == nil {
+			err = d.memoize(idx)
+		}
+	case pickleLongBinPut:
+		var idx uint64
+		if idx, err = d.readUint(4); err == nil {
+			err = d.memoize(idx)
+		}
+	case pickleMemoize:
+		err = d.memoize(uint64(len(d.memo)))
+
+	// length prefixed arguments
+	case pickleShortBinString, pickleShortBinUnicode:
+		err = d.readCounted(1, true)
+	case pickleBinString, pickleBinUnicode:
+		err = d.readCounted(4, true)
+	case pickleBinUnicode8:
+		err = d.readCounted(8, true)
+	case pickleShortBinBytes, pickleLong1:
+		err = d.readCounted(1, false)
+	case pickleBinBytes, pickleLong4:
+		err = d.readCounted(4, false)
+	case pickleBinBytes8, pickleByteArray8:
+		err = d.readCounted(8, false)
+
+	case pickleStackGlobal:
+		imp := pickleImport{opcode: "STACK_GLOBAL"}
+		if n := len(d.strs); n >= 2 && d.strs[n-2] != nil && d.strs[n-1] != nil {
+			imp.module, imp.name = *d.strs[n-2], *d.strs[n-1]
+		}
+		d.imports = append(d.imports, imp)
+		d.push(nil)
+	default:
+		return false, errors.New("unknown opcode")
+	}
+	return false, err
+}
+
+func (d *pickleDisassembler) push(s *string) {
+	if len(d.strs) >= maxPickleStack {
+		d.strs = append(d.strs[:0], d.strs[1:]...)
+	}
+	d.strs = append(d.strs, s)
+}
+
+func (d *pickleDisassembler) pushString(s string) {
+	d.push(&s)
+}
+
+// memoize stores the value on the top of the stack, MEMOIZE uses the number of
+// entries stored so far as its index
+func (d *pickleDisassembler) memoize(idx uint64) error {
+	if _, ok := d.memo[idx]; !ok && len(d.memo) >= maxPickleMemo {
+		return errors.New("too many memo entries")
+	}
+	var top *string
+	if len(d.strs) > 0 {
+		top = d.strs[len(d.strs)-1]
+	}
+	d.memo[idx] = top
+	return nil
+}
+
+func (d *pickleDisassembler) readLine() (string, error) {
+	var sb strings.Builder
+	for {
+		line, err := d.r.ReadSlice('\n')
+		sb.Write(line)
+		if sb.Len() > maxPickleLine {
+			return "", errors.New("argument line too long")
+		}
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, bufio.ErrBufferFull) {
+			return "", err
+		}
+	}
+	return strings.TrimRight(sb.String(), "\r\n"), nil
+}
+
+func (d *pickleDisassembler) readUint(size int) (uint64, error) {
+	buf := make([]byte, 8)
+	if _, err := io.ReadFull(d.r, buf[:size]); err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint64(buf), nil
+}
+
+func (d *pickleDisassembler) skip(n int64) error {
+	discarded, err := io.CopyN(io.Discard, d.r, n)
+	if err == nil && discarded != n {
+		err = io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// readCounted reads a length prefixed argument, short strings are kept as
+// STACK_GLOBAL candidates and everything else is skipped without buffering
+func (d *pickleDisassembler) readCounted(lenSize int, isString bool) error {
+	n, err := d.readUint(lenSize)
+	if err != nil {
+		return err
+	}
+	if n > 1<<62 {
+		return errors.New("invalid argument length")
+	}
+	if !isString || n > maxPickleName {
+		d.push(nil)
+		return d.skip(int64(n))
+	}
+	buf := make([]byte, n)
+	if _, err := io.ReadFull(d.r, buf); err != nil {
+		return err
+	}
+	d.pushString(string(buf))
+	return nil
+}
+
+// module prefixes whose callables can run commands, touch files or the network,
+// or load further code when a pickle is loaded
+var unsafePickleModules = []string{
+	"os", "posix", "nt", "subprocess", "sys", "socket", "shutil", "runpy", "pty", "commands",
+	"webbrowser", "importlib", "ctypes", "multiprocessing", "code", "codeop", "pdb", "bdb",
+	"timeit", "requests", "urllib", "urllib2", "httplib", "http", "ftplib", "smtplib",
+	"asyncio", "signal", "tempfile", "glob", "pip", "setuptools", "distutils", "marshal",
+	"pickle", "_pickle", "cPickle", "dill", "joblib", "shelve", "torch.hub", "numpy.testing",
+}
+
+// callables of otherwise harmless modules that can evaluate code or reach arbitrary attributes
+var unsafePickleGlobals = map[string][]string{
+	"builtins":    {"eval", "exec", "execfile", "compile", "open", "__import__", "getattr", "setattr", "delattr", "globals", "locals", "vars", "breakpoint", "input", "apply", "memoryview"},
+	"__builtin__": {"eval", "exec", "execfile", "compile", "open", "__import__", "getattr", "setattr", "delattr", "globals", "locals", "vars", "breakpoint", "input", "apply", "file"},
+	"operator":    {"attrgetter", "methodcaller", "call"},
+	"_operator":   {"attrgetter", "methodcaller", "call"},
+	"functools":   {"partial", "reduce"},
+	"types":       {"FunctionType", "CodeType", "MethodType", "ModuleType"},
+	"numpy":       {"load", "loads", "fromfile"},
+	"torch":       {"load"},
+}
+
+// modules and callables known to be used by pytorch, numpy and common training frameworks
+var safePickleModules = []string{"torch", "__torch__", "numpy", "collections", "datetime"}
+
+var safePickleGlobals = map[string][]string{
+	"builtins":    {"set", "frozenset", "dict", "list", "tuple", "bytearray", "bytes", "slice", "complex", "float", "int", "str", "bool", "object", "range"},
+	"__builtin__": {"set", "frozenset", "dict", "list", "tuple", "bytearray", "bytes", "slice", "complex", "float", "int", "str", "bool", "object", "range", "unicode", "long"},
+	"_codecs":     {"encode"},
+	"copyreg":     {"_reconstructor"},
+	"copy_reg":    {"_reconstructor"},
+	"argparse":    {"Namespace"},
+}
+
+func moduleIn(module string, modules []string) bool {
+	for _, m := range modules {
+		if module == m || strings.HasPrefix(module, m+".") {
+			return true
+		}
+	}
+	return false
+}
+
+func globalIn(module, name string, globals map[string][]string) bool {
+	for _, n := range globals[module] {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// classifyGlobals reports the imports that are not known to be safe
+func classifyGlobals(entry string, imports []pickleImport) []types.ModelFileScanFinding {
+	var findings []types.ModelFileScanFinding
+	for _, imp := range imports {
+		finding := types.ModelFileScanFinding{Entry: entry, Module: imp.module, Name: imp.name}
+		switch {
+		case imp.module == "":
+			finding.Severity = types.ModelFileScanSuspicious
+			finding.Message = fmt.Sprintf("%s import could not be resolved statically", imp.opcode)
+		case globalIn(imp.module, imp.name, unsafePickleGlobals) || moduleIn(imp.module, unsafePickleModules):
+			finding.Severity = types.ModelFileScanUnsafe
+			finding.Message = fmt.Sprintf("%s imports %s.%s which can execute code when the file is loaded", imp.opcode, imp.module, imp.name)
+		case globalIn(imp.module, imp.name, safePickleGlobals) || moduleIn(imp.module, safePickleModules):
+			continue
+		default:
+			finding.Severity = types.ModelFileScanSuspicious
+			finding.Message = fmt.Sprintf("%s imports %s.%s which is not known to be safe", imp.opcode, imp.module, imp.name)
+		}
+		findings = append(findings, finding)
+	}
+	return findings
+}
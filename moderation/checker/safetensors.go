@@ -0,0 +1,131 @@
+package checker
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"unicode/utf8"
+
+	"opencsg.com/csghub-server/common/types"
+)
+
+// maxSafetensorsHeader is the header size limit enforced by the safetensors library
+const maxSafetensorsHeader = 100 * 1024 * 1024
+
+// safetensorsDtypeBits are the element sizes of the dtypes defined by the safetensors format
+var safetensorsDtypeBits = map[string]uint64{
+	"BOOL": 8, "U8": 8, "I8": 8, "F8_E5M2": 8, "F8_E4M3": 8, "F8_E8M0": 8,
+	"I16": 16, "U16": 16, "F16": 16, "BF16": 16,
+	"I32": 32, "U32": 32, "F32": 32,
+	"I64": 64, "U64": 64, "F64": 64,
+	"F4": 4, "F6_E2M3": 6, "F6_E3M2": 6,
+}
+
+type safetensorsTensor struct {
+	name        string
+	Dtype       string   `json:"dtype"`
+	Shape       []uint64 `json:"shape"`
+	DataOffsets []uint64 `json:"data_offsets"`
+}
+
+// validateSafetensors checks the header of a safetensors file: an 8 bytes little endian
+// header size followed by a JSON header whose tensors must exactly and contiguously cover
+// the remaining bytes of the file
+func validateSafetensors(r io.ReaderAt, size int64) types.ModelFileScanResult {
+	invalid := func(format string, args ...any) types.ModelFileScanResult {
+		return types.ModelFileScanResult{Status: types.ModelFileScanInvalid, Format: types.ModelFileFormatSafetensors,
+			Message: fmt.Sprintf(format, args...)}
+	}
+
+	if size < 8 {
+		return invalid("file is too small to hold a safetensors header")
+	}
+	buf := make([]byte, 8)
+	if _, err := r.ReadAt(buf, 0); err != nil {
+		return types.ModelFileScanResult{Status: types.ModelFileScanError, Format: types.ModelFileFormatSafetensors,
+			Message: fmt.Sprintf("failed to read header size, %v", err)}
+	}
+	headerSize := binary.LittleEndian.Uint64(buf)
+	if headerSize == 0 || headerSize > maxSafetensorsHeader {
+		return invalid("header size %d is out of range", headerSize)
+	}
+	if headerSize > uint64(size-8) {
+		return invalid("header size %d exceeds file size %d", headerSize, size)
+	}
+	header := make([]byte, headerSize)
+	if _, err := r.ReadAt(header, 8); err != nil {
+		return types.ModelFileScanResult{Status: types.ModelFileScanError, Format: types.ModelFileFormatSafetensors,
+			Message: fmt.Sprintf("failed to read header, %v", err)}
+	}
+	// the header may be padded with trailing spaces to align the data
+	header = bytes.TrimRight(header, " ")
+	if len(header) == 0 || header[0] != '{' || !utf8.Valid(header) {
+		return invalid("header is not a utf-8 json object")
+	}
+
+	var entries map[string]json.RawMessage
+	if err := json.Unmarshal(header, &entries); err != nil {
+		return invalid("failed to parse header, %v", err)
+	}
+	tensors := make([]safetensorsTensor, 0, len(entries))
+	for name, raw := range entries {
+		if name == "__metadata__" {
+			var metadata map[string]string
+			if err := json.Unmarshal(raw, &metadata); err != nil {
+				return invalid("__metadata__ must map strings to strings")
+			}
+			continue
+		}
+		t := safetensorsTensor{name: name}
+		dec := json.NewDecoder(bytes.NewReader(raw))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&t); err != nil {
+			return invalid("invalid tensor %q, %v", name, err)
+		}
+		if len(t.DataOffsets) != 2 || t.DataOffsets[0] > t.DataOffsets[1] {
+			return invalid("tensor %q has invalid data offsets %v", name, t.DataOffsets)
+		}
+		tensors = append(tensors, t)
+	}
+
+	dataSize := uint64(size) - 8 - headerSize
+	sort.Slice(tensors, func(i, j int) bool {
+		if tensors[i].DataOffsets[0] != tensors[j].DataOffsets[0] {
+			return tensors[i].DataOffsets[0] < tensors[j].DataOffsets[0]
+		}
+		return tensors[i].DataOffsets[1] < tensors[j].DataOffsets[1]
+	})
+	var end uint64
+	for _, t := range tensors {
+		bits, ok := safetensorsDtypeBits[t.Dtype]
+		if !ok {
+			return invalid("tensor %q has unknown dtype %q", t.name, t.Dtype)
+		}
+		if t.DataOffsets[0] != end {
+			return invalid("tensor %q starts at %d, expected %d: tensors must not overlap or leave gaps", t.name, t.DataOffsets[0], end)
+		}
+		numel := uint64(1)
+		for _, dim := range t.Shape {
+			if dim != 0 && numel > math.MaxUint64/dim {
+				return invalid("tensor %q shape %v overflows", t.name, t.Shape)
+			}
+			numel *= dim
+		}
+		if numel > math.MaxUint64/bits {
+			return invalid("tensor %q shape %v overflows", t.name, t.Shape)
+		}
+		if numel*bits%8 != 0 || numel*bits/8 != t.DataOffsets[1]-t.DataOffsets[0] {
+			return invalid("tensor %q of dtype %s and shape %v does not match its %d data bytes",
+				t.name, t.Dtype, t.Shape, t.DataOffsets[1]-t.DataOffsets[0])
+		}
+		end = t.DataOffsets[1]
+	}
+	if end != dataSize {
+		return invalid("tensors cover %d data bytes but the file holds %d", end, dataSize)
+	}
+	return types.ModelFileScanResult{Status: types.ModelFileScanSafe, Format: types.ModelFileFormatSafetensors}
+}
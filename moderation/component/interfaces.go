@@ -22,6 +22,11 @@ type RepoFileComponent interface {
 	DetectRepoSensitiveCheckStatus(ctx context.Context, repoId int64, branch string) error
 }
 
+type ModelFileScanComponent interface {
+	// ScanRepoModelFiles scans the model weight files of a repository which have not been scanned yet
+	ScanRepoModelFiles(ctx context.Context, repoID int64) error
+}
+
 type SensitiveWordSetComponent interface {
 	Index(ctx context.Context, req types.SensitiveWordSetListReq) ([]types.SensitiveWordSet, int, error)
 	Get(ctx context.Context, id int64) (*types.SensitiveWordSet, error)
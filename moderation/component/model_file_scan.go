@@ -0,0 +1,187 @@
+package component
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+
+	"github.com/minio/minio-go/v7"
+	"opencsg.com/csghub-server/builder/git"
+	"opencsg.com/csghub-server/builder/git/gitserver"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/builder/store/s3"
+	"opencsg.com/csghub-server/common/config"
+	"opencsg.com/csghub-server/common/types"
+	"opencsg.com/csghub-server/common/utils/common"
+	"opencsg.com/csghub-server/moderation/checker"
+)
+
+// maxGitModelFileSize bounds model files stored in git instead of lfs, they are read into memory
+const maxGitModelFileSize = 64 * 1024 * 1024
+
+type modelFileScanComponentImpl struct {
+	rfs              database.RepoFileStore
+	mfs              database.ModelFileScanStore
+	git              gitserver.GitServer
+	s3Client         s3.Client
+	lfsBucket        string
+	scanner          checker.ModelFileScanner
+	concurrencyLimit int
+}
+
+func NewModelFileScanComponent(cfg *config.Config) (ModelFileScanComponent, error) {
+	gs, err := git.NewGitServer(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create git server, error: %w", err)
+	}
+	s3Client, err := s3.NewMinio(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create s3 client, error: %w", err)
+	}
+	return &modelFileScanComponentImpl{
+		rfs:              database.NewRepoFileStore(),
+		mfs:              database.NewModelFileScanStore(),
+		git:              gs,
+		s3Client:         s3Client,
+		lfsBucket:        cfg.S3.Bucket,
+		scanner:          checker.NewModelFileScanner(),
+		concurrencyLimit: max(cfg.Moderation.RepoFileCheckConcurrency, 1),
+	}, nil
+}
+
+func (c *modelFileScanComponentImpl) ScanRepoModelFiles(ctx context.Context, repoID int64) error {
+	var lastRepoFileID int64
+	batchSize := int64(100)
+	for {
+		files, err := c.rfs.BatchGet(ctx, repoID, lastRepoFileID, batchSize)
+		if err != nil {
+			return fmt.Errorf("failed to get repo files, repoID: %d, lastRepoFileID: %d, error: %w", repoID, lastRepoFileID, err)
+		}
+		pending, err := c.unscannedModelFiles(ctx, files)
+		if err != nil {
+			return err
+		}
+
+		var wg sync.WaitGroup
+		guard := make(chan struct{}, c.concurrencyLimit)
+		for _, file := range pending {
+			wg.Add(1)
+			guard <- struct{}{}
+			go func(f *database.RepositoryFile) {
+				defer wg.Done()
+				c.scanFile(ctx, f)
+				<-guard
+			}(file)
+		}
+		wg.Wait()
+
+		if len(files) < int(batchSize) {
+			return nil
+		}
+		lastRepoFileID = files[len(files)-1].ID
+	}
+}
+
+func (c *modelFileScanComponentImpl) unscannedModelFiles(ctx context.Context, files []*database.RepositoryFile) ([]*database.RepositoryFile, error) {
+	var ids []int64
+	for _, f := range files {
+		if types.IsModelScanFile(f.Path) {
+			ids = append(ids, f.ID)
+		}
+	}
+	scans, err := c.mfs.FindByRepoFileIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get model file scans, error: %w", err)
+	}
+	scanned := make(map[int64]bool, len(scans))
+	for _, s := range scans {
+		scanned[s.RepoFileID] = true
+	}
+	var pending []*database.RepositoryFile
+	for _, f := range files {
+		if types.IsModelScanFile(f.Path) && !scanned[f.ID] {
+			pending = append(pending, f)
+		}
+	}
+	return pending, nil
+}
+
+func (c *modelFileScanComponentImpl) scanFile(ctx context.Context, file *database.RepositoryFile) {
+	res := c.scan(ctx, file)
+	if res.Status == types.ModelFileScanUnsafe {
+		slog.WarnContext(ctx, "detect unsafe model file", slog.Int64("repo_id", file.RepositoryID),
+			slog.String("path", file.Path), slog.Any("findings", res.Findings))
+	}
+	err := c.mfs.Upsert(ctx, &database.ModelFileScan{
+		RepositoryID: file.RepositoryID,
+		RepoFileID:   file.ID,
+		Path:         file.Path,
+		Branch:       file.Branch,
+		FileSHA:      file.CommitSha,
+		Status:       res.Status,
+		Format:       res.Format,
+		Message:      res.Message,
+		Findings:     res.Findings,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to save model file scan", slog.Int64("repo_file_id", file.ID), slog.Any("error", err))
+	}
+}
+
+func (c *modelFileScanComponentImpl) scan(ctx context.Context, file *database.RepositoryFile) types.ModelFileScanResult {
+	scanError := func(format string, args ...any) types.ModelFileScanResult {
+		return types.ModelFileScanResult{Status: types.ModelFileScanError, Format: types.ModelFileFormatUnknown,
+			Message: fmt.Sprintf(format, args...)}
+	}
+	repo := file.Repository
+
+	if file.LfsRelativePath != "" {
+		if repo.XnetEnabled {
+			return types.ModelFileScanResult{Status: types.ModelFileScanSkipped, Format: types.ModelFileFormatUnknown,
+				Message: "files stored in xnet are not scanned"}
+		}
+		oid := strings.ReplaceAll(file.LfsRelativePath, "/", "")
+		objectKey := common.BuildLfsPath(repo.ID, oid, repo.Migrated)
+		obj, err := c.s3Client.GetObject(ctx, c.lfsBucket, objectKey, minio.GetObjectOptions{})
+		if err != nil {
+			return scanError("failed to get lfs object, %v", err)
+		}
+		defer obj.Close()
+		info, err := obj.Stat()
+		if err != nil {
+			return scanError("failed to stat lfs object, %v", err)
+		}
+		// minio objects support random access, so only the parts needed are downloaded
+		return c.scanner.Scan(ctx, file.Path, obj, info.Size)
+	}
+
+	namespace, name := repo.NamespaceAndName()
+	ref := file.Branch
+	if ref == "" {
+		ref = repo.DefaultBranch
+	}
+	reader, _, err := c.git.GetRepoFileReader(ctx, gitserver.GetRepoInfoByPathReq{
+		Namespace: namespace,
+		Name:      name,
+		Path:      file.Path,
+		RepoType:  repo.RepositoryType,
+		Ref:       ref,
+	})
+	if err != nil {
+		return scanError("failed to read file from git, %v", err)
+	}
+	defer reader.Close()
+	data, err := io.ReadAll(io.LimitReader(reader, maxGitModelFileSize+1))
+	if err != nil {
+		return scanError("failed to read file from git, %v", err)
+	}
+	if len(data) > maxGitModelFileSize {
+		return types.ModelFileScanResult{Status: types.ModelFileScanSkipped, Format: types.ModelFileFormatUnknown,
+			Message: "file stored in git is too large to scan"}
+	}
+	return c.scanner.Scan(ctx, file.Path, bytes.NewReader(data), int64(len(data)))
+}
@@ -0,0 +1,52 @@
+package component
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	mockgit "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/builder/git/gitserver"
+	mockdb "opencsg.com/csghub-server/_mocks/opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/builder/git/gitserver"
+	"opencsg.com/csghub-server/builder/store/database"
+	"opencsg.com/csghub-server/common/types"
+	"opencsg.com/csghub-server/moderation/checker"
+)
+
+func TestModelFileScanComponent_ScanRepoModelFiles(t *testing.T) {
+	mockRepoFileStore := mockdb.NewMockRepoFileStore(t)
+	mockScanStore := mockdb.NewMockModelFileScanStore(t)
+	mockGitServer := mockgit.NewMockGitServer(t)
+	c := &modelFileScanComponentImpl{
+		rfs:              mockRepoFileStore,
+		mfs:              mockScanStore,
+		git:              mockGitServer,
+		scanner:          checker.NewModelFileScanner(),
+		concurrencyLimit: 2,
+	}
+
+	repo := &database.Repository{ID: 1, Path: "ns/model", DefaultBranch: "main", RepositoryType: types.ModelRepo}
+	files := []*database.RepositoryFile{
+		{ID: 1, RepositoryID: 1, Path: "README.md", Branch: "main", Repository: repo},
+		{ID: 2, RepositoryID: 1, Path: "model.pkl", Branch: "main", CommitSha: "sha2", Repository: repo},
+		{ID: 3, RepositoryID: 1, Path: "old.bin", Branch: "main", Repository: repo},
+	}
+	mockRepoFileStore.EXPECT().BatchGet(mock.Anything, int64(1), int64(0), int64(100)).Return(files, nil)
+	mockScanStore.EXPECT().FindByRepoFileIDs(mock.Anything, []int64{2, 3}).Return([]database.ModelFileScan{{RepoFileID: 3}}, nil)
+
+	// protocol 0 pickle calling os.system
+	pickle := "cos\nsystem\n(S'id'\ntR."
+	mockGitServer.EXPECT().GetRepoFileReader(mock.Anything, gitserver.GetRepoInfoByPathReq{
+		Namespace: "ns", Name: "model", Path: "model.pkl", RepoType: types.ModelRepo, Ref: "main",
+	}).Return(io.NopCloser(bytes.NewReader([]byte(pickle))), int64(len(pickle)), nil)
+	mockScanStore.EXPECT().Upsert(mock.Anything, mock.MatchedBy(func(s *database.ModelFileScan) bool {
+		return s.RepoFileID == 2 && s.FileSHA == "sha2" && s.Status == types.ModelFileScanUnsafe &&
+			s.Format == types.ModelFileFormatPickle && len(s.Findings) == 1 && s.Findings[0].Module == "os"
+	})).Return(nil)
+
+	err := c.ScanRepoModelFiles(context.Background(), 1)
+	require.NoError(t, err)
+}
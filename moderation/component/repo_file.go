@@ -88,6 +88,8 @@ func (c *repoFileComponentImpl) createRepoFileRecords(ctx context.Context, repo
 	var (
 		files  []*types.File
 		cursor string
+		// listed is true when the whole tree is listed, the stale files are only removed then
+		listed bool
 	)
 	for {
 		resp, err := c.gs.GetTree(ctx, types.GetTreeRequest{
@@ -116,9 +118,11 @@ func (c *repoFileComponentImpl) createRepoFileRecords(ctx context.Context, repo
 		}
 
 		if resp.Cursor == "" {
+			listed = true
 			break
 		}
 	}
+	current := make([]database.RepositoryFile, 0, len(files))
 	//get all files
 	for _, file := range files {
 		// save repo files into db
@@ -131,6 +135,7 @@ func (c *repoFileComponentImpl) createRepoFileRecords(ctx context.Context, repo
 			LfsRelativePath: file.LfsRelativePath,
 			Branch:          repo.DefaultBranch,
 		}
+		current = append(current, rf)
 
 		var exists bool
 		var err error
@@ -150,6 +155,18 @@ func (c *repoFileComponentImpl) createRepoFileRecords(ctx context.Context, repo
 			return fmt.Errorf("failed to save repository file, error: %w", err)
 		}
 	}
+
+	if listed {
+		// the records of the deleted files and the older versions of the files are removed, with their
+		// sensitive check records, so they don't fail the sensitive check of the repository any more
+		removed, err := c.rfs.DeleteStale(ctx, repo.ID, repo.DefaultBranch, current)
+		if err != nil {
+			return fmt.Errorf("failed to remove stale repository files, error: %w", err)
+		}
+		if removed > 0 {
+			slog.InfoContext(ctx, "stale repository files removed", slog.Any("repo_id", repo.ID), slog.Int("count", removed))
+		}
+	}
 	return nil
 }
 
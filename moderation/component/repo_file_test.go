@@ -55,6 +55,7 @@ func TestRepoFileComponent_GenRepoFileRecords(t *testing.T) {
 		}
 		mockRepoFileStore.EXPECT().Exists(mock.Anything, rf).Return(false, nil)
 		mockRepoFileStore.EXPECT().Create(mock.Anything, &rf).Return(nil)
+		mockRepoFileStore.EXPECT().DeleteStale(mock.Anything, repo.ID, repo.DefaultBranch, []database.RepositoryFile{rf}).Return(0, nil)
 
 		err := componentImpl.GenRepoFileRecords(ctx, repo)
 		require.NoError(t, err)
@@ -40,6 +40,24 @@ func CheckRepoFiles(ctx context.Context, repo *database.Repository, config *conf
 	return nil
 }
 
+// ScanModelFiles scans the model weight files of a repository for code executed on load
+// and for malformed safetensors and gguf files.
+func ScanModelFiles(ctx context.Context, repo *database.Repository, config *config.Config) error {
+	logger := activity.GetLogger(ctx)
+	logger.Info("scan model files start", "repo_path", repo.Path)
+	mc, err := component.NewModelFileScanComponent(config)
+	if err != nil {
+		return fmt.Errorf("failed to create model file scan component, error: %w", err)
+	}
+	err = mc.ScanRepoModelFiles(ctx, repo.ID)
+	if err != nil {
+		logger.Error("scan model files failed", "error", err, "repo_path", repo.Path)
+		return err
+	}
+	logger.Info("scan model files complete", "repo_path", repo.Path)
+	return nil
+}
+
 // RepoSensitiveCheckPending updates the sensitive check status of a repository to pending.
 // This function is an activity that can be used in a workflow.
 func RepoSensitiveCheckPending(ctx context.Context, repo *database.Repository, config *config.Config) error {
@@ -83,7 +83,12 @@ func (rfc *repoFullCheck) Execute(ctx workflow.Context, repo common.Repo, cfg *c
 		logger.Error("failed to check repo files", "error", err, "repo", repo)
 		return err
 	}
-	// 3. update repo sensitive check status
+	// 3. scan model weight files, a failed scan does not block the sensitive check result
+	err = workflow.ExecuteActivity(actCtx, activity.ScanModelFiles, dbRepo, cfg).Get(ctx, nil)
+	if err != nil {
+		logger.Error("failed to scan model files", "error", err, "repo", repo)
+	}
+	// 4. update repo sensitive check status
 	err = workflow.ExecuteActivity(actCtx, activity.DetectRepoSensitiveCheckStatus, dbRepo, cfg).Get(ctx, nil)
 	if err != nil {
 		logger.Error("failed to detect repo sensitive check status", "error", err, "repo", repo)
@@ -57,12 +57,14 @@ func TestRepoFullCheckWorkflowSuccess(t *testing.T) {
 	env.RegisterActivity(activity.RepoSensitiveCheckPending)
 	env.RegisterActivity(activity.GenRepoFileList)
 	env.RegisterActivity(activity.CheckRepoFiles)
+	env.RegisterActivity(activity.ScanModelFiles)
 	env.RegisterActivity(activity.DetectRepoSensitiveCheckStatus)
 
 	// Set up activity expectations
 	env.OnActivity(activity.RepoSensitiveCheckPending, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	env.OnActivity(activity.GenRepoFileList, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	env.OnActivity(activity.CheckRepoFiles, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(activity.ScanModelFiles, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	env.OnActivity(activity.DetectRepoSensitiveCheckStatus, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
 	// Execute workflow
@@ -110,6 +112,7 @@ func TestRepoFullCheckWorkflowRepoSensitiveCheckPendingFailed(t *testing.T) {
 	env.RegisterActivity(activity.RepoSensitiveCheckPending)
 	env.RegisterActivity(activity.GenRepoFileList)
 	env.RegisterActivity(activity.CheckRepoFiles)
+	env.RegisterActivity(activity.ScanModelFiles)
 	env.RegisterActivity(activity.DetectRepoSensitiveCheckStatus)
 
 	// Set up activity expectations with RepoSensitiveCheckPending failing
@@ -162,6 +165,7 @@ func TestRepoFullCheckWorkflowGenRepoFileListFailed(t *testing.T) {
 	env.RegisterActivity(activity.RepoSensitiveCheckPending)
 	env.RegisterActivity(activity.GenRepoFileList)
 	env.RegisterActivity(activity.CheckRepoFiles)
+	env.RegisterActivity(activity.ScanModelFiles)
 	env.RegisterActivity(activity.DetectRepoSensitiveCheckStatus)
 
 	// Set up activity expectations with GenRepoFileList failing
@@ -215,6 +219,7 @@ func TestRepoFullCheckWorkflowCheckRepoFilesFailed(t *testing.T) {
 	env.RegisterActivity(activity.RepoSensitiveCheckPending)
 	env.RegisterActivity(activity.GenRepoFileList)
 	env.RegisterActivity(activity.CheckRepoFiles)
+	env.RegisterActivity(activity.ScanModelFiles)
 	env.RegisterActivity(activity.DetectRepoSensitiveCheckStatus)
 
 	// Set up activity expectations with CheckRepoFiles failing
@@ -304,12 +309,14 @@ func TestRepoFullCheckWorkflowDetectRepoSensitiveCheckStatusFailed(t *testing.T)
 	env.RegisterActivity(activity.RepoSensitiveCheckPending)
 	env.RegisterActivity(activity.GenRepoFileList)
 	env.RegisterActivity(activity.CheckRepoFiles)
+	env.RegisterActivity(activity.ScanModelFiles)
 	env.RegisterActivity(activity.DetectRepoSensitiveCheckStatus)
 
 	// Set up activity expectations with DetectRepoSensitiveCheckStatus failing
 	env.OnActivity(activity.RepoSensitiveCheckPending, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	env.OnActivity(activity.GenRepoFileList, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	env.OnActivity(activity.CheckRepoFiles, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(activity.ScanModelFiles, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	expectedError := fmt.Errorf("failed to detect repo sensitive check status")
 	env.OnActivity(activity.DetectRepoSensitiveCheckStatus, mock.Anything, mock.Anything, mock.Anything).Return(expectedError)
 
@@ -320,3 +327,45 @@ func TestRepoFullCheckWorkflowDetectRepoSensitiveCheckStatusFailed(t *testing.T)
 	require.Error(t, env.GetWorkflowError())
 	require.Contains(t, env.GetWorkflowError().Error(), "failed to detect repo sensitive check status")
 }
+
+func TestRepoFullCheckWorkflowScanModelFilesFailed(t *testing.T) {
+	mockRepoStore := mockdb.NewMockRepoStore(t)
+	testRepo := common.Repo{
+		Namespace: "test_user",
+		Name:      "test_repo",
+		RepoType:  types.ModelRepo,
+		Branch:    "main",
+	}
+	testConfig := &config.Config{}
+	dbRepo := &database.Repository{
+		ID:             1,
+		Path:           "test_user/test_repo",
+		DefaultBranch:  "main",
+		Name:           "test_repo",
+		RepositoryType: types.ModelRepo,
+	}
+	mockRepoStore.EXPECT().FindByPath(mock.Anything, testRepo.RepoType, testRepo.Namespace, testRepo.Name).Return(dbRepo, nil)
+
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+	wf := newRepoFullCheckWithDB(mockRepoStore)
+	env.RegisterWorkflow(wf.Execute)
+
+	env.RegisterActivity(activity.RepoSensitiveCheckPending)
+	env.RegisterActivity(activity.GenRepoFileList)
+	env.RegisterActivity(activity.CheckRepoFiles)
+	env.RegisterActivity(activity.ScanModelFiles)
+	env.RegisterActivity(activity.DetectRepoSensitiveCheckStatus)
+
+	// a failed model file scan must not block the sensitive check status
+	env.OnActivity(activity.RepoSensitiveCheckPending, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(activity.GenRepoFileList, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(activity.CheckRepoFiles, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(activity.ScanModelFiles, mock.Anything, mock.Anything, mock.Anything).Return(fmt.Errorf("s3 unavailable"))
+	env.OnActivity(activity.DetectRepoSensitiveCheckStatus, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+
+	env.ExecuteWorkflow(wf.Execute, testRepo, testConfig)
+
+	require.NoError(t, env.GetWorkflowError())
+	env.AssertExpectations(t)
+}
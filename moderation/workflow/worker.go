@@ -16,5 +16,6 @@ func RegisterWorker(wfClient temporal.Client) {
 	wfWorker.RegisterActivity(activity.RepoSensitiveCheckPending)
 	wfWorker.RegisterActivity(activity.GenRepoFileList)
 	wfWorker.RegisterActivity(activity.CheckRepoFiles)
+	wfWorker.RegisterActivity(activity.ScanModelFiles)
 	wfWorker.RegisterActivity(activity.DetectRepoSensitiveCheckStatus)
 }
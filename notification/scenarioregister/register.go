@@ -81,6 +81,54 @@ func Register(d *scenariomgr.DataProvider) {
 		},
 	})
 
+	// register discussion mention scenario
+	scenariomgr.RegisterScenario(types.MessageScenarioDiscussionMention, &scenariomgr.ScenarioDefinition{
+		Channels: []types.MessageChannel{
+			types.MessageChannelInternalMessage,
+			types.MessageChannelEmail,
+		},
+		ChannelGetDataFunc: map[types.MessageChannel]scenariomgr.GetDataFunc{
+			types.MessageChannelInternalMessage: internalnotification.GetSiteInternalMessageData,
+			types.MessageChannelEmail:           internalnotification.GetEmailDataFunc(d.GetNotificationStorage()),
+		},
+	})
+
+	// register repo release scenario
+	scenariomgr.RegisterScenario(types.MessageScenarioRepoRelease, &scenariomgr.ScenarioDefinition{
+		Channels: []types.MessageChannel{
+			types.MessageChannelInternalMessage,
+			types.MessageChannelEmail,
+		},
+		ChannelGetDataFunc: map[types.MessageChannel]scenariomgr.GetDataFunc{
+			types.MessageChannelInternalMessage: internalnotification.GetSiteInternalMessageData,
+			types.MessageChannelEmail:           internalnotification.GetEmailDataFunc(d.GetNotificationStorage()),
+		},
+	})
+
+	// register repo access request scenario
+	scenariomgr.RegisterScenario(types.MessageScenarioRepoAccessRequest, &scenariomgr.ScenarioDefinition{
+		Channels: []types.MessageChannel{
+			types.MessageChannelInternalMessage,
+			types.MessageChannelEmail,
+		},
+		ChannelGetDataFunc: map[types.MessageChannel]scenariomgr.GetDataFunc{
+			types.MessageChannelInternalMessage: internalnotification.GetSiteInternalMessageData,
+			types.MessageChannelEmail:           internalnotification.GetEmailDataFunc(d.GetNotificationStorage()),
+		},
+	})
+
+	// register repo access review scenario
+	scenariomgr.RegisterScenario(types.MessageScenarioRepoAccessReview, &scenariomgr.ScenarioDefinition{
+		Channels: []types.MessageChannel{
+			types.MessageChannelInternalMessage,
+			types.MessageChannelEmail,
+		},
+		ChannelGetDataFunc: map[types.MessageChannel]scenariomgr.GetDataFunc{
+			types.MessageChannelInternalMessage: internalnotification.GetSiteInternalMessageData,
+			types.MessageChannelEmail:           internalnotification.GetEmailDataFunc(d.GetNotificationStorage()),
+		},
+	})
+
 	// register resource application scenario
 	scenariomgr.RegisterScenario(types.MessageScenarioResourceApplication, &scenariomgr.ScenarioDefinition{
 		Channels: []types.MessageChannel{
@@ -115,5 +163,39 @@ func Register(d *scenariomgr.DataProvider) {
 		},
 	})
 
+	// register storage-quota scenario
+	scenariomgr.RegisterScenario(types.MessageScenarioStorageQuota, &scenariomgr.ScenarioDefinition{
+		Channels: []types.MessageChannel{
+			types.MessageChannelInternalMessage,
+			types.MessageChannelEmail,
+		},
+		ChannelGetDataFunc: map[types.MessageChannel]scenariomgr.GetDataFunc{
+			types.MessageChannelInternalMessage: internalnotification.GetSiteInternalMessageData,
+			types.MessageChannelEmail:           internalnotification.GetEmailDataFunc(d.GetNotificationStorage()),
+		},
+	})
+
+	// register deploy-alert scenario
+	scenariomgr.RegisterScenario(types.MessageScenarioDeployAlert, &scenariomgr.ScenarioDefinition{
+		Channels: []types.MessageChannel{
+			types.MessageChannelInternalMessage,
+			types.MessageChannelEmail,
+		},
+		ChannelGetDataFunc: map[types.MessageChannel]scenariomgr.GetDataFunc{
+			types.MessageChannelInternalMessage: internalnotification.GetSiteInternalMessageData,
+			types.MessageChannelEmail:           internalnotification.GetEmailDataFunc(d.GetNotificationStorage()),
+		},
+	})
+
+	// register feed-digest scenario
+	scenariomgr.RegisterScenario(types.MessageScenarioFeedDigest, &scenariomgr.ScenarioDefinition{
+		Channels: []types.MessageChannel{
+			types.MessageChannelEmail,
+		},
+		ChannelGetDataFunc: map[types.MessageChannel]scenariomgr.GetDataFunc{
+			types.MessageChannelEmail: internalnotification.GetEmailDataFunc(d.GetNotificationStorage()),
+		},
+	})
+
 	extend(d)
 }
@@ -0,0 +1,10 @@
+{{/* title section */}}
+Alert {{.rule_name}} of {{.deploy_name}} is {{.state}}
+---
+{{/* content section */}}
+<html>
+	<body>
+		<h3>Alert {{.rule_name}} of {{.deploy_name}} is {{.state}}</h3>
+		<p>The {{.metric}} of {{.deploy_name}} is {{.value}}, the alert threshold is {{.operator}} {{.threshold}}.</p>
+	</body>
+</html>
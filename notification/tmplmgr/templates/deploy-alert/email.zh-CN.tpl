@@ -0,0 +1,10 @@
+{{/* title section */}}
+{{.deploy_name}} 的告警 {{.rule_name}}{{if eq .state "firing"}}已触发{{else}}已恢复{{end}}
+---
+{{/* content section */}}
+<html>
+	<body>
+		<h3>{{.deploy_name}} 的告警 {{.rule_name}}{{if eq .state "firing"}}已触发{{else}}已恢复{{end}}</h3>
+		<p>{{.deploy_name}} 的 {{.metric}} 当前为 {{.value}}，告警阈值为 {{.operator}} {{.threshold}}。</p>
+	</body>
+</html>
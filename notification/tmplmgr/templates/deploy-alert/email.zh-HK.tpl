@@ -0,0 +1,10 @@
+{{/* title section */}}
+{{.deploy_name}} 的告警 {{.rule_name}}{{if eq .state "firing"}}已觸發{{else}}已恢復{{end}}
+---
+{{/* content section */}}
+<html>
+	<body>
+		<h3>{{.deploy_name}} 的告警 {{.rule_name}}{{if eq .state "firing"}}已觸發{{else}}已恢復{{end}}</h3>
+		<p>{{.deploy_name}} 的 {{.metric}} 當前為 {{.value}}，告警閾值為 {{.operator}} {{.threshold}}。</p>
+	</body>
+</html>
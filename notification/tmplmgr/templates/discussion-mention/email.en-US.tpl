@@ -0,0 +1,10 @@
+{{/* title section */}}
+{{html .sender}} mentioned you in {{.repo_path}}
+---
+{{/* content section */}}
+<html>
+    <body>
+        <h3>{{html .sender}} mentioned you in {{.repo_path}}</h3>
+        <p>{{html .sender}} mentioned you in the discussion "{{html .title}}" of the {{.repo_type}} {{.repo_path}}. Join the conversation!</p>
+    </body>
+</html>
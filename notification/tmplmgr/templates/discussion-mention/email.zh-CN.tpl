@@ -0,0 +1,10 @@
+{{/* title section */}}
+{{html .sender}} 在 {{.repo_path}} 中提到了你
+---
+{{/* content section */}}
+<html>
+    <body>
+        <h3>{{html .sender}} 在 {{.repo_path}} 中提到了你</h3>
+        <p>{{html .sender}} 在{{.repo_type}} {{.repo_path}} 的讨论“{{html .title}}”中提到了你，快来参与讨论吧！</p>
+    </body>
+</html>
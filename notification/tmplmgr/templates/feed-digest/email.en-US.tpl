@@ -0,0 +1,20 @@
+{{/* title section */}}
+{{.total}} new activities from the accounts and repositories you follow
+---
+{{/* content section */}}
+<html>
+	<body>
+		<h3>{{.total}} new activities from the accounts and repositories you follow</h3>
+		<ul>
+		{{- range .events}}
+			<li>
+			{{- if eq .type "repo_created"}}{{.actor}} created {{.repo_path}}
+			{{- else if eq .type "tag_created"}}New tag {{.tag}} in {{.repo_path}}
+			{{- else if eq .type "space_deployed"}}New version of {{.repo_path}} is running
+			{{- else if eq .type "discussion_created"}}{{.actor}} opened the discussion "{{.title}}" in {{.repo_path}}
+			{{- else}}{{.type}} in {{.repo_path}}{{end -}}
+			</li>
+		{{- end}}
+		</ul>
+	</body>
+</html>
@@ -0,0 +1,20 @@
+{{/* title section */}}
+你关注的账号和仓库有 {{.total}} 条新动态
+---
+{{/* content section */}}
+<html>
+	<body>
+		<h3>你关注的账号和仓库有 {{.total}} 条新动态</h3>
+		<ul>
+		{{- range .events}}
+			<li>
+			{{- if eq .type "repo_created"}}{{.actor}} 创建了 {{.repo_path}}
+			{{- else if eq .type "tag_created"}}{{.repo_path}} 发布了新标签 {{.tag}}
+			{{- else if eq .type "space_deployed"}}{{.repo_path}} 的新版本已运行
+			{{- else if eq .type "discussion_created"}}{{.actor}} 在 {{.repo_path}} 发起了讨论“{{.title}}”
+			{{- else}}{{.repo_path}}: {{.type}}{{end -}}
+			</li>
+		{{- end}}
+		</ul>
+	</body>
+</html>
@@ -0,0 +1,20 @@
+{{/* title section */}}
+你關注的賬號和倉庫有 {{.total}} 條新動態
+---
+{{/* content section */}}
+<html>
+	<body>
+		<h3>你關注的賬號和倉庫有 {{.total}} 條新動態</h3>
+		<ul>
+		{{- range .events}}
+			<li>
+			{{- if eq .type "repo_created"}}{{.actor}} 創建了 {{.repo_path}}
+			{{- else if eq .type "tag_created"}}{{.repo_path}} 發佈了新標籤 {{.tag}}
+			{{- else if eq .type "space_deployed"}}{{.repo_path}} 的新版本已運行
+			{{- else if eq .type "discussion_created"}}{{.actor}} 在 {{.repo_path}} 發起了討論「{{.title}}」
+			{{- else}}{{.repo_path}}: {{.type}}{{end -}}
+			</li>
+		{{- end}}
+		</ul>
+	</body>
+</html>
@@ -0,0 +1,10 @@
+{{/* title section */}}
+{{html .username}} requested access to {{.repo_path}}
+---
+{{/* content section */}}
+<html>
+    <body>
+        <h3>{{html .username}} requested access to {{.repo_path}}</h3>
+        <p>{{html .username}} requested access to the {{.repo_type}} {{.repo_path}}, the request is {{.status}}. Review the access requests in the repository settings.</p>
+    </body>
+</html>
@@ -0,0 +1,10 @@
+{{/* title section */}}
+{{html .username}} 申请访问 {{.repo_path}}
+---
+{{/* content section */}}
+<html>
+    <body>
+        <h3>{{html .username}} 申请访问 {{.repo_path}}</h3>
+        <p>{{html .username}} 申请访问{{.repo_type}} {{.repo_path}}，申请状态：{{.status}}。请在仓库设置中审核访问申请。</p>
+    </body>
+</html>
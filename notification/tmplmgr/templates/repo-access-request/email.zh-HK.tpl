@@ -0,0 +1,10 @@
+{{/* title section */}}
+{{html .username}} 申請訪問 {{.repo_path}}
+---
+{{/* content section */}}
+<html>
+    <body>
+        <h3>{{html .username}} 申請訪問 {{.repo_path}}</h3>
+        <p>{{html .username}} 申請訪問{{.repo_type}} {{.repo_path}}，申請狀態：{{.status}}。請在倉庫設置中審核訪問申請。</p>
+    </body>
+</html>
@@ -0,0 +1,10 @@
+{{/* title section */}}
+Your access request to {{.repo_path}} is {{.status}}
+---
+{{/* content section */}}
+<html>
+    <body>
+        <h3>Your access request to {{.repo_path}} is {{.status}}</h3>
+        <p>Your access request to the {{.repo_type}} {{.repo_path}} is {{.status}}. {{html .review_msg}}</p>
+    </body>
+</html>
@@ -0,0 +1,10 @@
+{{/* title section */}}
+你对 {{.repo_path}} 的访问申请已{{.status}}
+---
+{{/* content section */}}
+<html>
+    <body>
+        <h3>你对 {{.repo_path}} 的访问申请已{{.status}}</h3>
+        <p>你对{{.repo_type}} {{.repo_path}} 的访问申请已{{.status}}。{{html .review_msg}}</p>
+    </body>
+</html>
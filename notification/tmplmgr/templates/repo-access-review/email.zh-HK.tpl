@@ -0,0 +1,10 @@
+{{/* title section */}}
+你對 {{.repo_path}} 的訪問申請已{{.status}}
+---
+{{/* content section */}}
+<html>
+    <body>
+        <h3>你對 {{.repo_path}} 的訪問申請已{{.status}}</h3>
+        <p>你對{{.repo_type}} {{.repo_path}} 的訪問申請已{{.status}}。{{html .review_msg}}</p>
+    </body>
+</html>
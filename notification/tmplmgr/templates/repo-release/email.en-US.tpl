@@ -0,0 +1,10 @@
+{{/* title section */}}
+{{.repo_path}} released {{html .release_name}}
+---
+{{/* content section */}}
+<html>
+    <body>
+        <h3>{{.repo_path}} released {{html .release_name}}</h3>
+        <p>The {{.repo_type}} {{.repo_path}} you follow or like released {{html .release_name}}: {{html .title}}. Check out what's new!</p>
+    </body>
+</html>
@@ -0,0 +1,10 @@
+{{/* title section */}}
+{{.repo_path}} 发布了 {{html .release_name}}
+---
+{{/* content section */}}
+<html>
+    <body>
+        <h3>{{.repo_path}} 发布了 {{html .release_name}}</h3>
+        <p>你关注或点赞的{{.repo_type}} {{.repo_path}} 发布了 {{html .release_name}}：{{html .title}}，快来看看有哪些更新吧！</p>
+    </body>
+</html>
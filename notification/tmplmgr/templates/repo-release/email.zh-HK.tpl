@@ -0,0 +1,10 @@
+{{/* title section */}}
+{{.repo_path}} 發佈了 {{html .release_name}}
+---
+{{/* content section */}}
+<html>
+    <body>
+        <h3>{{.repo_path}} 發佈了 {{html .release_name}}</h3>
+        <p>你關注或點讚的{{.repo_type}} {{.repo_path}} 發佈了 {{html .release_name}}：{{html .title}}，快來看看有哪些更新吧！</p>
+    </body>
+</html>
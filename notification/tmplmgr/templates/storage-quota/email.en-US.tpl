@@ -0,0 +1,10 @@
+{{/* title section */}}
+Storage of {{.namespace}} is {{.percent}}% full
+---
+{{/* content section */}}
+<html>
+	<body>
+		<h3>Storage of {{.namespace}} is {{.percent}}% full</h3>
+		<p>The repositories of {{.namespace}} use {{.percent}}% of the storage quota. Pushes and uploads will be rejected once the quota is exceeded.</p>
+	</body>
+</html>
\ No newline at end of file
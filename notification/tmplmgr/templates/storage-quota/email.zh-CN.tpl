@@ -0,0 +1,10 @@
+{{/* title section */}}
+{{.namespace}} 的存储已使用 {{.percent}}%
+---
+{{/* content section */}}
+<html>
+	<body>
+		<h3>{{.namespace}} 的存储已使用 {{.percent}}%</h3>
+		<p>{{.namespace}} 的仓库已使用存储配额的 {{.percent}}%，超出配额后推送和上传将被拒绝。</p>
+	</body>
+</html>
\ No newline at end of file
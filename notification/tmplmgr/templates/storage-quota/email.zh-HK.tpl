@@ -0,0 +1,10 @@
+{{/* title section */}}
+{{.namespace}} 的存儲已使用 {{.percent}}%
+---
+{{/* content section */}}
+<html>
+	<body>
+		<h3>{{.namespace}} 的存儲已使用 {{.percent}}%</h3>
+		<p>{{.namespace}} 的倉庫已使用存儲配額的 {{.percent}}%，超出配額後推送和上傳將被拒絕。</p>
+	</body>
+</html>
\ No newline at end of file
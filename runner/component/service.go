@@ -301,6 +301,8 @@ func (s *serviceComponentImpl) generateService(ctx context.Context, cluster *clu
 		},
 	}
 
+	addServiceSecretEnv(&service.Spec.Template.Spec.Containers[0], request)
+
 	// fill node affinity
 	nodeutils.FillAffinity(&service.Spec.ConfigurationSpec.Template.Spec.PodSpec.Affinity, nodeAffinity)
 
@@ -326,6 +328,84 @@ func (s *serviceComponentImpl) getNimSecret(ctx context.Context, cluster *cluste
 	return string(secret.Data["NGC_API_KEY"]), nil
 }
 
+// serviceSecretName is the name of the kubernetes secret holding the secret env variables of the service
+func serviceSecretName(svcName string) string {
+	return svcName + "-secrets"
+}
+
+// addServiceSecretEnv loads the secret env variables of the service into the container, the secrets are kept
+// out of the pod spec and the plain env wins if a key is in both. The single host, multi-host and PD services
+// all load their secrets this way.
+func addServiceSecretEnv(container *corev1.Container, req types.SVCRequest) {
+	if len(req.Secrets) == 0 {
+		return
+	}
+	container.EnvFrom = append(container.EnvFrom, corev1.EnvFromSource{
+		SecretRef: &corev1.SecretEnvSource{
+			LocalObjectReference: corev1.LocalObjectReference{Name: serviceSecretName(req.SvcName)},
+		},
+	})
+}
+
+// usesServiceSecret reports whether the service loads its env variables from the service secret
+func usesServiceSecret(ksvc *v1.Service) bool {
+	if ksvc == nil {
+		return false
+	}
+	name := serviceSecretName(ksvc.Name)
+	for _, container := range ksvc.Spec.Template.Spec.Containers {
+		for _, envFrom := range container.EnvFrom {
+			if envFrom.SecretRef != nil && envFrom.SecretRef.Name == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// applyServiceSecret creates or replaces the kubernetes secret holding the secret env variables of the service,
+// and removes the secret left by the previous deploy if the service has no secret anymore
+func (s *serviceComponentImpl) applyServiceSecret(ctx context.Context, cluster *cluster.Cluster, req types.SVCRequest, secretExists bool) error {
+	if len(req.Secrets) == 0 {
+		if !secretExists {
+			return nil
+		}
+		return s.deleteServiceSecret(ctx, cluster, req.SvcName)
+	}
+	if cluster.Client == nil {
+		return fmt.Errorf("failed to apply secret of service %s, cluster %s has no kubernetes client", req.SvcName, cluster.ID)
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        serviceSecretName(req.SvcName),
+			Namespace:   s.k8sNameSpace,
+			Annotations: map[string]string{KeyDeployID: strconv.FormatInt(req.DeployID, 10)},
+		},
+		Type:       corev1.SecretTypeOpaque,
+		StringData: req.Secrets,
+	}
+	secrets := cluster.Client.CoreV1().Secrets(s.k8sNameSpace)
+	_, err := secrets.Update(ctx, secret, metav1.UpdateOptions{})
+	if k8serrors.IsNotFound(err) {
+		_, err = secrets.Create(ctx, secret, metav1.CreateOptions{})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to apply secret of service %s, error: %w", req.SvcName, err)
+	}
+	return nil
+}
+
+func (s *serviceComponentImpl) deleteServiceSecret(ctx context.Context, cluster *cluster.Cluster, svcName string) error {
+	if cluster.Client == nil {
+		return nil
+	}
+	err := cluster.Client.CoreV1().Secrets(s.k8sNameSpace).Delete(ctx, serviceSecretName(svcName), metav1.DeleteOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete secret of service %s, error: %w", svcName, err)
+	}
+	return nil
+}
+
 func (s *serviceComponentImpl) getServicePodsWithStatus(ctx context.Context, cluster *cluster.Cluster, svcName string, namespace string) (*types.InstanceInfo, []types.Revision, error) {
 	labelSelector := fmt.Sprintf("%s=%s", KeyServiceLabel, svcName)
 	// Get the list of Pods based on the label selector
@@ -1065,15 +1145,40 @@ func (s *serviceComponentImpl) GetServiceByName(ctx context.Context, svcName, cl
 
 func (s *serviceComponentImpl) RunService(ctx context.Context, req types.SVCRequest) error {
 	if req.PD != nil && req.PD.Enabled {
-		return s.runServicePD(ctx, req)
+		return s.runGroupService(ctx, req, s.runServicePD)
 	}
 	if req.Hardware.Replicas > 1 {
-		return s.runServiceMultiHost(ctx, req)
+		return s.runGroupService(ctx, req, s.runServiceMultiHost)
 	} else {
 		return s.runServiceSingleHost(ctx, req)
 	}
 }
 
+// runGroupService runs the multi-host and PD services, their secret is applied before the pods are created
+// and the pods load it with addServiceSecretEnv like the single host services. The secret is removed if the
+// service fails to run, the one left by a previous deploy is removed when the service is stopped or purged.
+func (s *serviceComponentImpl) runGroupService(ctx context.Context, req types.SVCRequest, run func(context.Context, types.SVCRequest) error) error {
+	if len(req.Secrets) == 0 {
+		return run(ctx, req)
+	}
+	cluster, err := s.clusterPool.GetClusterByID(ctx, req.ClusterID)
+	if err != nil {
+		return fmt.Errorf("fail to get cluster, error %v ", err)
+	}
+	err = s.applyServiceSecret(ctx, cluster, req, false)
+	if err != nil {
+		return err
+	}
+	err = run(ctx, req)
+	if err != nil {
+		if delErr := s.deleteServiceSecret(ctx, cluster, req.SvcName); delErr != nil {
+			slog.ErrorContext(ctx, "failed to clean up secret of service", slog.String("svc_name", req.SvcName), slog.Any("error", delErr))
+		}
+		return err
+	}
+	return nil
+}
+
 // RunService
 func (s *serviceComponentImpl) runServiceSingleHost(ctx context.Context, req types.SVCRequest) error {
 	cluster, err := s.clusterPool.GetClusterByID(ctx, req.ClusterID)
@@ -1082,7 +1187,8 @@ func (s *serviceComponentImpl) runServiceSingleHost(ctx context.Context, req typ
 	}
 	slog.Info("get cluster for run service", slog.Any("cluster", cluster))
 	// check if the ksvc exists
-	_, err = cluster.KnativeClient.ServingV1().Services(s.k8sNameSpace).Get(ctx, req.SvcName, metav1.GetOptions{})
+	existing, err := cluster.KnativeClient.ServingV1().Services(s.k8sNameSpace).Get(ctx, req.SvcName, metav1.GetOptions{})
+	secretExists := err == nil && usesServiceSecret(existing)
 	if err == nil {
 		err = s.removeServiceForcely(ctx, cluster, req.SvcName)
 		if err != nil {
@@ -1094,6 +1200,10 @@ func (s *serviceComponentImpl) runServiceSingleHost(ctx context.Context, req typ
 	if err != nil {
 		return fmt.Errorf("fail to generate service, %v ", err)
 	}
+	err = s.applyServiceSecret(ctx, cluster, req, secretExists)
+	if err != nil {
+		return err
+	}
 	volumes := []corev1.Volume{}
 	volumeMounts := []corev1.VolumeMount{}
 	if req.DeployType != types.SpaceType {
@@ -1249,6 +1359,10 @@ func (s *serviceComponentImpl) StopService(ctx context.Context, req types.StopRe
 		resp.Message = "failed to remove workset pod"
 		return &resp, fmt.Errorf("failed to remove workset pod, error: %v", err)
 	}
+	err = s.deleteServiceSecret(ctx, cluster, req.SvcName)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to delete service secret", slog.String("svc_name", req.SvcName), slog.Any("error", err))
+	}
 
 	err = s.deleteKServiceWithEvent(ctx, req.SvcName, cluster.ID, taskID)
 	if err != nil {
@@ -1374,7 +1488,14 @@ func (s *serviceComponentImpl) PurgeService(ctx context.Context, req types.Purge
 			return &resp, fmt.Errorf("failed to remove pvc, error: %v", err)
 		}
 	}
-	// 3 clean up workset pod
+	// 3 clean up secret
+	err = s.deleteServiceSecret(ctx, cluster, req.SvcName)
+	if err != nil {
+		resp.Code = -1
+		resp.Message = "failed to remove secret"
+		return &resp, err
+	}
+	// 4 clean up workset pod
 	err = s.RemoveWorkset(ctx, *cluster, ksvc)
 	if err != nil {
 		resp.Code = -1
@@ -83,6 +83,121 @@ func TestServiceComponent_RunService(t *testing.T) {
 	require.Equal(t, corev1.PullAlways, service.Spec.Template.Spec.Containers[0].ImagePullPolicy)
 }
 
+func TestServiceComponent_RunService_Secrets(t *testing.T) {
+	kss := mockdb.NewMockKnativeServiceStore(t)
+	ctx := context.TODO()
+	pool := mockCluster.NewMockPool(t)
+	expectCluster := &cluster.Cluster{
+		CID:           "config",
+		ID:            "test",
+		Client:        fake.NewSimpleClientset(),
+		KnativeClient: knativefake.NewSimpleClientset(),
+	}
+	pool.EXPECT().GetClusterByID(mock.Anything, "test").Return(expectCluster, nil)
+	sc := &serviceComponentImpl{
+		k8sNameSpace:       "test",
+		env:                &config.Config{},
+		modelDockerRegBase: "http://test.com",
+		imagePullSecret:    "test",
+		serviceStore:       kss,
+		clusterPool:        pool,
+		logReporter:        mockReporter.NewMockLogCollector(t),
+	}
+	req := types.SVCRequest{
+		ClusterID:  "test",
+		ImageID:    "test",
+		DeployID:   1,
+		DeployType: types.InferenceType,
+		RepoType:   string(types.ModelRepo),
+		MinReplica: 1,
+		MaxReplica: 1,
+		SvcName:    "test",
+		Env:        map[string]string{"port": "8000"},
+		Secrets:    types.SecretValues{"HF_TOKEN": "hf_xxx"},
+		Annotation: map[string]string{},
+	}
+	kss.EXPECT().Add(mock.Anything, mock.Anything).Return(nil)
+	err := sc.RunService(ctx, req)
+	require.Nil(t, err)
+
+	secret, err := expectCluster.Client.CoreV1().Secrets(sc.k8sNameSpace).Get(ctx, "test-secrets", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "hf_xxx", secret.StringData["HF_TOKEN"])
+	service, err := expectCluster.KnativeClient.ServingV1().Services(sc.k8sNameSpace).Get(ctx, req.SvcName, metav1.GetOptions{})
+	require.NoError(t, err)
+	container := service.Spec.Template.Spec.Containers[0]
+	require.Equal(t, "test-secrets", container.EnvFrom[0].SecretRef.Name)
+	for _, env := range container.Env {
+		require.NotEqual(t, "HF_TOKEN", env.Name)
+	}
+
+	// the secret is removed with the service
+	err = sc.deleteServiceSecret(ctx, expectCluster, req.SvcName)
+	require.NoError(t, err)
+	_, err = expectCluster.Client.CoreV1().Secrets(sc.k8sNameSpace).Get(ctx, "test-secrets", metav1.GetOptions{})
+	require.Error(t, err)
+}
+
+func TestServiceComponent_runGroupService_Secrets(t *testing.T) {
+	ctx := context.TODO()
+	pool := mockCluster.NewMockPool(t)
+	expectCluster := &cluster.Cluster{ID: "test", Client: fake.NewSimpleClientset()}
+	pool.EXPECT().GetClusterByID(mock.Anything, "test").Return(expectCluster, nil)
+	sc := &serviceComponentImpl{k8sNameSpace: "test", clusterPool: pool}
+	req := types.SVCRequest{
+		ClusterID: "test",
+		SvcName:   "test",
+		DeployID:  1,
+		Hardware:  types.HardWare{Replicas: 2},
+		Secrets:   types.SecretValues{"HF_TOKEN": "hf_xxx"},
+	}
+
+	// the secret is applied before the pods of the multi-host service are created
+	err := sc.runGroupService(ctx, req, func(ctx context.Context, req types.SVCRequest) error {
+		secret, err := expectCluster.Client.CoreV1().Secrets(sc.k8sNameSpace).Get(ctx, "test-secrets", metav1.GetOptions{})
+		require.NoError(t, err)
+		require.Equal(t, "hf_xxx", secret.StringData["HF_TOKEN"])
+
+		container := corev1.Container{}
+		addServiceSecretEnv(&container, req)
+		require.Equal(t, "test-secrets", container.EnvFrom[0].SecretRef.Name)
+		return nil
+	})
+	require.NoError(t, err)
+
+	// the secret is removed if the service fails to run
+	err = sc.runGroupService(ctx, req, func(ctx context.Context, req types.SVCRequest) error {
+		return fmt.Errorf("multi-host inference is not supported")
+	})
+	require.Error(t, err)
+	_, err = expectCluster.Client.CoreV1().Secrets(sc.k8sNameSpace).Get(ctx, "test-secrets", metav1.GetOptions{})
+	require.Error(t, err)
+}
+
+func TestServiceComponent_applyServiceSecret(t *testing.T) {
+	ctx := context.TODO()
+	sc := &serviceComponentImpl{k8sNameSpace: "test"}
+	req := types.SVCRequest{SvcName: "test", DeployID: 1}
+
+	// the kubernetes api is not called when the service has no secret
+	err := sc.applyServiceSecret(ctx, &cluster.Cluster{ID: "test"}, req, false)
+	require.NoError(t, err)
+
+	expectCluster := &cluster.Cluster{ID: "test", Client: fake.NewSimpleClientset()}
+	req.Secrets = types.SecretValues{"HF_TOKEN": "hf_xxx"}
+	err = sc.applyServiceSecret(ctx, expectCluster, req, false)
+	require.NoError(t, err)
+	_, err = expectCluster.Client.CoreV1().Secrets(sc.k8sNameSpace).Get(ctx, "test-secrets", metav1.GetOptions{})
+	require.NoError(t, err)
+
+	// the secret of the previous deploy is removed once the service has no secret
+	req.Secrets = nil
+	err = sc.applyServiceSecret(ctx, expectCluster, req, true)
+	require.NoError(t, err)
+	_, err = expectCluster.Client.CoreV1().Secrets(sc.k8sNameSpace).Get(ctx, "test-secrets", metav1.GetOptions{})
+	require.Error(t, err)
+}
+
 func TestServiceComponent_StopService(t *testing.T) {
 	kss := mockdb.NewMockKnativeServiceStore(t)
 	ctx := context.TODO()
@@ -22,6 +22,7 @@ import (
 	"github.com/argoproj/argo-workflows/v3/pkg/client/informers/externalversions"
 	internalinterfaces "github.com/argoproj/argo-workflows/v3/pkg/client/informers/externalversions/internalinterfaces"
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/tools/cache"
@@ -113,10 +114,18 @@ func (wc *workFlowComponentImpl) CreateWorkflow(ctx context.Context, req types.A
 	wc.setLabels(argowf, awf)
 	slog.InfoContext(ctx, "create workflow in runner", slog.Any("namespace", namespace), slog.Any("awf.name", awf.Name),
 		slog.Any("result-url", argowf.ResultURL), slog.Any("task-type", argowf.TaskType))
-	_, err = cluster.ArgoClient.ArgoprojV1alpha1().Workflows(namespace).Create(ctx, awf, v1.CreateOptions{})
+	err = wc.createWorkflowSecret(ctx, cluster, namespace, req)
 	if err != nil {
+		return nil, err
+	}
+	created, err := cluster.ArgoClient.ArgoprojV1alpha1().Workflows(namespace).Create(ctx, awf, v1.CreateOptions{})
+	if err != nil {
+		wc.deleteWorkflowSecret(ctx, cluster, namespace, req.TaskId)
 		return nil, fmt.Errorf("failed to create workflow in argo: %v", err)
 	}
+	if len(req.Secrets) > 0 {
+		wc.setWorkflowSecretOwner(ctx, cluster, namespace, created)
+	}
 
 	var wf *database.ArgoWorkflow
 
@@ -154,9 +163,68 @@ func (wc *workFlowComponentImpl) DeleteWorkflow(ctx context.Context, req *types.
 	if err != nil {
 		slog.WarnContext(ctx, "Error deleting argo workflow", slog.Any("error", err))
 	}
+	wc.deleteWorkflowSecret(ctx, cluster, req.Namespace, req.TaskID)
+	return nil
+}
+
+func workflowSecretName(taskID string) string {
+	return taskID + "-secrets"
+}
+
+// createWorkflowSecret creates the kubernetes secret loaded by the workflow pods, the secrets are kept out of
+// the workflow spec so they are not shown with the workflow
+func (wc *workFlowComponentImpl) createWorkflowSecret(ctx context.Context, cluster *cluster.Cluster, namespace string, req types.ArgoWorkFlowReq) error {
+	if len(req.Secrets) == 0 {
+		return nil
+	}
+	if cluster.Client == nil {
+		return fmt.Errorf("failed to create secret of workflow %s, cluster %s has no kubernetes client", req.TaskId, cluster.ID)
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      workflowSecretName(req.TaskId),
+			Namespace: namespace,
+		},
+		Type:       corev1.SecretTypeOpaque,
+		StringData: req.Secrets,
+	}
+	_, err := cluster.Client.CoreV1().Secrets(namespace).Create(ctx, secret, v1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to create secret of workflow %s, error: %w", req.TaskId, err)
+	}
 	return nil
 }
 
+// setWorkflowSecretOwner makes the workflow the owner of its secret, so the secret is garbage collected
+// with the workflow once it's deleted by the TTL strategy
+func (wc *workFlowComponentImpl) setWorkflowSecretOwner(ctx context.Context, cluster *cluster.Cluster, namespace string, awf *v1alpha1.Workflow) {
+	secrets := cluster.Client.CoreV1().Secrets(namespace)
+	secret, err := secrets.Get(ctx, workflowSecretName(awf.Name), v1.GetOptions{})
+	if err == nil {
+		secret.OwnerReferences = append(secret.OwnerReferences, v1.OwnerReference{
+			APIVersion: v1alpha1.SchemeGroupVersion.String(),
+			Kind:       "Workflow",
+			Name:       awf.Name,
+			UID:        awf.UID,
+		})
+		_, err = secrets.Update(ctx, secret, v1.UpdateOptions{})
+	}
+	if err != nil {
+		slog.WarnContext(ctx, "failed to set owner of workflow secret, it's removed when the workflow is deleted by the user",
+			slog.String("task_id", awf.Name), slog.Any("error", err))
+	}
+}
+
+func (wc *workFlowComponentImpl) deleteWorkflowSecret(ctx context.Context, cluster *cluster.Cluster, namespace, taskID string) {
+	if cluster.Client == nil {
+		return
+	}
+	err := cluster.Client.CoreV1().Secrets(namespace).Delete(ctx, workflowSecretName(taskID), v1.DeleteOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		slog.WarnContext(ctx, "failed to delete secret of workflow", slog.String("task_id", taskID), slog.Any("error", err))
+	}
+}
+
 func (wc *workFlowComponentImpl) GetWorkflow(ctx context.Context, id int64, username string) (*database.ArgoWorkflow, error) {
 	wf, err := wc.FindWorkFlowById(ctx, id)
 	if err != nil {
@@ -342,6 +410,15 @@ func generateWorkflow(req types.ArgoWorkFlowReq, config *config.Config) (*v1alph
 			},
 		}
 
+		// the secrets are kept out of the workflow spec, the plain env wins if a key is in both
+		if len(req.Secrets) > 0 {
+			temp.Container.EnvFrom = append(temp.Container.EnvFrom, corev1.EnvFromSource{
+				SecretRef: &corev1.SecretEnvSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: workflowSecretName(req.TaskId)},
+				},
+			})
+		}
+
 		// merge node affinity
 		utils.FillAffinity(&temp.Affinity, nodeAffinity)
 
@@ -73,6 +73,64 @@ func TestArgoComponent_CreateWorkflow(t *testing.T) {
 	require.Equal(t, v1alpha1.WorkflowPhase(v1alpha1.NodePending), wf.Status)
 }
 
+func TestArgoComponent_CreateWorkflow_Secrets(t *testing.T) {
+	argoStore := mockdb.NewMockArgoWorkFlowStore(t)
+	pool := mockCluster.NewMockPool(t)
+	argoClient := argofake.NewSimpleClientset()
+	expectCluster := &cluster.Cluster{
+		CID:        "config",
+		ID:         "test",
+		Client:     fake.NewSimpleClientset(),
+		ArgoClient: argoClient,
+	}
+	reporter := mockReporter.NewMockLogCollector(t)
+	cfg := &config.Config{}
+	cfg.Cluster.SpaceNamespace = "test"
+	wfc := workFlowComponentImpl{
+		wf:          argoStore,
+		clusterPool: pool,
+		config:      cfg,
+		logReporter: reporter,
+	}
+	pool.EXPECT().GetClusterByID(mock.Anything, "test").Return(expectCluster, nil)
+	ctx := context.TODO()
+	argoStore.EXPECT().CreateWorkFlow(ctx, mock.Anything).Return(&database.ArgoWorkflow{ID: 1, TaskId: "task1"}, nil)
+	reporter.EXPECT().Report(mock.Anything)
+
+	_, err := wfc.CreateWorkflow(ctx, types.ArgoWorkFlowReq{
+		ClusterID:  "test",
+		RepoType:   string(types.ModelRepo),
+		TaskId:     "task1",
+		TaskType:   types.TaskTypeFinetune,
+		Entrypoint: "finetune",
+		Templates: []types.ArgoFlowTemplate{
+			{Name: "finetune", Image: "test", Env: map[string]string{"MODEL_ID": "m1"}},
+		},
+		Secrets: types.SecretValues{"WANDB_API_KEY": "wandb-key"},
+	})
+	require.Nil(t, err)
+
+	secret, err := expectCluster.Client.CoreV1().Secrets("test").Get(ctx, "task1-secrets", v1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "wandb-key", secret.StringData["WANDB_API_KEY"])
+	// the secret is garbage collected with the workflow
+	require.Equal(t, "task1", secret.OwnerReferences[0].Name)
+
+	awf, err := argoClient.ArgoprojV1alpha1().Workflows("test").Get(ctx, "task1", v1.GetOptions{})
+	require.NoError(t, err)
+	container := awf.Spec.Templates[0].Container
+	require.Equal(t, "task1-secrets", container.EnvFrom[0].SecretRef.Name)
+	for _, env := range container.Env {
+		require.NotEqual(t, "WANDB_API_KEY", env.Name)
+	}
+
+	// the secret is removed with the workflow
+	err = wfc.DeleteWorkflow(ctx, &types.ArgoWorkFlowDeleteReq{ClusterID: "test", TaskID: "task1", Namespace: "test"})
+	require.Nil(t, err)
+	_, err = expectCluster.Client.CoreV1().Secrets("test").Get(ctx, "task1-secrets", v1.GetOptions{})
+	require.Error(t, err)
+}
+
 func TestArgoComponent_DeleteWorkflow(t *testing.T) {
 	argoStore := mockdb.NewMockArgoWorkFlowStore(t)
 	pool := mockCluster.NewMockPool(t)